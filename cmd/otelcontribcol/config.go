@@ -0,0 +1,54 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"strings"
+
+	"go.opentelemetry.io/collector/service/parserprovider"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/configprovider/s3provider"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/configprovider/secretsmanagerprovider"
+)
+
+// parserProvider returns the provider of the collector configuration: it is
+// loaded from S3 if the --config flag is an s3:// URI and from a file
+// otherwise, then the --set flags and the AWS Secrets Manager references are
+// applied.
+func parserProvider(args []string) parserprovider.ParserProvider {
+	base := parserprovider.NewFile()
+	if uri := configFlag(args); strings.HasPrefix(uri, s3provider.Scheme+"://") {
+		base = s3provider.New(uri)
+	}
+	return secretsmanagerprovider.New(parserprovider.NewSetFlag(base))
+}
+
+// configFlag returns the value of the --config flag in args. The flag is
+// parsed by the collector service, which doesn't expose its value.
+func configFlag(args []string) string {
+	for i, arg := range args {
+		if arg == "--" {
+			break
+		}
+		name := strings.TrimPrefix(strings.TrimPrefix(arg, "-"), "-")
+		if name == "config" && i+1 < len(args) {
+			return args[i+1]
+		}
+		if strings.HasPrefix(name, "config=") {
+			return strings.TrimPrefix(name, "config=")
+		}
+	}
+	return ""
+}
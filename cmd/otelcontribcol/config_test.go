@@ -0,0 +1,40 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfigFlag(t *testing.T) {
+	cases := []struct {
+		args     []string
+		expected string
+	}{
+		{args: []string{"--config", "s3://configs/config.yaml"}, expected: "s3://configs/config.yaml"},
+		{args: []string{"--config=config.yaml"}, expected: "config.yaml"},
+		{args: []string{"-config", "config.yaml", "--set=processors.batch.timeout=2s"}, expected: "config.yaml"},
+		{args: []string{"--mem-ballast-size-mib", "64", "--config=s3://configs/config.yaml"}, expected: "s3://configs/config.yaml"},
+		{args: []string{"--config"}},
+		{args: []string{"--", "--config=config.yaml"}},
+		{},
+	}
+
+	for _, tc := range cases {
+		assert.Equal(t, tc.expected, configFlag(tc.args), tc.args)
+	}
+}
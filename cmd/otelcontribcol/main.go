@@ -20,6 +20,7 @@ package main
 import (
 	"fmt"
 	"log"
+	"os"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/service"
@@ -39,7 +40,12 @@ func main() {
 		Version:     version.Version,
 	}
 
-	if err := run(service.AppSettings{BuildInfo: info, Factories: factories}); err != nil {
+	params := service.AppSettings{
+		BuildInfo:      info,
+		Factories:      factories,
+		ParserProvider: parserProvider(os.Args[1:]),
+	}
+	if err := run(params); err != nil {
 		log.Fatal(err)
 	}
 }
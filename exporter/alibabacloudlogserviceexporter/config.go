@@ -35,4 +35,10 @@ type Config struct {
 	ECSRamRole string `mapstructure:"ecs_ram_role"`
 	// Set Token File Path if you are using ACK
 	TokenFilePath string `mapstructure:"token_file_path"`
+	// StructuredContent keeps the nested log attributes and bodies as JSON documents instead of flattening
+	// them to strings, and writes each attribute to its own key, so that they can be queried with SQL
+	StructuredContent bool `mapstructure:"structured_content"`
+	// TopicAttribute is the log or resource attribute whose value is used as the LogService topic of
+	// the logs, the hostname is used when it's not set or the attribute is missing
+	TopicAttribute string `mapstructure:"topic_attribute"`
 }
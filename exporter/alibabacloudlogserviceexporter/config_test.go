@@ -57,6 +57,16 @@ func TestLoadConfig(t *testing.T) {
 	}
 	assert.Equal(t, &expectedCfg, e1)
 
+	e2 := cfg.Exporters[config.NewIDWithName(typeStr, "3")]
+	assert.Equal(t, &Config{
+		ExporterSettings:  config.NewExporterSettings(config.NewIDWithName(typeStr, "3")),
+		Endpoint:          "cn-hangzhou.log.aliyuncs.com",
+		Project:           "demo-project",
+		Logstore:          "demo-logstore",
+		StructuredContent: true,
+		TopicAttribute:    "log.topic",
+	}, e2)
+
 	params := component.ExporterCreateSettings{Logger: zap.NewNop()}
 
 	// missing params
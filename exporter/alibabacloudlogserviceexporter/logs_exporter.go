@@ -19,6 +19,7 @@ import (
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/consumer/pdata"
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
 	"go.uber.org/zap"
@@ -29,6 +30,10 @@ func newLogsExporter(logger *zap.Logger, cfg config.Exporter) (component.LogsExp
 
 	l := &logServiceLogsSender{
 		logger: logger,
+		converter: logConverter{
+			structured:     cfg.(*Config).StructuredContent,
+			topicAttribute: cfg.(*Config).TopicAttribute,
+		},
 	}
 
 	var err error
@@ -43,17 +48,22 @@ func newLogsExporter(logger *zap.Logger, cfg config.Exporter) (component.LogsExp
 }
 
 type logServiceLogsSender struct {
-	logger *zap.Logger
-	client LogServiceClient
+	logger    *zap.Logger
+	client    LogServiceClient
+	converter logConverter
 }
 
 func (s *logServiceLogsSender) pushLogsData(
 	ctx context.Context,
 	md pdata.Logs) error {
-	var err error
-	slsLogs := logDataToLogService(md)
-	if len(slsLogs) > 0 {
-		err = s.client.SendLogs(slsLogs)
+	var errs []error
+	for topic, slsLogs := range s.converter.convert(md) {
+		if len(slsLogs) == 0 {
+			continue
+		}
+		if err := s.client.SendLogsWithTopic(topic, slsLogs); err != nil {
+			errs = append(errs, err)
+		}
 	}
-	return err
+	return consumererror.Combine(errs)
 }
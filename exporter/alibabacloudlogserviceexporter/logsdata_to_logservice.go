@@ -42,22 +42,39 @@ const (
 	slsLogInstrumentationVersion = "otlp.version"
 )
 
+// logConverter converts the logs to LogService logs, grouped by the topic they are sent to.
+type logConverter struct {
+	// structured keeps the nested attributes and bodies as JSON documents and writes
+	// each attribute to its own key
+	structured bool
+	// topicAttribute is the attribute holding the topic of the logs, the logs without
+	// it are grouped under the empty topic
+	topicAttribute string
+}
+
 func logDataToLogService(ld pdata.Logs) []*sls.Log {
-	slsLogs := make([]*sls.Log, 0)
+	return logConverter{}.convert(ld)[""]
+}
+
+func (c logConverter) convert(ld pdata.Logs) map[string][]*sls.Log {
+	slsLogs := make(map[string][]*sls.Log)
 	rls := ld.ResourceLogs()
 	for i := 0; i < rls.Len(); i++ {
 		rl := rls.At(i)
 		ills := rl.InstrumentationLibraryLogs()
 		resource := rl.Resource()
-		resourceContents := resourceToLogContents(resource)
+		resourceContents := resourceToLogContents(resource, c.structured)
+		resourceTopic := c.topicFrom(resource.Attributes(), "")
 		for j := 0; j < ills.Len(); j++ {
 			ils := ills.At(j)
 			instrumentationLibraryContents := instrumentationLibraryToLogContents(ils.InstrumentationLibrary())
 			logs := ils.Logs()
 			for j := 0; j < logs.Len(); j++ {
-				slsLog := mapLogRecordToLogService(logs.At(j), resourceContents, instrumentationLibraryContents)
+				lr := logs.At(j)
+				slsLog := mapLogRecordToLogService(lr, resourceContents, instrumentationLibraryContents, c.structured)
 				if slsLog != nil {
-					slsLogs = append(slsLogs, slsLog)
+					topic := c.topicFrom(lr.Attributes(), resourceTopic)
+					slsLogs[topic] = append(slsLogs[topic], slsLog)
 				}
 			}
 		}
@@ -66,7 +83,20 @@ func logDataToLogService(ld pdata.Logs) []*sls.Log {
 	return slsLogs
 }
 
-func resourceToLogContents(resource pdata.Resource) []*sls.LogContent {
+// topicFrom returns the value of the topic attribute, or the given default if it's missing
+func (c logConverter) topicFrom(attrs pdata.AttributeMap, defaultTopic string) string {
+	if c.topicAttribute == "" {
+		return defaultTopic
+	}
+	if v, ok := attrs.Get(c.topicAttribute); ok {
+		if topic := tracetranslator.AttributeValueToString(v); topic != "" {
+			return topic
+		}
+	}
+	return defaultTopic
+}
+
+func resourceToLogContents(resource pdata.Resource, structured bool) []*sls.LogContent {
 	logContents := make([]*sls.LogContent, 3)
 	attrs := resource.Attributes()
 	if hostName, ok := attrs.Get(conventions.AttributeHostName); ok {
@@ -98,7 +128,15 @@ func resourceToLogContents(resource pdata.Resource) []*sls.LogContent {
 		if k == conventions.AttributeServiceName || k == conventions.AttributeHostName {
 			return true
 		}
-		fields[k] = tracetranslator.AttributeValueToString(v)
+		if structured {
+			fields[k] = attributeValueToRaw(v)
+			logContents = append(logContents, &sls.LogContent{
+				Key:   proto.String(slsLogResource + "." + k),
+				Value: proto.String(structuredValue(v)),
+			})
+		} else {
+			fields[k] = tracetranslator.AttributeValueToString(v)
+		}
 		return true
 	})
 	attributeBuffer, _ := json.Marshal(fields)
@@ -125,7 +163,8 @@ func instrumentationLibraryToLogContents(instrumentationLibrary pdata.Instrument
 
 func mapLogRecordToLogService(lr pdata.LogRecord,
 	resourceContents,
-	instrumentationLibraryContents []*sls.LogContent) *sls.Log {
+	instrumentationLibraryContents []*sls.LogContent,
+	structured bool) *sls.Log {
 	if lr.Body().Type() == pdata.AttributeValueTypeNull {
 		return nil
 	}
@@ -161,7 +200,15 @@ func mapLogRecordToLogService(lr pdata.LogRecord,
 
 	fields := map[string]interface{}{}
 	lr.Attributes().Range(func(k string, v pdata.AttributeValue) bool {
-		fields[k] = tracetranslator.AttributeValueToString(v)
+		if structured {
+			fields[k] = attributeValueToRaw(v)
+			contentsBuffer = append(contentsBuffer, sls.LogContent{
+				Key:   proto.String(slsLogAttribute + "." + k),
+				Value: proto.String(structuredValue(v)),
+			})
+		} else {
+			fields[k] = tracetranslator.AttributeValueToString(v)
+		}
 		return true
 	})
 	attributeBuffer, _ := json.Marshal(fields)
@@ -170,9 +217,13 @@ func mapLogRecordToLogService(lr pdata.LogRecord,
 		Value: proto.String(string(attributeBuffer)),
 	})
 
+	body := tracetranslator.AttributeValueToString(lr.Body())
+	if structured {
+		body = structuredValue(lr.Body())
+	}
 	contentsBuffer = append(contentsBuffer, sls.LogContent{
 		Key:   proto.String(slsLogContent),
-		Value: proto.String(tracetranslator.AttributeValueToString(lr.Body())),
+		Value: proto.String(body),
 	})
 
 	contentsBuffer = append(contentsBuffer, sls.LogContent{
@@ -203,3 +254,42 @@ func mapLogRecordToLogService(lr pdata.LogRecord,
 
 	return &slsLog
 }
+
+// structuredValue renders the strings as they are and the other values as JSON documents,
+// keeping the nested maps and arrays as such
+func structuredValue(v pdata.AttributeValue) string {
+	if v.Type() == pdata.AttributeValueTypeString {
+		return v.StringVal()
+	}
+	buf, _ := json.Marshal(attributeValueToRaw(v))
+	return string(buf)
+}
+
+func attributeValueToRaw(v pdata.AttributeValue) interface{} {
+	switch v.Type() {
+	case pdata.AttributeValueTypeString:
+		return v.StringVal()
+	case pdata.AttributeValueTypeInt:
+		return v.IntVal()
+	case pdata.AttributeValueTypeDouble:
+		return v.DoubleVal()
+	case pdata.AttributeValueTypeBool:
+		return v.BoolVal()
+	case pdata.AttributeValueTypeMap:
+		raw := make(map[string]interface{}, v.MapVal().Len())
+		v.MapVal().Range(func(k string, v pdata.AttributeValue) bool {
+			raw[k] = attributeValueToRaw(v)
+			return true
+		})
+		return raw
+	case pdata.AttributeValueTypeArray:
+		arr := v.ArrayVal()
+		raw := make([]interface{}, 0, arr.Len())
+		for i := 0; i < arr.Len(); i++ {
+			raw = append(raw, attributeValueToRaw(arr.At(i)))
+		}
+		return raw
+	default:
+		return nil
+	}
+}
@@ -15,12 +15,15 @@
 package alibabacloudlogserviceexporter
 
 import (
+	"encoding/json"
 	"reflect"
 	"sort"
 	"testing"
 	"time"
 
+	sls "github.com/aliyun/aliyun-log-go-sdk"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/consumer/pdata"
 	"go.opentelemetry.io/collector/translator/conventions"
 )
@@ -129,3 +132,50 @@ func TestLogsDataToLogService(t *testing.T) {
 		}
 	}
 }
+
+func contentsOf(log *sls.Log) map[string]string {
+	contents := make(map[string]string, len(log.Contents))
+	for _, content := range log.Contents {
+		contents[content.GetKey()] = content.GetValue()
+	}
+	return contents
+}
+
+func TestLogsDataToLogServiceStructured(t *testing.T) {
+	logs := createLogData(6)
+	lr := logs.ResourceLogs().At(1).InstrumentationLibraryLogs().At(0).Logs().At(5)
+	body := pdata.NewAttributeValueMap()
+	body.MapVal().InsertString("message", "hello")
+	body.CopyTo(lr.Body())
+
+	gotLogs := logConverter{structured: true}.convert(logs)[""]
+	require.Len(t, gotLogs, 5)
+
+	// the first log record has no body
+	contents := contentsOf(gotLogs[4])
+	assert.Equal(t, `{"message":"hello"}`, contents[slsLogContent])
+	assert.Equal(t, "myapp-type", contents["attribute.my-label"])
+	assert.Equal(t, "null", contents["attribute.null-value"])
+	assert.Equal(t, "resourceValue", contents["resource.resouceKey"])
+	assert.JSONEq(t,
+		`{"result":true,"status":"ok","value":1.3,"code":200,"null":null,"array":["array"],"map":{"data":"hello world"}}`,
+		contents["attribute.map-value"])
+
+	var attributes map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(contents[slsLogAttribute]), &attributes))
+	assert.Equal(t, map[string]interface{}{"data": "hello world"}, attributes["map-value"].(map[string]interface{})["map"])
+}
+
+func TestLogsDataToLogServiceTopic(t *testing.T) {
+	logs := createLogData(4)
+	rl := logs.ResourceLogs().At(1)
+	rl.Resource().Attributes().InsertString("log.topic", "resource-topic")
+	rl.InstrumentationLibraryLogs().At(0).Logs().At(1).Attributes().InsertString("log.topic", "record-topic")
+
+	gotLogs := logConverter{topicAttribute: "log.topic"}.convert(logs)
+
+	assert.Len(t, gotLogs, 2)
+	assert.Len(t, gotLogs["resource-topic"], 2)
+	assert.Len(t, gotLogs["record-topic"], 1)
+	assert.Equal(t, "true", contentsOf(gotLogs["record-topic"][0])[slsLogContent])
+}
@@ -55,7 +55,7 @@ func traceDataToLogServiceData(td pdata.Traces) []*sls.Log {
 }
 
 func resourceSpansToLogServiceData(resourceSpans pdata.ResourceSpans) []*sls.Log {
-	resourceContents := resourceToLogContents(resourceSpans.Resource())
+	resourceContents := resourceToLogContents(resourceSpans.Resource(), false)
 	insLibSpansSlice := resourceSpans.InstrumentationLibrarySpans()
 	var slsLogs []*sls.Log
 	for i := 0; i < insLibSpansSlice.Len(); i++ {
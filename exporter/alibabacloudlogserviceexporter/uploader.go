@@ -29,6 +29,8 @@ import (
 type LogServiceClient interface {
 	// SendLogs send message to LogService
 	SendLogs(logs []*sls.Log) error
+	// SendLogsWithTopic send message to LogService under the given topic
+	SendLogsWithTopic(topic string, logs []*sls.Log) error
 }
 
 type logServiceClientImpl struct {
@@ -87,6 +89,14 @@ func (c *logServiceClientImpl) SendLogs(logs []*sls.Log) error {
 	return c.clientInstance.SendLogListWithCallBack(c.project, c.logstore, c.topic, c.source, logs, c)
 }
 
+// SendLogsWithTopic send message to LogService under the given topic, or the default one if it's empty
+func (c *logServiceClientImpl) SendLogsWithTopic(topic string, logs []*sls.Log) error {
+	if topic == "" {
+		topic = c.topic
+	}
+	return c.clientInstance.SendLogListWithCallBack(c.project, c.logstore, topic, c.source, logs, c)
+}
+
 // Success is impl of producer.CallBack
 func (c *logServiceClientImpl) Success(*producer.Result) {}
 
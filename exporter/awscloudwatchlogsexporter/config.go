@@ -15,6 +15,9 @@
 package awscloudwatchlogsexporter
 
 import (
+	"errors"
+	"fmt"
+
 	"go.opentelemetry.io/collector/config/configmodels"
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
 )
@@ -27,12 +30,22 @@ type Config struct {
 
 	// LogGroupName is the name of CloudWatch log group which defines group of log streams
 	// that share the same retention, monitoring, and access control settings.
+	// It can refer to resource attributes, e.g. /eks/{k8s.cluster.name}/{k8s.namespace.name}.
 	LogGroupName string `mapstructure:"log_group_name"`
 
 	// LogStreamName is the name of CloudWatch log stream which is a sequence of log events
-	// that share the same source.
+	// that share the same source. It can refer to resource attributes like LogGroupName.
 	LogStreamName string `mapstructure:"log_stream_name"`
 
+	// LogRetention is the number of days the log events are kept in the log groups
+	// created by the exporter. 0 keeps them forever.
+	// Optional.
+	LogRetention int64 `mapstructure:"log_retention"`
+
+	// Tags are applied to the log groups created by the exporter.
+	// Optional.
+	Tags map[string]string `mapstructure:"tags"`
+
 	// Region is the AWS region where the logs are sent to.
 	// Optional.
 	Region string `mapstructure:"region"`
@@ -45,3 +58,23 @@ type Config struct {
 }
 
 // TODO(jbd): Add ARN role to config.
+
+// The retention periods accepted by CloudWatch Logs, in days.
+// https://docs.aws.amazon.com/AmazonCloudWatchLogs/latest/APIReference/API_PutRetentionPolicy.html
+var validLogRetentions = map[int64]struct{}{
+	1: {}, 3: {}, 5: {}, 7: {}, 14: {}, 30: {}, 60: {}, 90: {}, 120: {}, 150: {},
+	180: {}, 365: {}, 400: {}, 545: {}, 731: {}, 1827: {}, 3653: {},
+}
+
+func (c *Config) validate() error {
+	if c.LogGroupName == "" {
+		return errors.New("'log_group_name' must be set")
+	}
+	if c.LogStreamName == "" {
+		return errors.New("'log_stream_name' must be set")
+	}
+	if _, ok := validLogRetentions[c.LogRetention]; c.LogRetention != 0 && !ok {
+		return fmt.Errorf("'log_retention' of %d days isn't supported by CloudWatch Logs", c.LogRetention)
+	}
+	return nil
+}
@@ -17,15 +17,16 @@ package awscloudwatchlogsexporter
 import (
 	"context"
 	"encoding/json"
-	"errors"
-	"fmt"
 	"sync"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs/cloudwatchlogsiface"
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenterror"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/consumer/pdata"
 	"go.uber.org/zap"
 )
@@ -35,10 +36,12 @@ type exporter struct {
 	logger *zap.Logger
 
 	startOnce sync.Once
-	client    *cloudwatchlogs.CloudWatchLogs // available after startOnce
+	client    cloudwatchlogsiface.CloudWatchLogsAPI // available after startOnce
 
 	seqTokenMu sync.Mutex
-	seqToken   string
+	// seqTokens holds the sequence token of each stream written to, nil when
+	// it isn't known yet.
+	seqTokens map[logStream]*string
 }
 
 func (e *exporter) Start(ctx context.Context, host component.Host) error {
@@ -58,22 +61,6 @@ func (e *exporter) Start(ctx context.Context, host component.Host) error {
 			return
 		}
 		e.client = cloudwatchlogs.New(sess)
-
-		e.logger.Debug("Retrieving Cloud Watch sequence token")
-		out, err := e.client.DescribeLogStreams(&cloudwatchlogs.DescribeLogStreamsInput{
-			LogGroupName:        aws.String(e.config.LogGroupName),
-			LogStreamNamePrefix: aws.String(e.config.LogStreamName),
-		})
-		if err != nil {
-			startErr = err
-			return
-		}
-		if len(out.LogStreams) == 0 {
-			startErr = errors.New("cannot find log group and stream")
-			return
-		}
-		stream := out.LogStreams[0]
-		e.seqToken = *stream.UploadSequenceToken
 	})
 	return startErr
 }
@@ -89,45 +76,72 @@ func (e *exporter) PushLogs(ctx context.Context, ld pdata.Logs) (droppedTimeSeri
 	e.seqTokenMu.Lock()
 	defer e.seqTokenMu.Unlock()
 
-	logEvents, dropped := logsToCWLogs(e.logger, ld)
-	if len(logEvents) == 0 {
-		return 0, nil
-	}
+	batches, dropped := logsToCWLogs(e.logger, e.config, ld)
 
-	e.logger.Debug("Putting log events", zap.Int("num_of_events", len(logEvents)))
-	input := &cloudwatchlogs.PutLogEventsInput{
-		LogGroupName:  aws.String(e.config.LogGroupName),
-		LogStreamName: aws.String(e.config.LogStreamName),
-		LogEvents:     logEvents,
-		SequenceToken: aws.String(e.seqToken),
+	var errs []error
+	failed := pdata.NewLogs()
+	for _, b := range batches {
+		if len(b.events) == 0 {
+			continue
+		}
+		if err := e.putLogEvents(b.stream, b.events); err != nil {
+			errs = append(errs, err)
+			for _, rl := range b.resourceLogs {
+				failed.ResourceLogs().Append(rl)
+			}
+		}
 	}
-	out, err := e.client.PutLogEvents(input)
-	if err != nil {
-		return 0, err
+	if len(errs) == 0 {
+		return dropped, nil
 	}
-	if info := out.RejectedLogEventsInfo; info != nil {
-		return ld.LogRecordCount() + dropped, fmt.Errorf("log event rejected")
+
+	err = componenterror.CombineErrors(errs)
+	if len(errs) < len(batches) {
+		// only retry the streams which failed.
+		return dropped + failed.LogRecordCount(), consumererror.PartialLogsError(err, failed)
 	}
-	e.logger.Debug("Log events are successfully put")
+	return ld.LogRecordCount(), err
+}
 
-	e.seqToken = *out.NextSequenceToken
-	return dropped, nil
+// logBatch holds the log events sent to one stream and the resource logs they
+// come from.
+type logBatch struct {
+	stream       logStream
+	events       []*cloudwatchlogs.InputLogEvent
+	resourceLogs []pdata.ResourceLogs
 }
 
-func logsToCWLogs(logger *zap.Logger, ld pdata.Logs) ([]*cloudwatchlogs.InputLogEvent, int) {
+// logsToCWLogs converts the logs to CloudWatch log events, grouped by the
+// stream their resource resolves to, in the order the streams first appear.
+func logsToCWLogs(logger *zap.Logger, config *Config, ld pdata.Logs) ([]*logBatch, int) {
 	n := ld.ResourceLogs().Len()
 	if n == 0 {
-		return []*cloudwatchlogs.InputLogEvent{}, 0
+		return nil, 0
 	}
 
-	var dropped int
-	out := make([]*cloudwatchlogs.InputLogEvent, 0) // TODO(jbd): set a better capacity
+	var (
+		dropped int
+		batches []*logBatch
+	)
+	byStream := make(map[logStream]*logBatch)
 
 	rls := ld.ResourceLogs()
 	for i := 0; i < rls.Len(); i++ {
 		rl := rls.At(i)
 		resourceAttrs := attrsValue(rl.Resource().Attributes())
 
+		stream := resolveStream(logger, config, rl.Resource().Attributes())
+		batch, ok := byStream[stream]
+		if !ok {
+			batch = &logBatch{
+				stream: stream,
+				events: make([]*cloudwatchlogs.InputLogEvent, 0), // TODO(jbd): set a better capacity
+			}
+			byStream[stream] = batch
+			batches = append(batches, batch)
+		}
+		batch.resourceLogs = append(batch.resourceLogs, rl)
+
 		ills := rl.InstrumentationLibraryLogs()
 		for j := 0; j < ills.Len(); j++ {
 			ils := ills.At(j)
@@ -139,12 +153,12 @@ func logsToCWLogs(logger *zap.Logger, ld pdata.Logs) ([]*cloudwatchlogs.InputLog
 					logger.Debug("Failed to convert to CloudWatch Log", zap.Error(err))
 					dropped++
 				} else {
-					out = append(out, event)
+					batch.events = append(batch.events, event)
 				}
 			}
 		}
 	}
-	return out, dropped
+	return batches, dropped
 }
 
 type cwLogBody struct {
@@ -48,8 +48,11 @@ func createLogsExporter(ctx context.Context, params component.ExporterCreatePara
 	if !ok {
 		return nil, errors.New("invalid configuration type; can't cast to awscloudwatchlogsexporter.Config")
 	}
+	if err := config.validate(); err != nil {
+		return nil, err
+	}
 
-	exporter := &exporter{config: config, logger: params.Logger}
+	exporter := &exporter{config: config, logger: params.Logger, seqTokens: make(map[logStream]*string)}
 	return exporterhelper.NewLogsExporter(
 		config,
 		params.Logger,
@@ -15,10 +15,13 @@
 package awscloudwatchlogsexporter
 
 import (
+	"context"
 	"reflect"
 	"testing"
 
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/configmodels"
+	"go.uber.org/zap"
 )
 
 func TestDefaultConfig_exporterSettings(t *testing.T) {
@@ -33,3 +36,35 @@ func TestDefaultConfig_exporterSettings(t *testing.T) {
 		t.Errorf("createDefaultConfig().ExporterSettings = %v, want %v", got, want)
 	}
 }
+
+func TestCreateLogsExporter_invalidConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  *Config
+		wantErr string
+	}{
+		{
+			name:    "no log group",
+			config:  &Config{LogStreamName: "stream"},
+			wantErr: "'log_group_name' must be set",
+		},
+		{
+			name:    "no log stream",
+			config:  &Config{LogGroupName: "group"},
+			wantErr: "'log_stream_name' must be set",
+		},
+		{
+			name:    "unsupported retention",
+			config:  &Config{LogGroupName: "group", LogStreamName: "stream", LogRetention: 2},
+			wantErr: "'log_retention' of 2 days isn't supported by CloudWatch Logs",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := createLogsExporter(context.Background(), component.ExporterCreateParams{Logger: zap.NewNop()}, tt.config)
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("createLogsExporter() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
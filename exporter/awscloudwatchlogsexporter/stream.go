@@ -0,0 +1,171 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package awscloudwatchlogsexporter
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.uber.org/zap"
+)
+
+// placeholderPattern matches the references to resource attributes in the
+// log group and stream names, e.g. {k8s.namespace.name}.
+var placeholderPattern = regexp.MustCompile(`{([^{}]+)}`)
+
+// logStream identifies a CloudWatch log stream.
+type logStream struct {
+	group string
+	name  string
+}
+
+// resolveStream returns the log stream the logs of a resource are sent to.
+func resolveStream(logger *zap.Logger, config *Config, attrs pdata.AttributeMap) logStream {
+	return logStream{
+		group: resolveName(logger, config.LogGroupName, attrs),
+		name:  resolveName(logger, config.LogStreamName, attrs),
+	}
+}
+
+// resolveName replaces the references to resource attributes in name by their
+// value, or by "undefined" when the resource doesn't have them.
+func resolveName(logger *zap.Logger, name string, attrs pdata.AttributeMap) string {
+	return placeholderPattern.ReplaceAllStringFunc(name, func(placeholder string) string {
+		key := placeholder[1 : len(placeholder)-1]
+		value, ok := attrs.Get(key)
+		if !ok {
+			logger.Debug("No resource attribute found for placeholder", zap.String("placeholder", placeholder))
+			return "undefined"
+		}
+		if value.Type() == pdata.AttributeValueSTRING {
+			if value.StringVal() == "" {
+				logger.Debug("Empty resource attribute found for placeholder", zap.String("placeholder", placeholder))
+				return "undefined"
+			}
+			return value.StringVal()
+		}
+		return fmt.Sprint(attrValue(value))
+	})
+}
+
+// putLogEvents sends the events to the stream, creating the stream and its
+// log group when they don't exist yet.
+func (e *exporter) putLogEvents(stream logStream, events []*cloudwatchlogs.InputLogEvent) error {
+	e.logger.Debug("Putting log events",
+		zap.String("log_group_name", stream.group),
+		zap.String("log_stream_name", stream.name),
+		zap.Int("num_of_events", len(events)))
+	input := &cloudwatchlogs.PutLogEventsInput{
+		LogGroupName:  aws.String(stream.group),
+		LogStreamName: aws.String(stream.name),
+		LogEvents:     events,
+		SequenceToken: e.seqTokens[stream],
+	}
+
+	// The sequence token of a stream isn't known before writing to it for the
+	// first time, nor is whether the stream exists, so the request is tried
+	// again once with the expected token or after creating the stream.
+	out, err := e.client.PutLogEvents(input)
+	switch awsErr := err.(type) {
+	case *cloudwatchlogs.InvalidSequenceTokenException:
+		input.SequenceToken = awsErr.ExpectedSequenceToken
+		out, err = e.client.PutLogEvents(input)
+	case *cloudwatchlogs.ResourceNotFoundException:
+		if err = e.createStream(stream); err != nil {
+			return err
+		}
+		input.SequenceToken = nil
+		out, err = e.client.PutLogEvents(input)
+	}
+	if err != nil {
+		if awsErr, ok := err.(*cloudwatchlogs.DataAlreadyAcceptedException); ok {
+			// the events were already sent, don't send them again.
+			e.seqTokens[stream] = awsErr.ExpectedSequenceToken
+			return nil
+		}
+		return err
+	}
+	e.seqTokens[stream] = out.NextSequenceToken
+	if info := out.RejectedLogEventsInfo; info != nil {
+		return fmt.Errorf("log event rejected")
+	}
+	e.logger.Debug("Log events are successfully put")
+	return nil
+}
+
+// createStream creates the stream, creating its log group first when needed.
+func (e *exporter) createStream(stream logStream) error {
+	_, err := e.client.CreateLogStream(&cloudwatchlogs.CreateLogStreamInput{
+		LogGroupName:  aws.String(stream.group),
+		LogStreamName: aws.String(stream.name),
+	})
+	if isAWSErrorCode(err, cloudwatchlogs.ErrCodeResourceNotFoundException) {
+		if err = e.createLogGroup(stream.group); err != nil {
+			return err
+		}
+		_, err = e.client.CreateLogStream(&cloudwatchlogs.CreateLogStreamInput{
+			LogGroupName:  aws.String(stream.group),
+			LogStreamName: aws.String(stream.name),
+		})
+	}
+	if err != nil && !isAWSErrorCode(err, cloudwatchlogs.ErrCodeResourceAlreadyExistsException) {
+		return err
+	}
+	e.logger.Debug("Created log stream",
+		zap.String("log_group_name", stream.group),
+		zap.String("log_stream_name", stream.name))
+	return nil
+}
+
+// createLogGroup creates the log group with the configured tags and retention.
+func (e *exporter) createLogGroup(group string) error {
+	input := &cloudwatchlogs.CreateLogGroupInput{
+		LogGroupName: aws.String(group),
+	}
+	if len(e.config.Tags) > 0 {
+		input.Tags = aws.StringMap(e.config.Tags)
+	}
+	_, err := e.client.CreateLogGroup(input)
+	if isAWSErrorCode(err, cloudwatchlogs.ErrCodeResourceAlreadyExistsException) {
+		// created concurrently by someone else, keep their settings.
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	e.logger.Info("Created log group", zap.String("log_group_name", group))
+
+	if e.config.LogRetention == 0 {
+		return nil
+	}
+	_, err = e.client.PutRetentionPolicy(&cloudwatchlogs.PutRetentionPolicyInput{
+		LogGroupName:    aws.String(group),
+		RetentionInDays: aws.Int64(e.config.LogRetention),
+	})
+	if err != nil {
+		// the log group is usable anyway, don't fail the logs because of it.
+		e.logger.Warn("Failed to set the log group retention", zap.String("log_group_name", group), zap.Error(err))
+	}
+	return nil
+}
+
+func isAWSErrorCode(err error, code string) bool {
+	awsErr, ok := err.(awserr.Error)
+	return ok && awsErr.Code() == code
+}
@@ -0,0 +1,212 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package awscloudwatchlogsexporter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs/cloudwatchlogsiface"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.uber.org/zap"
+)
+
+func TestResolveName(t *testing.T) {
+	attrs := pdata.NewAttributeMap()
+	attrs.InsertString("k8s.cluster.name", "prod")
+	attrs.InsertString("k8s.namespace.name", "payments")
+	attrs.InsertString("empty", "")
+	attrs.InsertInt("node", 5)
+
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{name: "static", in: "testing-logs", want: "testing-logs"},
+		{name: "attributes", in: "/eks/{k8s.cluster.name}/{k8s.namespace.name}", want: "/eks/prod/payments"},
+		{name: "non-string attribute", in: "node-{node}", want: "node-5"},
+		{name: "missing attribute", in: "/eks/{k8s.pod.name}", want: "/eks/undefined"},
+		{name: "empty attribute", in: "/eks/{empty}", want: "/eks/undefined"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := resolveName(zap.NewNop(), tt.in, attrs); got != tt.want {
+				t.Errorf("resolveName() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPushLogsCreatesLogGroupAndStream(t *testing.T) {
+	client := &mockClient{
+		putErrs:    []error{&cloudwatchlogs.ResourceNotFoundException{}},
+		createErrs: []error{&cloudwatchlogs.ResourceNotFoundException{}},
+	}
+	e := testExporter(client, &Config{
+		LogGroupName:  "/eks/{k8s.namespace.name}",
+		LogStreamName: "{host}",
+		LogRetention:  7,
+		Tags:          map[string]string{"team": "payments"},
+	})
+
+	dropped, err := e.PushLogs(context.Background(), testLogs("payments"))
+	if err != nil || dropped != 0 {
+		t.Fatalf("PushLogs() = %d, %v", dropped, err)
+	}
+
+	wantGroup := &cloudwatchlogs.CreateLogGroupInput{
+		LogGroupName: aws.String("/eks/payments"),
+		Tags:         aws.StringMap(map[string]string{"team": "payments"}),
+	}
+	if !reflect.DeepEqual(client.createdGroups, []*cloudwatchlogs.CreateLogGroupInput{wantGroup}) {
+		t.Errorf("created log groups = %v, want %v", client.createdGroups, wantGroup)
+	}
+	wantRetention := &cloudwatchlogs.PutRetentionPolicyInput{
+		LogGroupName:    aws.String("/eks/payments"),
+		RetentionInDays: aws.Int64(7),
+	}
+	if !reflect.DeepEqual(client.retentions, []*cloudwatchlogs.PutRetentionPolicyInput{wantRetention}) {
+		t.Errorf("retention policies = %v, want %v", client.retentions, wantRetention)
+	}
+	if len(client.createdStreams) != 2 {
+		t.Errorf("got %d CreateLogStream calls, want 2", len(client.createdStreams))
+	}
+	if len(client.puts) != 2 || client.puts[1].SequenceToken != nil {
+		t.Errorf("PutLogEvents should be retried without sequence token, got %v", client.puts)
+	}
+	if got := e.seqTokens[logStream{group: "/eks/payments", name: "abc123"}]; got == nil || *got != "token-2" {
+		t.Errorf("sequence token = %v, want token-2", got)
+	}
+}
+
+func TestPushLogsInvalidSequenceToken(t *testing.T) {
+	client := &mockClient{
+		putErrs: []error{&cloudwatchlogs.InvalidSequenceTokenException{ExpectedSequenceToken: aws.String("expected")}},
+	}
+	e := testExporter(client, &Config{LogGroupName: "group", LogStreamName: "stream"})
+
+	if _, err := e.PushLogs(context.Background(), testLogs("payments")); err != nil {
+		t.Fatalf("PushLogs() error = %v", err)
+	}
+	if len(client.puts) != 2 || aws.StringValue(client.puts[1].SequenceToken) != "expected" {
+		t.Errorf("PutLogEvents should be retried with the expected sequence token, got %v", client.puts)
+	}
+	if len(client.createdStreams) != 0 {
+		t.Errorf("no stream should be created, got %v", client.createdStreams)
+	}
+}
+
+func TestPushLogsPartialFailure(t *testing.T) {
+	client := &mockClient{
+		failGroup: "/eks/failing",
+	}
+	e := testExporter(client, &Config{LogGroupName: "/eks/{k8s.namespace.name}", LogStreamName: "stream"})
+
+	ld := testLogs("payments")
+	failing := testLogs("failing")
+	failing.ResourceLogs().MoveAndAppendTo(ld.ResourceLogs())
+
+	dropped, err := e.PushLogs(context.Background(), ld)
+	if dropped != 1 {
+		t.Errorf("dropped = %d, want 1", dropped)
+	}
+	partialErr, ok := err.(consumererror.PartialError)
+	if !ok {
+		t.Fatalf("PushLogs() error = %v, want a partial error", err)
+	}
+	failed := partialErr.GetLogs()
+	if failed.ResourceLogs().Len() != 1 {
+		t.Fatalf("got %d failed resource logs, want 1", failed.ResourceLogs().Len())
+	}
+	if ns, _ := failed.ResourceLogs().At(0).Resource().Attributes().Get("k8s.namespace.name"); ns.StringVal() != "failing" {
+		t.Errorf("failed resource namespace = %q, want failing", ns.StringVal())
+	}
+}
+
+func testExporter(client cloudwatchlogsiface.CloudWatchLogsAPI, config *Config) *exporter {
+	return &exporter{
+		config:    config,
+		logger:    zap.NewNop(),
+		client:    client,
+		seqTokens: make(map[logStream]*string),
+	}
+}
+
+func testLogs(namespace string) pdata.Logs {
+	ld := pdata.NewLogs()
+	ld.ResourceLogs().Resize(1)
+	rl := ld.ResourceLogs().At(0)
+	testResource().CopyTo(rl.Resource())
+	rl.Resource().Attributes().InsertString("k8s.namespace.name", namespace)
+	rl.InstrumentationLibraryLogs().Resize(1)
+	rl.InstrumentationLibraryLogs().At(0).Logs().Append(testLogRecord())
+	return ld
+}
+
+// mockClient returns the errors of putErrs and createErrs, in order, to the
+// PutLogEvents and CreateLogStream calls, and fails the calls for failGroup.
+type mockClient struct {
+	cloudwatchlogsiface.CloudWatchLogsAPI
+
+	putErrs    []error
+	createErrs []error
+	failGroup  string
+
+	puts           []*cloudwatchlogs.PutLogEventsInput
+	createdStreams []*cloudwatchlogs.CreateLogStreamInput
+	createdGroups  []*cloudwatchlogs.CreateLogGroupInput
+	retentions     []*cloudwatchlogs.PutRetentionPolicyInput
+}
+
+func (m *mockClient) PutLogEvents(input *cloudwatchlogs.PutLogEventsInput) (*cloudwatchlogs.PutLogEventsOutput, error) {
+	copied := *input
+	m.puts = append(m.puts, &copied)
+	if aws.StringValue(input.LogGroupName) == m.failGroup {
+		return nil, errors.New("failed")
+	}
+	if len(m.putErrs) > 0 {
+		err := m.putErrs[0]
+		m.putErrs = m.putErrs[1:]
+		return nil, err
+	}
+	return &cloudwatchlogs.PutLogEventsOutput{NextSequenceToken: aws.String(fmt.Sprintf("token-%d", len(m.puts)))}, nil
+}
+
+func (m *mockClient) CreateLogStream(input *cloudwatchlogs.CreateLogStreamInput) (*cloudwatchlogs.CreateLogStreamOutput, error) {
+	m.createdStreams = append(m.createdStreams, input)
+	if len(m.createErrs) > 0 {
+		err := m.createErrs[0]
+		m.createErrs = m.createErrs[1:]
+		return nil, err
+	}
+	return &cloudwatchlogs.CreateLogStreamOutput{}, nil
+}
+
+func (m *mockClient) CreateLogGroup(input *cloudwatchlogs.CreateLogGroupInput) (*cloudwatchlogs.CreateLogGroupOutput, error) {
+	m.createdGroups = append(m.createdGroups, input)
+	return &cloudwatchlogs.CreateLogGroupOutput{}, nil
+}
+
+func (m *mockClient) PutRetentionPolicy(input *cloudwatchlogs.PutRetentionPolicyInput) (*cloudwatchlogs.PutRetentionPolicyOutput, error) {
+	m.retentions = append(m.retentions, input)
+	return &cloudwatchlogs.PutRetentionPolicyOutput{}, nil
+}
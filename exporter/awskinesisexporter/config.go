@@ -15,9 +15,23 @@
 package awskinesisexporter
 
 import (
+	"errors"
+	"fmt"
+
 	"go.opentelemetry.io/collector/config"
 )
 
+const (
+	// Limits of the Kinesis PutRecords API.
+	// https://docs.aws.amazon.com/kinesis/latest/APIReference/API_PutRecords.html
+	maxRecordsPerRequest = 500
+	maxBytesPerRequest   = 5 * 1024 * 1024
+	maxBytesPerRecord    = 1024 * 1024
+
+	compressionNone = "none"
+	compressionZstd = "zstd"
+)
+
 // AWSConfig contains AWS specific configuration such as awskinesis stream, region, etc.
 type AWSConfig struct {
 	StreamName      string `mapstructure:"stream_name"`
@@ -29,15 +43,25 @@ type AWSConfig struct {
 // KPLConfig contains awskinesis producer library related config to controls things
 // like aggregation, batching, connections, retries, etc.
 type KPLConfig struct {
-	AggregateBatchCount  int `mapstructure:"aggregate_batch_count"`
-	AggregateBatchSize   int `mapstructure:"aggregate_batch_size"`
-	BatchSize            int `mapstructure:"batch_size"`
-	BatchCount           int `mapstructure:"batch_count"`
-	BacklogCount         int `mapstructure:"backlog_count"`
+	// AggregateBatchCount is the maximum number of records packed in an aggregated
+	// record, 0 disables the aggregation.
+	AggregateBatchCount int `mapstructure:"aggregate_batch_count"`
+	// AggregateBatchSize is the maximum size in bytes of an aggregated record.
+	AggregateBatchSize int `mapstructure:"aggregate_batch_size"`
+	// BatchSize is the maximum size in bytes of a PutRecords request.
+	BatchSize int `mapstructure:"batch_size"`
+	// BatchCount is the maximum number of records of a PutRecords request.
+	BatchCount int `mapstructure:"batch_count"`
+	// BacklogCount is ignored, records are sent as soon as they are exported.
+	BacklogCount int `mapstructure:"backlog_count"`
+	// FlushIntervalSeconds is ignored, records are sent as soon as they are exported.
 	FlushIntervalSeconds int `mapstructure:"flush_interval_seconds"`
-	MaxConnections       int `mapstructure:"max_connections"`
-	MaxRetries           int `mapstructure:"max_retries"`
-	MaxBackoffSeconds    int `mapstructure:"max_backoff_seconds"`
+	// MaxConnections is the maximum number of connections to the Kinesis endpoint.
+	MaxConnections int `mapstructure:"max_connections"`
+	// MaxRetries is the number of times the records rejected by Kinesis are sent again.
+	MaxRetries int `mapstructure:"max_retries"`
+	// MaxBackoffSeconds is the maximum time waited between two retries.
+	MaxBackoffSeconds int `mapstructure:"max_backoff_seconds"`
 }
 
 // Config contains the main configuration options for the awskinesis exporter
@@ -52,4 +76,37 @@ type Config struct {
 	MaxBytesPerBatch     int `mapstructure:"max_bytes_per_batch"`
 	MaxBytesPerSpan      int `mapstructure:"max_bytes_per_span"`
 	FlushIntervalSeconds int `mapstructure:"flush_interval_seconds"`
+
+	// PartitionKeyAttributes are the span or resource attributes whose values
+	// make the partition key of the records. The trace ID is used when empty or
+	// when a span has none of them.
+	PartitionKeyAttributes []string `mapstructure:"partition_key_attributes"`
+
+	// Compression is the algorithm compressing the records, "none" or "zstd".
+	Compression string `mapstructure:"compression"`
+}
+
+// Validate checks the exporter configuration is valid.
+func (c *Config) Validate() error {
+	if c.KPL.BatchCount <= 0 || c.KPL.BatchCount > maxRecordsPerRequest {
+		return fmt.Errorf("kpl batch_count must be between 1 and %d", maxRecordsPerRequest)
+	}
+	if c.KPL.BatchSize <= 0 || c.KPL.BatchSize > maxBytesPerRequest {
+		return fmt.Errorf("kpl batch_size must be between 1 and %d", maxBytesPerRequest)
+	}
+	if c.KPL.AggregateBatchCount < 0 {
+		return errors.New("kpl aggregate_batch_count can't be negative")
+	}
+	if c.KPL.AggregateBatchCount > 0 && (c.KPL.AggregateBatchSize <= 0 || c.KPL.AggregateBatchSize > maxBytesPerRecord) {
+		return fmt.Errorf("kpl aggregate_batch_size must be between 1 and %d", maxBytesPerRecord)
+	}
+	if c.MaxBytesPerBatch <= 0 || c.MaxBytesPerBatch > maxBytesPerRecord {
+		return fmt.Errorf("max_bytes_per_batch must be between 1 and %d", maxBytesPerRecord)
+	}
+	switch c.Compression {
+	case compressionNone, compressionZstd:
+	default:
+		return fmt.Errorf("unsupported compression %q", c.Compression)
+	}
+	return nil
 }
@@ -46,7 +46,7 @@ func TestDefaultConfig(t *testing.T) {
 			},
 			KPL: KPLConfig{
 				BatchSize:            5242880,
-				BatchCount:           1000,
+				BatchCount:           500,
 				BacklogCount:         2000,
 				FlushIntervalSeconds: 5,
 				MaxConnections:       24,
@@ -57,6 +57,7 @@ func TestDefaultConfig(t *testing.T) {
 			FlushIntervalSeconds: 5,
 			MaxBytesPerBatch:     100000,
 			MaxBytesPerSpan:      900000,
+			Compression:          "none",
 		},
 	)
 }
@@ -100,6 +101,9 @@ func TestConfig(t *testing.T) {
 			FlushIntervalSeconds: 3,
 			MaxBytesPerBatch:     4,
 			MaxBytesPerSpan:      5,
+
+			PartitionKeyAttributes: []string{"service.name", "k8s.namespace.name"},
+			Compression:            "zstd",
 		},
 	)
 }
@@ -108,3 +112,53 @@ func TestConfigCheck(t *testing.T) {
 	cfg := (NewFactory()).CreateDefaultConfig()
 	assert.NoError(t, configcheck.ValidateConfig(cfg))
 }
+
+func TestConfigValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(c *Config)
+		wantErr string
+	}{
+		{
+			name:   "default",
+			mutate: func(c *Config) {},
+		},
+		{
+			name:    "too many records per request",
+			mutate:  func(c *Config) { c.KPL.BatchCount = 501 },
+			wantErr: "kpl batch_count must be between 1 and 500",
+		},
+		{
+			name:    "too large requests",
+			mutate:  func(c *Config) { c.KPL.BatchSize = 6 * 1024 * 1024 },
+			wantErr: "kpl batch_size must be between 1 and 5242880",
+		},
+		{
+			name:    "aggregation without size",
+			mutate:  func(c *Config) { c.KPL.AggregateBatchCount = 10 },
+			wantErr: "kpl aggregate_batch_size must be between 1 and 1048576",
+		},
+		{
+			name:    "too large records",
+			mutate:  func(c *Config) { c.MaxBytesPerBatch = 2 * 1024 * 1024 },
+			wantErr: "max_bytes_per_batch must be between 1 and 1048576",
+		},
+		{
+			name:    "unsupported compression",
+			mutate:  func(c *Config) { c.Compression = "lz4" },
+			wantErr: `unsupported compression "lz4"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := createDefaultConfig().(*Config)
+			tt.mutate(c)
+			err := c.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,191 @@
+// Copyright 2019 OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package awskinesisexporter
+
+import (
+	"strings"
+
+	"github.com/jaegertracing/jaeger/model"
+	"github.com/klauspost/compress/zstd"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/translator/conventions"
+	jaegertranslator "go.opentelemetry.io/collector/translator/trace/jaeger"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/encoding/protowire"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/awskinesisexporter/internal/aggregate"
+)
+
+const (
+	// Kinesis rejects partition keys longer than 256 characters.
+	maxPartitionKeyLength = 256
+
+	// the field number of the spans in a Jaeger batch.
+	batchSpans protowire.Number = 1
+)
+
+// encoder turns spans into Kinesis records, each holding a Jaeger batch of the
+// spans of one process sharing the same partition key.
+type encoder struct {
+	logger                 *zap.Logger
+	partitionKeyAttributes []string
+	maxRecordSize          int
+	maxSpanSize            int
+	zstd                   *zstd.Encoder
+}
+
+func newEncoder(c *Config, logger *zap.Logger) (*encoder, error) {
+	e := &encoder{
+		logger:                 logger,
+		partitionKeyAttributes: c.PartitionKeyAttributes,
+		maxRecordSize:          c.MaxBytesPerBatch,
+		maxSpanSize:            c.MaxBytesPerSpan,
+	}
+	if c.Compression == compressionZstd {
+		enc, err := zstd.NewWriter(nil)
+		if err != nil {
+			return nil, err
+		}
+		e.zstd = enc
+	}
+	return e, nil
+}
+
+// encode returns the records holding the spans, and the number of spans
+// dropped because they're too large.
+func (e *encoder) encode(td pdata.Traces) ([]aggregate.Record, int, error) {
+	batches, err := jaegertranslator.InternalTracesToJaegerProto(td)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var (
+		records []aggregate.Record
+		dropped int
+	)
+	for _, batch := range batches {
+		var (
+			keys  []string
+			byKey = make(map[string][]*model.Span)
+		)
+		for _, span := range batch.Spans {
+			if e.maxSpanSize > 0 && span.Size() > e.maxSpanSize {
+				e.logger.Warn("Dropping span exceeding the maximum size",
+					zap.Int("size", span.Size()),
+					zap.Int("max_bytes_per_span", e.maxSpanSize))
+				dropped++
+				continue
+			}
+			key := e.partitionKey(batch.Process, span)
+			if _, ok := byKey[key]; !ok {
+				keys = append(keys, key)
+			}
+			byKey[key] = append(byKey[key], span)
+		}
+
+		for _, key := range keys {
+			keyRecords, err := e.records(key, batch.Process, byKey[key])
+			if err != nil {
+				return nil, dropped, err
+			}
+			records = append(records, keyRecords...)
+		}
+	}
+	return records, dropped, nil
+}
+
+// records packs the spans in as few records as allowed by the record size.
+func (e *encoder) records(key string, process *model.Process, spans []*model.Span) ([]aggregate.Record, error) {
+	var (
+		records []aggregate.Record
+		pending []*model.Span
+	)
+	baseSize := (&model.Batch{Process: process}).Size()
+	size := baseSize
+	for _, span := range spans {
+		// the size of the span as a field of the batch.
+		spanSize := protowire.SizeTag(batchSpans) + protowire.SizeBytes(span.Size())
+		if len(pending) > 0 && size+spanSize > e.maxRecordSize {
+			record, err := e.record(key, process, pending)
+			if err != nil {
+				return nil, err
+			}
+			records = append(records, record)
+			pending = nil
+			size = baseSize
+		}
+		pending = append(pending, span)
+		size += spanSize
+	}
+	if len(pending) > 0 {
+		record, err := e.record(key, process, pending)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+func (e *encoder) record(key string, process *model.Process, spans []*model.Span) (aggregate.Record, error) {
+	data, err := (&model.Batch{Process: process, Spans: spans}).Marshal()
+	if err != nil {
+		return aggregate.Record{}, err
+	}
+	if e.zstd != nil {
+		data = e.zstd.EncodeAll(data, make([]byte, 0, len(data)))
+	}
+	return aggregate.Record{PartitionKey: key, Data: data}, nil
+}
+
+// partitionKey joins the values of the partition key attributes found on the
+// span or its process, falling back to the trace ID.
+func (e *encoder) partitionKey(process *model.Process, span *model.Span) string {
+	var (
+		values []string
+		found  bool
+	)
+	for _, attr := range e.partitionKeyAttributes {
+		value, ok := attributeValue(process, span, attr)
+		found = found || ok
+		values = append(values, value)
+	}
+	if !found {
+		return span.TraceID.String()
+	}
+
+	key := strings.Join(values, "/")
+	if runes := []rune(key); len(runes) > maxPartitionKeyLength {
+		key = string(runes[:maxPartitionKeyLength])
+	}
+	return key
+}
+
+func attributeValue(process *model.Process, span *model.Span, attr string) (string, bool) {
+	if kv, ok := model.KeyValues(span.Tags).FindByKey(attr); ok {
+		return kv.AsString(), true
+	}
+	if process == nil {
+		return "", false
+	}
+	// the service name is moved out of the process tags.
+	if attr == conventions.AttributeServiceName && process.ServiceName != "" {
+		return process.ServiceName, true
+	}
+	if kv, ok := model.KeyValues(process.Tags).FindByKey(attr); ok {
+		return kv.AsString(), true
+	}
+	return "", false
+}
@@ -17,62 +17,31 @@ package awskinesisexporter
 import (
 	"context"
 
-	awskinesis "github.com/signalfx/opencensus-go-exporter-kinesis"
-	"go.opentelemetry.io/collector/component"
-	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/consumer/pdata"
-	jaegertranslator "go.opentelemetry.io/collector/translator/trace/jaeger"
 	"go.uber.org/zap"
 )
 
-// Exporter implements an OpenTelemetry trace exporter that exports all spans to AWS Kinesis
+// Exporter sends the spans to a Kinesis stream.
 type Exporter struct {
-	awskinesis *awskinesis.Exporter
-	logger     *zap.Logger
+	encoder  *encoder
+	producer *producer
+	logger   *zap.Logger
 }
 
-var _ component.TracesExporter = (*Exporter)(nil)
-
-// Start tells the exporter to start. The exporter may prepare for exporting
-// by connecting to the endpoint. Host parameter can be used for communicating
-// with the host after Start() has already returned. If error is returned by
-// Start() then the collector startup will be aborted.
-func (e Exporter) Start(_ context.Context, _ component.Host) error {
-	return nil
-}
-
-// Capabilities implements the consumer interface.
-func (e Exporter) Capabilities() consumer.Capabilities {
-	return consumer.Capabilities{MutatesData: false}
-}
-
-// Shutdown is invoked during exporter shutdown.
-func (e Exporter) Shutdown(context.Context) error {
-	e.awskinesis.Flush()
-	return nil
-}
-
-// ConsumeTraces receives a span batch and exports it to AWS Kinesis
-func (e Exporter) ConsumeTraces(_ context.Context, td pdata.Traces) error {
-	pBatches, err := jaegertranslator.InternalTracesToJaegerProto(td)
+func (e Exporter) pushTraces(ctx context.Context, td pdata.Traces) error {
+	records, dropped, err := e.encoder.encode(td)
 	if err != nil {
 		e.logger.Error("error translating span batch", zap.Error(err))
 		return consumererror.Permanent(err)
 	}
-	// TODO: Use a multi error type
-	var exportErr error
-	for _, pBatch := range pBatches {
-		for _, span := range pBatch.GetSpans() {
-			if span.Process == nil {
-				span.Process = pBatch.Process
-			}
-			err := e.awskinesis.ExportSpan(span)
-			if err != nil {
-				e.logger.Error("error exporting span to awskinesis", zap.Error(err))
-				exportErr = err
-			}
-		}
+	if dropped > 0 {
+		e.logger.Debug("Spans dropped because of their size", zap.Int("dropped", dropped))
 	}
-	return exportErr
+
+	if err := e.producer.put(ctx, records); err != nil {
+		e.logger.Error("error exporting span to awskinesis", zap.Error(err))
+		return err
+	}
+	return nil
 }
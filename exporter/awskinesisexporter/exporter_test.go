@@ -0,0 +1,227 @@
+// Copyright 2019 OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package awskinesisexporter
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/kinesis"
+	"github.com/aws/aws-sdk-go/service/kinesis/kinesisiface"
+	"github.com/jaegertracing/jaeger/model"
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/awskinesisexporter/internal/aggregate"
+)
+
+func TestEncodePartitionKeys(t *testing.T) {
+	c := createDefaultConfig().(*Config)
+	c.PartitionKeyAttributes = []string{"service.name", "tenant"}
+	enc, err := newEncoder(c, zap.NewNop())
+	require.NoError(t, err)
+
+	td := testTraces(map[string]string{"tenant": "a"}, map[string]string{"tenant": "b"}, map[string]string{"tenant": "a"})
+	records, dropped, err := enc.encode(td)
+	require.NoError(t, err)
+	assert.Equal(t, 0, dropped)
+	require.Len(t, records, 2)
+	assert.Equal(t, "checkout/a", records[0].PartitionKey)
+	assert.Equal(t, "checkout/b", records[1].PartitionKey)
+
+	batch := decodeBatch(t, records[0].Data, nil)
+	assert.Equal(t, "checkout", batch.Process.ServiceName)
+	assert.Len(t, batch.Spans, 2)
+}
+
+func TestEncodeTraceIDPartitionKey(t *testing.T) {
+	enc, err := newEncoder(createDefaultConfig().(*Config), zap.NewNop())
+	require.NoError(t, err)
+
+	records, _, err := enc.encode(testTraces(nil))
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, model.NewTraceID(0x0102030405060708, 0x090a0b0c0d0e0f10).String(), records[0].PartitionKey)
+}
+
+func TestEncodeRecordSize(t *testing.T) {
+	c := createDefaultConfig().(*Config)
+	c.PartitionKeyAttributes = []string{"service.name"}
+	enc, err := newEncoder(c, zap.NewNop())
+	require.NoError(t, err)
+
+	td := testTraces(nil, nil, nil)
+	records, _, err := enc.encode(td)
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+
+	// a record can only hold one span
+	single, _, err := enc.encode(testTraces(nil))
+	require.NoError(t, err)
+	enc.maxRecordSize = len(single[0].Data)
+	records, _, err = enc.encode(td)
+	require.NoError(t, err)
+	assert.Len(t, records, 3)
+
+	// spans larger than the maximum are dropped
+	enc.maxSpanSize = 1
+	records, dropped, err := enc.encode(td)
+	require.NoError(t, err)
+	assert.Empty(t, records)
+	assert.Equal(t, 3, dropped)
+}
+
+func TestEncodeZstd(t *testing.T) {
+	c := createDefaultConfig().(*Config)
+	c.Compression = compressionZstd
+	enc, err := newEncoder(c, zap.NewNop())
+	require.NoError(t, err)
+
+	records, _, err := enc.encode(testTraces(nil))
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+
+	dec, err := zstd.NewReader(nil)
+	require.NoError(t, err)
+	defer dec.Close()
+	batch := decodeBatch(t, records[0].Data, dec)
+	assert.Len(t, batch.Spans, 1)
+}
+
+func TestPushTracesAggregated(t *testing.T) {
+	c := createDefaultConfig().(*Config)
+	c.AWS.StreamName = "spans"
+	c.PartitionKeyAttributes = []string{"service.name"}
+	c.MaxBytesPerBatch = 1
+	c.KPL.AggregateBatchCount = 100
+	c.KPL.AggregateBatchSize = 50000
+	client := &mockKinesis{}
+	exp := testExporter(t, c, client)
+
+	require.NoError(t, exp.pushTraces(context.Background(), testTraces(nil, nil, nil)))
+
+	require.Len(t, client.requests, 1)
+	assert.Equal(t, "spans", aws.StringValue(client.requests[0].StreamName))
+	require.Len(t, client.requests[0].Records, 1)
+	entry := client.requests[0].Records[0]
+	assert.Equal(t, "checkout", aws.StringValue(entry.PartitionKey))
+
+	records, err := aggregate.Deaggregate(aws.StringValue(entry.PartitionKey), entry.Data)
+	require.NoError(t, err)
+	assert.Len(t, records, 3)
+}
+
+func TestPushTracesRequestLimits(t *testing.T) {
+	c := createDefaultConfig().(*Config)
+	c.MaxBytesPerBatch = 1
+	c.KPL.BatchCount = 2
+	client := &mockKinesis{}
+	exp := testExporter(t, c, client)
+
+	require.NoError(t, exp.pushTraces(context.Background(), testTraces(nil, nil, nil)))
+	require.Len(t, client.requests, 2)
+	assert.Len(t, client.requests[0].Records, 2)
+	assert.Len(t, client.requests[1].Records, 1)
+}
+
+func TestPushTracesRetriesRejectedRecords(t *testing.T) {
+	c := createDefaultConfig().(*Config)
+	c.MaxBytesPerBatch = 1
+	c.KPL.MaxRetries = 1
+	client := &mockKinesis{rejectFirst: 2}
+	exp := testExporter(t, c, client)
+
+	require.NoError(t, exp.pushTraces(context.Background(), testTraces(nil, nil, nil)))
+	require.Len(t, client.requests, 2)
+	assert.Len(t, client.requests[0].Records, 3)
+	assert.Equal(t, client.requests[0].Records[:2], client.requests[1].Records)
+
+	client = &mockKinesis{rejectFirst: 1}
+	c.KPL.MaxRetries = 0
+	exp = testExporter(t, c, client)
+	err := exp.pushTraces(context.Background(), testTraces(nil))
+	assert.EqualError(t, err, "1 records rejected by Kinesis, first error: ProvisionedThroughputExceededException: slow down")
+}
+
+func testExporter(t *testing.T, c *Config, client kinesisiface.KinesisAPI) Exporter {
+	enc, err := newEncoder(c, zap.NewNop())
+	require.NoError(t, err)
+	return Exporter{
+		encoder:  enc,
+		producer: newProducer(c, client, zap.NewNop()),
+		logger:   zap.NewNop(),
+	}
+}
+
+// testTraces returns a span per set of span attributes, all of the checkout
+// service and of the same trace.
+func testTraces(spanAttrs ...map[string]string) pdata.Traces {
+	td := pdata.NewTraces()
+	rs := td.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().InsertString("service.name", "checkout")
+	spans := rs.InstrumentationLibrarySpans().AppendEmpty().Spans()
+	for i, attrs := range spanAttrs {
+		span := spans.AppendEmpty()
+		span.SetName("span")
+		span.SetTraceID(pdata.NewTraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}))
+		span.SetSpanID(pdata.NewSpanID([8]byte{1, 2, 3, 4, 5, 6, 7, byte(i + 1)}))
+		for k, v := range attrs {
+			span.Attributes().InsertString(k, v)
+		}
+	}
+	return td
+}
+
+func decodeBatch(t *testing.T, data []byte, dec *zstd.Decoder) *model.Batch {
+	if dec != nil {
+		var err error
+		data, err = dec.DecodeAll(data, nil)
+		require.NoError(t, err)
+	}
+	batch := &model.Batch{}
+	require.NoError(t, batch.Unmarshal(data))
+	return batch
+}
+
+// mockKinesis records the PutRecords requests and rejects the first
+// rejectFirst records of the first request.
+type mockKinesis struct {
+	kinesisiface.KinesisAPI
+
+	rejectFirst int
+	requests    []*kinesis.PutRecordsInput
+}
+
+func (m *mockKinesis) PutRecordsWithContext(_ aws.Context, input *kinesis.PutRecordsInput, _ ...request.Option) (*kinesis.PutRecordsOutput, error) {
+	m.requests = append(m.requests, input)
+	out := &kinesis.PutRecordsOutput{FailedRecordCount: aws.Int64(0)}
+	for i := range input.Records {
+		entry := &kinesis.PutRecordsResultEntry{SequenceNumber: aws.String("1")}
+		if len(m.requests) == 1 && i < m.rejectFirst {
+			entry = &kinesis.PutRecordsResultEntry{
+				ErrorCode:    aws.String("ProvisionedThroughputExceededException"),
+				ErrorMessage: aws.String("slow down"),
+			}
+			*out.FailedRecordCount++
+		}
+		out.Records = append(out.Records, entry)
+	}
+	return out, nil
+}
@@ -16,8 +16,13 @@ package awskinesisexporter
 
 import (
 	"context"
+	"net/http"
 
-	awskinesis "github.com/signalfx/opencensus-go-exporter-kinesis"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/kinesis"
+	"github.com/aws/aws-sdk-go/service/kinesis/kinesisiface"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
@@ -25,8 +30,7 @@ import (
 
 const (
 	// The value of "type" key in configuration.
-	typeStr      = "awskinesis"
-	exportFormat = "jaeger-proto"
+	typeStr = "awskinesis"
 )
 
 // NewFactory creates a factory for Kinesis exporter.
@@ -45,7 +49,7 @@ func createDefaultConfig() config.Exporter {
 		},
 		KPL: KPLConfig{
 			BatchSize:            5242880,
-			BatchCount:           1000,
+			BatchCount:           500,
 			BacklogCount:         2000,
 			FlushIntervalSeconds: 5,
 			MaxConnections:       24,
@@ -56,6 +60,7 @@ func createDefaultConfig() config.Exporter {
 		FlushIntervalSeconds: 5,
 		MaxBytesPerBatch:     100000,
 		MaxBytesPerSpan:      900000,
+		Compression:          compressionNone,
 	}
 }
 
@@ -65,33 +70,52 @@ func createTracesExporter(
 	config config.Exporter,
 ) (component.TracesExporter, error) {
 	c := config.(*Config)
-	k, err := awskinesis.NewExporter(&awskinesis.Options{
-		Name:               c.ID().String(),
-		StreamName:         c.AWS.StreamName,
-		AWSRegion:          c.AWS.Region,
-		AWSRole:            c.AWS.Role,
-		AWSKinesisEndpoint: c.AWS.KinesisEndpoint,
+	client, err := newKinesisClient(c)
+	if err != nil {
+		return nil, err
+	}
+	enc, err := newEncoder(c, params.Logger)
+	if err != nil {
+		return nil, err
+	}
 
-		KPLAggregateBatchSize:   c.KPL.AggregateBatchSize,
-		KPLAggregateBatchCount:  c.KPL.AggregateBatchCount,
-		KPLBatchSize:            c.KPL.BatchSize,
-		KPLBatchCount:           c.KPL.BatchCount,
-		KPLBacklogCount:         c.KPL.BacklogCount,
-		KPLFlushIntervalSeconds: c.KPL.FlushIntervalSeconds,
-		KPLMaxConnections:       c.KPL.MaxConnections,
-		KPLMaxRetries:           c.KPL.MaxRetries,
-		KPLMaxBackoffSeconds:    c.KPL.MaxBackoffSeconds,
+	exp := Exporter{
+		encoder:  enc,
+		producer: newProducer(c, client, params.Logger),
+		logger:   params.Logger,
+	}
+	return exporterhelper.NewTracesExporter(
+		c,
+		params.Logger,
+		exp.pushTraces,
+		exporterhelper.WithQueue(exporterhelper.QueueSettings{
+			Enabled:      true,
+			NumConsumers: c.NumWorkers,
+			QueueSize:    c.QueueSize,
+		}),
+		exporterhelper.WithRetry(exporterhelper.DefaultRetrySettings()),
+	)
+}
 
-		QueueSize:             c.QueueSize,
-		NumWorkers:            c.NumWorkers,
-		MaxAllowedSizePerSpan: c.MaxBytesPerSpan,
-		MaxListSize:           c.MaxBytesPerBatch,
-		ListFlushInterval:     c.FlushIntervalSeconds,
-		Encoding:              exportFormat,
-	}, params.Logger)
+func newKinesisClient(c *Config) (kinesisiface.KinesisAPI, error) {
+	awsConfig := aws.NewConfig().WithRegion(c.AWS.Region)
+	if c.AWS.KinesisEndpoint != "" {
+		awsConfig = awsConfig.WithEndpoint(c.AWS.KinesisEndpoint)
+	}
+	if c.KPL.MaxConnections > 0 {
+		awsConfig = awsConfig.WithHTTPClient(&http.Client{
+			Transport: &http.Transport{
+				Proxy:           http.ProxyFromEnvironment,
+				MaxConnsPerHost: c.KPL.MaxConnections,
+			},
+		})
+	}
+	sess, err := session.NewSession(awsConfig)
 	if err != nil {
 		return nil, err
 	}
-
-	return Exporter{k, params.Logger}, nil
+	if c.AWS.Role != "" {
+		awsConfig = awsConfig.WithCredentials(stscreds.NewCredentials(sess, c.AWS.Role))
+	}
+	return kinesis.New(sess, awsConfig), nil
 }
@@ -0,0 +1,37 @@
+// Copyright 2019 OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package awskinesisexporter
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.uber.org/zap"
+)
+
+func TestCreateTracesExporter(t *testing.T) {
+	c := createDefaultConfig().(*Config)
+	c.AWS.StreamName = "spans"
+	c.AWS.Role = "arn:aws:iam::123456789012:role/kinesis"
+	c.KPL.MaxConnections = 4
+	c.Compression = compressionZstd
+
+	exp, err := NewFactory().CreateTracesExporter(context.Background(), component.ExporterCreateSettings{Logger: zap.NewNop()}, c)
+	require.NoError(t, err)
+	assert.NotNil(t, exp)
+}
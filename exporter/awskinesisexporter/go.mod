@@ -4,12 +4,15 @@ go 1.16
 
 require (
 	github.com/armon/go-metrics v0.3.3 // indirect
+	github.com/aws/aws-sdk-go v1.38.3
 	github.com/hashicorp/go-immutable-radix v1.2.0 // indirect
+	github.com/jaegertracing/jaeger v1.22.0
+	github.com/klauspost/compress v1.12.2
 	github.com/mattn/go-colorable v0.1.7 // indirect
 	github.com/pelletier/go-toml v1.8.0 // indirect
-	github.com/signalfx/opencensus-go-exporter-kinesis v0.6.3
 	github.com/stretchr/testify v1.7.0
 	go.opentelemetry.io/collector v0.27.1-0.20210603182316-5369d7e9e83e
 	go.uber.org/zap v1.17.0
+	google.golang.org/protobuf v1.26.0
 	gopkg.in/ini.v1 v1.57.0 // indirect
 )
@@ -0,0 +1,207 @@
+// Copyright 2019 OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package aggregate packs records into aggregated Kinesis records following
+// the format of the Kinesis Producer Library (KPL), so that they can be read
+// back as individual records by the Kinesis Client Library (KCL), including
+// with enhanced fan-out consumers.
+//
+// See https://github.com/awslabs/amazon-kinesis-producer/blob/master/aggregation-format.md
+package aggregate
+
+import (
+	"bytes"
+	"crypto/md5" // #nosec the KPL format requires MD5, which isn't used for security
+	"errors"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// Magic prefixes every aggregated record.
+var Magic = []byte{0xf3, 0x89, 0x9a, 0xc2}
+
+// The field numbers of the AggregatedRecord and Record protobuf messages.
+const (
+	aggregatedPartitionKeyTable protowire.Number = 1
+	aggregatedRecords           protowire.Number = 3
+
+	recordPartitionKeyIndex protowire.Number = 1
+	recordData              protowire.Number = 3
+)
+
+// Record is a record sent to, or read from, a Kinesis stream.
+type Record struct {
+	PartitionKey string
+	Data         []byte
+}
+
+// Aggregate packs the records sharing the same partition key into aggregated
+// records holding at most maxCount records and maxSize bytes, keeping the order
+// of the records of each partition key. A record packed alone is returned as is.
+func Aggregate(records []Record, maxCount, maxSize int) []Record {
+	var (
+		out    []Record
+		keys   []string
+		groups = make(map[string][]Record)
+	)
+	for _, r := range records {
+		if _, ok := groups[r.PartitionKey]; !ok {
+			keys = append(keys, r.PartitionKey)
+		}
+		groups[r.PartitionKey] = append(groups[r.PartitionKey], r)
+	}
+
+	for _, key := range keys {
+		var pending []Record
+		size := aggregatedSize(key)
+		for _, r := range groups[key] {
+			recordSize := entrySize(r.Data)
+			if len(pending) > 0 && (len(pending) >= maxCount || size+recordSize > maxSize) {
+				out = append(out, pack(key, pending))
+				pending = nil
+				size = aggregatedSize(key)
+			}
+			pending = append(pending, r)
+			size += recordSize
+		}
+		if len(pending) > 0 {
+			out = append(out, pack(key, pending))
+		}
+	}
+	return out
+}
+
+// Deaggregate returns the records packed in data, or a single record holding
+// data when it isn't an aggregated record.
+func Deaggregate(partitionKey string, data []byte) ([]Record, error) {
+	if !bytes.HasPrefix(data, Magic) || len(data) < len(Magic)+md5.Size {
+		return []Record{{PartitionKey: partitionKey, Data: data}}, nil
+	}
+	message := data[len(Magic) : len(data)-md5.Size]
+	checksum := md5.Sum(message) // #nosec
+	if !bytes.Equal(checksum[:], data[len(data)-md5.Size:]) {
+		return []Record{{PartitionKey: partitionKey, Data: data}}, nil
+	}
+
+	var (
+		keys    []string
+		records []Record
+	)
+	for len(message) > 0 {
+		num, typ, n := protowire.ConsumeTag(message)
+		if n < 0 {
+			return nil, protowire.ParseError(n)
+		}
+		message = message[n:]
+		if typ != protowire.BytesType {
+			n = protowire.ConsumeFieldValue(num, typ, message)
+			if n < 0 {
+				return nil, protowire.ParseError(n)
+			}
+			message = message[n:]
+			continue
+		}
+		value, n := protowire.ConsumeBytes(message)
+		if n < 0 {
+			return nil, protowire.ParseError(n)
+		}
+		message = message[n:]
+
+		switch num {
+		case aggregatedPartitionKeyTable:
+			keys = append(keys, string(value))
+		case aggregatedRecords:
+			index, recordBytes, err := parseRecord(value)
+			if err != nil {
+				return nil, err
+			}
+			if index >= uint64(len(keys)) {
+				return nil, errors.New("partition key index out of range")
+			}
+			records = append(records, Record{PartitionKey: keys[index], Data: recordBytes})
+		}
+	}
+	return records, nil
+}
+
+func parseRecord(message []byte) (uint64, []byte, error) {
+	var (
+		index uint64
+		data  []byte
+	)
+	for len(message) > 0 {
+		num, typ, n := protowire.ConsumeTag(message)
+		if n < 0 {
+			return 0, nil, protowire.ParseError(n)
+		}
+		message = message[n:]
+		switch {
+		case num == recordPartitionKeyIndex && typ == protowire.VarintType:
+			index, n = protowire.ConsumeVarint(message)
+		case num == recordData && typ == protowire.BytesType:
+			data, n = protowire.ConsumeBytes(message)
+		default:
+			n = protowire.ConsumeFieldValue(num, typ, message)
+		}
+		if n < 0 {
+			return 0, nil, protowire.ParseError(n)
+		}
+		message = message[n:]
+	}
+	return index, data, nil
+}
+
+func pack(partitionKey string, records []Record) Record {
+	if len(records) == 1 {
+		return records[0]
+	}
+
+	var message []byte
+	message = protowire.AppendTag(message, aggregatedPartitionKeyTable, protowire.BytesType)
+	message = protowire.AppendString(message, partitionKey)
+	for _, r := range records {
+		message = protowire.AppendTag(message, aggregatedRecords, protowire.BytesType)
+		message = protowire.AppendBytes(message, recordMessage(r.Data))
+	}
+
+	checksum := md5.Sum(message) // #nosec
+	data := make([]byte, 0, len(Magic)+len(message)+len(checksum))
+	data = append(data, Magic...)
+	data = append(data, message...)
+	data = append(data, checksum[:]...)
+	return Record{PartitionKey: partitionKey, Data: data}
+}
+
+func recordMessage(data []byte) []byte {
+	var message []byte
+	// every record refers to the single partition key of the table.
+	message = protowire.AppendTag(message, recordPartitionKeyIndex, protowire.VarintType)
+	message = protowire.AppendVarint(message, 0)
+	message = protowire.AppendTag(message, recordData, protowire.BytesType)
+	message = protowire.AppendBytes(message, data)
+	return message
+}
+
+// aggregatedSize returns the size of an aggregated record without any record.
+func aggregatedSize(partitionKey string) int {
+	return len(Magic) + protowire.SizeTag(aggregatedPartitionKeyTable) +
+		protowire.SizeBytes(len(partitionKey)) + md5.Size
+}
+
+// entrySize returns the size a record holding data adds to an aggregated record.
+func entrySize(data []byte) int {
+	size := protowire.SizeTag(recordPartitionKeyIndex) + protowire.SizeVarint(0) +
+		protowire.SizeTag(recordData) + protowire.SizeBytes(len(data))
+	return protowire.SizeTag(aggregatedRecords) + protowire.SizeBytes(size)
+}
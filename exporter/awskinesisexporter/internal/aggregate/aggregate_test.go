@@ -0,0 +1,75 @@
+// Copyright 2019 OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aggregate
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAggregateRoundTrip(t *testing.T) {
+	var records []Record
+	for i := 0; i < 5; i++ {
+		records = append(records, Record{PartitionKey: "a", Data: []byte(fmt.Sprintf("record-a-%d", i))})
+	}
+	records = append(records, Record{PartitionKey: "b", Data: []byte("record-b-0")})
+
+	aggregated := Aggregate(records, 3, 1024)
+	require.Len(t, aggregated, 3)
+
+	assert.Equal(t, "a", aggregated[0].PartitionKey)
+	assert.True(t, bytes.HasPrefix(aggregated[0].Data, Magic))
+	assert.Equal(t, "a", aggregated[1].PartitionKey)
+	assert.True(t, bytes.HasPrefix(aggregated[1].Data, Magic))
+	// a record alone isn't aggregated
+	assert.Equal(t, records[5], aggregated[2])
+
+	var got []Record
+	for _, r := range aggregated {
+		deaggregated, err := Deaggregate(r.PartitionKey, r.Data)
+		require.NoError(t, err)
+		got = append(got, deaggregated...)
+	}
+	assert.Equal(t, records, got)
+}
+
+func TestAggregateMaxSize(t *testing.T) {
+	records := []Record{
+		{PartitionKey: "a", Data: bytes.Repeat([]byte("x"), 40)},
+		{PartitionKey: "a", Data: bytes.Repeat([]byte("y"), 40)},
+		{PartitionKey: "a", Data: bytes.Repeat([]byte("z"), 40)},
+	}
+
+	aggregated := Aggregate(records, 100, 128)
+	require.Len(t, aggregated, 2)
+	for _, r := range aggregated {
+		assert.LessOrEqual(t, len(r.Data), 128)
+	}
+
+	deaggregated, err := Deaggregate("a", aggregated[0].Data)
+	require.NoError(t, err)
+	assert.Equal(t, records[:2], deaggregated)
+	assert.Equal(t, records[2], aggregated[1])
+}
+
+func TestDeaggregateNonAggregatedRecord(t *testing.T) {
+	records, err := Deaggregate("key", []byte("plain"))
+	require.NoError(t, err)
+	assert.Equal(t, []Record{{PartitionKey: "key", Data: []byte("plain")}}, records)
+}
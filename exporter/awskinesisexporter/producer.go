@@ -0,0 +1,141 @@
+// Copyright 2019 OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package awskinesisexporter
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/kinesis"
+	"github.com/aws/aws-sdk-go/service/kinesis/kinesisiface"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/awskinesisexporter/internal/aggregate"
+)
+
+const initialBackoff = 100 * time.Millisecond
+
+// producer sends records to a Kinesis stream with PutRecords requests.
+type producer struct {
+	client           kinesisiface.KinesisAPI
+	logger           *zap.Logger
+	streamName       string
+	maxRequestCount  int
+	maxRequestSize   int
+	maxRetries       int
+	maxBackoff       time.Duration
+	aggregationCount int
+	aggregationSize  int
+}
+
+func newProducer(c *Config, client kinesisiface.KinesisAPI, logger *zap.Logger) *producer {
+	return &producer{
+		client:           client,
+		logger:           logger,
+		streamName:       c.AWS.StreamName,
+		maxRequestCount:  c.KPL.BatchCount,
+		maxRequestSize:   c.KPL.BatchSize,
+		maxRetries:       c.KPL.MaxRetries,
+		maxBackoff:       time.Duration(c.KPL.MaxBackoffSeconds) * time.Second,
+		aggregationCount: c.KPL.AggregateBatchCount,
+		aggregationSize:  c.KPL.AggregateBatchSize,
+	}
+}
+
+// put sends the records, aggregated when enabled, in as few requests as
+// allowed by the request limits.
+func (p *producer) put(ctx context.Context, records []aggregate.Record) error {
+	if p.aggregationCount > 0 {
+		records = aggregate.Aggregate(records, p.aggregationCount, p.aggregationSize)
+	}
+
+	var (
+		entries []*kinesis.PutRecordsRequestEntry
+		size    int
+	)
+	for _, r := range records {
+		entrySize := len(r.PartitionKey) + len(r.Data)
+		if len(entries) > 0 && (len(entries) >= p.maxRequestCount || size+entrySize > p.maxRequestSize) {
+			if err := p.putRecords(ctx, entries); err != nil {
+				return err
+			}
+			entries = nil
+			size = 0
+		}
+		entries = append(entries, &kinesis.PutRecordsRequestEntry{
+			PartitionKey: aws.String(r.PartitionKey),
+			Data:         r.Data,
+		})
+		size += entrySize
+	}
+	if len(entries) == 0 {
+		return nil
+	}
+	return p.putRecords(ctx, entries)
+}
+
+// putRecords sends one request, sending again the records rejected by Kinesis
+// up to the maximum number of retries.
+func (p *producer) putRecords(ctx context.Context, entries []*kinesis.PutRecordsRequestEntry) error {
+	backoff := initialBackoff
+	for attempt := 0; ; attempt++ {
+		out, err := p.client.PutRecordsWithContext(ctx, &kinesis.PutRecordsInput{
+			StreamName: aws.String(p.streamName),
+			Records:    entries,
+		})
+		if err != nil {
+			return err
+		}
+		if aws.Int64Value(out.FailedRecordCount) == 0 {
+			return nil
+		}
+
+		var failed []*kinesis.PutRecordsRequestEntry
+		for i, r := range out.Records {
+			if r.ErrorCode != nil {
+				failed = append(failed, entries[i])
+			}
+		}
+		if attempt >= p.maxRetries {
+			return fmt.Errorf("%d records rejected by Kinesis, first error: %s",
+				len(failed), firstRecordError(out.Records))
+		}
+		p.logger.Debug("Sending again the records rejected by Kinesis",
+			zap.Int("failed", len(failed)),
+			zap.String("error", firstRecordError(out.Records)))
+		entries = failed
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+		if p.maxBackoff > 0 && backoff > p.maxBackoff {
+			backoff = p.maxBackoff
+		}
+	}
+}
+
+func firstRecordError(records []*kinesis.PutRecordsResultEntry) string {
+	for _, r := range records {
+		if r.ErrorCode != nil {
+			return fmt.Sprintf("%s: %s", aws.StringValue(r.ErrorCode), aws.StringValue(r.ErrorMessage))
+		}
+	}
+	return ""
+}
@@ -30,6 +30,13 @@ const (
 	attributeRPCGRPCStatusCode     string = "rpc.grpc.status_code"
 	attributeOtelStatusCode        string = "otel.status_code"
 	attributeOtelStatusDescription string = "otel.status_description"
+
+	// attributeSampleRate carries the sampling percentage (0, 100] applied upstream
+	attributeSampleRate string = "microsoft.sample_rate"
+
+	// properties used to carry log record fields that have no AppInsights counterpart
+	attributeLogBody         string = "log.body"
+	attributeLogSeverityText string = "log.severity_text"
 )
 
 // NetworkAttributes is the set of known network attributes
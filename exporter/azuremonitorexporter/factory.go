@@ -42,7 +42,9 @@ func NewFactory() component.ExporterFactory {
 	return exporterhelper.NewFactory(
 		typeStr,
 		createDefaultConfig,
-		exporterhelper.WithTraces(f.createTracesExporter))
+		exporterhelper.WithTraces(f.createTracesExporter),
+		exporterhelper.WithMetrics(f.createMetricsExporter),
+		exporterhelper.WithLogs(f.createLogsExporter))
 }
 
 // Implements the interface from go.opentelemetry.io/collector/exporter/factory.go
@@ -74,7 +76,37 @@ func (f *factory) createTracesExporter(
 	return newTracesExporter(exporterConfig, tc, params.Logger)
 }
 
-// Configures the transport channel.
+func (f *factory) createMetricsExporter(
+	ctx context.Context,
+	params component.ExporterCreateSettings,
+	cfg config.Exporter,
+) (component.MetricsExporter, error) {
+	exporterConfig, ok := cfg.(*Config)
+
+	if !ok {
+		return nil, errUnexpectedConfigurationType
+	}
+
+	tc := f.getTransportChannel(exporterConfig, params.Logger)
+	return newMetricsExporter(exporterConfig, tc, params.Logger)
+}
+
+func (f *factory) createLogsExporter(
+	ctx context.Context,
+	params component.ExporterCreateSettings,
+	cfg config.Exporter,
+) (component.LogsExporter, error) {
+	exporterConfig, ok := cfg.(*Config)
+
+	if !ok {
+		return nil, errUnexpectedConfigurationType
+	}
+
+	tc := f.getTransportChannel(exporterConfig, params.Logger)
+	return newLogsExporter(exporterConfig, tc, params.Logger)
+}
+
+// Configures the transport channel. The channel is shared by the traces, metrics and logs exporters.
 // This method is not thread-safe
 func (f *factory) getTransportChannel(exporterConfig *Config, logger *zap.Logger) transportChannel {
 
@@ -65,3 +65,34 @@ func TestCreateTracesExporterUsingBadConfig(t *testing.T) {
 	assert.Nil(t, exporter)
 	assert.NotNil(t, err)
 }
+
+func TestCreateMetricsAndLogsExporterShareTransportChannel(t *testing.T) {
+	f := factory{}
+	ctx := context.Background()
+	params := component.ExporterCreateSettings{Logger: zap.NewNop()}
+
+	metricsExporter, err := f.createMetricsExporter(ctx, params, createDefaultConfig())
+	assert.NotNil(t, metricsExporter)
+	assert.Nil(t, err)
+	tChannel := f.tChannel
+	assert.NotNil(t, tChannel)
+
+	logsExporter, err := f.createLogsExporter(ctx, params, createDefaultConfig())
+	assert.NotNil(t, logsExporter)
+	assert.Nil(t, err)
+	assert.Equal(t, tChannel, f.tChannel)
+}
+
+func TestCreateMetricsAndLogsExporterUsingBadConfig(t *testing.T) {
+	f := factory{}
+	ctx := context.Background()
+	params := component.ExporterCreateSettings{Logger: zap.NewNop()}
+
+	metricsExporter, err := f.createMetricsExporter(ctx, params, &badConfig{})
+	assert.Nil(t, metricsExporter)
+	assert.NotNil(t, err)
+
+	logsExporter, err := f.createLogsExporter(ctx, params, &badConfig{})
+	assert.Nil(t, logsExporter)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,118 @@
+// Copyright OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azuremonitorexporter
+
+import (
+	"time"
+
+	"github.com/microsoft/ApplicationInsights-Go/appinsights/contracts"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	tracetranslator "go.opentelemetry.io/collector/translator/trace"
+	"go.uber.org/zap"
+)
+
+// Transforms a tuple of pdata.Resource, pdata.InstrumentationLibrary, pdata.LogRecord into an AppInsights contracts.Envelope.
+// Named log records become customEvents items, all other log records become traces (MessageData) items.
+func logRecordToEnvelope(
+	resource pdata.Resource,
+	instrumentationLibrary pdata.InstrumentationLibrary,
+	logRecord pdata.LogRecord,
+	logger *zap.Logger) *contracts.Envelope {
+
+	envelope := contracts.NewEnvelope()
+	envelope.Tags = make(map[string]string)
+	envelope.Time = toTime(logRecord.Timestamp()).Format(time.RFC3339Nano)
+
+	if !logRecord.TraceID().IsEmpty() {
+		envelope.Tags[contracts.OperationId] = logRecord.TraceID().HexString()
+	}
+	if !logRecord.SpanID().IsEmpty() {
+		envelope.Tags[contracts.OperationParentId] = logRecord.SpanID().HexString()
+	}
+
+	data := contracts.NewData()
+	var dataSanitizeFunc func() []string
+	var dataProperties map[string]string
+
+	if logRecord.Name() != "" {
+		eventData := contracts.NewEventData()
+		eventData.Name = logRecord.Name()
+		eventData.Properties = make(map[string]string)
+		eventData.Measurements = make(map[string]float64)
+
+		logRecord.Attributes().Range(func(k string, v pdata.AttributeValue) bool {
+			setAttributeValueAsPropertyOrMeasurement(k, v, eventData.Properties, eventData.Measurements)
+			return true
+		})
+
+		if body := logRecord.Body(); body.Type() != pdata.AttributeValueTypeNull {
+			eventData.Properties[attributeLogBody] = tracetranslator.AttributeValueToString(body)
+		}
+
+		dataProperties = eventData.Properties
+		dataSanitizeFunc = eventData.Sanitize
+		envelope.Name = eventData.EnvelopeName("")
+		data.BaseData = eventData
+		data.BaseType = eventData.BaseType()
+	} else {
+		messageData := contracts.NewMessageData()
+		messageData.Message = tracetranslator.AttributeValueToString(logRecord.Body())
+		messageData.SeverityLevel = severityNumberToLevel(logRecord.SeverityNumber())
+		messageData.Properties = make(map[string]string)
+
+		// MessageData has no measurements, so every attribute is recorded as a property
+		logRecord.Attributes().Range(func(k string, v pdata.AttributeValue) bool {
+			messageData.Properties[k] = tracetranslator.AttributeValueToString(v)
+			return true
+		})
+
+		dataProperties = messageData.Properties
+		dataSanitizeFunc = messageData.Sanitize
+		envelope.Name = messageData.EnvelopeName("")
+		data.BaseData = messageData
+		data.BaseType = messageData.BaseType()
+	}
+
+	if severityText := logRecord.SeverityText(); severityText != "" {
+		dataProperties[attributeLogSeverityText] = severityText
+	}
+
+	envelope.Data = data
+	applyResourceToEnvelope(envelope, dataProperties, resource, instrumentationLibrary)
+	applySampleRate(envelope, logRecord.Attributes())
+
+	// Sanitize the base data, the envelope and envelope tags
+	sanitize(dataSanitizeFunc, logger)
+	sanitize(func() []string { return envelope.Sanitize() }, logger)
+	sanitize(func() []string { return contracts.SanitizeTags(envelope.Tags) }, logger)
+
+	return envelope
+}
+
+// Maps the OpenTelemetry severity number ranges onto the AppInsights severity levels
+func severityNumberToLevel(severityNumber pdata.SeverityNumber) contracts.SeverityLevel {
+	switch {
+	case severityNumber >= pdata.SeverityNumberFATAL:
+		return contracts.Critical
+	case severityNumber >= pdata.SeverityNumberERROR:
+		return contracts.Error
+	case severityNumber >= pdata.SeverityNumberWARN:
+		return contracts.Warning
+	case severityNumber >= pdata.SeverityNumberINFO, severityNumber == pdata.SeverityNumberUNDEFINED:
+		return contracts.Information
+	default:
+		return contracts.Verbose
+	}
+}
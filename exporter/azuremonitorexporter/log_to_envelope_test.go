@@ -0,0 +1,130 @@
+// Copyright OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azuremonitorexporter
+
+import (
+	"context"
+	"testing"
+
+	"github.com/microsoft/ApplicationInsights-Go/appinsights/contracts"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultMessageDataEnvelopeName = "Microsoft.ApplicationInsights.Message"
+	defaultEventDataEnvelopeName   = "Microsoft.ApplicationInsights.Event"
+)
+
+func TestLogRecordToMessageData(t *testing.T) {
+	logRecord := getLogRecord()
+	logRecord.Body().SetStringVal("something happened")
+	logRecord.SetSeverityNumber(pdata.SeverityNumberWARN2)
+	logRecord.SetSeverityText("warning")
+
+	envelope := logRecordToEnvelope(getResource(), getInstrumentationLibrary(), logRecord, zap.NewNop())
+
+	assert.Equal(t, defaultMessageDataEnvelopeName, envelope.Name)
+	assert.Equal(t, defaultTraceIDAsHex, envelope.Tags[contracts.OperationId])
+	assert.Equal(t, defaultSpanIDAsHex, envelope.Tags[contracts.OperationParentId])
+	assert.Equal(t, defaultServiceNamespace+"."+defaultServiceName, envelope.Tags[contracts.CloudRole])
+	assert.Equal(t, float64(100), envelope.SampleRate)
+
+	messageData := envelope.Data.(*contracts.Data).BaseData.(*contracts.MessageData)
+	assert.Equal(t, "something happened", messageData.Message)
+	assert.Equal(t, contracts.Warning, messageData.SeverityLevel)
+	assert.Equal(t, "warning", messageData.Properties[attributeLogSeverityText])
+	assert.Equal(t, "bar", messageData.Properties["foo"])
+	assert.Equal(t, "7", messageData.Properties["count"])
+	assert.Equal(t, defaultInstrumentationLibraryName, messageData.Properties[instrumentationLibraryName])
+}
+
+func TestNamedLogRecordToEventData(t *testing.T) {
+	logRecord := getLogRecord()
+	logRecord.SetName("checkout")
+	logRecord.Body().SetStringVal("cart checked out")
+
+	envelope := logRecordToEnvelope(getResource(), getInstrumentationLibrary(), logRecord, zap.NewNop())
+
+	assert.Equal(t, defaultEventDataEnvelopeName, envelope.Name)
+	eventData := envelope.Data.(*contracts.Data).BaseData.(*contracts.EventData)
+	assert.Equal(t, "checkout", eventData.Name)
+	assert.Equal(t, "bar", eventData.Properties["foo"])
+	assert.Equal(t, float64(7), eventData.Measurements["count"])
+	assert.Equal(t, "cart checked out", eventData.Properties[attributeLogBody])
+}
+
+func TestLogRecordSampleRate(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    pdata.AttributeValue
+		expected float64
+	}{
+		{name: "double", value: pdata.NewAttributeValueDouble(12.5), expected: 12.5},
+		{name: "int", value: pdata.NewAttributeValueInt(25), expected: 25},
+		{name: "string", value: pdata.NewAttributeValueString("50"), expected: 50},
+		{name: "out of range", value: pdata.NewAttributeValueDouble(200), expected: 100},
+		{name: "not a number", value: pdata.NewAttributeValueString("half"), expected: 100},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logRecord := getLogRecord()
+			logRecord.Attributes().Upsert(attributeSampleRate, tt.value)
+
+			envelope := logRecordToEnvelope(getResource(), getInstrumentationLibrary(), logRecord, zap.NewNop())
+			assert.Equal(t, tt.expected, envelope.SampleRate)
+		})
+	}
+}
+
+func TestSeverityNumberToLevel(t *testing.T) {
+	assert.Equal(t, contracts.Information, severityNumberToLevel(pdata.SeverityNumberUNDEFINED))
+	assert.Equal(t, contracts.Verbose, severityNumberToLevel(pdata.SeverityNumberTRACE))
+	assert.Equal(t, contracts.Verbose, severityNumberToLevel(pdata.SeverityNumberDEBUG4))
+	assert.Equal(t, contracts.Information, severityNumberToLevel(pdata.SeverityNumberINFO))
+	assert.Equal(t, contracts.Warning, severityNumberToLevel(pdata.SeverityNumberWARN))
+	assert.Equal(t, contracts.Error, severityNumberToLevel(pdata.SeverityNumberERROR3))
+	assert.Equal(t, contracts.Critical, severityNumberToLevel(pdata.SeverityNumberFATAL))
+}
+
+// Tests the export onLogData callback
+func TestExporterLogDataCallback(t *testing.T) {
+	mockTransportChannel := getMockTransportChannel()
+	exporter := &logExporter{defaultConfig, mockTransportChannel, zap.NewNop()}
+
+	logs := pdata.NewLogs()
+	assert.NoError(t, exporter.onLogData(context.Background(), logs))
+	mockTransportChannel.AssertNumberOfCalls(t, "Send", 0)
+
+	rl := logs.ResourceLogs().AppendEmpty()
+	getResource().CopyTo(rl.Resource())
+	ill := rl.InstrumentationLibraryLogs().AppendEmpty()
+	getLogRecord().CopyTo(ill.Logs().AppendEmpty())
+
+	assert.NoError(t, exporter.onLogData(context.Background(), logs))
+	mockTransportChannel.AssertNumberOfCalls(t, "Send", 1)
+}
+
+func getLogRecord() pdata.LogRecord {
+	logRecord := pdata.NewLogRecord()
+	logRecord.SetTimestamp(defaultSpanStartTime)
+	logRecord.SetTraceID(pdata.NewTraceID(defaultTraceID))
+	logRecord.SetSpanID(pdata.NewSpanID(defaultSpanID))
+	logRecord.Attributes().InsertString("foo", "bar")
+	logRecord.Attributes().InsertInt("count", 7)
+	return logRecord
+}
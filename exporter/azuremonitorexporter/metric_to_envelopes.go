@@ -0,0 +1,151 @@
+// Copyright OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azuremonitorexporter
+
+import (
+	"time"
+
+	"github.com/microsoft/ApplicationInsights-Go/appinsights/contracts"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.uber.org/zap"
+)
+
+// Transforms a tuple of pdata.Resource, pdata.InstrumentationLibrary, pdata.Metric into AppInsights contracts.Envelopes.
+// Every data point of the metric becomes a separate customMetrics item, with the data point labels as properties.
+func metricToEnvelopes(
+	resource pdata.Resource,
+	instrumentationLibrary pdata.InstrumentationLibrary,
+	metric pdata.Metric,
+	logger *zap.Logger) []*contracts.Envelope {
+
+	var envelopes []*contracts.Envelope
+	newEnvelope := func(timestamp pdata.Timestamp, labels pdata.StringMap, dataPoint *contracts.DataPoint) {
+		envelopes = append(envelopes, metricDataPointToEnvelope(resource, instrumentationLibrary, timestamp, labels, dataPoint, logger))
+	}
+
+	switch metric.DataType() {
+	case pdata.MetricDataTypeIntGauge:
+		dps := metric.IntGauge().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			newEnvelope(dp.Timestamp(), dp.LabelsMap(), newMeasurement(metric.Name(), float64(dp.Value())))
+		}
+	case pdata.MetricDataTypeDoubleGauge:
+		dps := metric.DoubleGauge().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			newEnvelope(dp.Timestamp(), dp.LabelsMap(), newMeasurement(metric.Name(), dp.Value()))
+		}
+	case pdata.MetricDataTypeIntSum:
+		dps := metric.IntSum().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			newEnvelope(dp.Timestamp(), dp.LabelsMap(), newMeasurement(metric.Name(), float64(dp.Value())))
+		}
+	case pdata.MetricDataTypeDoubleSum:
+		dps := metric.DoubleSum().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			newEnvelope(dp.Timestamp(), dp.LabelsMap(), newMeasurement(metric.Name(), dp.Value()))
+		}
+	case pdata.MetricDataTypeIntHistogram:
+		dps := metric.IntHistogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			newEnvelope(dp.Timestamp(), dp.LabelsMap(), newAggregation(metric.Name(), float64(dp.Sum()), dp.Count()))
+		}
+	case pdata.MetricDataTypeHistogram:
+		dps := metric.Histogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			newEnvelope(dp.Timestamp(), dp.LabelsMap(), newAggregation(metric.Name(), dp.Sum(), dp.Count()))
+		}
+	case pdata.MetricDataTypeSummary:
+		dps := metric.Summary().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			dataPoint := newAggregation(metric.Name(), dp.Sum(), dp.Count())
+
+			// The 0 and 1 quantiles, when present, are the observed minimum and maximum
+			quantiles := dp.QuantileValues()
+			for j := 0; j < quantiles.Len(); j++ {
+				switch quantile := quantiles.At(j); quantile.Quantile() {
+				case 0:
+					dataPoint.Min = quantile.Value()
+				case 1:
+					dataPoint.Max = quantile.Value()
+				}
+			}
+
+			newEnvelope(dp.Timestamp(), dp.LabelsMap(), dataPoint)
+		}
+	}
+
+	return envelopes
+}
+
+func metricDataPointToEnvelope(
+	resource pdata.Resource,
+	instrumentationLibrary pdata.InstrumentationLibrary,
+	timestamp pdata.Timestamp,
+	labels pdata.StringMap,
+	dataPoint *contracts.DataPoint,
+	logger *zap.Logger) *contracts.Envelope {
+
+	metricData := contracts.NewMetricData()
+	metricData.Metrics = []*contracts.DataPoint{dataPoint}
+	metricData.Properties = make(map[string]string)
+
+	labels.Range(func(k string, v string) bool {
+		metricData.Properties[k] = v
+		return true
+	})
+
+	envelope := contracts.NewEnvelope()
+	envelope.Tags = make(map[string]string)
+	envelope.Time = toTime(timestamp).Format(time.RFC3339Nano)
+	envelope.Name = metricData.EnvelopeName("")
+
+	data := contracts.NewData()
+	data.BaseData = metricData
+	data.BaseType = metricData.BaseType()
+	envelope.Data = data
+
+	applyResourceToEnvelope(envelope, metricData.Properties, resource, instrumentationLibrary)
+
+	// Sanitize the base data, the envelope and envelope tags
+	sanitize(func() []string { return metricData.Sanitize() }, logger)
+	sanitize(func() []string { return envelope.Sanitize() }, logger)
+	sanitize(func() []string { return contracts.SanitizeTags(envelope.Tags) }, logger)
+
+	return envelope
+}
+
+func newMeasurement(name string, value float64) *contracts.DataPoint {
+	dataPoint := contracts.NewDataPoint()
+	dataPoint.Name = name
+	dataPoint.Value = value
+	dataPoint.Count = 1
+	return dataPoint
+}
+
+func newAggregation(name string, sum float64, count uint64) *contracts.DataPoint {
+	dataPoint := contracts.NewDataPoint()
+	dataPoint.Name = name
+	dataPoint.Kind = contracts.Aggregation
+	dataPoint.Value = sum
+	dataPoint.Count = int(count)
+	return dataPoint
+}
@@ -0,0 +1,146 @@
+// Copyright OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azuremonitorexporter
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/microsoft/ApplicationInsights-Go/appinsights/contracts"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.uber.org/zap"
+)
+
+const defaultMetricDataEnvelopeName = "Microsoft.ApplicationInsights.Metric"
+
+var defaultMetricTimestamp = pdata.TimestampFromTime(time.Unix(1600000000, 0))
+
+func TestGaugeToMeasurement(t *testing.T) {
+	metric := pdata.NewMetric()
+	metric.SetName("queue.length")
+	metric.SetDataType(pdata.MetricDataTypeIntGauge)
+	dp := metric.IntGauge().DataPoints().AppendEmpty()
+	dp.SetTimestamp(defaultMetricTimestamp)
+	dp.SetValue(42)
+	dp.LabelsMap().Insert("queue", "orders")
+
+	envelopes := metricToEnvelopes(getResource(), getInstrumentationLibrary(), metric, zap.NewNop())
+	require.Len(t, envelopes, 1)
+
+	metricData := commonMetricEnvelopeValidations(t, envelopes[0])
+	require.Len(t, metricData.Metrics, 1)
+	assert.Equal(t, "queue.length", metricData.Metrics[0].Name)
+	assert.Equal(t, contracts.Measurement, metricData.Metrics[0].Kind)
+	assert.Equal(t, float64(42), metricData.Metrics[0].Value)
+	assert.Equal(t, 1, metricData.Metrics[0].Count)
+	assert.Equal(t, "orders", metricData.Properties["queue"])
+}
+
+func TestSumDataPointsToSeparateEnvelopes(t *testing.T) {
+	metric := pdata.NewMetric()
+	metric.SetName("requests")
+	metric.SetDataType(pdata.MetricDataTypeDoubleSum)
+	for _, value := range []float64{1.5, 2.5} {
+		dp := metric.DoubleSum().DataPoints().AppendEmpty()
+		dp.SetTimestamp(defaultMetricTimestamp)
+		dp.SetValue(value)
+	}
+
+	envelopes := metricToEnvelopes(getResource(), getInstrumentationLibrary(), metric, zap.NewNop())
+	require.Len(t, envelopes, 2)
+	assert.Equal(t, 1.5, commonMetricEnvelopeValidations(t, envelopes[0]).Metrics[0].Value)
+	assert.Equal(t, 2.5, commonMetricEnvelopeValidations(t, envelopes[1]).Metrics[0].Value)
+}
+
+func TestHistogramToAggregation(t *testing.T) {
+	metric := pdata.NewMetric()
+	metric.SetName("latency")
+	metric.SetDataType(pdata.MetricDataTypeHistogram)
+	dp := metric.Histogram().DataPoints().AppendEmpty()
+	dp.SetTimestamp(defaultMetricTimestamp)
+	dp.SetCount(4)
+	dp.SetSum(10)
+
+	envelopes := metricToEnvelopes(getResource(), getInstrumentationLibrary(), metric, zap.NewNop())
+	require.Len(t, envelopes, 1)
+
+	dataPoint := commonMetricEnvelopeValidations(t, envelopes[0]).Metrics[0]
+	assert.Equal(t, contracts.Aggregation, dataPoint.Kind)
+	assert.Equal(t, float64(10), dataPoint.Value)
+	assert.Equal(t, 4, dataPoint.Count)
+}
+
+func TestSummaryToAggregationWithMinMax(t *testing.T) {
+	metric := pdata.NewMetric()
+	metric.SetName("latency")
+	metric.SetDataType(pdata.MetricDataTypeSummary)
+	dp := metric.Summary().DataPoints().AppendEmpty()
+	dp.SetTimestamp(defaultMetricTimestamp)
+	dp.SetCount(3)
+	dp.SetSum(6)
+	for quantile, value := range map[float64]float64{0: 1, 0.5: 2, 1: 3} {
+		qv := dp.QuantileValues().AppendEmpty()
+		qv.SetQuantile(quantile)
+		qv.SetValue(value)
+	}
+
+	envelopes := metricToEnvelopes(getResource(), getInstrumentationLibrary(), metric, zap.NewNop())
+	require.Len(t, envelopes, 1)
+
+	dataPoint := commonMetricEnvelopeValidations(t, envelopes[0]).Metrics[0]
+	assert.Equal(t, contracts.Aggregation, dataPoint.Kind)
+	assert.Equal(t, float64(6), dataPoint.Value)
+	assert.Equal(t, 3, dataPoint.Count)
+	assert.Equal(t, float64(1), dataPoint.Min)
+	assert.Equal(t, float64(3), dataPoint.Max)
+}
+
+// Tests the export onMetricData callback
+func TestExporterMetricDataCallback(t *testing.T) {
+	mockTransportChannel := getMockTransportChannel()
+	exporter := &metricExporter{defaultConfig, mockTransportChannel, zap.NewNop()}
+
+	metrics := pdata.NewMetrics()
+	assert.NoError(t, exporter.onMetricData(context.Background(), metrics))
+	mockTransportChannel.AssertNumberOfCalls(t, "Send", 0)
+
+	rm := metrics.ResourceMetrics().AppendEmpty()
+	getResource().CopyTo(rm.Resource())
+	ilm := rm.InstrumentationLibraryMetrics().AppendEmpty()
+	metric := ilm.Metrics().AppendEmpty()
+	metric.SetName("queue.length")
+	metric.SetDataType(pdata.MetricDataTypeIntGauge)
+	metric.IntGauge().DataPoints().AppendEmpty().SetValue(1)
+	metric.IntGauge().DataPoints().AppendEmpty().SetValue(2)
+
+	assert.NoError(t, exporter.onMetricData(context.Background(), metrics))
+	mockTransportChannel.AssertNumberOfCalls(t, "Send", 2)
+}
+
+func commonMetricEnvelopeValidations(t *testing.T, envelope *contracts.Envelope) *contracts.MetricData {
+	assert.Equal(t, defaultMetricDataEnvelopeName, envelope.Name)
+	assert.Equal(t, toTime(defaultMetricTimestamp).Format(time.RFC3339Nano), envelope.Time)
+	assert.Equal(t, defaultServiceNamespace+"."+defaultServiceName, envelope.Tags[contracts.CloudRole])
+	assert.Equal(t, defaultServiceInstance, envelope.Tags[contracts.CloudRoleInstance])
+
+	data := envelope.Data.(*contracts.Data)
+	assert.Equal(t, "MetricData", data.BaseType)
+	metricData := data.BaseData.(*contracts.MetricData)
+	assert.Equal(t, defaultInstrumentationLibraryName, metricData.Properties[instrumentationLibraryName])
+	return metricData
+}
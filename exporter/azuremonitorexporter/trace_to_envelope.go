@@ -113,8 +113,26 @@ func spanToEnvelope(
 	}
 
 	envelope.Data = data
-	resourceAttributes := resource.Attributes()
+	applyResourceToEnvelope(envelope, dataProperties, resource, instrumentationLibrary)
+	applySampleRate(envelope, attributeMap)
 
+	// Sanitize the base data, the envelope and envelope tags
+	sanitize(dataSanitizeFunc, logger)
+	sanitize(func() []string { return envelope.Sanitize() }, logger)
+	sanitize(func() []string { return contracts.SanitizeTags(envelope.Tags) }, logger)
+
+	return envelope, nil
+}
+
+// Copies the resource and instrumentation library details into the data properties and
+// sets the CloudRole and CloudRoleInstance envelope tags. Shared by all signals.
+func applyResourceToEnvelope(
+	envelope *contracts.Envelope,
+	dataProperties map[string]string,
+	resource pdata.Resource,
+	instrumentationLibrary pdata.InstrumentationLibrary) {
+
+	resourceAttributes := resource.Attributes()
 	// Copy all the resource labels into the base data properties. Resource values are always strings
 	resourceAttributes.Range(func(k string, v pdata.AttributeValue) bool {
 		dataProperties[k] = v.StringVal()
@@ -145,13 +163,35 @@ func spanToEnvelope(
 	if serviceInstance, exists := resourceAttributes.Get(conventions.AttributeServiceInstance); exists {
 		envelope.Tags[contracts.CloudRoleInstance] = serviceInstance.StringVal()
 	}
+}
+
+// Propagates the sampling percentage recorded by upstream samplers into the envelope, so that
+// Application Insights can extrapolate item counts. Values outside of (0, 100] are ignored.
+func applySampleRate(envelope *contracts.Envelope, attributes pdata.AttributeMap) {
+	value, exists := attributes.Get(attributeSampleRate)
+	if !exists {
+		return
+	}
 
-	// Sanitize the base data, the envelope and envelope tags
-	sanitize(dataSanitizeFunc, logger)
-	sanitize(func() []string { return envelope.Sanitize() }, logger)
-	sanitize(func() []string { return contracts.SanitizeTags(envelope.Tags) }, logger)
+	var rate float64
+	switch value.Type() {
+	case pdata.AttributeValueTypeDouble:
+		rate = value.DoubleVal()
+	case pdata.AttributeValueTypeInt:
+		rate = float64(value.IntVal())
+	case pdata.AttributeValueTypeString:
+		parsed, err := strconv.ParseFloat(value.StringVal(), 64)
+		if err != nil {
+			return
+		}
+		rate = parsed
+	default:
+		return
+	}
 
-	return envelope, nil
+	if rate > 0 && rate <= 100 {
+		envelope.SampleRate = rate
+	}
 }
 
 // Maps Server/Consumer Span to AppInsights RequestData
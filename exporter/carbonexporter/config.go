@@ -22,8 +22,9 @@ import (
 
 // Defaults for not specified configuration settings.
 const (
-	DefaultEndpoint    = "localhost:2003"
-	DefaultSendTimeout = 5 * time.Second
+	DefaultEndpoint     = "localhost:2003"
+	DefaultSendTimeout  = 5 * time.Second
+	DefaultMaxIdleConns = 10
 )
 
 // Config defines configuration for Carbon exporter.
@@ -38,4 +39,15 @@ type Config struct {
 	// data to the Carbon/Graphite backend.
 	// The default value is defined by the DefaultSendTimeout constant.
 	Timeout time.Duration `mapstructure:"timeout"`
+
+	// TaggedSeries controls if the metric labels are sent as Carbon tags, e.g.
+	// "metric;key=value", supported since Graphite 1.1. If false they are sent as
+	// nodes of plain metric paths instead, e.g. "metric.key.value".
+	// The default value is true.
+	TaggedSeries bool `mapstructure:"tagged_series"`
+
+	// MaxIdleConns is the maximum number of idle TCP connections kept open to
+	// the Carbon/Graphite backend to be reused by later sends. Zero means no
+	// limit. The default value is defined by the DefaultMaxIdleConns constant.
+	MaxIdleConns int `mapstructure:"max_idle_conns"`
 }
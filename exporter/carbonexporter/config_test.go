@@ -50,6 +50,8 @@ func TestLoadConfig(t *testing.T) {
 		ExporterSettings: config.NewExporterSettings(config.NewIDWithName(typeStr, "allsettings")),
 		Endpoint:         "localhost:8080",
 		Timeout:          10 * time.Second,
+		TaggedSeries:     false,
+		MaxIdleConns:     4,
 	}
 	assert.Equal(t, &expectedCfg, e1)
 
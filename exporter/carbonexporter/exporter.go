@@ -41,8 +41,13 @@ func newCarbonExporter(cfg *Config, params component.ExporterCreateSettings) (co
 		return nil, fmt.Errorf("%v exporter requires a positive timeout", cfg.ID())
 	}
 
+	if cfg.MaxIdleConns < 0 {
+		return nil, fmt.Errorf("%v exporter requires a non-negative max_idle_conns", cfg.ID())
+	}
+
 	sender := carbonSender{
-		connPool: newTCPConnPool(cfg.Endpoint, cfg.Timeout),
+		connPool:     newTCPConnPool(cfg.Endpoint, cfg.Timeout, cfg.MaxIdleConns),
+		taggedSeries: cfg.TaggedSeries,
 	}
 
 	return exporterhelper.NewMetricsExporter(
@@ -56,7 +61,8 @@ func newCarbonExporter(cfg *Config, params component.ExporterCreateSettings) (co
 // connections into an implementations of exporterhelper.PushMetricsData so
 // the exporter can leverage the helper and get consistent observability.
 type carbonSender struct {
-	connPool *connPool
+	connPool     *connPool
+	taggedSeries bool
 }
 
 func (cs *carbonSender) pushMetricsData(_ context.Context, md pdata.Metrics) error {
@@ -67,7 +73,7 @@ func (cs *carbonSender) pushMetricsData(_ context.Context, md pdata.Metrics) err
 		emsr.Node, emsr.Resource, emsr.Metrics = internaldata.ResourceMetricsToOC(rms.At(i))
 		mds = append(mds, emsr)
 	}
-	lines, _, _ := metricDataToPlaintext(mds)
+	lines, _, _ := metricDataToPlaintext(mds, cs.taggedSeries)
 
 	if _, err := cs.connPool.Write([]byte(lines)); err != nil {
 		// Use the sum of converted and dropped since the write failed for all.
@@ -82,63 +88,88 @@ func (cs *carbonSender) Shutdown(context.Context) error {
 	return nil
 }
 
+const (
+	// minReconnectBackoff and maxReconnectBackoff bound the time the pool waits
+	// before dialing again after failing to connect to the endpoint.
+	minReconnectBackoff = 100 * time.Millisecond
+	maxReconnectBackoff = 30 * time.Second
+)
+
 // connPool is a very simple implementation of a pool of net.TCPConn instances.
 // The implementation hides the pool and exposes a Write and Close methods.
 // It leverages the prior art from SignalFx Gateway (see
 // https://github.com/signalfx/gateway/blob/master/protocol/carbon/conn_pool.go
 // but not its implementation).
 //
-// It keeps a unbounded "stack" of TCPConn instances always "popping" the most
-// recently returned to the pool. There is no accounting to terminating old
-// unused connections as that was the case on the prior art mentioned above.
+// It keeps a "stack" of up to maxIdleConns TCPConn instances always "popping"
+// the most recently returned to the pool, connections returned to a full pool
+// are closed. After failing to connect, it backs off exponentially from
+// minReconnectBackoff up to maxReconnectBackoff before dialing again, failing
+// the writes meanwhile instead of hammering an unavailable endpoint.
 type connPool struct {
-	mtx      sync.Mutex
-	conns    []*net.TCPConn
-	endpoint string
-	timeout  time.Duration
+	mtx          sync.Mutex
+	conns        []*net.TCPConn
+	endpoint     string
+	timeout      time.Duration
+	maxIdleConns int
+
+	// The state of the reconnect backoff, protected by mtx.
+	backoff  time.Duration
+	nextDial time.Time
+	dialErr  error
 }
 
 func newTCPConnPool(
 	endpoint string,
 	timeout time.Duration,
+	maxIdleConns int,
 ) *connPool {
 	return &connPool{
-		endpoint: endpoint,
-		timeout:  timeout,
+		endpoint:     endpoint,
+		timeout:      timeout,
+		maxIdleConns: maxIdleConns,
 	}
 }
 
 func (cp *connPool) Write(bytes []byte) (int, error) {
-	var conn *net.TCPConn
-	var err error
-
-	// The deferred function below is what puts back connections on the pool.
-	defer func() {
-		if err == nil {
-			cp.mtx.Lock()
-			cp.conns = append(cp.conns, conn)
-			cp.mtx.Unlock()
-		} else {
-			if conn != nil {
-				conn.Close()
-			}
-		}
-	}()
-
 	start := time.Now()
 	cp.mtx.Lock()
+	var conn *net.TCPConn
 	lastIdx := len(cp.conns) - 1
 	if lastIdx >= 0 {
 		conn = cp.conns[lastIdx]
 		cp.conns = cp.conns[0:lastIdx]
 	}
 	cp.mtx.Unlock()
-	if conn == nil {
+
+	pooled := conn != nil
+	if !pooled {
+		var err error
 		if conn, err = cp.createTCPConn(); err != nil {
 			return 0, err
 		}
 	}
 
+	n, err := cp.write(conn, bytes, start)
+	if err != nil && pooled && n == 0 {
+		// The server may have closed the connection while it was idle on the
+		// pool, reconnect and try once more before failing.
+		conn.Close()
+		if conn, err = cp.createTCPConn(); err != nil {
+			return 0, err
+		}
+		n, err = cp.write(conn, bytes, start)
+	}
+	if err != nil {
+		conn.Close()
+		return n, err
+	}
+
+	cp.put(conn)
+	return n, nil
+}
+
+func (cp *connPool) write(conn *net.TCPConn, bytes []byte, start time.Time) (int, error) {
 	// There is no way to do a call equivalent to recvfrom with an empty buffer
 	// to check if the connection was terminated (if the size of the buffer is
 	// 0 the Read call doesn't call lower level). So due to buffer sizes it is
@@ -158,13 +189,23 @@ func (cp *connPool) Write(bytes []byte) (int, error) {
 	// needed in some scenarios the workaround should be validated on other
 	// platforms and offered as a configuration setting.
 
-	if err = conn.SetWriteDeadline(start.Add(cp.timeout)); err != nil {
+	if err := conn.SetWriteDeadline(start.Add(cp.timeout)); err != nil {
 		return 0, err
 	}
 
-	var n int
-	n, err = conn.Write(bytes)
-	return n, err
+	return conn.Write(bytes)
+}
+
+// put returns conn to the pool, closing it if the pool is full.
+func (cp *connPool) put(conn *net.TCPConn) {
+	cp.mtx.Lock()
+	defer cp.mtx.Unlock()
+
+	if cp.maxIdleConns > 0 && len(cp.conns) >= cp.maxIdleConns {
+		conn.Close()
+		return
+	}
+	cp.conns = append(cp.conns, conn)
 }
 
 func (cp *connPool) Close() {
@@ -178,9 +219,34 @@ func (cp *connPool) Close() {
 }
 
 func (cp *connPool) createTCPConn() (*net.TCPConn, error) {
+	now := time.Now()
+	cp.mtx.Lock()
+	if now.Before(cp.nextDial) {
+		err := cp.dialErr
+		nextDial := cp.nextDial
+		cp.mtx.Unlock()
+		return nil, fmt.Errorf("not reconnecting to %s until %v: %w", cp.endpoint, nextDial.Format(time.RFC3339Nano), err)
+	}
+	cp.mtx.Unlock()
+
 	c, err := net.DialTimeout("tcp", cp.endpoint, cp.timeout)
+
+	cp.mtx.Lock()
+	defer cp.mtx.Unlock()
 	if err != nil {
+		cp.backoff *= 2
+		if cp.backoff < minReconnectBackoff {
+			cp.backoff = minReconnectBackoff
+		}
+		if cp.backoff > maxReconnectBackoff {
+			cp.backoff = maxReconnectBackoff
+		}
+		cp.nextDial = time.Now().Add(cp.backoff)
+		cp.dialErr = err
 		return nil, err
 	}
-	return c.(*net.TCPConn), err
+	cp.backoff = 0
+	cp.nextDial = time.Time{}
+	cp.dialErr = nil
+	return c.(*net.TCPConn), nil
 }
@@ -220,7 +220,7 @@ func Test_connPool_Concurrency(t *testing.T) {
 
 	startCh := make(chan struct{})
 
-	cp := newTCPConnPool(addr, 500*time.Millisecond)
+	cp := newTCPConnPool(addr, 500*time.Millisecond, DefaultMaxIdleConns)
 	sender := carbonSender{connPool: cp}
 	ctx := context.Background()
 	md := generateLargeBatch()
@@ -282,6 +282,58 @@ func Test_connPool_Concurrency(t *testing.T) {
 	recvWG.Wait()
 }
 
+func Test_connPool_MaxIdleConns(t *testing.T) {
+	addr := testutil.GetAvailableLocalAddress(t)
+	laddr, err := net.ResolveTCPAddr("tcp", addr)
+	require.NoError(t, err)
+	ln, err := net.ListenTCP("tcp", laddr)
+	require.NoError(t, err)
+	defer ln.Close()
+
+	cp := newTCPConnPool(addr, 500*time.Millisecond, 1)
+	defer cp.Close()
+
+	conn0, err := cp.createTCPConn()
+	require.NoError(t, err)
+	conn1, err := cp.createTCPConn()
+	require.NoError(t, err)
+
+	cp.put(conn0)
+	cp.put(conn1)
+	assert.Equal(t, []*net.TCPConn{conn0}, cp.conns)
+	// The connection returned to the full pool was closed.
+	assert.Error(t, conn1.SetWriteDeadline(time.Now()))
+}
+
+func Test_connPool_ReconnectBackoff(t *testing.T) {
+	addr := testutil.GetAvailableLocalAddress(t)
+	cp := newTCPConnPool(addr, 500*time.Millisecond, DefaultMaxIdleConns)
+	defer cp.Close()
+
+	_, err := cp.Write([]byte("test 1 1574092046\n"))
+	require.Error(t, err)
+	assert.Equal(t, minReconnectBackoff, cp.backoff)
+
+	// Writes fail without dialing until the backoff elapsed.
+	_, err = cp.Write([]byte("test 1 1574092046\n"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not reconnecting to "+addr)
+	assert.Equal(t, minReconnectBackoff, cp.backoff)
+
+	laddr, err := net.ResolveTCPAddr("tcp", addr)
+	require.NoError(t, err)
+	ln, err := net.ListenTCP("tcp", laddr)
+	require.NoError(t, err)
+	defer ln.Close()
+
+	assert.Eventually(t, func() bool {
+		_, err = cp.Write([]byte("test 1 1574092046\n"))
+		return err == nil
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Zero(t, cp.backoff)
+	assert.Len(t, cp.conns, 1)
+}
+
 func generateLargeBatch() pdata.Metrics {
 	var metrics []*metricspb.Metric
 	ts := time.Now()
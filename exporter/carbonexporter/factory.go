@@ -40,6 +40,8 @@ func createDefaultConfig() config.Exporter {
 		ExporterSettings: config.NewExporterSettings(config.NewID(typeStr)),
 		Endpoint:         DefaultEndpoint,
 		Timeout:          DefaultSendTimeout,
+		TaggedSeries:     true,
+		MaxIdleConns:     DefaultMaxIdleConns,
 	}
 }
 
@@ -18,6 +18,7 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"unicode"
 
 	agentmetricspb "github.com/census-instrumentation/opencensus-proto/gen-go/agent/metrics/v1"
 	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
@@ -27,6 +28,9 @@ const (
 	// sanitizedRune is used to replace any invalid char per Carbon format.
 	sanitizedRune = '_'
 
+	// Separator of the nodes of a plain, untagged, Carbon metric path.
+	pathNodeSeparator = "."
+
 	// Tag related constants per Carbon plaintext protocol.
 	tagPrefix                 = ";"
 	tagKeyValueSeparator      = "="
@@ -34,14 +38,12 @@ const (
 	tagValueNotSetPlaceholder = "<null>"
 
 	// Constants used when converting from distribution metrics to Carbon format.
-	distributionBucketSuffix             = ".bucket"
-	distributionUpperBoundTagKey         = "upper_bound"
-	distributionUpperBoundTagBeforeValue = tagPrefix + distributionUpperBoundTagKey + tagKeyValueSeparator
+	distributionBucketSuffix     = ".bucket"
+	distributionUpperBoundTagKey = "upper_bound"
 
 	// Constants used when converting from summary metrics to Carbon format.
-	summaryQuantileSuffix         = ".quantile"
-	summaryQuantileTagKey         = "quantile"
-	summaryQuantileTagBeforeValue = tagPrefix + summaryQuantileTagKey + tagKeyValueSeparator
+	summaryQuantileSuffix = ".quantile"
+	summaryQuantileTagKey = "quantile"
 
 	// Suffix to be added to original metric name for a Carbon metric representing
 	// a count metric for either distribution or summary metrics.
@@ -72,6 +74,13 @@ const (
 // <tag> is of the form "key=val", where key can contain any char except ";!^=" and
 // val can contain any char except ";~".
 //
+// If taggedSeries is false, for Carbon versions without tag support, the tags are
+// instead appended to the metric name as path nodes:
+//
+// 	<metric_name>[.key0.val0...keyN.valN]
+//
+// with any '.', ';' or whitespace in the keys and values replaced by '_'.
+//
 // The <value> is the textual representation of the metric value.
 //
 // The <timestamp> is the Unix time text of when the measurement was made.
@@ -81,7 +90,7 @@ const (
 // 	  a single Carbon metric.
 //  - number of time series successfully converted to carbon.
 // 	- number of time series that could not be converted to Carbon.
-func metricDataToPlaintext(mds []*agentmetricspb.ExportMetricsServiceRequest) (string, int, int) {
+func metricDataToPlaintext(mds []*agentmetricspb.ExportMetricsServiceRequest, taggedSeries bool) (string, int, int) {
 	if len(mds) == 0 {
 		return "", 0, 0
 	}
@@ -123,18 +132,18 @@ func metricDataToPlaintext(mds []*agentmetricspb.ExportMetricsServiceRequest) (s
 					switch pv := point.Value.(type) {
 
 					case *metricspb.Point_Int64Value:
-						path := buildPath(name, tagKeys, ts.LabelValues)
+						path := buildPath(name, tagKeys, ts.LabelValues, taggedSeries)
 						valueStr := formatInt64(pv.Int64Value)
 						sb.WriteString(buildLine(path, valueStr, timestampStr))
 
 					case *metricspb.Point_DoubleValue:
-						path := buildPath(name, tagKeys, ts.LabelValues)
+						path := buildPath(name, tagKeys, ts.LabelValues, taggedSeries)
 						valueStr := formatFloatForValue(pv.DoubleValue)
 						sb.WriteString(buildLine(path, valueStr, timestampStr))
 
 					case *metricspb.Point_DistributionValue:
 						err := buildDistributionIntoBuilder(
-							&sb, name, tagKeys, ts.LabelValues, timestampStr, pv.DistributionValue)
+							&sb, name, tagKeys, ts.LabelValues, taggedSeries, timestampStr, pv.DistributionValue)
 						if err != nil {
 							// TODO: log error info
 							numTimeseriesDropped++
@@ -142,7 +151,7 @@ func metricDataToPlaintext(mds []*agentmetricspb.ExportMetricsServiceRequest) (s
 
 					case *metricspb.Point_SummaryValue:
 						err := buildSummaryIntoBuilder(
-							&sb, name, tagKeys, ts.LabelValues, timestampStr, pv.SummaryValue)
+							&sb, name, tagKeys, ts.LabelValues, taggedSeries, timestampStr, pv.SummaryValue)
 						if err != nil {
 							// TODO: log error info
 							numTimeseriesDropped++
@@ -175,6 +184,7 @@ func buildDistributionIntoBuilder(
 	metricName string,
 	tagKeys []string,
 	labelValues []*metricspb.LabelValue,
+	taggedSeries bool,
 	timestampStr string,
 	distributionValue *metricspb.DistributionValue,
 ) error {
@@ -183,6 +193,7 @@ func buildDistributionIntoBuilder(
 		metricName,
 		tagKeys,
 		labelValues,
+		taggedSeries,
 		distributionValue.GetCount(),
 		distributionValue.GetSum(),
 		timestampStr)
@@ -201,10 +212,10 @@ func buildDistributionIntoBuilder(
 	}
 	carbonBounds[len(carbonBounds)-1] = infinityCarbonValue
 
-	bucketPath := buildPath(metricName+distributionBucketSuffix, tagKeys, labelValues)
+	bucketPath := buildPath(metricName+distributionBucketSuffix, tagKeys, labelValues, taggedSeries)
 	for i, bucket := range distributionValue.Buckets {
 		sb.WriteString(buildLine(
-			bucketPath+distributionUpperBoundTagBeforeValue+carbonBounds[i],
+			appendTag(bucketPath, distributionUpperBoundTagKey, carbonBounds[i], taggedSeries),
 			formatInt64(bucket.Count),
 			timestampStr))
 	}
@@ -229,6 +240,7 @@ func buildSummaryIntoBuilder(
 	metricName string,
 	tagKeys []string,
 	labelValues []*metricspb.LabelValue,
+	taggedSeries bool,
 	timestampStr string,
 	summaryValue *metricspb.SummaryValue,
 ) error {
@@ -237,6 +249,7 @@ func buildSummaryIntoBuilder(
 		metricName,
 		tagKeys,
 		labelValues,
+		taggedSeries,
 		summaryValue.GetCount().GetValue(),
 		summaryValue.GetSum().GetValue(),
 		timestampStr)
@@ -248,10 +261,10 @@ func buildSummaryIntoBuilder(
 			metricName)
 	}
 
-	quantilePath := buildPath(metricName+summaryQuantileSuffix, tagKeys, labelValues)
+	quantilePath := buildPath(metricName+summaryQuantileSuffix, tagKeys, labelValues, taggedSeries)
 	for _, quantile := range percentiles {
 		sb.WriteString(buildLine(
-			quantilePath+summaryQuantileTagBeforeValue+formatFloatForLabel(quantile.GetPercentile()),
+			appendTag(quantilePath, summaryQuantileTagKey, formatFloatForLabel(quantile.GetPercentile()), taggedSeries),
 			formatFloatForValue(quantile.GetValue()),
 			timestampStr))
 	}
@@ -272,16 +285,17 @@ func buildCountAndSumIntoBuilder(
 	metricName string,
 	tagKeys []string,
 	labelValues []*metricspb.LabelValue,
+	taggedSeries bool,
 	count int64,
 	sum float64,
 	timestampStr string,
 ) {
 	// Build count and sum metrics.
-	countPath := buildPath(metricName+countSuffix, tagKeys, labelValues)
+	countPath := buildPath(metricName+countSuffix, tagKeys, labelValues, taggedSeries)
 	valueStr := formatInt64(count)
 	sb.WriteString(buildLine(countPath, valueStr, timestampStr))
 
-	sumPath := buildPath(metricName, tagKeys, labelValues)
+	sumPath := buildPath(metricName, tagKeys, labelValues, taggedSeries)
 	valueStr = formatFloatForValue(sum)
 	sb.WriteString(buildLine(sumPath, valueStr, timestampStr))
 }
@@ -293,6 +307,7 @@ func buildPath(
 	name string,
 	tagKeys []string,
 	labelValues []*metricspb.LabelValue,
+	taggedSeries bool,
 ) string {
 
 	if len(tagKeys) == 0 {
@@ -317,12 +332,21 @@ func buildPath(
 			value = sanitizeTagValue(value)
 		}
 
-		sb.WriteString(tagPrefix + tagKeys[i] + tagKeyValueSeparator + value)
+		sb.WriteString(appendTag("", tagKeys[i], value, taggedSeries))
 	}
 
 	return sb.String()
 }
 
+// appendTag appends the tag key=value to path, as a Carbon tag if taggedSeries
+// is true, or as the path nodes ".key.value" otherwise.
+func appendTag(path, key, value string, taggedSeries bool) string {
+	if taggedSeries {
+		return path + tagPrefix + key + tagKeyValueSeparator + value
+	}
+	return path + pathNodeSeparator + sanitizePathNode(key) + pathNodeSeparator + sanitizePathNode(value)
+}
+
 // buildSanitizedTagKeys builds an slice with the sanitized label keys to be
 // used as tag keys on the Carbon metric.
 func buildSanitizedTagKeys(labelKeys []*metricspb.LabelKey) []string {
@@ -375,6 +399,19 @@ func sanitizeTagValue(value string) string {
 	return strings.Map(mapRune, value)
 }
 
+// sanitizePathNode removes any character that would split a plain Carbon path
+// node or terminate the path, i.e. '.', ';' and whitespace.
+func sanitizePathNode(node string) string {
+	mapRune := func(r rune) rune {
+		if r == '.' || r == ';' || unicode.IsSpace(r) {
+			return sanitizedRune
+		}
+		return r
+	}
+
+	return strings.Map(mapRune, node)
+}
+
 // Formats a float64 per Prometheus label value. This is an attempt to keep other
 // the label values with different formats of metrics.
 func formatFloatForLabel(f float64) string {
@@ -132,12 +132,47 @@ func Test_buildPath(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := buildPath(tt.args.name, tt.args.tagKeys, tt.args.labelValues)
+			got := buildPath(tt.args.name, tt.args.tagKeys, tt.args.labelValues, true)
 			assert.Equal(t, tt.want, got)
 		})
 	}
 }
 
+func Test_buildPath_plain(t *testing.T) {
+	labelValues := []*metricspb.LabelValue{
+		{Value: "val.0 x", HasValue: true},
+		{Value: "", HasValue: false},
+	}
+	got := buildPath("plain.path", []string{"key;0", "k1"}, labelValues, false)
+	assert.Equal(t, "plain.path.key_0.val_0_x.k1."+tagValueNotSetPlaceholder, got)
+	assert.Equal(t, "plain.path", buildPath("plain.path", nil, nil, false))
+}
+
+func Test_metricDataToPlaintext_plain(t *testing.T) {
+	tsUnix := time.Unix(1574092046, 0)
+	mds := []*agentmetricspb.ExportMetricsServiceRequest{
+		{
+			Metrics: []*metricspb.Metric{
+				metricstestutil.Cumulative(
+					"distrib",
+					[]string{"k0"},
+					metricstestutil.Timeseries(
+						tsUnix,
+						[]string{"v0"},
+						metricstestutil.DistPt(tsUnix, []float64{1.5}, []int64{4, 2}))),
+			},
+		},
+	}
+
+	gotLines, gotNumConvertedTimeseries, gotNumDroppedTimeseries := metricDataToPlaintext(mds, false)
+	assert.Equal(t, 1, gotNumConvertedTimeseries)
+	assert.Equal(t, 0, gotNumDroppedTimeseries)
+	assert.Equal(t, "distrib.count.k0.v0 6 1574092046\n"+
+		"distrib.k0.v0 3 1574092046\n"+
+		"distrib.bucket.k0.v0.upper_bound.1_5 4 1574092046\n"+
+		"distrib.bucket.k0.v0.upper_bound.inf 2 1574092046\n", gotLines)
+}
+
 func Test_metricDataToPlaintext(t *testing.T) {
 
 	keys := []string{"k0", "k1"}
@@ -278,7 +313,7 @@ func Test_metricDataToPlaintext(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			gotLines, gotNunConvertedTimeseries, gotNumDroppedTimeseries := metricDataToPlaintext(tt.metricsDataFn())
+			gotLines, gotNunConvertedTimeseries, gotNumDroppedTimeseries := metricDataToPlaintext(tt.metricsDataFn(), true)
 			assert.Equal(t, tt.wantNumConvertedTimeseries, gotNunConvertedTimeseries)
 			assert.Equal(t, tt.wantNumDroppedTimeseries, gotNumDroppedTimeseries)
 			got := strings.Split(gotLines, "\n")
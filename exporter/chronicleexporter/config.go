@@ -0,0 +1,139 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chronicleexporter
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+)
+
+const (
+	// ModeUnstructured sends the logs as raw log lines, parsed by Chronicle.
+	ModeUnstructured = "unstructured"
+	// ModeUDM sends the logs as Unified Data Model events.
+	ModeUDM = "udm"
+)
+
+// Config defines configuration for the Google Chronicle exporter.
+type Config struct {
+	config.ExporterSettings       `mapstructure:",squash"`
+	confighttp.HTTPClientSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct.
+	exporterhelper.QueueSettings  `mapstructure:"sending_queue"`
+	exporterhelper.RetrySettings  `mapstructure:"retry_on_failure"`
+
+	// Mode is the kind of entries sent, either "unstructured" or "udm".
+	// Default is "unstructured".
+	Mode string `mapstructure:"mode"`
+
+	// CustomerID is the unique identifier of the Chronicle instance.
+	CustomerID string `mapstructure:"customer_id"`
+
+	// CredentialsFile is the path of the JSON key of the service account the
+	// logs are sent with. Either CredentialsFile or Credentials is required.
+	CredentialsFile string `mapstructure:"credentials_file"`
+
+	// Credentials is the JSON key of the service account the logs are sent with.
+	Credentials string `mapstructure:"credentials"`
+
+	// LogType is the Chronicle log type of the entries, used to pick the
+	// parser, e.g. "WINDOWS_DNS". Required in "unstructured" mode.
+	LogType string `mapstructure:"log_type"`
+
+	// LogTextField is the field of the log records sent as raw log line in
+	// "unstructured" mode. Default is "body".
+	LogTextField string `mapstructure:"log_text_field"`
+
+	// EventType is the UDM event type of the events sent in "udm" mode.
+	// Default is "GENERIC_EVENT".
+	EventType string `mapstructure:"event_type"`
+
+	// ProductName and VendorName are set in the metadata of the events sent
+	// in "udm" mode.
+	ProductName string `mapstructure:"product_name"`
+	VendorName  string `mapstructure:"vendor_name"`
+
+	// UDMMapping maps the fields of the UDM events to the fields of the log
+	// records in "udm" mode.
+	UDMMapping []UDMFieldMapping `mapstructure:"udm_mapping"`
+}
+
+// UDMFieldMapping maps a field of the UDM events to a field of the log records.
+type UDMFieldMapping struct {
+	// UDMField is the dot separated path of the UDM field, e.g. "principal.hostname".
+	UDMField string `mapstructure:"udm_field"`
+
+	// Field is the field of the log records: "body", "severity_text",
+	// "severity_number", "name", "trace_id", "span_id", "flags", "attributes",
+	// "resource", or a single attribute with "attributes.<key>" or
+	// "resource.<key>".
+	Field string `mapstructure:"field"`
+}
+
+func (c *Config) validate() error {
+	if c.CustomerID == "" {
+		return errors.New(`"customer_id" is required`)
+	}
+	if c.CredentialsFile == "" && c.Credentials == "" {
+		return errors.New(`either "credentials_file" or "credentials" is required`)
+	}
+	if c.CredentialsFile != "" && c.Credentials != "" {
+		return errors.New(`only one of "credentials_file" and "credentials" can be set`)
+	}
+
+	switch c.Mode {
+	case ModeUnstructured:
+		if c.LogType == "" {
+			return errors.New(`"log_type" is required in "unstructured" mode`)
+		}
+		if err := validateField(c.LogTextField); err != nil {
+			return fmt.Errorf(`invalid "log_text_field": %w`, err)
+		}
+	case ModeUDM:
+		if c.EventType == "" {
+			return errors.New(`"event_type" is required in "udm" mode`)
+		}
+		for _, m := range c.UDMMapping {
+			if err := validateUDMField(m.UDMField); err != nil {
+				return err
+			}
+			if err := validateField(m.Field); err != nil {
+				return fmt.Errorf("invalid field of UDM field %q of \"udm_mapping\": %w", m.UDMField, err)
+			}
+		}
+	default:
+		return fmt.Errorf(`"mode" must be either %q or %q`, ModeUnstructured, ModeUDM)
+	}
+	return nil
+}
+
+func validateUDMField(path string) error {
+	if path == "" {
+		return errors.New(`"udm_field" is required in "udm_mapping"`)
+	}
+	for _, segment := range strings.Split(path, ".") {
+		if segment == "" {
+			return fmt.Errorf("UDM field %q of \"udm_mapping\" has an empty segment", path)
+		}
+	}
+	if path == eventTimestampPath {
+		return fmt.Errorf("UDM field %q of \"udm_mapping\" can't be mapped", path)
+	}
+	return nil
+}
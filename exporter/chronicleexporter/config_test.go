@@ -0,0 +1,206 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chronicleexporter
+
+import (
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/config/configtest"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	assert.Nil(t, err)
+
+	factory := NewFactory()
+	factories.Exporters[typeStr] = factory
+	cfg, err := configtest.LoadConfigAndValidate(path.Join(".", "testdata", "config.yaml"), factories)
+
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	assert.Equal(t, 2, len(cfg.Exporters))
+
+	unstructuredCfg := factory.CreateDefaultConfig().(*Config)
+	unstructuredCfg.CustomerID = "00000000-0000-0000-0000-000000000000"
+	unstructuredCfg.CredentialsFile = "/etc/chronicle/credentials.json"
+	unstructuredCfg.LogType = "WINDOWS_DNS"
+	assert.Equal(t, unstructuredCfg, cfg.Exporters[config.NewID(typeStr)])
+
+	assert.Equal(t, &Config{
+		ExporterSettings: config.NewExporterSettings(config.NewIDWithName(typeStr, "udm")),
+		HTTPClientSettings: confighttp.HTTPClientSettings{
+			Endpoint: "https://europe-malachiteingestion-pa.googleapis.com",
+			Timeout:  10 * time.Second,
+		},
+		RetrySettings:   exporterhelper.DefaultRetrySettings(),
+		QueueSettings:   exporterhelper.DefaultQueueSettings(),
+		Mode:            ModeUDM,
+		CustomerID:      "00000000-0000-0000-0000-000000000000",
+		CredentialsFile: "/etc/chronicle/credentials.json",
+		LogTextField:    defaultLogTextField,
+		EventType:       "USER_LOGIN",
+		ProductName:     "my-app",
+		VendorName:      "my-company",
+		UDMMapping: []UDMFieldMapping{
+			{UDMField: "principal.hostname", Field: "resource.host.name"},
+			{UDMField: "target.user.userid", Field: "attributes.user.name"},
+			{UDMField: "metadata.description", Field: "body"},
+		},
+	}, cfg.Exporters[config.NewIDWithName(typeStr, "udm")])
+}
+
+func TestValidateConfig(t *testing.T) {
+	unstructured := func() *Config {
+		cfg := createDefaultConfig().(*Config)
+		cfg.CustomerID = "customer"
+		cfg.Credentials = "{}"
+		cfg.LogType = "WINDOWS_DNS"
+		return cfg
+	}
+	udm := func() *Config {
+		cfg := unstructured()
+		cfg.Mode = ModeUDM
+		cfg.LogType = ""
+		cfg.UDMMapping = []UDMFieldMapping{{UDMField: "principal.hostname", Field: "resource.host.name"}}
+		return cfg
+	}
+
+	tests := []struct {
+		name    string
+		cfg     *Config
+		wantErr string
+	}{
+		{name: "unstructured", cfg: unstructured()},
+		{name: "udm", cfg: udm()},
+		{
+			name: "invalid mode",
+			cfg: func() *Config {
+				cfg := unstructured()
+				cfg.Mode = "raw"
+				return cfg
+			}(),
+			wantErr: `"mode" must be either "unstructured" or "udm"`,
+		},
+		{
+			name: "missing customer",
+			cfg: func() *Config {
+				cfg := unstructured()
+				cfg.CustomerID = ""
+				return cfg
+			}(),
+			wantErr: `"customer_id" is required`,
+		},
+		{
+			name: "missing credentials",
+			cfg: func() *Config {
+				cfg := unstructured()
+				cfg.Credentials = ""
+				return cfg
+			}(),
+			wantErr: `either "credentials_file" or "credentials" is required`,
+		},
+		{
+			name: "both credentials",
+			cfg: func() *Config {
+				cfg := unstructured()
+				cfg.CredentialsFile = "credentials.json"
+				return cfg
+			}(),
+			wantErr: `only one of "credentials_file" and "credentials" can be set`,
+		},
+		{
+			name: "unstructured without log type",
+			cfg: func() *Config {
+				cfg := unstructured()
+				cfg.LogType = ""
+				return cfg
+			}(),
+			wantErr: `"log_type" is required in "unstructured" mode`,
+		},
+		{
+			name: "unknown log text field",
+			cfg: func() *Config {
+				cfg := unstructured()
+				cfg.LogTextField = "message"
+				return cfg
+			}(),
+			wantErr: `invalid "log_text_field": unknown field "message"`,
+		},
+		{
+			name: "udm without event type",
+			cfg: func() *Config {
+				cfg := udm()
+				cfg.EventType = ""
+				return cfg
+			}(),
+			wantErr: `"event_type" is required in "udm" mode`,
+		},
+		{
+			name: "mapping of event timestamp",
+			cfg: func() *Config {
+				cfg := udm()
+				cfg.UDMMapping = []UDMFieldMapping{{UDMField: "metadata.event_timestamp", Field: "body"}}
+				return cfg
+			}(),
+			wantErr: `UDM field "metadata.event_timestamp" of "udm_mapping" can't be mapped`,
+		},
+		{
+			name: "mapping with empty segment",
+			cfg: func() *Config {
+				cfg := udm()
+				cfg.UDMMapping = []UDMFieldMapping{{UDMField: "principal..hostname", Field: "body"}}
+				return cfg
+			}(),
+			wantErr: `UDM field "principal..hostname" of "udm_mapping" has an empty segment`,
+		},
+		{
+			name: "mapping of unknown field",
+			cfg: func() *Config {
+				cfg := udm()
+				cfg.UDMMapping = []UDMFieldMapping{{UDMField: "principal.hostname", Field: "host"}}
+				return cfg
+			}(),
+			wantErr: `invalid field of UDM field "principal.hostname" of "udm_mapping": unknown field "host"`,
+		},
+		{
+			name: "mapping without UDM field",
+			cfg: func() *Config {
+				cfg := udm()
+				cfg.UDMMapping = []UDMFieldMapping{{Field: "body"}}
+				return cfg
+			}(),
+			wantErr: `"udm_field" is required in "udm_mapping"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,228 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chronicleexporter
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+	"go.uber.org/zap"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+)
+
+const (
+	ingestionScope = "https://www.googleapis.com/auth/malachite-ingestion"
+
+	unstructuredPath = "/v2/unstructuredlogentries:batchCreate"
+	udmPath          = "/v2/udmevents:batchCreate"
+
+	// The Ingestion API accepts up to 1MB per request.
+	maxPayloadBytes = 1000 * 1000
+)
+
+type chronicleExporter struct {
+	config          *Config
+	logger          *zap.Logger
+	client          *http.Client
+	url             string
+	maxPayloadBytes int
+	now             func() time.Time
+}
+
+func newExporter(config *Config, logger *zap.Logger) *chronicleExporter {
+	path := unstructuredPath
+	if config.Mode == ModeUDM {
+		path = udmPath
+	}
+	return &chronicleExporter{
+		config:          config,
+		logger:          logger,
+		url:             strings.TrimSuffix(config.Endpoint, "/") + path,
+		maxPayloadBytes: maxPayloadBytes,
+		now:             time.Now,
+	}
+}
+
+func (e *chronicleExporter) start(ctx context.Context, host component.Host) error {
+	credentials := []byte(e.config.Credentials)
+	if e.config.CredentialsFile != "" {
+		var err error
+		if credentials, err = ioutil.ReadFile(e.config.CredentialsFile); err != nil {
+			return fmt.Errorf("failed to read credentials file: %w", err)
+		}
+	}
+
+	client, err := e.config.HTTPClientSettings.ToClient(host.GetExtensions())
+	if err != nil {
+		return err
+	}
+
+	// Tokens are requested and refreshed outside of the exported requests.
+	tokenCtx := context.WithValue(context.Background(), oauth2.HTTPClient, &http.Client{Timeout: e.config.Timeout})
+	creds, err := google.CredentialsFromJSON(tokenCtx, credentials, ingestionScope)
+	if err != nil {
+		return fmt.Errorf("failed to load credentials: %w", err)
+	}
+	client.Transport = &oauth2.Transport{
+		Source: creds.TokenSource,
+		Base:   client.Transport,
+	}
+
+	e.client = client
+	return nil
+}
+
+func (e *chronicleExporter) pushLogs(ctx context.Context, ld pdata.Logs) error {
+	payloads, dropped := e.logsToPayloads(ld)
+	if dropped > 0 {
+		e.logger.Warn("Dropped log records larger than the maximum payload size or without log text",
+			zap.Int("dropped", dropped),
+			zap.Int("max_payload_bytes", e.maxPayloadBytes))
+	}
+
+	for _, payload := range payloads {
+		if err := e.send(ctx, payload); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// logsToPayloads maps the log records to entries or events, grouped into
+// batchCreate requests fitting into the maximum payload size. Records too
+// large to fit, or without the field sent as log text, are dropped.
+func (e *chronicleExporter) logsToPayloads(ld pdata.Logs) (payloads [][]byte, dropped int) {
+	prefix, suffix := e.payloadEnvelope()
+	now := e.now()
+	buf := &bytes.Buffer{}
+	flush := func() {
+		if buf.Len() == 0 {
+			return
+		}
+		buf.WriteString(suffix)
+		payloads = append(payloads, buf.Bytes())
+		buf = &bytes.Buffer{}
+	}
+
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		ills := rl.InstrumentationLibraryLogs()
+		for j := 0; j < ills.Len(); j++ {
+			logs := ills.At(j).Logs()
+			for k := 0; k < logs.Len(); k++ {
+				item, ok := e.marshalLogRecord(logs.At(k), rl.Resource(), now)
+				if !ok {
+					dropped++
+					continue
+				}
+				if len(prefix)+len(item)+len(suffix) > e.maxPayloadBytes {
+					dropped++
+					continue
+				}
+				if buf.Len()+len(item)+len(suffix)+1 > e.maxPayloadBytes {
+					flush()
+				}
+				if buf.Len() == 0 {
+					buf.WriteString(prefix)
+				} else {
+					buf.WriteByte(',')
+				}
+				buf.Write(item)
+			}
+		}
+	}
+	flush()
+	return payloads, dropped
+}
+
+// payloadEnvelope returns the JSON written around the entries or events of a request.
+func (e *chronicleExporter) payloadEnvelope() (prefix, suffix string) {
+	customerID, _ := json.Marshal(e.config.CustomerID)
+	if e.config.Mode == ModeUDM {
+		return fmt.Sprintf(`{"customer_id":%s,"events":[`, customerID), "]}"
+	}
+	logType, _ := json.Marshal(e.config.LogType)
+	return fmt.Sprintf(`{"customer_id":%s,"log_type":%s,"entries":[`, customerID, logType), "]}"
+}
+
+func (e *chronicleExporter) marshalLogRecord(lr pdata.LogRecord, resource pdata.Resource, now time.Time) ([]byte, bool) {
+	var v interface{}
+	if e.config.Mode == ModeUDM {
+		v = logRecordToUDMEvent(e.config, lr, resource, now)
+	} else {
+		entry, ok := logRecordToEntry(e.config.LogTextField, lr, resource, now)
+		if !ok {
+			return nil, false
+		}
+		v = entry
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, false
+	}
+	return b, true
+}
+
+func (e *chronicleExporter) send(ctx context.Context, payload []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.url, bytes.NewReader(payload))
+	if err != nil {
+		return consumererror.Permanent(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return err
+	}
+	_, _ = io.Copy(ioutil.Discard, resp.Body)
+	_ = resp.Body.Close()
+
+	return handleHTTPCode(resp)
+}
+
+func handleHTTPCode(resp *http.Response) error {
+	if resp.StatusCode >= http.StatusOK && resp.StatusCode < http.StatusMultipleChoices {
+		return nil
+	}
+
+	err := fmt.Errorf("HTTP %d %q", resp.StatusCode, http.StatusText(resp.StatusCode))
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+		retryAfter := 0
+		if val := resp.Header.Get("Retry-After"); val != "" {
+			if seconds, errAtoi := strconv.Atoi(val); errAtoi == nil {
+				retryAfter = seconds
+			}
+		}
+		return exporterhelper.NewThrottleRetry(err, time.Duration(retryAfter)*time.Second)
+	case http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusNotFound, http.StatusRequestEntityTooLarge:
+		return consumererror.Permanent(err)
+	}
+	return err
+}
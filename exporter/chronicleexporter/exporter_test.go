@@ -0,0 +1,239 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chronicleexporter
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.uber.org/zap"
+)
+
+func testLogs(n int) pdata.Logs {
+	ld := pdata.NewLogs()
+	logs := ld.ResourceLogs().AppendEmpty().InstrumentationLibraryLogs().AppendEmpty().Logs()
+	for i := 0; i < n; i++ {
+		logs.AppendEmpty().Body().SetStringVal(fmt.Sprintf("log %d", i))
+	}
+	return ld
+}
+
+// newTokenServer returns a server issuing tokens for service account keys,
+// along with such a key pointing to it.
+func newTokenServer(t *testing.T, tokenRequests *int) (*httptest.Server, string) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*tokenRequests++
+		require.NoError(t, r.ParseForm())
+		assert.Equal(t, "urn:ietf:params:oauth:grant-type:jwt-bearer", r.Form.Get("grant_type"))
+		assert.NotEmpty(t, r.Form.Get("assertion"))
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"access_token":"token","token_type":"Bearer","expires_in":3600}`))
+	}))
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	credentials, err := json.Marshal(map[string]string{
+		"type":           "service_account",
+		"client_email":   "collector@project.iam.gserviceaccount.com",
+		"private_key_id": "key",
+		"private_key":    string(pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})),
+		"token_uri":      server.URL,
+	})
+	require.NoError(t, err)
+	return server, string(credentials)
+}
+
+func newTestExporter(t *testing.T, cfg *Config) *chronicleExporter {
+	require.NoError(t, cfg.validate())
+	exp := newExporter(cfg, zap.NewNop())
+	require.NoError(t, exp.start(context.Background(), componenttest.NewNopHost()))
+	return exp
+}
+
+func TestPushLogsUnstructured(t *testing.T) {
+	var tokenRequests int
+	tokenServer, credentials := newTokenServer(t, &tokenRequests)
+	defer tokenServer.Close()
+
+	var requests []map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v2/unstructuredlogentries:batchCreate", r.URL.Path)
+		assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		var req map[string]interface{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		requests = append(requests, req)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = server.URL
+	cfg.CustomerID = "customer"
+	cfg.Credentials = credentials
+	cfg.LogType = "WINDOWS_DNS"
+	exp := newTestExporter(t, cfg)
+
+	require.NoError(t, exp.pushLogs(context.Background(), testLogs(2)))
+	require.NoError(t, exp.pushLogs(context.Background(), testLogs(1)))
+	require.Len(t, requests, 2)
+	assert.Equal(t, "customer", requests[0]["customer_id"])
+	assert.Equal(t, "WINDOWS_DNS", requests[0]["log_type"])
+	entries := requests[0]["entries"].([]interface{})
+	require.Len(t, entries, 2)
+	assert.Equal(t, "log 0", entries[0].(map[string]interface{})["log_text"])
+	assert.Contains(t, entries[0], "ts_epoch_microseconds")
+	// The token is cached until it expires.
+	assert.Equal(t, 1, tokenRequests)
+}
+
+func TestPushLogsUDM(t *testing.T) {
+	var tokenRequests int
+	tokenServer, credentials := newTokenServer(t, &tokenRequests)
+	defer tokenServer.Close()
+	credentialsFile := filepath.Join(t.TempDir(), "credentials.json")
+	require.NoError(t, ioutil.WriteFile(credentialsFile, []byte(credentials), 0600))
+
+	var req map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v2/udmevents:batchCreate", r.URL.Path)
+		assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Mode = ModeUDM
+	cfg.Endpoint = server.URL
+	cfg.CustomerID = "customer"
+	cfg.CredentialsFile = credentialsFile
+	cfg.UDMMapping = []UDMFieldMapping{{UDMField: "metadata.description", Field: "body"}}
+	exp := newTestExporter(t, cfg)
+
+	require.NoError(t, exp.pushLogs(context.Background(), testLogs(1)))
+	assert.Equal(t, "customer", req["customer_id"])
+	events := req["events"].([]interface{})
+	require.Len(t, events, 1)
+	metadata := events[0].(map[string]interface{})["metadata"].(map[string]interface{})
+	assert.Equal(t, "GENERIC_EVENT", metadata["event_type"])
+	assert.Equal(t, "log 0", metadata["description"])
+	assert.Contains(t, metadata, "event_timestamp")
+}
+
+func TestStartInvalidCredentials(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.CustomerID = "customer"
+	cfg.CredentialsFile = filepath.Join(t.TempDir(), "missing.json")
+	cfg.LogType = "WINDOWS_DNS"
+	exp := newExporter(cfg, zap.NewNop())
+	err := exp.start(context.Background(), componenttest.NewNopHost())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to read credentials file")
+
+	cfg.CredentialsFile = ""
+	cfg.Credentials = "not json"
+	err = exp.start(context.Background(), componenttest.NewNopHost())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to load credentials")
+}
+
+func TestLogsToPayloadsSplitsBatches(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.CustomerID = "c"
+	cfg.LogType = "T"
+	exp := newExporter(cfg, zap.NewNop())
+	exp.now = func() time.Time { return time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC) }
+
+	// The envelope is 45 bytes long and each entry 61 bytes long.
+	exp.maxPayloadBytes = 170
+	ld := testLogs(5)
+	logs := ld.ResourceLogs().At(0).InstrumentationLibraryLogs().At(0).Logs()
+	logs.AppendEmpty().Body().SetStringVal(strings.Repeat("x", 200))
+	// Records without log text are dropped too.
+	logs.AppendEmpty()
+
+	payloads, dropped := exp.logsToPayloads(ld)
+	assert.Equal(t, 2, dropped)
+	require.Len(t, payloads, 3)
+	var entries int
+	for _, p := range payloads {
+		assert.LessOrEqual(t, len(p), exp.maxPayloadBytes)
+		var req struct {
+			Entries []unstructuredEntry `json:"entries"`
+		}
+		require.NoError(t, json.Unmarshal(p, &req))
+		entries += len(req.Entries)
+	}
+	assert.Equal(t, 5, entries)
+}
+
+func TestPushLogsErrors(t *testing.T) {
+	tests := []struct {
+		name          string
+		status        int
+		retryAfter    string
+		wantPermanent bool
+	}{
+		{name: "throttled", status: http.StatusTooManyRequests, retryAfter: "10"},
+		{name: "unavailable", status: http.StatusServiceUnavailable},
+		{name: "server error", status: http.StatusInternalServerError},
+		{name: "bad request", status: http.StatusBadRequest, wantPermanent: true},
+		{name: "forbidden", status: http.StatusForbidden, wantPermanent: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var tokenRequests int
+			tokenServer, credentials := newTokenServer(t, &tokenRequests)
+			defer tokenServer.Close()
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if tt.retryAfter != "" {
+					w.Header().Set("Retry-After", tt.retryAfter)
+				}
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+
+			cfg := createDefaultConfig().(*Config)
+			cfg.Endpoint = server.URL
+			cfg.CustomerID = "customer"
+			cfg.Credentials = credentials
+			cfg.LogType = "WINDOWS_DNS"
+			exp := newTestExporter(t, cfg)
+
+			err := exp.pushLogs(context.Background(), testLogs(1))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), fmt.Sprintf("HTTP %d", tt.status))
+			assert.Equal(t, tt.wantPermanent, consumererror.IsPermanent(err))
+		})
+	}
+}
@@ -0,0 +1,58 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chronicleexporter
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configcheck"
+	"go.uber.org/zap"
+)
+
+func TestFactory_CreateDefaultConfig(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	assert.NoError(t, configcheck.ValidateConfig(cfg))
+
+	ocfg := cfg.(*Config)
+	assert.Equal(t, ModeUnstructured, ocfg.Mode)
+	assert.Equal(t, defaultEndpoint, ocfg.Endpoint)
+	assert.Equal(t, 30*time.Second, ocfg.Timeout)
+	assert.Equal(t, defaultLogTextField, ocfg.LogTextField)
+	assert.Equal(t, defaultEventType, ocfg.EventType)
+	assert.True(t, ocfg.RetrySettings.Enabled)
+	assert.True(t, ocfg.QueueSettings.Enabled)
+}
+
+func TestFactory_CreateLogsExporter(t *testing.T) {
+	factory := NewFactory()
+	params := component.ExporterCreateSettings{Logger: zap.NewNop()}
+
+	cfg := factory.CreateDefaultConfig().(*Config)
+	_, err := factory.CreateLogsExporter(context.Background(), params, cfg)
+	assert.EqualError(t, err, `"customer_id" is required`)
+
+	cfg.CustomerID = "customer"
+	cfg.CredentialsFile = "credentials.json"
+	cfg.LogType = "WINDOWS_DNS"
+	exp, err := factory.CreateLogsExporter(context.Background(), params, cfg)
+	require.NoError(t, err)
+	assert.NotNil(t, exp)
+}
@@ -0,0 +1,173 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chronicleexporter
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	tracetranslator "go.opentelemetry.io/collector/translator/trace"
+)
+
+const (
+	eventTimestampPath = "metadata.event_timestamp"
+
+	attributesPrefix = "attributes."
+	resourcePrefix   = "resource."
+)
+
+var logRecordFields = map[string]bool{
+	"body":            true,
+	"severity_text":   true,
+	"severity_number": true,
+	"name":            true,
+	"trace_id":        true,
+	"span_id":         true,
+	"flags":           true,
+	"attributes":      true,
+	"resource":        true,
+}
+
+func validateField(field string) error {
+	if logRecordFields[field] {
+		return nil
+	}
+	if strings.HasPrefix(field, attributesPrefix) && len(field) > len(attributesPrefix) ||
+		strings.HasPrefix(field, resourcePrefix) && len(field) > len(resourcePrefix) {
+		return nil
+	}
+	return fmt.Errorf("unknown field %q", field)
+}
+
+// unstructuredEntry is a raw log line of the unstructuredlogentries:batchCreate API.
+type unstructuredEntry struct {
+	LogText             string `json:"log_text"`
+	TSEpochMicroseconds int64  `json:"ts_epoch_microseconds"`
+}
+
+// logRecordToEntry returns the raw log line of a log record, or false if the
+// field sent as log line is missing. Structured values are sent as JSON.
+func logRecordToEntry(field string, lr pdata.LogRecord, resource pdata.Resource, now time.Time) (unstructuredEntry, bool) {
+	v, ok := fieldValue(field, lr, resource)
+	if !ok {
+		return unstructuredEntry{}, false
+	}
+	var text string
+	switch tv := v.(type) {
+	case string:
+		text = tv
+	default:
+		b, err := json.Marshal(tv)
+		if err != nil {
+			return unstructuredEntry{}, false
+		}
+		text = string(b)
+	}
+	return unstructuredEntry{
+		LogText:             text,
+		TSEpochMicroseconds: timestamp(lr, now).UnixNano() / int64(time.Microsecond),
+	}, true
+}
+
+// logRecordToUDMEvent maps a log record to a UDM event. UDM fields mapped to
+// fields missing from the record are left out.
+func logRecordToUDMEvent(cfg *Config, lr pdata.LogRecord, resource pdata.Resource, now time.Time) map[string]interface{} {
+	event := map[string]interface{}{}
+	metadata := map[string]interface{}{
+		"event_timestamp": timestamp(lr, now).UTC().Format(time.RFC3339Nano),
+		"event_type":      cfg.EventType,
+	}
+	if cfg.ProductName != "" {
+		metadata["product_name"] = cfg.ProductName
+	}
+	if cfg.VendorName != "" {
+		metadata["vendor_name"] = cfg.VendorName
+	}
+	event["metadata"] = metadata
+
+	for _, m := range cfg.UDMMapping {
+		if v, ok := fieldValue(m.Field, lr, resource); ok {
+			setPath(event, strings.Split(m.UDMField, "."), v)
+		}
+	}
+	return event
+}
+
+// setPath sets the value at the given path, creating the intermediate objects.
+// Values already set on the way are replaced by objects.
+func setPath(obj map[string]interface{}, path []string, v interface{}) {
+	for _, segment := range path[:len(path)-1] {
+		next, ok := obj[segment].(map[string]interface{})
+		if !ok {
+			next = map[string]interface{}{}
+			obj[segment] = next
+		}
+		obj = next
+	}
+	obj[path[len(path)-1]] = v
+}
+
+func timestamp(lr pdata.LogRecord, now time.Time) time.Time {
+	if lr.Timestamp() != 0 {
+		return lr.Timestamp().AsTime()
+	}
+	return now
+}
+
+func fieldValue(field string, lr pdata.LogRecord, resource pdata.Resource) (interface{}, bool) {
+	switch field {
+	case "body":
+		return rawValue(lr.Body())
+	case "severity_text":
+		return lr.SeverityText(), lr.SeverityText() != ""
+	case "severity_number":
+		return int32(lr.SeverityNumber()), lr.SeverityNumber() != pdata.SeverityNumberUNDEFINED
+	case "name":
+		return lr.Name(), lr.Name() != ""
+	case "trace_id":
+		return lr.TraceID().HexString(), !lr.TraceID().IsEmpty()
+	case "span_id":
+		return lr.SpanID().HexString(), !lr.SpanID().IsEmpty()
+	case "flags":
+		return lr.Flags(), true
+	case "attributes":
+		return tracetranslator.AttributeMapToMap(lr.Attributes()), lr.Attributes().Len() > 0
+	case "resource":
+		return tracetranslator.AttributeMapToMap(resource.Attributes()), resource.Attributes().Len() > 0
+	}
+	if strings.HasPrefix(field, attributesPrefix) {
+		if v, ok := lr.Attributes().Get(strings.TrimPrefix(field, attributesPrefix)); ok {
+			return rawValue(v)
+		}
+	} else if strings.HasPrefix(field, resourcePrefix) {
+		if v, ok := resource.Attributes().Get(strings.TrimPrefix(field, resourcePrefix)); ok {
+			return rawValue(v)
+		}
+	}
+	return nil, false
+}
+
+// rawValue returns the Go value of an attribute value, or false if it is empty.
+func rawValue(v pdata.AttributeValue) (interface{}, bool) {
+	if v.Type() == pdata.AttributeValueTypeNull {
+		return nil, false
+	}
+	m := pdata.NewAttributeMap()
+	m.Upsert("v", v)
+	return tracetranslator.AttributeMapToMap(m)["v"], true
+}
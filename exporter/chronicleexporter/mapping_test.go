@@ -0,0 +1,86 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chronicleexporter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+func testLogRecord() (pdata.LogRecord, pdata.Resource) {
+	ld := pdata.NewLogs()
+	rl := ld.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().InsertString("host.name", "host-1")
+	lr := rl.InstrumentationLibraryLogs().AppendEmpty().Logs().AppendEmpty()
+	lr.SetTimestamp(pdata.TimestampFromTime(time.Date(2021, 6, 1, 12, 0, 0, 500000, time.UTC)))
+	lr.Body().SetStringVal("user logged in")
+	lr.SetSeverityText("INFO")
+	lr.Attributes().InsertString("user.name", "alice")
+	lr.Attributes().InsertInt("attempts", 2)
+	return lr, rl.Resource()
+}
+
+func TestLogRecordToEntry(t *testing.T) {
+	lr, resource := testLogRecord()
+	entry, ok := logRecordToEntry("body", lr, resource, time.Now())
+	assert.True(t, ok)
+	assert.Equal(t, unstructuredEntry{LogText: "user logged in", TSEpochMicroseconds: 1622548800000500}, entry)
+
+	entry, ok = logRecordToEntry("attributes", lr, resource, time.Now())
+	assert.True(t, ok)
+	assert.Equal(t, `{"attempts":2,"user.name":"alice"}`, entry.LogText)
+
+	_, ok = logRecordToEntry("attributes.missing", lr, resource, time.Now())
+	assert.False(t, ok)
+}
+
+func TestLogRecordToEntryWithoutTimestamp(t *testing.T) {
+	lr, resource := testLogRecord()
+	lr.SetTimestamp(0)
+	now := time.Date(2021, 6, 2, 0, 0, 0, 0, time.UTC)
+	entry, ok := logRecordToEntry("body", lr, resource, now)
+	assert.True(t, ok)
+	assert.Equal(t, now.UnixNano()/1000, entry.TSEpochMicroseconds)
+}
+
+func TestLogRecordToUDMEvent(t *testing.T) {
+	lr, resource := testLogRecord()
+	cfg := createDefaultConfig().(*Config)
+	cfg.EventType = "USER_LOGIN"
+	cfg.ProductName = "my-app"
+	cfg.UDMMapping = []UDMFieldMapping{
+		{UDMField: "principal.hostname", Field: "resource.host.name"},
+		{UDMField: "target.user.userid", Field: "attributes.user.name"},
+		{UDMField: "metadata.description", Field: "body"},
+		{UDMField: "security_result.severity_details", Field: "severity_text"},
+		{UDMField: "additional.missing", Field: "attributes.missing"},
+	}
+
+	event := logRecordToUDMEvent(cfg, lr, resource, time.Now())
+	assert.Equal(t, map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"event_timestamp": "2021-06-01T12:00:00.0005Z",
+			"event_type":      "USER_LOGIN",
+			"product_name":    "my-app",
+			"description":     "user logged in",
+		},
+		"principal":       map[string]interface{}{"hostname": "host-1"},
+		"target":          map[string]interface{}{"user": map[string]interface{}{"userid": "alice"}},
+		"security_result": map[string]interface{}{"severity_details": "INFO"},
+	}, event)
+}
@@ -117,6 +117,14 @@ type TracesConfig struct {
 	SpanNameRemappings map[string]string `mapstructure:"span_name_remappings"`
 }
 
+// LogsConfig defines the logs exporter specific configuration options
+type LogsConfig struct {
+	// TCPAddr.Endpoint is the host of the Datadog intake server to send logs to.
+	// It can also be set through the `DD_LOGS_URL` environment variable.
+	// If unset, the value is obtained from the Site.
+	confignet.TCPAddr `mapstructure:",squash"`
+}
+
 // TagsConfig defines the tag-related configuration
 // It is embedded in the configuration
 type TagsConfig struct {
@@ -177,6 +185,9 @@ type Config struct {
 	// Traces defines the Traces exporter specific configuration
 	Traces TracesConfig `mapstructure:"traces"`
 
+	// Logs defines the Logs exporter specific configuration
+	Logs LogsConfig `mapstructure:"logs"`
+
 	// SendMetadata defines whether to send host metadata
 	// This is undocumented and only used for unit testing.
 	//
@@ -200,7 +211,7 @@ type Config struct {
 	// Disable this in the Collector if you are using an agent-collector setup.
 	UseResourceMetadata bool `mapstructure:"use_resource_metadata"`
 
-	// onceMetadata ensures only one exporter (metrics/traces) sends host metadata
+	// onceMetadata ensures only one exporter (metrics/traces/logs) sends host metadata
 	onceMetadata sync.Once
 }
 
@@ -242,6 +253,10 @@ func (c *Config) Sanitize() error {
 		c.Traces.TCPAddr.Endpoint = fmt.Sprintf("https://trace.agent.%s", c.API.Site)
 	}
 
+	if c.Logs.TCPAddr.Endpoint == "" {
+		c.Logs.TCPAddr.Endpoint = fmt.Sprintf("https://http-intake.logs.%s", c.API.Site)
+	}
+
 	return nil
 }
 
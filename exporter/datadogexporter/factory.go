@@ -40,6 +40,7 @@ func NewFactory() component.ExporterFactory {
 		createDefaultConfig,
 		exporterhelper.WithMetrics(createMetricsExporter),
 		exporterhelper.WithTraces(createTracesExporter),
+		exporterhelper.WithLogs(createLogsExporter),
 	)
 }
 
@@ -80,6 +81,12 @@ func createDefaultConfig() config.Exporter {
 			IgnoreResources: []string{},
 		},
 
+		Logs: ddconfig.LogsConfig{
+			TCPAddr: confignet.TCPAddr{
+				Endpoint: "$DD_LOGS_URL", // If not provided, set during config sanitization
+			},
+		},
+
 		SendMetadata:        true,
 		UseResourceMetadata: true,
 	}
@@ -179,3 +186,50 @@ func createTracesExporter(
 		}),
 	)
 }
+
+// createLogsExporter creates a logs exporter based on this config.
+func createLogsExporter(
+	ctx context.Context,
+	params component.ExporterCreateSettings,
+	c config.Exporter,
+) (component.LogsExporter, error) {
+
+	cfg := c.(*ddconfig.Config)
+
+	params.Logger.Info("sanitizing Datadog logs exporter configuration")
+	if err := cfg.Sanitize(); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	var pushLogsFn consumerhelper.ConsumeLogsFunc
+
+	if cfg.OnlyMetadata {
+		pushLogsFn = func(_ context.Context, ld pdata.Logs) error {
+			// only sending metadata, use only attributes
+			once := cfg.OnceMetadata()
+			once.Do(func() {
+				attrs := pdata.NewAttributeMap()
+				if ld.ResourceLogs().Len() > 0 {
+					attrs = ld.ResourceLogs().At(0).Resource().Attributes()
+				}
+				go metadata.Pusher(ctx, params, cfg, attrs)
+			})
+			return nil
+		}
+	} else {
+		pushLogsFn = newLogsExporter(ctx, params, cfg).pushLogsData
+	}
+
+	return exporterhelper.NewLogsExporter(
+		cfg,
+		params.Logger,
+		pushLogsFn,
+		exporterhelper.WithQueue(exporterhelper.DefaultQueueSettings()),
+		exporterhelper.WithRetry(exporterhelper.DefaultRetrySettings()),
+		exporterhelper.WithShutdown(func(context.Context) error {
+			cancel()
+			return nil
+		}),
+	)
+}
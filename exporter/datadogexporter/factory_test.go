@@ -68,6 +68,12 @@ func TestCreateDefaultConfig(t *testing.T) {
 			IgnoreResources: []string{},
 		},
 
+		Logs: ddconfig.LogsConfig{
+			TCPAddr: confignet.TCPAddr{
+				Endpoint: "$DD_LOGS_URL",
+			},
+		},
+
 		TagsConfig: ddconfig.TagsConfig{
 			Hostname:   "$DD_HOST",
 			Env:        "$DD_ENV",
@@ -132,6 +138,11 @@ func TestLoadConfig(t *testing.T) {
 			},
 			IgnoreResources: []string{},
 		},
+		Logs: ddconfig.LogsConfig{
+			TCPAddr: confignet.TCPAddr{
+				Endpoint: "https://http-intake.logs.datadoghq.eu",
+			},
+		},
 		SendMetadata:        true,
 		OnlyMetadata:        false,
 		UseResourceMetadata: true,
@@ -172,6 +183,11 @@ func TestLoadConfig(t *testing.T) {
 			},
 			IgnoreResources: []string{},
 		},
+		Logs: ddconfig.LogsConfig{
+			TCPAddr: confignet.TCPAddr{
+				Endpoint: "https://http-intake.logs.datadoghq.com",
+			},
+		},
 		SendMetadata:        true,
 		OnlyMetadata:        false,
 		UseResourceMetadata: true,
@@ -194,6 +210,7 @@ func TestLoadConfigEnvVariables(t *testing.T) {
 	assert.NoError(t, os.Setenv("DD_TAGS", "envexample:tag envexample2:tag"))
 	assert.NoError(t, os.Setenv("DD_URL", "https://api.datadoghq.com"))
 	assert.NoError(t, os.Setenv("DD_APM_URL", "https://trace.agent.datadoghq.com"))
+	assert.NoError(t, os.Setenv("DD_LOGS_URL", "https://http-intake.logs.datadoghq.com"))
 
 	defer func() {
 		assert.NoError(t, os.Unsetenv("DD_API_KEY"))
@@ -205,6 +222,7 @@ func TestLoadConfigEnvVariables(t *testing.T) {
 		assert.NoError(t, os.Unsetenv("DD_TAGS"))
 		assert.NoError(t, os.Unsetenv("DD_URL"))
 		assert.NoError(t, os.Unsetenv("DD_APM_URL"))
+		assert.NoError(t, os.Unsetenv("DD_LOGS_URL"))
 	}()
 
 	factories, err := componenttest.NopFactories()
@@ -255,6 +273,11 @@ func TestLoadConfigEnvVariables(t *testing.T) {
 			},
 			IgnoreResources: []string{},
 		},
+		Logs: ddconfig.LogsConfig{
+			TCPAddr: confignet.TCPAddr{
+				Endpoint: "https://http-intake.logs.datadoghq.test",
+			},
+		},
 		SendMetadata:        true,
 		OnlyMetadata:        false,
 		UseResourceMetadata: true,
@@ -299,6 +322,11 @@ func TestLoadConfigEnvVariables(t *testing.T) {
 			},
 			IgnoreResources: []string{},
 		},
+		Logs: ddconfig.LogsConfig{
+			TCPAddr: confignet.TCPAddr{
+				Endpoint: "https://http-intake.logs.datadoghq.com",
+			},
+		},
 		SendMetadata:        true,
 		OnlyMetadata:        false,
 		UseResourceMetadata: true,
@@ -369,6 +397,37 @@ func TestCreateAPITracesExporter(t *testing.T) {
 	assert.NotNil(t, exp)
 }
 
+func TestCreateAPILogsExporter(t *testing.T) {
+	server := testutils.DatadogServerMock()
+	defer server.Close()
+
+	logger := zap.NewNop()
+
+	factories, err := componenttest.NopFactories()
+	require.NoError(t, err)
+
+	factory := NewFactory()
+	factories.Exporters[typeStr] = factory
+	cfg, err := configtest.LoadConfigAndValidate(path.Join(".", "testdata", "config.yaml"), factories)
+
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	c := (cfg.Exporters[config.NewIDWithName(typeStr, "api")]).(*ddconfig.Config)
+	c.Metrics.TCPAddr.Endpoint = server.URL
+	c.SendMetadata = false
+
+	ctx := context.Background()
+	exp, err := factory.CreateLogsExporter(
+		ctx,
+		component.ExporterCreateSettings{Logger: logger},
+		cfg.Exporters[config.NewIDWithName(typeStr, "api")],
+	)
+
+	assert.NoError(t, err)
+	assert.NotNil(t, exp)
+}
+
 func TestOnlyMetadata(t *testing.T) {
 	server := testutils.DatadogServerMock()
 	defer server.Close()
@@ -0,0 +1,125 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadogexporter
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/pdata"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/datadogexporter/config"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/datadogexporter/metadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/datadogexporter/utils"
+)
+
+const (
+	logsIntakeTimeout time.Duration = 10 * time.Second
+	// logsIntakeMaxBatchSize is the maximum number of logs accepted in a single request by the logs intake
+	logsIntakeMaxBatchSize = 1000
+)
+
+type logsExporter struct {
+	params  component.ExporterCreateSettings
+	cfg     *config.Config
+	ctx     context.Context
+	client  *http.Client
+	logsURL string
+}
+
+func newLogsExporter(ctx context.Context, params component.ExporterCreateSettings, cfg *config.Config) *logsExporter {
+	return &logsExporter{
+		params:  params,
+		cfg:     cfg,
+		ctx:     ctx,
+		client:  utils.NewHTTPClient(logsIntakeTimeout),
+		logsURL: cfg.Logs.TCPAddr.Endpoint + "/v1/input",
+	}
+}
+
+func (exp *logsExporter) pushLogsData(ctx context.Context, ld pdata.Logs) error {
+	// Start host metadata with resource attributes from
+	// the first payload.
+	if exp.cfg.SendMetadata {
+		once := exp.cfg.OnceMetadata()
+		once.Do(func() {
+			attrs := pdata.NewAttributeMap()
+			if ld.ResourceLogs().Len() > 0 {
+				attrs = ld.ResourceLogs().At(0).Resource().Attributes()
+			}
+			go metadata.Pusher(exp.ctx, exp.params, exp.cfg, attrs)
+		})
+	}
+
+	fallbackHost := metadata.GetHost(exp.params.Logger, exp.cfg)
+	logs := convertToDatadogLogs(ld, fallbackHost, exp.cfg)
+
+	for len(logs) > 0 {
+		n := len(logs)
+		if n > logsIntakeMaxBatchSize {
+			n = logsIntakeMaxBatchSize
+		}
+		if err := exp.sendLogs(ctx, logs[:n]); err != nil {
+			return err
+		}
+		logs = logs[n:]
+	}
+	return nil
+}
+
+// sendLogs sends the logs in a gzipped JSON array to the logs intake
+func (exp *logsExporter) sendLogs(ctx context.Context, logs []ddLog) error {
+	var buf bytes.Buffer
+	gzipper := gzip.NewWriter(&buf)
+	if err := json.NewEncoder(gzipper).Encode(logs); err != nil {
+		return consumererror.Permanent(fmt.Errorf("failed to encode logs payload: %w", err))
+	}
+	if err := gzipper.Close(); err != nil {
+		return consumererror.Permanent(fmt.Errorf("failed to compress logs payload: %w", err))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", exp.logsURL, &buf)
+	if err != nil {
+		return consumererror.Permanent(err)
+	}
+	utils.SetDDHeaders(req.Header, exp.params.BuildInfo, exp.cfg.API.Key)
+	utils.SetExtraHeaders(req.Header, utils.JSONHeaders)
+
+	resp, err := exp.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(ioutil.Discard, resp.Body)
+
+	if resp.StatusCode/100 != 2 {
+		err = fmt.Errorf("request to %s responded with %s", exp.logsURL, resp.Status)
+		// 5xx errors and throttling are retriable, all others aren't
+		if resp.StatusCode/100 == 5 || resp.StatusCode == http.StatusTooManyRequests {
+			return err
+		}
+		return consumererror.Permanent(err)
+	}
+	return nil
+}
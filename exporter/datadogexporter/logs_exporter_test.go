@@ -0,0 +1,93 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadogexporter
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/datadogexporter/config"
+)
+
+func TestLogsExporter(t *testing.T) {
+	var received []map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/input", r.URL.Path)
+		assert.Equal(t, "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa", r.Header.Get("DD-Api-Key"))
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		assert.Equal(t, "gzip", r.Header.Get("Content-Encoding"))
+
+		reader, err := gzip.NewReader(r.Body)
+		require.NoError(t, err)
+		require.NoError(t, json.NewDecoder(reader).Decode(&received))
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{
+		API:  config.APIConfig{Key: "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"},
+		Logs: config.LogsConfig{},
+		TagsConfig: config.TagsConfig{
+			Env: "none",
+		},
+	}
+	cfg.Logs.TCPAddr.Endpoint = server.URL
+
+	exp := newLogsExporter(context.Background(), component.ExporterCreateSettings{Logger: zap.NewNop()}, cfg)
+	require.NoError(t, exp.pushLogsData(context.Background(), newTestLogs()))
+
+	require.Len(t, received, 1)
+	assert.Equal(t, "order placed", received[0]["message"])
+	assert.Equal(t, "checkout", received[0]["service"])
+	assert.Equal(t, "2", received[0]["dd.trace_id"])
+}
+
+func TestLogsExporterErrors(t *testing.T) {
+	tests := []struct {
+		status        int
+		wantPermanent bool
+	}{
+		{status: http.StatusBadRequest, wantPermanent: true},
+		{status: http.StatusForbidden, wantPermanent: true},
+		{status: http.StatusTooManyRequests, wantPermanent: false},
+		{status: http.StatusServiceUnavailable, wantPermanent: false},
+	}
+	for _, tt := range tests {
+		t.Run(http.StatusText(tt.status), func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+
+			cfg := &config.Config{API: config.APIConfig{Key: "key"}}
+			cfg.Logs.TCPAddr.Endpoint = server.URL
+
+			exp := newLogsExporter(context.Background(), component.ExporterCreateSettings{Logger: zap.NewNop()}, cfg)
+			err := exp.pushLogsData(context.Background(), newTestLogs())
+			require.Error(t, err)
+			assert.Equal(t, tt.wantPermanent, consumererror.IsPermanent(err))
+		})
+	}
+}
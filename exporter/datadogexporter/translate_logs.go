@@ -0,0 +1,167 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadogexporter
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/translator/conventions"
+	tracetranslator "go.opentelemetry.io/collector/translator/trace"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/datadogexporter/attributes"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/datadogexporter/config"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/datadogexporter/metadata"
+)
+
+const (
+	// Datadog reserved log attributes
+	// https://docs.datadoghq.com/logs/log_configuration/attributes_naming_convention/#reserved-attributes
+	ddLogMessage   = "message"
+	ddLogStatus    = "status"
+	ddLogHostname  = "hostname"
+	ddLogService   = "service"
+	ddLogSource    = "ddsource"
+	ddLogTags      = "ddtags"
+	ddLogTimestamp = "timestamp"
+	// ddLogTraceID and ddLogSpanID correlate logs with the traces sent by the traces exporter
+	ddLogTraceID = "dd.trace_id"
+	ddLogSpanID  = "dd.span_id"
+
+	// defaultLogSource is the ddsource of the logs without a ddsource attribute
+	defaultLogSource = "opentelemetry"
+)
+
+// ddLog is a log in the format of the Datadog logs intake: the reserved
+// attributes are set next to the attributes of the log record.
+type ddLog map[string]interface{}
+
+// convertToDatadogLogs converts logs into Datadog logs. The ddsource and ddtags
+// log or resource attributes are remapped to the Datadog reserved attributes.
+func convertToDatadogLogs(ld pdata.Logs, fallbackHost string, cfg *config.Config) []ddLog {
+	var logs []ddLog
+
+	hostTags := cfg.GetHostTags()
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		resAttrs := rl.Resource().Attributes()
+
+		host, ok := metadata.HostnameFromAttributes(resAttrs)
+		if !ok {
+			host = fallbackHost
+		}
+
+		service := cfg.Service
+		if v, ok := resAttrs.Get(conventions.AttributeServiceName); ok {
+			service = v.StringVal()
+		}
+
+		source := defaultLogSource
+		if v, ok := resAttrs.Get(ddLogSource); ok {
+			source = v.StringVal()
+		}
+
+		tags := append([]string{}, hostTags...)
+		tags = append(tags, attributes.TagsFromAttributes(resAttrs)...)
+		if cfg.Version != "" {
+			tags = append(tags, fmt.Sprintf("version:%s", cfg.Version))
+		}
+		if v, ok := resAttrs.Get(ddLogTags); ok {
+			tags = append(tags, v.StringVal())
+		}
+
+		ills := rl.InstrumentationLibraryLogs()
+		for j := 0; j < ills.Len(); j++ {
+			lrs := ills.At(j).Logs()
+			for k := 0; k < lrs.Len(); k++ {
+				logs = append(logs, logRecordToDatadogLog(lrs.At(k), host, service, source, tags))
+			}
+		}
+	}
+
+	return logs
+}
+
+func logRecordToDatadogLog(lr pdata.LogRecord, host, service, source string, resourceTags []string) ddLog {
+	log := ddLog(tracetranslator.AttributeMapToMap(lr.Attributes()))
+
+	tags := resourceTags
+	if v, ok := log[ddLogTags]; ok {
+		tags = append(append([]string{}, resourceTags...), fmt.Sprint(v))
+	}
+	if v, ok := log[ddLogSource]; ok {
+		source = fmt.Sprint(v)
+	}
+
+	log[ddLogMessage] = tracetranslator.AttributeValueToString(lr.Body())
+	log[ddLogHostname] = host
+	log[ddLogSource] = source
+	log[ddLogTags] = joinTags(tags)
+	if service != "" {
+		log[ddLogService] = service
+	}
+	if status := logStatus(lr); status != "" {
+		log[ddLogStatus] = status
+	}
+	if lr.Timestamp() != 0 {
+		log[ddLogTimestamp] = lr.Timestamp().AsTime().UnixNano() / int64(time.Millisecond)
+	}
+	if !lr.TraceID().IsEmpty() {
+		log[ddLogTraceID] = fmt.Sprint(decodeAPMTraceID(lr.TraceID().Bytes()))
+	}
+	if !lr.SpanID().IsEmpty() {
+		log[ddLogSpanID] = fmt.Sprint(decodeAPMSpanID(lr.SpanID().Bytes()))
+	}
+
+	return log
+}
+
+// logStatus returns the Datadog status of the log record, its severity text or
+// the name of the range of its severity number.
+func logStatus(lr pdata.LogRecord) string {
+	if lr.SeverityText() != "" {
+		return lr.SeverityText()
+	}
+
+	switch sev := lr.SeverityNumber(); {
+	case sev >= pdata.SeverityNumberFATAL:
+		return "fatal"
+	case sev >= pdata.SeverityNumberERROR:
+		return "error"
+	case sev >= pdata.SeverityNumberWARN:
+		return "warn"
+	case sev >= pdata.SeverityNumberINFO:
+		return "info"
+	case sev >= pdata.SeverityNumberDEBUG:
+		return "debug"
+	case sev >= pdata.SeverityNumberTRACE:
+		return "trace"
+	}
+	return ""
+}
+
+// joinTags joins the non empty tags in the comma separated format of ddtags.
+func joinTags(tags []string) string {
+	nonEmpty := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		if tag != "" {
+			nonEmpty = append(nonEmpty, tag)
+		}
+	}
+	return strings.Join(nonEmpty, ",")
+}
@@ -0,0 +1,121 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadogexporter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/translator/conventions"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/datadogexporter/config"
+)
+
+func newTestLogs() pdata.Logs {
+	ld := pdata.NewLogs()
+	rl := ld.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().InsertString(conventions.AttributeServiceName, "checkout")
+	rl.Resource().Attributes().InsertString(conventions.AttributeDeploymentEnvironment, "staging")
+	rl.Resource().Attributes().InsertString("datadog.host.name", "custom-hostname")
+
+	lr := rl.InstrumentationLibraryLogs().AppendEmpty().Logs().AppendEmpty()
+	lr.Body().SetStringVal("order placed")
+	lr.SetSeverityNumber(pdata.SeverityNumberWARN2)
+	lr.SetTimestamp(pdata.TimestampFromTime(time.Unix(1624000000, 123456789)))
+	lr.SetTraceID(pdata.NewTraceID([16]byte{0, 0, 0, 0, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0, 0, 2}))
+	lr.SetSpanID(pdata.NewSpanID([8]byte{0, 0, 0, 0, 0, 0, 0, 3}))
+	lr.Attributes().InsertString("order.id", "1234")
+	lr.Attributes().InsertInt("order.items", 3)
+	lr.Attributes().InsertString("ddsource", "checkout-service")
+	lr.Attributes().InsertString("ddtags", "team:payments")
+	return ld
+}
+
+func TestConvertToDatadogLogs(t *testing.T) {
+	cfg := &config.Config{
+		TagsConfig: config.TagsConfig{
+			Env:     "none",
+			Version: "1.2.3",
+			Tags:    []string{"region:eu"},
+		},
+	}
+
+	logs := convertToDatadogLogs(newTestLogs(), "fallbackhost", cfg)
+	require.Len(t, logs, 1)
+	assert.Equal(t, ddLog{
+		"message":     "order placed",
+		"status":      "warn",
+		"hostname":    "custom-hostname",
+		"service":     "checkout",
+		"ddsource":    "checkout-service",
+		"ddtags":      "region:eu,service:checkout,env:staging,version:1.2.3,team:payments",
+		"timestamp":   int64(1624000000123),
+		"dd.trace_id": "2",
+		"dd.span_id":  "3",
+		"order.id":    "1234",
+		"order.items": int64(3),
+	}, logs[0])
+}
+
+func TestConvertToDatadogLogsDefaults(t *testing.T) {
+	cfg := &config.Config{
+		TagsConfig: config.TagsConfig{
+			Env:     "prod",
+			Service: "myservice",
+		},
+	}
+
+	ld := pdata.NewLogs()
+	lr := ld.ResourceLogs().AppendEmpty().InstrumentationLibraryLogs().AppendEmpty().Logs().AppendEmpty()
+	lr.Body().SetIntVal(42)
+
+	logs := convertToDatadogLogs(ld, "fallbackhost", cfg)
+	require.Len(t, logs, 1)
+	assert.Equal(t, ddLog{
+		"message":  "42",
+		"hostname": "fallbackhost",
+		"service":  "myservice",
+		"ddsource": "opentelemetry",
+		"ddtags":   "env:prod",
+	}, logs[0])
+}
+
+func TestLogStatus(t *testing.T) {
+	tests := []struct {
+		severityText   string
+		severityNumber pdata.SeverityNumber
+		want           string
+	}{
+		{want: ""},
+		{severityNumber: pdata.SeverityNumberTRACE3, want: "trace"},
+		{severityNumber: pdata.SeverityNumberDEBUG, want: "debug"},
+		{severityNumber: pdata.SeverityNumberINFO4, want: "info"},
+		{severityNumber: pdata.SeverityNumberWARN, want: "warn"},
+		{severityNumber: pdata.SeverityNumberERROR2, want: "error"},
+		{severityNumber: pdata.SeverityNumberFATAL4, want: "fatal"},
+		{severityText: "critical", severityNumber: pdata.SeverityNumberERROR, want: "critical"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			lr := pdata.NewLogRecord()
+			lr.SetSeverityText(tt.severityText)
+			lr.SetSeverityNumber(tt.severityNumber)
+			assert.Equal(t, tt.want, logStatus(lr))
+		})
+	}
+}
@@ -41,6 +41,13 @@ type Config struct {
 
 	// String to prefix all metric names
 	Prefix string `mapstructure:"prefix"`
+
+	// OneAgentMetadata adds the host metadata of the OneAgent monitoring the collector, if any,
+	// as dimensions to all exported metrics
+	OneAgentMetadata bool `mapstructure:"oneagent_metadata"`
+
+	// MetricMetadata sends the unit and description of the exported metrics to Dynatrace
+	MetricMetadata bool `mapstructure:"metric_metadata"`
 }
 
 // Sanitize ensures an API token has been provided
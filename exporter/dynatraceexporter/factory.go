@@ -53,6 +53,9 @@ func createDefaultConfig() config.Exporter {
 		HTTPClientSettings: confighttp.HTTPClientSettings{Endpoint: ""},
 
 		Tags: []string{},
+
+		OneAgentMetadata: true,
+		MetricMetadata:   true,
 	}
 }
 
@@ -47,6 +47,9 @@ func TestCreateDefaultConfig(t *testing.T) {
 		},
 
 		Tags: []string{},
+
+		OneAgentMetadata: true,
+		MetricMetadata:   true,
 	}, cfg, "failed to create default config")
 
 	assert.NoError(t, configcheck.ValidateConfig(cfg))
@@ -85,6 +88,9 @@ func TestLoadConfig(t *testing.T) {
 		Prefix: "myprefix",
 
 		Tags: []string{"example=tag"},
+
+		OneAgentMetadata: true,
+		MetricMetadata:   true,
 	}, apiConfig)
 
 	invalidConfig2 := cfg.Exporters[config.NewIDWithName(typeStr, "invalid")].(*dtconfig.Config)
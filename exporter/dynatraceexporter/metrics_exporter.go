@@ -50,6 +50,8 @@ type exporter struct {
 	cfg        *config.Config
 	client     *http.Client
 	isDisabled bool
+	// oneAgentTags are the dimensions read from the OneAgent host metadata
+	oneAgentTags []string
 }
 
 const (
@@ -84,7 +86,9 @@ func (e *exporter) serializeMetrics(md pdata.Metrics) ([]string, int) {
 
 	resourceMetrics := md.ResourceMetrics()
 
-	e.logger.Debug(fmt.Sprintf("res metric len: %d, e.cfg.Tags: %v\n", resourceMetrics.Len(), e.cfg.Tags))
+	tags := append(append([]string{}, e.cfg.Tags...), e.oneAgentTags...)
+
+	e.logger.Debug(fmt.Sprintf("res metric len: %d, tags: %v\n", resourceMetrics.Len(), tags))
 
 	for i := 0; i < resourceMetrics.Len(); i++ {
 		resourceMetric := resourceMetrics.At(i)
@@ -106,17 +110,22 @@ func (e *exporter) serializeMetrics(md pdata.Metrics) ([]string, int) {
 				case pdata.MetricDataTypeNone:
 					continue
 				case pdata.MetricDataTypeIntGauge:
-					l = serialization.SerializeIntDataPoints(name, metric.IntGauge().DataPoints(), e.cfg.Tags)
+					l = serialization.SerializeIntDataPoints(name, metric.IntGauge().DataPoints(), tags)
 				case pdata.MetricDataTypeDoubleGauge:
-					l = serialization.SerializeDoubleDataPoints(name, metric.DoubleGauge().DataPoints(), e.cfg.Tags)
+					l = serialization.SerializeDoubleDataPoints(name, metric.DoubleGauge().DataPoints(), tags)
 				case pdata.MetricDataTypeIntSum:
-					l = serialization.SerializeIntDataPoints(name, metric.IntSum().DataPoints(), e.cfg.Tags)
+					l = serialization.SerializeIntDataPoints(name, metric.IntSum().DataPoints(), tags)
 				case pdata.MetricDataTypeDoubleSum:
-					l = serialization.SerializeDoubleDataPoints(name, metric.DoubleSum().DataPoints(), e.cfg.Tags)
+					l = serialization.SerializeDoubleDataPoints(name, metric.DoubleSum().DataPoints(), tags)
 				case pdata.MetricDataTypeIntHistogram:
-					l = serialization.SerializeIntHistogramMetrics(name, metric.IntHistogram().DataPoints(), e.cfg.Tags)
+					l = serialization.SerializeIntHistogramMetrics(name, metric.IntHistogram().DataPoints(), tags)
 				case pdata.MetricDataTypeHistogram:
-					l = serialization.SerializeHistogramMetrics(name, metric.Histogram().DataPoints(), e.cfg.Tags)
+					l = serialization.SerializeHistogramMetrics(name, metric.Histogram().DataPoints(), tags)
+				}
+				if e.cfg.MetricMetadata && len(l) > 0 {
+					if metadata := serialization.SerializeMetadata(name, metric.Unit(), metric.Description()); metadata != "" {
+						lines = append(lines, metadata)
+					}
 				}
 				lines = append(lines, l...)
 				e.logger.Debug(fmt.Sprintf("Exporting type %s, Name: %s, len: %d ", metric.DataType().String(), name, len(l)))
@@ -236,6 +245,10 @@ func (e *exporter) start(_ context.Context, host component.Host) (err error) {
 
 	e.client = client
 
+	if e.cfg.OneAgentMetadata {
+		e.oneAgentTags = readOneAgentMetadata(e.logger, oneAgentMetadataFile)
+	}
+
 	return nil
 }
 
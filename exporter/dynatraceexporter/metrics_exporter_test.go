@@ -172,6 +172,55 @@ func Test_exporter_PushMetricsData(t *testing.T) {
 	}
 }
 
+func Test_exporter_PushMetricsData_Metadata(t *testing.T) {
+	sent := "not sent"
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		bodyBytes, _ := ioutil.ReadAll(r.Body)
+		sent = string(bodyBytes)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer ts.Close()
+
+	md := pdata.NewMetrics()
+	metrics := md.ResourceMetrics().AppendEmpty().InstrumentationLibraryMetrics().AppendEmpty().Metrics()
+
+	describedMetric := metrics.AppendEmpty()
+	describedMetric.SetDataType(pdata.MetricDataTypeIntGauge)
+	describedMetric.SetName("described")
+	describedMetric.SetUnit("By")
+	describedMetric.SetDescription("A described gauge")
+	describedDataPoint := describedMetric.IntGauge().DataPoints().AppendEmpty()
+	describedDataPoint.SetValue(10)
+	describedDataPoint.SetTimestamp(pdata.Timestamp(100_000_000))
+
+	plainMetric := metrics.AppendEmpty()
+	plainMetric.SetDataType(pdata.MetricDataTypeIntGauge)
+	plainMetric.SetName("plain")
+	plainDataPoint := plainMetric.IntGauge().DataPoints().AppendEmpty()
+	plainDataPoint.SetValue(20)
+	plainDataPoint.SetTimestamp(pdata.Timestamp(100_000_000))
+
+	e := &exporter{
+		logger: zap.NewNop(),
+		cfg: &config.Config{
+			HTTPClientSettings: confighttp.HTTPClientSettings{Endpoint: ts.URL},
+			Tags:               []string{"tag=value"},
+			MetricMetadata:     true,
+		},
+		client:       ts.Client(),
+		oneAgentTags: []string{`dt.entity.host="HOST-1234"`},
+	}
+	err := e.PushMetricsData(context.Background(), md)
+	if err != nil {
+		t.Errorf("exporter.PushMetricsData() error = %v", err)
+		return
+	}
+
+	if wantBody := "#described gauge dt.meta.unit=\"By\",dt.meta.description=\"A described gauge\"\ndescribed,tag=value,dt.entity.host=\"HOST-1234\" 10 100\nplain,tag=value,dt.entity.host=\"HOST-1234\" 20 100"; sent != wantBody {
+		t.Errorf("exporter.PushMetricsData():ResponseBody = %v, want %v", sent, wantBody)
+	}
+}
+
 func Test_exporter_PushMetricsData_EmptyPayload(t *testing.T) {
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		t.Fatal("Server should not be called")
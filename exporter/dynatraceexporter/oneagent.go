@@ -0,0 +1,61 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dynatraceexporter
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/dynatraceexporter/serialization"
+)
+
+// oneAgentMetadataFile is made readable by the OneAgent monitoring the process.
+// It contains the path of the properties file holding the host metadata.
+const oneAgentMetadataFile = "dt_metadata_e617c525669e072eebe3d0f08212e8f2.properties"
+
+// readOneAgentMetadata returns the host metadata of the OneAgent monitoring the
+// collector as dimensions, or nil if the collector isn't monitored by a OneAgent.
+func readOneAgentMetadata(logger *zap.Logger, indirectionFile string) []string {
+	indirection, err := ioutil.ReadFile(indirectionFile)
+	if err != nil {
+		logger.Debug(fmt.Sprintf("OneAgent metadata not available: %s", err.Error()))
+		return nil
+	}
+
+	content, err := ioutil.ReadFile(strings.TrimSpace(string(indirection)))
+	if err != nil {
+		logger.Warn(fmt.Sprintf("Failed to read OneAgent metadata: %s", err.Error()))
+		return nil
+	}
+
+	var tags []string
+	for _, line := range strings.Split(string(content), "\n") {
+		kv := strings.SplitN(strings.TrimSpace(line), "=", 2)
+		if len(kv) != 2 || kv[0] == "" || kv[1] == "" {
+			continue
+		}
+		tag, err := serialization.SerializeDimension(kv[0], kv[1])
+		if err != nil {
+			logger.Debug(fmt.Sprintf("Skipping OneAgent metadata %s: %s", kv[0], err.Error()))
+			continue
+		}
+		tags = append(tags, tag)
+	}
+
+	return tags
+}
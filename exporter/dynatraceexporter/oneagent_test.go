@@ -0,0 +1,48 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dynatraceexporter
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func Test_readOneAgentMetadata(t *testing.T) {
+	dir, err := ioutil.TempDir("", "oneagent")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	metadataFile := filepath.Join(dir, "metadata.properties")
+	require.NoError(t, ioutil.WriteFile(metadataFile, []byte("dt.entity.host=HOST-1234\n\ndt.host_group.id=\ninvalid\n1=one\ndt.entity.process_group_instance=PROCESS_GROUP_INSTANCE-5678\n"), 0600))
+
+	indirectionFile := filepath.Join(dir, oneAgentMetadataFile)
+	require.NoError(t, ioutil.WriteFile(indirectionFile, []byte(metadataFile+"\n"), 0600))
+
+	assert.Equal(t, []string{
+		`dt.entity.host="HOST-1234"`,
+		`dt.entity.process_group_instance="PROCESS_GROUP_INSTANCE-5678"`,
+	}, readOneAgentMetadata(zap.NewNop(), indirectionFile))
+
+	assert.Nil(t, readOneAgentMetadata(zap.NewNop(), filepath.Join(dir, "missing")))
+
+	require.NoError(t, ioutil.WriteFile(indirectionFile, []byte(filepath.Join(dir, "missing")), 0600))
+	assert.Nil(t, readOneAgentMetadata(zap.NewNop(), indirectionFile))
+}
@@ -121,15 +121,40 @@ func serializeLine(name, tagline, valueline string, timestamp pdata.Timestamp) s
 	return output
 }
 
+// SerializeMetadata serializes the unit and description of a metric to a Dynatrace
+// metadata line. It returns an empty string if the metric has neither.
+func SerializeMetadata(name, unit, description string) string {
+	// #{name} gauge dt.meta.unit="{unit}",dt.meta.description="{description}"
+	props := []string{}
+	if unit != "" {
+		props = append(props, "dt.meta.unit="+escapeDimension(unit))
+	}
+	if description != "" {
+		props = append(props, "dt.meta.description="+escapeDimension(description))
+	}
+	if len(props) == 0 {
+		return ""
+	}
+
+	return "#" + name + " gauge " + strings.Join(props, ",")
+}
+
+// SerializeDimension serializes a dimension to the key="value" format of the exporter tags.
+func SerializeDimension(key, value string) (string, error) {
+	key, err := NormalizeString(strings.ToLower(key), maxDimKeyLen)
+	if err != nil {
+		return "", err
+	}
+	return key + "=" + escapeDimension(value), nil
+}
+
 func serializeTags(labels pdata.StringMap, exporterTags []string) string {
 	tags := append([]string{}, exporterTags...)
 	labels.Range(func(k string, v string) bool {
-		key, err := NormalizeString(strings.ToLower(k), maxDimKeyLen)
+		tag, err := SerializeDimension(k, v)
 		if err != nil {
 			return true
 		}
-		value := escapeDimension(v)
-		tag := key + "=" + value
 		tags = append(tags, tag)
 		return true
 	})
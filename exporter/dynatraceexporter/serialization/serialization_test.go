@@ -386,6 +386,42 @@ func Test_serializeTags(t *testing.T) {
 	}
 }
 
+func TestSerializeMetadata(t *testing.T) {
+	type args struct {
+		name        string
+		unit        string
+		description string
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "No unit or description",
+			args: args{name: "my_int_gauge"},
+			want: "",
+		},
+		{
+			name: "Unit",
+			args: args{name: "my_int_gauge", unit: "By"},
+			want: "#my_int_gauge gauge dt.meta.unit=\"By\"",
+		},
+		{
+			name: "Unit and description",
+			args: args{name: "my_int_gauge", unit: "ms", description: "The request duration"},
+			want: "#my_int_gauge gauge dt.meta.unit=\"ms\",dt.meta.description=\"The request duration\"",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SerializeMetadata(tt.args.name, tt.args.unit, tt.args.description); got != tt.want {
+				t.Errorf("SerializeMetadata() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestNormalizeString(t *testing.T) {
 	type args struct {
 		str string
@@ -35,6 +35,10 @@ type Config struct {
 	exporterhelper.RetrySettings   `mapstructure:"retry_on_failure"`
 
 	ResourceMappings []ResourceMapping `mapstructure:"resource_mappings"`
+	// GenericResourceFallback maps resources that match neither a configured mapping nor a well-known
+	// monitored resource type to generic_task or generic_node, based on the OpenTelemetry service.* and host.*
+	// resource attributes, instead of the label-less global resource.
+	GenericResourceFallback bool `mapstructure:"generic_resource_fallback"`
 	// GetClientOptions returns additional options to be passed
 	// to the underlying Google Cloud API client.
 	// Must be set programmatically (no support via declarative config).
@@ -51,6 +55,8 @@ type MetricConfig struct {
 
 // ResourceMapping defines mapping of resources from source (OpenCensus) to target (Google Cloud).
 type ResourceMapping struct {
+	// SourceType is the resource type to match. When empty, the mapping applies to every resource
+	// carrying all the required labels of LabelMappings, regardless of its type.
 	SourceType string `mapstructure:"source_type"`
 	TargetType string `mapstructure:"target_type"`
 
@@ -155,9 +155,10 @@ func newGoogleCloudMetricsExporter(cfg *Config, params component.ExporterCreateS
 	if cfg.MetricConfig.SkipCreateMetricDescriptor {
 		options.SkipCMD = true
 	}
-	if len(cfg.ResourceMappings) > 0 {
+	if len(cfg.ResourceMappings) > 0 || cfg.GenericResourceFallback {
 		rm := resourceMapper{
-			mappings: cfg.ResourceMappings,
+			mappings:        cfg.ResourceMappings,
+			genericFallback: cfg.GenericResourceFallback,
 		}
 		options.MapResource = rm.mapResource
 	}
@@ -17,16 +17,24 @@ package googlecloudexporter
 import (
 	"contrib.go.opencensus.io/exporter/stackdriver"
 	"go.opencensus.io/resource"
+	"go.opentelemetry.io/collector/translator/conventions"
 	monitoredrespb "google.golang.org/genproto/googleapis/api/monitoredres"
 )
 
+const (
+	genericTaskType = "generic_task"
+	genericNodeType = "generic_node"
+	globalType      = "global"
+)
+
 type resourceMapper struct {
-	mappings []ResourceMapping
+	mappings        []ResourceMapping
+	genericFallback bool
 }
 
 func (mr *resourceMapper) mapResource(res *resource.Resource) *monitoredrespb.MonitoredResource {
 	for _, mapping := range mr.mappings {
-		if res.Type != mapping.SourceType {
+		if mapping.SourceType != "" && res.Type != mapping.SourceType {
 			continue
 		}
 
@@ -53,7 +61,63 @@ func (mr *resourceMapper) mapResource(res *resource.Resource) *monitoredrespb.Mo
 	}
 
 	// Keep original behavior by default
-	return stackdriver.DefaultMapResource(res)
+	result := stackdriver.DefaultMapResource(res)
+	if mr.genericFallback && result.Type == globalType {
+		if generic := mapGenericResource(res.Labels); generic != nil {
+			if projectID, ok := result.Labels["project_id"]; ok {
+				generic.Labels["project_id"] = projectID
+			}
+			return generic
+		}
+	}
+	return result
+}
+
+// mapGenericResource maps the OpenTelemetry resource attributes to a generic_task monitored resource when the
+// service is identifiable, or to a generic_node when only the host is. Returns nil when neither is possible.
+func mapGenericResource(labels map[string]string) *monitoredrespb.MonitoredResource {
+	location := firstLabel(labels, conventions.AttributeCloudAvailabilityZone, "cloud.zone", conventions.AttributeCloudRegion)
+	if location == "" {
+		location = globalType
+	}
+	namespace := labels[conventions.AttributeServiceNamespace]
+
+	job := labels[conventions.AttributeServiceName]
+	taskID := firstLabel(labels, conventions.AttributeServiceInstance, conventions.AttributeHostName, conventions.AttributeHostID)
+	if job != "" && taskID != "" {
+		return &monitoredrespb.MonitoredResource{
+			Type: genericTaskType,
+			Labels: map[string]string{
+				"location":  location,
+				"namespace": namespace,
+				"job":       job,
+				"task_id":   taskID,
+			},
+		}
+	}
+
+	if nodeID := firstLabel(labels, conventions.AttributeHostID, conventions.AttributeHostName); nodeID != "" {
+		return &monitoredrespb.MonitoredResource{
+			Type: genericNodeType,
+			Labels: map[string]string{
+				"location":  location,
+				"namespace": namespace,
+				"node_id":   nodeID,
+			},
+		}
+	}
+
+	return nil
+}
+
+// firstLabel returns the value of the first non-empty label out of keys.
+func firstLabel(labels map[string]string, keys ...string) string {
+	for _, key := range keys {
+		if v := labels[key]; v != "" {
+			return v
+		}
+	}
+	return ""
 }
 
 // transformLabels transforms labels according to the configured mappings.
@@ -187,3 +187,125 @@ func TestResourceMapper(t *testing.T) {
 		})
 	}
 }
+
+func TestResourceMapperWithoutSourceType(t *testing.T) {
+	rm := resourceMapper{
+		mappings: []ResourceMapping{
+			{
+				TargetType: "generic_node",
+				LabelMappings: []LabelMapping{
+					{SourceKey: "host.name", TargetKey: "node_id"},
+					{SourceKey: "cloud.region", TargetKey: "location"},
+					{SourceKey: "service.namespace", TargetKey: "namespace", Optional: true},
+				},
+			},
+		},
+	}
+
+	result := rm.mapResource(&resource.Resource{
+		Type: "host",
+		Labels: map[string]string{
+			"host.name":    "node1",
+			"cloud.region": "us-east1",
+		},
+	})
+	assert.Equal(t, "generic_node", result.Type)
+	assert.EqualValues(t, map[string]string{"node_id": "node1", "location": "us-east1"}, result.Labels)
+
+	// A required label is missing, so the default mapping applies
+	result = rm.mapResource(&resource.Resource{
+		Type:   "host",
+		Labels: map[string]string{"host.name": "node1"},
+	})
+	assert.Equal(t, "global", result.Type)
+}
+
+func TestResourceMapperGenericFallback(t *testing.T) {
+	rm := resourceMapper{genericFallback: true}
+
+	tests := []struct {
+		name           string
+		sourceResource *resource.Resource
+		wantResource   *monitoredres.MonitoredResource
+	}{
+		{
+			name: "Service maps to generic_task",
+			sourceResource: &resource.Resource{
+				Labels: map[string]string{
+					"contrib.opencensus.io/exporter/stackdriver/project_id": "123",
+					"service.name":            "checkout",
+					"service.namespace":       "shop",
+					"service.instance.id":     "instance1",
+					"host.name":               "host1",
+					"cloud.availability_zone": "us-east1-b",
+				},
+			},
+			wantResource: &monitoredres.MonitoredResource{
+				Type: "generic_task",
+				Labels: map[string]string{
+					"project_id": "123",
+					"location":   "us-east1-b",
+					"namespace":  "shop",
+					"job":        "checkout",
+					"task_id":    "instance1",
+				},
+			},
+		},
+		{
+			name: "Service without instance id uses the host name as task id",
+			sourceResource: &resource.Resource{
+				Labels: map[string]string{
+					"service.name": "checkout",
+					"host.name":    "host1",
+					"cloud.region": "us-east1",
+				},
+			},
+			wantResource: &monitoredres.MonitoredResource{
+				Type: "generic_task",
+				Labels: map[string]string{
+					"location":  "us-east1",
+					"namespace": "",
+					"job":       "checkout",
+					"task_id":   "host1",
+				},
+			},
+		},
+		{
+			name: "Host maps to generic_node",
+			sourceResource: &resource.Resource{
+				Labels: map[string]string{
+					"host.id":   "i-123",
+					"host.name": "host1",
+				},
+			},
+			wantResource: &monitoredres.MonitoredResource{
+				Type: "generic_node",
+				Labels: map[string]string{
+					"location":  "global",
+					"namespace": "",
+					"node_id":   "i-123",
+				},
+			},
+		},
+		{
+			name: "Unidentifiable resource stays global",
+			sourceResource: &resource.Resource{
+				Labels: map[string]string{
+					"service.name": "checkout",
+				},
+			},
+			wantResource: &monitoredres.MonitoredResource{
+				Type: "global",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := rm.mapResource(tt.sourceResource)
+			require.NotNil(t, result)
+			assert.Equal(t, tt.wantResource.Type, result.Type)
+			assert.EqualValues(t, tt.wantResource.Labels, result.Labels)
+		})
+	}
+}
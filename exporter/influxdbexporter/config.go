@@ -15,6 +15,8 @@
 package influxdbexporter
 
 import (
+	"fmt"
+
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
@@ -23,6 +25,12 @@ import (
 const (
 	// The value of "type" key in configuration.
 	typeStr = "influxdb"
+
+	apiVersionV2 = "v2"
+	apiVersionV3 = "v3"
+
+	compressionNone = "none"
+	compressionGzip = "gzip"
 )
 
 // Config defines configuration for the InfluxDB exporter.
@@ -32,9 +40,15 @@ type Config struct {
 	exporterhelper.QueueSettings  `mapstructure:"sending_queue"`
 	exporterhelper.RetrySettings  `mapstructure:"retry_on_failure"`
 
+	// APIVersion selects the write API of the destination. Options:
+	// - v2: the InfluxDB 2.x /api/v2/write API
+	// - v3: the InfluxDB 3 (IOx) /api/v3/write_lp API
+	APIVersion string `mapstructure:"api_version"`
+
 	// Org is the InfluxDB organization name of the destination bucket.
 	Org string `mapstructure:"org"`
 	// Bucket is the InfluxDB bucket name that telemetry will be written to.
+	// With the v3 API, it is the name of the database.
 	Bucket string `mapstructure:"bucket"`
 	// Token is used to identify InfluxDB permissions within the organization.
 	Token string `mapstructure:"token"`
@@ -43,5 +57,40 @@ type Config struct {
 	// Options:
 	// - telegraf-prometheus-v1
 	// - telegraf-prometheus-v2
+	// - otel-native
 	MetricsSchema string `mapstructure:"metrics_schema"`
+
+	// Compression is the content encoding of the line protocol payloads, either "gzip" or "none".
+	Compression string `mapstructure:"compression"`
+	// PayloadMaxLines is the maximum number of lines written in a single request, 0 means no limit.
+	PayloadMaxLines int `mapstructure:"payload_max_lines"`
+	// PayloadMaxBytes is the maximum size of the uncompressed line protocol written in a
+	// single request, 0 means no limit.
+	PayloadMaxBytes int `mapstructure:"payload_max_bytes"`
+}
+
+var _ config.Exporter = (*Config)(nil)
+
+// Validate checks if the exporter configuration is valid.
+func (cfg *Config) Validate() error {
+	switch cfg.APIVersion {
+	case apiVersionV2, apiVersionV3:
+	default:
+		return fmt.Errorf("api_version '%s' not recognized", cfg.APIVersion)
+	}
+	if _, found := metricsSchemata[cfg.MetricsSchema]; !found && cfg.MetricsSchema != metricsSchemaOtelNative {
+		return fmt.Errorf("schema '%s' not recognized", cfg.MetricsSchema)
+	}
+	switch cfg.Compression {
+	case "", compressionNone, compressionGzip:
+	default:
+		return fmt.Errorf("compression '%s' not recognized", cfg.Compression)
+	}
+	if cfg.PayloadMaxLines < 0 {
+		return fmt.Errorf("payload_max_lines must not be negative")
+	}
+	if cfg.PayloadMaxBytes < 0 {
+		return fmt.Errorf("payload_max_bytes must not be negative")
+	}
+	return nil
 }
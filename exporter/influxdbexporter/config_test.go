@@ -61,9 +61,80 @@ func TestLoadConfig(t *testing.T) {
 			MaxInterval:     3 * time.Second,
 			MaxElapsedTime:  10 * time.Second,
 		},
-		Org:           "my-org",
-		Bucket:        "my-bucket",
-		Token:         "my-token",
-		MetricsSchema: "telegraf-prometheus-v2",
+		APIVersion:      "v2",
+		Org:             "my-org",
+		Bucket:          "my-bucket",
+		Token:           "my-token",
+		MetricsSchema:   "telegraf-prometheus-v2",
+		Compression:     "gzip",
+		PayloadMaxLines: 10_000,
+		PayloadMaxBytes: 10_000_000,
 	})
+
+	configV3 := cfg.Exporters[config.NewIDWithName(typeStr, "v3")].(*Config)
+	expectedV3 := factory.CreateDefaultConfig().(*Config)
+	expectedV3.ExporterSettings = config.NewExporterSettings(config.NewIDWithName(typeStr, "v3"))
+	expectedV3.Endpoint = "http://localhost:8181"
+	expectedV3.APIVersion = "v3"
+	expectedV3.Bucket = "my-database"
+	expectedV3.Token = "my-token"
+	expectedV3.MetricsSchema = "otel-native"
+	expectedV3.Compression = "none"
+	expectedV3.PayloadMaxLines = 100
+	expectedV3.PayloadMaxBytes = 1000
+	assert.Equal(t, expectedV3, configV3)
+}
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*Config)
+		err    string
+	}{
+		{
+			name:   "default",
+			modify: func(cfg *Config) {},
+		},
+		{
+			name:   "v3 otel-native",
+			modify: func(cfg *Config) { cfg.APIVersion = "v3"; cfg.MetricsSchema = "otel-native" },
+		},
+		{
+			name:   "unknown api version",
+			modify: func(cfg *Config) { cfg.APIVersion = "v1" },
+			err:    "api_version 'v1' not recognized",
+		},
+		{
+			name:   "unknown schema",
+			modify: func(cfg *Config) { cfg.MetricsSchema = "otel" },
+			err:    "schema 'otel' not recognized",
+		},
+		{
+			name:   "unknown compression",
+			modify: func(cfg *Config) { cfg.Compression = "zstd" },
+			err:    "compression 'zstd' not recognized",
+		},
+		{
+			name:   "negative payload max lines",
+			modify: func(cfg *Config) { cfg.PayloadMaxLines = -1 },
+			err:    "payload_max_lines must not be negative",
+		},
+		{
+			name:   "negative payload max bytes",
+			modify: func(cfg *Config) { cfg.PayloadMaxBytes = -1 },
+			err:    "payload_max_bytes must not be negative",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			tt.modify(cfg)
+			err := cfg.Validate()
+			if tt.err == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.err)
+		})
+	}
 }
@@ -52,7 +52,7 @@ func (e *tracesExporter) pushTraces(ctx context.Context, td pdata.Traces) error
 	}
 	err = e.converter.WriteTracesFromRequestBytes(ctx, protoBytes, batch)
 	if err != nil {
-		return consumererror.Permanent(err)
+		return batch.conversionError(err)
 	}
 	return batch.flushAndClose(ctx)
 }
@@ -73,9 +73,16 @@ type metricsExporter struct {
 	logger    common.Logger
 	cfg       *Config
 	writer    *influxHTTPWriter
-	converter *otel2influx.OtelMetricsToLineProtocol
+	converter metricsConverter
 }
 
+// metricsConverter writes metrics as line protocol points to an InfluxWriter.
+type metricsConverter interface {
+	writeMetrics(ctx context.Context, md pdata.Metrics, w otel2influx.InfluxWriter) error
+}
+
+const metricsSchemaOtelNative = "otel-native"
+
 var metricsSchemata = map[string]common.MetricsSchema{
 	"telegraf-prometheus-v1": common.MetricsSchemaTelegrafPrometheusV1,
 	"telegraf-prometheus-v2": common.MetricsSchemaTelegrafPrometheusV2,
@@ -83,14 +90,21 @@ var metricsSchemata = map[string]common.MetricsSchema{
 
 func newMetricsExporter(config *Config, params component.ExporterCreateSettings) (*metricsExporter, error) {
 	logger := newZapInfluxLogger(params.Logger)
-	schema, found := metricsSchemata[config.MetricsSchema]
-	if !found {
-		return nil, fmt.Errorf("schema '%s' not recognized", config.MetricsSchema)
-	}
 
-	converter, err := otel2influx.NewOtelMetricsToLineProtocol(logger, schema)
-	if err != nil {
-		return nil, err
+	var converter metricsConverter
+	if config.MetricsSchema == metricsSchemaOtelNative {
+		// The otel-native schema is not provided by otel2influx.
+		converter = &otelNativeMetricsConverter{logger: logger}
+	} else {
+		schema, found := metricsSchemata[config.MetricsSchema]
+		if !found {
+			return nil, fmt.Errorf("schema '%s' not recognized", config.MetricsSchema)
+		}
+		otelConverter, err := otel2influx.NewOtelMetricsToLineProtocol(logger, schema)
+		if err != nil {
+			return nil, err
+		}
+		converter = &telegrafMetricsConverter{converter: otelConverter}
 	}
 
 	return &metricsExporter{
@@ -103,15 +117,24 @@ func newMetricsExporter(config *Config, params component.ExporterCreateSettings)
 func (e *metricsExporter) pushMetrics(ctx context.Context, md pdata.Metrics) error {
 	batch := e.writer.newBatch()
 
-	protoBytes, err := md.ToOtlpProtoBytes()
+	err := e.converter.writeMetrics(ctx, md, batch)
 	if err != nil {
-		return consumererror.Permanent(err)
+		return batch.conversionError(err)
 	}
-	err = e.converter.WriteMetricsFromRequestBytes(ctx, protoBytes, batch)
+	return batch.flushAndClose(ctx)
+}
+
+// telegrafMetricsConverter converts metrics with the Telegraf Prometheus schemata of otel2influx.
+type telegrafMetricsConverter struct {
+	converter *otel2influx.OtelMetricsToLineProtocol
+}
+
+func (c *telegrafMetricsConverter) writeMetrics(ctx context.Context, md pdata.Metrics, w otel2influx.InfluxWriter) error {
+	protoBytes, err := md.ToOtlpProtoBytes()
 	if err != nil {
-		return consumererror.Permanent(err)
+		return err
 	}
-	return batch.flushAndClose(ctx)
+	return c.converter.WriteMetricsFromRequestBytes(ctx, protoBytes, w)
 }
 
 // start starts the metrics exporter
@@ -153,7 +176,7 @@ func (e *logsExporter) pushLogs(ctx context.Context, ld pdata.Logs) error {
 	}
 	err = e.converter.WriteLogsFromRequestBytes(ctx, protoBytes, batch)
 	if err != nil {
-		return consumererror.Permanent(err)
+		return batch.conversionError(err)
 	}
 	return batch.flushAndClose(ctx)
 }
@@ -92,8 +92,12 @@ func createDefaultConfig() config.Exporter {
 				"User-Agent": "OpenTelemetry -> Influx",
 			},
 		},
-		QueueSettings: exporterhelper.DefaultQueueSettings(),
-		RetrySettings: exporterhelper.DefaultRetrySettings(),
-		MetricsSchema: "telegraf-prometheus-v1",
+		QueueSettings:   exporterhelper.DefaultQueueSettings(),
+		RetrySettings:   exporterhelper.DefaultRetrySettings(),
+		APIVersion:      apiVersionV2,
+		MetricsSchema:   "telegraf-prometheus-v1",
+		Compression:     compressionGzip,
+		PayloadMaxLines: 10_000,
+		PayloadMaxBytes: 10_000_000,
 	}
 }
@@ -0,0 +1,208 @@
+// Copyright 2021, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package influxdbexporter
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/influxdata/influxdb-observability/common"
+	"github.com/influxdata/influxdb-observability/otel2influx"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	tracetranslator "go.opentelemetry.io/collector/translator/trace"
+)
+
+const (
+	otelNativeValueFieldKey          = "value"
+	otelNativeStartTimeFieldKey      = "start_time_unix_nano"
+	otelNativeBucketFieldKeyPrefix   = "bucket_"
+	otelNativeQuantileFieldKeyPrefix = "quantile_"
+)
+
+// otelNativeMetricsConverter writes every metric to the measurement named after it, with a
+// fixed set of fields per metric type, so that each measurement maps onto an InfluxDB 3 (IOx)
+// table with a stable schema:
+//   - gauge and sum points have the field "value"
+//   - histogram points have the fields "count", "sum" and one "bucket_<upper bound>" field per
+//     bucket, holding the count of the bucket as in OTLP (not cumulative)
+//   - summary points have the fields "count", "sum" and one "quantile_<quantile>" field per quantile
+//
+// Points with a start time also have the field "start_time_unix_nano".
+// Resource attributes, instrumentation library name and version, and point labels are tags.
+type otelNativeMetricsConverter struct {
+	logger common.Logger
+}
+
+func (c *otelNativeMetricsConverter) writeMetrics(ctx context.Context, md pdata.Metrics, w otel2influx.InfluxWriter) error {
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		resourceTags := make(map[string]string)
+		rm.Resource().Attributes().Range(func(k string, v pdata.AttributeValue) bool {
+			resourceTags[k] = tracetranslator.AttributeValueToString(v)
+			return true
+		})
+
+		ilms := rm.InstrumentationLibraryMetrics()
+		for j := 0; j < ilms.Len(); j++ {
+			ilm := ilms.At(j)
+			libraryTags := make(map[string]string, len(resourceTags)+2)
+			for k, v := range resourceTags {
+				libraryTags[k] = v
+			}
+			if name := ilm.InstrumentationLibrary().Name(); name != "" {
+				libraryTags[common.AttributeInstrumentationLibraryName] = name
+			}
+			if version := ilm.InstrumentationLibrary().Version(); version != "" {
+				libraryTags[common.AttributeInstrumentationLibraryVersion] = version
+			}
+
+			metrics := ilm.Metrics()
+			for k := 0; k < metrics.Len(); k++ {
+				if err := c.writeMetric(ctx, metrics.At(k), libraryTags, w); err != nil {
+					return fmt.Errorf("failed to convert OTLP metric to line protocol: %w", err)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (c *otelNativeMetricsConverter) writeMetric(ctx context.Context, metric pdata.Metric, baseTags map[string]string, w otel2influx.InfluxWriter) error {
+	measurement := metric.Name()
+
+	switch metric.DataType() {
+	case pdata.MetricDataTypeIntGauge:
+		dps := metric.IntGauge().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			fields := map[string]interface{}{otelNativeValueFieldKey: dp.Value()}
+			if err := c.writePoint(ctx, w, measurement, baseTags, dp.LabelsMap(), fields, dp.StartTimestamp(), dp.Timestamp(), common.InfluxMetricValueTypeGauge); err != nil {
+				return err
+			}
+		}
+	case pdata.MetricDataTypeDoubleGauge:
+		dps := metric.DoubleGauge().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			fields := map[string]interface{}{otelNativeValueFieldKey: dp.Value()}
+			if err := c.writePoint(ctx, w, measurement, baseTags, dp.LabelsMap(), fields, dp.StartTimestamp(), dp.Timestamp(), common.InfluxMetricValueTypeGauge); err != nil {
+				return err
+			}
+		}
+	case pdata.MetricDataTypeIntSum:
+		dps := metric.IntSum().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			fields := map[string]interface{}{otelNativeValueFieldKey: dp.Value()}
+			if err := c.writePoint(ctx, w, measurement, baseTags, dp.LabelsMap(), fields, dp.StartTimestamp(), dp.Timestamp(), common.InfluxMetricValueTypeSum); err != nil {
+				return err
+			}
+		}
+	case pdata.MetricDataTypeDoubleSum:
+		dps := metric.DoubleSum().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			fields := map[string]interface{}{otelNativeValueFieldKey: dp.Value()}
+			if err := c.writePoint(ctx, w, measurement, baseTags, dp.LabelsMap(), fields, dp.StartTimestamp(), dp.Timestamp(), common.InfluxMetricValueTypeSum); err != nil {
+				return err
+			}
+		}
+	case pdata.MetricDataTypeIntHistogram:
+		dps := metric.IntHistogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			fields := histogramFields(dp.Count(), dp.Sum(), dp.ExplicitBounds(), dp.BucketCounts())
+			if err := c.writePoint(ctx, w, measurement, baseTags, dp.LabelsMap(), fields, dp.StartTimestamp(), dp.Timestamp(), common.InfluxMetricValueTypeHistogram); err != nil {
+				return err
+			}
+		}
+	case pdata.MetricDataTypeHistogram:
+		dps := metric.Histogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			fields := histogramFields(dp.Count(), dp.Sum(), dp.ExplicitBounds(), dp.BucketCounts())
+			if err := c.writePoint(ctx, w, measurement, baseTags, dp.LabelsMap(), fields, dp.StartTimestamp(), dp.Timestamp(), common.InfluxMetricValueTypeHistogram); err != nil {
+				return err
+			}
+		}
+	case pdata.MetricDataTypeSummary:
+		dps := metric.Summary().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			fields := map[string]interface{}{
+				common.MetricSummaryCountFieldKey: dp.Count(),
+				common.MetricSummarySumFieldKey:   dp.Sum(),
+			}
+			qvs := dp.QuantileValues()
+			for j := 0; j < qvs.Len(); j++ {
+				qv := qvs.At(j)
+				fields[otelNativeQuantileFieldKeyPrefix+formatFloat(qv.Quantile())] = qv.Value()
+			}
+			if err := c.writePoint(ctx, w, measurement, baseTags, dp.LabelsMap(), fields, dp.StartTimestamp(), dp.Timestamp(), common.InfluxMetricValueTypeSummary); err != nil {
+				return err
+			}
+		}
+	default:
+		c.logger.Debug("metric type not supported", "name", measurement, "type", metric.DataType().String())
+	}
+	return nil
+}
+
+func (c *otelNativeMetricsConverter) writePoint(ctx context.Context, w otel2influx.InfluxWriter, measurement string, baseTags map[string]string, labels pdata.StringMap, fields map[string]interface{}, start, ts pdata.Timestamp, vType common.InfluxMetricValueType) error {
+	if ts == 0 {
+		c.logger.Debug("metric point has no timestamp", "name", measurement)
+		return nil
+	}
+
+	tags := make(map[string]string, len(baseTags)+labels.Len())
+	for k, v := range baseTags {
+		tags[k] = v
+	}
+	labels.Range(func(k string, v string) bool {
+		tags[k] = v
+		return true
+	})
+
+	if start != 0 {
+		fields[otelNativeStartTimeFieldKey] = int64(start)
+	}
+
+	if err := w.WritePoint(ctx, measurement, tags, fields, time.Unix(0, int64(ts)), vType); err != nil {
+		return fmt.Errorf("failed to write point for %s: %w", vType, err)
+	}
+	return nil
+}
+
+func histogramFields(count uint64, sum interface{}, bounds []float64, bucketCounts []uint64) map[string]interface{} {
+	fields := map[string]interface{}{
+		common.MetricHistogramCountFieldKey: count,
+		common.MetricHistogramSumFieldKey:   sum,
+	}
+	for i, bucketCount := range bucketCounts {
+		bound := common.MetricHistogramInfFieldKey
+		if i < len(bounds) {
+			bound = formatFloat(bounds[i])
+		}
+		fields[otelNativeBucketFieldKeyPrefix+bound] = bucketCount
+	}
+	return fields
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
@@ -0,0 +1,156 @@
+// Copyright 2021, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package influxdbexporter
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/influxdata/influxdb-observability/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.uber.org/zap"
+)
+
+type point struct {
+	measurement string
+	tags        map[string]string
+	fields      map[string]interface{}
+	ts          time.Time
+	vType       common.InfluxMetricValueType
+}
+
+type mockInfluxWriter struct {
+	points []point
+}
+
+func (w *mockInfluxWriter) WritePoint(_ context.Context, measurement string, tags map[string]string, fields map[string]interface{}, ts time.Time, vType common.InfluxMetricValueType) error {
+	w.points = append(w.points, point{measurement: measurement, tags: tags, fields: fields, ts: ts, vType: vType})
+	return nil
+}
+
+func TestOtelNativeMetricsConverter(t *testing.T) {
+	start := pdata.Timestamp(1622505500000000000)
+	ts := pdata.Timestamp(1622505600000000000)
+
+	md := pdata.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().InsertString("service.name", "checkout")
+	ilm := rm.InstrumentationLibraryMetrics().AppendEmpty()
+	ilm.InstrumentationLibrary().SetName("otelcol")
+	ilm.InstrumentationLibrary().SetVersion("v1.0.0")
+
+	gauge := ilm.Metrics().AppendEmpty()
+	gauge.SetName("cpu_temp")
+	gauge.SetDataType(pdata.MetricDataTypeDoubleGauge)
+	gdp := gauge.DoubleGauge().DataPoints().AppendEmpty()
+	gdp.SetTimestamp(ts)
+	gdp.SetValue(87.5)
+	gdp.LabelsMap().Insert("cpu", "0")
+
+	sum := ilm.Metrics().AppendEmpty()
+	sum.SetName("http_requests_total")
+	sum.SetDataType(pdata.MetricDataTypeIntSum)
+	sdp := sum.IntSum().DataPoints().AppendEmpty()
+	sdp.SetStartTimestamp(start)
+	sdp.SetTimestamp(ts)
+	sdp.SetValue(1027)
+
+	histogram := ilm.Metrics().AppendEmpty()
+	histogram.SetName("http_request_duration_seconds")
+	histogram.SetDataType(pdata.MetricDataTypeHistogram)
+	hdp := histogram.Histogram().DataPoints().AppendEmpty()
+	hdp.SetTimestamp(ts)
+	hdp.SetCount(10)
+	hdp.SetSum(2.5)
+	hdp.SetExplicitBounds([]float64{0.1, 0.5})
+	hdp.SetBucketCounts([]uint64{3, 5, 2})
+
+	summary := ilm.Metrics().AppendEmpty()
+	summary.SetName("rpc_duration_seconds")
+	summary.SetDataType(pdata.MetricDataTypeSummary)
+	smdp := summary.Summary().DataPoints().AppendEmpty()
+	smdp.SetTimestamp(ts)
+	smdp.SetCount(4)
+	smdp.SetSum(1.2)
+	qv := smdp.QuantileValues().AppendEmpty()
+	qv.SetQuantile(0.99)
+	qv.SetValue(0.8)
+
+	noTimestamp := ilm.Metrics().AppendEmpty()
+	noTimestamp.SetName("no_timestamp")
+	noTimestamp.SetDataType(pdata.MetricDataTypeDoubleGauge)
+	noTimestamp.DoubleGauge().DataPoints().AppendEmpty().SetValue(1)
+
+	converter := &otelNativeMetricsConverter{logger: newZapInfluxLogger(zap.NewNop())}
+	w := &mockInfluxWriter{}
+	require.NoError(t, converter.writeMetrics(context.Background(), md, w))
+
+	baseTags := map[string]string{
+		"service.name":         "checkout",
+		"otel.library.name":    "otelcol",
+		"otel.library.version": "v1.0.0",
+	}
+	withTags := func(tags map[string]string) map[string]string {
+		for k, v := range baseTags {
+			tags[k] = v
+		}
+		return tags
+	}
+	expectedTime := time.Unix(0, int64(ts))
+
+	assert.Equal(t, []point{
+		{
+			measurement: "cpu_temp",
+			tags:        withTags(map[string]string{"cpu": "0"}),
+			fields:      map[string]interface{}{"value": 87.5},
+			ts:          expectedTime,
+			vType:       common.InfluxMetricValueTypeGauge,
+		},
+		{
+			measurement: "http_requests_total",
+			tags:        withTags(map[string]string{}),
+			fields:      map[string]interface{}{"value": int64(1027), "start_time_unix_nano": int64(start)},
+			ts:          expectedTime,
+			vType:       common.InfluxMetricValueTypeSum,
+		},
+		{
+			measurement: "http_request_duration_seconds",
+			tags:        withTags(map[string]string{}),
+			fields: map[string]interface{}{
+				"count":      uint64(10),
+				"sum":        2.5,
+				"bucket_0.1": uint64(3),
+				"bucket_0.5": uint64(5),
+				"bucket_inf": uint64(2),
+			},
+			ts:    expectedTime,
+			vType: common.InfluxMetricValueTypeHistogram,
+		},
+		{
+			measurement: "rpc_duration_seconds",
+			tags:        withTags(map[string]string{}),
+			fields: map[string]interface{}{
+				"count":         uint64(4),
+				"sum":           1.2,
+				"quantile_0.99": 0.8,
+			},
+			ts:    expectedTime,
+			vType: common.InfluxMetricValueTypeSummary,
+		},
+	}, w.points)
+}
@@ -16,6 +16,7 @@ package influxdbexporter
 
 import (
 	"bytes"
+	"compress/gzip"
 	"context"
 	"fmt"
 	"io/ioutil"
@@ -33,31 +34,31 @@ import (
 
 type influxHTTPWriter struct {
 	encoderPool sync.Pool
+	gzipPool    *sync.Pool
 	httpClient  *http.Client
 	writeURL    string
 
+	payloadMaxLines int
+	payloadMaxBytes int
+
 	logger common.Logger
 }
 
 func newInfluxHTTPWriter(logger common.Logger, config *Config, host component.Host) (*influxHTTPWriter, error) {
-	writeURL, err := url.Parse(config.HTTPClientSettings.Endpoint)
+	writeURL, err := composeWriteURL(config)
 	if err != nil {
 		return nil, err
 	}
-	if writeURL.Path == "" || writeURL.Path == "/" {
-		writeURL, err = writeURL.Parse("api/v2/write")
-		if err != nil {
-			return nil, err
-		}
-	}
-	queryValues := writeURL.Query()
-	queryValues.Set("org", config.Org)
-	queryValues.Set("bucket", config.Bucket)
-	queryValues.Set("precision", "ns")
-	writeURL.RawQuery = queryValues.Encode()
 
 	if config.Token != "" {
-		config.HTTPClientSettings.Headers["Authorization"] = "Token " + config.Token
+		if config.HTTPClientSettings.Headers == nil {
+			config.HTTPClientSettings.Headers = make(map[string]string)
+		}
+		if config.APIVersion == apiVersionV3 {
+			config.HTTPClientSettings.Headers["Authorization"] = "Bearer " + config.Token
+		} else {
+			config.HTTPClientSettings.Headers["Authorization"] = "Token " + config.Token
+		}
 	}
 
 	httpClient, err := config.HTTPClientSettings.ToClient(host.GetExtensions())
@@ -65,6 +66,15 @@ func newInfluxHTTPWriter(logger common.Logger, config *Config, host component.Ho
 		return nil, err
 	}
 
+	var gzipPool *sync.Pool
+	if config.Compression == compressionGzip {
+		gzipPool = &sync.Pool{
+			New: func() interface{} {
+				return gzip.NewWriter(nil)
+			},
+		}
+	}
+
 	return &influxHTTPWriter{
 		encoderPool: sync.Pool{
 			New: func() interface{} {
@@ -74,12 +84,47 @@ func newInfluxHTTPWriter(logger common.Logger, config *Config, host component.Ho
 				return e
 			},
 		},
-		httpClient: httpClient,
-		writeURL:   writeURL.String(),
-		logger:     logger,
+		gzipPool:        gzipPool,
+		httpClient:      httpClient,
+		writeURL:        writeURL,
+		payloadMaxLines: config.PayloadMaxLines,
+		payloadMaxBytes: config.PayloadMaxBytes,
+		logger:          logger,
 	}, nil
 }
 
+// composeWriteURL returns the URL of the write API selected by the configuration.
+// The endpoint path is kept when set, so that proxies with custom routes can be used.
+func composeWriteURL(config *Config) (string, error) {
+	writeURL, err := url.Parse(config.HTTPClientSettings.Endpoint)
+	if err != nil {
+		return "", err
+	}
+
+	writePath := "api/v2/write"
+	if config.APIVersion == apiVersionV3 {
+		writePath = "api/v3/write_lp"
+	}
+	if writeURL.Path == "" || writeURL.Path == "/" {
+		writeURL, err = writeURL.Parse(writePath)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	queryValues := writeURL.Query()
+	if config.APIVersion == apiVersionV3 {
+		queryValues.Set("db", config.Bucket)
+		queryValues.Set("precision", "nanosecond")
+	} else {
+		queryValues.Set("org", config.Org)
+		queryValues.Set("bucket", config.Bucket)
+		queryValues.Set("precision", "ns")
+	}
+	writeURL.RawQuery = queryValues.Encode()
+	return writeURL.String(), nil
+}
+
 func (w *influxHTTPWriter) newBatch() *influxHTTPWriterBatch {
 	return &influxHTTPWriterBatch{
 		w:       w,
@@ -89,14 +134,19 @@ func (w *influxHTTPWriter) newBatch() *influxHTTPWriterBatch {
 }
 
 type influxHTTPWriterBatch struct {
-	w       *influxHTTPWriter
-	encoder *lineprotocol.Encoder
-	logger  common.Logger
+	w         *influxHTTPWriter
+	encoder   *lineprotocol.Encoder
+	lineCount int
+	logger    common.Logger
+
+	// flushErr is the error of the last write request sent by WritePoint when the payload limits are reached.
+	flushErr error
 }
 
 // WritePoint emits a set of line protocol attributes (metrics, tags, fields, timestamp)
 // to the internal line protocol buffer. This method implements otel2influx.InfluxWriter.
-func (b *influxHTTPWriterBatch) WritePoint(_ context.Context, measurement string, tags map[string]string, fields map[string]interface{}, ts time.Time, _ common.InfluxMetricValueType) error {
+// The buffered lines are written to InfluxDB once the payload limits are reached.
+func (b *influxHTTPWriterBatch) WritePoint(ctx context.Context, measurement string, tags map[string]string, fields map[string]interface{}, ts time.Time, _ common.InfluxMetricValueType) error {
 	b.encoder.StartLine(measurement)
 	for _, tag := range b.sortTags(tags) {
 		b.encoder.AddTag(tag.k, tag.v)
@@ -110,16 +160,62 @@ func (b *influxHTTPWriterBatch) WritePoint(_ context.Context, measurement string
 		defer b.encoder.ClearErr()
 		return consumererror.Permanent(fmt.Errorf("failed to encode point: %w", err))
 	}
+	b.lineCount++
+
+	if (b.w.payloadMaxLines > 0 && b.lineCount >= b.w.payloadMaxLines) ||
+		(b.w.payloadMaxBytes > 0 && len(b.encoder.Bytes()) >= b.w.payloadMaxBytes) {
+		if err := b.flush(ctx); err != nil {
+			b.flushErr = err
+			return err
+		}
+	}
 
 	return nil
 }
 
+// conversionError returns the error to report when the conversion of the data to line
+// protocol failed with err. Conversion errors are permanent, unless caused by a failed
+// write request.
+func (b *influxHTTPWriterBatch) conversionError(err error) error {
+	if b.flushErr != nil {
+		return b.flushErr
+	}
+	return consumererror.Permanent(err)
+}
+
 func (b *influxHTTPWriterBatch) flushAndClose(ctx context.Context) error {
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, b.w.writeURL, bytes.NewReader(b.encoder.Bytes()))
+	if b.lineCount > 0 {
+		if err := b.flush(ctx); err != nil {
+			return err
+		}
+	}
+
+	b.encoder.Reset()
+	b.w.encoderPool.Put(b.encoder)
+
+	// Caller has a reference to this batch; don't let the caller keep references to its members.
+	b.encoder = nil
+	b.logger = nil
+	b.w = nil
+	return nil
+}
+
+// flush writes the buffered lines to InfluxDB and resets the buffer.
+func (b *influxHTTPWriterBatch) flush(ctx context.Context) error {
+	body, err := b.payload()
 	if err != nil {
 		return consumererror.Permanent(err)
 	}
 
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, b.w.writeURL, bytes.NewReader(body))
+	if err != nil {
+		return consumererror.Permanent(err)
+	}
+	req.Header.Set("Content-Type", "text/plain; charset=utf-8")
+	if b.w.gzipPool != nil {
+		req.Header.Set("Content-Encoding", "gzip")
+	}
+
 	if res, err := b.w.httpClient.Do(req); err != nil {
 		return err
 	} else if body, err := ioutil.ReadAll(res.Body); err != nil {
@@ -138,15 +234,29 @@ func (b *influxHTTPWriterBatch) flushAndClose(ctx context.Context) error {
 	}
 
 	b.encoder.Reset()
-	b.w.encoderPool.Put(b.encoder)
-
-	// Caller has a reference to this batch; don't let the caller keep references to its members.
-	b.encoder = nil
-	b.logger = nil
-	b.w = nil
+	b.lineCount = 0
 	return nil
 }
 
+// payload returns the buffered lines, compressed if configured.
+func (b *influxHTTPWriterBatch) payload() ([]byte, error) {
+	if b.w.gzipPool == nil {
+		return b.encoder.Bytes(), nil
+	}
+
+	buf := new(bytes.Buffer)
+	gzipWriter := b.w.gzipPool.Get().(*gzip.Writer)
+	defer b.w.gzipPool.Put(gzipWriter)
+	gzipWriter.Reset(buf)
+	if _, err := gzipWriter.Write(b.encoder.Bytes()); err != nil {
+		return nil, fmt.Errorf("failed to compress line protocol: %w", err)
+	}
+	if err := gzipWriter.Close(); err != nil {
+		return nil, fmt.Errorf("failed to compress line protocol: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
 type tag struct {
 	k, v string
 }
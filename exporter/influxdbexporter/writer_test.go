@@ -0,0 +1,194 @@
+// Copyright 2021, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package influxdbexporter
+
+import (
+	"compress/gzip"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.uber.org/zap"
+)
+
+type writeRequest struct {
+	path, query, authorization, contentEncoding string
+	body                                        string
+}
+
+func newWriteServer(t *testing.T, status int) (*httptest.Server, func() []writeRequest) {
+	var mu sync.Mutex
+	var requests []writeRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body := r.Body
+		if r.Header.Get("Content-Encoding") == "gzip" {
+			gzipReader, err := gzip.NewReader(r.Body)
+			require.NoError(t, err)
+			body = gzipReader
+		}
+		b, err := ioutil.ReadAll(body)
+		require.NoError(t, err)
+
+		mu.Lock()
+		requests = append(requests, writeRequest{
+			path:            r.URL.Path,
+			query:           r.URL.RawQuery,
+			authorization:   r.Header.Get("Authorization"),
+			contentEncoding: r.Header.Get("Content-Encoding"),
+			body:            string(b),
+		})
+		mu.Unlock()
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(server.Close)
+	return server, func() []writeRequest {
+		mu.Lock()
+		defer mu.Unlock()
+		return requests
+	}
+}
+
+func newTestWriter(t *testing.T, cfg *Config) *influxHTTPWriter {
+	writer, err := newInfluxHTTPWriter(newZapInfluxLogger(zap.NewNop()), cfg, componenttest.NewNopHost())
+	require.NoError(t, err)
+	return writer
+}
+
+func TestComposeWriteURL(t *testing.T) {
+	tests := []struct {
+		name     string
+		modify   func(*Config)
+		expected string
+	}{
+		{
+			name:     "v2",
+			modify:   func(cfg *Config) {},
+			expected: "http://localhost:8086/api/v2/write?bucket=my-bucket&org=my-org&precision=ns",
+		},
+		{
+			name:     "v3",
+			modify:   func(cfg *Config) { cfg.APIVersion = apiVersionV3 },
+			expected: "http://localhost:8086/api/v3/write_lp?db=my-bucket&precision=nanosecond",
+		},
+		{
+			name:     "custom path",
+			modify:   func(cfg *Config) { cfg.APIVersion = apiVersionV3; cfg.Endpoint = "http://localhost:8086/influx/write" },
+			expected: "http://localhost:8086/influx/write?db=my-bucket&precision=nanosecond",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.Endpoint = "http://localhost:8086"
+			cfg.Org = "my-org"
+			cfg.Bucket = "my-bucket"
+			tt.modify(cfg)
+			writeURL, err := composeWriteURL(cfg)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, writeURL)
+		})
+	}
+}
+
+func TestWriterV3Gzip(t *testing.T) {
+	server, requests := newWriteServer(t, http.StatusNoContent)
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = server.URL
+	cfg.APIVersion = apiVersionV3
+	cfg.Bucket = "my-database"
+	cfg.Token = "my-token"
+	writer := newTestWriter(t, cfg)
+
+	batch := writer.newBatch()
+	ts := time.Unix(0, 1622505600000000000)
+	require.NoError(t, batch.WritePoint(context.Background(), "cpu_temp", map[string]string{"host": "a"}, map[string]interface{}{"value": 87.5}, ts, 0))
+	require.NoError(t, batch.flushAndClose(context.Background()))
+
+	reqs := requests()
+	require.Len(t, reqs, 1)
+	assert.Equal(t, "/api/v3/write_lp", reqs[0].path)
+	assert.Equal(t, "db=my-database&precision=nanosecond", reqs[0].query)
+	assert.Equal(t, "Bearer my-token", reqs[0].authorization)
+	assert.Equal(t, "gzip", reqs[0].contentEncoding)
+	assert.Equal(t, "cpu_temp,host=a value=87.5 1622505600000000000\n", reqs[0].body)
+}
+
+func TestWriterPayloadMaxLines(t *testing.T) {
+	server, requests := newWriteServer(t, http.StatusNoContent)
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = server.URL
+	cfg.Token = "my-token"
+	cfg.Compression = compressionNone
+	cfg.PayloadMaxLines = 2
+	writer := newTestWriter(t, cfg)
+
+	batch := writer.newBatch()
+	ts := time.Unix(0, 1622505600000000000)
+	for i := 0; i < 5; i++ {
+		require.NoError(t, batch.WritePoint(context.Background(), "m", nil, map[string]interface{}{"value": int64(i)}, ts, 0))
+	}
+	require.NoError(t, batch.flushAndClose(context.Background()))
+
+	reqs := requests()
+	require.Len(t, reqs, 3)
+	for _, req := range reqs {
+		assert.Equal(t, "/api/v2/write", req.path)
+		assert.Equal(t, "Token my-token", req.authorization)
+		assert.Empty(t, req.contentEncoding)
+	}
+	assert.Equal(t, 2, strings.Count(reqs[0].body, "\n"))
+	assert.Equal(t, 2, strings.Count(reqs[1].body, "\n"))
+	assert.Equal(t, "m value=4i 1622505600000000000\n", reqs[2].body)
+}
+
+func TestWriterErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		status    int
+		permanent bool
+	}{
+		{name: "server error", status: http.StatusServiceUnavailable, permanent: false},
+		{name: "client error", status: http.StatusBadRequest, permanent: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, _ := newWriteServer(t, tt.status)
+
+			cfg := createDefaultConfig().(*Config)
+			cfg.Endpoint = server.URL
+			cfg.PayloadMaxLines = 1
+			writer := newTestWriter(t, cfg)
+
+			batch := writer.newBatch()
+			err := batch.WritePoint(context.Background(), "m", nil, map[string]interface{}{"value": 1.0}, time.Now(), 0)
+			require.Error(t, err)
+			assert.Equal(t, tt.permanent, consumererror.IsPermanent(err))
+
+			// A failed write request is reported as is, whatever the conversion error.
+			assert.Equal(t, tt.permanent, consumererror.IsPermanent(batch.conversionError(err)))
+		})
+	}
+}
@@ -15,6 +15,9 @@
 package loadbalancingexporter
 
 import (
+	"errors"
+	"time"
+
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/exporter/otlpexporter"
 )
@@ -22,8 +25,41 @@ import (
 // Config defines configuration for the exporter.
 type Config struct {
 	config.ExporterSettings `mapstructure:",squash"`
-	Protocol                Protocol         `mapstructure:"protocol"`
-	Resolver                ResolverSettings `mapstructure:"resolver"`
+	Protocol                Protocol            `mapstructure:"protocol"`
+	Resolver                ResolverSettings    `mapstructure:"resolver"`
+	HealthCheck             HealthCheckSettings `mapstructure:"health_check"`
+}
+
+var _ config.Exporter = (*Config)(nil)
+
+// Validate checks if the exporter configuration is valid.
+func (cfg *Config) Validate() error {
+	if !cfg.HealthCheck.Enabled {
+		return nil
+	}
+	hc := cfg.HealthCheck
+	if hc.Interval <= 0 {
+		return errors.New("health_check interval must be greater than zero")
+	}
+	if hc.MinRequests <= 0 {
+		return errors.New("health_check min_requests must be greater than zero")
+	}
+	if hc.MaxErrorRate <= 0 || hc.MaxErrorRate > 1 {
+		return errors.New("health_check max_error_rate must be in the (0, 1] range")
+	}
+	if hc.MaxLatency < 0 {
+		return errors.New("health_check max_latency must not be negative")
+	}
+	if hc.EjectionDuration <= 0 {
+		return errors.New("health_check ejection_duration must be greater than zero")
+	}
+	if hc.MaxEjectionPercent < 0 || hc.MaxEjectionPercent > 100 {
+		return errors.New("health_check max_ejection_percent must be in the [0, 100] range")
+	}
+	if hc.RecoveryWeightPercent <= 0 || hc.RecoveryWeightPercent > 100 {
+		return errors.New("health_check recovery_weight_percent must be in the (0, 100] range")
+	}
+	return nil
 }
 
 // Protocol holds the individual protocol-specific settings. Only OTLP is supported at the moment.
@@ -47,3 +83,35 @@ type DNSResolver struct {
 	Hostname string `mapstructure:"hostname"`
 	Port     string `mapstructure:"port"`
 }
+
+// HealthCheckSettings defines how the health of the backends is tracked. Backends failing or answering
+// too slowly are ejected from the ring for a while, and get back a reduced share of the traffic once the
+// ejection expires, until they prove to be healthy again.
+type HealthCheckSettings struct {
+	// Enabled turns the tracking of the backends health on.
+	Enabled bool `mapstructure:"enabled"`
+
+	// Interval is the window the outcomes of the exports to a backend are evaluated over.
+	Interval time.Duration `mapstructure:"interval"`
+
+	// MinRequests is the number of exports within the interval required to evaluate the health of a backend.
+	MinRequests int `mapstructure:"min_requests"`
+
+	// MaxErrorRate is the ratio of failed exports within the interval over which a backend is ejected.
+	MaxErrorRate float64 `mapstructure:"max_error_rate"`
+
+	// MaxLatency is the average export latency within the interval over which a backend is ejected.
+	// Zero disables the ejection of slow backends.
+	MaxLatency time.Duration `mapstructure:"max_latency"`
+
+	// EjectionDuration is how long a backend is ejected for. It's multiplied by the number of
+	// consecutive ejections of the backend.
+	EjectionDuration time.Duration `mapstructure:"ejection_duration"`
+
+	// MaxEjectionPercent is the maximum share of the backends that can be ejected at the same time.
+	MaxEjectionPercent int `mapstructure:"max_ejection_percent"`
+
+	// RecoveryWeightPercent is the share of its regular weight a backend gets in the ring once its
+	// ejection expires, until it's evaluated as healthy again.
+	RecoveryWeightPercent int `mapstructure:"recovery_weight_percent"`
+}
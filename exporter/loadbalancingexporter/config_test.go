@@ -17,10 +17,12 @@ package loadbalancingexporter
 import (
 	"path"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/config/configtest"
 )
 
@@ -33,4 +35,81 @@ func TestLoadConfig(t *testing.T) {
 	cfg, err := configtest.LoadConfigAndValidate(path.Join(".", "testdata", "config.yaml"), factories)
 	require.NoError(t, err)
 	require.NotNil(t, cfg)
+
+	e4 := cfg.Exporters[config.NewIDWithName(typeStr, "4")].(*Config)
+	assert.Equal(t, HealthCheckSettings{
+		Enabled:               true,
+		Interval:              30 * time.Second,
+		MinRequests:           20,
+		MaxErrorRate:          0.25,
+		MaxLatency:            2 * time.Second,
+		EjectionDuration:      time.Minute,
+		MaxEjectionPercent:    30,
+		RecoveryWeightPercent: 20,
+	}, e4.HealthCheck)
+}
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*HealthCheckSettings)
+		err    string
+	}{
+		{
+			name:   "health check disabled",
+			modify: func(hc *HealthCheckSettings) { hc.Enabled = false },
+		},
+		{
+			name:   "defaults",
+			modify: func(*HealthCheckSettings) {},
+		},
+		{
+			name:   "zero interval",
+			modify: func(hc *HealthCheckSettings) { hc.Interval = 0 },
+			err:    "health_check interval must be greater than zero",
+		},
+		{
+			name:   "zero min requests",
+			modify: func(hc *HealthCheckSettings) { hc.MinRequests = 0 },
+			err:    "health_check min_requests must be greater than zero",
+		},
+		{
+			name:   "max error rate out of range",
+			modify: func(hc *HealthCheckSettings) { hc.MaxErrorRate = 1.5 },
+			err:    "health_check max_error_rate must be in the (0, 1] range",
+		},
+		{
+			name:   "negative max latency",
+			modify: func(hc *HealthCheckSettings) { hc.MaxLatency = -time.Second },
+			err:    "health_check max_latency must not be negative",
+		},
+		{
+			name:   "zero ejection duration",
+			modify: func(hc *HealthCheckSettings) { hc.EjectionDuration = 0 },
+			err:    "health_check ejection_duration must be greater than zero",
+		},
+		{
+			name:   "max ejection percent out of range",
+			modify: func(hc *HealthCheckSettings) { hc.MaxEjectionPercent = 101 },
+			err:    "health_check max_ejection_percent must be in the [0, 100] range",
+		},
+		{
+			name:   "zero recovery weight",
+			modify: func(hc *HealthCheckSettings) { hc.RecoveryWeightPercent = 0 },
+			err:    "health_check recovery_weight_percent must be in the (0, 100] range",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.HealthCheck.Enabled = true
+			tt.modify(&cfg.HealthCheck)
+			err := cfg.Validate()
+			if tt.err == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.err)
+		})
+	}
 }
@@ -48,6 +48,25 @@ func newHashRing(endpoints []string) *hashRing {
 	}
 }
 
+// newWeightedHashRing builds a new immutable consistent hash ring based on the given endpoints, placing
+// each endpoint in as many positions as its weight. Endpoints without a weight get the default one,
+// and endpoints with a zero weight are left out of the ring, unless all of them would be left out.
+func newWeightedHashRing(endpoints []string, weights map[string]int) *hashRing {
+	weightFor := func(endpoint string) int {
+		if weight, ok := weights[endpoint]; ok {
+			return weight
+		}
+		return defaultWeight
+	}
+	items := positionsForWeightedEndpoints(endpoints, weightFor)
+	if len(items) == 0 {
+		items = positionsForEndpoints(endpoints, defaultWeight)
+	}
+	return &hashRing{
+		items: items,
+	}
+}
+
 // endpointFor calculates which backend is responsible for the given traceID
 func (h *hashRing) endpointFor(traceID pdata.TraceID) string {
 	b := traceID.Bytes()
@@ -122,11 +141,16 @@ func positionsFor(endpoint string, numPoints int) []position {
 
 // positionsForEndpoints calculates all the positions for all the given endpoints
 func positionsForEndpoints(endpoints []string, weight int) []ringItem {
+	return positionsForWeightedEndpoints(endpoints, func(string) int { return weight })
+}
+
+// positionsForWeightedEndpoints calculates all the positions for all the given endpoints, with the
+// number of positions for each endpoint given by weightFor
+func positionsForWeightedEndpoints(endpoints []string, weightFor func(string) int) []ringItem {
 	var items []ringItem
 	positions := map[position]bool{} // tracking the used positions
 	for _, endpoint := range endpoints {
-		// for this initial implementation, we don't allow endpoints to have custom weights
-		for _, pos := range positionsFor(endpoint, weight) {
+		for _, pos := range positionsFor(endpoint, weightFor(endpoint)) {
 			// if this position is occupied already, skip this item
 			if _, found := positions[pos]; found {
 				continue
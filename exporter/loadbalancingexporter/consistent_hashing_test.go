@@ -33,6 +33,31 @@ func TestNewHashRing(t *testing.T) {
 	assert.Len(t, ring.items, 2*defaultWeight)
 }
 
+func TestNewWeightedHashRing(t *testing.T) {
+	// prepare
+	endpoints := []string{"endpoint-1", "endpoint-2", "endpoint-3"}
+
+	// test
+	ring := newWeightedHashRing(endpoints, map[string]int{"endpoint-2": 10, "endpoint-3": 0})
+
+	// verify
+	assert.Len(t, ring.items, defaultWeight+10)
+	for _, item := range ring.items {
+		assert.NotEqual(t, "endpoint-3", item.endpoint)
+	}
+}
+
+func TestNewWeightedHashRingAllEjected(t *testing.T) {
+	// prepare
+	endpoints := []string{"endpoint-1", "endpoint-2"}
+
+	// test
+	ring := newWeightedHashRing(endpoints, map[string]int{"endpoint-1": 0, "endpoint-2": 0})
+
+	// verify
+	assert.Len(t, ring.items, 2*defaultWeight)
+}
+
 func TestEndpointFor(t *testing.T) {
 	// prepare
 	endpoints := []string{"endpoint-1", "endpoint-2"}
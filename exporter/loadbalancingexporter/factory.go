@@ -16,6 +16,7 @@ package loadbalancingexporter
 
 import (
 	"context"
+	"time"
 
 	"go.opencensus.io/stats/view"
 	"go.opentelemetry.io/collector/component"
@@ -27,6 +28,13 @@ import (
 const (
 	// The value of "type" key in configuration.
 	typeStr = "loadbalancing"
+
+	defaultHealthCheckInterval     = 10 * time.Second
+	defaultHealthCheckMinRequests  = 10
+	defaultHealthCheckMaxErrorRate = 0.5
+	defaultEjectionDuration        = 30 * time.Second
+	defaultMaxEjectionPercent      = 50
+	defaultRecoveryWeightPercent   = 10
 )
 
 // NewFactory creates a factory for the exporter.
@@ -50,6 +58,14 @@ func createDefaultConfig() config.Exporter {
 		Protocol: Protocol{
 			OTLP: *otlpDefaultCfg,
 		},
+		HealthCheck: HealthCheckSettings{
+			Interval:              defaultHealthCheckInterval,
+			MinRequests:           defaultHealthCheckMinRequests,
+			MaxErrorRate:          defaultHealthCheckMaxErrorRate,
+			EjectionDuration:      defaultEjectionDuration,
+			MaxEjectionPercent:    defaultMaxEjectionPercent,
+			RecoveryWeightPercent: defaultRecoveryWeightPercent,
+		},
 	}
 }
 
@@ -0,0 +1,200 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadbalancingexporter
+
+import (
+	"sync"
+	"time"
+)
+
+// maxEjectionMultiplier caps how much longer an endpoint is ejected for after consecutive ejections
+const maxEjectionMultiplier = 10
+
+type endpointState int
+
+const (
+	endpointHealthy endpointState = iota
+	endpointEjected
+	endpointRecovering
+)
+
+// endpointHealth holds the outcomes of the exports to an endpoint within the current window.
+type endpointHealth struct {
+	state        endpointState
+	windowStart  time.Time
+	requests     int
+	failures     int
+	latency      time.Duration
+	ejectedUntil time.Time
+	ejections    int
+}
+
+func (eh *endpointHealth) resetWindow(now time.Time) {
+	eh.windowStart = now
+	eh.requests = 0
+	eh.failures = 0
+	eh.latency = 0
+}
+
+// healthTracker tracks the error rate and latency of the exports to each endpoint, ejecting the unhealthy
+// endpoints from the ring and giving them back a reduced weight once the ejection expires, so that the
+// following exports probe whether they recovered.
+type healthTracker struct {
+	sync.Mutex
+
+	settings  HealthCheckSettings
+	now       func() time.Time
+	endpoints map[string]*endpointHealth
+}
+
+func newHealthTracker(settings HealthCheckSettings) *healthTracker {
+	return &healthTracker{
+		settings:  settings,
+		now:       time.Now,
+		endpoints: map[string]*endpointHealth{},
+	}
+}
+
+// setEndpoints starts tracking the given endpoints, forgetting about the ones that are gone
+func (h *healthTracker) setEndpoints(endpoints []string) {
+	h.Lock()
+	defer h.Unlock()
+
+	current := make(map[string]*endpointHealth, len(endpoints))
+	for _, endpoint := range endpoints {
+		eh, ok := h.endpoints[endpoint]
+		if !ok {
+			eh = &endpointHealth{windowStart: h.now()}
+		}
+		current[endpoint] = eh
+	}
+	h.endpoints = current
+}
+
+// record registers the outcome of an export to the endpoint, returning the state of the endpoint
+// and whether it changed
+func (h *healthTracker) record(endpoint string, latency time.Duration, failed bool) (endpointState, bool) {
+	h.Lock()
+	defer h.Unlock()
+
+	eh, ok := h.endpoints[endpoint]
+	if !ok {
+		return endpointHealthy, false
+	}
+	if eh.state == endpointEjected {
+		return eh.state, false
+	}
+
+	now := h.now()
+	if eh.state == endpointHealthy && now.Sub(eh.windowStart) >= h.settings.Interval {
+		eh.resetWindow(now)
+	}
+
+	eh.requests++
+	eh.latency += latency
+	if failed {
+		eh.failures++
+	}
+	if eh.requests < h.settings.MinRequests {
+		return eh.state, false
+	}
+
+	if h.isHealthy(eh) {
+		if eh.state == endpointRecovering {
+			eh.state = endpointHealthy
+			eh.ejections = 0
+			eh.resetWindow(now)
+			return eh.state, true
+		}
+		return eh.state, false
+	}
+
+	if !h.canEject() {
+		if eh.state == endpointRecovering {
+			eh.resetWindow(now)
+		}
+		return eh.state, false
+	}
+	h.eject(eh, now)
+	return eh.state, true
+}
+
+// refresh moves the endpoints whose ejection expired to the recovering state, returning whether any endpoint changed
+func (h *healthTracker) refresh() bool {
+	h.Lock()
+	defer h.Unlock()
+
+	now := h.now()
+	changed := false
+	for _, eh := range h.endpoints {
+		if eh.state == endpointEjected && !now.Before(eh.ejectedUntil) {
+			eh.state = endpointRecovering
+			eh.resetWindow(now)
+			changed = true
+		}
+	}
+	return changed
+}
+
+// weights returns the weight in the ring of the endpoints that aren't healthy
+func (h *healthTracker) weights() map[string]int {
+	h.Lock()
+	defer h.Unlock()
+
+	weights := map[string]int{}
+	for endpoint, eh := range h.endpoints {
+		switch eh.state {
+		case endpointEjected:
+			weights[endpoint] = 0
+		case endpointRecovering:
+			weight := defaultWeight * h.settings.RecoveryWeightPercent / 100
+			if weight < 1 {
+				weight = 1
+			}
+			weights[endpoint] = weight
+		}
+	}
+	return weights
+}
+
+func (h *healthTracker) isHealthy(eh *endpointHealth) bool {
+	if float64(eh.failures)/float64(eh.requests) > h.settings.MaxErrorRate {
+		return false
+	}
+	if h.settings.MaxLatency > 0 && eh.latency/time.Duration(eh.requests) > h.settings.MaxLatency {
+		return false
+	}
+	return true
+}
+
+// canEject tells whether one more endpoint can be ejected without going over the max ejection percent
+func (h *healthTracker) canEject() bool {
+	ejected := 0
+	for _, eh := range h.endpoints {
+		if eh.state == endpointEjected {
+			ejected++
+		}
+	}
+	return (ejected+1)*100 <= len(h.endpoints)*h.settings.MaxEjectionPercent
+}
+
+func (h *healthTracker) eject(eh *endpointHealth, now time.Time) {
+	if eh.ejections < maxEjectionMultiplier {
+		eh.ejections++
+	}
+	eh.state = endpointEjected
+	eh.ejectedUntil = now.Add(h.settings.EjectionDuration * time.Duration(eh.ejections))
+	eh.resetWindow(now)
+}
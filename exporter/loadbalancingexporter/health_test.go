@@ -0,0 +1,154 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadbalancingexporter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestHealthTracker(endpoints ...string) (*healthTracker, *time.Time) {
+	now := time.Unix(1_600_000_000, 0)
+	h := newHealthTracker(HealthCheckSettings{
+		Enabled:               true,
+		Interval:              10 * time.Second,
+		MinRequests:           4,
+		MaxErrorRate:          0.5,
+		MaxLatency:            time.Second,
+		EjectionDuration:      30 * time.Second,
+		MaxEjectionPercent:    50,
+		RecoveryWeightPercent: 10,
+	})
+	h.now = func() time.Time { return now }
+	h.setEndpoints(endpoints)
+	return h, &now
+}
+
+func TestHealthTrackerEjectsFailingEndpoint(t *testing.T) {
+	h, _ := newTestHealthTracker("endpoint-1", "endpoint-2")
+
+	for i := 0; i < 3; i++ {
+		_, changed := h.record("endpoint-1", time.Millisecond, true)
+		assert.False(t, changed, "endpoint evaluated before reaching the min requests")
+	}
+	state, changed := h.record("endpoint-1", time.Millisecond, true)
+
+	assert.True(t, changed)
+	assert.Equal(t, endpointEjected, state)
+	assert.Equal(t, map[string]int{"endpoint-1": 0}, h.weights())
+}
+
+func TestHealthTrackerEjectsSlowEndpoint(t *testing.T) {
+	h, _ := newTestHealthTracker("endpoint-1", "endpoint-2")
+
+	var state endpointState
+	for i := 0; i < 4; i++ {
+		state, _ = h.record("endpoint-1", 2*time.Second, false)
+	}
+
+	assert.Equal(t, endpointEjected, state)
+}
+
+func TestHealthTrackerKeepsHealthyEndpoint(t *testing.T) {
+	h, _ := newTestHealthTracker("endpoint-1", "endpoint-2")
+
+	for i := 0; i < 10; i++ {
+		// one out of five exports failing is below the max error rate
+		state, changed := h.record("endpoint-1", time.Millisecond, i%5 == 0)
+		assert.False(t, changed)
+		assert.Equal(t, endpointHealthy, state)
+	}
+	assert.Empty(t, h.weights())
+}
+
+func TestHealthTrackerWindowExpires(t *testing.T) {
+	h, now := newTestHealthTracker("endpoint-1", "endpoint-2")
+
+	for i := 0; i < 3; i++ {
+		h.record("endpoint-1", time.Millisecond, true)
+	}
+	*now = now.Add(11 * time.Second)
+	state, changed := h.record("endpoint-1", time.Millisecond, true)
+
+	// the failures of the previous window are gone
+	assert.False(t, changed)
+	assert.Equal(t, endpointHealthy, state)
+}
+
+func TestHealthTrackerMaxEjectionPercent(t *testing.T) {
+	h, _ := newTestHealthTracker("endpoint-1", "endpoint-2")
+
+	for i := 0; i < 4; i++ {
+		h.record("endpoint-1", time.Millisecond, true)
+	}
+	var changed bool
+	for i := 0; i < 4; i++ {
+		_, changed = h.record("endpoint-2", time.Millisecond, true)
+	}
+
+	// ejecting the second endpoint would leave no endpoint in the ring
+	assert.False(t, changed)
+	assert.Equal(t, map[string]int{"endpoint-1": 0}, h.weights())
+}
+
+func TestHealthTrackerRecovery(t *testing.T) {
+	h, now := newTestHealthTracker("endpoint-1", "endpoint-2")
+	for i := 0; i < 4; i++ {
+		h.record("endpoint-1", time.Millisecond, true)
+	}
+
+	// the ejection hasn't expired yet
+	*now = now.Add(29 * time.Second)
+	assert.False(t, h.refresh())
+
+	*now = now.Add(time.Second)
+	assert.True(t, h.refresh())
+	assert.Equal(t, map[string]int{"endpoint-1": 10}, h.weights())
+
+	// the probes are failing, the endpoint is ejected for twice as long
+	for i := 0; i < 4; i++ {
+		h.record("endpoint-1", time.Millisecond, true)
+	}
+	assert.Equal(t, now.Add(60*time.Second), h.endpoints["endpoint-1"].ejectedUntil)
+
+	*now = now.Add(60 * time.Second)
+	assert.True(t, h.refresh())
+
+	// the probes are succeeding, the endpoint gets its whole weight back
+	var state endpointState
+	var changed bool
+	for i := 0; i < 4; i++ {
+		state, changed = h.record("endpoint-1", time.Millisecond, false)
+	}
+	assert.True(t, changed)
+	assert.Equal(t, endpointHealthy, state)
+	assert.Empty(t, h.weights())
+	assert.Equal(t, 0, h.endpoints["endpoint-1"].ejections)
+}
+
+func TestHealthTrackerForgetsRemovedEndpoints(t *testing.T) {
+	h, _ := newTestHealthTracker("endpoint-1", "endpoint-2")
+	for i := 0; i < 4; i++ {
+		h.record("endpoint-1", time.Millisecond, true)
+	}
+
+	h.setEndpoints([]string{"endpoint-2", "endpoint-3"})
+
+	assert.Empty(t, h.weights())
+	_, changed := h.record("endpoint-1", time.Millisecond, true)
+	assert.False(t, changed)
+}
@@ -20,7 +20,10 @@ import (
 	"fmt"
 	"strings"
 	"sync"
+	"time"
 
+	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/consumer/pdata"
@@ -29,6 +32,9 @@ import (
 
 const (
 	defaultPort = "4317"
+
+	// healthRefreshInterval is how often the ejected endpoints are checked for expiration
+	healthRefreshInterval = time.Second
 )
 
 var (
@@ -44,14 +50,22 @@ type loadBalancer interface {
 	component.Component
 	Endpoint(traceID pdata.TraceID) string
 	Exporter(endpoint string) (component.Exporter, error)
+	RecordOutcome(endpoint string, latency time.Duration, err error)
 }
 
 type loadBalancerImp struct {
 	logger *zap.Logger
 	host   component.Host
 
-	res  resolver
-	ring *hashRing
+	res       resolver
+	ring      *hashRing
+	endpoints []string
+
+	// health is nil when the health check is disabled
+	health          *healthTracker
+	refreshInterval time.Duration
+	stopCh          chan struct{}
+	stopOnce        sync.Once
 
 	componentFactory componentFactory
 	exporters        map[string]component.Exporter
@@ -90,9 +104,17 @@ func newLoadBalancer(params component.ExporterCreateSettings, cfg config.Exporte
 		return nil, errNoResolver
 	}
 
+	var health *healthTracker
+	if oCfg.HealthCheck.Enabled {
+		health = newHealthTracker(oCfg.HealthCheck)
+	}
+
 	return &loadBalancerImp{
 		logger:           params.Logger,
 		res:              res,
+		health:           health,
+		refreshInterval:  healthRefreshInterval,
+		stopCh:           make(chan struct{}),
 		componentFactory: factory,
 		exporters:        map[string]component.Exporter{},
 	}, nil
@@ -101,17 +123,26 @@ func newLoadBalancer(params component.ExporterCreateSettings, cfg config.Exporte
 func (lb *loadBalancerImp) Start(ctx context.Context, host component.Host) error {
 	lb.res.onChange(lb.onBackendChanges)
 	lb.host = host
+	if lb.health != nil {
+		go lb.refreshHealth()
+	}
 	return lb.res.start(ctx)
 }
 
 func (lb *loadBalancerImp) onBackendChanges(resolved []string) {
-	newRing := newHashRing(resolved)
+	var weights map[string]int
+	if lb.health != nil {
+		lb.health.setEndpoints(resolved)
+		weights = lb.health.weights()
+	}
+	newRing := newWeightedHashRing(resolved, weights)
 
 	if !newRing.equal(lb.ring) {
 		lb.updateLock.Lock()
 		defer lb.updateLock.Unlock()
 
 		lb.ring = newRing
+		lb.endpoints = resolved
 
 		// TODO: set a timeout?
 		ctx := context.Background()
@@ -170,9 +201,59 @@ func endpointFound(endpoint string, endpoints []string) bool {
 
 func (lb *loadBalancerImp) Shutdown(context.Context) error {
 	lb.stopped = true
+	lb.stopOnce.Do(func() {
+		close(lb.stopCh)
+	})
 	return nil
 }
 
+// RecordOutcome feeds the outcome of an export to the health tracker, rebuilding the ring
+// when the endpoint got ejected or recovered.
+func (lb *loadBalancerImp) RecordOutcome(endpoint string, latency time.Duration, err error) {
+	if lb.health == nil {
+		return
+	}
+	state, changed := lb.health.record(endpoint, latency, err != nil)
+	if !changed {
+		return
+	}
+
+	if state == endpointEjected {
+		ctx, _ := tag.New(context.Background(), tag.Upsert(tag.MustNewKey("endpoint"), endpoint))
+		stats.Record(ctx, mBackendEjections.M(1))
+		lb.logger.Warn("backend ejected from the ring", zap.String("endpoint", endpoint))
+	} else {
+		lb.logger.Info("backend recovered", zap.String("endpoint", endpoint))
+	}
+	lb.rebuildRing()
+}
+
+// refreshHealth periodically gives back a share of the traffic to the endpoints whose ejection expired
+func (lb *loadBalancerImp) refreshHealth() {
+	ticker := time.NewTicker(lb.refreshInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if lb.health.refresh() {
+				lb.rebuildRing()
+			}
+		case <-lb.stopCh:
+			return
+		}
+	}
+}
+
+func (lb *loadBalancerImp) rebuildRing() {
+	lb.updateLock.Lock()
+	defer lb.updateLock.Unlock()
+
+	if len(lb.endpoints) == 0 {
+		return
+	}
+	lb.ring = newWeightedHashRing(lb.endpoints, lb.health.weights())
+}
+
 func (lb *loadBalancerImp) Endpoint(traceID pdata.TraceID) string {
 	lb.updateLock.RLock()
 	defer lb.updateLock.RUnlock()
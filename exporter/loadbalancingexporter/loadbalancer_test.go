@@ -18,6 +18,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -217,6 +218,70 @@ func TestOnBackendChanges(t *testing.T) {
 	assert.Len(t, p.ring.items, 2*defaultWeight)
 }
 
+func TestRecordOutcomeEjectsEndpoint(t *testing.T) {
+	// prepare
+	config := simpleConfig()
+	config.HealthCheck = createDefaultConfig().(*Config).HealthCheck
+	config.HealthCheck.Enabled = true
+	config.HealthCheck.MinRequests = 1
+	params := component.ExporterCreateSettings{
+		Logger: zap.NewNop(),
+	}
+	componentFactory := func(ctx context.Context, endpoint string) (component.Exporter, error) {
+		return newNopMockExporter(), nil
+	}
+	p, err := newLoadBalancer(params, config, componentFactory)
+	require.NotNil(t, p)
+	require.NoError(t, err)
+
+	endpoints := []string{"endpoint-1", "endpoint-2"}
+	p.onBackendChanges(endpoints)
+	require.Len(t, p.ring.items, 2*defaultWeight)
+
+	// test
+	p.RecordOutcome("endpoint-1", time.Millisecond, errors.New("some expected err"))
+
+	// verify
+	assert.Len(t, p.ring.items, defaultWeight)
+	for _, item := range p.ring.items {
+		assert.Equal(t, "endpoint-2", item.endpoint)
+	}
+}
+
+func TestRefreshHealthGivesTrafficBack(t *testing.T) {
+	// prepare
+	config := simpleConfig()
+	config.HealthCheck = createDefaultConfig().(*Config).HealthCheck
+	config.HealthCheck.Enabled = true
+	config.HealthCheck.MinRequests = 1
+	config.HealthCheck.EjectionDuration = time.Millisecond
+	config.Resolver.Static.Hostnames = []string{"endpoint-1", "endpoint-2"}
+	params := component.ExporterCreateSettings{
+		Logger: zap.NewNop(),
+	}
+	componentFactory := func(ctx context.Context, endpoint string) (component.Exporter, error) {
+		return newNopMockExporter(), nil
+	}
+	p, err := newLoadBalancer(params, config, componentFactory)
+	require.NotNil(t, p)
+	require.NoError(t, err)
+	p.refreshInterval = time.Millisecond
+
+	require.NoError(t, p.Start(context.Background(), componenttest.NewNopHost()))
+	defer p.Shutdown(context.Background())
+
+	// test
+	p.RecordOutcome("endpoint-1", time.Millisecond, errors.New("some expected err"))
+
+	// verify
+	recoveryWeight := defaultWeight * defaultRecoveryWeightPercent / 100
+	assert.Eventually(t, func() bool {
+		p.updateLock.RLock()
+		defer p.updateLock.RUnlock()
+		return len(p.ring.items) == defaultWeight+recoveryWeight
+	}, time.Second, time.Millisecond)
+}
+
 func TestRemoveExtraExporters(t *testing.T) {
 	// prepare
 	config := simpleConfig()
@@ -119,6 +119,7 @@ func (e *logExporterImp) consumeLog(ctx context.Context, ld pdata.Logs) error {
 	start := time.Now()
 	err = le.ConsumeLogs(ctx, ld)
 	duration := time.Since(start)
+	e.loadBalancer.RecordOutcome(endpoint, duration, err)
 	ctx, _ = tag.New(ctx, tag.Upsert(tag.MustNewKey("endpoint"), endpoint))
 
 	if err == nil {
@@ -21,9 +21,10 @@ import (
 )
 
 var (
-	mNumResolutions = stats.Int64("loadbalancer_num_resolutions", "Number of times the resolver triggered a new resolutions", stats.UnitDimensionless)
-	mNumBackends    = stats.Int64("loadbalancer_num_backends", "Current number of backends in use", stats.UnitDimensionless)
-	mBackendLatency = stats.Int64("loadbalancer_backend_latency", "Response latency in ms for the backends", stats.UnitMilliseconds)
+	mNumResolutions   = stats.Int64("loadbalancer_num_resolutions", "Number of times the resolver triggered a new resolutions", stats.UnitDimensionless)
+	mNumBackends      = stats.Int64("loadbalancer_num_backends", "Current number of backends in use", stats.UnitDimensionless)
+	mBackendLatency   = stats.Int64("loadbalancer_backend_latency", "Response latency in ms for the backends", stats.UnitMilliseconds)
+	mBackendEjections = stats.Int64("loadbalancer_backend_ejections", "Number of times the backends were ejected from the ring", stats.UnitDimensionless)
 )
 
 // MetricViews return the metrics views according to given telemetry level.
@@ -76,5 +77,14 @@ func MetricViews() []*view.View {
 			},
 			Aggregation: view.Count(),
 		},
+		{
+			Name:        mBackendEjections.Name(),
+			Measure:     mBackendEjections,
+			Description: mBackendEjections.Description(),
+			TagKeys: []tag.Key{
+				tag.MustNewKey("endpoint"),
+			},
+			Aggregation: view.Sum(),
+		},
 	}
 }
@@ -126,6 +126,7 @@ func (e *traceExporterImp) consumeTrace(ctx context.Context, td pdata.Traces) er
 	start := time.Now()
 	err = te.ConsumeTraces(ctx, td)
 	duration := time.Since(start)
+	e.loadBalancer.RecordOutcome(endpoint, duration, err)
 	ctx, _ = tag.New(ctx, tag.Upsert(tag.MustNewKey("endpoint"), endpoint))
 
 	if err == nil {
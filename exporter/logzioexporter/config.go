@@ -16,22 +16,76 @@ package logzioexporter
 
 import (
 	"errors"
+	"fmt"
 
 	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+)
+
+const (
+	usRegionCode = "us"
+
+	// listenerPort is the port of the listener receiving traces and logs.
+	listenerPort = 8071
+	// metricsListenerPort is the port of the listener receiving metrics with the
+	// Prometheus remote write protocol over HTTPS.
+	metricsListenerPort = 8053
 )
 
 // Config contains Logz.io specific configuration such as Account TracesToken, Region, etc.
 type Config struct {
-	config.ExporterSettings `mapstructure:",squash"`
-	TracesToken             string `mapstructure:"account_token"`   // Your Logz.io Account Token, can be found at https://app.logz.io/#/dashboard/settings/general
-	MetricsToken            string `mapstructure:"metrics_token"`   // Your Logz.io Metrics Token, can be found at https://docs.logz.io/user-guide/accounts/finding-your-metrics-account-token/
-	Region                  string `mapstructure:"region"`          // Your Logz.io 2-letter region code, can be found at https://docs.logz.io/user-guide/accounts/account-region.html#available-regions
-	CustomEndpoint          string `mapstructure:"custom_endpoint"` // Custom endpoint to ship traces to. Use only for dev and tests.
+	config.ExporterSettings        `mapstructure:",squash"`
+	exporterhelper.TimeoutSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct.
+	exporterhelper.QueueSettings   `mapstructure:"sending_queue"`
+	exporterhelper.RetrySettings   `mapstructure:"retry_on_failure"`
+	TracesToken                    string `mapstructure:"account_token"`           // Your Logz.io Account Token, can be found at https://app.logz.io/#/dashboard/settings/general
+	MetricsToken                   string `mapstructure:"metrics_token"`           // Your Logz.io Prometheus Metrics Token, can be found at https://docs.logz.io/user-guide/accounts/finding-your-metrics-account-token/
+	LogsToken                      string `mapstructure:"logs_token"`              // Your Logz.io Logs Token, the token of the account to ship logs to
+	Region                         string `mapstructure:"region"`                  // Your Logz.io 2-letter region code, can be found at https://docs.logz.io/user-guide/accounts/account-region.html#available-regions
+	CustomEndpoint                 string `mapstructure:"custom_endpoint"`         // Custom endpoint to ship traces and logs to. Use only for dev and tests.
+	CustomMetricsEndpoint          string `mapstructure:"custom_metrics_endpoint"` // Custom endpoint to ship metrics to. Use only for dev and tests.
 }
 
-func (c *Config) validate() error {
-	if c.TracesToken == "" {
-		return errors.New("`account_token` not specified")
+// validate checks the configuration needed to export the given data type.
+func (c *Config) validate(dataType config.DataType) error {
+	switch dataType {
+	case config.TracesDataType:
+		if c.TracesToken == "" {
+			return errors.New("`account_token` not specified")
+		}
+	case config.MetricsDataType:
+		if c.MetricsToken == "" {
+			return errors.New("`metrics_token` not specified")
+		}
+	case config.LogsDataType:
+		if c.LogsToken == "" {
+			return errors.New("`logs_token` not specified")
+		}
 	}
 	return nil
 }
+
+// logsEndpoint returns the endpoint of the listener of the region receiving logs in bulks.
+func (c *Config) logsEndpoint() string {
+	if c.CustomEndpoint != "" {
+		return c.CustomEndpoint
+	}
+	return fmt.Sprintf("https://listener%s.logz.io:%d", c.regionCode(), listenerPort)
+}
+
+// metricsEndpoint returns the Prometheus remote write endpoint of the listener of the region.
+func (c *Config) metricsEndpoint() string {
+	if c.CustomMetricsEndpoint != "" {
+		return c.CustomMetricsEndpoint
+	}
+	return fmt.Sprintf("https://listener%s.logz.io:%d", c.regionCode(), metricsListenerPort)
+}
+
+// regionCode returns the suffix of the listener host name of the region, the US region
+// listener has none.
+func (c *Config) regionCode() string {
+	if c.Region == "" || c.Region == usRegionCode {
+		return ""
+	}
+	return "-" + c.Region
+}
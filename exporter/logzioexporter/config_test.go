@@ -17,6 +17,7 @@ package logzioexporter
 import (
 	"path"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -39,10 +40,51 @@ func TestLoadConfig(t *testing.T) {
 	assert.Equal(t, 2, len(cfg.Exporters))
 
 	cfgExp := cfg.Exporters[config.NewIDWithName(typeStr, "2")]
-	assert.Equal(t, &Config{
-		ExporterSettings: config.NewExporterSettings(config.NewIDWithName(typeStr, "2")),
-		TracesToken:      "logzioTESTtoken",
-		Region:           "eu",
-		CustomEndpoint:   "https://some-url.com:8888",
-	}, cfgExp)
+	expected := factory.CreateDefaultConfig().(*Config)
+	expected.ExporterSettings = config.NewExporterSettings(config.NewIDWithName(typeStr, "2"))
+	expected.TracesToken = "logzioTESTtoken"
+	expected.MetricsToken = "logzioTESTmetricstoken"
+	expected.LogsToken = "logzioTESTlogstoken"
+	expected.Region = "eu"
+	expected.CustomEndpoint = "https://some-url.com:8888"
+	expected.CustomMetricsEndpoint = "https://some-url.com:8889"
+	expected.Timeout = 10 * time.Second
+	expected.QueueSettings.Enabled = false
+	assert.Equal(t, expected, cfgExp)
+}
+
+func TestValidateConfig(t *testing.T) {
+	cfg := &Config{TracesToken: "traces"}
+	assert.NoError(t, cfg.validate(config.TracesDataType))
+	assert.EqualError(t, cfg.validate(config.MetricsDataType), "`metrics_token` not specified")
+	assert.EqualError(t, cfg.validate(config.LogsDataType), "`logs_token` not specified")
+
+	cfg = &Config{MetricsToken: "metrics", LogsToken: "logs"}
+	assert.EqualError(t, cfg.validate(config.TracesDataType), "`account_token` not specified")
+	assert.NoError(t, cfg.validate(config.MetricsDataType))
+	assert.NoError(t, cfg.validate(config.LogsDataType))
+}
+
+func TestRegionEndpoints(t *testing.T) {
+	tests := []struct {
+		region          string
+		logsEndpoint    string
+		metricsEndpoint string
+	}{
+		{"", "https://listener.logz.io:8071", "https://listener.logz.io:8053"},
+		{"us", "https://listener.logz.io:8071", "https://listener.logz.io:8053"},
+		{"eu", "https://listener-eu.logz.io:8071", "https://listener-eu.logz.io:8053"},
+		{"au", "https://listener-au.logz.io:8071", "https://listener-au.logz.io:8053"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.region, func(t *testing.T) {
+			cfg := &Config{Region: tt.region}
+			assert.Equal(t, tt.logsEndpoint, cfg.logsEndpoint())
+			assert.Equal(t, tt.metricsEndpoint, cfg.metricsEndpoint())
+		})
+	}
+
+	cfg := &Config{Region: "eu", CustomEndpoint: "http://localhost:8071", CustomMetricsEndpoint: "http://localhost:8053"}
+	assert.Equal(t, "http://localhost:8071", cfg.logsEndpoint())
+	assert.Equal(t, "http://localhost:8053", cfg.metricsEndpoint())
 }
@@ -23,6 +23,7 @@ import (
 	"github.com/jaegertracing/jaeger/model"
 	"github.com/logzio/jaeger-logzio/store"
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/consumer/pdata"
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
 	"go.opentelemetry.io/collector/translator/trace/jaeger"
@@ -70,31 +71,22 @@ func newLogzioExporter(config *Config, params component.ExporterCreateSettings)
 	}, nil
 }
 
-func newLogzioTracesExporter(config *Config, params component.ExporterCreateSettings) (component.TracesExporter, error) {
-	exporter, err := newLogzioExporter(config, params)
+func newLogzioTracesExporter(cfg *Config, params component.ExporterCreateSettings) (component.TracesExporter, error) {
+	exporter, err := newLogzioExporter(cfg, params)
 	if err != nil {
 		return nil, err
 	}
-	if err := config.validate(); err != nil {
+	if err := cfg.validate(config.TracesDataType); err != nil {
 		return nil, err
 	}
 
 	return exporterhelper.NewTracesExporter(
-		config,
+		cfg,
 		params.Logger,
 		exporter.pushTraceData,
 		exporterhelper.WithShutdown(exporter.Shutdown))
 }
 
-func newLogzioMetricsExporter(config *Config, params component.ExporterCreateSettings) (component.MetricsExporter, error) {
-	exporter, _ := newLogzioExporter(config, params)
-	return exporterhelper.NewMetricsExporter(
-		config,
-		params.Logger,
-		exporter.pushMetricsData,
-		exporterhelper.WithShutdown(exporter.Shutdown))
-}
-
 func (exporter *logzioExporter) pushTraceData(ctx context.Context, traces pdata.Traces) error {
 	batches, err := exporter.InternalTracesToJaegerTraces(traces)
 	if err != nil {
@@ -111,10 +103,6 @@ func (exporter *logzioExporter) pushTraceData(ctx context.Context, traces pdata.
 	return nil
 }
 
-func (exporter *logzioExporter) pushMetricsData(ctx context.Context, md pdata.Metrics) error {
-	return nil
-}
-
 func (exporter *logzioExporter) Shutdown(ctx context.Context) error {
 	exporter.logger.Info("Closing logzio exporter..")
 	exporter.writer.Close()
@@ -23,6 +23,7 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	commonpb "github.com/census-instrumentation/opencensus-proto/gen-go/agent/common/v1"
 	tracepb "github.com/census-instrumentation/opencensus-proto/gen-go/trace/v1"
@@ -31,6 +32,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/consumer/pdata"
 	"go.opentelemetry.io/collector/translator/internaldata"
@@ -76,8 +78,10 @@ func testMetricsExporter(md pdata.Metrics, t *testing.T, cfg *Config) {
 	params := component.ExporterCreateSettings{Logger: zap.NewNop()}
 	exporter, err := createMetricsExporter(context.Background(), params, cfg)
 	require.NoError(t, err)
+	require.NoError(t, exporter.Start(context.Background(), componenttest.NewNopHost()))
 	err = exporter.ConsumeMetrics(context.Background(), md)
 	assert.NoError(t, err)
+	require.NoError(t, exporter.Shutdown(context.Background()))
 }
 
 func TestNullExporterConfig(tester *testing.T) {
@@ -174,13 +178,42 @@ func TestPushTraceData(tester *testing.T) {
 }
 
 func TestPushMetricsData(tester *testing.T) {
+	requests := make(chan *http.Request, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		requests <- req
+		rw.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
 	cfg := Config{
-		ExporterSettings: config.NewExporterSettings(config.NewID(typeStr)),
-		MetricsToken:     "test",
-		Region:           "eu",
-		CustomEndpoint:   "url",
+		ExporterSettings:      config.NewExporterSettings(config.NewID(typeStr)),
+		MetricsToken:          "test",
+		Region:                "eu",
+		CustomMetricsEndpoint: server.URL,
 	}
 	md := pdata.NewMetrics()
+	metric := md.ResourceMetrics().AppendEmpty().InstrumentationLibraryMetrics().AppendEmpty().Metrics().AppendEmpty()
+	metric.SetName("test_gauge")
+	metric.SetDataType(pdata.MetricDataTypeIntGauge)
+	dp := metric.IntGauge().DataPoints().AppendEmpty()
+	dp.SetValue(1)
+	dp.SetTimestamp(pdata.TimestampFromTime(time.Now()))
 
 	testMetricsExporter(md, tester, &cfg)
+	select {
+	case req := <-requests:
+		assert.Equal(tester, "Bearer test", req.Header.Get("Authorization"))
+		assert.Equal(tester, "snappy", req.Header.Get("Content-Encoding"))
+	case <-time.After(5 * time.Second):
+		tester.Fatal("no metrics received")
+	}
+}
+
+func TestNullMetricsTokenConfig(tester *testing.T) {
+	cfg := Config{
+		Region:      "eu",
+		TracesToken: "test",
+	}
+	params := component.ExporterCreateSettings{Logger: zap.NewNop()}
+	_, err := createMetricsExporter(context.Background(), params, &cfg)
+	assert.Error(tester, err, "Empty metrics token should produce error")
 }
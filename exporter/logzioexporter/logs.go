@@ -0,0 +1,241 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logzioexporter
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+	tracetranslator "go.opentelemetry.io/collector/translator/trace"
+	"go.uber.org/zap"
+)
+
+// maxBulkSize is the maximum size of the uncompressed body of a request shipping logs, the
+// listener refuses bulks larger than 10MB.
+const maxBulkSize = 9 * 1024 * 1024
+
+// logzioLogsExporter ships log records to the Logz.io listener in bulks of JSON documents, one
+// per line.
+type logzioLogsExporter struct {
+	url    string
+	client *http.Client
+	logger *zap.Logger
+}
+
+func newLogzioLogsExporter(cfg *Config, params component.ExporterCreateSettings) (component.LogsExporter, error) {
+	if err := cfg.validate(config.LogsDataType); err != nil {
+		return nil, err
+	}
+
+	u, err := url.Parse(cfg.logsEndpoint())
+	if err != nil {
+		return nil, fmt.Errorf("invalid logs endpoint: %w", err)
+	}
+	query := u.Query()
+	query.Set("token", cfg.LogsToken)
+	u.RawQuery = query.Encode()
+
+	exporter := &logzioLogsExporter{
+		url:    u.String(),
+		client: &http.Client{Timeout: cfg.Timeout},
+		logger: params.Logger,
+	}
+
+	return exporterhelper.NewLogsExporter(
+		cfg,
+		params.Logger,
+		exporter.pushLogsData,
+		// Disable the timeout of exporterhelper, the HTTP client enforces it.
+		exporterhelper.WithTimeout(exporterhelper.TimeoutSettings{Timeout: 0}),
+		exporterhelper.WithQueue(cfg.QueueSettings),
+		exporterhelper.WithRetry(cfg.RetrySettings))
+}
+
+func (exporter *logzioLogsExporter) pushLogsData(ctx context.Context, ld pdata.Logs) error {
+	var (
+		buf bytes.Buffer
+		// index is the position of the current log record in ld, bulkStart the one of the
+		// first log record of the bulk being built.
+		index, bulkStart int
+		dropped          int
+	)
+	defer func() {
+		if dropped > 0 {
+			exporter.logger.Warn("Dropped log records that cannot be encoded", zap.Int("dropped_items", dropped))
+		}
+	}()
+
+	encoder := json.NewEncoder(&buf)
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		resourceAttributes := tracetranslator.AttributeMapToMap(rl.Resource().Attributes())
+		ills := rl.InstrumentationLibraryLogs()
+		for j := 0; j < ills.Len(); j++ {
+			logs := ills.At(j).Logs()
+			for k := 0; k < logs.Len(); k, index = k+1, index+1 {
+				mark := buf.Len()
+				// The encoder terminates each document with a line break.
+				if err := encoder.Encode(logRecordToDocument(resourceAttributes, logs.At(k))); err != nil {
+					buf.Truncate(mark)
+					dropped++
+					exporter.logger.Debug("Dropped log record that cannot be encoded", zap.Error(err))
+					continue
+				}
+				if buf.Len() > maxBulkSize && mark > 0 {
+					if err := exporter.send(ctx, buf.Bytes()[:mark]); err != nil {
+						return consumererror.NewLogs(err, logsFrom(ld, bulkStart))
+					}
+					remaining := append([]byte(nil), buf.Bytes()[mark:]...)
+					buf.Reset()
+					buf.Write(remaining)
+					bulkStart = index
+				}
+			}
+		}
+	}
+
+	if buf.Len() > 0 {
+		if err := exporter.send(ctx, buf.Bytes()); err != nil {
+			return consumererror.NewLogs(err, logsFrom(ld, bulkStart))
+		}
+	}
+	return nil
+}
+
+// logsFrom returns the log records of ld from the given position on, so that only the bulks
+// that were not sent are retried.
+func logsFrom(ld pdata.Logs, start int) pdata.Logs {
+	if start == 0 {
+		return ld
+	}
+	out := pdata.NewLogs()
+	index := 0
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		var (
+			outRl  pdata.ResourceLogs
+			copied bool
+		)
+		ills := rl.InstrumentationLibraryLogs()
+		for j := 0; j < ills.Len(); j++ {
+			ill := ills.At(j)
+			logs := ill.Logs()
+			if index+logs.Len() <= start {
+				index += logs.Len()
+				continue
+			}
+			if !copied {
+				outRl = out.ResourceLogs().AppendEmpty()
+				rl.Resource().CopyTo(outRl.Resource())
+				copied = true
+			}
+			outIll := outRl.InstrumentationLibraryLogs().AppendEmpty()
+			ill.InstrumentationLibrary().CopyTo(outIll.InstrumentationLibrary())
+			for k := 0; k < logs.Len(); k, index = k+1, index+1 {
+				if index >= start {
+					logs.At(k).CopyTo(outIll.Logs().AppendEmpty())
+				}
+			}
+		}
+	}
+	return out
+}
+
+// send posts the bulk of documents, gzip compressed, to the listener.
+func (exporter *logzioLogsExporter) send(ctx context.Context, bulk []byte) error {
+	var body bytes.Buffer
+	gzipWriter := gzip.NewWriter(&body)
+	if _, err := gzipWriter.Write(bulk); err != nil {
+		return consumererror.Permanent(err)
+	}
+	if err := gzipWriter.Close(); err != nil {
+		return consumererror.Permanent(err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, exporter.url, &body)
+	if err != nil {
+		return consumererror.Permanent(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Content-Encoding", "gzip")
+
+	resp, err := exporter.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_, _ = io.Copy(ioutil.Discard, resp.Body)
+		resp.Body.Close()
+	}()
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+	err = fmt.Errorf("logs listener responded with HTTP Status Code %d", resp.StatusCode)
+	switch resp.StatusCode {
+	case http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusRequestEntityTooLarge:
+		// Retrying the same bulk would fail again.
+		return consumererror.Permanent(err)
+	}
+	return err
+}
+
+// logRecordToDocument returns the JSON document of the log record, holding the attributes of
+// the resource and of the log record as fields.
+func logRecordToDocument(resourceAttributes map[string]interface{}, lr pdata.LogRecord) map[string]interface{} {
+	doc := make(map[string]interface{}, len(resourceAttributes)+lr.Attributes().Len()+6)
+	for k, v := range resourceAttributes {
+		doc[k] = v
+	}
+	for k, v := range tracetranslator.AttributeMapToMap(lr.Attributes()) {
+		doc[k] = v
+	}
+
+	if lr.Timestamp() != 0 {
+		doc["@timestamp"] = lr.Timestamp().AsTime().UTC().Format(time.RFC3339Nano)
+	}
+	doc["message"] = tracetranslator.AttributeValueToString(lr.Body())
+	if lr.Name() != "" {
+		doc["name"] = lr.Name()
+	}
+	if lr.SeverityText() != "" {
+		doc["severity"] = lr.SeverityText()
+	}
+	if lr.SeverityNumber() != pdata.SeverityNumberUNDEFINED {
+		doc["severity_number"] = int32(lr.SeverityNumber())
+	}
+	if traceID := lr.TraceID(); !traceID.IsEmpty() {
+		doc["trace_id"] = traceID.HexString()
+	}
+	if spanID := lr.SpanID(); !spanID.IsEmpty() {
+		doc["span_id"] = spanID.HexString()
+	}
+	return doc
+}
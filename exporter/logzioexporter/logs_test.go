@@ -0,0 +1,198 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logzioexporter
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.uber.org/zap"
+)
+
+func testLogs() pdata.Logs {
+	ld := pdata.NewLogs()
+	rl := ld.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().InsertString("service.name", testService)
+	logs := rl.InstrumentationLibraryLogs().AppendEmpty().Logs()
+
+	lr := logs.AppendEmpty()
+	lr.SetTimestamp(pdata.TimestampFromTime(time.Date(2021, 6, 1, 10, 0, 0, 0, time.UTC)))
+	lr.Body().SetStringVal("first log")
+	lr.SetSeverityText("INFO")
+	lr.SetSeverityNumber(pdata.SeverityNumberINFO)
+	lr.SetTraceID(pdata.NewTraceID([16]byte{1}))
+	lr.SetSpanID(pdata.NewSpanID([8]byte{2}))
+	lr.Attributes().InsertInt("attempt", 2)
+
+	lr = logs.AppendEmpty()
+	lr.Body().SetStringVal("second log")
+	lr.Attributes().InsertString("service.name", "override")
+	return ld
+}
+
+func TestLogRecordToDocument(t *testing.T) {
+	rl := testLogs().ResourceLogs().At(0)
+	logs := rl.InstrumentationLibraryLogs().At(0).Logs()
+	resourceAttributes := map[string]interface{}{"service.name": testService}
+
+	assert.Equal(t, map[string]interface{}{
+		"@timestamp":      "2021-06-01T10:00:00Z",
+		"message":         "first log",
+		"severity":        "INFO",
+		"severity_number": int32(pdata.SeverityNumberINFO),
+		"trace_id":        "01000000000000000000000000000000",
+		"span_id":         "0200000000000000",
+		"attempt":         int64(2),
+		"service.name":    testService,
+	}, logRecordToDocument(resourceAttributes, logs.At(0)))
+
+	assert.Equal(t, map[string]interface{}{
+		"message":      "second log",
+		"service.name": "override",
+	}, logRecordToDocument(resourceAttributes, logs.At(1)))
+}
+
+func TestPushLogsData(t *testing.T) {
+	var (
+		query string
+		lines []string
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		query = req.URL.RawQuery
+		assert.Equal(t, "gzip", req.Header.Get("Content-Encoding"))
+		reader, err := gzip.NewReader(req.Body)
+		require.NoError(t, err)
+		body, err := ioutil.ReadAll(reader)
+		require.NoError(t, err)
+		lines = strings.Split(strings.TrimSuffix(string(body), "\n"), "\n")
+		rw.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	cfg := &Config{
+		ExporterSettings: config.NewExporterSettings(config.NewID(typeStr)),
+		LogsToken:        "logsToken",
+		CustomEndpoint:   server.URL,
+	}
+	params := component.ExporterCreateSettings{Logger: zap.NewNop()}
+	exporter, err := createLogsExporter(context.Background(), params, cfg)
+	require.NoError(t, err)
+	require.NoError(t, exporter.Start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, exporter.ConsumeLogs(context.Background(), testLogs()))
+	require.NoError(t, exporter.Shutdown(context.Background()))
+
+	assert.Equal(t, "token=logsToken", query)
+	require.Len(t, lines, 2)
+	var doc map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &doc))
+	assert.Equal(t, "first log", doc["message"])
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &doc))
+	assert.Equal(t, "second log", doc["message"])
+}
+
+func TestPushLogsDataError(t *testing.T) {
+	tests := []struct {
+		name      string
+		status    int
+		permanent bool
+	}{
+		{name: "unauthorized", status: http.StatusUnauthorized, permanent: true},
+		{name: "server error", status: http.StatusServiceUnavailable, permanent: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				rw.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+
+			exporter := &logzioLogsExporter{url: server.URL, client: http.DefaultClient, logger: zap.NewNop()}
+			err := exporter.pushLogsData(context.Background(), testLogs())
+			require.Error(t, err)
+			assert.Equal(t, tt.permanent, consumererror.IsPermanent(err))
+		})
+	}
+}
+
+func TestPushLogsDataPartialError(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		requests++
+		// Accept the first bulk only.
+		if requests > 1 {
+			rw.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		rw.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	// Each log record is larger than half a bulk, so that each one is sent in its own bulk.
+	ld := pdata.NewLogs()
+	logs := ld.ResourceLogs().AppendEmpty().InstrumentationLibraryLogs().AppendEmpty().Logs()
+	body := strings.Repeat("a", maxBulkSize/2+1)
+	for _, name := range []string{"first", "second", "third"} {
+		lr := logs.AppendEmpty()
+		lr.SetName(name)
+		lr.Body().SetStringVal(body)
+	}
+
+	exporter := &logzioLogsExporter{url: server.URL, client: http.DefaultClient, logger: zap.NewNop()}
+	err := exporter.pushLogsData(context.Background(), ld)
+	require.Error(t, err)
+	assert.False(t, consumererror.IsPermanent(err))
+	assert.Equal(t, 2, requests)
+
+	var logsErr consumererror.Logs
+	require.True(t, consumererror.AsLogs(err, &logsErr))
+	failed := logsErr.GetLogs()
+	require.Equal(t, 2, failed.LogRecordCount())
+	failedLogs := failed.ResourceLogs().At(0).InstrumentationLibraryLogs().At(0).Logs()
+	assert.Equal(t, "second", failedLogs.At(0).Name())
+	assert.Equal(t, "third", failedLogs.At(1).Name())
+}
+
+func TestLogsFrom(t *testing.T) {
+	ld := testLogs()
+	assert.Equal(t, ld, logsFrom(ld, 0))
+
+	rest := logsFrom(ld, 1)
+	require.Equal(t, 1, rest.LogRecordCount())
+	rl := rest.ResourceLogs().At(0)
+	assert.Equal(t, ld.ResourceLogs().At(0).Resource(), rl.Resource())
+	assert.Equal(t, "second log", rl.InstrumentationLibraryLogs().At(0).Logs().At(0).Body().StringVal())
+
+	assert.Equal(t, 0, logsFrom(ld, 2).LogRecordCount())
+}
+
+func TestNullLogsTokenConfig(t *testing.T) {
+	params := component.ExporterCreateSettings{Logger: zap.NewNop()}
+	_, err := createLogsExporter(context.Background(), params, &Config{TracesToken: "test"})
+	assert.Error(t, err, "Empty logs token should produce error")
+}
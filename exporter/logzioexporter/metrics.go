@@ -0,0 +1,50 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logzioexporter
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	prw "go.opentelemetry.io/collector/exporter/prometheusremotewriteexporter"
+)
+
+// newLogzioMetricsExporter creates an exporter shipping metrics to the Prometheus remote write
+// endpoint of the Logz.io listener, authenticated by the metrics token.
+func newLogzioMetricsExporter(cfg *Config, params component.ExporterCreateSettings) (component.MetricsExporter, error) {
+	if err := cfg.validate(config.MetricsDataType); err != nil {
+		return nil, err
+	}
+
+	factory := prw.NewFactory()
+	prwCfg := factory.CreateDefaultConfig().(*prw.Config)
+	prwCfg.ExporterSettings = cfg.ExporterSettings
+	prwCfg.TimeoutSettings = cfg.TimeoutSettings
+	prwCfg.RetrySettings = cfg.RetrySettings
+	if cfg.QueueSettings.Enabled {
+		prwCfg.RemoteWriteQueue = prw.RemoteWriteQueue{
+			QueueSize:    cfg.QueueSettings.QueueSize,
+			NumConsumers: cfg.QueueSettings.NumConsumers,
+		}
+	}
+	prwCfg.HTTPClientSettings.Endpoint = cfg.metricsEndpoint()
+	prwCfg.HTTPClientSettings.Timeout = cfg.Timeout
+	prwCfg.HTTPClientSettings.Headers = map[string]string{
+		"Authorization": "Bearer " + cfg.MetricsToken,
+	}
+
+	return factory.CreateMetricsExporter(context.Background(), params, prwCfg)
+}
@@ -0,0 +1,68 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlphttpauthexporter
+
+import (
+	"fmt"
+	"net/http"
+
+	"golang.org/x/oauth2"
+)
+
+type authRoundTripper struct {
+	transport   http.RoundTripper
+	tokenSource oauth2.TokenSource
+	header      string
+}
+
+func (rt *authRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	// Clone request to ensure thread safety
+	req2 := req.Clone(req.Context())
+
+	tkn, err := rt.tokenSource.Token()
+	if err != nil {
+		return nil, err
+	}
+	if http.CanonicalHeaderKey(rt.header) == defaultHeader {
+		tkn.SetAuthHeader(req2)
+	} else {
+		req2.Header.Set(rt.header, tkn.AccessToken)
+	}
+
+	resp, err := rt.transport.RoundTrip(req2)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func newAuthRoundTripper(ts oauth2.TokenSource, header string, next http.RoundTripper) (http.RoundTripper, error) {
+	if ts == nil {
+		return nil, fmt.Errorf("no TokenSource exists")
+	}
+
+	if len(header) == 0 {
+		return nil, fmt.Errorf("no header provided")
+	}
+
+	rt := authRoundTripper{
+		transport:   next,
+		tokenSource: ts,
+		header:      header,
+	}
+
+	return &rt, nil
+}
@@ -0,0 +1,115 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlphttpauthexporter
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+)
+
+type errorRoundTripper struct{}
+
+func (ert *errorRoundTripper) RoundTrip(r *http.Request) (*http.Response, error) {
+	return nil, fmt.Errorf("error")
+}
+
+type invalidTokenSource struct{}
+
+func (ts *invalidTokenSource) Token() (*oauth2.Token, error) {
+	return nil, fmt.Errorf("bad TokenSource for testing")
+}
+
+func TestAuthRoundTripper_RoundTrip(t *testing.T) {
+	validTokenSource := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "test_access_token"})
+	defaultRoundTripper := (http.RoundTripper)(http.DefaultTransport.(*http.Transport).Clone())
+
+	tests := []struct {
+		name           string
+		rt             http.RoundTripper
+		token          oauth2.TokenSource
+		header         string
+		expectedHeader string
+		expectedValue  string
+		shouldError    bool
+	}{
+		{
+			name:           "Test authorization header",
+			rt:             defaultRoundTripper,
+			token:          validTokenSource,
+			header:         "Authorization",
+			expectedHeader: "Authorization",
+			expectedValue:  "Bearer test_access_token",
+		},
+		{
+			name:           "Test custom header",
+			rt:             defaultRoundTripper,
+			token:          validTokenSource,
+			header:         "x-api-key",
+			expectedHeader: "X-Api-Key",
+			expectedValue:  "test_access_token",
+		},
+		{
+			name:        "Test invalid token source",
+			rt:          defaultRoundTripper,
+			token:       &invalidTokenSource{},
+			header:      "Authorization",
+			shouldError: true,
+		},
+		{
+			name:        "Test error in next round tripper",
+			rt:          &errorRoundTripper{},
+			token:       validTokenSource,
+			header:      "Authorization",
+			shouldError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, tt.expectedValue, r.Header.Get(tt.expectedHeader))
+			}))
+			defer server.Close()
+			rt, err := newAuthRoundTripper(tt.token, tt.header, tt.rt)
+			require.NoError(t, err)
+			req, err := http.NewRequest("POST", server.URL, strings.NewReader(""))
+			require.NoError(t, err)
+			res, err := rt.RoundTrip(req)
+			if tt.shouldError {
+				assert.Nil(t, res)
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, 200, res.StatusCode)
+			res.Body.Close()
+		})
+	}
+}
+
+func TestNewAuthRoundTripper(t *testing.T) {
+	_, err := newAuthRoundTripper(nil, "Authorization", http.DefaultTransport)
+	assert.EqualError(t, err, "no TokenSource exists")
+
+	_, err = newAuthRoundTripper(oauth2.StaticTokenSource(&oauth2.Token{}), "", http.DefaultTransport)
+	assert.EqualError(t, err, "no header provided")
+}
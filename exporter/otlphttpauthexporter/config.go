@@ -0,0 +1,165 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlphttpauthexporter
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"time"
+
+	otlphttp "go.opentelemetry.io/collector/exporter/otlphttpexporter"
+)
+
+const (
+	// tokenSourceGCPServiceAccount issues Google-signed ID tokens for a service account.
+	tokenSourceGCPServiceAccount = "gcp_service_account"
+	// tokenSourceOIDC obtains access tokens using the OAuth2 client credentials flow.
+	tokenSourceOIDC = "oidc"
+	// tokenSourceStatic uses a fixed token.
+	tokenSourceStatic = "static"
+	// tokenSourceExec runs a command that prints the token to its standard output.
+	tokenSourceExec = "exec"
+
+	defaultHeader        = "Authorization"
+	defaultTokenLifetime = 5 * time.Minute
+)
+
+// Config defines configuration for the authenticated OTLP/HTTP exporter.
+type Config struct {
+	// Config represents the OTLP HTTP Exporter configuration.
+	otlphttp.Config `mapstructure:",squash"`
+
+	// AuthConfig represents the configuration of the token source used to authenticate requests.
+	AuthConfig AuthConfig `mapstructure:"token_auth"`
+}
+
+func (c *Config) sanitize() error {
+	if len(c.Endpoint) == 0 {
+		return fmt.Errorf("missing required \"endpoint\" setting")
+	}
+
+	endpointURL, err := url.Parse(c.Endpoint)
+	if err != nil {
+		return err
+	}
+
+	if len(c.AuthConfig.Header) == 0 {
+		c.AuthConfig.Header = defaultHeader
+	}
+
+	switch c.AuthConfig.TokenSource {
+	case tokenSourceGCPServiceAccount:
+		gcp := &c.AuthConfig.GCPServiceAccount
+		if len(gcp.CredentialFile) == 0 {
+			return fmt.Errorf("missing required \"token_auth.gcp_service_account.credential_file\" setting")
+		}
+		if _, err := os.Stat(gcp.CredentialFile); os.IsNotExist(err) {
+			return fmt.Errorf("the provided \"token_auth.gcp_service_account.credential_file\" does not exist")
+		}
+		if len(gcp.Audience) == 0 {
+			gcp.Audience = fmt.Sprintf("%s://%s", endpointURL.Scheme, endpointURL.Hostname())
+		}
+	case tokenSourceOIDC:
+		oidc := c.AuthConfig.OIDC
+		if len(oidc.TokenURL) == 0 {
+			return fmt.Errorf("missing required \"token_auth.oidc.token_url\" setting")
+		}
+		if len(oidc.ClientID) == 0 {
+			return fmt.Errorf("missing required \"token_auth.oidc.client_id\" setting")
+		}
+	case tokenSourceStatic:
+		if len(c.AuthConfig.Static.Token) == 0 {
+			return fmt.Errorf("missing required \"token_auth.static.token\" setting")
+		}
+	case tokenSourceExec:
+		if len(c.AuthConfig.Exec.Command) == 0 {
+			return fmt.Errorf("missing required \"token_auth.exec.command\" setting")
+		}
+		if c.AuthConfig.Exec.TokenLifetime <= 0 {
+			c.AuthConfig.Exec.TokenLifetime = defaultTokenLifetime
+		}
+	case "":
+		return fmt.Errorf("missing required \"token_auth.token_source\" setting")
+	default:
+		return fmt.Errorf("unsupported \"token_auth.token_source\" %q", c.AuthConfig.TokenSource)
+	}
+
+	return nil
+}
+
+// AuthConfig defines the token source used by the authRoundTripper.
+type AuthConfig struct {
+	// TokenSource is the kind of token source: gcp_service_account, oidc, static or exec.
+	TokenSource string `mapstructure:"token_source"`
+
+	// Header is the request header carrying the token. When it is "Authorization", the
+	// token is sent with its type as prefix (e.g. "Bearer <token>"), otherwise as is.
+	Header string `mapstructure:"header"`
+
+	// GCPServiceAccount configures the gcp_service_account token source.
+	GCPServiceAccount GCPServiceAccountConfig `mapstructure:"gcp_service_account"`
+
+	// OIDC configures the oidc token source.
+	OIDC OIDCConfig `mapstructure:"oidc"`
+
+	// Static configures the static token source.
+	Static StaticConfig `mapstructure:"static"`
+
+	// Exec configures the exec token source.
+	Exec ExecConfig `mapstructure:"exec"`
+}
+
+// GCPServiceAccountConfig defines the configuration of Google-signed ID tokens.
+type GCPServiceAccountConfig struct {
+	// CredentialFile is the path to the service account key file.
+	CredentialFile string `mapstructure:"credential_file"`
+
+	// Audience is the audience of the ID token. Defaults to the scheme and host of the endpoint.
+	Audience string `mapstructure:"audience"`
+}
+
+// OIDCConfig defines the configuration of the OAuth2 client credentials flow.
+type OIDCConfig struct {
+	// TokenURL is the token endpoint of the identity provider.
+	TokenURL string `mapstructure:"token_url"`
+
+	// ClientID is the identifier of the client.
+	ClientID string `mapstructure:"client_id"`
+
+	// ClientSecret is the secret of the client.
+	ClientSecret string `mapstructure:"client_secret"`
+
+	// Scopes are the scopes requested for the token.
+	Scopes []string `mapstructure:"scopes"`
+
+	// Audience, when set, is sent as the "audience" parameter of the token request.
+	Audience string `mapstructure:"audience"`
+}
+
+// StaticConfig defines a fixed token.
+type StaticConfig struct {
+	// Token is sent with every request.
+	Token string `mapstructure:"token"`
+}
+
+// ExecConfig defines a command printing the token to its standard output.
+type ExecConfig struct {
+	// Command is the program to run followed by its arguments.
+	Command []string `mapstructure:"command"`
+
+	// TokenLifetime is how long a token is reused before running the command again.
+	TokenLifetime time.Duration `mapstructure:"token_lifetime"`
+}
@@ -0,0 +1,185 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlphttpauthexporter
+
+import (
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configtest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	assert.Nil(t, err)
+
+	factory := NewFactory()
+	factories.Exporters[typeStr] = factory
+	cfg, err := configtest.LoadConfigAndValidate(path.Join(".", "testdata", "config.yaml"), factories)
+
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	assert.Equal(t, 4, len(cfg.Exporters))
+
+	static := cfg.Exporters[config.NewID(typeStr)].(*Config)
+	assert.Equal(t, "https://otlp.example.com", static.Endpoint)
+	assert.Equal(t, AuthConfig{
+		TokenSource: tokenSourceStatic,
+		Header:      defaultHeader,
+		Static:      StaticConfig{Token: "secret"},
+		Exec:        ExecConfig{TokenLifetime: defaultTokenLifetime},
+	}, static.AuthConfig)
+
+	gcp := cfg.Exporters[config.NewIDWithName(typeStr, "gcp")].(*Config)
+	assert.Equal(t, GCPServiceAccountConfig{
+		CredentialFile: "/etc/creds/key.json",
+		Audience:       "exampleaudience",
+	}, gcp.AuthConfig.GCPServiceAccount)
+
+	oidc := cfg.Exporters[config.NewIDWithName(typeStr, "oidc")].(*Config)
+	assert.Equal(t, OIDCConfig{
+		TokenURL:     "https://idp.example.com/oauth2/token",
+		ClientID:     "collector",
+		ClientSecret: "secret",
+		Scopes:       []string{"telemetry.write"},
+		Audience:     "https://otlp.example.com",
+	}, oidc.AuthConfig.OIDC)
+
+	exec := cfg.Exporters[config.NewIDWithName(typeStr, "exec")].(*Config)
+	assert.Equal(t, 10*time.Second, exec.Timeout)
+	assert.Equal(t, "X-Api-Key", exec.AuthConfig.Header)
+	assert.Equal(t, ExecConfig{
+		Command:       []string{"vault", "read", "-field=token", "secret/otlp"},
+		TokenLifetime: time.Minute,
+	}, exec.AuthConfig.Exec)
+}
+
+func TestConfig_sanitize(t *testing.T) {
+	const validEndpoint = "https://validendpoint.local"
+
+	tests := []struct {
+		name         string
+		endpoint     string
+		auth         AuthConfig
+		errorMessage string
+	}{
+		{
+			name:         "Test missing endpoint",
+			errorMessage: "missing required \"endpoint\" setting",
+		},
+		{
+			name:     "Test invalid endpoint",
+			endpoint: "this://is:an:invalid:endpoint.com",
+		},
+		{
+			name:         "Test missing token source",
+			endpoint:     validEndpoint,
+			errorMessage: "missing required \"token_auth.token_source\" setting",
+		},
+		{
+			name:         "Test unsupported token source",
+			endpoint:     validEndpoint,
+			auth:         AuthConfig{TokenSource: "kerberos"},
+			errorMessage: "unsupported \"token_auth.token_source\" \"kerberos\"",
+		},
+		{
+			name:         "Test credential file not provided",
+			endpoint:     validEndpoint,
+			auth:         AuthConfig{TokenSource: tokenSourceGCPServiceAccount},
+			errorMessage: "missing required \"token_auth.gcp_service_account.credential_file\" setting",
+		},
+		{
+			name:     "Test non-existent credential file",
+			endpoint: validEndpoint,
+			auth: AuthConfig{
+				TokenSource:       tokenSourceGCPServiceAccount,
+				GCPServiceAccount: GCPServiceAccountConfig{CredentialFile: "non-existent cred file"},
+			},
+			errorMessage: "the provided \"token_auth.gcp_service_account.credential_file\" does not exist",
+		},
+		{
+			name:         "Test missing token URL",
+			endpoint:     validEndpoint,
+			auth:         AuthConfig{TokenSource: tokenSourceOIDC},
+			errorMessage: "missing required \"token_auth.oidc.token_url\" setting",
+		},
+		{
+			name:     "Test missing client ID",
+			endpoint: validEndpoint,
+			auth: AuthConfig{
+				TokenSource: tokenSourceOIDC,
+				OIDC:        OIDCConfig{TokenURL: "https://idp.local/token"},
+			},
+			errorMessage: "missing required \"token_auth.oidc.client_id\" setting",
+		},
+		{
+			name:         "Test missing static token",
+			endpoint:     validEndpoint,
+			auth:         AuthConfig{TokenSource: tokenSourceStatic},
+			errorMessage: "missing required \"token_auth.static.token\" setting",
+		},
+		{
+			name:         "Test missing command",
+			endpoint:     validEndpoint,
+			auth:         AuthConfig{TokenSource: tokenSourceExec},
+			errorMessage: "missing required \"token_auth.exec.command\" setting",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := NewFactory().CreateDefaultConfig().(*Config)
+			cfg.Endpoint = tt.endpoint
+			cfg.AuthConfig = tt.auth
+
+			err := cfg.sanitize()
+			require.Error(t, err)
+			if len(tt.errorMessage) != 0 {
+				assert.Equal(t, tt.errorMessage, err.Error())
+			}
+		})
+	}
+
+	t.Run("Test defaults are set", func(t *testing.T) {
+		cfg := NewFactory().CreateDefaultConfig().(*Config)
+		cfg.Endpoint = validEndpoint
+		cfg.AuthConfig = AuthConfig{
+			TokenSource:       tokenSourceGCPServiceAccount,
+			GCPServiceAccount: GCPServiceAccountConfig{CredentialFile: "testdata/empty_credential_file.json"},
+		}
+
+		require.NoError(t, cfg.sanitize())
+		assert.Equal(t, defaultHeader, cfg.AuthConfig.Header)
+		assert.Equal(t, validEndpoint, cfg.AuthConfig.GCPServiceAccount.Audience)
+	})
+
+	t.Run("Test exec token lifetime defaults", func(t *testing.T) {
+		cfg := NewFactory().CreateDefaultConfig().(*Config)
+		cfg.Endpoint = validEndpoint
+		cfg.AuthConfig = AuthConfig{
+			TokenSource: tokenSourceExec,
+			Exec:        ExecConfig{Command: []string{"echo", "token"}},
+		}
+
+		require.NoError(t, cfg.sanitize())
+		assert.Equal(t, defaultTokenLifetime, cfg.AuthConfig.Exec.TokenLifetime)
+	})
+}
@@ -0,0 +1,114 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlphttpauthexporter
+
+import (
+	"context"
+	"net/http"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	otlphttp "go.opentelemetry.io/collector/exporter/otlphttpexporter"
+	"golang.org/x/oauth2"
+)
+
+const typeStr = "otlphttpauth" // The value of "type" key in configuration.
+
+type TokenSourceGetter func(config *Config) (oauth2.TokenSource, error)
+
+type otlphttpAuthFactory struct {
+	component.ExporterFactory
+	getTokenSource TokenSourceGetter
+}
+
+// NewFactory returns a factory of the authenticated OTLP/HTTP exporter that can be registered to the Collector.
+func NewFactory() component.ExporterFactory {
+	return NewFactoryWithTokenSourceGetter(getTokenSourceFromConfig)
+}
+
+func NewFactoryWithTokenSourceGetter(tsg TokenSourceGetter) component.ExporterFactory {
+	return &otlphttpAuthFactory{ExporterFactory: otlphttp.NewFactory(), getTokenSource: tsg}
+}
+
+func (f *otlphttpAuthFactory) Type() config.Type {
+	return typeStr
+}
+
+func (f *otlphttpAuthFactory) CreateMetricsExporter(
+	ctx context.Context,
+	params component.ExporterCreateSettings,
+	config config.Exporter) (component.MetricsExporter, error) {
+
+	cfg := config.(*Config)
+
+	if err := cfg.sanitize(); err != nil {
+		return nil, err
+	}
+
+	return f.ExporterFactory.CreateMetricsExporter(ctx, params, &cfg.Config)
+}
+
+func (f *otlphttpAuthFactory) CreateTracesExporter(
+	ctx context.Context,
+	params component.ExporterCreateSettings,
+	config config.Exporter) (component.TracesExporter, error) {
+
+	cfg := config.(*Config)
+
+	if err := cfg.sanitize(); err != nil {
+		return nil, err
+	}
+
+	return f.ExporterFactory.CreateTracesExporter(ctx, params, &cfg.Config)
+}
+
+func (f *otlphttpAuthFactory) CreateLogsExporter(
+	ctx context.Context,
+	params component.ExporterCreateSettings,
+	config config.Exporter) (component.LogsExporter, error) {
+
+	cfg := config.(*Config)
+
+	if err := cfg.sanitize(); err != nil {
+		return nil, err
+	}
+
+	return f.ExporterFactory.CreateLogsExporter(ctx, params, &cfg.Config)
+}
+
+func (f *otlphttpAuthFactory) CreateDefaultConfig() config.Exporter {
+	cfg := &Config{
+		Config: *f.ExporterFactory.CreateDefaultConfig().(*otlphttp.Config),
+		AuthConfig: AuthConfig{
+			Header: defaultHeader,
+			Exec: ExecConfig{
+				TokenLifetime: defaultTokenLifetime,
+			},
+		},
+	}
+
+	cfg.ExporterSettings = config.NewExporterSettings(config.NewID(typeStr))
+
+	cfg.HTTPClientSettings.CustomRoundTripper = func(next http.RoundTripper) (http.RoundTripper, error) {
+		ts, err := f.getTokenSource(cfg)
+		if err != nil {
+			return nil, err
+		}
+
+		return newAuthRoundTripper(ts, cfg.AuthConfig.Header, next)
+	}
+
+	return cfg
+}
@@ -0,0 +1,94 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlphttpauthexporter
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configcheck"
+	"go.opentelemetry.io/collector/testutil"
+	"go.uber.org/zap"
+	"golang.org/x/oauth2"
+)
+
+func TestFactory_TestType(t *testing.T) {
+	f := NewFactory()
+	assert.Equal(t, f.Type(), config.Type(typeStr))
+}
+
+func TestFactory_CreateDefaultConfig(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	assert.NotNil(t, cfg, "failed to create default config")
+	assert.NoError(t, configcheck.ValidateConfig(cfg))
+	ocfg, ok := factory.CreateDefaultConfig().(*Config)
+	assert.True(t, ok)
+	assert.Equal(t, ocfg.HTTPClientSettings.Endpoint, "")
+	assert.Equal(t, ocfg.HTTPClientSettings.Timeout, 30*time.Second, "default timeout is 30 seconds")
+	assert.Equal(t, ocfg.AuthConfig.Header, "Authorization", "default header is Authorization")
+	assert.Equal(t, ocfg.AuthConfig.Exec.TokenLifetime, 5*time.Minute, "default token lifetime is 5 minutes")
+	assert.NotNil(t, ocfg.HTTPClientSettings.CustomRoundTripper)
+}
+
+func TestFactory_CreateExporters(t *testing.T) {
+	factory := NewFactoryWithTokenSourceGetter(mockTokenSourceGetter)
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.HTTPClientSettings.Endpoint = "https://" + testutil.GetAvailableLocalAddress(t)
+	cfg.AuthConfig.TokenSource = tokenSourceStatic
+	cfg.AuthConfig.Static.Token = "tests"
+
+	creationParams := component.ExporterCreateSettings{Logger: zap.NewNop()}
+
+	mexp, err := factory.CreateMetricsExporter(context.Background(), creationParams, cfg)
+	require.NoError(t, err)
+	require.NotNil(t, mexp)
+
+	texp, err := factory.CreateTracesExporter(context.Background(), creationParams, cfg)
+	require.NoError(t, err)
+	require.NotNil(t, texp)
+
+	lexp, err := factory.CreateLogsExporter(context.Background(), creationParams, cfg)
+	require.NoError(t, err)
+	require.NotNil(t, lexp)
+}
+
+func TestFactory_CreateExportersInvalidConfig(t *testing.T) {
+	factory := NewFactoryWithTokenSourceGetter(mockTokenSourceGetter)
+	cfg := factory.CreateDefaultConfig().(*Config)
+
+	creationParams := component.ExporterCreateSettings{Logger: zap.NewNop()}
+
+	mexp, err := factory.CreateMetricsExporter(context.Background(), creationParams, cfg)
+	require.Error(t, err)
+	require.Nil(t, mexp)
+
+	texp, err := factory.CreateTracesExporter(context.Background(), creationParams, cfg)
+	require.Error(t, err)
+	require.Nil(t, texp)
+
+	lexp, err := factory.CreateLogsExporter(context.Background(), creationParams, cfg)
+	require.Error(t, err)
+	require.Nil(t, lexp)
+}
+
+func mockTokenSourceGetter(_ *Config) (oauth2.TokenSource, error) {
+	return oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "test_access_token"}), nil
+}
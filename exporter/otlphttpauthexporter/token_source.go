@@ -0,0 +1,85 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlphttpauthexporter
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/url"
+	"os/exec"
+	"strings"
+	"time"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+	"google.golang.org/api/idtoken"
+)
+
+// getTokenSourceFromConfig gets a TokenSource based on the configuration.
+func getTokenSourceFromConfig(config *Config) (oauth2.TokenSource, error) {
+	auth := config.AuthConfig
+	switch auth.TokenSource {
+	case tokenSourceGCPServiceAccount:
+		return idtoken.NewTokenSource(context.Background(), auth.GCPServiceAccount.Audience,
+			idtoken.WithCredentialsFile(auth.GCPServiceAccount.CredentialFile))
+	case tokenSourceOIDC:
+		cc := &clientcredentials.Config{
+			ClientID:     auth.OIDC.ClientID,
+			ClientSecret: auth.OIDC.ClientSecret,
+			TokenURL:     auth.OIDC.TokenURL,
+			Scopes:       auth.OIDC.Scopes,
+		}
+		if len(auth.OIDC.Audience) != 0 {
+			cc.EndpointParams = url.Values{"audience": {auth.OIDC.Audience}}
+		}
+		return cc.TokenSource(context.Background()), nil
+	case tokenSourceStatic:
+		return oauth2.StaticTokenSource(&oauth2.Token{AccessToken: auth.Static.Token}), nil
+	case tokenSourceExec:
+		return oauth2.ReuseTokenSource(nil, &execTokenSource{
+			command:  auth.Exec.Command,
+			lifetime: auth.Exec.TokenLifetime,
+		}), nil
+	}
+
+	return nil, fmt.Errorf("unsupported token source %q", auth.TokenSource)
+}
+
+// execTokenSource runs a command and uses its trimmed standard output as token.
+type execTokenSource struct {
+	command  []string
+	lifetime time.Duration
+}
+
+func (ts *execTokenSource) Token() (*oauth2.Token, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command(ts.command[0], ts.command[1:]...) // #nosec G204
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("failed to run token command: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	token := strings.TrimSpace(stdout.String())
+	if len(token) == 0 {
+		return nil, fmt.Errorf("token command returned an empty token")
+	}
+
+	return &oauth2.Token{
+		AccessToken: token,
+		Expiry:      time.Now().Add(ts.lifetime),
+	}, nil
+}
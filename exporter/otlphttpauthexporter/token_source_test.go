@@ -0,0 +1,124 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlphttpauthexporter
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetTokenSourceFromConfig(t *testing.T) {
+	t.Run("static", func(t *testing.T) {
+		cfg := &Config{AuthConfig: AuthConfig{
+			TokenSource: tokenSourceStatic,
+			Static:      StaticConfig{Token: "static_token"},
+		}}
+		ts, err := getTokenSourceFromConfig(cfg)
+		require.NoError(t, err)
+		tkn, err := ts.Token()
+		require.NoError(t, err)
+		assert.Equal(t, "static_token", tkn.AccessToken)
+	})
+
+	t.Run("exec", func(t *testing.T) {
+		cfg := &Config{AuthConfig: AuthConfig{
+			TokenSource: tokenSourceExec,
+			Exec: ExecConfig{
+				Command:       []string{"echo", "exec_token"},
+				TokenLifetime: time.Minute,
+			},
+		}}
+		ts, err := getTokenSourceFromConfig(cfg)
+		require.NoError(t, err)
+		tkn, err := ts.Token()
+		require.NoError(t, err)
+		assert.Equal(t, "exec_token", tkn.AccessToken)
+		assert.WithinDuration(t, time.Now().Add(time.Minute), tkn.Expiry, 10*time.Second)
+	})
+
+	t.Run("exec failure", func(t *testing.T) {
+		cfg := &Config{AuthConfig: AuthConfig{
+			TokenSource: tokenSourceExec,
+			Exec:        ExecConfig{Command: []string{"false"}},
+		}}
+		ts, err := getTokenSourceFromConfig(cfg)
+		require.NoError(t, err)
+		_, err = ts.Token()
+		assert.Error(t, err)
+	})
+
+	t.Run("exec empty token", func(t *testing.T) {
+		cfg := &Config{AuthConfig: AuthConfig{
+			TokenSource: tokenSourceExec,
+			Exec:        ExecConfig{Command: []string{"true"}},
+		}}
+		ts, err := getTokenSourceFromConfig(cfg)
+		require.NoError(t, err)
+		_, err = ts.Token()
+		assert.EqualError(t, err, "token command returned an empty token")
+	})
+
+	t.Run("oidc", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.NoError(t, r.ParseForm())
+			assert.Equal(t, "client_credentials", r.PostForm.Get("grant_type"))
+			assert.Equal(t, "telemetry.write", r.PostForm.Get("scope"))
+			assert.Equal(t, "https://otlp.local", r.PostForm.Get("audience"))
+			w.Header().Set("Content-Type", "application/json")
+			_, err := w.Write([]byte(`{"access_token":"oidc_token","token_type":"Bearer","expires_in":3600}`))
+			assert.NoError(t, err)
+		}))
+		defer server.Close()
+
+		cfg := &Config{AuthConfig: AuthConfig{
+			TokenSource: tokenSourceOIDC,
+			OIDC: OIDCConfig{
+				TokenURL:     server.URL,
+				ClientID:     "collector",
+				ClientSecret: "secret",
+				Scopes:       []string{"telemetry.write"},
+				Audience:     "https://otlp.local",
+			},
+		}}
+		ts, err := getTokenSourceFromConfig(cfg)
+		require.NoError(t, err)
+		tkn, err := ts.Token()
+		require.NoError(t, err)
+		assert.Equal(t, "oidc_token", tkn.AccessToken)
+	})
+
+	t.Run("gcp service account with invalid credentials", func(t *testing.T) {
+		cfg := &Config{AuthConfig: AuthConfig{
+			TokenSource: tokenSourceGCPServiceAccount,
+			GCPServiceAccount: GCPServiceAccountConfig{
+				CredentialFile: "testdata/empty_credential_file.json",
+				Audience:       "tests",
+			},
+		}}
+		ts, err := getTokenSourceFromConfig(cfg)
+		assert.Error(t, err)
+		assert.Nil(t, ts)
+	})
+
+	t.Run("unsupported", func(t *testing.T) {
+		_, err := getTokenSourceFromConfig(&Config{})
+		assert.Error(t, err)
+	})
+}
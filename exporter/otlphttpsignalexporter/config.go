@@ -0,0 +1,97 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlphttpsignalexporter
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"go.opentelemetry.io/collector/config/configgrpc"
+	"go.opentelemetry.io/collector/exporter/otlphttpexporter"
+)
+
+// compressionNone disables the compression set for all the signals.
+const compressionNone = "none"
+
+// Config defines configuration for the OTLP/HTTP exporter with per signal settings.
+type Config struct {
+	// Config represents the OTLP/HTTP exporter configuration, shared by all the signals.
+	otlphttpexporter.Config `mapstructure:",squash"`
+
+	// Traces overrides the shared settings for the traces.
+	Traces SignalSettings `mapstructure:"traces"`
+
+	// Metrics overrides the shared settings for the metrics.
+	Metrics SignalSettings `mapstructure:"metrics"`
+
+	// Logs overrides the shared settings for the logs.
+	Logs SignalSettings `mapstructure:"logs"`
+}
+
+// SignalSettings overrides the shared settings for the data of one signal.
+type SignalSettings struct {
+	// Endpoint is the URL the data is sent to. Optional.
+	Endpoint string `mapstructure:"endpoint"`
+
+	// Headers are added to the shared headers, replacing the shared headers
+	// with the same name. Optional.
+	Headers map[string]string `mapstructure:"headers"`
+
+	// Compression replaces the shared compression, "none" disabling it. Optional.
+	Compression string `mapstructure:"compression"`
+}
+
+// Validate checks if the exporter configuration is valid
+func (cfg *Config) Validate() error {
+	for signal, settings := range map[string]SignalSettings{"traces": cfg.Traces, "metrics": cfg.Metrics, "logs": cfg.Logs} {
+		if settings.Endpoint != "" {
+			if _, err := url.Parse(settings.Endpoint); err != nil {
+				return fmt.Errorf("%s.endpoint must be a valid URL", signal)
+			}
+		}
+		switch strings.ToLower(settings.Compression) {
+		case "", compressionNone, configgrpc.CompressionGzip:
+		default:
+			return fmt.Errorf("%s.compression: unsupported compression type %q", signal, settings.Compression)
+		}
+	}
+	return nil
+}
+
+// signalConfig returns the configuration of the OTLP/HTTP exporter sending the
+// data of a signal, the shared settings being overridden by the given ones.
+// The endpoint is set by the caller, the field depending on the signal.
+func (cfg *Config) signalConfig(settings SignalSettings) *otlphttpexporter.Config {
+	sCfg := cfg.Config
+
+	sCfg.Headers = make(map[string]string, len(cfg.Headers)+len(settings.Headers))
+	for name, value := range cfg.Headers {
+		sCfg.Headers[name] = value
+	}
+	for name, value := range settings.Headers {
+		sCfg.Headers[name] = value
+	}
+
+	switch strings.ToLower(settings.Compression) {
+	case "":
+	case compressionNone:
+		sCfg.Compression = ""
+	default:
+		sCfg.Compression = settings.Compression
+	}
+
+	return &sCfg
+}
@@ -0,0 +1,87 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlphttpsignalexporter
+
+import (
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configtest"
+)
+
+// TestLoadConfig checks whether yaml configuration can be loaded correctly.
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	assert.NoError(t, err)
+
+	factory := NewFactory()
+	factories.Exporters[typeStr] = factory
+	cfg, err := configtest.LoadConfigAndValidate(path.Join(".", "testdata", "config.yaml"), factories)
+
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	assert.Equal(t, factory.CreateDefaultConfig(), cfg.Exporters[config.NewID(typeStr)])
+
+	expected := factory.CreateDefaultConfig().(*Config)
+	expected.ExporterSettings = config.NewExporterSettings(config.NewIDWithName(typeStr, "2"))
+	expected.Endpoint = "https://vendor-a.example.com"
+	expected.TLSSetting.CAFile = "/var/lib/mycert.pem"
+	expected.Compression = "gzip"
+	expected.Headers = map[string]string{"api-key": "vendor-a-key"}
+	expected.Traces = SignalSettings{Endpoint: "https://traces.vendor-a.example.com/v1/traces"}
+	expected.Metrics = SignalSettings{
+		Endpoint:    "https://vendor-b.example.com/api/v1/otlp/metrics",
+		Compression: "none",
+		Headers:     map[string]string{"api-key": "vendor-b-key", "tenant": "team-1"},
+	}
+	assert.Equal(t, expected, cfg.Exporters[config.NewIDWithName(typeStr, "2")])
+}
+
+func TestValidateConfig(t *testing.T) {
+	cfg := NewFactory().CreateDefaultConfig().(*Config)
+	assert.NoError(t, cfg.Validate())
+
+	cfg.Logs.Compression = "zstd"
+	assert.EqualError(t, cfg.Validate(), `logs.compression: unsupported compression type "zstd"`)
+
+	cfg.Logs.Compression = "None"
+	assert.NoError(t, cfg.Validate())
+
+	cfg.Traces.Endpoint = "http://local host:4318\x7f"
+	assert.EqualError(t, cfg.Validate(), "traces.endpoint must be a valid URL")
+}
+
+func TestSignalConfig(t *testing.T) {
+	cfg := NewFactory().CreateDefaultConfig().(*Config)
+	cfg.Compression = "gzip"
+	cfg.Headers = map[string]string{"api-key": "shared", "x-scope": "all"}
+
+	oCfg := cfg.signalConfig(SignalSettings{})
+	assert.Equal(t, "gzip", oCfg.Compression)
+	assert.Equal(t, map[string]string{"api-key": "shared", "x-scope": "all"}, oCfg.Headers)
+
+	oCfg = cfg.signalConfig(SignalSettings{Compression: "none", Headers: map[string]string{"api-key": "metrics"}})
+	assert.Equal(t, "", oCfg.Compression)
+	assert.Equal(t, map[string]string{"api-key": "metrics", "x-scope": "all"}, oCfg.Headers)
+
+	// The shared settings are left untouched.
+	assert.Equal(t, "gzip", cfg.Compression)
+	assert.Equal(t, map[string]string{"api-key": "shared", "x-scope": "all"}, cfg.Headers)
+}
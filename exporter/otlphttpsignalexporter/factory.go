@@ -0,0 +1,76 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlphttpsignalexporter
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/exporter/otlphttpexporter"
+)
+
+const typeStr = "otlphttpsignal" // The value of "type" key in configuration.
+
+type signalFactory struct {
+	component.ExporterFactory
+}
+
+// NewFactory returns a factory of the OTLP/HTTP exporter with per signal settings that can be registered to the Collector.
+func NewFactory() component.ExporterFactory {
+	return &signalFactory{ExporterFactory: otlphttpexporter.NewFactory()}
+}
+
+func (sf *signalFactory) Type() config.Type {
+	return typeStr
+}
+
+func (sf *signalFactory) CreateDefaultConfig() config.Exporter {
+	cfg := &Config{
+		Config: *sf.ExporterFactory.CreateDefaultConfig().(*otlphttpexporter.Config),
+	}
+	cfg.ExporterSettings = config.NewExporterSettings(config.NewID(typeStr))
+	return cfg
+}
+
+func (sf *signalFactory) CreateTracesExporter(ctx context.Context, params component.ExporterCreateSettings,
+	cfg config.Exporter) (component.TracesExporter, error) {
+	sCfg := cfg.(*Config)
+	oCfg := sCfg.signalConfig(sCfg.Traces)
+	if sCfg.Traces.Endpoint != "" {
+		oCfg.TracesEndpoint = sCfg.Traces.Endpoint
+	}
+	return sf.ExporterFactory.CreateTracesExporter(ctx, params, oCfg)
+}
+
+func (sf *signalFactory) CreateMetricsExporter(ctx context.Context, params component.ExporterCreateSettings,
+	cfg config.Exporter) (component.MetricsExporter, error) {
+	sCfg := cfg.(*Config)
+	oCfg := sCfg.signalConfig(sCfg.Metrics)
+	if sCfg.Metrics.Endpoint != "" {
+		oCfg.MetricsEndpoint = sCfg.Metrics.Endpoint
+	}
+	return sf.ExporterFactory.CreateMetricsExporter(ctx, params, oCfg)
+}
+
+func (sf *signalFactory) CreateLogsExporter(ctx context.Context, params component.ExporterCreateSettings,
+	cfg config.Exporter) (component.LogsExporter, error) {
+	sCfg := cfg.(*Config)
+	oCfg := sCfg.signalConfig(sCfg.Logs)
+	if sCfg.Logs.Endpoint != "" {
+		oCfg.LogsEndpoint = sCfg.Logs.Endpoint
+	}
+	return sf.ExporterFactory.CreateLogsExporter(ctx, params, oCfg)
+}
@@ -0,0 +1,125 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlphttpsignalexporter
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configcheck"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.uber.org/zap"
+)
+
+type receivedRequest struct {
+	path            string
+	apiKey          string
+	contentEncoding string
+}
+
+type recorder struct {
+	mu       sync.Mutex
+	requests []receivedRequest
+}
+
+func (rec *recorder) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+	rec.requests = append(rec.requests, receivedRequest{
+		path:            r.URL.Path,
+		apiKey:          r.Header.Get("api-key"),
+		contentEncoding: r.Header.Get("Content-Encoding"),
+	})
+	w.WriteHeader(http.StatusOK)
+}
+
+func TestType(t *testing.T) {
+	af := NewFactory()
+	assert.Equal(t, af.Type(), config.Type(typeStr))
+}
+
+func TestCreateDefaultConfig(t *testing.T) {
+	cfg := NewFactory().CreateDefaultConfig()
+	assert.NotNil(t, cfg, "failed to create default config")
+	assert.Equal(t, config.NewID(typeStr), cfg.ID())
+	assert.NoError(t, configcheck.ValidateConfig(cfg))
+}
+
+func TestCreateExporters(t *testing.T) {
+	vendorA := &recorder{}
+	serverA := httptest.NewServer(vendorA)
+	defer serverA.Close()
+	vendorB := &recorder{}
+	serverB := httptest.NewServer(vendorB)
+	defer serverB.Close()
+
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.Endpoint = serverA.URL
+	cfg.Compression = "gzip"
+	cfg.Headers = map[string]string{"api-key": "vendor-a-key"}
+	cfg.RetrySettings.Enabled = false
+	cfg.QueueSettings.Enabled = false
+	cfg.Metrics = SignalSettings{
+		Endpoint:    serverB.URL + "/api/metrics",
+		Compression: "none",
+		Headers:     map[string]string{"api-key": "vendor-b-key"},
+	}
+	cfg.Logs = SignalSettings{Endpoint: serverA.URL + "/custom/logs"}
+
+	params := component.ExporterCreateSettings{Logger: zap.NewNop()}
+	host := componenttest.NewNopHost()
+	ctx := context.Background()
+
+	te, err := factory.CreateTracesExporter(ctx, params, cfg)
+	require.NoError(t, err)
+	require.NoError(t, te.Start(ctx, host))
+	defer te.Shutdown(ctx)
+	td := pdata.NewTraces()
+	td.ResourceSpans().AppendEmpty().InstrumentationLibrarySpans().AppendEmpty().Spans().AppendEmpty()
+	require.NoError(t, te.ConsumeTraces(ctx, td))
+
+	me, err := factory.CreateMetricsExporter(ctx, params, cfg)
+	require.NoError(t, err)
+	require.NoError(t, me.Start(ctx, host))
+	defer me.Shutdown(ctx)
+	md := pdata.NewMetrics()
+	md.ResourceMetrics().AppendEmpty().InstrumentationLibraryMetrics().AppendEmpty().Metrics().AppendEmpty()
+	require.NoError(t, me.ConsumeMetrics(ctx, md))
+
+	le, err := factory.CreateLogsExporter(ctx, params, cfg)
+	require.NoError(t, err)
+	require.NoError(t, le.Start(ctx, host))
+	defer le.Shutdown(ctx)
+	ld := pdata.NewLogs()
+	ld.ResourceLogs().AppendEmpty().InstrumentationLibraryLogs().AppendEmpty().Logs().AppendEmpty()
+	require.NoError(t, le.ConsumeLogs(ctx, ld))
+
+	assert.Equal(t, []receivedRequest{
+		{path: "/v1/traces", apiKey: "vendor-a-key", contentEncoding: "gzip"},
+		{path: "/custom/logs", apiKey: "vendor-a-key", contentEncoding: "gzip"},
+	}, vendorA.requests)
+	assert.Equal(t, []receivedRequest{
+		{path: "/api/metrics", apiKey: "vendor-b-key"},
+	}, vendorB.requests)
+}
@@ -0,0 +1,9 @@
+module github.com/open-telemetry/opentelemetry-collector-contrib/exporter/otlphttpsignalexporter
+
+go 1.16
+
+require (
+	github.com/stretchr/testify v1.7.0
+	go.opentelemetry.io/collector v0.27.1-0.20210603182316-5369d7e9e83e
+	go.uber.org/zap v1.17.0
+)
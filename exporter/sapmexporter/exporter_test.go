@@ -17,16 +17,20 @@ package sapmexporter
 import (
 	"context"
 	"fmt"
+	"io/ioutil"
 	"math/rand"
 	"net/http"
 	"net/http/httptest"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/jaegertracing/jaeger/model"
+	splunksapm "github.com/signalfx/sapm-proto/gen"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/consumer/pdata"
 	"go.opentelemetry.io/collector/translator/trace/jaeger"
@@ -238,3 +242,77 @@ func TestSAPMClientTokenUsageAndErrorMarshalling(t *testing.T) {
 		})
 	}
 }
+
+func TestConsumeTracesWithAccessTokenPassthrough(t *testing.T) {
+	tests := []struct {
+		name                   string
+		accessTokenPassthrough bool
+		expectedSpans          map[string]int
+	}{
+		{
+			name:                   "passthrough access token",
+			accessTokenPassthrough: true,
+			expectedSpans: map[string]int{
+				"ClientAccessToken": 10,
+				"MyToken0":          2,
+				"MyToken1":          3,
+				"MyToken2":          2,
+				"MyToken3":          3,
+			},
+		},
+		{
+			name:                   "don't passthrough access token",
+			accessTokenPassthrough: false,
+			expectedSpans: map[string]int{
+				"ClientAccessToken": 20,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			received := struct {
+				sync.Mutex
+				spans map[string]int
+			}{spans: map[string]int{}}
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, err := ioutil.ReadAll(r.Body)
+				require.NoError(t, err)
+				var req splunksapm.PostSpansRequest
+				require.NoError(t, req.Unmarshal(body))
+				assert.False(t, hasToken(req.Batches), "access token leaked to the backend")
+
+				received.Lock()
+				defer received.Unlock()
+				for _, batch := range req.Batches {
+					received.spans[r.Header.Get("x-sf-token")] += len(batch.Spans)
+				}
+				w.WriteHeader(http.StatusOK)
+			}))
+			defer server.Close()
+
+			cfg := &Config{
+				ExporterSettings:   config.NewExporterSettings(config.NewID(typeStr)),
+				Endpoint:           server.URL,
+				AccessToken:        "ClientAccessToken",
+				DisableCompression: true,
+				AccessTokenPassthroughConfig: splunk.AccessTokenPassthroughConfig{
+					AccessTokenPassthrough: tt.accessTokenPassthrough,
+				},
+			}
+			params := component.ExporterCreateSettings{Logger: zap.NewNop()}
+
+			te, err := newSAPMTracesExporter(cfg, params)
+			require.NoError(t, err)
+			require.NoError(t, te.Start(context.Background(), componenttest.NewNopHost()))
+			defer func() {
+				assert.NoError(t, te.Shutdown(context.Background()))
+			}()
+
+			require.NoError(t, te.ConsumeTraces(context.Background(), buildTestTraces(true)))
+
+			received.Lock()
+			defer received.Unlock()
+			assert.Equal(t, tt.expectedSpans, received.spans)
+		})
+	}
+}
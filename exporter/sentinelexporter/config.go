@@ -0,0 +1,132 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sentinelexporter
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+)
+
+const (
+	// ModeDataCollector sends the logs with the Log Analytics HTTP Data Collector API.
+	ModeDataCollector = "data_collector"
+	// ModeDCR sends the logs with the Logs Ingestion API, through a data collection rule.
+	ModeDCR = "dcr"
+)
+
+var logTypeRegexp = regexp.MustCompile(`^[A-Za-z0-9_]{1,100}$`)
+
+// Config defines configuration for the Microsoft Sentinel exporter.
+type Config struct {
+	config.ExporterSettings       `mapstructure:",squash"`
+	confighttp.HTTPClientSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct.
+	exporterhelper.QueueSettings  `mapstructure:"sending_queue"`
+	exporterhelper.RetrySettings  `mapstructure:"retry_on_failure"`
+
+	// Mode is the ingestion API used, either "data_collector" or "dcr".
+	// Default is "data_collector".
+	Mode string `mapstructure:"mode"`
+
+	// WorkspaceID is the ID of the Log Analytics workspace, used in "data_collector" mode.
+	WorkspaceID string `mapstructure:"workspace_id"`
+
+	// SharedKey is the primary or secondary key of the Log Analytics workspace,
+	// used in "data_collector" mode.
+	SharedKey string `mapstructure:"shared_key"`
+
+	// LogType is the name of the custom table the logs are sent to, used in
+	// "data_collector" mode. Log Analytics adds the "_CL" suffix.
+	LogType string `mapstructure:"log_type"`
+
+	// DCRImmutableID is the immutable ID of the data collection rule, used in "dcr" mode.
+	DCRImmutableID string `mapstructure:"dcr_immutable_id"`
+
+	// StreamName is the name of the stream of the data collection rule, used in "dcr" mode.
+	StreamName string `mapstructure:"stream_name"`
+
+	// TenantID, ClientID and ClientSecret are the credentials of the Azure AD
+	// application the logs are sent with, used in "dcr" mode.
+	TenantID     string `mapstructure:"tenant_id"`
+	ClientID     string `mapstructure:"client_id"`
+	ClientSecret string `mapstructure:"client_secret"`
+
+	// AuthorityHost is the Azure AD host tokens are requested from, used in
+	// "dcr" mode. Default is "https://login.microsoftonline.com".
+	AuthorityHost string `mapstructure:"authority_host"`
+
+	// FieldMapping maps the columns of the table to the fields of the log
+	// records, replacing the default columns. The "TimeGenerated" column is
+	// always set to the timestamp of the log records.
+	FieldMapping []FieldMapping `mapstructure:"field_mapping"`
+}
+
+// FieldMapping maps a column of the table to a field of the log records.
+type FieldMapping struct {
+	// Column is the name of the column, column names are case sensitive.
+	Column string `mapstructure:"column"`
+
+	// Field is the field of the log records: "body", "severity_text",
+	// "severity_number", "name", "trace_id", "span_id", "flags", "attributes",
+	// "resource", or a single attribute with "attributes.<key>" or
+	// "resource.<key>".
+	Field string `mapstructure:"field"`
+}
+
+func (c *Config) validate() error {
+	switch c.Mode {
+	case ModeDataCollector:
+		if c.WorkspaceID == "" {
+			return errors.New(`"workspace_id" is required in "data_collector" mode`)
+		}
+		if _, err := base64.StdEncoding.DecodeString(c.SharedKey); c.SharedKey == "" || err != nil {
+			return errors.New(`"shared_key" must be a base64 encoded key in "data_collector" mode`)
+		}
+		if !logTypeRegexp.MatchString(c.LogType) {
+			return errors.New(`"log_type" must only contain letters, numbers and underscores, up to 100 characters`)
+		}
+	case ModeDCR:
+		if _, err := url.Parse(c.Endpoint); c.Endpoint == "" || err != nil {
+			return errors.New(`"endpoint" must be the URL of the data collection endpoint in "dcr" mode`)
+		}
+		if c.DCRImmutableID == "" || c.StreamName == "" {
+			return errors.New(`"dcr_immutable_id" and "stream_name" are required in "dcr" mode`)
+		}
+		if c.TenantID == "" || c.ClientID == "" || c.ClientSecret == "" {
+			return errors.New(`"tenant_id", "client_id" and "client_secret" are required in "dcr" mode`)
+		}
+	default:
+		return fmt.Errorf(`"mode" must be either %q or %q`, ModeDataCollector, ModeDCR)
+	}
+
+	for _, m := range c.FieldMapping {
+		if m.Column == "" {
+			return errors.New(`"column" is required in "field_mapping"`)
+		}
+		if m.Column == timeGeneratedColumn {
+			return fmt.Errorf("column %q of \"field_mapping\" can't be mapped", m.Column)
+		}
+		if err := validateField(m.Field); err != nil {
+			return fmt.Errorf("invalid field of column %q of \"field_mapping\": %w", m.Column, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,202 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sentinelexporter
+
+import (
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/config/configtest"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	assert.Nil(t, err)
+
+	factory := NewFactory()
+	factories.Exporters[typeStr] = factory
+	cfg, err := configtest.LoadConfigAndValidate(path.Join(".", "testdata", "config.yaml"), factories)
+
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	assert.Equal(t, 2, len(cfg.Exporters))
+
+	dataCollectorCfg := factory.CreateDefaultConfig().(*Config)
+	dataCollectorCfg.WorkspaceID = "00000000-0000-0000-0000-000000000000"
+	dataCollectorCfg.SharedKey = "c2VjcmV0"
+	dataCollectorCfg.LogType = "OTelLogs"
+	assert.Equal(t, dataCollectorCfg, cfg.Exporters[config.NewID(typeStr)])
+
+	assert.Equal(t, &Config{
+		ExporterSettings: config.NewExporterSettings(config.NewIDWithName(typeStr, "dcr")),
+		HTTPClientSettings: confighttp.HTTPClientSettings{
+			Endpoint: "https://my-dce.westeurope-1.ingest.monitor.azure.com",
+			Timeout:  10 * time.Second,
+		},
+		RetrySettings:  exporterhelper.DefaultRetrySettings(),
+		QueueSettings:  exporterhelper.DefaultQueueSettings(),
+		Mode:           ModeDCR,
+		DCRImmutableID: "dcr-00000000000000000000000000000000",
+		StreamName:     "Custom-OTelLogs_CL",
+		TenantID:       "tenant",
+		ClientID:       "client",
+		ClientSecret:   "secret",
+		AuthorityHost:  defaultAuthorityHost,
+		FieldMapping: []FieldMapping{
+			{Column: "Message", Field: "body"},
+			{Column: "Severity", Field: "severity_text"},
+			{Column: "Host", Field: "resource.host.name"},
+			{Column: "UserName", Field: "attributes.user.name"},
+		},
+	}, cfg.Exporters[config.NewIDWithName(typeStr, "dcr")])
+}
+
+func TestValidateConfig(t *testing.T) {
+	dataCollector := func() *Config {
+		cfg := createDefaultConfig().(*Config)
+		cfg.WorkspaceID = "workspace"
+		cfg.SharedKey = "c2VjcmV0"
+		cfg.LogType = "OTelLogs"
+		return cfg
+	}
+	dcr := func() *Config {
+		cfg := createDefaultConfig().(*Config)
+		cfg.Mode = ModeDCR
+		cfg.Endpoint = "https://my-dce.westeurope-1.ingest.monitor.azure.com"
+		cfg.DCRImmutableID = "dcr-id"
+		cfg.StreamName = "Custom-OTelLogs_CL"
+		cfg.TenantID = "tenant"
+		cfg.ClientID = "client"
+		cfg.ClientSecret = "secret"
+		return cfg
+	}
+
+	tests := []struct {
+		name    string
+		cfg     *Config
+		wantErr string
+	}{
+		{name: "data collector", cfg: dataCollector()},
+		{name: "dcr", cfg: dcr()},
+		{
+			name: "invalid mode",
+			cfg: func() *Config {
+				cfg := dataCollector()
+				cfg.Mode = "syslog"
+				return cfg
+			}(),
+			wantErr: `"mode" must be either "data_collector" or "dcr"`,
+		},
+		{
+			name: "missing workspace",
+			cfg: func() *Config {
+				cfg := dataCollector()
+				cfg.WorkspaceID = ""
+				return cfg
+			}(),
+			wantErr: `"workspace_id" is required in "data_collector" mode`,
+		},
+		{
+			name: "shared key not base64",
+			cfg: func() *Config {
+				cfg := dataCollector()
+				cfg.SharedKey = "not base64!"
+				return cfg
+			}(),
+			wantErr: `"shared_key" must be a base64 encoded key in "data_collector" mode`,
+		},
+		{
+			name: "invalid log type",
+			cfg: func() *Config {
+				cfg := dataCollector()
+				cfg.LogType = "OTel-Logs"
+				return cfg
+			}(),
+			wantErr: `"log_type" must only contain letters, numbers and underscores, up to 100 characters`,
+		},
+		{
+			name: "dcr without endpoint",
+			cfg: func() *Config {
+				cfg := dcr()
+				cfg.Endpoint = ""
+				return cfg
+			}(),
+			wantErr: `"endpoint" must be the URL of the data collection endpoint in "dcr" mode`,
+		},
+		{
+			name: "dcr without stream",
+			cfg: func() *Config {
+				cfg := dcr()
+				cfg.StreamName = ""
+				return cfg
+			}(),
+			wantErr: `"dcr_immutable_id" and "stream_name" are required in "dcr" mode`,
+		},
+		{
+			name: "dcr without credentials",
+			cfg: func() *Config {
+				cfg := dcr()
+				cfg.ClientSecret = ""
+				return cfg
+			}(),
+			wantErr: `"tenant_id", "client_id" and "client_secret" are required in "dcr" mode`,
+		},
+		{
+			name: "mapping of TimeGenerated",
+			cfg: func() *Config {
+				cfg := dcr()
+				cfg.FieldMapping = []FieldMapping{{Column: "TimeGenerated", Field: "body"}}
+				return cfg
+			}(),
+			wantErr: `column "TimeGenerated" of "field_mapping" can't be mapped`,
+		},
+		{
+			name: "mapping of unknown field",
+			cfg: func() *Config {
+				cfg := dcr()
+				cfg.FieldMapping = []FieldMapping{{Column: "Message", Field: "message"}}
+				return cfg
+			}(),
+			wantErr: `invalid field of column "Message" of "field_mapping": unknown field "message"`,
+		},
+		{
+			name: "mapping without column",
+			cfg: func() *Config {
+				cfg := dcr()
+				cfg.FieldMapping = []FieldMapping{{Field: "body"}}
+				return cfg
+			}(),
+			wantErr: `"column" is required in "field_mapping"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,239 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sentinelexporter
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+	"go.uber.org/zap"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+)
+
+const (
+	dataCollectorAPIVersion = "2016-04-01"
+	logsIngestionAPIVersion = "2023-01-01"
+	logsIngestionScope      = "https://monitor.azure.com//.default"
+
+	// The Data Collector API accepts up to 30MB per request, the Logs Ingestion API up to 1MB.
+	dataCollectorMaxPayloadBytes = 25 * 1024 * 1024
+	dcrMaxPayloadBytes           = 1000 * 1000
+)
+
+type sentinelExporter struct {
+	config          *Config
+	logger          *zap.Logger
+	client          *http.Client
+	url             string
+	sharedKey       []byte
+	mapping         []FieldMapping
+	maxPayloadBytes int
+	now             func() time.Time
+}
+
+func newExporter(config *Config, logger *zap.Logger) (*sentinelExporter, error) {
+	e := &sentinelExporter{
+		config:  config,
+		logger:  logger,
+		mapping: config.FieldMapping,
+		now:     time.Now,
+	}
+	if len(e.mapping) == 0 {
+		e.mapping = defaultFieldMapping
+	}
+
+	switch config.Mode {
+	case ModeDataCollector:
+		key, err := base64.StdEncoding.DecodeString(config.SharedKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode shared key: %w", err)
+		}
+		e.sharedKey = key
+		base := config.Endpoint
+		if base == "" {
+			base = fmt.Sprintf("https://%s.ods.opinsights.azure.com", config.WorkspaceID)
+		}
+		e.url = fmt.Sprintf("%s/api/logs?api-version=%s", strings.TrimSuffix(base, "/"), dataCollectorAPIVersion)
+		e.maxPayloadBytes = dataCollectorMaxPayloadBytes
+	case ModeDCR:
+		e.url = fmt.Sprintf("%s/dataCollectionRules/%s/streams/%s?api-version=%s",
+			strings.TrimSuffix(config.Endpoint, "/"),
+			url.PathEscape(config.DCRImmutableID),
+			url.PathEscape(config.StreamName),
+			logsIngestionAPIVersion)
+		e.maxPayloadBytes = dcrMaxPayloadBytes
+	}
+	return e, nil
+}
+
+func (e *sentinelExporter) start(_ context.Context, host component.Host) error {
+	client, err := e.config.HTTPClientSettings.ToClient(host.GetExtensions())
+	if err != nil {
+		return err
+	}
+
+	if e.config.Mode == ModeDCR {
+		cc := clientcredentials.Config{
+			ClientID:     e.config.ClientID,
+			ClientSecret: e.config.ClientSecret,
+			TokenURL:     fmt.Sprintf("%s/%s/oauth2/v2.0/token", strings.TrimSuffix(e.config.AuthorityHost, "/"), url.PathEscape(e.config.TenantID)),
+			Scopes:       []string{logsIngestionScope},
+		}
+		// Tokens are requested and refreshed outside of the exported requests.
+		tokenCtx := context.WithValue(context.Background(), oauth2.HTTPClient, &http.Client{Timeout: e.config.Timeout})
+		client.Transport = &oauth2.Transport{
+			Source: cc.TokenSource(tokenCtx),
+			Base:   client.Transport,
+		}
+	}
+
+	e.client = client
+	return nil
+}
+
+func (e *sentinelExporter) pushLogs(ctx context.Context, ld pdata.Logs) error {
+	payloads, dropped := e.logsToPayloads(ld)
+	if dropped > 0 {
+		e.logger.Warn("Dropped log records larger than the maximum payload size",
+			zap.Int("dropped", dropped),
+			zap.Int("max_payload_bytes", e.maxPayloadBytes))
+	}
+
+	for _, payload := range payloads {
+		if err := e.send(ctx, payload); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// logsToPayloads maps the log records to rows, grouped into JSON arrays fitting
+// into the maximum payload size. Rows too large to fit are dropped.
+func (e *sentinelExporter) logsToPayloads(ld pdata.Logs) (payloads [][]byte, dropped int) {
+	now := e.now()
+	buf := &bytes.Buffer{}
+	flush := func() {
+		if buf.Len() == 0 {
+			return
+		}
+		buf.WriteByte(']')
+		payloads = append(payloads, buf.Bytes())
+		buf = &bytes.Buffer{}
+	}
+
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		ills := rl.InstrumentationLibraryLogs()
+		for j := 0; j < ills.Len(); j++ {
+			logs := ills.At(j).Logs()
+			for k := 0; k < logs.Len(); k++ {
+				row, err := json.Marshal(logRecordToRow(e.mapping, logs.At(k), rl.Resource(), now))
+				if err != nil {
+					dropped++
+					continue
+				}
+				// Leave room for the array brackets.
+				if len(row)+2 > e.maxPayloadBytes {
+					dropped++
+					continue
+				}
+				if buf.Len()+len(row)+2 > e.maxPayloadBytes {
+					flush()
+				}
+				if buf.Len() == 0 {
+					buf.WriteByte('[')
+				} else {
+					buf.WriteByte(',')
+				}
+				buf.Write(row)
+			}
+		}
+	}
+	flush()
+	return payloads, dropped
+}
+
+func (e *sentinelExporter) send(ctx context.Context, payload []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.url, bytes.NewReader(payload))
+	if err != nil {
+		return consumererror.Permanent(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	if e.config.Mode == ModeDataCollector {
+		date := e.now().UTC().Format(http.TimeFormat)
+		req.Header.Set("Log-Type", e.config.LogType)
+		req.Header.Set("x-ms-date", date)
+		req.Header.Set("time-generated-field", timeGeneratedColumn)
+		req.Header.Set("Authorization", e.signature(date, len(payload)))
+	}
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return err
+	}
+	_, _ = io.Copy(ioutil.Discard, resp.Body)
+	_ = resp.Body.Close()
+
+	return handleHTTPCode(resp)
+}
+
+// signature returns the value of the "Authorization" header of the Data Collector API.
+// See https://docs.microsoft.com/azure/azure-monitor/logs/data-collector-api#authorization.
+func (e *sentinelExporter) signature(date string, contentLength int) string {
+	stringToSign := fmt.Sprintf("POST\n%d\napplication/json\nx-ms-date:%s\n/api/logs", contentLength, date)
+	mac := hmac.New(sha256.New, e.sharedKey)
+	mac.Write([]byte(stringToSign))
+	return fmt.Sprintf("SharedKey %s:%s", e.config.WorkspaceID, base64.StdEncoding.EncodeToString(mac.Sum(nil)))
+}
+
+func handleHTTPCode(resp *http.Response) error {
+	if resp.StatusCode >= http.StatusOK && resp.StatusCode < http.StatusMultipleChoices {
+		return nil
+	}
+
+	err := fmt.Errorf("HTTP %d %q", resp.StatusCode, http.StatusText(resp.StatusCode))
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+		retryAfter := 0
+		if val := resp.Header.Get("Retry-After"); val != "" {
+			if seconds, errAtoi := strconv.Atoi(val); errAtoi == nil {
+				retryAfter = seconds
+			}
+		}
+		return exporterhelper.NewThrottleRetry(err, time.Duration(retryAfter)*time.Second)
+	case http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusNotFound, http.StatusRequestEntityTooLarge:
+		return consumererror.Permanent(err)
+	}
+	return err
+}
@@ -0,0 +1,213 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sentinelexporter
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.uber.org/zap"
+)
+
+func testLogs(n int) pdata.Logs {
+	ld := pdata.NewLogs()
+	logs := ld.ResourceLogs().AppendEmpty().InstrumentationLibraryLogs().AppendEmpty().Logs()
+	for i := 0; i < n; i++ {
+		logs.AppendEmpty().Body().SetStringVal(fmt.Sprintf("log %d", i))
+	}
+	return ld
+}
+
+func newTestExporter(t *testing.T, cfg *Config) *sentinelExporter {
+	require.NoError(t, cfg.validate())
+	exp, err := newExporter(cfg, zap.NewNop())
+	require.NoError(t, err)
+	require.NoError(t, exp.start(context.Background(), componenttest.NewNopHost()))
+	return exp
+}
+
+func TestPushLogsDataCollector(t *testing.T) {
+	const sharedKey = "c2VjcmV0"
+	var rows []map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/logs", r.URL.Path)
+		assert.Equal(t, "2016-04-01", r.URL.Query().Get("api-version"))
+		assert.Equal(t, "OTelLogs", r.Header.Get("Log-Type"))
+		assert.Equal(t, "TimeGenerated", r.Header.Get("time-generated-field"))
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+
+		body, err := ioutil.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		// Check the signature the same way Log Analytics does.
+		key, _ := base64.StdEncoding.DecodeString(sharedKey)
+		mac := hmac.New(sha256.New, key)
+		mac.Write([]byte(fmt.Sprintf("POST\n%d\napplication/json\nx-ms-date:%s\n/api/logs", len(body), r.Header.Get("x-ms-date"))))
+		assert.Equal(t, "SharedKey workspace:"+base64.StdEncoding.EncodeToString(mac.Sum(nil)), r.Header.Get("Authorization"))
+		_, err = time.Parse(http.TimeFormat, r.Header.Get("x-ms-date"))
+		assert.NoError(t, err)
+
+		require.NoError(t, json.Unmarshal(body, &rows))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = server.URL
+	cfg.WorkspaceID = "workspace"
+	cfg.SharedKey = sharedKey
+	cfg.LogType = "OTelLogs"
+	exp := newTestExporter(t, cfg)
+
+	require.NoError(t, exp.pushLogs(context.Background(), testLogs(2)))
+	require.Len(t, rows, 2)
+	assert.Equal(t, "log 0", rows[0]["Body"])
+	assert.Equal(t, "log 1", rows[1]["Body"])
+	assert.Contains(t, rows[0], "TimeGenerated")
+}
+
+func TestPushLogsDCR(t *testing.T) {
+	var tokenRequests int
+	tokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tokenRequests++
+		assert.Equal(t, "/tenant/oauth2/v2.0/token", r.URL.Path)
+		require.NoError(t, r.ParseForm())
+		assert.Equal(t, "client_credentials", r.Form.Get("grant_type"))
+		assert.Equal(t, logsIngestionScope, r.Form.Get("scope"))
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"access_token":"token","token_type":"Bearer","expires_in":3600}`))
+	}))
+	defer tokenServer.Close()
+
+	var payloads int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		payloads++
+		assert.Equal(t, "/dataCollectionRules/dcr-id/streams/Custom-OTelLogs_CL", r.URL.Path)
+		assert.Equal(t, "2023-01-01", r.URL.Query().Get("api-version"))
+		assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		var rows []map[string]interface{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&rows))
+		assert.Len(t, rows, 1)
+		assert.Equal(t, []string{"Message", "TimeGenerated"}, keys(rows[0]))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Mode = ModeDCR
+	cfg.Endpoint = server.URL
+	cfg.AuthorityHost = tokenServer.URL
+	cfg.DCRImmutableID = "dcr-id"
+	cfg.StreamName = "Custom-OTelLogs_CL"
+	cfg.TenantID = "tenant"
+	cfg.ClientID = "client"
+	cfg.ClientSecret = "secret"
+	cfg.FieldMapping = []FieldMapping{{Column: "Message", Field: "body"}}
+	exp := newTestExporter(t, cfg)
+
+	require.NoError(t, exp.pushLogs(context.Background(), testLogs(1)))
+	require.NoError(t, exp.pushLogs(context.Background(), testLogs(1)))
+	assert.Equal(t, 2, payloads)
+	// The token is cached until it expires.
+	assert.Equal(t, 1, tokenRequests)
+}
+
+func keys(m map[string]interface{}) []string {
+	var out []string
+	for k := range m {
+		out = append(out, k)
+	}
+	sort.Strings(out)
+	return out
+}
+
+func TestLogsToPayloadsSplitsBatches(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.WorkspaceID = "workspace"
+	cfg.SharedKey = "c2VjcmV0"
+	cfg.LogType = "OTelLogs"
+	exp, err := newExporter(cfg, zap.NewNop())
+	require.NoError(t, err)
+	exp.mapping = []FieldMapping{{Column: "Body", Field: "body"}}
+	exp.now = func() time.Time { return time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC) }
+
+	// Each row is 52 bytes long.
+	exp.maxPayloadBytes = 120
+	ld := testLogs(5)
+	ld.ResourceLogs().At(0).InstrumentationLibraryLogs().At(0).Logs().AppendEmpty().Body().SetStringVal(strings.Repeat("x", 200))
+
+	payloads, dropped := exp.logsToPayloads(ld)
+	assert.Equal(t, 1, dropped)
+	require.Len(t, payloads, 3)
+	for _, p := range payloads {
+		assert.LessOrEqual(t, len(p), exp.maxPayloadBytes)
+		var rows []map[string]interface{}
+		require.NoError(t, json.Unmarshal(p, &rows))
+	}
+}
+
+func TestPushLogsErrors(t *testing.T) {
+	tests := []struct {
+		name          string
+		status        int
+		retryAfter    string
+		wantPermanent bool
+	}{
+		{name: "throttled", status: http.StatusTooManyRequests, retryAfter: "10"},
+		{name: "unavailable", status: http.StatusServiceUnavailable},
+		{name: "server error", status: http.StatusInternalServerError},
+		{name: "bad request", status: http.StatusBadRequest, wantPermanent: true},
+		{name: "forbidden", status: http.StatusForbidden, wantPermanent: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if tt.retryAfter != "" {
+					w.Header().Set("Retry-After", tt.retryAfter)
+				}
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+
+			cfg := createDefaultConfig().(*Config)
+			cfg.Endpoint = server.URL
+			cfg.WorkspaceID = "workspace"
+			cfg.SharedKey = "c2VjcmV0"
+			cfg.LogType = "OTelLogs"
+			exp := newTestExporter(t, cfg)
+
+			err := exp.pushLogs(context.Background(), testLogs(1))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), fmt.Sprintf("HTTP %d", tt.status))
+			assert.Equal(t, tt.wantPermanent, consumererror.IsPermanent(err))
+		})
+	}
+}
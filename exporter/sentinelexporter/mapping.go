@@ -0,0 +1,127 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sentinelexporter
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	tracetranslator "go.opentelemetry.io/collector/translator/trace"
+)
+
+const (
+	timeGeneratedColumn = "TimeGenerated"
+
+	attributesPrefix = "attributes."
+	resourcePrefix   = "resource."
+)
+
+// defaultFieldMapping is the mapping used when none is configured.
+var defaultFieldMapping = []FieldMapping{
+	{Column: "Body", Field: "body"},
+	{Column: "SeverityText", Field: "severity_text"},
+	{Column: "SeverityNumber", Field: "severity_number"},
+	{Column: "Name", Field: "name"},
+	{Column: "TraceId", Field: "trace_id"},
+	{Column: "SpanId", Field: "span_id"},
+	{Column: "Attributes", Field: "attributes"},
+	{Column: "Resource", Field: "resource"},
+}
+
+var logRecordFields = map[string]bool{
+	"body":            true,
+	"severity_text":   true,
+	"severity_number": true,
+	"name":            true,
+	"trace_id":        true,
+	"span_id":         true,
+	"flags":           true,
+	"attributes":      true,
+	"resource":        true,
+}
+
+func validateField(field string) error {
+	if logRecordFields[field] {
+		return nil
+	}
+	if strings.HasPrefix(field, attributesPrefix) && len(field) > len(attributesPrefix) ||
+		strings.HasPrefix(field, resourcePrefix) && len(field) > len(resourcePrefix) {
+		return nil
+	}
+	return fmt.Errorf("unknown field %q", field)
+}
+
+// logRecordToRow maps a log record to a row of the table. Columns mapped to
+// fields missing from the record are left out.
+func logRecordToRow(mapping []FieldMapping, lr pdata.LogRecord, resource pdata.Resource, now time.Time) map[string]interface{} {
+	ts := now
+	if lr.Timestamp() != 0 {
+		ts = lr.Timestamp().AsTime()
+	}
+	row := map[string]interface{}{
+		timeGeneratedColumn: ts.UTC().Format(time.RFC3339Nano),
+	}
+	for _, m := range mapping {
+		if v, ok := fieldValue(m.Field, lr, resource); ok {
+			row[m.Column] = v
+		}
+	}
+	return row
+}
+
+func fieldValue(field string, lr pdata.LogRecord, resource pdata.Resource) (interface{}, bool) {
+	switch field {
+	case "body":
+		return rawValue(lr.Body())
+	case "severity_text":
+		return lr.SeverityText(), lr.SeverityText() != ""
+	case "severity_number":
+		return int32(lr.SeverityNumber()), lr.SeverityNumber() != pdata.SeverityNumberUNDEFINED
+	case "name":
+		return lr.Name(), lr.Name() != ""
+	case "trace_id":
+		return lr.TraceID().HexString(), !lr.TraceID().IsEmpty()
+	case "span_id":
+		return lr.SpanID().HexString(), !lr.SpanID().IsEmpty()
+	case "flags":
+		return lr.Flags(), true
+	case "attributes":
+		return tracetranslator.AttributeMapToMap(lr.Attributes()), lr.Attributes().Len() > 0
+	case "resource":
+		return tracetranslator.AttributeMapToMap(resource.Attributes()), resource.Attributes().Len() > 0
+	}
+	if strings.HasPrefix(field, attributesPrefix) {
+		if v, ok := lr.Attributes().Get(strings.TrimPrefix(field, attributesPrefix)); ok {
+			return rawValue(v)
+		}
+	} else if strings.HasPrefix(field, resourcePrefix) {
+		if v, ok := resource.Attributes().Get(strings.TrimPrefix(field, resourcePrefix)); ok {
+			return rawValue(v)
+		}
+	}
+	return nil, false
+}
+
+// rawValue returns the Go value of an attribute value, or false if it is empty.
+func rawValue(v pdata.AttributeValue) (interface{}, bool) {
+	if v.Type() == pdata.AttributeValueTypeNull {
+		return nil, false
+	}
+	m := pdata.NewAttributeMap()
+	m.Upsert("v", v)
+	return tracetranslator.AttributeMapToMap(m)["v"], true
+}
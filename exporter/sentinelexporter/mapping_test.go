@@ -0,0 +1,73 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sentinelexporter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+func testLogRecord() (pdata.LogRecord, pdata.Resource) {
+	ld := pdata.NewLogs()
+	rl := ld.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().InsertString("host.name", "host-1")
+	lr := rl.InstrumentationLibraryLogs().AppendEmpty().Logs().AppendEmpty()
+	lr.SetTimestamp(pdata.TimestampFromTime(time.Date(2021, 6, 1, 12, 0, 0, 500, time.UTC)))
+	lr.Body().SetStringVal("user logged in")
+	lr.SetSeverityText("INFO")
+	lr.SetSeverityNumber(pdata.SeverityNumberINFO)
+	lr.SetTraceID(pdata.NewTraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}))
+	lr.Attributes().InsertString("user.name", "alice")
+	lr.Attributes().InsertInt("attempts", 2)
+	return lr, rl.Resource()
+}
+
+func TestLogRecordToRowDefaultMapping(t *testing.T) {
+	lr, resource := testLogRecord()
+	row := logRecordToRow(defaultFieldMapping, lr, resource, time.Now())
+	assert.Equal(t, map[string]interface{}{
+		"TimeGenerated":  "2021-06-01T12:00:00.0000005Z",
+		"Body":           "user logged in",
+		"SeverityText":   "INFO",
+		"SeverityNumber": int32(pdata.SeverityNumberINFO),
+		"TraceId":        "0102030405060708090a0b0c0d0e0f10",
+		"Attributes":     map[string]interface{}{"user.name": "alice", "attempts": int64(2)},
+		"Resource":       map[string]interface{}{"host.name": "host-1"},
+	}, row)
+}
+
+func TestLogRecordToRowCustomMapping(t *testing.T) {
+	lr, resource := testLogRecord()
+	lr.SetTimestamp(0)
+	now := time.Date(2021, 6, 2, 0, 0, 0, 0, time.UTC)
+	row := logRecordToRow([]FieldMapping{
+		{Column: "Message", Field: "body"},
+		{Column: "Host", Field: "resource.host.name"},
+		{Column: "UserName", Field: "attributes.user.name"},
+		{Column: "Attempts", Field: "attributes.attempts"},
+		{Column: "Missing", Field: "attributes.missing"},
+		{Column: "SpanId", Field: "span_id"},
+	}, lr, resource, now)
+	assert.Equal(t, map[string]interface{}{
+		"TimeGenerated": "2021-06-02T00:00:00Z",
+		"Message":       "user logged in",
+		"Host":          "host-1",
+		"UserName":      "alice",
+		"Attempts":      int64(2),
+	}, row)
+}
@@ -18,6 +18,7 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -55,6 +56,7 @@ func (s *SentryExporter) pushTraceData(_ context.Context, td pdata.Traces) error
 	}
 
 	maybeOrphanSpans := make([]*sentry.Span, 0, td.SpanCount())
+	var errorEvents []*sentry.Event
 
 	// Maps all child span ids to their root span.
 	idMap := make(map[sentry.SpanID]sentry.SpanID)
@@ -73,6 +75,7 @@ func (s *SentryExporter) pushTraceData(_ context.Context, td pdata.Traces) error
 			spans := ils.Spans()
 			for k := 0; k < spans.Len(); k++ {
 				sentrySpan := convertToSentrySpan(spans.At(k), library, resourceTags)
+				errorEvents = append(errorEvents, errorsFromSpanEvents(spans.At(k).Events(), sentrySpan)...)
 
 				// If a span is a root span, we consider it the start of a Sentry transaction.
 				// We should then create a new transaction for that root span, and keep track of it.
@@ -94,17 +97,20 @@ func (s *SentryExporter) pushTraceData(_ context.Context, td pdata.Traces) error
 		}
 	}
 
-	if len(transactionMap) == 0 {
-		return nil
-	}
+	events := errorEvents
+	if len(transactionMap) != 0 {
+		// After the first pass through, we can't necessarily make the assumption we have not associated all
+		// the spans with a transaction. As such, we must classify the remaining spans as orphans or not.
+		orphanSpans := classifyAsOrphanSpans(maybeOrphanSpans, len(maybeOrphanSpans)+1, idMap, transactionMap)
 
-	// After the first pass through, we can't necessarily make the assumption we have not associated all
-	// the spans with a transaction. As such, we must classify the remaining spans as orphans or not.
-	orphanSpans := classifyAsOrphanSpans(maybeOrphanSpans, len(maybeOrphanSpans)+1, idMap, transactionMap)
+		events = append(generateTransactions(transactionMap, orphanSpans), events...)
+	}
 
-	transactions := generateTransactions(transactionMap, orphanSpans)
+	if len(events) == 0 {
+		return nil
+	}
 
-	s.transport.SendTransactions(transactions)
+	s.transport.SendEvents(events)
 
 	return nil
 }
@@ -312,6 +318,8 @@ func transactionFromSpan(span *sentry.Span) *sentry.Event {
 	transaction.Sdk.Name = otelSentryExporterName
 	transaction.Sdk.Version = otelSentryExporterVersion
 
+	transaction.Release, transaction.Environment = releaseAndEnvironment(span)
+
 	transaction.StartTime = span.StartTime
 	transaction.Tags = span.Tags
 	transaction.Timestamp = span.EndTime
@@ -320,6 +328,124 @@ func transactionFromSpan(span *sentry.Span) *sentry.Event {
 	return transaction
 }
 
+// errorsFromSpanEvents converts the span events recording exceptions to Sentry error events, so they
+// are grouped as Sentry issues. The other span events are attached to the errors as breadcrumbs.
+//
+// See https://github.com/open-telemetry/opentelemetry-specification/blob/main/specification/trace/semantic_conventions/exceptions.md
+func errorsFromSpanEvents(spanEvents pdata.SpanEventSlice, span *sentry.Span) []*sentry.Event {
+	var (
+		exceptions  []pdata.SpanEvent
+		breadcrumbs []*sentry.Breadcrumb
+	)
+	for i := 0; i < spanEvents.Len(); i++ {
+		spanEvent := spanEvents.At(i)
+		if isExceptionEvent(spanEvent) {
+			exceptions = append(exceptions, spanEvent)
+		} else {
+			breadcrumbs = append(breadcrumbs, breadcrumbFromSpanEvent(spanEvent))
+		}
+	}
+	if len(exceptions) == 0 {
+		return nil
+	}
+	sort.SliceStable(breadcrumbs, func(i, j int) bool {
+		return breadcrumbs[i].Timestamp.Before(breadcrumbs[j].Timestamp)
+	})
+
+	errorEvents := make([]*sentry.Event, 0, len(exceptions))
+	for _, exception := range exceptions {
+		errorEvents = append(errorEvents, errorFromSpanEvent(exception, span, breadcrumbs))
+	}
+	return errorEvents
+}
+
+// isExceptionEvent determines if a span event records an exception, that is if it has an exception
+// type or message.
+func isExceptionEvent(spanEvent pdata.SpanEvent) bool {
+	attrs := spanEvent.Attributes()
+	_, hasType := attrs.Get(conventions.AttributeExceptionType)
+	_, hasMessage := attrs.Get(conventions.AttributeExceptionMessage)
+	return hasType || hasMessage
+}
+
+// errorFromSpanEvent converts a span event recording an exception to a Sentry error event, with the
+// breadcrumbs that happened up to the exception.
+func errorFromSpanEvent(spanEvent pdata.SpanEvent, span *sentry.Span, breadcrumbs []*sentry.Breadcrumb) *sentry.Event {
+	errorEvent := sentry.NewEvent()
+
+	attrs := spanEvent.Attributes()
+	exception := sentry.Exception{}
+	if exceptionType, ok := attrs.Get(conventions.AttributeExceptionType); ok {
+		exception.Type = exceptionType.StringVal()
+	}
+	if message, ok := attrs.Get(conventions.AttributeExceptionMessage); ok {
+		exception.Value = message.StringVal()
+	}
+	errorEvent.Exception = []sentry.Exception{exception}
+	if stacktrace, ok := attrs.Get(conventions.AttributeExceptionStacktrace); ok {
+		errorEvent.Extra["stacktrace"] = stacktrace.StringVal()
+	}
+
+	errorEvent.Contexts["trace"] = sentry.TraceContext{
+		TraceID:      span.TraceID,
+		SpanID:       span.SpanID,
+		ParentSpanID: span.ParentSpanID,
+		Op:           span.Op,
+		Description:  span.Description,
+		Status:       span.Status,
+	}
+
+	errorEvent.Level = sentry.LevelError
+
+	errorEvent.Sdk.Name = otelSentryExporterName
+	errorEvent.Sdk.Version = otelSentryExporterVersion
+
+	errorEvent.Release, errorEvent.Environment = releaseAndEnvironment(span)
+
+	tags := generateTagsFromAttributes(attrs)
+	delete(tags, conventions.AttributeExceptionType)
+	delete(tags, conventions.AttributeExceptionMessage)
+	delete(tags, conventions.AttributeExceptionStacktrace)
+	for k, v := range span.Tags {
+		tags[k] = v
+	}
+	errorEvent.Tags = tags
+	errorEvent.Timestamp = unixNanoToTime(spanEvent.Timestamp())
+	errorEvent.Transaction = span.Description
+
+	for _, breadcrumb := range breadcrumbs {
+		if breadcrumb.Timestamp.After(errorEvent.Timestamp) {
+			break
+		}
+		errorEvent.Breadcrumbs = append(errorEvent.Breadcrumbs, breadcrumb)
+	}
+
+	return errorEvent
+}
+
+// breadcrumbFromSpanEvent converts a span event to a Sentry breadcrumb.
+func breadcrumbFromSpanEvent(spanEvent pdata.SpanEvent) *sentry.Breadcrumb {
+	data := make(map[string]interface{})
+	for k, v := range generateTagsFromAttributes(spanEvent.Attributes()) {
+		data[k] = v
+	}
+
+	return &sentry.Breadcrumb{
+		Type:      "default",
+		Category:  "span.event",
+		Message:   spanEvent.Name(),
+		Data:      data,
+		Level:     sentry.LevelInfo,
+		Timestamp: unixNanoToTime(spanEvent.Timestamp()),
+	}
+}
+
+// releaseAndEnvironment returns the Sentry release and environment of a span, from the service version
+// and deployment environment of its resource.
+func releaseAndEnvironment(span *sentry.Span) (release string, environment string) {
+	return span.Tags[conventions.AttributeServiceVersion], span.Tags[conventions.AttributeDeploymentEnvironment]
+}
+
 // CreateSentryExporter returns a new Sentry Exporter.
 func CreateSentryExporter(config *Config, params component.ExporterCreateSettings) (component.TracesExporter, error) {
 	transport := newSentryTransport()
@@ -23,6 +23,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/consumer/pdata"
 	"go.opentelemetry.io/collector/translator/conventions"
 )
@@ -494,13 +495,108 @@ func TestGenerateTransactions(t *testing.T) {
 	assert.Len(t, transactions, 4)
 }
 
+func TestTransactionFromSpan(t *testing.T) {
+	span := &sentry.Span{
+		Tags: map[string]string{
+			conventions.AttributeServiceVersion:        "1.2.3",
+			conventions.AttributeDeploymentEnvironment: "production",
+		},
+	}
+
+	transaction := transactionFromSpan(span)
+	assert.Equal(t, "1.2.3", transaction.Release)
+	assert.Equal(t, "production", transaction.Environment)
+}
+
+func TestErrorsFromSpanEvents(t *testing.T) {
+	span := &sentry.Span{
+		TraceID:     TraceIDFromHex("01020304050607080807060504030201"),
+		SpanID:      SpanIDFromHex("0102030405060708"),
+		Description: "GET /api/users",
+		Op:          "http.server",
+		Status:      sentry.SpanStatusInternalError,
+		Tags: map[string]string{
+			"key":                                      "value",
+			conventions.AttributeServiceVersion:        "1.2.3",
+			conventions.AttributeDeploymentEnvironment: "production",
+		},
+	}
+
+	t.Run("without exception", func(t *testing.T) {
+		events := pdata.NewSpanEventSlice()
+		events.AppendEmpty().SetName("cache miss")
+
+		assert.Empty(t, errorsFromSpanEvents(events, span))
+	})
+
+	t.Run("with exception", func(t *testing.T) {
+		events := pdata.NewSpanEventSlice()
+		after := events.AppendEmpty()
+		after.SetName("retry")
+		after.SetTimestamp(300)
+		before := events.AppendEmpty()
+		before.SetName("cache miss")
+		before.SetTimestamp(100)
+		before.Attributes().InsertString("cache.key", "users")
+		exception := events.AppendEmpty()
+		exception.SetName(conventions.AttributeExceptionEventName)
+		exception.SetTimestamp(200)
+		exception.Attributes().InsertString(conventions.AttributeExceptionType, "ValueError")
+		exception.Attributes().InsertString(conventions.AttributeExceptionMessage, "invalid user id")
+		exception.Attributes().InsertString(conventions.AttributeExceptionStacktrace, "Traceback (most recent call last)")
+		exception.Attributes().InsertBool("exception.escaped", true)
+
+		errorEvents := errorsFromSpanEvents(events, span)
+		require.Len(t, errorEvents, 1)
+		actual := errorEvents[0]
+
+		expected := sentry.NewEvent()
+		expected.Level = sentry.LevelError
+		expected.Exception = []sentry.Exception{{Type: "ValueError", Value: "invalid user id"}}
+		expected.Extra["stacktrace"] = "Traceback (most recent call last)"
+		expected.Contexts["trace"] = sentry.TraceContext{
+			TraceID:     span.TraceID,
+			SpanID:      span.SpanID,
+			Op:          "http.server",
+			Description: "GET /api/users",
+			Status:      sentry.SpanStatusInternalError,
+		}
+		expected.Sdk.Name = otelSentryExporterName
+		expected.Sdk.Version = otelSentryExporterVersion
+		expected.Release = "1.2.3"
+		expected.Environment = "production"
+		expected.Tags = map[string]string{
+			"key":                                      "value",
+			"exception.escaped":                        "true",
+			conventions.AttributeServiceVersion:        "1.2.3",
+			conventions.AttributeDeploymentEnvironment: "production",
+		}
+		expected.Timestamp = unixNanoToTime(200)
+		expected.Transaction = "GET /api/users"
+		expected.Breadcrumbs = []*sentry.Breadcrumb{
+			{
+				Type:      "default",
+				Category:  "span.event",
+				Message:   "cache miss",
+				Data:      map[string]interface{}{"cache.key": "users"},
+				Level:     sentry.LevelInfo,
+				Timestamp: unixNanoToTime(100),
+			},
+		}
+
+		if diff := cmp.Diff(expected, actual); diff != "" {
+			t.Errorf("Event mismatch (-expected +actual):\n%s", diff)
+		}
+	})
+}
+
 type mockTransport struct {
-	called       bool
-	transactions []*sentry.Event
+	called bool
+	events []*sentry.Event
 }
 
-func (t *mockTransport) SendTransactions(transactions []*sentry.Event) {
-	t.transactions = transactions
+func (t *mockTransport) SendEvents(events []*sentry.Event) {
+	t.events = events
 	t.called = true
 }
 
@@ -544,6 +640,17 @@ func TestPushTraceData(t *testing.T) {
 			}(),
 			called: false,
 		},
+		{
+			testName: "with exception in child span",
+			td: func() pdata.Traces {
+				traces := pdata.NewTraces()
+				span := traces.ResourceSpans().AppendEmpty().InstrumentationLibrarySpans().AppendEmpty().Spans().AppendEmpty()
+				span.SetParentSpanID(pdata.NewSpanID([8]byte{1}))
+				span.Events().AppendEmpty().Attributes().InsertString(conventions.AttributeExceptionType, "ValueError")
+				return traces
+			}(),
+			called: true,
+		},
 		{
 			testName: "with full trace",
 			td: func() pdata.Traces {
@@ -0,0 +1,124 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sftpexporter
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"time"
+
+	"go.opentelemetry.io/collector/config"
+)
+
+const (
+	// CompressionGzip compresses the files with gzip.
+	CompressionGzip = "gzip"
+	// CompressionNone leaves the files uncompressed.
+	CompressionNone = "none"
+)
+
+// Config defines configuration for the SFTP exporter.
+type Config struct {
+	config.ExporterSettings `mapstructure:",squash"`
+
+	// Directory is the local directory the files are written to before being
+	// uploaded. Files are only removed from it once uploaded.
+	Directory string `mapstructure:"directory"`
+
+	// Compression of the files, either "gzip" or "none". Default is "gzip".
+	Compression string `mapstructure:"compression"`
+
+	// RotationInterval is the longest duration a file is written to before
+	// being closed and queued for upload. Default is 1m.
+	RotationInterval time.Duration `mapstructure:"rotation_interval"`
+
+	// MaxFileSize is the size in bytes above which a file is closed and queued
+	// for upload. Default is 64MiB.
+	MaxFileSize int64 `mapstructure:"max_file_size"`
+
+	// UploadInterval is the interval at which the closed files are uploaded.
+	// Default is 1m.
+	UploadInterval time.Duration `mapstructure:"upload_interval"`
+
+	// SFTP defines the target the files are uploaded to.
+	SFTP SFTPSettings `mapstructure:"sftp"`
+}
+
+// SFTPSettings defines the SFTP target of the files.
+type SFTPSettings struct {
+	// Endpoint is the "host:port" of the SFTP server.
+	Endpoint string `mapstructure:"endpoint"`
+
+	// Username to log in with.
+	Username string `mapstructure:"username"`
+
+	// Password to log in with. Either Password or PrivateKeyFile is required.
+	Password string `mapstructure:"password"`
+
+	// PrivateKeyFile is the path of the PEM encoded private key to log in with.
+	PrivateKeyFile string `mapstructure:"private_key_file"`
+
+	// PrivateKeyPassphrase decrypts PrivateKeyFile if it's encrypted.
+	PrivateKeyPassphrase string `mapstructure:"private_key_passphrase"`
+
+	// KnownHostsFile is the path of the known_hosts file the host key of the
+	// server is checked against. Required unless InsecureIgnoreHostKey is set.
+	KnownHostsFile string `mapstructure:"known_hosts_file"`
+
+	// InsecureIgnoreHostKey disables the check of the host key of the server.
+	InsecureIgnoreHostKey bool `mapstructure:"insecure_ignore_host_key"`
+
+	// RemoteDirectory is the absolute path of the directory the files are
+	// uploaded to.
+	RemoteDirectory string `mapstructure:"remote_directory"`
+
+	// Timeout of the connection to the server. Default is 30s.
+	Timeout time.Duration `mapstructure:"timeout"`
+
+	// VerifyChecksum reads the uploaded files back to check their SHA-256
+	// checksum before renaming them to their final name. Default is true.
+	VerifyChecksum bool `mapstructure:"verify_checksum"`
+}
+
+func (cfg *Config) validate() error {
+	if cfg.Directory == "" {
+		return errors.New(`"directory" is required`)
+	}
+	if cfg.Compression != CompressionGzip && cfg.Compression != CompressionNone {
+		return fmt.Errorf(`"compression" must be either %q or %q`, CompressionGzip, CompressionNone)
+	}
+	if cfg.RotationInterval <= 0 || cfg.UploadInterval <= 0 {
+		return errors.New(`"rotation_interval" and "upload_interval" must be positive`)
+	}
+	if cfg.MaxFileSize <= 0 {
+		return errors.New(`"max_file_size" must be positive`)
+	}
+
+	s := cfg.SFTP
+	if s.Endpoint == "" || s.Username == "" {
+		return errors.New(`"sftp.endpoint" and "sftp.username" are required`)
+	}
+	if s.Password == "" && s.PrivateKeyFile == "" {
+		return errors.New(`either "sftp.password" or "sftp.private_key_file" is required`)
+	}
+	if s.KnownHostsFile == "" && !s.InsecureIgnoreHostKey {
+		return errors.New(`"sftp.known_hosts_file" is required unless "sftp.insecure_ignore_host_key" is set`)
+	}
+	if !path.IsAbs(s.RemoteDirectory) {
+		return errors.New(`"sftp.remote_directory" must be an absolute path`)
+	}
+	return nil
+}
@@ -0,0 +1,148 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sftpexporter
+
+import (
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configtest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	assert.Nil(t, err)
+
+	factory := NewFactory()
+	factories.Exporters[typeStr] = factory
+	cfg, err := configtest.LoadConfigAndValidate(path.Join(".", "testdata", "config.yaml"), factories)
+
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	assert.Equal(t, 2, len(cfg.Exporters))
+
+	defaultCfg := factory.CreateDefaultConfig().(*Config)
+	defaultCfg.Directory = "/var/lib/otelcol/sftp"
+	defaultCfg.SFTP.Endpoint = "sftp.example.com:22"
+	defaultCfg.SFTP.Username = "otel"
+	defaultCfg.SFTP.PrivateKeyFile = "/etc/otelcol/id_ed25519"
+	defaultCfg.SFTP.KnownHostsFile = "/etc/otelcol/known_hosts"
+	defaultCfg.SFTP.RemoteDirectory = "/upload/telemetry"
+	assert.Equal(t, defaultCfg, cfg.Exporters[config.NewID(typeStr)])
+
+	assert.Equal(t, &Config{
+		ExporterSettings: config.NewExporterSettings(config.NewIDWithName(typeStr, "custom")),
+		Directory:        "/var/lib/otelcol/sftp",
+		Compression:      CompressionNone,
+		RotationInterval: 5 * time.Minute,
+		MaxFileSize:      1048576,
+		UploadInterval:   time.Hour,
+		SFTP: SFTPSettings{
+			Endpoint:              "10.0.0.1:2222",
+			Username:              "otel",
+			Password:              "secret",
+			InsecureIgnoreHostKey: true,
+			RemoteDirectory:       "/upload",
+			Timeout:               10 * time.Second,
+			VerifyChecksum:        false,
+		},
+	}, cfg.Exporters[config.NewIDWithName(typeStr, "custom")])
+}
+
+func TestValidateConfig(t *testing.T) {
+	valid := func() *Config {
+		cfg := createDefaultConfig().(*Config)
+		cfg.Directory = "/tmp/sftp"
+		cfg.SFTP.Endpoint = "localhost:22"
+		cfg.SFTP.Username = "otel"
+		cfg.SFTP.Password = "secret"
+		cfg.SFTP.KnownHostsFile = "known_hosts"
+		cfg.SFTP.RemoteDirectory = "/upload"
+		return cfg
+	}
+
+	tests := []struct {
+		name    string
+		modify  func(cfg *Config)
+		wantErr string
+	}{
+		{name: "valid", modify: func(cfg *Config) {}},
+		{
+			name:    "missing directory",
+			modify:  func(cfg *Config) { cfg.Directory = "" },
+			wantErr: `"directory" is required`,
+		},
+		{
+			name:    "invalid compression",
+			modify:  func(cfg *Config) { cfg.Compression = "zstd" },
+			wantErr: `"compression" must be either "gzip" or "none"`,
+		},
+		{
+			name:    "invalid rotation interval",
+			modify:  func(cfg *Config) { cfg.RotationInterval = 0 },
+			wantErr: `"rotation_interval" and "upload_interval" must be positive`,
+		},
+		{
+			name:    "invalid max file size",
+			modify:  func(cfg *Config) { cfg.MaxFileSize = 0 },
+			wantErr: `"max_file_size" must be positive`,
+		},
+		{
+			name:    "missing endpoint",
+			modify:  func(cfg *Config) { cfg.SFTP.Endpoint = "" },
+			wantErr: `"sftp.endpoint" and "sftp.username" are required`,
+		},
+		{
+			name:    "missing credentials",
+			modify:  func(cfg *Config) { cfg.SFTP.Password = "" },
+			wantErr: `either "sftp.password" or "sftp.private_key_file" is required`,
+		},
+		{
+			name:    "missing known hosts",
+			modify:  func(cfg *Config) { cfg.SFTP.KnownHostsFile = "" },
+			wantErr: `"sftp.known_hosts_file" is required unless "sftp.insecure_ignore_host_key" is set`,
+		},
+		{
+			name: "insecure host key",
+			modify: func(cfg *Config) {
+				cfg.SFTP.KnownHostsFile = ""
+				cfg.SFTP.InsecureIgnoreHostKey = true
+			},
+		},
+		{
+			name:    "relative remote directory",
+			modify:  func(cfg *Config) { cfg.SFTP.RemoteDirectory = "upload" },
+			wantErr: `"sftp.remote_directory" must be an absolute path`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := valid()
+			tt.modify(cfg)
+			err := cfg.validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+		})
+	}
+}
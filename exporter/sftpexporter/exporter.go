@@ -0,0 +1,306 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sftpexporter
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.uber.org/zap"
+)
+
+const (
+	signalTraces  = "traces"
+	signalMetrics = "metrics"
+	signalLogs    = "logs"
+
+	// Files are written with this suffix, removed once they're closed.
+	writingSuffix = ".tmp"
+)
+
+// sftpExporter writes the batches of a signal to local files, and uploads
+// them to the SFTP server once closed.
+type sftpExporter struct {
+	config   *Config
+	logger   *zap.Logger
+	signal   string
+	uploader *uploader
+	now      func() time.Time
+
+	mu       sync.Mutex
+	file     *os.File
+	writer   io.Writer
+	gzip     *gzip.Writer
+	written  int64
+	openedAt time.Time
+
+	// uploadMu serializes the uploads of the background loop and of shutdown.
+	uploadMu sync.Mutex
+	done     chan struct{}
+	wg       sync.WaitGroup
+}
+
+func newExporter(cfg *Config, logger *zap.Logger, signal string) (*sftpExporter, error) {
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+	u, err := newUploader(cfg.SFTP, logger)
+	if err != nil {
+		return nil, err
+	}
+	return &sftpExporter{
+		config:   cfg,
+		logger:   logger,
+		signal:   signal,
+		uploader: u,
+		now:      time.Now,
+		done:     make(chan struct{}),
+	}, nil
+}
+
+func (e *sftpExporter) start(context.Context, component.Host) error {
+	if err := os.MkdirAll(e.config.Directory, 0700); err != nil {
+		return err
+	}
+	// Close the files left open by a previous run, so that they get uploaded.
+	leftovers, err := filepath.Glob(filepath.Join(e.config.Directory, e.signal+"-*"+writingSuffix))
+	if err != nil {
+		return err
+	}
+	for _, f := range leftovers {
+		if err := os.Rename(f, strings.TrimSuffix(f, writingSuffix)); err != nil {
+			return err
+		}
+	}
+
+	e.wg.Add(1)
+	go e.loop()
+	return nil
+}
+
+func (e *sftpExporter) shutdown(ctx context.Context) error {
+	close(e.done)
+	e.wg.Wait()
+
+	e.mu.Lock()
+	err := e.closeFile()
+	e.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	// Files that fail to upload stay in the directory for the next run.
+	e.upload(ctx)
+	return nil
+}
+
+func (e *sftpExporter) loop() {
+	defer e.wg.Done()
+	rotation := time.NewTicker(e.config.RotationInterval)
+	defer rotation.Stop()
+	upload := time.NewTicker(e.config.UploadInterval)
+	defer upload.Stop()
+
+	for {
+		select {
+		case <-e.done:
+			return
+		case <-rotation.C:
+			e.mu.Lock()
+			if e.file != nil && e.now().Sub(e.openedAt) >= e.config.RotationInterval {
+				if err := e.closeFile(); err != nil {
+					e.logger.Error("Failed to close file", zap.Error(err))
+				}
+			}
+			e.mu.Unlock()
+		case <-upload.C:
+			ctx, cancel := context.WithCancel(context.Background())
+			go func() {
+				select {
+				case <-e.done:
+					cancel()
+				case <-ctx.Done():
+				}
+			}()
+			e.upload(ctx)
+			cancel()
+		}
+	}
+}
+
+func (e *sftpExporter) pushTraces(_ context.Context, td pdata.Traces) error {
+	b, err := td.ToOtlpProtoBytes()
+	if err != nil {
+		return err
+	}
+	return e.write(b)
+}
+
+func (e *sftpExporter) pushMetrics(_ context.Context, md pdata.Metrics) error {
+	b, err := md.ToOtlpProtoBytes()
+	if err != nil {
+		return err
+	}
+	return e.write(b)
+}
+
+func (e *sftpExporter) pushLogs(_ context.Context, ld pdata.Logs) error {
+	b, err := ld.ToOtlpProtoBytes()
+	if err != nil {
+		return err
+	}
+	return e.write(b)
+}
+
+// write appends a batch to the current file, prefixed by its size as a 4
+// bytes big endian integer.
+func (e *sftpExporter) write(b []byte) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.file == nil {
+		if err := e.openFile(); err != nil {
+			return err
+		}
+	}
+
+	var size [4]byte
+	binary.BigEndian.PutUint32(size[:], uint32(len(b)))
+	if _, err := e.writer.Write(size[:]); err != nil {
+		return err
+	}
+	if _, err := e.writer.Write(b); err != nil {
+		return err
+	}
+	// Flush every batch, so that files left open by a crash can be read up to
+	// the last batch.
+	if e.gzip != nil {
+		if err := e.gzip.Flush(); err != nil {
+			return err
+		}
+	}
+	e.written += int64(len(size) + len(b))
+
+	if e.written >= e.config.MaxFileSize {
+		return e.closeFile()
+	}
+	return nil
+}
+
+func (e *sftpExporter) openFile() error {
+	now := e.now().UTC()
+	name := fmt.Sprintf("%s-%s.otlp.pb", e.signal, now.Format("20060102T150405.000000000Z"))
+	if e.config.Compression == CompressionGzip {
+		name += ".gz"
+	}
+	f, err := os.OpenFile(filepath.Join(e.config.Directory, name+writingSuffix), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return err
+	}
+
+	e.file = f
+	e.writer = f
+	e.gzip = nil
+	if e.config.Compression == CompressionGzip {
+		e.gzip = gzip.NewWriter(f)
+		e.writer = e.gzip
+	}
+	e.written = 0
+	e.openedAt = now
+	return nil
+}
+
+// closeFile closes the current file, if any, and renames it so that it gets
+// uploaded.
+func (e *sftpExporter) closeFile() error {
+	if e.file == nil {
+		return nil
+	}
+	f := e.file
+	e.file = nil
+
+	if e.gzip != nil {
+		if err := e.gzip.Close(); err != nil {
+			_ = f.Close()
+			return err
+		}
+	}
+	if err := f.Sync(); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), strings.TrimSuffix(f.Name(), writingSuffix))
+}
+
+// upload uploads the closed files of the signal, oldest first, and removes
+// them once uploaded.
+func (e *sftpExporter) upload(ctx context.Context) {
+	e.uploadMu.Lock()
+	defer e.uploadMu.Unlock()
+
+	files, err := e.closedFiles()
+	if err != nil {
+		e.logger.Error("Failed to list files to upload", zap.Error(err))
+		return
+	}
+	if len(files) == 0 {
+		return
+	}
+
+	uploaded, err := e.uploader.upload(ctx, files)
+	for _, f := range uploaded {
+		if errRemove := os.Remove(f); errRemove != nil {
+			e.logger.Error("Failed to remove uploaded file", zap.String("file", f), zap.Error(errRemove))
+		}
+	}
+	if err != nil {
+		e.logger.Warn("Failed to upload files, will retry",
+			zap.Int("uploaded", len(uploaded)),
+			zap.Int("pending", len(files)-len(uploaded)),
+			zap.Error(err))
+	}
+}
+
+func (e *sftpExporter) closedFiles() ([]string, error) {
+	entries, err := ioutil.ReadDir(e.config.Directory)
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, e.signal+"-") || strings.HasSuffix(name, writingSuffix) {
+			continue
+		}
+		files = append(files, filepath.Join(e.config.Directory, name))
+	}
+	// The names start with the time the files were opened at.
+	sort.Strings(files)
+	return files, nil
+}
@@ -0,0 +1,161 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sftpexporter
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/binary"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.uber.org/zap"
+)
+
+func testTraces(name string) pdata.Traces {
+	td := pdata.NewTraces()
+	td.ResourceSpans().AppendEmpty().InstrumentationLibrarySpans().AppendEmpty().Spans().AppendEmpty().SetName(name)
+	return td
+}
+
+func newTestExporter(t *testing.T, endpoint, remoteDir string) *sftpExporter {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Directory = t.TempDir()
+	// Rotation and uploads only happen on size and shutdown in tests.
+	cfg.RotationInterval = time.Hour
+	cfg.UploadInterval = time.Hour
+	cfg.SFTP = testSFTPSettings(endpoint, remoteDir)
+	exp, err := newExporter(cfg, zap.NewNop(), signalTraces)
+	require.NoError(t, err)
+	return exp
+}
+
+// readBatches reads the size prefixed batches of a file.
+func readBatches(t *testing.T, file string, gzipped bool) [][]byte {
+	f, err := os.Open(file)
+	require.NoError(t, err)
+	defer f.Close()
+	var r io.Reader = f
+	if gzipped {
+		gr, err := gzip.NewReader(f)
+		require.NoError(t, err)
+		r = gr
+	}
+	content, err := ioutil.ReadAll(r)
+	require.NoError(t, err)
+
+	var batches [][]byte
+	for len(content) > 0 {
+		require.GreaterOrEqual(t, len(content), 4)
+		size := binary.BigEndian.Uint32(content[:4])
+		batches = append(batches, content[4:4+size])
+		content = content[4+size:]
+	}
+	return batches
+}
+
+func TestExporterWritesAndUploadsOnShutdown(t *testing.T) {
+	endpoint, _ := startSFTPServer(t)
+	remoteDir := t.TempDir()
+	exp := newTestExporter(t, endpoint, remoteDir)
+	require.NoError(t, exp.start(context.Background(), componenttest.NewNopHost()))
+
+	require.NoError(t, exp.pushTraces(context.Background(), testTraces("span-1")))
+	require.NoError(t, exp.pushTraces(context.Background(), testTraces("span-2")))
+	require.NoError(t, exp.shutdown(context.Background()))
+
+	uploaded, err := filepath.Glob(filepath.Join(remoteDir, "traces-*.otlp.pb.gz"))
+	require.NoError(t, err)
+	require.Len(t, uploaded, 1)
+	_, err = os.Stat(uploaded[0] + checksumSuffix)
+	assert.NoError(t, err)
+
+	batches := readBatches(t, uploaded[0], true)
+	require.Len(t, batches, 2)
+	td, err := pdata.TracesFromOtlpProtoBytes(batches[1])
+	require.NoError(t, err)
+	assert.Equal(t, "span-2", td.ResourceSpans().At(0).InstrumentationLibrarySpans().At(0).Spans().At(0).Name())
+
+	// Uploaded files are removed.
+	local, err := ioutil.ReadDir(exp.config.Directory)
+	require.NoError(t, err)
+	assert.Empty(t, local)
+}
+
+func TestExporterRotatesOnSize(t *testing.T) {
+	exp := newTestExporter(t, "localhost:0", "/upload")
+	exp.config.Compression = CompressionNone
+	exp.config.MaxFileSize = 1
+	var now time.Time
+	exp.now = func() time.Time {
+		now = now.Add(time.Second)
+		return now
+	}
+
+	require.NoError(t, exp.write([]byte("batch-1")))
+	require.NoError(t, exp.write([]byte("batch-2")))
+	files, err := exp.closedFiles()
+	require.NoError(t, err)
+	require.Len(t, files, 2)
+	assert.Equal(t, "traces-00010101T000001.000000000Z.otlp.pb", filepath.Base(files[0]))
+	assert.Equal(t, [][]byte{[]byte("batch-1")}, readBatches(t, files[0], false))
+	assert.Equal(t, [][]byte{[]byte("batch-2")}, readBatches(t, files[1], false))
+}
+
+func TestExporterKeepsFilesOnUploadFailure(t *testing.T) {
+	exp := newTestExporter(t, "127.0.0.1:1", "/upload")
+	require.NoError(t, exp.start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, exp.pushTraces(context.Background(), testTraces("span")))
+	require.NoError(t, exp.shutdown(context.Background()))
+
+	files, err := exp.closedFiles()
+	require.NoError(t, err)
+	assert.Len(t, files, 1)
+}
+
+func TestExporterStartClosesLeftoverFiles(t *testing.T) {
+	endpoint, _ := startSFTPServer(t)
+	remoteDir := t.TempDir()
+	exp := newTestExporter(t, endpoint, remoteDir)
+
+	// A file left open by a crash, readable up to the last batch.
+	var buf bytes.Buffer
+	gw := gzip.NewWriter(&buf)
+	_, err := gw.Write([]byte{0, 0, 0, 5, 'b', 'a', 't', 'c', 'h'})
+	require.NoError(t, err)
+	require.NoError(t, gw.Flush())
+	leftover := filepath.Join(exp.config.Directory, "traces-20210601T000000.000000000Z.otlp.pb.gz"+writingSuffix)
+	require.NoError(t, ioutil.WriteFile(leftover, buf.Bytes(), 0600))
+	// Files of other signals are left alone.
+	other := filepath.Join(exp.config.Directory, "logs-20210601T000000.000000000Z.otlp.pb.gz"+writingSuffix)
+	require.NoError(t, ioutil.WriteFile(other, buf.Bytes(), 0600))
+
+	require.NoError(t, exp.start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, exp.shutdown(context.Background()))
+
+	_, err = os.Stat(filepath.Join(remoteDir, "traces-20210601T000000.000000000Z.otlp.pb.gz"))
+	assert.NoError(t, err)
+	_, err = os.Stat(other)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,66 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sftpexporter
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configcheck"
+	"go.uber.org/zap"
+)
+
+func TestCreateDefaultConfig(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	assert.NoError(t, configcheck.ValidateConfig(cfg))
+
+	ocfg := cfg.(*Config)
+	assert.Equal(t, CompressionGzip, ocfg.Compression)
+	assert.Equal(t, defaultRotationInterval, ocfg.RotationInterval)
+	assert.Equal(t, int64(defaultMaxFileSize), ocfg.MaxFileSize)
+	assert.Equal(t, defaultUploadInterval, ocfg.UploadInterval)
+	assert.Equal(t, defaultTimeout, ocfg.SFTP.Timeout)
+	assert.True(t, ocfg.SFTP.VerifyChecksum)
+}
+
+func TestCreateExporters(t *testing.T) {
+	factory := NewFactory()
+	params := component.ExporterCreateSettings{Logger: zap.NewNop()}
+
+	cfg := factory.CreateDefaultConfig().(*Config)
+	_, err := factory.CreateTracesExporter(context.Background(), params, cfg)
+	assert.EqualError(t, err, `"directory" is required`)
+
+	cfg.Directory = t.TempDir()
+	cfg.SFTP.Endpoint = "localhost:22"
+	cfg.SFTP.Username = "otel"
+	cfg.SFTP.Password = "secret"
+	cfg.SFTP.InsecureIgnoreHostKey = true
+	cfg.SFTP.RemoteDirectory = "/upload"
+
+	te, err := factory.CreateTracesExporter(context.Background(), params, cfg)
+	require.NoError(t, err)
+	assert.NotNil(t, te)
+	me, err := factory.CreateMetricsExporter(context.Background(), params, cfg)
+	require.NoError(t, err)
+	assert.NotNil(t, me)
+	le, err := factory.CreateLogsExporter(context.Background(), params, cfg)
+	require.NoError(t, err)
+	assert.NotNil(t, le)
+}
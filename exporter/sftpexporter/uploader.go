@@ -0,0 +1,277 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sftpexporter
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/pkg/sftp"
+	"go.uber.org/zap"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+const (
+	// Files are uploaded with this suffix, removed once they're verified.
+	partialSuffix = ".part"
+	// The SHA-256 checksum of a file is uploaded alongside it, in the format
+	// of sha256sum.
+	checksumSuffix = ".sha256"
+)
+
+type uploader struct {
+	settings     SFTPSettings
+	logger       *zap.Logger
+	clientConfig *ssh.ClientConfig
+}
+
+func newUploader(settings SFTPSettings, logger *zap.Logger) (*uploader, error) {
+	var auth []ssh.AuthMethod
+	if settings.PrivateKeyFile != "" {
+		key, err := ioutil.ReadFile(settings.PrivateKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read private key: %w", err)
+		}
+		var signer ssh.Signer
+		if settings.PrivateKeyPassphrase != "" {
+			signer, err = ssh.ParsePrivateKeyWithPassphrase(key, []byte(settings.PrivateKeyPassphrase))
+		} else {
+			signer, err = ssh.ParsePrivateKey(key)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse private key: %w", err)
+		}
+		auth = append(auth, ssh.PublicKeys(signer))
+	}
+	if settings.Password != "" {
+		auth = append(auth, ssh.Password(settings.Password))
+	}
+
+	hostKeyCallback := ssh.InsecureIgnoreHostKey() // #nosec
+	if !settings.InsecureIgnoreHostKey {
+		var err error
+		if hostKeyCallback, err = knownhosts.New(settings.KnownHostsFile); err != nil {
+			return nil, fmt.Errorf("failed to read known hosts: %w", err)
+		}
+	}
+
+	return &uploader{
+		settings: settings,
+		logger:   logger,
+		clientConfig: &ssh.ClientConfig{
+			User:            settings.Username,
+			Auth:            auth,
+			HostKeyCallback: hostKeyCallback,
+			Timeout:         settings.Timeout,
+		},
+	}, nil
+}
+
+// upload uploads the files in order, stopping at the first failure. It
+// returns the files uploaded.
+func (u *uploader) upload(ctx context.Context, files []string) ([]string, error) {
+	conn, err := u.dial(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	client, err := sftp.NewClient(conn)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+
+	// Closing the connection interrupts the upload in progress.
+	stop := make(chan struct{})
+	defer close(stop)
+	go func() {
+		select {
+		case <-ctx.Done():
+			_ = conn.Close()
+		case <-stop:
+		}
+	}()
+
+	var uploaded []string
+	for _, f := range files {
+		if ctx.Err() != nil {
+			return uploaded, ctx.Err()
+		}
+		resumedAt, err := u.uploadFile(client, f)
+		if err != nil {
+			return uploaded, fmt.Errorf("failed to upload %s: %w", filepath.Base(f), err)
+		}
+		if resumedAt > 0 {
+			u.logger.Debug("Resumed upload", zap.String("file", f), zap.Int64("offset", resumedAt))
+		}
+		uploaded = append(uploaded, f)
+	}
+	return uploaded, nil
+}
+
+func (u *uploader) dial(ctx context.Context) (*ssh.Client, error) {
+	d := net.Dialer{Timeout: u.settings.Timeout}
+	netConn, err := d.DialContext(ctx, "tcp", u.settings.Endpoint)
+	if err != nil {
+		return nil, err
+	}
+	c, chans, reqs, err := ssh.NewClientConn(netConn, u.settings.Endpoint, u.clientConfig)
+	if err != nil {
+		_ = netConn.Close()
+		return nil, err
+	}
+	return ssh.NewClient(c, chans, reqs), nil
+}
+
+// uploadFile uploads a file under a partial name, resuming a previous upload
+// of it if any, checks it, and renames it to its final name. It returns the
+// offset the upload was resumed at.
+func (u *uploader) uploadFile(client *sftp.Client, localPath string) (int64, error) {
+	local, err := os.Open(localPath)
+	if err != nil {
+		return 0, err
+	}
+	defer local.Close()
+	st, err := local.Stat()
+	if err != nil {
+		return 0, err
+	}
+	size := st.Size()
+
+	name := filepath.Base(localPath)
+	remotePath := path.Join(u.settings.RemoteDirectory, name)
+	partialPath := remotePath + partialSuffix
+
+	var offset int64
+	if remoteSt, errStat := client.Stat(partialPath); errStat == nil && remoteSt.Size() <= size {
+		offset = remoteSt.Size()
+	}
+
+	flags := os.O_WRONLY | os.O_CREATE
+	if offset == 0 {
+		flags |= os.O_TRUNC
+	}
+	remote, err := client.OpenFile(partialPath, flags)
+	if err != nil {
+		return 0, err
+	}
+	if _, err = remote.Seek(offset, io.SeekStart); err != nil {
+		_ = remote.Close()
+		return 0, err
+	}
+	if _, err = local.Seek(offset, io.SeekStart); err != nil {
+		_ = remote.Close()
+		return 0, err
+	}
+	if _, err = io.Copy(remote, local); err != nil {
+		_ = remote.Close()
+		return 0, err
+	}
+	if err = remote.Close(); err != nil {
+		return 0, err
+	}
+
+	remoteSt, err := client.Stat(partialPath)
+	if err != nil {
+		return 0, err
+	}
+	if remoteSt.Size() != size {
+		_ = client.Remove(partialPath)
+		return 0, fmt.Errorf("uploaded size %d doesn't match the size %d of the file", remoteSt.Size(), size)
+	}
+
+	checksum, err := fileChecksum(local)
+	if err != nil {
+		return 0, err
+	}
+	if u.settings.VerifyChecksum {
+		remoteChecksum, errChecksum := remoteFileChecksum(client, partialPath)
+		if errChecksum != nil {
+			return 0, errChecksum
+		}
+		if remoteChecksum != checksum {
+			// Start over on the next attempt.
+			_ = client.Remove(partialPath)
+			return 0, fmt.Errorf("uploaded checksum %s doesn't match the checksum %s of the file", remoteChecksum, checksum)
+		}
+	}
+
+	// The checksum is uploaded first, so that it's there once the file is.
+	if err = writeRemoteFile(client, remotePath+checksumSuffix, []byte(checksum+"  "+name+"\n")); err != nil {
+		return 0, err
+	}
+	if err = rename(client, partialPath, remotePath); err != nil {
+		return 0, err
+	}
+	return offset, nil
+}
+
+func fileChecksum(f *os.File) (string, error) {
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func remoteFileChecksum(client *sftp.Client, p string) (string, error) {
+	f, err := client.Open(p)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err = io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func writeRemoteFile(client *sftp.Client, p string, content []byte) error {
+	f, err := client.OpenFile(p, os.O_WRONLY|os.O_CREATE|os.O_TRUNC)
+	if err != nil {
+		return err
+	}
+	if _, err = io.Copy(f, bytes.NewReader(content)); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// rename renames the file, replacing the target if it exists, e.g. when an
+// upload was interrupted after the rename but before the local file was removed.
+func rename(client *sftp.Client, oldPath, newPath string) error {
+	if err := client.PosixRename(oldPath, newPath); err == nil {
+		return nil
+	}
+	// The server doesn't support the posix-rename extension.
+	_ = client.Remove(newPath)
+	return client.Rename(oldPath, newPath)
+}
@@ -0,0 +1,227 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sftpexporter
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pkg/sftp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+const (
+	testUser     = "otel"
+	testPassword = "secret"
+)
+
+// startSFTPServer starts an SFTP server accepting testUser/testPassword, and
+// returns its address and host key.
+func startSFTPServer(t *testing.T) (string, ssh.PublicKey) {
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	signer, err := ssh.NewSignerFromKey(priv)
+	require.NoError(t, err)
+
+	serverConfig := &ssh.ServerConfig{
+		PasswordCallback: func(c ssh.ConnMetadata, pass []byte) (*ssh.Permissions, error) {
+			if c.User() == testUser && string(pass) == testPassword {
+				return nil, nil
+			}
+			return nil, assert.AnError
+		},
+	}
+	serverConfig.AddHostKey(signer)
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = l.Close() })
+
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go serveSFTP(conn, serverConfig)
+		}
+	}()
+	return l.Addr().String(), signer.PublicKey()
+}
+
+func serveSFTP(conn net.Conn, serverConfig *ssh.ServerConfig) {
+	_, chans, reqs, err := ssh.NewServerConn(conn, serverConfig)
+	if err != nil {
+		return
+	}
+	go ssh.DiscardRequests(reqs)
+	for newChannel := range chans {
+		if newChannel.ChannelType() != "session" {
+			_ = newChannel.Reject(ssh.UnknownChannelType, "unknown channel type")
+			continue
+		}
+		channel, requests, err := newChannel.Accept()
+		if err != nil {
+			return
+		}
+		go func() {
+			for req := range requests {
+				// The payload of subsystem requests is the length prefixed name.
+				ok := req.Type == "subsystem" && len(req.Payload) > 4 && string(req.Payload[4:]) == "sftp"
+				_ = req.Reply(ok, nil)
+			}
+		}()
+		go func() {
+			defer channel.Close()
+			server, err := sftp.NewServer(channel)
+			if err != nil {
+				return
+			}
+			_ = server.Serve()
+		}()
+	}
+}
+
+func testSFTPSettings(endpoint, remoteDir string) SFTPSettings {
+	s := createDefaultConfig().(*Config).SFTP
+	s.Endpoint = endpoint
+	s.Username = testUser
+	s.Password = testPassword
+	s.InsecureIgnoreHostKey = true
+	s.RemoteDirectory = remoteDir
+	return s
+}
+
+func writeLocalFile(t *testing.T, content string) string {
+	f := filepath.Join(t.TempDir(), "traces-20210601T000000.000000000Z.otlp.pb.gz")
+	require.NoError(t, ioutil.WriteFile(f, []byte(content), 0600))
+	return f
+}
+
+func TestUpload(t *testing.T) {
+	endpoint, _ := startSFTPServer(t)
+	remoteDir := t.TempDir()
+	u, err := newUploader(testSFTPSettings(endpoint, remoteDir), zap.NewNop())
+	require.NoError(t, err)
+
+	local := writeLocalFile(t, "some telemetry")
+	uploaded, err := u.upload(context.Background(), []string{local})
+	require.NoError(t, err)
+	assert.Equal(t, []string{local}, uploaded)
+
+	name := filepath.Base(local)
+	content, err := ioutil.ReadFile(filepath.Join(remoteDir, name))
+	require.NoError(t, err)
+	assert.Equal(t, "some telemetry", string(content))
+	checksum, err := ioutil.ReadFile(filepath.Join(remoteDir, name+checksumSuffix))
+	require.NoError(t, err)
+	assert.Equal(t, "1515469a61321e4b3d511a3fcddcde6e86c15c74e5b2d9264c94cbe7796a08aa  "+name+"\n", string(checksum))
+	_, err = os.Stat(filepath.Join(remoteDir, name+partialSuffix))
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestUploadResumesPartialFile(t *testing.T) {
+	endpoint, _ := startSFTPServer(t)
+	remoteDir := t.TempDir()
+	u, err := newUploader(testSFTPSettings(endpoint, remoteDir), zap.NewNop())
+	require.NoError(t, err)
+
+	local := writeLocalFile(t, "some telemetry")
+	name := filepath.Base(local)
+	require.NoError(t, ioutil.WriteFile(filepath.Join(remoteDir, name+partialSuffix), []byte("some "), 0600))
+
+	conn, err := u.dial(context.Background())
+	require.NoError(t, err)
+	defer conn.Close()
+	client, err := sftp.NewClient(conn)
+	require.NoError(t, err)
+	defer client.Close()
+
+	offset, err := u.uploadFile(client, local)
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), offset)
+	content, err := ioutil.ReadFile(filepath.Join(remoteDir, name))
+	require.NoError(t, err)
+	assert.Equal(t, "some telemetry", string(content))
+}
+
+func TestUploadCorruptedPartialFile(t *testing.T) {
+	endpoint, _ := startSFTPServer(t)
+	remoteDir := t.TempDir()
+	u, err := newUploader(testSFTPSettings(endpoint, remoteDir), zap.NewNop())
+	require.NoError(t, err)
+
+	local := writeLocalFile(t, "some telemetry")
+	name := filepath.Base(local)
+	require.NoError(t, ioutil.WriteFile(filepath.Join(remoteDir, name+partialSuffix), []byte("SOME "), 0600))
+
+	uploaded, err := u.upload(context.Background(), []string{local})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "doesn't match the checksum")
+	assert.Empty(t, uploaded)
+	_, err = os.Stat(filepath.Join(remoteDir, name))
+	assert.True(t, os.IsNotExist(err))
+
+	// The next attempt starts over.
+	uploaded, err = u.upload(context.Background(), []string{local})
+	require.NoError(t, err)
+	assert.Equal(t, []string{local}, uploaded)
+	content, err := ioutil.ReadFile(filepath.Join(remoteDir, name))
+	require.NoError(t, err)
+	assert.Equal(t, "some telemetry", string(content))
+}
+
+func TestUploadKnownHosts(t *testing.T) {
+	endpoint, hostKey := startSFTPServer(t)
+	otherEndpoint, _ := startSFTPServer(t)
+	knownHosts := filepath.Join(t.TempDir(), "known_hosts")
+	require.NoError(t, ioutil.WriteFile(knownHosts, []byte(knownhosts.Line([]string{endpoint, otherEndpoint}, hostKey)+"\n"), 0600))
+
+	settings := testSFTPSettings(endpoint, t.TempDir())
+	settings.InsecureIgnoreHostKey = false
+	settings.KnownHostsFile = knownHosts
+	u, err := newUploader(settings, zap.NewNop())
+	require.NoError(t, err)
+	_, err = u.upload(context.Background(), []string{writeLocalFile(t, "some telemetry")})
+	assert.NoError(t, err)
+
+	// The other server has a different key.
+	settings.Endpoint = otherEndpoint
+	u, err = newUploader(settings, zap.NewNop())
+	require.NoError(t, err)
+	_, err = u.upload(context.Background(), []string{writeLocalFile(t, "some telemetry")})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "key mismatch")
+}
+
+func TestUploadWrongPassword(t *testing.T) {
+	endpoint, _ := startSFTPServer(t)
+	settings := testSFTPSettings(endpoint, t.TempDir())
+	settings.Password = "wrong"
+	u, err := newUploader(settings, zap.NewNop())
+	require.NoError(t, err)
+	_, err = u.upload(context.Background(), []string{writeLocalFile(t, "some telemetry")})
+	assert.Error(t, err)
+}
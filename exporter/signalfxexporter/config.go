@@ -25,9 +25,11 @@ import (
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/signalfxexporter/correlation"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/signalfxexporter/dimensions"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/signalfxexporter/translation"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/signalfxexporter/translation/dpfilters"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/splunk"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/queueperresourceattr"
 )
 
 const (
@@ -69,6 +71,11 @@ type Config struct {
 	// Whether to log dimension updates being sent to SignalFx.
 	LogDimensionUpdates bool `mapstructure:"log_dimension_updates"`
 
+	// DimensionUpdates controls which metadata is synced as dimension properties
+	// and tags, e.g. the k8s labels reported by the k8s_cluster receiver, and
+	// under which names.
+	DimensionUpdates DimensionUpdatesConfig `mapstructure:"dimension_updates"`
+
 	splunk.AccessTokenPassthroughConfig `mapstructure:",squash"`
 
 	// TranslationRules defines a set of rules how to translate metrics to a SignalFx compatible format
@@ -105,6 +112,129 @@ type Config struct {
 	// NonAlphanumericDimensionChars is a list of allowable characters, in addition to alphanumeric ones,
 	// to be used in a dimension key.
 	NonAlphanumericDimensionChars string `mapstructure:"nonalphanumeric_dimension_chars"`
+
+	// DPMBudget limits the number of datapoints sent to SignalFx per minute.
+	// Disabled by default.
+	DPMBudget *DPMBudgetConfig `mapstructure:"dpm_budget"`
+
+	// SendingQueuePerToken gives each access token passed through its own
+	// sending queue and retries, so that a token being throttled or rejected
+	// doesn't use up the queue capacity of the others.
+	// Requires AccessTokenPassthrough, disabled by default.
+	SendingQueuePerToken queueperresourceattr.Settings `mapstructure:"sending_queue_per_token"`
+
+	// RateLimit limits the datapoints and requests sent to SignalFx per second.
+	// Disabled by default.
+	RateLimit *RateLimitConfig `mapstructure:"rate_limit"`
+
+	// MetricRoutes sends the datapoints they match to other ingest endpoints,
+	// e.g. while migrating some classes of metrics to another organization.
+	// Datapoints go to the first route matching them, the ones matching none
+	// are sent to the default ingest endpoint.
+	MetricRoutes []MetricRoute `mapstructure:"metric_routes"`
+
+	// HistogramQuantiles translates the histograms to these quantiles, e.g. [0.5, 0.9, 0.99],
+	// sent as "<metric>_quantile" gauges with a "quantile" dimension and estimated by linear
+	// interpolation within the buckets, instead of a "<metric>_bucket" datapoint per bucket.
+	// The "<metric>_count" and "<metric>" sum datapoints are still sent. Disabled by default.
+	HistogramQuantiles []float64 `mapstructure:"histogram_quantiles"`
+
+	// Traces configures sending the traces to SignalFx. Traces are only used to
+	// correlate services and environments to metrics by default.
+	Traces TracesConfig `mapstructure:"traces"`
+}
+
+// TracesConfig defines how traces are sent to SignalFx, with the access token
+// and realm of the exporter.
+type TracesConfig struct {
+	// Format is the format traces are sent in: "sapm", "otlp" or "zipkin".
+	// Traces are not sent if empty.
+	Format string `mapstructure:"format"`
+
+	// IngestURL is the destination to where traces will be sent to. It defaults
+	// to the value of "ingest_url" or to the ingest endpoint of Realm. If a path
+	// is not included the exporter will automatically append the path of the
+	// format, "v2/trace" or "v2/trace/otlp".
+	IngestURL string `mapstructure:"ingest_url"`
+}
+
+// DimensionUpdatesConfig defines the properties and tags synced to SignalFx
+// by the dimension updates. Properties and tags are matched by the name they
+// would be synced as before renaming, with the syntax of the metric names of
+// dpfilter.MetricFilters: exact names, globs or /regexes/.
+type DimensionUpdatesConfig struct {
+	// IncludeProperties are the properties and tags synced. All are synced if empty.
+	IncludeProperties []string `mapstructure:"include_properties"`
+
+	// ExcludeProperties are the properties and tags not synced, even if they
+	// match IncludeProperties.
+	ExcludeProperties []string `mapstructure:"exclude_properties"`
+
+	// RenameProperties maps the names of properties and tags to the names
+	// they are synced as.
+	RenameProperties map[string]string `mapstructure:"rename_properties"`
+
+	// MaxPropertyValueLength truncates the property values longer than this
+	// number of characters. Values are not truncated if zero.
+	MaxPropertyValueLength int `mapstructure:"max_property_value_length"`
+}
+
+func (c DimensionUpdatesConfig) isSet() bool {
+	return len(c.IncludeProperties) > 0 || len(c.ExcludeProperties) > 0 ||
+		len(c.RenameProperties) > 0 || c.MaxPropertyValueLength > 0
+}
+
+// MetricRoute defines the datapoints sent to an ingest endpoint other than
+// the default one.
+type MetricRoute struct {
+	// MetricTypes are the SignalFx metric types routed: "gauge", "counter" or
+	// "cumulative_counter". Any type is routed if empty.
+	MetricTypes []string `mapstructure:"metric_types"`
+
+	// Metrics is the list of dpfilters.MetricFilters routed. Any metric of
+	// the routed types is routed if empty.
+	Metrics []dpfilters.MetricFilter `mapstructure:"metrics"`
+
+	// IngestURL is the destination of the routed datapoints.
+	IngestURL string `mapstructure:"ingest_url"`
+
+	// AccessToken is the token the routed datapoints are sent with. The token
+	// of the datapoints is kept if empty.
+	AccessToken string `mapstructure:"access_token"`
+}
+
+// DPMBudgetConfig defines how datapoints are shed once the datapoints-per-minute
+// budget is exceeded.
+type DPMBudgetConfig struct {
+	// Limit is the maximum number of datapoints sent per minute.
+	Limit int `mapstructure:"limit"`
+
+	// Mode is the degradation applied when a batch doesn't fit into the remaining
+	// budget: "drop" sheds the datapoints matching DropPriority first, "sample"
+	// samples the batch down to the remaining budget. In "drop" mode, datapoints
+	// still over budget once every priority pattern was shed are sampled.
+	// Default is "drop".
+	Mode string `mapstructure:"mode"`
+
+	// DropPriority is the list of dpfilter.MetricFilters shed in "drop" mode,
+	// lowest priority first.
+	DropPriority []dpfilters.MetricFilter `mapstructure:"drop_priority"`
+}
+
+// RateLimitConfig defines the client side rate limits applied before sending
+// datapoints, and how they recover once ingest throttled.
+type RateLimitConfig struct {
+	// DatapointsPerSecond is the maximum number of datapoints sent per second.
+	// Zero means no limit.
+	DatapointsPerSecond float64 `mapstructure:"datapoints_per_second"`
+
+	// RequestsPerSecond is the maximum number of requests sent per second.
+	// Zero means no limit.
+	RequestsPerSecond float64 `mapstructure:"requests_per_second"`
+
+	// RecoveryTime is how long it takes to ramp back up to the configured rates
+	// once ingest stopped throttling. Default is 1m.
+	RecoveryTime time.Duration `mapstructure:"recovery_time"`
 }
 
 func (cfg *Config) getOptionsFromConfig() (*exporterOptions, error) {
@@ -131,13 +261,26 @@ func (cfg *Config) getOptionsFromConfig() (*exporterOptions, error) {
 		return nil, fmt.Errorf("invalid \"%s\": %v", translationRulesConfigKey, err)
 	}
 
+	var propertyTransformer *dimensions.PropertyTransformer
+	if cfg.DimensionUpdates.isSet() {
+		propertyTransformer, err = dimensions.NewPropertyTransformer(
+			cfg.DimensionUpdates.IncludeProperties,
+			cfg.DimensionUpdates.ExcludeProperties,
+			cfg.DimensionUpdates.RenameProperties,
+			cfg.DimensionUpdates.MaxPropertyValueLength)
+		if err != nil {
+			return nil, fmt.Errorf(`invalid "dimension_updates": %v`, err)
+		}
+	}
+
 	return &exporterOptions{
-		ingestURL:        ingestURL,
-		apiURL:           apiURL,
-		httpTimeout:      cfg.Timeout,
-		token:            cfg.AccessToken,
-		logDimUpdate:     cfg.LogDimensionUpdates,
-		metricTranslator: metricTranslator,
+		ingestURL:           ingestURL,
+		apiURL:              apiURL,
+		httpTimeout:         cfg.Timeout,
+		token:               cfg.AccessToken,
+		logDimUpdate:        cfg.LogDimensionUpdates,
+		metricTranslator:    metricTranslator,
+		propertyTransformer: propertyTransformer,
 	}, nil
 }
 
@@ -155,6 +298,76 @@ func (cfg *Config) validateConfig() error {
 		return errors.New(`cannot have a negative "timeout"`)
 	}
 
+	if cfg.DimensionUpdates.MaxPropertyValueLength < 0 {
+		return errors.New(`"dimension_updates" cannot have a negative "max_property_value_length"`)
+	}
+	for name, renamed := range cfg.DimensionUpdates.RenameProperties {
+		if renamed == "" {
+			return fmt.Errorf(`"dimension_updates" cannot rename %q to an empty name`, name)
+		}
+	}
+
+	if cfg.DPMBudget != nil {
+		if cfg.DPMBudget.Limit <= 0 {
+			return errors.New(`"dpm_budget" requires a positive "limit"`)
+		}
+		switch cfg.DPMBudget.Mode {
+		case "", dpmBudgetModeDrop, dpmBudgetModeSample:
+		default:
+			return fmt.Errorf(`invalid "dpm_budget" mode %q, must be either %q or %q`,
+				cfg.DPMBudget.Mode, dpmBudgetModeDrop, dpmBudgetModeSample)
+		}
+	}
+
+	if cfg.RateLimit != nil {
+		if cfg.RateLimit.DatapointsPerSecond < 0 || cfg.RateLimit.RequestsPerSecond < 0 {
+			return errors.New(`"rate_limit" cannot have negative rates`)
+		}
+		if cfg.RateLimit.DatapointsPerSecond == 0 && cfg.RateLimit.RequestsPerSecond == 0 {
+			return errors.New(`"rate_limit" requires a positive "datapoints_per_second" or "requests_per_second"`)
+		}
+		if cfg.RateLimit.RecoveryTime < 0 {
+			return errors.New(`"rate_limit" cannot have a negative "recovery_time"`)
+		}
+	}
+
+	for i, route := range cfg.MetricRoutes {
+		if len(route.MetricTypes) == 0 && len(route.Metrics) == 0 {
+			return fmt.Errorf(`"metric_routes" %d requires "metric_types" or "metrics"`, i)
+		}
+		for _, metricType := range route.MetricTypes {
+			if _, ok := routeMetricTypes[metricType]; !ok {
+				return fmt.Errorf(`"metric_routes" %d has an invalid metric type %q`, i, metricType)
+			}
+		}
+		if route.IngestURL == "" {
+			return fmt.Errorf(`"metric_routes" %d requires a non-empty "ingest_url"`, i)
+		}
+		if _, err := url.Parse(route.IngestURL); err != nil {
+			return fmt.Errorf(`"metric_routes" %d has an invalid "ingest_url": %v`, i, err)
+		}
+	}
+
+	for _, q := range cfg.HistogramQuantiles {
+		if q < 0 || q > 1 {
+			return fmt.Errorf(`"histogram_quantiles" must be between 0 and 1, got %v`, q)
+		}
+	}
+
+	if cfg.Traces.Format != "" {
+		if _, ok := traceFormatPaths[cfg.Traces.Format]; !ok {
+			return fmt.Errorf(`invalid "traces" format %q, must be one of %q, %q or %q`,
+				cfg.Traces.Format, traceFormatSAPM, traceFormatOTLP, traceFormatZipkin)
+		}
+		if _, err := url.Parse(cfg.Traces.IngestURL); err != nil {
+			return fmt.Errorf(`invalid "traces" "ingest_url": %v`, err)
+		}
+	}
+
+	if cfg.SendingQueuePerToken.Enabled && !cfg.AccessTokenPassthrough {
+		return errors.New(`"sending_queue_per_token" requires "access_token_passthrough" to be enabled`)
+	}
+
 	return nil
 }
 
@@ -167,6 +380,14 @@ func (cfg *Config) getIngestURL() (*url.URL, error) {
 	return url.Parse(fmt.Sprintf("https://ingest.%s.signalfx.com", cfg.Realm))
 }
 
+func (cfg *Config) getTraceIngestURL() (*url.URL, error) {
+	if cfg.Traces.IngestURL != "" {
+		return url.Parse(cfg.Traces.IngestURL)
+	}
+
+	return cfg.getIngestURL()
+}
+
 func (cfg *Config) getAPIURL() (*url.URL, error) {
 	if cfg.APIURL != "" {
 		// Ignore realm and use the APIURL. Typically used for debugging.
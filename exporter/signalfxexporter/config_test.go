@@ -36,6 +36,7 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/signalfxexporter/translation"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/signalfxexporter/translation/dpfilters"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/splunk"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/queueperresourceattr"
 )
 
 func TestLoadConfig(t *testing.T) {
@@ -149,6 +150,13 @@ func TestLoadConfig(t *testing.T) {
 					"container_name": "/^[A-Z][A-Z]$/",
 				},
 			},
+			{
+				MetricName: `/^k8s\..*/`,
+				Dimensions: map[string]interface{}{
+					"k8s.namespace.name": []interface{}{"*", "!kube-system"},
+				},
+				Values: []string{"==0"},
+			},
 		},
 		IncludeMetrics: []dpfilters.MetricFilter{
 			{
@@ -179,6 +187,52 @@ func TestLoadConfig(t *testing.T) {
 			},
 		},
 		NonAlphanumericDimensionChars: "_-.",
+		DPMBudget: &DPMBudgetConfig{
+			Limit: 100000,
+			Mode:  "drop",
+			DropPriority: []dpfilters.MetricFilter{
+				{
+					MetricNames: []string{"container.*"},
+				},
+				{
+					MetricName: "k8s.pod.phase",
+				},
+			},
+		},
+		SendingQueuePerToken: queueperresourceattr.Settings{
+			Enabled:     false,
+			MaxQueues:   50,
+			IdleTimeout: 30 * time.Minute,
+		},
+		RateLimit: &RateLimitConfig{
+			DatapointsPerSecond: 5000,
+			RequestsPerSecond:   20,
+			RecoveryTime:        30 * time.Second,
+		},
+		MetricRoutes: []MetricRoute{
+			{
+				MetricTypes: []string{"counter", "cumulative_counter"},
+				IngestURL:   "https://ingest.eu0.signalfx.com",
+				AccessToken: "newOrgToken",
+			},
+			{
+				Metrics: []dpfilters.MetricFilter{
+					{MetricNames: []string{"k8s.*"}},
+				},
+				IngestURL: "https://ingest.eu0.signalfx.com",
+			},
+		},
+		HistogramQuantiles: []float64{0.5, 0.9, 0.99},
+		DimensionUpdates: DimensionUpdatesConfig{
+			IncludeProperties:      []string{"app", "kubernetes_*"},
+			ExcludeProperties:      []string{"/token/"},
+			RenameProperties:       map[string]string{"app": "service"},
+			MaxPropertyValueLength: 256,
+		},
+		Traces: TracesConfig{
+			Format:    "otlp",
+			IngestURL: "https://ingest.eu0.signalfx.com",
+		},
 	}
 	assert.Equal(t, &expectedCfg, e1)
 
@@ -194,14 +248,18 @@ func TestConfig_getOptionsFromConfig(t *testing.T) {
 		return translator
 	}
 	type fields struct {
-		AccessToken      string
-		Realm            string
-		IngestURL        string
-		APIURL           string
-		Timeout          time.Duration
-		Headers          map[string]string
-		TranslationRules []translation.Rule
-		SyncHostMetadata bool
+		AccessToken        string
+		Realm              string
+		IngestURL          string
+		APIURL             string
+		Timeout            time.Duration
+		Headers            map[string]string
+		TranslationRules   []translation.Rule
+		SyncHostMetadata   bool
+		DPMBudget          *DPMBudgetConfig
+		HistogramQuantiles []float64
+		Traces             TracesConfig
+		DimensionUpdates   DimensionUpdatesConfig
 	}
 	tests := []struct {
 		name    string
@@ -307,6 +365,79 @@ func TestConfig_getOptionsFromConfig(t *testing.T) {
 			want:    nil,
 			wantErr: true,
 		},
+		{
+			name: "Test non-positive dpm budget limit",
+			fields: fields{
+				Realm:       "us0",
+				AccessToken: "access_token",
+				DPMBudget:   &DPMBudgetConfig{},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "Test invalid dpm budget mode",
+			fields: fields{
+				Realm:       "us0",
+				AccessToken: "access_token",
+				DPMBudget: &DPMBudgetConfig{
+					Limit: 10,
+					Mode:  "throttle",
+				},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "Test invalid histogram quantile",
+			fields: fields{
+				Realm:              "us0",
+				AccessToken:        "access_token",
+				HistogramQuantiles: []float64{0.5, 99},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "Test negative max property value length",
+			fields: fields{
+				Realm:            "us0",
+				AccessToken:      "access_token",
+				DimensionUpdates: DimensionUpdatesConfig{MaxPropertyValueLength: -1},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "Test empty property rename",
+			fields: fields{
+				Realm:            "us0",
+				AccessToken:      "access_token",
+				DimensionUpdates: DimensionUpdatesConfig{RenameProperties: map[string]string{"app": ""}},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "Test invalid include properties",
+			fields: fields{
+				Realm:            "us0",
+				AccessToken:      "access_token",
+				DimensionUpdates: DimensionUpdatesConfig{IncludeProperties: []string{"/[/"}},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "Test invalid traces format",
+			fields: fields{
+				Realm:       "us0",
+				AccessToken: "access_token",
+				Traces:      TracesConfig{Format: "jaeger"},
+			},
+			want:    nil,
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -322,6 +453,10 @@ func TestConfig_getOptionsFromConfig(t *testing.T) {
 				Headers:             tt.fields.Headers,
 				TranslationRules:    tt.fields.TranslationRules,
 				SyncHostMetadata:    tt.fields.SyncHostMetadata,
+				DPMBudget:           tt.fields.DPMBudget,
+				HistogramQuantiles:  tt.fields.HistogramQuantiles,
+				Traces:              tt.fields.Traces,
+				DimensionUpdates:    tt.fields.DimensionUpdates,
 				DeltaTranslationTTL: 3600,
 			}
 
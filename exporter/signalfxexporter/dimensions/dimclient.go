@@ -31,6 +31,7 @@ import (
 
 	"go.uber.org/zap"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/signalfxexporter/observability"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/signalfxexporter/translation"
 )
 
@@ -73,6 +74,8 @@ type DimensionClient struct {
 	logUpdates                   bool
 	logger                       *zap.Logger
 	metricsConverter             translation.MetricsConverter
+	recorder                     *observability.Recorder
+	propertyTransformer          *PropertyTransformer
 }
 
 type queuedDimension struct {
@@ -88,6 +91,11 @@ type DimensionClientOptions struct {
 	SendDelay             int
 	PropertiesMaxBuffered int
 	MetricsConverter      translation.MetricsConverter
+	// Recorder records the latency of the dimension updates, optional.
+	Recorder *observability.Recorder
+	// PropertyTransformer is applied to the properties and tags of the
+	// dimension updates, optional.
+	PropertyTransformer *PropertyTransformer
 }
 
 // NewDimensionClient returns a new client
@@ -110,18 +118,20 @@ func NewDimensionClient(ctx context.Context, options DimensionClientOptions) *Di
 	sender := NewReqSender(ctx, client, 20, map[string]string{"client": "dimension"})
 
 	return &DimensionClient{
-		ctx:              ctx,
-		Token:            options.Token,
-		APIURL:           options.APIURL,
-		sendDelay:        time.Duration(options.SendDelay) * time.Second,
-		delayedSet:       make(map[DimensionKey]*DimensionUpdate),
-		delayedQueue:     make(chan *queuedDimension, options.PropertiesMaxBuffered),
-		requestSender:    sender,
-		client:           client,
-		now:              time.Now,
-		logger:           options.Logger,
-		logUpdates:       options.LogUpdates,
-		metricsConverter: options.MetricsConverter,
+		ctx:                 ctx,
+		Token:               options.Token,
+		APIURL:              options.APIURL,
+		sendDelay:           time.Duration(options.SendDelay) * time.Second,
+		delayedSet:          make(map[DimensionKey]*DimensionUpdate),
+		delayedQueue:        make(chan *queuedDimension, options.PropertiesMaxBuffered),
+		requestSender:       sender,
+		client:              client,
+		now:                 time.Now,
+		logger:              options.Logger,
+		logUpdates:          options.LogUpdates,
+		metricsConverter:    options.MetricsConverter,
+		recorder:            options.Recorder,
+		propertyTransformer: options.PropertyTransformer,
 	}
 }
 
@@ -286,6 +296,9 @@ func (dc *DimensionClient) handleDimensionUpdate(dimUpdate *DimensionUpdate) err
 			}
 		})))
 
+	req = req.WithContext(
+		context.WithValue(req.Context(), RequestDoneCallbackKey, RequestDoneCallback(dc.recorder.RecordDimensionUpdate)))
+
 	dc.requestSender.Send(req)
 
 	return nil
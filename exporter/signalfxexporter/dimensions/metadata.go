@@ -115,6 +115,14 @@ func (dc *DimensionClient) PushMetadata(metadata []*metadata.MetadataUpdate) err
 			return fmt.Errorf("dimensionUpdate %v is missing Name or value, cannot send", dimensionUpdate)
 		}
 
+		if dc.propertyTransformer != nil {
+			dc.propertyTransformer.transform(dimensionUpdate)
+			// Nothing left to update once every property and tag was filtered out.
+			if len(dimensionUpdate.Properties) == 0 && len(dimensionUpdate.Tags) == 0 {
+				continue
+			}
+		}
+
 		if err := dc.acceptDimension(dimensionUpdate); err != nil {
 			errs = append(errs, err)
 		}
@@ -0,0 +1,93 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dimensions
+
+import (
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/signalfxexporter/translation/dpfilters"
+)
+
+// PropertyTransformer selects, renames and truncates the properties and tags
+// of the dimension updates before they are sent.
+type PropertyTransformer struct {
+	// include is nil to keep all the properties.
+	include        *dpfilters.StringFilter
+	exclude        *dpfilters.StringFilter
+	rename         map[string]string
+	maxValueLength int
+}
+
+// NewPropertyTransformer returns a PropertyTransformer keeping the properties
+// and tags matching include, all if empty, and not matching exclude, in the
+// syntax of the dpfilters.StringFilter items. The kept properties and tags are
+// renamed according to rename and their values truncated to maxValueLength
+// characters if it is positive.
+func NewPropertyTransformer(include, exclude []string, rename map[string]string, maxValueLength int) (*PropertyTransformer, error) {
+	pt := &PropertyTransformer{
+		rename:         rename,
+		maxValueLength: maxValueLength,
+	}
+
+	var err error
+	if len(include) > 0 {
+		if pt.include, err = dpfilters.NewStringFilter(include); err != nil {
+			return nil, err
+		}
+	}
+	if pt.exclude, err = dpfilters.NewStringFilter(exclude); err != nil {
+		return nil, err
+	}
+	return pt, nil
+}
+
+func (pt *PropertyTransformer) keep(name string) bool {
+	if pt.include != nil && !pt.include.Matches(name) {
+		return false
+	}
+	return !pt.exclude.Matches(name)
+}
+
+func (pt *PropertyTransformer) name(name string) string {
+	if renamed, ok := pt.rename[name]; ok {
+		return renamed
+	}
+	return name
+}
+
+// transform applies the transformer to the properties and tags of update.
+func (pt *PropertyTransformer) transform(update *DimensionUpdate) {
+	properties := make(map[string]*string, len(update.Properties))
+	for name, value := range update.Properties {
+		if !pt.keep(name) {
+			continue
+		}
+		if value != nil && pt.maxValueLength > 0 {
+			if runes := []rune(*value); len(runes) > pt.maxValueLength {
+				truncated := string(runes[:pt.maxValueLength])
+				value = &truncated
+			}
+		}
+		properties[pt.name(name)] = value
+	}
+
+	tags := make(map[string]bool, len(update.Tags))
+	for name, add := range update.Tags {
+		if pt.keep(name) {
+			tags[pt.name(name)] = add
+		}
+	}
+
+	update.Properties = properties
+	update.Tags = tags
+}
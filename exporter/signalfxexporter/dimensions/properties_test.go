@@ -0,0 +1,155 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dimensions
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/signalfxexporter/translation"
+	metadata "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/experimentalmetricmetadata"
+)
+
+func TestPropertyTransformer(t *testing.T) {
+	tests := []struct {
+		name           string
+		include        []string
+		exclude        []string
+		rename         map[string]string
+		maxValueLength int
+		want           *DimensionUpdate
+	}{
+		{
+			name: "Test no transformation",
+			want: &DimensionUpdate{
+				Properties: map[string]*string{
+					"app":                newString("checkout"),
+					"secret-annotation":  newString("s3cr3t"),
+					"kubernetes_service": newString("checkout-svc"),
+					"removed":            nil,
+				},
+				Tags: map[string]bool{"canary": true, "secret-tag": false},
+			},
+		},
+		{
+			name:    "Test include and exclude",
+			include: []string{"app", "kubernetes_*", "secret-*", "removed", "canary"},
+			exclude: []string{"/^secret-/"},
+			want: &DimensionUpdate{
+				Properties: map[string]*string{
+					"app":                newString("checkout"),
+					"kubernetes_service": newString("checkout-svc"),
+					"removed":            nil,
+				},
+				Tags: map[string]bool{"canary": true},
+			},
+		},
+		{
+			name:   "Test rename",
+			rename: map[string]string{"app": "service", "canary": "release_canary"},
+			want: &DimensionUpdate{
+				Properties: map[string]*string{
+					"service":            newString("checkout"),
+					"secret-annotation":  newString("s3cr3t"),
+					"kubernetes_service": newString("checkout-svc"),
+					"removed":            nil,
+				},
+				Tags: map[string]bool{"release_canary": true, "secret-tag": false},
+			},
+		},
+		{
+			name:           "Test value truncation",
+			maxValueLength: 5,
+			want: &DimensionUpdate{
+				Properties: map[string]*string{
+					"app":                newString("check"),
+					"secret-annotation":  newString("s3cr3"),
+					"kubernetes_service": newString("check"),
+					"removed":            nil,
+				},
+				Tags: map[string]bool{"canary": true, "secret-tag": false},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pt, err := NewPropertyTransformer(tt.include, tt.exclude, tt.rename, tt.maxValueLength)
+			require.NoError(t, err)
+
+			update := &DimensionUpdate{
+				Properties: map[string]*string{
+					"app":                newString("checkout"),
+					"secret-annotation":  newString("s3cr3t"),
+					"kubernetes_service": newString("checkout-svc"),
+					"removed":            nil,
+				},
+				Tags: map[string]bool{"canary": true, "secret-tag": false},
+			}
+			pt.transform(update)
+			assert.Equal(t, tt.want, update)
+		})
+	}
+}
+
+func TestNewPropertyTransformerInvalidFilter(t *testing.T) {
+	_, err := NewPropertyTransformer([]string{"/[/"}, nil, nil, 0)
+	assert.Error(t, err)
+	_, err = NewPropertyTransformer(nil, []string{"/[/"}, nil, 0)
+	assert.Error(t, err)
+}
+
+func TestPushMetadataWithPropertyTransformer(t *testing.T) {
+	pt, err := NewPropertyTransformer(nil, []string{"secret-*"}, map[string]string{"app": "service"}, 0)
+	require.NoError(t, err)
+	converter, err := translation.NewMetricsConverter(zap.NewNop(), nil, nil, nil, "")
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	client := NewDimensionClient(ctx, DimensionClientOptions{
+		Logger:                zap.NewNop(),
+		PropertiesMaxBuffered: 10,
+		MetricsConverter:      *converter,
+		PropertyTransformer:   pt,
+	})
+
+	require.NoError(t, client.PushMetadata([]*metadata.MetadataUpdate{
+		{
+			ResourceIDKey: "pod_uid",
+			ResourceID:    "pod-1",
+			MetadataDelta: metadata.MetadataDelta{
+				MetadataToAdd: map[string]string{"app": "checkout", "secret-token": "s3cr3t"},
+			},
+		},
+		{
+			ResourceIDKey: "pod_uid",
+			ResourceID:    "pod-2",
+			MetadataDelta: metadata.MetadataDelta{
+				MetadataToAdd: map[string]string{"secret-token": "s3cr3t"},
+			},
+		},
+	}))
+
+	// The update of pod-2 is dropped since all its properties were filtered out.
+	require.Len(t, client.delayedSet, 1)
+	update := client.delayedSet[DimensionKey{Name: "pod_uid", Value: "pod-1"}]
+	require.NotNil(t, update)
+	assert.Equal(t, map[string]*string{"service": newString("checkout")}, update.Properties)
+}
@@ -34,6 +34,7 @@ import (
 	"io/ioutil"
 	"net/http"
 	"sync/atomic"
+	"time"
 )
 
 // ReqSender is a direct port of
@@ -101,7 +102,9 @@ func (rs *ReqSender) processRequests() {
 }
 
 func (rs *ReqSender) sendRequest(req *http.Request) error {
+	start := time.Now()
 	body, statusCode, err := sendRequest(rs.client, req)
+	onRequestDone(req, statusCode, time.Since(start))
 	// If it was successful there is nothing else to do.
 	if statusCode == 200 {
 		onRequestSuccess(req, body)
@@ -123,10 +126,15 @@ type key int
 
 const RequestFailedCallbackKey key = 1
 const RequestSuccessCallbackKey key = 2
+const RequestDoneCallbackKey key = 3
 
 type RequestFailedCallback func(statusCode int, err error)
 type RequestSuccessCallback func([]byte)
 
+// RequestDoneCallback is called with the status code of the response, 0 if none was
+// received, and the latency of every request.
+type RequestDoneCallback func(statusCode int, latency time.Duration)
+
 func onRequestSuccess(req *http.Request, body []byte) {
 	ctx := req.Context()
 	cb, ok := ctx.Value(RequestSuccessCallbackKey).(RequestSuccessCallback)
@@ -143,6 +151,14 @@ func onRequestFailed(req *http.Request, statusCode int, err error) {
 	}
 	cb(statusCode, err)
 }
+func onRequestDone(req *http.Request, statusCode int, latency time.Duration) {
+	ctx := req.Context()
+	cb, ok := ctx.Value(RequestDoneCallbackKey).(RequestDoneCallback)
+	if !ok {
+		return
+	}
+	cb(statusCode, latency)
+}
 
 func sendRequest(client *http.Client, req *http.Request) ([]byte, int, error) {
 	resp, err := client.Do(req)
@@ -31,6 +31,7 @@ import (
 	"go.opentelemetry.io/collector/consumer/pdata"
 	"go.uber.org/zap"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/signalfxexporter/observability"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/signalfxexporter/translation"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/splunk"
 )
@@ -40,6 +41,7 @@ type sfxClientBase struct {
 	headers   map[string]string
 	client    *http.Client
 	zippers   sync.Pool
+	recorder  *observability.Recorder
 }
 
 // avoid attempting to compress things that fit into a single ethernet frame
@@ -67,6 +69,12 @@ type sfxDPClient struct {
 	logger                 *zap.Logger
 	accessTokenPassthrough bool
 	converter              *translation.MetricsConverter
+	dpmBudget              *dpmBudget
+	rateLimiter            *rateLimiter
+	routes                 []*metricRoute
+	// route is the index of the metric route whose datapoints the client sends,
+	// or defaultRoute for the datapoints matching none.
+	route int
 }
 
 func (s *sfxDPClient) pushMetricsData(
@@ -87,16 +95,49 @@ func (s *sfxDPClient) pushMetricsData(
 		sfxDataPoints = append(sfxDataPoints, s.converter.MetricDataToSignalFxV2(rms.At(i))...)
 	}
 
-	return s.pushMetricsDataForToken(ctx, sfxDataPoints, metricToken)
+	ingestURL := s.ingestURL
+	if len(s.routes) > 0 {
+		unrouted, routed := routeDataPoints(s.routes, sfxDataPoints)
+		if s.route == defaultRoute {
+			sfxDataPoints = unrouted
+		} else {
+			route := s.routes[s.route]
+			sfxDataPoints = routed[s.route]
+			ingestURL = route.ingestURL
+			if route.accessToken != "" {
+				metricToken = route.accessToken
+			}
+		}
+		if len(sfxDataPoints) == 0 {
+			return 0, nil
+		}
+	}
+
+	var shed int
+	if s.dpmBudget != nil {
+		sfxDataPoints, shed = s.dpmBudget.apply(sfxDataPoints)
+		if len(sfxDataPoints) == 0 {
+			return shed, nil
+		}
+	}
+
+	dropped, err := s.pushMetricsDataForToken(ctx, ingestURL, sfxDataPoints, metricToken)
+	return dropped + shed, err
 }
 
-func (s *sfxDPClient) pushMetricsDataForToken(ctx context.Context, sfxDataPoints []*sfxpb.DataPoint, accessToken string) (int, error) {
+func (s *sfxDPClient) pushMetricsDataForToken(ctx context.Context, ingestURL *url.URL, sfxDataPoints []*sfxpb.DataPoint, accessToken string) (int, error) {
+	if s.rateLimiter != nil {
+		if err := s.rateLimiter.wait(ctx, len(sfxDataPoints)); err != nil {
+			return len(sfxDataPoints), err
+		}
+	}
+
 	body, compressed, err := s.encodeBody(sfxDataPoints)
 	if err != nil {
 		return len(sfxDataPoints), consumererror.Permanent(err)
 	}
 
-	datapointURL := *s.ingestURL
+	datapointURL := *ingestURL
 	if !strings.HasSuffix(datapointURL.Path, "v2/datapoint") {
 		datapointURL.Path = path.Join(datapointURL.Path, "v2/datapoint")
 	}
@@ -104,6 +145,7 @@ func (s *sfxDPClient) pushMetricsDataForToken(ctx context.Context, sfxDataPoints
 	if err != nil {
 		return len(sfxDataPoints), consumererror.Permanent(err)
 	}
+	s.recorder.RecordMetricsPayload(int(req.ContentLength), compressed)
 
 	for k, v := range s.headers {
 		req.Header.Set(k, v)
@@ -128,10 +170,17 @@ func (s *sfxDPClient) pushMetricsDataForToken(ctx context.Context, sfxDataPoints
 	io.Copy(ioutil.Discard, resp.Body)
 	resp.Body.Close()
 
+	if s.rateLimiter != nil {
+		s.rateLimiter.observe(resp)
+	}
+
 	err = splunk.HandleHTTPCode(resp)
 	if err != nil {
 		return len(sfxDataPoints), err
 	}
+	if s.dpmBudget != nil {
+		s.dpmBudget.charge(len(sfxDataPoints))
+	}
 	return 0, nil
 }
 
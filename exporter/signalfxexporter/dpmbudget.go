@@ -0,0 +1,177 @@
+// Copyright 2021, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signalfxexporter
+
+import (
+	"sync"
+	"time"
+
+	sfxpb "github.com/signalfx/com_signalfx_metrics_protobuf/model"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/signalfxexporter/translation/dpfilters"
+)
+
+const (
+	dpmBudgetModeDrop   = "drop"
+	dpmBudgetModeSample = "sample"
+)
+
+// dpmBudget enforces a datapoints-per-minute budget over fixed one minute windows.
+type dpmBudget struct {
+	logger     *zap.Logger
+	limit      int
+	mode       string
+	priorities []*dpfilters.FilterSet
+	now        func() time.Time
+	afterFunc  func(d time.Duration, f func())
+
+	mu             sync.Mutex
+	windowStart    time.Time
+	sent           int
+	shedByPriority int
+	shedBySampling int
+	reportPending  bool
+}
+
+func newDPMBudget(logger *zap.Logger, cfg *DPMBudgetConfig) (*dpmBudget, error) {
+	b := &dpmBudget{
+		logger: logger,
+		limit:  cfg.Limit,
+		mode:   cfg.Mode,
+		now:    time.Now,
+		afterFunc: func(d time.Duration, f func()) {
+			time.AfterFunc(d, f)
+		},
+	}
+	if b.mode == "" {
+		b.mode = dpmBudgetModeDrop
+	}
+
+	for _, f := range cfg.DropPriority {
+		fs, err := dpfilters.NewFilterSet([]dpfilters.MetricFilter{f}, nil)
+		if err != nil {
+			return nil, err
+		}
+		b.priorities = append(b.priorities, fs)
+	}
+	return b, nil
+}
+
+// apply returns the datapoints fitting into the budget left in the current window,
+// along with the number of datapoints shed. The datapoints kept are only counted
+// against the budget once sent, see charge.
+func (b *dpmBudget) apply(dps []*sfxpb.DataPoint) ([]*sfxpb.DataPoint, int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.rollWindow()
+
+	remaining := b.limit - b.sent
+	if len(dps) <= remaining {
+		return dps, 0
+	}
+
+	total := len(dps)
+	if b.mode == dpmBudgetModeDrop {
+		for _, fs := range b.priorities {
+			if len(dps) <= remaining {
+				break
+			}
+			dps = dropMatching(dps, fs)
+		}
+		b.shedByPriority += total - len(dps)
+	}
+
+	// Spill over to sampling whatever still doesn't fit.
+	if len(dps) > remaining {
+		before := len(dps)
+		dps = sampleDataPoints(dps, remaining)
+		b.shedBySampling += before - len(dps)
+	}
+
+	shed := total - len(dps)
+	b.logger.Debug("DPM budget exceeded, shedding datapoints",
+		zap.Int("limit", b.limit),
+		zap.Int("shed", shed),
+		zap.Int("kept", len(dps)))
+
+	// Report what was shed a minute from now, whether or not more data comes in.
+	if !b.reportPending {
+		b.reportPending = true
+		b.afterFunc(time.Minute, b.report)
+	}
+	return dps, shed
+}
+
+// charge counts n sent datapoints against the budget of the current window. It is
+// called once the datapoints are sent, so that failed sends, and the retries of
+// the exporter, don't use up the budget.
+func (b *dpmBudget) charge(n int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.rollWindow()
+	b.sent += n
+}
+
+// report logs a summary of the datapoints shed since the previous report.
+func (b *dpmBudget) report() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.logger.Warn("DPM budget exceeded, datapoints were shed",
+		zap.Int("limit", b.limit),
+		zap.Int("shed_by_priority", b.shedByPriority),
+		zap.Int("shed_by_sampling", b.shedBySampling))
+
+	b.shedByPriority = 0
+	b.shedBySampling = 0
+	b.reportPending = false
+}
+
+// rollWindow starts a new window once the current one is over. It must be called
+// with the lock held.
+func (b *dpmBudget) rollWindow() {
+	now := b.now()
+	if now.Sub(b.windowStart) < time.Minute {
+		return
+	}
+	b.windowStart = now
+	b.sent = 0
+}
+
+// dropMatching returns the datapoints not matched by the given filter set.
+func dropMatching(dps []*sfxpb.DataPoint, fs *dpfilters.FilterSet) []*sfxpb.DataPoint {
+	out := make([]*sfxpb.DataPoint, 0, len(dps))
+	for _, dp := range dps {
+		if !fs.Matches(dp) {
+			out = append(out, dp)
+		}
+	}
+	return out
+}
+
+// sampleDataPoints keeps n datapoints evenly spread across the given ones.
+func sampleDataPoints(dps []*sfxpb.DataPoint, n int) []*sfxpb.DataPoint {
+	if n <= 0 {
+		return nil
+	}
+	out := make([]*sfxpb.DataPoint, n)
+	for i := range out {
+		out[i] = dps[i*len(dps)/n]
+	}
+	return out
+}
@@ -0,0 +1,223 @@
+// Copyright 2021, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signalfxexporter
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	sfxpb "github.com/signalfx/com_signalfx_metrics_protobuf/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/signalfxexporter/translation/dpfilters"
+)
+
+func makeDataPoints(metric string, n int) []*sfxpb.DataPoint {
+	dps := make([]*sfxpb.DataPoint, n)
+	for i := range dps {
+		dps[i] = &sfxpb.DataPoint{
+			Metric:     metric,
+			Dimensions: []*sfxpb.Dimension{{Key: "index", Value: strconv.Itoa(i)}},
+		}
+	}
+	return dps
+}
+
+func metricNames(dps []*sfxpb.DataPoint) map[string]int {
+	names := map[string]int{}
+	for _, dp := range dps {
+		names[dp.Metric]++
+	}
+	return names
+}
+
+func TestDPMBudget(t *testing.T) {
+	tests := []struct {
+		name      string
+		cfg       DPMBudgetConfig
+		batches   [][]*sfxpb.DataPoint
+		wantKept  []map[string]int
+		wantShed  []int
+		wantErr   bool
+		advanceBy time.Duration
+	}{
+		{
+			name: "within budget",
+			cfg:  DPMBudgetConfig{Limit: 10},
+			batches: [][]*sfxpb.DataPoint{
+				makeDataPoints("cpu.utilization", 4),
+				makeDataPoints("cpu.utilization", 6),
+			},
+			wantKept: []map[string]int{
+				{"cpu.utilization": 4},
+				{"cpu.utilization": 6},
+			},
+			wantShed: []int{0, 0},
+		},
+		{
+			name: "drop lowest priority first",
+			cfg: DPMBudgetConfig{
+				Limit: 10,
+				DropPriority: []dpfilters.MetricFilter{
+					{MetricName: "container.memory.usage"},
+					{MetricName: "k8s.pod.phase"},
+				},
+			},
+			batches: [][]*sfxpb.DataPoint{
+				append(append(makeDataPoints("cpu.utilization", 4),
+					makeDataPoints("k8s.pod.phase", 4)...),
+					makeDataPoints("container.memory.usage", 4)...),
+			},
+			wantKept: []map[string]int{
+				{"cpu.utilization": 4, "k8s.pod.phase": 4},
+			},
+			wantShed: []int{4},
+		},
+		{
+			name: "spill over to sampling",
+			cfg: DPMBudgetConfig{
+				Limit: 6,
+				DropPriority: []dpfilters.MetricFilter{
+					{MetricName: "k8s.pod.phase"},
+				},
+			},
+			batches: [][]*sfxpb.DataPoint{
+				append(makeDataPoints("cpu.utilization", 8), makeDataPoints("k8s.pod.phase", 2)...),
+			},
+			wantKept: []map[string]int{
+				{"cpu.utilization": 6},
+			},
+			wantShed: []int{4},
+		},
+		{
+			name: "sample",
+			cfg: DPMBudgetConfig{
+				Limit: 5,
+				Mode:  dpmBudgetModeSample,
+				DropPriority: []dpfilters.MetricFilter{
+					{MetricName: "k8s.pod.phase"},
+				},
+			},
+			batches: [][]*sfxpb.DataPoint{
+				append(makeDataPoints("cpu.utilization", 5), makeDataPoints("k8s.pod.phase", 5)...),
+				makeDataPoints("cpu.utilization", 1),
+			},
+			wantKept: []map[string]int{
+				{"cpu.utilization": 3, "k8s.pod.phase": 2},
+				{},
+			},
+			wantShed: []int{5, 1},
+		},
+		{
+			name:      "new window",
+			cfg:       DPMBudgetConfig{Limit: 5},
+			advanceBy: time.Minute,
+			batches: [][]*sfxpb.DataPoint{
+				makeDataPoints("cpu.utilization", 8),
+				makeDataPoints("cpu.utilization", 5),
+			},
+			wantKept: []map[string]int{
+				{"cpu.utilization": 5},
+				{"cpu.utilization": 5},
+			},
+			wantShed: []int{3, 0},
+		},
+		{
+			name: "invalid filter",
+			cfg: DPMBudgetConfig{
+				Limit: 5,
+				DropPriority: []dpfilters.MetricFilter{
+					{Dimensions: map[string]interface{}{"foo": 1}},
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := newDPMBudget(zap.NewNop(), &tt.cfg)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			now := time.Unix(1600000000, 0)
+			b.now = func() time.Time { return now }
+
+			for i, batch := range tt.batches {
+				kept, shed := b.apply(batch)
+				assert.Equal(t, tt.wantKept[i], metricNames(kept))
+				assert.Equal(t, tt.wantShed[i], shed)
+				b.charge(len(kept))
+				now = now.Add(tt.advanceBy)
+			}
+		})
+	}
+}
+
+func TestDPMBudgetFailedSendsNotCharged(t *testing.T) {
+	b, err := newDPMBudget(zap.NewNop(), &DPMBudgetConfig{Limit: 5})
+	require.NoError(t, err)
+
+	// The send of the first batch fails, its retry fits into the whole budget.
+	kept, shed := b.apply(makeDataPoints("cpu.utilization", 5))
+	assert.Len(t, kept, 5)
+	assert.Equal(t, 0, shed)
+	kept, shed = b.apply(makeDataPoints("cpu.utilization", 5))
+	assert.Len(t, kept, 5)
+	assert.Equal(t, 0, shed)
+	b.charge(len(kept))
+
+	kept, shed = b.apply(makeDataPoints("cpu.utilization", 1))
+	assert.Len(t, kept, 0)
+	assert.Equal(t, 1, shed)
+}
+
+func TestDPMBudgetReport(t *testing.T) {
+	core, logs := observer.New(zap.WarnLevel)
+	b, err := newDPMBudget(zap.New(core), &DPMBudgetConfig{
+		Limit:        2,
+		DropPriority: []dpfilters.MetricFilter{{MetricName: "k8s.pod.phase"}},
+	})
+	require.NoError(t, err)
+
+	var reports []func()
+	b.afterFunc = func(d time.Duration, f func()) {
+		assert.Equal(t, time.Minute, d)
+		reports = append(reports, f)
+	}
+
+	b.apply(append(makeDataPoints("cpu.utilization", 2), makeDataPoints("k8s.pod.phase", 1)...))
+	b.apply(makeDataPoints("cpu.utilization", 4))
+	// A single report is pending until it runs, even though no more data comes in.
+	require.Len(t, reports, 1)
+	assert.Equal(t, 0, logs.Len())
+
+	reports[0]()
+	require.Equal(t, 1, logs.Len())
+	assert.Equal(t, map[string]interface{}{
+		"limit":            int64(2),
+		"shed_by_priority": int64(1),
+		"shed_by_sampling": int64(2),
+	}, logs.All()[0].ContextMap())
+
+	b.apply(makeDataPoints("cpu.utilization", 3))
+	assert.Len(t, reports, 2)
+}
@@ -73,6 +73,7 @@ func (s *sfxEventClient) pushLogsData(ctx context.Context, ld pdata.Logs) (int,
 	if err != nil {
 		return ld.LogRecordCount(), consumererror.Permanent(err)
 	}
+	s.recorder.RecordEventsPayload(int(req.ContentLength), compressed)
 
 	for k, v := range s.headers {
 		req.Header.Set(k, v)
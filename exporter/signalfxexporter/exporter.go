@@ -31,6 +31,7 @@ import (
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/signalfxexporter/dimensions"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/signalfxexporter/hostmetadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/signalfxexporter/observability"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/signalfxexporter/translation"
 	metadata "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/experimentalmetricmetadata"
 )
@@ -47,6 +48,12 @@ type baseLogsExporter struct {
 	consumer.Logs
 }
 
+// TODO: Find a place for this to be shared.
+type baseTracesExporter struct {
+	component.Component
+	consumer.Traces
+}
+
 type signalfMetadataExporter struct {
 	component.MetricsExporter
 	pushMetadata func(metadata []*metadata.MetadataUpdate) error
@@ -57,19 +64,23 @@ func (sme *signalfMetadataExporter) ConsumeMetadata(metadata []*metadata.Metadat
 }
 
 type signalfxExporter struct {
-	pushMetricsData    func(ctx context.Context, md pdata.Metrics) (droppedTimeSeries int, err error)
-	pushMetadata       func(metadata []*metadata.MetadataUpdate) error
-	pushLogsData       func(ctx context.Context, ld pdata.Logs) (droppedLogRecords int, err error)
-	hostMetadataSyncer *hostmetadata.Syncer
+	pushMetricsData func(ctx context.Context, md pdata.Metrics) (droppedTimeSeries int, err error)
+	// routePushMetricsData pushes the datapoints of each metric route, while
+	// pushMetricsData pushes the ones matching none.
+	routePushMetricsData []func(ctx context.Context, md pdata.Metrics) (droppedTimeSeries int, err error)
+	pushMetadata         func(metadata []*metadata.MetadataUpdate) error
+	pushLogsData         func(ctx context.Context, ld pdata.Logs) (droppedLogRecords int, err error)
+	hostMetadataSyncer   *hostmetadata.Syncer
 }
 
 type exporterOptions struct {
-	ingestURL        *url.URL
-	apiURL           *url.URL
-	httpTimeout      time.Duration
-	token            string
-	logDimUpdate     bool
-	metricTranslator *translation.MetricTranslator
+	ingestURL           *url.URL
+	apiURL              *url.URL
+	httpTimeout         time.Duration
+	token               string
+	logDimUpdate        bool
+	metricTranslator    *translation.MetricTranslator
+	propertyTransformer *dimensions.PropertyTransformer
 }
 
 // newSignalFxExporter returns a new SignalFx exporter.
@@ -88,26 +99,71 @@ func newSignalFxExporter(
 	}
 
 	headers := buildHeaders(config)
+	recorder := observability.NewRecorder(config.ID().String())
 
-	converter, err := translation.NewMetricsConverter(logger, options.metricTranslator, config.ExcludeMetrics, config.IncludeMetrics, config.NonAlphanumericDimensionChars)
+	converter, err := translation.NewMetricsConverter(logger, options.metricTranslator, config.ExcludeMetrics, config.IncludeMetrics, config.NonAlphanumericDimensionChars,
+		translation.WithRecorder(recorder), translation.WithHistogramQuantiles(config.HistogramQuantiles))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create metric converter: %v", err)
 	}
 
-	dpClient := &sfxDPClient{
-		sfxClientBase: sfxClientBase{
-			ingestURL: options.ingestURL,
-			headers:   headers,
-			client: &http.Client{
-				// TODO: What other settings of http.Client to expose via config?
-				//  Or what others change from default values?
-				Timeout: config.Timeout,
+	var budget *dpmBudget
+	if config.DPMBudget != nil {
+		budget, err = newDPMBudget(logger, config.DPMBudget)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create dpm budget: %v", err)
+		}
+	}
+
+	routes, err := newMetricRoutes(config.MetricRoutes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create metric routes: %v", err)
+	}
+
+	var limiter *rateLimiter
+	if config.RateLimit != nil {
+		limiter = newRateLimiter(config.RateLimit)
+	}
+
+	newDPClient := func(converter *translation.MetricsConverter, route int) *sfxDPClient {
+		return &sfxDPClient{
+			sfxClientBase: sfxClientBase{
+				ingestURL: options.ingestURL,
+				headers:   headers,
+				client: &http.Client{
+					// TODO: What other settings of http.Client to expose via config?
+					//  Or what others change from default values?
+					Timeout: config.Timeout,
+				},
+				zippers:  newGzipPool(),
+				recorder: recorder,
 			},
-			zippers: newGzipPool(),
-		},
-		logger:                 logger,
-		accessTokenPassthrough: config.AccessTokenPassthrough,
-		converter:              converter,
+			logger:                 logger,
+			accessTokenPassthrough: config.AccessTokenPassthrough,
+			converter:              converter,
+			dpmBudget:              budget,
+			rateLimiter:            limiter,
+			routes:                 routes,
+			route:                  route,
+		}
+	}
+	dpClient := newDPClient(converter, defaultRoute)
+
+	// Each metric route is sent by its own client, with its own converter since
+	// the delta translations depend on the datapoints translated before. Only the
+	// default converter records the translation metrics.
+	routePushMetricsData := make([]func(ctx context.Context, md pdata.Metrics) (int, error), len(routes))
+	for i := range routes {
+		routeTranslator, err := translation.NewMetricTranslator(config.TranslationRules, config.DeltaTranslationTTL)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create metric translator: %v", err)
+		}
+		routeConverter, err := translation.NewMetricsConverter(logger, routeTranslator, config.ExcludeMetrics, config.IncludeMetrics, config.NonAlphanumericDimensionChars,
+			translation.WithHistogramQuantiles(config.HistogramQuantiles))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create metric converter: %v", err)
+		}
+		routePushMetricsData[i] = newDPClient(routeConverter, i).pushMetricsData
 	}
 
 	dimClient := dimensions.NewDimensionClient(
@@ -125,6 +181,8 @@ func newSignalFxExporter(
 			// to make configurable.
 			PropertiesMaxBuffered: 10000,
 			MetricsConverter:      *converter,
+			Recorder:              recorder,
+			PropertyTransformer:   options.propertyTransformer,
 		})
 	dimClient.Start()
 
@@ -134,9 +192,10 @@ func newSignalFxExporter(
 	}
 
 	return &signalfxExporter{
-		pushMetricsData:    dpClient.pushMetricsData,
-		pushMetadata:       dimClient.PushMetadata,
-		hostMetadataSyncer: hms,
+		pushMetricsData:      dpClient.pushMetricsData,
+		routePushMetricsData: routePushMetricsData,
+		pushMetadata:         dimClient.PushMetadata,
+		hostMetadataSyncer:   hms,
 	}, nil
 }
 
@@ -146,6 +205,32 @@ func newGzipPool() sync.Pool {
 	}}
 }
 
+func newTraceClient(config *Config, logger *zap.Logger) (*sfxTraceClient, error) {
+	if err := config.validateConfig(); err != nil {
+		return nil, fmt.Errorf("failed to process %q config: %v", config.ID().String(), err)
+	}
+
+	ingestURL, err := config.getTraceIngestURL()
+	if err != nil {
+		return nil, fmt.Errorf("invalid \"traces\" \"ingest_url\": %v", err)
+	}
+
+	return &sfxTraceClient{
+		sfxClientBase: sfxClientBase{
+			ingestURL: ingestURL,
+			headers:   buildHeaders(config),
+			client: &http.Client{
+				Timeout: config.Timeout,
+			},
+			zippers:  newGzipPool(),
+			recorder: observability.NewRecorder(config.ID().String()),
+		},
+		logger:                 logger,
+		accessTokenPassthrough: config.AccessTokenPassthrough,
+		format:                 config.Traces.Format,
+	}, nil
+}
+
 func newEventExporter(config *Config, logger *zap.Logger) (*signalfxExporter, error) {
 	if config == nil {
 		return nil, errors.New("nil config")
@@ -168,7 +253,8 @@ func newEventExporter(config *Config, logger *zap.Logger) (*signalfxExporter, er
 				//  Or what others change from default values?
 				Timeout: config.Timeout,
 			},
-			zippers: newGzipPool(),
+			zippers:  newGzipPool(),
+			recorder: observability.NewRecorder(config.ID().String()),
 		},
 		logger:                 logger,
 		accessTokenPassthrough: config.AccessTokenPassthrough,
@@ -187,6 +273,15 @@ func (se *signalfxExporter) pushMetrics(ctx context.Context, md pdata.Metrics) e
 	return err
 }
 
+// pushRouteMetrics returns the function pushing the datapoints of the metric
+// route of the given index.
+func (se *signalfxExporter) pushRouteMetrics(route int) func(ctx context.Context, md pdata.Metrics) error {
+	return func(ctx context.Context, md pdata.Metrics) error {
+		_, err := se.routePushMetricsData[route](ctx, md)
+		return err
+	}
+}
+
 func (se *signalfxExporter) pushLogs(ctx context.Context, ld pdata.Logs) error {
 	_, err := se.pushLogsData(ctx, ld)
 	return err
@@ -27,6 +27,7 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -443,6 +444,269 @@ func TestConsumeMetricsWithAccessTokenPassthrough(t *testing.T) {
 	}
 }
 
+func TestConsumeMetricsWithSendingQueuePerToken(t *testing.T) {
+	const (
+		throttledToken = "ThrottledToken"
+		healthyToken   = "HealthyToken"
+	)
+
+	metricsWithToken := func(token string) pdata.Metrics {
+		out := pdata.NewMetrics()
+		rm := out.ResourceMetrics().AppendEmpty()
+		rm.Resource().Attributes().InsertString("com.splunk.signalfx.access_token", token)
+		m := rm.InstrumentationLibraryMetrics().AppendEmpty().Metrics().AppendEmpty()
+		m.SetName("test_gauge")
+		m.SetDataType(pdata.MetricDataTypeDoubleGauge)
+		m.DoubleGauge().DataPoints().AppendEmpty().SetValue(123)
+		return out
+	}
+
+	var healthyReceived int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("x-sf-token") == throttledToken {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		atomic.AddInt32(&healthyReceived, 1)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	cfg := NewFactory().CreateDefaultConfig().(*Config)
+	cfg.IngestURL = server.URL
+	cfg.APIURL = server.URL
+	cfg.AccessToken = "AccessTokenFromClientHeaders"
+	cfg.QueueSettings = exporterhelper.QueueSettings{Enabled: true, NumConsumers: 1, QueueSize: 1}
+	cfg.RetrySettings.InitialInterval = time.Minute
+	cfg.SendingQueuePerToken.Enabled = true
+
+	sfxExp, err := NewFactory().CreateMetricsExporter(context.Background(), component.ExporterCreateSettings{Logger: zap.NewNop()}, cfg)
+	require.NoError(t, err)
+	require.NoError(t, sfxExp.Start(context.Background(), componenttest.NewNopHost()))
+	defer sfxExp.Shutdown(context.Background())
+
+	// Keep the consumer of the throttled token busy retrying and fill its queue.
+	for i := 0; i < 3; i++ {
+		_ = sfxExp.ConsumeMetrics(context.Background(), metricsWithToken(throttledToken))
+	}
+	assert.Error(t, sfxExp.ConsumeMetrics(context.Background(), metricsWithToken(throttledToken)),
+		"the queue of the throttled token should be full")
+
+	require.NoError(t, sfxExp.ConsumeMetrics(context.Background(), metricsWithToken(healthyToken)))
+	require.Eventually(t, func() bool {
+		return atomic.LoadInt32(&healthyReceived) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestConsumeMetricsWithMetricRoutes(t *testing.T) {
+	type received struct {
+		token   string
+		metrics []string
+	}
+	newServer := func(ch chan<- received) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, err := ioutil.ReadAll(r.Body)
+			assert.NoError(t, err)
+			msg := &sfxpb.DataPointUploadMessage{}
+			assert.NoError(t, msg.Unmarshal(body))
+			var names []string
+			for _, dp := range msg.Datapoints {
+				names = append(names, dp.Metric)
+			}
+			sort.Strings(names)
+			ch <- received{token: r.Header.Get("x-sf-token"), metrics: names}
+			w.WriteHeader(http.StatusAccepted)
+		}))
+	}
+
+	defaultCh := make(chan received, 10)
+	defaultServer := newServer(defaultCh)
+	defer defaultServer.Close()
+	migratedCh := make(chan received, 10)
+	migratedServer := newServer(migratedCh)
+	defer migratedServer.Close()
+
+	cfg := NewFactory().CreateDefaultConfig().(*Config)
+	cfg.IngestURL = defaultServer.URL
+	cfg.APIURL = defaultServer.URL
+	cfg.AccessToken = "old-org"
+	cfg.QueueSettings.Enabled = false
+	cfg.MetricRoutes = []MetricRoute{
+		{
+			MetricTypes: []string{"cumulative_counter"},
+			IngestURL:   migratedServer.URL,
+			AccessToken: "new-org",
+		},
+		{
+			Metrics:   []dpfilters.MetricFilter{{MetricName: "migrated.*"}},
+			IngestURL: migratedServer.URL,
+		},
+	}
+
+	md := pdata.NewMetrics()
+	ms := md.ResourceMetrics().AppendEmpty().InstrumentationLibraryMetrics().AppendEmpty().Metrics()
+	for _, name := range []string{"gauge", "migrated.gauge"} {
+		m := ms.AppendEmpty()
+		m.SetName(name)
+		m.SetDataType(pdata.MetricDataTypeDoubleGauge)
+		m.DoubleGauge().DataPoints().AppendEmpty().SetValue(1)
+	}
+	m := ms.AppendEmpty()
+	m.SetName("cumulative")
+	m.SetDataType(pdata.MetricDataTypeIntSum)
+	m.IntSum().SetIsMonotonic(true)
+	m.IntSum().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+	m.IntSum().DataPoints().AppendEmpty().SetValue(1)
+
+	exp, err := NewFactory().CreateMetricsExporter(context.Background(), component.ExporterCreateSettings{Logger: zap.NewNop()}, cfg)
+	require.NoError(t, err)
+	require.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+	defer exp.Shutdown(context.Background())
+	require.NoError(t, exp.ConsumeMetrics(context.Background(), md))
+
+	assert.Equal(t, received{token: "old-org", metrics: []string{"gauge"}}, <-defaultCh)
+	assert.Equal(t, received{token: "new-org", metrics: []string{"cumulative"}}, <-migratedCh)
+	assert.Equal(t, received{token: "old-org", metrics: []string{"migrated.gauge"}}, <-migratedCh)
+	assert.Len(t, defaultCh, 0)
+	assert.Len(t, migratedCh, 0)
+}
+
+func TestConsumeMetricsWithMetricRoutesRetry(t *testing.T) {
+	var defaultReceived, routedReceived int32
+	defaultServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&defaultReceived, 1)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer defaultServer.Close()
+	routedServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Fail the first request only.
+		if atomic.AddInt32(&routedReceived, 1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer routedServer.Close()
+
+	newMetrics := func() pdata.Metrics {
+		md := pdata.NewMetrics()
+		ms := md.ResourceMetrics().AppendEmpty().InstrumentationLibraryMetrics().AppendEmpty().Metrics()
+		for _, name := range []string{"gauge", "migrated.gauge"} {
+			m := ms.AppendEmpty()
+			m.SetName(name)
+			m.SetDataType(pdata.MetricDataTypeDoubleGauge)
+			m.DoubleGauge().DataPoints().AppendEmpty().SetValue(1)
+		}
+		return md
+	}
+
+	tests := []struct {
+		name            string
+		routedURL       string
+		wantErr         bool
+		defaultRequests int32
+		routedRequests  int32
+	}{
+		{
+			name:            "failed_route_retried_alone",
+			routedURL:       routedServer.URL,
+			defaultRequests: 1,
+			routedRequests:  2,
+		},
+		{
+			name:            "failing_route_does_not_resend_default",
+			routedURL:       "http://localhost:1",
+			wantErr:         true,
+			defaultRequests: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			atomic.StoreInt32(&defaultReceived, 0)
+			atomic.StoreInt32(&routedReceived, 0)
+
+			cfg := NewFactory().CreateDefaultConfig().(*Config)
+			cfg.IngestURL = defaultServer.URL
+			cfg.APIURL = defaultServer.URL
+			cfg.AccessToken = "token"
+			cfg.QueueSettings.Enabled = false
+			cfg.RetrySettings.InitialInterval = 10 * time.Millisecond
+			cfg.RetrySettings.MaxInterval = 10 * time.Millisecond
+			cfg.RetrySettings.MaxElapsedTime = 100 * time.Millisecond
+			cfg.MetricRoutes = []MetricRoute{{
+				Metrics:   []dpfilters.MetricFilter{{MetricName: "migrated.*"}},
+				IngestURL: tt.routedURL,
+			}}
+
+			exp, err := NewFactory().CreateMetricsExporter(context.Background(), component.ExporterCreateSettings{Logger: zap.NewNop()}, cfg)
+			require.NoError(t, err)
+			require.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+			defer exp.Shutdown(context.Background())
+
+			err = exp.ConsumeMetrics(context.Background(), newMetrics())
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tt.defaultRequests, atomic.LoadInt32(&defaultReceived))
+			assert.Equal(t, tt.routedRequests, atomic.LoadInt32(&routedReceived))
+		})
+	}
+}
+
+func TestConsumeMetricsWithMetricRoutesQueued(t *testing.T) {
+	var defaultReceived, routedReceived int32
+	defaultServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&defaultReceived, 1)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer defaultServer.Close()
+	routedServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Fail the first two requests.
+		if atomic.AddInt32(&routedReceived, 1) <= 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer routedServer.Close()
+
+	cfg := NewFactory().CreateDefaultConfig().(*Config)
+	cfg.IngestURL = defaultServer.URL
+	cfg.APIURL = defaultServer.URL
+	cfg.AccessToken = "token"
+	cfg.QueueSettings = exporterhelper.QueueSettings{Enabled: true, NumConsumers: 1, QueueSize: 10}
+	cfg.RetrySettings.InitialInterval = 10 * time.Millisecond
+	cfg.RetrySettings.MaxInterval = 10 * time.Millisecond
+	cfg.MetricRoutes = []MetricRoute{{
+		Metrics:   []dpfilters.MetricFilter{{MetricName: "migrated.*"}},
+		IngestURL: routedServer.URL,
+	}}
+
+	exp, err := NewFactory().CreateMetricsExporter(context.Background(), component.ExporterCreateSettings{Logger: zap.NewNop()}, cfg)
+	require.NoError(t, err)
+	require.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+	defer exp.Shutdown(context.Background())
+
+	md := pdata.NewMetrics()
+	ms := md.ResourceMetrics().AppendEmpty().InstrumentationLibraryMetrics().AppendEmpty().Metrics()
+	for _, name := range []string{"gauge", "migrated.gauge"} {
+		m := ms.AppendEmpty()
+		m.SetName(name)
+		m.SetDataType(pdata.MetricDataTypeDoubleGauge)
+		m.DoubleGauge().DataPoints().AppendEmpty().SetValue(1)
+	}
+	require.NoError(t, exp.ConsumeMetrics(context.Background(), md))
+
+	// The failing route is retried from its own queue, the default endpoint
+	// receives the datapoints once.
+	require.Eventually(t, func() bool {
+		return atomic.LoadInt32(&routedReceived) == 3
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&defaultReceived))
+}
+
 func TestNewEventExporter(t *testing.T) {
 	got, err := newEventExporter(nil, zap.NewNop())
 	assert.EqualError(t, err, "nil config")
@@ -21,17 +21,22 @@ import (
 	"strings"
 	"time"
 
+	"go.opencensus.io/stats/view"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/config/configparser"
+	"go.opentelemetry.io/collector/consumer/consumerhelper"
+	"go.opentelemetry.io/collector/consumer/pdata"
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
 	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/signalfxexporter/correlation"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/signalfxexporter/observability"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/signalfxexporter/translation"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/signalfxexporter/translation/dpfilters"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/splunk"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/batchperresourceattr"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/queueperresourceattr"
 )
 
 const (
@@ -43,6 +48,8 @@ const (
 
 // NewFactory creates a factory for SignalFx exporter.
 func NewFactory() component.ExporterFactory {
+	_ = view.Register(observability.MetricViews()...)
+
 	return exporterhelper.NewFactory(
 		typeStr,
 		createDefaultConfig,
@@ -64,6 +71,7 @@ func createDefaultConfig() config.Exporter {
 		DeltaTranslationTTL:           3600,
 		Correlation:                   correlation.DefaultConfig(),
 		NonAlphanumericDimensionChars: "_-.",
+		SendingQueuePerToken:          queueperresourceattr.DefaultSettings(),
 	}
 }
 
@@ -88,21 +96,57 @@ func createTracesExporter(
 	params.Logger.Info("Correlation tracking enabled", zap.String("endpoint", corrCfg.Endpoint))
 	tracker := correlation.NewTracker(corrCfg, cfg.AccessToken, params)
 
-	return exporterhelper.NewTracesExporter(
+	if cfg.Traces.Format == "" {
+		return exporterhelper.NewTracesExporter(
+			cfg,
+			params.Logger,
+			tracker.AddSpans,
+			exporterhelper.WithStart(tracker.Start),
+			exporterhelper.WithShutdown(tracker.Shutdown))
+	}
+
+	traceClient, err := newTraceClient(cfg, params.Logger)
+	if err != nil {
+		return nil, err
+	}
+
+	te, err := exporterhelper.NewTracesExporter(
 		cfg,
 		params.Logger,
-		tracker.AddSpans,
+		func(ctx context.Context, td pdata.Traces) error {
+			if err := traceClient.pushTraceData(ctx, td); err != nil {
+				return err
+			}
+			return tracker.AddSpans(ctx, td)
+		},
 		exporterhelper.WithStart(tracker.Start),
-		exporterhelper.WithShutdown(tracker.Shutdown))
+		exporterhelper.WithShutdown(tracker.Shutdown),
+		// explicitly disable since we rely on http.Client timeout logic.
+		exporterhelper.WithTimeout(exporterhelper.TimeoutSettings{Timeout: 0}),
+		exporterhelper.WithRetry(cfg.RetrySettings),
+		exporterhelper.WithQueue(cfg.QueueSettings))
+	if err != nil {
+		return nil, err
+	}
+
+	// If AccessTokenPassthrough enabled, split the incoming Traces data by splunk.SFxAccessTokenLabel,
+	// this ensures that we get batches of data for the same token when pushing to the backend.
+	if cfg.AccessTokenPassthrough {
+		te = &baseTracesExporter{
+			Component: te,
+			Traces:    batchperresourceattr.NewBatchPerResourceTraces(splunk.SFxAccessTokenLabel, te),
+		}
+	}
+	return te, nil
 }
 
 func createMetricsExporter(
 	_ context.Context,
 	params component.ExporterCreateSettings,
-	config config.Exporter,
+	cfg config.Exporter,
 ) (component.MetricsExporter, error) {
 
-	expCfg := config.(*Config)
+	expCfg := cfg.(*Config)
 
 	err := setDefaultExcludes(expCfg)
 	if err != nil {
@@ -114,14 +158,37 @@ func createMetricsExporter(
 		return nil, err
 	}
 
-	me, err := exporterhelper.NewMetricsExporter(
+	me, err := queueperresourceattr.NewMetricsExporter(
+		splunk.SFxAccessTokenLabel,
+		expCfg.SendingQueuePerToken,
 		expCfg,
 		params.Logger,
-		exp.pushMetrics,
-		// explicitly disable since we rely on http.Client timeout logic.
-		exporterhelper.WithTimeout(exporterhelper.TimeoutSettings{Timeout: 0}),
-		exporterhelper.WithRetry(expCfg.RetrySettings),
-		exporterhelper.WithQueue(expCfg.QueueSettings))
+		func(cfg config.Exporter) (component.MetricsExporter, error) {
+			newSender := func(cfg config.Exporter, push consumerhelper.ConsumeMetricsFunc) (component.MetricsExporter, error) {
+				return exporterhelper.NewMetricsExporter(
+					cfg,
+					params.Logger,
+					push,
+					// explicitly disable since we rely on http.Client timeout logic.
+					exporterhelper.WithTimeout(exporterhelper.TimeoutSettings{Timeout: 0}),
+					exporterhelper.WithRetry(expCfg.RetrySettings),
+					exporterhelper.WithQueue(expCfg.QueueSettings))
+			}
+
+			sender, err := newSender(cfg, exp.pushMetrics)
+			if err != nil || len(exp.routePushMetricsData) == 0 {
+				return sender, err
+			}
+			routed := &routedMetricsExporter{senders: []component.MetricsExporter{sender}}
+			for i := range exp.routePushMetricsData {
+				sender, err = newSender(newRouteConfig(cfg, i), exp.pushRouteMetrics(i))
+				if err != nil {
+					return nil, err
+				}
+				routed.senders = append(routed.senders, sender)
+			}
+			return routed, nil
+		})
 
 	if err != nil {
 		return nil, err
@@ -129,7 +196,8 @@ func createMetricsExporter(
 
 	// If AccessTokenPassthrough enabled, split the incoming Metrics data by splunk.SFxAccessTokenLabel,
 	// this ensures that we get batches of data for the same token when pushing to the backend.
-	if expCfg.AccessTokenPassthrough {
+	// Dedicated sending queues per token already split the data.
+	if expCfg.AccessTokenPassthrough && !expCfg.SendingQueuePerToken.Enabled {
 		me = &baseMetricsExporter{
 			Component: me,
 			Metrics:   batchperresourceattr.NewBatchPerResourceMetrics(splunk.SFxAccessTokenLabel, me),
@@ -196,14 +264,21 @@ func createLogsExporter(
 		return nil, err
 	}
 
-	le, err := exporterhelper.NewLogsExporter(
+	le, err := queueperresourceattr.NewLogsExporter(
+		splunk.SFxAccessTokenLabel,
+		expCfg.SendingQueuePerToken,
 		expCfg,
 		params.Logger,
-		exp.pushLogs,
-		// explicitly disable since we rely on http.Client timeout logic.
-		exporterhelper.WithTimeout(exporterhelper.TimeoutSettings{Timeout: 0}),
-		exporterhelper.WithRetry(expCfg.RetrySettings),
-		exporterhelper.WithQueue(expCfg.QueueSettings))
+		func(cfg config.Exporter) (component.LogsExporter, error) {
+			return exporterhelper.NewLogsExporter(
+				cfg,
+				params.Logger,
+				exp.pushLogs,
+				// explicitly disable since we rely on http.Client timeout logic.
+				exporterhelper.WithTimeout(exporterhelper.TimeoutSettings{Timeout: 0}),
+				exporterhelper.WithRetry(expCfg.RetrySettings),
+				exporterhelper.WithQueue(expCfg.QueueSettings))
+		})
 
 	if err != nil {
 		return nil, err
@@ -211,7 +286,8 @@ func createLogsExporter(
 
 	// If AccessTokenPassthrough enabled, split the incoming Metrics data by splunk.SFxAccessTokenLabel,
 	// this ensures that we get batches of data for the same token when pushing to the backend.
-	if expCfg.AccessTokenPassthrough {
+	// Dedicated sending queues per token already split the data.
+	if expCfg.AccessTokenPassthrough && !expCfg.SendingQueuePerToken.Enabled {
 		le = &baseLogsExporter{
 			Component: le,
 			Logs:      batchperresourceattr.NewBatchPerResourceLogs(splunk.SFxAccessTokenLabel, le),
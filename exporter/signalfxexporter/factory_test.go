@@ -38,6 +38,7 @@ import (
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/signalfxexporter/translation"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/signalfxexporter/translation/dpfilters"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/queueperresourceattr"
 )
 
 func TestCreateDefaultConfig(t *testing.T) {
@@ -66,6 +67,33 @@ func TestCreateTracesExporter(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestCreateTracesExporterWithFormat(t *testing.T) {
+	for _, format := range []string{"sapm", "otlp", "zipkin"} {
+		t.Run(format, func(t *testing.T) {
+			cfg := createDefaultConfig()
+			c := cfg.(*Config)
+			c.AccessToken = "access_token"
+			c.Realm = "us0"
+			c.Traces.Format = format
+
+			te, err := createTracesExporter(context.Background(), component.ExporterCreateSettings{Logger: zap.NewNop()}, cfg)
+			assert.NoError(t, err)
+			assert.IsType(t, &baseTracesExporter{}, te)
+		})
+	}
+}
+
+func TestCreateTracesExporterInvalidFormat(t *testing.T) {
+	cfg := createDefaultConfig()
+	c := cfg.(*Config)
+	c.AccessToken = "access_token"
+	c.Realm = "us0"
+	c.Traces.Format = "jaeger"
+
+	_, err := createTracesExporter(context.Background(), component.ExporterCreateSettings{Logger: zap.NewNop()}, cfg)
+	assert.Error(t, err)
+}
+
 func TestCreateTracesExporterNoAccessToken(t *testing.T) {
 	cfg := createDefaultConfig()
 	c := cfg.(*Config)
@@ -163,6 +191,73 @@ func TestFactory_CreateMetricsExporterFails(t *testing.T) {
 			errorMessage: "failed to process \"signalfx\" config: requires a non-empty \"realm\"," +
 				" or \"ingest_url\" and \"api_url\" should be explicitly set",
 		},
+		{
+			name: "sending_queue_per_token_without_passthrough",
+			config: &Config{
+				ExporterSettings: config.NewExporterSettings(config.NewID(typeStr)),
+				AccessToken:      "testToken",
+				Realm:            "lab",
+				SendingQueuePerToken: queueperresourceattr.Settings{
+					Enabled: true,
+				},
+			},
+			errorMessage: "failed to process \"signalfx\" config: \"sending_queue_per_token\" requires" +
+				" \"access_token_passthrough\" to be enabled",
+		},
+		{
+			name: "rate_limit_without_rates",
+			config: &Config{
+				ExporterSettings: config.NewExporterSettings(config.NewID(typeStr)),
+				AccessToken:      "testToken",
+				Realm:            "lab",
+				RateLimit:        &RateLimitConfig{RecoveryTime: time.Second},
+			},
+			errorMessage: "failed to process \"signalfx\" config: \"rate_limit\" requires a positive" +
+				" \"datapoints_per_second\" or \"requests_per_second\"",
+		},
+		{
+			name: "rate_limit_negative_rate",
+			config: &Config{
+				ExporterSettings: config.NewExporterSettings(config.NewID(typeStr)),
+				AccessToken:      "testToken",
+				Realm:            "lab",
+				RateLimit:        &RateLimitConfig{DatapointsPerSecond: -1},
+			},
+			errorMessage: "failed to process \"signalfx\" config: \"rate_limit\" cannot have negative rates",
+		},
+		{
+			name: "metric_route_without_matcher",
+			config: &Config{
+				ExporterSettings: config.NewExporterSettings(config.NewID(typeStr)),
+				AccessToken:      "testToken",
+				Realm:            "lab",
+				MetricRoutes:     []MetricRoute{{IngestURL: "https://ingest.us1.signalfx.com"}},
+			},
+			errorMessage: "failed to process \"signalfx\" config: \"metric_routes\" 0 requires \"metric_types\" or \"metrics\"",
+		},
+		{
+			name: "metric_route_invalid_type",
+			config: &Config{
+				ExporterSettings: config.NewExporterSettings(config.NewID(typeStr)),
+				AccessToken:      "testToken",
+				Realm:            "lab",
+				MetricRoutes: []MetricRoute{{
+					MetricTypes: []string{"histogram"},
+					IngestURL:   "https://ingest.us1.signalfx.com",
+				}},
+			},
+			errorMessage: "failed to process \"signalfx\" config: \"metric_routes\" 0 has an invalid metric type \"histogram\"",
+		},
+		{
+			name: "metric_route_without_ingest_url",
+			config: &Config{
+				ExporterSettings: config.NewExporterSettings(config.NewID(typeStr)),
+				AccessToken:      "testToken",
+				Realm:            "lab",
+				MetricRoutes:     []MetricRoute{{MetricTypes: []string{"gauge"}}},
+			},
+			errorMessage: "failed to process \"signalfx\" config: \"metric_routes\" 0 requires a non-empty \"ingest_url\"",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -0,0 +1,164 @@
+// Copyright 2021, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signalfxexporter
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	sfxpb "github.com/signalfx/com_signalfx_metrics_protobuf/model"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/pdata"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/signalfxexporter/translation/dpfilters"
+)
+
+// defaultRoute stands for the datapoints matching no metric route, sent to the
+// ingest endpoint of the exporter.
+const defaultRoute = -1
+
+var routeMetricTypes = map[string]sfxpb.MetricType{
+	"gauge":              sfxpb.MetricType_GAUGE,
+	"counter":            sfxpb.MetricType_COUNTER,
+	"cumulative_counter": sfxpb.MetricType_CUMULATIVE_COUNTER,
+}
+
+// metricRoute sends the datapoints it matches to another ingest endpoint.
+type metricRoute struct {
+	ingestURL   *url.URL
+	accessToken string
+	types       map[sfxpb.MetricType]bool
+	filter      *dpfilters.FilterSet
+}
+
+func newMetricRoutes(routes []MetricRoute) ([]*metricRoute, error) {
+	out := make([]*metricRoute, 0, len(routes))
+	for i, route := range routes {
+		ingestURL, err := url.Parse(route.IngestURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ingest_url of metric route %d: %v", i, err)
+		}
+		r := &metricRoute{
+			ingestURL:   ingestURL,
+			accessToken: route.AccessToken,
+		}
+		if len(route.MetricTypes) > 0 {
+			r.types = make(map[sfxpb.MetricType]bool, len(route.MetricTypes))
+			for _, t := range route.MetricTypes {
+				r.types[routeMetricTypes[t]] = true
+			}
+		}
+		if len(route.Metrics) > 0 {
+			if r.filter, err = dpfilters.NewFilterSet(route.Metrics, nil); err != nil {
+				return nil, fmt.Errorf("invalid metrics of metric route %d: %v", i, err)
+			}
+		}
+		out = append(out, r)
+	}
+	return out, nil
+}
+
+func (r *metricRoute) matches(dp *sfxpb.DataPoint) bool {
+	if r.types != nil && !r.types[dp.GetMetricType()] {
+		return false
+	}
+	return r.filter == nil || r.filter.Matches(dp)
+}
+
+// routeDataPoints splits the datapoints by the first route matching them, the
+// ones matching none are returned separately.
+func routeDataPoints(routes []*metricRoute, dps []*sfxpb.DataPoint) (unrouted []*sfxpb.DataPoint, routed [][]*sfxpb.DataPoint) {
+	routed = make([][]*sfxpb.DataPoint, len(routes))
+	for _, dp := range dps {
+		matched := false
+		for i, r := range routes {
+			if r.matches(dp) {
+				routed[i] = append(routed[i], dp)
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			unrouted = append(unrouted, dp)
+		}
+	}
+	return unrouted, routed
+}
+
+// routeConfig overrides the ID of the exporter configuration for the sender of
+// a metric route, so that its queue and send metrics are reported separately.
+type routeConfig struct {
+	config.Exporter
+	id config.ComponentID
+}
+
+func (c *routeConfig) ID() config.ComponentID {
+	return c.id
+}
+
+func newRouteConfig(cfg config.Exporter, route int) config.Exporter {
+	id := cfg.ID()
+	name := "route_" + strconv.Itoa(route)
+	if id.Name() != "" {
+		name = id.Name() + "_" + name
+	}
+	return &routeConfig{Exporter: cfg, id: config.NewIDWithName(id.Type(), name)}
+}
+
+// routedMetricsExporter passes the metrics to the senders of the default
+// endpoint and of each metric route. Every sender has its own queue and retries,
+// so that a failing endpoint doesn't make the others receive the same datapoints
+// again.
+type routedMetricsExporter struct {
+	senders []component.MetricsExporter
+}
+
+func (e *routedMetricsExporter) Start(ctx context.Context, host component.Host) error {
+	for _, s := range e.senders {
+		if err := s.Start(ctx, host); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *routedMetricsExporter) Shutdown(ctx context.Context) error {
+	var errs []error
+	for _, s := range e.senders {
+		if err := s.Shutdown(ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return consumererror.Combine(errs)
+}
+
+func (e *routedMetricsExporter) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
+
+func (e *routedMetricsExporter) ConsumeMetrics(ctx context.Context, md pdata.Metrics) error {
+	var errs []error
+	for _, s := range e.senders {
+		if err := s.ConsumeMetrics(ctx, md); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return consumererror.Combine(errs)
+}
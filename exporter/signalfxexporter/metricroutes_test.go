@@ -0,0 +1,66 @@
+// Copyright 2021, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signalfxexporter
+
+import (
+	"testing"
+
+	sfxpb "github.com/signalfx/com_signalfx_metrics_protobuf/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/signalfxexporter/translation/dpfilters"
+)
+
+func dataPoint(metric string, metricType sfxpb.MetricType) *sfxpb.DataPoint {
+	return &sfxpb.DataPoint{Metric: metric, MetricType: &metricType}
+}
+
+func TestRouteDataPoints(t *testing.T) {
+	routes, err := newMetricRoutes([]MetricRoute{
+		{
+			MetricTypes: []string{"counter", "cumulative_counter"},
+			IngestURL:   "https://ingest.eu0.signalfx.com",
+		},
+		{
+			Metrics:   []dpfilters.MetricFilter{{MetricNames: []string{"k8s.*"}}},
+			IngestURL: "https://ingest.us2.signalfx.com",
+		},
+		{
+			MetricTypes: []string{"gauge"},
+			Metrics:     []dpfilters.MetricFilter{{MetricName: "cpu.utilization"}},
+			IngestURL:   "https://ingest.us2.signalfx.com",
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, routes, 3)
+	assert.Equal(t, "ingest.eu0.signalfx.com", routes[0].ingestURL.Host)
+
+	counter := dataPoint("requests", sfxpb.MetricType_COUNTER)
+	k8sCounter := dataPoint("k8s.container.restarts", sfxpb.MetricType_CUMULATIVE_COUNTER)
+	k8sGauge := dataPoint("k8s.pod.phase", sfxpb.MetricType_GAUGE)
+	cpuGauge := dataPoint("cpu.utilization", sfxpb.MetricType_GAUGE)
+	memGauge := dataPoint("memory.utilization", sfxpb.MetricType_GAUGE)
+	noType := &sfxpb.DataPoint{Metric: "cpu.utilization"}
+
+	unrouted, routed := routeDataPoints(routes, []*sfxpb.DataPoint{counter, k8sCounter, k8sGauge, cpuGauge, memGauge, noType})
+	assert.Equal(t, []*sfxpb.DataPoint{memGauge}, unrouted)
+	// The first matching route wins, datapoints without type are gauges.
+	assert.Equal(t, [][]*sfxpb.DataPoint{
+		{counter, k8sCounter},
+		{k8sGauge},
+		{cpuGauge, noType},
+	}, routed)
+}
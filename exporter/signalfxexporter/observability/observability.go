@@ -0,0 +1,138 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package observability records the metrics the signalfx exporter reports about
+// its own operation.
+package observability
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+)
+
+const (
+	dataTypeMetrics = "metrics"
+	dataTypeEvents  = "events"
+)
+
+var (
+	tagExporter   = tag.MustNewKey("exporter")
+	tagFilter     = tag.MustNewKey("filter")
+	tagRule       = tag.MustNewKey("rule")
+	tagAction     = tag.MustNewKey("action")
+	tagStatus     = tag.MustNewKey("status")
+	tagDataType   = tag.MustNewKey("data_type")
+	tagCompressed = tag.MustNewKey("compressed")
+
+	mDatapointsFiltered     = stats.Int64("signalfxexporter/datapoints_filtered", "Number of datapoints dropped by an exclude_metrics filter", stats.UnitDimensionless)
+	mTranslationRuleApplied = stats.Int64("signalfxexporter/translation_rule_datapoints", "Number of datapoints a translation rule was applied to", stats.UnitDimensionless)
+	mDimensionUpdateLatency = stats.Float64("signalfxexporter/dimension_update_latency", "Latency of the requests updating dimension properties and tags", stats.UnitMilliseconds)
+	mPayloadSize            = stats.Int64("signalfxexporter/payload_size", "Size of the payloads sent to the ingest endpoint", stats.UnitBytes)
+
+	latencyDistribution     = view.Distribution(5, 10, 25, 50, 100, 250, 500, 1000, 2500, 5000, 10000)
+	payloadSizeDistribution = view.Distribution(1500, 4096, 16384, 65536, 262144, 1048576, 4194304)
+)
+
+// MetricViews returns the views of the metrics of the exporter.
+func MetricViews() []*view.View {
+	return []*view.View{
+		buildView([]tag.Key{tagExporter, tagFilter}, mDatapointsFiltered, view.Sum()),
+		buildView([]tag.Key{tagExporter, tagRule, tagAction}, mTranslationRuleApplied, view.Sum()),
+		buildView([]tag.Key{tagExporter, tagStatus}, mDimensionUpdateLatency, latencyDistribution),
+		buildView([]tag.Key{tagExporter, tagDataType, tagCompressed}, mPayloadSize, payloadSizeDistribution),
+	}
+}
+
+func buildView(tagKeys []tag.Key, m stats.Measure, a *view.Aggregation) *view.View {
+	return &view.View{
+		Name:        m.Name(),
+		Measure:     m,
+		Description: m.Description(),
+		TagKeys:     tagKeys,
+		Aggregation: a,
+	}
+}
+
+// Recorder records the metrics of an exporter. A nil Recorder records nothing.
+type Recorder struct {
+	exporter string
+}
+
+// NewRecorder returns a Recorder recording the metrics of the exporter with the given name.
+func NewRecorder(exporter string) *Recorder {
+	return &Recorder{exporter: exporter}
+}
+
+// RecordDatapointsFiltered records the datapoints dropped by an exclude_metrics filter.
+func (r *Recorder) RecordDatapointsFiltered(filter string, count int) {
+	if r == nil || count == 0 {
+		return
+	}
+	r.record([]tag.Mutator{tag.Upsert(tagFilter, filter)}, mDatapointsFiltered.M(int64(count)))
+}
+
+// RecordTranslationRuleApplied records the datapoints a translation rule, identified by
+// its index in the translation rules, was applied to.
+func (r *Recorder) RecordTranslationRuleApplied(rule int, action string, count int) {
+	if r == nil || count == 0 {
+		return
+	}
+	r.record([]tag.Mutator{
+		tag.Upsert(tagRule, strconv.Itoa(rule)),
+		tag.Upsert(tagAction, action),
+	}, mTranslationRuleApplied.M(int64(count)))
+}
+
+// RecordDimensionUpdate records the latency of a dimension update request. statusCode is
+// 0 when no response was received.
+func (r *Recorder) RecordDimensionUpdate(statusCode int, latency time.Duration) {
+	if r == nil {
+		return
+	}
+	status := "error"
+	if statusCode != 0 {
+		status = strconv.Itoa(statusCode)
+	}
+	r.record([]tag.Mutator{tag.Upsert(tagStatus, status)}, mDimensionUpdateLatency.M(float64(latency)/float64(time.Millisecond)))
+}
+
+// RecordMetricsPayload records the size of a datapoints payload, as sent.
+func (r *Recorder) RecordMetricsPayload(size int, compressed bool) {
+	r.recordPayload(dataTypeMetrics, size, compressed)
+}
+
+// RecordEventsPayload records the size of an events payload, as sent.
+func (r *Recorder) RecordEventsPayload(size int, compressed bool) {
+	r.recordPayload(dataTypeEvents, size, compressed)
+}
+
+func (r *Recorder) recordPayload(dataType string, size int, compressed bool) {
+	if r == nil {
+		return
+	}
+	r.record([]tag.Mutator{
+		tag.Upsert(tagDataType, dataType),
+		tag.Upsert(tagCompressed, strconv.FormatBool(compressed)),
+	}, mPayloadSize.M(int64(size)))
+}
+
+func (r *Recorder) record(mutators []tag.Mutator, m stats.Measurement) {
+	mutators = append(mutators, tag.Upsert(tagExporter, r.exporter))
+	_ = stats.RecordWithTags(context.Background(), mutators, m)
+}
@@ -0,0 +1,87 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package observability
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+)
+
+func TestRecorder(t *testing.T) {
+	views := MetricViews()
+	require.NoError(t, view.Register(views...))
+	defer view.Unregister(views...)
+
+	r := NewRecorder("signalfx/test")
+	r.RecordDatapointsFiltered("exclude_metrics/0", 3)
+	r.RecordDatapointsFiltered("exclude_metrics/0", 2)
+	r.RecordDatapointsFiltered("exclude_metrics/1", 0)
+	r.RecordTranslationRuleApplied(4, "rename_metrics", 7)
+	r.RecordDimensionUpdate(200, 30*time.Millisecond)
+	r.RecordDimensionUpdate(0, time.Second)
+	r.RecordMetricsPayload(2000, true)
+	r.RecordEventsPayload(100, false)
+
+	exporter := tag.Tag{Key: tagExporter, Value: "signalfx/test"}
+
+	rows, err := view.RetrieveData(mDatapointsFiltered.Name())
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	assert.ElementsMatch(t, []tag.Tag{exporter, {Key: tagFilter, Value: "exclude_metrics/0"}}, rows[0].Tags)
+	assert.Equal(t, float64(5), rows[0].Data.(*view.SumData).Value)
+
+	rows, err = view.RetrieveData(mTranslationRuleApplied.Name())
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	assert.ElementsMatch(t, []tag.Tag{exporter, {Key: tagRule, Value: "4"}, {Key: tagAction, Value: "rename_metrics"}}, rows[0].Tags)
+	assert.Equal(t, float64(7), rows[0].Data.(*view.SumData).Value)
+
+	rows, err = view.RetrieveData(mDimensionUpdateLatency.Name())
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+	latencies := map[string]float64{}
+	for _, row := range rows {
+		for _, tg := range row.Tags {
+			if tg.Key == tagStatus {
+				latencies[tg.Value] = row.Data.(*view.DistributionData).Mean
+			}
+		}
+	}
+	assert.Equal(t, map[string]float64{"200": 30, "error": 1000}, latencies)
+
+	rows, err = view.RetrieveData(mPayloadSize.Name())
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+	for _, row := range rows {
+		assert.Contains(t, row.Tags, exporter)
+		assert.Equal(t, int64(1), row.Data.(*view.DistributionData).Count)
+	}
+}
+
+func TestNilRecorder(t *testing.T) {
+	var r *Recorder
+	assert.NotPanics(t, func() {
+		r.RecordDatapointsFiltered("exclude_metrics/0", 1)
+		r.RecordTranslationRuleApplied(0, "rename_metrics", 1)
+		r.RecordDimensionUpdate(200, time.Millisecond)
+		r.RecordMetricsPayload(10, false)
+		r.RecordEventsPayload(10, false)
+	})
+}
@@ -0,0 +1,203 @@
+// Copyright 2021, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signalfxexporter
+
+import (
+	"context"
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+	"golang.org/x/time/rate"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/splunk"
+)
+
+const (
+	defaultRateLimitRecoveryTime = time.Minute
+
+	// minRateFactor is the lowest fraction of the configured rates the limiter
+	// backs off to after repeated throttling.
+	minRateFactor = 0.1
+)
+
+var errRateLimited = errors.New("client side rate limit exceeded")
+
+// rateLimiter limits the datapoints and requests sent per second. It backs off
+// once ingest throttles, pausing for the "Retry-After" duration and halving
+// its rates, then ramps back up to the configured rates over recoveryTime so
+// that data queued in the meantime isn't sent in one burst.
+type rateLimiter struct {
+	dpLimiter    *rate.Limiter
+	reqLimiter   *rate.Limiter
+	dpRate       float64
+	reqRate      float64
+	recoveryTime time.Duration
+	now          func() time.Time
+
+	mu          sync.Mutex
+	pausedUntil time.Time
+	factor      float64
+}
+
+func newRateLimiter(cfg *RateLimitConfig) *rateLimiter {
+	l := &rateLimiter{
+		dpRate:       cfg.DatapointsPerSecond,
+		reqRate:      cfg.RequestsPerSecond,
+		recoveryTime: cfg.RecoveryTime,
+		now:          time.Now,
+		factor:       1,
+	}
+	if l.recoveryTime == 0 {
+		l.recoveryTime = defaultRateLimitRecoveryTime
+	}
+	// Bursts are capped to one second worth of data.
+	if l.dpRate > 0 {
+		l.dpLimiter = rate.NewLimiter(rate.Limit(l.dpRate), burstFor(l.dpRate))
+	}
+	if l.reqRate > 0 {
+		l.reqLimiter = rate.NewLimiter(rate.Limit(l.reqRate), burstFor(l.reqRate))
+	}
+	return l
+}
+
+func burstFor(r float64) int {
+	return int(math.Max(1, math.Ceil(r)))
+}
+
+// wait blocks until a request carrying numDataPoints datapoints can be sent.
+// When ctx has a deadline that would be exceeded, it returns a throttle error
+// right away so that the retry settings take over.
+func (l *rateLimiter) wait(ctx context.Context, numDataPoints int) error {
+	l.mu.Lock()
+	now := l.now()
+	l.adjustRates(now)
+
+	start := now
+	if l.pausedUntil.After(now) {
+		start = l.pausedUntil
+	}
+
+	var reservations []*rate.Reservation
+	var delay time.Duration
+	reserve := func(lim *rate.Limiter, n int) {
+		r := lim.ReserveN(start, n)
+		reservations = append(reservations, r)
+		if d := r.DelayFrom(now); d > delay {
+			delay = d
+		}
+	}
+	if l.reqLimiter != nil {
+		reserve(l.reqLimiter, 1)
+	}
+	if l.dpLimiter != nil {
+		// Batches larger than the burst are reserved in chunks, each one
+		// pushing the time the batch can be sent further.
+		for remaining := numDataPoints; remaining > 0; remaining -= l.dpLimiter.Burst() {
+			reserve(l.dpLimiter, minInt(remaining, l.dpLimiter.Burst()))
+		}
+	}
+	if start.Sub(now) > delay {
+		delay = start.Sub(now)
+	}
+	l.mu.Unlock()
+
+	cancel := func() {
+		for i := len(reservations) - 1; i >= 0; i-- {
+			reservations[i].CancelAt(now)
+		}
+	}
+
+	if delay <= 0 {
+		return nil
+	}
+	if deadline, ok := ctx.Deadline(); ok && deadline.Sub(now) < delay {
+		cancel()
+		return exporterhelper.NewThrottleRetry(errRateLimited, delay)
+	}
+
+	t := time.NewTimer(delay)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		cancel()
+		return ctx.Err()
+	}
+}
+
+// observe backs off when the response indicates that ingest is throttling.
+func (l *rateLimiter) observe(resp *http.Response) {
+	if resp.StatusCode != http.StatusTooManyRequests && resp.StatusCode != http.StatusServiceUnavailable {
+		return
+	}
+
+	var retryAfter time.Duration
+	if val := resp.Header.Get(splunk.HeaderRetryAfter); val != "" {
+		if seconds, err := strconv.Atoi(val); err == nil && seconds > 0 {
+			retryAfter = time.Duration(seconds) * time.Second
+		}
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.factor = math.Max(l.currentFactor(now)/2, minRateFactor)
+	if until := now.Add(retryAfter); until.After(l.pausedUntil) {
+		l.pausedUntil = until
+	} else if l.pausedUntil.Before(now) {
+		l.pausedUntil = now
+	}
+	l.adjustRates(now)
+}
+
+// currentFactor returns the fraction of the configured rates allowed at the
+// given time, ramping back up linearly once the pause is over. It must be
+// called with the lock held.
+func (l *rateLimiter) currentFactor(now time.Time) float64 {
+	if l.factor >= 1 || now.Before(l.pausedUntil) {
+		return l.factor
+	}
+	recovered := float64(now.Sub(l.pausedUntil)) / float64(l.recoveryTime)
+	return math.Min(1, l.factor+(1-l.factor)*recovered)
+}
+
+// adjustRates applies the current factor to the limiters. It must be called
+// with the lock held.
+func (l *rateLimiter) adjustRates(now time.Time) {
+	f := l.currentFactor(now)
+	if f >= 1 {
+		l.factor = 1
+	}
+	if l.dpLimiter != nil {
+		l.dpLimiter.SetLimitAt(now, rate.Limit(l.dpRate*f))
+	}
+	if l.reqLimiter != nil {
+		l.reqLimiter.SetLimitAt(now, rate.Limit(l.reqRate*f))
+	}
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,115 @@
+// Copyright 2021, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signalfxexporter
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+)
+
+func throttledResponse(status int, retryAfter string) *http.Response {
+	resp := &http.Response{StatusCode: status, Header: http.Header{}}
+	if retryAfter != "" {
+		resp.Header.Set("Retry-After", retryAfter)
+	}
+	return resp
+}
+
+func TestRateLimiterWait(t *testing.T) {
+	l := newRateLimiter(&RateLimitConfig{DatapointsPerSecond: 10, RequestsPerSecond: 100})
+
+	// One second worth of datapoints is sent right away.
+	require.NoError(t, l.wait(context.Background(), 10))
+
+	// The next batch doesn't fit before the deadline.
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	err := l.wait(ctx, 10)
+	require.Error(t, err)
+	assert.Equal(t, exporterhelper.NewThrottleRetry(errRateLimited, time.Second).Error(), err.Error())
+
+	// Canceled reservations are given back: a small batch only waits for its share.
+	start := time.Now()
+	require.NoError(t, l.wait(context.Background(), 1))
+	assert.Less(t, int64(time.Since(start)), int64(500*time.Millisecond))
+}
+
+func TestRateLimiterWaitBatchLargerThanBurst(t *testing.T) {
+	l := newRateLimiter(&RateLimitConfig{DatapointsPerSecond: 10})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	err := l.wait(ctx, 25)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), errRateLimited.Error())
+}
+
+func TestRateLimiterWaitCanceled(t *testing.T) {
+	l := newRateLimiter(&RateLimitConfig{RequestsPerSecond: 1})
+	require.NoError(t, l.wait(context.Background(), 0))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.Equal(t, context.Canceled, l.wait(ctx, 0))
+}
+
+func TestRateLimiterObserve(t *testing.T) {
+	now := time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)
+	l := newRateLimiter(&RateLimitConfig{
+		DatapointsPerSecond: 1000,
+		RequestsPerSecond:   10,
+		RecoveryTime:        10 * time.Second,
+	})
+	l.now = func() time.Time { return now }
+
+	l.observe(throttledResponse(http.StatusOK, ""))
+	assert.Equal(t, 1.0, l.currentFactor(now))
+
+	l.observe(throttledResponse(http.StatusTooManyRequests, "2"))
+	assert.Equal(t, now.Add(2*time.Second), l.pausedUntil)
+	assert.Equal(t, 0.5, l.currentFactor(now))
+	assert.Equal(t, float64(500), float64(l.dpLimiter.Limit()))
+	assert.Equal(t, float64(5), float64(l.reqLimiter.Limit()))
+
+	// Requests wait for the pause to be over.
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	assert.Error(t, l.wait(ctx, 1))
+
+	// Rates ramp back up linearly once the pause is over.
+	assert.Equal(t, 0.5, l.currentFactor(now.Add(2*time.Second)))
+	assert.Equal(t, 0.75, l.currentFactor(now.Add(7*time.Second)))
+	assert.Equal(t, 1.0, l.currentFactor(now.Add(12*time.Second)))
+	assert.Equal(t, 1.0, l.currentFactor(now.Add(time.Hour)))
+}
+
+func TestRateLimiterObserveRepeatedThrottling(t *testing.T) {
+	now := time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)
+	l := newRateLimiter(&RateLimitConfig{DatapointsPerSecond: 1000})
+	l.now = func() time.Time { return now }
+
+	for i := 0; i < 10; i++ {
+		l.observe(throttledResponse(http.StatusServiceUnavailable, "invalid"))
+	}
+	assert.Equal(t, now, l.pausedUntil)
+	assert.Equal(t, minRateFactor, l.currentFactor(now))
+	assert.Equal(t, float64(100), float64(l.dpLimiter.Limit()))
+}
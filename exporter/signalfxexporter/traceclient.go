@@ -0,0 +1,151 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signalfxexporter
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"path"
+	"strings"
+
+	splunksapm "github.com/signalfx/sapm-proto/gen"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/translator/trace/jaeger"
+	"go.opentelemetry.io/collector/translator/trace/zipkin"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/splunk"
+)
+
+const (
+	traceFormatSAPM   = "sapm"
+	traceFormatOTLP   = "otlp"
+	traceFormatZipkin = "zipkin"
+)
+
+// traceFormatPaths are the ingest paths of the trace formats.
+var traceFormatPaths = map[string]string{
+	traceFormatSAPM:   "v2/trace",
+	traceFormatOTLP:   "v2/trace/otlp",
+	traceFormatZipkin: "v2/trace",
+}
+
+// sfxTraceClient sends the traces to the SignalFx backend.
+type sfxTraceClient struct {
+	sfxClientBase
+	logger                 *zap.Logger
+	accessTokenPassthrough bool
+	format                 string
+}
+
+func (s *sfxTraceClient) pushTraceData(ctx context.Context, td pdata.Traces) error {
+	rss := td.ResourceSpans()
+	if rss.Len() == 0 {
+		return nil
+	}
+
+	// All spans in the pdata.Traces have the same access token because of the BatchPerResourceTraces.
+	accessToken := s.retrieveAccessToken(rss.At(0))
+
+	payload, contentType, err := s.encodeTraces(td)
+	if err != nil {
+		return consumererror.Permanent(err)
+	}
+
+	body, compressed, err := s.getReader(payload)
+	if err != nil {
+		return consumererror.Permanent(err)
+	}
+
+	traceURL := *s.ingestURL
+	if formatPath := traceFormatPaths[s.format]; !strings.HasSuffix(traceURL.Path, formatPath) {
+		traceURL.Path = path.Join(traceURL.Path, formatPath)
+	}
+	req, err := http.NewRequestWithContext(ctx, "POST", traceURL.String(), body)
+	if err != nil {
+		return consumererror.Permanent(err)
+	}
+
+	for k, v := range s.headers {
+		req.Header.Set(k, v)
+	}
+	req.Header.Set("Content-Type", contentType)
+
+	if s.accessTokenPassthrough && accessToken != "" {
+		req.Header.Set(splunk.SFxAccessTokenHeader, accessToken)
+	}
+
+	if compressed {
+		req.Header.Set("Content-Encoding", "gzip")
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+
+	io.Copy(ioutil.Discard, resp.Body)
+	resp.Body.Close()
+
+	return splunk.HandleHTTPCode(resp)
+}
+
+// encodeTraces serializes the traces in the configured format, without the access
+// token passed through, and returns them along with their content type.
+func (s *sfxTraceClient) encodeTraces(td pdata.Traces) ([]byte, string, error) {
+	// Exporters must not modify the incoming data, remove the token from a copy.
+	td = td.Clone()
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rss.At(i).Resource().Attributes().Delete(splunk.SFxAccessTokenLabel)
+	}
+
+	switch s.format {
+	case traceFormatOTLP:
+		payload, err := td.ToOtlpProtoBytes()
+		return payload, "application/x-protobuf", err
+	case traceFormatZipkin:
+		spans, err := zipkin.InternalTracesToZipkinSpans(td)
+		if err != nil {
+			return nil, "", err
+		}
+		payload, err := json.Marshal(spans)
+		return payload, "application/json", err
+	}
+
+	batches, err := jaeger.InternalTracesToJaegerProto(td)
+	if err != nil {
+		return nil, "", err
+	}
+	payload, err := (&splunksapm.PostSpansRequest{Batches: batches}).Marshal()
+	return payload, "application/x-protobuf", err
+}
+
+func (s *sfxTraceClient) retrieveAccessToken(rs pdata.ResourceSpans) string {
+	if !s.accessTokenPassthrough {
+		// Nothing to do if token is pass through not configured or resource is nil.
+		return ""
+	}
+
+	attrs := rs.Resource().Attributes()
+	if accessToken, ok := attrs.Get(splunk.SFxAccessTokenLabel); ok {
+		return accessToken.StringVal()
+	}
+	return ""
+}
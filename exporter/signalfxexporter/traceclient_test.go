@@ -0,0 +1,184 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signalfxexporter
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	splunksapm "github.com/signalfx/sapm-proto/gen"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/splunk"
+)
+
+func makeSampleTraces(accessToken string) pdata.Traces {
+	td := pdata.NewTraces()
+	rs := td.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().InsertString("service.name", "test-service")
+	if accessToken != "" {
+		rs.Resource().Attributes().InsertString(splunk.SFxAccessTokenLabel, accessToken)
+	}
+	span := rs.InstrumentationLibrarySpans().AppendEmpty().Spans().AppendEmpty()
+	span.SetName("test-span")
+	span.SetTraceID(pdata.NewTraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}))
+	span.SetSpanID(pdata.NewSpanID([8]byte{1, 2, 3, 4, 5, 6, 7, 8}))
+	return td
+}
+
+func TestPushTraceData(t *testing.T) {
+	tests := []struct {
+		format              string
+		path                string
+		contentType         string
+		passthrough         bool
+		expectedToken       string
+		checkPayloadDecoded func(t *testing.T, payload []byte)
+	}{
+		{
+			format:        traceFormatSAPM,
+			path:          "/v2/trace",
+			contentType:   "application/x-protobuf",
+			passthrough:   true,
+			expectedToken: "fromLabel",
+			checkPayloadDecoded: func(t *testing.T, payload []byte) {
+				var req splunksapm.PostSpansRequest
+				require.NoError(t, req.Unmarshal(payload))
+				require.Len(t, req.Batches, 1)
+				require.Len(t, req.Batches[0].Spans, 1)
+				assert.Equal(t, "test-span", req.Batches[0].Spans[0].OperationName)
+				for _, tag := range req.Batches[0].Process.Tags {
+					assert.NotEqual(t, splunk.SFxAccessTokenLabel, tag.Key)
+				}
+			},
+		},
+		{
+			format:        traceFormatOTLP,
+			path:          "/v2/trace/otlp",
+			contentType:   "application/x-protobuf",
+			passthrough:   false,
+			expectedToken: "fromHeaders",
+			checkPayloadDecoded: func(t *testing.T, payload []byte) {
+				td, err := pdata.TracesFromOtlpProtoBytes(payload)
+				require.NoError(t, err)
+				require.Equal(t, 1, td.SpanCount())
+				_, ok := td.ResourceSpans().At(0).Resource().Attributes().Get(splunk.SFxAccessTokenLabel)
+				assert.False(t, ok)
+			},
+		},
+		{
+			format:        traceFormatZipkin,
+			path:          "/v2/trace",
+			contentType:   "application/json",
+			passthrough:   true,
+			expectedToken: "fromLabel",
+			checkPayloadDecoded: func(t *testing.T, payload []byte) {
+				var spans []map[string]interface{}
+				require.NoError(t, json.Unmarshal(payload, &spans))
+				require.Len(t, spans, 1)
+				assert.Equal(t, "test-span", spans[0]["name"])
+				assert.NotContains(t, spans[0]["tags"], splunk.SFxAccessTokenLabel)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, tt.path, r.URL.Path)
+				assert.Equal(t, tt.contentType, r.Header.Get("Content-Type"))
+				assert.Equal(t, tt.expectedToken, r.Header.Get(splunk.SFxAccessTokenHeader))
+
+				body := r.Body
+				if r.Header.Get("Content-Encoding") == "gzip" {
+					gz, err := gzip.NewReader(r.Body)
+					require.NoError(t, err)
+					body = gz
+				}
+				payload, err := ioutil.ReadAll(body)
+				require.NoError(t, err)
+				tt.checkPayloadDecoded(t, payload)
+				w.WriteHeader(http.StatusAccepted)
+			}))
+			defer server.Close()
+
+			cfg := NewFactory().CreateDefaultConfig().(*Config)
+			cfg.AccessToken = "fromHeaders"
+			cfg.AccessTokenPassthrough = tt.passthrough
+			cfg.Traces.Format = tt.format
+			cfg.Realm = "us0"
+			cfg.Traces.IngestURL = server.URL
+
+			client, err := newTraceClient(cfg, zap.NewNop())
+			require.NoError(t, err)
+
+			td := makeSampleTraces("fromLabel")
+			require.NoError(t, client.pushTraceData(context.Background(), td))
+
+			// The incoming data must not be modified.
+			token, ok := td.ResourceSpans().At(0).Resource().Attributes().Get(splunk.SFxAccessTokenLabel)
+			assert.True(t, ok)
+			assert.Equal(t, "fromLabel", token.StringVal())
+		})
+	}
+}
+
+func TestPushTraceDataErrors(t *testing.T) {
+	tests := []struct {
+		name          string
+		status        int
+		wantPermanent bool
+	}{
+		{
+			name:          "bad request",
+			status:        http.StatusBadRequest,
+			wantPermanent: true,
+		},
+		{
+			name:          "service unavailable",
+			status:        http.StatusServiceUnavailable,
+			wantPermanent: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+
+			cfg := NewFactory().CreateDefaultConfig().(*Config)
+			cfg.AccessToken = "token"
+			cfg.Traces.Format = traceFormatOTLP
+			cfg.Realm = "us0"
+			cfg.Traces.IngestURL = server.URL
+
+			client, err := newTraceClient(cfg, zap.NewNop())
+			require.NoError(t, err)
+
+			err = client.pushTraceData(context.Background(), makeSampleTraces(""))
+			require.Error(t, err)
+			assert.Equal(t, tt.wantPermanent, consumererror.IsPermanent(err))
+		})
+	}
+}
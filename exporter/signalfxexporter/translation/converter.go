@@ -28,6 +28,7 @@ import (
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/signalfxexporter/observability"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/signalfxexporter/translation/dpfilters"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/splunk"
 )
@@ -55,6 +56,27 @@ type MetricsConverter struct {
 	metricTranslator   *MetricTranslator
 	filterSet          *dpfilters.FilterSet
 	datapointValidator *datapointValidator
+	recorder           *observability.Recorder
+	histogramQuantiles []float64
+}
+
+// MetricsConverterOption configures a MetricsConverter.
+type MetricsConverterOption func(*MetricsConverter)
+
+// WithRecorder makes the MetricsConverter record the datapoints dropped by each filter
+// and the datapoints each translation rule is applied to.
+func WithRecorder(recorder *observability.Recorder) MetricsConverterOption {
+	return func(c *MetricsConverter) {
+		c.recorder = recorder
+	}
+}
+
+// WithHistogramQuantiles makes the MetricsConverter translate the histograms to
+// the given quantiles, interpolated from the buckets, instead of a datapoint per bucket.
+func WithHistogramQuantiles(quantiles []float64) MetricsConverterOption {
+	return func(c *MetricsConverter) {
+		c.histogramQuantiles = quantiles
+	}
 }
 
 // NewMetricsConverter creates a MetricsConverter from the passed in logger and
@@ -65,17 +87,22 @@ func NewMetricsConverter(
 	t *MetricTranslator,
 	excludes []dpfilters.MetricFilter,
 	includes []dpfilters.MetricFilter,
-	nonAlphanumericDimChars string) (*MetricsConverter, error) {
+	nonAlphanumericDimChars string,
+	opts ...MetricsConverterOption) (*MetricsConverter, error) {
 	fs, err := dpfilters.NewFilterSet(excludes, includes)
 	if err != nil {
 		return nil, err
 	}
-	return &MetricsConverter{
+	c := &MetricsConverter{
 		logger:             logger,
 		metricTranslator:   t,
 		filterSet:          fs,
 		datapointValidator: newDatapointValidator(logger, nonAlphanumericDimChars),
-	}, nil
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c, nil
 }
 
 // MetricDataToSignalFxV2 converts the passed in MetricsData to SFx datapoints,
@@ -116,27 +143,38 @@ func (c *MetricsConverter) metricToSfxDataPoints(metric pdata.Metric, extraDimen
 	case pdata.MetricDataTypeDoubleSum:
 		dps = convertDoubleDatapoints(metric.DoubleSum().DataPoints(), basePoint, extraDimensions)
 	case pdata.MetricDataTypeIntHistogram:
-		dps = convertIntHistogram(metric.IntHistogram().DataPoints(), basePoint, extraDimensions)
+		dps = convertIntHistogram(metric.IntHistogram().DataPoints(), basePoint, extraDimensions, c.histogramQuantiles)
 	case pdata.MetricDataTypeHistogram:
-		dps = convertHistogram(metric.Histogram().DataPoints(), basePoint, extraDimensions)
+		dps = convertHistogram(metric.Histogram().DataPoints(), basePoint, extraDimensions, c.histogramQuantiles)
 	case pdata.MetricDataTypeSummary:
 		dps = convertSummaryDataPoints(metric.Summary().DataPoints(), metric.Name(), extraDimensions)
 	}
 
 	if c.metricTranslator != nil {
-		dps = c.metricTranslator.TranslateDataPoints(c.logger, dps)
+		dps = c.metricTranslator.translateDataPoints(c.logger, dps, c.recorder)
 	}
 
+	var filtered map[string]int
 	resultSliceLen := 0
 	for i, dp := range dps {
-		if !c.filterSet.Matches(dp) {
-			if resultSliceLen < i {
-				dps[resultSliceLen] = dp
+		if filter, matched := c.filterSet.MatchingFilter(dp); matched {
+			if c.recorder != nil {
+				if filtered == nil {
+					filtered = map[string]int{}
+				}
+				filtered[filter]++
 			}
-			resultSliceLen++
+			continue
+		}
+		if resultSliceLen < i {
+			dps[resultSliceLen] = dp
 		}
+		resultSliceLen++
 	}
 	dps = dps[:resultSliceLen]
+	for filter, count := range filtered {
+		c.recorder.RecordDatapointsFiltered(filter, count)
+	}
 	return dps
 }
 
@@ -298,7 +336,7 @@ func fromMetricDataTypeToMetricType(metric pdata.Metric) *sfxpb.MetricType {
 	return nil
 }
 
-func convertIntHistogram(histDPs pdata.IntHistogramDataPointSlice, basePoint *sfxpb.DataPoint, extraDims []*sfxpb.Dimension) []*sfxpb.DataPoint {
+func convertIntHistogram(histDPs pdata.IntHistogramDataPointSlice, basePoint *sfxpb.DataPoint, extraDims []*sfxpb.Dimension, quantiles []float64) []*sfxpb.DataPoint {
 	var out []*sfxpb.DataPoint
 
 	for i := 0; i < histDPs.Len(); i++ {
@@ -329,6 +367,12 @@ func convertIntHistogram(histDPs pdata.IntHistogramDataPointSlice, basePoint *sf
 			continue
 		}
 
+		if len(quantiles) > 0 {
+			dims := labelsToDimensions(histDP.LabelsMap(), extraDims)
+			out = append(out, histogramQuantileDataPoints(basePoint.Metric, ts, dims, bounds, counts, quantiles)...)
+			continue
+		}
+
 		for j, c := range counts {
 			bound := infinityBoundSFxDimValue
 			if j < len(bounds) {
@@ -353,7 +397,7 @@ func convertIntHistogram(histDPs pdata.IntHistogramDataPointSlice, basePoint *sf
 	return out
 }
 
-func convertHistogram(histDPs pdata.HistogramDataPointSlice, basePoint *sfxpb.DataPoint, extraDims []*sfxpb.Dimension) []*sfxpb.DataPoint {
+func convertHistogram(histDPs pdata.HistogramDataPointSlice, basePoint *sfxpb.DataPoint, extraDims []*sfxpb.Dimension, quantiles []float64) []*sfxpb.DataPoint {
 	var out []*sfxpb.DataPoint
 
 	for i := 0; i < histDPs.Len(); i++ {
@@ -384,6 +428,12 @@ func convertHistogram(histDPs pdata.HistogramDataPointSlice, basePoint *sfxpb.Da
 			continue
 		}
 
+		if len(quantiles) > 0 {
+			dims := labelsToDimensions(histDP.LabelsMap(), extraDims)
+			out = append(out, histogramQuantileDataPoints(basePoint.Metric, ts, dims, bounds, counts, quantiles)...)
+			continue
+		}
+
 		for j, c := range counts {
 			bound := infinityBoundSFxDimValue
 			if j < len(bounds) {
@@ -26,10 +26,13 @@ import (
 	sfxpb "github.com/signalfx/com_signalfx_metrics_protobuf/model"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
 	"go.opentelemetry.io/collector/consumer/pdata"
 	"go.opentelemetry.io/collector/translator/conventions"
 	"go.uber.org/zap"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/signalfxexporter/observability"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/signalfxexporter/translation/dpfilters"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/testing/util"
 )
@@ -846,6 +849,65 @@ func TestMetricDataToSignalFxV2WithTranslation(t *testing.T) {
 	assert.EqualValues(t, expected, c.MetricDataToSignalFxV2(rm))
 }
 
+func TestMetricDataToSignalFxV2WithRecorder(t *testing.T) {
+	views := observability.MetricViews()
+	require.NoError(t, view.Register(views...))
+	defer view.Unregister(views...)
+
+	translator, err := NewMetricTranslator([]Rule{
+		{
+			Action: ActionRenameMetrics,
+			Mapping: map[string]string{
+				"metric1": "metric1.renamed",
+			},
+		},
+		{
+			Action:      ActionDropMetrics,
+			MetricNames: map[string]bool{"metric2": true},
+		},
+		{
+			Action:  ActionRenameDimensionKeys,
+			Mapping: map[string]string{"unknown": "dim"},
+		},
+	}, 1)
+	require.NoError(t, err)
+
+	rm := pdata.NewResourceMetrics()
+	metrics := rm.InstrumentationLibraryMetrics().AppendEmpty().Metrics()
+	for _, name := range []string{"metric1", "metric2", "metric3"} {
+		md := metrics.AppendEmpty()
+		md.SetDataType(pdata.MetricDataTypeIntGauge)
+		md.SetName(name)
+		md.IntGauge().DataPoints().AppendEmpty().SetValue(1)
+		md.IntGauge().DataPoints().AppendEmpty().SetValue(2)
+	}
+
+	c, err := NewMetricsConverter(zap.NewNop(), translator,
+		[]dpfilters.MetricFilter{{Name: "no_metric3", MetricName: "metric3"}}, nil, "",
+		WithRecorder(observability.NewRecorder("signalfx/recorder")))
+	require.NoError(t, err)
+	dps := c.MetricDataToSignalFxV2(rm)
+	require.Len(t, dps, 2)
+
+	counts := func(name string, key tag.Key) map[string]float64 {
+		rows, err := view.RetrieveData(name)
+		require.NoError(t, err)
+		out := map[string]float64{}
+		for _, row := range rows {
+			for _, tg := range row.Tags {
+				if tg.Key == key {
+					out[tg.Value] = row.Data.(*view.SumData).Value
+				}
+			}
+		}
+		return out
+	}
+	assert.Equal(t, map[string]float64{"0": 2, "1": 2},
+		counts("signalfxexporter/translation_rule_datapoints", tag.MustNewKey("rule")))
+	assert.Equal(t, map[string]float64{"no_metric3": 2},
+		counts("signalfxexporter/datapoints_filtered", tag.MustNewKey("filter")))
+}
+
 func TestDimensionKeyCharsWithPeriod(t *testing.T) {
 	translator, err := NewMetricTranslator([]Rule{
 		{
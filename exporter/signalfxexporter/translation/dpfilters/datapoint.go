@@ -21,12 +21,15 @@ import (
 )
 
 type dataPointFilter struct {
+	// name identifies the filter in the metrics of the exporter.
+	name             string
 	metricFilter     *StringFilter
 	dimensionsFilter *dimensionsFilter
+	valueFilter      *valueFilter
 }
 
 // newDataPointFilter returns a new dataPointFilter filter with the given configuration.
-func newDataPointFilter(metricNames []string, dimSet map[string][]string) (*dataPointFilter, error) {
+func newDataPointFilter(metricNames []string, dimSet map[string][]string, values []string) (*dataPointFilter, error) {
 	var metricFilter *StringFilter
 	if len(metricNames) > 0 {
 		var err error
@@ -45,22 +48,35 @@ func newDataPointFilter(metricNames []string, dimSet map[string][]string) (*data
 		}
 	}
 
-	if metricFilter == nil && dimensionsFilter == nil {
-		return nil, errors.New("metric filter must have at least one metric or dimension defined on it")
+	var valueFilter *valueFilter
+	if len(values) > 0 {
+		var err error
+		valueFilter, err = newValueFilter(values)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if metricFilter == nil && dimensionsFilter == nil && valueFilter == nil {
+		return nil, errors.New("metric filter must have at least one metric, dimension or value defined on it")
 	}
 
 	return &dataPointFilter{
 		metricFilter:     metricFilter,
 		dimensionsFilter: dimensionsFilter,
+		valueFilter:      valueFilter,
 	}, nil
 }
 
 // Matches tests a datapoint to see whether it is excluded by this
 func (f *dataPointFilter) Matches(dp *sfxpb.DataPoint) bool {
 	metricNameMatched := f.metricFilter == nil || f.metricFilter.Matches(dp.Metric)
-	if metricNameMatched {
-		return f.dimensionsFilter == nil || f.dimensionsFilter.Matches(dp.Dimensions)
+	if !metricNameMatched {
+		return false
+	}
+	if f.dimensionsFilter != nil && !f.dimensionsFilter.Matches(dp.Dimensions) {
+		return false
 	}
-	return false
+	return f.valueFilter == nil || f.valueFilter.Matches(&dp.Value)
 
 }
@@ -121,6 +121,45 @@ func TestDimensionsFilter(t *testing.T) {
 			},
 			shouldMatch: false,
 		},
+		{
+			name: "Filter with only negated values does not match other values",
+			filter: map[string][]string{
+				"env": {"!prod", "!/^staging-.*/"},
+			},
+			input: []*sfxpb.Dimension{
+				{
+					Key:   "env",
+					Value: "dev",
+				},
+			},
+			shouldMatch: false,
+		},
+		{
+			name: "Filter with wildcard and negated values matches other values",
+			filter: map[string][]string{
+				"env": {"*", "!prod", "!/^staging-.*/"},
+			},
+			input: []*sfxpb.Dimension{
+				{
+					Key:   "env",
+					Value: "dev",
+				},
+			},
+			shouldMatch: true,
+		},
+		{
+			name: "Filter with wildcard and negated values does not match negated value",
+			filter: map[string][]string{
+				"env": {"*", "!prod", "!/^staging-.*/"},
+			},
+			input: []*sfxpb.Dimension{
+				{
+					Key:   "env",
+					Value: "staging-eu",
+				},
+			},
+			shouldMatch: false,
+		},
 		{
 			name: "Error creating filter with no dimension values",
 			filter: map[string][]string{
@@ -14,7 +14,11 @@
 
 package dpfilters
 
-import sfxpb "github.com/signalfx/com_signalfx_metrics_protobuf/model"
+import (
+	"strconv"
+
+	sfxpb "github.com/signalfx/com_signalfx_metrics_protobuf/model"
+)
 
 // FilterSet is a collection of datapont filters, any one of which must match
 // for a datapoint to be matched.
@@ -26,28 +30,35 @@ type FilterSet struct {
 // Matches sends a datapoint through each of the filters in the set and returns
 // true if at least one of them matches the datapoint.
 func (fs *FilterSet) Matches(dp *sfxpb.DataPoint) bool {
+	_, matched := fs.MatchingFilter(dp)
+	return matched
+}
+
+// MatchingFilter returns the name of the exclusionary filter matching the datapoint,
+// if the datapoint is matched.
+func (fs *FilterSet) MatchingFilter(dp *sfxpb.DataPoint) (string, bool) {
 	for _, ex := range fs.excludeFilters {
 		if ex.Matches(dp) {
 			// If we match an exclusionary filter, run through each inclusion
 			// filter and see if anything includes the metrics.
 			for _, in := range fs.includeFilters {
 				if in.Matches(dp) {
-					return false
+					return "", false
 				}
 			}
-			return true
+			return ex.name, true
 		}
 	}
-	return false
+	return "", false
 }
 
 func NewFilterSet(excludes []MetricFilter, includes []MetricFilter) (*FilterSet, error) {
-	excludeSet, err := getDataPointFilters(excludes)
+	excludeSet, err := getDataPointFilters("exclude_metrics", excludes)
 	if err != nil {
 		return nil, err
 	}
 
-	includeSet, err := getDataPointFilters(includes)
+	includeSet, err := getDataPointFilters("include_metrics", includes)
 	if err != nil {
 		return nil, err
 	}
@@ -58,18 +69,22 @@ func NewFilterSet(excludes []MetricFilter, includes []MetricFilter) (*FilterSet,
 	}, nil
 }
 
-func getDataPointFilters(metricFilters []MetricFilter) ([]*dataPointFilter, error) {
+func getDataPointFilters(kind string, metricFilters []MetricFilter) ([]*dataPointFilter, error) {
 	var out []*dataPointFilter
-	for _, f := range metricFilters {
+	for i, f := range metricFilters {
 		dimSet, err := f.normalize()
 		if err != nil {
 			return nil, err
 		}
 
-		dpf, err := newDataPointFilter(f.MetricNames, dimSet)
+		dpf, err := newDataPointFilter(f.MetricNames, dimSet, f.Values)
 		if err != nil {
 			return nil, err
 		}
+		dpf.name = f.Name
+		if dpf.name == "" {
+			dpf.name = kind + "/" + strconv.Itoa(i)
+		}
 
 		out = append(out, dpf)
 	}
@@ -339,17 +339,102 @@ func TestFilterSet(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Match based on datapoint value",
+			excludes: []MetricFilter{{
+				MetricNames: []string{`/^k8s\./`},
+				Values:      []string{"==0"},
+			}},
+			expectedMatches: []*sfxpb.DataPoint{
+				{
+					Metric: "k8s.pod.restarts",
+					Value:  sfxpb.Datum{IntValue: newInt(0)},
+				},
+			},
+			expectedNonMatches: []*sfxpb.DataPoint{
+				{
+					Metric: "k8s.pod.restarts",
+					Value:  sfxpb.Datum{IntValue: newInt(1)},
+				},
+				{
+					Metric: "cpu.utilization",
+					Value:  sfxpb.Datum{IntValue: newInt(0)},
+				},
+			},
+		},
+		{
+			name: "Match based on datapoint value only",
+			excludes: []MetricFilter{{
+				Values: []string{"<0", ">1e6"},
+			}},
+			expectedMatches: []*sfxpb.DataPoint{
+				{
+					Metric: "cpu.utilization",
+					Value:  sfxpb.Datum{DoubleValue: newDouble(-0.5)},
+				},
+				{
+					Metric: "memory.utilization",
+					Value:  sfxpb.Datum{DoubleValue: newDouble(2e6)},
+				},
+			},
+			expectedNonMatches: []*sfxpb.DataPoint{
+				{
+					Metric: "cpu.utilization",
+					Value:  sfxpb.Datum{DoubleValue: newDouble(0.5)},
+				},
+				{
+					Metric: "cpu.utilization",
+					Value:  sfxpb.Datum{StrValue: newString("-1")},
+				},
+			},
+		},
+		{
+			name: "Match based on dimension and datapoint value",
+			excludes: []MetricFilter{{
+				Dimensions: map[string]interface{}{
+					"container_name": []interface{}{"*", "!pause"},
+				},
+				Values: []string{"0"},
+			}},
+			expectedMatches: []*sfxpb.DataPoint{
+				{
+					Metric:     "cpu.utilization",
+					Dimensions: []*sfxpb.Dimension{{Key: "container_name", Value: "mycontainer"}},
+					Value:      sfxpb.Datum{IntValue: newInt(0)},
+				},
+			},
+			expectedNonMatches: []*sfxpb.DataPoint{
+				{
+					Metric:     "cpu.utilization",
+					Dimensions: []*sfxpb.Dimension{{Key: "container_name", Value: "pause"}},
+					Value:      sfxpb.Datum{IntValue: newInt(0)},
+				},
+				{
+					Metric:     "cpu.utilization",
+					Dimensions: []*sfxpb.Dimension{{Key: "container_name", Value: "mycontainer"}},
+					Value:      sfxpb.Datum{IntValue: newInt(3)},
+				},
+			},
+		},
+		{
+			name: "Error on invalid value comparison",
+			excludes: []MetricFilter{{
+				Values: []string{"=>0"},
+			}},
+			wantErr:    true,
+			wantErrMsg: `invalid value comparison "=>0"`,
+		},
 		{
 			name:       "Error creating exclude empty filter",
 			excludes:   []MetricFilter{{}},
 			wantErr:    true,
-			wantErrMsg: "metric filter must have at least one metric or dimension defined on it",
+			wantErrMsg: "metric filter must have at least one metric, dimension or value defined on it",
 		},
 		{
 			name:       "Error creating include empty filter",
 			includes:   []MetricFilter{{}},
 			wantErr:    true,
-			wantErrMsg: "metric filter must have at least one metric or dimension defined on it",
+			wantErrMsg: "metric filter must have at least one metric, dimension or value defined on it",
 		},
 		{
 			name: "Error creating filter with empty dimension list",
@@ -416,3 +501,40 @@ func TestFilterSet(t *testing.T) {
 		})
 	}
 }
+
+func TestFilterSetMatchingFilter(t *testing.T) {
+	f, err := NewFilterSet(
+		[]MetricFilter{
+			{MetricName: "cpu.utilization"},
+			{Name: "no_memory", MetricNames: []string{"memory.*"}},
+		},
+		[]MetricFilter{{MetricName: "memory.free"}},
+	)
+	require.NoError(t, err)
+
+	name, matched := f.MatchingFilter(&sfxpb.DataPoint{Metric: "cpu.utilization"})
+	require.True(t, matched)
+	require.Equal(t, "exclude_metrics/0", name)
+
+	name, matched = f.MatchingFilter(&sfxpb.DataPoint{Metric: "memory.used"})
+	require.True(t, matched)
+	require.Equal(t, "no_memory", name)
+
+	_, matched = f.MatchingFilter(&sfxpb.DataPoint{Metric: "memory.free"})
+	require.False(t, matched)
+
+	_, matched = f.MatchingFilter(&sfxpb.DataPoint{Metric: "disk.utilization"})
+	require.False(t, matched)
+}
+
+func newInt(v int64) *int64 {
+	return &v
+}
+
+func newDouble(v float64) *float64 {
+	return &v
+}
+
+func newString(v string) *string {
+	return &v
+}
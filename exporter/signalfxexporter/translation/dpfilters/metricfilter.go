@@ -17,14 +17,21 @@ package dpfilters
 import "fmt"
 
 type MetricFilter struct {
+	// An optional name identifying the filter in the metrics of the exporter.
+	// Defaults to the position of the filter, e.g. "exclude_metrics/0".
+	Name string `mapstructure:"name"`
 	// A single metric name to match against.
 	MetricName string `mapstructure:"metric_name"`
 	// A list of metric names to match against.
 	MetricNames []string `mapstructure:"metric_names"`
 	// A map of dimension key/values to match against. All key/values must
 	// match a datapoint for it to be matched. The map values can be either
-	// a single string or a list of strings.
+	// a single string or a list of strings. A list of only negated values
+	// matches any other value of the dimension.
 	Dimensions map[string]interface{} `mapstructure:"dimensions"`
+	// A list of comparisons of the datapoint value to match against, e.g.
+	// "==0", ">=100" or "<0.5". The value must satisfy at least one of them.
+	Values []string `mapstructure:"values"`
 }
 
 func (mf *MetricFilter) normalize() (map[string][]string, error) {
@@ -0,0 +1,99 @@
+// Copyright 2021, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dpfilters
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	sfxpb "github.com/signalfx/com_signalfx_metrics_protobuf/model"
+)
+
+// valueFilter will match if the numeric value of a datapoint satisfies any
+// one of the given comparisons.
+type valueFilter struct {
+	comparisons []valueComparison
+}
+
+type valueComparison struct {
+	operator string
+	value    float64
+}
+
+// Operators are ordered so that the longest ones are tried first.
+var valueOperators = []string{"==", "!=", ">=", "<=", ">", "<"}
+
+// newValueFilter returns a filter matching datapoint values against the given
+// comparisons, e.g. "==0", ">=100" or "<0.5". A bare number is an equality.
+func newValueFilter(items []string) (*valueFilter, error) {
+	var comparisons []valueComparison
+	for _, item := range items {
+		s := strings.TrimSpace(item)
+		operator := "=="
+		for _, op := range valueOperators {
+			if strings.HasPrefix(s, op) {
+				operator = op
+				s = strings.TrimSpace(s[len(op):])
+				break
+			}
+		}
+		v, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value comparison %q", item)
+		}
+		comparisons = append(comparisons, valueComparison{operator: operator, value: v})
+	}
+	return &valueFilter{comparisons: comparisons}, nil
+}
+
+// Matches returns true if the datapoint has a numeric value satisfying at
+// least one of the comparisons.
+func (f *valueFilter) Matches(datum *sfxpb.Datum) bool {
+	var v float64
+	switch {
+	case datum == nil:
+		return false
+	case datum.IntValue != nil:
+		v = float64(*datum.IntValue)
+	case datum.DoubleValue != nil:
+		v = *datum.DoubleValue
+	default:
+		return false
+	}
+
+	for _, c := range f.comparisons {
+		if c.matches(v) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c valueComparison) matches(v float64) bool {
+	switch c.operator {
+	case "!=":
+		return v != c.value
+	case ">=":
+		return v >= c.value
+	case "<=":
+		return v <= c.value
+	case ">":
+		return v > c.value
+	case "<":
+		return v < c.value
+	}
+	return v == c.value
+}
@@ -0,0 +1,82 @@
+// Copyright 2021, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dpfilters
+
+import (
+	"testing"
+
+	sfxpb "github.com/signalfx/com_signalfx_metrics_protobuf/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValueFilter(t *testing.T) {
+	tests := []struct {
+		name        string
+		filter      []string
+		inputs      []sfxpb.Datum
+		shouldMatch []bool
+		shouldError bool
+	}{
+		{
+			name:        "bare number is an equality",
+			filter:      []string{"0"},
+			inputs:      []sfxpb.Datum{{IntValue: newInt(0)}, {DoubleValue: newDouble(0)}, {IntValue: newInt(1)}},
+			shouldMatch: []bool{true, true, false},
+		},
+		{
+			name:        "inequality",
+			filter:      []string{"!= 0"},
+			inputs:      []sfxpb.Datum{{IntValue: newInt(0)}, {DoubleValue: newDouble(0.1)}},
+			shouldMatch: []bool{false, true},
+		},
+		{
+			name:        "range",
+			filter:      []string{"<10", ">=100"},
+			inputs:      []sfxpb.Datum{{IntValue: newInt(9)}, {IntValue: newInt(10)}, {IntValue: newInt(99)}, {IntValue: newInt(100)}},
+			shouldMatch: []bool{true, false, false, true},
+		},
+		{
+			name:        "bounds",
+			filter:      []string{"<=-1.5", "> 2"},
+			inputs:      []sfxpb.Datum{{DoubleValue: newDouble(-1.5)}, {DoubleValue: newDouble(2)}, {DoubleValue: newDouble(2.5)}},
+			shouldMatch: []bool{true, false, true},
+		},
+		{
+			name:        "non numeric values never match",
+			filter:      []string{"!=0"},
+			inputs:      []sfxpb.Datum{{StrValue: newString("up")}, {}},
+			shouldMatch: []bool{false, false},
+		},
+		{
+			name:        "invalid number",
+			filter:      []string{">zero"},
+			shouldError: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			f, err := newValueFilter(test.filter)
+			if test.shouldError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			for i := range test.inputs {
+				assert.Equal(t, test.shouldMatch[i], f.Matches(&test.inputs[i]))
+			}
+		})
+	}
+}
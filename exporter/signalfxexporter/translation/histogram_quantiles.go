@@ -0,0 +1,93 @@
+// Copyright OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translation
+
+import (
+	"strconv"
+
+	sfxpb "github.com/signalfx/com_signalfx_metrics_protobuf/model"
+)
+
+// histogramQuantileDataPoints returns a "<name>_quantile" gauge, with a "quantile" dimension like
+// the ones of the summaries, for each of the given quantiles of the histogram.
+func histogramQuantileDataPoints(
+	name string,
+	ts int64,
+	dims []*sfxpb.Dimension,
+	bounds []float64,
+	counts []uint64,
+	quantiles []float64,
+) []*sfxpb.DataPoint {
+	var total uint64
+	for _, c := range counts {
+		total += c
+	}
+	if total == 0 {
+		return nil
+	}
+
+	out := make([]*sfxpb.DataPoint, 0, len(quantiles))
+	for _, q := range quantiles {
+		qDims := make([]*sfxpb.Dimension, len(dims), len(dims)+1)
+		copy(qDims, dims)
+		qDims = append(qDims, &sfxpb.Dimension{
+			Key:   "quantile",
+			Value: strconv.FormatFloat(q, 'f', -1, 64),
+		})
+		v := histogramQuantile(q, bounds, counts, total)
+		dp := &sfxpb.DataPoint{
+			Metric:     name + "_quantile",
+			Timestamp:  ts,
+			Dimensions: qDims,
+			MetricType: &sfxMetricTypeGauge,
+		}
+		dp.Value.DoubleValue = &v
+		out = append(out, dp)
+	}
+	return out
+}
+
+// histogramQuantile estimates the quantile q of the histogram by linear interpolation within
+// the bucket holding it, the same way Prometheus' histogram_quantile does: the lower bound of
+// the first bucket is assumed to be 0 if its upper bound is positive, and the quantiles falling
+// into the overflow bucket are estimated to the highest explicit bound.
+func histogramQuantile(q float64, bounds []float64, counts []uint64, total uint64) float64 {
+	rank := q * float64(total)
+	var cumulative uint64
+	for i, c := range counts {
+		prev := cumulative
+		cumulative += c
+		if float64(cumulative) < rank || c == 0 {
+			continue
+		}
+		if i == len(bounds) {
+			break
+		}
+
+		upper := bounds[i]
+		lower := 0.0
+		if i > 0 {
+			lower = bounds[i-1]
+		} else if upper <= 0 {
+			return upper
+		}
+		return lower + (upper-lower)*(rank-float64(prev))/float64(c)
+	}
+
+	if len(bounds) == 0 {
+		return 0
+	}
+	return bounds[len(bounds)-1]
+}
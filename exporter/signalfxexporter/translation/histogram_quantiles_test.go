@@ -0,0 +1,99 @@
+// Copyright OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translation
+
+import (
+	"testing"
+
+	sfxpb "github.com/signalfx/com_signalfx_metrics_protobuf/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.uber.org/zap"
+)
+
+func TestHistogramQuantile(t *testing.T) {
+	bounds := []float64{1, 2, 4}
+	counts := []uint64{4, 2, 3, 7}
+	tests := []struct {
+		name   string
+		q      float64
+		bounds []float64
+		counts []uint64
+		want   float64
+	}{
+		{name: "min", q: 0, bounds: bounds, counts: counts, want: 0},
+		{name: "first-bucket", q: 0.1, bounds: bounds, counts: counts, want: 0.4},
+		{name: "median", q: 0.5, bounds: bounds, counts: counts, want: 2 + 2*2.0/3},
+		{name: "overflow-bucket", q: 0.99, bounds: bounds, counts: counts, want: 4},
+		{name: "non-positive-first-bound", q: 0.25, bounds: []float64{-1, 0}, counts: []uint64{2, 2, 0}, want: -1},
+		{name: "no-bounds", q: 0.5, bounds: nil, counts: []uint64{3}, want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var total uint64
+			for _, c := range tt.counts {
+				total += c
+			}
+			assert.InDelta(t, tt.want, histogramQuantile(tt.q, tt.bounds, tt.counts, total), 1e-9)
+		})
+	}
+}
+
+func TestMetricDataToSignalFxV2WithHistogramQuantiles(t *testing.T) {
+	md := pdata.NewMetrics()
+	m := md.ResourceMetrics().AppendEmpty().InstrumentationLibraryMetrics().AppendEmpty().Metrics().AppendEmpty()
+	m.SetName("latency")
+	m.SetDataType(pdata.MetricDataTypeHistogram)
+	m.Histogram().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+	dp := m.Histogram().DataPoints().AppendEmpty()
+	dp.SetTimestamp(pdata.Timestamp(100 * 1e6))
+	dp.SetCount(16)
+	dp.SetSum(100)
+	dp.SetExplicitBounds([]float64{1, 2, 4})
+	dp.SetBucketCounts([]uint64{4, 2, 3, 7})
+	dp.LabelsMap().Insert("k0", "v0")
+
+	c, err := NewMetricsConverter(zap.NewNop(), nil, nil, nil, "", WithHistogramQuantiles([]float64{0.5, 0.99}))
+	require.NoError(t, err)
+	dps := c.MetricDataToSignalFxV2(md.ResourceMetrics().At(0))
+	require.Len(t, dps, 4)
+
+	assert.Equal(t, "latency_count", dps[0].Metric)
+	assert.Equal(t, int64(16), dps[0].Value.GetIntValue())
+	assert.Equal(t, "latency", dps[1].Metric)
+	assert.Equal(t, 100.0, dps[1].Value.GetDoubleValue())
+
+	for i, want := range []struct {
+		quantile string
+		value    float64
+	}{{"0.5", 2 + 2*2.0/3}, {"0.99", 4}} {
+		pt := dps[i+2]
+		assert.Equal(t, "latency_quantile", pt.Metric)
+		assert.Equal(t, sfxpb.MetricType_GAUGE, *pt.MetricType)
+		assert.Equal(t, int64(100), pt.Timestamp)
+		assert.InDelta(t, want.value, pt.Value.GetDoubleValue(), 1e-9)
+		assert.Equal(t, []*sfxpb.Dimension{
+			{Key: "k0", Value: "v0"},
+			{Key: "quantile", Value: want.quantile},
+		}, pt.Dimensions)
+	}
+
+	// No quantile is sent for an empty histogram.
+	dp.SetCount(0)
+	dp.SetSum(0)
+	dp.SetBucketCounts([]uint64{0, 0, 0, 0})
+	assert.Len(t, c.MetricDataToSignalFxV2(md.ResourceMetrics().At(0)), 2)
+}
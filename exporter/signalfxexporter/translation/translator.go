@@ -23,6 +23,7 @@ import (
 	sfxpb "github.com/signalfx/com_signalfx_metrics_protobuf/model"
 	"go.uber.org/zap"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/signalfxexporter/observability"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/signalfxexporter/translation/dpfilters"
 )
 
@@ -404,20 +405,32 @@ func getMetricNamesAsSlice(metricName string, metricNames map[string]bool) []str
 // TranslateDataPoints transforms datapoints to a format compatible with signalfx backend
 // sfxDataPoints represents one metric converted to signalfx protobuf datapoints
 func (mp *MetricTranslator) TranslateDataPoints(logger *zap.Logger, sfxDataPoints []*sfxpb.DataPoint) []*sfxpb.DataPoint {
+	return mp.translateDataPoints(logger, sfxDataPoints, nil)
+}
+
+// translateDataPoints transforms the datapoints and records the number of datapoints
+// each translation rule was applied to.
+func (mp *MetricTranslator) translateDataPoints(logger *zap.Logger, sfxDataPoints []*sfxpb.DataPoint, recorder *observability.Recorder) []*sfxpb.DataPoint {
 	processedDataPoints := sfxDataPoints
 
-	for _, tr := range mp.rules {
+	for i, tr := range mp.rules {
+		applied := 0
 		switch tr.Action {
 		case ActionRenameDimensionKeys:
 			for _, dp := range processedDataPoints {
 				if len(tr.MetricNames) > 0 && !tr.MetricNames[dp.Metric] {
 					continue
 				}
+				renamed := false
 				for _, d := range dp.Dimensions {
 					if newKey, ok := tr.Mapping[d.Key]; ok {
 						d.Key = newKey
+						renamed = true
 					}
 				}
+				if renamed {
+					applied++
+				}
 			}
 		case ActionRenameMetrics:
 			var additionalDimensions []*sfxpb.Dimension
@@ -430,6 +443,7 @@ func (mp *MetricTranslator) TranslateDataPoints(logger *zap.Logger, sfxDataPoint
 			for _, dp := range processedDataPoints {
 				if newKey, ok := tr.Mapping[dp.Metric]; ok {
 					dp.Metric = newKey
+					applied++
 					if tr.CopyDimensions != nil {
 						for _, d := range dp.Dimensions {
 							if k, ok := tr.CopyDimensions[d.Key]; ok {
@@ -449,6 +463,7 @@ func (mp *MetricTranslator) TranslateDataPoints(logger *zap.Logger, sfxDataPoint
 					v := dp.GetValue().IntValue
 					if v != nil {
 						*v = *v * multiplier
+						applied++
 					}
 				}
 			}
@@ -458,6 +473,7 @@ func (mp *MetricTranslator) TranslateDataPoints(logger *zap.Logger, sfxDataPoint
 					v := dp.GetValue().IntValue
 					if v != nil {
 						*v = *v / divisor
+						applied++
 					}
 				}
 			}
@@ -467,6 +483,7 @@ func (mp *MetricTranslator) TranslateDataPoints(logger *zap.Logger, sfxDataPoint
 					v := dp.GetValue().DoubleValue
 					if v != nil {
 						*v = *v * multiplier
+						applied++
 					}
 				}
 			}
@@ -476,6 +493,7 @@ func (mp *MetricTranslator) TranslateDataPoints(logger *zap.Logger, sfxDataPoint
 					newDataPoint := copyMetric(tr, dp, newMetric)
 					if newDataPoint != nil {
 						processedDataPoints = append(processedDataPoints, newDataPoint)
+						applied++
 					}
 				}
 			}
@@ -483,12 +501,14 @@ func (mp *MetricTranslator) TranslateDataPoints(logger *zap.Logger, sfxDataPoint
 			for _, dp := range processedDataPoints {
 				if tr.MetricName == dp.Metric {
 					splitMetric(dp, tr.DimensionKey, tr.Mapping)
+					applied++
 				}
 			}
 		case ActionConvertValues:
 			for _, dp := range processedDataPoints {
 				if newType, ok := tr.TypesMapping[dp.Metric]; ok {
 					convertMetricValue(logger, dp, newType)
+					applied++
 				}
 			}
 		case ActionCalculateNewMetric:
@@ -499,6 +519,7 @@ func (mp *MetricTranslator) TranslateDataPoints(logger *zap.Logger, sfxDataPoint
 					continue
 				}
 				processedDataPoints = append(processedDataPoints, newPt)
+				applied++
 			}
 
 		case ActionAggregateMetric:
@@ -521,6 +542,7 @@ func (mp *MetricTranslator) TranslateDataPoints(logger *zap.Logger, sfxDataPoint
 					otherDps = append(otherDps, dp)
 				}
 			}
+			applied = len(dpsToAggregate)
 			aggregatedDps := aggregateDatapoints(dpsToAggregate, tr.WithoutDimensions, tr.AggregationMethod)
 			processedDataPoints = append(otherDps, aggregatedDps...)
 
@@ -534,16 +556,23 @@ func (mp *MetricTranslator) TranslateDataPoints(logger *zap.Logger, sfxDataPoint
 					resultSliceLen++
 				}
 			}
+			applied = len(processedDataPoints) - resultSliceLen
 			processedDataPoints = processedDataPoints[:resultSliceLen]
 
 		case ActionDeltaMetric:
-			processedDataPoints = mp.deltaTranslator.translate(processedDataPoints, tr)
+			// The rule applies to the datapoints a delta could be computed for.
+			translated := mp.deltaTranslator.translate(processedDataPoints, tr)
+			applied = len(translated) - len(processedDataPoints)
+			processedDataPoints = translated
 
 		case ActionDropDimensions:
 			for _, dp := range processedDataPoints {
-				dropDimensions(dp, tr)
+				if dropDimensions(dp, tr) {
+					applied++
+				}
 			}
 		}
+		recorder.RecordTranslationRuleApplied(i, string(tr.Action), applied)
 	}
 
 	return processedDataPoints
@@ -850,15 +879,19 @@ func copyMetric(tr Rule, dp *sfxpb.DataPoint, newMetricName string) *sfxpb.DataP
 	return newDataPoint
 }
 
-func dropDimensions(dp *sfxpb.DataPoint, rule Rule) {
+// dropDimensions drops the dimensions of the datapoint matching the rule and returns
+// whether any was dropped.
+func dropDimensions(dp *sfxpb.DataPoint, rule Rule) bool {
 	if rule.metricMatcher != nil && !rule.metricMatcher.Matches(dp.Metric) {
-		return
+		return false
 	}
 	processedDimensions := filterDimensionsByValues(dp.Dimensions, rule.DimensionPairs)
 	if processedDimensions == nil {
-		return
+		return false
 	}
+	dropped := len(processedDimensions) < len(dp.Dimensions)
 	dp.Dimensions = processedDimensions
+	return dropped
 }
 
 func filterDimensionsByValues(
@@ -38,6 +38,7 @@ import (
 type client struct {
 	config  *Config
 	url     *url.URL
+	rawURL  *url.URL
 	client  *http.Client
 	logger  *zap.Logger
 	zippers sync.Pool
@@ -60,6 +61,13 @@ func (c *client) pushMetricsData(
 		return nil
 	}
 
+	if c.config.UseMultiMetricFormat {
+		var err error
+		if splunkDataPoints, err = mergeEventsToMultiMetricFormat(splunkDataPoints); err != nil {
+			return consumererror.Permanent(err)
+		}
+	}
+
 	body, compressed, err := encodeBodyEvents(&c.zippers, splunkDataPoints, c.config.DisableCompression)
 	if err != nil {
 		return consumererror.Permanent(err)
@@ -139,10 +147,10 @@ func (c *client) pushLogData(ctx context.Context, ld pdata.Logs) error {
 				return fmt.Errorf("failed flushing compressed data to gzip writer: %v", err)
 			}
 
-			return c.postEvents(ctx, gzipBuffer, shouldCompress)
+			return c.postLogs(ctx, gzipBuffer, shouldCompress)
 		}
 
-		return c.postEvents(ctx, buf, shouldCompress)
+		return c.postLogs(ctx, buf, shouldCompress)
 	}
 
 	return c.pushLogDataInBatches(ctx, ld, send)
@@ -183,14 +191,11 @@ func (c *client) pushLogDataInBatches(ctx context.Context, ld pdata.Logs, send f
 					bufFront = &logIndex{resource: i, library: j, record: k}
 				}
 
-				// Parsing log record to Splunk event.
-				event := mapLogRecordToSplunkEvent(res, logs.At(k), c.config, c.logger)
-				// JSON encoding event and writing to buffer.
-				if err := encoder.Encode(event); err != nil {
-					permanentErrors = append(permanentErrors, consumererror.Permanent(fmt.Errorf("dropped log event: %v, error: %v", event, err)))
+				// Writing the log record to buffer.
+				if err := c.writeLogRecord(buf, encoder, res, logs.At(k)); err != nil {
+					permanentErrors = append(permanentErrors, consumererror.Permanent(err))
 					continue
 				}
-				buf.WriteString("\r\n\r\n")
 
 				// Continue adding events to buffer up to capacity.
 				// 0 capacity is interpreted as unknown/unbound consistent with ContentLength in http.Request.
@@ -237,8 +242,49 @@ func (c *client) pushLogDataInBatches(ctx context.Context, ld pdata.Logs, send f
 	return consumererror.Combine(permanentErrors)
 }
 
+// writeLogRecord writes the log record to buf, either as a JSON encoded Splunk event or,
+// when exported raw, as its body followed by a line break.
+func (c *client) writeLogRecord(buf *bytes.Buffer, encoder *json.Encoder, res pdata.Resource, lr pdata.LogRecord) error {
+	if c.config.ExportRaw {
+		body := lr.Body()
+		if body.Type() != pdata.AttributeValueTypeString {
+			// JSON encoding the body, the encoder terminates the line.
+			if err := encoder.Encode(convertAttributeValue(body, c.logger)); err != nil {
+				return fmt.Errorf("dropped log record: %v, error: %v", body, err)
+			}
+			return nil
+		}
+		buf.WriteString(body.StringVal())
+		buf.WriteString("\n")
+		return nil
+	}
+
+	// Parsing log record to Splunk event.
+	event := mapLogRecordToSplunkEvent(res, lr, c.config, c.logger)
+	// JSON encoding event and writing to buffer.
+	if err := encoder.Encode(event); err != nil {
+		return fmt.Errorf("dropped log event: %v, error: %v", event, err)
+	}
+	buf.WriteString("\r\n\r\n")
+	return nil
+}
+
+// postLogs sends the log records to the raw endpoint when they are exported raw, to the
+// event endpoint otherwise.
+func (c *client) postLogs(ctx context.Context, logs io.Reader, compressed bool) error {
+	if c.config.ExportRaw {
+		return c.post(ctx, c.rawURL, logs, compressed, "text/plain")
+	}
+	return c.postEvents(ctx, logs, compressed)
+}
+
 func (c *client) postEvents(ctx context.Context, events io.Reader, compressed bool) error {
-	req, err := http.NewRequestWithContext(ctx, "POST", c.url.String(), events)
+	return c.post(ctx, c.url, events, compressed, "")
+}
+
+// post sends the body to url, contentType overrides the Content-Type header when set.
+func (c *client) post(ctx context.Context, url *url.URL, body io.Reader, compressed bool, contentType string) error {
+	req, err := http.NewRequestWithContext(ctx, "POST", url.String(), body)
 	if err != nil {
 		return consumererror.Permanent(err)
 	}
@@ -247,6 +293,10 @@ func (c *client) postEvents(ctx context.Context, events io.Reader, compressed bo
 		req.Header.Set(k, v)
 	}
 
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
 	if compressed {
 		req.Header.Set("Content-Encoding", "gzip")
 	}
@@ -23,6 +23,7 @@ import (
 	"math"
 	"net"
 	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"sync"
 	"testing"
@@ -441,6 +442,57 @@ func TestReceiveLogs(t *testing.T) {
 	}
 }
 
+func TestReceiveRawLogs(t *testing.T) {
+	type request struct {
+		path        string
+		query       string
+		contentType string
+		body        string
+	}
+	requests := make(chan request, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		requests <- request{
+			path:        r.URL.Path,
+			query:       r.URL.RawQuery,
+			contentType: r.Header.Get("Content-Type"),
+			body:        string(body),
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	cfg := NewFactory().CreateDefaultConfig().(*Config)
+	cfg.Endpoint = server.URL + "/services/collector"
+	cfg.Token = "1234-1234"
+	cfg.SourceType = "syslog"
+	cfg.DisableCompression = true
+	cfg.ExportRaw = true
+
+	ld := createLogData(1, 1, 2)
+	lr := ld.ResourceLogs().At(0).InstrumentationLibraryLogs().At(0).Logs().AppendEmpty()
+	body := pdata.NewAttributeValueMap()
+	body.MapVal().InsertString("message", "structured")
+	body.CopyTo(lr.Body())
+
+	params := component.ExporterCreateSettings{Logger: zap.NewNop()}
+	exporter, err := NewFactory().CreateLogsExporter(context.Background(), params, cfg)
+	require.NoError(t, err)
+	require.NoError(t, exporter.Start(context.Background(), componenttest.NewNopHost()))
+	defer exporter.Shutdown(context.Background())
+
+	require.NoError(t, exporter.ConsumeLogs(context.Background(), ld))
+	select {
+	case got := <-requests:
+		assert.Equal(t, "/services/collector/raw", got.path)
+		assert.Equal(t, "sourcetype=syslog", got.query)
+		assert.Equal(t, "text/plain", got.contentType)
+		assert.Equal(t, "mylog\nmylog\n{\"message\":\"structured\"}\n", got.body)
+	case <-time.After(1 * time.Second):
+		t.Fatal("timeout")
+	}
+}
+
 func TestReceiveMetrics(t *testing.T) {
 	actual, err := runMetricsExport(true, 3, t)
 	assert.NoError(t, err)
@@ -453,6 +505,43 @@ func TestReceiveMetrics(t *testing.T) {
 	assert.Equal(t, expected, actual)
 }
 
+func TestReceiveMetricsWithMultiMetricFormat(t *testing.T) {
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = ioutil.ReadAll(r.Body)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	serverURL, err := url.Parse(server.URL)
+	require.NoError(t, err)
+
+	cfg := NewFactory().CreateDefaultConfig().(*Config)
+	cfg.DisableCompression = true
+	cfg.UseMultiMetricFormat = true
+	c := client{
+		url:    serverURL,
+		client: http.DefaultClient,
+		logger: zap.NewNop(),
+		config: cfg,
+	}
+
+	md := createMetricsData(2)
+	ilm := md.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0)
+	metric := ilm.Metrics().AppendEmpty()
+	metric.SetName("other_gauge")
+	metric.SetDataType(pdata.MetricDataTypeDoubleGauge)
+	ilm.Metrics().At(0).DoubleGauge().DataPoints().At(0).CopyTo(metric.DoubleGauge().DataPoints().AppendEmpty())
+	metric.DoubleGauge().DataPoints().At(0).SetValue(42)
+
+	require.NoError(t, c.pushMetricsData(context.Background(), md))
+	expected := `{"host":"unknown","event":"metric","fields":{"k/n0":"vn0","k/n1":"vn1","k/r0":"vr0","k/r1":"vr1","k0":"v0","k1":"v1","metric_name:gauge_double_with_dims":1234.5678,"metric_name:other_gauge":42,"metric_type":"DoubleGauge"}}`
+	expected += "\n\r\n\r\n"
+	expected += `{"time":1.001,"host":"unknown","event":"metric","fields":{"k/n0":"vn0","k/n1":"vn1","k/r0":"vr0","k/r1":"vr1","k0":"v0","k1":"v1","metric_name:gauge_double_with_dims":1234.5678,"metric_type":"DoubleGauge"}}`
+	expected += "\n\r\n\r\n"
+	assert.Equal(t, expected, string(body))
+}
+
 func TestReceiveTracesWithCompression(t *testing.T) {
 	request, err := runTraceExport(false, 1000, t)
 	assert.NoError(t, err)
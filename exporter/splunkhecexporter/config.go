@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"net/url"
 	"path"
+	"strings"
 
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/config/configtls"
@@ -29,6 +30,8 @@ const (
 	// hecPath is the default HEC path on the Splunk instance.
 	hecPath                   = "services/collector"
 	maxContentLengthLogsLimit = 2 * 1024 * 1024
+	// rawPath is the last element of the path of the HEC raw endpoint.
+	rawPath = "raw"
 )
 
 // Config defines configuration for Splunk exporter.
@@ -71,6 +74,15 @@ type Config struct {
 
 	// App version is used to track telemetry information for Splunk App's using HEC by App version. Defaults to the current OpenTelemetry Collector Contrib build version.
 	SplunkAppVersion string `mapstructure:"splunk_app_version"`
+
+	// ExportRaw sends the bodies of the log records as is, one per line, to the HEC raw
+	// endpoint instead of sending the log records as events. Defaults to false.
+	ExportRaw bool `mapstructure:"export_raw"`
+
+	// UseMultiMetricFormat merges the metric values sharing the same timestamp and
+	// dimensions into single events, using the Splunk multiple-metric format.
+	// Defaults to false.
+	UseMultiMetricFormat bool `mapstructure:"use_multi_metric_format"`
 }
 
 func (cfg *Config) getOptionsFromConfig() (*exporterOptions, error) {
@@ -83,10 +95,14 @@ func (cfg *Config) getOptionsFromConfig() (*exporterOptions, error) {
 		return nil, fmt.Errorf(`invalid "endpoint": %v`, err)
 	}
 
-	return &exporterOptions{
+	options := &exporterOptions{
 		url:   url,
 		token: cfg.Token,
-	}, nil
+	}
+	if cfg.ExportRaw {
+		options.rawURL = cfg.getRawURL(url)
+	}
+	return options, nil
 }
 
 func (cfg *Config) validateConfig() error {
@@ -117,3 +133,28 @@ func (cfg *Config) getURL() (out *url.URL, err error) {
 
 	return
 }
+
+// getRawURL returns the URL of the HEC raw endpoint next to the event endpoint u. The
+// raw endpoint receives the source, sourcetype and index as query parameters.
+func (cfg *Config) getRawURL(u *url.URL) *url.URL {
+	out := *u
+	p := strings.TrimSuffix(out.Path, "/")
+	p = strings.TrimSuffix(p, "/event")
+	if path.Base(p) != rawPath {
+		p = path.Join(p, rawPath)
+	}
+	out.Path = p
+
+	query := out.Query()
+	for k, v := range map[string]string{
+		"source":     cfg.Source,
+		"sourcetype": cfg.SourceType,
+		"index":      cfg.Index,
+	} {
+		if v != "" && query.Get(k) == "" {
+			query.Set(k, v)
+		}
+	}
+	out.RawQuery = query.Encode()
+	return &out
+}
@@ -61,6 +61,8 @@ func TestLoadConfig(t *testing.T) {
 		Index:                "metrics",
 		SplunkAppName:        "OpenTelemetry-Collector Splunk Exporter",
 		SplunkAppVersion:     "v0.0.1",
+		ExportRaw:            true,
+		UseMultiMetricFormat: true,
 		MaxConnections:       100,
 		MaxContentLengthLogs: 2 * 1024 * 1024,
 		TimeoutSettings: exporterhelper.TimeoutSettings{
@@ -103,6 +105,7 @@ func TestConfig_getOptionsFromConfig(t *testing.T) {
 		SourceType           string
 		Index                string
 		MaxContentLengthLogs uint
+		ExportRaw            bool
 	}
 	tests := []struct {
 		name    string
@@ -142,6 +145,30 @@ func TestConfig_getOptionsFromConfig(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "Test raw URL",
+			fields: fields{
+				Token:      "1234",
+				Endpoint:   "https://example.com:8000/services/collector",
+				SourceType: "syslog",
+				ExportRaw:  true,
+			},
+			want: &exporterOptions{
+				token: "1234",
+				url: &url.URL{
+					Scheme: "https",
+					Host:   "example.com:8000",
+					Path:   "/services/collector",
+				},
+				rawURL: &url.URL{
+					Scheme:   "https",
+					Host:     "example.com:8000",
+					Path:     "/services/collector/raw",
+					RawQuery: "sourcetype=syslog",
+				},
+			},
+			wantErr: false,
+		},
 		{
 			name:    "Test empty config",
 			want:    nil,
@@ -167,6 +194,7 @@ func TestConfig_getOptionsFromConfig(t *testing.T) {
 				SourceType:           tt.fields.SourceType,
 				Index:                tt.fields.Index,
 				MaxContentLengthLogs: tt.fields.MaxContentLengthLogs,
+				ExportRaw:            tt.fields.ExportRaw,
 			}
 			got, err := cfg.getOptionsFromConfig()
 			if (err != nil) != tt.wantErr {
@@ -177,3 +205,50 @@ func TestConfig_getOptionsFromConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestConfig_getRawURL(t *testing.T) {
+	tests := []struct {
+		name     string
+		endpoint string
+		want     string
+	}{
+		{
+			name:     "collector path",
+			endpoint: "https://splunk:8088/services/collector",
+			want:     "https://splunk:8088/services/collector/raw?index=main&source=otel&sourcetype=otel",
+		},
+		{
+			name:     "trailing slash",
+			endpoint: "https://splunk:8088/services/collector/",
+			want:     "https://splunk:8088/services/collector/raw?index=main&source=otel&sourcetype=otel",
+		},
+		{
+			name:     "event path",
+			endpoint: "https://splunk:8088/services/collector/event",
+			want:     "https://splunk:8088/services/collector/raw?index=main&source=otel&sourcetype=otel",
+		},
+		{
+			name:     "raw path",
+			endpoint: "https://splunk:8088/services/collector/raw",
+			want:     "https://splunk:8088/services/collector/raw?index=main&source=otel&sourcetype=otel",
+		},
+		{
+			name:     "query parameters",
+			endpoint: "https://splunk:8088/services/collector?index=other&channel=1234",
+			want:     "https://splunk:8088/services/collector/raw?channel=1234&index=other&source=otel&sourcetype=otel",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{
+				Endpoint:   tt.endpoint,
+				Source:     "otel",
+				SourceType: "otel",
+				Index:      "main",
+			}
+			u, err := cfg.getURL()
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, cfg.getRawURL(u).String())
+		})
+	}
+}
@@ -51,6 +51,8 @@ type splunkExporter struct {
 type exporterOptions struct {
 	url   *url.URL
 	token string
+	// rawURL is the URL the logs are sent to when they are exported raw.
+	rawURL *url.URL
 }
 
 // createExporter returns a new Splunk exporter.
@@ -97,7 +99,8 @@ func buildClient(options *exporterOptions, config *Config, logger *zap.Logger) (
 		return nil, fmt.Errorf("could not retrieve TLS config for Splunk HEC Exporter: %w", err)
 	}
 	return &client{
-		url: options.url,
+		url:    options.url,
+		rawURL: options.rawURL,
 		client: &http.Client{
 			Timeout: config.Timeout,
 			Transport: &http.Transport{
@@ -55,6 +55,8 @@ func mapLogRecordToSplunkEvent(res pdata.Resource, lr pdata.LogRecord, config *C
 		case conventions.AttributeServiceName:
 			source = v.StringVal()
 			fields[k] = v.StringVal()
+		case splunk.SourceLabel:
+			source = v.StringVal()
 		case splunk.SourcetypeLabel:
 			sourcetype = v.StringVal()
 		case splunk.IndexLabel:
@@ -72,6 +74,8 @@ func mapLogRecordToSplunkEvent(res pdata.Resource, lr pdata.LogRecord, config *C
 		case conventions.AttributeServiceName:
 			source = v.StringVal()
 			fields[k] = v.StringVal()
+		case splunk.SourceLabel:
+			source = v.StringVal()
 		case splunk.SourcetypeLabel:
 			sourcetype = v.StringVal()
 		case splunk.IndexLabel:
@@ -15,8 +15,10 @@
 package splunkhecexporter
 
 import (
+	"encoding/json"
 	"math"
 	"strconv"
+	"strings"
 
 	"go.opentelemetry.io/collector/consumer/pdata"
 	"go.opentelemetry.io/collector/translator/conventions"
@@ -61,6 +63,9 @@ func metricDataToSplunk(logger *zap.Logger, data pdata.Metrics, config *Config)
 		if sourceSet, isSet := attributes.Get(conventions.AttributeServiceName); isSet {
 			source = sourceSet.StringVal()
 		}
+		if sourceSet, isSet := attributes.Get(splunk.SourceLabel); isSet {
+			source = sourceSet.StringVal()
+		}
 		if sourcetypeSet, isSet := attributes.Get(splunk.SourcetypeLabel); isSet {
 			sourceType = sourcetypeSet.StringVal()
 		}
@@ -281,6 +286,52 @@ func metricDataToSplunk(logger *zap.Logger, data pdata.Metrics, config *Config)
 	return splunkMetrics, numDroppedTimeSeries
 }
 
+// mergeEventsToMultiMetricFormat merges the metric events sharing the same time, host,
+// source, sourcetype, index and fields other than the metric values into single events
+// holding all their values, as in the Splunk multiple-metric format.
+// See https://docs.splunk.com/Documentation/Splunk/latest/Metrics/GetMetricsInOther#The_multiple-metric_JSON_format
+func mergeEventsToMultiMetricFormat(events []*splunk.Event) ([]*splunk.Event, error) {
+	merged := make([]*splunk.Event, 0, len(events))
+	byKey := make(map[string]*splunk.Event, len(events))
+	for _, e := range events {
+		key, err := multiMetricKey(e)
+		if err != nil {
+			return nil, err
+		}
+		pick, ok := byKey[key]
+		if !ok {
+			byKey[key] = e
+			merged = append(merged, e)
+			continue
+		}
+		for k, v := range e.Fields {
+			if isMetricValueField(k) {
+				pick.Fields[k] = v
+			}
+		}
+	}
+	return merged, nil
+}
+
+// multiMetricKey returns the JSON encoding of the event without its metric values,
+// events with the same key can be merged.
+func multiMetricKey(e *splunk.Event) (string, error) {
+	dims := make(map[string]interface{}, len(e.Fields))
+	for k, v := range e.Fields {
+		if !isMetricValueField(k) {
+			dims[k] = v
+		}
+	}
+	key := *e
+	key.Fields = dims
+	b, err := json.Marshal(&key)
+	return string(b), err
+}
+
+func isMetricValueField(field string) bool {
+	return strings.HasPrefix(field, splunkMetricValue+":")
+}
+
 func createEvent(timestamp pdata.Timestamp, host string, source string, sourceType string, index string, fields map[string]interface{}) *splunk.Event {
 	return &splunk.Event{
 		Time:       timestampToSecondsWithMillisecondPrecision(timestamp),
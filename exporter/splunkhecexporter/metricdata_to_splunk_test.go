@@ -20,6 +20,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/consumer/pdata"
 	"go.uber.org/zap"
 
@@ -672,6 +673,35 @@ func commonSplunkMetric(
 	}
 }
 
+func TestMergeEventsToMultiMetricFormat(t *testing.T) {
+	ts1 := 1.001
+	ts2 := 2.001
+	keys := []string{"k0", "metric_type"}
+	events := []*splunk.Event{
+		commonSplunkMetric("cpu", &ts1, keys, []interface{}{"v0", "Gauge"}, 1.0, "source", "sourcetype", "index", "host"),
+		commonSplunkMetric("memory", &ts1, keys, []interface{}{"v0", "Gauge"}, 2.0, "source", "sourcetype", "index", "host"),
+		commonSplunkMetric("disk", &ts1, keys, []interface{}{"v1", "Gauge"}, 3.0, "source", "sourcetype", "index", "host"),
+		commonSplunkMetric("cpu", &ts2, keys, []interface{}{"v0", "Gauge"}, 4.0, "source", "sourcetype", "index", "host"),
+		commonSplunkMetric("requests", &ts1, keys, []interface{}{"v0", "Sum"}, 5.0, "source", "sourcetype", "index", "host"),
+		commonSplunkMetric("network", &ts1, keys, []interface{}{"v0", "Gauge"}, 6.0, "source", "sourcetype", "index", "other"),
+	}
+
+	merged, err := mergeEventsToMultiMetricFormat(events)
+	require.NoError(t, err)
+	require.Len(t, merged, 5)
+	assert.Equal(t, map[string]interface{}{
+		"k0":                 "v0",
+		"metric_type":        "Gauge",
+		"metric_name:cpu":    1.0,
+		"metric_name:memory": 2.0,
+	}, merged[0].Fields)
+	assert.Equal(t, &ts1, merged[0].Time)
+	assert.Equal(t, events[2], merged[1])
+	assert.Equal(t, events[3], merged[2])
+	assert.Equal(t, events[4], merged[3])
+	assert.Equal(t, events[5], merged[4])
+}
+
 func TestTimestampFormat(t *testing.T) {
 	ts := pdata.Timestamp(32001000345)
 	assert.Equal(t, 32.001, *timestampToSecondsWithMillisecondPrecision(ts))
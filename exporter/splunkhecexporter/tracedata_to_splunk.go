@@ -78,6 +78,9 @@ func traceDataToSplunk(logger *zap.Logger, data pdata.Traces, config *Config) ([
 		if sourceSet, isSet := attributes.Get(conventions.AttributeServiceName); isSet {
 			source = sourceSet.StringVal()
 		}
+		if sourceSet, isSet := attributes.Get(splunk.SourceLabel); isSet {
+			source = sourceSet.StringVal()
+		}
 		if sourcetypeSet, isSet := attributes.Get(splunk.SourcetypeLabel); isSet {
 			sourceType = sourcetypeSet.StringVal()
 		}
@@ -15,6 +15,7 @@
 package tanzuobservabilityexporter
 
 import (
+	"errors"
 	"fmt"
 	"net/url"
 
@@ -32,9 +33,45 @@ type Config struct {
 
 	// Traces defines the Traces exporter specific configuration
 	Traces TracesConfig `mapstructure:"traces"`
+
+	// DirectIngestion sends the data straight to the Tanzu Observability ingestion endpoint
+	// instead of a Wavefront proxy. The traces endpoint is ignored when it is set.
+	DirectIngestion *DirectIngestionConfig `mapstructure:"direct_ingestion"`
+}
+
+// DirectIngestionConfig defines the Tanzu Observability instance and the credentials used for direct ingestion.
+type DirectIngestionConfig struct {
+	// Server is the URL of the Tanzu Observability instance, eg: https://<INSTANCE>.wavefront.com.
+	Server string `mapstructure:"server"`
+
+	// Token is a static API token with the direct data ingestion permission. Mutually exclusive with CSP.
+	Token string `mapstructure:"token"`
+
+	// CSP defines the VMware Cloud Services OAuth app credentials used to obtain the API token,
+	// which is refreshed before it expires. Mutually exclusive with Token.
+	CSP *CSPConfig `mapstructure:"csp"`
+}
+
+// CSPConfig defines the VMware Cloud Services OAuth app credentials.
+type CSPConfig struct {
+	// Endpoint is the URL of the VMware Cloud Services console. Default is https://console.cloud.vmware.com.
+	Endpoint string `mapstructure:"endpoint"`
+
+	// AppID is the ID of the server to server OAuth app.
+	AppID string `mapstructure:"app_id"`
+
+	// AppSecret is the secret of the server to server OAuth app.
+	AppSecret string `mapstructure:"app_secret"`
+
+	// OrgID is the ID of the organization the token is requested for. Optional, the default
+	// organization of the app is used if empty.
+	OrgID string `mapstructure:"org_id"`
 }
 
 func (c *Config) Validate() error {
+	if c.DirectIngestion != nil {
+		return c.DirectIngestion.validate()
+	}
 	if c.Traces.Endpoint == "" {
 		return fmt.Errorf("A non-empty traces.endpoint is required")
 	}
@@ -43,3 +80,19 @@ func (c *Config) Validate() error {
 	}
 	return nil
 }
+
+func (c *DirectIngestionConfig) validate() error {
+	if c.Server == "" {
+		return errors.New("a non-empty direct_ingestion.server is required")
+	}
+	if _, err := url.Parse(c.Server); err != nil {
+		return fmt.Errorf("invalid direct_ingestion.server %s", err)
+	}
+	if (c.Token == "") == (c.CSP == nil) {
+		return errors.New("exactly one of direct_ingestion.token or direct_ingestion.csp is required")
+	}
+	if c.CSP != nil && (c.CSP.AppID == "" || c.CSP.AppSecret == "") {
+		return errors.New("direct_ingestion.csp requires a non-empty app_id and app_secret")
+	}
+	return nil
+}
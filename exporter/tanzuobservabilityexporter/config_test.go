@@ -43,3 +43,64 @@ func TestConfigRequiresValidEndpointUrl(t *testing.T) {
 
 	assert.Error(t, c.Validate())
 }
+
+func TestConfigDirectIngestion(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     *DirectIngestionConfig
+		wantErr string
+	}{
+		{
+			name: "static token",
+			cfg:  &DirectIngestionConfig{Server: "https://example.wavefront.com", Token: "token"},
+		},
+		{
+			name: "csp",
+			cfg: &DirectIngestionConfig{
+				Server: "https://example.wavefront.com",
+				CSP:    &CSPConfig{AppID: "app", AppSecret: "secret"},
+			},
+		},
+		{
+			name:    "missing server",
+			cfg:     &DirectIngestionConfig{Token: "token"},
+			wantErr: "a non-empty direct_ingestion.server is required",
+		},
+		{
+			name:    "missing credentials",
+			cfg:     &DirectIngestionConfig{Server: "https://example.wavefront.com"},
+			wantErr: "exactly one of direct_ingestion.token or direct_ingestion.csp is required",
+		},
+		{
+			name: "both credentials",
+			cfg: &DirectIngestionConfig{
+				Server: "https://example.wavefront.com",
+				Token:  "token",
+				CSP:    &CSPConfig{AppID: "app", AppSecret: "secret"},
+			},
+			wantErr: "exactly one of direct_ingestion.token or direct_ingestion.csp is required",
+		},
+		{
+			name: "missing app secret",
+			cfg: &DirectIngestionConfig{
+				Server: "https://example.wavefront.com",
+				CSP:    &CSPConfig{AppID: "app"},
+			},
+			wantErr: "direct_ingestion.csp requires a non-empty app_id and app_secret",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Config{
+				ExporterSettings: config.ExporterSettings{},
+				DirectIngestion:  tt.cfg,
+			}
+			err := c.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+		})
+	}
+}
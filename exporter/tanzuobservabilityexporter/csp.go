@@ -0,0 +1,207 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tanzuobservabilityexporter
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/wavefronthq/wavefront-sdk-go/senders"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultCSPEndpoint = "https://console.cloud.vmware.com"
+	cspAuthorizePath   = "/csp/gateway/am/api/auth/authorize"
+	cspRequestTimeout  = 10 * time.Second
+
+	// tokenRetryInterval is the delay before trying again to refresh a token after a failure.
+	tokenRetryInterval = 30 * time.Second
+)
+
+// tokenSource obtains an API token along with its lifetime.
+type tokenSource func(ctx context.Context) (token string, expiresIn time.Duration, err error)
+
+// newCSPTokenSource returns a tokenSource exchanging the OAuth app credentials for an access token
+// using the client credentials grant.
+func newCSPTokenSource(cfg *CSPConfig) tokenSource {
+	endpoint := cfg.Endpoint
+	if endpoint == "" {
+		endpoint = defaultCSPEndpoint
+	}
+	client := &http.Client{Timeout: cspRequestTimeout}
+
+	return func(ctx context.Context) (string, time.Duration, error) {
+		form := url.Values{"grant_type": {"client_credentials"}}
+		if cfg.OrgID != "" {
+			form.Set("orgId", cfg.OrgID)
+		}
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimSuffix(endpoint, "/")+cspAuthorizePath, strings.NewReader(form.Encode()))
+		if err != nil {
+			return "", 0, err
+		}
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.SetBasicAuth(cfg.AppID, cfg.AppSecret)
+
+		resp, err := client.Do(req)
+		if err != nil {
+			return "", 0, fmt.Errorf("failed to request CSP token: %v", err)
+		}
+		defer func() {
+			_, _ = io.Copy(ioutil.Discard, resp.Body)
+			resp.Body.Close()
+		}()
+
+		if resp.StatusCode != http.StatusOK {
+			return "", 0, fmt.Errorf("failed to request CSP token: %s", resp.Status)
+		}
+
+		var body struct {
+			AccessToken string `json:"access_token"`
+			ExpiresIn   int64  `json:"expires_in"`
+		}
+		if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+			return "", 0, fmt.Errorf("failed to decode CSP token: %v", err)
+		}
+		if body.AccessToken == "" {
+			return "", 0, fmt.Errorf("CSP returned an empty access token")
+		}
+		return body.AccessToken, time.Duration(body.ExpiresIn) * time.Second, nil
+	}
+}
+
+// tokenRotatingSender is a spanSender whose underlying sender is recreated each time the API token is refreshed.
+type tokenRotatingSender struct {
+	logger    *zap.Logger
+	source    tokenSource
+	newSender func(token string) (spanSender, error)
+
+	mu     sync.RWMutex
+	sender spanSender
+
+	done chan struct{}
+	wg   sync.WaitGroup
+}
+
+var _ spanSender = (*tokenRotatingSender)(nil)
+
+// newTokenRotatingSender obtains the first token and starts refreshing it in the background.
+func newTokenRotatingSender(
+	l *zap.Logger,
+	source tokenSource,
+	newSender func(token string) (spanSender, error),
+) (*tokenRotatingSender, error) {
+	s := &tokenRotatingSender{
+		logger:    l,
+		source:    source,
+		newSender: newSender,
+		done:      make(chan struct{}),
+	}
+
+	expiresIn, err := s.rotate()
+	if err != nil {
+		return nil, err
+	}
+
+	s.wg.Add(1)
+	go s.refreshLoop(expiresIn)
+	return s, nil
+}
+
+// rotate obtains a new token and swaps the underlying sender, flushing and closing the previous one.
+func (s *tokenRotatingSender) rotate() (time.Duration, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), cspRequestTimeout)
+	defer cancel()
+
+	token, expiresIn, err := s.source(ctx)
+	if err != nil {
+		return 0, err
+	}
+	sender, err := s.newSender(token)
+	if err != nil {
+		return 0, err
+	}
+
+	s.mu.Lock()
+	previous := s.sender
+	s.sender = sender
+	s.mu.Unlock()
+
+	if previous != nil {
+		if err := previous.Flush(); err != nil {
+			s.logger.Warn("Failed to flush the sender using the previous token", zap.Error(err))
+		}
+		previous.Close()
+	}
+	return expiresIn, nil
+}
+
+func (s *tokenRotatingSender) refreshLoop(expiresIn time.Duration) {
+	defer s.wg.Done()
+
+	timer := time.NewTimer(refreshDelay(expiresIn))
+	defer timer.Stop()
+	for {
+		select {
+		case <-s.done:
+			return
+		case <-timer.C:
+			var err error
+			expiresIn, err = s.rotate()
+			if err != nil {
+				s.logger.Warn("Failed to refresh the API token", zap.Error(err))
+				timer.Reset(tokenRetryInterval)
+				continue
+			}
+			s.logger.Debug("Refreshed the API token", zap.Duration("expires_in", expiresIn))
+			timer.Reset(refreshDelay(expiresIn))
+		}
+	}
+}
+
+// refreshDelay leaves a fifth of the token lifetime to refresh it before it expires.
+func refreshDelay(expiresIn time.Duration) time.Duration {
+	return expiresIn - expiresIn/5
+}
+
+func (s *tokenRotatingSender) SendSpan(name string, startMillis, durationMillis int64, source, traceID, spanID string, parents, followsFrom []string, tags []senders.SpanTag, spanLogs []senders.SpanLog) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.sender.SendSpan(name, startMillis, durationMillis, source, traceID, spanID, parents, followsFrom, tags, spanLogs)
+}
+
+func (s *tokenRotatingSender) Flush() error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.sender.Flush()
+}
+
+func (s *tokenRotatingSender) Close() {
+	close(s.done)
+	s.wg.Wait()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sender.Close()
+}
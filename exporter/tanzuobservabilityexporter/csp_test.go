@@ -0,0 +1,114 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tanzuobservabilityexporter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestCSPTokenSource(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, cspAuthorizePath, r.URL.Path)
+		appID, appSecret, ok := r.BasicAuth()
+		assert.True(t, ok)
+		require.NoError(t, r.ParseForm())
+		assert.Equal(t, "client_credentials", r.PostForm.Get("grant_type"))
+		assert.Equal(t, "org", r.PostForm.Get("orgId"))
+		if appID != "app" || appSecret != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		fmt.Fprint(w, `{"access_token": "token", "expires_in": 1799}`)
+	}))
+	defer server.Close()
+
+	source := newCSPTokenSource(&CSPConfig{Endpoint: server.URL, AppID: "app", AppSecret: "secret", OrgID: "org"})
+	token, expiresIn, err := source(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "token", token)
+	assert.Equal(t, 1799*time.Second, expiresIn)
+
+	source = newCSPTokenSource(&CSPConfig{Endpoint: server.URL, AppID: "app", AppSecret: "wrong", OrgID: "org"})
+	_, _, err = source(context.Background())
+	assert.EqualError(t, err, "failed to request CSP token: 401 Unauthorized")
+}
+
+// tokenSender records the token it was created with.
+type tokenSender struct {
+	mockSender
+	token  string
+	closed bool
+}
+
+func (s *tokenSender) Close() { s.closed = true }
+
+func TestTokenRotatingSender(t *testing.T) {
+	var mu sync.Mutex
+	var created []*tokenSender
+	calls := 0
+
+	source := func(context.Context) (string, time.Duration, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		calls++
+		return fmt.Sprintf("token-%d", calls), 50 * time.Millisecond, nil
+	}
+	newSender := func(token string) (spanSender, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		s := &tokenSender{token: token}
+		created = append(created, s)
+		return s, nil
+	}
+
+	s, err := newTokenRotatingSender(zap.NewNop(), source, newSender)
+	require.NoError(t, err)
+
+	assert.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(created) >= 3
+	}, time.Second, 10*time.Millisecond)
+
+	s.Close()
+
+	mu.Lock()
+	defer mu.Unlock()
+	for i, sender := range created {
+		assert.Equal(t, fmt.Sprintf("token-%d", i+1), sender.token)
+		assert.True(t, sender.closed)
+	}
+}
+
+func TestTokenRotatingSenderInitialFailure(t *testing.T) {
+	source := func(context.Context) (string, time.Duration, error) {
+		return "", 0, errors.New("unavailable")
+	}
+	_, err := newTokenRotatingSender(zap.NewNop(), source, func(string) (spanSender, error) {
+		return &mockSender{}, nil
+	})
+	assert.EqualError(t, err, "unavailable")
+}
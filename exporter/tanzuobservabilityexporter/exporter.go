@@ -64,6 +64,25 @@ func newTracesExporter(l *zap.Logger, c config.Exporter) (*tracesExporter, error
 		return nil, fmt.Errorf("invalid config: %#v", c)
 	}
 
+	var s spanSender
+	var err error
+	if cfg.DirectIngestion != nil {
+		s, err = newDirectSender(l, cfg.DirectIngestion)
+	} else {
+		s, err = newProxySender(cfg)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &tracesExporter{
+		cfg:    cfg,
+		sender: s,
+		logger: l,
+	}, nil
+}
+
+func newProxySender(cfg *Config) (spanSender, error) {
 	endpoint, err := url.Parse(cfg.Traces.Endpoint)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse traces.endpoint: %v", err)
@@ -85,12 +104,26 @@ func newTracesExporter(l *zap.Logger, c config.Exporter) (*tracesExporter, error
 	if err != nil {
 		return nil, fmt.Errorf("failed to create proxy sender: %v", err)
 	}
+	return s, nil
+}
 
-	return &tracesExporter{
-		cfg:    cfg,
-		sender: s,
-		logger: l,
-	}, nil
+func newDirectSender(l *zap.Logger, cfg *DirectIngestionConfig) (spanSender, error) {
+	newSender := func(token string) (spanSender, error) {
+		s, err := senders.NewDirectSender(&senders.DirectConfiguration{
+			Server:               cfg.Server,
+			Token:                token,
+			FlushIntervalSeconds: 1,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create direct sender: %v", err)
+		}
+		return s, nil
+	}
+
+	if cfg.CSP == nil {
+		return newSender(cfg.Token)
+	}
+	return newTokenRotatingSender(l, newCSPTokenSource(cfg.CSP), newSender)
 }
 
 func (e *tracesExporter) pushTraceData(ctx context.Context, td pdata.Traces) error {
@@ -60,6 +60,24 @@ func TestLoadConfig(t *testing.T) {
 		},
 	}
 	assert.Equal(t, expected, actual)
+
+	actual, ok = cfg.Exporters[config.NewIDWithName("tanzuobservability", "direct")]
+	require.True(t, ok)
+	expected = &Config{
+		ExporterSettings: config.NewExporterSettings(config.NewIDWithName("tanzuobservability", "direct")),
+		Traces: TracesConfig{
+			HTTPClientSettings: confighttp.HTTPClientSettings{Endpoint: "http://localhost:30001"},
+		},
+		DirectIngestion: &DirectIngestionConfig{
+			Server: "https://example.wavefront.com",
+			CSP: &CSPConfig{
+				AppID:     "app",
+				AppSecret: "secret",
+				OrgID:     "org",
+			},
+		},
+	}
+	assert.Equal(t, expected, actual)
 }
 
 func TestCreateExporter(t *testing.T) {
@@ -0,0 +1,66 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admissioncontrolextension
+
+import (
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/collector/config"
+)
+
+type Config struct {
+	config.ExtensionSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+
+	// CheckInterval is the time between measurements of the memory used by the
+	// collector. Default is 1s.
+	CheckInterval time.Duration `mapstructure:"check_interval"`
+
+	// LimitMiB is the soft limit of the memory allocated by the collector, in
+	// MiB. Admission is paused once it is reached.
+	LimitMiB uint64 `mapstructure:"limit_mib"`
+
+	// ResumeMiB is the memory allocated by the collector, in MiB, below which
+	// admission resumes once paused. Default is 90% of limit_mib.
+	ResumeMiB uint64 `mapstructure:"resume_mib"`
+
+	// RetryAfter is how long the senders of the refused data are asked to wait
+	// before sending it again. Default is 5s.
+	RetryAfter time.Duration `mapstructure:"retry_after"`
+}
+
+func (cfg *Config) Validate() error {
+	if cfg.CheckInterval <= 0 {
+		return errors.New("check_interval must be positive")
+	}
+	if cfg.LimitMiB == 0 {
+		return errors.New("limit_mib must be positive")
+	}
+	if cfg.ResumeMiB >= cfg.LimitMiB {
+		return errors.New("resume_mib must be lower than limit_mib")
+	}
+	if cfg.RetryAfter < 0 {
+		return errors.New("retry_after must not be negative")
+	}
+	return nil
+}
+
+// resumeBytes returns the memory allocated below which admission resumes.
+func (cfg *Config) resumeBytes() uint64 {
+	if cfg.ResumeMiB == 0 {
+		return cfg.LimitMiB * mib * 9 / 10
+	}
+	return cfg.ResumeMiB * mib
+}
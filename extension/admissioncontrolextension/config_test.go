@@ -0,0 +1,75 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admissioncontrolextension
+
+import (
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configtest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	require.NoError(t, err)
+
+	factories.Extensions[typeStr] = NewFactory()
+
+	cfg, err := configtest.LoadConfigAndValidate(path.Join(".", "testdata", "config.yaml"), factories)
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	defaultCfg := createDefaultConfig().(*Config)
+	defaultCfg.LimitMiB = 4000
+	assert.Equal(t, defaultCfg, cfg.Extensions[config.NewID(typeStr)])
+	assert.Equal(t,
+		&Config{
+			ExtensionSettings: config.NewExtensionSettings(config.NewIDWithName(typeStr, "custom")),
+			CheckInterval:     500 * time.Millisecond,
+			LimitMiB:          2000,
+			ResumeMiB:         1500,
+			RetryAfter:        10 * time.Second,
+		},
+		cfg.Extensions[config.NewIDWithName(typeStr, "custom")],
+	)
+}
+
+func TestValidateConfig(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	assert.EqualError(t, cfg.Validate(), "limit_mib must be positive")
+
+	cfg.LimitMiB = 100
+	assert.NoError(t, cfg.Validate())
+	assert.Equal(t, uint64(90*mib), cfg.resumeBytes())
+
+	cfg.ResumeMiB = 100
+	assert.EqualError(t, cfg.Validate(), "resume_mib must be lower than limit_mib")
+
+	cfg.ResumeMiB = 50
+	assert.NoError(t, cfg.Validate())
+	assert.Equal(t, uint64(50*mib), cfg.resumeBytes())
+
+	cfg.CheckInterval = 0
+	assert.EqualError(t, cfg.Validate(), "check_interval must be positive")
+
+	cfg.CheckInterval = time.Second
+	cfg.RetryAfter = -time.Second
+	assert.EqualError(t, cfg.Validate(), "retry_after must not be negative")
+}
@@ -0,0 +1,164 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admissioncontrolextension
+
+import (
+	"context"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/admissioncontrol"
+)
+
+const mib = 1024 * 1024
+
+// Controller pauses the admission of data while the memory allocated by the
+// collector is above the soft limit, so the senders hold the data back instead
+// of the collector dropping it.
+type Controller interface {
+	component.Extension
+
+	// Admit returns an *admissioncontrol.PausedError while admission is paused,
+	// nil otherwise.
+	Admit() error
+	// RegisterHook registers a function called with true when admission pauses
+	// and with false when it resumes, so the receivers pulling their data, e.g.
+	// from a queue or by scraping, stop fetching it. The returned function
+	// unregisters the hook.
+	RegisterHook(hook func(paused bool)) (unregister func())
+}
+
+type admissionController struct {
+	logger *zap.Logger
+	config *Config
+
+	readMemStats func(*runtime.MemStats)
+	gc           func()
+
+	// paused is 1 while admission is paused.
+	paused int32
+
+	mu       sync.Mutex
+	hooks    map[int]func(bool)
+	nextHook int
+
+	done chan struct{}
+	wg   sync.WaitGroup
+}
+
+var _ Controller = (*admissionController)(nil)
+
+func newAdmissionController(logger *zap.Logger, config *Config) *admissionController {
+	return &admissionController{
+		logger:       logger,
+		config:       config,
+		readMemStats: runtime.ReadMemStats,
+		gc:           runtime.GC,
+		hooks:        make(map[int]func(bool)),
+		done:         make(chan struct{}),
+	}
+}
+
+func (ac *admissionController) Start(context.Context, component.Host) error {
+	ac.wg.Add(1)
+	go func() {
+		defer ac.wg.Done()
+		ticker := time.NewTicker(ac.config.CheckInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				ac.check()
+			case <-ac.done:
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+func (ac *admissionController) Shutdown(context.Context) error {
+	close(ac.done)
+	ac.wg.Wait()
+	return nil
+}
+
+func (ac *admissionController) Admit() error {
+	if atomic.LoadInt32(&ac.paused) == 1 {
+		return &admissioncontrol.PausedError{RetryAfter: ac.config.RetryAfter}
+	}
+	return nil
+}
+
+func (ac *admissionController) RegisterHook(hook func(paused bool)) func() {
+	ac.mu.Lock()
+	defer ac.mu.Unlock()
+	id := ac.nextHook
+	ac.nextHook++
+	ac.hooks[id] = hook
+	return func() {
+		ac.mu.Lock()
+		defer ac.mu.Unlock()
+		delete(ac.hooks, id)
+	}
+}
+
+// check measures the memory allocated and pauses or resumes admission when
+// it crosses the soft limit or the resume threshold.
+func (ac *admissionController) check() {
+	var ms runtime.MemStats
+	ac.readMemStats(&ms)
+
+	if atomic.LoadInt32(&ac.paused) == 0 {
+		if ms.Alloc < ac.config.LimitMiB*mib {
+			return
+		}
+		// The data already admitted is still being processed, collecting the
+		// garbage right away lets admission resume sooner.
+		ac.logger.Warn("Memory soft limit exceeded, pausing admission", zap.Uint64("cur_mem_mib", ms.Alloc/mib))
+		ac.setPaused(true)
+		ac.gc()
+		return
+	}
+
+	if ms.Alloc < ac.config.resumeBytes() {
+		ac.logger.Info("Memory back below the resume threshold, resuming admission", zap.Uint64("cur_mem_mib", ms.Alloc/mib))
+		ac.setPaused(false)
+	}
+}
+
+func (ac *admissionController) setPaused(paused bool) {
+	if paused {
+		atomic.StoreInt32(&ac.paused, 1)
+	} else {
+		atomic.StoreInt32(&ac.paused, 0)
+	}
+
+	ac.mu.Lock()
+	hooks := make([]func(bool), 0, len(ac.hooks))
+	for _, hook := range ac.hooks {
+		hooks = append(hooks, hook)
+	}
+	ac.mu.Unlock()
+
+	for _, hook := range hooks {
+		hook(paused)
+	}
+}
@@ -0,0 +1,83 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admissioncontrolextension
+
+import (
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/admissioncontrol"
+)
+
+func newTestController(alloc *uint64) (*admissionController, *int) {
+	ac := newAdmissionController(zap.NewNop(), &Config{
+		CheckInterval: time.Second,
+		LimitMiB:      100,
+		ResumeMiB:     80,
+		RetryAfter:    3 * time.Second,
+	})
+	gcs := 0
+	ac.readMemStats = func(ms *runtime.MemStats) { ms.Alloc = *alloc }
+	ac.gc = func() { gcs++ }
+	return ac, &gcs
+}
+
+func TestAdmissionControllerCheck(t *testing.T) {
+	alloc := uint64(50 * mib)
+	ac, gcs := newTestController(&alloc)
+
+	var events []bool
+	unregister := ac.RegisterHook(func(paused bool) { events = append(events, paused) })
+
+	ac.check()
+	assert.NoError(t, ac.Admit())
+
+	alloc = 100 * mib
+	ac.check()
+	err := ac.Admit()
+	assert.EqualError(t, err, "admission paused: memory soft limit exceeded, retry after 3s")
+	assert.Equal(t, 1, *gcs)
+	assert.Equal(t, []bool{true}, events)
+
+	// Admission stays paused until the memory gets below the resume threshold.
+	alloc = 90 * mib
+	ac.check()
+	assert.Error(t, ac.Admit())
+	assert.Equal(t, []bool{true}, events)
+
+	alloc = 70 * mib
+	ac.check()
+	assert.NoError(t, ac.Admit())
+	assert.Equal(t, []bool{true, false}, events)
+
+	unregister()
+	alloc = 200 * mib
+	ac.check()
+	assert.Error(t, ac.Admit())
+	assert.Equal(t, []bool{true, false}, events)
+}
+
+func TestAdmitRetryAfter(t *testing.T) {
+	ac := newAdmissionController(zap.NewNop(), &Config{LimitMiB: 100, RetryAfter: 3 * time.Second})
+	ac.setPaused(true)
+
+	retryAfter, ok := admissioncontrol.RetryAfter(ac.Admit())
+	assert.True(t, ok)
+	assert.Equal(t, 3*time.Second, retryAfter)
+}
@@ -0,0 +1,43 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deliverytrackingextension
+
+import (
+	"errors"
+
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/confighttp"
+)
+
+type Config struct {
+	config.ExtensionSettings      `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+	confighttp.HTTPServerSettings `mapstructure:",squash"`
+
+	// MaxBatches is the number of batches whose delivery is remembered, the
+	// oldest ones are forgotten first. Default is 1000.
+	MaxBatches int `mapstructure:"max_batches"`
+
+	// IndexTraceIDs makes the trace IDs of the spans and log records of each
+	// batch searchable, so the batches carrying a given trace can be looked up.
+	// Default is true.
+	IndexTraceIDs bool `mapstructure:"index_trace_ids"`
+}
+
+func (cfg *Config) Validate() error {
+	if cfg.MaxBatches <= 0 {
+		return errors.New("max_batches must be positive")
+	}
+	return nil
+}
@@ -0,0 +1,327 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deliverytrackingextension
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.uber.org/zap"
+)
+
+// Tracker records what happens to the batches flowing through the pipelines.
+type Tracker interface {
+	component.Extension
+
+	// Track registers a batch of items entering the given pipeline and returns
+	// the ID assigned to it. traceIDs are the trace IDs carried by the batch,
+	// they are only kept when index_trace_ids is enabled.
+	Track(pipeline, signal string, items int, traceIDs []pdata.TraceID) string
+	// Done records the outcome of the delivery of the batch with the given ID,
+	// err being the error returned by the rest of the pipeline.
+	Done(id string, err error)
+}
+
+// Signals of the tracked batches.
+const (
+	SignalTraces  = "traces"
+	SignalMetrics = "metrics"
+	SignalLogs    = "logs"
+)
+
+type outcome string
+
+const (
+	// outcomePending is the outcome of the batches still being delivered.
+	outcomePending outcome = "pending"
+	// outcomeSuccess is the outcome of the batches accepted by the rest of the pipeline.
+	outcomeSuccess outcome = "success"
+	// outcomeRetried is the outcome of the batches refused with a retryable
+	// error, which the sender is expected to send again as a new batch.
+	outcomeRetried outcome = "retried"
+	// outcomeDropped is the outcome of the batches refused with a permanent error.
+	outcomeDropped outcome = "dropped"
+)
+
+const defaultQueryLimit = 100
+
+type batch struct {
+	ID          string     `json:"batch_id"`
+	Pipeline    string     `json:"pipeline"`
+	Signal      string     `json:"signal"`
+	Items       int        `json:"items"`
+	ReceivedAt  time.Time  `json:"received_at"`
+	Outcome     outcome    `json:"outcome"`
+	Reason      string     `json:"reason,omitempty"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+
+	traceIDs []string
+}
+
+type deliveryTracker struct {
+	logger *zap.Logger
+	config *Config
+	now    func() time.Time
+
+	server *http.Server
+	addr   net.Addr
+	wg     sync.WaitGroup
+
+	mu sync.Mutex
+	// batches is a ring buffer of the last max_batches batches, next being the
+	// slot the next batch is stored in.
+	batches   []*batch
+	next      int
+	byID      map[string]*batch
+	byTraceID map[string][]string
+}
+
+var _ Tracker = (*deliveryTracker)(nil)
+
+func newDeliveryTracker(logger *zap.Logger, config *Config) *deliveryTracker {
+	return &deliveryTracker{
+		logger:    logger,
+		config:    config,
+		now:       time.Now,
+		batches:   make([]*batch, config.MaxBatches),
+		byID:      make(map[string]*batch),
+		byTraceID: make(map[string][]string),
+	}
+}
+
+func (dt *deliveryTracker) Start(_ context.Context, host component.Host) error {
+	ln, err := dt.config.HTTPServerSettings.ToListener()
+	if err != nil {
+		return fmt.Errorf("failed to bind to address %s: %w", dt.config.Endpoint, err)
+	}
+	dt.addr = ln.Addr()
+
+	dt.server = dt.config.HTTPServerSettings.ToServer(http.HandlerFunc(dt.serveHTTP))
+	dt.wg.Add(1)
+	go func() {
+		defer dt.wg.Done()
+		if errHTTP := dt.server.Serve(ln); errHTTP != nil && errHTTP != http.ErrServerClosed {
+			host.ReportFatalError(errHTTP)
+		}
+	}()
+	return nil
+}
+
+func (dt *deliveryTracker) Shutdown(context.Context) error {
+	if dt.server == nil {
+		return nil
+	}
+	err := dt.server.Close()
+	dt.wg.Wait()
+	return err
+}
+
+func (dt *deliveryTracker) Track(pipeline, signal string, items int, traceIDs []pdata.TraceID) string {
+	b := &batch{
+		ID:         newBatchID(),
+		Pipeline:   pipeline,
+		Signal:     signal,
+		Items:      items,
+		ReceivedAt: dt.now(),
+		Outcome:    outcomePending,
+	}
+	if dt.config.IndexTraceIDs {
+		b.traceIDs = uniqueTraceIDs(traceIDs)
+	}
+
+	dt.mu.Lock()
+	defer dt.mu.Unlock()
+	if evicted := dt.batches[dt.next]; evicted != nil {
+		dt.forget(evicted)
+	}
+	dt.batches[dt.next] = b
+	dt.next = (dt.next + 1) % len(dt.batches)
+	dt.byID[b.ID] = b
+	for _, traceID := range b.traceIDs {
+		dt.byTraceID[traceID] = append(dt.byTraceID[traceID], b.ID)
+	}
+	return b.ID
+}
+
+// forget removes an evicted batch from the indexes.
+func (dt *deliveryTracker) forget(b *batch) {
+	delete(dt.byID, b.ID)
+	for _, traceID := range b.traceIDs {
+		ids := dt.byTraceID[traceID]
+		for i, id := range ids {
+			if id == b.ID {
+				ids = append(ids[:i], ids[i+1:]...)
+				break
+			}
+		}
+		if len(ids) == 0 {
+			delete(dt.byTraceID, traceID)
+		} else {
+			dt.byTraceID[traceID] = ids
+		}
+	}
+}
+
+func (dt *deliveryTracker) Done(id string, err error) {
+	result, reason := outcomeSuccess, ""
+	if err != nil {
+		result, reason = outcomeRetried, err.Error()
+		if consumererror.IsPermanent(err) {
+			result = outcomeDropped
+		}
+	}
+
+	dt.mu.Lock()
+	b, ok := dt.byID[id]
+	var fields []zap.Field
+	if ok {
+		completedAt := dt.now()
+		b.Outcome, b.Reason, b.CompletedAt = result, reason, &completedAt
+		fields = []zap.Field{
+			zap.String("batch_id", id),
+			zap.String("pipeline", b.Pipeline),
+			zap.String("signal", b.Signal),
+			zap.Int("items", b.Items),
+			zap.String("outcome", string(result)),
+		}
+	}
+	dt.mu.Unlock()
+	if !ok {
+		// the batch was evicted while being delivered
+		return
+	}
+
+	switch result {
+	case outcomeSuccess:
+		dt.logger.Debug("Batch delivered", fields...)
+	case outcomeRetried:
+		dt.logger.Info("Batch refused, expecting a retry", append(fields, zap.String("reason", reason))...)
+	default:
+		dt.logger.Warn("Batch dropped", append(fields, zap.String("reason", reason))...)
+	}
+}
+
+type query struct {
+	batchID  string
+	traceID  string
+	pipeline string
+	outcome  outcome
+	limit    int
+}
+
+// find returns copies of the batches matching q, the most recent first.
+func (dt *deliveryTracker) find(q query) []batch {
+	dt.mu.Lock()
+	defer dt.mu.Unlock()
+
+	var candidates []*batch
+	switch {
+	case q.batchID != "":
+		if b, ok := dt.byID[q.batchID]; ok {
+			candidates = []*batch{b}
+		}
+	case q.traceID != "":
+		ids := dt.byTraceID[q.traceID]
+		for i := len(ids) - 1; i >= 0; i-- {
+			candidates = append(candidates, dt.byID[ids[i]])
+		}
+	default:
+		n := len(dt.batches)
+		for i := 1; i <= n; i++ {
+			if b := dt.batches[(dt.next-i+n)%n]; b != nil {
+				candidates = append(candidates, b)
+			}
+		}
+	}
+
+	result := []batch{}
+	for _, b := range candidates {
+		if len(result) == q.limit {
+			break
+		}
+		if (q.pipeline == "" || q.pipeline == b.Pipeline) && (q.outcome == "" || q.outcome == b.Outcome) {
+			result = append(result, *b)
+		}
+	}
+	return result
+}
+
+func (dt *deliveryTracker) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	params := r.URL.Query()
+	q := query{
+		batchID:  params.Get("batch_id"),
+		traceID:  params.Get("trace_id"),
+		pipeline: params.Get("pipeline"),
+		outcome:  outcome(params.Get("outcome")),
+		limit:    defaultQueryLimit,
+	}
+	if limit := params.Get("limit"); limit != "" {
+		l, err := strconv.Atoi(limit)
+		if err != nil || l <= 0 {
+			http.Error(w, fmt.Sprintf("invalid limit %q", limit), http.StatusBadRequest)
+			return
+		}
+		q.limit = l
+	}
+
+	batches := dt.find(q)
+	if q.batchID != "" && len(batches) == 0 {
+		http.Error(w, fmt.Sprintf("no batch with ID %q", q.batchID), http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(struct {
+		Batches []batch `json:"batches"`
+	}{batches}); err != nil {
+		dt.logger.Debug("Failed to write tracked batches", zap.Error(err))
+	}
+}
+
+func newBatchID() string {
+	var id [8]byte
+	_, _ = rand.Read(id[:])
+	return hex.EncodeToString(id[:])
+}
+
+func uniqueTraceIDs(traceIDs []pdata.TraceID) []string {
+	seen := make(map[pdata.TraceID]struct{}, len(traceIDs))
+	out := make([]string, 0, len(traceIDs))
+	for _, traceID := range traceIDs {
+		if _, ok := seen[traceID]; ok || traceID.IsEmpty() {
+			continue
+		}
+		seen[traceID] = struct{}{}
+		out = append(out, traceID.HexString())
+	}
+	return out
+}
@@ -0,0 +1,199 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deliverytrackingextension
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+var (
+	traceID1 = pdata.NewTraceID([16]byte{1})
+	traceID2 = pdata.NewTraceID([16]byte{2})
+)
+
+func newTestTracker(t *testing.T, maxBatches int, logger *zap.Logger) (*deliveryTracker, string) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = "localhost:0"
+	cfg.MaxBatches = maxBatches
+
+	dt := newDeliveryTracker(logger, cfg)
+	require.NoError(t, dt.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { assert.NoError(t, dt.Shutdown(context.Background())) })
+	return dt, "http://" + dt.addr.String()
+}
+
+type queryResponse struct {
+	Batches []batch `json:"batches"`
+}
+
+func get(t *testing.T, url string) (int, queryResponse) {
+	resp, err := http.Get(url)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	var qr queryResponse
+	if resp.StatusCode == http.StatusOK {
+		assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+		require.NoError(t, json.Unmarshal(body, &qr))
+	}
+	return resp.StatusCode, qr
+}
+
+func TestTrackOutcomes(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	dt, url := newTestTracker(t, 10, zap.New(core))
+	now := time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)
+	dt.now = func() time.Time { return now }
+
+	delivered := dt.Track("traces", SignalTraces, 3, []pdata.TraceID{traceID1, traceID1, pdata.NewTraceID([16]byte{})})
+	retried := dt.Track("traces", SignalTraces, 1, []pdata.TraceID{traceID2})
+	dropped := dt.Track("logs", SignalLogs, 2, nil)
+	pending := dt.Track("metrics", SignalMetrics, 5, nil)
+	assert.NotEqual(t, delivered, retried)
+
+	dt.Done(delivered, nil)
+	dt.Done(retried, errors.New("exporter queue is full"))
+	dt.Done(dropped, consumererror.Permanent(errors.New("invalid payload")))
+
+	status, qr := get(t, url)
+	require.Equal(t, http.StatusOK, status)
+	require.Len(t, qr.Batches, 4)
+	// the most recent batches come first
+	assert.Equal(t, pending, qr.Batches[0].ID)
+	assert.Equal(t, outcomePending, qr.Batches[0].Outcome)
+	assert.Nil(t, qr.Batches[0].CompletedAt)
+
+	assert.Equal(t, batch{
+		ID:          dropped,
+		Pipeline:    "logs",
+		Signal:      SignalLogs,
+		Items:       2,
+		ReceivedAt:  now,
+		Outcome:     outcomeDropped,
+		Reason:      "Permanent error: invalid payload",
+		CompletedAt: &now,
+	}, qr.Batches[1])
+	assert.Equal(t, outcomeRetried, qr.Batches[2].Outcome)
+	assert.Equal(t, "exporter queue is full", qr.Batches[2].Reason)
+	assert.Equal(t, outcomeSuccess, qr.Batches[3].Outcome)
+	assert.Empty(t, qr.Batches[3].Reason)
+
+	// every outcome is logged
+	require.Equal(t, 3, logs.Len())
+	assert.Equal(t, zapcore.DebugLevel, logs.All()[0].Level)
+	assert.Equal(t, delivered, logs.All()[0].ContextMap()["batch_id"])
+	assert.Equal(t, zapcore.InfoLevel, logs.All()[1].Level)
+	assert.Equal(t, zapcore.WarnLevel, logs.All()[2].Level)
+	assert.Equal(t, "Permanent error: invalid payload", logs.All()[2].ContextMap()["reason"])
+}
+
+func TestQueryFilters(t *testing.T) {
+	dt, url := newTestTracker(t, 10, zap.NewNop())
+	first := dt.Track("traces", SignalTraces, 1, []pdata.TraceID{traceID1})
+	second := dt.Track("traces/2", SignalTraces, 1, []pdata.TraceID{traceID1, traceID2})
+	third := dt.Track("logs", SignalLogs, 1, []pdata.TraceID{traceID2})
+	dt.Done(first, nil)
+	dt.Done(second, consumererror.Permanent(errors.New("bad")))
+
+	status, qr := get(t, url+"?batch_id="+second)
+	require.Equal(t, http.StatusOK, status)
+	require.Len(t, qr.Batches, 1)
+	assert.Equal(t, second, qr.Batches[0].ID)
+
+	status, qr = get(t, url+"?trace_id="+traceID1.HexString())
+	require.Equal(t, http.StatusOK, status)
+	require.Len(t, qr.Batches, 2)
+	assert.Equal(t, second, qr.Batches[0].ID)
+	assert.Equal(t, first, qr.Batches[1].ID)
+
+	_, qr = get(t, url+"?trace_id="+traceID2.HexString()+"&pipeline=logs")
+	require.Len(t, qr.Batches, 1)
+	assert.Equal(t, third, qr.Batches[0].ID)
+
+	_, qr = get(t, url+"?outcome=dropped")
+	require.Len(t, qr.Batches, 1)
+	assert.Equal(t, second, qr.Batches[0].ID)
+
+	_, qr = get(t, url+"?limit=1")
+	require.Len(t, qr.Batches, 1)
+	assert.Equal(t, third, qr.Batches[0].ID)
+
+	status, _ = get(t, url+"?batch_id=unknown")
+	assert.Equal(t, http.StatusNotFound, status)
+
+	status, _ = get(t, url+"?limit=-1")
+	assert.Equal(t, http.StatusBadRequest, status)
+}
+
+func TestTrackEvictsOldestBatches(t *testing.T) {
+	dt, url := newTestTracker(t, 2, zap.NewNop())
+	first := dt.Track("traces", SignalTraces, 1, []pdata.TraceID{traceID1})
+	second := dt.Track("traces", SignalTraces, 1, []pdata.TraceID{traceID1})
+	third := dt.Track("traces", SignalTraces, 1, []pdata.TraceID{traceID2})
+
+	_, qr := get(t, url)
+	require.Len(t, qr.Batches, 2)
+	assert.Equal(t, third, qr.Batches[0].ID)
+	assert.Equal(t, second, qr.Batches[1].ID)
+
+	status, _ := get(t, url+"?batch_id="+first)
+	assert.Equal(t, http.StatusNotFound, status)
+
+	_, qr = get(t, url+"?trace_id="+traceID1.HexString())
+	require.Len(t, qr.Batches, 1)
+	assert.Equal(t, second, qr.Batches[0].ID)
+
+	// the outcome of an evicted batch is ignored
+	dt.Done(first, nil)
+	dt.Track("traces", SignalTraces, 1, nil)
+	_, qr = get(t, url+"?trace_id="+traceID1.HexString())
+	assert.Empty(t, qr.Batches)
+	assert.Len(t, dt.byTraceID, 1)
+}
+
+func TestTrackWithoutTraceIndex(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.IndexTraceIDs = false
+	dt := newDeliveryTracker(zap.NewNop(), cfg)
+	dt.Track("traces", SignalTraces, 1, []pdata.TraceID{traceID1})
+	assert.Empty(t, dt.byTraceID)
+	assert.Empty(t, dt.find(query{traceID: traceID1.HexString(), limit: defaultQueryLimit}))
+}
+
+func TestMethodNotAllowed(t *testing.T) {
+	_, url := newTestTracker(t, 1, zap.NewNop())
+	resp, err := http.Post(url, "application/json", nil)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+	assert.Equal(t, http.MethodGet, resp.Header.Get("Allow"))
+}
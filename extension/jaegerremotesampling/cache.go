@@ -0,0 +1,81 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jaegerremotesampling
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/jaegertracing/jaeger/cmd/collector/app/sampling/strategystore"
+	"github.com/jaegertracing/jaeger/thrift-gen/sampling"
+	"go.uber.org/zap"
+)
+
+type cacheEntry struct {
+	strategy  *sampling.SamplingStrategyResponse
+	fetchedAt time.Time
+}
+
+// cachingStrategyStore serves the strategies fetched from an upstream store
+// for ttl before fetching them again. When the upstream fails, the last
+// strategy fetched for the service is served until it succeeds again.
+type cachingStrategyStore struct {
+	logger   *zap.Logger
+	upstream strategystore.StrategyStore
+	ttl      time.Duration
+	now      func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]*cacheEntry
+}
+
+var _ strategystore.StrategyStore = (*cachingStrategyStore)(nil)
+
+func newCachingStrategyStore(logger *zap.Logger, upstream strategystore.StrategyStore, ttl time.Duration) *cachingStrategyStore {
+	return &cachingStrategyStore{
+		logger:   logger,
+		upstream: upstream,
+		ttl:      ttl,
+		now:      time.Now,
+		entries:  make(map[string]*cacheEntry),
+	}
+}
+
+func (c *cachingStrategyStore) GetSamplingStrategy(ctx context.Context, serviceName string) (*sampling.SamplingStrategyResponse, error) {
+	now := c.now()
+
+	c.mu.Lock()
+	entry, ok := c.entries[serviceName]
+	c.mu.Unlock()
+	if ok && now.Sub(entry.fetchedAt) < c.ttl {
+		return entry.strategy, nil
+	}
+
+	strategy, err := c.upstream.GetSamplingStrategy(ctx, serviceName)
+	if err != nil {
+		if ok {
+			c.logger.Warn("Failed to fetch the sampling strategy, serving the cached one",
+				zap.String("service", serviceName), zap.Error(err))
+			return entry.strategy, nil
+		}
+		return nil, err
+	}
+
+	c.mu.Lock()
+	c.entries[serviceName] = &cacheEntry{strategy: strategy, fetchedAt: now}
+	c.mu.Unlock()
+	return strategy, nil
+}
@@ -0,0 +1,82 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jaegerremotesampling
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jaegertracing/jaeger/thrift-gen/sampling"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type fakeUpstream struct {
+	calls    int
+	rate     float64
+	err      error
+	services []string
+}
+
+func (u *fakeUpstream) GetSamplingStrategy(_ context.Context, serviceName string) (*sampling.SamplingStrategyResponse, error) {
+	u.calls++
+	u.services = append(u.services, serviceName)
+	if u.err != nil {
+		return nil, u.err
+	}
+	return &sampling.SamplingStrategyResponse{
+		StrategyType:          sampling.SamplingStrategyType_PROBABILISTIC,
+		ProbabilisticSampling: &sampling.ProbabilisticSamplingStrategy{SamplingRate: u.rate},
+	}, nil
+}
+
+func TestCachingStrategyStore(t *testing.T) {
+	upstream := &fakeUpstream{rate: 0.5}
+	store := newCachingStrategyStore(zap.NewNop(), upstream, time.Minute)
+	now := time.Unix(1000, 0)
+	store.now = func() time.Time { return now }
+
+	strategy, err := store.GetSamplingStrategy(context.Background(), "foo")
+	require.NoError(t, err)
+	assert.Equal(t, 0.5, strategy.GetProbabilisticSampling().GetSamplingRate())
+
+	// The strategy is served from the cache until it expires.
+	upstream.rate = 0.1
+	now = now.Add(30 * time.Second)
+	strategy, err = store.GetSamplingStrategy(context.Background(), "foo")
+	require.NoError(t, err)
+	assert.Equal(t, 0.5, strategy.GetProbabilisticSampling().GetSamplingRate())
+	assert.Equal(t, 1, upstream.calls)
+
+	now = now.Add(time.Minute)
+	strategy, err = store.GetSamplingStrategy(context.Background(), "foo")
+	require.NoError(t, err)
+	assert.Equal(t, 0.1, strategy.GetProbabilisticSampling().GetSamplingRate())
+	assert.Equal(t, 2, upstream.calls)
+
+	// The expired strategy is served while the upstream fails.
+	upstream.err = errors.New("unavailable")
+	now = now.Add(2 * time.Minute)
+	strategy, err = store.GetSamplingStrategy(context.Background(), "foo")
+	require.NoError(t, err)
+	assert.Equal(t, 0.1, strategy.GetProbabilisticSampling().GetSamplingRate())
+
+	_, err = store.GetSamplingStrategy(context.Background(), "bar")
+	assert.EqualError(t, err, "unavailable")
+	assert.Equal(t, []string{"foo", "foo", "foo", "bar"}, upstream.services)
+}
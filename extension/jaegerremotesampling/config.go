@@ -0,0 +1,78 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jaegerremotesampling
+
+import (
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configgrpc"
+	"go.opentelemetry.io/collector/config/confighttp"
+)
+
+type Config struct {
+	config.ExtensionSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+
+	// HTTPServerSettings is where the strategies are served to the SDKs, on
+	// the /sampling path. Default endpoint is localhost:5778.
+	HTTPServerSettings *confighttp.HTTPServerSettings `mapstructure:"http"`
+
+	// GRPCServerSettings is where the strategies are served with the Jaeger
+	// SamplingManager gRPC service, e.g. to other collectors. The transport
+	// defaults to tcp. Disabled by default.
+	GRPCServerSettings *configgrpc.GRPCServerSettings `mapstructure:"grpc"`
+
+	// Source is where the strategies are read from.
+	Source Source `mapstructure:"source"`
+}
+
+// Source is where the strategies are read from: either a strategies file or
+// an upstream serving the Jaeger SamplingManager gRPC service.
+type Source struct {
+	// Remote is the upstream the strategies are fetched from, e.g. a Jaeger
+	// collector or another collector serving them over gRPC.
+	Remote *configgrpc.GRPCClientSettings `mapstructure:"remote"`
+
+	// CacheTTL is how long the strategies fetched from the remote are served
+	// before being fetched again. Default is 1m.
+	CacheTTL time.Duration `mapstructure:"cache_ttl"`
+
+	// File is the path or the URL of a Jaeger strategies file.
+	File string `mapstructure:"file"`
+
+	// ReloadInterval is the interval at which the file is read again. The
+	// file is only read once by default.
+	ReloadInterval time.Duration `mapstructure:"reload_interval"`
+}
+
+func (cfg *Config) Validate() error {
+	if cfg.HTTPServerSettings == nil && cfg.GRPCServerSettings == nil {
+		return errors.New("at least one of http or grpc must be enabled")
+	}
+	if (cfg.Source.Remote == nil) == (cfg.Source.File == "") {
+		return errors.New("exactly one of source.remote or source.file must be set")
+	}
+	if cfg.Source.Remote != nil && cfg.Source.Remote.Endpoint == "" {
+		return errors.New("source.remote.endpoint must be set")
+	}
+	if cfg.Source.CacheTTL < 0 {
+		return errors.New("source.cache_ttl must not be negative")
+	}
+	if cfg.Source.ReloadInterval < 0 {
+		return errors.New("source.reload_interval must not be negative")
+	}
+	return nil
+}
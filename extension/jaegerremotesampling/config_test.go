@@ -0,0 +1,97 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jaegerremotesampling
+
+import (
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configgrpc"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/config/confignet"
+	"go.opentelemetry.io/collector/config/configtest"
+	"go.opentelemetry.io/collector/config/configtls"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	require.NoError(t, err)
+
+	factories.Extensions[typeStr] = NewFactory()
+
+	cfg, err := configtest.LoadConfigAndValidate(path.Join(".", "testdata", "config.yaml"), factories)
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	defaultCfg := createDefaultConfig().(*Config)
+	defaultCfg.Source.File = "/etc/otel/sampling_strategies.json"
+	assert.Equal(t, defaultCfg, cfg.Extensions[config.NewID(typeStr)])
+	assert.Equal(t,
+		&Config{
+			ExtensionSettings: config.NewExtensionSettings(config.NewIDWithName(typeStr, "remote")),
+			HTTPServerSettings: &confighttp.HTTPServerSettings{
+				Endpoint: "localhost:5779",
+			},
+			GRPCServerSettings: &configgrpc.GRPCServerSettings{
+				NetAddr: confignet.NetAddr{
+					Endpoint: "localhost:14251",
+				},
+			},
+			Source: Source{
+				Remote: &configgrpc.GRPCClientSettings{
+					Endpoint: "jaeger-collector:14250",
+					TLSSetting: configtls.TLSClientSetting{
+						Insecure: true,
+					},
+				},
+				CacheTTL: 5 * time.Minute,
+			},
+		},
+		cfg.Extensions[config.NewIDWithName(typeStr, "remote")],
+	)
+}
+
+func TestValidateConfig(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	assert.EqualError(t, cfg.Validate(), "exactly one of source.remote or source.file must be set")
+
+	cfg.Source.File = "strategies.json"
+	assert.NoError(t, cfg.Validate())
+
+	cfg.Source.Remote = &configgrpc.GRPCClientSettings{}
+	assert.EqualError(t, cfg.Validate(), "exactly one of source.remote or source.file must be set")
+
+	cfg.Source.File = ""
+	assert.EqualError(t, cfg.Validate(), "source.remote.endpoint must be set")
+
+	cfg.Source.Remote.Endpoint = "localhost:14250"
+	assert.NoError(t, cfg.Validate())
+
+	cfg.Source.CacheTTL = -time.Second
+	assert.EqualError(t, cfg.Validate(), "source.cache_ttl must not be negative")
+
+	cfg.Source.CacheTTL = 0
+	cfg.Source.ReloadInterval = -time.Second
+	assert.EqualError(t, cfg.Validate(), "source.reload_interval must not be negative")
+
+	cfg.Source.ReloadInterval = 0
+	cfg.HTTPServerSettings = nil
+	assert.EqualError(t, cfg.Validate(), "at least one of http or grpc must be enabled")
+}
@@ -0,0 +1,178 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jaegerremotesampling
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/gorilla/mux"
+	jaegergrpc "github.com/jaegertracing/jaeger/cmd/agent/app/configmanager/grpc"
+	collectorsampling "github.com/jaegertracing/jaeger/cmd/collector/app/sampling"
+	"github.com/jaegertracing/jaeger/cmd/collector/app/sampling/strategystore"
+	"github.com/jaegertracing/jaeger/pkg/clientcfg/clientcfghttp"
+	"github.com/jaegertracing/jaeger/plugin/sampling/strategystore/static"
+	"github.com/jaegertracing/jaeger/proto-gen/api_v2"
+	"github.com/uber/jaeger-lib/metrics"
+	"go.opentelemetry.io/collector/component"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+)
+
+// samplingExtension serves the Jaeger remote sampling API, the strategies
+// being read from a strategies file or fetched from an upstream.
+type samplingExtension struct {
+	logger *zap.Logger
+	config *Config
+
+	store     strategystore.StrategyStore
+	closeFile func()
+	conn      *grpc.ClientConn
+
+	httpServer *http.Server
+	grpcServer *grpc.Server
+	wg         sync.WaitGroup
+}
+
+func newSamplingExtension(logger *zap.Logger, config *Config) *samplingExtension {
+	return &samplingExtension{
+		logger: logger,
+		config: config,
+	}
+}
+
+func (se *samplingExtension) Start(_ context.Context, host component.Host) error {
+	if err := se.startSource(host); err != nil {
+		return err
+	}
+
+	if se.config.HTTPServerSettings != nil {
+		if err := se.startHTTPServer(host); err != nil {
+			return err
+		}
+	}
+
+	if se.config.GRPCServerSettings != nil {
+		if err := se.startGRPCServer(host); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (se *samplingExtension) startSource(host component.Host) error {
+	source := se.config.Source
+	if source.File != "" {
+		store, err := static.NewStrategyStore(static.Options{
+			StrategiesFile: source.File,
+			ReloadInterval: source.ReloadInterval,
+		}, se.logger)
+		if err != nil {
+			return fmt.Errorf("failed to load the strategies file: %w", err)
+		}
+		se.store = store
+		if closer, ok := store.(interface{ Close() }); ok {
+			se.closeFile = closer.Close
+		}
+		return nil
+	}
+
+	opts, err := source.Remote.ToDialOptions(host.GetExtensions())
+	if err != nil {
+		return fmt.Errorf("failed to create the dial options of the remote source: %w", err)
+	}
+	conn, err := grpc.Dial(source.Remote.Endpoint, opts...)
+	if err != nil {
+		return fmt.Errorf("failed to connect to the remote source %s: %w", source.Remote.Endpoint, err)
+	}
+	se.conn = conn
+	se.store = newCachingStrategyStore(se.logger, jaegergrpc.NewConfigManager(conn), source.CacheTTL)
+	return nil
+}
+
+func (se *samplingExtension) startHTTPServer(host component.Host) error {
+	settings := se.config.HTTPServerSettings
+	ln, err := settings.ToListener()
+	if err != nil {
+		return fmt.Errorf("failed to bind to address %s: %w", settings.Endpoint, err)
+	}
+
+	router := mux.NewRouter()
+	clientcfghttp.NewHTTPHandler(clientcfghttp.HTTPHandlerParams{
+		ConfigManager:  &clientcfghttp.ConfigManager{SamplingStrategyStore: se.store},
+		MetricsFactory: metrics.NullFactory,
+	}).RegisterRoutes(router)
+
+	se.httpServer = settings.ToServer(router)
+	se.wg.Add(1)
+	go func() {
+		defer se.wg.Done()
+		if errHTTP := se.httpServer.Serve(ln); errHTTP != nil && errHTTP != http.ErrServerClosed {
+			host.ReportFatalError(errHTTP)
+		}
+	}()
+	return nil
+}
+
+func (se *samplingExtension) startGRPCServer(host component.Host) error {
+	settings := *se.config.GRPCServerSettings
+	if settings.NetAddr.Transport == "" {
+		settings.NetAddr.Transport = "tcp"
+	}
+	opts, err := settings.ToServerOption(host.GetExtensions())
+	if err != nil {
+		return err
+	}
+	ln, err := settings.ToListener()
+	if err != nil {
+		return fmt.Errorf("failed to bind to address %s: %w", settings.NetAddr.Endpoint, err)
+	}
+
+	se.grpcServer = grpc.NewServer(opts...)
+	api_v2.RegisterSamplingManagerServer(se.grpcServer, collectorsampling.NewGRPCHandler(se.store))
+	se.wg.Add(1)
+	go func() {
+		defer se.wg.Done()
+		if errGRPC := se.grpcServer.Serve(ln); errGRPC != nil && !errors.Is(errGRPC, grpc.ErrServerStopped) {
+			host.ReportFatalError(errGRPC)
+		}
+	}()
+	return nil
+}
+
+func (se *samplingExtension) Shutdown(context.Context) error {
+	var err error
+	if se.httpServer != nil {
+		err = se.httpServer.Close()
+	}
+	if se.grpcServer != nil {
+		se.grpcServer.Stop()
+	}
+	se.wg.Wait()
+
+	if se.conn != nil {
+		if errConn := se.conn.Close(); err == nil {
+			err = errConn
+		}
+	}
+	if se.closeFile != nil {
+		se.closeFile()
+	}
+	return err
+}
@@ -0,0 +1,100 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jaegerremotesampling
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/jaegertracing/jaeger/thrift-gen/sampling"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configgrpc"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/config/confignet"
+	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/testutil"
+	"go.uber.org/zap"
+)
+
+func getStrategy(t *testing.T, endpoint, service string) *sampling.SamplingStrategyResponse {
+	resp, err := http.Get(fmt.Sprintf("http://%s/sampling?service=%s", endpoint, service))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	body, err := ioutil.ReadAll(resp.Body)
+	require.NoError(t, err)
+	strategy := &sampling.SamplingStrategyResponse{}
+	require.NoError(t, json.Unmarshal(body, strategy))
+	return strategy
+}
+
+func TestServeStrategies(t *testing.T) {
+	// The strategies read from the file by the first extension are fetched
+	// from its gRPC server by the second one.
+	fileCfg := createDefaultConfig().(*Config)
+	fileCfg.HTTPServerSettings = &confighttp.HTTPServerSettings{Endpoint: testutil.GetAvailableLocalAddress(t)}
+	fileCfg.GRPCServerSettings = &configgrpc.GRPCServerSettings{NetAddr: confignet.NetAddr{Endpoint: testutil.GetAvailableLocalAddress(t)}}
+	fileCfg.Source.File = "testdata/strategies.json"
+
+	fileExt := newSamplingExtension(zap.NewNop(), fileCfg)
+	require.NoError(t, fileExt.Start(context.Background(), componenttest.NewNopHost()))
+	defer fileExt.Shutdown(context.Background())
+
+	remoteCfg := createDefaultConfig().(*Config)
+	remoteCfg.HTTPServerSettings = &confighttp.HTTPServerSettings{Endpoint: testutil.GetAvailableLocalAddress(t)}
+	remoteCfg.Source.Remote = &configgrpc.GRPCClientSettings{
+		Endpoint:   fileCfg.GRPCServerSettings.NetAddr.Endpoint,
+		TLSSetting: configtls.TLSClientSetting{Insecure: true},
+	}
+
+	remoteExt := newSamplingExtension(zap.NewNop(), remoteCfg)
+	require.NoError(t, remoteExt.Start(context.Background(), componenttest.NewNopHost()))
+	defer remoteExt.Shutdown(context.Background())
+
+	for _, endpoint := range []string{fileCfg.HTTPServerSettings.Endpoint, remoteCfg.HTTPServerSettings.Endpoint} {
+		foo := getStrategy(t, endpoint, "foo")
+		assert.Equal(t, sampling.SamplingStrategyType_PROBABILISTIC, foo.StrategyType)
+		assert.Equal(t, 0.8, foo.GetProbabilisticSampling().GetSamplingRate())
+
+		bar := getStrategy(t, endpoint, "bar")
+		assert.Equal(t, sampling.SamplingStrategyType_RATE_LIMITING, bar.StrategyType)
+		assert.Equal(t, int16(5), bar.GetRateLimitingSampling().GetMaxTracesPerSecond())
+
+		other := getStrategy(t, endpoint, "other")
+		assert.Equal(t, 0.5, other.GetProbabilisticSampling().GetSamplingRate())
+	}
+}
+
+func TestStartErrors(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Source.File = "testdata/missing.json"
+	ext := newSamplingExtension(zap.NewNop(), cfg)
+	assert.Error(t, ext.Start(context.Background(), componenttest.NewNopHost()))
+	assert.NoError(t, ext.Shutdown(context.Background()))
+
+	cfg = createDefaultConfig().(*Config)
+	cfg.HTTPServerSettings.Endpoint = "invalid"
+	cfg.Source.File = "testdata/strategies.json"
+	ext = newSamplingExtension(zap.NewNop(), cfg)
+	assert.Error(t, ext.Start(context.Background(), componenttest.NewNopHost()))
+	assert.NoError(t, ext.Shutdown(context.Background()))
+}
@@ -0,0 +1,13 @@
+module github.com/open-telemetry/opentelemetry-collector-contrib/extension/jaegerremotesampling
+
+go 1.16
+
+require (
+	github.com/gorilla/mux v1.8.0
+	github.com/jaegertracing/jaeger v1.22.0
+	github.com/stretchr/testify v1.7.0
+	github.com/uber/jaeger-lib v2.4.1+incompatible
+	go.opentelemetry.io/collector v0.27.1-0.20210603182316-5369d7e9e83e
+	go.uber.org/zap v1.17.0
+	google.golang.org/grpc v1.38.0
+)
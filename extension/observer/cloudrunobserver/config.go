@@ -0,0 +1,44 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudrunobserver
+
+import (
+	"time"
+
+	"go.opentelemetry.io/collector/config"
+)
+
+// Config defines configuration for the Cloud Run observer.
+type Config struct {
+	config.ExtensionSettings `mapstructure:",squash"`
+
+	// ProjectID is the Google Cloud project to discover services in. Defaults to the project
+	// of the credentials.
+	ProjectID string `mapstructure:"project_id"`
+
+	// Regions restricts the discovery to services of these regions. All the regions are
+	// discovered if empty.
+	Regions []string `mapstructure:"regions"`
+
+	// CredentialsFile is the path of a service account key file. Application default
+	// credentials are used if empty.
+	CredentialsFile string `mapstructure:"credentials_file"`
+
+	// Endpoint is the Cloud Run Admin API endpoint.
+	Endpoint string `mapstructure:"endpoint"`
+
+	// RefreshInterval determines how often the services are listed for changes.
+	RefreshInterval time.Duration `mapstructure:"refresh_interval"`
+}
@@ -0,0 +1,232 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudrunobserver
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.uber.org/zap"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer"
+)
+
+const (
+	apiScope    = "https://www.googleapis.com/auth/cloud-platform"
+	apiTimeout  = 30 * time.Second
+	regionLabel = "cloud.googleapis.com/location"
+)
+
+// serviceList is the subset of the response of the Cloud Run Admin API listing services
+// that is used by the observer.
+// See https://cloud.google.com/run/docs/reference/rest/v1/namespaces.services/list
+type serviceList struct {
+	Items    []service `json:"items"`
+	Metadata struct {
+		Continue string `json:"continue"`
+	} `json:"metadata"`
+}
+
+type service struct {
+	Metadata struct {
+		Name        string            `json:"name"`
+		Labels      map[string]string `json:"labels"`
+		Annotations map[string]string `json:"annotations"`
+	} `json:"metadata"`
+	Status struct {
+		URL                     string `json:"url"`
+		LatestReadyRevisionName string `json:"latestReadyRevisionName"`
+		Conditions              []struct {
+			Type   string `json:"type"`
+			Status string `json:"status"`
+		} `json:"conditions"`
+	} `json:"status"`
+}
+
+type cloudRunObserver struct {
+	observer.EndpointsWatcher
+	lister *endpointsLister
+}
+
+type endpointsLister struct {
+	logger     *zap.Logger
+	config     *Config
+	projectID  string
+	regions    map[string]bool
+	httpClient *http.Client
+}
+
+var _ component.Extension = (*cloudRunObserver)(nil)
+
+func newObserver(logger *zap.Logger, config *Config) (component.Extension, error) {
+	lister := &endpointsLister{
+		logger:    logger,
+		config:    config,
+		projectID: config.ProjectID,
+		regions:   map[string]bool{},
+	}
+	for _, r := range config.Regions {
+		lister.regions[r] = true
+	}
+	return &cloudRunObserver{
+		EndpointsWatcher: observer.EndpointsWatcher{
+			RefreshInterval: config.RefreshInterval,
+			Endpointslister: lister,
+		},
+		lister: lister,
+	}, nil
+}
+
+func (c *cloudRunObserver) Start(ctx context.Context, _ component.Host) error {
+	creds, err := findCredentials(ctx, c.lister.config.CredentialsFile)
+	if err != nil {
+		return fmt.Errorf("failed to find Google Cloud credentials: %w", err)
+	}
+	if c.lister.projectID == "" {
+		c.lister.projectID = creds.ProjectID
+	}
+	if c.lister.projectID == "" {
+		return errors.New("project_id must be specified as it cannot be found from the credentials")
+	}
+
+	c.lister.httpClient = &http.Client{
+		Timeout: apiTimeout,
+		Transport: &oauth2.Transport{
+			Source: creds.TokenSource,
+		},
+	}
+	return nil
+}
+
+func findCredentials(ctx context.Context, credentialsFile string) (*google.Credentials, error) {
+	if credentialsFile == "" {
+		return google.FindDefaultCredentials(ctx, apiScope)
+	}
+	data, err := ioutil.ReadFile(credentialsFile)
+	if err != nil {
+		return nil, err
+	}
+	return google.CredentialsFromJSON(ctx, data, apiScope)
+}
+
+func (c *cloudRunObserver) Shutdown(context.Context) error {
+	c.StopListAndWatch()
+	return nil
+}
+
+func (e *endpointsLister) ListEndpoints() []observer.Endpoint {
+	services, err := e.listServices()
+	if err != nil {
+		e.logger.Error("Could not list Cloud Run services", zap.Error(err))
+		return nil
+	}
+	return e.collectEndpoints(services)
+}
+
+func (e *endpointsLister) listServices() ([]service, error) {
+	listURL := fmt.Sprintf("%s/apis/serving.knative.dev/v1/namespaces/%s/services",
+		strings.TrimSuffix(e.config.Endpoint, "/"), url.PathEscape(e.projectID))
+
+	var services []service
+	continueToken := ""
+	for {
+		reqURL := listURL
+		if continueToken != "" {
+			reqURL += "?continue=" + url.QueryEscape(continueToken)
+		}
+		list, err := e.fetchServiceList(reqURL)
+		if err != nil {
+			return nil, err
+		}
+		services = append(services, list.Items...)
+		if list.Metadata.Continue == "" {
+			return services, nil
+		}
+		continueToken = list.Metadata.Continue
+	}
+}
+
+func (e *endpointsLister) fetchServiceList(reqURL string) (*serviceList, error) {
+	resp, err := e.httpClient.Get(reqURL)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+
+	list := &serviceList{}
+	if err := json.NewDecoder(resp.Body).Decode(list); err != nil {
+		return nil, fmt.Errorf("failed to decode service list: %w", err)
+	}
+	return list, nil
+}
+
+func (e *endpointsLister) collectEndpoints(services []service) []observer.Endpoint {
+	var endpoints []observer.Endpoint
+	for _, s := range services {
+		region := s.Metadata.Labels[regionLabel]
+		if len(e.regions) > 0 && !e.regions[region] {
+			continue
+		}
+		if !isReady(s) {
+			continue
+		}
+		u, err := url.Parse(s.Status.URL)
+		if err != nil || u.Hostname() == "" {
+			e.logger.Debug("Skipping service with invalid URL", zap.String("service", s.Metadata.Name), zap.String("url", s.Status.URL))
+			continue
+		}
+
+		endpoints = append(endpoints, observer.Endpoint{
+			ID:     observer.EndpointID(fmt.Sprintf("%s/%s/%s", e.projectID, region, s.Metadata.Name)),
+			Target: u.Hostname() + ":443",
+			Details: &observer.CloudRunService{
+				Name:        s.Metadata.Name,
+				Project:     e.projectID,
+				Region:      region,
+				URL:         s.Status.URL,
+				Revision:    s.Status.LatestReadyRevisionName,
+				Labels:      s.Metadata.Labels,
+				Annotations: s.Metadata.Annotations,
+			},
+		})
+	}
+	return endpoints
+}
+
+// isReady returns whether the service has a revision ready to serve requests.
+func isReady(s service) bool {
+	if s.Status.URL == "" {
+		return false
+	}
+	for _, c := range s.Status.Conditions {
+		if c.Type == "Ready" {
+			return c.Status == "True"
+		}
+	}
+	return false
+}
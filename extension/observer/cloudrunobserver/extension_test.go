@@ -0,0 +1,249 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudrunobserver
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer"
+)
+
+func newAPIServer(t *testing.T) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/apis/serving.knative.dev/v1/namespaces/my-project/services" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		switch r.URL.Query().Get("continue") {
+		case "":
+			http.ServeFile(w, r, path.Join(".", "testdata", "services_page1.json"))
+		case "next page":
+			http.ServeFile(w, r, path.Join(".", "testdata", "services_page2.json"))
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func newTestObserver(t *testing.T, cfg *Config) *cloudRunObserver {
+	ext, err := newObserver(zap.NewNop(), cfg)
+	require.NoError(t, err)
+	o := ext.(*cloudRunObserver)
+	// Bypass Start, which requires Google Cloud credentials.
+	o.lister.httpClient = http.DefaultClient
+	return o
+}
+
+var (
+	apiEndpoint = observer.Endpoint{
+		ID:     "my-project/us-central1/api",
+		Target: "api-abc123-uc.a.run.app:443",
+		Details: &observer.CloudRunService{
+			Name:     "api",
+			Project:  "my-project",
+			Region:   "us-central1",
+			URL:      "https://api-abc123-uc.a.run.app",
+			Revision: "api-00003-xyz",
+			Labels: map[string]string{
+				"cloud.googleapis.com/location": "us-central1",
+				"team":                          "payments",
+			},
+			Annotations: map[string]string{
+				"run.googleapis.com/ingress": "all",
+			},
+		},
+	}
+	frontendEndpoint = observer.Endpoint{
+		ID:     "my-project/europe-west1/frontend",
+		Target: "frontend-abc123-ew.a.run.app:443",
+		Details: &observer.CloudRunService{
+			Name:     "frontend",
+			Project:  "my-project",
+			Region:   "europe-west1",
+			URL:      "https://frontend-abc123-ew.a.run.app",
+			Revision: "frontend-00012-abc",
+			Labels: map[string]string{
+				"cloud.googleapis.com/location": "europe-west1",
+			},
+		},
+	}
+)
+
+func TestListEndpoints(t *testing.T) {
+	server := newAPIServer(t)
+
+	tests := []struct {
+		name    string
+		regions []string
+		want    []observer.Endpoint
+	}{
+		{
+			name: "all regions",
+			want: []observer.Endpoint{apiEndpoint, frontendEndpoint},
+		},
+		{
+			name:    "filtered regions",
+			regions: []string{"europe-west1"},
+			want:    []observer.Endpoint{frontendEndpoint},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.Endpoint = server.URL + "/"
+			cfg.ProjectID = "my-project"
+			cfg.Regions = tt.regions
+			o := newTestObserver(t, cfg)
+			assert.Equal(t, tt.want, o.lister.ListEndpoints())
+		})
+	}
+}
+
+func TestListAndWatch(t *testing.T) {
+	server := newAPIServer(t)
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = server.URL
+	cfg.ProjectID = "my-project"
+	cfg.RefreshInterval = time.Hour
+	o := newTestObserver(t, cfg)
+
+	mn := mockNotifier{endpointsMap: map[observer.EndpointID]observer.Endpoint{}}
+	o.ListAndWatch(mn)
+	require.NoError(t, o.Shutdown(context.Background()))
+
+	assert.Equal(t, map[observer.EndpointID]observer.Endpoint{
+		apiEndpoint.ID:      apiEndpoint,
+		frontendEndpoint.ID: frontendEndpoint,
+	}, mn.endpointsMap)
+}
+
+func TestListEndpointsFailure(t *testing.T) {
+	server := newAPIServer(t)
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = server.URL
+	cfg.ProjectID = "unknown-project"
+	o := newTestObserver(t, cfg)
+
+	assert.Empty(t, o.lister.ListEndpoints())
+}
+
+func TestStartWithCredentialsFile(t *testing.T) {
+	dir := t.TempDir()
+
+	serviceAccount := filepath.Join(dir, "service_account.json")
+	require.NoError(t, ioutil.WriteFile(serviceAccount, []byte(`{
+		"type": "service_account",
+		"project_id": "key-project",
+		"private_key_id": "1",
+		"private_key": "unused",
+		"client_email": "otel@key-project.iam.gserviceaccount.com",
+		"client_id": "1",
+		"token_uri": "https://oauth2.googleapis.com/token"
+	}`), 0600))
+
+	authorizedUser := filepath.Join(dir, "authorized_user.json")
+	require.NoError(t, ioutil.WriteFile(authorizedUser, []byte(`{
+		"type": "authorized_user",
+		"client_id": "1",
+		"client_secret": "secret",
+		"refresh_token": "token"
+	}`), 0600))
+
+	tests := []struct {
+		name            string
+		credentialsFile string
+		projectID       string
+		wantProjectID   string
+		wantErr         string
+	}{
+		{
+			name:            "project from credentials",
+			credentialsFile: serviceAccount,
+			wantProjectID:   "key-project",
+		},
+		{
+			name:            "configured project",
+			credentialsFile: serviceAccount,
+			projectID:       "my-project",
+			wantProjectID:   "my-project",
+		},
+		{
+			name:            "no project",
+			credentialsFile: authorizedUser,
+			wantErr:         "project_id must be specified as it cannot be found from the credentials",
+		},
+		{
+			name:            "missing file",
+			credentialsFile: filepath.Join(dir, "missing.json"),
+			wantErr:         "failed to find Google Cloud credentials",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.CredentialsFile = tt.credentialsFile
+			cfg.ProjectID = tt.projectID
+			ext, err := newObserver(zap.NewNop(), cfg)
+			require.NoError(t, err)
+
+			err = ext.Start(context.Background(), componenttest.NewNopHost())
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantProjectID, ext.(*cloudRunObserver).lister.projectID)
+		})
+	}
+}
+
+type mockNotifier struct {
+	endpointsMap map[observer.EndpointID]observer.Endpoint
+}
+
+func (m mockNotifier) OnAdd(added []observer.Endpoint) {
+	for _, e := range added {
+		m.endpointsMap[e.ID] = e
+	}
+}
+
+func (m mockNotifier) OnRemove(removed []observer.Endpoint) {
+	for _, e := range removed {
+		delete(m.endpointsMap, e.ID)
+	}
+}
+
+func (m mockNotifier) OnChange(changed []observer.Endpoint) {
+	for _, e := range changed {
+		m.endpointsMap[e.ID] = e
+	}
+}
@@ -0,0 +1,13 @@
+module github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer/cloudrunobserver
+
+go 1.16
+
+require (
+	github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer v0.0.0-00010101000000-000000000000
+	github.com/stretchr/testify v1.7.0
+	go.opentelemetry.io/collector v0.27.1-0.20210603182316-5369d7e9e83e
+	go.uber.org/zap v1.17.0
+	golang.org/x/oauth2 v0.0.0-20210514164344-f6687ab2804c
+)
+
+replace github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer => ../
@@ -0,0 +1,37 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ecstaskobserver
+
+import (
+	"time"
+
+	"go.opentelemetry.io/collector/config"
+)
+
+// Config defines configuration for the ECS task observer.
+type Config struct {
+	config.ExtensionSettings `mapstructure:",squash"`
+
+	// Endpoint is the ECS task metadata endpoint (version 4). Defaults to the value of the
+	// ECS_CONTAINER_METADATA_URI_V4 environment variable set by the ECS agent.
+	Endpoint string `mapstructure:"endpoint"`
+
+	// RefreshInterval determines how often the task metadata is polled for changes.
+	RefreshInterval time.Duration `mapstructure:"refresh_interval"`
+
+	// PortLabels is a list of container labels checked in order for the port of a container.
+	// Containers without any of these labels are discovered on each of their mapped ports.
+	PortLabels []string `mapstructure:"port_labels"`
+}
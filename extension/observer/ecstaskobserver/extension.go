@@ -0,0 +1,235 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ecstaskobserver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer"
+)
+
+const (
+	metadataEndpointEnvVar = "ECS_CONTAINER_METADATA_URI_V4"
+	metadataTimeout        = 5 * time.Second
+)
+
+// taskMetadata is the subset of the task metadata returned by the ECS task metadata endpoint
+// that is used by the observer.
+// See https://docs.aws.amazon.com/AmazonECS/latest/developerguide/task-metadata-endpoint-v4.html
+type taskMetadata struct {
+	Cluster    string              `json:"Cluster"`
+	TaskARN    string              `json:"TaskARN"`
+	Family     string              `json:"Family"`
+	Containers []containerMetadata `json:"Containers"`
+}
+
+type containerMetadata struct {
+	DockerID    string            `json:"DockerId"`
+	Name        string            `json:"Name"`
+	Image       string            `json:"Image"`
+	Labels      map[string]string `json:"Labels"`
+	KnownStatus string            `json:"KnownStatus"`
+	Networks    []struct {
+		IPv4Addresses []string `json:"IPv4Addresses"`
+	} `json:"Networks"`
+	Ports []struct {
+		ContainerPort uint16 `json:"ContainerPort"`
+		Protocol      string `json:"Protocol"`
+	} `json:"Ports"`
+}
+
+type ecsTaskObserver struct {
+	observer.EndpointsWatcher
+	lister *endpointsLister
+}
+
+type endpointsLister struct {
+	logger     *zap.Logger
+	config     *Config
+	endpoint   string
+	httpClient *http.Client
+}
+
+var _ component.Extension = (*ecsTaskObserver)(nil)
+
+func newObserver(logger *zap.Logger, config *Config) (component.Extension, error) {
+	lister := &endpointsLister{
+		logger:     logger,
+		config:     config,
+		httpClient: &http.Client{Timeout: metadataTimeout},
+	}
+	return &ecsTaskObserver{
+		EndpointsWatcher: observer.EndpointsWatcher{
+			RefreshInterval: config.RefreshInterval,
+			Endpointslister: lister,
+		},
+		lister: lister,
+	}, nil
+}
+
+func (e *ecsTaskObserver) Start(context.Context, component.Host) error {
+	endpoint := e.lister.config.Endpoint
+	if endpoint == "" {
+		endpoint = os.Getenv(metadataEndpointEnvVar)
+	}
+	if endpoint == "" {
+		return fmt.Errorf("no endpoint configured and %s is not set, the collector must run in an ECS task", metadataEndpointEnvVar)
+	}
+	e.lister.endpoint = strings.TrimSuffix(endpoint, "/")
+	return nil
+}
+
+func (e *ecsTaskObserver) Shutdown(context.Context) error {
+	e.StopListAndWatch()
+	return nil
+}
+
+func (e *endpointsLister) ListEndpoints() []observer.Endpoint {
+	task, err := e.fetchTaskMetadata()
+	if err != nil {
+		e.logger.Error("Could not fetch ECS task metadata", zap.Error(err))
+		return nil
+	}
+	return e.collectEndpoints(task)
+}
+
+func (e *endpointsLister) fetchTaskMetadata() (*taskMetadata, error) {
+	resp, err := e.httpClient.Get(e.endpoint + "/task")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+
+	task := &taskMetadata{}
+	if err := json.NewDecoder(resp.Body).Decode(task); err != nil {
+		return nil, fmt.Errorf("failed to decode task metadata: %w", err)
+	}
+	return task, nil
+}
+
+func (e *endpointsLister) collectEndpoints(task *taskMetadata) []observer.Endpoint {
+	var endpoints []observer.Endpoint
+	for _, c := range task.Containers {
+		if c.KnownStatus != "RUNNING" {
+			continue
+		}
+		host := containerHost(c)
+		if host == "" {
+			e.logger.Debug("Skipping container without IPv4 address", zap.String("container", c.Name))
+			continue
+		}
+
+		ports, err := e.containerPorts(c)
+		if err != nil {
+			e.logger.Warn("Skipping container with invalid port label", zap.String("container", c.Name), zap.Error(err))
+			continue
+		}
+
+		image, tag := splitImage(c.Image)
+		for _, p := range ports {
+			endpoints = append(endpoints, observer.Endpoint{
+				ID:     observer.EndpointID(fmt.Sprintf("%s/%s(%d)", task.TaskARN, c.DockerID, p.port)),
+				Target: fmt.Sprintf("%s:%d", host, p.port),
+				Details: &observer.Container{
+					Name:        c.Name,
+					Image:       image,
+					Tag:         tag,
+					Port:        p.port,
+					ContainerID: c.DockerID,
+					Host:        host,
+					Transport:   p.transport,
+					Labels:      c.Labels,
+					TaskARN:     task.TaskARN,
+					TaskFamily:  task.Family,
+					Cluster:     task.Cluster,
+				},
+			})
+		}
+	}
+	return endpoints
+}
+
+type containerPort struct {
+	port      uint16
+	transport observer.Transport
+}
+
+// containerPorts returns the port set by the first port label found on the container, or
+// all the mapped ports of the container if it has none.
+func (e *endpointsLister) containerPorts(c containerMetadata) ([]containerPort, error) {
+	for _, label := range e.config.PortLabels {
+		value, ok := c.Labels[label]
+		if !ok {
+			continue
+		}
+		port, err := strconv.ParseUint(value, 10, 16)
+		if err != nil || port == 0 {
+			return nil, fmt.Errorf("label %s has invalid port %q", label, value)
+		}
+		return []containerPort{{port: uint16(port), transport: observer.ProtocolTCP}}, nil
+	}
+
+	var ports []containerPort
+	for _, p := range c.Ports {
+		ports = append(ports, containerPort{port: p.ContainerPort, transport: protocolToTransport(p.Protocol)})
+	}
+	return ports, nil
+}
+
+func containerHost(c containerMetadata) string {
+	for _, n := range c.Networks {
+		if len(n.IPv4Addresses) > 0 {
+			return n.IPv4Addresses[0]
+		}
+	}
+	return ""
+}
+
+// splitImage splits an image reference into the image name and its tag. A digest is
+// returned as the tag of images referenced by digest.
+func splitImage(image string) (string, string) {
+	if i := strings.Index(image, "@"); i >= 0 {
+		return image[:i], image[i+1:]
+	}
+	// A colon before the last slash separates the port of the registry.
+	if i := strings.LastIndex(image, ":"); i > strings.LastIndex(image, "/") {
+		return image[:i], image[i+1:]
+	}
+	return image, "latest"
+}
+
+func protocolToTransport(protocol string) observer.Transport {
+	switch strings.ToLower(protocol) {
+	case "tcp", "":
+		return observer.ProtocolTCP
+	case "udp":
+		return observer.ProtocolUDP
+	default:
+		return observer.ProtocolUnknown
+	}
+}
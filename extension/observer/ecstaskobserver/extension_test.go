@@ -0,0 +1,191 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ecstaskobserver
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer"
+)
+
+const (
+	taskARN = "arn:aws:ecs:us-west-2:123456789012:task/default/158d1c8083dd49d6b527399fd6414f5c"
+	cluster = "arn:aws:ecs:us-west-2:123456789012:cluster/default"
+	appID   = "ea32192c8553fbff06c9340478a2ff089b2bb5646fb718b4ee206641c9086d66"
+	statsID = "7bd6a0a2a0e3f9ad4d1a5d2c6a0e5b8f6c9d3e1f2a4b5c6d7e8f9a0b1c2d3e4f"
+)
+
+func newMetadataServer(t *testing.T) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v4/abcdef/task" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		http.ServeFile(w, r, path.Join(".", "testdata", "task.json"))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestListEndpoints(t *testing.T) {
+	server := newMetadataServer(t)
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = server.URL + "/v4/abcdef/"
+	ext, err := newObserver(zap.NewNop(), cfg)
+	require.NoError(t, err)
+	require.NoError(t, ext.Start(context.Background(), componenttest.NewNopHost()))
+
+	endpoints := ext.(*ecsTaskObserver).lister.ListEndpoints()
+	assert.Equal(t, []observer.Endpoint{
+		{
+			ID:     observer.EndpointID(taskARN + "/" + appID + "(9090)"),
+			Target: "10.0.2.106:9090",
+			Details: &observer.Container{
+				Name:        "app",
+				Image:       "registry.example.com:5000/app",
+				Tag:         "1.2.0",
+				Port:        9090,
+				ContainerID: appID,
+				Host:        "10.0.2.106",
+				Transport:   observer.ProtocolTCP,
+				Labels:      map[string]string{"ECS_TASK_OBSERVER_PORT": "9090"},
+				TaskARN:     taskARN,
+				TaskFamily:  "app",
+				Cluster:     cluster,
+			},
+		},
+		{
+			ID:     observer.EndpointID(taskARN + "/" + statsID + "(8125)"),
+			Target: "10.0.2.106:8125",
+			Details: &observer.Container{
+				Name:        "statsd",
+				Image:       "statsd/statsd",
+				Tag:         "latest",
+				Port:        8125,
+				ContainerID: statsID,
+				Host:        "10.0.2.106",
+				Transport:   observer.ProtocolUDP,
+				TaskARN:     taskARN,
+				TaskFamily:  "app",
+				Cluster:     cluster,
+			},
+		},
+		{
+			ID:     observer.EndpointID(taskARN + "/" + statsID + "(8126)"),
+			Target: "10.0.2.106:8126",
+			Details: &observer.Container{
+				Name:        "statsd",
+				Image:       "statsd/statsd",
+				Tag:         "latest",
+				Port:        8126,
+				ContainerID: statsID,
+				Host:        "10.0.2.106",
+				Transport:   observer.ProtocolTCP,
+				TaskARN:     taskARN,
+				TaskFamily:  "app",
+				Cluster:     cluster,
+			},
+		},
+	}, endpoints)
+}
+
+func TestListAndWatch(t *testing.T) {
+	server := newMetadataServer(t)
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = server.URL + "/v4/abcdef"
+	cfg.RefreshInterval = time.Hour
+	ext, err := newObserver(zap.NewNop(), cfg)
+	require.NoError(t, err)
+	require.NoError(t, ext.Start(context.Background(), componenttest.NewNopHost()))
+
+	mn := mockNotifier{endpointsMap: map[observer.EndpointID]observer.Endpoint{}}
+	ext.(*ecsTaskObserver).ListAndWatch(mn)
+	require.NoError(t, ext.Shutdown(context.Background()))
+
+	assert.Len(t, mn.endpointsMap, 3)
+}
+
+func TestListEndpointsFailure(t *testing.T) {
+	server := newMetadataServer(t)
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = server.URL + "/v4/unknown"
+	ext, err := newObserver(zap.NewNop(), cfg)
+	require.NoError(t, err)
+	require.NoError(t, ext.Start(context.Background(), componenttest.NewNopHost()))
+
+	assert.Empty(t, ext.(*ecsTaskObserver).lister.ListEndpoints())
+}
+
+func TestInvalidPortLabel(t *testing.T) {
+	e := &endpointsLister{logger: zap.NewNop(), config: createDefaultConfig().(*Config)}
+	_, err := e.containerPorts(containerMetadata{Labels: map[string]string{"ECS_TASK_OBSERVER_PORT": "http"}})
+	assert.EqualError(t, err, `label ECS_TASK_OBSERVER_PORT has invalid port "http"`)
+}
+
+func TestSplitImage(t *testing.T) {
+	tests := []struct {
+		image string
+		name  string
+		tag   string
+	}{
+		{image: "nginx", name: "nginx", tag: "latest"},
+		{image: "nginx:1.19", name: "nginx", tag: "1.19"},
+		{image: "localhost:5000/nginx", name: "localhost:5000/nginx", tag: "latest"},
+		{image: "localhost:5000/nginx:1.19", name: "localhost:5000/nginx", tag: "1.19"},
+		{image: "nginx@sha256:abcdef", name: "nginx", tag: "sha256:abcdef"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.image, func(t *testing.T) {
+			name, tag := splitImage(tt.image)
+			assert.Equal(t, tt.name, name)
+			assert.Equal(t, tt.tag, tag)
+		})
+	}
+}
+
+type mockNotifier struct {
+	endpointsMap map[observer.EndpointID]observer.Endpoint
+}
+
+func (m mockNotifier) OnAdd(added []observer.Endpoint) {
+	for _, e := range added {
+		m.endpointsMap[e.ID] = e
+	}
+}
+
+func (m mockNotifier) OnRemove(removed []observer.Endpoint) {
+	for _, e := range removed {
+		delete(m.endpointsMap, e.ID)
+	}
+}
+
+func (m mockNotifier) OnChange(changed []observer.Endpoint) {
+	for _, e := range changed {
+		m.endpointsMap[e.ID] = e
+	}
+}
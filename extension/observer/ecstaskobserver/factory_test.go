@@ -0,0 +1,58 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ecstaskobserver
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configcheck"
+	"go.uber.org/zap"
+)
+
+func TestValidConfig(t *testing.T) {
+	err := configcheck.ValidateConfig(createDefaultConfig())
+	require.NoError(t, err)
+}
+
+func TestCreateExtension(t *testing.T) {
+	ecsTaskObserver, err := createExtension(
+		context.Background(),
+		component.ExtensionCreateSettings{Logger: zap.NewNop()},
+		createDefaultConfig(),
+	)
+	require.NoError(t, err)
+	require.NotNil(t, ecsTaskObserver)
+}
+
+func TestStartWithoutEndpoint(t *testing.T) {
+	if os.Getenv(metadataEndpointEnvVar) != "" {
+		t.Skip("running in an ECS task")
+	}
+
+	ecsTaskObserver, err := createExtension(
+		context.Background(),
+		component.ExtensionCreateSettings{Logger: zap.NewNop()},
+		createDefaultConfig(),
+	)
+	require.NoError(t, err)
+	assert.EqualError(t, ecsTaskObserver.Start(context.Background(), componenttest.NewNopHost()),
+		"no endpoint configured and ECS_CONTAINER_METADATA_URI_V4 is not set, the collector must run in an ECS task")
+}
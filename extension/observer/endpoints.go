@@ -35,12 +35,18 @@ const (
 	PodType EndpointType = "pod"
 	// HostPortType is a hostport endpoint.
 	HostPortType EndpointType = "hostport"
+	// ContainerType is a container endpoint.
+	ContainerType EndpointType = "container"
+	// CloudRunServiceType is a Cloud Run service endpoint.
+	CloudRunServiceType EndpointType = "cloudrun_service"
 )
 
 var (
 	_ EndpointDetails = (*Pod)(nil)
 	_ EndpointDetails = (*Port)(nil)
 	_ EndpointDetails = (*HostPort)(nil)
+	_ EndpointDetails = (*Container)(nil)
+	_ EndpointDetails = (*CloudRunService)(nil)
 )
 
 // EndpointDetails provides additional context about an endpoint such as a Pod or Port.
@@ -158,3 +164,84 @@ func (h *HostPort) Env() EndpointEnv {
 func (h *HostPort) Type() EndpointType {
 	return HostPortType
 }
+
+// Container is a discovered container, such as one of the containers of an ECS task.
+type Container struct {
+	// Name is the name of the container.
+	Name string
+	// Image is the container image, without its tag.
+	Image string
+	// Tag is the tag of the container image.
+	Tag string
+	// Port is the port of the endpoint within the container.
+	Port uint16
+	// ContainerID is the id of the container.
+	ContainerID string
+	// Host is the IP address or hostname of the container.
+	Host string
+	// Transport is the transport protocol used by the Endpoint. (TCP or UDP).
+	Transport Transport
+	// Labels is a map of user-specified metadata on the container.
+	Labels map[string]string
+	// TaskARN is the ARN of the ECS task running the container, if any.
+	TaskARN string
+	// TaskFamily is the family of the task definition of the ECS task running the container, if any.
+	TaskFamily string
+	// Cluster is the ECS cluster running the container, if any.
+	Cluster string
+}
+
+func (c *Container) Env() EndpointEnv {
+	return map[string]interface{}{
+		"name":         c.Name,
+		"image":        c.Image,
+		"tag":          c.Tag,
+		"port":         c.Port,
+		"container_id": c.ContainerID,
+		"host":         c.Host,
+		"transport":    c.Transport,
+		"labels":       c.Labels,
+		"task_arn":     c.TaskARN,
+		"task_family":  c.TaskFamily,
+		"cluster":      c.Cluster,
+	}
+}
+
+func (c *Container) Type() EndpointType {
+	return ContainerType
+}
+
+// CloudRunService is a discovered Cloud Run service. Cloud Run does not expose its
+// instances, the endpoint is the URL serving the latest ready revision of the service.
+type CloudRunService struct {
+	// Name is the name of the service.
+	Name string
+	// Project is the id of the Google Cloud project of the service.
+	Project string
+	// Region is the region the service runs in.
+	Region string
+	// URL is the URL serving the service.
+	URL string
+	// Revision is the name of the latest ready revision of the service.
+	Revision string
+	// Labels is a map of user-specified metadata on the service.
+	Labels map[string]string
+	// Annotations is a map of user-specified metadata on the service.
+	Annotations map[string]string
+}
+
+func (c *CloudRunService) Env() EndpointEnv {
+	return map[string]interface{}{
+		"name":        c.Name,
+		"project":     c.Project,
+		"region":      c.Region,
+		"url":         c.URL,
+		"revision":    c.Revision,
+		"labels":      c.Labels,
+		"annotations": c.Annotations,
+	}
+}
+
+func (c *CloudRunService) Type() EndpointType {
+	return CloudRunServiceType
+}
@@ -124,6 +124,82 @@ func TestEndpointEnv(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "Container",
+			endpoint: Endpoint{
+				ID:     EndpointID("container_id"),
+				Target: "10.0.0.12:9090",
+				Details: &Container{
+					Name:        "app",
+					Image:       "example/app",
+					Tag:         "1.0",
+					Port:        9090,
+					ContainerID: "abcdef",
+					Host:        "10.0.0.12",
+					Transport:   ProtocolTCP,
+					Labels: map[string]string{
+						"label_key": "label_val",
+					},
+					TaskARN:    "arn:aws:ecs:us-west-2:123456789012:task/cluster/1234",
+					TaskFamily: "app",
+					Cluster:    "cluster",
+				},
+			},
+			want: EndpointEnv{
+				"type":         "container",
+				"endpoint":     "10.0.0.12:9090",
+				"name":         "app",
+				"image":        "example/app",
+				"tag":          "1.0",
+				"port":         uint16(9090),
+				"container_id": "abcdef",
+				"host":         "10.0.0.12",
+				"transport":    ProtocolTCP,
+				"labels": map[string]string{
+					"label_key": "label_val",
+				},
+				"task_arn":    "arn:aws:ecs:us-west-2:123456789012:task/cluster/1234",
+				"task_family": "app",
+				"cluster":     "cluster",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Cloud Run service",
+			endpoint: Endpoint{
+				ID:     EndpointID("service_id"),
+				Target: "app-abc123-uc.a.run.app:443",
+				Details: &CloudRunService{
+					Name:     "app",
+					Project:  "project",
+					Region:   "us-central1",
+					URL:      "https://app-abc123-uc.a.run.app",
+					Revision: "app-00001-abc",
+					Labels: map[string]string{
+						"label_key": "label_val",
+					},
+					Annotations: map[string]string{
+						"annotation_1": "value_1",
+					},
+				},
+			},
+			want: EndpointEnv{
+				"type":     "cloudrun_service",
+				"endpoint": "app-abc123-uc.a.run.app:443",
+				"name":     "app",
+				"project":  "project",
+				"region":   "us-central1",
+				"url":      "https://app-abc123-uc.a.run.app",
+				"revision": "app-00001-abc",
+				"labels": map[string]string{
+					"label_key": "label_val",
+				},
+				"annotations": map[string]string{
+					"annotation_1": "value_1",
+				},
+			},
+			wantErr: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -0,0 +1,47 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package payloadcaptureextension
+
+import (
+	"errors"
+
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/confighttp"
+)
+
+// Config defines the configuration options for payloadcaptureextension.
+type Config struct {
+	config.ExtensionSettings      `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+	confighttp.HTTPServerSettings `mapstructure:",squash"`
+
+	// MaxBatches is the number of batches kept per pipeline, the oldest ones are
+	// dropped first. Default is 10.
+	MaxBatches int `mapstructure:"max_batches"`
+
+	// BearerToken is the token clients must send in the "Authorization" header
+	// to retrieve the captured batches. Required.
+	BearerToken string `mapstructure:"bearer_token"`
+}
+
+// Validate checks that the number of batches is positive and that a token is set.
+func (cfg *Config) Validate() error {
+	if cfg.MaxBatches <= 0 {
+		return errors.New("max_batches must be positive")
+	}
+	if cfg.BearerToken == "" {
+		return errors.New("bearer_token must be set")
+	}
+	return nil
+}
@@ -0,0 +1,242 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package payloadcaptureextension
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.uber.org/zap"
+)
+
+// Capturer records the batches flowing through pipelines so that they can be
+// retrieved later on. It is implemented by the payloadcapture extension.
+type Capturer interface {
+	component.Extension
+
+	// CaptureTraces records a batch of traces for the given pipeline.
+	CaptureTraces(pipeline string, td pdata.Traces)
+	// CaptureMetrics records a batch of metrics for the given pipeline.
+	CaptureMetrics(pipeline string, md pdata.Metrics)
+	// CaptureLogs records a batch of logs for the given pipeline.
+	CaptureLogs(pipeline string, ld pdata.Logs)
+}
+
+const (
+	signalTraces  = "traces"
+	signalMetrics = "metrics"
+	signalLogs    = "logs"
+)
+
+// batch is a captured batch, kept in the OTLP protobuf encoding so that it is
+// isolated from the pipeline and only converted to JSON when retrieved.
+type batch struct {
+	timestamp time.Time
+	signal    string
+	payload   []byte
+}
+
+// ringBuffer keeps the last batches of a pipeline.
+type ringBuffer struct {
+	batches []batch
+	next    int
+	full    bool
+}
+
+func (r *ringBuffer) add(b batch) {
+	r.batches[r.next] = b
+	r.next = (r.next + 1) % len(r.batches)
+	if r.next == 0 {
+		r.full = true
+	}
+}
+
+// ordered returns the batches oldest first.
+func (r *ringBuffer) ordered() []batch {
+	if !r.full {
+		return append([]batch(nil), r.batches[:r.next]...)
+	}
+	return append(append([]batch(nil), r.batches[r.next:]...), r.batches[:r.next]...)
+}
+
+type payloadCapture struct {
+	logger *zap.Logger
+	config *Config
+	now    func() time.Time
+
+	server *http.Server
+	addr   net.Addr
+	wg     sync.WaitGroup
+
+	mu      sync.Mutex
+	buffers map[string]*ringBuffer
+}
+
+var _ Capturer = (*payloadCapture)(nil)
+
+func newPayloadCapture(logger *zap.Logger, config *Config) *payloadCapture {
+	return &payloadCapture{
+		logger:  logger,
+		config:  config,
+		now:     time.Now,
+		buffers: make(map[string]*ringBuffer),
+	}
+}
+
+// Start starts the HTTP server the captured batches are retrieved from.
+func (pc *payloadCapture) Start(_ context.Context, host component.Host) error {
+	ln, err := pc.config.HTTPServerSettings.ToListener()
+	if err != nil {
+		return fmt.Errorf("failed to bind to address %s: %w", pc.config.Endpoint, err)
+	}
+	pc.addr = ln.Addr()
+
+	pc.server = pc.config.HTTPServerSettings.ToServer(http.HandlerFunc(pc.serveHTTP))
+	pc.wg.Add(1)
+	go func() {
+		defer pc.wg.Done()
+		if errHTTP := pc.server.Serve(ln); errHTTP != nil && errHTTP != http.ErrServerClosed {
+			host.ReportFatalError(errHTTP)
+		}
+	}()
+	return nil
+}
+
+// Shutdown stops the HTTP server.
+func (pc *payloadCapture) Shutdown(context.Context) error {
+	if pc.server == nil {
+		return nil
+	}
+	err := pc.server.Close()
+	pc.wg.Wait()
+	return err
+}
+
+func (pc *payloadCapture) CaptureTraces(pipeline string, td pdata.Traces) {
+	pc.capture(pipeline, signalTraces, td.ToOtlpProtoBytes)
+}
+
+func (pc *payloadCapture) CaptureMetrics(pipeline string, md pdata.Metrics) {
+	pc.capture(pipeline, signalMetrics, md.ToOtlpProtoBytes)
+}
+
+func (pc *payloadCapture) CaptureLogs(pipeline string, ld pdata.Logs) {
+	pc.capture(pipeline, signalLogs, ld.ToOtlpProtoBytes)
+}
+
+func (pc *payloadCapture) capture(pipeline, signal string, marshal func() ([]byte, error)) {
+	payload, err := marshal()
+	if err != nil {
+		pc.logger.Debug("Failed to marshal captured batch", zap.String("pipeline", pipeline), zap.Error(err))
+		return
+	}
+
+	pc.mu.Lock()
+	defer pc.mu.Unlock()
+	buf, ok := pc.buffers[pipeline]
+	if !ok {
+		buf = &ringBuffer{batches: make([]batch, pc.config.MaxBatches)}
+		pc.buffers[pipeline] = buf
+	}
+	buf.add(batch{timestamp: pc.now(), signal: signal, payload: payload})
+}
+
+// snapshot returns the batches of the given pipeline, or of all of them if it is empty.
+func (pc *payloadCapture) snapshot(pipeline string) map[string][]batch {
+	pc.mu.Lock()
+	defer pc.mu.Unlock()
+
+	out := make(map[string][]batch)
+	for name, buf := range pc.buffers {
+		if pipeline == "" || pipeline == name {
+			out[name] = buf.ordered()
+		}
+	}
+	return out
+}
+
+type pipelineDump struct {
+	Name    string      `json:"name"`
+	Batches []batchDump `json:"batches"`
+}
+
+type batchDump struct {
+	Timestamp time.Time       `json:"timestamp"`
+	Signal    string          `json:"signal"`
+	Payload   json.RawMessage `json:"payload"`
+}
+
+// serveHTTP dumps the captured batches as OTLP/JSON, for all the pipelines or
+// for the one given by the "pipeline" query parameter.
+func (pc *payloadCapture) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if !pc.authorized(r) {
+		w.Header().Set("WWW-Authenticate", "Bearer")
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	pipeline := r.URL.Query().Get("pipeline")
+	captured := pc.snapshot(pipeline)
+	if pipeline != "" && len(captured) == 0 {
+		http.Error(w, fmt.Sprintf("no batch captured for pipeline %q", pipeline), http.StatusNotFound)
+		return
+	}
+
+	dumps := make([]pipelineDump, 0, len(captured))
+	for name, batches := range captured {
+		dump := pipelineDump{Name: name, Batches: make([]batchDump, 0, len(batches))}
+		for _, b := range batches {
+			payload, err := toJSON(b.signal, b.payload)
+			if err != nil {
+				http.Error(w, fmt.Sprintf("failed to convert captured batch to JSON: %v", err), http.StatusInternalServerError)
+				return
+			}
+			dump.Batches = append(dump.Batches, batchDump{Timestamp: b.timestamp, Signal: b.signal, Payload: payload})
+		}
+		dumps = append(dumps, dump)
+	}
+	sort.Slice(dumps, func(i, j int) bool { return dumps[i].Name < dumps[j].Name })
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(struct {
+		Pipelines []pipelineDump `json:"pipelines"`
+	}{dumps}); err != nil {
+		pc.logger.Debug("Failed to write captured batches", zap.Error(err))
+	}
+}
+
+func (pc *payloadCapture) authorized(r *http.Request) bool {
+	const prefix = "Bearer "
+	auth := r.Header.Get("Authorization")
+	if len(auth) < len(prefix) || !strings.EqualFold(auth[:len(prefix)], prefix) {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(auth[len(prefix):]), []byte(pc.config.BearerToken)) == 1
+}
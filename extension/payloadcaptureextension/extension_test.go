@@ -0,0 +1,196 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package payloadcaptureextension
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.uber.org/zap"
+)
+
+func newTestCapture(t *testing.T, maxBatches int) (*payloadCapture, string) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = "localhost:0"
+	cfg.MaxBatches = maxBatches
+	cfg.BearerToken = "secret"
+
+	pc := newPayloadCapture(zap.NewNop(), cfg)
+	require.NoError(t, pc.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { assert.NoError(t, pc.Shutdown(context.Background())) })
+	return pc, "http://" + pc.addr.String()
+}
+
+func testTraces(spanName string) pdata.Traces {
+	td := pdata.NewTraces()
+	span := td.ResourceSpans().AppendEmpty().InstrumentationLibrarySpans().AppendEmpty().Spans().AppendEmpty()
+	span.SetName(spanName)
+	return td
+}
+
+func testLogs(body string) pdata.Logs {
+	ld := pdata.NewLogs()
+	lr := ld.ResourceLogs().AppendEmpty().InstrumentationLibraryLogs().AppendEmpty().Logs().AppendEmpty()
+	lr.Body().SetStringVal(body)
+	return ld
+}
+
+func testMetrics(name string) pdata.Metrics {
+	md := pdata.NewMetrics()
+	m := md.ResourceMetrics().AppendEmpty().InstrumentationLibraryMetrics().AppendEmpty().Metrics().AppendEmpty()
+	m.SetName(name)
+	m.SetDataType(pdata.MetricDataTypeDoubleGauge)
+	m.DoubleGauge().DataPoints().AppendEmpty().SetValue(1)
+	return md
+}
+
+type dumpResponse struct {
+	Pipelines []struct {
+		Name    string `json:"name"`
+		Batches []struct {
+			Timestamp time.Time       `json:"timestamp"`
+			Signal    string          `json:"signal"`
+			Payload   json.RawMessage `json:"payload"`
+		} `json:"batches"`
+	} `json:"pipelines"`
+}
+
+func get(t *testing.T, url, token string) (*http.Response, []byte) {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	require.NoError(t, err)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return resp, body
+}
+
+func TestCaptureKeepsLastBatches(t *testing.T) {
+	pc, url := newTestCapture(t, 2)
+
+	for i := 0; i < 3; i++ {
+		pc.CaptureTraces("traces", testTraces(fmt.Sprintf("span-%d", i)))
+	}
+	pc.CaptureMetrics("metrics", testMetrics("cpu.utilization"))
+	pc.CaptureLogs("logs", testLogs("log body"))
+
+	resp, body := get(t, url, "secret")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+
+	var dump dumpResponse
+	require.NoError(t, json.Unmarshal(body, &dump))
+	require.Len(t, dump.Pipelines, 3)
+
+	assert.Equal(t, "logs", dump.Pipelines[0].Name)
+	require.Len(t, dump.Pipelines[0].Batches, 1)
+	assert.Equal(t, "logs", dump.Pipelines[0].Batches[0].Signal)
+	assert.Contains(t, string(dump.Pipelines[0].Batches[0].Payload), `"resourceLogs"`)
+	assert.Contains(t, string(dump.Pipelines[0].Batches[0].Payload), "log body")
+
+	assert.Equal(t, "metrics", dump.Pipelines[1].Name)
+	require.Len(t, dump.Pipelines[1].Batches, 1)
+	assert.Contains(t, string(dump.Pipelines[1].Batches[0].Payload), "cpu.utilization")
+
+	// Only the last 2 batches are kept, oldest first.
+	assert.Equal(t, "traces", dump.Pipelines[2].Name)
+	require.Len(t, dump.Pipelines[2].Batches, 2)
+	assert.Contains(t, string(dump.Pipelines[2].Batches[0].Payload), "span-1")
+	assert.Contains(t, string(dump.Pipelines[2].Batches[1].Payload), "span-2")
+}
+
+func TestCaptureSinglePipeline(t *testing.T) {
+	pc, url := newTestCapture(t, 10)
+	pc.CaptureTraces("traces", testTraces("span"))
+	pc.CaptureLogs("logs", testLogs("log body"))
+
+	resp, body := get(t, url+"?pipeline=logs", "secret")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var dump dumpResponse
+	require.NoError(t, json.Unmarshal(body, &dump))
+	require.Len(t, dump.Pipelines, 1)
+	assert.Equal(t, "logs", dump.Pipelines[0].Name)
+
+	resp, _ = get(t, url+"?pipeline=unknown", "secret")
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestCaptureIsolatedFromPipeline(t *testing.T) {
+	pc, url := newTestCapture(t, 10)
+	td := testTraces("original")
+	pc.CaptureTraces("traces", td)
+	td.ResourceSpans().At(0).InstrumentationLibrarySpans().At(0).Spans().At(0).SetName("modified")
+
+	_, body := get(t, url, "secret")
+	assert.Contains(t, string(body), "original")
+	assert.NotContains(t, string(body), "modified")
+}
+
+func TestCaptureAuthentication(t *testing.T) {
+	pc, url := newTestCapture(t, 10)
+	pc.CaptureTraces("traces", testTraces("span"))
+
+	tests := []struct {
+		name          string
+		authorization string
+		wantStatus    int
+	}{
+		{name: "missing", wantStatus: http.StatusUnauthorized},
+		{name: "wrong token", authorization: "Bearer wrong", wantStatus: http.StatusUnauthorized},
+		{name: "wrong scheme", authorization: "Basic secret", wantStatus: http.StatusUnauthorized},
+		{name: "valid", authorization: "Bearer secret", wantStatus: http.StatusOK},
+		{name: "case insensitive scheme", authorization: "bearer secret", wantStatus: http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			resp, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+			resp.Body.Close()
+			assert.Equal(t, tt.wantStatus, resp.StatusCode)
+			if tt.wantStatus == http.StatusUnauthorized {
+				assert.Equal(t, "Bearer", resp.Header.Get("WWW-Authenticate"))
+			}
+		})
+	}
+}
+
+func TestCaptureMethodNotAllowed(t *testing.T) {
+	_, url := newTestCapture(t, 10)
+	req, err := http.NewRequest(http.MethodPost, url, nil)
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer secret")
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+}
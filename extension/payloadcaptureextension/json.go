@@ -0,0 +1,45 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package payloadcaptureextension
+
+import (
+	"fmt"
+
+	collectorlogs "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	collectormetrics "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	collectortrace "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// toJSON converts a batch in the OTLP protobuf encoding to the OTLP/JSON encoding,
+// pdata doesn't expose a JSON marshaler.
+func toJSON(signal string, payload []byte) ([]byte, error) {
+	var req proto.Message
+	switch signal {
+	case signalTraces:
+		req = &collectortrace.ExportTraceServiceRequest{}
+	case signalMetrics:
+		req = &collectormetrics.ExportMetricsServiceRequest{}
+	case signalLogs:
+		req = &collectorlogs.ExportLogsServiceRequest{}
+	default:
+		return nil, fmt.Errorf("unknown signal %q", signal)
+	}
+	if err := proto.Unmarshal(payload, req); err != nil {
+		return nil, err
+	}
+	return protojson.Marshal(req)
+}
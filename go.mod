@@ -23,6 +23,8 @@ require (
 	github.com/open-telemetry/opentelemetry-collector-contrib/exporter/logzioexporter v0.0.0-00010101000000-000000000000
 	github.com/open-telemetry/opentelemetry-collector-contrib/exporter/lokiexporter v0.0.0-00010101000000-000000000000
 	github.com/open-telemetry/opentelemetry-collector-contrib/exporter/newrelicexporter v0.0.0-00010101000000-000000000000
+	github.com/open-telemetry/opentelemetry-collector-contrib/exporter/otlphttpauthexporter v0.0.0-00010101000000-000000000000
+	github.com/open-telemetry/opentelemetry-collector-contrib/exporter/otlphttpsignalexporter v0.0.0-00010101000000-000000000000
 	github.com/open-telemetry/opentelemetry-collector-contrib/exporter/sapmexporter v0.0.0-00010101000000-000000000000
 	github.com/open-telemetry/opentelemetry-collector-contrib/exporter/sentryexporter v0.0.0-00010101000000-000000000000
 	github.com/open-telemetry/opentelemetry-collector-contrib/exporter/signalfxexporter v0.0.0-00010101000000-000000000000
@@ -35,6 +37,7 @@ require (
 	github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer/hostobserver v0.0.0-00010101000000-000000000000
 	github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer/k8sobserver v0.0.0-00010101000000-000000000000
 	github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage v0.0.0-00010101000000-000000000000
+	github.com/open-telemetry/opentelemetry-collector-contrib/internal/configprovider v0.0.0-00010101000000-000000000000
 	github.com/open-telemetry/opentelemetry-collector-contrib/processor/groupbyattrsprocessor v0.0.0-00010101000000-000000000000
 	github.com/open-telemetry/opentelemetry-collector-contrib/processor/groupbytraceprocessor v0.0.0-00010101000000-000000000000
 	github.com/open-telemetry/opentelemetry-collector-contrib/processor/k8sprocessor v0.0.0-00010101000000-000000000000
@@ -94,6 +97,10 @@ replace github.com/open-telemetry/opentelemetry-collector-contrib/internal/aws/a
 
 replace github.com/open-telemetry/opentelemetry-collector-contrib/internal/stanza => ./internal/stanza
 
+replace github.com/open-telemetry/opentelemetry-collector-contrib/internal/configprovider => ./internal/configprovider
+
+replace github.com/open-telemetry/opentelemetry-collector-contrib/internal/admissioncontrol => ./internal/admissioncontrol
+
 replace github.com/open-telemetry/opentelemetry-collector-contrib/exporter/alibabacloudlogserviceexporter => ./exporter/alibabacloudlogserviceexporter
 
 replace github.com/open-telemetry/opentelemetry-collector-contrib/exporter/awsprometheusremotewriteexporter => ./exporter/awsprometheusremotewriteexporter
@@ -156,6 +163,18 @@ replace github.com/open-telemetry/opentelemetry-collector-contrib/extension/obse
 
 replace github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage => ./extension/storage
 
+replace github.com/open-telemetry/opentelemetry-collector-contrib/extension/payloadcaptureextension => ./extension/payloadcaptureextension
+
+replace github.com/open-telemetry/opentelemetry-collector-contrib/extension/deliverytrackingextension => ./extension/deliverytrackingextension
+
+replace github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer/ecstaskobserver => ./extension/observer/ecstaskobserver
+
+replace github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer/cloudrunobserver => ./extension/observer/cloudrunobserver
+
+replace github.com/open-telemetry/opentelemetry-collector-contrib/extension/admissioncontrolextension => ./extension/admissioncontrolextension
+
+replace github.com/open-telemetry/opentelemetry-collector-contrib/extension/jaegerremotesampling => ./extension/jaegerremotesampling
+
 replace github.com/open-telemetry/opentelemetry-collector-contrib/pkg/batchperresourceattr => ./pkg/batchperresourceattr
 
 replace github.com/open-telemetry/opentelemetry-collector-contrib/pkg/batchpertrace => ./pkg/batchpertrace
@@ -164,6 +183,8 @@ replace github.com/open-telemetry/opentelemetry-collector-contrib/pkg/batchpersi
 
 replace github.com/open-telemetry/opentelemetry-collector-contrib/pkg/experimentalmetricmetadata => ./pkg/experimentalmetricmetadata
 
+replace github.com/open-telemetry/opentelemetry-collector-contrib/pkg/queueperresourceattr => ./pkg/queueperresourceattr
+
 replace github.com/open-telemetry/opentelemetry-collector-contrib/receiver/awsecscontainermetricsreceiver => ./receiver/awsecscontainermetricsreceiver
 
 replace github.com/open-telemetry/opentelemetry-collector-contrib/receiver/awsxrayreceiver => ./receiver/awsxrayreceiver
@@ -220,6 +241,24 @@ replace github.com/open-telemetry/opentelemetry-collector-contrib/receiver/memca
 
 replace github.com/open-telemetry/opentelemetry-collector-contrib/receiver/kafkametricsreceiver => ./receiver/kafkametricsreceiver
 
+replace github.com/open-telemetry/opentelemetry-collector-contrib/receiver/loadgenreceiver => ./receiver/loadgenreceiver
+
+replace github.com/open-telemetry/opentelemetry-collector-contrib/receiver/chronyreceiver => ./receiver/chronyreceiver
+
+replace github.com/open-telemetry/opentelemetry-collector-contrib/receiver/sshcheckreceiver => ./receiver/sshcheckreceiver
+
+replace github.com/open-telemetry/opentelemetry-collector-contrib/receiver/tcpcheckreceiver => ./receiver/tcpcheckreceiver
+
+replace github.com/open-telemetry/opentelemetry-collector-contrib/receiver/haproxyreceiver => ./receiver/haproxyreceiver
+
+replace github.com/open-telemetry/opentelemetry-collector-contrib/receiver/azureeventhubreceiver => ./receiver/azureeventhubreceiver
+
+replace github.com/open-telemetry/opentelemetry-collector-contrib/receiver/subprocessreceiver => ./receiver/subprocessreceiver
+
+replace github.com/open-telemetry/opentelemetry-collector-contrib/receiver/expvarreceiver => ./receiver/expvarreceiver
+
+replace github.com/open-telemetry/opentelemetry-collector-contrib/receiver/nsxtreceiver => ./receiver/nsxtreceiver
+
 replace github.com/open-telemetry/opentelemetry-collector-contrib/processor/groupbyattrsprocessor => ./processor/groupbyattrsprocessor
 
 replace github.com/open-telemetry/opentelemetry-collector-contrib/processor/groupbytraceprocessor => ./processor/groupbytraceprocessor
@@ -236,6 +275,38 @@ replace github.com/open-telemetry/opentelemetry-collector-contrib/processor/tail
 
 replace github.com/open-telemetry/opentelemetry-collector-contrib/processor/spanmetricsprocessor => ./processor/spanmetricsprocessor/
 
+replace github.com/open-telemetry/opentelemetry-collector-contrib/processor/servicegraphprocessor => ./processor/servicegraphprocessor/
+
+replace github.com/open-telemetry/opentelemetry-collector-contrib/processor/exceptionsprocessor => ./processor/exceptionsprocessor
+
+replace github.com/open-telemetry/opentelemetry-collector-contrib/processor/redactionprocessor => ./processor/redactionprocessor
+
+replace github.com/open-telemetry/opentelemetry-collector-contrib/processor/remotetapprocessor => ./processor/remotetapprocessor
+
+replace github.com/open-telemetry/opentelemetry-collector-contrib/processor/payloadcaptureprocessor => ./processor/payloadcaptureprocessor
+
+replace github.com/open-telemetry/opentelemetry-collector-contrib/processor/signalfxtranslationprocessor => ./processor/signalfxtranslationprocessor
+
+replace github.com/open-telemetry/opentelemetry-collector-contrib/processor/deliverytrackingprocessor => ./processor/deliverytrackingprocessor
+
+replace github.com/open-telemetry/opentelemetry-collector-contrib/processor/intervalprocessor => ./processor/intervalprocessor
+
+replace github.com/open-telemetry/opentelemetry-collector-contrib/processor/logdeduplicationprocessor => ./processor/logdeduplicationprocessor
+
+replace github.com/open-telemetry/opentelemetry-collector-contrib/processor/schemaprocessor => ./processor/schemaprocessor
+
+replace github.com/open-telemetry/opentelemetry-collector-contrib/processor/admissioncontrolprocessor => ./processor/admissioncontrolprocessor
+
 replace github.com/open-telemetry/opentelemetry-collector-contrib/exporter/googlecloudexporter => ./exporter/googlecloudexporter
 
 replace github.com/open-telemetry/opentelemetry-collector-contrib/exporter/stackdriverexporter => ./exporter/stackdriverexporter
+
+replace github.com/open-telemetry/opentelemetry-collector-contrib/exporter/sentinelexporter => ./exporter/sentinelexporter
+
+replace github.com/open-telemetry/opentelemetry-collector-contrib/exporter/chronicleexporter => ./exporter/chronicleexporter
+
+replace github.com/open-telemetry/opentelemetry-collector-contrib/exporter/sftpexporter => ./exporter/sftpexporter
+
+replace github.com/open-telemetry/opentelemetry-collector-contrib/exporter/otlphttpauthexporter => ./exporter/otlphttpauthexporter
+
+replace github.com/open-telemetry/opentelemetry-collector-contrib/exporter/otlphttpsignalexporter => ./exporter/otlphttpsignalexporter
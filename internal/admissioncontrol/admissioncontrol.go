@@ -0,0 +1,59 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package admissioncontrol defines the error data is refused with while the
+// admission of data is paused by the admissioncontrol extension, so the receivers
+// can ask their senders to retry later without depending on the extension.
+package admissioncontrol
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// PausedError is the retryable error data is refused with while admission is paused.
+type PausedError struct {
+	// RetryAfter is how long the sender is asked to wait before sending the data again.
+	RetryAfter time.Duration
+}
+
+func (e *PausedError) Error() string {
+	return fmt.Sprintf("admission paused: memory soft limit exceeded, retry after %s", e.RetryAfter)
+}
+
+// RetryAfter returns how long the sender of data refused with err should wait
+// before sending it again, and whether err was caused by paused admission.
+func RetryAfter(err error) (time.Duration, bool) {
+	var pErr *PausedError
+	if errors.As(err, &pErr) {
+		return pErr.RetryAfter, true
+	}
+	return 0, false
+}
+
+// SetRetryAfter sets the Retry-After header, in whole seconds rounded up, when
+// err was caused by paused admission and reports whether it did. HTTP receivers
+// then answer 429 Too Many Requests so the client sends the data again later.
+func SetRetryAfter(header http.Header, err error) bool {
+	retryAfter, ok := RetryAfter(err)
+	if !ok {
+		return false
+	}
+	header.Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+	return true
+}
@@ -0,0 +1,47 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admissioncontrol
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetryAfter(t *testing.T) {
+	retryAfter, ok := RetryAfter(fmt.Errorf("consumer failed: %w", &PausedError{RetryAfter: 3 * time.Second}))
+	assert.True(t, ok)
+	assert.Equal(t, 3*time.Second, retryAfter)
+
+	_, ok = RetryAfter(errors.New("other error"))
+	assert.False(t, ok)
+
+	_, ok = RetryAfter(nil)
+	assert.False(t, ok)
+}
+
+func TestSetRetryAfter(t *testing.T) {
+	header := http.Header{}
+	assert.True(t, SetRetryAfter(header, &PausedError{RetryAfter: 1500 * time.Millisecond}))
+	assert.Equal(t, "2", header.Get("Retry-After"))
+
+	header = http.Header{}
+	assert.False(t, SetRetryAfter(header, errors.New("other error")))
+	assert.Empty(t, header.Get("Retry-After"))
+}
@@ -0,0 +1,139 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package s3provider implements a parser provider loading the collector
+// configuration from an object of Amazon S3.
+package s3provider
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"go.opentelemetry.io/collector/config/configparser"
+	"go.opentelemetry.io/collector/service/parserprovider"
+)
+
+// Scheme is the scheme of the URIs of the configurations in S3.
+const Scheme = "s3"
+
+// requestTimeout is the timeout of the request getting the configuration.
+const requestTimeout = 30 * time.Second
+
+// virtualHostRegexp matches the virtual-hosted–style host names of the S3
+// buckets, which hold the region of the bucket.
+var virtualHostRegexp = regexp.MustCompile(`^(.+)\.s3\.([a-z0-9-]+)\.amazonaws\.com$`)
+
+// object is the location of the configuration in S3.
+type object struct {
+	bucket string
+	key    string
+	// region is the region of the bucket, empty to use the region of the
+	// AWS shared configuration or environment.
+	region string
+}
+
+type s3Provider struct {
+	uri       string
+	getObject func(ctx context.Context, obj object) ([]byte, error)
+}
+
+// New returns a ParserProvider loading the YAML configuration from the S3
+// object at uri, either "s3://<bucket>/<key>" or
+// "s3://<bucket>.s3.<region>.amazonaws.com/<key>". The credentials are
+// those of the AWS default credential chain.
+func New(uri string) parserprovider.ParserProvider {
+	return &s3Provider{
+		uri:       uri,
+		getObject: getObject,
+	}
+}
+
+func (p *s3Provider) Get() (*configparser.Parser, error) {
+	obj, err := parseURI(p.uri)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+	data, err := p.getObject(ctx, obj)
+	if err != nil {
+		return nil, fmt.Errorf("error loading config %q: %w", p.uri, err)
+	}
+
+	cp, err := configparser.NewParserFromBuffer(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("error parsing config %q: %w", p.uri, err)
+	}
+	return cp, nil
+}
+
+// parseURI returns the S3 object of an s3:// URI.
+func parseURI(uri string) (object, error) {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return object{}, fmt.Errorf("invalid S3 URI %q: %w", uri, err)
+	}
+	if u.Scheme != Scheme {
+		return object{}, fmt.Errorf("invalid S3 URI %q: scheme must be %q", uri, Scheme)
+	}
+
+	obj := object{bucket: u.Host, key: strings.TrimPrefix(u.Path, "/")}
+	if match := virtualHostRegexp.FindStringSubmatch(u.Host); match != nil {
+		obj.bucket = match[1]
+		obj.region = match[2]
+	}
+	if obj.bucket == "" {
+		return object{}, fmt.Errorf("invalid S3 URI %q: missing bucket", uri)
+	}
+	if obj.key == "" {
+		return object{}, fmt.Errorf("invalid S3 URI %q: missing key", uri)
+	}
+	return obj, nil
+}
+
+func getObject(ctx context.Context, obj object) ([]byte, error) {
+	sess, err := session.NewSessionWithOptions(session.Options{
+		SharedConfigState: session.SharedConfigEnable,
+	})
+	if err != nil {
+		return nil, err
+	}
+	cfg := aws.NewConfig()
+	if obj.region != "" {
+		cfg = cfg.WithRegion(obj.region)
+	} else if aws.StringValue(sess.Config.Region) == "" {
+		return nil, errors.New("missing AWS region: set it in the S3 URI or the AWS configuration")
+	}
+
+	out, err := s3.New(sess, cfg).GetObjectWithContext(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(obj.bucket),
+		Key:    aws.String(obj.key),
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer out.Body.Close()
+	return ioutil.ReadAll(out.Body)
+}
@@ -0,0 +1,89 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s3provider
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseURI(t *testing.T) {
+	cases := []struct {
+		uri      string
+		expected object
+		err      string
+	}{
+		{
+			uri:      "s3://configs/otelcol/config.yaml",
+			expected: object{bucket: "configs", key: "otelcol/config.yaml"},
+		},
+		{
+			uri:      "s3://configs.s3.eu-west-1.amazonaws.com/config.yaml",
+			expected: object{bucket: "configs", key: "config.yaml", region: "eu-west-1"},
+		},
+		{uri: "https://configs/config.yaml", err: `invalid S3 URI "https://configs/config.yaml": scheme must be "s3"`},
+		{uri: "s3:///config.yaml", err: `invalid S3 URI "s3:///config.yaml": missing bucket`},
+		{uri: "s3://configs/", err: `invalid S3 URI "s3://configs/": missing key`},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.uri, func(t *testing.T) {
+			obj, err := parseURI(tc.uri)
+			if tc.err != "" {
+				assert.EqualError(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, obj)
+		})
+	}
+}
+
+func TestGet(t *testing.T) {
+	var requested object
+	p := &s3Provider{
+		uri: "s3://configs.s3.us-east-1.amazonaws.com/config.yaml",
+		getObject: func(_ context.Context, obj object) ([]byte, error) {
+			requested = obj
+			return []byte("receivers:\n  otlp:\n    protocols:\n      grpc:\n        endpoint: 0.0.0.0:4317\n"), nil
+		},
+	}
+
+	cp, err := p.Get()
+	require.NoError(t, err)
+	assert.Equal(t, object{bucket: "configs", key: "config.yaml", region: "us-east-1"}, requested)
+	assert.Equal(t, "0.0.0.0:4317", cp.Get("receivers::otlp::protocols::grpc::endpoint"))
+}
+
+func TestGetError(t *testing.T) {
+	p := &s3Provider{
+		uri: "s3://configs/config.yaml",
+		getObject: func(context.Context, object) ([]byte, error) {
+			return nil, errors.New("access denied")
+		},
+	}
+	_, err := p.Get()
+	assert.EqualError(t, err, `error loading config "s3://configs/config.yaml": access denied`)
+
+	p.getObject = func(context.Context, object) ([]byte, error) {
+		return []byte("receivers: ["), nil
+	}
+	_, err = p.Get()
+	assert.Error(t, err)
+}
@@ -0,0 +1,159 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package secretsmanagerprovider implements a parser provider resolving the
+// references to AWS Secrets Manager secrets in the collector configuration.
+package secretsmanagerprovider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
+	"go.opentelemetry.io/collector/config/configparser"
+	"go.opentelemetry.io/collector/service/parserprovider"
+)
+
+// requestTimeout is the timeout of the requests getting the secrets.
+const requestTimeout = 10 * time.Second
+
+// referenceRegexp matches the references to secrets, ${secretsmanager:<secret-id>},
+// the secret id being the name or the ARN of the secret.
+var referenceRegexp = regexp.MustCompile(`\$\{secretsmanager:([^}]+)\}`)
+
+type secretsManagerProvider struct {
+	base           parserprovider.ParserProvider
+	getSecretValue func(ctx context.Context, secretID string) (string, error)
+}
+
+// New returns a ParserProvider replacing the ${secretsmanager:<secret-id>}
+// references in the string values of the configuration of base by the value
+// of the secrets. The secrets are looked up in the region of their ARN, or in
+// the region of the AWS shared configuration or environment, with the AWS
+// default credential chain.
+//
+// The references are resolved before the environment variables are expanded,
+// which would otherwise replace them by empty strings. The "$" of the secret
+// values are escaped as "$$" so that the expansion leaves them unchanged.
+func New(base parserprovider.ParserProvider) parserprovider.ParserProvider {
+	return &secretsManagerProvider{
+		base:           base,
+		getSecretValue: getSecretValue,
+	}
+}
+
+func (p *secretsManagerProvider) Get() (*configparser.Parser, error) {
+	cp, err := p.base.Get()
+	if err != nil {
+		return nil, err
+	}
+
+	r := &resolver{getSecretValue: p.getSecretValue, secrets: map[string]string{}}
+	for _, k := range cp.AllKeys() {
+		v, changed, err := r.resolve(cp.Get(k))
+		if err != nil {
+			return nil, fmt.Errorf("error resolving %q: %w", k, err)
+		}
+		if changed {
+			cp.Set(k, v)
+		}
+	}
+	return cp, nil
+}
+
+// resolver resolves the secret references, getting each secret once.
+type resolver struct {
+	getSecretValue func(ctx context.Context, secretID string) (string, error)
+	secrets        map[string]string
+}
+
+// resolve returns the value with its secret references resolved, and whether
+// it had any.
+func (r *resolver) resolve(value interface{}) (interface{}, bool, error) {
+	switch v := value.(type) {
+	case string:
+		return r.resolveString(v)
+	case []interface{}:
+		resolved := make([]interface{}, len(v))
+		anyChanged := false
+		for i, item := range v {
+			ri, changed, err := r.resolve(item)
+			if err != nil {
+				return nil, false, err
+			}
+			resolved[i] = ri
+			anyChanged = anyChanged || changed
+		}
+		return resolved, anyChanged, nil
+	}
+	return value, false, nil
+}
+
+func (r *resolver) resolveString(value string) (string, bool, error) {
+	if !strings.Contains(value, "${secretsmanager:") {
+		return value, false, nil
+	}
+
+	var err error
+	resolved := referenceRegexp.ReplaceAllStringFunc(value, func(ref string) string {
+		if err != nil {
+			return ref
+		}
+		secretID := referenceRegexp.FindStringSubmatch(ref)[1]
+		secret, ok := r.secrets[secretID]
+		if !ok {
+			ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+			defer cancel()
+			secret, err = r.getSecretValue(ctx, secretID)
+			if err != nil {
+				err = fmt.Errorf("error getting secret %q: %w", secretID, err)
+				return ref
+			}
+			r.secrets[secretID] = secret
+		}
+		return strings.ReplaceAll(secret, "$", "$$")
+	})
+	return resolved, true, err
+}
+
+func getSecretValue(ctx context.Context, secretID string) (string, error) {
+	sess, err := session.NewSessionWithOptions(session.Options{
+		SharedConfigState: session.SharedConfigEnable,
+	})
+	if err != nil {
+		return "", err
+	}
+	cfg := aws.NewConfig()
+	if a, err := arn.Parse(secretID); err == nil {
+		cfg = cfg.WithRegion(a.Region)
+	}
+
+	out, err := secretsmanager.New(sess, cfg).GetSecretValueWithContext(ctx, &secretsmanager.GetSecretValueInput{
+		SecretId: aws.String(secretID),
+	})
+	if err != nil {
+		return "", err
+	}
+	if out.SecretString == nil {
+		return "", errors.New("secret is not a string")
+	}
+	return *out.SecretString, nil
+}
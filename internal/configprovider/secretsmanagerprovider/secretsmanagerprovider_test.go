@@ -0,0 +1,130 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secretsmanagerprovider
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configloader"
+	"go.opentelemetry.io/collector/config/configparser"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+)
+
+type testProvider struct {
+	cfg map[string]interface{}
+}
+
+func (p *testProvider) Get() (*configparser.Parser, error) {
+	return configparser.NewParserFromStringMap(p.cfg), nil
+}
+
+func TestGet(t *testing.T) {
+	const tokenARN = "arn:aws:secretsmanager:us-east-1:123456789012:secret:otelcol/token-AbCdEf"
+
+	base := &testProvider{cfg: map[string]interface{}{
+		"exporters": map[string]interface{}{
+			"otlphttp": map[string]interface{}{
+				"endpoint": "https://otlp.example.com",
+				"headers": map[string]interface{}{
+					"authorization": "Bearer ${secretsmanager:" + tokenARN + "}",
+				},
+			},
+		},
+		"receivers": map[string]interface{}{
+			"kafka": map[string]interface{}{
+				"brokers": []interface{}{"${secretsmanager:kafka-broker}", "localhost:9092"},
+				"auth": map[string]interface{}{
+					"plain_text": map[string]interface{}{
+						"password": "${secretsmanager:" + tokenARN + "}",
+					},
+				},
+			},
+		},
+	}}
+
+	calls := map[string]int{}
+	p := &secretsManagerProvider{
+		base: base,
+		getSecretValue: func(_ context.Context, secretID string) (string, error) {
+			calls[secretID]++
+			return "value of " + secretID, nil
+		},
+	}
+
+	cp, err := p.Get()
+	require.NoError(t, err)
+	assert.Equal(t, "https://otlp.example.com", cp.Get("exporters::otlphttp::endpoint"))
+	assert.Equal(t, "Bearer value of "+tokenARN, cp.Get("exporters::otlphttp::headers::authorization"))
+	assert.Equal(t, "value of "+tokenARN, cp.Get("receivers::kafka::auth::plain_text::password"))
+	assert.Equal(t, []interface{}{"value of kafka-broker", "localhost:9092"}, cp.Get("receivers::kafka::brokers"))
+	// Each secret is retrieved once.
+	assert.Equal(t, map[string]int{tokenARN: 1, "kafka-broker": 1}, calls)
+}
+
+type testExporterConfig struct {
+	config.ExporterSettings `mapstructure:",squash"`
+	Password                string `mapstructure:"password"`
+}
+
+func TestGetSecretWithDollarSign(t *testing.T) {
+	require.NoError(t, os.Setenv("VAR", "expanded"))
+	defer os.Unsetenv("VAR")
+
+	p := &secretsManagerProvider{
+		base: &testProvider{cfg: map[string]interface{}{
+			"exporters": map[string]interface{}{
+				"test": map[string]interface{}{"password": "${secretsmanager:password}"},
+			},
+		}},
+		getSecretValue: func(context.Context, string) (string, error) {
+			return "pa$VAR$$word", nil
+		},
+	}
+	cp, err := p.Get()
+	require.NoError(t, err)
+
+	// The secret value goes through the expansion of the environment variables
+	// done when loading the configuration, and must come out unchanged.
+	factories, err := componenttest.NopFactories()
+	require.NoError(t, err)
+	factories.Exporters["test"] = exporterhelper.NewFactory("test", func() config.Exporter {
+		return &testExporterConfig{ExporterSettings: config.NewExporterSettings(config.NewID("test"))}
+	})
+	cfg, err := configloader.Load(cp, factories)
+	require.NoError(t, err)
+	assert.Equal(t, "pa$VAR$$word", cfg.Exporters[config.NewID("test")].(*testExporterConfig).Password)
+}
+
+func TestGetError(t *testing.T) {
+	p := &secretsManagerProvider{
+		base: &testProvider{cfg: map[string]interface{}{
+			"extensions": map[string]interface{}{
+				"oauth2client": map[string]interface{}{"client_secret": "${secretsmanager:missing}"},
+			},
+		}},
+		getSecretValue: func(context.Context, string) (string, error) {
+			return "", errors.New("secret not found")
+		},
+	}
+	_, err := p.Get()
+	assert.EqualError(t, err, `error resolving "extensions::oauth2client::client_secret": error getting secret "missing": secret not found`)
+}
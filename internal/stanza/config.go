@@ -27,6 +27,14 @@ type BaseConfig struct {
 	config.ReceiverSettings `mapstructure:",squash"`
 	Operators               OperatorConfigs `mapstructure:"operators"`
 	Converter               ConverterConfig `mapstructure:"converter"`
+	// ShutdownDrainTimeout bounds how long the receiver waits on shutdown
+	// for the already read log entries to be accepted by the pipeline.
+	// By default: DefaultShutdownDrainTimeout.
+	ShutdownDrainTimeout time.Duration `mapstructure:"shutdown_drain_timeout"`
+	// StorageID selects the storage extension used to persist the operators
+	// state, e.g. file offsets. When not set, the only storage extension
+	// configured in the collector is used, if any.
+	StorageID *config.ComponentID `mapstructure:"storage"`
 }
 
 // OperatorConfigs is an alias that allows for unmarshaling outside of mapstructure
@@ -86,6 +86,12 @@ type Converter struct {
 	stopOnce sync.Once
 	stopChan chan struct{}
 
+	// drainOnce guards closing workerChan when the converter is drained and
+	// closeOnce guards closing pLogsChan which happens either at the end of
+	// a drain or on Stop(), whichever comes first.
+	drainOnce sync.Once
+	closeOnce sync.Once
+
 	// workerChan is an internal communication channel that gets the log
 	// entries from Batch() calls and it receives the data in workerLoop().
 	workerChan chan *entry.Entry
@@ -114,6 +120,9 @@ type Converter struct {
 	// wg is a WaitGroup that makes sure that we wait for spun up goroutines exit
 	// when Stop() is called.
 	wg sync.WaitGroup
+	// workerWg tracks only the workers so that batchChan can be closed once
+	// all of them returned during a drain.
+	workerWg sync.WaitGroup
 
 	logger *zap.Logger
 }
@@ -178,9 +187,20 @@ func (c *Converter) Start() {
 
 	for i := 0; i < c.workerCount; i++ {
 		c.wg.Add(1)
+		c.workerWg.Add(1)
 		go c.workerLoop()
 	}
 
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+		c.workerWg.Wait()
+		// Workers only return once workerChan is closed by Drain() or
+		// the converter is stopped, either way nothing else will be sent
+		// onto batchChan.
+		close(c.batchChan)
+	}()
+
 	c.wg.Add(1)
 	go c.batchLoop()
 
@@ -192,7 +212,18 @@ func (c *Converter) Stop() {
 	c.stopOnce.Do(func() {
 		close(c.stopChan)
 		c.wg.Wait()
-		close(c.pLogsChan)
+		c.closeOnce.Do(func() { close(c.pLogsChan) })
+	})
+}
+
+// Drain stops accepting new entries and lets the entries which were already
+// batched be converted and flushed onto the out channel, which is closed
+// afterwards. Drain doesn't block, callers should consume OutChannel() until
+// it's closed and call Stop() if they give up earlier.
+// Batch() must not be called after Drain().
+func (c *Converter) Drain() {
+	c.drainOnce.Do(func() {
+		close(c.workerChan)
 	})
 }
 
@@ -212,6 +243,7 @@ type workerItem struct {
 // associated Resource through the batchChan for aggregation.
 func (c *Converter) workerLoop() {
 	defer c.wg.Done()
+	defer c.workerWg.Done()
 
 	var (
 		buff    = bytes.Buffer{}
@@ -264,6 +296,18 @@ func (c *Converter) batchLoop() {
 		select {
 		case wi, ok := <-c.batchChan:
 			if !ok {
+				// All workers are done, flush whatever is left and let
+				// flushLoop know there's nothing more to come.
+				for r, pLogs := range c.data {
+					select {
+					case c.flushChan <- pLogs:
+					case <-c.stopChan:
+						return
+					}
+					delete(c.data, r)
+				}
+				c.logRecordCount = 0
+				close(c.flushChan)
 				return
 			}
 
@@ -324,7 +368,11 @@ func (c *Converter) flushLoop() {
 		case <-c.stopChan:
 			return
 
-		case pLogs := <-c.flushChan:
+		case pLogs, ok := <-c.flushChan:
+			if !ok {
+				c.closeOnce.Do(func() { close(c.pLogsChan) })
+				return
+			}
 			if err := c.flush(ctx, pLogs); err != nil {
 				c.logger.Debug("Problem sending log entries",
 					zap.Error(err),
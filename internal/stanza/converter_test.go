@@ -415,6 +415,32 @@ func TestConverterCancelledContextCancellsTheFlush(t *testing.T) {
 	wg.Wait()
 }
 
+func TestConverterDrainFlushesPendingEntries(t *testing.T) {
+	converter := NewConverter(
+		WithWorkerCount(2),
+		// Make sure entries are only flushed because of the drain.
+		WithMaxFlushCount(1000),
+		WithFlushInterval(time.Hour),
+	)
+	converter.Start()
+	defer converter.Stop()
+
+	const entries = 50
+	for _, ent := range complexEntriesForNDifferentHosts(entries, 5) {
+		require.NoError(t, converter.Batch(ent))
+	}
+	converter.Drain()
+
+	var actualCount int
+	for pLogs := range converter.OutChannel() {
+		actualCount += pLogs.LogRecordCount()
+	}
+
+	assert.Equal(t, entries, actualCount,
+		"all batched entries should be flushed before the out channel is closed",
+	)
+}
+
 func TestConvertMetadata(t *testing.T) {
 	now := time.Now()
 
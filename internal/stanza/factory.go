@@ -16,6 +16,7 @@ package stanza
 
 import (
 	"context"
+	"time"
 
 	"github.com/open-telemetry/opentelemetry-log-collection/agent"
 	"github.com/open-telemetry/opentelemetry-log-collection/operator"
@@ -25,6 +26,10 @@ import (
 	"go.opentelemetry.io/collector/receiver/receiverhelper"
 )
 
+// DefaultShutdownDrainTimeout is how long a stanza-based receiver waits on
+// shutdown for in-flight log entries when no timeout is configured.
+const DefaultShutdownDrainTimeout = 5 * time.Second
+
 // LogReceiverType is the interface used by stanza-based log receivers
 type LogReceiverType interface {
 	Type() config.Type
@@ -33,6 +38,12 @@ type LogReceiverType interface {
 	DecodeInputConfig(config.Receiver) (*operator.Config, error)
 }
 
+// PersisterWrapper is optionally implemented by a LogReceiverType that needs to
+// change how the state of its operators is persisted.
+type PersisterWrapper interface {
+	WrapPersister(config.Receiver, operator.Persister) operator.Persister
+}
+
 // NewFactory creates a factory for a Stanza-based receiver
 func NewFactory(logReceiverType LogReceiverType) component.ReceiverFactory {
 	return receiverhelper.NewFactory(
@@ -85,13 +96,28 @@ func createLogsReceiver(logReceiverType LogReceiverType) receiverhelper.CreateLo
 		}
 		converter := NewConverter(opts...)
 
+		drainTimeout := DefaultShutdownDrainTimeout
+		if baseCfg.ShutdownDrainTimeout > 0 {
+			drainTimeout = baseCfg.ShutdownDrainTimeout
+		}
+
+		var wrapPersister func(operator.Persister) operator.Persister
+		if wrapper, ok := logReceiverType.(PersisterWrapper); ok {
+			wrapPersister = func(p operator.Persister) operator.Persister {
+				return wrapper.WrapPersister(cfg, p)
+			}
+		}
+
 		return &receiver{
-			id:        cfg.ID(),
-			agent:     logAgent,
-			emitter:   emitter,
-			consumer:  nextConsumer,
-			logger:    params.Logger,
-			converter: converter,
+			id:            cfg.ID(),
+			agent:         logAgent,
+			emitter:       emitter,
+			consumer:      nextConsumer,
+			logger:        params.Logger,
+			converter:     converter,
+			storageID:     baseCfg.StorageID,
+			wrapPersister: wrapPersister,
+			drainTimeout:  drainTimeout,
 		}, nil
 	}
 }
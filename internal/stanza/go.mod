@@ -3,10 +3,12 @@ module github.com/open-telemetry/opentelemetry-collector-contrib/internal/stanza
 go 1.16
 
 require (
+	github.com/jpillora/backoff v1.0.0
 	github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage v0.0.0-00010101000000-000000000000
 	github.com/open-telemetry/opentelemetry-log-collection v0.18.1-0.20210524142652-964a7f9c789f
 	github.com/pelletier/go-toml v1.8.0 // indirect
 	github.com/stretchr/testify v1.7.0
+	go.opencensus.io v0.23.0
 	go.opentelemetry.io/collector v0.27.1-0.20210603182316-5369d7e9e83e
 	go.uber.org/multierr v1.6.0
 	go.uber.org/zap v1.17.0
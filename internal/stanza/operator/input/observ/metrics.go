@@ -0,0 +1,69 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package observ contains the internal metrics of the network log inputs of
+// the stanza-based log receivers.
+package observ
+
+import (
+	"context"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+)
+
+var (
+	// TagKeyInput is the tag key of the ID of the input operator recording the metrics.
+	TagKeyInput, _ = tag.NewKey("input")
+
+	// DroppedPackets measure for number of UDP packets dropped because the decoding queue was full.
+	DroppedPackets = stats.Int64(
+		"stanza_input_dropped_packets",
+		"Number of UDP packets dropped because the decoding queue was full",
+		stats.UnitDimensionless)
+	droppedPacketsView = &view.View{
+		Name:        DroppedPackets.Name(),
+		Measure:     DroppedPackets,
+		Description: DroppedPackets.Description(),
+		TagKeys:     []tag.Key{TagKeyInput},
+		Aggregation: view.Sum(),
+	}
+
+	// DroppedEntries measure for number of log messages dropped because they could not be decoded.
+	DroppedEntries = stats.Int64(
+		"stanza_input_dropped_entries",
+		"Number of log messages dropped because they could not be decoded",
+		stats.UnitDimensionless)
+	droppedEntriesView = &view.View{
+		Name:        DroppedEntries.Name(),
+		Measure:     DroppedEntries,
+		Description: DroppedEntries.Description(),
+		TagKeys:     []tag.Key{TagKeyInput},
+		Aggregation: view.Sum(),
+	}
+)
+
+// MetricViews returns the views of the metrics of the network log inputs.
+func MetricViews() []*view.View {
+	return []*view.View{
+		droppedPacketsView,
+		droppedEntriesView,
+	}
+}
+
+// RecordDropped records m for the input with the given ID.
+func RecordDropped(input string, m stats.Measurement) {
+	_ = stats.RecordWithTags(context.Background(), []tag.Mutator{tag.Upsert(TagKeyInput, input)}, m)
+}
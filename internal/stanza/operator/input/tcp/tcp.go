@@ -0,0 +1,387 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package tcp implements the tcp input of the tcplog receiver. Unlike the tcp
+// input of the log collection library, the connections only split the stream
+// into messages and hand them to a pool of workers through a bounded queue,
+// which decode them into log entries. When the queue is full the connections
+// stop reading, pushing back on the senders through TCP flow control.
+package tcp
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/jpillora/backoff"
+	"github.com/open-telemetry/opentelemetry-log-collection/operator"
+	"github.com/open-telemetry/opentelemetry-log-collection/operator/helper"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/stanza/operator/input/observ"
+)
+
+const (
+	operatorType = "tcp_input"
+
+	// minMaxLogSize is the minimal size which can be used for buffering
+	// TCP input
+	minMaxLogSize = 64 * 1024
+
+	// DefaultMaxLogSize is the max buffer sized used
+	// if MaxLogSize is not set
+	DefaultMaxLogSize = 1024 * 1024
+
+	// DefaultWorkerCount is the number of workers decoding messages if WorkerCount is not set.
+	DefaultWorkerCount = 4
+	// DefaultQueueSize is the number of messages waiting for a worker if QueueSize is not set.
+	DefaultQueueSize = 10000
+)
+
+// NewTCPInputConfig creates a new TCP input config with default values
+func NewTCPInputConfig(operatorID string) *TCPInputConfig {
+	return &TCPInputConfig{
+		InputConfig: helper.NewInputConfig(operatorID, operatorType),
+		Multiline:   helper.NewMultilineConfig(),
+		Encoding:    helper.NewEncodingConfig(),
+		WorkerCount: DefaultWorkerCount,
+		QueueSize:   DefaultQueueSize,
+	}
+}
+
+// TCPInputConfig is the configuration of a tcp input operator.
+type TCPInputConfig struct {
+	helper.InputConfig `yaml:",inline"`
+
+	MaxLogSize     helper.ByteSize         `mapstructure:"max_log_size,omitempty"     json:"max_log_size,omitempty"     yaml:"max_log_size,omitempty"`
+	ListenAddress  string                  `mapstructure:"listen_address,omitempty"   json:"listen_address,omitempty"   yaml:"listen_address,omitempty"`
+	TLS            *helper.TLSServerConfig `mapstructure:"tls,omitempty"              json:"tls,omitempty"              yaml:"tls,omitempty"`
+	AddAttributes  bool                    `mapstructure:"add_attributes,omitempty"   json:"add_attributes,omitempty"   yaml:"add_attributes,omitempty"`
+	Encoding       helper.EncodingConfig   `mapstructure:",squash,omitempty"          json:",inline,omitempty"          yaml:",inline,omitempty"`
+	Multiline      helper.MultilineConfig  `mapstructure:"multiline,omitempty"        json:"multiline,omitempty"        yaml:"multiline,omitempty"`
+	ReadBufferSize helper.ByteSize         `mapstructure:"read_buffer_size,omitempty" json:"read_buffer_size,omitempty" yaml:"read_buffer_size,omitempty"`
+	WorkerCount    int                     `mapstructure:"worker_count,omitempty"     json:"worker_count,omitempty"     yaml:"worker_count,omitempty"`
+	QueueSize      int                     `mapstructure:"queue_size,omitempty"       json:"queue_size,omitempty"       yaml:"queue_size,omitempty"`
+}
+
+// Build will build a tcp input operator.
+func (c TCPInputConfig) Build(context operator.BuildContext) ([]operator.Operator, error) {
+	inputOperator, err := c.InputConfig.Build(context)
+	if err != nil {
+		return nil, err
+	}
+
+	// If MaxLogSize not set, set sane default in order to remain
+	// backwards compatible with existing plugins and configurations
+	if c.MaxLogSize == 0 {
+		c.MaxLogSize = DefaultMaxLogSize
+	}
+
+	if c.MaxLogSize < minMaxLogSize {
+		return nil, fmt.Errorf("invalid value for parameter 'max_log_size', must be equal to or greater than %d bytes", minMaxLogSize)
+	}
+
+	if c.ListenAddress == "" {
+		return nil, fmt.Errorf("missing required parameter 'listen_address'")
+	}
+
+	// validate the input address
+	if _, err := net.ResolveTCPAddr("tcp", c.ListenAddress); err != nil {
+		return nil, fmt.Errorf("failed to resolve listen_address: %s", err)
+	}
+
+	if c.ReadBufferSize < 0 {
+		return nil, fmt.Errorf("invalid value for parameter 'read_buffer_size', must not be negative")
+	}
+	if c.WorkerCount < 1 {
+		return nil, fmt.Errorf("invalid value for parameter 'worker_count', must be at least 1")
+	}
+	if c.QueueSize < 1 {
+		return nil, fmt.Errorf("invalid value for parameter 'queue_size', must be at least 1")
+	}
+
+	encoding, err := c.Encoding.Build(context)
+	if err != nil {
+		return nil, err
+	}
+
+	splitFunc, err := c.Multiline.Build(context, encoding.Encoding, true)
+	if err != nil {
+		return nil, err
+	}
+
+	var resolver *helper.IPResolver
+	if c.AddAttributes {
+		resolver = helper.NewIpResolver()
+	}
+
+	tcpInput := &TCPInput{
+		InputOperator:  inputOperator,
+		address:        c.ListenAddress,
+		MaxLogSize:     int(c.MaxLogSize),
+		addAttributes:  c.AddAttributes,
+		readBufferSize: int(c.ReadBufferSize),
+		workers:        c.WorkerCount,
+		queueSize:      c.QueueSize,
+		encoding:       encoding,
+		splitFunc:      splitFunc,
+		backoff: backoff.Backoff{
+			Max: 3 * time.Second,
+		},
+		resolver: resolver,
+	}
+
+	if c.TLS != nil {
+		tcpInput.tls, err = c.TLS.LoadTLSConfig()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return []operator.Operator{tcpInput}, nil
+}
+
+// message is a message read from a connection, waiting to be decoded.
+type message struct {
+	data       []byte
+	localAddr  net.Addr
+	remoteAddr net.Addr
+}
+
+// TCPInput is an operator that listens for log entries over tcp.
+type TCPInput struct {
+	helper.InputOperator
+	address        string
+	MaxLogSize     int
+	addAttributes  bool
+	readBufferSize int
+	workers        int
+	queueSize      int
+
+	listener  net.Listener
+	messages  chan message
+	cancel    context.CancelFunc
+	wg        sync.WaitGroup
+	workersWG sync.WaitGroup
+	tls       *tls.Config
+	backoff   backoff.Backoff
+
+	encoding  helper.Encoding
+	splitFunc bufio.SplitFunc
+	resolver  *helper.IPResolver
+}
+
+// Start will start listening for log entries over tcp.
+func (t *TCPInput) Start(_ operator.Persister) error {
+	if err := t.configureListener(); err != nil {
+		return fmt.Errorf("failed to listen on interface: %w", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.cancel = cancel
+	t.messages = make(chan message, t.queueSize)
+	for i := 0; i < t.workers; i++ {
+		t.goProcessMessages(ctx)
+	}
+	t.goListen(ctx)
+	return nil
+}
+
+func (t *TCPInput) configureListener() error {
+	listener, err := net.Listen("tcp", t.address)
+	if err != nil {
+		return fmt.Errorf("failed to configure tcp listener: %w", err)
+	}
+	if t.readBufferSize > 0 {
+		listener = &readBufferListener{Listener: listener, readBufferSize: t.readBufferSize}
+	}
+
+	if t.tls == nil {
+		t.listener = listener
+		return nil
+	}
+
+	t.tls.Time = time.Now
+	t.tls.Rand = rand.Reader
+	t.listener = tls.NewListener(listener, t.tls)
+	return nil
+}
+
+// readBufferListener sets the OS receive buffer size of the accepted connections.
+type readBufferListener struct {
+	net.Listener
+	readBufferSize int
+}
+
+func (l *readBufferListener) Accept() (net.Conn, error) {
+	conn, err := l.Listener.Accept()
+	if err != nil {
+		return nil, err
+	}
+	if tcpConn, ok := conn.(*net.TCPConn); ok {
+		if err := tcpConn.SetReadBuffer(l.readBufferSize); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("failed to set read buffer size: %w", err)
+		}
+	}
+	return conn, nil
+}
+
+// goListen will listen for tcp connections.
+func (t *TCPInput) goListen(ctx context.Context) {
+	t.wg.Add(1)
+
+	go func() {
+		defer t.wg.Done()
+
+		for {
+			conn, err := t.listener.Accept()
+			if err != nil {
+				select {
+				case <-ctx.Done():
+					return
+				default:
+					t.Debugw("Listener accept error", zap.Error(err))
+					time.Sleep(t.backoff.Duration())
+					continue
+				}
+			}
+			t.backoff.Reset()
+
+			t.Debugf("Received connection: %s", conn.RemoteAddr().String())
+			subctx, cancel := context.WithCancel(ctx)
+			t.goHandleClose(subctx, conn)
+			t.goReadMessages(subctx, conn, cancel)
+		}
+	}()
+}
+
+// goHandleClose will wait for the context to finish before closing a connection.
+func (t *TCPInput) goHandleClose(ctx context.Context, conn net.Conn) {
+	t.wg.Add(1)
+
+	go func() {
+		defer t.wg.Done()
+		<-ctx.Done()
+		t.Debugf("Closing connection: %s", conn.RemoteAddr().String())
+		if err := conn.Close(); err != nil {
+			t.Errorf("Failed to close connection: %s", err)
+		}
+	}()
+}
+
+// goReadMessages splits the stream of a tcp connection into messages and
+// queues them for the workers, waiting while the queue is full.
+func (t *TCPInput) goReadMessages(ctx context.Context, conn net.Conn, cancel context.CancelFunc) {
+	t.wg.Add(1)
+
+	go func() {
+		defer t.wg.Done()
+		defer cancel()
+
+		buf := make([]byte, 0, t.MaxLogSize)
+		scanner := bufio.NewScanner(conn)
+		scanner.Buffer(buf, t.MaxLogSize)
+
+		scanner.Split(t.splitFunc)
+
+		for scanner.Scan() {
+			m := message{
+				data:       append([]byte(nil), scanner.Bytes()...),
+				localAddr:  conn.LocalAddr(),
+				remoteAddr: conn.RemoteAddr(),
+			}
+			select {
+			case t.messages <- m:
+			case <-ctx.Done():
+				return
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			t.Errorw("Scanner error", zap.Error(err))
+		}
+	}()
+}
+
+// goProcessMessages decodes the queued messages into log entries until the
+// queue is closed.
+func (t *TCPInput) goProcessMessages(ctx context.Context) {
+	t.workersWG.Add(1)
+
+	go func() {
+		defer t.workersWG.Done()
+
+		for m := range t.messages {
+			t.processMessage(ctx, m)
+		}
+	}()
+}
+
+func (t *TCPInput) processMessage(ctx context.Context, m message) {
+	decoded, err := t.encoding.Decode(m.data)
+	if err != nil {
+		t.Errorw("Failed to decode data", zap.Error(err))
+		observ.RecordDropped(t.ID(), observ.DroppedEntries.M(1))
+		return
+	}
+
+	entry, err := t.NewEntry(decoded)
+	if err != nil {
+		t.Errorw("Failed to create entry", zap.Error(err))
+		observ.RecordDropped(t.ID(), observ.DroppedEntries.M(1))
+		return
+	}
+
+	if t.addAttributes {
+		entry.AddAttribute("net.transport", "IP.TCP")
+		if addr, ok := m.remoteAddr.(*net.TCPAddr); ok {
+			ip := addr.IP.String()
+			entry.AddAttribute("net.peer.ip", ip)
+			entry.AddAttribute("net.peer.port", strconv.FormatInt(int64(addr.Port), 10))
+			entry.AddAttribute("net.peer.name", t.resolver.GetHostFromIp(ip))
+		}
+
+		if addr, ok := m.localAddr.(*net.TCPAddr); ok {
+			ip := addr.IP.String()
+			entry.AddAttribute("net.host.ip", ip)
+			entry.AddAttribute("net.host.port", strconv.FormatInt(int64(addr.Port), 10))
+			entry.AddAttribute("net.host.name", t.resolver.GetHostFromIp(ip))
+		}
+	}
+
+	t.Write(ctx, entry)
+}
+
+// Stop will stop listening for log entries over TCP.
+func (t *TCPInput) Stop() error {
+	t.cancel()
+
+	if err := t.listener.Close(); err != nil {
+		return err
+	}
+
+	t.wg.Wait()
+	close(t.messages)
+	t.workersWG.Wait()
+	if t.resolver != nil {
+		t.resolver.Stop()
+	}
+	return nil
+}
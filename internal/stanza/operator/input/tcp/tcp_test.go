@@ -0,0 +1,126 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tcp
+
+import (
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-log-collection/operator"
+	"github.com/open-telemetry/opentelemetry-log-collection/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestInput(t *testing.T, cfg *TCPInputConfig) (*TCPInput, *testutil.FakeOutput) {
+	ops, err := cfg.Build(testutil.NewBuildContext(t))
+	require.NoError(t, err)
+	tcpInput := ops[0].(*TCPInput)
+	fake := testutil.NewFakeOutput(t)
+	tcpInput.OutputOperators = []operator.Operator{fake}
+	return tcpInput, fake
+}
+
+func TestBuildErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(cfg *TCPInputConfig)
+		wantErr string
+	}{
+		{
+			name:    "no_listen_address",
+			modify:  func(cfg *TCPInputConfig) { cfg.ListenAddress = "" },
+			wantErr: "missing required parameter 'listen_address'",
+		},
+		{
+			name:    "small_max_log_size",
+			modify:  func(cfg *TCPInputConfig) { cfg.MaxLogSize = 1024 },
+			wantErr: "invalid value for parameter 'max_log_size'",
+		},
+		{
+			name:    "negative_read_buffer_size",
+			modify:  func(cfg *TCPInputConfig) { cfg.ReadBufferSize = -1 },
+			wantErr: "invalid value for parameter 'read_buffer_size'",
+		},
+		{
+			name:    "no_workers",
+			modify:  func(cfg *TCPInputConfig) { cfg.WorkerCount = 0 },
+			wantErr: "invalid value for parameter 'worker_count'",
+		},
+		{
+			name:    "no_queue",
+			modify:  func(cfg *TCPInputConfig) { cfg.QueueSize = 0 },
+			wantErr: "invalid value for parameter 'queue_size'",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := NewTCPInputConfig("test_input")
+			cfg.ListenAddress = ":0"
+			tt.modify(cfg)
+			_, err := cfg.Build(testutil.NewBuildContext(t))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestTCPInputWorkers(t *testing.T) {
+	cfg := NewTCPInputConfig("test_input")
+	cfg.ListenAddress = "127.0.0.1:0"
+	cfg.ReadBufferSize = 1024 * 1024
+	cfg.QueueSize = 2
+	cfg.AddAttributes = true
+	tcpInput, fake := newTestInput(t, cfg)
+	require.NoError(t, tcpInput.Start(testutil.NewMockPersister("test")))
+	defer tcpInput.Stop()
+
+	conn, err := net.Dial("tcp", tcpInput.listener.Addr().String())
+	require.NoError(t, err)
+	defer conn.Close()
+
+	// More messages than the fake output and the queue hold: the connection
+	// waits for the workers instead of dropping them.
+	const numMessages = 200
+	var want []string
+	var sb strings.Builder
+	for i := 0; i < numMessages; i++ {
+		body := "message" + strconv.Itoa(i)
+		want = append(want, body)
+		sb.WriteString(body + "\n")
+	}
+	_, err = conn.Write([]byte(sb.String()))
+	require.NoError(t, err)
+
+	var got []string
+	for i := 0; i < numMessages; i++ {
+		select {
+		case e := <-fake.Received:
+			got = append(got, e.Body.(string))
+			assert.Equal(t, "IP.TCP", e.Attributes["net.transport"])
+			assert.Equal(t, strconv.Itoa(conn.LocalAddr().(*net.TCPAddr).Port), e.Attributes["net.peer.port"])
+		case <-time.After(time.Second):
+			require.FailNow(t, "Timed out waiting for message to be written")
+		}
+	}
+	// The workers decode the messages concurrently, in any order.
+	sort.Strings(want)
+	sort.Strings(got)
+	assert.Equal(t, want, got)
+}
@@ -0,0 +1,289 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package udp implements the udp input of the udplog receiver. Unlike the udp
+// input of the log collection library, it reads packets on a dedicated
+// goroutine and hands them to a pool of workers through a bounded queue, so that
+// decoding and a slow pipeline don't leave packets waiting in the OS receive
+// buffer until it overflows. Packets arriving while the queue is full are
+// dropped and counted.
+package udp
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"sync"
+
+	"github.com/open-telemetry/opentelemetry-log-collection/operator"
+	"github.com/open-telemetry/opentelemetry-log-collection/operator/helper"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/stanza/operator/input/observ"
+)
+
+const (
+	operatorType = "udp_input"
+
+	// MaxUDPSize is the maximum UDP packet size.
+	MaxUDPSize = 64 * 1024
+
+	// DefaultWorkerCount is the number of workers decoding packets if WorkerCount is not set.
+	DefaultWorkerCount = 4
+	// DefaultQueueSize is the number of packets waiting for a worker if QueueSize is not set.
+	DefaultQueueSize = 10000
+)
+
+// NewUDPInputConfig creates a new UDP input config with default values
+func NewUDPInputConfig(operatorID string) *UDPInputConfig {
+	return &UDPInputConfig{
+		InputConfig: helper.NewInputConfig(operatorID, operatorType),
+		Encoding:    helper.NewEncodingConfig(),
+		Multiline: helper.MultilineConfig{
+			LineStartPattern: "",
+			LineEndPattern:   ".^", // Use never matching regex to not split data by default
+		},
+		WorkerCount: DefaultWorkerCount,
+		QueueSize:   DefaultQueueSize,
+	}
+}
+
+// UDPInputConfig is the configuration of a udp input operator.
+type UDPInputConfig struct {
+	helper.InputConfig `yaml:",inline"`
+
+	ListenAddress  string                 `mapstructure:"listen_address,omitempty"   json:"listen_address,omitempty"   yaml:"listen_address,omitempty"`
+	AddAttributes  bool                   `mapstructure:"add_attributes,omitempty"   json:"add_attributes,omitempty"   yaml:"add_attributes,omitempty"`
+	Encoding       helper.EncodingConfig  `mapstructure:",squash,omitempty"          json:",inline,omitempty"          yaml:",inline,omitempty"`
+	Multiline      helper.MultilineConfig `mapstructure:"multiline,omitempty"        json:"multiline,omitempty"        yaml:"multiline,omitempty"`
+	ReadBufferSize helper.ByteSize        `mapstructure:"read_buffer_size,omitempty" json:"read_buffer_size,omitempty" yaml:"read_buffer_size,omitempty"`
+	WorkerCount    int                    `mapstructure:"worker_count,omitempty"     json:"worker_count,omitempty"     yaml:"worker_count,omitempty"`
+	QueueSize      int                    `mapstructure:"queue_size,omitempty"       json:"queue_size,omitempty"       yaml:"queue_size,omitempty"`
+}
+
+// Build will build a udp input operator.
+func (c UDPInputConfig) Build(context operator.BuildContext) ([]operator.Operator, error) {
+	inputOperator, err := c.InputConfig.Build(context)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.ListenAddress == "" {
+		return nil, fmt.Errorf("missing required parameter 'listen_address'")
+	}
+
+	address, err := net.ResolveUDPAddr("udp", c.ListenAddress)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve listen_address: %s", err)
+	}
+
+	if c.ReadBufferSize < 0 {
+		return nil, fmt.Errorf("invalid value for parameter 'read_buffer_size', must not be negative")
+	}
+	if c.WorkerCount < 1 {
+		return nil, fmt.Errorf("invalid value for parameter 'worker_count', must be at least 1")
+	}
+	if c.QueueSize < 1 {
+		return nil, fmt.Errorf("invalid value for parameter 'queue_size', must be at least 1")
+	}
+
+	encoding, err := c.Encoding.Build(context)
+	if err != nil {
+		return nil, err
+	}
+
+	splitFunc, err := c.Multiline.Build(context, encoding.Encoding, true)
+	if err != nil {
+		return nil, err
+	}
+
+	var resolver *helper.IPResolver
+	if c.AddAttributes {
+		resolver = helper.NewIpResolver()
+	}
+
+	udpInput := &UDPInput{
+		InputOperator:  inputOperator,
+		address:        address,
+		addAttributes:  c.AddAttributes,
+		readBufferSize: int(c.ReadBufferSize),
+		workers:        c.WorkerCount,
+		queueSize:      c.QueueSize,
+		encoding:       encoding,
+		splitFunc:      splitFunc,
+		resolver:       resolver,
+	}
+	return []operator.Operator{udpInput}, nil
+}
+
+// packet is a UDP packet waiting to be decoded.
+type packet struct {
+	data       []byte
+	remoteAddr net.Addr
+}
+
+// UDPInput is an operator that listens to a socket for log entries.
+type UDPInput struct {
+	helper.InputOperator
+	address        *net.UDPAddr
+	addAttributes  bool
+	readBufferSize int
+	workers        int
+	queueSize      int
+
+	connection *net.UDPConn
+	packets    chan packet
+	cancel     context.CancelFunc
+	readerWG   sync.WaitGroup
+	workersWG  sync.WaitGroup
+
+	encoding  helper.Encoding
+	splitFunc bufio.SplitFunc
+	resolver  *helper.IPResolver
+}
+
+// Start will start listening for messages on a socket.
+func (u *UDPInput) Start(_ operator.Persister) error {
+	conn, err := net.ListenUDP("udp", u.address)
+	if err != nil {
+		return fmt.Errorf("failed to open connection: %s", err)
+	}
+	if u.readBufferSize > 0 {
+		if err := conn.SetReadBuffer(u.readBufferSize); err != nil {
+			conn.Close()
+			return fmt.Errorf("failed to set read buffer size: %w", err)
+		}
+	}
+	u.connection = conn
+
+	ctx, cancel := context.WithCancel(context.Background())
+	u.cancel = cancel
+	u.packets = make(chan packet, u.queueSize)
+	for i := 0; i < u.workers; i++ {
+		u.goProcessPackets(ctx)
+	}
+	u.goReadPackets(ctx)
+	return nil
+}
+
+// goReadPackets reads packets from the connection and queues them for the
+// workers, dropping them if the queue is full.
+func (u *UDPInput) goReadPackets(ctx context.Context) {
+	u.readerWG.Add(1)
+
+	go func() {
+		defer u.readerWG.Done()
+
+		buf := make([]byte, MaxUDPSize)
+		for {
+			n, remoteAddr, err := u.connection.ReadFrom(buf)
+			if err != nil {
+				select {
+				case <-ctx.Done():
+					return
+				default:
+					u.Errorw("Failed reading messages", zap.Error(err))
+					continue
+				}
+			}
+
+			// Remove trailing characters and NULs
+			for ; (n > 0) && (buf[n-1] < 32); n-- {
+			}
+
+			p := packet{data: make([]byte, n), remoteAddr: remoteAddr}
+			copy(p.data, buf[:n])
+			select {
+			case u.packets <- p:
+			default:
+				observ.RecordDropped(u.ID(), observ.DroppedPackets.M(1))
+			}
+		}
+	}()
+}
+
+// goProcessPackets decodes the queued packets into log entries until the queue
+// is closed.
+func (u *UDPInput) goProcessPackets(ctx context.Context) {
+	u.workersWG.Add(1)
+
+	go func() {
+		defer u.workersWG.Done()
+
+		buf := make([]byte, 0, MaxUDPSize)
+		for p := range u.packets {
+			u.processPacket(ctx, p, buf)
+		}
+	}()
+}
+
+func (u *UDPInput) processPacket(ctx context.Context, p packet, buf []byte) {
+	scanner := bufio.NewScanner(bytes.NewReader(p.data))
+	scanner.Buffer(buf, MaxUDPSize)
+	scanner.Split(u.splitFunc)
+
+	for scanner.Scan() {
+		decoded, err := u.encoding.Decode(scanner.Bytes())
+		if err != nil {
+			u.Errorw("Failed to decode data", zap.Error(err))
+			observ.RecordDropped(u.ID(), observ.DroppedEntries.M(1))
+			continue
+		}
+
+		entry, err := u.NewEntry(decoded)
+		if err != nil {
+			u.Errorw("Failed to create entry", zap.Error(err))
+			observ.RecordDropped(u.ID(), observ.DroppedEntries.M(1))
+			continue
+		}
+
+		if u.addAttributes {
+			entry.AddAttribute("net.transport", "IP.UDP")
+			if addr, ok := u.connection.LocalAddr().(*net.UDPAddr); ok {
+				ip := addr.IP.String()
+				entry.AddAttribute("net.host.ip", ip)
+				entry.AddAttribute("net.host.port", strconv.FormatInt(int64(addr.Port), 10))
+				entry.AddAttribute("net.host.name", u.resolver.GetHostFromIp(ip))
+			}
+
+			if addr, ok := p.remoteAddr.(*net.UDPAddr); ok {
+				ip := addr.IP.String()
+				entry.AddAttribute("net.peer.ip", ip)
+				entry.AddAttribute("net.peer.port", strconv.FormatInt(int64(addr.Port), 10))
+				entry.AddAttribute("net.peer.name", u.resolver.GetHostFromIp(ip))
+			}
+		}
+
+		u.Write(ctx, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		u.Errorw("Scanner error", zap.Error(err))
+	}
+}
+
+// Stop will stop listening for udp messages.
+func (u *UDPInput) Stop() error {
+	u.cancel()
+	u.connection.Close()
+	u.readerWG.Wait()
+	close(u.packets)
+	u.workersWG.Wait()
+	if u.resolver != nil {
+		u.resolver.Stop()
+	}
+	return nil
+}
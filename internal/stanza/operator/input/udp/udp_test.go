@@ -0,0 +1,157 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package udp
+
+import (
+	"net"
+	"sort"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-log-collection/entry"
+	"github.com/open-telemetry/opentelemetry-log-collection/operator"
+	"github.com/open-telemetry/opentelemetry-log-collection/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/stats/view"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/stanza/operator/input/observ"
+)
+
+func newTestInput(t *testing.T, cfg *UDPInputConfig) (*UDPInput, *testutil.FakeOutput) {
+	ops, err := cfg.Build(testutil.NewBuildContext(t))
+	require.NoError(t, err)
+	udpInput := ops[0].(*UDPInput)
+	fake := testutil.NewFakeOutput(t)
+	udpInput.OutputOperators = []operator.Operator{fake}
+	return udpInput, fake
+}
+
+func TestBuildErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(cfg *UDPInputConfig)
+		wantErr string
+	}{
+		{
+			name:    "no_listen_address",
+			modify:  func(cfg *UDPInputConfig) { cfg.ListenAddress = "" },
+			wantErr: "missing required parameter 'listen_address'",
+		},
+		{
+			name:    "negative_read_buffer_size",
+			modify:  func(cfg *UDPInputConfig) { cfg.ReadBufferSize = -1 },
+			wantErr: "invalid value for parameter 'read_buffer_size'",
+		},
+		{
+			name:    "no_workers",
+			modify:  func(cfg *UDPInputConfig) { cfg.WorkerCount = 0 },
+			wantErr: "invalid value for parameter 'worker_count'",
+		},
+		{
+			name:    "no_queue",
+			modify:  func(cfg *UDPInputConfig) { cfg.QueueSize = 0 },
+			wantErr: "invalid value for parameter 'queue_size'",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := NewUDPInputConfig("test_input")
+			cfg.ListenAddress = ":0"
+			tt.modify(cfg)
+			_, err := cfg.Build(testutil.NewBuildContext(t))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestUDPInputWorkers(t *testing.T) {
+	cfg := NewUDPInputConfig("test_input")
+	cfg.ListenAddress = "127.0.0.1:0"
+	cfg.ReadBufferSize = 1024 * 1024
+	cfg.AddAttributes = true
+	udpInput, fake := newTestInput(t, cfg)
+	require.NoError(t, udpInput.Start(testutil.NewMockPersister("test")))
+	defer udpInput.Stop()
+
+	conn, err := net.Dial("udp", udpInput.connection.LocalAddr().String())
+	require.NoError(t, err)
+	defer conn.Close()
+
+	const numMessages = 20
+	var want []string
+	for i := 0; i < numMessages; i++ {
+		body := "message" + strconv.Itoa(i)
+		want = append(want, body)
+		_, err = conn.Write([]byte(body + "\n"))
+		require.NoError(t, err)
+	}
+
+	var got []string
+	for i := 0; i < numMessages; i++ {
+		select {
+		case e := <-fake.Received:
+			got = append(got, e.Body.(string))
+			assert.Equal(t, "IP.UDP", e.Attributes["net.transport"])
+			assert.Equal(t, conn.LocalAddr().(*net.UDPAddr).IP.String(), e.Attributes["net.peer.ip"])
+			assert.Equal(t, strconv.Itoa(conn.LocalAddr().(*net.UDPAddr).Port), e.Attributes["net.peer.port"])
+		case <-time.After(time.Second):
+			require.FailNow(t, "Timed out waiting for message to be written")
+		}
+	}
+	// The workers decode the packets concurrently, in any order.
+	sort.Strings(want)
+	sort.Strings(got)
+	assert.Equal(t, want, got)
+}
+
+func TestUDPInputQueueFull(t *testing.T) {
+	views := observ.MetricViews()
+	require.NoError(t, view.Register(views...))
+	defer view.Unregister(views...)
+
+	cfg := NewUDPInputConfig("test_input")
+	cfg.ListenAddress = "127.0.0.1:0"
+	cfg.WorkerCount = 1
+	cfg.QueueSize = 1
+	udpInput, fake := newTestInput(t, cfg)
+	// Block the worker on the first entry until the test reads it.
+	fake.Received = make(chan *entry.Entry)
+	require.NoError(t, udpInput.Start(testutil.NewMockPersister("test")))
+
+	conn, err := net.Dial("udp", udpInput.connection.LocalAddr().String())
+	require.NoError(t, err)
+	defer conn.Close()
+
+	for i := 0; i < 10; i++ {
+		_, err = conn.Write([]byte("message"))
+		require.NoError(t, err)
+	}
+
+	assert.Eventually(t, func() bool {
+		rows, err := view.RetrieveData(observ.DroppedPackets.Name())
+		require.NoError(t, err)
+		return len(rows) == 1 && rows[0].Data.(*view.SumData).Value >= 8
+	}, 5*time.Second, 10*time.Millisecond)
+
+	go func() {
+		for range fake.Received {
+		}
+	}()
+	require.NoError(t, udpInput.Stop())
+	close(fake.Received)
+}
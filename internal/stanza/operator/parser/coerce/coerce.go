@@ -0,0 +1,118 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package coerce converts the string values produced by parsers into typed values.
+package coerce
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const timestampPrefix = "timestamp:"
+
+// Func converts a raw string value into a typed value.
+type Func func(string) (interface{}, error)
+
+// New returns the Func for a type name. Supported types are string, int, float,
+// bool and timestamp. A timestamp is parsed as RFC 3339 unless a Go time layout
+// is given as "timestamp:<layout>", and is converted to nanoseconds since the epoch.
+func New(typ string) (Func, error) {
+	switch typ {
+	case "string", "":
+		return toString, nil
+	case "int":
+		return toInt, nil
+	case "float":
+		return toFloat, nil
+	case "bool":
+		return toBool, nil
+	case "timestamp":
+		return timestampFunc(time.RFC3339Nano), nil
+	}
+	if strings.HasPrefix(typ, timestampPrefix) {
+		layout := strings.TrimPrefix(typ, timestampPrefix)
+		if layout == "" {
+			return nil, fmt.Errorf("missing layout in type %q", typ)
+		}
+		return timestampFunc(layout), nil
+	}
+	return nil, fmt.Errorf("unsupported type %q", typ)
+}
+
+// NewMap returns the Funcs for a map of field name to type name.
+func NewMap(types map[string]string) (map[string]Func, error) {
+	if len(types) == 0 {
+		return nil, nil
+	}
+
+	// Sort the names so the reported error does not depend on map iteration order.
+	names := make([]string, 0, len(types))
+	for name := range types {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	funcs := make(map[string]Func, len(types))
+	for _, name := range names {
+		f, err := New(types[name])
+		if err != nil {
+			return nil, fmt.Errorf("field %q: %w", name, err)
+		}
+		funcs[name] = f
+	}
+	return funcs, nil
+}
+
+// Value converts value with the Func registered for name, if any.
+func Value(funcs map[string]Func, name, value string) (interface{}, error) {
+	f, ok := funcs[name]
+	if !ok {
+		return value, nil
+	}
+	v, err := f(value)
+	if err != nil {
+		return nil, fmt.Errorf("field %q: %w", name, err)
+	}
+	return v, nil
+}
+
+func toString(s string) (interface{}, error) {
+	return s, nil
+}
+
+func toInt(s string) (interface{}, error) {
+	return strconv.ParseInt(strings.TrimSpace(s), 10, 64)
+}
+
+func toFloat(s string) (interface{}, error) {
+	return strconv.ParseFloat(strings.TrimSpace(s), 64)
+}
+
+func toBool(s string) (interface{}, error) {
+	return strconv.ParseBool(strings.TrimSpace(s))
+}
+
+func timestampFunc(layout string) Func {
+	return func(s string) (interface{}, error) {
+		t, err := time.Parse(layout, strings.TrimSpace(s))
+		if err != nil {
+			return nil, err
+		}
+		return t.UnixNano(), nil
+	}
+}
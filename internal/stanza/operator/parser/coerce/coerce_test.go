@@ -0,0 +1,84 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package coerce
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	ts := time.Date(2021, 6, 1, 12, 30, 0, 0, time.UTC)
+
+	cases := []struct {
+		typ      string
+		input    string
+		expected interface{}
+	}{
+		{"", "abc", "abc"},
+		{"string", " abc ", " abc "},
+		{"int", " -42", int64(-42)},
+		{"float", "1.5", 1.5},
+		{"bool", "true", true},
+		{"timestamp", "2021-06-01T12:30:00Z", ts.UnixNano()},
+		{"timestamp:2006-01-02 15:04:05", "2021-06-01 12:30:00", ts.UnixNano()},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.typ, func(t *testing.T) {
+			f, err := New(tc.typ)
+			require.NoError(t, err)
+			v, err := f(tc.input)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, v)
+		})
+	}
+}
+
+func TestNewErrors(t *testing.T) {
+	_, err := New("uint")
+	assert.EqualError(t, err, `unsupported type "uint"`)
+
+	_, err = New("timestamp:")
+	assert.EqualError(t, err, `missing layout in type "timestamp:"`)
+
+	_, err = NewMap(map[string]string{"a": "int", "b": "complex"})
+	assert.EqualError(t, err, `field "b": unsupported type "complex"`)
+}
+
+func TestValue(t *testing.T) {
+	funcs, err := NewMap(map[string]string{"count": "int"})
+	require.NoError(t, err)
+
+	v, err := Value(funcs, "count", "3")
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), v)
+
+	v, err = Value(funcs, "name", "3")
+	require.NoError(t, err)
+	assert.Equal(t, "3", v)
+
+	_, err = Value(funcs, "count", "three")
+	assert.Error(t, err)
+
+	funcs, err = NewMap(nil)
+	require.NoError(t, err)
+	v, err = Value(funcs, "count", "3")
+	require.NoError(t, err)
+	assert.Equal(t, "3", v)
+}
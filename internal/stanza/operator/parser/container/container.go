@@ -0,0 +1,317 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-log-collection/entry"
+	"github.com/open-telemetry/opentelemetry-log-collection/errors"
+	"github.com/open-telemetry/opentelemetry-log-collection/operator"
+	"github.com/open-telemetry/opentelemetry-log-collection/operator/helper"
+)
+
+const (
+	operatorType = "container"
+
+	formatAuto       = "auto"
+	formatDocker     = "docker"
+	formatCRIO       = "crio"
+	formatContainerd = "containerd"
+
+	// streamAttribute is the attribute that receives the stream (stdout or stderr)
+	// the line was written to.
+	streamAttribute = "log.iostream"
+
+	// Attributes set by file_input that identify the source of an entry. Partial
+	// lines are only reassembled with lines coming from the same source.
+	filePathAttribute = "file_path"
+	fileNameAttribute = "file_name"
+
+	criPartialTag = "P"
+	criFullTag    = "F"
+)
+
+func init() {
+	operator.Register(operatorType, func() operator.Builder { return NewParserConfig("") })
+}
+
+// NewParserConfig creates a new container parser config with default values
+func NewParserConfig(operatorID string) *ParserConfig {
+	return &ParserConfig{
+		ParserConfig: helper.NewParserConfig(operatorID, operatorType),
+		Format:       formatAuto,
+	}
+}
+
+// ParserConfig is the configuration of a container parser operator.
+type ParserConfig struct {
+	helper.ParserConfig `mapstructure:",squash" yaml:",inline"`
+
+	Format     string          `mapstructure:"format"       json:"format"       yaml:"format"`
+	MaxLogSize helper.ByteSize `mapstructure:"max_log_size" json:"max_log_size" yaml:"max_log_size"`
+}
+
+// Build will build a container parser operator.
+func (c ParserConfig) Build(context operator.BuildContext) ([]operator.Operator, error) {
+	parserOperator, err := c.ParserConfig.Build(context)
+	if err != nil {
+		return nil, err
+	}
+
+	switch c.Format {
+	case formatAuto, formatDocker, formatCRIO, formatContainerd:
+	case "":
+		c.Format = formatAuto
+	default:
+		return nil, fmt.Errorf("invalid value '%s' for parameter 'format'", c.Format)
+	}
+
+	if c.MaxLogSize < 0 {
+		return nil, fmt.Errorf("invalid value '%d' for parameter 'max_log_size'", c.MaxLogSize)
+	}
+
+	return []operator.Operator{&Parser{
+		ParserOperator: parserOperator,
+		format:         c.Format,
+		maxLogSize:     int(c.MaxLogSize),
+		pending:        make(map[string]*pendingLine),
+	}}, nil
+}
+
+// Parser is an operator that parses lines written by docker (json-file driver),
+// containerd and CRI-O, reassembling lines the runtime split into several parts.
+type Parser struct {
+	helper.ParserOperator
+	format     string
+	maxLogSize int
+
+	sync.Mutex
+	pending map[string]*pendingLine
+}
+
+// line is a single line as written by the container runtime.
+type line struct {
+	timestamp time.Time
+	stream    string
+	partial   bool
+	log       string
+}
+
+// pendingLine holds the parts of a line that has not been completed yet.
+type pendingLine struct {
+	entry     *entry.Entry
+	timestamp time.Time
+	stream    string
+	log       strings.Builder
+}
+
+// Stop flushes any partial line that is still pending.
+func (p *Parser) Stop() error {
+	p.Lock()
+	defer p.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	for key, pending := range p.pending {
+		// Errors are already handled according to on_error by emit.
+		_ = p.emit(ctx, pending.entry, pending.timestamp, pending.stream, pending.log.String())
+		delete(p.pending, key)
+	}
+	return nil
+}
+
+// Process will parse an entry and forward it once its line is complete.
+func (p *Parser) Process(ctx context.Context, e *entry.Entry) error {
+	skip, err := p.Skip(ctx, e)
+	if err != nil {
+		return p.HandleEntryError(ctx, e, err)
+	}
+	if skip {
+		p.Write(ctx, e)
+		return nil
+	}
+
+	value, ok := e.Get(p.ParseFrom)
+	if !ok {
+		err := errors.NewError(
+			"Entry is missing the expected parse_from field.",
+			"Ensure that all incoming entries contain the parse_from field.",
+			"parse_from", p.ParseFrom.String(),
+		)
+		return p.HandleEntryError(ctx, e, err)
+	}
+
+	l, err := p.parse(value)
+	if err != nil {
+		return p.HandleEntryError(ctx, e, err)
+	}
+
+	p.Lock()
+	defer p.Unlock()
+
+	key := sourceKey(e, l.stream)
+	pending, ok := p.pending[key]
+	if l.partial {
+		if !ok {
+			pending = &pendingLine{entry: e, timestamp: l.timestamp, stream: l.stream}
+			p.pending[key] = pending
+		}
+		pending.log.WriteString(l.log)
+		if p.maxLogSize > 0 && pending.log.Len() >= p.maxLogSize {
+			p.Warnw("Partial line exceeds max_log_size, flushing it incomplete", "max_log_size", p.maxLogSize)
+			delete(p.pending, key)
+			return p.emit(ctx, pending.entry, pending.timestamp, pending.stream, pending.log.String())
+		}
+		return nil
+	}
+
+	if !ok {
+		return p.emit(ctx, e, l.timestamp, l.stream, l.log)
+	}
+
+	delete(p.pending, key)
+	pending.log.WriteString(l.log)
+	return p.emit(ctx, pending.entry, pending.timestamp, pending.stream, pending.log.String())
+}
+
+// emit sets the parsed values on the entry and writes it to the next operators.
+func (p *Parser) emit(ctx context.Context, e *entry.Entry, timestamp time.Time, stream, log string) error {
+	original, _ := e.Delete(p.ParseFrom)
+	if err := e.Set(p.ParseTo, log); err != nil {
+		return p.HandleEntryError(ctx, e, errors.Wrap(err, "set parse_to"))
+	}
+	if p.PreserveTo != nil {
+		if err := e.Set(p.PreserveTo, original); err != nil {
+			return p.HandleEntryError(ctx, e, errors.Wrap(err, "set preserve_to"))
+		}
+	}
+
+	e.Timestamp = timestamp
+	e.AddAttribute(streamAttribute, stream)
+
+	if p.TimeParser != nil {
+		if err := p.TimeParser.Parse(e); err != nil {
+			return p.HandleEntryError(ctx, e, errors.Wrap(err, "time parser"))
+		}
+	}
+	if p.SeverityParser != nil {
+		if err := p.SeverityParser.Parse(e); err != nil {
+			return p.HandleEntryError(ctx, e, errors.Wrap(err, "severity parser"))
+		}
+	}
+	if p.TraceParser != nil {
+		if err := p.TraceParser.Parse(e); err != nil {
+			return p.HandleEntryError(ctx, e, errors.Wrap(err, "trace parser"))
+		}
+	}
+
+	p.Write(ctx, e)
+	return nil
+}
+
+// parse will parse a raw line according to the configured format.
+func (p *Parser) parse(value interface{}) (line, error) {
+	var raw string
+	switch v := value.(type) {
+	case string:
+		raw = v
+	case []byte:
+		raw = string(v)
+	default:
+		return line{}, fmt.Errorf("type %T cannot be parsed as a container log", value)
+	}
+
+	switch p.format {
+	case formatDocker:
+		return parseDocker(raw)
+	case formatCRIO, formatContainerd:
+		return parseCRI(raw)
+	default:
+		if strings.HasPrefix(raw, "{") {
+			return parseDocker(raw)
+		}
+		return parseCRI(raw)
+	}
+}
+
+// parseDocker parses a line written by the docker json-file logging driver. Docker
+// splits long lines into several records, all but the last missing the trailing newline.
+func parseDocker(raw string) (line, error) {
+	var record struct {
+		Log    string `json:"log"`
+		Stream string `json:"stream"`
+		Time   string `json:"time"`
+	}
+	if err := json.Unmarshal([]byte(raw), &record); err != nil {
+		return line{}, fmt.Errorf("parse docker log: %w", err)
+	}
+
+	timestamp, err := time.Parse(time.RFC3339Nano, record.Time)
+	if err != nil {
+		return line{}, fmt.Errorf("parse docker log time: %w", err)
+	}
+
+	l := line{timestamp: timestamp, stream: record.Stream}
+	if strings.HasSuffix(record.Log, "\n") {
+		l.log = strings.TrimSuffix(record.Log, "\n")
+	} else {
+		l.log = record.Log
+		l.partial = true
+	}
+	return l, nil
+}
+
+// parseCRI parses a line in the CRI logging format used by containerd and CRI-O:
+// "<time> <stream> <tag> <log>", where the tag is P for partial and F for full lines.
+func parseCRI(raw string) (line, error) {
+	parts := strings.SplitN(raw, " ", 4)
+	if len(parts) < 3 {
+		return line{}, fmt.Errorf("parse cri log: expected '<time> <stream> <tag> <log>', got %q", raw)
+	}
+
+	timestamp, err := time.Parse(time.RFC3339Nano, parts[0])
+	if err != nil {
+		return line{}, fmt.Errorf("parse cri log time: %w", err)
+	}
+
+	l := line{timestamp: timestamp, stream: parts[1]}
+	// The tag may carry more flags separated by colons, only the first is defined.
+	switch tag := strings.SplitN(parts[2], ":", 2)[0]; tag {
+	case criPartialTag:
+		l.partial = true
+	case criFullTag:
+	default:
+		return line{}, fmt.Errorf("parse cri log: unknown tag %q", tag)
+	}
+	if len(parts) == 4 {
+		l.log = parts[3]
+	}
+	return l, nil
+}
+
+// sourceKey identifies the file and stream an entry came from.
+func sourceKey(e *entry.Entry, stream string) string {
+	source, ok := e.Attributes[filePathAttribute]
+	if !ok {
+		source = e.Attributes[fileNameAttribute]
+	}
+	return source + "\x00" + stream
+}
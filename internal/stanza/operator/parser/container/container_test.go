@@ -0,0 +1,216 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-log-collection/entry"
+	"github.com/open-telemetry/opentelemetry-log-collection/operator"
+	"github.com/open-telemetry/opentelemetry-log-collection/operator/helper"
+	"github.com/open-telemetry/opentelemetry-log-collection/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestParser(t *testing.T, cfg *ParserConfig) (*Parser, *testutil.FakeOutput) {
+	ops, err := cfg.Build(testutil.NewBuildContext(t))
+	require.NoError(t, err)
+	parser := ops[0].(*Parser)
+	fake := testutil.NewFakeOutput(t)
+	parser.OutputOperators = []operator.Operator{fake}
+	return parser, fake
+}
+
+func newEntry(body, file string) *entry.Entry {
+	e := entry.New()
+	e.Body = body
+	e.AddAttribute(fileNameAttribute, file)
+	return e
+}
+
+func expectLog(t *testing.T, fake *testutil.FakeOutput, body, stream string, timestamp time.Time) {
+	select {
+	case e := <-fake.Received:
+		require.Equal(t, body, e.Body)
+		require.Equal(t, stream, e.Attributes[streamAttribute])
+		require.True(t, timestamp.Equal(e.Timestamp), "expected %s, got %s", timestamp, e.Timestamp)
+	case <-time.After(time.Second):
+		require.FailNow(t, "timed out waiting for entry")
+	}
+}
+
+func expectNoLog(t *testing.T, fake *testutil.FakeOutput) {
+	select {
+	case e := <-fake.Received:
+		require.FailNow(t, "unexpected entry", "%v", e)
+	default:
+	}
+}
+
+func TestBuild(t *testing.T) {
+	cfg := NewParserConfig("test")
+	ops, err := cfg.Build(testutil.NewBuildContext(t))
+	require.NoError(t, err)
+	require.IsType(t, &Parser{}, ops[0])
+
+	cfg.Format = "journald"
+	_, err = cfg.Build(testutil.NewBuildContext(t))
+	require.EqualError(t, err, "invalid value 'journald' for parameter 'format'")
+
+	cfg = NewParserConfig("test")
+	cfg.MaxLogSize = -1
+	_, err = cfg.Build(testutil.NewBuildContext(t))
+	require.Error(t, err)
+}
+
+func TestParseFormats(t *testing.T) {
+	ts := time.Date(2021, 6, 22, 10, 27, 25, 813799277, time.UTC)
+
+	cases := []struct {
+		name   string
+		format string
+		input  string
+		log    string
+		stream string
+	}{
+		{
+			name:   "docker",
+			format: formatDocker,
+			input:  `{"log":"INFO starting\n","stream":"stdout","time":"2021-06-22T10:27:25.813799277Z"}`,
+			log:    "INFO starting",
+			stream: "stdout",
+		},
+		{
+			name:   "containerd",
+			format: formatContainerd,
+			input:  "2021-06-22T10:27:25.813799277Z stderr F ERROR failed to connect",
+			log:    "ERROR failed to connect",
+			stream: "stderr",
+		},
+		{
+			name:   "crio",
+			format: formatCRIO,
+			input:  "2021-06-22T10:27:25.813799277+00:00 stdout F INFO ready",
+			log:    "INFO ready",
+			stream: "stdout",
+		},
+		{
+			name:   "auto_docker",
+			format: formatAuto,
+			input:  `{"log":"a b c\n","stream":"stderr","time":"2021-06-22T10:27:25.813799277Z"}`,
+			log:    "a b c",
+			stream: "stderr",
+		},
+		{
+			name:   "auto_cri",
+			format: formatAuto,
+			input:  "2021-06-22T10:27:25.813799277Z stdout F {\"msg\":\"json body\"}",
+			log:    `{"msg":"json body"}`,
+			stream: "stdout",
+		},
+		{
+			name:   "cri_empty_line",
+			format: formatAuto,
+			input:  "2021-06-22T10:27:25.813799277Z stdout F",
+			log:    "",
+			stream: "stdout",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := NewParserConfig("test")
+			cfg.Format = tc.format
+			parser, fake := newTestParser(t, cfg)
+
+			require.NoError(t, parser.Process(context.Background(), newEntry(tc.input, "a.log")))
+			expectLog(t, fake, tc.log, tc.stream, ts)
+		})
+	}
+}
+
+func TestReassembleCRI(t *testing.T) {
+	parser, fake := newTestParser(t, NewParserConfig("test"))
+	ctx := context.Background()
+
+	require.NoError(t, parser.Process(ctx, newEntry("2021-06-22T10:27:25.000000001Z stdout P first ", "a.log")))
+	require.NoError(t, parser.Process(ctx, newEntry("2021-06-22T10:27:25.000000002Z stderr F other stream", "a.log")))
+	require.NoError(t, parser.Process(ctx, newEntry("2021-06-22T10:27:25.000000003Z stdout P second ", "b.log")))
+	require.NoError(t, parser.Process(ctx, newEntry("2021-06-22T10:27:25.000000004Z stdout P middle ", "a.log")))
+	expectLog(t, fake, "other stream", "stderr", time.Date(2021, 6, 22, 10, 27, 25, 2, time.UTC))
+	expectNoLog(t, fake)
+
+	require.NoError(t, parser.Process(ctx, newEntry("2021-06-22T10:27:25.000000005Z stdout F last", "a.log")))
+	expectLog(t, fake, "first middle last", "stdout", time.Date(2021, 6, 22, 10, 27, 25, 1, time.UTC))
+	expectNoLog(t, fake)
+
+	require.NoError(t, parser.Stop())
+	expectLog(t, fake, "second ", "stdout", time.Date(2021, 6, 22, 10, 27, 25, 3, time.UTC))
+}
+
+func TestReassembleDocker(t *testing.T) {
+	parser, fake := newTestParser(t, NewParserConfig("test"))
+	ctx := context.Background()
+
+	require.NoError(t, parser.Process(ctx, newEntry(`{"log":"part one, ","stream":"stdout","time":"2021-06-22T10:27:25Z"}`, "a.log")))
+	expectNoLog(t, fake)
+	require.NoError(t, parser.Process(ctx, newEntry(`{"log":"part two\n","stream":"stdout","time":"2021-06-22T10:27:26Z"}`, "a.log")))
+	expectLog(t, fake, "part one, part two", "stdout", time.Date(2021, 6, 22, 10, 27, 25, 0, time.UTC))
+}
+
+func TestMaxLogSize(t *testing.T) {
+	cfg := NewParserConfig("test")
+	cfg.MaxLogSize = helper.ByteSize(8)
+	parser, fake := newTestParser(t, cfg)
+	ctx := context.Background()
+
+	require.NoError(t, parser.Process(ctx, newEntry("2021-06-22T10:27:25Z stdout P 1234", "a.log")))
+	expectNoLog(t, fake)
+	require.NoError(t, parser.Process(ctx, newEntry("2021-06-22T10:27:25Z stdout P 5678", "a.log")))
+	expectLog(t, fake, "12345678", "stdout", time.Date(2021, 6, 22, 10, 27, 25, 0, time.UTC))
+	require.NoError(t, parser.Process(ctx, newEntry("2021-06-22T10:27:25Z stdout F 9", "a.log")))
+	expectLog(t, fake, "9", "stdout", time.Date(2021, 6, 22, 10, 27, 25, 0, time.UTC))
+}
+
+func TestParseErrors(t *testing.T) {
+	cases := []struct {
+		name   string
+		format string
+		input  interface{}
+	}{
+		{"not_a_string", formatAuto, 1},
+		{"invalid_json", formatDocker, "{not json"},
+		{"docker_bad_time", formatDocker, `{"log":"x\n","stream":"stdout","time":"yesterday"}`},
+		{"cri_too_short", formatCRIO, "2021-06-22T10:27:25Z stdout"},
+		{"cri_bad_time", formatContainerd, "yesterday stdout F x"},
+		{"cri_bad_tag", formatContainerd, "2021-06-22T10:27:25Z stdout X x"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := NewParserConfig("test")
+			cfg.Format = tc.format
+			cfg.OnError = helper.DropOnError
+			parser, fake := newTestParser(t, cfg)
+
+			e := entry.New()
+			e.Body = tc.input
+			require.Error(t, parser.Process(context.Background(), e))
+			expectNoLog(t, fake)
+		})
+	}
+}
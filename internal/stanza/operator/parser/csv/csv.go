@@ -0,0 +1,214 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package csv
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/open-telemetry/opentelemetry-log-collection/entry"
+	"github.com/open-telemetry/opentelemetry-log-collection/operator"
+	"github.com/open-telemetry/opentelemetry-log-collection/operator/helper"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/stanza/operator/parser/coerce"
+)
+
+const operatorType = "csv_parser"
+
+var errUnterminatedQuote = errors.New("unterminated quoted field")
+
+func init() {
+	operator.Register(operatorType, func() operator.Builder { return NewCSVParserConfig("") })
+}
+
+// NewCSVParserConfig creates a new csv parser config with default values
+func NewCSVParserConfig(operatorID string) *CSVParserConfig {
+	return &CSVParserConfig{
+		ParserConfig:   helper.NewParserConfig(operatorID, operatorType),
+		FieldDelimiter: ",",
+	}
+}
+
+// CSVParserConfig is the configuration of a csv parser operator.
+type CSVParserConfig struct {
+	helper.ParserConfig `mapstructure:",squash" yaml:",inline"`
+
+	Header         string            `mapstructure:"header"              json:"header"              yaml:"header"`
+	FieldDelimiter string            `mapstructure:"delimiter,omitempty" json:"delimiter,omitempty" yaml:"delimiter,omitempty"`
+	Types          map[string]string `mapstructure:"types,omitempty"     json:"types,omitempty"     yaml:"types,omitempty"`
+}
+
+// Build will build a csv parser operator.
+func (c CSVParserConfig) Build(context operator.BuildContext) ([]operator.Operator, error) {
+	parserOperator, err := c.ParserConfig.Build(context)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.Header == "" {
+		return nil, fmt.Errorf("missing required field 'header'")
+	}
+
+	if c.FieldDelimiter == "" {
+		c.FieldDelimiter = ","
+	}
+	if len([]rune(c.FieldDelimiter)) != 1 || c.FieldDelimiter == `"` {
+		return nil, fmt.Errorf("invalid 'delimiter': '%s'", c.FieldDelimiter)
+	}
+
+	parser := &CSVParser{
+		ParserOperator: parserOperator,
+		fieldDelimiter: c.FieldDelimiter,
+	}
+
+	err = parser.split(c.Header, func(_ int, field string) error {
+		parser.header = append(parser.header, field)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("invalid 'header': %w", err)
+	}
+
+	for name := range c.Types {
+		if !parser.hasColumn(name) {
+			return nil, fmt.Errorf("'types' refers to unknown column '%s'", name)
+		}
+	}
+	if parser.types, err = coerce.NewMap(c.Types); err != nil {
+		return nil, fmt.Errorf("invalid 'types': %w", err)
+	}
+
+	return []operator.Operator{parser}, nil
+}
+
+// CSVParser is an operator that parses csv in an entry.
+type CSVParser struct {
+	helper.ParserOperator
+	header         []string
+	fieldDelimiter string
+	types          map[string]coerce.Func
+}
+
+// Process will parse an entry for csv.
+func (r *CSVParser) Process(ctx context.Context, entry *entry.Entry) error {
+	return r.ParserOperator.ProcessWith(ctx, entry, r.parse)
+}
+
+// parse will parse a value using the supplied csv header.
+func (r *CSVParser) parse(value interface{}) (interface{}, error) {
+	var csvLine string
+	switch val := value.(type) {
+	case string:
+		csvLine = val
+	case []byte:
+		csvLine = string(val)
+	default:
+		return nil, fmt.Errorf("type '%T' cannot be parsed as csv", value)
+	}
+
+	parsedValues := make(map[string]interface{}, len(r.header))
+	numFields := 0
+	err := r.split(csvLine, func(i int, field string) error {
+		numFields++
+		if i >= len(r.header) {
+			return nil
+		}
+		v, err := coerce.Value(r.types, r.header[i], field)
+		if err != nil {
+			return err
+		}
+		parsedValues[r.header[i]] = v
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if numFields != len(r.header) {
+		return nil, fmt.Errorf("wrong number of fields: expected %d, found %d", len(r.header), numFields)
+	}
+
+	return parsedValues, nil
+}
+
+func (r *CSVParser) hasColumn(name string) bool {
+	for _, column := range r.header {
+		if column == name {
+			return true
+		}
+	}
+	return false
+}
+
+// split calls fn with each field of line. Fields are substrings of line, so only
+// quoted fields containing escaped quotes are copied.
+func (r *CSVParser) split(line string, fn func(int, string) error) error {
+	for i := 0; ; i++ {
+		var field string
+		if strings.HasPrefix(line, `"`) {
+			var err error
+			field, line, err = readQuoted(line[1:])
+			if err != nil {
+				return err
+			}
+			if line != "" && !strings.HasPrefix(line, r.fieldDelimiter) {
+				return fmt.Errorf("unexpected characters after quoted field %d", i+1)
+			}
+		} else {
+			end := strings.Index(line, r.fieldDelimiter)
+			if end < 0 {
+				end = len(line)
+			}
+			field, line = line[:end], line[end:]
+		}
+
+		if err := fn(i, field); err != nil {
+			return err
+		}
+		if line == "" {
+			return nil
+		}
+		line = line[len(r.fieldDelimiter):]
+	}
+}
+
+// readQuoted reads a quoted field, s starting right after the opening quote. It
+// returns the unescaped field and the remainder of s after the closing quote.
+func readQuoted(s string) (string, string, error) {
+	var b *strings.Builder
+	for {
+		end := strings.IndexByte(s, '"')
+		if end < 0 {
+			return "", "", errUnterminatedQuote
+		}
+
+		// A doubled quote is an escaped quote inside the field.
+		if end+1 < len(s) && s[end+1] == '"' {
+			if b == nil {
+				b = &strings.Builder{}
+			}
+			b.WriteString(s[:end+1])
+			s = s[end+2:]
+			continue
+		}
+
+		if b == nil {
+			return s[:end], s[end+1:], nil
+		}
+		b.WriteString(s[:end])
+		return b.String(), s[end+1:], nil
+	}
+}
@@ -0,0 +1,223 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package csv
+
+import (
+	"testing"
+
+	"github.com/open-telemetry/opentelemetry-log-collection/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestParser(t testing.TB, cfg *CSVParserConfig) *CSVParser {
+	ops, err := cfg.Build(testutil.NewBuildContext(t))
+	require.NoError(t, err)
+	return ops[0].(*CSVParser)
+}
+
+func TestBuildErrors(t *testing.T) {
+	cases := []struct {
+		name     string
+		modify   func(*CSVParserConfig)
+		expected string
+	}{
+		{
+			name:     "missing_header",
+			modify:   func(cfg *CSVParserConfig) {},
+			expected: "missing required field 'header'",
+		},
+		{
+			name: "long_delimiter",
+			modify: func(cfg *CSVParserConfig) {
+				cfg.Header = "a,b"
+				cfg.FieldDelimiter = ";;"
+			},
+			expected: "invalid 'delimiter': ';;'",
+		},
+		{
+			name: "quote_delimiter",
+			modify: func(cfg *CSVParserConfig) {
+				cfg.Header = "a,b"
+				cfg.FieldDelimiter = `"`
+			},
+			expected: `invalid 'delimiter': '"'`,
+		},
+		{
+			name: "invalid_header",
+			modify: func(cfg *CSVParserConfig) {
+				cfg.Header = `a,"b`
+			},
+			expected: "invalid 'header': unterminated quoted field",
+		},
+		{
+			name: "unknown_column",
+			modify: func(cfg *CSVParserConfig) {
+				cfg.Header = "a,b"
+				cfg.Types = map[string]string{"c": "int"}
+			},
+			expected: "'types' refers to unknown column 'c'",
+		},
+		{
+			name: "invalid_type",
+			modify: func(cfg *CSVParserConfig) {
+				cfg.Header = "a,b"
+				cfg.Types = map[string]string{"a": "number"}
+			},
+			expected: `invalid 'types': field "a": unsupported type "number"`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := NewCSVParserConfig("test")
+			tc.modify(cfg)
+			_, err := cfg.Build(testutil.NewBuildContext(t))
+			require.EqualError(t, err, tc.expected)
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	cases := []struct {
+		name      string
+		header    string
+		delimiter string
+		types     map[string]string
+		input     interface{}
+		expected  map[string]interface{}
+	}{
+		{
+			name:     "basic",
+			header:   "name,age,height",
+			input:    "stanza,2,short",
+			expected: map[string]interface{}{"name": "stanza", "age": "2", "height": "short"},
+		},
+		{
+			name:     "bytes",
+			header:   "name,age",
+			input:    []byte("stanza,2"),
+			expected: map[string]interface{}{"name": "stanza", "age": "2"},
+		},
+		{
+			name:     "single_column",
+			header:   "message",
+			input:    "hello world",
+			expected: map[string]interface{}{"message": "hello world"},
+		},
+		{
+			name:     "empty_fields",
+			header:   "a,b,c",
+			input:    ",,",
+			expected: map[string]interface{}{"a": "", "b": "", "c": ""},
+		},
+		{
+			name:     "quoted",
+			header:   "a,b,c",
+			input:    `"x,y","say ""hi""",""`,
+			expected: map[string]interface{}{"a": "x,y", "b": `say "hi"`, "c": ""},
+		},
+		{
+			name:      "tab_delimiter",
+			header:    "a\tb",
+			delimiter: "\t",
+			input:     "1\t2",
+			expected:  map[string]interface{}{"a": "1", "b": "2"},
+		},
+		{
+			name:      "multibyte_delimiter",
+			header:    "a│b",
+			delimiter: "│",
+			input:     "1│2",
+			expected:  map[string]interface{}{"a": "1", "b": "2"},
+		},
+		{
+			name:   "types",
+			header: "name,count,ratio,ok,at",
+			types: map[string]string{
+				"count": "int",
+				"ratio": "float",
+				"ok":    "bool",
+				"at":    "timestamp",
+			},
+			input: "x,42,0.5,true,2021-06-01T00:00:00Z",
+			expected: map[string]interface{}{
+				"name":  "x",
+				"count": int64(42),
+				"ratio": 0.5,
+				"ok":    true,
+				"at":    int64(1622505600000000000),
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := NewCSVParserConfig("test")
+			cfg.Header = tc.header
+			if tc.delimiter != "" {
+				cfg.FieldDelimiter = tc.delimiter
+			}
+			cfg.Types = tc.types
+			parser := newTestParser(t, cfg)
+
+			parsed, err := parser.parse(tc.input)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, parsed)
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	cfg := NewCSVParserConfig("test")
+	cfg.Header = "a,b"
+	cfg.Types = map[string]string{"b": "int"}
+	parser := newTestParser(t, cfg)
+
+	cases := []struct {
+		name     string
+		input    interface{}
+		expected string
+	}{
+		{"type", 1, "type 'int' cannot be parsed as csv"},
+		{"too_few", "1", "wrong number of fields: expected 2, found 1"},
+		{"too_many", "1,2,3", "wrong number of fields: expected 2, found 3"},
+		{"unterminated", `"1,2`, "unterminated quoted field"},
+		{"after_quote", `"1"x,2`, "unexpected characters after quoted field 1"},
+		{"coercion", "1,two", `field "b": strconv.ParseInt: parsing "two": invalid syntax`},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := parser.parse(tc.input)
+			require.EqualError(t, err, tc.expected)
+		})
+	}
+}
+
+func BenchmarkParse(b *testing.B) {
+	cfg := NewCSVParserConfig("test")
+	cfg.Header = "timestamp,level,service,latency,message"
+	cfg.Types = map[string]string{"latency": "float"}
+	parser := newTestParser(b, cfg)
+	line := `2021-06-01T00:00:00Z,INFO,checkout,12.5,"order placed, id ""42"""`
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := parser.parse(line); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,168 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keyvalue
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/open-telemetry/opentelemetry-log-collection/entry"
+	"github.com/open-telemetry/opentelemetry-log-collection/operator"
+	"github.com/open-telemetry/opentelemetry-log-collection/operator/helper"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/stanza/operator/parser/coerce"
+)
+
+const operatorType = "key_value_parser"
+
+func init() {
+	operator.Register(operatorType, func() operator.Builder { return NewKVParserConfig("") })
+}
+
+// NewKVParserConfig creates a new key value parser config with default values
+func NewKVParserConfig(operatorID string) *KVParserConfig {
+	return &KVParserConfig{
+		ParserConfig: helper.NewParserConfig(operatorID, operatorType),
+		Delimiter:    "=",
+	}
+}
+
+// KVParserConfig is the configuration of a key value parser operator.
+type KVParserConfig struct {
+	helper.ParserConfig `mapstructure:",squash" yaml:",inline"`
+
+	Delimiter     string            `mapstructure:"delimiter,omitempty"      json:"delimiter,omitempty"      yaml:"delimiter,omitempty"`
+	PairDelimiter string            `mapstructure:"pair_delimiter,omitempty" json:"pair_delimiter,omitempty" yaml:"pair_delimiter,omitempty"`
+	Types         map[string]string `mapstructure:"types,omitempty"          json:"types,omitempty"          yaml:"types,omitempty"`
+}
+
+// Build will build a key value parser operator.
+func (c KVParserConfig) Build(context operator.BuildContext) ([]operator.Operator, error) {
+	parserOperator, err := c.ParserConfig.Build(context)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.Delimiter == "" {
+		c.Delimiter = "="
+	}
+	if c.Delimiter == c.PairDelimiter {
+		return nil, fmt.Errorf("'delimiter' and 'pair_delimiter' must differ")
+	}
+
+	types, err := coerce.NewMap(c.Types)
+	if err != nil {
+		return nil, fmt.Errorf("invalid 'types': %w", err)
+	}
+
+	return []operator.Operator{&KVParser{
+		ParserOperator: parserOperator,
+		delimiter:      c.Delimiter,
+		pairDelimiter:  c.PairDelimiter,
+		types:          types,
+	}}, nil
+}
+
+// KVParser is an operator that parses key value pairs in an entry.
+type KVParser struct {
+	helper.ParserOperator
+	delimiter     string
+	pairDelimiter string
+	types         map[string]coerce.Func
+}
+
+// Process will parse an entry for key value pairs.
+func (kv *KVParser) Process(ctx context.Context, entry *entry.Entry) error {
+	return kv.ParserOperator.ProcessWith(ctx, entry, kv.parse)
+}
+
+// parse will parse a value into key value pairs. Values may be enclosed in single
+// or double quotes to contain the delimiters.
+func (kv *KVParser) parse(value interface{}) (interface{}, error) {
+	var line string
+	switch val := value.(type) {
+	case string:
+		line = val
+	case []byte:
+		line = string(val)
+	default:
+		return nil, fmt.Errorf("type '%T' cannot be parsed as key value pairs", value)
+	}
+
+	parsedValues := make(map[string]interface{})
+	for line = kv.skipPairDelimiter(line); line != ""; line = kv.skipPairDelimiter(line) {
+		end := strings.Index(line, kv.delimiter)
+		if end < 0 {
+			return nil, fmt.Errorf("missing delimiter '%s' in '%s'", kv.delimiter, kv.nextValue(line))
+		}
+		key := strings.TrimSpace(line[:end])
+		if key == "" {
+			return nil, fmt.Errorf("empty key before delimiter '%s'", kv.delimiter)
+		}
+		line = line[end+len(kv.delimiter):]
+
+		var raw string
+		if line != "" && (line[0] == '"' || line[0] == '\'') {
+			end := strings.IndexByte(line[1:], line[0])
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated quoted value for key '%s'", key)
+			}
+			raw, line = line[1:end+1], line[end+2:]
+			if strings.TrimSpace(kv.nextValue(line)) != "" {
+				return nil, fmt.Errorf("unexpected characters after quoted value for key '%s'", key)
+			}
+		} else {
+			raw = kv.nextValue(line)
+			line = line[len(raw):]
+			if kv.pairDelimiter != "" {
+				raw = strings.TrimSpace(raw)
+			}
+		}
+
+		v, err := coerce.Value(kv.types, key, raw)
+		if err != nil {
+			return nil, err
+		}
+		parsedValues[key] = v
+	}
+
+	return parsedValues, nil
+}
+
+// nextValue returns the prefix of line up to the next pair delimiter.
+func (kv *KVParser) nextValue(line string) string {
+	var end int
+	if kv.pairDelimiter == "" {
+		end = strings.IndexFunc(line, unicode.IsSpace)
+	} else {
+		end = strings.Index(line, kv.pairDelimiter)
+	}
+	if end < 0 {
+		return line
+	}
+	return line[:end]
+}
+
+// skipPairDelimiter removes the pair delimiter and surrounding whitespace at the
+// start of line.
+func (kv *KVParser) skipPairDelimiter(line string) string {
+	line = strings.TrimLeftFunc(line, unicode.IsSpace)
+	if kv.pairDelimiter != "" && strings.HasPrefix(line, kv.pairDelimiter) {
+		line = strings.TrimLeftFunc(line[len(kv.pairDelimiter):], unicode.IsSpace)
+	}
+	return line
+}
@@ -0,0 +1,152 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keyvalue
+
+import (
+	"testing"
+
+	"github.com/open-telemetry/opentelemetry-log-collection/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestParser(t testing.TB, cfg *KVParserConfig) *KVParser {
+	ops, err := cfg.Build(testutil.NewBuildContext(t))
+	require.NoError(t, err)
+	return ops[0].(*KVParser)
+}
+
+func TestBuildErrors(t *testing.T) {
+	cfg := NewKVParserConfig("test")
+	cfg.PairDelimiter = "="
+	_, err := cfg.Build(testutil.NewBuildContext(t))
+	require.EqualError(t, err, "'delimiter' and 'pair_delimiter' must differ")
+
+	cfg = NewKVParserConfig("test")
+	cfg.Types = map[string]string{"a": "number"}
+	_, err = cfg.Build(testutil.NewBuildContext(t))
+	require.EqualError(t, err, `invalid 'types': field "a": unsupported type "number"`)
+}
+
+func TestParse(t *testing.T) {
+	cases := []struct {
+		name          string
+		delimiter     string
+		pairDelimiter string
+		types         map[string]string
+		input         interface{}
+		expected      map[string]interface{}
+	}{
+		{
+			name:     "whitespace",
+			input:    "name=stanza  age=2\tempty=",
+			expected: map[string]interface{}{"name": "stanza", "age": "2", "empty": ""},
+		},
+		{
+			name:     "bytes",
+			input:    []byte("a=1"),
+			expected: map[string]interface{}{"a": "1"},
+		},
+		{
+			name:     "empty",
+			input:    "  ",
+			expected: map[string]interface{}{},
+		},
+		{
+			name:     "quoted",
+			input:    `msg="hello world" path='/a b' q="it's"`,
+			expected: map[string]interface{}{"msg": "hello world", "path": "/a b", "q": "it's"},
+		},
+		{
+			name:          "pair_delimiter",
+			delimiter:     ":",
+			pairDelimiter: ";",
+			input:         "user: alice smith; role:admin ;empty:",
+			expected:      map[string]interface{}{"user": "alice smith", "role": "admin", "empty": ""},
+		},
+		{
+			name:     "value_with_delimiter",
+			input:    "query=a=b",
+			expected: map[string]interface{}{"query": "a=b"},
+		},
+		{
+			name:  "types",
+			types: map[string]string{"status": "int", "duration": "float", "cached": "bool"},
+			input: "status=200 duration=1.25 cached=false path=/",
+			expected: map[string]interface{}{
+				"status":   int64(200),
+				"duration": 1.25,
+				"cached":   false,
+				"path":     "/",
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := NewKVParserConfig("test")
+			if tc.delimiter != "" {
+				cfg.Delimiter = tc.delimiter
+			}
+			cfg.PairDelimiter = tc.pairDelimiter
+			cfg.Types = tc.types
+			parser := newTestParser(t, cfg)
+
+			parsed, err := parser.parse(tc.input)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, parsed)
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	cfg := NewKVParserConfig("test")
+	cfg.Types = map[string]string{"status": "int"}
+	parser := newTestParser(t, cfg)
+
+	cases := []struct {
+		name     string
+		input    interface{}
+		expected string
+	}{
+		{"type", 1, "type 'int' cannot be parsed as key value pairs"},
+		{"missing_delimiter", "a=1 oops", "missing delimiter '=' in 'oops'"},
+		{"empty_key", "=1", "empty key before delimiter '='"},
+		{"unterminated", `a="1 b=2`, "unterminated quoted value for key 'a'"},
+		{"after_quote", `a="1"x b=2`, "unexpected characters after quoted value for key 'a'"},
+		{"coercion", "status=ok", `field "status": strconv.ParseInt: parsing "ok": invalid syntax`},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := parser.parse(tc.input)
+			require.EqualError(t, err, tc.expected)
+		})
+	}
+}
+
+func BenchmarkParse(b *testing.B) {
+	cfg := NewKVParserConfig("test")
+	cfg.Types = map[string]string{"status": "int", "duration": "float"}
+	parser := newTestParser(b, cfg)
+	line := `level=info service=checkout status=200 duration=12.5 msg="order placed"`
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := parser.parse(line); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
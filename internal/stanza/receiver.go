@@ -18,8 +18,10 @@ import (
 	"context"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/open-telemetry/opentelemetry-log-collection/agent"
+	"github.com/open-telemetry/opentelemetry-log-collection/operator"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/consumer"
@@ -39,8 +41,15 @@ type receiver struct {
 	emitter       *LogEmitter
 	consumer      consumer.Logs
 	storageClient storage.Client
+	storageID     *config.ComponentID
+	// wrapPersister, when set, decorates the persister handed to the operators.
+	wrapPersister func(operator.Persister) operator.Persister
 	converter     *Converter
 	logger        *zap.Logger
+
+	// drainTimeout bounds how long Shutdown waits for the entries already
+	// read by the inputs to be converted and accepted by the pipeline.
+	drainTimeout time.Duration
 }
 
 // Ensure this receiver adheres to required interface
@@ -102,7 +111,11 @@ func (r *receiver) emitterLoop(ctx context.Context) {
 
 		case e, ok := <-r.emitter.logChan:
 			if !ok {
-				continue
+				// The emitter is closed once the agent is stopped, no new
+				// entries will arrive so drain the converter.
+				r.logger.Debug("Receive loop stopped, draining converter")
+				r.converter.Drain()
+				return
 			}
 
 			r.converter.Batch(e)
@@ -127,7 +140,7 @@ func (r *receiver) consumerLoop(ctx context.Context) {
 		case pLogs, ok := <-pLogsChan:
 			if !ok {
 				r.logger.Debug("Converter channel got closed")
-				continue
+				return
 			}
 			if cErr := r.consumer.ConsumeLogs(ctx, pLogs); cErr != nil {
 				r.logger.Error("ConsumeLogs() failed", zap.Error(cErr))
@@ -142,10 +155,31 @@ func (r *receiver) Shutdown(ctx context.Context) error {
 	defer r.Unlock()
 
 	r.logger.Info("Stopping stanza receiver")
+	// Stopping the agent stops the inputs from accepting new data and closes
+	// the emitter which in turn lets both loops drain and return.
 	agentErr := r.agent.Stop()
+
+	done := make(chan struct{})
+	go func() {
+		r.wg.Wait()
+		close(done)
+	}()
+
+	timer := time.NewTimer(r.drainTimeout)
+	defer timer.Stop()
+	select {
+	case <-done:
+	case <-timer.C:
+		r.logger.Warn("Timed out draining log entries on shutdown, dropping remaining entries",
+			zap.Duration("shutdown_drain_timeout", r.drainTimeout))
+	case <-ctx.Done():
+		r.logger.Warn("Shutdown context done before draining log entries, dropping remaining entries",
+			zap.Error(ctx.Err()))
+	}
+
 	r.converter.Stop()
 	r.cancel()
-	r.wg.Wait()
+	<-done
 
 	clientErr := r.storageClient.Close(ctx)
 	return multierr.Combine(agentErr, clientErr)
@@ -66,6 +66,35 @@ func TestStart(t *testing.T) {
 	logsReceiver.Shutdown(context.Background())
 }
 
+func TestShutdownDrainsPendingEntries(t *testing.T) {
+	params := component.ReceiverCreateSettings{
+		Logger: zaptest.NewLogger(t),
+	}
+	mockConsumer := mockLogsConsumer{}
+
+	factory := NewFactory(TestReceiverType{})
+
+	cfg := factory.CreateDefaultConfig().(*TestConfig)
+	// Make sure entries are only flushed because of the drain.
+	cfg.Converter.MaxFlushCount = 1000
+	cfg.Converter.FlushInterval = time.Hour
+
+	logsReceiver, err := factory.CreateLogsReceiver(context.Background(), params, cfg, &mockConsumer)
+	require.NoError(t, err, "receiver should successfully build")
+
+	err = logsReceiver.Start(context.Background(), componenttest.NewNopHost())
+	require.NoError(t, err, "receiver start failed")
+
+	stanzaReceiver := logsReceiver.(*receiver)
+	for i := 0; i < 10; i++ {
+		stanzaReceiver.emitter.logChan <- entry.New()
+	}
+
+	require.NoError(t, logsReceiver.Shutdown(context.Background()))
+	// All entries share the same resource so they're flushed in one batch.
+	require.Equal(t, 1, mockConsumer.Received())
+}
+
 func TestHandleStartError(t *testing.T) {
 	params := component.ReceiverCreateSettings{
 		Logger: zaptest.NewLogger(t),
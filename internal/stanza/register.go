@@ -25,4 +25,8 @@ import (
 	_ "github.com/open-telemetry/opentelemetry-log-collection/operator/builtin/transformer/recombine"
 	_ "github.com/open-telemetry/opentelemetry-log-collection/operator/builtin/transformer/restructure"
 	_ "github.com/open-telemetry/opentelemetry-log-collection/operator/builtin/transformer/router"
+
+	_ "github.com/open-telemetry/opentelemetry-collector-contrib/internal/stanza/operator/parser/container"
+	_ "github.com/open-telemetry/opentelemetry-collector-contrib/internal/stanza/operator/parser/csv"
+	_ "github.com/open-telemetry/opentelemetry-collector-contrib/internal/stanza/operator/parser/keyvalue"
 )
@@ -17,6 +17,7 @@ package stanza
 import (
 	"context"
 	"errors"
+	"fmt"
 
 	"github.com/open-telemetry/opentelemetry-log-collection/operator"
 	"go.opentelemetry.io/collector/component"
@@ -25,14 +26,9 @@ import (
 )
 
 func (r *receiver) setStorageClient(ctx context.Context, host component.Host) error {
-	var storageExtension storage.Extension
-	for _, ext := range host.GetExtensions() {
-		if se, ok := ext.(storage.Extension); ok {
-			if storageExtension != nil {
-				return errors.New("multiple storage extensions found")
-			}
-			storageExtension = se
-		}
+	storageExtension, err := r.findStorageExtension(host)
+	if err != nil {
+		return err
 	}
 
 	if storageExtension == nil {
@@ -49,8 +45,39 @@ func (r *receiver) setStorageClient(ctx context.Context, host component.Host) er
 	return nil
 }
 
+// findStorageExtension returns the configured storage extension or, when none
+// is configured, the only storage extension of the collector.
+func (r *receiver) findStorageExtension(host component.Host) (storage.Extension, error) {
+	if r.storageID != nil {
+		ext, found := host.GetExtensions()[*r.storageID]
+		if !found {
+			return nil, fmt.Errorf("storage extension %q not found", r.storageID)
+		}
+		se, ok := ext.(storage.Extension)
+		if !ok {
+			return nil, fmt.Errorf("extension %q is not a storage extension", r.storageID)
+		}
+		return se, nil
+	}
+
+	var storageExtension storage.Extension
+	for _, ext := range host.GetExtensions() {
+		if se, ok := ext.(storage.Extension); ok {
+			if storageExtension != nil {
+				return nil, errors.New("multiple storage extensions found")
+			}
+			storageExtension = se
+		}
+	}
+	return storageExtension, nil
+}
+
 func (r *receiver) getPersister() operator.Persister {
-	return &persister{r.storageClient}
+	var p operator.Persister = &persister{r.storageClient}
+	if r.wrapPersister != nil {
+		p = r.wrapPersister(p)
+	}
+	return p
 }
 
 type persister struct {
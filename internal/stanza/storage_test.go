@@ -19,8 +19,10 @@ import (
 	"io/ioutil"
 	"testing"
 
+	"github.com/open-telemetry/opentelemetry-log-collection/operator"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
 	"go.uber.org/zap/zaptest"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/storagetest"
@@ -85,6 +87,42 @@ func TestFailOnMultipleStorageExtensions(t *testing.T) {
 	require.Equal(t, "storage client: multiple storage extensions found", err.Error())
 }
 
+func TestSelectStorageExtension(t *testing.T) {
+	ctx := context.Background()
+	tempDir, err := ioutil.TempDir("", "")
+	require.NoError(t, err)
+
+	host := storagetest.NewStorageHost(t, tempDir, "one", "two")
+
+	r := createReceiver(t)
+	id := config.NewIDWithName("nop", "two")
+	r.storageID = &id
+	require.NoError(t, r.Start(ctx, host))
+	require.NoError(t, r.storageClient.Set(ctx, "key", []byte("value")))
+	require.NoError(t, r.Shutdown(ctx))
+
+	r = createReceiver(t)
+	missing := config.NewIDWithName("nop", "three")
+	r.storageID = &missing
+	err = r.Start(ctx, host)
+	require.Error(t, err)
+	require.Equal(t, `storage client: storage extension "nop/three" not found`, err.Error())
+}
+
+func TestWrapPersister(t *testing.T) {
+	r := createReceiver(t)
+	require.IsType(t, &persister{}, r.getPersister())
+
+	var wrapped operator.Persister
+	r.wrapPersister = func(p operator.Persister) operator.Persister {
+		wrapped = p
+		return operator.NewScopedPersister("scope", p)
+	}
+	p := r.getPersister()
+	require.IsType(t, &persister{}, wrapped)
+	require.NotEqual(t, wrapped, p)
+}
+
 func createReceiver(t *testing.T) *receiver {
 	params := component.ReceiverCreateSettings{
 		Logger: zaptest.NewLogger(t),
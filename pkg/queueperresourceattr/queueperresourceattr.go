@@ -0,0 +1,396 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package queueperresourceattr provides exporter wrappers that give every
+// distinct value of a resource attribute, typically a tenant access token,
+// its own exporter instance and therefore its own sending queue, so that an
+// outage affecting one value doesn't consume the queue capacity of others.
+//
+// Each dedicated exporter has its own queue and consumer goroutines, so up to
+// MaxQueues times the memory and goroutines of the shared exporter can be used.
+// Dedicated exporters which don't receive data for IdleTimeout are shut down.
+package queueperresourceattr
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/batchperresourceattr"
+)
+
+const (
+	defaultMaxQueues   = 100
+	defaultIdleTimeout = 10 * time.Minute
+)
+
+// Settings configures the dedicated sending queues per attribute value.
+type Settings struct {
+	// Enabled turns on a dedicated sending queue per attribute value.
+	Enabled bool `mapstructure:"enabled"`
+	// MaxQueues bounds the number of dedicated queues. Data for attribute
+	// values seen after the limit is reached goes to the shared queue.
+	MaxQueues int `mapstructure:"max_queues"`
+	// IdleTimeout is the duration after which a dedicated queue which didn't
+	// receive data is shut down, 0 keeps the queues until shutdown. Shutting a
+	// queue down sends the data left in it once, without retries, so it should
+	// be longer than the time data can spend in the queue.
+	IdleTimeout time.Duration `mapstructure:"idle_timeout"`
+}
+
+// DefaultSettings returns the default Settings, dedicated queues are disabled.
+func DefaultSettings() Settings {
+	return Settings{
+		MaxQueues:   defaultMaxQueues,
+		IdleTimeout: defaultIdleTimeout,
+	}
+}
+
+// CreateMetricsExporterFunc creates an exporter with its own sending queue for
+// the given configuration. The configuration is the one passed to
+// NewMetricsExporter with an ID unique to the attribute value, so that the
+// exporterhelper queue and send metrics are reported per attribute value.
+type CreateMetricsExporterFunc func(cfg config.Exporter) (component.MetricsExporter, error)
+
+// CreateLogsExporterFunc is the logs equivalent of CreateMetricsExporterFunc.
+type CreateLogsExporterFunc func(cfg config.Exporter) (component.LogsExporter, error)
+
+// idConfig overrides the ID of the wrapped exporter configuration.
+type idConfig struct {
+	config.Exporter
+	id config.ComponentID
+}
+
+func (c *idConfig) ID() config.ComponentID {
+	return c.id
+}
+
+// queueID returns the ID of the exporter dedicated to attrVal. The value is
+// hashed since it is usually a secret and the ID ends up in metric labels.
+func queueID(id config.ComponentID, attrVal string) config.ComponentID {
+	sum := sha256.Sum256([]byte(attrVal))
+	name := "queue_" + hex.EncodeToString(sum[:])[:8]
+	if id.Name() != "" {
+		name = id.Name() + "_" + name
+	}
+	return config.NewIDWithName(id.Type(), name)
+}
+
+// dedicatedExporter is an exporter dedicated to an attribute value.
+type dedicatedExporter struct {
+	component.Component
+	id       config.ComponentID
+	lastUsed time.Time
+	// inUse counts the calls sending data through the exporter, which can't be
+	// evicted meanwhile.
+	inUse int
+}
+
+// router keeps the exporters dedicated to each attribute value and the shared
+// one used for data without the attribute or once MaxQueues is reached.
+type router struct {
+	attrKey  string
+	settings Settings
+	cfg      config.Exporter
+	logger   *zap.Logger
+	create   func(cfg config.Exporter) (component.Component, error)
+
+	shared component.Component
+	now    func() time.Time
+
+	mu        sync.Mutex
+	host      component.Host
+	exporters map[string]*dedicatedExporter
+	shutdown  bool
+
+	stopCh chan struct{}
+	doneCh chan struct{}
+}
+
+func newRouter(
+	attrKey string,
+	settings Settings,
+	cfg config.Exporter,
+	logger *zap.Logger,
+	create func(cfg config.Exporter) (component.Component, error),
+) (*router, error) {
+	shared, err := create(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if settings.MaxQueues <= 0 {
+		settings.MaxQueues = defaultMaxQueues
+	}
+	return &router{
+		attrKey:   attrKey,
+		settings:  settings,
+		cfg:       cfg,
+		logger:    logger,
+		create:    create,
+		shared:    shared,
+		now:       time.Now,
+		exporters: map[string]*dedicatedExporter{},
+		stopCh:    make(chan struct{}),
+		doneCh:    make(chan struct{}),
+	}, nil
+}
+
+func (r *router) Start(ctx context.Context, host component.Host) error {
+	r.mu.Lock()
+	r.host = host
+	r.mu.Unlock()
+	if err := r.shared.Start(ctx, host); err != nil {
+		close(r.doneCh)
+		return err
+	}
+	if r.settings.IdleTimeout > 0 {
+		go r.evictIdleLoop()
+	} else {
+		close(r.doneCh)
+	}
+	return nil
+}
+
+func (r *router) Shutdown(ctx context.Context) error {
+	r.mu.Lock()
+	started := !r.shutdown && r.host != nil
+	r.shutdown = true
+	exporters := r.exporters
+	r.exporters = map[string]*dedicatedExporter{}
+	r.mu.Unlock()
+
+	if started {
+		close(r.stopCh)
+		<-r.doneCh
+	}
+
+	var errs []error
+	if err := r.shared.Shutdown(ctx); err != nil {
+		errs = append(errs, err)
+	}
+	for _, exp := range exporters {
+		if err := exp.Shutdown(ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return consumererror.Combine(errs)
+}
+
+func (r *router) evictIdleLoop() {
+	defer close(r.doneCh)
+	ticker := time.NewTicker(r.settings.IdleTimeout / 2)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			r.evictIdle(context.Background())
+		case <-r.stopCh:
+			return
+		}
+	}
+}
+
+// evictIdle shuts down the dedicated exporters which didn't receive data for
+// IdleTimeout. Data for their attribute value recreates them.
+func (r *router) evictIdle(ctx context.Context) {
+	deadline := r.now().Add(-r.settings.IdleTimeout)
+	var idle []*dedicatedExporter
+
+	r.mu.Lock()
+	for attrVal, exp := range r.exporters {
+		if exp.inUse == 0 && exp.lastUsed.Before(deadline) {
+			delete(r.exporters, attrVal)
+			idle = append(idle, exp)
+		}
+	}
+	r.mu.Unlock()
+
+	for _, exp := range idle {
+		if err := exp.Shutdown(ctx); err != nil {
+			r.logger.Warn("Failed to shut down idle dedicated sending queue",
+				zap.String("queue", exp.id.String()), zap.Error(err))
+			continue
+		}
+		r.logger.Debug("Shut down idle dedicated sending queue", zap.String("queue", exp.id.String()))
+	}
+}
+
+// exporterFor returns the exporter dedicated to attrVal, creating and
+// starting it on first use, or the shared one if there is none. The returned
+// function must be called once done sending through the exporter.
+func (r *router) exporterFor(ctx context.Context, attrVal string) (component.Component, func()) {
+	if attrVal == "" {
+		return r.shared, func() {}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if exp, ok := r.exporters[attrVal]; ok {
+		return r.use(exp)
+	}
+	if r.shutdown || r.host == nil {
+		return r.shared, func() {}
+	}
+	if len(r.exporters) >= r.settings.MaxQueues {
+		r.logger.Debug("Maximum number of dedicated sending queues reached, using the shared queue",
+			zap.Int("max_queues", r.settings.MaxQueues))
+		return r.shared, func() {}
+	}
+
+	id := queueID(r.cfg.ID(), attrVal)
+	exp, err := r.create(&idConfig{Exporter: r.cfg, id: id})
+	if err == nil {
+		err = exp.Start(ctx, r.host)
+	}
+	if err != nil {
+		r.logger.Warn("Failed to create dedicated sending queue, using the shared queue",
+			zap.String("queue", id.String()), zap.Error(err))
+		return r.shared, func() {}
+	}
+
+	r.logger.Debug("Created dedicated sending queue", zap.String("queue", id.String()))
+	dedicated := &dedicatedExporter{Component: exp, id: id}
+	r.exporters[attrVal] = dedicated
+	return r.use(dedicated)
+}
+
+// use marks exp as in use until the returned function is called. It must be
+// called with the lock held.
+func (r *router) use(exp *dedicatedExporter) (component.Component, func()) {
+	exp.inUse++
+	return exp.Component, func() {
+		r.mu.Lock()
+		exp.inUse--
+		exp.lastUsed = r.now()
+		r.mu.Unlock()
+	}
+}
+
+func (r *router) attrValue(res pdata.Resource) string {
+	if v, ok := res.Attributes().Get(r.attrKey); ok {
+		return v.StringVal()
+	}
+	return ""
+}
+
+type metricsExporter struct {
+	*router
+	consumer.Metrics
+}
+
+// NewMetricsExporter returns an exporter which sends metrics through an
+// exporter dedicated to the value of the attrKey resource attribute when
+// settings are enabled. Otherwise the exporter created for cfg is returned.
+func NewMetricsExporter(
+	attrKey string,
+	settings Settings,
+	cfg config.Exporter,
+	logger *zap.Logger,
+	create CreateMetricsExporterFunc,
+) (component.MetricsExporter, error) {
+	if !settings.Enabled {
+		return create(cfg)
+	}
+
+	r, err := newRouter(attrKey, settings, cfg, logger, func(cfg config.Exporter) (component.Component, error) {
+		return create(cfg)
+	})
+	if err != nil {
+		return nil, err
+	}
+	me := &metricsExporter{router: r}
+	// Batches are split per attribute value so each one has a single owner.
+	me.Metrics = batchperresourceattr.NewBatchPerResourceMetrics(attrKey, consumerFunc{
+		consumeMetrics: me.route,
+	})
+	return me, nil
+}
+
+func (me *metricsExporter) route(ctx context.Context, md pdata.Metrics) error {
+	var attrVal string
+	if rms := md.ResourceMetrics(); rms.Len() > 0 {
+		attrVal = me.attrValue(rms.At(0).Resource())
+	}
+	exp, done := me.exporterFor(ctx, attrVal)
+	defer done()
+	return exp.(consumer.Metrics).ConsumeMetrics(ctx, md)
+}
+
+type logsExporter struct {
+	*router
+	consumer.Logs
+}
+
+// NewLogsExporter is the logs equivalent of NewMetricsExporter.
+func NewLogsExporter(
+	attrKey string,
+	settings Settings,
+	cfg config.Exporter,
+	logger *zap.Logger,
+	create CreateLogsExporterFunc,
+) (component.LogsExporter, error) {
+	if !settings.Enabled {
+		return create(cfg)
+	}
+
+	r, err := newRouter(attrKey, settings, cfg, logger, func(cfg config.Exporter) (component.Component, error) {
+		return create(cfg)
+	})
+	if err != nil {
+		return nil, err
+	}
+	le := &logsExporter{router: r}
+	le.Logs = batchperresourceattr.NewBatchPerResourceLogs(attrKey, consumerFunc{
+		consumeLogs: le.route,
+	})
+	return le, nil
+}
+
+func (le *logsExporter) route(ctx context.Context, ld pdata.Logs) error {
+	var attrVal string
+	if rls := ld.ResourceLogs(); rls.Len() > 0 {
+		attrVal = le.attrValue(rls.At(0).Resource())
+	}
+	exp, done := le.exporterFor(ctx, attrVal)
+	defer done()
+	return exp.(consumer.Logs).ConsumeLogs(ctx, ld)
+}
+
+// consumerFunc adapts the routing functions to the consumer interfaces.
+type consumerFunc struct {
+	consumeMetrics func(ctx context.Context, md pdata.Metrics) error
+	consumeLogs    func(ctx context.Context, ld pdata.Logs) error
+}
+
+func (cf consumerFunc) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
+
+func (cf consumerFunc) ConsumeMetrics(ctx context.Context, md pdata.Metrics) error {
+	return cf.consumeMetrics(ctx, md)
+}
+
+func (cf consumerFunc) ConsumeLogs(ctx context.Context, ld pdata.Logs) error {
+	return cf.consumeLogs(ctx, ld)
+}
@@ -0,0 +1,220 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package queueperresourceattr
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.uber.org/zap"
+)
+
+const attrKey = "tenant"
+
+type testMetricsExporter struct {
+	consumertest.MetricsSink
+	id       config.ComponentID
+	started  bool
+	shutdown bool
+}
+
+func (e *testMetricsExporter) Start(context.Context, component.Host) error {
+	e.started = true
+	return nil
+}
+
+func (e *testMetricsExporter) Shutdown(context.Context) error {
+	e.shutdown = true
+	return nil
+}
+
+type testLogsExporter struct {
+	consumertest.LogsSink
+	id config.ComponentID
+}
+
+func (e *testLogsExporter) Start(context.Context, component.Host) error { return nil }
+
+func (e *testLogsExporter) Shutdown(context.Context) error { return nil }
+
+func newMetrics(tenants ...string) pdata.Metrics {
+	md := pdata.NewMetrics()
+	for _, tenant := range tenants {
+		rm := md.ResourceMetrics().AppendEmpty()
+		if tenant != "" {
+			rm.Resource().Attributes().InsertString(attrKey, tenant)
+		}
+		m := rm.InstrumentationLibraryMetrics().AppendEmpty().Metrics().AppendEmpty()
+		m.SetName("metric")
+	}
+	return md
+}
+
+func newLogs(tenants ...string) pdata.Logs {
+	ld := pdata.NewLogs()
+	for _, tenant := range tenants {
+		rl := ld.ResourceLogs().AppendEmpty()
+		if tenant != "" {
+			rl.Resource().Attributes().InsertString(attrKey, tenant)
+		}
+		rl.InstrumentationLibraryLogs().AppendEmpty().Logs().AppendEmpty()
+	}
+	return ld
+}
+
+func createMetricsExporters(t *testing.T, settings Settings) (component.MetricsExporter, map[string]*testMetricsExporter) {
+	cfg := config.NewExporterSettings(config.NewIDWithName("test", "name"))
+	created := map[string]*testMetricsExporter{}
+	exp, err := NewMetricsExporter(attrKey, settings, &cfg, zap.NewNop(), func(cfg config.Exporter) (component.MetricsExporter, error) {
+		e := &testMetricsExporter{id: cfg.ID()}
+		created[cfg.ID().String()] = e
+		return e, nil
+	})
+	require.NoError(t, err)
+	return exp, created
+}
+
+func TestMetricsExporterDisabled(t *testing.T) {
+	exp, created := createMetricsExporters(t, DefaultSettings())
+	require.Len(t, created, 1)
+	assert.Same(t, created["test/name"], exp)
+}
+
+func TestMetricsExporterDedicatedQueues(t *testing.T) {
+	exp, created := createMetricsExporters(t, Settings{Enabled: true, MaxQueues: 2})
+	require.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+
+	require.NoError(t, exp.ConsumeMetrics(context.Background(), newMetrics("a", "b", "", "a")))
+	// Reaching max_queues falls back on the shared queue.
+	require.NoError(t, exp.ConsumeMetrics(context.Background(), newMetrics("c")))
+
+	require.Len(t, created, 3)
+	shared := created["test/name"]
+	assert.True(t, shared.started)
+	assert.Len(t, shared.AllMetrics(), 2,
+		"data without the attribute and data over the limit should use the shared exporter")
+
+	var dedicated []*testMetricsExporter
+	for id, e := range created {
+		if id == "test/name" {
+			continue
+		}
+		dedicated = append(dedicated, e)
+		assert.True(t, e.started)
+		assert.True(t, strings.HasPrefix(id, "test/name_queue_"), id)
+	}
+	require.Len(t, dedicated, 2)
+	counts := []int{len(dedicated[0].AllMetrics()), len(dedicated[1].AllMetrics())}
+	assert.ElementsMatch(t, []int{1, 1}, counts)
+
+	require.NoError(t, exp.Shutdown(context.Background()))
+	for _, e := range created {
+		assert.True(t, e.shutdown)
+	}
+}
+
+func TestQueueIDIsStable(t *testing.T) {
+	id := config.NewID("test")
+	assert.Equal(t, queueID(id, "token"), queueID(id, "token"))
+	assert.NotEqual(t, queueID(id, "token"), queueID(id, "other"))
+	assert.Equal(t, config.Type("test"), queueID(id, "token").Type())
+	assert.True(t, strings.HasPrefix(queueID(id, "token").Name(), "queue_"))
+}
+
+func TestLogsExporterDedicatedQueues(t *testing.T) {
+	cfg := config.NewExporterSettings(config.NewID("test"))
+	created := map[string]*testLogsExporter{}
+	exp, err := NewLogsExporter(attrKey, Settings{Enabled: true}, &cfg, zap.NewNop(), func(cfg config.Exporter) (component.LogsExporter, error) {
+		e := &testLogsExporter{id: cfg.ID()}
+		created[cfg.ID().String()] = e
+		return e, nil
+	})
+	require.NoError(t, err)
+	require.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+
+	require.NoError(t, exp.ConsumeLogs(context.Background(), newLogs("a", "b", "b", "")))
+
+	require.Len(t, created, 3)
+	assert.Equal(t, 1, created["test"].LogRecordsCount())
+	assert.Equal(t, 1, created[queueID(config.NewID("test"), "a").String()].LogRecordsCount())
+	assert.Equal(t, 2, created[queueID(config.NewID("test"), "b").String()].LogRecordsCount())
+	require.NoError(t, exp.Shutdown(context.Background()))
+}
+
+func TestMetricsExporterEvictsIdleQueues(t *testing.T) {
+	exp, created := createMetricsExporters(t, Settings{Enabled: true, MaxQueues: 1, IdleTimeout: time.Minute})
+	r := exp.(*metricsExporter).router
+	now := time.Now()
+	r.now = func() time.Time { return now }
+	require.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+
+	require.NoError(t, exp.ConsumeMetrics(context.Background(), newMetrics("a")))
+	idA := queueID(config.NewIDWithName("test", "name"), "a").String()
+	require.Contains(t, created, idA)
+
+	now = now.Add(time.Minute)
+	r.evictIdle(context.Background())
+	assert.False(t, created[idA].shutdown, "queue used within the idle timeout should be kept")
+
+	now = now.Add(time.Minute + time.Second)
+	r.evictIdle(context.Background())
+	assert.True(t, created[idA].shutdown)
+
+	// The evicted queue no longer counts towards max_queues.
+	require.NoError(t, exp.ConsumeMetrics(context.Background(), newMetrics("b")))
+	idB := queueID(config.NewIDWithName("test", "name"), "b").String()
+	require.Contains(t, created, idB)
+	assert.Len(t, created[idB].AllMetrics(), 1)
+
+	// Data for an evicted value recreates its queue once there is room.
+	now = now.Add(2*time.Minute + time.Second)
+	r.evictIdle(context.Background())
+	require.NoError(t, exp.ConsumeMetrics(context.Background(), newMetrics("a")))
+	assert.True(t, created[idA].started)
+	assert.False(t, created[idA].shutdown)
+	assert.Len(t, created[idA].AllMetrics(), 1)
+
+	require.NoError(t, exp.Shutdown(context.Background()))
+}
+
+func TestEvictIdleSkipsQueuesInUse(t *testing.T) {
+	exp, created := createMetricsExporters(t, Settings{Enabled: true, MaxQueues: 1, IdleTimeout: time.Minute})
+	r := exp.(*metricsExporter).router
+	now := time.Now()
+	r.now = func() time.Time { return now }
+	require.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+
+	_, done := r.exporterFor(context.Background(), "a")
+	now = now.Add(time.Hour)
+	r.evictIdle(context.Background())
+	id := queueID(config.NewIDWithName("test", "name"), "a").String()
+	assert.False(t, created[id].shutdown)
+
+	done()
+	now = now.Add(time.Hour)
+	r.evictIdle(context.Background())
+	assert.True(t, created[id].shutdown)
+
+	require.NoError(t, exp.Shutdown(context.Background()))
+}
@@ -0,0 +1,36 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admissioncontrolprocessor
+
+import (
+	"errors"
+
+	"go.opentelemetry.io/collector/config"
+)
+
+type Config struct {
+	config.ProcessorSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+
+	// Extension is the ID of the admissioncontrol extension deciding whether
+	// the data is admitted. Default is "admissioncontrol".
+	Extension string `mapstructure:"extension"`
+}
+
+func (cfg *Config) Validate() error {
+	if _, err := config.NewIDFromString(cfg.Extension); err != nil {
+		return errors.New("extension must be a valid extension ID")
+	}
+	return nil
+}
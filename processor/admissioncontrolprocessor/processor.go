@@ -0,0 +1,68 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admissioncontrolprocessor
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer/pdata"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/admissioncontrolextension"
+)
+
+// admissionControlProcessor refuses the data while the admissioncontrol
+// extension has paused admission. The refusal is a retryable
+// *admissioncontrol.PausedError, so the receivers ask their senders
+// to retry instead of the data being dropped.
+type admissionControlProcessor struct {
+	config     *Config
+	controller admissioncontrolextension.Controller
+}
+
+func newAdmissionControlProcessor(cfg *Config) *admissionControlProcessor {
+	return &admissionControlProcessor{config: cfg}
+}
+
+func (p *admissionControlProcessor) Start(_ context.Context, host component.Host) error {
+	id, err := config.NewIDFromString(p.config.Extension)
+	if err != nil {
+		return err
+	}
+	ext, ok := host.GetExtensions()[id]
+	if !ok {
+		return fmt.Errorf("extension %q not found", id)
+	}
+	controller, ok := ext.(admissioncontrolextension.Controller)
+	if !ok {
+		return fmt.Errorf("extension %q is not an admissioncontrol extension", id)
+	}
+	p.controller = controller
+	return nil
+}
+
+func (p *admissionControlProcessor) ProcessTraces(_ context.Context, td pdata.Traces) (pdata.Traces, error) {
+	return td, p.controller.Admit()
+}
+
+func (p *admissionControlProcessor) ProcessMetrics(_ context.Context, md pdata.Metrics) (pdata.Metrics, error) {
+	return md, p.controller.Admit()
+}
+
+func (p *admissionControlProcessor) ProcessLogs(_ context.Context, ld pdata.Logs) (pdata.Logs, error) {
+	return ld, p.controller.Admit()
+}
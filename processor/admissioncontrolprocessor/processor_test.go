@@ -0,0 +1,146 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admissioncontrolprocessor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenthelper"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/consumer/pdata"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/admissioncontrol"
+)
+
+type fakeController struct {
+	component.Component
+	paused bool
+}
+
+func (c *fakeController) Admit() error {
+	if c.paused {
+		return &admissioncontrol.PausedError{RetryAfter: time.Second}
+	}
+	return nil
+}
+
+func (c *fakeController) RegisterHook(func(bool)) func() {
+	return func() {}
+}
+
+type extensionsHost struct {
+	component.Host
+	extensions map[config.ComponentID]component.Extension
+}
+
+func (h *extensionsHost) GetExtensions() map[config.ComponentID]component.Extension {
+	return h.extensions
+}
+
+func newHost(id config.ComponentID, ext component.Extension) component.Host {
+	return &extensionsHost{
+		Host:       componenttest.NewNopHost(),
+		extensions: map[config.ComponentID]component.Extension{id: ext},
+	}
+}
+
+func testTraces() pdata.Traces {
+	td := pdata.NewTraces()
+	td.ResourceSpans().AppendEmpty().InstrumentationLibrarySpans().AppendEmpty().Spans().AppendEmpty()
+	return td
+}
+
+func testMetrics() pdata.Metrics {
+	md := pdata.NewMetrics()
+	m := md.ResourceMetrics().AppendEmpty().InstrumentationLibraryMetrics().AppendEmpty().Metrics().AppendEmpty()
+	m.SetName("cpu.utilization")
+	m.SetDataType(pdata.MetricDataTypeDoubleGauge)
+	m.DoubleGauge().DataPoints().AppendEmpty().SetValue(1)
+	return md
+}
+
+func testLogs() pdata.Logs {
+	ld := pdata.NewLogs()
+	ld.ResourceLogs().AppendEmpty().InstrumentationLibraryLogs().AppendEmpty().Logs().AppendEmpty()
+	return ld
+}
+
+func TestProcessorAdmission(t *testing.T) {
+	controller := &fakeController{Component: componenthelper.New()}
+	host := newHost(config.NewID("admissioncontrol"), controller)
+
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	params := component.ProcessorCreateSettings{}
+
+	tracesSink := new(consumertest.TracesSink)
+	tp, err := factory.CreateTracesProcessor(context.Background(), params, cfg, tracesSink)
+	require.NoError(t, err)
+	require.NoError(t, tp.Start(context.Background(), host))
+	assert.False(t, tp.Capabilities().MutatesData)
+
+	metricsSink := new(consumertest.MetricsSink)
+	mp, err := factory.CreateMetricsProcessor(context.Background(), params, cfg, metricsSink)
+	require.NoError(t, err)
+	require.NoError(t, mp.Start(context.Background(), host))
+
+	logsSink := new(consumertest.LogsSink)
+	lp, err := factory.CreateLogsProcessor(context.Background(), params, cfg, logsSink)
+	require.NoError(t, err)
+	require.NoError(t, lp.Start(context.Background(), host))
+
+	require.NoError(t, tp.ConsumeTraces(context.Background(), testTraces()))
+	require.NoError(t, mp.ConsumeMetrics(context.Background(), testMetrics()))
+	require.NoError(t, lp.ConsumeLogs(context.Background(), testLogs()))
+
+	controller.paused = true
+	for _, err := range []error{
+		tp.ConsumeTraces(context.Background(), testTraces()),
+		mp.ConsumeMetrics(context.Background(), testMetrics()),
+		lp.ConsumeLogs(context.Background(), testLogs()),
+	} {
+		retryAfter, ok := admissioncontrol.RetryAfter(err)
+		assert.True(t, ok)
+		assert.Equal(t, time.Second, retryAfter)
+		assert.False(t, consumererror.IsPermanent(err))
+	}
+
+	assert.Equal(t, 1, tracesSink.SpansCount())
+	assert.Len(t, metricsSink.AllMetrics(), 1)
+	assert.Equal(t, 1, logsSink.LogRecordsCount())
+}
+
+func TestProcessorStartErrors(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	tp, err := factory.CreateTracesProcessor(context.Background(), component.ProcessorCreateSettings{}, cfg, consumertest.NewNop())
+	require.NoError(t, err)
+
+	err = tp.Start(context.Background(), componenttest.NewNopHost())
+	assert.EqualError(t, err, `extension "admissioncontrol" not found`)
+
+	host := newHost(config.NewID("admissioncontrol"), componenthelper.New())
+	err = tp.Start(context.Background(), host)
+	assert.EqualError(t, err, `extension "admissioncontrol" is not an admissioncontrol extension`)
+	assert.NoError(t, tp.Shutdown(context.Background()))
+}
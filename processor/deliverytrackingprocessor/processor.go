@@ -0,0 +1,150 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deliverytrackingprocessor
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/pdata"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/deliverytrackingextension"
+)
+
+// deliveryTrackingProcessor holds what the processors of every signal share.
+// Unlike most processors it doesn't use processorhelper, as it needs the
+// error returned by the rest of the pipeline to record the outcome of each batch.
+type deliveryTrackingProcessor struct {
+	config   *Config
+	pipeline string
+	tracker  deliverytrackingextension.Tracker
+}
+
+func newDeliveryTrackingProcessor(cfg *Config) *deliveryTrackingProcessor {
+	pipeline := cfg.Pipeline
+	if pipeline == "" {
+		pipeline = cfg.ID().String()
+	}
+	return &deliveryTrackingProcessor{
+		config:   cfg,
+		pipeline: pipeline,
+	}
+}
+
+func (p *deliveryTrackingProcessor) Start(_ context.Context, host component.Host) error {
+	id, err := config.NewIDFromString(p.config.Extension)
+	if err != nil {
+		return err
+	}
+	ext, ok := host.GetExtensions()[id]
+	if !ok {
+		return fmt.Errorf("extension %q not found", id)
+	}
+	tracker, ok := ext.(deliverytrackingextension.Tracker)
+	if !ok {
+		return fmt.Errorf("extension %q is not a deliverytracking extension", id)
+	}
+	p.tracker = tracker
+	return nil
+}
+
+func (p *deliveryTrackingProcessor) Shutdown(context.Context) error {
+	return nil
+}
+
+func (p *deliveryTrackingProcessor) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: p.config.BatchIDAttribute != ""}
+}
+
+// setBatchID sets the batch ID attribute on the given resource when enabled.
+func (p *deliveryTrackingProcessor) setBatchID(resource pdata.Resource, id string) {
+	if p.config.BatchIDAttribute != "" {
+		resource.Attributes().UpsertString(p.config.BatchIDAttribute, id)
+	}
+}
+
+type tracesProcessor struct {
+	*deliveryTrackingProcessor
+	next consumer.Traces
+}
+
+func (p *tracesProcessor) ConsumeTraces(ctx context.Context, td pdata.Traces) error {
+	var traceIDs []pdata.TraceID
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		ilss := rss.At(i).InstrumentationLibrarySpans()
+		for j := 0; j < ilss.Len(); j++ {
+			spans := ilss.At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				traceIDs = append(traceIDs, spans.At(k).TraceID())
+			}
+		}
+	}
+
+	id := p.tracker.Track(p.pipeline, deliverytrackingextension.SignalTraces, td.SpanCount(), traceIDs)
+	for i := 0; i < rss.Len(); i++ {
+		p.setBatchID(rss.At(i).Resource(), id)
+	}
+	err := p.next.ConsumeTraces(ctx, td)
+	p.tracker.Done(id, err)
+	return err
+}
+
+type metricsProcessor struct {
+	*deliveryTrackingProcessor
+	next consumer.Metrics
+}
+
+func (p *metricsProcessor) ConsumeMetrics(ctx context.Context, md pdata.Metrics) error {
+	_, dataPoints := md.MetricAndDataPointCount()
+	id := p.tracker.Track(p.pipeline, deliverytrackingextension.SignalMetrics, dataPoints, nil)
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		p.setBatchID(rms.At(i).Resource(), id)
+	}
+	err := p.next.ConsumeMetrics(ctx, md)
+	p.tracker.Done(id, err)
+	return err
+}
+
+type logsProcessor struct {
+	*deliveryTrackingProcessor
+	next consumer.Logs
+}
+
+func (p *logsProcessor) ConsumeLogs(ctx context.Context, ld pdata.Logs) error {
+	var traceIDs []pdata.TraceID
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		ills := rls.At(i).InstrumentationLibraryLogs()
+		for j := 0; j < ills.Len(); j++ {
+			logs := ills.At(j).Logs()
+			for k := 0; k < logs.Len(); k++ {
+				traceIDs = append(traceIDs, logs.At(k).TraceID())
+			}
+		}
+	}
+
+	id := p.tracker.Track(p.pipeline, deliverytrackingextension.SignalLogs, ld.LogRecordCount(), traceIDs)
+	for i := 0; i < rls.Len(); i++ {
+		p.setBatchID(rls.At(i).Resource(), id)
+	}
+	err := p.next.ConsumeLogs(ctx, ld)
+	p.tracker.Done(id, err)
+	return err
+}
@@ -0,0 +1,188 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deliverytrackingprocessor
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenthelper"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+type trackedBatch struct {
+	pipeline string
+	signal   string
+	items    int
+	traceIDs []pdata.TraceID
+	err      error
+	done     bool
+}
+
+type fakeTracker struct {
+	component.Component
+	batches []*trackedBatch
+}
+
+func (t *fakeTracker) Track(pipeline, signal string, items int, traceIDs []pdata.TraceID) string {
+	t.batches = append(t.batches, &trackedBatch{pipeline: pipeline, signal: signal, items: items, traceIDs: traceIDs})
+	return strconv.Itoa(len(t.batches) - 1)
+}
+
+func (t *fakeTracker) Done(id string, err error) {
+	i, _ := strconv.Atoi(id)
+	t.batches[i].err = err
+	t.batches[i].done = true
+}
+
+type extensionsHost struct {
+	component.Host
+	extensions map[config.ComponentID]component.Extension
+}
+
+func (h *extensionsHost) GetExtensions() map[config.ComponentID]component.Extension {
+	return h.extensions
+}
+
+func newHost(id config.ComponentID, ext component.Extension) component.Host {
+	return &extensionsHost{
+		Host:       componenttest.NewNopHost(),
+		extensions: map[config.ComponentID]component.Extension{id: ext},
+	}
+}
+
+func testTraces() pdata.Traces {
+	td := pdata.NewTraces()
+	spans := td.ResourceSpans().AppendEmpty().InstrumentationLibrarySpans().AppendEmpty().Spans()
+	spans.AppendEmpty().SetTraceID(pdata.NewTraceID([16]byte{1}))
+	spans.AppendEmpty().SetTraceID(pdata.NewTraceID([16]byte{2}))
+	return td
+}
+
+func testMetrics() pdata.Metrics {
+	md := pdata.NewMetrics()
+	m := md.ResourceMetrics().AppendEmpty().InstrumentationLibraryMetrics().AppendEmpty().Metrics().AppendEmpty()
+	m.SetName("cpu.utilization")
+	m.SetDataType(pdata.MetricDataTypeDoubleGauge)
+	m.DoubleGauge().DataPoints().AppendEmpty().SetValue(1)
+	m.DoubleGauge().DataPoints().AppendEmpty().SetValue(2)
+	return md
+}
+
+func testLogs() pdata.Logs {
+	ld := pdata.NewLogs()
+	lr := ld.ResourceLogs().AppendEmpty().InstrumentationLibraryLogs().AppendEmpty().Logs().AppendEmpty()
+	lr.SetTraceID(pdata.NewTraceID([16]byte{3}))
+	return ld
+}
+
+func TestProcessorTracksBatches(t *testing.T) {
+	tracker := &fakeTracker{Component: componenthelper.New()}
+	host := newHost(config.NewID("deliverytracking"), tracker)
+
+	factory := NewFactory()
+	params := component.ProcessorCreateSettings{}
+
+	tracesSink := new(consumertest.TracesSink)
+	tp, err := factory.CreateTracesProcessor(context.Background(), params, factory.CreateDefaultConfig(), tracesSink)
+	require.NoError(t, err)
+	require.NoError(t, tp.Start(context.Background(), host))
+	assert.False(t, tp.Capabilities().MutatesData)
+	require.NoError(t, tp.ConsumeTraces(context.Background(), testTraces()))
+	assert.Equal(t, 2, tracesSink.SpansCount())
+
+	metricsCfg := factory.CreateDefaultConfig().(*Config)
+	metricsCfg.Pipeline = "metrics-ingest"
+	metricsSink := new(consumertest.MetricsSink)
+	mp, err := factory.CreateMetricsProcessor(context.Background(), params, metricsCfg, metricsSink)
+	require.NoError(t, err)
+	require.NoError(t, mp.Start(context.Background(), host))
+	require.NoError(t, mp.ConsumeMetrics(context.Background(), testMetrics()))
+	assert.Len(t, metricsSink.AllMetrics(), 1)
+
+	logsCfg := factory.CreateDefaultConfig()
+	lp, err := factory.CreateLogsProcessor(context.Background(), params, logsCfg, consumertest.NewErr(errors.New("exporter unavailable")))
+	require.NoError(t, err)
+	require.NoError(t, lp.Start(context.Background(), host))
+	assert.EqualError(t, lp.ConsumeLogs(context.Background(), testLogs()), "exporter unavailable")
+
+	require.Len(t, tracker.batches, 3)
+	assert.Equal(t, &trackedBatch{
+		pipeline: "deliverytracking",
+		signal:   "traces",
+		items:    2,
+		traceIDs: []pdata.TraceID{pdata.NewTraceID([16]byte{1}), pdata.NewTraceID([16]byte{2})},
+		done:     true,
+	}, tracker.batches[0])
+	assert.Equal(t, &trackedBatch{
+		pipeline: "metrics-ingest",
+		signal:   "metrics",
+		items:    2,
+		done:     true,
+	}, tracker.batches[1])
+	assert.Equal(t, &trackedBatch{
+		pipeline: "deliverytracking",
+		signal:   "logs",
+		items:    1,
+		traceIDs: []pdata.TraceID{pdata.NewTraceID([16]byte{3})},
+		err:      errors.New("exporter unavailable"),
+		done:     true,
+	}, tracker.batches[2])
+}
+
+func TestProcessorSetsBatchIDAttribute(t *testing.T) {
+	tracker := &fakeTracker{Component: componenthelper.New()}
+	host := newHost(config.NewID("deliverytracking"), tracker)
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.BatchIDAttribute = "otelcol.batch.id"
+	sink := new(consumertest.TracesSink)
+	tp, err := NewFactory().CreateTracesProcessor(context.Background(), component.ProcessorCreateSettings{}, cfg, sink)
+	require.NoError(t, err)
+	require.NoError(t, tp.Start(context.Background(), host))
+	assert.True(t, tp.Capabilities().MutatesData)
+
+	require.NoError(t, tp.ConsumeTraces(context.Background(), testTraces()))
+	require.NoError(t, tp.ConsumeTraces(context.Background(), testTraces()))
+	require.Len(t, sink.AllTraces(), 2)
+	for i, td := range sink.AllTraces() {
+		v, ok := td.ResourceSpans().At(0).Resource().Attributes().Get("otelcol.batch.id")
+		require.True(t, ok)
+		assert.Equal(t, strconv.Itoa(i), v.StringVal())
+	}
+}
+
+func TestProcessorStartErrors(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	tp, err := factory.CreateTracesProcessor(context.Background(), component.ProcessorCreateSettings{}, cfg, consumertest.NewNop())
+	require.NoError(t, err)
+
+	err = tp.Start(context.Background(), componenttest.NewNopHost())
+	assert.EqualError(t, err, `extension "deliverytracking" not found`)
+
+	host := newHost(config.NewID("deliverytracking"), componenthelper.New())
+	err = tp.Start(context.Background(), host)
+	assert.EqualError(t, err, `extension "deliverytracking" is not a deliverytracking extension`)
+	assert.NoError(t, tp.Shutdown(context.Background()))
+}
@@ -0,0 +1,54 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exceptionsprocessor
+
+import (
+	"errors"
+
+	"go.opentelemetry.io/collector/config"
+)
+
+// Dimension defines the dimension name and optional default value if the Dimension is missing from a span attribute.
+type Dimension struct {
+	Name    string  `mapstructure:"name"`
+	Default *string `mapstructure:"default"`
+}
+
+// Config defines the configuration options for exceptionsprocessor.
+type Config struct {
+	config.ProcessorSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+
+	// MetricsExporter is the name of the metrics exporter to use to ship the exception count metrics.
+	// No metrics are generated if empty.
+	MetricsExporter string `mapstructure:"metrics_exporter"`
+
+	// LogsExporter is the name of the logs exporter to use to ship the exception log records.
+	// No log records are generated if empty.
+	LogsExporter string `mapstructure:"logs_exporter"`
+
+	// Dimensions defines the list of additional dimensions on top of the provided:
+	// - service.name
+	// - exception.type
+	// The dimensions will be fetched from the span's attributes.
+	Dimensions []Dimension `mapstructure:"dimensions"`
+}
+
+// Validate checks that at least one of the exporters is configured.
+func (cfg *Config) Validate() error {
+	if cfg.MetricsExporter == "" && cfg.LogsExporter == "" {
+		return errors.New("at least one of metrics_exporter or logs_exporter must be set")
+	}
+	return nil
+}
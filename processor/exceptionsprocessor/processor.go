@@ -0,0 +1,312 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exceptionsprocessor
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/translator/conventions"
+	tracetranslator "go.opentelemetry.io/collector/translator/trace"
+	"go.uber.org/zap"
+)
+
+const (
+	serviceNameKey     = conventions.AttributeServiceName
+	exceptionTypeKey   = conventions.AttributeExceptionType
+	spanNameKey        = "span.name"
+	metricKeySeparator = string(byte(0))
+)
+
+type metricKey string
+
+type processorImp struct {
+	lock   sync.RWMutex
+	logger *zap.Logger
+	config Config
+
+	metricsExporter component.MetricsExporter
+	logsExporter    component.LogsExporter
+	nextConsumer    consumer.Traces
+
+	// Additional dimensions to add to metrics.
+	dimensions []Dimension
+
+	// The starting time of the data points.
+	startTime time.Time
+
+	// Exception counts.
+	exceptionsTotal map[metricKey]int64
+
+	// A cache of dimension key-value maps keyed by a unique identifier formed by a concatenation of its values.
+	metricKeyToDimensions map[metricKey]map[string]string
+}
+
+func newProcessor(logger *zap.Logger, config config.Processor, nextConsumer consumer.Traces) *processorImp {
+	logger.Info("Building exceptionsprocessor")
+	pConfig := config.(*Config)
+
+	return &processorImp{
+		logger:                logger,
+		config:                *pConfig,
+		nextConsumer:          nextConsumer,
+		dimensions:            pConfig.Dimensions,
+		startTime:             time.Now(),
+		exceptionsTotal:       make(map[metricKey]int64),
+		metricKeyToDimensions: make(map[metricKey]map[string]string),
+	}
+}
+
+// Start implements the component.Component interface.
+func (p *processorImp) Start(_ context.Context, host component.Host) error {
+	p.logger.Info("Starting exceptionsprocessor")
+	exporters := host.GetExporters()
+
+	if p.config.MetricsExporter != "" {
+		exp, err := findExporter(exporters, config.MetricsDataType, p.config.MetricsExporter)
+		if err != nil {
+			return err
+		}
+		metricsExp, ok := exp.(component.MetricsExporter)
+		if !ok {
+			return fmt.Errorf("the exporter %q isn't a metrics exporter", p.config.MetricsExporter)
+		}
+		p.metricsExporter = metricsExp
+	}
+
+	if p.config.LogsExporter != "" {
+		exp, err := findExporter(exporters, config.LogsDataType, p.config.LogsExporter)
+		if err != nil {
+			return err
+		}
+		logsExp, ok := exp.(component.LogsExporter)
+		if !ok {
+			return fmt.Errorf("the exporter %q isn't a logs exporter", p.config.LogsExporter)
+		}
+		p.logsExporter = logsExp
+	}
+
+	p.logger.Info("Started exceptionsprocessor")
+	return nil
+}
+
+// findExporter looks up the named exporter among the exporters of the pipelines of the given data type.
+func findExporter(exporters map[config.DataType]map[config.ComponentID]component.Exporter, dataType config.DataType, name string) (component.Exporter, error) {
+	var available []string
+	for k, exp := range exporters[dataType] {
+		if k.String() == name {
+			return exp, nil
+		}
+		available = append(available, k.String())
+	}
+	return nil, fmt.Errorf("failed to find %s exporter: '%s'; please configure %s_exporter from one of: %+v",
+		dataType, name, dataType, available)
+}
+
+// Shutdown implements the component.Component interface.
+func (p *processorImp) Shutdown(context.Context) error {
+	p.logger.Info("Shutting down exceptionsprocessor")
+	return nil
+}
+
+// Capabilities implements the consumer interface.
+func (p *processorImp) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
+
+// ConsumeTraces implements the consumer.Traces interface.
+// It extracts the exception events of the spans into log records and exception count metrics,
+// forwarding them to the discovered exporters. The original input trace data will be forwarded
+// to the next consumer, unmodified.
+func (p *processorImp) ConsumeTraces(ctx context.Context, traces pdata.Traces) error {
+	logs := p.extractExceptions(traces)
+
+	// Firstly, export the derived data to avoid being impacted by downstream trace processor errors/latency.
+	if p.logsExporter != nil && logs.LogRecordCount() > 0 {
+		if err := p.logsExporter.ConsumeLogs(ctx, logs); err != nil {
+			return err
+		}
+	}
+	if p.metricsExporter != nil {
+		if m := p.buildMetrics(); m.MetricCount() > 0 {
+			if err := p.metricsExporter.ConsumeMetrics(ctx, m); err != nil {
+				return err
+			}
+		}
+	}
+
+	// Forward trace data unmodified.
+	return p.nextConsumer.ConsumeTraces(ctx, traces)
+}
+
+// extractExceptions walks through the span events, counting the exceptions and building a log record for each one.
+func (p *processorImp) extractExceptions(traces pdata.Traces) pdata.Logs {
+	logs := pdata.NewLogs()
+
+	for i := 0; i < traces.ResourceSpans().Len(); i++ {
+		rspans := traces.ResourceSpans().At(i)
+
+		serviceName := ""
+		if attr, ok := rspans.Resource().Attributes().Get(serviceNameKey); ok {
+			serviceName = attr.StringVal()
+		}
+
+		var ill pdata.InstrumentationLibraryLogs
+		hasLogs := false
+		ilsSlice := rspans.InstrumentationLibrarySpans()
+		for j := 0; j < ilsSlice.Len(); j++ {
+			spans := ilsSlice.At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				span := spans.At(k)
+				events := span.Events()
+				for l := 0; l < events.Len(); l++ {
+					event := events.At(l)
+					if event.Name() != conventions.AttributeExceptionEventName {
+						continue
+					}
+
+					p.aggregateException(serviceName, span, event)
+
+					if p.logsExporter == nil {
+						continue
+					}
+					if !hasLogs {
+						hasLogs = true
+						rl := logs.ResourceLogs().AppendEmpty()
+						rspans.Resource().CopyTo(rl.Resource())
+						ill = rl.InstrumentationLibraryLogs().AppendEmpty()
+						ill.InstrumentationLibrary().SetName("exceptionsprocessor")
+					}
+					buildLogRecord(ill.Logs().AppendEmpty(), span, event)
+				}
+			}
+		}
+	}
+
+	return logs
+}
+
+func (p *processorImp) aggregateException(serviceName string, span pdata.Span, event pdata.SpanEvent) {
+	exceptionType := ""
+	if attr, ok := event.Attributes().Get(exceptionTypeKey); ok {
+		exceptionType = attr.StringVal()
+	}
+
+	key := buildKey(serviceName, exceptionType, span, p.dimensions)
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if _, ok := p.metricKeyToDimensions[key]; !ok {
+		p.metricKeyToDimensions[key] = buildDimensionKVs(serviceName, exceptionType, span, p.dimensions)
+	}
+	p.exceptionsTotal[key]++
+}
+
+// buildLogRecord fills the log record with the exception event, linked to the span it was recorded on.
+func buildLogRecord(lr pdata.LogRecord, span pdata.Span, event pdata.SpanEvent) {
+	lr.SetTimestamp(event.Timestamp())
+	lr.SetTraceID(span.TraceID())
+	lr.SetSpanID(span.SpanID())
+	lr.SetSeverityNumber(pdata.SeverityNumberERROR)
+	lr.SetSeverityText("ERROR")
+
+	if msg, ok := event.Attributes().Get(conventions.AttributeExceptionMessage); ok {
+		lr.Body().SetStringVal(msg.StringVal())
+	}
+
+	event.Attributes().CopyTo(lr.Attributes())
+	lr.Attributes().UpsertString(spanNameKey, span.Name())
+}
+
+// buildMetrics collects the computed raw metrics data and builds the metrics object.
+func (p *processorImp) buildMetrics() pdata.Metrics {
+	m := pdata.NewMetrics()
+
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	if len(p.exceptionsTotal) == 0 {
+		return m
+	}
+
+	ilm := m.ResourceMetrics().AppendEmpty().InstrumentationLibraryMetrics().AppendEmpty()
+	ilm.InstrumentationLibrary().SetName("exceptionsprocessor")
+
+	for key, count := range p.exceptionsTotal {
+		mExceptions := ilm.Metrics().AppendEmpty()
+		mExceptions.SetDataType(pdata.MetricDataTypeIntSum)
+		mExceptions.SetName("exceptions_total")
+		mExceptions.IntSum().SetIsMonotonic(true)
+		mExceptions.IntSum().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+
+		dp := mExceptions.IntSum().DataPoints().AppendEmpty()
+		dp.SetStartTimestamp(pdata.TimestampFromTime(p.startTime))
+		dp.SetTimestamp(pdata.TimestampFromTime(time.Now()))
+		dp.SetValue(count)
+
+		dp.LabelsMap().InitFromMap(p.metricKeyToDimensions[key])
+	}
+
+	return m
+}
+
+func buildDimensionKVs(serviceName, exceptionType string, span pdata.Span, optionalDims []Dimension) map[string]string {
+	dims := make(map[string]string)
+	dims[serviceNameKey] = serviceName
+	dims[exceptionTypeKey] = exceptionType
+	spanAttr := span.Attributes()
+	for _, d := range optionalDims {
+		if attr, ok := spanAttr.Get(d.Name); ok {
+			dims[d.Name] = tracetranslator.AttributeValueToString(attr)
+		} else if d.Default != nil {
+			// Set the default if configured, otherwise this metric should have no value set for the dimension.
+			dims[d.Name] = *d.Default
+		}
+	}
+	return dims
+}
+
+// buildKey builds the metric key from the service name, the exception type and any additional dimensions the
+// user has configured. The metric key is a simple concatenation of dimension values.
+func buildKey(serviceName, exceptionType string, span pdata.Span, optionalDims []Dimension) metricKey {
+	var metricKeyBuilder strings.Builder
+	metricKeyBuilder.WriteString(serviceName)
+	metricKeyBuilder.WriteString(metricKeySeparator)
+	metricKeyBuilder.WriteString(exceptionType)
+
+	spanAttr := span.Attributes()
+	for _, d := range optionalDims {
+		value := ""
+		// Set the default if configured, otherwise this metric will have no value set for the dimension.
+		if d.Default != nil {
+			value = *d.Default
+		}
+		if attr, ok := spanAttr.Get(d.Name); ok {
+			value = tracetranslator.AttributeValueToString(attr)
+		}
+		metricKeyBuilder.WriteString(metricKeySeparator)
+		metricKeyBuilder.WriteString(value)
+	}
+
+	return metricKey(metricKeyBuilder.String())
+}
@@ -0,0 +1,197 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exceptionsprocessor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/translator/conventions"
+	"go.uber.org/zap"
+)
+
+type mockHost struct {
+	component.Host
+	exporters map[config.DataType]map[config.ComponentID]component.Exporter
+}
+
+func (h *mockHost) GetExporters() map[config.DataType]map[config.ComponentID]component.Exporter {
+	return h.exporters
+}
+
+type mockMetricsExporter struct {
+	component.Component
+	*consumertest.MetricsSink
+}
+
+type mockLogsExporter struct {
+	component.Component
+	*consumertest.LogsSink
+}
+
+func newMockHost(mexp component.Exporter, lexp component.Exporter) component.Host {
+	return &mockHost{
+		Host: componenttest.NewNopHost(),
+		exporters: map[config.DataType]map[config.ComponentID]component.Exporter{
+			config.MetricsDataType: {config.NewID("otlp"): mexp},
+			config.LogsDataType:    {config.NewID("otlp"): lexp},
+		},
+	}
+}
+
+func TestProcessorStart(t *testing.T) {
+	mexp := &mockMetricsExporter{MetricsSink: new(consumertest.MetricsSink)}
+	lexp := &mockLogsExporter{LogsSink: new(consumertest.LogsSink)}
+
+	for _, tc := range []struct {
+		name            string
+		metricsExporter string
+		logsExporter    string
+		host            component.Host
+		wantErrorMsg    string
+	}{
+		{"export to both exporters", "otlp", "otlp", newMockHost(mexp, lexp), ""},
+		{"export to logs exporter only", "", "otlp", newMockHost(mexp, lexp), ""},
+		{"unable to find metrics exporter", "prometheus", "", newMockHost(mexp, lexp), "failed to find metrics exporter: 'prometheus'; please configure metrics_exporter from one of: [otlp]"},
+		{"unable to find logs exporter", "", "loki", newMockHost(mexp, lexp), "failed to find logs exporter: 'loki'; please configure logs_exporter from one of: [otlp]"},
+		{"metrics exporter of the wrong type", "otlp", "", newMockHost(lexp, lexp), "the exporter \"otlp\" isn't a metrics exporter"},
+		{"logs exporter of the wrong type", "", "otlp", newMockHost(mexp, mexp), "the exporter \"otlp\" isn't a logs exporter"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.MetricsExporter = tc.metricsExporter
+			cfg.LogsExporter = tc.logsExporter
+			p := newProcessor(zap.NewNop(), cfg, consumertest.NewNop())
+
+			err := p.Start(context.Background(), tc.host)
+
+			if tc.wantErrorMsg != "" {
+				assert.EqualError(t, err, tc.wantErrorMsg)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestProcessorConsumeTraces(t *testing.T) {
+	// Prepare
+	mexp := &mockMetricsExporter{MetricsSink: new(consumertest.MetricsSink)}
+	lexp := &mockLogsExporter{LogsSink: new(consumertest.LogsSink)}
+	next := new(consumertest.TracesSink)
+
+	defaultMethod := "GET"
+	cfg := createDefaultConfig().(*Config)
+	cfg.MetricsExporter = "otlp"
+	cfg.LogsExporter = "otlp"
+	cfg.Dimensions = []Dimension{{"http.method", &defaultMethod}}
+	p := newProcessor(zap.NewNop(), cfg, next)
+	require.NoError(t, p.Start(context.Background(), newMockHost(mexp, lexp)))
+
+	// Test
+	require.NoError(t, p.ConsumeTraces(context.Background(), buildSampleTrace()))
+	require.NoError(t, p.ConsumeTraces(context.Background(), buildSampleTrace()))
+
+	// Verify
+	assert.Equal(t, 2, len(next.AllTraces()))
+
+	// one log record per exception event
+	require.Len(t, lexp.AllLogs(), 2)
+	logs := lexp.AllLogs()[0]
+	require.Equal(t, 2, logs.LogRecordCount())
+	rl := logs.ResourceLogs().At(0)
+	svc, _ := rl.Resource().Attributes().Get(conventions.AttributeServiceName)
+	assert.Equal(t, "checkout", svc.StringVal())
+	lr := rl.InstrumentationLibraryLogs().At(0).Logs().At(0)
+	assert.Equal(t, "connection refused", lr.Body().StringVal())
+	assert.Equal(t, pdata.SeverityNumberERROR, lr.SeverityNumber())
+	assert.False(t, lr.TraceID().IsEmpty())
+	assert.False(t, lr.SpanID().IsEmpty())
+	typ, _ := lr.Attributes().Get(conventions.AttributeExceptionType)
+	assert.Equal(t, "java.net.ConnectException", typ.StringVal())
+	name, _ := lr.Attributes().Get(spanNameKey)
+	assert.Equal(t, "POST /checkout", name.StringVal())
+
+	// cumulative exception counts per type
+	require.Len(t, mexp.AllMetrics(), 2)
+	metrics := mexp.AllMetrics()[1].ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics()
+	require.Equal(t, 2, metrics.Len())
+	counts := make(map[string]int64)
+	for i := 0; i < metrics.Len(); i++ {
+		m := metrics.At(i)
+		assert.Equal(t, "exceptions_total", m.Name())
+		dp := m.IntSum().DataPoints().At(0)
+		typ, _ := dp.LabelsMap().Get(exceptionTypeKey)
+		svc, _ := dp.LabelsMap().Get(serviceNameKey)
+		method, _ := dp.LabelsMap().Get("http.method")
+		assert.Equal(t, "checkout", svc)
+		assert.Equal(t, "GET", method)
+		counts[typ] = dp.Value()
+	}
+	assert.Equal(t, map[string]int64{"java.net.ConnectException": 2, "java.lang.NullPointerException": 2}, counts)
+}
+
+func TestProcessorConsumeTracesWithoutExceptions(t *testing.T) {
+	mexp := &mockMetricsExporter{MetricsSink: new(consumertest.MetricsSink)}
+	lexp := &mockLogsExporter{LogsSink: new(consumertest.LogsSink)}
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.MetricsExporter = "otlp"
+	cfg.LogsExporter = "otlp"
+	p := newProcessor(zap.NewNop(), cfg, consumertest.NewNop())
+	require.NoError(t, p.Start(context.Background(), newMockHost(mexp, lexp)))
+
+	td := pdata.NewTraces()
+	td.ResourceSpans().AppendEmpty().InstrumentationLibrarySpans().AppendEmpty().Spans().AppendEmpty().SetName("no exception")
+	require.NoError(t, p.ConsumeTraces(context.Background(), td))
+
+	assert.Empty(t, mexp.AllMetrics())
+	assert.Empty(t, lexp.AllLogs())
+}
+
+func buildSampleTrace() pdata.Traces {
+	traces := pdata.NewTraces()
+	rs := traces.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().InsertString(conventions.AttributeServiceName, "checkout")
+
+	span := rs.InstrumentationLibrarySpans().AppendEmpty().Spans().AppendEmpty()
+	span.SetName("POST /checkout")
+	span.SetTraceID(pdata.NewTraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}))
+	span.SetSpanID(pdata.NewSpanID([8]byte{1, 2, 3, 4, 5, 6, 7, 8}))
+
+	event := span.Events().AppendEmpty()
+	event.SetName(conventions.AttributeExceptionEventName)
+	event.SetTimestamp(pdata.TimestampFromTime(time.Unix(1000, 0)))
+	event.Attributes().InsertString(conventions.AttributeExceptionType, "java.net.ConnectException")
+	event.Attributes().InsertString(conventions.AttributeExceptionMessage, "connection refused")
+	event.Attributes().InsertString(conventions.AttributeExceptionStacktrace, "at Checkout.pay(Checkout.java:42)")
+
+	event = span.Events().AppendEmpty()
+	event.SetName("retry")
+
+	event = span.Events().AppendEmpty()
+	event.SetName(conventions.AttributeExceptionEventName)
+	event.Attributes().InsertString(conventions.AttributeExceptionType, "java.lang.NullPointerException")
+
+	return traces
+}
@@ -15,6 +15,8 @@
 package groupbytraceprocessor
 
 import (
+	"errors"
+	"fmt"
 	"time"
 
 	"go.opentelemetry.io/collector/config"
@@ -47,4 +49,34 @@ type Config struct {
 	// Default: false.
 	// Not yet implemented, and an error will be returned when this option is used.
 	StoreOnDisk bool `mapstructure:"store_on_disk"`
+
+	// Storage is the ID of a storage extension the in-flight traces are persisted to. The traces
+	// still held by the processor are written to the storage on shutdown and restored on start,
+	// so that a restart during the wait duration doesn't drop partially-assembled traces.
+	// Default: "" (traces are kept in memory only).
+	Storage string `mapstructure:"storage"`
+
+	// MaxTracesInMemory is the max number of traces to keep in memory when a storage is set.
+	// Traces received once this limit is reached are spilled to the storage instead of being kept in memory.
+	// Default: 0 (no limit other than NumTraces).
+	MaxTracesInMemory int `mapstructure:"max_traces_in_memory"`
+}
+
+var _ config.Processor = (*Config)(nil)
+
+// Validate checks if the processor configuration is valid.
+func (cfg *Config) Validate() error {
+	if cfg.MaxTracesInMemory < 0 {
+		return errors.New("max_traces_in_memory must not be negative")
+	}
+	if cfg.Storage == "" {
+		if cfg.MaxTracesInMemory > 0 {
+			return errors.New("max_traces_in_memory requires a storage")
+		}
+		return nil
+	}
+	if _, err := config.NewIDFromString(cfg.Storage); err != nil {
+		return fmt.Errorf("storage %q is invalid: %w", cfg.Storage, err)
+	}
+	return nil
 }
@@ -0,0 +1,72 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package groupbytraceprocessor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*Config)
+		err    string
+	}{
+		{
+			name:   "default",
+			modify: func(*Config) {},
+		},
+		{
+			name: "storage with memory limit",
+			modify: func(cfg *Config) {
+				cfg.Storage = "file_storage"
+				cfg.MaxTracesInMemory = 1000
+			},
+		},
+		{
+			name:   "invalid storage",
+			modify: func(cfg *Config) { cfg.Storage = "file_storage/" },
+			err:    `storage "file_storage/" is invalid`,
+		},
+		{
+			name:   "memory limit without storage",
+			modify: func(cfg *Config) { cfg.MaxTracesInMemory = 1000 },
+			err:    "max_traces_in_memory requires a storage",
+		},
+		{
+			name: "negative memory limit",
+			modify: func(cfg *Config) {
+				cfg.Storage = "file_storage"
+				cfg.MaxTracesInMemory = -1
+			},
+			err: "max_traces_in_memory must not be negative",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			tt.modify(cfg)
+			err := cfg.Validate()
+			if tt.err == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.err)
+		})
+	}
+}
@@ -84,8 +84,10 @@ func createTracesProcessor(
 		return nil, errDiscardOrphansNotSupported
 	}
 
-	// the only supported storage for now
 	st = newMemoryStorage()
+	if oCfg.Storage != "" {
+		st = newPersistentStorage(params.Logger, oCfg.ID(), oCfg.Storage, st.(*memoryStorage), oCfg.MaxTracesInMemory)
+	}
 
 	return newGroupByTraceProcessor(params.Logger, st, nextConsumer, *oCfg), nil
 }
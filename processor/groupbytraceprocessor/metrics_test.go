@@ -26,6 +26,7 @@ func TestProcessorMetrics(t *testing.T) {
 		"processor/groupbytrace/processor_groupbytrace_num_events_in_queue",
 		"processor/groupbytrace/processor_groupbytrace_num_traces_in_memory",
 		"processor/groupbytrace/processor_groupbytrace_traces_evicted",
+		"processor/groupbytrace/processor_groupbytrace_traces_spilled",
 		"processor/groupbytrace/processor_groupbytrace_spans_released",
 		"processor/groupbytrace/processor_groupbytrace_traces_released",
 		"processor/groupbytrace/processor_groupbytrace_incomplete_releases",
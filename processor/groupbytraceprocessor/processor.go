@@ -94,14 +94,31 @@ func (sp *groupByTraceProcessor) Capabilities() consumer.Capabilities {
 }
 
 // Start is invoked during service startup.
-func (sp *groupByTraceProcessor) Start(context.Context, component.Host) error {
+func (sp *groupByTraceProcessor) Start(ctx context.Context, host component.Host) error {
 	// start these metrics, as it might take a while for them to receive their first event
 	stats.Record(context.Background(), mTracesEvicted.M(0))
 	stats.Record(context.Background(), mIncompleteReleases.M(0))
 	stats.Record(context.Background(), mNumTracesConf.M(int64(sp.config.NumTraces)))
 
 	sp.eventMachine.startInBackground()
-	return sp.st.start()
+	if err := sp.st.start(ctx, host); err != nil {
+		return err
+	}
+
+	rst, ok := sp.st.(restorableStorage)
+	if !ok {
+		return nil
+	}
+	restored, err := rst.restore(func(td pdata.Traces) error {
+		return sp.ConsumeTraces(ctx, td)
+	})
+	if restored > 0 {
+		sp.logger.Info("restored traces from the storage", zap.Int("traces", restored))
+	}
+	if err != nil {
+		return fmt.Errorf("couldn't restore the traces from the storage: %w", err)
+	}
+	return nil
 }
 
 // Shutdown is invoked during service shutdown.
@@ -15,6 +15,9 @@
 package groupbytraceprocessor
 
 import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer/pdata"
 )
 
@@ -35,8 +38,15 @@ type storage interface {
 	delete(pdata.TraceID) ([]pdata.ResourceSpans, error)
 
 	// start gives the storage the opportunity to initialize any resources or procedures
-	start() error
+	start(context.Context, component.Host) error
 
 	// shutdown signals the storage that the processor is shutting down
 	shutdown() error
 }
+
+// restorableStorage is implemented by the storages that keep traces across restarts.
+type restorableStorage interface {
+	// restore hands the traces kept by a previous run to consume, so that they can go through the
+	// processor again, removing each one from the storage once consumed. It returns the number of traces restored.
+	restore(consume func(pdata.Traces) error) (int, error)
+}
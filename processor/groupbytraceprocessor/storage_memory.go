@@ -20,6 +20,7 @@ import (
 	"time"
 
 	"go.opencensus.io/stats"
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer/pdata"
 )
 
@@ -84,7 +85,7 @@ func (st *memoryStorage) delete(traceID pdata.TraceID) ([]pdata.ResourceSpans, e
 	return st.content[traceID], nil
 }
 
-func (st *memoryStorage) start() error {
+func (st *memoryStorage) start(context.Context, component.Host) error {
 	go st.periodicMetrics()
 	return nil
 }
@@ -112,6 +113,13 @@ func (st *memoryStorage) periodicMetrics() {
 	})
 }
 
+func (st *memoryStorage) contains(traceID pdata.TraceID) bool {
+	st.RLock()
+	defer st.RUnlock()
+	_, ok := st.content[traceID]
+	return ok
+}
+
 func (st *memoryStorage) count() int {
 	st.RLock()
 	defer st.RUnlock()
@@ -0,0 +1,324 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package groupbytraceprocessor
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+
+	"go.opencensus.io/stats"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.uber.org/zap"
+
+	storageext "github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage"
+)
+
+// persistentSlotsKey is the key of the range of slots that might hold a trace in the storage extension,
+// as the first slot and the next slot to use, each one encoded as a big endian uint64
+const persistentSlotsKey = "slots"
+
+// persistentStorage keeps the traces in memory up to the given limit, spilling the other ones to a
+// storage extension. The traces still in memory on shutdown are written to the storage extension as well,
+// and all the traces in the storage extension are handed back to the processor on the next start.
+//
+// Each trace written to the storage extension gets a key of its own, from a slot number that is never reused,
+// so that spilling and deleting a trace only touches the key of that trace and the range of slots.
+type persistentStorage struct {
+	// guards the slots and all the operations spanning both the memory and the storage extension
+	sync.Mutex
+
+	logger      *zap.Logger
+	id          config.ComponentID
+	storageID   string
+	mem         *memoryStorage
+	maxInMemory int
+
+	client storageext.Client
+
+	// the slots of the traces held by the storage extension
+	index map[pdata.TraceID]uint64
+
+	// the range of slots that might hold a trace, the ones before restoreEnd having been written by the previous run
+	firstSlot  uint64
+	nextSlot   uint64
+	restoreEnd uint64
+}
+
+var _ storage = (*persistentStorage)(nil)
+var _ restorableStorage = (*persistentStorage)(nil)
+
+func newPersistentStorage(logger *zap.Logger, id config.ComponentID, storageID string, mem *memoryStorage, maxInMemory int) *persistentStorage {
+	return &persistentStorage{
+		logger:      logger,
+		id:          id,
+		storageID:   storageID,
+		mem:         mem,
+		maxInMemory: maxInMemory,
+		index:       make(map[pdata.TraceID]uint64),
+	}
+}
+
+func (st *persistentStorage) createOrAppend(traceID pdata.TraceID, td pdata.Traces) error {
+	st.Lock()
+	defer st.Unlock()
+
+	if slot, ok := st.index[traceID]; ok {
+		rss, err := st.read(slot)
+		if err != nil {
+			return err
+		}
+		return st.write(slot, append(rss, resourceSpansOf(td)...))
+	}
+
+	if st.maxInMemory <= 0 || st.mem.contains(traceID) || st.mem.count() < st.maxInMemory {
+		return st.mem.createOrAppend(traceID, td)
+	}
+
+	if err := st.spill(traceID, resourceSpansOf(td)); err != nil {
+		return err
+	}
+	stats.Record(context.Background(), mTracesSpilled.M(1))
+	return st.writeSlots()
+}
+
+func (st *persistentStorage) get(traceID pdata.TraceID) ([]pdata.ResourceSpans, error) {
+	st.Lock()
+	defer st.Unlock()
+
+	if slot, ok := st.index[traceID]; ok {
+		return st.read(slot)
+	}
+	return st.mem.get(traceID)
+}
+
+func (st *persistentStorage) delete(traceID pdata.TraceID) ([]pdata.ResourceSpans, error) {
+	st.Lock()
+	defer st.Unlock()
+
+	slot, ok := st.index[traceID]
+	if !ok {
+		return st.mem.delete(traceID)
+	}
+
+	rss, err := st.read(slot)
+	if err != nil {
+		return nil, err
+	}
+	if err := st.client.Delete(context.Background(), slotKey(slot)); err != nil {
+		return nil, err
+	}
+	delete(st.index, traceID)
+
+	// once no trace is left in the storage extension, the range of slots can start over from the next one,
+	// unless the traces of the previous run are still being restored
+	if len(st.index) == 0 && st.firstSlot >= st.restoreEnd {
+		st.firstSlot = st.nextSlot
+		return rss, st.writeSlots()
+	}
+	return rss, nil
+}
+
+func (st *persistentStorage) start(ctx context.Context, host component.Host) error {
+	id, err := config.NewIDFromString(st.storageID)
+	if err != nil {
+		return err
+	}
+	ext, found := host.GetExtensions()[id]
+	if !found {
+		return fmt.Errorf("storage extension %q not found", id)
+	}
+	se, ok := ext.(storageext.Extension)
+	if !ok {
+		return fmt.Errorf("extension %q is not a storage extension", id)
+	}
+	client, err := se.GetClient(ctx, component.KindProcessor, st.id, "")
+	if err != nil {
+		return err
+	}
+
+	data, err := client.Get(ctx, persistentSlotsKey)
+	if err != nil {
+		return err
+	}
+	var firstSlot, nextSlot uint64
+	if data != nil {
+		if len(data) != 16 {
+			return errors.New("the slots of the persisted traces are corrupted")
+		}
+		firstSlot = binary.BigEndian.Uint64(data[:8])
+		nextSlot = binary.BigEndian.Uint64(data[8:])
+	}
+
+	st.Lock()
+	st.client = client
+	st.firstSlot = firstSlot
+	st.nextSlot = nextSlot
+	st.restoreEnd = nextSlot
+	st.Unlock()
+
+	return st.mem.start(ctx, host)
+}
+
+func (st *persistentStorage) restore(consume func(pdata.Traces) error) (int, error) {
+	st.Lock()
+	first, end := st.firstSlot, st.restoreEnd
+	st.Unlock()
+
+	restored := 0
+	for slot := first; slot < end; slot++ {
+		// the lock isn't held while the trace is handed back, as the processor stores it again
+		st.Lock()
+		rss, err := st.read(slot)
+		st.Unlock()
+		if err != nil {
+			return restored, err
+		}
+		if len(rss) == 0 {
+			continue
+		}
+
+		td := pdata.NewTraces()
+		for _, rs := range rss {
+			td.ResourceSpans().Append(rs)
+		}
+		// the trace is only removed from the storage extension once handed back, so that it isn't lost
+		// if the collector stops in between
+		if err := consume(td); err != nil {
+			return restored, err
+		}
+		if err := st.client.Delete(context.Background(), slotKey(slot)); err != nil {
+			return restored, err
+		}
+		restored++
+	}
+
+	st.Lock()
+	defer st.Unlock()
+	st.firstSlot = end
+	if len(st.index) == 0 {
+		st.firstSlot = st.nextSlot
+	}
+	return restored, st.writeSlots()
+}
+
+func (st *persistentStorage) shutdown() error {
+	st.Lock()
+	defer st.Unlock()
+
+	if err := st.mem.shutdown(); err != nil {
+		return err
+	}
+	if st.client == nil {
+		return nil
+	}
+
+	// persist the traces that are still waiting in memory, so that they survive the restart
+	st.mem.Lock()
+	content := st.mem.content
+	st.mem.content = make(map[pdata.TraceID][]pdata.ResourceSpans)
+	st.mem.Unlock()
+
+	var errs []error
+	for traceID, rss := range content {
+		if slot, ok := st.index[traceID]; ok {
+			persisted, err := st.read(slot)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			if err := st.write(slot, append(persisted, rss...)); err != nil {
+				errs = append(errs, err)
+			}
+			continue
+		}
+		if err := st.spill(traceID, rss); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(content) > 0 {
+		st.logger.Info("traces persisted to the storage", zap.Int("traces", len(content)))
+	}
+
+	if err := st.writeSlots(); err != nil {
+		errs = append(errs, err)
+	}
+	if err := st.client.Close(context.Background()); err != nil {
+		errs = append(errs, err)
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("couldn't persist the traces to the storage: %v", errs)
+	}
+	return nil
+}
+
+// spill writes the trace to the next slot of the storage extension. The caller is responsible for writing the slots.
+func (st *persistentStorage) spill(traceID pdata.TraceID, rss []pdata.ResourceSpans) error {
+	slot := st.nextSlot
+	if err := st.write(slot, rss); err != nil {
+		return err
+	}
+	st.nextSlot++
+	st.index[traceID] = slot
+	return nil
+}
+
+// read retrieves the trace from the storage extension, returning nil in case it cannot be found
+func (st *persistentStorage) read(slot uint64) ([]pdata.ResourceSpans, error) {
+	data, err := st.client.Get(context.Background(), slotKey(slot))
+	if err != nil || data == nil {
+		return nil, err
+	}
+	td, err := pdata.TracesFromOtlpProtoBytes(data)
+	if err != nil {
+		return nil, err
+	}
+	return resourceSpansOf(td), nil
+}
+
+func (st *persistentStorage) write(slot uint64, rss []pdata.ResourceSpans) error {
+	td := pdata.NewTraces()
+	for _, rs := range rss {
+		rs.CopyTo(td.ResourceSpans().AppendEmpty())
+	}
+	data, err := td.ToOtlpProtoBytes()
+	if err != nil {
+		return err
+	}
+	return st.client.Set(context.Background(), slotKey(slot), data)
+}
+
+func (st *persistentStorage) writeSlots() error {
+	data := make([]byte, 16)
+	binary.BigEndian.PutUint64(data[:8], st.firstSlot)
+	binary.BigEndian.PutUint64(data[8:], st.nextSlot)
+	return st.client.Set(context.Background(), persistentSlotsKey, data)
+}
+
+func slotKey(slot uint64) string {
+	return "trace_" + strconv.FormatUint(slot, 10)
+}
+
+func resourceSpansOf(td pdata.Traces) []pdata.ResourceSpans {
+	rss := make([]pdata.ResourceSpans, 0, td.ResourceSpans().Len())
+	for i := 0; i < td.ResourceSpans().Len(); i++ {
+		rss = append(rss, td.ResourceSpans().At(i))
+	}
+	return rss
+}
@@ -0,0 +1,223 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package groupbytraceprocessor
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/storagetest"
+)
+
+func newTestPersistentStorage(maxInMemory int) *persistentStorage {
+	return newPersistentStorage(zap.NewNop(), config.NewID(typeStr), "nop/test", newMemoryStorage(), maxInMemory)
+}
+
+func TestPersistentSpillsOverMemoryLimit(t *testing.T) {
+	// prepare
+	host := storagetest.NewStorageHost(t, t.TempDir(), "test")
+	st := newTestPersistentStorage(1)
+	require.NoError(t, st.start(context.Background(), host))
+	defer st.shutdown()
+
+	first := pdata.NewTraceID([16]byte{1, 2, 3, 4})
+	second := pdata.NewTraceID([16]byte{2, 3, 4, 5})
+
+	// test
+	require.NoError(t, st.createOrAppend(first, simpleTracesWithID(first)))
+	require.NoError(t, st.createOrAppend(second, simpleTracesWithID(second)))
+	require.NoError(t, st.createOrAppend(second, simpleTracesWithID(second)))
+
+	// verify
+	assert.True(t, st.mem.contains(first))
+	assert.False(t, st.mem.contains(second))
+	assert.Contains(t, st.index, second)
+
+	rss, err := st.get(second)
+	require.NoError(t, err)
+	require.Len(t, rss, 2)
+	assert.Equal(t, second, rss[0].InstrumentationLibrarySpans().At(0).Spans().At(0).TraceID())
+
+	rss, err = st.delete(second)
+	require.NoError(t, err)
+	assert.Len(t, rss, 2)
+	assert.NotContains(t, st.index, second)
+
+	rss, err = st.get(second)
+	require.NoError(t, err)
+	assert.Nil(t, rss)
+}
+
+func TestPersistentRestoreAfterShutdown(t *testing.T) {
+	// prepare
+	dir := t.TempDir()
+	traceIDs := []pdata.TraceID{
+		pdata.NewTraceID([16]byte{1, 2, 3, 4}),
+		pdata.NewTraceID([16]byte{2, 3, 4, 5}),
+	}
+
+	st := newTestPersistentStorage(1)
+	require.NoError(t, st.start(context.Background(), storagetest.NewStorageHost(t, dir, "test")))
+	for _, traceID := range traceIDs {
+		require.NoError(t, st.createOrAppend(traceID, simpleTracesWithID(traceID)))
+	}
+	require.NoError(t, st.shutdown())
+
+	// test
+	st = newTestPersistentStorage(1)
+	require.NoError(t, st.start(context.Background(), storagetest.NewStorageHost(t, dir, "test")))
+	defer st.shutdown()
+	var traces []pdata.Traces
+	consume := func(td pdata.Traces) error {
+		traces = append(traces, td)
+		return nil
+	}
+	restored, err := st.restore(consume)
+
+	// verify
+	require.NoError(t, err)
+	assert.Equal(t, 2, restored)
+	require.Len(t, traces, 2)
+	var restoredIDs []pdata.TraceID
+	for _, td := range traces {
+		restoredIDs = append(restoredIDs, td.ResourceSpans().At(0).InstrumentationLibrarySpans().At(0).Spans().At(0).TraceID())
+	}
+	assert.ElementsMatch(t, traceIDs, restoredIDs)
+	assert.Empty(t, st.index)
+
+	// the traces are handed back only once
+	traces = nil
+	restored, err = st.restore(consume)
+	require.NoError(t, err)
+	assert.Equal(t, 0, restored)
+	assert.Empty(t, traces)
+}
+
+func TestPersistentRestoreKeepsTracesNotConsumed(t *testing.T) {
+	// prepare
+	dir := t.TempDir()
+	traceIDs := []pdata.TraceID{
+		pdata.NewTraceID([16]byte{1, 2, 3, 4}),
+		pdata.NewTraceID([16]byte{2, 3, 4, 5}),
+	}
+
+	st := newTestPersistentStorage(0)
+	require.NoError(t, st.start(context.Background(), storagetest.NewStorageHost(t, dir, "test")))
+	for _, traceID := range traceIDs {
+		require.NoError(t, st.createOrAppend(traceID, simpleTracesWithID(traceID)))
+	}
+	require.NoError(t, st.shutdown())
+
+	// test
+	st = newTestPersistentStorage(0)
+	require.NoError(t, st.start(context.Background(), storagetest.NewStorageHost(t, dir, "test")))
+	restored, err := st.restore(func(pdata.Traces) error {
+		return errors.New("processor is full")
+	})
+	require.Error(t, err)
+	assert.Equal(t, 0, restored)
+	require.NoError(t, st.shutdown())
+
+	// verify
+	st = newTestPersistentStorage(0)
+	require.NoError(t, st.start(context.Background(), storagetest.NewStorageHost(t, dir, "test")))
+	defer st.shutdown()
+	restored, err = st.restore(func(pdata.Traces) error { return nil })
+	require.NoError(t, err)
+	assert.Equal(t, 2, restored)
+}
+
+func TestPersistentSlotsStartOverOnceEmpty(t *testing.T) {
+	// prepare
+	st := newTestPersistentStorage(1)
+	require.NoError(t, st.start(context.Background(), storagetest.NewStorageHost(t, t.TempDir(), "test")))
+	defer st.shutdown()
+
+	inMemory := pdata.NewTraceID([16]byte{1, 2, 3, 4})
+	require.NoError(t, st.createOrAppend(inMemory, simpleTracesWithID(inMemory)))
+
+	// test
+	for i := byte(0); i < 3; i++ {
+		traceID := pdata.NewTraceID([16]byte{2, i})
+		require.NoError(t, st.createOrAppend(traceID, simpleTracesWithID(traceID)))
+		assert.Equal(t, uint64(i), st.index[traceID])
+		assert.Equal(t, uint64(i+1), st.nextSlot)
+		_, err := st.delete(traceID)
+		require.NoError(t, err)
+	}
+
+	// verify
+	assert.Equal(t, uint64(3), st.firstSlot)
+	assert.Equal(t, uint64(3), st.nextSlot)
+	data, err := st.client.Get(context.Background(), persistentSlotsKey)
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0, 0, 0, 0, 0, 0, 0, 3, 0, 0, 0, 0, 0, 0, 0, 3}, data)
+}
+
+func TestPersistentProcessorRestoresTraces(t *testing.T) {
+	// prepare
+	dir := t.TempDir()
+	cfg := Config{
+		ProcessorSettings: config.NewProcessorSettings(config.NewID(typeStr)),
+		NumTraces:         8,
+		NumWorkers:        1,
+		WaitDuration:      time.Hour,
+		Storage:           "nop/test",
+	}
+	traceID := pdata.NewTraceID([16]byte{1, 2, 3, 4})
+
+	p := newGroupByTraceProcessor(zap.NewNop(), newTestPersistentStorage(0), &mockProcessor{}, cfg)
+	require.NoError(t, p.Start(context.Background(), storagetest.NewStorageHost(t, dir, "test")))
+	require.NoError(t, p.ConsumeTraces(context.Background(), simpleTracesWithID(traceID)))
+	require.Eventually(t, func() bool {
+		return p.st.(*persistentStorage).mem.contains(traceID)
+	}, time.Second, 10*time.Millisecond)
+	require.NoError(t, p.Shutdown(context.Background()))
+
+	// test
+	cfg.WaitDuration = 10 * time.Millisecond
+	released := make(chan pdata.Traces, 1)
+	next := &mockProcessor{
+		onTraces: func(_ context.Context, td pdata.Traces) error {
+			released <- td
+			return nil
+		},
+	}
+	p = newGroupByTraceProcessor(zap.NewNop(), newTestPersistentStorage(0), next, cfg)
+	require.NoError(t, p.Start(context.Background(), storagetest.NewStorageHost(t, dir, "test")))
+	defer p.Shutdown(context.Background())
+
+	// verify
+	select {
+	case td := <-released:
+		assert.Equal(t, traceID, td.ResourceSpans().At(0).InstrumentationLibrarySpans().At(0).Spans().At(0).TraceID())
+	case <-time.After(5 * time.Second):
+		t.Fatal("the restored trace wasn't released")
+	}
+}
+
+func TestPersistentStorageNotFound(t *testing.T) {
+	st := newPersistentStorage(zap.NewNop(), config.NewID(typeStr), "file_storage", newMemoryStorage(), 0)
+	err := st.start(context.Background(), componenttest.NewNopHost())
+	assert.EqualError(t, err, `storage extension "file_storage" not found`)
+}
@@ -0,0 +1,339 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package intervalprocessor
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	tracetranslator "go.opentelemetry.io/collector/translator/trace"
+)
+
+const keySeparator = string(byte(0))
+
+// aggregator accumulates metric data points until they are exported. Data points are identified by
+// their resource, instrumentation library, metric identity and labels; a new data point for an
+// existing identity is merged into the one already held.
+type aggregator struct {
+	resources map[string]*resourceState
+	// order of the resource keys, so that the export is deterministic
+	resourceOrder []string
+}
+
+type resourceState struct {
+	resource     pdata.Resource
+	libraries    map[string]*libraryState
+	libraryOrder []string
+}
+
+type libraryState struct {
+	library     pdata.InstrumentationLibrary
+	metrics     map[string]*metricState
+	metricOrder []string
+}
+
+type metricState struct {
+	metric pdata.Metric
+	// index of the data point held for each label set
+	points map[string]int
+}
+
+func newAggregator() *aggregator {
+	return &aggregator{resources: make(map[string]*resourceState)}
+}
+
+// aggregate merges a metric into the aggregator state.
+func (a *aggregator) aggregate(resource pdata.Resource, library pdata.InstrumentationLibrary, metric pdata.Metric) {
+	rKey := attributeMapKey(resource.Attributes())
+	rs, ok := a.resources[rKey]
+	if !ok {
+		rs = &resourceState{resource: pdata.NewResource(), libraries: make(map[string]*libraryState)}
+		resource.CopyTo(rs.resource)
+		a.resources[rKey] = rs
+		a.resourceOrder = append(a.resourceOrder, rKey)
+	}
+
+	lKey := library.Name() + keySeparator + library.Version()
+	ls, ok := rs.libraries[lKey]
+	if !ok {
+		ls = &libraryState{library: pdata.NewInstrumentationLibrary(), metrics: make(map[string]*metricState)}
+		library.CopyTo(ls.library)
+		rs.libraries[lKey] = ls
+		rs.libraryOrder = append(rs.libraryOrder, lKey)
+	}
+
+	mKey := metricKey(metric)
+	ms, ok := ls.metrics[mKey]
+	if !ok {
+		ms = &metricState{metric: newEmptyMetric(metric), points: make(map[string]int)}
+		ls.metrics[mKey] = ms
+		ls.metricOrder = append(ls.metricOrder, mKey)
+	}
+
+	ms.merge(metric)
+}
+
+// export moves the aggregated data points into a pdata.Metrics and resets the aggregator.
+func (a *aggregator) export() pdata.Metrics {
+	md := pdata.NewMetrics()
+	for _, rKey := range a.resourceOrder {
+		rs := a.resources[rKey]
+		rm := md.ResourceMetrics().AppendEmpty()
+		rs.resource.CopyTo(rm.Resource())
+
+		for _, lKey := range rs.libraryOrder {
+			ls := rs.libraries[lKey]
+			ilm := rm.InstrumentationLibraryMetrics().AppendEmpty()
+			ls.library.CopyTo(ilm.InstrumentationLibrary())
+
+			for _, mKey := range ls.metricOrder {
+				ls.metrics[mKey].metric.CopyTo(ilm.Metrics().AppendEmpty())
+			}
+		}
+	}
+
+	a.resources = make(map[string]*resourceState)
+	a.resourceOrder = nil
+	return md
+}
+
+func (ms *metricState) merge(metric pdata.Metric) {
+	switch metric.DataType() {
+	case pdata.MetricDataTypeIntGauge:
+		src, dst := metric.IntGauge().DataPoints(), ms.metric.IntGauge().DataPoints()
+		for i := 0; i < src.Len(); i++ {
+			dp := src.At(i)
+			if existing, ok := ms.intPoint(dst, dp); ok && dp.Timestamp() >= existing.Timestamp() {
+				dp.CopyTo(existing)
+			}
+		}
+	case pdata.MetricDataTypeDoubleGauge:
+		src, dst := metric.DoubleGauge().DataPoints(), ms.metric.DoubleGauge().DataPoints()
+		for i := 0; i < src.Len(); i++ {
+			dp := src.At(i)
+			if existing, ok := ms.doublePoint(dst, dp); ok && dp.Timestamp() >= existing.Timestamp() {
+				dp.CopyTo(existing)
+			}
+		}
+	case pdata.MetricDataTypeIntSum:
+		delta := metric.IntSum().AggregationTemporality() == pdata.AggregationTemporalityDelta
+		src, dst := metric.IntSum().DataPoints(), ms.metric.IntSum().DataPoints()
+		for i := 0; i < src.Len(); i++ {
+			dp := src.At(i)
+			existing, ok := ms.intPoint(dst, dp)
+			switch {
+			case !ok:
+			case delta:
+				existing.SetValue(existing.Value() + dp.Value())
+				mergeTimestamps(existing, dp)
+			case dp.Timestamp() >= existing.Timestamp():
+				dp.CopyTo(existing)
+			}
+		}
+	case pdata.MetricDataTypeDoubleSum:
+		delta := metric.DoubleSum().AggregationTemporality() == pdata.AggregationTemporalityDelta
+		src, dst := metric.DoubleSum().DataPoints(), ms.metric.DoubleSum().DataPoints()
+		for i := 0; i < src.Len(); i++ {
+			dp := src.At(i)
+			existing, ok := ms.doublePoint(dst, dp)
+			switch {
+			case !ok:
+			case delta:
+				existing.SetValue(existing.Value() + dp.Value())
+				mergeTimestamps(existing, dp)
+			case dp.Timestamp() >= existing.Timestamp():
+				dp.CopyTo(existing)
+			}
+		}
+	case pdata.MetricDataTypeIntHistogram:
+		delta := metric.IntHistogram().AggregationTemporality() == pdata.AggregationTemporalityDelta
+		src, dst := metric.IntHistogram().DataPoints(), ms.metric.IntHistogram().DataPoints()
+		for i := 0; i < src.Len(); i++ {
+			dp := src.At(i)
+			existing, ok := ms.intHistogramPoint(dst, dp)
+			switch {
+			case !ok:
+			case delta && equalBounds(existing.ExplicitBounds(), dp.ExplicitBounds()):
+				existing.SetCount(existing.Count() + dp.Count())
+				existing.SetSum(existing.Sum() + dp.Sum())
+				existing.SetBucketCounts(addBucketCounts(existing.BucketCounts(), dp.BucketCounts()))
+				mergeTimestamps(existing, dp)
+			case dp.Timestamp() >= existing.Timestamp():
+				dp.CopyTo(existing)
+			}
+		}
+	case pdata.MetricDataTypeHistogram:
+		delta := metric.Histogram().AggregationTemporality() == pdata.AggregationTemporalityDelta
+		src, dst := metric.Histogram().DataPoints(), ms.metric.Histogram().DataPoints()
+		for i := 0; i < src.Len(); i++ {
+			dp := src.At(i)
+			existing, ok := ms.histogramPoint(dst, dp)
+			switch {
+			case !ok:
+			case delta && equalBounds(existing.ExplicitBounds(), dp.ExplicitBounds()):
+				existing.SetCount(existing.Count() + dp.Count())
+				existing.SetSum(existing.Sum() + dp.Sum())
+				existing.SetBucketCounts(addBucketCounts(existing.BucketCounts(), dp.BucketCounts()))
+				mergeTimestamps(existing, dp)
+			case dp.Timestamp() >= existing.Timestamp():
+				dp.CopyTo(existing)
+			}
+		}
+	}
+}
+
+// intPoint returns the data point held for the labels of dp. When there is none yet, dp is
+// copied into a new data point and false is returned, as there is nothing left to merge.
+func (ms *metricState) intPoint(dst pdata.IntDataPointSlice, dp pdata.IntDataPoint) (pdata.IntDataPoint, bool) {
+	key := stringMapKey(dp.LabelsMap())
+	if idx, ok := ms.points[key]; ok {
+		return dst.At(idx), true
+	}
+	dp.CopyTo(dst.AppendEmpty())
+	ms.points[key] = dst.Len() - 1
+	return pdata.IntDataPoint{}, false
+}
+
+func (ms *metricState) doublePoint(dst pdata.DoubleDataPointSlice, dp pdata.DoubleDataPoint) (pdata.DoubleDataPoint, bool) {
+	key := stringMapKey(dp.LabelsMap())
+	if idx, ok := ms.points[key]; ok {
+		return dst.At(idx), true
+	}
+	dp.CopyTo(dst.AppendEmpty())
+	ms.points[key] = dst.Len() - 1
+	return pdata.DoubleDataPoint{}, false
+}
+
+func (ms *metricState) intHistogramPoint(dst pdata.IntHistogramDataPointSlice, dp pdata.IntHistogramDataPoint) (pdata.IntHistogramDataPoint, bool) {
+	key := stringMapKey(dp.LabelsMap())
+	if idx, ok := ms.points[key]; ok {
+		return dst.At(idx), true
+	}
+	dp.CopyTo(dst.AppendEmpty())
+	ms.points[key] = dst.Len() - 1
+	return pdata.IntHistogramDataPoint{}, false
+}
+
+func (ms *metricState) histogramPoint(dst pdata.HistogramDataPointSlice, dp pdata.HistogramDataPoint) (pdata.HistogramDataPoint, bool) {
+	key := stringMapKey(dp.LabelsMap())
+	if idx, ok := ms.points[key]; ok {
+		return dst.At(idx), true
+	}
+	dp.CopyTo(dst.AppendEmpty())
+	ms.points[key] = dst.Len() - 1
+	return pdata.HistogramDataPoint{}, false
+}
+
+type timestamped interface {
+	StartTimestamp() pdata.Timestamp
+	SetStartTimestamp(pdata.Timestamp)
+	Timestamp() pdata.Timestamp
+	SetTimestamp(pdata.Timestamp)
+}
+
+// mergeTimestamps widens the time range of existing so that it also covers dp.
+func mergeTimestamps(existing, dp timestamped) {
+	if dp.StartTimestamp() < existing.StartTimestamp() {
+		existing.SetStartTimestamp(dp.StartTimestamp())
+	}
+	if dp.Timestamp() > existing.Timestamp() {
+		existing.SetTimestamp(dp.Timestamp())
+	}
+}
+
+func equalBounds(a, b []float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func addBucketCounts(a, b []uint64) []uint64 {
+	if len(a) != len(b) {
+		return b
+	}
+	sum := make([]uint64, len(a))
+	for i := range a {
+		sum[i] = a[i] + b[i]
+	}
+	return sum
+}
+
+// newEmptyMetric returns a metric with the same descriptor as metric but without data points.
+func newEmptyMetric(metric pdata.Metric) pdata.Metric {
+	empty := pdata.NewMetric()
+	empty.SetName(metric.Name())
+	empty.SetDescription(metric.Description())
+	empty.SetUnit(metric.Unit())
+	empty.SetDataType(metric.DataType())
+
+	switch metric.DataType() {
+	case pdata.MetricDataTypeIntSum:
+		empty.IntSum().SetAggregationTemporality(metric.IntSum().AggregationTemporality())
+		empty.IntSum().SetIsMonotonic(metric.IntSum().IsMonotonic())
+	case pdata.MetricDataTypeDoubleSum:
+		empty.DoubleSum().SetAggregationTemporality(metric.DoubleSum().AggregationTemporality())
+		empty.DoubleSum().SetIsMonotonic(metric.DoubleSum().IsMonotonic())
+	case pdata.MetricDataTypeIntHistogram:
+		empty.IntHistogram().SetAggregationTemporality(metric.IntHistogram().AggregationTemporality())
+	case pdata.MetricDataTypeHistogram:
+		empty.Histogram().SetAggregationTemporality(metric.Histogram().AggregationTemporality())
+	}
+	return empty
+}
+
+// metricKey identifies a metric by its descriptor, so that data points of metrics sharing a
+// name but of different types or temporalities are never merged.
+func metricKey(metric pdata.Metric) string {
+	parts := []string{metric.Name(), metric.Unit(), metric.DataType().String()}
+	switch metric.DataType() {
+	case pdata.MetricDataTypeIntSum:
+		parts = append(parts, metric.IntSum().AggregationTemporality().String(), strconv.FormatBool(metric.IntSum().IsMonotonic()))
+	case pdata.MetricDataTypeDoubleSum:
+		parts = append(parts, metric.DoubleSum().AggregationTemporality().String(), strconv.FormatBool(metric.DoubleSum().IsMonotonic()))
+	case pdata.MetricDataTypeIntHistogram:
+		parts = append(parts, metric.IntHistogram().AggregationTemporality().String())
+	case pdata.MetricDataTypeHistogram:
+		parts = append(parts, metric.Histogram().AggregationTemporality().String())
+	}
+	return strings.Join(parts, keySeparator)
+}
+
+func attributeMapKey(attrs pdata.AttributeMap) string {
+	parts := make([]string, 0, attrs.Len())
+	attrs.Range(func(k string, v pdata.AttributeValue) bool {
+		parts = append(parts, k+keySeparator+tracetranslator.AttributeValueToString(v))
+		return true
+	})
+	sort.Strings(parts)
+	return strings.Join(parts, keySeparator)
+}
+
+func stringMapKey(labels pdata.StringMap) string {
+	parts := make([]string, 0, labels.Len())
+	labels.Range(func(k string, v string) bool {
+		parts = append(parts, k+keySeparator+v)
+		return true
+	})
+	sort.Strings(parts)
+	return strings.Join(parts, keySeparator)
+}
@@ -0,0 +1,170 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package intervalprocessor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+func TestAggregateSums(t *testing.T) {
+	tests := []struct {
+		name          string
+		temporality   pdata.AggregationTemporality
+		wantValue     int64
+		wantStartTime pdata.Timestamp
+	}{
+		{name: "delta sums are added", temporality: pdata.AggregationTemporalityDelta, wantValue: 6, wantStartTime: 0},
+		{name: "cumulative sums keep the last value", temporality: pdata.AggregationTemporalityCumulative, wantValue: 3, wantStartTime: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := newAggregator()
+			aggregateAll(a, newIntSumMetrics(tt.temporality, 1))
+			aggregateAll(a, newIntSumMetrics(tt.temporality, 0, 2, 3))
+
+			metrics := exportedMetrics(t, a.export())
+			require.Len(t, metrics, 1)
+			assert.Equal(t, tt.temporality, metrics[0].IntSum().AggregationTemporality())
+			dps := metrics[0].IntSum().DataPoints()
+			require.Equal(t, 1, dps.Len())
+			assert.Equal(t, tt.wantValue, dps.At(0).Value())
+			assert.Equal(t, tt.wantStartTime, dps.At(0).StartTimestamp())
+			assert.Equal(t, pdata.Timestamp(3), dps.At(0).Timestamp())
+		})
+	}
+}
+
+func TestAggregateGaugeKeepsLatestPerLabelSet(t *testing.T) {
+	md := pdata.NewMetrics()
+	metric := md.ResourceMetrics().AppendEmpty().InstrumentationLibraryMetrics().AppendEmpty().Metrics().AppendEmpty()
+	metric.SetName("gauge")
+	metric.SetDataType(pdata.MetricDataTypeIntGauge)
+	for _, p := range []struct {
+		host  string
+		ts    pdata.Timestamp
+		value int64
+	}{
+		{"a", 2, 20},
+		{"b", 1, 10},
+		// out of order data point is ignored
+		{"a", 1, 15},
+		{"b", 3, 30},
+	} {
+		dp := metric.IntGauge().DataPoints().AppendEmpty()
+		dp.LabelsMap().Insert("host", p.host)
+		dp.SetTimestamp(p.ts)
+		dp.SetValue(p.value)
+	}
+
+	a := newAggregator()
+	aggregateAll(a, md)
+
+	metrics := exportedMetrics(t, a.export())
+	require.Len(t, metrics, 1)
+	dps := metrics[0].IntGauge().DataPoints()
+	require.Equal(t, 2, dps.Len())
+	assert.Equal(t, int64(20), dps.At(0).Value())
+	assert.Equal(t, int64(30), dps.At(1).Value())
+}
+
+func TestAggregateDeltaHistograms(t *testing.T) {
+	newHistogram := func(bounds []float64, counts []uint64, sum float64) pdata.Metrics {
+		md := pdata.NewMetrics()
+		metric := md.ResourceMetrics().AppendEmpty().InstrumentationLibraryMetrics().AppendEmpty().Metrics().AppendEmpty()
+		metric.SetName("histogram")
+		metric.SetDataType(pdata.MetricDataTypeHistogram)
+		metric.Histogram().SetAggregationTemporality(pdata.AggregationTemporalityDelta)
+		dp := metric.Histogram().DataPoints().AppendEmpty()
+		dp.SetExplicitBounds(bounds)
+		dp.SetBucketCounts(counts)
+		var count uint64
+		for _, c := range counts {
+			count += c
+		}
+		dp.SetCount(count)
+		dp.SetSum(sum)
+		return md
+	}
+
+	a := newAggregator()
+	aggregateAll(a, newHistogram([]float64{1, 2}, []uint64{1, 2, 3}, 10))
+	aggregateAll(a, newHistogram([]float64{1, 2}, []uint64{1, 0, 1}, 5))
+
+	metrics := exportedMetrics(t, a.export())
+	require.Len(t, metrics, 1)
+	dp := metrics[0].Histogram().DataPoints().At(0)
+	assert.Equal(t, []uint64{2, 2, 4}, dp.BucketCounts())
+	assert.Equal(t, uint64(8), dp.Count())
+	assert.Equal(t, float64(15), dp.Sum())
+
+	// Data points with different bounds cannot be merged, the latest one wins
+	aggregateAll(a, newHistogram([]float64{1, 2}, []uint64{1, 2, 3}, 10))
+	aggregateAll(a, newHistogram([]float64{5}, []uint64{4, 1}, 20))
+	metrics = exportedMetrics(t, a.export())
+	dp = metrics[0].Histogram().DataPoints().At(0)
+	assert.Equal(t, []float64{5}, dp.ExplicitBounds())
+	assert.Equal(t, []uint64{4, 1}, dp.BucketCounts())
+}
+
+func TestAggregateKeepsResourcesAndMetricTypesApart(t *testing.T) {
+	a := newAggregator()
+
+	for _, service := range []string{"a", "b", "a"} {
+		md := newIntSumMetrics(pdata.AggregationTemporalityDelta, 1)
+		md.ResourceMetrics().At(0).Resource().Attributes().InsertString("service.name", service)
+		aggregateAll(a, md)
+	}
+	// Same name, different temporality
+	md := newIntSumMetrics(pdata.AggregationTemporalityCumulative, 7)
+	md.ResourceMetrics().At(0).Resource().Attributes().InsertString("service.name", "a")
+	aggregateAll(a, md)
+
+	exported := a.export()
+	require.Equal(t, 2, exported.ResourceMetrics().Len())
+	assert.Equal(t, 3, exported.MetricCount())
+
+	first := exported.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics()
+	require.Equal(t, 2, first.Len())
+	assert.Equal(t, int64(2), first.At(0).IntSum().DataPoints().At(0).Value())
+	assert.Equal(t, int64(7), first.At(1).IntSum().DataPoints().At(0).Value())
+}
+
+func aggregateAll(a *aggregator, md pdata.Metrics) {
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		ilms := rms.At(i).InstrumentationLibraryMetrics()
+		for j := 0; j < ilms.Len(); j++ {
+			metrics := ilms.At(j).Metrics()
+			for k := 0; k < metrics.Len(); k++ {
+				a.aggregate(rms.At(i).Resource(), ilms.At(j).InstrumentationLibrary(), metrics.At(k))
+			}
+		}
+	}
+}
+
+func exportedMetrics(t *testing.T, md pdata.Metrics) []pdata.Metric {
+	require.Equal(t, 1, md.ResourceMetrics().Len())
+	require.Equal(t, 1, md.ResourceMetrics().At(0).InstrumentationLibraryMetrics().Len())
+	metrics := md.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics()
+	result := make([]pdata.Metric, metrics.Len())
+	for i := range result {
+		result[i] = metrics.At(i)
+	}
+	return result
+}
@@ -0,0 +1,41 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package intervalprocessor
+
+import (
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/collector/config"
+)
+
+// Config defines the configuration options for intervalprocessor.
+type Config struct {
+	config.ProcessorSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+
+	// Interval is the export interval. Data points received during an interval are aggregated
+	// and exported together once it elapses.
+	Interval time.Duration `mapstructure:"interval"`
+}
+
+var _ config.Processor = (*Config)(nil)
+
+// Validate checks that the processor configuration is valid.
+func (cfg *Config) Validate() error {
+	if cfg.Interval <= 0 {
+		return errors.New("interval must be greater than zero")
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package intervalprocessor
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.uber.org/zap"
+)
+
+type processorImp struct {
+	logger       *zap.Logger
+	interval     time.Duration
+	nextConsumer consumer.Metrics
+
+	lock       sync.Mutex
+	aggregator *aggregator
+
+	shutdownCh chan struct{}
+	done       sync.WaitGroup
+}
+
+func newProcessor(logger *zap.Logger, cfg *Config, nextConsumer consumer.Metrics) *processorImp {
+	return &processorImp{
+		logger:       logger,
+		interval:     cfg.Interval,
+		nextConsumer: nextConsumer,
+		aggregator:   newAggregator(),
+		shutdownCh:   make(chan struct{}),
+	}
+}
+
+// Start implements the component.Component interface.
+func (p *processorImp) Start(_ context.Context, _ component.Host) error {
+	p.done.Add(1)
+	go func() {
+		defer p.done.Done()
+
+		ticker := time.NewTicker(p.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				p.exportMetrics(context.Background())
+			case <-p.shutdownCh:
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+// Shutdown implements the component.Component interface. Data points aggregated so far are
+// exported before returning.
+func (p *processorImp) Shutdown(ctx context.Context) error {
+	close(p.shutdownCh)
+	p.done.Wait()
+	p.exportMetrics(ctx)
+	return nil
+}
+
+// Capabilities implements the consumer interface.
+func (p *processorImp) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: true}
+}
+
+// ConsumeMetrics aggregates the incoming data points. Summaries cannot be merged, so they are
+// passed through to the next consumer right away.
+func (p *processorImp) ConsumeMetrics(ctx context.Context, md pdata.Metrics) error {
+	p.lock.Lock()
+	md.ResourceMetrics().RemoveIf(func(rm pdata.ResourceMetrics) bool {
+		rm.InstrumentationLibraryMetrics().RemoveIf(func(ilm pdata.InstrumentationLibraryMetrics) bool {
+			ilm.Metrics().RemoveIf(func(m pdata.Metric) bool {
+				switch m.DataType() {
+				case pdata.MetricDataTypeSummary:
+					return false
+				case pdata.MetricDataTypeNone:
+					return true
+				}
+				p.aggregator.aggregate(rm.Resource(), ilm.InstrumentationLibrary(), m)
+				return true
+			})
+			return ilm.Metrics().Len() == 0
+		})
+		return rm.InstrumentationLibraryMetrics().Len() == 0
+	})
+	p.lock.Unlock()
+
+	if md.ResourceMetrics().Len() == 0 {
+		return nil
+	}
+	return p.nextConsumer.ConsumeMetrics(ctx, md)
+}
+
+func (p *processorImp) exportMetrics(ctx context.Context) {
+	p.lock.Lock()
+	md := p.aggregator.export()
+	p.lock.Unlock()
+
+	if md.ResourceMetrics().Len() == 0 {
+		return
+	}
+	if err := p.nextConsumer.ConsumeMetrics(ctx, md); err != nil {
+		p.logger.Error("Failed to export aggregated metrics", zap.Error(err))
+	}
+}
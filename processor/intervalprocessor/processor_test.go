@@ -0,0 +1,116 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package intervalprocessor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.uber.org/zap"
+)
+
+func TestCreateProcessor(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	assert.Equal(t, defaultInterval, cfg.Interval)
+
+	mp, err := factory.CreateMetricsProcessor(context.Background(), component.ProcessorCreateSettings{Logger: zap.NewNop()}, cfg, consumertest.NewNop())
+	require.NoError(t, err)
+	assert.Equal(t, cfg.Interval, mp.(*processorImp).interval)
+}
+
+func TestProcessorAggregatesUntilExport(t *testing.T) {
+	sink := new(consumertest.MetricsSink)
+	p := newProcessor(zap.NewNop(), &Config{Interval: time.Hour}, sink)
+
+	for i := 1; i <= 3; i++ {
+		md := pdata.NewMetrics()
+		ilm := md.ResourceMetrics().AppendEmpty().InstrumentationLibraryMetrics().AppendEmpty()
+		gauge := ilm.Metrics().AppendEmpty()
+		gauge.SetName("gauge")
+		gauge.SetDataType(pdata.MetricDataTypeDoubleGauge)
+		dp := gauge.DoubleGauge().DataPoints().AppendEmpty()
+		dp.SetTimestamp(pdata.Timestamp(i))
+		dp.SetValue(float64(i))
+
+		summary := ilm.Metrics().AppendEmpty()
+		summary.SetName("summary")
+		summary.SetDataType(pdata.MetricDataTypeSummary)
+		summary.Summary().DataPoints().AppendEmpty().SetCount(uint64(i))
+
+		require.NoError(t, p.ConsumeMetrics(context.Background(), md))
+	}
+
+	// Summaries are passed through right away, gauges are held
+	require.Len(t, sink.AllMetrics(), 3)
+	for _, md := range sink.AllMetrics() {
+		require.Equal(t, 1, md.MetricCount())
+		assert.Equal(t, "summary", md.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics().At(0).Name())
+	}
+	sink.Reset()
+
+	p.exportMetrics(context.Background())
+	require.Len(t, sink.AllMetrics(), 1)
+	md := sink.AllMetrics()[0]
+	require.Equal(t, 1, md.MetricCount())
+	gauge := md.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics().At(0)
+	require.Equal(t, 1, gauge.DoubleGauge().DataPoints().Len())
+	assert.Equal(t, float64(3), gauge.DoubleGauge().DataPoints().At(0).Value())
+
+	// Nothing is exported for an empty interval
+	sink.Reset()
+	p.exportMetrics(context.Background())
+	assert.Len(t, sink.AllMetrics(), 0)
+}
+
+func TestProcessorExportsOnTickAndShutdown(t *testing.T) {
+	sink := new(consumertest.MetricsSink)
+	p := newProcessor(zap.NewNop(), &Config{Interval: 10 * time.Millisecond}, sink)
+	require.NoError(t, p.Start(context.Background(), componenttest.NewNopHost()))
+
+	require.NoError(t, p.ConsumeMetrics(context.Background(), newIntSumMetrics(pdata.AggregationTemporalityDelta, 1)))
+	assert.Eventually(t, func() bool { return len(sink.AllMetrics()) == 1 }, time.Second, 5*time.Millisecond)
+
+	require.NoError(t, p.ConsumeMetrics(context.Background(), newIntSumMetrics(pdata.AggregationTemporalityDelta, 2)))
+	require.NoError(t, p.Shutdown(context.Background()))
+
+	var total int64
+	for _, md := range sink.AllMetrics() {
+		total += md.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics().At(0).IntSum().DataPoints().At(0).Value()
+	}
+	assert.Equal(t, int64(3), total)
+}
+
+func newIntSumMetrics(temporality pdata.AggregationTemporality, values ...int64) pdata.Metrics {
+	md := pdata.NewMetrics()
+	metric := md.ResourceMetrics().AppendEmpty().InstrumentationLibraryMetrics().AppendEmpty().Metrics().AppendEmpty()
+	metric.SetName("sum")
+	metric.SetDataType(pdata.MetricDataTypeIntSum)
+	metric.IntSum().SetAggregationTemporality(temporality)
+	for i, v := range values {
+		dp := metric.IntSum().DataPoints().AppendEmpty()
+		dp.SetStartTimestamp(pdata.Timestamp(i))
+		dp.SetTimestamp(pdata.Timestamp(i + 1))
+		dp.SetValue(v)
+	}
+	return md
+}
@@ -56,10 +56,11 @@ type ExtractConfig struct {
 	// The field accepts a list of strings.
 	//
 	// Metadata fields supported right now are,
-	//   k8s.namespace.name, k8s.pod.name, k8s.pod.uid, k8s.deployment.name, k8s.cluster.name,
-	//   k8s.node.name and k8s.pod.start_time
+	//   k8s.namespace.name, k8s.pod.name, k8s.pod.uid, k8s.deployment.name, k8s.statefulset.name,
+	//   k8s.cronjob.name, k8s.cluster.name, k8s.node.name and k8s.pod.start_time
 	// Specifying anything other than these values will result in an error.
-	// By default all of the fields are extracted and added to spans and metrics.
+	// By default all of the fields except k8s.statefulset.name and k8s.cronjob.name are
+	// extracted and added to spans and metrics.
 	Metadata []string `mapstructure:"metadata"`
 
 	// Annotations allows extracting data from pod annotations and record it
@@ -42,7 +42,22 @@
 //
 // RBAC
 //
-// TODO: mention the required RBAC rules.
+// The processor lists and watches pods. Pods only reference the ReplicaSet or Job
+// controlling them, so extracting k8s.deployment.name or k8s.cronjob.name also requires
+// getting replicasets in the "apps" API group and jobs in the "batch" API group respectively
+// to find their owner. Without access to replicasets, the deployment name is derived from
+// the ReplicaSet name and the "pod-template-hash" label of the pod.
+//
+//    rules:
+//    - apiGroups: [""]
+//      resources: ["pods"]
+//      verbs: ["list", "watch"]
+//    - apiGroups: ["apps"]
+//      resources: ["replicasets"]
+//      verbs: ["get"]
+//    - apiGroups: ["batch"]
+//      resources: ["jobs"]
+//      verbs: ["get"]
 //
 // Config
 //
@@ -16,7 +16,6 @@ package kube
 
 import (
 	"fmt"
-	"regexp"
 	"strings"
 	"sync"
 	"time"
@@ -24,6 +23,7 @@ import (
 	"go.opentelemetry.io/collector/translator/conventions"
 	"go.uber.org/zap"
 	api_v1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/selection"
@@ -36,14 +36,14 @@ import (
 
 // WatchClient is the main interface provided by this package to a kubernetes cluster.
 type WatchClient struct {
-	m               sync.RWMutex
-	deleteMut       sync.Mutex
-	logger          *zap.Logger
-	kc              kubernetes.Interface
-	informer        cache.SharedInformer
-	deploymentRegex *regexp.Regexp
-	deleteQueue     []deleteRequest
-	stopCh          chan struct{}
+	m           sync.RWMutex
+	deleteMut   sync.Mutex
+	logger      *zap.Logger
+	kc          kubernetes.Interface
+	informer    cache.SharedInformer
+	owners      *ownerCache
+	deleteQueue []deleteRequest
+	stopCh      chan struct{}
 
 	// A map containing Pod related data, used to associate them with resources.
 	// Key can be either an IP address or Pod UID
@@ -53,19 +53,14 @@ type WatchClient struct {
 	Associations []Association
 }
 
-// Extract deployment name from the pod name. Pod name is created using
-// format: [deployment-name]-[Random-String-For-ReplicaSet]-[Random-String-For-Pod]
-var dRegex = regexp.MustCompile(`^(.*)-[0-9a-zA-Z]*-[0-9a-zA-Z]*$`)
-
 // New initializes a new k8s Client.
 func New(logger *zap.Logger, apiCfg k8sconfig.APIConfig, rules ExtractionRules, filters Filters, associations []Association, newClientSet APIClientsetProvider, newInformer InformerProvider) (Client, error) {
 	c := &WatchClient{
-		logger:          logger,
-		Rules:           rules,
-		Filters:         filters,
-		Associations:    associations,
-		deploymentRegex: dRegex,
-		stopCh:          make(chan struct{}),
+		logger:       logger,
+		Rules:        rules,
+		Filters:      filters,
+		Associations: associations,
+		stopCh:       make(chan struct{}),
 	}
 	go c.deleteLoop(time.Second*30, defaultPodDeleteGracePeriod)
 
@@ -79,6 +74,7 @@ func New(logger *zap.Logger, apiCfg k8sconfig.APIConfig, rules ExtractionRules,
 		return nil, err
 	}
 	c.kc = kc
+	c.owners = newOwnerCache(kc)
 
 	labelSelector, fieldSelector, err := selectorsFromFilters(c.Filters)
 	if err != nil {
@@ -180,6 +176,8 @@ func (c *WatchClient) deleteLoop(interval time.Duration, gracePeriod time.Durati
 			observability.RecordPodTableSize(int64(podTableSize))
 			c.m.Unlock()
 
+			c.owners.prune(now.Add(-ownerCacheTTL))
+
 		case <-c.stopCh:
 			return
 		}
@@ -223,12 +221,8 @@ func (c *WatchClient) extractPodAttributes(pod *api_v1.Pod) map[string]string {
 		tags[conventions.AttributeK8sPodUID] = string(uid)
 	}
 
-	if c.Rules.Deployment {
-		// format: [deployment-name]-[Random-String-For-ReplicaSet]-[Random-String-For-Pod]
-		parts := c.deploymentRegex.FindStringSubmatch(pod.Name)
-		if len(parts) == 2 {
-			tags[conventions.AttributeK8sDeployment] = parts[1]
-		}
+	if c.Rules.Deployment || c.Rules.StatefulSet || c.Rules.CronJob {
+		c.extractOwnerAttributes(pod, tags)
 	}
 
 	if c.Rules.Node {
@@ -256,6 +250,53 @@ func (c *WatchClient) extractPodAttributes(pod *api_v1.Pod) map[string]string {
 	return tags
 }
 
+// extractOwnerAttributes walks the owner references of the pod up to the workload
+// running it. Pods only reference their ReplicaSet or Job directly, the Deployment
+// or CronJob that controls it is looked up through the k8s API.
+func (c *WatchClient) extractOwnerAttributes(pod *api_v1.Pod, tags map[string]string) {
+	ref := meta_v1.GetControllerOf(pod)
+	if ref == nil {
+		return
+	}
+
+	switch ref.Kind {
+	case kindStatefulSet:
+		if c.Rules.StatefulSet {
+			tags[conventions.AttributeK8sStatefulSet] = ref.Name
+		}
+	case kindReplicaSet:
+		if !c.Rules.Deployment {
+			return
+		}
+		controller, err := c.owners.controllerOf(pod.Namespace, *ref)
+		if err != nil {
+			c.logger.Debug("failed to look up the owner of the ReplicaSet",
+				zap.String("replicaset", ref.Name), zap.String("namespace", pod.Namespace), zap.Error(err))
+			// ReplicaSets created by a deployment are named [deployment-name]-[pod-template-hash].
+			if hash, ok := pod.Labels[podTemplateHashLabel]; ok && strings.HasSuffix(ref.Name, "-"+hash) {
+				tags[conventions.AttributeK8sDeployment] = strings.TrimSuffix(ref.Name, "-"+hash)
+			}
+			return
+		}
+		if controller != nil && controller.Kind == kindDeployment {
+			tags[conventions.AttributeK8sDeployment] = controller.Name
+		}
+	case kindJob:
+		if !c.Rules.CronJob {
+			return
+		}
+		controller, err := c.owners.controllerOf(pod.Namespace, *ref)
+		if err != nil {
+			c.logger.Debug("failed to look up the owner of the Job",
+				zap.String("job", ref.Name), zap.String("namespace", pod.Namespace), zap.Error(err))
+			return
+		}
+		if controller != nil && controller.Kind == kindCronJob {
+			tags[conventions.AttributeK8sCronJob] = controller.Name
+		}
+	}
+}
+
 func (c *WatchClient) extractField(v string, r FieldExtractionRule) string {
 	// Check if a subset of the field should be extracted with a regular expression
 	// instead of the whole field.
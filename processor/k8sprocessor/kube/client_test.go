@@ -15,6 +15,7 @@
 package kube
 
 import (
+	"context"
 	"fmt"
 	"reflect"
 	"regexp"
@@ -26,11 +27,14 @@ import (
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 	"go.uber.org/zap/zaptest/observer"
+	apps_v1 "k8s.io/api/apps/v1"
+	batch_v1 "k8s.io/api/batch/v1"
 	api_v1 "k8s.io/api/core/v1"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/fake"
 
@@ -343,6 +347,7 @@ func TestHandlerWrongType(t *testing.T) {
 
 func TestExtractionRules(t *testing.T) {
 	c, _ := newTestClientWithRulesAndFilters(t, ExtractionRules{}, Filters{})
+	rs := createOwned(t, c, &apps_v1.ReplicaSet{}, "ns1", "auth-service-abc12", kindDeployment, "auth-service")
 
 	pod := &api_v1.Pod{
 		ObjectMeta: meta_v1.ObjectMeta{
@@ -351,6 +356,7 @@ func TestExtractionRules(t *testing.T) {
 			Namespace:         "ns1",
 			CreationTimestamp: meta_v1.Now(),
 			ClusterName:       "cluster1",
+			OwnerReferences:   controllerRefs(kindReplicaSet, rs),
 			Labels: map[string]string{
 				"label1": "lv1",
 				"label2": "k1=v1 k5=v5 extra!",
@@ -445,6 +451,133 @@ func TestExtractionRules(t *testing.T) {
 	}
 }
 
+// createOwned creates obj in the fake clientset of c, controlled by the ownerKind named ownerName.
+func createOwned(t *testing.T, c *WatchClient, obj meta_v1.Object, namespace, name, ownerKind, ownerName string) meta_v1.Object {
+	obj.SetName(name)
+	obj.SetNamespace(namespace)
+	obj.SetUID(types.UID(namespace + "/" + name))
+	if ownerKind != "" {
+		obj.SetOwnerReferences(controllerRefs(ownerKind, &meta_v1.ObjectMeta{Name: ownerName, UID: types.UID(ownerName)}))
+	}
+
+	var err error
+	switch o := obj.(type) {
+	case *apps_v1.ReplicaSet:
+		_, err = c.kc.AppsV1().ReplicaSets(namespace).Create(context.Background(), o, meta_v1.CreateOptions{})
+	case *batch_v1.Job:
+		_, err = c.kc.BatchV1().Jobs(namespace).Create(context.Background(), o, meta_v1.CreateOptions{})
+	}
+	require.NoError(t, err)
+	return obj
+}
+
+func controllerRefs(kind string, owner meta_v1.Object) []meta_v1.OwnerReference {
+	controller := true
+	return []meta_v1.OwnerReference{{
+		Kind:       kind,
+		Name:       owner.GetName(),
+		UID:        owner.GetUID(),
+		Controller: &controller,
+	}}
+}
+
+func TestExtractOwnerAttributes(t *testing.T) {
+	c, _ := newTestClientWithRulesAndFilters(t, ExtractionRules{
+		Deployment:  true,
+		StatefulSet: true,
+		CronJob:     true,
+	}, Filters{})
+
+	deploymentRS := createOwned(t, c, &apps_v1.ReplicaSet{}, "ns1", "web-5d4f8c7b9", kindDeployment, "web")
+	bareRS := createOwned(t, c, &apps_v1.ReplicaSet{}, "ns1", "bare", "", "")
+	cronJobJob := createOwned(t, c, &batch_v1.Job{}, "ns1", "backup-27093480", kindCronJob, "backup")
+	bareJob := createOwned(t, c, &batch_v1.Job{}, "ns1", "migrate-12345", "", "")
+
+	testCases := []struct {
+		name       string
+		owners     []meta_v1.OwnerReference
+		labels     map[string]string
+		attributes map[string]string
+	}{{
+		name:       "no owner",
+		attributes: map[string]string{},
+	}, {
+		name:   "deployment",
+		owners: controllerRefs(kindReplicaSet, deploymentRS),
+		attributes: map[string]string{
+			"k8s.deployment.name": "web",
+		},
+	}, {
+		name:       "replicaset without deployment",
+		owners:     controllerRefs(kindReplicaSet, bareRS),
+		attributes: map[string]string{},
+	}, {
+		name:   "unknown replicaset with pod template hash",
+		owners: controllerRefs(kindReplicaSet, &meta_v1.ObjectMeta{Name: "api-7f9c6d5b8", UID: "missing"}),
+		labels: map[string]string{"pod-template-hash": "7f9c6d5b8"},
+		attributes: map[string]string{
+			"k8s.deployment.name": "api",
+		},
+	}, {
+		name:       "unknown replicaset",
+		owners:     controllerRefs(kindReplicaSet, &meta_v1.ObjectMeta{Name: "api-7f9c6d5b8", UID: "missing"}),
+		attributes: map[string]string{},
+	}, {
+		name:   "statefulset",
+		owners: controllerRefs(kindStatefulSet, &meta_v1.ObjectMeta{Name: "db", UID: "db"}),
+		attributes: map[string]string{
+			"k8s.statefulset.name": "db",
+		},
+	}, {
+		name:   "cronjob",
+		owners: controllerRefs(kindJob, cronJobJob),
+		attributes: map[string]string{
+			"k8s.cronjob.name": "backup",
+		},
+	}, {
+		name:       "job without cronjob",
+		owners:     controllerRefs(kindJob, bareJob),
+		attributes: map[string]string{},
+	}}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pod := &api_v1.Pod{
+				ObjectMeta: meta_v1.ObjectMeta{
+					Name:            "pod",
+					Namespace:       "ns1",
+					Labels:          tc.labels,
+					OwnerReferences: tc.owners,
+				},
+			}
+			assert.Equal(t, tc.attributes, c.extractPodAttributes(pod))
+		})
+	}
+}
+
+func TestOwnerCache(t *testing.T) {
+	c, _ := newTestClient(t)
+	rs := createOwned(t, c, &apps_v1.ReplicaSet{}, "ns1", "web-5d4f8c7b9", kindDeployment, "web")
+	ref := controllerRefs(kindReplicaSet, rs)[0]
+
+	controller, err := c.owners.controllerOf("ns1", ref)
+	require.NoError(t, err)
+	assert.Equal(t, "web", controller.Name)
+
+	// The controller is served from the cache once looked up.
+	require.NoError(t, c.kc.AppsV1().ReplicaSets("ns1").Delete(context.Background(), rs.GetName(), meta_v1.DeleteOptions{}))
+	controller, err = c.owners.controllerOf("ns1", ref)
+	require.NoError(t, err)
+	assert.Equal(t, "web", controller.Name)
+
+	c.owners.prune(time.Now().Add(time.Minute))
+	assert.Empty(t, c.owners.owners)
+	_, err = c.owners.controllerOf("ns1", ref)
+	assert.Error(t, err)
+
+	_, err = c.owners.controllerOf("ns1", meta_v1.OwnerReference{Kind: "DaemonSet", Name: "agent"})
+	assert.Error(t, err)
+}
+
 func TestFilters(t *testing.T) {
 	testCases := []struct {
 		name    string
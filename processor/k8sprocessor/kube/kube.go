@@ -108,13 +108,15 @@ type FieldFilter struct {
 // ExtractionRules is used to specify the information that needs to be extracted
 // from pods and added to the spans as tags.
 type ExtractionRules struct {
-	Deployment bool
-	Namespace  bool
-	PodName    bool
-	PodUID     bool
-	Node       bool
-	Cluster    bool
-	StartTime  bool
+	Deployment  bool
+	StatefulSet bool
+	CronJob     bool
+	Namespace   bool
+	PodName     bool
+	PodUID      bool
+	Node        bool
+	Cluster     bool
+	StartTime   bool
 
 	Annotations []FieldExtractionRule
 	Labels      []FieldExtractionRule
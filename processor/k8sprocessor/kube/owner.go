@@ -0,0 +1,109 @@
+// Copyright 2020 OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kube
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	kindCronJob     = "CronJob"
+	kindDeployment  = "Deployment"
+	kindJob         = "Job"
+	kindReplicaSet  = "ReplicaSet"
+	kindStatefulSet = "StatefulSet"
+
+	// podTemplateHashLabel is set by the deployment controller on the pods of the
+	// ReplicaSets it creates, which are named [deployment-name]-[pod-template-hash].
+	podTemplateHashLabel = "pod-template-hash"
+)
+
+// ownerCacheTTL is how long the controller of a ReplicaSet or Job is kept after
+// it was last looked up.
+var ownerCacheTTL = time.Minute * 10
+
+type ownerEntry struct {
+	// controller is the controller of the owner, nil if it has none.
+	controller *meta_v1.OwnerReference
+	lastSeen   time.Time
+}
+
+// ownerCache looks up the controllers of the ReplicaSets and Jobs controlling pods,
+// e.g. Deployments and CronJobs, which pods do not reference directly. Lookups are
+// cached by the UID of the ReplicaSet or Job since its controller doesn't change.
+type ownerCache struct {
+	m      sync.Mutex
+	kc     kubernetes.Interface
+	owners map[types.UID]*ownerEntry
+}
+
+func newOwnerCache(kc kubernetes.Interface) *ownerCache {
+	return &ownerCache{
+		kc:     kc,
+		owners: map[types.UID]*ownerEntry{},
+	}
+}
+
+// controllerOf returns the controller of the object ref points to in namespace,
+// nil if it has none.
+func (oc *ownerCache) controllerOf(namespace string, ref meta_v1.OwnerReference) (*meta_v1.OwnerReference, error) {
+	now := time.Now()
+
+	oc.m.Lock()
+	if e, ok := oc.owners[ref.UID]; ok {
+		e.lastSeen = now
+		oc.m.Unlock()
+		return e.controller, nil
+	}
+	oc.m.Unlock()
+
+	var obj meta_v1.Object
+	var err error
+	switch ref.Kind {
+	case kindReplicaSet:
+		obj, err = oc.kc.AppsV1().ReplicaSets(namespace).Get(context.Background(), ref.Name, meta_v1.GetOptions{})
+	case kindJob:
+		obj, err = oc.kc.BatchV1().Jobs(namespace).Get(context.Background(), ref.Name, meta_v1.GetOptions{})
+	default:
+		return nil, fmt.Errorf("unsupported owner kind %q", ref.Kind)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	controller := meta_v1.GetControllerOf(obj)
+	oc.m.Lock()
+	oc.owners[ref.UID] = &ownerEntry{controller: controller, lastSeen: now}
+	oc.m.Unlock()
+	return controller, nil
+}
+
+// prune removes the owners not looked up since cutoff.
+func (oc *ownerCache) prune(cutoff time.Time) {
+	oc.m.Lock()
+	defer oc.m.Unlock()
+	for uid, e := range oc.owners {
+		if e.lastSeen.Before(cutoff) {
+			delete(oc.owners, uid)
+		}
+	}
+}
@@ -95,6 +95,10 @@ func WithExtractMetadata(fields ...string) Option {
 				p.rules.StartTime = true
 			case metadataDeployment, conventions.AttributeK8sDeployment:
 				p.rules.Deployment = true
+			case conventions.AttributeK8sStatefulSet:
+				p.rules.StatefulSet = true
+			case conventions.AttributeK8sCronJob:
+				p.rules.CronJob = true
 			case metadataCluster, conventions.AttributeK8sCluster:
 				p.rules.Cluster = true
 			case metadataNode, conventions.AttributeK8sNodeName:
@@ -206,6 +206,8 @@ func TestWithExtractMetadata(t *testing.T) {
 	assert.True(t, p.rules.Deployment)
 	assert.True(t, p.rules.Cluster)
 	assert.True(t, p.rules.Node)
+	assert.False(t, p.rules.StatefulSet)
+	assert.False(t, p.rules.CronJob)
 
 	p = &kubernetesprocessor{}
 	err := WithExtractMetadata("randomfield")(p)
@@ -231,6 +233,13 @@ func TestWithExtractMetadata(t *testing.T) {
 	assert.False(t, p.rules.StartTime)
 	assert.False(t, p.rules.Deployment)
 	assert.False(t, p.rules.Node)
+
+	p = &kubernetesprocessor{}
+
+	assert.NoError(t, WithExtractMetadata(conventions.AttributeK8sStatefulSet, conventions.AttributeK8sCronJob)(p))
+	assert.True(t, p.rules.StatefulSet)
+	assert.True(t, p.rules.CronJob)
+	assert.False(t, p.rules.Deployment)
 }
 
 func TestWithFilterLabels(t *testing.T) {
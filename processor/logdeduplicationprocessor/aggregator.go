@@ -0,0 +1,181 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logdeduplicationprocessor
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	tracetranslator "go.opentelemetry.io/collector/translator/trace"
+)
+
+// aggregator counts identical log records, grouped by resource and instrumentation library.
+// Records are exported in the order their first occurrence was received.
+type aggregator struct {
+	countAttribute    string
+	excludeAttributes map[string]struct{}
+
+	resources     []*resourceAggregate
+	resourceIndex map[string]*resourceAggregate
+}
+
+type resourceAggregate struct {
+	resource     pdata.Resource
+	libraries    []*libraryAggregate
+	libraryIndex map[string]*libraryAggregate
+}
+
+type libraryAggregate struct {
+	library     pdata.InstrumentationLibrary
+	records     []*recordAggregate
+	recordIndex map[string]*recordAggregate
+}
+
+type recordAggregate struct {
+	record pdata.LogRecord
+	count  int64
+}
+
+func newAggregator(countAttribute string, excludeAttributes []string) *aggregator {
+	excluded := make(map[string]struct{}, len(excludeAttributes))
+	for _, key := range excludeAttributes {
+		excluded[key] = struct{}{}
+	}
+	return &aggregator{
+		countAttribute:    countAttribute,
+		excludeAttributes: excluded,
+		resourceIndex:     make(map[string]*resourceAggregate),
+	}
+}
+
+// aggregate adds all the records of ld.
+func (a *aggregator) aggregate(ld pdata.Logs) {
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		ra := a.resourceAggregate(rl.Resource())
+
+		ills := rl.InstrumentationLibraryLogs()
+		for j := 0; j < ills.Len(); j++ {
+			ill := ills.At(j)
+			la := ra.libraryAggregate(ill.InstrumentationLibrary())
+
+			logs := ill.Logs()
+			for k := 0; k < logs.Len(); k++ {
+				a.addRecord(la, logs.At(k))
+			}
+		}
+	}
+}
+
+// export returns the aggregated records, each one with the number of identical records it
+// replaces, and resets the aggregator.
+func (a *aggregator) export() pdata.Logs {
+	ld := pdata.NewLogs()
+	for _, ra := range a.resources {
+		rl := ld.ResourceLogs().AppendEmpty()
+		ra.resource.CopyTo(rl.Resource())
+
+		for _, la := range ra.libraries {
+			ill := rl.InstrumentationLibraryLogs().AppendEmpty()
+			la.library.CopyTo(ill.InstrumentationLibrary())
+
+			for _, agg := range la.records {
+				lr := ill.Logs().AppendEmpty()
+				agg.record.CopyTo(lr)
+				lr.Attributes().UpsertInt(a.countAttribute, agg.count)
+			}
+		}
+	}
+
+	a.resources = nil
+	a.resourceIndex = make(map[string]*resourceAggregate)
+	return ld
+}
+
+func (a *aggregator) resourceAggregate(resource pdata.Resource) *resourceAggregate {
+	key := attributesKey(resource.Attributes(), nil)
+	ra, ok := a.resourceIndex[key]
+	if !ok {
+		ra = &resourceAggregate{
+			resource:     pdata.NewResource(),
+			libraryIndex: make(map[string]*libraryAggregate),
+		}
+		resource.CopyTo(ra.resource)
+		a.resourceIndex[key] = ra
+		a.resources = append(a.resources, ra)
+	}
+	return ra
+}
+
+func (ra *resourceAggregate) libraryAggregate(library pdata.InstrumentationLibrary) *libraryAggregate {
+	key := library.Name() + "\x00" + library.Version()
+	la, ok := ra.libraryIndex[key]
+	if !ok {
+		la = &libraryAggregate{
+			library:     pdata.NewInstrumentationLibrary(),
+			recordIndex: make(map[string]*recordAggregate),
+		}
+		library.CopyTo(la.library)
+		ra.libraryIndex[key] = la
+		ra.libraries = append(ra.libraries, la)
+	}
+	return la
+}
+
+func (a *aggregator) addRecord(la *libraryAggregate, lr pdata.LogRecord) {
+	key := a.recordKey(lr)
+	if agg, ok := la.recordIndex[key]; ok {
+		agg.count++
+		return
+	}
+
+	agg := &recordAggregate{record: pdata.NewLogRecord(), count: 1}
+	lr.CopyTo(agg.record)
+	la.recordIndex[key] = agg
+	la.records = append(la.records, agg)
+}
+
+// recordKey identifies the content of a record. Timestamps and trace context are not part of
+// it, so records only differing by when or within which span they were emitted are identical.
+func (a *aggregator) recordKey(lr pdata.LogRecord) string {
+	var b strings.Builder
+	b.WriteString(strconv.Itoa(int(lr.SeverityNumber())))
+	b.WriteByte(0)
+	b.WriteString(lr.SeverityText())
+	b.WriteByte(0)
+	b.WriteString(lr.Name())
+	b.WriteByte(0)
+	b.WriteString(lr.Body().Type().String())
+	b.WriteByte(0)
+	b.WriteString(tracetranslator.AttributeValueToString(lr.Body()))
+	b.WriteByte(0)
+	b.WriteString(attributesKey(lr.Attributes(), a.excludeAttributes))
+	return b.String()
+}
+
+// attributesKey returns a representation of attrs that does not depend on the order of the
+// attributes, leaving out the excluded keys.
+func attributesKey(attrs pdata.AttributeMap, excluded map[string]struct{}) string {
+	m := tracetranslator.AttributeMapToMap(attrs)
+	for key := range excluded {
+		delete(m, key)
+	}
+	// encoding/json sorts map keys.
+	b, _ := json.Marshal(m)
+	return string(b)
+}
@@ -0,0 +1,114 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logdeduplicationprocessor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+func appendRecord(ld pdata.Logs, service, body string, attrs map[string]pdata.AttributeValue) pdata.LogRecord {
+	var rl pdata.ResourceLogs
+	for i := 0; i < ld.ResourceLogs().Len(); i++ {
+		existing := ld.ResourceLogs().At(i)
+		if v, ok := existing.Resource().Attributes().Get("service.name"); ok && v.StringVal() == service {
+			rl = existing
+		}
+	}
+	if rl == (pdata.ResourceLogs{}) {
+		rl = ld.ResourceLogs().AppendEmpty()
+		rl.Resource().Attributes().InsertString("service.name", service)
+		rl.InstrumentationLibraryLogs().AppendEmpty().InstrumentationLibrary().SetName("lib")
+	}
+
+	lr := rl.InstrumentationLibraryLogs().At(0).Logs().AppendEmpty()
+	lr.Body().SetStringVal(body)
+	lr.SetSeverityNumber(pdata.SeverityNumberERROR)
+	pdata.NewAttributeMap().InitFromMap(attrs).CopyTo(lr.Attributes())
+	return lr
+}
+
+func TestAggregatorCountsDuplicates(t *testing.T) {
+	a := newAggregator(defaultCountAttribute, []string{"process.pid"})
+
+	for i := 0; i < 3; i++ {
+		ld := pdata.NewLogs()
+		lr := appendRecord(ld, "api", "connection refused", map[string]pdata.AttributeValue{
+			"host":        pdata.NewAttributeValueString("db"),
+			"process.pid": pdata.NewAttributeValueInt(int64(100 + i)),
+		})
+		lr.SetTimestamp(pdata.Timestamp(i + 1))
+		appendRecord(ld, "worker", "connection refused", nil)
+		a.aggregate(ld)
+	}
+
+	ld := pdata.NewLogs()
+	// Differs by an attribute value type only
+	appendRecord(ld, "api", "connection refused", map[string]pdata.AttributeValue{
+		"host": pdata.NewAttributeValueInt(1),
+	})
+	appendRecord(ld, "api", "started", nil)
+	a.aggregate(ld)
+
+	out := a.export()
+	require.Equal(t, 2, out.ResourceLogs().Len())
+
+	api := out.ResourceLogs().At(0).InstrumentationLibraryLogs().At(0)
+	assert.Equal(t, "lib", api.InstrumentationLibrary().Name())
+	require.Equal(t, 3, api.Logs().Len())
+
+	first := api.Logs().At(0)
+	assert.Equal(t, "connection refused", first.Body().StringVal())
+	assert.Equal(t, pdata.Timestamp(1), first.Timestamp())
+	count, _ := first.Attributes().Get(defaultCountAttribute)
+	assert.Equal(t, int64(3), count.IntVal())
+	pid, _ := first.Attributes().Get("process.pid")
+	assert.Equal(t, int64(100), pid.IntVal())
+
+	count, _ = api.Logs().At(1).Attributes().Get(defaultCountAttribute)
+	assert.Equal(t, int64(1), count.IntVal())
+	assert.Equal(t, "started", api.Logs().At(2).Body().StringVal())
+
+	worker := out.ResourceLogs().At(1)
+	service, _ := worker.Resource().Attributes().Get("service.name")
+	assert.Equal(t, "worker", service.StringVal())
+	count, _ = worker.InstrumentationLibraryLogs().At(0).Logs().At(0).Attributes().Get(defaultCountAttribute)
+	assert.Equal(t, int64(3), count.IntVal())
+
+	// The aggregator is reset after an export
+	assert.Equal(t, 0, a.export().ResourceLogs().Len())
+}
+
+func TestAggregatorAttributeOrder(t *testing.T) {
+	a := newAggregator(defaultCountAttribute, nil)
+
+	ld := pdata.NewLogs()
+	lr := appendRecord(ld, "api", "msg", nil)
+	lr.Attributes().InsertString("a", "1")
+	lr.Attributes().InsertString("b", "2")
+	lr = appendRecord(ld, "api", "msg", nil)
+	lr.Attributes().InsertString("b", "2")
+	lr.Attributes().InsertString("a", "1")
+	a.aggregate(ld)
+
+	out := a.export()
+	logs := out.ResourceLogs().At(0).InstrumentationLibraryLogs().At(0).Logs()
+	require.Equal(t, 1, logs.Len())
+	count, _ := logs.At(0).Attributes().Get(defaultCountAttribute)
+	assert.Equal(t, int64(2), count.IntVal())
+}
@@ -0,0 +1,52 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logdeduplicationprocessor
+
+import (
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/collector/config"
+)
+
+// Config defines the configuration options for logdeduplicationprocessor.
+type Config struct {
+	config.ProcessorSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+
+	// Interval is the aggregation window. Identical log records received during an interval
+	// are exported once, when it elapses.
+	Interval time.Duration `mapstructure:"interval"`
+
+	// CountAttribute is the attribute of the exported record holding the number of identical
+	// records it replaces.
+	CountAttribute string `mapstructure:"count_attribute"`
+
+	// ExcludeAttributes lists attribute keys that are ignored when comparing records, such as
+	// a process ID that changes every time a crash-looping container restarts.
+	ExcludeAttributes []string `mapstructure:"exclude_attributes"`
+}
+
+var _ config.Processor = (*Config)(nil)
+
+// Validate checks that the processor configuration is valid.
+func (cfg *Config) Validate() error {
+	if cfg.Interval <= 0 {
+		return errors.New("interval must be greater than zero")
+	}
+	if cfg.CountAttribute == "" {
+		return errors.New("count_attribute must not be empty")
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logdeduplicationprocessor
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.uber.org/zap"
+)
+
+type processorImp struct {
+	logger       *zap.Logger
+	interval     time.Duration
+	nextConsumer consumer.Logs
+
+	lock       sync.Mutex
+	aggregator *aggregator
+
+	shutdownCh chan struct{}
+	done       sync.WaitGroup
+}
+
+func newProcessor(logger *zap.Logger, cfg *Config, nextConsumer consumer.Logs) *processorImp {
+	return &processorImp{
+		logger:       logger,
+		interval:     cfg.Interval,
+		nextConsumer: nextConsumer,
+		aggregator:   newAggregator(cfg.CountAttribute, cfg.ExcludeAttributes),
+		shutdownCh:   make(chan struct{}),
+	}
+}
+
+// Start implements the component.Component interface.
+func (p *processorImp) Start(_ context.Context, _ component.Host) error {
+	p.done.Add(1)
+	go func() {
+		defer p.done.Done()
+
+		ticker := time.NewTicker(p.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				p.exportLogs(context.Background())
+			case <-p.shutdownCh:
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+// Shutdown implements the component.Component interface. Records aggregated so far are
+// exported before returning.
+func (p *processorImp) Shutdown(ctx context.Context) error {
+	close(p.shutdownCh)
+	p.done.Wait()
+	p.exportLogs(ctx)
+	return nil
+}
+
+// Capabilities implements the consumer interface.
+func (p *processorImp) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
+
+// ConsumeLogs aggregates the incoming records. They are exported at the end of the interval.
+func (p *processorImp) ConsumeLogs(_ context.Context, ld pdata.Logs) error {
+	p.lock.Lock()
+	p.aggregator.aggregate(ld)
+	p.lock.Unlock()
+	return nil
+}
+
+func (p *processorImp) exportLogs(ctx context.Context) {
+	p.lock.Lock()
+	ld := p.aggregator.export()
+	p.lock.Unlock()
+
+	if ld.ResourceLogs().Len() == 0 {
+		return
+	}
+	if err := p.nextConsumer.ConsumeLogs(ctx, ld); err != nil {
+		p.logger.Error("Failed to export deduplicated logs", zap.Error(err))
+	}
+}
@@ -0,0 +1,88 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logdeduplicationprocessor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.uber.org/zap"
+)
+
+func TestCreateProcessor(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	assert.Equal(t, defaultInterval, cfg.Interval)
+	assert.Equal(t, defaultCountAttribute, cfg.CountAttribute)
+
+	lp, err := factory.CreateLogsProcessor(context.Background(), component.ProcessorCreateSettings{Logger: zap.NewNop()}, cfg, consumertest.NewNop())
+	require.NoError(t, err)
+	assert.Equal(t, cfg.Interval, lp.(*processorImp).interval)
+}
+
+func TestProcessorAggregatesUntilExport(t *testing.T) {
+	sink := new(consumertest.LogsSink)
+	p := newProcessor(zap.NewNop(), &Config{Interval: time.Hour, CountAttribute: defaultCountAttribute}, sink)
+
+	for i := 0; i < 5; i++ {
+		ld := pdata.NewLogs()
+		appendRecord(ld, "api", "panic: nil pointer dereference", nil)
+		require.NoError(t, p.ConsumeLogs(context.Background(), ld))
+	}
+	assert.Empty(t, sink.AllLogs())
+
+	p.exportLogs(context.Background())
+	require.Len(t, sink.AllLogs(), 1)
+	ld := sink.AllLogs()[0]
+	require.Equal(t, 1, ld.LogRecordCount())
+	count, _ := ld.ResourceLogs().At(0).InstrumentationLibraryLogs().At(0).Logs().At(0).Attributes().Get(defaultCountAttribute)
+	assert.Equal(t, int64(5), count.IntVal())
+
+	// Nothing is exported for an empty interval
+	sink.Reset()
+	p.exportLogs(context.Background())
+	assert.Empty(t, sink.AllLogs())
+}
+
+func TestProcessorExportsOnTickAndShutdown(t *testing.T) {
+	sink := new(consumertest.LogsSink)
+	p := newProcessor(zap.NewNop(), &Config{Interval: 10 * time.Millisecond, CountAttribute: defaultCountAttribute}, sink)
+	require.NoError(t, p.Start(context.Background(), componenttest.NewNopHost()))
+
+	ld := pdata.NewLogs()
+	appendRecord(ld, "api", "tick", nil)
+	require.NoError(t, p.ConsumeLogs(context.Background(), ld))
+	require.Eventually(t, func() bool { return len(sink.AllLogs()) == 1 }, 5*time.Second, 10*time.Millisecond)
+
+	ld = pdata.NewLogs()
+	appendRecord(ld, "api", "shutdown", nil)
+	p.lock.Lock()
+	p.aggregator.aggregate(ld)
+	p.lock.Unlock()
+	require.NoError(t, p.Shutdown(context.Background()))
+
+	var bodies []string
+	for _, ld := range sink.AllLogs() {
+		bodies = append(bodies, ld.ResourceLogs().At(0).InstrumentationLibraryLogs().At(0).Logs().At(0).Body().StringVal())
+	}
+	assert.Equal(t, []string{"tick", "shutdown"}, bodies)
+}
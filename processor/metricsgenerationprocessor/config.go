@@ -39,6 +39,12 @@ const (
 
 	// operationFieldName is the mapstructure field name for Operation field
 	operationFieldName = "operation"
+
+	// expressionFieldName is the mapstructure field name for Expression field
+	expressionFieldName = "expression"
+
+	// operandsFieldName is the mapstructure field name for Operands field
+	operandsFieldName = "operands"
 )
 
 // Config defines the configuration for the processor.
@@ -56,17 +62,30 @@ type Rule struct {
 	// The rule type following which the new metric will be generated. This is a required field.
 	Type GenerationType `mapstructure:"type"`
 
-	// First operand metric to use in the calculation. This is a required field.
+	// First operand metric to use in the calculation. A required field if the type is calculate or scale.
 	Metric1 string `mapstructure:"metric1"`
 
 	// Second operand metric to use in the calculation. A required field if the type is calculate.
 	Metric2 string `mapstructure:"metric2"`
 
-	// The arithmetic operation to apply for the calculation. This is a required field.
+	// The arithmetic operation to apply for the calculation. A required field if the type is calculate.
 	Operation OperationType `mapstructure:"operation"`
 
 	// A constant number by which the first operand will be scaled. A required field if the type is scale.
 	ScaleBy float64 `mapstructure:"scale_by"`
+
+	// The arithmetic expression computing the new metric, e.g. "(a - b) / c * 100". It supports numbers,
+	// the operands declared in Operands, +, -, *, / and parentheses. A required field if the type is expression.
+	Expression string `mapstructure:"expression"`
+
+	// Operands maps the names used in Expression to the metrics they stand for. A required field if the
+	// type is expression.
+	Operands map[string]string `mapstructure:"operands"`
+
+	// MatchLabels are the labels joining the data points of the operands. Every data point of the first
+	// operand, the driving one, is combined with the data points of the other operands having the same
+	// values for these labels. When empty, data points are joined on identical label sets.
+	MatchLabels []string `mapstructure:"match_labels"`
 }
 
 type GenerationType string
@@ -78,9 +97,12 @@ const (
 
 	// Generates a new metric scaling the value of s given metric with a provided constant
 	scale GenerationType = "scale"
+
+	// Generates a new metric evaluating an arithmetic expression over any number of operands
+	expression GenerationType = "expression"
 )
 
-var generationTypes = map[GenerationType]struct{}{calculate: {}, scale: {}}
+var generationTypes = map[GenerationType]struct{}{calculate: {}, scale: {}, expression: {}}
 
 func (gt GenerationType) isValid() bool {
 	_, ok := generationTypes[gt]
@@ -158,6 +180,13 @@ func (config *Config) Validate() error {
 			return fmt.Errorf("%q must be in %q", typeFieldName, generationTypeKeys())
 		}
 
+		if rule.Type == expression {
+			if err := validateExpression(rule); err != nil {
+				return err
+			}
+			continue
+		}
+
 		if rule.Metric1 == "" {
 			return fmt.Errorf("missing required field %q", metric1FieldName)
 		}
@@ -166,6 +195,10 @@ func (config *Config) Validate() error {
 			return fmt.Errorf("missing required field %q for generation type %q", metric2FieldName, calculate)
 		}
 
+		if rule.Type == calculate && rule.Operation == "" {
+			return fmt.Errorf("missing required field %q for generation type %q", operationFieldName, calculate)
+		}
+
 		if rule.Type == scale && rule.ScaleBy <= 0 {
 			return fmt.Errorf("field %q required to be greater than 0 for generation type %q", scaleByFieldName, scale)
 		}
@@ -176,3 +209,25 @@ func (config *Config) Validate() error {
 	}
 	return nil
 }
+
+func validateExpression(rule Rule) error {
+	if rule.Expression == "" {
+		return fmt.Errorf("missing required field %q for generation type %q", expressionFieldName, expression)
+	}
+
+	_, operands, err := parseExpression(rule.Expression)
+	if err != nil {
+		return fmt.Errorf("invalid %q: %w", expressionFieldName, err)
+	}
+
+	if len(operands) == 0 {
+		return fmt.Errorf("%q must use at least one operand", expressionFieldName)
+	}
+
+	for _, operand := range operands {
+		if rule.Operands[operand] == "" {
+			return fmt.Errorf("operand %q of %q missing from %q", operand, expressionFieldName, operandsFieldName)
+		}
+	}
+	return nil
+}
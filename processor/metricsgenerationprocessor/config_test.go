@@ -50,6 +50,17 @@ func TestLoadingFullConfig(t *testing.T) {
 						ScaleBy:   1000,
 						Operation: "multiply",
 					},
+					{
+						Name:       "new_metric",
+						Type:       "expression",
+						Expression: "(a - b) / c * 100",
+						Operands: map[string]string{
+							"a": "metric1",
+							"b": "metric2",
+							"c": "metric3",
+						},
+						MatchLabels: []string{"label1"},
+					},
 				},
 			},
 		},
@@ -112,6 +123,26 @@ func TestValidateConfig(t *testing.T) {
 			succeed:      false,
 			errorMessage: fmt.Sprintf("field %q required to be greater than 0 for generation type %q", scaleByFieldName, scale),
 		},
+		{
+			configName:   "config_missing_operation.yaml",
+			succeed:      false,
+			errorMessage: fmt.Sprintf("missing required field %q for generation type %q", operationFieldName, calculate),
+		},
+		{
+			configName:   "config_missing_expression.yaml",
+			succeed:      false,
+			errorMessage: fmt.Sprintf("missing required field %q for generation type %q", expressionFieldName, expression),
+		},
+		{
+			configName:   "config_invalid_expression.yaml",
+			succeed:      false,
+			errorMessage: fmt.Sprintf("invalid %q: missing closing parenthesis at position 6", expressionFieldName),
+		},
+		{
+			configName:   "config_undeclared_operand.yaml",
+			succeed:      false,
+			errorMessage: fmt.Sprintf("operand %q of %q missing from %q", "c", expressionFieldName, operandsFieldName),
+		},
 		{
 			configName:   "config_invalid_operation.yaml",
 			succeed:      false,
@@ -0,0 +1,217 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metricsgenerationprocessor
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"unicode"
+)
+
+var errDivisionByZero = errors.New("division by zero")
+
+// arithmeticExpr is an arithmetic expression over named operands.
+type arithmeticExpr interface {
+	eval(vars map[string]float64) (float64, error)
+}
+
+type numberExpr float64
+
+func (n numberExpr) eval(map[string]float64) (float64, error) {
+	return float64(n), nil
+}
+
+type variableExpr string
+
+func (v variableExpr) eval(vars map[string]float64) (float64, error) {
+	val, ok := vars[string(v)]
+	if !ok {
+		return 0, fmt.Errorf("no value for operand %q", string(v))
+	}
+	return val, nil
+}
+
+type negateExpr struct {
+	operand arithmeticExpr
+}
+
+func (n negateExpr) eval(vars map[string]float64) (float64, error) {
+	val, err := n.operand.eval(vars)
+	return -val, err
+}
+
+type binaryExpr struct {
+	op          byte
+	left, right arithmeticExpr
+}
+
+func (b binaryExpr) eval(vars map[string]float64) (float64, error) {
+	left, err := b.left.eval(vars)
+	if err != nil {
+		return 0, err
+	}
+	right, err := b.right.eval(vars)
+	if err != nil {
+		return 0, err
+	}
+
+	switch b.op {
+	case '+':
+		return left + right, nil
+	case '-':
+		return left - right, nil
+	case '*':
+		return left * right, nil
+	case '/':
+		if right == 0 {
+			return 0, errDivisionByZero
+		}
+		return left / right, nil
+	}
+	return 0, fmt.Errorf("unknown operator %q", b.op)
+}
+
+// parseExpression parses an arithmetic expression made of numbers, operands, the
+// operators +, -, * and /, and parentheses, following the usual precedence rules.
+// It returns the expression and its operands in order of first appearance.
+func parseExpression(input string) (arithmeticExpr, []string, error) {
+	p := &expressionParser{input: input, seen: map[string]bool{}}
+	expr, err := p.parseSum()
+	if err != nil {
+		return nil, nil, err
+	}
+	p.skipSpaces()
+	if p.pos < len(p.input) {
+		return nil, nil, fmt.Errorf("unexpected %q at position %d", p.input[p.pos], p.pos)
+	}
+	return expr, p.variables, nil
+}
+
+type expressionParser struct {
+	input     string
+	pos       int
+	variables []string
+	seen      map[string]bool
+}
+
+func (p *expressionParser) skipSpaces() {
+	for p.pos < len(p.input) && unicode.IsSpace(rune(p.input[p.pos])) {
+		p.pos++
+	}
+}
+
+// parseSum parses terms separated by + and -.
+func (p *expressionParser) parseSum() (arithmeticExpr, error) {
+	left, err := p.parseProduct()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		p.skipSpaces()
+		if p.pos >= len(p.input) || (p.input[p.pos] != '+' && p.input[p.pos] != '-') {
+			return left, nil
+		}
+		op := p.input[p.pos]
+		p.pos++
+		right, err := p.parseProduct()
+		if err != nil {
+			return nil, err
+		}
+		left = binaryExpr{op: op, left: left, right: right}
+	}
+}
+
+// parseProduct parses factors separated by * and /.
+func (p *expressionParser) parseProduct() (arithmeticExpr, error) {
+	left, err := p.parseFactor()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		p.skipSpaces()
+		if p.pos >= len(p.input) || (p.input[p.pos] != '*' && p.input[p.pos] != '/') {
+			return left, nil
+		}
+		op := p.input[p.pos]
+		p.pos++
+		right, err := p.parseFactor()
+		if err != nil {
+			return nil, err
+		}
+		left = binaryExpr{op: op, left: left, right: right}
+	}
+}
+
+// parseFactor parses a number, an operand, a negated factor or a parenthesized expression.
+func (p *expressionParser) parseFactor() (arithmeticExpr, error) {
+	p.skipSpaces()
+	if p.pos >= len(p.input) {
+		return nil, errors.New("unexpected end of expression")
+	}
+
+	c := p.input[p.pos]
+	switch {
+	case c == '-':
+		p.pos++
+		operand, err := p.parseFactor()
+		if err != nil {
+			return nil, err
+		}
+		return negateExpr{operand: operand}, nil
+	case c == '(':
+		p.pos++
+		expr, err := p.parseSum()
+		if err != nil {
+			return nil, err
+		}
+		p.skipSpaces()
+		if p.pos >= len(p.input) || p.input[p.pos] != ')' {
+			return nil, fmt.Errorf("missing closing parenthesis at position %d", p.pos)
+		}
+		p.pos++
+		return expr, nil
+	case c == '.' || isDigit(c):
+		start := p.pos
+		for p.pos < len(p.input) && (p.input[p.pos] == '.' || isDigit(p.input[p.pos])) {
+			p.pos++
+		}
+		val, err := strconv.ParseFloat(p.input[start:p.pos], 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %q", p.input[start:p.pos])
+		}
+		return numberExpr(val), nil
+	case c == '_' || isLetter(c):
+		start := p.pos
+		for p.pos < len(p.input) && (p.input[p.pos] == '_' || isLetter(p.input[p.pos]) || isDigit(p.input[p.pos])) {
+			p.pos++
+		}
+		name := p.input[start:p.pos]
+		if !p.seen[name] {
+			p.seen[name] = true
+			p.variables = append(p.variables, name)
+		}
+		return variableExpr(name), nil
+	}
+	return nil, fmt.Errorf("unexpected %q at position %d", c, p.pos)
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isLetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
@@ -0,0 +1,87 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metricsgenerationprocessor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseExpression(t *testing.T) {
+	vars := map[string]float64{"a": 10, "b": 4, "c": 2, "metric_1": 3}
+
+	tests := []struct {
+		expression string
+		operands   []string
+		want       float64
+	}{
+		{expression: "a", operands: []string{"a"}, want: 10},
+		{expression: "a + b * c", operands: []string{"a", "b", "c"}, want: 18},
+		{expression: "(a - b) / c * 100", operands: []string{"a", "b", "c"}, want: 300},
+		{expression: "a - b - c", operands: []string{"a", "b", "c"}, want: 4},
+		{expression: "a / b / c", operands: []string{"a", "b", "c"}, want: 1.25},
+		{expression: "-a + -(b - c)", operands: []string{"a", "b", "c"}, want: -12},
+		{expression: "metric_1 * 0.5 + .5", operands: []string{"metric_1"}, want: 2},
+		{expression: "c / c + c", operands: []string{"c"}, want: 3},
+		{expression: " 2*(3+4) ", want: 14},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			expr, operands, err := parseExpression(tt.expression)
+			require.NoError(t, err)
+			assert.Equal(t, tt.operands, operands)
+
+			got, err := expr.eval(vars)
+			require.NoError(t, err)
+			assert.InDelta(t, tt.want, got, 1e-9)
+		})
+	}
+}
+
+func TestParseExpressionErrors(t *testing.T) {
+	tests := []struct {
+		expression string
+		err        string
+	}{
+		{expression: "", err: "unexpected end of expression"},
+		{expression: "a +", err: "unexpected end of expression"},
+		{expression: "(a + b", err: "missing closing parenthesis at position 6"},
+		{expression: "a + b)", err: "unexpected ')' at position 5"},
+		{expression: "a % b", err: "unexpected '%' at position 2"},
+		{expression: "1.2.3", err: "invalid number \"1.2.3\""},
+		{expression: "a b", err: "unexpected 'b' at position 2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			_, _, err := parseExpression(tt.expression)
+			assert.EqualError(t, err, tt.err)
+		})
+	}
+}
+
+func TestEvalErrors(t *testing.T) {
+	expr, _, err := parseExpression("a / b")
+	require.NoError(t, err)
+
+	_, err = expr.eval(map[string]float64{"a": 1, "b": 0})
+	assert.Equal(t, errDivisionByZero, err)
+
+	_, err = expr.eval(map[string]float64{"a": 1})
+	assert.EqualError(t, err, "no value for operand \"b\"")
+}
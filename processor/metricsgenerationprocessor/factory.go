@@ -56,8 +56,15 @@ func createMetricsProcessor(
 		return nil, fmt.Errorf("configuration parsing error")
 	}
 
-	processorConfig.Validate()
-	metricsProcessor := newMetricsGenerationProcessor(buildInternalConfig(processorConfig), params.Logger)
+	if err := processorConfig.Validate(); err != nil {
+		return nil, err
+	}
+
+	rules, err := buildInternalConfig(processorConfig)
+	if err != nil {
+		return nil, err
+	}
+	metricsProcessor := newMetricsGenerationProcessor(rules, params.Logger)
 
 	return processorhelper.NewMetricsProcessor(
 		cfg,
@@ -66,20 +73,56 @@ func createMetricsProcessor(
 		processorhelper.WithCapabilities(processorCapabilities))
 }
 
-// buildInternalConfig constructs the internal metric generation rules
-func buildInternalConfig(config *Config) []internalRule {
+// buildInternalConfig constructs the internal metric generation rules. The calculate and scale rules are
+// translated into the equivalent expressions.
+func buildInternalConfig(config *Config) ([]internalRule, error) {
 	internalRules := make([]internalRule, len(config.Rules))
 
 	for i, rule := range config.Rules {
 		customRule := internalRule{
-			name:      rule.Name,
-			ruleType:  string(rule.Type),
-			metric1:   rule.Metric1,
-			metric2:   rule.Metric2,
-			operation: string(rule.Operation),
-			scaleBy:   rule.ScaleBy,
+			name:        rule.Name,
+			matchLabels: rule.MatchLabels,
+		}
+
+		switch rule.Type {
+		case expression:
+			expr, operands, err := parseExpression(rule.Expression)
+			if err != nil {
+				return nil, err
+			}
+			customRule.expr = expr
+			customRule.operands = operands
+			customRule.metrics = rule.Operands
+		case calculate:
+			customRule.expr = operationExpr(rule.Operation, variableExpr(metric1FieldName), variableExpr(metric2FieldName))
+			customRule.operands = []string{metric1FieldName, metric2FieldName}
+			customRule.metrics = map[string]string{metric1FieldName: rule.Metric1, metric2FieldName: rule.Metric2}
+		case scale:
+			operation := rule.Operation
+			if operation == "" {
+				operation = multiply
+			}
+			customRule.expr = operationExpr(operation, variableExpr(metric1FieldName), numberExpr(rule.ScaleBy))
+			customRule.operands = []string{metric1FieldName}
+			customRule.metrics = map[string]string{metric1FieldName: rule.Metric1}
 		}
 		internalRules[i] = customRule
 	}
-	return internalRules
+	return internalRules, nil
+}
+
+// operationExpr returns the expression applying the operation to the operands.
+func operationExpr(operation OperationType, left, right arithmeticExpr) arithmeticExpr {
+	switch operation {
+	case add:
+		return binaryExpr{op: '+', left: left, right: right}
+	case subtract:
+		return binaryExpr{op: '-', left: left, right: right}
+	case multiply:
+		return binaryExpr{op: '*', left: left, right: right}
+	case divide:
+		return binaryExpr{op: '/', left: left, right: right}
+	}
+	// percent
+	return binaryExpr{op: '*', left: binaryExpr{op: '/', left: left, right: right}, right: numberExpr(100)}
 }
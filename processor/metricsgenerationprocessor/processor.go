@@ -16,6 +16,8 @@ package metricsgenerationprocessor
 
 import (
 	"context"
+	"sort"
+	"strings"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer/pdata"
@@ -31,12 +33,21 @@ type metricsGenerationProcessor struct {
 var _ processorhelper.MProcessor = (*metricsGenerationProcessor)(nil)
 
 type internalRule struct {
-	name      string
-	ruleType  string
-	metric1   string
-	metric2   string
-	operation string
-	scaleBy   float64
+	name string
+	expr arithmeticExpr
+	// operands of expr in order of first appearance; the first one drives the join.
+	operands []string
+	// metrics maps the operands to the metrics they stand for.
+	metrics     map[string]string
+	matchLabels []string
+}
+
+// dataPoint is a numeric data point of an operand metric.
+type dataPoint struct {
+	labels         pdata.StringMap
+	startTimestamp pdata.Timestamp
+	timestamp      pdata.Timestamp
+	value          float64
 }
 
 func newMetricsGenerationProcessor(rules []internalRule, logger *zap.Logger) *metricsGenerationProcessor {
@@ -51,11 +62,155 @@ func (mgp *metricsGenerationProcessor) Start(context.Context, component.Host) er
 	return nil
 }
 
-// ProcessMetrics implements the MProcessor interface.
+// ProcessMetrics implements the MProcessor interface. Rules are applied in order within every resource, so a
+// rule can use the metrics generated by the rules before it.
 func (mgp *metricsGenerationProcessor) ProcessMetrics(_ context.Context, md pdata.Metrics) (pdata.Metrics, error) {
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		ilms := rms.At(i).InstrumentationLibraryMetrics()
+		metrics := make(map[string]pdata.Metric)
+		libraries := make(map[string]pdata.MetricSlice)
+		for j := 0; j < ilms.Len(); j++ {
+			ms := ilms.At(j).Metrics()
+			for k := 0; k < ms.Len(); k++ {
+				metrics[ms.At(k).Name()] = ms.At(k)
+				libraries[ms.At(k).Name()] = ms
+			}
+		}
+
+		for _, rule := range mgp.rules {
+			generated, ok := mgp.generateMetric(rule, metrics)
+			if !ok {
+				continue
+			}
+			// The new metric belongs to the instrumentation library of the driving operand.
+			ms := libraries[rule.metrics[rule.operands[0]]]
+			ms.Append(generated)
+			metrics[rule.name] = ms.At(ms.Len() - 1)
+			libraries[rule.name] = ms
+		}
+	}
 	return md, nil
 }
 
+// generateMetric evaluates the rule for every data point of its driving operand that has a matching data point in
+// each of the other operands. It returns false when no data point could be generated.
+func (mgp *metricsGenerationProcessor) generateMetric(rule internalRule, metrics map[string]pdata.Metric) (pdata.Metric, bool) {
+	dataPoints := make(map[string][]dataPoint, len(rule.operands))
+	for _, operand := range rule.operands {
+		metric, ok := metrics[rule.metrics[operand]]
+		if !ok {
+			return pdata.Metric{}, false
+		}
+		dataPoints[operand] = getDataPoints(metric)
+	}
+
+	joined := make(map[string]map[string]float64, len(rule.operands)-1)
+	for _, operand := range rule.operands[1:] {
+		values := make(map[string]float64, len(dataPoints[operand]))
+		for _, dp := range dataPoints[operand] {
+			values[joinKey(dp.labels, rule.matchLabels)] = dp.value
+		}
+		joined[operand] = values
+	}
+
+	generated := pdata.NewMetric()
+	generated.SetName(rule.name)
+	generated.SetDataType(pdata.MetricDataTypeDoubleGauge)
+	dps := generated.DoubleGauge().DataPoints()
+
+	driving := rule.operands[0]
+	for _, dp := range dataPoints[driving] {
+		key := joinKey(dp.labels, rule.matchLabels)
+		vars := map[string]float64{driving: dp.value}
+		for operand, values := range joined {
+			if value, ok := values[key]; ok {
+				vars[operand] = value
+			}
+		}
+
+		value, err := rule.expr.eval(vars)
+		if err != nil {
+			mgp.logger.Debug("failed to generate data point",
+				zap.String("metric", rule.name),
+				zap.Error(err),
+			)
+			continue
+		}
+
+		ndp := dps.AppendEmpty()
+		dp.labels.CopyTo(ndp.LabelsMap())
+		ndp.SetStartTimestamp(dp.startTimestamp)
+		ndp.SetTimestamp(dp.timestamp)
+		ndp.SetValue(value)
+	}
+
+	return generated, dps.Len() > 0
+}
+
+// getDataPoints returns the data points of gauge and sum metrics.
+func getDataPoints(metric pdata.Metric) []dataPoint {
+	var out []dataPoint
+	switch metric.DataType() {
+	case pdata.MetricDataTypeIntGauge:
+		out = intDataPoints(metric.IntGauge().DataPoints())
+	case pdata.MetricDataTypeIntSum:
+		out = intDataPoints(metric.IntSum().DataPoints())
+	case pdata.MetricDataTypeDoubleGauge:
+		out = doubleDataPoints(metric.DoubleGauge().DataPoints())
+	case pdata.MetricDataTypeDoubleSum:
+		out = doubleDataPoints(metric.DoubleSum().DataPoints())
+	}
+	return out
+}
+
+func intDataPoints(dps pdata.IntDataPointSlice) []dataPoint {
+	out := make([]dataPoint, dps.Len())
+	for i := 0; i < dps.Len(); i++ {
+		dp := dps.At(i)
+		out[i] = dataPoint{
+			labels:         dp.LabelsMap(),
+			startTimestamp: dp.StartTimestamp(),
+			timestamp:      dp.Timestamp(),
+			value:          float64(dp.Value()),
+		}
+	}
+	return out
+}
+
+func doubleDataPoints(dps pdata.DoubleDataPointSlice) []dataPoint {
+	out := make([]dataPoint, dps.Len())
+	for i := 0; i < dps.Len(); i++ {
+		dp := dps.At(i)
+		out[i] = dataPoint{
+			labels:         dp.LabelsMap(),
+			startTimestamp: dp.StartTimestamp(),
+			timestamp:      dp.Timestamp(),
+			value:          dp.Value(),
+		}
+	}
+	return out
+}
+
+// joinKey identifies the data points to combine: by the values of matchLabels when set, by the full label set
+// otherwise.
+func joinKey(labels pdata.StringMap, matchLabels []string) string {
+	var parts []string
+	if len(matchLabels) == 0 {
+		labels.Range(func(k string, v string) bool {
+			parts = append(parts, k+"="+v)
+			return true
+		})
+		sort.Strings(parts)
+	} else {
+		for _, label := range matchLabels {
+			v, _ := labels.Get(label)
+			parts = append(parts, label+"="+v)
+		}
+	}
+	return strings.Join(parts, "\x00")
+}
+
 // Shutdown is invoked during service shutdown.
 func (mgp *metricsGenerationProcessor) Shutdown(context.Context) error {
 	return nil
@@ -0,0 +1,283 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metricsgenerationprocessor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.uber.org/zap"
+)
+
+type testDataPoint struct {
+	labels map[string]string
+	value  float64
+}
+
+type testMetric struct {
+	name       string
+	dataType   pdata.MetricDataType
+	dataPoints []testDataPoint
+}
+
+func TestMetricsGenerationProcessor(t *testing.T) {
+	nodeLimits := testMetric{
+		name:     "node.cpu.limit",
+		dataType: pdata.MetricDataTypeIntGauge,
+		dataPoints: []testDataPoint{
+			{labels: map[string]string{"node": "n1"}, value: 4},
+			{labels: map[string]string{"node": "n2"}, value: 0},
+		},
+	}
+	podUsage := testMetric{
+		name:     "pod.cpu.usage",
+		dataType: pdata.MetricDataTypeDoubleGauge,
+		dataPoints: []testDataPoint{
+			{labels: map[string]string{"node": "n1", "pod": "p1"}, value: 1},
+			{labels: map[string]string{"node": "n1", "pod": "p2"}, value: 2},
+			{labels: map[string]string{"node": "n2", "pod": "p3"}, value: 3},
+			{labels: map[string]string{"node": "n3", "pod": "p4"}, value: 1},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		rules    []Rule
+		input    []testMetric
+		expected []testMetric
+	}{
+		{
+			name: "calculate joins identical label sets",
+			rules: []Rule{
+				{
+					Name:      "disk.utilization",
+					Type:      calculate,
+					Metric1:   "disk.used",
+					Metric2:   "disk.total",
+					Operation: percent,
+				},
+			},
+			input: []testMetric{
+				{
+					name:     "disk.used",
+					dataType: pdata.MetricDataTypeIntSum,
+					dataPoints: []testDataPoint{
+						{labels: map[string]string{"device": "sda"}, value: 25},
+						{labels: map[string]string{"device": "sdb"}, value: 10},
+					},
+				},
+				{
+					name:     "disk.total",
+					dataType: pdata.MetricDataTypeDoubleSum,
+					dataPoints: []testDataPoint{
+						{labels: map[string]string{"device": "sda"}, value: 100},
+					},
+				},
+			},
+			expected: []testMetric{
+				{
+					name:     "disk.utilization",
+					dataType: pdata.MetricDataTypeDoubleGauge,
+					dataPoints: []testDataPoint{
+						{labels: map[string]string{"device": "sda"}, value: 25},
+					},
+				},
+			},
+		},
+		{
+			name: "scale",
+			rules: []Rule{
+				{
+					Name:      "pod.memory.usage.bytes",
+					Type:      scale,
+					Metric1:   "pod.memory.usage.megabytes",
+					Operation: multiply,
+					ScaleBy:   1048576,
+				},
+			},
+			input: []testMetric{
+				{
+					name:     "pod.memory.usage.megabytes",
+					dataType: pdata.MetricDataTypeIntGauge,
+					dataPoints: []testDataPoint{
+						{labels: map[string]string{"pod": "p1"}, value: 2},
+					},
+				},
+			},
+			expected: []testMetric{
+				{
+					name:     "pod.memory.usage.bytes",
+					dataType: pdata.MetricDataTypeDoubleGauge,
+					dataPoints: []testDataPoint{
+						{labels: map[string]string{"pod": "p1"}, value: 2097152},
+					},
+				},
+			},
+		},
+		{
+			name: "expression joins on match labels and skips failed evaluations",
+			rules: []Rule{
+				{
+					Name:        "pod.cpu.utilization",
+					Type:        expression,
+					Expression:  "usage / limit * 100",
+					Operands:    map[string]string{"usage": "pod.cpu.usage", "limit": "node.cpu.limit"},
+					MatchLabels: []string{"node"},
+				},
+			},
+			input: []testMetric{nodeLimits, podUsage},
+			expected: []testMetric{
+				{
+					name:     "pod.cpu.utilization",
+					dataType: pdata.MetricDataTypeDoubleGauge,
+					dataPoints: []testDataPoint{
+						{labels: map[string]string{"node": "n1", "pod": "p1"}, value: 25},
+						{labels: map[string]string{"node": "n1", "pod": "p2"}, value: 50},
+					},
+				},
+			},
+		},
+		{
+			name: "rules use metrics generated by previous rules",
+			rules: []Rule{
+				{
+					Name:       "pod.cpu.usage.millicores",
+					Type:       expression,
+					Expression: "usage * 1000",
+					Operands:   map[string]string{"usage": "pod.cpu.usage"},
+				},
+				{
+					Name:       "pod.cpu.usage.doubled",
+					Type:       expression,
+					Expression: "(millicores + millicores) / 1000",
+					Operands:   map[string]string{"millicores": "pod.cpu.usage.millicores"},
+				},
+			},
+			input: []testMetric{
+				{
+					name:     "pod.cpu.usage",
+					dataType: pdata.MetricDataTypeDoubleGauge,
+					dataPoints: []testDataPoint{
+						{labels: map[string]string{"pod": "p1"}, value: 1.5},
+					},
+				},
+			},
+			expected: []testMetric{
+				{
+					name:     "pod.cpu.usage.millicores",
+					dataType: pdata.MetricDataTypeDoubleGauge,
+					dataPoints: []testDataPoint{
+						{labels: map[string]string{"pod": "p1"}, value: 1500},
+					},
+				},
+				{
+					name:     "pod.cpu.usage.doubled",
+					dataType: pdata.MetricDataTypeDoubleGauge,
+					dataPoints: []testDataPoint{
+						{labels: map[string]string{"pod": "p1"}, value: 3},
+					},
+				},
+			},
+		},
+		{
+			name: "missing operand metric",
+			rules: []Rule{
+				{
+					Name:       "pod.cpu.utilization",
+					Type:       expression,
+					Expression: "usage / limit",
+					Operands:   map[string]string{"usage": "pod.cpu.usage", "limit": "pod.cpu.limit"},
+				},
+			},
+			input: []testMetric{podUsage},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{Rules: tt.rules}
+			require.NoError(t, cfg.Validate())
+
+			next := new(consumertest.MetricsSink)
+			factory := NewFactory()
+			mp, err := factory.CreateMetricsProcessor(
+				context.Background(),
+				component.ProcessorCreateSettings{Logger: zap.NewNop()},
+				cfg,
+				next,
+			)
+			require.NoError(t, err)
+
+			require.NoError(t, mp.ConsumeMetrics(context.Background(), generateMetrics(tt.input)))
+
+			metrics := next.AllMetrics()[0].ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics()
+			require.Equal(t, len(tt.input)+len(tt.expected), metrics.Len())
+			for i, expected := range tt.expected {
+				metric := metrics.At(len(tt.input) + i)
+				assert.Equal(t, expected.name, metric.Name())
+				assert.Equal(t, expected.dataType, metric.DataType())
+
+				dps := metric.DoubleGauge().DataPoints()
+				require.Equal(t, len(expected.dataPoints), dps.Len())
+				for j, expectedDataPoint := range expected.dataPoints {
+					dp := dps.At(j)
+					labels := map[string]string{}
+					dp.LabelsMap().Range(func(k string, v string) bool {
+						labels[k] = v
+						return true
+					})
+					assert.Equal(t, expectedDataPoint.labels, labels)
+					assert.InDelta(t, expectedDataPoint.value, dp.Value(), 1e-9)
+				}
+			}
+		})
+	}
+}
+
+func generateMetrics(metrics []testMetric) pdata.Metrics {
+	md := pdata.NewMetrics()
+	ms := md.ResourceMetrics().AppendEmpty().InstrumentationLibraryMetrics().AppendEmpty().Metrics()
+	for _, tm := range metrics {
+		m := ms.AppendEmpty()
+		m.SetName(tm.name)
+		m.SetDataType(tm.dataType)
+		for _, tdp := range tm.dataPoints {
+			switch tm.dataType {
+			case pdata.MetricDataTypeIntGauge:
+				dp := m.IntGauge().DataPoints().AppendEmpty()
+				dp.LabelsMap().InitFromMap(tdp.labels)
+				dp.SetValue(int64(tdp.value))
+			case pdata.MetricDataTypeIntSum:
+				dp := m.IntSum().DataPoints().AppendEmpty()
+				dp.LabelsMap().InitFromMap(tdp.labels)
+				dp.SetValue(int64(tdp.value))
+			case pdata.MetricDataTypeDoubleGauge:
+				dp := m.DoubleGauge().DataPoints().AppendEmpty()
+				dp.LabelsMap().InitFromMap(tdp.labels)
+				dp.SetValue(tdp.value)
+			case pdata.MetricDataTypeDoubleSum:
+				dp := m.DoubleSum().DataPoints().AppendEmpty()
+				dp.LabelsMap().InitFromMap(tdp.labels)
+				dp.SetValue(tdp.value)
+			}
+		}
+	}
+	return md
+}
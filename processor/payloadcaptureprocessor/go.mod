@@ -0,0 +1,11 @@
+module github.com/open-telemetry/opentelemetry-collector-contrib/processor/payloadcaptureprocessor
+
+go 1.16
+
+require (
+	github.com/open-telemetry/opentelemetry-collector-contrib/extension/payloadcaptureextension v0.0.0-00010101000000-000000000000
+	github.com/stretchr/testify v1.7.0
+	go.opentelemetry.io/collector v0.27.1-0.20210603182316-5369d7e9e83e
+)
+
+replace github.com/open-telemetry/opentelemetry-collector-contrib/extension/payloadcaptureextension => ../../extension/payloadcaptureextension
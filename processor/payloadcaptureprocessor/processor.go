@@ -0,0 +1,81 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package payloadcaptureprocessor
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer/pdata"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/payloadcaptureextension"
+)
+
+// payloadCaptureProcessor records the batches flowing through it into the
+// payloadcapture extension and passes them through unmodified.
+type payloadCaptureProcessor struct {
+	config   *Config
+	pipeline string
+	capturer payloadcaptureextension.Capturer
+}
+
+func newPayloadCaptureProcessor(cfg *Config) *payloadCaptureProcessor {
+	pipeline := cfg.Pipeline
+	if pipeline == "" {
+		pipeline = cfg.ID().String()
+	}
+	return &payloadCaptureProcessor{
+		config:   cfg,
+		pipeline: pipeline,
+	}
+}
+
+// Start looks up the extension the batches are recorded into.
+func (p *payloadCaptureProcessor) Start(_ context.Context, host component.Host) error {
+	id, err := config.NewIDFromString(p.config.Extension)
+	if err != nil {
+		return err
+	}
+	ext, ok := host.GetExtensions()[id]
+	if !ok {
+		return fmt.Errorf("extension %q not found", id)
+	}
+	capturer, ok := ext.(payloadcaptureextension.Capturer)
+	if !ok {
+		return fmt.Errorf("extension %q is not a payloadcapture extension", id)
+	}
+	p.capturer = capturer
+	return nil
+}
+
+// ProcessTraces records the traces and passes them through unmodified.
+func (p *payloadCaptureProcessor) ProcessTraces(_ context.Context, td pdata.Traces) (pdata.Traces, error) {
+	p.capturer.CaptureTraces(p.pipeline, td)
+	return td, nil
+}
+
+// ProcessMetrics records the metrics and passes them through unmodified.
+func (p *payloadCaptureProcessor) ProcessMetrics(_ context.Context, md pdata.Metrics) (pdata.Metrics, error) {
+	p.capturer.CaptureMetrics(p.pipeline, md)
+	return md, nil
+}
+
+// ProcessLogs records the logs and passes them through unmodified.
+func (p *payloadCaptureProcessor) ProcessLogs(_ context.Context, ld pdata.Logs) (pdata.Logs, error) {
+	p.capturer.CaptureLogs(p.pipeline, ld)
+	return ld, nil
+}
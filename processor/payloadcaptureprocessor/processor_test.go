@@ -0,0 +1,119 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package payloadcaptureprocessor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenthelper"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+type capturedBatch struct {
+	pipeline string
+	signal   string
+}
+
+type fakeCapturer struct {
+	component.Component
+	batches []capturedBatch
+}
+
+func (c *fakeCapturer) CaptureTraces(pipeline string, _ pdata.Traces) {
+	c.batches = append(c.batches, capturedBatch{pipeline, "traces"})
+}
+
+func (c *fakeCapturer) CaptureMetrics(pipeline string, _ pdata.Metrics) {
+	c.batches = append(c.batches, capturedBatch{pipeline, "metrics"})
+}
+
+func (c *fakeCapturer) CaptureLogs(pipeline string, _ pdata.Logs) {
+	c.batches = append(c.batches, capturedBatch{pipeline, "logs"})
+}
+
+type extensionsHost struct {
+	component.Host
+	extensions map[config.ComponentID]component.Extension
+}
+
+func (h *extensionsHost) GetExtensions() map[config.ComponentID]component.Extension {
+	return h.extensions
+}
+
+func newHost(id config.ComponentID, ext component.Extension) component.Host {
+	return &extensionsHost{
+		Host:       componenttest.NewNopHost(),
+		extensions: map[config.ComponentID]component.Extension{id: ext},
+	}
+}
+
+func TestProcessorCapturesBatches(t *testing.T) {
+	capturer := &fakeCapturer{Component: componenthelper.New()}
+	host := newHost(config.NewID("payloadcapture"), capturer)
+
+	factory := NewFactory()
+	params := component.ProcessorCreateSettings{}
+
+	tracesCfg := factory.CreateDefaultConfig()
+	tracesSink := new(consumertest.TracesSink)
+	tp, err := factory.CreateTracesProcessor(context.Background(), params, tracesCfg, tracesSink)
+	require.NoError(t, err)
+	require.NoError(t, tp.Start(context.Background(), host))
+
+	metricsCfg := factory.CreateDefaultConfig().(*Config)
+	metricsCfg.Pipeline = "metrics-ingest"
+	metricsSink := new(consumertest.MetricsSink)
+	mp, err := factory.CreateMetricsProcessor(context.Background(), params, metricsCfg, metricsSink)
+	require.NoError(t, err)
+	require.NoError(t, mp.Start(context.Background(), host))
+
+	logsSink := new(consumertest.LogsSink)
+	lp, err := factory.CreateLogsProcessor(context.Background(), params, factory.CreateDefaultConfig(), logsSink)
+	require.NoError(t, err)
+	require.NoError(t, lp.Start(context.Background(), host))
+
+	require.NoError(t, tp.ConsumeTraces(context.Background(), pdata.NewTraces()))
+	require.NoError(t, mp.ConsumeMetrics(context.Background(), pdata.NewMetrics()))
+	require.NoError(t, lp.ConsumeLogs(context.Background(), pdata.NewLogs()))
+
+	assert.Len(t, tracesSink.AllTraces(), 1)
+	assert.Len(t, metricsSink.AllMetrics(), 1)
+	assert.Len(t, logsSink.AllLogs(), 1)
+	assert.Equal(t, []capturedBatch{
+		{"payloadcapture", "traces"},
+		{"metrics-ingest", "metrics"},
+		{"payloadcapture", "logs"},
+	}, capturer.batches)
+}
+
+func TestProcessorStartFails(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	tp, err := factory.CreateTracesProcessor(context.Background(), component.ProcessorCreateSettings{}, cfg, consumertest.NewNop())
+	require.NoError(t, err)
+
+	err = tp.Start(context.Background(), componenttest.NewNopHost())
+	assert.EqualError(t, err, `extension "payloadcapture" not found`)
+
+	err = tp.Start(context.Background(), newHost(config.NewID("payloadcapture"), componenthelper.New()))
+	assert.EqualError(t, err, `extension "payloadcapture" is not a payloadcapture extension`)
+}
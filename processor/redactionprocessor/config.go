@@ -0,0 +1,64 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redactionprocessor
+
+import (
+	"fmt"
+	"regexp"
+
+	"go.opentelemetry.io/collector/config"
+)
+
+const (
+	// summaryDebug adds the redacted and masked keys along with their count.
+	summaryDebug = "debug"
+	// summaryInfo only adds the count of redacted and masked values.
+	summaryInfo = "info"
+	// summarySilent doesn't add any summary attribute.
+	summarySilent = "silent"
+)
+
+// Config defines the configuration options for redactionprocessor.
+type Config struct {
+	config.ProcessorSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+
+	// AllowedKeys is the list of attribute keys allowed through. When set, the processor runs in
+	// allowed-keys mode and removes every attribute whose key isn't listed. All keys are allowed if empty.
+	AllowedKeys []string `mapstructure:"allowed_keys"`
+
+	// BlockedValues is the list of regular expressions matched against the attribute values.
+	// The matching values are masked.
+	BlockedValues []string `mapstructure:"blocked_values"`
+
+	// Summary controls the verbosity of the summary attributes added to the redacted spans,
+	// log records and data points: debug, info or silent. Default is info.
+	Summary string `mapstructure:"summary"`
+}
+
+// Validate checks that the blocked values are valid regular expressions and that the summary level is known.
+func (cfg *Config) Validate() error {
+	for _, pattern := range cfg.BlockedValues {
+		if _, err := regexp.Compile(pattern); err != nil {
+			return fmt.Errorf("invalid blocked_values pattern %q: %w", pattern, err)
+		}
+	}
+
+	switch cfg.Summary {
+	case summaryDebug, summaryInfo, summarySilent:
+	default:
+		return fmt.Errorf("invalid summary %q, must be one of %s, %s or %s", cfg.Summary, summaryDebug, summaryInfo, summarySilent)
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redactionprocessor
+
+import (
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configtest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	require.NoError(t, err)
+
+	factories.Processors[typeStr] = NewFactory()
+
+	cfg, err := configtest.LoadConfigAndValidate(path.Join(".", "testdata", "config.yaml"), factories)
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	assert.Equal(t,
+		&Config{
+			ProcessorSettings: config.NewProcessorSettings(config.NewID(typeStr)),
+			AllowedKeys:       []string{"description", "group", "id", "name"},
+			BlockedValues: []string{
+				"4[0-9]{12}(?:[0-9]{3})?",
+				"(5[1-5][0-9]{14})",
+				`[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}`,
+				`(?i)bearer\s+[a-z0-9._~+/=-]+`,
+			},
+			Summary: summaryDebug,
+		},
+		cfg.Processors[config.NewID(typeStr)],
+	)
+	assert.Equal(t, createDefaultConfig().(*Config).Summary, cfg.Processors[config.NewIDWithName(typeStr, "defaults")].(*Config).Summary)
+}
+
+func TestValidateConfig(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	assert.NoError(t, cfg.Validate())
+
+	cfg.BlockedValues = []string{"("}
+	assert.Error(t, cfg.Validate())
+
+	cfg.BlockedValues = nil
+	cfg.Summary = "verbose"
+	assert.EqualError(t, cfg.Validate(), `invalid summary "verbose", must be one of debug, info or silent`)
+}
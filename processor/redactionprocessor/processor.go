@@ -0,0 +1,276 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redactionprocessor
+
+import (
+	"context"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.uber.org/zap"
+)
+
+const (
+	// maskedValue replaces the attribute values matching one of the blocked values.
+	maskedValue = "****"
+
+	redactedKeys  = "redaction.redacted.keys"
+	redactedCount = "redaction.redacted.count"
+	maskedKeys    = "redaction.masked.keys"
+	maskedCount   = "redaction.masked.count"
+)
+
+type redaction struct {
+	logger *zap.Logger
+
+	// allowList is nil when all keys are allowed.
+	allowList      map[string]struct{}
+	blockRegexList []*regexp.Regexp
+	summary        string
+}
+
+// summary holds the keys redacted and masked in a single collection of attributes.
+type summary struct {
+	redacted []string
+	masked   []string
+}
+
+// newRedaction builds the redaction processor. The configuration is expected to be validated already.
+func newRedaction(cfg *Config, logger *zap.Logger) *redaction {
+	var allowList map[string]struct{}
+	if len(cfg.AllowedKeys) > 0 {
+		allowList = make(map[string]struct{}, len(cfg.AllowedKeys))
+		for _, key := range cfg.AllowedKeys {
+			allowList[key] = struct{}{}
+		}
+	}
+
+	blockRegexList := make([]*regexp.Regexp, 0, len(cfg.BlockedValues))
+	for _, pattern := range cfg.BlockedValues {
+		blockRegexList = append(blockRegexList, regexp.MustCompile(pattern))
+	}
+
+	return &redaction{
+		logger:         logger,
+		allowList:      allowList,
+		blockRegexList: blockRegexList,
+		summary:        cfg.Summary,
+	}
+}
+
+// ProcessTraces redacts the span attributes.
+func (s *redaction) ProcessTraces(_ context.Context, td pdata.Traces) (pdata.Traces, error) {
+	var total summaryCount
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		ilss := rss.At(i).InstrumentationLibrarySpans()
+		for j := 0; j < ilss.Len(); j++ {
+			spans := ilss.At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				total.add(s.processAttrs(spans.At(k).Attributes()))
+			}
+		}
+	}
+	s.logBatch("spans", total)
+	return td, nil
+}
+
+// ProcessLogs redacts the log record attributes.
+func (s *redaction) ProcessLogs(_ context.Context, ld pdata.Logs) (pdata.Logs, error) {
+	var total summaryCount
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		ills := rls.At(i).InstrumentationLibraryLogs()
+		for j := 0; j < ills.Len(); j++ {
+			logs := ills.At(j).Logs()
+			for k := 0; k < logs.Len(); k++ {
+				total.add(s.processAttrs(logs.At(k).Attributes()))
+			}
+		}
+	}
+	s.logBatch("log records", total)
+	return ld, nil
+}
+
+// ProcessMetrics redacts the data point labels.
+func (s *redaction) ProcessMetrics(_ context.Context, md pdata.Metrics) (pdata.Metrics, error) {
+	var total summaryCount
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		ilms := rms.At(i).InstrumentationLibraryMetrics()
+		for j := 0; j < ilms.Len(); j++ {
+			metrics := ilms.At(j).Metrics()
+			for k := 0; k < metrics.Len(); k++ {
+				forEachLabelsMap(metrics.At(k), func(labels pdata.StringMap) {
+					total.add(s.processLabels(labels))
+				})
+			}
+		}
+	}
+	s.logBatch("data points", total)
+	return md, nil
+}
+
+func forEachLabelsMap(metric pdata.Metric, f func(labels pdata.StringMap)) {
+	switch metric.DataType() {
+	case pdata.MetricDataTypeIntGauge:
+		dps := metric.IntGauge().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			f(dps.At(i).LabelsMap())
+		}
+	case pdata.MetricDataTypeDoubleGauge:
+		dps := metric.DoubleGauge().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			f(dps.At(i).LabelsMap())
+		}
+	case pdata.MetricDataTypeIntSum:
+		dps := metric.IntSum().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			f(dps.At(i).LabelsMap())
+		}
+	case pdata.MetricDataTypeDoubleSum:
+		dps := metric.DoubleSum().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			f(dps.At(i).LabelsMap())
+		}
+	case pdata.MetricDataTypeIntHistogram:
+		dps := metric.IntHistogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			f(dps.At(i).LabelsMap())
+		}
+	case pdata.MetricDataTypeHistogram:
+		dps := metric.Histogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			f(dps.At(i).LabelsMap())
+		}
+	case pdata.MetricDataTypeSummary:
+		dps := metric.Summary().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			f(dps.At(i).LabelsMap())
+		}
+	}
+}
+
+// processAttrs removes the attributes which aren't allowed, masks the values matching a blocked value
+// and adds the summary attributes.
+func (s *redaction) processAttrs(attrs pdata.AttributeMap) summary {
+	var sum summary
+	attrs.Range(func(k string, v pdata.AttributeValue) bool {
+		if !s.allowed(k) {
+			sum.redacted = append(sum.redacted, k)
+		} else if v.Type() == pdata.AttributeValueTypeString && s.blocked(v.StringVal()) {
+			sum.masked = append(sum.masked, k)
+		}
+		return true
+	})
+
+	for _, k := range sum.redacted {
+		attrs.Delete(k)
+	}
+	for _, k := range sum.masked {
+		attrs.UpdateString(k, maskedValue)
+	}
+
+	s.addSummary(sum, attrs.UpsertString, func(k string, v int) { attrs.UpsertInt(k, int64(v)) })
+	return sum
+}
+
+// processLabels is the equivalent of processAttrs for the data point labels.
+func (s *redaction) processLabels(labels pdata.StringMap) summary {
+	var sum summary
+	labels.Range(func(k string, v string) bool {
+		if !s.allowed(k) {
+			sum.redacted = append(sum.redacted, k)
+		} else if s.blocked(v) {
+			sum.masked = append(sum.masked, k)
+		}
+		return true
+	})
+
+	for _, k := range sum.redacted {
+		labels.Delete(k)
+	}
+	for _, k := range sum.masked {
+		labels.Update(k, maskedValue)
+	}
+
+	s.addSummary(sum, labels.Upsert, func(k string, v int) { labels.Upsert(k, strconv.Itoa(v)) })
+	return sum
+}
+
+func (s *redaction) allowed(key string) bool {
+	if s.allowList == nil {
+		return true
+	}
+	_, ok := s.allowList[key]
+	return ok
+}
+
+func (s *redaction) blocked(value string) bool {
+	for _, re := range s.blockRegexList {
+		if re.MatchString(value) {
+			return true
+		}
+	}
+	return false
+}
+
+// addSummary records the redacted and masked values according to the configured summary level.
+func (s *redaction) addSummary(sum summary, setString func(k, v string), setInt func(k string, v int)) {
+	if s.summary == summarySilent {
+		return
+	}
+
+	if len(sum.redacted) > 0 {
+		setInt(redactedCount, len(sum.redacted))
+		if s.summary == summaryDebug {
+			setString(redactedKeys, joinSorted(sum.redacted))
+		}
+	}
+	if len(sum.masked) > 0 {
+		setInt(maskedCount, len(sum.masked))
+		if s.summary == summaryDebug {
+			setString(maskedKeys, joinSorted(sum.masked))
+		}
+	}
+}
+
+func (s *redaction) logBatch(items string, total summaryCount) {
+	if total.redacted == 0 && total.masked == 0 {
+		return
+	}
+	s.logger.Debug("Redacted "+items,
+		zap.Int("redacted", total.redacted),
+		zap.Int("masked", total.masked))
+}
+
+// summaryCount holds the number of values redacted and masked in a batch.
+type summaryCount struct {
+	redacted int
+	masked   int
+}
+
+func (c *summaryCount) add(sum summary) {
+	c.redacted += len(sum.redacted)
+	c.masked += len(sum.masked)
+}
+
+func joinSorted(keys []string) string {
+	sort.Strings(keys)
+	return strings.Join(keys, ",")
+}
@@ -0,0 +1,157 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redactionprocessor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.uber.org/zap"
+)
+
+func newTestRedaction(allowedKeys []string, summary string) *redaction {
+	cfg := &Config{
+		ProcessorSettings: config.NewProcessorSettings(config.NewID(typeStr)),
+		AllowedKeys:       allowedKeys,
+		BlockedValues:     []string{"4[0-9]{12}(?:[0-9]{3})?", `[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}`},
+		Summary:           summary,
+	}
+	return newRedaction(cfg, zap.NewNop())
+}
+
+func TestProcessTraces(t *testing.T) {
+	tests := []struct {
+		name        string
+		allowedKeys []string
+		summary     string
+		want        map[string]pdata.AttributeValue
+	}{
+		{
+			name:    "mask values",
+			summary: summaryInfo,
+			want: map[string]pdata.AttributeValue{
+				"name":                   pdata.NewAttributeValueString("checkout"),
+				"card":                   pdata.NewAttributeValueString(maskedValue),
+				"email":                  pdata.NewAttributeValueString(maskedValue),
+				"secret":                 pdata.NewAttributeValueString("hunter2"),
+				"retries":                pdata.NewAttributeValueInt(4111111111111),
+				"redaction.masked.count": pdata.NewAttributeValueInt(2),
+			},
+		},
+		{
+			name:        "allowed keys",
+			allowedKeys: []string{"name", "card", "retries"},
+			summary:     summaryDebug,
+			want: map[string]pdata.AttributeValue{
+				"name":                     pdata.NewAttributeValueString("checkout"),
+				"card":                     pdata.NewAttributeValueString(maskedValue),
+				"retries":                  pdata.NewAttributeValueInt(4111111111111),
+				"redaction.masked.count":   pdata.NewAttributeValueInt(1),
+				"redaction.masked.keys":    pdata.NewAttributeValueString("card"),
+				"redaction.redacted.count": pdata.NewAttributeValueInt(2),
+				"redaction.redacted.keys":  pdata.NewAttributeValueString("email,secret"),
+			},
+		},
+		{
+			name:        "silent",
+			allowedKeys: []string{"name", "card", "retries"},
+			summary:     summarySilent,
+			want: map[string]pdata.AttributeValue{
+				"name":    pdata.NewAttributeValueString("checkout"),
+				"card":    pdata.NewAttributeValueString(maskedValue),
+				"retries": pdata.NewAttributeValueInt(4111111111111),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			td := pdata.NewTraces()
+			span := td.ResourceSpans().AppendEmpty().InstrumentationLibrarySpans().AppendEmpty().Spans().AppendEmpty()
+			span.Attributes().InitFromMap(map[string]pdata.AttributeValue{
+				"name":    pdata.NewAttributeValueString("checkout"),
+				"card":    pdata.NewAttributeValueString("4111111111111111"),
+				"email":   pdata.NewAttributeValueString("john.doe@example.com"),
+				"secret":  pdata.NewAttributeValueString("hunter2"),
+				"retries": pdata.NewAttributeValueInt(4111111111111),
+			})
+
+			processed, err := newTestRedaction(tt.allowedKeys, tt.summary).ProcessTraces(context.Background(), td)
+			require.NoError(t, err)
+
+			got := processed.ResourceSpans().At(0).InstrumentationLibrarySpans().At(0).Spans().At(0).Attributes()
+			assert.Equal(t, pdata.NewAttributeMap().InitFromMap(tt.want).Sort(), got.Sort())
+		})
+	}
+}
+
+func TestProcessLogs(t *testing.T) {
+	ld := pdata.NewLogs()
+	lr := ld.ResourceLogs().AppendEmpty().InstrumentationLibraryLogs().AppendEmpty().Logs().AppendEmpty()
+	lr.Attributes().InsertString("user", "john.doe@example.com")
+
+	processed, err := newTestRedaction(nil, summaryInfo).ProcessLogs(context.Background(), ld)
+	require.NoError(t, err)
+
+	attrs := processed.ResourceLogs().At(0).InstrumentationLibraryLogs().At(0).Logs().At(0).Attributes()
+	user, ok := attrs.Get("user")
+	require.True(t, ok)
+	assert.Equal(t, maskedValue, user.StringVal())
+	count, ok := attrs.Get(maskedCount)
+	require.True(t, ok)
+	assert.EqualValues(t, 1, count.IntVal())
+}
+
+func TestProcessMetrics(t *testing.T) {
+	md := pdata.NewMetrics()
+	metrics := md.ResourceMetrics().AppendEmpty().InstrumentationLibraryMetrics().AppendEmpty().Metrics()
+
+	gauge := metrics.AppendEmpty()
+	gauge.SetDataType(pdata.MetricDataTypeDoubleGauge)
+	gauge.DoubleGauge().DataPoints().AppendEmpty().LabelsMap().InitFromMap(map[string]string{
+		"name":  "checkout",
+		"email": "john.doe@example.com",
+	})
+
+	histogram := metrics.AppendEmpty()
+	histogram.SetDataType(pdata.MetricDataTypeHistogram)
+	histogram.Histogram().DataPoints().AppendEmpty().LabelsMap().InitFromMap(map[string]string{
+		"name":   "checkout",
+		"secret": "hunter2",
+	})
+
+	processed, err := newTestRedaction([]string{"name", "email"}, summaryDebug).ProcessMetrics(context.Background(), md)
+	require.NoError(t, err)
+
+	processedMetrics := processed.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics()
+	assert.Equal(t,
+		pdata.NewStringMap().InitFromMap(map[string]string{
+			"name":                   "checkout",
+			"email":                  maskedValue,
+			"redaction.masked.count": "1",
+			"redaction.masked.keys":  "email",
+		}).Sort(),
+		processedMetrics.At(0).DoubleGauge().DataPoints().At(0).LabelsMap().Sort())
+	assert.Equal(t,
+		pdata.NewStringMap().InitFromMap(map[string]string{
+			"name":                     "checkout",
+			"redaction.redacted.count": "1",
+			"redaction.redacted.keys":  "secret",
+		}).Sort(),
+		processedMetrics.At(1).Histogram().DataPoints().At(0).LabelsMap().Sort())
+}
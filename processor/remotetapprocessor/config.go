@@ -0,0 +1,40 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remotetapprocessor
+
+import (
+	"errors"
+
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/confighttp"
+)
+
+// Config defines the configuration options for remotetapprocessor.
+type Config struct {
+	config.ProcessorSettings      `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+	confighttp.HTTPServerSettings `mapstructure:",squash"`
+
+	// Limit is the maximum number of messages per second sent to each connected client.
+	// Data flowing through the processor beyond that rate isn't sent to the client. Default is 1.
+	Limit float64 `mapstructure:"limit"`
+}
+
+// Validate checks that the rate limit is positive.
+func (cfg *Config) Validate() error {
+	if cfg.Limit <= 0 {
+		return errors.New("limit must be positive")
+	}
+	return nil
+}
@@ -0,0 +1,127 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remotetapprocessor
+
+import (
+	"context"
+	"sync"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+)
+
+const (
+	// The value of "type" key in configuration.
+	typeStr = "remotetap"
+
+	defaultEndpoint = "localhost:12001"
+	defaultLimit    = 1
+)
+
+var processorCapabilities = consumer.Capabilities{MutatesData: false}
+
+// NewFactory creates a factory for the remotetap processor.
+func NewFactory() component.ProcessorFactory {
+	return processorhelper.NewFactory(
+		typeStr,
+		createDefaultConfig,
+		processorhelper.WithTraces(createTracesProcessor),
+		processorhelper.WithMetrics(createMetricsProcessor),
+		processorhelper.WithLogs(createLogsProcessor),
+	)
+}
+
+func createDefaultConfig() config.Processor {
+	return &Config{
+		ProcessorSettings: config.NewProcessorSettings(config.NewID(typeStr)),
+		HTTPServerSettings: confighttp.HTTPServerSettings{
+			Endpoint: defaultEndpoint,
+		},
+		Limit: defaultLimit,
+	}
+}
+
+func createTracesProcessor(
+	_ context.Context,
+	params component.ProcessorCreateSettings,
+	cfg config.Processor,
+	nextConsumer consumer.Traces,
+) (component.TracesProcessor, error) {
+	tap := getOrCreateTap(params, cfg.(*Config))
+	return processorhelper.NewTracesProcessor(
+		cfg,
+		nextConsumer,
+		tap,
+		processorhelper.WithCapabilities(processorCapabilities),
+		processorhelper.WithStart(tap.Start),
+		processorhelper.WithShutdown(tap.Shutdown))
+}
+
+func createMetricsProcessor(
+	_ context.Context,
+	params component.ProcessorCreateSettings,
+	cfg config.Processor,
+	nextConsumer consumer.Metrics,
+) (component.MetricsProcessor, error) {
+	tap := getOrCreateTap(params, cfg.(*Config))
+	return processorhelper.NewMetricsProcessor(
+		cfg,
+		nextConsumer,
+		tap,
+		processorhelper.WithCapabilities(processorCapabilities),
+		processorhelper.WithStart(tap.Start),
+		processorhelper.WithShutdown(tap.Shutdown))
+}
+
+func createLogsProcessor(
+	_ context.Context,
+	params component.ProcessorCreateSettings,
+	cfg config.Processor,
+	nextConsumer consumer.Logs,
+) (component.LogsProcessor, error) {
+	tap := getOrCreateTap(params, cfg.(*Config))
+	return processorhelper.NewLogsProcessor(
+		cfg,
+		nextConsumer,
+		tap,
+		processorhelper.WithCapabilities(processorCapabilities),
+		processorhelper.WithStart(tap.Start),
+		processorhelper.WithShutdown(tap.Shutdown))
+}
+
+// This is the map of already created remote taps for particular configurations.
+// We maintain this map because the factory is asked trace, metric and log processors separately
+// when it gets CreateTracesProcessor(), CreateMetricsProcessor() and CreateLogsProcessor()
+// but they must not create separate objects, they must use one remoteTap object per configuration,
+// listening on a single endpoint.
+var (
+	tapsMu sync.Mutex
+	taps   = map[*Config]*remoteTap{}
+)
+
+func getOrCreateTap(params component.ProcessorCreateSettings, cfg *Config) *remoteTap {
+	tapsMu.Lock()
+	defer tapsMu.Unlock()
+
+	tap, ok := taps[cfg]
+	if !ok {
+		tap = newRemoteTap(params.Logger, cfg)
+		taps[cfg] = tap
+	}
+	return tap
+}
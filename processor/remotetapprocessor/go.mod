@@ -0,0 +1,13 @@
+module github.com/open-telemetry/opentelemetry-collector-contrib/processor/remotetapprocessor
+
+go 1.16
+
+require (
+	github.com/stretchr/testify v1.7.0
+	go.opentelemetry.io/collector v0.27.1-0.20210603182316-5369d7e9e83e
+	go.opentelemetry.io/proto/otlp v0.7.0
+	go.uber.org/zap v1.17.0
+	golang.org/x/net v0.0.0-20210427231257-85d9c07bbe3a
+	golang.org/x/time v0.0.0-20210220033141-f8bda1e9f3ba
+	google.golang.org/protobuf v1.26.0
+)
@@ -0,0 +1,63 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remotetapprocessor
+
+import (
+	"go.opentelemetry.io/collector/consumer/pdata"
+	collectorlogs "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	collectormetrics "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	collectortrace "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// The data is marshaled to the OTLP/JSON encoding by going through the OTLP protobuf encoding,
+// pdata doesn't expose a JSON marshaler.
+
+func tracesToJSON(td pdata.Traces) ([]byte, error) {
+	b, err := td.ToOtlpProtoBytes()
+	if err != nil {
+		return nil, err
+	}
+	var req collectortrace.ExportTraceServiceRequest
+	if err := proto.Unmarshal(b, &req); err != nil {
+		return nil, err
+	}
+	return protojson.Marshal(&req)
+}
+
+func metricsToJSON(md pdata.Metrics) ([]byte, error) {
+	b, err := md.ToOtlpProtoBytes()
+	if err != nil {
+		return nil, err
+	}
+	var req collectormetrics.ExportMetricsServiceRequest
+	if err := proto.Unmarshal(b, &req); err != nil {
+		return nil, err
+	}
+	return protojson.Marshal(&req)
+}
+
+func logsToJSON(ld pdata.Logs) ([]byte, error) {
+	b, err := ld.ToOtlpProtoBytes()
+	if err != nil {
+		return nil, err
+	}
+	var req collectorlogs.ExportLogsServiceRequest
+	if err := proto.Unmarshal(b, &req); err != nil {
+		return nil, err
+	}
+	return protojson.Marshal(&req)
+}
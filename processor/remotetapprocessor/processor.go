@@ -0,0 +1,223 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remotetapprocessor
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.uber.org/zap"
+	"golang.org/x/net/websocket"
+	"golang.org/x/time/rate"
+)
+
+// clientBufferSize is the number of messages buffered for a slow client before dropping new ones.
+const clientBufferSize = 16
+
+// remoteTap streams a rate-limited sample of the data flowing through it to the connected clients.
+type remoteTap struct {
+	logger *zap.Logger
+	config *Config
+
+	startOnce    sync.Once
+	shutdownOnce sync.Once
+	server       *http.Server
+	addr         net.Addr
+	done         chan struct{}
+	wg           sync.WaitGroup
+
+	mu      sync.Mutex
+	clients map[*client]struct{}
+}
+
+// client is a connected client, it gets its own rate limit.
+type client struct {
+	limiter  *rate.Limiter
+	messages chan []byte
+}
+
+func newRemoteTap(logger *zap.Logger, config *Config) *remoteTap {
+	return &remoteTap{
+		logger:  logger,
+		config:  config,
+		done:    make(chan struct{}),
+		clients: make(map[*client]struct{}),
+	}
+}
+
+// Start starts the HTTP server the clients connect to. It is shared by all the pipelines
+// the processor is part of, so only the first call has an effect.
+func (p *remoteTap) Start(_ context.Context, host component.Host) error {
+	var err error
+	p.startOnce.Do(func() {
+		var ln net.Listener
+		ln, err = p.config.HTTPServerSettings.ToListener()
+		if err != nil {
+			err = fmt.Errorf("failed to bind to address %s: %w", p.config.Endpoint, err)
+			return
+		}
+		p.addr = ln.Addr()
+
+		p.server = p.config.HTTPServerSettings.ToServer(http.HandlerFunc(p.serveHTTP))
+		p.wg.Add(1)
+		go func() {
+			defer p.wg.Done()
+			if errHTTP := p.server.Serve(ln); errHTTP != nil && errHTTP != http.ErrServerClosed {
+				host.ReportFatalError(errHTTP)
+			}
+		}()
+	})
+	return err
+}
+
+// Shutdown disconnects the clients and stops the HTTP server.
+func (p *remoteTap) Shutdown(context.Context) error {
+	var err error
+	p.shutdownOnce.Do(func() {
+		close(p.done)
+		if p.server != nil {
+			err = p.server.Close()
+			p.wg.Wait()
+		}
+	})
+	return err
+}
+
+// ProcessTraces sends the traces to the connected clients and passes them through unmodified.
+func (p *remoteTap) ProcessTraces(_ context.Context, td pdata.Traces) (pdata.Traces, error) {
+	p.broadcast(func() ([]byte, error) { return tracesToJSON(td) })
+	return td, nil
+}
+
+// ProcessMetrics sends the metrics to the connected clients and passes them through unmodified.
+func (p *remoteTap) ProcessMetrics(_ context.Context, md pdata.Metrics) (pdata.Metrics, error) {
+	p.broadcast(func() ([]byte, error) { return metricsToJSON(md) })
+	return md, nil
+}
+
+// ProcessLogs sends the logs to the connected clients and passes them through unmodified.
+func (p *remoteTap) ProcessLogs(_ context.Context, ld pdata.Logs) (pdata.Logs, error) {
+	p.broadcast(func() ([]byte, error) { return logsToJSON(ld) })
+	return ld, nil
+}
+
+// broadcast sends the data to the clients within their rate limit, without ever blocking the pipeline.
+// The data is only marshaled if at least one client is going to get it.
+func (p *remoteTap) broadcast(marshal func() ([]byte, error)) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var data []byte
+	for c := range p.clients {
+		if !c.limiter.Allow() {
+			continue
+		}
+		if data == nil {
+			var err error
+			if data, err = marshal(); err != nil {
+				p.logger.Debug("Failed to marshal data for the remote tap", zap.Error(err))
+				return
+			}
+		}
+		select {
+		case c.messages <- data:
+		default:
+		}
+	}
+}
+
+func (p *remoteTap) register() *client {
+	c := &client{
+		limiter:  rate.NewLimiter(rate.Limit(p.config.Limit), 1),
+		messages: make(chan []byte, clientBufferSize),
+	}
+	p.mu.Lock()
+	p.clients[c] = struct{}{}
+	p.mu.Unlock()
+	return c
+}
+
+func (p *remoteTap) unregister(c *client) {
+	p.mu.Lock()
+	delete(p.clients, c)
+	p.mu.Unlock()
+}
+
+// serveHTTP streams the data over a WebSocket connection if the client asks for an upgrade,
+// as newline delimited JSON otherwise.
+func (p *remoteTap) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	if strings.EqualFold(r.Header.Get("Upgrade"), "websocket") {
+		websocket.Handler(p.serveWebSocket).ServeHTTP(w, r)
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	c := p.register()
+	defer p.unregister(c)
+	for {
+		select {
+		case <-p.done:
+			return
+		case <-r.Context().Done():
+			return
+		case data := <-c.messages:
+			if _, err := w.Write(append(data, '\n')); err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
+
+func (p *remoteTap) serveWebSocket(ws *websocket.Conn) {
+	c := p.register()
+	defer p.unregister(c)
+
+	// The clients aren't expected to send anything, reading only detects when they go away.
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		var discard string
+		for websocket.Message.Receive(ws, &discard) == nil {
+		}
+	}()
+
+	for {
+		select {
+		case <-p.done:
+			return
+		case <-closed:
+			return
+		case data := <-c.messages:
+			if err := websocket.Message.Send(ws, string(data)); err != nil {
+				return
+			}
+		}
+	}
+}
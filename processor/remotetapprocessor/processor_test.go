@@ -0,0 +1,140 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remotetapprocessor
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.uber.org/zap"
+	"golang.org/x/net/websocket"
+)
+
+func startTestTap(t *testing.T, limit float64) *remoteTap {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = "localhost:0"
+	cfg.Limit = limit
+
+	tap := newRemoteTap(zap.NewNop(), cfg)
+	require.NoError(t, tap.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		assert.NoError(t, tap.Shutdown(context.Background()))
+	})
+	return tap
+}
+
+func waitForClients(t *testing.T, tap *remoteTap, n int) {
+	assert.Eventually(t, func() bool {
+		tap.mu.Lock()
+		defer tap.mu.Unlock()
+		return len(tap.clients) == n
+	}, time.Second, 10*time.Millisecond)
+}
+
+func newTestTraces() pdata.Traces {
+	td := pdata.NewTraces()
+	span := td.ResourceSpans().AppendEmpty().InstrumentationLibrarySpans().AppendEmpty().Spans().AppendEmpty()
+	span.SetName("checkout")
+	return td
+}
+
+func TestWebSocket(t *testing.T) {
+	tap := startTestTap(t, 100)
+
+	ws, err := websocket.Dial("ws://"+tap.addr.String(), "", "http://localhost/")
+	require.NoError(t, err)
+	defer ws.Close()
+	waitForClients(t, tap, 1)
+
+	td := newTestTraces()
+	processed, err := tap.ProcessTraces(context.Background(), td)
+	require.NoError(t, err)
+	assert.Equal(t, td, processed)
+
+	var msg string
+	require.NoError(t, websocket.Message.Receive(ws, &msg))
+
+	var got struct {
+		ResourceSpans []struct {
+			InstrumentationLibrarySpans []struct {
+				Spans []struct {
+					Name string `json:"name"`
+				} `json:"spans"`
+			} `json:"instrumentationLibrarySpans"`
+		} `json:"resourceSpans"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(msg), &got))
+	assert.Equal(t, "checkout", got.ResourceSpans[0].InstrumentationLibrarySpans[0].Spans[0].Name)
+
+	ws.Close()
+	waitForClients(t, tap, 0)
+}
+
+func TestHTTPStream(t *testing.T) {
+	// The limit must let through the metrics and the logs sent right after.
+	tap := startTestTap(t, 1e9)
+
+	resp, err := http.Get("http://" + tap.addr.String())
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, "application/x-ndjson", resp.Header.Get("Content-Type"))
+	waitForClients(t, tap, 1)
+
+	md := pdata.NewMetrics()
+	md.ResourceMetrics().AppendEmpty().InstrumentationLibraryMetrics().AppendEmpty().Metrics().AppendEmpty().SetName("cpu")
+	_, err = tap.ProcessMetrics(context.Background(), md)
+	require.NoError(t, err)
+
+	ld := pdata.NewLogs()
+	ld.ResourceLogs().AppendEmpty().InstrumentationLibraryLogs().AppendEmpty().Logs().AppendEmpty().SetName("log")
+	_, err = tap.ProcessLogs(context.Background(), ld)
+	require.NoError(t, err)
+
+	scanner := bufio.NewScanner(resp.Body)
+	require.True(t, scanner.Scan())
+	assert.Contains(t, scanner.Text(), `"name":"cpu"`)
+	require.True(t, scanner.Scan())
+	assert.Contains(t, scanner.Text(), `"name":"log"`)
+}
+
+func TestRateLimit(t *testing.T) {
+	tap := startTestTap(t, 0.001)
+	c := tap.register()
+	defer tap.unregister(c)
+
+	for i := 0; i < 5; i++ {
+		_, err := tap.ProcessTraces(context.Background(), newTestTraces())
+		require.NoError(t, err)
+	}
+	assert.Len(t, c.messages, 1)
+}
+
+func TestNoClients(t *testing.T) {
+	tap := newRemoteTap(zap.NewNop(), createDefaultConfig().(*Config))
+	marshaled := false
+	tap.broadcast(func() ([]byte, error) {
+		marshaled = true
+		return nil, nil
+	})
+	assert.False(t, marshaled)
+}
@@ -35,6 +35,9 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/env"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/gcp/gce"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/gcp/gke"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/heroku"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/paas"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/railway"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/system"
 )
 
@@ -66,6 +69,9 @@ func NewFactory() component.ProcessorFactory {
 		env.TypeStr:              env.NewDetector,
 		gce.TypeStr:              gce.NewDetector,
 		gke.TypeStr:              gke.NewDetector,
+		heroku.TypeStr:           heroku.NewDetector,
+		paas.TypeStr:             paas.NewDetector,
+		railway.TypeStr:          railway.NewDetector,
 		system.TypeStr:           system.NewDetector,
 	})
 
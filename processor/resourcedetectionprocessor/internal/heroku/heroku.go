@@ -0,0 +1,78 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package heroku provides a detector that loads resource information from
+// the environment variables set in Heroku dynos.
+package heroku
+
+import (
+	"context"
+	"os"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/translator/conventions"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
+)
+
+// TypeStr is type of detector.
+const TypeStr = "heroku"
+
+// attributes maps resource attributes to the environment variables they are
+// read from. Besides DYNO, the variables are the dyno metadata set when the
+// runtime-dyno-metadata labs feature is enabled on the app.
+var attributes = []struct {
+	key    string
+	envVar string
+}{
+	{conventions.AttributeServiceName, "HEROKU_APP_NAME"},
+	{conventions.AttributeServiceInstance, "HEROKU_DYNO_ID"},
+	{conventions.AttributeServiceVersion, "HEROKU_RELEASE_VERSION"},
+	{conventions.AttributeHostName, "DYNO"},
+	{"heroku.app.id", "HEROKU_APP_ID"},
+	{"heroku.release.commit", "HEROKU_SLUG_COMMIT"},
+	{"heroku.release.creation_timestamp", "HEROKU_RELEASE_CREATED_AT"},
+}
+
+var _ internal.Detector = (*Detector)(nil)
+
+// Detector detects the resource of Heroku dynos.
+type Detector struct{}
+
+// NewDetector returns a detector of the resource of Heroku dynos.
+func NewDetector(component.ProcessorCreateSettings, internal.DetectorConfig) (internal.Detector, error) {
+	return &Detector{}, nil
+}
+
+// Detect returns a Resource describing the dyno, an empty Resource if the
+// application doesn't run on Heroku.
+func (d *Detector) Detect(context.Context) (pdata.Resource, error) {
+	res := pdata.NewResource()
+
+	// DYNO is always set in dynos.
+	if os.Getenv("DYNO") == "" {
+		return res, nil
+	}
+
+	attr := res.Attributes()
+	attr.InsertString(conventions.AttributeCloudProvider, "heroku")
+	for _, a := range attributes {
+		if v := os.Getenv(a.envVar); v != "" {
+			attr.InsertString(a.key, v)
+		}
+	}
+
+	return res, nil
+}
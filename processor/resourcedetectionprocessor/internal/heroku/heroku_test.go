@@ -0,0 +1,93 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package heroku
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
+)
+
+// setEnv sets the environment variables and returns a function unsetting them.
+func setEnv(t *testing.T, env map[string]string) func() {
+	for k, v := range env {
+		require.NoError(t, os.Setenv(k, v))
+	}
+	return func() {
+		for k := range env {
+			require.NoError(t, os.Unsetenv(k))
+		}
+	}
+}
+
+func TestNewDetector(t *testing.T) {
+	d, err := NewDetector(component.ProcessorCreateSettings{Logger: zap.NewNop()}, nil)
+	assert.NoError(t, err)
+	assert.NotNil(t, d)
+}
+
+func TestDetect(t *testing.T) {
+	defer setEnv(t, map[string]string{
+		"DYNO":                      "web.1",
+		"HEROKU_APP_ID":             "9daa2797-e49b-4624-932f-ec3f9688e3da",
+		"HEROKU_APP_NAME":           "example-app",
+		"HEROKU_DYNO_ID":            "1vac4117-c29f-4312-521e-ba4d8638c1ac",
+		"HEROKU_RELEASE_CREATED_AT": "2015-04-02T18:00:42Z",
+		"HEROKU_RELEASE_VERSION":    "v42",
+		"HEROKU_SLUG_COMMIT":        "2c3a0b24069af49b3de35b8e8c26765c1dba9ff0",
+	})()
+
+	res, err := (&Detector{}).Detect(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]interface{}{
+		"cloud.provider":                    "heroku",
+		"service.name":                      "example-app",
+		"service.instance.id":               "1vac4117-c29f-4312-521e-ba4d8638c1ac",
+		"service.version":                   "v42",
+		"host.name":                         "web.1",
+		"heroku.app.id":                     "9daa2797-e49b-4624-932f-ec3f9688e3da",
+		"heroku.release.commit":             "2c3a0b24069af49b3de35b8e8c26765c1dba9ff0",
+		"heroku.release.creation_timestamp": "2015-04-02T18:00:42Z",
+	}, internal.AttributesToMap(res.Attributes()))
+}
+
+// Without the dyno metadata feature, only the DYNO variable is set.
+func TestDetectWithoutDynoMetadata(t *testing.T) {
+	defer setEnv(t, map[string]string{"DYNO": "worker.2"})()
+
+	res, err := (&Detector{}).Detect(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]interface{}{
+		"cloud.provider": "heroku",
+		"host.name":      "worker.2",
+	}, internal.AttributesToMap(res.Attributes()))
+}
+
+func TestNotHeroku(t *testing.T) {
+	defer setEnv(t, map[string]string{"HEROKU_APP_NAME": "example-app"})()
+
+	res, err := (&Detector{}).Detect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 0, res.Attributes().Len(), "Resource object should be empty")
+}
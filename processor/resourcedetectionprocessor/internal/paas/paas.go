@@ -0,0 +1,123 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package paas provides a detector that loads resource information from the
+// environment variables of the first Platform as a Service detected among
+// Heroku, Railway, Render and Fly.io.
+package paas
+
+import (
+	"context"
+	"os"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/translator/conventions"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/heroku"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/railway"
+)
+
+// TypeStr is type of detector.
+const TypeStr = "paas"
+
+// platform describes the environment variables set by a provider without a
+// detector of its own.
+type platform struct {
+	// provider is the value of the cloud.provider attribute.
+	provider string
+	// marker is an environment variable that is always set on the platform.
+	marker string
+	// attributes maps resource attributes to the environment variables they are
+	// read from. Unset environment variables are skipped.
+	attributes []attribute
+}
+
+type attribute struct {
+	key    string
+	envVar string
+}
+
+var render = &platform{
+	provider: "render",
+	marker:   "RENDER_SERVICE_ID",
+	attributes: []attribute{
+		{conventions.AttributeServiceName, "RENDER_SERVICE_NAME"},
+		{conventions.AttributeServiceInstance, "RENDER_INSTANCE_ID"},
+		{conventions.AttributeServiceVersion, "RENDER_GIT_COMMIT"},
+		{conventions.AttributeHostName, "RENDER_INSTANCE_ID"},
+		{"render.service.id", "RENDER_SERVICE_ID"},
+	},
+}
+
+var fly = &platform{
+	provider: "fly",
+	marker:   "FLY_APP_NAME",
+	attributes: []attribute{
+		{conventions.AttributeCloudRegion, "FLY_REGION"},
+		{conventions.AttributeServiceName, "FLY_APP_NAME"},
+		{conventions.AttributeServiceInstance, "FLY_ALLOC_ID"},
+		{conventions.AttributeHostName, "FLY_ALLOC_ID"},
+		{"fly.image.ref", "FLY_IMAGE_REF"},
+	},
+}
+
+func (p *platform) Detect(context.Context) (pdata.Resource, error) {
+	res := pdata.NewResource()
+
+	if os.Getenv(p.marker) == "" {
+		return res, nil
+	}
+
+	attr := res.Attributes()
+	attr.InsertString(conventions.AttributeCloudProvider, p.provider)
+	for _, a := range p.attributes {
+		if v := os.Getenv(a.envVar); v != "" {
+			attr.InsertString(a.key, v)
+		}
+	}
+
+	return res, nil
+}
+
+var _ internal.Detector = (*Detector)(nil)
+
+// Detector detects the resource of an application running on a PaaS.
+type Detector struct {
+	// detectors are run in order, the first one returning a non-empty
+	// resource is used.
+	detectors []internal.Detector
+}
+
+// NewDetector returns a detector of the resource of applications running on
+// any of the supported platforms.
+func NewDetector(component.ProcessorCreateSettings, internal.DetectorConfig) (internal.Detector, error) {
+	return &Detector{detectors: []internal.Detector{&heroku.Detector{}, &railway.Detector{}, render, fly}}, nil
+}
+
+// Detect returns a Resource describing the platform the application runs on,
+// an empty Resource if it doesn't run on any of the supported platforms.
+func (d *Detector) Detect(ctx context.Context) (pdata.Resource, error) {
+	for _, detector := range d.detectors {
+		res, err := detector.Detect(ctx)
+		if err != nil {
+			return res, err
+		}
+		if res.Attributes().Len() > 0 {
+			return res, nil
+		}
+	}
+	return pdata.NewResource(), nil
+}
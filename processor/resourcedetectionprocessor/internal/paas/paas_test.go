@@ -0,0 +1,125 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package paas
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
+)
+
+// setEnv sets the environment variables and returns a function unsetting them.
+func setEnv(t *testing.T, env map[string]string) func() {
+	for k, v := range env {
+		require.NoError(t, os.Setenv(k, v))
+	}
+	return func() {
+		for k := range env {
+			require.NoError(t, os.Unsetenv(k))
+		}
+	}
+}
+
+func TestNewDetector(t *testing.T) {
+	d, err := NewDetector(component.ProcessorCreateSettings{Logger: zap.NewNop()}, nil)
+	assert.NoError(t, err)
+	assert.NotNil(t, d)
+}
+
+func TestDetect(t *testing.T) {
+	cases := []struct {
+		name     string
+		env      map[string]string
+		expected map[string]interface{}
+	}{
+		{
+			name: "railway",
+			env:  map[string]string{"RAILWAY_PROJECT_ID": "4d1c5a8e", "RAILWAY_SERVICE_NAME": "api"},
+			expected: map[string]interface{}{
+				"cloud.provider":     "railway",
+				"service.name":       "api",
+				"railway.project.id": "4d1c5a8e",
+			},
+		},
+		{
+			name:     "heroku",
+			env:      map[string]string{"DYNO": "web.1", "HEROKU_APP_NAME": "example-app"},
+			expected: map[string]interface{}{"cloud.provider": "heroku", "host.name": "web.1", "service.name": "example-app"},
+		},
+		{
+			name: "render",
+			env: map[string]string{
+				"RENDER_SERVICE_ID":   "srv-c1a2b3c4d5e6f7g8h9i0",
+				"RENDER_SERVICE_NAME": "web",
+				"RENDER_INSTANCE_ID":  "srv-c1a2b3c4d5e6f7g8h9i0-5d7f8",
+				"RENDER_GIT_COMMIT":   "8f2d9c1",
+			},
+			expected: map[string]interface{}{
+				"cloud.provider":      "render",
+				"service.name":        "web",
+				"service.instance.id": "srv-c1a2b3c4d5e6f7g8h9i0-5d7f8",
+				"service.version":     "8f2d9c1",
+				"host.name":           "srv-c1a2b3c4d5e6f7g8h9i0-5d7f8",
+				"render.service.id":   "srv-c1a2b3c4d5e6f7g8h9i0",
+			},
+		},
+		{
+			name: "fly",
+			env: map[string]string{
+				"FLY_APP_NAME":  "example",
+				"FLY_ALLOC_ID":  "b996131a-5bae-215b-d0f1-2f1d1a7e6f2c",
+				"FLY_REGION":    "ams",
+				"FLY_IMAGE_REF": "registry.fly.io/example:deployment-01",
+			},
+			expected: map[string]interface{}{
+				"cloud.provider":      "fly",
+				"cloud.region":        "ams",
+				"service.name":        "example",
+				"service.instance.id": "b996131a-5bae-215b-d0f1-2f1d1a7e6f2c",
+				"host.name":           "b996131a-5bae-215b-d0f1-2f1d1a7e6f2c",
+				"fly.image.ref":       "registry.fly.io/example:deployment-01",
+			},
+		},
+		{
+			name:     "first platform detected",
+			env:      map[string]string{"DYNO": "web.1", "FLY_APP_NAME": "example"},
+			expected: map[string]interface{}{"cloud.provider": "heroku", "host.name": "web.1"},
+		},
+		{
+			name:     "no platform",
+			expected: map[string]interface{}{},
+		},
+	}
+
+	d, err := NewDetector(component.ProcessorCreateSettings{Logger: zap.NewNop()}, nil)
+	require.NoError(t, err)
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			defer setEnv(t, tc.env)()
+
+			res, err := d.Detect(context.Background())
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, internal.AttributesToMap(res.Attributes()))
+		})
+	}
+}
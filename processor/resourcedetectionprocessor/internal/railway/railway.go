@@ -0,0 +1,80 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package railway provides a detector that loads resource information from
+// the variables Railway provides to deployments.
+package railway
+
+import (
+	"context"
+	"os"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/translator/conventions"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
+)
+
+// TypeStr is type of detector.
+const TypeStr = "railway"
+
+// attributes maps resource attributes to the environment variables they are
+// read from.
+var attributes = []struct {
+	key    string
+	envVar string
+}{
+	{conventions.AttributeCloudRegion, "RAILWAY_REPLICA_REGION"},
+	{conventions.AttributeServiceName, "RAILWAY_SERVICE_NAME"},
+	{conventions.AttributeServiceInstance, "RAILWAY_REPLICA_ID"},
+	{conventions.AttributeServiceVersion, "RAILWAY_GIT_COMMIT_SHA"},
+	{conventions.AttributeHostName, "RAILWAY_REPLICA_ID"},
+	{conventions.AttributeDeploymentEnvironment, "RAILWAY_ENVIRONMENT_NAME"},
+	{"railway.project.id", "RAILWAY_PROJECT_ID"},
+	{"railway.project.name", "RAILWAY_PROJECT_NAME"},
+	{"railway.service.id", "RAILWAY_SERVICE_ID"},
+	{"railway.deployment.id", "RAILWAY_DEPLOYMENT_ID"},
+}
+
+var _ internal.Detector = (*Detector)(nil)
+
+// Detector detects the resource of Railway deployments.
+type Detector struct{}
+
+// NewDetector returns a detector of the resource of Railway deployments.
+func NewDetector(component.ProcessorCreateSettings, internal.DetectorConfig) (internal.Detector, error) {
+	return &Detector{}, nil
+}
+
+// Detect returns a Resource describing the deployment, an empty Resource if
+// the application doesn't run on Railway.
+func (d *Detector) Detect(context.Context) (pdata.Resource, error) {
+	res := pdata.NewResource()
+
+	// RAILWAY_PROJECT_ID is always set in deployments.
+	if os.Getenv("RAILWAY_PROJECT_ID") == "" {
+		return res, nil
+	}
+
+	attr := res.Attributes()
+	attr.InsertString(conventions.AttributeCloudProvider, "railway")
+	for _, a := range attributes {
+		if v := os.Getenv(a.envVar); v != "" {
+			attr.InsertString(a.key, v)
+		}
+	}
+
+	return res, nil
+}
@@ -0,0 +1,85 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package railway
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
+)
+
+// setEnv sets the environment variables and returns a function unsetting them.
+func setEnv(t *testing.T, env map[string]string) func() {
+	for k, v := range env {
+		require.NoError(t, os.Setenv(k, v))
+	}
+	return func() {
+		for k := range env {
+			require.NoError(t, os.Unsetenv(k))
+		}
+	}
+}
+
+func TestNewDetector(t *testing.T) {
+	d, err := NewDetector(component.ProcessorCreateSettings{Logger: zap.NewNop()}, nil)
+	assert.NoError(t, err)
+	assert.NotNil(t, d)
+}
+
+func TestDetect(t *testing.T) {
+	defer setEnv(t, map[string]string{
+		"RAILWAY_PROJECT_ID":       "4d1c5a8e-3b36-4f69-a1a7-0e5f7c9b2d11",
+		"RAILWAY_PROJECT_NAME":     "shop",
+		"RAILWAY_ENVIRONMENT_NAME": "production",
+		"RAILWAY_SERVICE_ID":       "b6a7c2e1-8f3d-4b5a-9c1e-2d4f6a8b0c3e",
+		"RAILWAY_SERVICE_NAME":     "api",
+		"RAILWAY_DEPLOYMENT_ID":    "0f9e8d7c-6b5a-4c3d-2e1f-0a9b8c7d6e5f",
+		"RAILWAY_REPLICA_ID":       "7e6d5c4b-3a2f-1e0d-9c8b-7a6f5e4d3c2b",
+		"RAILWAY_REPLICA_REGION":   "us-west2",
+		"RAILWAY_GIT_COMMIT_SHA":   "d1e2f3a4b5c6d7e8f9a0b1c2d3e4f5a6b7c8d9e0",
+	})()
+
+	res, err := (&Detector{}).Detect(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]interface{}{
+		"cloud.provider":         "railway",
+		"cloud.region":           "us-west2",
+		"service.name":           "api",
+		"service.instance.id":    "7e6d5c4b-3a2f-1e0d-9c8b-7a6f5e4d3c2b",
+		"service.version":        "d1e2f3a4b5c6d7e8f9a0b1c2d3e4f5a6b7c8d9e0",
+		"host.name":              "7e6d5c4b-3a2f-1e0d-9c8b-7a6f5e4d3c2b",
+		"deployment.environment": "production",
+		"railway.project.id":     "4d1c5a8e-3b36-4f69-a1a7-0e5f7c9b2d11",
+		"railway.project.name":   "shop",
+		"railway.service.id":     "b6a7c2e1-8f3d-4b5a-9c1e-2d4f6a8b0c3e",
+		"railway.deployment.id":  "0f9e8d7c-6b5a-4c3d-2e1f-0a9b8c7d6e5f",
+	}, internal.AttributesToMap(res.Attributes()))
+}
+
+func TestNotRailway(t *testing.T) {
+	defer setEnv(t, map[string]string{"RAILWAY_SERVICE_NAME": "api"})()
+
+	res, err := (&Detector{}).Detect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 0, res.Attributes().Len(), "Resource object should be empty")
+}
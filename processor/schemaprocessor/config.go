@@ -0,0 +1,71 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schemaprocessor
+
+import (
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/config"
+)
+
+// Config defines the configuration options for schemaprocessor.
+type Config struct {
+	config.ProcessorSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+
+	// SchemaFile is the path of the schema file describing the changes between the versions
+	// of a schema family, e.g. the OpenTelemetry semantic conventions.
+	SchemaFile string `mapstructure:"schema_file"`
+
+	// Target is the schema URL telemetry is translated to.
+	Target string `mapstructure:"target"`
+
+	// DefaultSource is the schema URL assumed for telemetry whose resource does not carry
+	// SourceAttribute. Telemetry without a known source schema is passed through unchanged.
+	DefaultSource string `mapstructure:"default_source"`
+
+	// SourceAttribute is the resource attribute holding the schema URL the telemetry of the
+	// resource conforms to. Once translated, it is set to Target.
+	SourceAttribute string `mapstructure:"source_attribute"`
+}
+
+var _ config.Processor = (*Config)(nil)
+
+// Validate checks that the processor configuration is valid.
+func (cfg *Config) Validate() error {
+	if cfg.SchemaFile == "" {
+		return errors.New("schema_file must be set")
+	}
+	if cfg.Target == "" {
+		return errors.New("target must be set")
+	}
+	targetFamily, _, err := parseSchemaURL(cfg.Target)
+	if err != nil {
+		return fmt.Errorf("invalid target: %w", err)
+	}
+	if cfg.DefaultSource == "" && cfg.SourceAttribute == "" {
+		return errors.New("one of default_source and source_attribute must be set")
+	}
+	if cfg.DefaultSource != "" {
+		sourceFamily, _, err := parseSchemaURL(cfg.DefaultSource)
+		if err != nil {
+			return fmt.Errorf("invalid default_source: %w", err)
+		}
+		if sourceFamily != targetFamily {
+			return fmt.Errorf("default_source %q and target %q belong to different schema families", cfg.DefaultSource, cfg.Target)
+		}
+	}
+	return nil
+}
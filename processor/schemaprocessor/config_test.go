@@ -0,0 +1,103 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schemaprocessor
+
+import (
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configtest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	require.NoError(t, err)
+
+	factories.Processors[typeStr] = NewFactory()
+
+	cfg, err := configtest.LoadConfigAndValidate(path.Join(".", "testdata", "config.yaml"), factories)
+
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+	assert.Equal(t,
+		&Config{
+			ProcessorSettings: config.NewProcessorSettings(config.NewID(typeStr)),
+			SchemaFile:        "./testdata/schema.yaml",
+			Target:            "https://opentelemetry.io/schemas/1.2.0",
+			DefaultSource:     "https://opentelemetry.io/schemas/1.0.0",
+			SourceAttribute:   "telemetry.schema_url",
+		},
+		cfg.Processors[config.NewID(typeStr)],
+	)
+}
+
+func TestValidateConfig(t *testing.T) {
+	cases := []struct {
+		name     string
+		modify   func(*Config)
+		expected string
+	}{
+		{
+			name:     "missing_schema_file",
+			modify:   func(cfg *Config) { cfg.SchemaFile = "" },
+			expected: "schema_file must be set",
+		},
+		{
+			name:     "missing_target",
+			modify:   func(cfg *Config) { cfg.Target = "" },
+			expected: "target must be set",
+		},
+		{
+			name:     "relative_target",
+			modify:   func(cfg *Config) { cfg.Target = "schemas/1.2.0" },
+			expected: `invalid target: schema URL "schemas/1.2.0" must be absolute`,
+		},
+		{
+			name:     "invalid_target_version",
+			modify:   func(cfg *Config) { cfg.Target = "https://opentelemetry.io/schemas/latest" },
+			expected: `invalid target: invalid version "latest"`,
+		},
+		{
+			name: "missing_source",
+			modify: func(cfg *Config) {
+				cfg.DefaultSource = ""
+				cfg.SourceAttribute = ""
+			},
+			expected: "one of default_source and source_attribute must be set",
+		},
+		{
+			name:     "different_family",
+			modify:   func(cfg *Config) { cfg.DefaultSource = "https://example.com/schemas/1.0.0" },
+			expected: `default_source "https://example.com/schemas/1.0.0" and target "https://opentelemetry.io/schemas/1.2.0" belong to different schema families`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := &Config{
+				SchemaFile:    "schema.yaml",
+				Target:        "https://opentelemetry.io/schemas/1.2.0",
+				DefaultSource: "https://opentelemetry.io/schemas/1.0.0",
+			}
+			require.NoError(t, cfg.Validate())
+			tc.modify(cfg)
+			assert.EqualError(t, cfg.Validate(), tc.expected)
+		})
+	}
+}
@@ -0,0 +1,168 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schemaprocessor
+
+import (
+	"context"
+	"sync"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.uber.org/zap"
+)
+
+type schemaProcessor struct {
+	logger          *zap.Logger
+	schema          *schema
+	target          string
+	defaultSource   string
+	sourceAttribute string
+
+	lock  sync.Mutex
+	plans map[string][]step // by source schema URL, nil when it cannot be translated
+}
+
+func newSchemaProcessor(logger *zap.Logger, cfg *Config) (*schemaProcessor, error) {
+	s, err := loadSchema(cfg.SchemaFile)
+	if err != nil {
+		return nil, err
+	}
+	p := &schemaProcessor{
+		logger:          logger,
+		schema:          s,
+		target:          cfg.Target,
+		defaultSource:   cfg.DefaultSource,
+		sourceAttribute: cfg.SourceAttribute,
+		plans:           make(map[string][]step),
+	}
+	// Fail early rather than passing everything through when the target or default source
+	// are not part of the schema file.
+	if _, err := s.plan(cfg.Target, cfg.Target); err != nil {
+		return nil, err
+	}
+	if cfg.DefaultSource != "" {
+		if _, err := s.plan(cfg.DefaultSource, cfg.Target); err != nil {
+			return nil, err
+		}
+	}
+	return p, nil
+}
+
+// ProcessTraces translates the spans of each resource to the target schema.
+func (p *schemaProcessor) ProcessTraces(_ context.Context, td pdata.Traces) (pdata.Traces, error) {
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		steps, ok := p.stepsFor(rs.Resource())
+		if !ok {
+			continue
+		}
+		for _, st := range steps {
+			st.applyToResource(rs.Resource())
+			ilss := rs.InstrumentationLibrarySpans()
+			for j := 0; j < ilss.Len(); j++ {
+				spans := ilss.At(j).Spans()
+				for k := 0; k < spans.Len(); k++ {
+					st.applyToSpan(spans.At(k))
+				}
+			}
+		}
+		p.setSchemaURL(rs.Resource())
+	}
+	return td, nil
+}
+
+// ProcessMetrics translates the metrics of each resource to the target schema.
+func (p *schemaProcessor) ProcessMetrics(_ context.Context, md pdata.Metrics) (pdata.Metrics, error) {
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		steps, ok := p.stepsFor(rm.Resource())
+		if !ok {
+			continue
+		}
+		for _, st := range steps {
+			st.applyToResource(rm.Resource())
+			ilms := rm.InstrumentationLibraryMetrics()
+			for j := 0; j < ilms.Len(); j++ {
+				metrics := ilms.At(j).Metrics()
+				for k := 0; k < metrics.Len(); k++ {
+					st.applyToMetric(metrics.At(k))
+				}
+			}
+		}
+		p.setSchemaURL(rm.Resource())
+	}
+	return md, nil
+}
+
+// ProcessLogs translates the log records of each resource to the target schema.
+func (p *schemaProcessor) ProcessLogs(_ context.Context, ld pdata.Logs) (pdata.Logs, error) {
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		steps, ok := p.stepsFor(rl.Resource())
+		if !ok {
+			continue
+		}
+		for _, st := range steps {
+			st.applyToResource(rl.Resource())
+			ills := rl.InstrumentationLibraryLogs()
+			for j := 0; j < ills.Len(); j++ {
+				logs := ills.At(j).Logs()
+				for k := 0; k < logs.Len(); k++ {
+					st.applyToLogRecord(logs.At(k))
+				}
+			}
+		}
+		p.setSchemaURL(rl.Resource())
+	}
+	return ld, nil
+}
+
+// stepsFor returns the steps translating the telemetry of resource to the target schema.
+// It returns false when the source schema of the resource is unknown or cannot be translated.
+func (p *schemaProcessor) stepsFor(resource pdata.Resource) ([]step, bool) {
+	source := p.defaultSource
+	if p.sourceAttribute != "" {
+		if v, ok := resource.Attributes().Get(p.sourceAttribute); ok && v.Type() == pdata.AttributeValueTypeString {
+			source = v.StringVal()
+		}
+	}
+	if source == "" {
+		return nil, false
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	steps, ok := p.plans[source]
+	if !ok {
+		var err error
+		steps, err = p.schema.plan(source, p.target)
+		if err != nil {
+			p.logger.Warn("Cannot translate telemetry to the target schema, passing it through unchanged",
+				zap.String("source", source), zap.String("target", p.target), zap.Error(err))
+		}
+		p.plans[source] = steps
+	}
+	return steps, steps != nil || source == p.target
+}
+
+// setSchemaURL records the schema the telemetry of resource now conforms to.
+func (p *schemaProcessor) setSchemaURL(resource pdata.Resource) {
+	if p.sourceAttribute != "" {
+		resource.Attributes().UpsertString(p.sourceAttribute, p.target)
+	}
+}
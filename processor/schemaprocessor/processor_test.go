@@ -0,0 +1,221 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schemaprocessor
+
+import (
+	"context"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.uber.org/zap"
+)
+
+func newTestProcessor(t *testing.T, source, target string) *schemaProcessor {
+	p, err := newSchemaProcessor(zap.NewNop(), &Config{
+		SchemaFile:      path.Join("testdata", "schema.yaml"),
+		Target:          target,
+		DefaultSource:   source,
+		SourceAttribute: "telemetry.schema_url",
+	})
+	require.NoError(t, err)
+	return p
+}
+
+func attributeKeys(attrs pdata.AttributeMap) []string {
+	var keys []string
+	attrs.Range(func(k string, _ pdata.AttributeValue) bool {
+		keys = append(keys, k)
+		return true
+	})
+	return keys
+}
+
+func TestCreateProcessors(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.SchemaFile = path.Join("testdata", "schema.yaml")
+	cfg.Target = schemaV120
+	cfg.DefaultSource = schemaV100
+	params := component.ProcessorCreateSettings{Logger: zap.NewNop()}
+
+	tp, err := factory.CreateTracesProcessor(context.Background(), params, cfg, consumertest.NewNop())
+	require.NoError(t, err)
+	assert.True(t, tp.Capabilities().MutatesData)
+	_, err = factory.CreateMetricsProcessor(context.Background(), params, cfg, consumertest.NewNop())
+	require.NoError(t, err)
+	_, err = factory.CreateLogsProcessor(context.Background(), params, cfg, consumertest.NewNop())
+	require.NoError(t, err)
+
+	cfg.Target = "https://opentelemetry.io/schemas/2.0.0"
+	_, err = factory.CreateTracesProcessor(context.Background(), params, cfg, consumertest.NewNop())
+	assert.EqualError(t, err, `version 2.0.0 is not defined by schema family "https://opentelemetry.io/schemas"`)
+
+	cfg.Target = schemaV120
+	cfg.SchemaFile = path.Join("testdata", "missing.yaml")
+	_, err = factory.CreateTracesProcessor(context.Background(), params, cfg, consumertest.NewNop())
+	assert.Error(t, err)
+}
+
+func TestProcessTracesUpgrade(t *testing.T) {
+	p := newTestProcessor(t, schemaV100, schemaV120)
+
+	td := pdata.NewTraces()
+	rs := td.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().InsertString("telemetry.auto.version", "0.1")
+	rs.Resource().Attributes().InsertString("k8s.cluster.name", "prod")
+	spans := rs.InstrumentationLibrarySpans().AppendEmpty().Spans()
+
+	query := spans.AppendEmpty()
+	query.SetName("cassandra.query")
+	query.Attributes().InsertString("db.cassandra.keyspace", "orders")
+	event := query.Events().AppendEmpty()
+	event.SetName("exception.thrown")
+	event.Attributes().InsertString("message", "timeout")
+
+	other := spans.AppendEmpty()
+	other.SetName("http.request")
+	other.Attributes().InsertString("db.cassandra.keyspace", "orders")
+
+	td, err := p.ProcessTraces(context.Background(), td)
+	require.NoError(t, err)
+
+	resource := td.ResourceSpans().At(0).Resource().Attributes()
+	assert.ElementsMatch(t, []string{"telemetry.auto_version", "kubernetes.cluster.name", "telemetry.schema_url"}, attributeKeys(resource))
+	schemaURL, _ := resource.Get("telemetry.schema_url")
+	assert.Equal(t, schemaV120, schemaURL.StringVal())
+
+	assert.Equal(t, []string{"db.name"}, attributeKeys(query.Attributes()))
+	assert.Equal(t, "exception", event.Name())
+	assert.Equal(t, []string{"exception.message"}, attributeKeys(event.Attributes()))
+	// The span rename only applies to cassandra.query spans
+	assert.Equal(t, []string{"db.cassandra.keyspace"}, attributeKeys(other.Attributes()))
+}
+
+func TestProcessTracesDowngradeFromSourceAttribute(t *testing.T) {
+	p := newTestProcessor(t, "", schemaV100)
+
+	td := pdata.NewTraces()
+	rs := td.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().InsertString("telemetry.schema_url", schemaV120)
+	rs.Resource().Attributes().InsertString("telemetry.auto_version", "0.1")
+	span := rs.InstrumentationLibrarySpans().AppendEmpty().Spans().AppendEmpty()
+	span.SetName("cassandra.query")
+	event := span.Events().AppendEmpty()
+	event.SetName("exception")
+	event.Attributes().InsertString("exception.message", "timeout")
+
+	// Without a source, the resource is passed through unchanged
+	unknown := td.ResourceSpans().AppendEmpty()
+	unknown.Resource().Attributes().InsertString("telemetry.auto_version", "0.1")
+
+	td, err := p.ProcessTraces(context.Background(), td)
+	require.NoError(t, err)
+
+	resource := td.ResourceSpans().At(0).Resource().Attributes()
+	assert.ElementsMatch(t, []string{"telemetry.auto.version", "telemetry.schema_url"}, attributeKeys(resource))
+	assert.Equal(t, "exception.thrown", event.Name())
+	assert.Equal(t, []string{"message"}, attributeKeys(event.Attributes()))
+
+	assert.Equal(t, []string{"telemetry.auto_version"}, attributeKeys(td.ResourceSpans().At(1).Resource().Attributes()))
+}
+
+func TestProcessTracesUnknownSource(t *testing.T) {
+	p := newTestProcessor(t, "", schemaV120)
+
+	td := pdata.NewTraces()
+	rs := td.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().InsertString("telemetry.schema_url", "https://opentelemetry.io/schemas/0.9.0")
+	rs.Resource().Attributes().InsertString("k8s.cluster.name", "prod")
+
+	td, err := p.ProcessTraces(context.Background(), td)
+	require.NoError(t, err)
+	schemaURL, _ := td.ResourceSpans().At(0).Resource().Attributes().Get("telemetry.schema_url")
+	assert.Equal(t, "https://opentelemetry.io/schemas/0.9.0", schemaURL.StringVal())
+	_, ok := td.ResourceSpans().At(0).Resource().Attributes().Get("k8s.cluster.name")
+	assert.True(t, ok)
+}
+
+func TestProcessMetrics(t *testing.T) {
+	p := newTestProcessor(t, schemaV110, schemaV120)
+
+	md := pdata.NewMetrics()
+	metrics := md.ResourceMetrics().AppendEmpty().InstrumentationLibraryMetrics().AppendEmpty().Metrics()
+
+	usage := metrics.AppendEmpty()
+	usage.SetName("container.cpu.usage.total")
+	usage.SetDataType(pdata.MetricDataTypeIntSum)
+	usage.IntSum().DataPoints().AppendEmpty().LabelsMap().Insert("k8s.cluster.name", "prod")
+
+	utilization := metrics.AppendEmpty()
+	utilization.SetName("system.cpu.utilization")
+	utilization.SetDataType(pdata.MetricDataTypeDoubleGauge)
+	utilization.DoubleGauge().DataPoints().AppendEmpty().LabelsMap().Insert("state", "idle")
+
+	memory := metrics.AppendEmpty()
+	memory.SetName("system.memory.usage")
+	memory.SetDataType(pdata.MetricDataTypeIntGauge)
+	memory.IntGauge().DataPoints().AppendEmpty().LabelsMap().Insert("state", "free")
+
+	md, err := p.ProcessMetrics(context.Background(), md)
+	require.NoError(t, err)
+
+	assert.Equal(t, "container.cpu.usage", usage.Name())
+	_, ok := usage.IntSum().DataPoints().At(0).LabelsMap().Get("kubernetes.cluster.name")
+	assert.True(t, ok)
+	v, _ := utilization.DoubleGauge().DataPoints().At(0).LabelsMap().Get("cpu.state")
+	assert.Equal(t, "idle", v)
+	_, ok = memory.IntGauge().DataPoints().At(0).LabelsMap().Get("state")
+	assert.True(t, ok)
+}
+
+func TestProcessLogs(t *testing.T) {
+	p := newTestProcessor(t, schemaV100, schemaV120)
+
+	ld := pdata.NewLogs()
+	rl := ld.ResourceLogs().AppendEmpty()
+	lr := rl.InstrumentationLibraryLogs().AppendEmpty().Logs().AppendEmpty()
+	lr.Attributes().InsertString("process.executable_name", "java")
+	lr.Attributes().InsertString("k8s.cluster.name", "prod")
+
+	ld, err := p.ProcessLogs(context.Background(), ld)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"process.executable.name", "kubernetes.cluster.name"}, attributeKeys(lr.Attributes()))
+}
+
+func TestRenameAttributesSwap(t *testing.T) {
+	attrs := pdata.NewAttributeMap()
+	attrs.InsertString("a", "1")
+	attrs.InsertString("b", "2")
+	renameAttributes(attrs, map[string]string{"a": "b", "b": "a"})
+
+	a, _ := attrs.Get("a")
+	b, _ := attrs.Get("b")
+	assert.Equal(t, "2", a.StringVal())
+	assert.Equal(t, "1", b.StringVal())
+
+	labels := pdata.NewStringMap()
+	labels.Insert("a", "1")
+	labels.Insert("b", "2")
+	renameLabels(labels, map[string]string{"a": "b", "b": "a"})
+	a2, _ := labels.Get("a")
+	b2, _ := labels.Get("b")
+	assert.Equal(t, "2", a2)
+	assert.Equal(t, "1", b2)
+}
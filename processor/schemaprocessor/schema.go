@@ -0,0 +1,321 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schemaprocessor
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// schemaFile is the content of a schema file, as defined by
+// https://github.com/open-telemetry/oteps/blob/main/text/0152-telemetry-schemas.md.
+type schemaFile struct {
+	FileFormat string                       `yaml:"file_format"`
+	SchemaURL  string                       `yaml:"schema_url"`
+	Versions   map[string]versionDefinition `yaml:"versions"`
+}
+
+// versionDefinition lists the changes made by a version compared to the previous one.
+type versionDefinition struct {
+	All        sectionDefinition `yaml:"all"`
+	Resources  sectionDefinition `yaml:"resources"`
+	Spans      sectionDefinition `yaml:"spans"`
+	SpanEvents sectionDefinition `yaml:"span_events"`
+	Metrics    sectionDefinition `yaml:"metrics"`
+	Logs       sectionDefinition `yaml:"logs"`
+}
+
+type sectionDefinition struct {
+	Changes []changeDefinition `yaml:"changes"`
+}
+
+// changeDefinition is a single change. Exactly one of its fields is set.
+type changeDefinition struct {
+	RenameAttributes *renameAttributesDefinition `yaml:"rename_attributes"`
+	RenameMetrics    map[string]string           `yaml:"rename_metrics"`
+	RenameEvents     *renameEventsDefinition     `yaml:"rename_events"`
+}
+
+type renameAttributesDefinition struct {
+	AttributeMap   map[string]string `yaml:"attribute_map"`
+	ApplyToSpans   []string          `yaml:"apply_to_spans"`
+	ApplyToEvents  []string          `yaml:"apply_to_events"`
+	ApplyToMetrics []string          `yaml:"apply_to_metrics"`
+}
+
+type renameEventsDefinition struct {
+	NameMap map[string]string `yaml:"name_map"`
+}
+
+// change renames attributes, or renames metrics or span events when names is set.
+// applyTo restricts attribute renames to the listed span or metric names, applyToEvents to
+// the listed span event names.
+type change struct {
+	attributes    map[string]string
+	names         map[string]string
+	applyTo       map[string]bool
+	applyToEvents map[string]bool
+}
+
+// section is the ordered list of changes applied to a kind of telemetry.
+type section []change
+
+// step holds the changes to apply to go from one version of the schema to the next one,
+// or to the previous one when translating to an older version.
+type step struct {
+	all        section
+	resources  section
+	spans      section
+	spanEvents section
+	metrics    section
+	logs       section
+}
+
+type version struct {
+	name string
+	num  [3]int
+	step step
+}
+
+// schema is a loaded schema file.
+type schema struct {
+	family   string
+	versions []version // sorted oldest first
+}
+
+// loadSchema reads and parses the schema file at path.
+func loadSchema(path string) (*schema, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return parseSchema(b)
+}
+
+func parseSchema(b []byte) (*schema, error) {
+	var file schemaFile
+	if err := yaml.UnmarshalStrict(b, &file); err != nil {
+		return nil, err
+	}
+	if !strings.HasPrefix(file.FileFormat, "1.") {
+		return nil, fmt.Errorf("unsupported file_format %q", file.FileFormat)
+	}
+	family, _, err := parseSchemaURL(file.SchemaURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid schema_url: %w", err)
+	}
+
+	s := &schema{family: family}
+	for name, def := range file.Versions {
+		num, err := parseVersion(name)
+		if err != nil {
+			return nil, err
+		}
+		st, err := def.step()
+		if err != nil {
+			return nil, fmt.Errorf("version %s: %w", name, err)
+		}
+		s.versions = append(s.versions, version{name: name, num: num, step: st})
+	}
+	sort.Slice(s.versions, func(i, j int) bool {
+		return compareVersions(s.versions[i].num, s.versions[j].num) < 0
+	})
+	return s, nil
+}
+
+// plan returns the steps translating telemetry from the source to the target schema URL.
+func (s *schema) plan(source, target string) ([]step, error) {
+	from, err := s.versionIndex(source)
+	if err != nil {
+		return nil, err
+	}
+	to, err := s.versionIndex(target)
+	if err != nil {
+		return nil, err
+	}
+
+	var steps []step
+	// The changes of a version describe how to reach it from the previous version.
+	for i := from + 1; i <= to; i++ {
+		steps = append(steps, s.versions[i].step)
+	}
+	for i := from; i > to; i-- {
+		steps = append(steps, s.versions[i].step.reverse())
+	}
+	return steps, nil
+}
+
+func (s *schema) versionIndex(schemaURL string) (int, error) {
+	family, name, err := parseSchemaURL(schemaURL)
+	if err != nil {
+		return 0, err
+	}
+	if family != s.family {
+		return 0, fmt.Errorf("schema URL %q does not belong to schema family %q", schemaURL, s.family)
+	}
+	for i, v := range s.versions {
+		if v.name == name {
+			return i, nil
+		}
+	}
+	return 0, fmt.Errorf("version %s is not defined by schema family %q", name, s.family)
+}
+
+func (def versionDefinition) step() (step, error) {
+	var st step
+	var err error
+	if st.all, err = def.All.section(); err != nil {
+		return st, fmt.Errorf("all: %w", err)
+	}
+	if st.resources, err = def.Resources.section(); err != nil {
+		return st, fmt.Errorf("resources: %w", err)
+	}
+	if st.spans, err = def.Spans.section(); err != nil {
+		return st, fmt.Errorf("spans: %w", err)
+	}
+	if st.spanEvents, err = def.SpanEvents.section(); err != nil {
+		return st, fmt.Errorf("span_events: %w", err)
+	}
+	if st.metrics, err = def.Metrics.section(); err != nil {
+		return st, fmt.Errorf("metrics: %w", err)
+	}
+	if st.logs, err = def.Logs.section(); err != nil {
+		return st, fmt.Errorf("logs: %w", err)
+	}
+	return st, nil
+}
+
+func (def sectionDefinition) section() (section, error) {
+	var sec section
+	for _, c := range def.Changes {
+		switch {
+		case c.RenameAttributes != nil:
+			applyTo := append(append([]string{}, c.RenameAttributes.ApplyToSpans...), c.RenameAttributes.ApplyToMetrics...)
+			sec = append(sec, change{
+				attributes:    c.RenameAttributes.AttributeMap,
+				applyTo:       toSet(applyTo),
+				applyToEvents: toSet(c.RenameAttributes.ApplyToEvents),
+			})
+		case c.RenameMetrics != nil:
+			sec = append(sec, change{names: c.RenameMetrics})
+		case c.RenameEvents != nil:
+			sec = append(sec, change{names: c.RenameEvents.NameMap})
+		default:
+			return nil, fmt.Errorf("unsupported change")
+		}
+	}
+	return sec, nil
+}
+
+// reverse returns the step undoing st.
+func (st step) reverse() step {
+	return step{
+		all:        st.all.reverse(),
+		resources:  st.resources.reverse(),
+		spans:      st.spans.reverse(),
+		spanEvents: st.spanEvents.reverse(),
+		metrics:    st.metrics.reverse(),
+		logs:       st.logs.reverse(),
+	}
+}
+
+// reverse returns the section undoing sec: its changes are inverted and applied in reverse order.
+func (sec section) reverse() section {
+	if len(sec) == 0 {
+		return nil
+	}
+	reversed := make(section, 0, len(sec))
+	for i := len(sec) - 1; i >= 0; i-- {
+		c := sec[i]
+		reversed = append(reversed, change{
+			attributes:    invert(c.attributes),
+			names:         invert(c.names),
+			applyTo:       c.applyTo,
+			applyToEvents: c.applyToEvents,
+		})
+	}
+	return reversed
+}
+
+// parseSchemaURL splits a schema URL into its family and version, e.g.
+// "https://opentelemetry.io/schemas/1.4.0" into "https://opentelemetry.io/schemas" and "1.4.0".
+func parseSchemaURL(schemaURL string) (string, string, error) {
+	u, err := url.Parse(schemaURL)
+	if err != nil {
+		return "", "", err
+	}
+	if u.Scheme == "" || u.Host == "" {
+		return "", "", fmt.Errorf("schema URL %q must be absolute", schemaURL)
+	}
+	i := strings.LastIndexByte(schemaURL, '/')
+	name := schemaURL[i+1:]
+	if _, err := parseVersion(name); err != nil {
+		return "", "", err
+	}
+	return schemaURL[:i], name, nil
+}
+
+func parseVersion(name string) ([3]int, error) {
+	var num [3]int
+	parts := strings.Split(name, ".")
+	if len(parts) == 0 || len(parts) > 3 {
+		return num, fmt.Errorf("invalid version %q", name)
+	}
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 0 {
+			return num, fmt.Errorf("invalid version %q", name)
+		}
+		num[i] = n
+	}
+	return num, nil
+}
+
+func compareVersions(a, b [3]int) int {
+	for i := range a {
+		if a[i] != b[i] {
+			return a[i] - b[i]
+		}
+	}
+	return 0
+}
+
+func toSet(names []string) map[string]bool {
+	if len(names) == 0 {
+		return nil
+	}
+	set := make(map[string]bool, len(names))
+	for _, name := range names {
+		set[name] = true
+	}
+	return set
+}
+
+func invert(m map[string]string) map[string]string {
+	if m == nil {
+		return nil
+	}
+	inverted := make(map[string]string, len(m))
+	for k, v := range m {
+		inverted[v] = k
+	}
+	return inverted
+}
@@ -0,0 +1,115 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schemaprocessor
+
+import (
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	schemaV100 = "https://opentelemetry.io/schemas/1.0.0"
+	schemaV110 = "https://opentelemetry.io/schemas/1.1.0"
+	schemaV120 = "https://opentelemetry.io/schemas/1.2.0"
+)
+
+func TestLoadSchema(t *testing.T) {
+	s, err := loadSchema(path.Join("testdata", "schema.yaml"))
+	require.NoError(t, err)
+	assert.Equal(t, "https://opentelemetry.io/schemas", s.family)
+
+	var names []string
+	for _, v := range s.versions {
+		names = append(names, v.name)
+	}
+	assert.Equal(t, []string{"1.0.0", "1.1.0", "1.2.0"}, names)
+
+	_, err = loadSchema(path.Join("testdata", "missing.yaml"))
+	assert.Error(t, err)
+}
+
+func TestParseSchemaErrors(t *testing.T) {
+	cases := []struct {
+		name     string
+		content  string
+		expected string
+	}{
+		{
+			name:     "file_format",
+			content:  "file_format: 2.0.0\nschema_url: https://opentelemetry.io/schemas/1.0.0\n",
+			expected: `unsupported file_format "2.0.0"`,
+		},
+		{
+			name:     "schema_url",
+			content:  "file_format: 1.0.0\nschema_url: opentelemetry\n",
+			expected: `invalid schema_url: schema URL "opentelemetry" must be absolute`,
+		},
+		{
+			name:     "version",
+			content:  "file_format: 1.0.0\nschema_url: https://opentelemetry.io/schemas/1.0.0\nversions:\n  next:\n",
+			expected: `invalid version "next"`,
+		},
+		{
+			name: "change",
+			content: `file_format: 1.0.0
+schema_url: https://opentelemetry.io/schemas/1.0.0
+versions:
+  1.0.0:
+    spans:
+      changes:
+        - {}
+`,
+			expected: "version 1.0.0: spans: unsupported change",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := parseSchema([]byte(tc.content))
+			assert.EqualError(t, err, tc.expected)
+		})
+	}
+}
+
+func TestPlan(t *testing.T) {
+	s, err := loadSchema(path.Join("testdata", "schema.yaml"))
+	require.NoError(t, err)
+
+	steps, err := s.plan(schemaV100, schemaV120)
+	require.NoError(t, err)
+	require.Len(t, steps, 2)
+	assert.Equal(t, "telemetry.auto_version", steps[0].resources[0].attributes["telemetry.auto.version"])
+	assert.Equal(t, "container.cpu.usage", steps[1].metrics[0].names["container.cpu.usage.total"])
+
+	steps, err = s.plan(schemaV120, schemaV110)
+	require.NoError(t, err)
+	require.Len(t, steps, 1)
+	// Reversed changes are applied in reverse order
+	assert.Equal(t, "state", steps[0].metrics[0].attributes["cpu.state"])
+	assert.Equal(t, "container.cpu.usage.total", steps[0].metrics[1].names["container.cpu.usage"])
+
+	steps, err = s.plan(schemaV110, schemaV110)
+	require.NoError(t, err)
+	assert.Empty(t, steps)
+
+	_, err = s.plan("https://opentelemetry.io/schemas/0.9.0", schemaV120)
+	assert.EqualError(t, err, `version 0.9.0 is not defined by schema family "https://opentelemetry.io/schemas"`)
+
+	_, err = s.plan("https://example.com/schemas/1.0.0", schemaV120)
+	assert.EqualError(t, err, `schema URL "https://example.com/schemas/1.0.0" does not belong to schema family "https://opentelemetry.io/schemas"`)
+}
@@ -0,0 +1,174 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schemaprocessor
+
+import (
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+// renameAttributes renames the keys of attrs according to renames. The values are all read
+// before any is written so that renames swapping two keys work.
+func renameAttributes(attrs pdata.AttributeMap, renames map[string]string) {
+	type renamed struct {
+		key   string
+		value pdata.AttributeValue
+	}
+	var found []renamed
+	for from, to := range renames {
+		v, ok := attrs.Get(from)
+		if !ok {
+			continue
+		}
+		value := pdata.NewAttributeValueNull()
+		v.CopyTo(value)
+		found = append(found, renamed{key: to, value: value})
+		attrs.Delete(from)
+	}
+	for _, r := range found {
+		attrs.Upsert(r.key, r.value)
+	}
+}
+
+// renameLabels is renameAttributes for metric data point labels.
+func renameLabels(labels pdata.StringMap, renames map[string]string) {
+	type renamed struct {
+		key   string
+		value string
+	}
+	var found []renamed
+	for from, to := range renames {
+		v, ok := labels.Get(from)
+		if !ok {
+			continue
+		}
+		found = append(found, renamed{key: to, value: v})
+		labels.Delete(from)
+	}
+	for _, r := range found {
+		labels.Upsert(r.key, r.value)
+	}
+}
+
+func (sec section) applyToAttributes(attrs pdata.AttributeMap) {
+	for _, c := range sec {
+		if c.attributes != nil {
+			renameAttributes(attrs, c.attributes)
+		}
+	}
+}
+
+func (st step) applyToResource(resource pdata.Resource) {
+	st.all.applyToAttributes(resource.Attributes())
+	st.resources.applyToAttributes(resource.Attributes())
+}
+
+func (st step) applyToSpan(span pdata.Span) {
+	st.all.applyToAttributes(span.Attributes())
+	for _, c := range st.spans {
+		if c.attributes != nil && matches(c.applyTo, span.Name()) {
+			renameAttributes(span.Attributes(), c.attributes)
+		}
+	}
+
+	events := span.Events()
+	for i := 0; i < events.Len(); i++ {
+		event := events.At(i)
+		st.all.applyToAttributes(event.Attributes())
+		for _, c := range st.spanEvents {
+			switch {
+			case c.names != nil:
+				if to, ok := c.names[event.Name()]; ok {
+					event.SetName(to)
+				}
+			case matches(c.applyTo, span.Name()) && matches(c.applyToEvents, event.Name()):
+				renameAttributes(event.Attributes(), c.attributes)
+			}
+		}
+	}
+}
+
+func (st step) applyToMetric(metric pdata.Metric) {
+	forEachLabels(metric, func(labels pdata.StringMap) {
+		for _, c := range st.all {
+			if c.attributes != nil {
+				renameLabels(labels, c.attributes)
+			}
+		}
+	})
+	for _, c := range st.metrics {
+		if c.names != nil {
+			if to, ok := c.names[metric.Name()]; ok {
+				metric.SetName(to)
+			}
+			continue
+		}
+		if matches(c.applyTo, metric.Name()) {
+			forEachLabels(metric, func(labels pdata.StringMap) {
+				renameLabels(labels, c.attributes)
+			})
+		}
+	}
+}
+
+func (st step) applyToLogRecord(lr pdata.LogRecord) {
+	st.all.applyToAttributes(lr.Attributes())
+	st.logs.applyToAttributes(lr.Attributes())
+}
+
+// matches reports whether name is in set, an empty set matching any name.
+func matches(set map[string]bool, name string) bool {
+	return len(set) == 0 || set[name]
+}
+
+// forEachLabels calls fn with the labels of each data point of metric.
+func forEachLabels(metric pdata.Metric, fn func(pdata.StringMap)) {
+	switch metric.DataType() {
+	case pdata.MetricDataTypeIntGauge:
+		dps := metric.IntGauge().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			fn(dps.At(i).LabelsMap())
+		}
+	case pdata.MetricDataTypeDoubleGauge:
+		dps := metric.DoubleGauge().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			fn(dps.At(i).LabelsMap())
+		}
+	case pdata.MetricDataTypeIntSum:
+		dps := metric.IntSum().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			fn(dps.At(i).LabelsMap())
+		}
+	case pdata.MetricDataTypeDoubleSum:
+		dps := metric.DoubleSum().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			fn(dps.At(i).LabelsMap())
+		}
+	case pdata.MetricDataTypeIntHistogram:
+		dps := metric.IntHistogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			fn(dps.At(i).LabelsMap())
+		}
+	case pdata.MetricDataTypeHistogram:
+		dps := metric.Histogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			fn(dps.At(i).LabelsMap())
+		}
+	case pdata.MetricDataTypeSummary:
+		dps := metric.Summary().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			fn(dps.At(i).LabelsMap())
+		}
+	}
+}
@@ -0,0 +1,68 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package servicegraphprocessor
+
+import (
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/collector/config"
+)
+
+// Config defines the configuration options for servicegraphprocessor.
+type Config struct {
+	config.ProcessorSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+
+	// MetricsExporter is the name of the metrics exporter to use to ship metrics.
+	MetricsExporter string `mapstructure:"metrics_exporter"`
+
+	// LatencyHistogramBuckets is the list of durations representing latency histogram buckets.
+	// See defaultLatencyHistogramBucketsSec in processor.go for the default value.
+	LatencyHistogramBuckets []time.Duration `mapstructure:"latency_histogram_buckets"`
+
+	// Dimensions defines the list of additional span attributes added to each edge. Each attribute is
+	// looked up on both the client and the server span, and added with a "client_" or "server_" prefix.
+	Dimensions []string `mapstructure:"dimensions"`
+
+	// Store holds the configuration of the in-memory store of incomplete edges.
+	Store StoreConfig `mapstructure:"store"`
+}
+
+// StoreConfig defines the configuration of the in-memory store of incomplete edges.
+type StoreConfig struct {
+	// MaxItems is the maximum number of incomplete edges held in memory. Spans that would
+	// create new edges once the limit is reached are dropped.
+	MaxItems int `mapstructure:"max_items"`
+
+	// TTL is how long an edge waits for its counterpart span before being expired.
+	TTL time.Duration `mapstructure:"ttl"`
+}
+
+// Validate checks that the processor configuration is valid.
+func (cfg *Config) Validate() error {
+	if cfg.Store.MaxItems <= 0 {
+		return errors.New("store.max_items must be greater than zero")
+	}
+	if cfg.Store.TTL <= 0 {
+		return errors.New("store.ttl must be greater than zero")
+	}
+	// Bucket lookup is a binary search over the bounds, which must therefore be sorted.
+	for i := 1; i < len(cfg.LatencyHistogramBuckets); i++ {
+		if cfg.LatencyHistogramBuckets[i] <= cfg.LatencyHistogramBuckets[i-1] {
+			return errors.New("latency_histogram_buckets must be strictly increasing")
+		}
+	}
+	return nil
+}
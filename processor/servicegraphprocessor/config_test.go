@@ -0,0 +1,80 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package servicegraphprocessor
+
+import (
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configtest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	// Prepare
+	factories, err := componenttest.NopFactories()
+	require.NoError(t, err)
+
+	factories.Processors[typeStr] = NewFactory()
+
+	// Test
+	cfg, err := configtest.LoadConfigAndValidate(path.Join(".", "testdata", "config.yaml"), factories)
+
+	// Verify
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+	assert.Equal(t,
+		&Config{
+			ProcessorSettings: config.NewProcessorSettings(config.NewID(typeStr)),
+			MetricsExporter:   "nop/servicegraph",
+			LatencyHistogramBuckets: []time.Duration{
+				100 * time.Millisecond,
+				250 * time.Millisecond,
+				time.Second,
+				5 * time.Second,
+				10 * time.Second,
+			},
+			Dimensions: []string{"http.method", "db.system"},
+			Store: StoreConfig{
+				TTL:      time.Second,
+				MaxItems: 10,
+			},
+		},
+		cfg.Processors[config.NewID(typeStr)],
+	)
+}
+
+func TestValidateConfig(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	assert.NoError(t, cfg.Validate())
+
+	cfg.Store.MaxItems = 0
+	assert.EqualError(t, cfg.Validate(), "store.max_items must be greater than zero")
+
+	cfg = createDefaultConfig().(*Config)
+	cfg.Store.TTL = 0
+	assert.EqualError(t, cfg.Validate(), "store.ttl must be greater than zero")
+
+	cfg = createDefaultConfig().(*Config)
+	cfg.LatencyHistogramBuckets = []time.Duration{time.Second, 100 * time.Millisecond}
+	assert.EqualError(t, cfg.Validate(), "latency_histogram_buckets must be strictly increasing")
+
+	cfg.LatencyHistogramBuckets = []time.Duration{time.Second, time.Second}
+	assert.EqualError(t, cfg.Validate(), "latency_histogram_buckets must be strictly increasing")
+}
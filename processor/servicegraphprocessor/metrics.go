@@ -0,0 +1,59 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package servicegraphprocessor
+
+import (
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opentelemetry.io/collector/obsreport"
+)
+
+var (
+	mNumEdgesInStore = stats.Int64("processor_servicegraph_num_edges_in_store", "Number of incomplete edges currently in the in-memory store", stats.UnitDimensionless)
+	mCompletedEdges  = stats.Int64("processor_servicegraph_completed_edges", "Edges for which both the client and server span were seen", stats.UnitDimensionless)
+	mExpiredEdges    = stats.Int64("processor_servicegraph_expired_edges", "Edges evicted from the store before their counterpart span arrived", stats.UnitDimensionless)
+	mDroppedSpans    = stats.Int64("processor_servicegraph_dropped_spans", "Spans dropped because the store was full", stats.UnitDimensionless)
+)
+
+// MetricViews return the metrics views according to given telemetry level.
+func MetricViews() []*view.View {
+	return []*view.View{
+		{
+			Name:        obsreport.BuildProcessorCustomMetricName(string(typeStr), mNumEdgesInStore.Name()),
+			Measure:     mNumEdgesInStore,
+			Description: mNumEdgesInStore.Description(),
+			Aggregation: view.LastValue(),
+		},
+		{
+			Name:        obsreport.BuildProcessorCustomMetricName(string(typeStr), mCompletedEdges.Name()),
+			Measure:     mCompletedEdges,
+			Description: mCompletedEdges.Description(),
+			Aggregation: view.Sum(),
+		},
+		{
+			Name:        obsreport.BuildProcessorCustomMetricName(string(typeStr), mExpiredEdges.Name()),
+			Measure:     mExpiredEdges,
+			Description: mExpiredEdges.Description(),
+			// sum allows us to start from 0, count will only show up if there's at least one eviction
+			Aggregation: view.Sum(),
+		},
+		{
+			Name:        obsreport.BuildProcessorCustomMetricName(string(typeStr), mDroppedSpans.Name()),
+			Measure:     mDroppedSpans,
+			Description: mDroppedSpans.Description(),
+			Aggregation: view.Sum(),
+		},
+	}
+}
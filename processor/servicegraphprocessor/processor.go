@@ -0,0 +1,382 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package servicegraphprocessor
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opencensus.io/stats"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/translator/conventions"
+	tracetranslator "go.opentelemetry.io/collector/translator/trace"
+	"go.uber.org/zap"
+)
+
+const (
+	clientKey          = "client"
+	serverKey          = "server"
+	metricKeySeparator = string(byte(0))
+
+	// expireInterval is how often the store is checked for expired edges.
+	expireInterval = time.Second
+)
+
+var (
+	defaultLatencyHistogramBucketsSec = []float64{
+		0.1, 0.2, 0.4, 0.8, 1.6, 3.2, 6.4, 12.8,
+	}
+)
+
+type metricKey string
+
+type processorImp struct {
+	lock   sync.RWMutex
+	logger *zap.Logger
+	config Config
+
+	metricsExporter component.MetricsExporter
+	nextConsumer    consumer.Traces
+
+	store *store
+
+	// The starting time of the data points.
+	startTime time.Time
+
+	// Request & failed request counts.
+	requestTotal       map[metricKey]int64
+	requestFailedTotal map[metricKey]int64
+
+	// Client and server latency histograms.
+	clientLatencyCount        map[metricKey]uint64
+	clientLatencySum          map[metricKey]float64
+	clientLatencyBucketCounts map[metricKey][]uint64
+	serverLatencyCount        map[metricKey]uint64
+	serverLatencySum          map[metricKey]float64
+	serverLatencyBucketCounts map[metricKey][]uint64
+	latencyBounds             []float64
+
+	// A cache of dimension key-value maps keyed by a unique identifier formed by a concatenation of its values.
+	metricKeyToDimensions map[metricKey]map[string]string
+
+	shutdownCh chan struct{}
+}
+
+func newProcessor(logger *zap.Logger, config config.Processor, nextConsumer consumer.Traces) *processorImp {
+	logger.Info("Building servicegraphprocessor")
+	pConfig := config.(*Config)
+
+	bounds := defaultLatencyHistogramBucketsSec
+	if pConfig.LatencyHistogramBuckets != nil {
+		bounds = make([]float64, len(pConfig.LatencyHistogramBuckets))
+		for i, d := range pConfig.LatencyHistogramBuckets {
+			bounds[i] = d.Seconds()
+		}
+	}
+
+	p := &processorImp{
+		logger:                    logger,
+		config:                    *pConfig,
+		nextConsumer:              nextConsumer,
+		startTime:                 time.Now(),
+		requestTotal:              make(map[metricKey]int64),
+		requestFailedTotal:        make(map[metricKey]int64),
+		clientLatencyCount:        make(map[metricKey]uint64),
+		clientLatencySum:          make(map[metricKey]float64),
+		clientLatencyBucketCounts: make(map[metricKey][]uint64),
+		serverLatencyCount:        make(map[metricKey]uint64),
+		serverLatencySum:          make(map[metricKey]float64),
+		serverLatencyBucketCounts: make(map[metricKey][]uint64),
+		latencyBounds:             bounds,
+		metricKeyToDimensions:     make(map[metricKey]map[string]string),
+		shutdownCh:                make(chan struct{}),
+	}
+	p.store = newStore(pConfig.Store.TTL, pConfig.Store.MaxItems, p.onComplete, p.onExpire)
+
+	return p
+}
+
+// Start implements the component.Component interface.
+func (p *processorImp) Start(_ context.Context, host component.Host) error {
+	p.logger.Info("Starting servicegraphprocessor")
+	exporters := host.GetExporters()
+
+	var availableMetricsExporters []string
+
+	// The available list of exporters come from any configured metrics pipelines' exporters.
+	for k, exp := range exporters[config.MetricsDataType] {
+		metricsExp, ok := exp.(component.MetricsExporter)
+		if !ok {
+			return fmt.Errorf("the exporter %q isn't a metrics exporter", k.String())
+		}
+
+		availableMetricsExporters = append(availableMetricsExporters, k.String())
+
+		if k.String() == p.config.MetricsExporter {
+			p.metricsExporter = metricsExp
+			p.logger.Info("Found exporter", zap.String("servicegraph-exporter", p.config.MetricsExporter))
+			break
+		}
+	}
+	if p.metricsExporter == nil {
+		return fmt.Errorf("failed to find metrics exporter: '%s'; please configure metrics_exporter from one of: %+v",
+			p.config.MetricsExporter, availableMetricsExporters)
+	}
+
+	go p.expireLoop()
+
+	p.logger.Info("Started servicegraphprocessor")
+	return nil
+}
+
+// Shutdown implements the component.Component interface.
+func (p *processorImp) Shutdown(context.Context) error {
+	p.logger.Info("Shutting down servicegraphprocessor")
+	close(p.shutdownCh)
+	return nil
+}
+
+// Capabilities implements the consumer interface.
+func (p *processorImp) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
+
+// ConsumeTraces implements the consumer.Traces interface.
+// It pairs client and server spans into edges, forwarding the resulting metrics to the discovered metrics exporter.
+// The original input trace data will be forwarded to the next consumer, unmodified.
+func (p *processorImp) ConsumeTraces(ctx context.Context, traces pdata.Traces) error {
+	p.aggregateEdges(ctx, traces)
+
+	m := p.buildMetrics()
+
+	// Firstly, export metrics to avoid being impacted by downstream trace processor errors/latency.
+	if err := p.metricsExporter.ConsumeMetrics(ctx, m); err != nil {
+		return err
+	}
+
+	// Forward trace data unmodified.
+	return p.nextConsumer.ConsumeTraces(ctx, traces)
+}
+
+func (p *processorImp) expireLoop() {
+	ticker := time.NewTicker(expireInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			p.store.expire()
+			stats.Record(context.Background(), mNumEdgesInStore.M(int64(p.store.len())))
+		case <-p.shutdownCh:
+			return
+		}
+	}
+}
+
+// aggregateEdges looks for client and server spans in the input trace data, and upserts
+// the edge they belong to. A client span and a server span belong to the same edge when
+// the client's span ID is the server's parent span ID.
+func (p *processorImp) aggregateEdges(ctx context.Context, traces pdata.Traces) {
+	for i := 0; i < traces.ResourceSpans().Len(); i++ {
+		rspans := traces.ResourceSpans().At(i)
+
+		attr, ok := rspans.Resource().Attributes().Get(conventions.AttributeServiceName)
+		if !ok {
+			continue
+		}
+		serviceName := attr.StringVal()
+
+		ilsSlice := rspans.InstrumentationLibrarySpans()
+		for j := 0; j < ilsSlice.Len(); j++ {
+			spans := ilsSlice.At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				span := spans.At(k)
+
+				var err error
+				switch span.Kind() {
+				case pdata.SpanKindClient:
+					key := buildEdgeKey(span.TraceID(), span.SpanID())
+					err = p.store.upsertEdge(key, func(e *edge) {
+						e.clientService = serviceName
+						e.clientLatencySec = spanDurationSec(span)
+						e.failed = e.failed || span.Status().Code() == pdata.StatusCodeError
+						p.upsertDimensions(clientKey, e.dimensions, span)
+					})
+				case pdata.SpanKindServer:
+					key := buildEdgeKey(span.TraceID(), span.ParentSpanID())
+					err = p.store.upsertEdge(key, func(e *edge) {
+						e.serverService = serviceName
+						e.serverLatencySec = spanDurationSec(span)
+						e.failed = e.failed || span.Status().Code() == pdata.StatusCodeError
+						p.upsertDimensions(serverKey, e.dimensions, span)
+					})
+				default:
+					continue
+				}
+
+				if err == errTooManyItems {
+					stats.Record(ctx, mDroppedSpans.M(1))
+				}
+			}
+		}
+	}
+}
+
+func (p *processorImp) upsertDimensions(prefix string, dims map[string]string, span pdata.Span) {
+	spanAttr := span.Attributes()
+	for _, d := range p.config.Dimensions {
+		if attr, ok := spanAttr.Get(d); ok {
+			dims[prefix+"_"+d] = tracetranslator.AttributeValueToString(attr)
+		}
+	}
+}
+
+func (p *processorImp) onComplete(e *edge) {
+	stats.Record(context.Background(), mCompletedEdges.M(1))
+
+	key := p.buildMetricKey(e)
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.cache(key, e)
+	p.requestTotal[key]++
+	if e.failed {
+		p.requestFailedTotal[key]++
+	}
+	p.updateLatencyMetrics(p.clientLatencyCount, p.clientLatencySum, p.clientLatencyBucketCounts, key, e.clientLatencySec)
+	p.updateLatencyMetrics(p.serverLatencyCount, p.serverLatencySum, p.serverLatencyBucketCounts, key, e.serverLatencySec)
+}
+
+func (p *processorImp) onExpire(*edge) {
+	stats.Record(context.Background(), mExpiredEdges.M(1))
+}
+
+// updateLatencyMetrics increments the histogram counts for the given metric key and latency.
+func (p *processorImp) updateLatencyMetrics(count map[metricKey]uint64, sum map[metricKey]float64, bucketCounts map[metricKey][]uint64, key metricKey, latency float64) {
+	if _, ok := bucketCounts[key]; !ok {
+		bucketCounts[key] = make([]uint64, len(p.latencyBounds)+1)
+	}
+
+	// Binary search to find the latency bucket index, the last bucket being the catch-all one.
+	index := sort.SearchFloat64s(p.latencyBounds, latency)
+
+	sum[key] += latency
+	count[key]++
+	bucketCounts[key][index]++
+}
+
+// buildMetricKey builds the metric key from the client and server service names, and the configured dimensions.
+// The metric key is a simple concatenation of dimension values.
+func (p *processorImp) buildMetricKey(e *edge) metricKey {
+	var metricKeyBuilder strings.Builder
+	metricKeyBuilder.WriteString(e.clientService)
+	metricKeyBuilder.WriteString(metricKeySeparator)
+	metricKeyBuilder.WriteString(e.serverService)
+
+	for _, d := range p.config.Dimensions {
+		for _, prefix := range []string{clientKey, serverKey} {
+			metricKeyBuilder.WriteString(metricKeySeparator)
+			metricKeyBuilder.WriteString(e.dimensions[prefix+"_"+d])
+		}
+	}
+
+	return metricKey(metricKeyBuilder.String())
+}
+
+// cache the dimension key-value map for the metricKey if there is a cache miss.
+func (p *processorImp) cache(k metricKey, e *edge) {
+	if _, ok := p.metricKeyToDimensions[k]; ok {
+		return
+	}
+
+	dims := make(map[string]string, len(e.dimensions)+2)
+	dims[clientKey] = e.clientService
+	dims[serverKey] = e.serverService
+	for name, value := range e.dimensions {
+		dims[name] = value
+	}
+	p.metricKeyToDimensions[k] = dims
+}
+
+// buildMetrics collects the computed raw metrics data and builds the metrics object.
+func (p *processorImp) buildMetrics() pdata.Metrics {
+	m := pdata.NewMetrics()
+	ilm := m.ResourceMetrics().AppendEmpty().InstrumentationLibraryMetrics().AppendEmpty()
+	ilm.InstrumentationLibrary().SetName("servicegraphprocessor")
+
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	p.collectCountMetrics(ilm, "traces_service_graph_request_total", p.requestTotal)
+	p.collectCountMetrics(ilm, "traces_service_graph_request_failed_total", p.requestFailedTotal)
+	p.collectLatencyMetrics(ilm, "traces_service_graph_request_client_seconds", p.clientLatencyCount, p.clientLatencySum, p.clientLatencyBucketCounts)
+	p.collectLatencyMetrics(ilm, "traces_service_graph_request_server_seconds", p.serverLatencyCount, p.serverLatencySum, p.serverLatencyBucketCounts)
+
+	return m
+}
+
+// collectCountMetrics writes the given request counts into the given instrumentation library metrics.
+func (p *processorImp) collectCountMetrics(ilm pdata.InstrumentationLibraryMetrics, name string, counts map[metricKey]int64) {
+	for key, count := range counts {
+		mCount := ilm.Metrics().AppendEmpty()
+		mCount.SetDataType(pdata.MetricDataTypeIntSum)
+		mCount.SetName(name)
+		mCount.IntSum().SetIsMonotonic(true)
+		mCount.IntSum().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+
+		dp := mCount.IntSum().DataPoints().AppendEmpty()
+		dp.SetStartTimestamp(pdata.TimestampFromTime(p.startTime))
+		dp.SetTimestamp(pdata.TimestampFromTime(time.Now()))
+		dp.SetValue(count)
+
+		dp.LabelsMap().InitFromMap(p.metricKeyToDimensions[key])
+	}
+}
+
+// collectLatencyMetrics writes the given latency histograms into the given instrumentation library metrics.
+func (p *processorImp) collectLatencyMetrics(ilm pdata.InstrumentationLibraryMetrics, name string, count map[metricKey]uint64, sum map[metricKey]float64, bucketCounts map[metricKey][]uint64) {
+	for key := range count {
+		mLatency := ilm.Metrics().AppendEmpty()
+		mLatency.SetDataType(pdata.MetricDataTypeHistogram)
+		mLatency.SetName(name)
+		mLatency.Histogram().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+
+		dp := mLatency.Histogram().DataPoints().AppendEmpty()
+		dp.SetStartTimestamp(pdata.TimestampFromTime(p.startTime))
+		dp.SetTimestamp(pdata.TimestampFromTime(time.Now()))
+		dp.SetExplicitBounds(p.latencyBounds)
+		dp.SetBucketCounts(append([]uint64(nil), bucketCounts[key]...))
+		dp.SetCount(count[key])
+		dp.SetSum(sum[key])
+
+		dp.LabelsMap().InitFromMap(p.metricKeyToDimensions[key])
+	}
+}
+
+func buildEdgeKey(traceID pdata.TraceID, spanID pdata.SpanID) string {
+	return traceID.HexString() + spanID.HexString()
+}
+
+func spanDurationSec(span pdata.Span) float64 {
+	return float64(span.EndTimestamp()-span.StartTimestamp()) / float64(time.Second.Nanoseconds())
+}
@@ -0,0 +1,229 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package servicegraphprocessor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/translator/conventions"
+	"go.uber.org/zap"
+)
+
+type mockHost struct {
+	component.Host
+	exporters map[config.DataType]map[config.ComponentID]component.Exporter
+}
+
+func (h *mockHost) GetExporters() map[config.DataType]map[config.ComponentID]component.Exporter {
+	return h.exporters
+}
+
+type mockMetricsExporter struct {
+	component.Component
+	*consumertest.MetricsSink
+}
+
+type mockTracesExporter struct {
+	component.Component
+	*consumertest.TracesSink
+}
+
+func newMockHost(id config.ComponentID, exp component.Exporter) component.Host {
+	return &mockHost{
+		Host: componenttest.NewNopHost(),
+		exporters: map[config.DataType]map[config.ComponentID]component.Exporter{
+			config.MetricsDataType: {id: exp},
+		},
+	}
+}
+
+func TestProcessorStart(t *testing.T) {
+	mexp := &mockMetricsExporter{MetricsSink: new(consumertest.MetricsSink)}
+	texp := &mockTracesExporter{TracesSink: new(consumertest.TracesSink)}
+
+	for _, tc := range []struct {
+		name            string
+		exporter        component.Exporter
+		metricsExporter string
+		wantErrorMsg    string
+	}{
+		{"export to active metrics exporter", mexp, "otlp", ""},
+		{"unable to find configured exporter in active exporter list", mexp, "prometheus", "failed to find metrics exporter: 'prometheus'; please configure metrics_exporter from one of: [otlp]"},
+		{"export to active traces exporter should error", texp, "otlp", "the exporter \"otlp\" isn't a metrics exporter"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			// Prepare
+			cfg := createDefaultConfig().(*Config)
+			cfg.MetricsExporter = tc.metricsExporter
+			p := newProcessor(zap.NewNop(), cfg, consumertest.NewNop())
+
+			// Test
+			err := p.Start(context.Background(), newMockHost(config.NewID("otlp"), tc.exporter))
+
+			// Verify
+			if tc.wantErrorMsg != "" {
+				assert.EqualError(t, err, tc.wantErrorMsg)
+			} else {
+				assert.NoError(t, err)
+				assert.NoError(t, p.Shutdown(context.Background()))
+			}
+		})
+	}
+}
+
+func TestProcessorConsumeTraces(t *testing.T) {
+	// Prepare
+	mexp := &mockMetricsExporter{MetricsSink: new(consumertest.MetricsSink)}
+	next := new(consumertest.TracesSink)
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.MetricsExporter = "otlp"
+	cfg.Dimensions = []string{"http.method"}
+	p := newProcessor(zap.NewNop(), cfg, next)
+	require.NoError(t, p.Start(context.Background(), newMockHost(config.NewID("otlp"), mexp)))
+	defer func() { require.NoError(t, p.Shutdown(context.Background())) }()
+
+	// Test
+	require.NoError(t, p.ConsumeTraces(context.Background(), buildSampleTrace()))
+
+	// Verify
+	assert.Equal(t, 3, next.SpansCount())
+	require.Len(t, mexp.AllMetrics(), 1)
+
+	rms := mexp.AllMetrics()[0].ResourceMetrics()
+	require.Equal(t, 1, rms.Len())
+	metrics := rms.At(0).InstrumentationLibraryMetrics().At(0).Metrics()
+
+	wantLabels := map[string]string{
+		"client":             "frontend",
+		"server":             "backend",
+		"client_http.method": "GET",
+		"server_http.method": "GET",
+	}
+	seen := make(map[string]bool)
+	for i := 0; i < metrics.Len(); i++ {
+		m := metrics.At(i)
+		seen[m.Name()] = true
+
+		switch m.Name() {
+		case "traces_service_graph_request_total", "traces_service_graph_request_failed_total":
+			dp := m.IntSum().DataPoints().At(0)
+			assert.Equal(t, int64(1), dp.Value())
+			assert.Equal(t, wantLabels, labelsAsMap(dp.LabelsMap()))
+		case "traces_service_graph_request_client_seconds":
+			dp := m.Histogram().DataPoints().At(0)
+			assert.Equal(t, uint64(1), dp.Count())
+			assert.InDelta(t, 2, dp.Sum(), 0.0001)
+			assert.Equal(t, []uint64{0, 0, 0, 0, 0, 1, 0, 0, 0}, dp.BucketCounts())
+			assert.Equal(t, wantLabels, labelsAsMap(dp.LabelsMap()))
+		case "traces_service_graph_request_server_seconds":
+			dp := m.Histogram().DataPoints().At(0)
+			assert.Equal(t, uint64(1), dp.Count())
+			assert.InDelta(t, 1, dp.Sum(), 0.0001)
+			assert.Equal(t, []uint64{0, 0, 0, 0, 1, 0, 0, 0, 0}, dp.BucketCounts())
+			assert.Equal(t, wantLabels, labelsAsMap(dp.LabelsMap()))
+		default:
+			t.Errorf("unexpected metric %q", m.Name())
+		}
+	}
+	assert.Len(t, seen, 4)
+	assert.Equal(t, 0, p.store.len())
+}
+
+func TestProcessorUnpairedSpanIsKept(t *testing.T) {
+	// Prepare
+	mexp := &mockMetricsExporter{MetricsSink: new(consumertest.MetricsSink)}
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.MetricsExporter = "otlp"
+	p := newProcessor(zap.NewNop(), cfg, consumertest.NewNop())
+	require.NoError(t, p.Start(context.Background(), newMockHost(config.NewID("otlp"), mexp)))
+	defer func() { require.NoError(t, p.Shutdown(context.Background())) }()
+
+	traces := buildSampleTrace()
+	// drop the server side of the request
+	traces.ResourceSpans().RemoveIf(func(rs pdata.ResourceSpans) bool {
+		svc, _ := rs.Resource().Attributes().Get(conventions.AttributeServiceName)
+		return svc.StringVal() == "backend"
+	})
+
+	// Test
+	require.NoError(t, p.ConsumeTraces(context.Background(), traces))
+
+	// Verify
+	assert.Equal(t, 1, p.store.len())
+	require.Len(t, mexp.AllMetrics(), 1)
+	assert.Equal(t, 0, mexp.AllMetrics()[0].MetricCount())
+}
+
+func buildSampleTrace() pdata.Traces {
+	traceID := pdata.NewTraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16})
+	clientSpanID := pdata.NewSpanID([8]byte{1, 2, 3, 4, 5, 6, 7, 8})
+	start := time.Unix(1000, 0)
+
+	traces := pdata.NewTraces()
+
+	// client side
+	rs := traces.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().InsertString(conventions.AttributeServiceName, "frontend")
+	span := rs.InstrumentationLibrarySpans().AppendEmpty().Spans().AppendEmpty()
+	span.SetName("GET /orders")
+	span.SetKind(pdata.SpanKindClient)
+	span.SetTraceID(traceID)
+	span.SetSpanID(clientSpanID)
+	span.SetStartTimestamp(pdata.TimestampFromTime(start))
+	span.SetEndTimestamp(pdata.TimestampFromTime(start.Add(2 * time.Second)))
+	span.Attributes().InsertString("http.method", "GET")
+
+	// server side, failing
+	rs = traces.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().InsertString(conventions.AttributeServiceName, "backend")
+	span = rs.InstrumentationLibrarySpans().AppendEmpty().Spans().AppendEmpty()
+	span.SetName("/orders")
+	span.SetKind(pdata.SpanKindServer)
+	span.SetTraceID(traceID)
+	span.SetSpanID(pdata.NewSpanID([8]byte{8, 7, 6, 5, 4, 3, 2, 1}))
+	span.SetParentSpanID(clientSpanID)
+	span.SetStartTimestamp(pdata.TimestampFromTime(start))
+	span.SetEndTimestamp(pdata.TimestampFromTime(start.Add(time.Second)))
+	span.Status().SetCode(pdata.StatusCodeError)
+	span.Attributes().InsertString("http.method", "GET")
+
+	// internal span, ignored
+	span = rs.InstrumentationLibrarySpans().At(0).Spans().AppendEmpty()
+	span.SetName("db")
+	span.SetKind(pdata.SpanKindInternal)
+	span.SetTraceID(traceID)
+
+	return traces
+}
+
+func labelsAsMap(labels pdata.StringMap) map[string]string {
+	m := make(map[string]string, labels.Len())
+	labels.Range(func(k, v string) bool {
+		m[k] = v
+		return true
+	})
+	return m
+}
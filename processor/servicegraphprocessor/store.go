@@ -0,0 +1,154 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package servicegraphprocessor
+
+import (
+	"container/list"
+	"errors"
+	"sync"
+	"time"
+)
+
+var errTooManyItems = errors.New("too many items")
+
+// edge is a request between two services, built from a client span and its matching server span.
+type edge struct {
+	key string
+
+	clientService, serverService string
+	clientLatencySec             float64
+	serverLatencySec             float64
+
+	// failed is set when either side of the request reported an error status.
+	failed bool
+
+	// dimensions holds the additional configured dimensions, already prefixed by "client_" or "server_".
+	dimensions map[string]string
+
+	// expiration is the time at which the edge is removed from the store if still incomplete.
+	expiration time.Time
+}
+
+func newEdge(key string, ttl time.Duration) *edge {
+	return &edge{
+		key:        key,
+		dimensions: make(map[string]string),
+		expiration: time.Now().Add(ttl),
+	}
+}
+
+// isComplete returns true if both the client and server side of the edge have been seen.
+func (e *edge) isComplete() bool {
+	return len(e.clientService) != 0 && len(e.serverService) != 0
+}
+
+func (e *edge) isExpired() bool {
+	return time.Now().After(e.expiration)
+}
+
+type storeCallback func(e *edge)
+
+// store keeps incomplete edges in memory until their counterpart span arrives, or until they expire.
+// Edges are kept in insertion order, so the oldest ones are always at the front of the list.
+type store struct {
+	mtx sync.Mutex
+	l   *list.List
+	m   map[string]*list.Element
+
+	onComplete storeCallback
+	onExpire   storeCallback
+
+	ttl      time.Duration
+	maxItems int
+}
+
+func newStore(ttl time.Duration, maxItems int, onComplete, onExpire storeCallback) *store {
+	return &store{
+		l:          list.New(),
+		m:          make(map[string]*list.Element),
+		onComplete: onComplete,
+		onExpire:   onExpire,
+		ttl:        ttl,
+		maxItems:   maxItems,
+	}
+}
+
+// len returns the number of incomplete edges currently held.
+func (s *store) len() int {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	return s.l.Len()
+}
+
+// upsertEdge fetches the edge for the given key, creating it if needed, and applies the update to it.
+// Edges that become complete are removed from the store and passed to onComplete.
+// It returns errTooManyItems if a new edge is needed but the store is full.
+func (s *store) upsertEdge(key string, update storeCallback) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	if ele, ok := s.m[key]; ok {
+		e := ele.Value.(*edge)
+		update(e)
+
+		if e.isComplete() {
+			s.onComplete(e)
+			s.l.Remove(ele)
+			delete(s.m, key)
+		}
+		return nil
+	}
+
+	if s.l.Len() >= s.maxItems {
+		// Make room by evicting expired edges before giving up.
+		s.expireLocked()
+		if s.l.Len() >= s.maxItems {
+			return errTooManyItems
+		}
+	}
+
+	e := newEdge(key, s.ttl)
+	update(e)
+
+	if e.isComplete() {
+		s.onComplete(e)
+		return nil
+	}
+
+	s.m[key] = s.l.PushBack(e)
+	return nil
+}
+
+// expire evicts all expired edges, passing each one to onExpire.
+func (s *store) expire() {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	s.expireLocked()
+}
+
+func (s *store) expireLocked() {
+	for ele := s.l.Front(); ele != nil; ele = s.l.Front() {
+		e := ele.Value.(*edge)
+		if !e.isExpired() {
+			return
+		}
+
+		s.onExpire(e)
+		s.l.Remove(ele)
+		delete(s.m, e.key)
+	}
+}
@@ -0,0 +1,86 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package servicegraphprocessor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStoreUpsertEdge(t *testing.T) {
+	var completed, expired []*edge
+	s := newStore(time.Hour, 10,
+		func(e *edge) { completed = append(completed, e) },
+		func(e *edge) { expired = append(expired, e) },
+	)
+
+	// first half of the edge is kept in the store
+	require.NoError(t, s.upsertEdge("key", func(e *edge) { e.clientService = "client" }))
+	assert.Equal(t, 1, s.len())
+	assert.Empty(t, completed)
+
+	// second half completes the edge, which is removed from the store
+	require.NoError(t, s.upsertEdge("key", func(e *edge) { e.serverService = "server" }))
+	assert.Equal(t, 0, s.len())
+	require.Len(t, completed, 1)
+	assert.Equal(t, "client", completed[0].clientService)
+	assert.Equal(t, "server", completed[0].serverService)
+	assert.Empty(t, expired)
+}
+
+func TestStoreExpire(t *testing.T) {
+	var completed, expired []*edge
+	s := newStore(time.Millisecond, 10,
+		func(e *edge) { completed = append(completed, e) },
+		func(e *edge) { expired = append(expired, e) },
+	)
+
+	require.NoError(t, s.upsertEdge("key-1", func(e *edge) { e.clientService = "client" }))
+	require.NoError(t, s.upsertEdge("key-2", func(e *edge) { e.serverService = "server" }))
+	assert.Equal(t, 2, s.len())
+
+	time.Sleep(5 * time.Millisecond)
+	s.expire()
+
+	assert.Equal(t, 0, s.len())
+	assert.Len(t, expired, 2)
+	assert.Empty(t, completed)
+}
+
+func TestStoreMaxItems(t *testing.T) {
+	s := newStore(time.Hour, 1, func(*edge) {}, func(*edge) {})
+
+	require.NoError(t, s.upsertEdge("key-1", func(e *edge) { e.clientService = "client" }))
+	assert.Equal(t, errTooManyItems, s.upsertEdge("key-2", func(e *edge) { e.clientService = "client" }))
+
+	// existing edges can still be completed when the store is full
+	assert.NoError(t, s.upsertEdge("key-1", func(e *edge) { e.serverService = "server" }))
+	assert.Equal(t, 0, s.len())
+}
+
+func TestStoreMaxItemsEvictsExpired(t *testing.T) {
+	var expired int
+	s := newStore(time.Millisecond, 1, func(*edge) {}, func(*edge) { expired++ })
+
+	require.NoError(t, s.upsertEdge("key-1", func(e *edge) { e.clientService = "client" }))
+	time.Sleep(5 * time.Millisecond)
+
+	assert.NoError(t, s.upsertEdge("key-2", func(e *edge) { e.clientService = "client" }))
+	assert.Equal(t, 1, expired)
+	assert.Equal(t, 1, s.len())
+}
@@ -0,0 +1,85 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signalfxtranslationprocessor
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configparser"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/signalfxexporter/translation"
+)
+
+const translationRulesConfigKey = "translation_rules"
+
+// Config defines configuration for the SignalFx translation processor.
+type Config struct {
+	config.ProcessorSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+
+	// TranslationRules are the rules applied to the metrics, using the same
+	// syntax as the "translation_rules" of the SignalFx exporter.
+	TranslationRules []translation.Rule `mapstructure:"translation_rules"`
+
+	// UseDefaultTranslationRules applies the default translation rules of the
+	// SignalFx exporter before TranslationRules.
+	UseDefaultTranslationRules bool `mapstructure:"use_default_translation_rules"`
+
+	// DeltaTranslationTTL specifies in seconds the max duration to keep the most recent datapoint for any
+	// `delta_metric` specified in TranslationRules. Default is 3600s.
+	DeltaTranslationTTL int64 `mapstructure:"delta_translation_ttl"`
+}
+
+var _ config.Processor = (*Config)(nil)
+
+// Validate checks if the processor configuration is valid.
+func (cfg *Config) Validate() error {
+	if len(cfg.TranslationRules) == 0 && !cfg.UseDefaultTranslationRules {
+		return fmt.Errorf("%q is required unless \"use_default_translation_rules\" is enabled", translationRulesConfigKey)
+	}
+	if cfg.DeltaTranslationTTL <= 0 {
+		return errors.New(`"delta_translation_ttl" must be positive`)
+	}
+	return nil
+}
+
+// rules returns the translation rules to apply, including the default ones if enabled.
+func (cfg *Config) rules() ([]translation.Rule, error) {
+	if !cfg.UseDefaultTranslationRules {
+		return cfg.TranslationRules, nil
+	}
+	defaults, err := loadDefaultTranslationRules()
+	if err != nil {
+		return nil, err
+	}
+	return append(defaults, cfg.TranslationRules...), nil
+}
+
+func loadDefaultTranslationRules() ([]translation.Rule, error) {
+	cfg := Config{}
+
+	cp, err := configparser.NewParserFromBuffer(strings.NewReader(translation.DefaultTranslationRulesYaml))
+	if err != nil {
+		return nil, err
+	}
+
+	if err = cp.UnmarshalExact(&cfg); err != nil {
+		return nil, fmt.Errorf("failed to load default translation rules: %v", err)
+	}
+
+	return cfg.TranslationRules, nil
+}
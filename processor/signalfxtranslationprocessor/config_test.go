@@ -0,0 +1,93 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signalfxtranslationprocessor
+
+import (
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configtest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/signalfxexporter/translation"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	assert.NoError(t, err)
+
+	factory := NewFactory()
+	factories.Processors[typeStr] = factory
+	cfg, err := configtest.LoadConfigAndValidate(path.Join(".", "testdata", "config.yaml"), factories)
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	assert.Equal(t, &Config{
+		ProcessorSettings: config.NewProcessorSettings(config.NewID(typeStr)),
+		TranslationRules: []translation.Rule{
+			{
+				Action:  translation.ActionRenameMetrics,
+				Mapping: map[string]string{"system.cpu.time": "cpu.time"},
+			},
+			{
+				Action:  translation.ActionRenameDimensionKeys,
+				Mapping: map[string]string{"state": "cpu_state"},
+			},
+		},
+		DeltaTranslationTTL: 3600,
+	}, cfg.Processors[config.NewID(typeStr)])
+
+	assert.Equal(t, &Config{
+		ProcessorSettings:          config.NewProcessorSettings(config.NewIDWithName(typeStr, "defaults")),
+		UseDefaultTranslationRules: true,
+		DeltaTranslationTTL:        60,
+	}, cfg.Processors[config.NewIDWithName(typeStr, "defaults")])
+}
+
+func TestValidateConfig(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	assert.EqualError(t, cfg.Validate(), `"translation_rules" is required unless "use_default_translation_rules" is enabled`)
+
+	cfg.UseDefaultTranslationRules = true
+	assert.NoError(t, cfg.Validate())
+
+	cfg.DeltaTranslationTTL = 0
+	assert.EqualError(t, cfg.Validate(), `"delta_translation_ttl" must be positive`)
+}
+
+func TestRules(t *testing.T) {
+	custom := translation.Rule{
+		Action:  translation.ActionRenameMetrics,
+		Mapping: map[string]string{"a": "b"},
+	}
+	cfg := createDefaultConfig().(*Config)
+	cfg.TranslationRules = []translation.Rule{custom}
+
+	rules, err := cfg.rules()
+	require.NoError(t, err)
+	assert.Equal(t, []translation.Rule{custom}, rules)
+
+	cfg.UseDefaultTranslationRules = true
+	defaults, err := loadDefaultTranslationRules()
+	require.NoError(t, err)
+	require.NotEmpty(t, defaults)
+	rules, err = cfg.rules()
+	require.NoError(t, err)
+	// The custom rules are applied after the default ones.
+	assert.Equal(t, append(defaults, custom), rules)
+}
@@ -0,0 +1,192 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signalfxtranslationprocessor
+
+import (
+	"context"
+
+	sfxpb "github.com/signalfx/com_signalfx_metrics_protobuf/model"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+	tracetranslator "go.opentelemetry.io/collector/translator/trace"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/signalfxexporter/translation"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/splunk"
+)
+
+// nonAlphanumericDimensionChars are the characters allowed in dimension keys,
+// the default of the SignalFx exporter.
+const nonAlphanumericDimensionChars = "_-."
+
+type translationProcessor struct {
+	logger    *zap.Logger
+	converter *translation.MetricsConverter
+}
+
+var _ processorhelper.MProcessor = (*translationProcessor)(nil)
+
+func newTranslationProcessor(logger *zap.Logger, rules []translation.Rule, deltaTTL int64) (*translationProcessor, error) {
+	metricTranslator, err := translation.NewMetricTranslator(rules, deltaTTL)
+	if err != nil {
+		return nil, err
+	}
+	converter, err := translation.NewMetricsConverter(logger, metricTranslator, nil, nil, nonAlphanumericDimensionChars)
+	if err != nil {
+		return nil, err
+	}
+	return &translationProcessor{
+		logger:    logger,
+		converter: converter,
+	}, nil
+}
+
+// ProcessMetrics converts the metrics of each resource to SignalFx data points,
+// applies the translation rules to them, and converts them back.
+func (p *translationProcessor) ProcessMetrics(_ context.Context, md pdata.Metrics) (pdata.Metrics, error) {
+	out := pdata.NewMetrics()
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		dps := p.converter.MetricDataToSignalFxV2(rm)
+		if len(dps) == 0 {
+			continue
+		}
+
+		outRM := out.ResourceMetrics().AppendEmpty()
+		rm.Resource().CopyTo(outRM.Resource())
+		dropped := dataPointsToMetrics(dps, resourceDimensions(rm.Resource()), outRM.InstrumentationLibraryMetrics().AppendEmpty().Metrics())
+		if dropped > 0 {
+			p.logger.Debug("Dropped translated data points without value", zap.Int("dropped", dropped))
+		}
+	}
+
+	if out.ResourceMetrics().Len() == 0 {
+		return out, processorhelper.ErrSkipProcessingData
+	}
+	return out, nil
+}
+
+// resourceDimensions returns the dimensions the converter adds to the data
+// points from the resource, which stay on the resource.
+func resourceDimensions(res pdata.Resource) map[string]string {
+	dims := map[string]string{}
+	if hostID, ok := splunk.ResourceToHostID(res); ok && hostID.Key != splunk.HostIDKeyHost {
+		dims[string(hostID.Key)] = hostID.ID
+	}
+	res.Attributes().Range(func(k string, v pdata.AttributeValue) bool {
+		dims[k] = tracetranslator.AttributeValueToString(v)
+		return true
+	})
+	return dims
+}
+
+type metricKey struct {
+	name       string
+	metricType sfxpb.MetricType
+	isInt      bool
+}
+
+// dataPointsToMetrics appends the data points to the metrics, grouping them by
+// name and type. It returns the number of data points dropped for not having
+// a value.
+func dataPointsToMetrics(dps []*sfxpb.DataPoint, resourceDims map[string]string, metrics pdata.MetricSlice) int {
+	dropped := 0
+	byKey := map[metricKey]pdata.Metric{}
+	for _, dp := range dps {
+		if dp.Value.IntValue == nil && dp.Value.DoubleValue == nil {
+			dropped++
+			continue
+		}
+
+		key := metricKey{name: dp.Metric, metricType: dp.GetMetricType(), isInt: dp.Value.IntValue != nil}
+		m, ok := byKey[key]
+		if !ok {
+			m = metrics.AppendEmpty()
+			m.SetName(key.name)
+			setDataType(m, key)
+			byKey[key] = m
+		}
+
+		switch m.DataType() {
+		case pdata.MetricDataTypeIntGauge:
+			fillIntDataPoint(dp, resourceDims, m.IntGauge().DataPoints().AppendEmpty())
+		case pdata.MetricDataTypeIntSum:
+			fillIntDataPoint(dp, resourceDims, m.IntSum().DataPoints().AppendEmpty())
+		case pdata.MetricDataTypeDoubleGauge:
+			fillDoubleDataPoint(dp, resourceDims, m.DoubleGauge().DataPoints().AppendEmpty())
+		case pdata.MetricDataTypeDoubleSum:
+			fillDoubleDataPoint(dp, resourceDims, m.DoubleSum().DataPoints().AppendEmpty())
+		}
+	}
+	return dropped
+}
+
+func setDataType(m pdata.Metric, key metricKey) {
+	if key.metricType == sfxpb.MetricType_GAUGE || key.metricType == sfxpb.MetricType_ENUM {
+		if key.isInt {
+			m.SetDataType(pdata.MetricDataTypeIntGauge)
+		} else {
+			m.SetDataType(pdata.MetricDataTypeDoubleGauge)
+		}
+		return
+	}
+
+	temporality := pdata.AggregationTemporalityCumulative
+	if key.metricType == sfxpb.MetricType_COUNTER {
+		temporality = pdata.AggregationTemporalityDelta
+	}
+	if key.isInt {
+		m.SetDataType(pdata.MetricDataTypeIntSum)
+		m.IntSum().SetIsMonotonic(true)
+		m.IntSum().SetAggregationTemporality(temporality)
+	} else {
+		m.SetDataType(pdata.MetricDataTypeDoubleSum)
+		m.DoubleSum().SetIsMonotonic(true)
+		m.DoubleSum().SetAggregationTemporality(temporality)
+	}
+}
+
+func fillIntDataPoint(sfxDataPoint *sfxpb.DataPoint, resourceDims map[string]string, dp pdata.IntDataPoint) {
+	dp.SetTimestamp(dpTimestamp(sfxDataPoint))
+	dp.SetValue(*sfxDataPoint.Value.IntValue)
+	fillInLabels(sfxDataPoint.Dimensions, resourceDims, dp.LabelsMap())
+}
+
+func fillDoubleDataPoint(sfxDataPoint *sfxpb.DataPoint, resourceDims map[string]string, dp pdata.DoubleDataPoint) {
+	dp.SetTimestamp(dpTimestamp(sfxDataPoint))
+	dp.SetValue(*sfxDataPoint.Value.DoubleValue)
+	fillInLabels(sfxDataPoint.Dimensions, resourceDims, dp.LabelsMap())
+}
+
+func dpTimestamp(dp *sfxpb.DataPoint) pdata.Timestamp {
+	// Convert from SignalFx millis to pdata nanos
+	return pdata.Timestamp(dp.GetTimestamp() * 1e6)
+}
+
+// fillInLabels sets the dimensions as labels, except the ones coming from
+// the resource unchanged.
+func fillInLabels(dimensions []*sfxpb.Dimension, resourceDims map[string]string, labels pdata.StringMap) {
+	labels.EnsureCapacity(len(dimensions))
+	for _, dim := range dimensions {
+		if dim == nil {
+			continue
+		}
+		if v, ok := resourceDims[dim.Key]; ok && v == dim.Value {
+			continue
+		}
+		labels.Upsert(dim.Key, dim.Value)
+	}
+}
@@ -0,0 +1,142 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signalfxtranslationprocessor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/signalfxexporter/translation"
+)
+
+var ts = pdata.TimestampFromTime(time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC))
+
+func testMetrics() pdata.Metrics {
+	md := pdata.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().InsertString("host.name", "host-1")
+	metrics := rm.InstrumentationLibraryMetrics().AppendEmpty().Metrics()
+
+	cpu := metrics.AppendEmpty()
+	cpu.SetName("system.cpu.time")
+	cpu.SetDataType(pdata.MetricDataTypeDoubleSum)
+	cpu.DoubleSum().SetIsMonotonic(true)
+	cpu.DoubleSum().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+	for _, state := range []string{"user", "system"} {
+		dp := cpu.DoubleSum().DataPoints().AppendEmpty()
+		dp.SetTimestamp(ts)
+		dp.SetValue(10)
+		dp.LabelsMap().Insert("state", state)
+	}
+
+	mem := metrics.AppendEmpty()
+	mem.SetName("system.memory.usage")
+	mem.SetDataType(pdata.MetricDataTypeIntGauge)
+	dp := mem.IntGauge().DataPoints().AppendEmpty()
+	dp.SetTimestamp(ts)
+	dp.SetValue(2048)
+	return md
+}
+
+func TestProcessMetrics(t *testing.T) {
+	p, err := newTranslationProcessor(zap.NewNop(), []translation.Rule{
+		{
+			Action:  translation.ActionRenameMetrics,
+			Mapping: map[string]string{"system.cpu.time": "cpu.time"},
+		},
+		{
+			Action:  translation.ActionRenameDimensionKeys,
+			Mapping: map[string]string{"state": "cpu_state"},
+		},
+		{
+			Action:          translation.ActionDivideInt,
+			ScaleFactorsInt: map[string]int64{"system.memory.usage": 1024},
+		},
+	}, 3600)
+	require.NoError(t, err)
+
+	md, err := p.ProcessMetrics(context.Background(), testMetrics())
+	require.NoError(t, err)
+
+	require.Equal(t, 1, md.ResourceMetrics().Len())
+	rm := md.ResourceMetrics().At(0)
+	host, ok := rm.Resource().Attributes().Get("host.name")
+	require.True(t, ok)
+	assert.Equal(t, "host-1", host.StringVal())
+
+	metrics := rm.InstrumentationLibraryMetrics().At(0).Metrics()
+	require.Equal(t, 2, metrics.Len())
+
+	cpu := metrics.At(0)
+	assert.Equal(t, "cpu.time", cpu.Name())
+	require.Equal(t, pdata.MetricDataTypeDoubleSum, cpu.DataType())
+	assert.True(t, cpu.DoubleSum().IsMonotonic())
+	assert.Equal(t, pdata.AggregationTemporalityCumulative, cpu.DoubleSum().AggregationTemporality())
+	require.Equal(t, 2, cpu.DoubleSum().DataPoints().Len())
+	dp := cpu.DoubleSum().DataPoints().At(0)
+	assert.Equal(t, ts, dp.Timestamp())
+	assert.Equal(t, 10.0, dp.Value())
+	// The resource attributes aren't copied to the labels.
+	assert.Equal(t, map[string]string{"cpu_state": "user"}, labels(dp.LabelsMap()))
+
+	mem := metrics.At(1)
+	assert.Equal(t, "system.memory.usage", mem.Name())
+	require.Equal(t, pdata.MetricDataTypeIntGauge, mem.DataType())
+	assert.Equal(t, int64(2), mem.IntGauge().DataPoints().At(0).Value())
+}
+
+func TestProcessMetricsRenamedResourceDimension(t *testing.T) {
+	p, err := newTranslationProcessor(zap.NewNop(), []translation.Rule{
+		{
+			Action:  translation.ActionRenameDimensionKeys,
+			Mapping: map[string]string{"host.name": "host"},
+		},
+	}, 3600)
+	require.NoError(t, err)
+
+	md, err := p.ProcessMetrics(context.Background(), testMetrics())
+	require.NoError(t, err)
+	dp := md.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics().At(1).IntGauge().DataPoints().At(0)
+	assert.Equal(t, map[string]string{"host": "host-1"}, labels(dp.LabelsMap()))
+}
+
+func TestProcessMetricsAllDropped(t *testing.T) {
+	p, err := newTranslationProcessor(zap.NewNop(), []translation.Rule{
+		{
+			Action:      translation.ActionDropMetrics,
+			MetricNames: map[string]bool{"system.cpu.time": true, "system.memory.usage": true},
+		},
+	}, 3600)
+	require.NoError(t, err)
+
+	_, err = p.ProcessMetrics(context.Background(), testMetrics())
+	assert.Equal(t, processorhelper.ErrSkipProcessingData, err)
+}
+
+func labels(m pdata.StringMap) map[string]string {
+	out := map[string]string{}
+	m.Range(func(k, v string) bool {
+		out[k] = v
+		return true
+	})
+	return out
+}
@@ -99,4 +99,8 @@ type Config struct {
 	// PolicyCfgs sets the tail-based sampling policy which makes a sampling decision
 	// for a given trace when requested.
 	PolicyCfgs []PolicyCfg `mapstructure:"policies"`
+	// ShadowMode evaluates the policies and records their decisions as usual, but forwards
+	// all the traces, including the ones the policies decided not to sample. It allows to
+	// safely roll out new policies by watching their would-be effect first.
+	ShadowMode bool `mapstructure:"shadow_mode"`
 }
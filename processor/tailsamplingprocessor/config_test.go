@@ -43,6 +43,7 @@ func TestLoadConfig(t *testing.T) {
 			DecisionWait:            10 * time.Second,
 			NumTraces:               100,
 			ExpectedNewTracesPerSec: 10,
+			ShadowMode:              true,
 			PolicyCfgs: []PolicyCfg{
 				{
 					Name: "test-policy-1",
@@ -37,10 +37,15 @@ var (
 	statPolicyEvaluationErrorCount = stats.Int64("sampling_policy_evaluation_error", "Count of sampling policy evaluation errors", stats.UnitDimensionless)
 
 	statCountTracesSampled = stats.Int64("count_traces_sampled", "Count of traces that were sampled or not", stats.UnitDimensionless)
+	statCountFinalDecision = stats.Int64("count_final_decision", "Count of traces that were sampled or not by any of the policies", stats.UnitDimensionless)
 
-	statDroppedTooEarlyCount    = stats.Int64("sampling_trace_dropped_too_early", "Count of traces that needed to be dropped the configured wait time", stats.UnitDimensionless)
-	statNewTraceIDReceivedCount = stats.Int64("new_trace_id_received", "Counts the arrival of new traces", stats.UnitDimensionless)
-	statTracesOnMemoryGauge     = stats.Int64("sampling_traces_on_memory", "Tracks the number of traces current on memory", stats.UnitDimensionless)
+	statDroppedTooEarlyCount     = stats.Int64("sampling_trace_dropped_too_early", "Count of traces that needed to be dropped the configured wait time", stats.UnitDimensionless)
+	statNewTraceIDReceivedCount  = stats.Int64("new_trace_id_received", "Counts the arrival of new traces", stats.UnitDimensionless)
+	statTracesOnMemoryGauge      = stats.Int64("sampling_traces_on_memory", "Tracks the number of traces current on memory", stats.UnitDimensionless)
+	statTracesOnMemorySaturation = stats.Float64("sampling_traces_on_memory_saturation", "Ratio of the number of traces on memory to num_traces, traces are dropped before their decision from 1", stats.UnitDimensionless)
+	statDecisionBatchSize        = stats.Int64("sampling_decision_batch_size", "Number of traces whose decision_wait elapsed at each run of the sampling decision timer", stats.UnitDimensionless)
+
+	statShadowNotSampledCount = stats.Int64("sampling_shadow_traces_not_sampled", "Count of traces forwarded in shadow mode that no policy decided to sample", stats.UnitDimensionless)
 )
 
 // SamplingProcessorMetricViews return the metrics views according to given telemetry level.
@@ -85,6 +90,7 @@ func SamplingProcessorMetricViews(level configtelemetry.Level) []*view.View {
 		Name:        obsreport.BuildProcessorCustomMetricName(typeStr, statPolicyEvaluationErrorCount.Name()),
 		Measure:     statPolicyEvaluationErrorCount,
 		Description: statPolicyEvaluationErrorCount.Description(),
+		TagKeys:     policyTagKeys,
 		Aggregation: view.Sum(),
 	}
 
@@ -96,6 +102,13 @@ func SamplingProcessorMetricViews(level configtelemetry.Level) []*view.View {
 		TagKeys:     sampledTagKeys,
 		Aggregation: view.Sum(),
 	}
+	countFinalDecisionView := &view.View{
+		Name:        obsreport.BuildProcessorCustomMetricName(typeStr, statCountFinalDecision.Name()),
+		Measure:     statCountFinalDecision,
+		Description: statCountFinalDecision.Description(),
+		TagKeys:     []tag.Key{tagSampledKey},
+		Aggregation: view.Sum(),
+	}
 
 	countTraceDroppedTooEarlyView := &view.View{
 		Name:        obsreport.BuildProcessorCustomMetricName(typeStr, statDroppedTooEarlyCount.Name()),
@@ -115,6 +128,25 @@ func SamplingProcessorMetricViews(level configtelemetry.Level) []*view.View {
 		Description: statTracesOnMemoryGauge.Description(),
 		Aggregation: view.LastValue(),
 	}
+	trackTracesOnMemorySaturationView := &view.View{
+		Name:        obsreport.BuildProcessorCustomMetricName(typeStr, statTracesOnMemorySaturation.Name()),
+		Measure:     statTracesOnMemorySaturation,
+		Description: statTracesOnMemorySaturation.Description(),
+		Aggregation: view.LastValue(),
+	}
+	decisionBatchSizeView := &view.View{
+		Name:        obsreport.BuildProcessorCustomMetricName(typeStr, statDecisionBatchSize.Name()),
+		Measure:     statDecisionBatchSize,
+		Description: statDecisionBatchSize.Description(),
+		Aggregation: view.Distribution(0, 10, 50, 100, 500, 1000, 5000, 10000, 50000, 100000),
+	}
+
+	countShadowNotSampledView := &view.View{
+		Name:        obsreport.BuildProcessorCustomMetricName(typeStr, statShadowNotSampledCount.Name()),
+		Measure:     statShadowNotSampledCount,
+		Description: statShadowNotSampledCount.Description(),
+		Aggregation: view.Sum(),
+	}
 
 	return []*view.View{
 		decisionLatencyView,
@@ -126,9 +158,14 @@ func SamplingProcessorMetricViews(level configtelemetry.Level) []*view.View {
 		countPolicyEvaluationErrorView,
 
 		countTracesSampledView,
+		countFinalDecisionView,
 
 		countTraceDroppedTooEarlyView,
 		countTraceIDArrivalView,
 		trackTracesOnMemorylView,
+		trackTracesOnMemorySaturationView,
+		decisionBatchSizeView,
+
+		countShadowNotSampledView,
 	}
 }
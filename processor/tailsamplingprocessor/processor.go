@@ -18,6 +18,7 @@ import (
 	"context"
 	"fmt"
 	"runtime"
+	"strconv"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -59,6 +60,7 @@ type tailSamplingSpanProcessor struct {
 	decisionBatcher idbatcher.Batcher
 	deleteChan      chan pdata.TraceID
 	numTracesOnMap  uint64
+	shadowMode      bool
 }
 
 const (
@@ -105,6 +107,7 @@ func newTracesProcessor(logger *zap.Logger, nextConsumer consumer.Traces, cfg Co
 		logger:          logger,
 		decisionBatcher: inBatcher,
 		policies:        policies,
+		shadowMode:      cfg.ShadowMode,
 	}
 
 	tsp.policyTicker = &policyTicker{onTick: tsp.samplingPolicyOnTick}
@@ -133,6 +136,7 @@ func getPolicyEvaluator(logger *zap.Logger, cfg *PolicyCfg) (sampling.PolicyEval
 
 type policyMetrics struct {
 	idNotFoundOnMapCount, evaluateErrorCount, decisionSampled, decisionNotSampled int64
+	shadowNotSampled                                                              int64
 }
 
 func (tsp *tailSamplingSpanProcessor) samplingPolicyOnTick() {
@@ -159,25 +163,46 @@ func (tsp *tailSamplingSpanProcessor) samplingPolicyOnTick() {
 		trace.ReceivedBatches = nil
 		trace.Unlock()
 
-		if decision == sampling.Sampled {
+		_ = stats.RecordWithTags(
+			tsp.ctx,
+			[]tag.Mutator{tag.Insert(tagSampledKey, strconv.FormatBool(decision == sampling.Sampled))},
+			statCountFinalDecision.M(int64(1)),
+		)
 
-			// Combine all individual batches into a single batch so
-			// consumers may operate on the entire trace
-			allSpans := pdata.NewTraces()
-			for j := 0; j < len(traceBatches); j++ {
-				batch := traceBatches[j]
-				batch.ResourceSpans().MoveAndAppendTo(allSpans.ResourceSpans())
-			}
+		ctx := tsp.ctx
+		if decision == sampling.Sampled {
+			ctx = policy.ctx
+		} else if tsp.shadowMode {
+			metrics.shadowNotSampled++
+			tsp.logger.Debug("Shadow mode forwarding trace not sampled by any policy",
+				zap.String("traceID", id.HexString()))
+		} else {
+			continue
+		}
 
-			_ = tsp.nextConsumer.ConsumeTraces(policy.ctx, allSpans)
+		// Combine all individual batches into a single batch so
+		// consumers may operate on the entire trace
+		allSpans := pdata.NewTraces()
+		for j := 0; j < len(traceBatches); j++ {
+			batch := traceBatches[j]
+			batch.ResourceSpans().MoveAndAppendTo(allSpans.ResourceSpans())
 		}
+
+		_ = tsp.nextConsumer.ConsumeTraces(ctx, allSpans)
 	}
 
+	numTracesOnMap := atomic.LoadUint64(&tsp.numTracesOnMap)
+	var saturation float64
+	if tsp.maxNumTraces > 0 {
+		saturation = float64(numTracesOnMap) / float64(tsp.maxNumTraces)
+	}
 	stats.Record(tsp.ctx,
 		statOverallDecisionLatencyUs.M(int64(time.Since(startTime)/time.Microsecond)),
 		statDroppedTooEarlyCount.M(metrics.idNotFoundOnMapCount),
-		statPolicyEvaluationErrorCount.M(metrics.evaluateErrorCount),
-		statTracesOnMemoryGauge.M(int64(atomic.LoadUint64(&tsp.numTracesOnMap))))
+		statTracesOnMemoryGauge.M(int64(numTracesOnMap)),
+		statTracesOnMemorySaturation.M(saturation),
+		statDecisionBatchSize.M(int64(batchLen)),
+		statShadowNotSampledCount.M(metrics.shadowNotSampled))
 
 	tsp.logger.Debug("Sampling policy evaluation completed",
 		zap.Int("batch.len", batchLen),
@@ -185,6 +210,7 @@ func (tsp *tailSamplingSpanProcessor) samplingPolicyOnTick() {
 		zap.Int64("notSampled", metrics.decisionNotSampled),
 		zap.Int64("droppedPriorToEvaluation", metrics.idNotFoundOnMapCount),
 		zap.Int64("policyEvaluationErrors", metrics.evaluateErrorCount),
+		zap.Int64("shadowNotSampled", metrics.shadowNotSampled),
 	)
 }
 
@@ -201,6 +227,7 @@ func (tsp *tailSamplingSpanProcessor) makeDecision(id pdata.TraceID, trace *samp
 
 		if err != nil {
 			trace.Decisions[i] = sampling.NotSampled
+			stats.Record(policy.ctx, statPolicyEvaluationErrorCount.M(int64(1)))
 			metrics.evaluateErrorCount++
 			tsp.logger.Debug("Sampling policy error", zap.Error(err))
 		} else {
@@ -302,6 +329,8 @@ func (tsp *tailSamplingSpanProcessor) processTraces(resourceSpans pdata.Resource
 			}
 		}
 
+		lateSampled := false
+		lateArrival := false
 		for i, policy := range tsp.policies {
 			var traceTd pdata.Traces
 			actualData.Lock()
@@ -317,6 +346,7 @@ func (tsp *tailSamplingSpanProcessor) processTraces(resourceSpans pdata.Resource
 				break
 			}
 			actualData.Unlock()
+			lateArrival = true
 
 			switch actualDecision {
 			case sampling.Sampled:
@@ -341,9 +371,17 @@ func (tsp *tailSamplingSpanProcessor) processTraces(resourceSpans pdata.Resource
 			// At this point the late arrival has been passed to nextConsumer. Need to break out of the policy loop
 			// so that it isn't sent to nextConsumer more than once when multiple policies chose to sample
 			if actualDecision == sampling.Sampled {
+				lateSampled = true
 				break
 			}
 		}
+
+		// In shadow mode, the late spans of a trace no policy sampled are forwarded as well.
+		if tsp.shadowMode && lateArrival && !lateSampled {
+			if err := tsp.nextConsumer.ConsumeTraces(tsp.ctx, prepareTraceBatch(resourceSpans, spans)); err != nil {
+				tsp.logger.Warn("Error sending late arrived spans in shadow mode", zap.Error(err))
+			}
+		}
 	}
 
 	stats.Record(tsp.ctx, statNewTraceIDReceivedCount.M(newTraceIDs))
@@ -23,9 +23,14 @@ import (
 	"testing"
 	"time"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+	"go.opentelemetry.io/collector/config/configtelemetry"
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/obsreport"
 	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/idbatcher"
@@ -330,6 +335,112 @@ func TestSamplingPolicyDecisionNotSampled(t *testing.T) {
 	require.Equal(t, 2, mpe.LateArrivingSpansCount, "policy was not notified of the late span")
 }
 
+func TestSamplingPolicyDecisionNotSampledShadowMode(t *testing.T) {
+	const maxSize = 100
+	const decisionWaitSeconds = 5
+	// For this test explicitly control the timer calls and batcher, and set a mock
+	// sampling policy evaluator.
+	msp := new(consumertest.TracesSink)
+	mpe := &mockPolicyEvaluator{}
+	mtt := &manualTTicker{}
+	tsp := &tailSamplingSpanProcessor{
+		ctx:             context.Background(),
+		nextConsumer:    msp,
+		maxNumTraces:    maxSize,
+		logger:          zap.NewNop(),
+		decisionBatcher: newSyncIDBatcher(decisionWaitSeconds),
+		policies:        []*Policy{{Name: "mock-policy", Evaluator: mpe, ctx: context.TODO()}},
+		deleteChan:      make(chan pdata.TraceID, maxSize),
+		policyTicker:    mtt,
+		shadowMode:      true,
+	}
+
+	_, batches := generateIdsAndBatches(210)
+	currItem := 0
+	numSpansPerBatchWindow := 10
+	// First evaluations shouldn't have anything to evaluate, until decision wait time passed.
+	for evalNum := 0; evalNum < decisionWaitSeconds; evalNum++ {
+		for ; currItem < numSpansPerBatchWindow*(evalNum+1); currItem++ {
+			tsp.ConsumeTraces(context.Background(), batches[currItem])
+		}
+		tsp.samplingPolicyOnTick()
+		require.EqualValues(t, 0, msp.SpansCount(), "policy for initial items was evaluated before decision wait period")
+	}
+
+	// Now the first batch that waited the decision period, it is forwarded even though it isn't sampled.
+	mpe.NextDecision = sampling.NotSampled
+	tsp.samplingPolicyOnTick()
+	require.EqualValues(t, 4, mpe.EvaluationCount, "policy should have been evaluated 4 times")
+	require.NotZero(t, msp.SpansCount(), "exporter should have received the spans in shadow mode")
+	require.Len(t, msp.AllTraces(), 4, "each trace should have been forwarded once")
+
+	// Late span of a non-sampled trace is forwarded as well.
+	spansCount := msp.SpansCount()
+	tsp.ConsumeTraces(context.Background(), batches[0])
+	require.Equal(t, spansCount+batches[0].SpanCount(), msp.SpansCount())
+	require.Equal(t, 1, mpe.LateArrivingSpansCount, "policy was not notified of the late span")
+}
+
+func TestSamplingPolicyMetrics(t *testing.T) {
+	views := SamplingProcessorMetricViews(configtelemetry.LevelNormal)
+	view.Unregister(views...)
+	require.NoError(t, view.Register(views...))
+	defer view.Unregister(views...)
+
+	const maxSize = 100
+	const decisionWaitSeconds = 1
+	okCtx, err := tag.New(context.Background(), tag.Upsert(tagPolicyKey, "ok-policy"))
+	require.NoError(t, err)
+	errCtx, err := tag.New(context.Background(), tag.Upsert(tagPolicyKey, "error-policy"))
+	require.NoError(t, err)
+
+	msp := new(consumertest.TracesSink)
+	tsp := &tailSamplingSpanProcessor{
+		ctx:             context.Background(),
+		nextConsumer:    msp,
+		maxNumTraces:    maxSize,
+		logger:          zap.NewNop(),
+		decisionBatcher: newSyncIDBatcher(decisionWaitSeconds),
+		policies: []*Policy{
+			{Name: "ok-policy", Evaluator: &mockPolicyEvaluator{NextDecision: sampling.Sampled}, ctx: okCtx},
+			{Name: "error-policy", Evaluator: &mockPolicyEvaluator{NextError: errors.New("mock policy error")}, ctx: errCtx},
+		},
+		deleteChan:   make(chan pdata.TraceID, maxSize),
+		policyTicker: &manualTTicker{},
+	}
+
+	_, batches := generateIdsAndBatches(10)
+	for _, batch := range batches {
+		require.NoError(t, tsp.ConsumeTraces(context.Background(), batch))
+	}
+	tsp.samplingPolicyOnTick()
+	tsp.samplingPolicyOnTick()
+	require.Len(t, msp.AllTraces(), 10)
+
+	rows, err := view.RetrieveData(obsreport.BuildProcessorCustomMetricName(typeStr, statPolicyEvaluationErrorCount.Name()))
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	assert.Equal(t, []tag.Tag{{Key: tagPolicyKey, Value: "error-policy"}}, rows[0].Tags)
+	assert.EqualValues(t, 10, rows[0].Data.(*view.SumData).Value)
+
+	rows, err = view.RetrieveData(obsreport.BuildProcessorCustomMetricName(typeStr, statCountFinalDecision.Name()))
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	assert.Equal(t, []tag.Tag{{Key: tagSampledKey, Value: "true"}}, rows[0].Tags)
+	assert.EqualValues(t, 10, rows[0].Data.(*view.SumData).Value)
+
+	rows, err = view.RetrieveData(obsreport.BuildProcessorCustomMetricName(typeStr, statTracesOnMemorySaturation.Name()))
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	assert.EqualValues(t, 0.1, rows[0].Data.(*view.LastValueData).Value)
+
+	rows, err = view.RetrieveData(obsreport.BuildProcessorCustomMetricName(typeStr, statDecisionBatchSize.Name()))
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	assert.EqualValues(t, 2, rows[0].Data.(*view.DistributionData).Count)
+	assert.EqualValues(t, 10, rows[0].Data.(*view.DistributionData).Max)
+}
+
 func TestMultipleBatchesAreCombinedIntoOne(t *testing.T) {
 	const maxSize = 100
 	const decisionWaitSeconds = 1
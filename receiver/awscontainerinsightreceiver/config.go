@@ -15,6 +15,9 @@
 package awscontainerinsightreceiver
 
 import (
+	"errors"
+	"fmt"
+	"runtime"
 	"time"
 
 	"go.opentelemetry.io/collector/config"
@@ -29,4 +32,46 @@ type Config struct {
 
 	// ContainerOrchestrator is the type of container orchestration service, e.g. eks or ecs. The default is eks.
 	ContainerOrchestrator string `mapstructure:"container_orchestrator"`
+
+	// ComputeType is the kind of node the receiver collects from: "ec2" for Linux EC2 nodes, where the
+	// embedded cadvisor is used, "windows" for Windows nodes and "fargate" for EKS Fargate, where the
+	// metrics are built from the kubelet Summary API instead. The default is "windows" when the collector
+	// runs on Windows and "ec2" otherwise.
+	ComputeType string `mapstructure:"compute_type"`
+
+	// ClusterName overrides the cluster name discovered from the EC2 instance tags. It is required on
+	// Fargate, where there is no EC2 instance to discover it from.
+	ClusterName string `mapstructure:"cluster_name"`
+
+	// KubeletEndpoint is the host:port of the kubelet read on Windows nodes. The default is the
+	// address in the HOST_IP environment variable on port 10250.
+	KubeletEndpoint string `mapstructure:"kubelet_endpoint"`
+
+	// KubeletInsecureSkipVerify disables the verification of the kubelet serving certificate, which
+	// is usually self-signed. The default is true.
+	KubeletInsecureSkipVerify bool `mapstructure:"kubelet_insecure_skip_verify"`
+}
+
+// Validate checks the receiver configuration is valid.
+func (cfg *Config) Validate() error {
+	switch cfg.computeType() {
+	case computeTypeEC2, computeTypeWindows:
+	case computeTypeFargate:
+		if cfg.ClusterName == "" {
+			return errors.New("cluster_name must be specified when compute_type is fargate")
+		}
+	default:
+		return fmt.Errorf("unsupported compute_type %q, must be one of ec2, windows or fargate", cfg.ComputeType)
+	}
+	return nil
+}
+
+func (cfg *Config) computeType() string {
+	if cfg.ComputeType != "" {
+		return cfg.ComputeType
+	}
+	if runtime.GOOS == "windows" {
+		return computeTypeWindows
+	}
+	return computeTypeEC2
 }
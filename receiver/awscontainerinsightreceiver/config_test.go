@@ -37,7 +37,7 @@ func TestLoadConfig(t *testing.T) {
 	require.NoError(t, err)
 	require.NotNil(t, cfg)
 
-	assert.Equal(t, len(cfg.Receivers), 2)
+	assert.Equal(t, len(cfg.Receivers), 4)
 
 	//ensure default configurations are generated when users provide nothing
 	r0 := cfg.Receivers[config.NewID(typeStr)]
@@ -49,8 +49,32 @@ func TestLoadConfig(t *testing.T) {
 	r2 := cfg.Receivers[config.NewIDWithName(typeStr, "collection_interval_settings")].(*Config)
 	assert.Equal(t, r2,
 		&Config{
-			ReceiverSettings:      config.NewReceiverSettings(config.NewIDWithName(typeStr, "collection_interval_settings")),
-			CollectionInterval:    60 * time.Second,
-			ContainerOrchestrator: "eks",
+			ReceiverSettings:          config.NewReceiverSettings(config.NewIDWithName(typeStr, "collection_interval_settings")),
+			CollectionInterval:        60 * time.Second,
+			ContainerOrchestrator:     "eks",
+			KubeletInsecureSkipVerify: true,
 		})
+
+	r3 := cfg.Receivers[config.NewIDWithName(typeStr, "fargate")].(*Config)
+	assert.Equal(t, "fargate", r3.ComputeType)
+	assert.Equal(t, "demo", r3.ClusterName)
+
+	r4 := cfg.Receivers[config.NewIDWithName(typeStr, "windows")].(*Config)
+	assert.Equal(t, "windows", r4.ComputeType)
+	assert.Equal(t, "10.0.0.1:10250", r4.KubeletEndpoint)
+	assert.False(t, r4.KubeletInsecureSkipVerify)
+}
+
+func TestValidateConfig(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	assert.NoError(t, cfg.Validate())
+
+	cfg.ComputeType = "fargate"
+	assert.EqualError(t, cfg.Validate(), "cluster_name must be specified when compute_type is fargate")
+
+	cfg.ClusterName = "demo"
+	assert.NoError(t, cfg.Validate())
+
+	cfg.ComputeType = "ecs"
+	assert.EqualError(t, cfg.Validate(), `unsupported compute_type "ecs", must be one of ec2, windows or fargate`)
 }
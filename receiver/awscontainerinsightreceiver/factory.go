@@ -16,6 +16,8 @@ package awscontainerinsightreceiver
 
 import (
 	"context"
+	"net"
+	"os"
 	"time"
 
 	"go.opentelemetry.io/collector/component"
@@ -24,9 +26,11 @@ import (
 	"go.opentelemetry.io/collector/receiver/receiverhelper"
 	"go.uber.org/zap"
 
+	ci "github.com/open-telemetry/opentelemetry-collector-contrib/internal/aws/containerinsight"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/awscontainerinsightreceiver/internal/cadvisor"
 	hostInfo "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/awscontainerinsightreceiver/internal/host"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/awscontainerinsightreceiver/internal/k8sapiserver"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/awscontainerinsightreceiver/internal/kubeletsummary"
 )
 
 // Factory for awscontainerinsightreceiver
@@ -39,6 +43,13 @@ const (
 
 	// Default container orchestrator service is aws eks
 	defaultContainerOrchestrator = "eks"
+
+	computeTypeEC2     = "ec2"
+	computeTypeWindows = "windows"
+	computeTypeFargate = "fargate"
+
+	// Environment variable holding the address of the node, usually set from status.hostIP
+	hostIPEnv = "HOST_IP"
 )
 
 // NewFactory creates a factory for AWS container insight receiver
@@ -52,9 +63,10 @@ func NewFactory() component.ReceiverFactory {
 // createDefaultConfig returns a default config for the receiver.
 func createDefaultConfig() config.Receiver {
 	return &Config{
-		ReceiverSettings:      config.NewReceiverSettings(config.NewID(typeStr)),
-		CollectionInterval:    defaultCollectionInterval,
-		ContainerOrchestrator: defaultContainerOrchestrator,
+		ReceiverSettings:          config.NewReceiverSettings(config.NewID(typeStr)),
+		CollectionInterval:        defaultCollectionInterval,
+		ContainerOrchestrator:     defaultContainerOrchestrator,
+		KubeletInsecureSkipVerify: true,
 	}
 }
 
@@ -68,12 +80,55 @@ func createMetricsReceiver(
 
 	rCfg := baseCfg.(*Config)
 	logger := params.Logger
+
+	// There is no EC2 instance behind a Fargate pod, so the host information is not looked up
+	if rCfg.computeType() == computeTypeFargate {
+		clusterName := &clusterNameProvider{name: rCfg.ClusterName}
+		summary, err := kubeletsummary.NewFargateProvider(clusterName, logger)
+		if err != nil {
+			return nil, err
+		}
+		return New(logger, rCfg, consumer, summary, k8sapiserver.New(clusterName, logger))
+	}
+
 	hostInfo, err := hostInfo.NewInfo(rCfg.CollectionInterval, logger)
 	// TODO: I will need to change the code here to let cadvisor and k8sapiserver return err as well
 	if err != nil {
 		logger.Warn("failed to initialize hostInfo", zap.Error(err))
 	}
+	clusterName := &clusterNameProvider{name: rCfg.ClusterName, hostInfo: hostInfo}
+	k8sapiserver := k8sapiserver.New(clusterName, logger)
+
+	if rCfg.computeType() == computeTypeWindows {
+		endpoint := rCfg.KubeletEndpoint
+		if endpoint == "" && os.Getenv(hostIPEnv) != "" {
+			endpoint = net.JoinHostPort(os.Getenv(hostIPEnv), ci.KubeSecurePort)
+		}
+		var capacity kubeletsummary.CapacityProvider
+		if hostInfo != nil {
+			capacity = hostInfo
+		}
+		summary, err := kubeletsummary.NewKubeletProvider(endpoint, rCfg.KubeletInsecureSkipVerify, clusterName, capacity, logger)
+		if err != nil {
+			return nil, err
+		}
+		return New(logger, rCfg, consumer, summary, k8sapiserver)
+	}
+
 	cadvisor := cadvisor.New(rCfg.ContainerOrchestrator, hostInfo, logger)
-	k8sapiserver := k8sapiserver.New(hostInfo, logger)
 	return New(logger, rCfg, consumer, cadvisor, k8sapiserver)
 }
+
+// clusterNameProvider returns the configured cluster name, falling back to the
+// one discovered from the tags of the EC2 instance.
+type clusterNameProvider struct {
+	name     string
+	hostInfo *hostInfo.Info
+}
+
+func (c *clusterNameProvider) GetClusterName() string {
+	if c.name != "" || c.hostInfo == nil {
+		return c.name
+	}
+	return c.hostInfo.GetClusterName()
+}
@@ -16,6 +16,7 @@ package awscontainerinsightreceiver
 
 import (
 	"context"
+	"os"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -40,3 +41,43 @@ func TestCreateMetricsReceiver(t *testing.T) {
 
 	require.NotNil(t, metricsReceiver)
 }
+
+func TestCreateMetricsReceiverWindows(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.ComputeType = computeTypeWindows
+	cfg.KubeletEndpoint = "10.0.0.1:10250"
+	metricsReceiver, err := createMetricsReceiver(
+		context.Background(),
+		component.ReceiverCreateSettings{Logger: zap.NewNop()},
+		cfg,
+		&testbed.MockMetricConsumer{},
+	)
+
+	require.NoError(t, err)
+	require.NotNil(t, metricsReceiver)
+}
+
+func TestCreateMetricsReceiverFargateOutsideCluster(t *testing.T) {
+	host, ok := os.LookupEnv("KUBERNETES_SERVICE_HOST")
+	os.Unsetenv("KUBERNETES_SERVICE_HOST")
+	if ok {
+		defer os.Setenv("KUBERNETES_SERVICE_HOST", host)
+	}
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.ComputeType = computeTypeFargate
+	cfg.ClusterName = "demo"
+	_, err := createMetricsReceiver(
+		context.Background(),
+		component.ReceiverCreateSettings{Logger: zap.NewNop()},
+		cfg,
+		&testbed.MockMetricConsumer{},
+	)
+
+	assert.Error(t, err)
+}
+
+func TestClusterNameProvider(t *testing.T) {
+	assert.Equal(t, "demo", (&clusterNameProvider{name: "demo"}).GetClusterName())
+	assert.Equal(t, "", (&clusterNameProvider{}).GetClusterName())
+}
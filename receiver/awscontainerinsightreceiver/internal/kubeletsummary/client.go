@@ -0,0 +1,207 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubeletsummary
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/multierr"
+)
+
+const (
+	// fargateComputeTypeLabel is set by EKS on the nodes backing Fargate pods.
+	fargateComputeTypeLabel = "eks.amazonaws.com/compute-type"
+	fargateComputeType      = "fargate"
+
+	requestTimeout = 10 * time.Second
+)
+
+// nodeSummary is the summary of a node together with the node capacity used
+// to compute utilization metrics. A zero capacity disables those metrics.
+type nodeSummary struct {
+	summary        *summary
+	cpuCapacity    float64 // in millicores
+	memoryCapacity float64 // in bytes
+}
+
+type summarySource interface {
+	fetch(ctx context.Context) ([]nodeSummary, error)
+}
+
+// CapacityProvider provides the capacity of the local node.
+type CapacityProvider interface {
+	GetNumCores() int64
+	GetMemoryCapacity() int64
+}
+
+// kubeletSource reads the summary of the local node from the kubelet.
+type kubeletSource struct {
+	client    *http.Client
+	endpoint  string
+	tokenFile string
+	capacity  CapacityProvider
+}
+
+func (s *kubeletSource) fetch(ctx context.Context) ([]nodeSummary, error) {
+	var sum summary
+	if err := getJSON(ctx, s.client, s.endpoint+"/stats/summary", s.tokenFile, &sum); err != nil {
+		return nil, err
+	}
+	ns := nodeSummary{summary: &sum}
+	if s.capacity != nil {
+		ns.cpuCapacity = float64(s.capacity.GetNumCores() * 1000)
+		ns.memoryCapacity = float64(s.capacity.GetMemoryCapacity())
+	}
+	return []nodeSummary{ns}, nil
+}
+
+// apiServerSource reads the summaries of all the Fargate nodes of the cluster
+// through the API server node proxy, as the kubelet of a Fargate node can not
+// be reached from the pods it runs.
+type apiServerSource struct {
+	client    *http.Client
+	endpoint  string
+	tokenFile string
+}
+
+type nodeList struct {
+	Items []node `json:"items"`
+}
+
+type node struct {
+	Metadata struct {
+		Name string `json:"name"`
+	} `json:"metadata"`
+	Status struct {
+		Capacity map[string]string `json:"capacity"`
+	} `json:"status"`
+}
+
+func (s *apiServerSource) fetch(ctx context.Context) ([]nodeSummary, error) {
+	var nodes nodeList
+	selector := url.QueryEscape(fargateComputeTypeLabel + "=" + fargateComputeType)
+	if err := getJSON(ctx, s.client, s.endpoint+"/api/v1/nodes?labelSelector="+selector, s.tokenFile, &nodes); err != nil {
+		return nil, err
+	}
+
+	var errs error
+	summaries := make([]nodeSummary, 0, len(nodes.Items))
+	for _, n := range nodes.Items {
+		var sum summary
+		summaryURL := s.endpoint + "/api/v1/nodes/" + url.PathEscape(n.Metadata.Name) + "/proxy/stats/summary"
+		if err := getJSON(ctx, s.client, summaryURL, s.tokenFile, &sum); err != nil {
+			errs = multierr.Append(errs, err)
+			continue
+		}
+		ns := nodeSummary{summary: &sum}
+		if cpu, err := parseQuantity(n.Status.Capacity["cpu"]); err == nil {
+			ns.cpuCapacity = cpu * 1000
+		}
+		if memory, err := parseQuantity(n.Status.Capacity["memory"]); err == nil {
+			ns.memoryCapacity = memory
+		}
+		summaries = append(summaries, ns)
+	}
+	return summaries, errs
+}
+
+func getJSON(ctx context.Context, client *http.Client, target string, tokenFile string, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return err
+	}
+	if tokenFile != "" {
+		// The token is read on every request as projected service account tokens are rotated.
+		token, err := ioutil.ReadFile(tokenFile)
+		if err != nil {
+			return fmt.Errorf("failed to read service account token: %w", err)
+		}
+		req.Header.Set("Authorization", "Bearer "+strings.TrimSpace(string(token)))
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("request to %s failed with status %d", target, resp.StatusCode)
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+func newHTTPClient(caFile string, insecureSkipVerify bool) (*http.Client, error) {
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: insecureSkipVerify, // #nosec G402: kubelet serving certificates are commonly self-signed
+	}
+	if caFile != "" && !insecureSkipVerify {
+		ca, err := ioutil.ReadFile(caFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA file: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(ca) {
+			return nil, fmt.Errorf("no certificate found in %s", caFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+	return &http.Client{Transport: transport, Timeout: requestTimeout}, nil
+}
+
+var quantitySuffixes = []struct {
+	suffix     string
+	multiplier float64
+}{
+	{"Ki", 1 << 10},
+	{"Mi", 1 << 20},
+	{"Gi", 1 << 30},
+	{"Ti", 1 << 40},
+	{"m", 1e-3},
+	{"k", 1e3},
+	{"M", 1e6},
+	{"G", 1e9},
+	{"T", 1e12},
+}
+
+// parseQuantity parses the kubernetes resource quantities reported in the
+// node capacity, e.g. "2", "500m" or "3977704Ki".
+func parseQuantity(s string) (float64, error) {
+	multiplier := 1.0
+	for _, q := range quantitySuffixes {
+		if strings.HasSuffix(s, q.suffix) {
+			s = strings.TrimSuffix(s, q.suffix)
+			multiplier = q.multiplier
+			break
+		}
+	}
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, err
+	}
+	return v * multiplier, nil
+}
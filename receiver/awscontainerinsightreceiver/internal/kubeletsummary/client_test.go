@@ -0,0 +1,164 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubeletsummary
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeToken(t *testing.T) string {
+	tokenFile := path.Join(t.TempDir(), "token")
+	require.NoError(t, ioutil.WriteFile(tokenFile, []byte("secret\n"), 0600))
+	return tokenFile
+}
+
+type fixedCapacity struct{}
+
+func (fixedCapacity) GetNumCores() int64       { return 2 }
+func (fixedCapacity) GetMemoryCapacity() int64 { return 1 << 30 }
+
+func TestKubeletSourceFetch(t *testing.T) {
+	summaryJSON, err := ioutil.ReadFile(path.Join("testdata", "summary.json"))
+	require.NoError(t, err)
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/stats/summary", r.URL.Path)
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+		_, _ = w.Write(summaryJSON)
+	}))
+	defer server.Close()
+
+	client, err := newHTTPClient("", true)
+	require.NoError(t, err)
+	source := &kubeletSource{
+		client:    client,
+		endpoint:  server.URL,
+		tokenFile: writeToken(t),
+		capacity:  fixedCapacity{},
+	}
+
+	summaries, err := source.fetch(context.Background())
+	require.NoError(t, err)
+	require.Len(t, summaries, 1)
+	assert.Equal(t, "fargate-ip-192-168-1-10.us-west-2.compute.internal", summaries[0].summary.Node.NodeName)
+	assert.Len(t, summaries[0].summary.Pods, 2)
+	assert.Equal(t, float64(2000), summaries[0].cpuCapacity)
+	assert.Equal(t, float64(1<<30), summaries[0].memoryCapacity)
+}
+
+func TestKubeletSourceFetchError(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	// the self-signed certificate of the test server is rejected unless verification is skipped
+	client, err := newHTTPClient("", false)
+	require.NoError(t, err)
+	source := &kubeletSource{client: client, endpoint: server.URL}
+	_, err = source.fetch(context.Background())
+	assert.Error(t, err)
+
+	source.client, err = newHTTPClient("", true)
+	require.NoError(t, err)
+	_, err = source.fetch(context.Background())
+	assert.EqualError(t, err, "request to "+server.URL+"/stats/summary failed with status 401")
+}
+
+func TestAPIServerSourceFetch(t *testing.T) {
+	summaryJSON, err := ioutil.ReadFile(path.Join("testdata", "summary.json"))
+	require.NoError(t, err)
+
+	nodes := nodeList{Items: make([]node, 2)}
+	nodes.Items[0].Metadata.Name = "fargate-ip-192-168-1-10.us-west-2.compute.internal"
+	nodes.Items[0].Status.Capacity = map[string]string{"cpu": "2", "memory": "4Gi"}
+	nodes.Items[1].Metadata.Name = "fargate-ip-192-168-1-11.us-west-2.compute.internal"
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+		switch r.URL.Path {
+		case "/api/v1/nodes":
+			assert.Equal(t, "eks.amazonaws.com/compute-type=fargate", r.URL.Query().Get("labelSelector"))
+			assert.NoError(t, json.NewEncoder(w).Encode(nodes))
+		case "/api/v1/nodes/fargate-ip-192-168-1-10.us-west-2.compute.internal/proxy/stats/summary":
+			_, _ = w.Write(summaryJSON)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := newHTTPClient("", true)
+	require.NoError(t, err)
+	source := &apiServerSource{
+		client:    client,
+		endpoint:  server.URL,
+		tokenFile: writeToken(t),
+	}
+
+	// the summary of the second node is not found, the first one is still returned
+	summaries, err := source.fetch(context.Background())
+	assert.Error(t, err)
+	require.Len(t, summaries, 1)
+	assert.Equal(t, float64(2000), summaries[0].cpuCapacity)
+	assert.Equal(t, float64(4<<30), summaries[0].memoryCapacity)
+	assert.Len(t, summaries[0].summary.Pods, 2)
+}
+
+func TestNewHTTPClientCAFile(t *testing.T) {
+	_, err := newHTTPClient(path.Join(t.TempDir(), "missing.crt"), false)
+	assert.Error(t, err)
+
+	empty := path.Join(t.TempDir(), "empty.crt")
+	require.NoError(t, ioutil.WriteFile(empty, nil, 0600))
+	_, err = newHTTPClient(empty, false)
+	assert.Error(t, err)
+}
+
+func TestParseQuantity(t *testing.T) {
+	tests := []struct {
+		quantity string
+		want     float64
+		wantErr  bool
+	}{
+		{quantity: "2", want: 2},
+		{quantity: "500m", want: 0.5},
+		{quantity: "3977704Ki", want: 3977704 * 1024},
+		{quantity: "4Gi", want: 4 << 30},
+		{quantity: "1G", want: 1e9},
+		{quantity: "", wantErr: true},
+		{quantity: "abc", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.quantity, func(t *testing.T) {
+			got, err := parseQuantity(tt.quantity)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
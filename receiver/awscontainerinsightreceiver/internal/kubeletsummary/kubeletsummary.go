@@ -0,0 +1,348 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubeletsummary
+
+import (
+	"context"
+	"errors"
+	"net"
+	"os"
+	"strconv"
+	"time"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.uber.org/zap"
+
+	ci "github.com/open-telemetry/opentelemetry-collector-contrib/internal/aws/containerinsight"
+)
+
+const (
+	kubernetesServiceHostEnv = "KUBERNETES_SERVICE_HOST"
+	kubernetesServicePortEnv = "KUBERNETES_SERVICE_PORT"
+
+	// metricsVersion is the Version attribute set by the other Container Insights producers.
+	metricsVersion = "0"
+)
+
+type clusterNameProvider interface {
+	GetClusterName() string
+}
+
+// Provider generates Container Insights node, pod and container metrics from
+// the kubelet Summary API. It is used where the embedded cadvisor can not run:
+// on Windows nodes and on EKS Fargate.
+type Provider struct {
+	logger              *zap.Logger
+	source              summarySource
+	clusterNameProvider clusterNameProvider
+	rates               *rateCalculator
+	nowFunc             func() time.Time
+}
+
+// NewKubeletProvider creates a Provider reading the summary of the local node
+// from the kubelet listening on endpoint (host:port). capacity may be nil, in
+// which case no utilization metrics are generated.
+func NewKubeletProvider(endpoint string, insecureSkipVerify bool, clusterNameProvider clusterNameProvider,
+	capacity CapacityProvider, logger *zap.Logger) (*Provider, error) {
+	if endpoint == "" {
+		return nil, errors.New("kubelet endpoint is not set")
+	}
+	client, err := newHTTPClient(ci.CAFile, insecureSkipVerify)
+	if err != nil {
+		return nil, err
+	}
+	source := &kubeletSource{
+		client:    client,
+		endpoint:  "https://" + endpoint,
+		tokenFile: ci.BearerToken,
+		capacity:  capacity,
+	}
+	return newProvider(source, clusterNameProvider, logger), nil
+}
+
+// NewFargateProvider creates a Provider reading the summaries of the EKS
+// Fargate nodes of the cluster through the API server.
+func NewFargateProvider(clusterNameProvider clusterNameProvider, logger *zap.Logger) (*Provider, error) {
+	host, port := os.Getenv(kubernetesServiceHostEnv), os.Getenv(kubernetesServicePortEnv)
+	if host == "" || port == "" {
+		return nil, errors.New("unable to locate the kubernetes API server, " +
+			kubernetesServiceHostEnv + " and " + kubernetesServicePortEnv + " must be set")
+	}
+	client, err := newHTTPClient(ci.CAFile, false)
+	if err != nil {
+		return nil, err
+	}
+	source := &apiServerSource{
+		client:    client,
+		endpoint:  "https://" + net.JoinHostPort(host, port),
+		tokenFile: ci.BearerToken,
+	}
+	return newProvider(source, clusterNameProvider, logger), nil
+}
+
+func newProvider(source summarySource, clusterNameProvider clusterNameProvider, logger *zap.Logger) *Provider {
+	return &Provider{
+		logger:              logger,
+		source:              source,
+		clusterNameProvider: clusterNameProvider,
+		rates:               newRateCalculator(),
+		nowFunc:             time.Now,
+	}
+}
+
+// GetMetrics returns the metrics of the nodes, pods and containers reported by the kubelets.
+func (p *Provider) GetMetrics() []pdata.Metrics {
+	summaries, err := p.source.fetch(context.Background())
+	if err != nil {
+		p.logger.Warn("failed to fetch kubelet summary", zap.Error(err))
+	}
+
+	now := p.nowFunc()
+	result := []pdata.Metrics{}
+	for _, ns := range summaries {
+		result = append(result, p.convert(ns, now)...)
+	}
+	p.rates.advance()
+	return result
+}
+
+func (p *Provider) convert(ns nodeSummary, now time.Time) []pdata.Metrics {
+	var result []pdata.Metrics
+	node := ns.summary.Node
+	baseTags := map[string]string{
+		ci.ClusterNameKey: p.clusterNameProvider.GetClusterName(),
+		ci.NodeNameKey:    node.NodeName,
+		ci.Timestamp:      strconv.FormatInt(now.UnixNano(), 10),
+		ci.Version:        metricsVersion,
+	}
+	emit := func(mType string, tags map[string]string, fields map[string]float64) {
+		if len(fields) == 0 {
+			return
+		}
+		prefixed := make(map[string]interface{}, len(fields))
+		for k, v := range fields {
+			prefixed[ci.MetricName(mType, k)] = v
+		}
+		withType := copyTags(tags)
+		withType[ci.MetricType] = mType
+		result = append(result, ci.ConvertToOTLPMetrics(prefixed, withType, p.logger))
+	}
+
+	nodeKey := "node/" + node.NodeName
+	nodeFields := map[string]float64{}
+	p.addCPU(nodeFields, node.CPU, ns.cpuCapacity)
+	p.addMemory(nodeFields, nodeKey, node.Memory, ns.memoryCapacity, now)
+	p.addNetwork(nodeFields, nodeKey, node.Network, now)
+	if ns.cpuCapacity > 0 {
+		nodeFields[ci.CPULimit] = ns.cpuCapacity
+	}
+	if ns.memoryCapacity > 0 {
+		nodeFields[ci.MemLimit] = ns.memoryCapacity
+	}
+	emit(ci.TypeNode, baseTags, nodeFields)
+	if node.Fs != nil {
+		emit(ci.TypeNodeFS, baseTags, fsFields(node.Fs, true))
+	}
+
+	for _, pod := range ns.summary.Pods {
+		podTags := copyTags(baseTags)
+		podTags[ci.K8sNamespace] = pod.PodRef.Namespace
+		podTags[ci.K8sPodNameKey] = pod.PodRef.Name
+		podTags[ci.PodIDKey] = pod.PodRef.UID
+		podKey := "pod/" + pod.PodRef.UID
+
+		containerFields := make([]map[string]interface{}, 0, len(pod.Containers))
+		for _, c := range pod.Containers {
+			containerTags := copyTags(podTags)
+			containerTags[ci.ContainerNamekey] = c.Name
+			fields := map[string]float64{}
+			p.addCPU(fields, c.CPU, ns.cpuCapacity)
+			p.addMemory(fields, podKey+"/"+c.Name, c.Memory, ns.memoryCapacity, now)
+			emit(ci.TypeContainer, containerTags, fields)
+			if c.Rootfs != nil {
+				emit(ci.TypeContainerFS, containerTags, fsFields(c.Rootfs, false))
+			}
+
+			summable := make(map[string]interface{}, len(fields))
+			for k, v := range fields {
+				summable[k] = v
+			}
+			containerFields = append(containerFields, summable)
+		}
+
+		// Windows pods are not always reported with pod level cpu and memory
+		// stats, those are then aggregated from the containers.
+		podFields := map[string]float64{}
+		if pod.CPU == nil || pod.Memory == nil {
+			for k, v := range ci.SumFields(containerFields) {
+				podFields[k] = v
+			}
+		}
+		p.addCPU(podFields, pod.CPU, ns.cpuCapacity)
+		p.addMemory(podFields, podKey, pod.Memory, ns.memoryCapacity, now)
+		p.addNetwork(podFields, podKey, pod.Network, now)
+		emit(ci.TypePod, podTags, podFields)
+	}
+	return result
+}
+
+func (p *Provider) addCPU(fields map[string]float64, stats *cpuStats, capacity float64) {
+	if stats == nil || stats.UsageNanoCores == nil {
+		return
+	}
+	millicores := float64(*stats.UsageNanoCores) / 1e6
+	fields[ci.CPUTotal] = millicores
+	if capacity > 0 {
+		fields[ci.CPUUtilization] = millicores / capacity * 100
+	}
+}
+
+func (p *Provider) addMemory(fields map[string]float64, key string, stats *memoryStats, capacity float64, now time.Time) {
+	if stats == nil {
+		return
+	}
+	if stats.UsageBytes != nil {
+		fields[ci.MemUsage] = float64(*stats.UsageBytes)
+	}
+	if stats.RSSBytes != nil {
+		fields[ci.MemRss] = float64(*stats.RSSBytes)
+	}
+	if stats.WorkingSetBytes != nil {
+		fields[ci.MemWorkingset] = float64(*stats.WorkingSetBytes)
+		if capacity > 0 {
+			fields[ci.MemUtilization] = float64(*stats.WorkingSetBytes) / capacity * 100
+		}
+	}
+	ts := sampleTime(stats.Time, now)
+	p.addRate(fields, key, ci.MemPgfault, stats.PageFaults, ts)
+	p.addRate(fields, key, ci.MemPgmajfault, stats.MajorPageFaults, ts)
+}
+
+func (p *Provider) addNetwork(fields map[string]float64, key string, stats *networkStats, now time.Time) {
+	if stats == nil || len(stats.Interfaces) == 0 {
+		return
+	}
+	var rxBytes, rxErrors, txBytes, txErrors uint64
+	for _, i := range stats.Interfaces {
+		rxBytes += value(i.RxBytes)
+		rxErrors += value(i.RxErrors)
+		txBytes += value(i.TxBytes)
+		txErrors += value(i.TxErrors)
+	}
+	ts := sampleTime(stats.Time, now)
+	p.addRate(fields, key, ci.NetRxBytes, &rxBytes, ts)
+	p.addRate(fields, key, ci.NetRxErrors, &rxErrors, ts)
+	p.addRate(fields, key, ci.NetTxBytes, &txBytes, ts)
+	p.addRate(fields, key, ci.NetTxErrors, &txErrors, ts)
+	rx, rxOK := fields[ci.NetRxBytes]
+	tx, txOK := fields[ci.NetTxBytes]
+	if rxOK && txOK {
+		fields[ci.NetTotalBytes] = rx + tx
+	}
+}
+
+func (p *Provider) addRate(fields map[string]float64, key string, metric string, counter *uint64, ts time.Time) {
+	if counter == nil {
+		return
+	}
+	if rate, ok := p.rates.rate(key+"/"+metric, float64(*counter), ts); ok {
+		fields[metric] = rate
+	}
+}
+
+func fsFields(stats *fsStats, withInodes bool) map[string]float64 {
+	fields := map[string]float64{}
+	if stats.UsedBytes != nil {
+		fields[ci.FSUsage] = float64(*stats.UsedBytes)
+	}
+	if stats.AvailableBytes != nil {
+		fields[ci.FSAvailable] = float64(*stats.AvailableBytes)
+	}
+	if stats.CapacityBytes != nil {
+		fields[ci.FSCapacity] = float64(*stats.CapacityBytes)
+		if stats.UsedBytes != nil && *stats.CapacityBytes > 0 {
+			fields[ci.FSUtilization] = float64(*stats.UsedBytes) / float64(*stats.CapacityBytes) * 100
+		}
+	}
+	if withInodes {
+		if stats.Inodes != nil {
+			fields[ci.FSInodes] = float64(*stats.Inodes)
+		}
+		if stats.InodesFree != nil {
+			fields[ci.FSInodesfree] = float64(*stats.InodesFree)
+		}
+	}
+	return fields
+}
+
+func copyTags(tags map[string]string) map[string]string {
+	c := make(map[string]string, len(tags)+1)
+	for k, v := range tags {
+		c[k] = v
+	}
+	return c
+}
+
+func sampleTime(t time.Time, now time.Time) time.Time {
+	if t.IsZero() {
+		return now
+	}
+	return t
+}
+
+func value(v *uint64) uint64 {
+	if v == nil {
+		return 0
+	}
+	return *v
+}
+
+type sample struct {
+	value float64
+	time  time.Time
+}
+
+// rateCalculator turns the cumulative counters of the summary into per second
+// rates. Counters not seen during a collection are forgotten.
+type rateCalculator struct {
+	previous map[string]sample
+	current  map[string]sample
+}
+
+func newRateCalculator() *rateCalculator {
+	return &rateCalculator{
+		previous: map[string]sample{},
+		current:  map[string]sample{},
+	}
+}
+
+func (r *rateCalculator) rate(key string, v float64, t time.Time) (float64, bool) {
+	r.current[key] = sample{value: v, time: t}
+	prev, ok := r.previous[key]
+	if !ok {
+		return 0, false
+	}
+	elapsed := t.Sub(prev.time)
+	// a counter going backwards means the container or interface was reset
+	if elapsed < ci.MinTimeDiff || v < prev.value {
+		return 0, false
+	}
+	return (v - prev.value) / elapsed.Seconds(), true
+}
+
+func (r *rateCalculator) advance() {
+	r.previous = r.current
+	r.current = map[string]sample{}
+}
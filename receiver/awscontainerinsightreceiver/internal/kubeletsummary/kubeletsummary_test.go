@@ -0,0 +1,217 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubeletsummary
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.uber.org/zap"
+
+	ci "github.com/open-telemetry/opentelemetry-collector-contrib/internal/aws/containerinsight"
+)
+
+type fakeSource struct {
+	summaries []nodeSummary
+	err       error
+}
+
+func (s *fakeSource) fetch(context.Context) ([]nodeSummary, error) {
+	return s.summaries, s.err
+}
+
+type fakeClusterName struct{}
+
+func (fakeClusterName) GetClusterName() string { return "test-cluster" }
+
+func loadSummary(t *testing.T) *summary {
+	data, err := ioutil.ReadFile(path.Join("testdata", "summary.json"))
+	require.NoError(t, err)
+	var s summary
+	require.NoError(t, json.Unmarshal(data, &s))
+	return &s
+}
+
+// metricsByEntity indexes the metric values by metric type and the pod and container names.
+func metricsByEntity(mds []pdata.Metrics) map[string]map[string]float64 {
+	result := map[string]map[string]float64{}
+	for _, md := range mds {
+		rm := md.ResourceMetrics().At(0)
+		attrs := rm.Resource().Attributes()
+		key := ""
+		for _, k := range []string{ci.MetricType, ci.K8sPodNameKey, ci.ContainerNamekey} {
+			if v, ok := attrs.Get(k); ok {
+				key += "/" + v.StringVal()
+			}
+		}
+		values := map[string]float64{}
+		ilms := rm.InstrumentationLibraryMetrics()
+		for i := 0; i < ilms.Len(); i++ {
+			m := ilms.At(i).Metrics().At(0)
+			values[m.Name()] = m.DoubleGauge().DataPoints().At(0).Value()
+		}
+		result[key] = values
+	}
+	return result
+}
+
+func TestGetMetrics(t *testing.T) {
+	sum := loadSummary(t)
+	source := &fakeSource{summaries: []nodeSummary{{summary: sum, cpuCapacity: 2000, memoryCapacity: 1 << 30}}}
+	p := newProvider(source, fakeClusterName{}, zap.NewNop())
+	now := time.Date(2021, 6, 10, 10, 0, 5, 0, time.UTC)
+	p.nowFunc = func() time.Time { return now }
+
+	mds := p.GetMetrics()
+	// node, node fs, 2 pods, 3 containers and 1 container fs
+	require.Len(t, mds, 8)
+
+	attrs := mds[0].ResourceMetrics().At(0).Resource().Attributes()
+	assert.Equal(t, map[string]interface{}{
+		ci.ClusterNameKey: "test-cluster",
+		ci.NodeNameKey:    "fargate-ip-192-168-1-10.us-west-2.compute.internal",
+		ci.MetricType:     ci.TypeNode,
+		ci.Timestamp:      "1623319205000",
+		ci.Version:        "0",
+	}, attrsToMap(attrs))
+
+	metrics := metricsByEntity(mds)
+	assert.Equal(t, map[string]float64{
+		"node_cpu_usage_total":    500,
+		"node_cpu_utilization":    25,
+		"node_cpu_limit":          2000,
+		"node_memory_usage":       1 << 30,
+		"node_memory_working_set": 1 << 29,
+		"node_memory_rss":         1 << 28,
+		"node_memory_utilization": 50,
+		"node_memory_limit":       1 << 30,
+	}, metrics["/Node"])
+	assert.Equal(t, map[string]float64{
+		"node_filesystem_usage":       4000,
+		"node_filesystem_available":   6000,
+		"node_filesystem_capacity":    10000,
+		"node_filesystem_utilization": 40,
+		"node_filesystem_inodes":      1000,
+		"node_filesystem_inodes_free": 900,
+	}, metrics["/NodeFS"])
+	assert.Equal(t, map[string]float64{
+		"pod_cpu_usage_total":    250,
+		"pod_cpu_utilization":    12.5,
+		"pod_memory_working_set": 200 << 20,
+		"pod_memory_utilization": 200.0 / 1024 * 100,
+	}, metrics["/Pod/web-0"])
+	assert.Equal(t, map[string]float64{
+		"container_cpu_usage_total":    200,
+		"container_cpu_utilization":    10,
+		"container_memory_working_set": 100 << 20,
+		"container_memory_utilization": 100.0 / 1024 * 100,
+	}, metrics["/Container/web-0/web"])
+	assert.Equal(t, map[string]float64{
+		"container_filesystem_usage":       250,
+		"container_filesystem_available":   750,
+		"container_filesystem_capacity":    1000,
+		"container_filesystem_utilization": 25,
+	}, metrics["/ContainerFS/web-0/web"])
+
+	// the windows pod has no pod level stats, they are aggregated from its containers
+	assert.Equal(t, map[string]float64{
+		"pod_cpu_usage_total":    150,
+		"pod_cpu_utilization":    7.5,
+		"pod_memory_working_set": 150 << 20,
+		"pod_memory_utilization": 150.0 / 1024 * 100,
+	}, metrics["/Pod/iis-0"])
+}
+
+func TestGetMetricsRates(t *testing.T) {
+	first := loadSummary(t)
+	source := &fakeSource{summaries: []nodeSummary{{summary: first}}}
+	p := newProvider(source, fakeClusterName{}, zap.NewNop())
+	p.GetMetrics()
+
+	second := loadSummary(t)
+	advance := func(ts *time.Time) { *ts = ts.Add(10 * time.Second) }
+	advance(&second.Node.Memory.Time)
+	advance(&second.Node.Network.Time)
+	advance(&second.Pods[0].Network.Time)
+	*second.Node.Memory.PageFaults += 500
+	*second.Node.Memory.MajorPageFaults += 20
+	*second.Node.Network.Interfaces[0].RxBytes += 1000
+	*second.Node.Network.Interfaces[0].TxBytes += 3000
+	*second.Pods[0].Network.Interfaces[0].RxBytes += 100
+	// a counter going backwards does not produce a rate
+	*second.Pods[0].Network.Interfaces[0].TxBytes -= 100
+	source.summaries = []nodeSummary{{summary: second}}
+
+	metrics := metricsByEntity(p.GetMetrics())
+	node := metrics["/Node"]
+	assert.Equal(t, float64(50), node["node_memory_pgfault"])
+	assert.Equal(t, float64(2), node["node_memory_pgmajfault"])
+	assert.Equal(t, float64(100), node["node_network_rx_bytes"])
+	assert.Equal(t, float64(300), node["node_network_tx_bytes"])
+	assert.Equal(t, float64(400), node["node_network_total_bytes"])
+	assert.Equal(t, float64(0), node["node_network_rx_errors"])
+	// no utilization without the node capacity
+	assert.NotContains(t, node, "node_cpu_utilization")
+
+	pod := metrics["/Pod/web-0"]
+	assert.Equal(t, float64(10), pod["pod_network_rx_bytes"])
+	assert.NotContains(t, pod, "pod_network_tx_bytes")
+	assert.NotContains(t, pod, "pod_network_total_bytes")
+}
+
+func TestGetMetricsFetchError(t *testing.T) {
+	source := &fakeSource{err: errors.New("unreachable")}
+	p := newProvider(source, fakeClusterName{}, zap.NewNop())
+	mds := p.GetMetrics()
+	assert.NotNil(t, mds)
+	assert.Len(t, mds, 0)
+}
+
+func TestNewKubeletProvider(t *testing.T) {
+	_, err := NewKubeletProvider("", true, fakeClusterName{}, nil, zap.NewNop())
+	assert.Error(t, err)
+
+	p, err := NewKubeletProvider("10.0.0.1:10250", true, fakeClusterName{}, nil, zap.NewNop())
+	require.NoError(t, err)
+	assert.Equal(t, "https://10.0.0.1:10250", p.source.(*kubeletSource).endpoint)
+}
+
+func TestNewFargateProvider(t *testing.T) {
+	host, ok := os.LookupEnv(kubernetesServiceHostEnv)
+	os.Unsetenv(kubernetesServiceHostEnv)
+	if ok {
+		defer os.Setenv(kubernetesServiceHostEnv, host)
+	}
+	_, err := NewFargateProvider(fakeClusterName{}, zap.NewNop())
+	assert.Error(t, err)
+}
+
+func attrsToMap(attrs pdata.AttributeMap) map[string]interface{} {
+	m := map[string]interface{}{}
+	attrs.Range(func(k string, v pdata.AttributeValue) bool {
+		m[k] = v.StringVal()
+		return true
+	})
+	return m
+}
@@ -0,0 +1,90 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubeletsummary
+
+import "time"
+
+// The types below mirror the subset of the kubelet Summary API
+// (k8s.io/kubelet/pkg/apis/stats/v1alpha1) used to build Container Insights
+// metrics. They are declared here so the receiver does not depend on the kubelet module.
+
+type summary struct {
+	Node nodeStats  `json:"node"`
+	Pods []podStats `json:"pods"`
+}
+
+type nodeStats struct {
+	NodeName string        `json:"nodeName"`
+	CPU      *cpuStats     `json:"cpu,omitempty"`
+	Memory   *memoryStats  `json:"memory,omitempty"`
+	Network  *networkStats `json:"network,omitempty"`
+	Fs       *fsStats      `json:"fs,omitempty"`
+}
+
+type podReference struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	UID       string `json:"uid"`
+}
+
+type podStats struct {
+	PodRef     podReference     `json:"podRef"`
+	Containers []containerStats `json:"containers"`
+	CPU        *cpuStats        `json:"cpu,omitempty"`
+	Memory     *memoryStats     `json:"memory,omitempty"`
+	Network    *networkStats    `json:"network,omitempty"`
+}
+
+type containerStats struct {
+	Name   string       `json:"name"`
+	CPU    *cpuStats    `json:"cpu,omitempty"`
+	Memory *memoryStats `json:"memory,omitempty"`
+	Rootfs *fsStats     `json:"rootfs,omitempty"`
+}
+
+type cpuStats struct {
+	Time           time.Time `json:"time"`
+	UsageNanoCores *uint64   `json:"usageNanoCores,omitempty"`
+}
+
+type memoryStats struct {
+	Time            time.Time `json:"time"`
+	UsageBytes      *uint64   `json:"usageBytes,omitempty"`
+	WorkingSetBytes *uint64   `json:"workingSetBytes,omitempty"`
+	RSSBytes        *uint64   `json:"rssBytes,omitempty"`
+	PageFaults      *uint64   `json:"pageFaults,omitempty"`
+	MajorPageFaults *uint64   `json:"majorPageFaults,omitempty"`
+}
+
+type interfaceStats struct {
+	Name     string  `json:"name"`
+	RxBytes  *uint64 `json:"rxBytes,omitempty"`
+	RxErrors *uint64 `json:"rxErrors,omitempty"`
+	TxBytes  *uint64 `json:"txBytes,omitempty"`
+	TxErrors *uint64 `json:"txErrors,omitempty"`
+}
+
+type networkStats struct {
+	Time       time.Time        `json:"time"`
+	Interfaces []interfaceStats `json:"interfaces,omitempty"`
+}
+
+type fsStats struct {
+	AvailableBytes *uint64 `json:"availableBytes,omitempty"`
+	CapacityBytes  *uint64 `json:"capacityBytes,omitempty"`
+	UsedBytes      *uint64 `json:"usedBytes,omitempty"`
+	InodesFree     *uint64 `json:"inodesFree,omitempty"`
+	Inodes         *uint64 `json:"inodes,omitempty"`
+}
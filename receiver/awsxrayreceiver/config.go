@@ -15,6 +15,9 @@
 package awsxrayreceiver
 
 import (
+	"errors"
+	"time"
+
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/config/confignet"
 
@@ -31,4 +34,44 @@ type Config struct {
 
 	// ProxyServer defines configurations related to the local TCP proxy server.
 	ProxyServer *proxy.Config `mapstructure:"proxy_server"`
+
+	// MaxSegmentSize is the maximum size, in bytes, of the segment documents
+	// accepted by the receiver. Larger documents are dropped.
+	MaxSegmentSize int `mapstructure:"max_segment_size"`
+
+	// PartialSegments defines configurations related to the reassembly of
+	// in_progress segments.
+	PartialSegments PartialSegmentsConfig `mapstructure:"partial_segments"`
+}
+
+// PartialSegmentsConfig defines how the in_progress updates of a segment are
+// buffered until its completed version is received.
+type PartialSegmentsConfig struct {
+	// Enabled turns the reassembly on. When off, every update is translated
+	// to a span of its own.
+	Enabled bool `mapstructure:"enabled"`
+
+	// Timeout is how long an in_progress segment is kept without any update
+	// before being translated as is.
+	Timeout time.Duration `mapstructure:"timeout"`
+
+	// MaxPending is the maximum number of in_progress segments kept. The
+	// oldest one is translated as is to make room for a new one.
+	MaxPending int `mapstructure:"max_pending"`
+}
+
+// Validate checks the receiver configuration is valid.
+func (cfg *Config) Validate() error {
+	if cfg.MaxSegmentSize <= 0 {
+		return errors.New("max_segment_size must be positive")
+	}
+	if cfg.PartialSegments.Enabled {
+		if cfg.PartialSegments.Timeout <= 0 {
+			return errors.New("partial_segments timeout must be positive")
+		}
+		if cfg.PartialSegments.MaxPending <= 0 {
+			return errors.New("partial_segments max_pending must be positive")
+		}
+	}
+	return nil
 }
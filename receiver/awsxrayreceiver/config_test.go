@@ -17,6 +17,7 @@ package awsxrayreceiver
 import (
 	"path"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -41,7 +42,7 @@ func TestLoadConfig(t *testing.T) {
 	require.NoError(t, err)
 	require.NotNil(t, cfg)
 
-	assert.Equal(t, len(cfg.Receivers), 3)
+	assert.Equal(t, len(cfg.Receivers), 4)
 
 	// ensure default configurations are generated when users provide
 	// nothing.
@@ -70,6 +71,12 @@ func TestLoadConfig(t *testing.T) {
 				RoleARN:     "",
 				AWSEndpoint: "",
 			},
+			MaxSegmentSize: 64 * 1024,
+			PartialSegments: PartialSegmentsConfig{
+				Enabled:    true,
+				Timeout:    time.Minute,
+				MaxPending: 1000,
+			},
 		},
 		r1)
 
@@ -96,6 +103,69 @@ func TestLoadConfig(t *testing.T) {
 				AWSEndpoint: "https://another.aws.endpoint.com",
 				LocalMode:   true,
 			},
+			MaxSegmentSize: 64 * 1024,
+			PartialSegments: PartialSegmentsConfig{
+				Enabled:    true,
+				Timeout:    time.Minute,
+				MaxPending: 1000,
+			},
 		},
 		r2)
+
+	// ensure the segment size and partial segments settings are properly overwritten
+	r3 := cfg.Receivers[config.NewIDWithName(awsxray.TypeStr, "partial_segments")].(*Config)
+	assert.Equal(t, 32*1024, r3.MaxSegmentSize)
+	assert.Equal(t,
+		PartialSegmentsConfig{
+			Enabled:    true,
+			Timeout:    30 * time.Second,
+			MaxPending: 500,
+		},
+		r3.PartialSegments)
+}
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(cfg *Config)
+		wantErr string
+	}{
+		{
+			name:   "default",
+			mutate: func(cfg *Config) {},
+		},
+		{
+			name:    "no max segment size",
+			mutate:  func(cfg *Config) { cfg.MaxSegmentSize = 0 },
+			wantErr: "max_segment_size must be positive",
+		},
+		{
+			name:    "no partial segments timeout",
+			mutate:  func(cfg *Config) { cfg.PartialSegments.Timeout = 0 },
+			wantErr: "partial_segments timeout must be positive",
+		},
+		{
+			name:    "no partial segments max pending",
+			mutate:  func(cfg *Config) { cfg.PartialSegments.MaxPending = 0 },
+			wantErr: "partial_segments max_pending must be positive",
+		},
+		{
+			name: "partial segments disabled",
+			mutate: func(cfg *Config) {
+				cfg.PartialSegments = PartialSegmentsConfig{}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			tt.mutate(cfg)
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+		})
+	}
 }
@@ -16,7 +16,9 @@ package awsxrayreceiver
 
 import (
 	"context"
+	"time"
 
+	"go.opencensus.io/stats/view"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/config/confignet"
@@ -24,12 +26,24 @@ import (
 	"go.opentelemetry.io/collector/receiver/receiverhelper"
 
 	awsxray "github.com/open-telemetry/opentelemetry-collector-contrib/internal/aws/xray"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/awsxrayreceiver/internal/observ"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/awsxrayreceiver/internal/proxy"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/awsxrayreceiver/internal/udppoller"
 )
 
+const (
+	// X-Ray rejects segment documents larger than 64 kB.
+	// https://docs.aws.amazon.com/general/latest/gr/xray.html#limits_xray
+	defaultMaxSegmentSize = 64 * 1024
+
+	defaultPartialSegmentsTimeout    = time.Minute
+	defaultPartialSegmentsMaxPending = 1000
+)
+
 // NewFactory creates a factory for AWS receiver.
 func NewFactory() component.ReceiverFactory {
+	_ = view.Register(observ.MetricViews()...)
+
 	return receiverhelper.NewFactory(
 		awsxray.TypeStr,
 		createDefaultConfig,
@@ -48,7 +62,13 @@ func createDefaultConfig() config.Receiver {
 			Endpoint:  "0.0.0.0:2000",
 			Transport: udppoller.Transport,
 		},
-		ProxyServer: proxy.DefaultConfig(),
+		ProxyServer:    proxy.DefaultConfig(),
+		MaxSegmentSize: defaultMaxSegmentSize,
+		PartialSegments: PartialSegmentsConfig{
+			Enabled:    true,
+			Timeout:    defaultPartialSegmentsTimeout,
+			MaxPending: defaultPartialSegmentsMaxPending,
+		},
 	}
 }
 
@@ -0,0 +1,103 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package observ contains logic pertaining to the internal observation
+// of the AWS X-Ray receiver.
+package observ
+
+import (
+	"context"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+)
+
+// Reasons a segment is dropped, used as the value of the reason tag.
+const (
+	ReasonMissingBody = "missing_body"
+	ReasonOversized   = "oversized"
+	ReasonUnparseable = "unparseable"
+	ReasonInvalid     = "invalid"
+)
+
+var (
+	tagReason, _ = tag.NewKey("reason")
+
+	// SegmentBodySize measure for the size of the segment documents received.
+	SegmentBodySize = stats.Int64(
+		"awsxray_receiver_segment_body_size",
+		"Size of the X-Ray segment documents received",
+		stats.UnitBytes)
+	segmentBodySizeView = &view.View{
+		Name:        SegmentBodySize.Name(),
+		Measure:     SegmentBodySize,
+		Description: SegmentBodySize.Description(),
+		Aggregation: view.Distribution(256, 1024, 4096, 16384, 32768, 65536),
+	}
+
+	// SegmentsDropped measure for number of segments dropped before being translated.
+	SegmentsDropped = stats.Int64(
+		"awsxray_receiver_segments_dropped",
+		"Number of X-Ray segments dropped before being translated",
+		stats.UnitDimensionless)
+	segmentsDroppedView = &view.View{
+		Name:        SegmentsDropped.Name(),
+		Measure:     SegmentsDropped,
+		Description: SegmentsDropped.Description(),
+		TagKeys:     []tag.Key{tagReason},
+		Aggregation: view.Sum(),
+	}
+
+	// PartialSegmentsReassembled measure for number of completed segments merged
+	// with their in_progress updates.
+	PartialSegmentsReassembled = stats.Int64(
+		"awsxray_receiver_partial_segments_reassembled",
+		"Number of completed X-Ray segments merged with their in_progress updates",
+		stats.UnitDimensionless)
+	partialSegmentsReassembledView = &view.View{
+		Name:        PartialSegmentsReassembled.Name(),
+		Measure:     PartialSegmentsReassembled,
+		Description: PartialSegmentsReassembled.Description(),
+		Aggregation: view.Sum(),
+	}
+
+	// PartialSegmentsFlushed measure for number of in_progress segments emitted
+	// without their completed version, because they expired or were evicted.
+	PartialSegmentsFlushed = stats.Int64(
+		"awsxray_receiver_partial_segments_flushed",
+		"Number of in_progress X-Ray segments emitted before being completed",
+		stats.UnitDimensionless)
+	partialSegmentsFlushedView = &view.View{
+		Name:        PartialSegmentsFlushed.Name(),
+		Measure:     PartialSegmentsFlushed,
+		Description: PartialSegmentsFlushed.Description(),
+		Aggregation: view.Sum(),
+	}
+)
+
+// MetricViews returns the metrics views related to the receiver.
+func MetricViews() []*view.View {
+	return []*view.View{
+		segmentBodySizeView,
+		segmentsDroppedView,
+		partialSegmentsReassembledView,
+		partialSegmentsFlushedView,
+	}
+}
+
+// RecordDropped records a segment dropped for the given reason.
+func RecordDropped(ctx context.Context, reason string) {
+	_ = stats.RecordWithTags(ctx, []tag.Mutator{tag.Upsert(tagReason, reason)}, SegmentsDropped.M(1))
+}
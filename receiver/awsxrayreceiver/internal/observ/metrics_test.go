@@ -0,0 +1,52 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package observ
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/stats/view"
+)
+
+func TestViews(t *testing.T) {
+	require.Equal(t, len(MetricViews()), 4)
+}
+
+func TestRecordDropped(t *testing.T) {
+	require.NoError(t, view.Register(MetricViews()...))
+	defer view.Unregister(MetricViews()...)
+
+	RecordDropped(context.Background(), ReasonOversized)
+	RecordDropped(context.Background(), ReasonOversized)
+	RecordDropped(context.Background(), ReasonUnparseable)
+
+	rows, err := view.RetrieveData(SegmentsDropped.Name())
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+	for _, row := range rows {
+		require.Len(t, row.Tags, 1)
+		switch row.Tags[0].Value {
+		case ReasonOversized:
+			assert.Equal(t, float64(2), row.Data.(*view.SumData).Value)
+		case ReasonUnparseable:
+			assert.Equal(t, float64(1), row.Data.(*view.SumData).Value)
+		default:
+			t.Errorf("unexpected reason %q", row.Tags[0].Value)
+		}
+	}
+}
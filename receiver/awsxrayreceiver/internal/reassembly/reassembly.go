@@ -0,0 +1,145 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package reassembly holds the in_progress updates of X-Ray segments until
+// their completed version is received.
+//
+// Some X-Ray SDKs send a segment (or an independent subsegment) as soon as it
+// starts with `in_progress` set, then send it again once it ends. Translating
+// every update produces several spans with the same span ID, so the updates are
+// buffered here and merged with the completed segment instead.
+package reassembly
+
+import (
+	"time"
+
+	awsxray "github.com/open-telemetry/opentelemetry-collector-contrib/internal/aws/xray"
+)
+
+type key struct {
+	traceID string
+	id      string
+}
+
+type pendingSegment struct {
+	seg      *awsxray.Segment
+	received time.Time
+}
+
+// Buffer keeps the latest in_progress update of each segment. It isn't safe
+// for concurrent use.
+type Buffer struct {
+	timeout    time.Duration
+	maxPending int
+	pending    map[key]*pendingSegment
+}
+
+// NewBuffer creates a Buffer keeping at most maxPending in_progress segments,
+// each of them for at most timeout.
+func NewBuffer(timeout time.Duration, maxPending int) *Buffer {
+	return &Buffer{
+		timeout:    timeout,
+		maxPending: maxPending,
+		pending:    make(map[key]*pendingSegment),
+	}
+}
+
+// Add records a validated segment received at now.
+//
+// A completed segment is returned right away, merged with its buffered
+// in_progress update if any, in which case reassembled is true. An in_progress
+// segment replaces its previous update and isn't returned, but the oldest
+// in_progress segment is returned as evicted when it has to make room for it.
+func (b *Buffer) Add(seg *awsxray.Segment, now time.Time) (completed, evicted *awsxray.Segment, reassembled bool) {
+	k := key{traceID: *seg.TraceID, id: *seg.ID}
+	prev, found := b.pending[k]
+
+	if seg.InProgress == nil || !*seg.InProgress {
+		if !found {
+			return seg, nil, false
+		}
+		delete(b.pending, k)
+		mergeSubsegments(seg, prev.seg)
+		return seg, nil, true
+	}
+
+	if found {
+		mergeSubsegments(seg, prev.seg)
+	} else if len(b.pending) >= b.maxPending {
+		evicted = b.evictOldest()
+	}
+	b.pending[k] = &pendingSegment{seg: seg, received: now}
+	return nil, evicted, false
+}
+
+// Expire removes and returns the in_progress segments that didn't get any
+// update for longer than the timeout.
+func (b *Buffer) Expire(now time.Time) []*awsxray.Segment {
+	var expired []*awsxray.Segment
+	for k, p := range b.pending {
+		if now.Sub(p.received) >= b.timeout {
+			expired = append(expired, p.seg)
+			delete(b.pending, k)
+		}
+	}
+	return expired
+}
+
+// Flush removes and returns all the in_progress segments.
+func (b *Buffer) Flush() []*awsxray.Segment {
+	flushed := make([]*awsxray.Segment, 0, len(b.pending))
+	for k, p := range b.pending {
+		flushed = append(flushed, p.seg)
+		delete(b.pending, k)
+	}
+	return flushed
+}
+
+// Len returns the number of buffered in_progress segments.
+func (b *Buffer) Len() int {
+	return len(b.pending)
+}
+
+func (b *Buffer) evictOldest() *awsxray.Segment {
+	var (
+		oldestKey key
+		oldest    *pendingSegment
+	)
+	for k, p := range b.pending {
+		if oldest == nil || p.received.Before(oldest.received) {
+			oldestKey, oldest = k, p
+		}
+	}
+	delete(b.pending, oldestKey)
+	return oldest.seg
+}
+
+// mergeSubsegments appends to seg the embedded subsegments of an earlier
+// update that the latest one doesn't carry anymore.
+func mergeSubsegments(seg, earlier *awsxray.Segment) {
+	ids := make(map[string]struct{}, len(seg.Subsegments))
+	for _, s := range seg.Subsegments {
+		if s.ID != nil {
+			ids[*s.ID] = struct{}{}
+		}
+	}
+	for _, s := range earlier.Subsegments {
+		if s.ID == nil {
+			continue
+		}
+		if _, ok := ids[*s.ID]; !ok {
+			seg.Subsegments = append(seg.Subsegments, s)
+		}
+	}
+}
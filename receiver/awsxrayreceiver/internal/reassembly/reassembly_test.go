@@ -0,0 +1,115 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reassembly
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+
+	awsxray "github.com/open-telemetry/opentelemetry-collector-contrib/internal/aws/xray"
+)
+
+const traceID = "1-5f187253-6a106696d56b1f4ef9eba2ed"
+
+func segment(id string, inProgress bool, subsegmentIDs ...string) *awsxray.Segment {
+	seg := &awsxray.Segment{
+		Name:      aws.String("seg"),
+		ID:        aws.String(id),
+		TraceID:   aws.String(traceID),
+		StartTime: aws.Float64(1595437651.680097),
+	}
+	if inProgress {
+		seg.InProgress = aws.Bool(true)
+	} else {
+		seg.EndTime = aws.Float64(1595437652.197392)
+	}
+	for _, subID := range subsegmentIDs {
+		seg.Subsegments = append(seg.Subsegments, awsxray.Segment{ID: aws.String(subID)})
+	}
+	return seg
+}
+
+func subsegmentIDs(seg *awsxray.Segment) []string {
+	var ids []string
+	for _, s := range seg.Subsegments {
+		ids = append(ids, *s.ID)
+	}
+	return ids
+}
+
+func TestCompletedSegmentWithoutUpdate(t *testing.T) {
+	b := NewBuffer(time.Minute, 10)
+	seg := segment("5cc4a447f5d4d696", false)
+
+	completed, evicted, reassembled := b.Add(seg, time.Now())
+	assert.Same(t, seg, completed)
+	assert.Nil(t, evicted)
+	assert.False(t, reassembled)
+	assert.Equal(t, 0, b.Len())
+}
+
+func TestReassembly(t *testing.T) {
+	b := NewBuffer(time.Minute, 10)
+	now := time.Now()
+
+	completed, evicted, _ := b.Add(segment("5cc4a447f5d4d696", true, "a"), now)
+	assert.Nil(t, completed)
+	assert.Nil(t, evicted)
+	completed, _, _ = b.Add(segment("5cc4a447f5d4d696", true, "b"), now)
+	assert.Nil(t, completed)
+	assert.Equal(t, 1, b.Len())
+
+	seg := segment("5cc4a447f5d4d696", false, "c", "b")
+	completed, _, reassembled := b.Add(seg, now)
+	assert.True(t, reassembled)
+	assert.Same(t, seg, completed)
+	assert.Equal(t, []string{"c", "b", "a"}, subsegmentIDs(seg))
+	assert.Equal(t, 0, b.Len())
+}
+
+func TestExpire(t *testing.T) {
+	b := NewBuffer(time.Minute, 10)
+	now := time.Now()
+
+	old := segment("5cc4a447f5d4d696", true)
+	b.Add(old, now)
+	b.Add(segment("6cc4a447f5d4d696", true), now.Add(30*time.Second))
+
+	assert.Empty(t, b.Expire(now.Add(59*time.Second)))
+	assert.Equal(t, []*awsxray.Segment{old}, b.Expire(now.Add(time.Minute)))
+	assert.Equal(t, 1, b.Len())
+	assert.Len(t, b.Flush(), 1)
+	assert.Equal(t, 0, b.Len())
+}
+
+func TestEvictOldest(t *testing.T) {
+	b := NewBuffer(time.Minute, 2)
+	now := time.Now()
+
+	oldest := segment("1cc4a447f5d4d696", true)
+	b.Add(oldest, now)
+	b.Add(segment("2cc4a447f5d4d696", true), now.Add(time.Second))
+
+	// an update of a buffered segment doesn't evict anything
+	_, evicted, _ := b.Add(segment("2cc4a447f5d4d696", true), now.Add(2*time.Second))
+	assert.Nil(t, evicted)
+
+	_, evicted, _ = b.Add(segment("3cc4a447f5d4d696", true), now.Add(3*time.Second))
+	assert.Same(t, oldest, evicted)
+	assert.Equal(t, 2, b.Len())
+}
@@ -35,6 +35,18 @@ const (
 
 // ToTraces converts X-Ray segment (and its subsegments) to an OT ResourceSpans.
 func ToTraces(rawSeg []byte) (*pdata.Traces, int, error) {
+	seg, count, err := ParseSegment(rawSeg)
+	if err != nil {
+		return nil, count, err
+	}
+	return SegmentToTraces(seg)
+}
+
+// ParseSegment unmarshals and validates an X-Ray segment document, returning
+// the total count of the segment and its embedded subsegments. When the
+// document can be unmarshalled but isn't valid, the segment is returned along
+// with the validation error.
+func ParseSegment(rawSeg []byte) (*awsxray.Segment, int, error) {
 	var seg awsxray.Segment
 	err := json.Unmarshal(rawSeg, &seg)
 	if err != nil {
@@ -42,9 +54,15 @@ func ToTraces(rawSeg []byte) (*pdata.Traces, int, error) {
 		// because we can't parse the body the UDP packet.
 		return nil, 1, err
 	}
-	count := totalSegmentsCount(seg)
+	return &seg, totalSegmentsCount(seg), seg.Validate()
+}
+
+// SegmentToTraces converts a segment returned by ParseSegment (and its
+// subsegments) to an OT ResourceSpans.
+func SegmentToTraces(seg *awsxray.Segment) (*pdata.Traces, int, error) {
+	count := totalSegmentsCount(*seg)
 
-	err = seg.Validate()
+	err := seg.Validate()
 	if err != nil {
 		return nil, count, err
 	}
@@ -65,14 +83,14 @@ func ToTraces(rawSeg []byte) (*pdata.Traces, int, error) {
 	spans := ils.Spans()
 
 	// populating global attributes shared among segment and embedded subsegment(s)
-	populateResource(&seg, &resource)
+	populateResource(seg, &resource)
 
 	// recursively traverse segment and embedded subsegments
 	// to populate the spans. We also need to pass in the
 	// TraceID of the root segment in because embedded subsegments
 	// do not have that information, but it's needed after we flatten
 	// the embedded subsegment to generate independent child spans.
-	_, _, err = segToSpans(seg, seg.TraceID, nil, &spans, 0)
+	_, _, err = segToSpans(*seg, seg.TraceID, nil, &spans, 0)
 	if err != nil {
 		return nil, count, err
 	}
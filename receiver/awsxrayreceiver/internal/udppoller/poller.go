@@ -21,12 +21,14 @@ import (
 	"net"
 	"sync"
 
+	"go.opencensus.io/stats"
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/obsreport"
 	"go.uber.org/zap"
 
 	awsxray "github.com/open-telemetry/opentelemetry-collector-contrib/internal/aws/xray"
 	recvErr "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/awsxrayreceiver/internal/errors"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/awsxrayreceiver/internal/observ"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/awsxrayreceiver/internal/socketconn"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/awsxrayreceiver/internal/tracesegment"
 )
@@ -69,6 +71,9 @@ type Config struct {
 	Transport          string
 	Endpoint           string
 	NumOfPollerToStart int
+	// MaxSegmentSize is the maximum size in bytes of the segment bodies
+	// sent to the channel, larger ones are dropped. 0 means no limit.
+	MaxSegmentSize int
 }
 
 type poller struct {
@@ -78,6 +83,7 @@ type poller struct {
 	wg                   sync.WaitGroup
 	receiverLongLivedCtx context.Context
 	maxPollerCount       int
+	maxSegmentSize       int
 	// closing this channel will shutdown all goroutines
 	// within this poller
 	shutDown chan struct{}
@@ -113,6 +119,7 @@ func New(cfg *Config, logger *zap.Logger) (Poller, error) {
 		udpSock:        sock,
 		logger:         logger,
 		maxPollerCount: cfg.NumOfPollerToStart,
+		maxSegmentSize: cfg.MaxSegmentSize,
 		shutDown:       make(chan struct{}),
 		segChan:        make(chan RawSegment, segChanSize),
 		obsrecv:        obsreport.NewReceiver(obsreport.ReceiverSettings{ReceiverID: cfg.ReceiverID, Transport: cfg.Transport}),
@@ -206,10 +213,23 @@ func (p *poller) poll() {
 					zap.String("header format", header.Format),
 					zap.Int("header version", header.Version),
 				)
+				observ.RecordDropped(ctx, observ.ReasonMissingBody)
 				p.obsrecv.EndTracesOp(ctx, awsxray.TypeStr, 1,
 					errors.New("dropped span due to missing body that contains segment"))
 				continue
 			}
+
+			stats.Record(ctx, observ.SegmentBodySize.M(int64(len(body))))
+			if p.maxSegmentSize > 0 && len(body) > p.maxSegmentSize {
+				p.logger.Warn("Segment body exceeds the maximum size",
+					zap.Int("size", len(body)),
+					zap.Int("max_segment_size", p.maxSegmentSize),
+				)
+				observ.RecordDropped(ctx, observ.ReasonOversized)
+				p.obsrecv.EndTracesOp(ctx, awsxray.TypeStr, 1,
+					fmt.Errorf("dropped span due to segment body of %d bytes exceeding the maximum size", len(body)))
+				continue
+			}
 			copybody := make([]byte, len(body))
 			copy(copybody, body)
 
@@ -203,6 +203,33 @@ func TestIncompletePacketNoBody(t *testing.T) {
 	obsreporttest.CheckReceiverTraces(t, receiverID, Transport, 0, 1)
 }
 
+func TestOversizedPacket(t *testing.T) {
+	doneFn, err := obsreporttest.SetupRecordedMetricsTest()
+	assert.NoError(t, err, "SetupRecordedMetricsTest should succeed")
+	defer doneFn()
+
+	receiverID := config.NewID("TestOversizedPacket")
+
+	addr, p, recordedLogs := createAndOptionallyStartPoller(t, receiverID, true)
+	defer p.Close()
+
+	rawData := []byte(`{"format": "json", "version": 1}` + "\n" + strings.Repeat("x", 65))
+	err = writePacket(t, addr, string(rawData))
+	assert.NoError(t, err, "can not write packet in the TestOversizedPacket case")
+	assert.Eventuallyf(t, func() bool {
+		logs := recordedLogs.All()
+		if len(logs) == 0 {
+			return false
+		}
+		lastEntry := logs[len(logs)-1]
+		return strings.Contains(lastEntry.Message, "Segment body exceeds the maximum size") &&
+			lastEntry.Context[0].Integer == 65 &&
+			lastEntry.Context[1].Integer == 64
+	}, 10*time.Second, 5*time.Millisecond, "poller should log oversized body")
+
+	obsreporttest.CheckReceiverTraces(t, receiverID, Transport, 0, 1)
+}
+
 func TestNonJsonHeader(t *testing.T) {
 	doneFn, err := obsreporttest.SetupRecordedMetricsTest()
 	assert.NoError(t, err, "SetupRecordedMetricsTest should succeed")
@@ -435,6 +462,7 @@ func createAndOptionallyStartPoller(
 		Transport:          Transport,
 		Endpoint:           addr,
 		NumOfPollerToStart: 2,
+		MaxSegmentSize:     64,
 	}, logger)
 	assert.NoError(t, err, "receiver should be created")
 
@@ -17,7 +17,10 @@ package awsxrayreceiver
 import (
 	"context"
 	"fmt"
+	"sync"
+	"time"
 
+	"go.opencensus.io/stats"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenterror"
 	"go.opentelemetry.io/collector/config"
@@ -26,7 +29,9 @@ import (
 	"go.uber.org/zap"
 
 	awsxray "github.com/open-telemetry/opentelemetry-collector-contrib/internal/aws/xray"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/awsxrayreceiver/internal/observ"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/awsxrayreceiver/internal/proxy"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/awsxrayreceiver/internal/reassembly"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/awsxrayreceiver/internal/translator"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/awsxrayreceiver/internal/udppoller"
 )
@@ -35,6 +40,9 @@ const (
 	// number of goroutines polling the UDP socket.
 	// https://github.com/aws/aws-xray-daemon/blob/master/pkg/cfg/cfg.go#L184
 	maxPollerCount = 2
+
+	// how often the buffered in_progress segments are checked for expiration.
+	partialSegmentsExpiryInterval = time.Second
 )
 
 // xrayReceiver implements the component.TracesReceiver interface for converting
@@ -47,6 +55,11 @@ type xrayReceiver struct {
 	consumer     consumer.Traces
 	longLivedCtx context.Context
 	obsrecv      *obsreport.Receiver
+	// partials is nil when the reassembly of in_progress segments is disabled.
+	partials *reassembly.Buffer
+	// tracks the goroutine consuming the segments, which emits the
+	// buffered in_progress segments once the poller is closed.
+	consuming sync.WaitGroup
 }
 
 func newReceiver(config *Config,
@@ -64,6 +77,7 @@ func newReceiver(config *Config,
 		Transport:          config.Transport,
 		Endpoint:           config.Endpoint,
 		NumOfPollerToStart: maxPollerCount,
+		MaxSegmentSize:     config.MaxSegmentSize,
 	}, logger)
 	if err != nil {
 		return nil, err
@@ -77,6 +91,11 @@ func newReceiver(config *Config,
 		return nil, err
 	}
 
+	var partials *reassembly.Buffer
+	if config.PartialSegments.Enabled {
+		partials = reassembly.NewBuffer(config.PartialSegments.Timeout, config.PartialSegments.MaxPending)
+	}
+
 	return &xrayReceiver{
 		instanceID: config.ID(),
 		poller:     poller,
@@ -84,6 +103,7 @@ func newReceiver(config *Config,
 		logger:     logger,
 		consumer:   consumer,
 		obsrecv:    obsreport.NewReceiver(obsreport.ReceiverSettings{ReceiverID: config.ID(), Transport: udppoller.Transport}),
+		partials:   partials,
 	}, nil
 }
 
@@ -91,6 +111,7 @@ func (x *xrayReceiver) Start(ctx context.Context, host component.Host) error {
 	// TODO: Might want to pass `host` into read() below to report a fatal error
 	x.longLivedCtx = obsreport.ReceiverContext(ctx, x.instanceID, udppoller.Transport)
 	x.poller.Start(x.longLivedCtx)
+	x.consuming.Add(1)
 	go x.start()
 	go x.server.ListenAndServe()
 	x.logger.Info("X-Ray TCP proxy server started")
@@ -102,6 +123,7 @@ func (x *xrayReceiver) Shutdown(_ context.Context) error {
 	if pollerErr := x.poller.Close(); pollerErr != nil {
 		err = pollerErr
 	}
+	x.consuming.Wait()
 
 	if proxyErr := x.server.Close(); proxyErr != nil {
 		if err == nil {
@@ -115,22 +137,88 @@ func (x *xrayReceiver) Shutdown(_ context.Context) error {
 }
 
 func (x *xrayReceiver) start() {
+	defer x.consuming.Done()
 	incomingSegments := x.poller.SegmentsChan()
-	for seg := range incomingSegments {
-		ctx := x.obsrecv.StartTracesOp(seg.Ctx)
-		traces, totalSpansCount, err := translator.ToTraces(seg.Payload)
-		if err != nil {
-			x.logger.Warn("X-Ray segment to OT traces conversion failed", zap.Error(err))
-			x.obsrecv.EndTracesOp(ctx, awsxray.TypeStr, totalSpansCount, err)
-			continue
+	if x.partials == nil {
+		for seg := range incomingSegments {
+			x.process(seg)
 		}
+		return
+	}
 
-		err = x.consumer.ConsumeTraces(ctx, *traces)
-		if err != nil {
-			x.logger.Warn("Trace consumer errored out", zap.Error(err))
-			x.obsrecv.EndTracesOp(ctx, awsxray.TypeStr, totalSpansCount, err)
-			continue
+	ticker := time.NewTicker(partialSegmentsExpiryInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case seg, open := <-incomingSegments:
+			if !open {
+				// the poller is stopped, emit what's left as is.
+				x.flushPartials(x.partials.Flush())
+				return
+			}
+			x.process(seg)
+		case now := <-ticker.C:
+			x.flushPartials(x.partials.Expire(now))
 		}
-		x.obsrecv.EndTracesOp(ctx, awsxray.TypeStr, totalSpansCount, nil)
 	}
 }
+
+func (x *xrayReceiver) process(rawSeg udppoller.RawSegment) {
+	ctx := x.obsrecv.StartTracesOp(rawSeg.Ctx)
+	seg, totalSpansCount, err := translator.ParseSegment(rawSeg.Payload)
+	if err != nil {
+		reason := observ.ReasonInvalid
+		if seg == nil {
+			reason = observ.ReasonUnparseable
+		}
+		observ.RecordDropped(ctx, reason)
+		x.logger.Warn("X-Ray segment to OT traces conversion failed", zap.Error(err))
+		x.obsrecv.EndTracesOp(ctx, awsxray.TypeStr, totalSpansCount, err)
+		return
+	}
+
+	if x.partials == nil {
+		x.consume(ctx, seg)
+		return
+	}
+
+	completed, evicted, reassembled := x.partials.Add(seg, time.Now())
+	if evicted != nil {
+		x.flushPartials([]*awsxray.Segment{evicted})
+	}
+	if completed == nil {
+		// the in_progress segment is emitted once completed or expired.
+		x.obsrecv.EndTracesOp(ctx, awsxray.TypeStr, 0, nil)
+		return
+	}
+	if reassembled {
+		stats.Record(ctx, observ.PartialSegmentsReassembled.M(1))
+	}
+	x.consume(ctx, completed)
+}
+
+// flushPartials emits in_progress segments that won't be completed.
+func (x *xrayReceiver) flushPartials(segs []*awsxray.Segment) {
+	for _, seg := range segs {
+		ctx := x.obsrecv.StartTracesOp(x.longLivedCtx)
+		stats.Record(ctx, observ.PartialSegmentsFlushed.M(1))
+		x.consume(ctx, seg)
+	}
+}
+
+func (x *xrayReceiver) consume(ctx context.Context, seg *awsxray.Segment) {
+	traces, totalSpansCount, err := translator.SegmentToTraces(seg)
+	if err != nil {
+		x.logger.Warn("X-Ray segment to OT traces conversion failed", zap.Error(err))
+		x.obsrecv.EndTracesOp(ctx, awsxray.TypeStr, totalSpansCount, err)
+		return
+	}
+
+	err = x.consumer.ConsumeTraces(ctx, *traces)
+	if err != nil {
+		x.logger.Warn("Trace consumer errored out", zap.Error(err))
+		x.obsrecv.EndTracesOp(ctx, awsxray.TypeStr, totalSpansCount, err)
+		return
+	}
+	x.obsrecv.EndTracesOp(ctx, awsxray.TypeStr, totalSpansCount, nil)
+}
@@ -41,7 +41,9 @@ import (
 	"go.uber.org/zap/zapcore"
 	"go.uber.org/zap/zaptest/observer"
 
+	awsxray "github.com/open-telemetry/opentelemetry-collector-contrib/internal/aws/xray"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/awsxrayreceiver/internal/proxy"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/awsxrayreceiver/internal/reassembly"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/awsxrayreceiver/internal/udppoller"
 )
 
@@ -146,6 +148,56 @@ func TestSegmentsPassedToConsumer(t *testing.T) {
 	obsreporttest.CheckReceiverTraces(t, receiverID, udppoller.Transport, 18, 0)
 }
 
+func TestPartialSegmentsReassembled(t *testing.T) {
+	segChan := make(chan udppoller.RawSegment, 2)
+	rcvr, sink := createReceiverWithPoller(t, segChan, time.Minute)
+	defer rcvr.Shutdown(context.Background())
+
+	segChan <- udppoller.RawSegment{Ctx: context.Background(), Payload: []byte(
+		`{"trace_id": "1-5f187253-6a106696d56b1f4ef9eba2ed", "id": "5cc4a447f5d4d696", ` +
+			`"name": "LongOperation", "start_time": 1595437651.680097, "in_progress": true}`)}
+	segChan <- udppoller.RawSegment{Ctx: context.Background(), Payload: []byte(
+		`{"trace_id": "1-5f187253-6a106696d56b1f4ef9eba2ed", "id": "5cc4a447f5d4d696", ` +
+			`"name": "LongOperation", "start_time": 1595437651.680097, "end_time": 1595437652.197392}`)}
+
+	assert.Eventuallyf(t, func() bool {
+		return sink.SpansCount() == 1
+	}, 10*time.Second, 5*time.Millisecond, "consumer should eventually get the completed span")
+
+	span := sink.AllTraces()[0].ResourceSpans().At(0).InstrumentationLibrarySpans().At(0).Spans().At(0)
+	assert.NotZero(t, span.EndTimestamp())
+	inProgress, _ := span.Attributes().Get(awsxray.AWSXRayInProgressAttribute)
+	assert.False(t, inProgress.BoolVal())
+}
+
+func TestPartialSegmentsExpired(t *testing.T) {
+	segChan := make(chan udppoller.RawSegment, 1)
+	rcvr, sink := createReceiverWithPoller(t, segChan, time.Millisecond)
+	defer rcvr.Shutdown(context.Background())
+
+	content, err := ioutil.ReadFile(path.Join("../../internal/aws/xray", "testdata", "minInProgress.txt"))
+	assert.NoError(t, err, "can not read raw segment")
+	segChan <- udppoller.RawSegment{Ctx: context.Background(), Payload: content}
+
+	assert.Eventuallyf(t, func() bool {
+		return sink.SpansCount() == 1
+	}, 10*time.Second, 5*time.Millisecond, "consumer should eventually get the expired in_progress span")
+}
+
+func TestPartialSegmentsFlushedOnShutdown(t *testing.T) {
+	segChan := make(chan udppoller.RawSegment, 1)
+	rcvr, sink := createReceiverWithPoller(t, segChan, time.Hour)
+
+	content, err := ioutil.ReadFile(path.Join("../../internal/aws/xray", "testdata", "minInProgress.txt"))
+	assert.NoError(t, err, "can not read raw segment")
+	segChan <- udppoller.RawSegment{Ctx: context.Background(), Payload: content}
+
+	assert.NoError(t, rcvr.Shutdown(context.Background()))
+	assert.Eventuallyf(t, func() bool {
+		return sink.SpansCount() == 1
+	}, 10*time.Second, 5*time.Millisecond, "consumer should get the in_progress span on shutdown")
+}
+
 func TestTranslatorErrorsOut(t *testing.T) {
 	doneFn, err := obsreporttest.SetupRecordedMetricsTest()
 	assert.NoError(t, err, "SetupRecordedMetricsTest should succeed")
@@ -248,15 +300,22 @@ func TestBothPollerAndProxyCloseError(t *testing.T) {
 
 type mockPoller struct {
 	closeErr error
+	segChan  chan udppoller.RawSegment
 }
 
 func (m *mockPoller) SegmentsChan() <-chan udppoller.RawSegment {
+	if m.segChan != nil {
+		return m.segChan
+	}
 	return make(chan udppoller.RawSegment, 1)
 }
 
 func (m *mockPoller) Start(ctx context.Context) {}
 
 func (m *mockPoller) Close() error {
+	if m.segChan != nil {
+		close(m.segChan)
+	}
 	if m.closeErr != nil {
 		return m.closeErr
 	}
@@ -320,6 +379,28 @@ func createAndOptionallyStartReceiver(
 	return addr, rcvr, recorded
 }
 
+// createReceiverWithPoller creates and starts a receiver reassembling the
+// in_progress segments sent to segChan.
+func createReceiverWithPoller(
+	t *testing.T,
+	segChan chan udppoller.RawSegment,
+	partialSegmentsTimeout time.Duration) (component.TracesReceiver, *consumertest.TracesSink) {
+	env := stashEnv()
+	defer restoreEnv(env)
+	os.Setenv(defaultRegionEnvName, mockRegion)
+
+	_, rcvr, _ := createAndOptionallyStartReceiver(t, config.NewID(awsxray.TypeStr), nil, false)
+	xr := rcvr.(*xrayReceiver)
+	assert.NoError(t, xr.poller.Close())
+	xr.poller = &mockPoller{segChan: segChan}
+	xr.server = &mockProxy{}
+	xr.partials = reassembly.NewBuffer(partialSegmentsTimeout, 10)
+
+	err := rcvr.Start(context.Background(), componenttest.NewNopHost())
+	assert.NoError(t, err, "receiver should be started")
+	return rcvr, xr.consumer.(*consumertest.TracesSink)
+}
+
 // findAvailableUDPAddress finds an available local address+port and returns it.
 // There might be race condition on the address returned by this function if
 // there's some other code that grab the address before we can listen on it.
@@ -0,0 +1,309 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azureeventhubreceiver
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/translator/conventions"
+)
+
+const (
+	attributeAzureResourceID = "azure.resource.id"
+
+	attributeCategory          = "azure.category"
+	attributeOperationName     = "azure.operation_name"
+	attributeResultType        = "azure.result_type"
+	attributeResultSignature   = "azure.result_signature"
+	attributeResultDescription = "azure.result_description"
+	attributeDurationMs        = "azure.duration_ms"
+	attributeCallerIPAddress   = "azure.caller_ip_address"
+	attributeCorrelationID     = "azure.correlation_id"
+	attributeIdentity          = "azure.identity"
+	attributeLocation          = "azure.location"
+
+	instrumentationLibraryName = "otelcol/azureeventhubreceiver"
+)
+
+// azureRecords is the body of the events that Azure diagnostic settings send to Event Hubs.
+type azureRecords struct {
+	Records []azureRecord `json:"records"`
+}
+
+// azureRecord is a resource log or a platform metric in the common schema of
+// the Azure diagnostic records:
+// https://docs.microsoft.com/en-us/azure/azure-monitor/essentials/resource-logs-schema
+type azureRecord struct {
+	Time       string `json:"time"`
+	TimeStamp  string `json:"timeStamp"`
+	ResourceID string `json:"resourceId"`
+
+	// Resource log fields.
+	Category          string      `json:"category"`
+	OperationName     string      `json:"operationName"`
+	ResultType        string      `json:"resultType"`
+	ResultSignature   string      `json:"resultSignature"`
+	ResultDescription string      `json:"resultDescription"`
+	DurationMs        json.Number `json:"durationMs"`
+	CallerIPAddress   string      `json:"callerIpAddress"`
+	CorrelationID     string      `json:"correlationId"`
+	Identity          interface{} `json:"identity"`
+	Level             string      `json:"level"`
+	Location          string      `json:"location"`
+	Properties        interface{} `json:"properties"`
+
+	// Platform metric fields.
+	MetricName string   `json:"metricName"`
+	TimeGrain  string   `json:"timeGrain"`
+	Count      *float64 `json:"count"`
+	Total      *float64 `json:"total"`
+	Minimum    *float64 `json:"minimum"`
+	Maximum    *float64 `json:"maximum"`
+}
+
+func (r *azureRecord) isMetric() bool {
+	return r.MetricName != ""
+}
+
+// timestamp returns the time of the record, zero if it has none or it is invalid.
+func (r *azureRecord) timestamp() time.Time {
+	value := r.Time
+	if value == "" {
+		value = r.TimeStamp
+	}
+	t, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
+
+// parseRecords converts the Azure diagnostic records of an event into logs
+// and metrics, with a resource per Azure resource.
+func parseRecords(event []byte) (pdata.Logs, pdata.Metrics, error) {
+	logs := pdata.NewLogs()
+	metrics := pdata.NewMetrics()
+
+	var records azureRecords
+	decoder := json.NewDecoder(bytes.NewReader(event))
+	decoder.UseNumber()
+	if err := decoder.Decode(&records); err != nil {
+		return logs, metrics, fmt.Errorf("invalid Azure diagnostic records: %w", err)
+	}
+
+	logsByResource := map[string]pdata.LogSlice{}
+	metricsByResource := map[string]pdata.MetricSlice{}
+	for i := range records.Records {
+		record := &records.Records[i]
+		if record.isMetric() {
+			ms, ok := metricsByResource[record.ResourceID]
+			if !ok {
+				rm := metrics.ResourceMetrics().AppendEmpty()
+				setResourceAttributes(rm.Resource(), record.ResourceID)
+				ilm := rm.InstrumentationLibraryMetrics().AppendEmpty()
+				ilm.InstrumentationLibrary().SetName(instrumentationLibraryName)
+				ms = ilm.Metrics()
+				metricsByResource[record.ResourceID] = ms
+			}
+			appendMetric(ms, record)
+			continue
+		}
+
+		lrs, ok := logsByResource[record.ResourceID]
+		if !ok {
+			rl := logs.ResourceLogs().AppendEmpty()
+			setResourceAttributes(rl.Resource(), record.ResourceID)
+			ill := rl.InstrumentationLibraryLogs().AppendEmpty()
+			ill.InstrumentationLibrary().SetName(instrumentationLibraryName)
+			lrs = ill.Logs()
+			logsByResource[record.ResourceID] = lrs
+		}
+		appendLogRecord(lrs, record)
+	}
+
+	return logs, metrics, nil
+}
+
+func setResourceAttributes(resource pdata.Resource, resourceID string) {
+	attrs := resource.Attributes()
+	attrs.InsertString(conventions.AttributeCloudProvider, conventions.AttributeCloudProviderAzure)
+	if resourceID != "" {
+		attrs.InsertString(attributeAzureResourceID, resourceID)
+	}
+}
+
+func appendLogRecord(lrs pdata.LogSlice, record *azureRecord) {
+	lr := lrs.AppendEmpty()
+	if t := record.timestamp(); !t.IsZero() {
+		lr.SetTimestamp(pdata.TimestampFromTime(t))
+	}
+	if record.Level != "" {
+		lr.SetSeverityText(record.Level)
+		lr.SetSeverityNumber(severityNumber(record.Level))
+	}
+	if record.Properties != nil {
+		newAttributeValue(record.Properties).CopyTo(lr.Body())
+	}
+
+	attrs := lr.Attributes()
+	insertNonEmptyString(attrs, attributeCategory, record.Category)
+	insertNonEmptyString(attrs, attributeOperationName, record.OperationName)
+	insertNonEmptyString(attrs, attributeResultType, record.ResultType)
+	insertNonEmptyString(attrs, attributeResultSignature, record.ResultSignature)
+	insertNonEmptyString(attrs, attributeResultDescription, record.ResultDescription)
+	if record.DurationMs != "" {
+		attrs.Insert(attributeDurationMs, newNumberAttributeValue(record.DurationMs))
+	}
+	insertNonEmptyString(attrs, attributeCallerIPAddress, record.CallerIPAddress)
+	insertNonEmptyString(attrs, attributeCorrelationID, record.CorrelationID)
+	if record.Identity != nil {
+		attrs.Insert(attributeIdentity, newAttributeValue(record.Identity))
+	}
+	insertNonEmptyString(attrs, attributeLocation, record.Location)
+}
+
+func insertNonEmptyString(attrs pdata.AttributeMap, key, value string) {
+	if value != "" {
+		attrs.InsertString(key, value)
+	}
+}
+
+// severityNumber maps the levels of the Azure resource logs to severity numbers.
+func severityNumber(level string) pdata.SeverityNumber {
+	switch strings.ToLower(level) {
+	case "verbose", "debug":
+		return pdata.SeverityNumberDEBUG
+	case "informational", "information", "info":
+		return pdata.SeverityNumberINFO
+	case "warning", "warn":
+		return pdata.SeverityNumberWARN
+	case "error":
+		return pdata.SeverityNumberERROR
+	case "critical":
+		return pdata.SeverityNumberFATAL
+	}
+	return pdata.SeverityNumberUNDEFINED
+}
+
+// newAttributeValue converts a value decoded from JSON, with numbers decoded
+// as json.Number, into an attribute value.
+func newAttributeValue(value interface{}) pdata.AttributeValue {
+	switch v := value.(type) {
+	case string:
+		return pdata.NewAttributeValueString(v)
+	case bool:
+		return pdata.NewAttributeValueBool(v)
+	case json.Number:
+		return newNumberAttributeValue(v)
+	case map[string]interface{}:
+		av := pdata.NewAttributeValueMap()
+		m := av.MapVal()
+		for key, item := range v {
+			m.Insert(key, newAttributeValue(item))
+		}
+		m.Sort()
+		return av
+	case []interface{}:
+		av := pdata.NewAttributeValueArray()
+		arr := av.ArrayVal()
+		for _, item := range v {
+			newAttributeValue(item).CopyTo(arr.AppendEmpty())
+		}
+		return av
+	}
+	return pdata.NewAttributeValueNull()
+}
+
+func newNumberAttributeValue(n json.Number) pdata.AttributeValue {
+	if i, err := n.Int64(); err == nil {
+		return pdata.NewAttributeValueInt(i)
+	}
+	if f, err := n.Float64(); err == nil {
+		return pdata.NewAttributeValueDouble(f)
+	}
+	return pdata.NewAttributeValueString(n.String())
+}
+
+// appendMetric converts a platform metric, aggregated over its time grain,
+// into a summary: the count and total are the count and sum of the summary,
+// the minimum and maximum its 0 and 1 quantiles.
+func appendMetric(ms pdata.MetricSlice, record *azureRecord) {
+	m := ms.AppendEmpty()
+	m.SetName(record.MetricName)
+	m.SetDataType(pdata.MetricDataTypeSummary)
+
+	dp := m.Summary().DataPoints().AppendEmpty()
+	if t := record.timestamp(); !t.IsZero() {
+		dp.SetTimestamp(pdata.TimestampFromTime(t))
+		if grain, err := parseISO8601Duration(record.TimeGrain); err == nil {
+			dp.SetStartTimestamp(pdata.TimestampFromTime(t.Add(-grain)))
+		}
+	}
+	if record.Count != nil {
+		dp.SetCount(uint64(*record.Count))
+	}
+	if record.Total != nil {
+		dp.SetSum(*record.Total)
+	}
+	if record.Minimum != nil {
+		q := dp.QuantileValues().AppendEmpty()
+		q.SetQuantile(0)
+		q.SetValue(*record.Minimum)
+	}
+	if record.Maximum != nil {
+		q := dp.QuantileValues().AppendEmpty()
+		q.SetQuantile(1)
+		q.SetValue(*record.Maximum)
+	}
+}
+
+var iso8601DurationRegexp = regexp.MustCompile(`^P(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+(?:\.\d+)?)S)?)?$`)
+
+// parseISO8601Duration parses the ISO 8601 durations of the metric time
+// grains, e.g. "PT1M" or "P1D". Years, months and weeks are not supported.
+func parseISO8601Duration(value string) (time.Duration, error) {
+	match := iso8601DurationRegexp.FindStringSubmatch(value)
+	if match == nil || value == "P" || strings.HasSuffix(value, "T") {
+		return 0, fmt.Errorf("invalid ISO 8601 duration %q", value)
+	}
+
+	var d time.Duration
+	units := []time.Duration{24 * time.Hour, time.Hour, time.Minute}
+	for i, unit := range units {
+		if match[i+1] == "" {
+			continue
+		}
+		n, err := strconv.ParseInt(match[i+1], 10, 64)
+		if err != nil {
+			return 0, err
+		}
+		d += time.Duration(n) * unit
+	}
+	if match[4] != "" {
+		s, err := strconv.ParseFloat(match[4], 64)
+		if err != nil {
+			return 0, err
+		}
+		d += time.Duration(s * float64(time.Second))
+	}
+	return d, nil
+}
@@ -0,0 +1,155 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azureeventhubreceiver
+
+import (
+	"io/ioutil"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/translator/conventions"
+)
+
+const (
+	vaultResourceID = "/SUBSCRIPTIONS/00000000-0000-0000-0000-000000000000/RESOURCEGROUPS/RG/PROVIDERS/MICROSOFT.KEYVAULT/VAULTS/VAULT"
+	appResourceID   = "/SUBSCRIPTIONS/00000000-0000-0000-0000-000000000000/RESOURCEGROUPS/RG/PROVIDERS/MICROSOFT.WEB/SITES/APP"
+)
+
+func readTestdata(t *testing.T, name string) []byte {
+	data, err := ioutil.ReadFile(path.Join("testdata", name))
+	require.NoError(t, err)
+	return data
+}
+
+func TestParseLogRecords(t *testing.T) {
+	logs, metrics, err := parseRecords(readTestdata(t, "logs.json"))
+	require.NoError(t, err)
+	assert.Equal(t, 0, metrics.MetricCount())
+	require.Equal(t, 3, logs.LogRecordCount())
+
+	rls := logs.ResourceLogs()
+	require.Equal(t, 2, rls.Len())
+	resAttrs := rls.At(0).Resource().Attributes()
+	assert.Equal(t, 2, resAttrs.Len())
+	provider, _ := resAttrs.Get(conventions.AttributeCloudProvider)
+	assert.Equal(t, conventions.AttributeCloudProviderAzure, provider.StringVal())
+	resourceID, _ := resAttrs.Get(attributeAzureResourceID)
+	assert.Equal(t, vaultResourceID, resourceID.StringVal())
+	resourceID, _ = rls.At(1).Resource().Attributes().Get(attributeAzureResourceID)
+	assert.Equal(t, appResourceID, resourceID.StringVal())
+
+	lrs := rls.At(0).InstrumentationLibraryLogs().At(0).Logs()
+	require.Equal(t, 2, lrs.Len())
+
+	lr := lrs.At(0)
+	assert.Equal(t, time.Date(2021, 6, 1, 10, 15, 30, 123456700, time.UTC), lr.Timestamp().AsTime())
+	assert.Equal(t, "Informational", lr.SeverityText())
+	assert.Equal(t, pdata.SeverityNumberINFO, lr.SeverityNumber())
+	assert.Equal(t, pdata.AttributeValueTypeMap, lr.Body().Type())
+	status, _ := lr.Body().MapVal().Get("httpStatusCode")
+	assert.Equal(t, int64(200), status.IntVal())
+	rbac, _ := lr.Body().MapVal().Get("isRbacAuthorized")
+	assert.True(t, rbac.BoolVal())
+
+	attrs := lr.Attributes()
+	for key, expected := range map[string]string{
+		attributeCategory:        "AuditEvent",
+		attributeOperationName:   "SecretGet",
+		attributeResultType:      "Success",
+		attributeResultSignature: "OK",
+		attributeCallerIPAddress: "10.0.0.4",
+		attributeCorrelationID:   "5b6b5a7c-1d8e-4f2a-9c3b-7e6d5c4b3a29",
+		attributeLocation:        "westeurope",
+	} {
+		v, ok := attrs.Get(key)
+		require.True(t, ok, key)
+		assert.Equal(t, expected, v.StringVal(), key)
+	}
+	// durationMs is a string in some resource logs.
+	duration, _ := attrs.Get(attributeDurationMs)
+	assert.Equal(t, int64(25), duration.IntVal())
+	identity, _ := attrs.Get(attributeIdentity)
+	claim, _ := identity.MapVal().Get("claim")
+	appID, _ := claim.MapVal().Get("appid")
+	assert.Equal(t, "11111111-1111-1111-1111-111111111111", appID.StringVal())
+
+	lr = lrs.At(1)
+	assert.Equal(t, pdata.SeverityNumberERROR, lr.SeverityNumber())
+	duration, _ = lr.Attributes().Get(attributeDurationMs)
+	assert.Equal(t, 3.5, duration.DoubleVal())
+	tags, _ := lr.Body().MapVal().Get("tags")
+	require.Equal(t, 2, tags.ArrayVal().Len())
+	assert.Equal(t, "b", tags.ArrayVal().At(1).StringVal())
+	_, ok := lr.Attributes().Get(attributeCallerIPAddress)
+	assert.False(t, ok)
+
+	lr = rls.At(1).InstrumentationLibraryLogs().At(0).Logs().At(0)
+	assert.Equal(t, pdata.SeverityNumberWARN, lr.SeverityNumber())
+}
+
+func TestParseMetricRecords(t *testing.T) {
+	logs, metrics, err := parseRecords(readTestdata(t, "metrics.json"))
+	require.NoError(t, err)
+	assert.Equal(t, 0, logs.LogRecordCount())
+	require.Equal(t, 1, metrics.ResourceMetrics().Len())
+
+	ms := metrics.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics()
+	require.Equal(t, 2, ms.Len())
+	m := ms.At(0)
+	assert.Equal(t, "HttpResponseTime", m.Name())
+	require.Equal(t, pdata.MetricDataTypeSummary, m.DataType())
+
+	dp := m.Summary().DataPoints().At(0)
+	end := time.Date(2021, 6, 1, 10, 15, 0, 0, time.UTC)
+	assert.Equal(t, end, dp.Timestamp().AsTime())
+	assert.Equal(t, end.Add(-time.Minute), dp.StartTimestamp().AsTime())
+	assert.Equal(t, uint64(4), dp.Count())
+	assert.Equal(t, 180.0, dp.Sum())
+	require.Equal(t, 2, dp.QuantileValues().Len())
+	assert.Equal(t, 0.0, dp.QuantileValues().At(0).Quantile())
+	assert.Equal(t, 20.0, dp.QuantileValues().At(0).Value())
+	assert.Equal(t, 1.0, dp.QuantileValues().At(1).Quantile())
+	assert.Equal(t, 70.0, dp.QuantileValues().At(1).Value())
+}
+
+func TestParseInvalidRecords(t *testing.T) {
+	_, _, err := parseRecords([]byte("not json"))
+	assert.Error(t, err)
+}
+
+func TestParseISO8601Duration(t *testing.T) {
+	cases := map[string]time.Duration{
+		"PT1M":    time.Minute,
+		"PT5M":    5 * time.Minute,
+		"PT1H":    time.Hour,
+		"P1D":     24 * time.Hour,
+		"PT1H30M": 90 * time.Minute,
+		"PT0.5S":  500 * time.Millisecond,
+	}
+	for value, expected := range cases {
+		d, err := parseISO8601Duration(value)
+		require.NoError(t, err, value)
+		assert.Equal(t, expected, d, value)
+	}
+
+	for _, value := range []string{"", "P", "PT", "1M", "P1M", "PT1X"} {
+		_, err := parseISO8601Duration(value)
+		assert.Error(t, err, value)
+	}
+}
@@ -0,0 +1,126 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azureeventhubreceiver
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"go.opentelemetry.io/collector/config"
+)
+
+const (
+	initialOffsetLatest   = "latest"
+	initialOffsetEarliest = "earliest"
+)
+
+var (
+	errMissingConnection = errors.New("missing connection")
+	errMissingEventHub   = errors.New("missing event_hub: the connection has no EntityPath")
+)
+
+// Config defines configuration for the Azure Event Hub receiver.
+type Config struct {
+	config.ReceiverSettings `mapstructure:",squash"`
+
+	// Connection is the connection string of a shared access policy, with the
+	// Listen claim, of the Event Hub or of its namespace.
+	Connection string `mapstructure:"connection"`
+
+	// EventHub is the name of the Event Hub. Required if Connection is the
+	// connection string of the namespace, which has no EntityPath.
+	EventHub string `mapstructure:"event_hub"`
+
+	// ConsumerGroup is the Event Hub consumer group the receiver joins. The
+	// consumed offsets are committed to it. Default is the "$Default" consumer
+	// group, which can't be set in the configuration without escaping the $.
+	ConsumerGroup string `mapstructure:"consumer_group"`
+
+	// InitialOffset is where the receiver starts consuming the partitions of
+	// the Event Hub without committed offset, "latest" or "earliest". Default
+	// is "latest".
+	InitialOffset string `mapstructure:"initial_offset"`
+}
+
+var _ config.Receiver = (*Config)(nil)
+
+// Validate checks if the receiver configuration is valid
+func (cfg *Config) Validate() error {
+	if _, err := cfg.eventHub(); err != nil {
+		return err
+	}
+	switch cfg.InitialOffset {
+	case initialOffsetLatest, initialOffsetEarliest:
+	default:
+		return fmt.Errorf("invalid initial_offset %q, must be %q or %q", cfg.InitialOffset, initialOffsetLatest, initialOffsetEarliest)
+	}
+	return nil
+}
+
+// consumerGroup returns the consumer group to join.
+func (cfg *Config) consumerGroup() string {
+	if cfg.ConsumerGroup == "" {
+		return defaultConsumerGroup
+	}
+	return cfg.ConsumerGroup
+}
+
+// eventHub is the namespace and name of the Event Hub to consume.
+type eventHub struct {
+	// namespace is the host name of the Event Hubs namespace, e.g. "myns.servicebus.windows.net".
+	namespace string
+	name      string
+}
+
+// eventHub returns the Event Hub to consume, from the connection string and EventHub.
+func (cfg *Config) eventHub() (eventHub, error) {
+	if cfg.Connection == "" {
+		return eventHub{}, errMissingConnection
+	}
+
+	var hub eventHub
+	for _, part := range strings.Split(cfg.Connection, ";") {
+		kv := strings.SplitN(part, "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		switch strings.ToLower(strings.TrimSpace(kv[0])) {
+		case "endpoint":
+			u, err := url.Parse(strings.TrimSpace(kv[1]))
+			if err != nil {
+				return eventHub{}, fmt.Errorf("invalid connection Endpoint: %w", err)
+			}
+			hub.namespace = u.Hostname()
+		case "entitypath":
+			hub.name = strings.TrimSpace(kv[1])
+		}
+	}
+	if hub.namespace == "" {
+		return eventHub{}, errors.New("invalid connection: missing Endpoint")
+	}
+
+	if cfg.EventHub != "" {
+		if hub.name != "" && hub.name != cfg.EventHub {
+			return eventHub{}, fmt.Errorf("event_hub %q does not match the connection EntityPath %q", cfg.EventHub, hub.name)
+		}
+		hub.name = cfg.EventHub
+	}
+	if hub.name == "" {
+		return eventHub{}, errMissingEventHub
+	}
+	return hub, nil
+}
@@ -0,0 +1,104 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azureeventhubreceiver
+
+import (
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configtest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	require.NoError(t, err)
+
+	factory := NewFactory()
+	factories.Receivers[typeStr] = factory
+
+	cfg, err := configtest.LoadConfigAndValidate(path.Join(".", "testdata", "config.yaml"), factories)
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	defaultCfg := factory.CreateDefaultConfig().(*Config)
+	defaultCfg.Connection = "Endpoint=sb://namespace.servicebus.windows.net/;SharedAccessKeyName=listen;SharedAccessKey=c2VjcmV0;EntityPath=insights-logs"
+	assert.Equal(t, defaultCfg, cfg.Receivers[config.NewID(typeStr)])
+	assert.Equal(t,
+		&Config{
+			ReceiverSettings: config.NewReceiverSettings(config.NewIDWithName(typeStr, "all_settings")),
+			Connection:       "Endpoint=sb://namespace.servicebus.windows.net/;SharedAccessKeyName=listen;SharedAccessKey=c2VjcmV0",
+			EventHub:         "insights-metrics-pt1m",
+			ConsumerGroup:    "otelcol",
+			InitialOffset:    "earliest",
+		},
+		cfg.Receivers[config.NewIDWithName(typeStr, "all_settings")],
+	)
+}
+
+func TestValidateConfig(t *testing.T) {
+	const namespaceConnection = "Endpoint=sb://namespace.servicebus.windows.net/;SharedAccessKeyName=listen;SharedAccessKey=c2VjcmV0"
+
+	cases := []struct {
+		name       string
+		connection string
+		eventHub   string
+		expected   string
+	}{
+		{name: "entity path", connection: namespaceConnection + ";EntityPath=hub"},
+		{name: "event hub", connection: namespaceConnection, eventHub: "hub"},
+		{name: "matching event hub", connection: namespaceConnection + ";EntityPath=hub", eventHub: "hub"},
+		{name: "missing connection", expected: "missing connection"},
+		{name: "missing event hub", connection: namespaceConnection, expected: "missing event_hub: the connection has no EntityPath"},
+		{name: "missing endpoint", connection: "SharedAccessKeyName=listen;SharedAccessKey=c2VjcmV0;EntityPath=hub", expected: "invalid connection: missing Endpoint"},
+		{
+			name:       "mismatched event hub",
+			connection: namespaceConnection + ";EntityPath=hub",
+			eventHub:   "other",
+			expected:   `event_hub "other" does not match the connection EntityPath "hub"`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.Connection = tc.connection
+			cfg.EventHub = tc.eventHub
+			err := cfg.Validate()
+			if tc.expected == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expected)
+			}
+		})
+	}
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Connection = namespaceConnection + ";EntityPath=hub"
+	cfg.InitialOffset = "oldest"
+	assert.EqualError(t, cfg.Validate(), `invalid initial_offset "oldest", must be "latest" or "earliest"`)
+}
+
+func TestEventHub(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Connection = "Endpoint=sb://namespace.servicebus.windows.net/;SharedAccessKeyName=listen;SharedAccessKey=c2VjcmV0;EntityPath=hub"
+	hub, err := cfg.eventHub()
+	require.NoError(t, err)
+	assert.Equal(t, eventHub{namespace: "namespace.servicebus.windows.net", name: "hub"}, hub)
+	assert.Equal(t, "$Default", cfg.consumerGroup())
+}
@@ -0,0 +1,216 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azureeventhubreceiver
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"sync"
+
+	"github.com/Shopify/sarama"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/obsreport"
+	"go.uber.org/zap"
+)
+
+const (
+	transport = "eventhub"
+	format    = "azure_resource_logs"
+
+	// kafkaPort is the port of the Kafka endpoint of the Event Hubs namespaces.
+	kafkaPort = "9093"
+	// connectionStringUser is the SASL PLAIN user authenticating with a connection string.
+	connectionStringUser = "$ConnectionString"
+)
+
+// eventHubReceiver consumes an Event Hub through the Kafka endpoint of its
+// namespace, the Event Hub being the topic and its consumer group the Kafka
+// consumer group, and converts the Azure diagnostic records it receives.
+type eventHubReceiver struct {
+	logger *zap.Logger
+	config Config
+
+	logsConsumer    consumer.Logs
+	metricsConsumer consumer.Metrics
+
+	startOnce         sync.Once
+	stopOnce          sync.Once
+	consumerGroup     sarama.ConsumerGroup
+	cancelConsumeLoop context.CancelFunc
+	consumeLoopDone   chan struct{}
+}
+
+var _ component.LogsReceiver = (*eventHubReceiver)(nil)
+var _ component.MetricsReceiver = (*eventHubReceiver)(nil)
+
+func newReceiver(logger *zap.Logger, config Config) *eventHubReceiver {
+	return &eventHubReceiver{
+		logger: logger,
+		config: config,
+	}
+}
+
+// RegisterLogsConsumer sets the consumer of the diagnostic logs.
+func (r *eventHubReceiver) RegisterLogsConsumer(lc consumer.Logs) {
+	r.logsConsumer = lc
+}
+
+// RegisterMetricsConsumer sets the consumer of the platform metrics.
+func (r *eventHubReceiver) RegisterMetricsConsumer(mc consumer.Metrics) {
+	r.metricsConsumer = mc
+}
+
+// Start joins the consumer group of the Event Hub and starts consuming it.
+func (r *eventHubReceiver) Start(_ context.Context, _ component.Host) error {
+	var err error
+	r.startOnce.Do(func() {
+		var hub eventHub
+		hub, err = r.config.eventHub()
+		if err != nil {
+			return
+		}
+		r.consumerGroup, err = sarama.NewConsumerGroup(
+			[]string{net.JoinHostPort(hub.namespace, kafkaPort)},
+			r.config.consumerGroup(),
+			r.saramaConfig())
+		if err != nil {
+			return
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		r.cancelConsumeLoop = cancel
+		r.consumeLoopDone = make(chan struct{})
+		handler := &consumerGroupHandler{
+			id:              r.config.ID(),
+			logger:          r.logger,
+			logsConsumer:    r.logsConsumer,
+			metricsConsumer: r.metricsConsumer,
+			obsrecv:         obsreport.NewReceiver(obsreport.ReceiverSettings{ReceiverID: r.config.ID(), Transport: transport}),
+		}
+		go r.consumeLoop(ctx, []string{hub.name}, handler)
+	})
+	return err
+}
+
+func (r *eventHubReceiver) saramaConfig() *sarama.Config {
+	c := sarama.NewConfig()
+	c.Version = sarama.V1_0_0_0
+	c.Net.TLS.Enable = true
+	c.Net.TLS.Config = &tls.Config{MinVersion: tls.VersionTLS12}
+	c.Net.SASL.Enable = true
+	c.Net.SASL.Mechanism = sarama.SASLTypePlaintext
+	c.Net.SASL.User = connectionStringUser
+	c.Net.SASL.Password = r.config.Connection
+	c.Consumer.Return.Errors = false
+	if r.config.InitialOffset == initialOffsetEarliest {
+		c.Consumer.Offsets.Initial = sarama.OffsetOldest
+	} else {
+		c.Consumer.Offsets.Initial = sarama.OffsetNewest
+	}
+	return c
+}
+
+func (r *eventHubReceiver) consumeLoop(ctx context.Context, topics []string, handler sarama.ConsumerGroupHandler) {
+	defer close(r.consumeLoopDone)
+	for {
+		// Consume returns when the partitions of the Event Hub are rebalanced
+		// between the consumers of the group, and is called again to get the
+		// new claims.
+		if err := r.consumerGroup.Consume(ctx, topics, handler); err != nil {
+			r.logger.Error("Error from Event Hub consumer", zap.Error(err))
+		}
+		if ctx.Err() != nil {
+			return
+		}
+	}
+}
+
+// Shutdown stops consuming the Event Hub and leaves its consumer group.
+func (r *eventHubReceiver) Shutdown(context.Context) error {
+	var err error
+	r.stopOnce.Do(func() {
+		if r.consumerGroup == nil {
+			return
+		}
+		r.cancelConsumeLoop()
+		err = r.consumerGroup.Close()
+		<-r.consumeLoopDone
+	})
+	return err
+}
+
+// consumerGroupHandler converts the events of the claimed partitions and
+// sends the logs and metrics to the consumers of the receiver.
+type consumerGroupHandler struct {
+	id              config.ComponentID
+	logger          *zap.Logger
+	logsConsumer    consumer.Logs
+	metricsConsumer consumer.Metrics
+	obsrecv         *obsreport.Receiver
+}
+
+var _ sarama.ConsumerGroupHandler = (*consumerGroupHandler)(nil)
+
+func (h *consumerGroupHandler) Setup(sarama.ConsumerGroupSession) error {
+	return nil
+}
+
+func (h *consumerGroupHandler) Cleanup(sarama.ConsumerGroupSession) error {
+	return nil
+}
+
+func (h *consumerGroupHandler) ConsumeClaim(session sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim) error {
+	h.logger.Info("Consuming Event Hub partition", zap.Int32("partition", claim.Partition()))
+	for message := range claim.Messages() {
+		h.consumeEvent(session.Context(), message.Value)
+		// Events that can't be converted or consumed are dropped rather than
+		// retried forever: they would block the partition.
+		session.MarkMessage(message, "")
+	}
+	return nil
+}
+
+func (h *consumerGroupHandler) consumeEvent(ctx context.Context, event []byte) {
+	logs, metrics, err := parseRecords(event)
+	if err != nil {
+		h.logger.Error("Failed to parse Azure diagnostic records", zap.Error(err))
+		return
+	}
+
+	if h.logsConsumer != nil && logs.LogRecordCount() > 0 {
+		ctx := obsreport.ReceiverContext(ctx, h.id, transport)
+		ctx = h.obsrecv.StartLogsOp(ctx)
+		count := logs.LogRecordCount()
+		err := h.logsConsumer.ConsumeLogs(ctx, logs)
+		h.obsrecv.EndLogsOp(ctx, format, count, err)
+		if err != nil {
+			h.logger.Error("Failed to consume Azure diagnostic logs", zap.Error(err))
+		}
+	}
+
+	if h.metricsConsumer != nil && metrics.MetricCount() > 0 {
+		ctx := obsreport.ReceiverContext(ctx, h.id, transport)
+		ctx = h.obsrecv.StartMetricsOp(ctx)
+		_, dataPoints := metrics.MetricAndDataPointCount()
+		err := h.metricsConsumer.ConsumeMetrics(ctx, metrics)
+		h.obsrecv.EndMetricsOp(ctx, format, dataPoints, err)
+		if err != nil {
+			h.logger.Error("Failed to consume Azure metrics", zap.Error(err))
+		}
+	}
+}
@@ -0,0 +1,103 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azureeventhubreceiver
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/obsreport"
+	"go.uber.org/zap"
+)
+
+func TestConsumeClaim(t *testing.T) {
+	id := config.NewID(typeStr)
+	logsSink := new(consumertest.LogsSink)
+	metricsSink := new(consumertest.MetricsSink)
+	handler := &consumerGroupHandler{
+		id:              id,
+		logger:          zap.NewNop(),
+		logsConsumer:    logsSink,
+		metricsConsumer: metricsSink,
+		obsrecv:         obsreport.NewReceiver(obsreport.ReceiverSettings{ReceiverID: id, Transport: transport}),
+	}
+
+	claim := &testClaim{messages: make(chan *sarama.ConsumerMessage, 3)}
+	claim.messages <- &sarama.ConsumerMessage{Offset: 1, Value: readTestdata(t, "logs.json")}
+	claim.messages <- &sarama.ConsumerMessage{Offset: 2, Value: []byte("not json")}
+	claim.messages <- &sarama.ConsumerMessage{Offset: 3, Value: readTestdata(t, "metrics.json")}
+	close(claim.messages)
+
+	session := &testSession{ctx: context.Background()}
+	require.NoError(t, handler.ConsumeClaim(session, claim))
+
+	assert.Equal(t, 3, logsSink.LogRecordsCount())
+	assert.Len(t, metricsSink.AllMetrics(), 1)
+	// Invalid events are dropped, not redelivered.
+	assert.Equal(t, []int64{1, 2, 3}, session.marked)
+}
+
+func TestSaramaConfig(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Connection = "Endpoint=sb://namespace.servicebus.windows.net/;SharedAccessKeyName=listen;SharedAccessKey=c2VjcmV0;EntityPath=hub"
+
+	c := newReceiver(zap.NewNop(), *cfg).saramaConfig()
+	require.NoError(t, c.Validate())
+	assert.True(t, c.Net.TLS.Enable)
+	assert.Equal(t, "$ConnectionString", c.Net.SASL.User)
+	assert.Equal(t, cfg.Connection, c.Net.SASL.Password)
+	assert.Equal(t, sarama.OffsetNewest, c.Consumer.Offsets.Initial)
+
+	cfg.InitialOffset = initialOffsetEarliest
+	c = newReceiver(zap.NewNop(), *cfg).saramaConfig()
+	assert.Equal(t, sarama.OffsetOldest, c.Consumer.Offsets.Initial)
+}
+
+func TestShutdownWithoutStart(t *testing.T) {
+	r := newReceiver(zap.NewNop(), *createDefaultConfig().(*Config))
+	assert.NoError(t, r.Shutdown(context.Background()))
+}
+
+type testSession struct {
+	sarama.ConsumerGroupSession
+	ctx    context.Context
+	marked []int64
+}
+
+func (s *testSession) Context() context.Context {
+	return s.ctx
+}
+
+func (s *testSession) MarkMessage(msg *sarama.ConsumerMessage, _ string) {
+	s.marked = append(s.marked, msg.Offset)
+}
+
+type testClaim struct {
+	sarama.ConsumerGroupClaim
+	messages chan *sarama.ConsumerMessage
+}
+
+func (c *testClaim) Partition() int32 {
+	return 0
+}
+
+func (c *testClaim) Messages() <-chan *sarama.ConsumerMessage {
+	return c.messages
+}
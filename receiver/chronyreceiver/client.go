@@ -0,0 +1,327 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chronyreceiver
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"time"
+)
+
+// syncStatus is the clock synchronization status reported by chronyd or an NTP server.
+type syncStatus struct {
+	stratum        int64
+	offset         float64
+	rootDelay      float64
+	rootDispersion float64
+
+	// rmsOffset is only reported by chronyd.
+	rmsOffset    float64
+	hasRMSOffset bool
+
+	// sinceLastSync is only known when the reference clock was synchronized at least once.
+	sinceLastSync    float64
+	hasSinceLastSync bool
+}
+
+// client queries the clock synchronization status.
+type client interface {
+	query(ctx context.Context) (*syncStatus, error)
+}
+
+func newClient(endpoint *url.URL, timeout time.Duration) client {
+	switch endpoint.Scheme {
+	case schemeNTP:
+		return &ntpClient{address: endpoint.Host, timeout: timeout}
+	case schemeUnix:
+		return &chronyClient{network: "unixgram", address: endpoint.Path, timeout: timeout}
+	default:
+		return &chronyClient{network: "udp", address: endpoint.Host, timeout: timeout}
+	}
+}
+
+// deadline returns the deadline of a query started now.
+func deadline(ctx context.Context, timeout time.Duration) time.Time {
+	d := time.Now().Add(timeout)
+	if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(d) {
+		return ctxDeadline
+	}
+	return d
+}
+
+// The chrony command protocol, see candm.h in the chrony sources.
+const (
+	chronyProtoVersion     = 6
+	chronyPktTypeRequest   = 1
+	chronyPktTypeReply     = 2
+	chronyReqTracking      = 33
+	chronyRpyTracking      = 5
+	chronyStatusSuccess    = 0
+	chronyStatusUnauth     = 2
+	chronyReplyHeaderLen   = 28
+	chronyTrackingReplyLen = chronyReplyHeaderLen + 80
+	// chronyNoHighSec marks the high 32 bits of a timestamp as unused.
+	chronyNoHighSec = 0x7fffffff
+)
+
+// localSocketSeq makes the names of the local sockets bound by chronyClient unique.
+var localSocketSeq uint32
+
+// chronyClient queries chronyd with the tracking command, like `chronyc tracking`.
+type chronyClient struct {
+	network string
+	address string
+	timeout time.Duration
+}
+
+func (c *chronyClient) query(ctx context.Context) (*syncStatus, error) {
+	conn, err := c.dial()
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	if err = conn.SetDeadline(deadline(ctx, c.timeout)); err != nil {
+		return nil, err
+	}
+
+	// chronyd ignores requests shorter than their reply, to prevent traffic amplification.
+	req := make([]byte, chronyTrackingReplyLen)
+	seq := rand.Uint32()
+	req[0] = chronyProtoVersion
+	req[1] = chronyPktTypeRequest
+	binary.BigEndian.PutUint16(req[4:], chronyReqTracking)
+	binary.BigEndian.PutUint32(req[8:], seq)
+	if _, err = conn.Write(req); err != nil {
+		return nil, fmt.Errorf("failed to send tracking request: %w", err)
+	}
+
+	reply := make([]byte, 1024)
+	n, err := conn.Read(reply)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read tracking reply: %w", err)
+	}
+	return parseTrackingReply(reply[:n], seq, time.Now())
+}
+
+// dial connects to chronyd. A unix datagram socket needs a local address for chronyd to
+// reply to, bound next to the chronyd socket as chronyc does.
+func (c *chronyClient) dial() (net.Conn, error) {
+	if c.network != "unixgram" {
+		return net.Dial(c.network, c.address)
+	}
+
+	local := filepath.Join(filepath.Dir(c.address),
+		fmt.Sprintf("otelcol-chrony.%d.%d.sock", os.Getpid(), atomic.AddUint32(&localSocketSeq, 1)))
+	laddr := &net.UnixAddr{Name: local, Net: c.network}
+	raddr := &net.UnixAddr{Name: c.address, Net: c.network}
+	conn, err := net.DialUnix(c.network, laddr, raddr)
+	if err != nil {
+		return nil, err
+	}
+	// chronyd may run as another user, it must be able to write its reply.
+	if err := os.Chmod(local, 0666); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return &unlinkingConn{UnixConn: conn, path: local}, nil
+}
+
+// unlinkingConn removes the socket file it is bound to when closed.
+type unlinkingConn struct {
+	*net.UnixConn
+	path string
+}
+
+func (c *unlinkingConn) Close() error {
+	err := c.UnixConn.Close()
+	os.Remove(c.path)
+	return err
+}
+
+func parseTrackingReply(reply []byte, seq uint32, now time.Time) (*syncStatus, error) {
+	if len(reply) < chronyReplyHeaderLen {
+		return nil, fmt.Errorf("tracking reply too short: %d bytes", len(reply))
+	}
+	if reply[0] != chronyProtoVersion || reply[1] != chronyPktTypeReply {
+		return nil, fmt.Errorf("unexpected reply version %d or type %d", reply[0], reply[1])
+	}
+	if got := binary.BigEndian.Uint32(reply[16:]); got != seq {
+		return nil, fmt.Errorf("unexpected reply sequence %d, expected %d", got, seq)
+	}
+	switch status := binary.BigEndian.Uint16(reply[8:]); status {
+	case chronyStatusSuccess:
+	case chronyStatusUnauth:
+		return nil, errors.New("chronyd refused the tracking request, allow it with the cmdallow directive")
+	default:
+		return nil, fmt.Errorf("chronyd returned status %d", status)
+	}
+	if rpy := binary.BigEndian.Uint16(reply[6:]); rpy != chronyRpyTracking {
+		return nil, fmt.Errorf("unexpected reply %d to tracking request", rpy)
+	}
+	if len(reply) < chronyTrackingReplyLen {
+		return nil, fmt.Errorf("tracking reply too short: %d bytes", len(reply))
+	}
+
+	data := reply[chronyReplyHeaderLen:]
+	status := &syncStatus{
+		stratum:        int64(binary.BigEndian.Uint16(data[24:])),
+		offset:         chronyFloat(data[40:]),
+		rmsOffset:      chronyFloat(data[48:]),
+		hasRMSOffset:   true,
+		rootDelay:      chronyFloat(data[64:]),
+		rootDispersion: chronyFloat(data[68:]),
+	}
+
+	high := binary.BigEndian.Uint32(data[28:])
+	if high == chronyNoHighSec {
+		high = 0
+	}
+	sec := int64(high)<<32 | int64(binary.BigEndian.Uint32(data[32:]))
+	nsec := int64(binary.BigEndian.Uint32(data[36:]))
+	if sec != 0 || nsec != 0 {
+		status.sinceLastSync = now.Sub(time.Unix(sec, nsec)).Seconds()
+		status.hasSinceLastSync = true
+	}
+	return status, nil
+}
+
+// chronyFloat decodes the 32-bit float format of chrony: a 7-bit signed exponent followed
+// by a 25-bit signed coefficient.
+func chronyFloat(b []byte) float64 {
+	const (
+		expBits  = 7
+		coefBits = 25
+	)
+	x := binary.BigEndian.Uint32(b)
+
+	exp := int32(x >> coefBits)
+	if exp >= 1<<(expBits-1) {
+		exp -= 1 << expBits
+	}
+	exp -= coefBits
+
+	coef := int32(x % (1 << coefBits))
+	if coef >= 1<<(coefBits-1) {
+		coef -= 1 << coefBits
+	}
+	return float64(coef) * math.Pow(2, float64(exp))
+}
+
+// NTP packets, see RFC 5905.
+const (
+	ntpPacketLen    = 48
+	ntpVersion      = 4
+	ntpModeClient   = 3
+	ntpModeServer   = 4
+	ntpEpochOffset  = 2208988800 // seconds between 1900-01-01 and 1970-01-01
+	ntpEraThreshold = 0x80000000
+)
+
+// ntpClient queries an NTP server with a single SNTP request.
+type ntpClient struct {
+	address string
+	timeout time.Duration
+}
+
+func (c *ntpClient) query(ctx context.Context) (*syncStatus, error) {
+	conn, err := net.Dial("udp", c.address)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	if err = conn.SetDeadline(deadline(ctx, c.timeout)); err != nil {
+		return nil, err
+	}
+
+	req := make([]byte, ntpPacketLen)
+	req[0] = ntpVersion<<3 | ntpModeClient
+	sent := time.Now()
+	binary.BigEndian.PutUint64(req[40:], toNTPTime(sent))
+	if _, err = conn.Write(req); err != nil {
+		return nil, fmt.Errorf("failed to send NTP request: %w", err)
+	}
+
+	resp := make([]byte, 1024)
+	n, err := conn.Read(resp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read NTP response: %w", err)
+	}
+	return parseNTPResponse(resp[:n], req, sent, time.Now())
+}
+
+func parseNTPResponse(resp, req []byte, sent, received time.Time) (*syncStatus, error) {
+	if len(resp) < ntpPacketLen {
+		return nil, fmt.Errorf("NTP response too short: %d bytes", len(resp))
+	}
+	if mode := resp[0] & 0x7; mode != ntpModeServer {
+		return nil, fmt.Errorf("unexpected NTP mode %d", mode)
+	}
+	// The server copies the transmit timestamp of the request into the originate timestamp.
+	if binary.BigEndian.Uint64(resp[24:]) != binary.BigEndian.Uint64(req[40:]) {
+		return nil, errors.New("NTP response does not match the request")
+	}
+	stratum := resp[1]
+	if stratum == 0 {
+		return nil, fmt.Errorf("NTP server sent kiss code %q", resp[12:16])
+	}
+
+	serverReceived := fromNTPTime(binary.BigEndian.Uint64(resp[32:]))
+	serverSent := fromNTPTime(binary.BigEndian.Uint64(resp[40:]))
+	status := &syncStatus{
+		stratum:        int64(stratum),
+		offset:         (serverReceived.Sub(sent) + serverSent.Sub(received)).Seconds() / 2,
+		rootDelay:      ntpShort(resp[4:]),
+		rootDispersion: ntpShort(resp[8:]),
+	}
+	if ref := binary.BigEndian.Uint64(resp[16:]); ref != 0 {
+		status.sinceLastSync = received.Sub(fromNTPTime(ref)).Seconds()
+		status.hasSinceLastSync = true
+	}
+	return status, nil
+}
+
+// toNTPTime converts t to a 64-bit NTP timestamp: seconds since 1900 and a 32-bit fraction.
+func toNTPTime(t time.Time) uint64 {
+	sec := uint64(t.Unix()+ntpEpochOffset) & math.MaxUint32
+	frac := uint64(t.Nanosecond()) << 32 / uint64(time.Second)
+	return sec<<32 | frac
+}
+
+// fromNTPTime converts a 64-bit NTP timestamp. Timestamps with the high bit of the seconds
+// unset are assumed to belong to the era starting in 2036.
+func fromNTPTime(ts uint64) time.Time {
+	sec := int64(ts >> 32)
+	if sec < ntpEraThreshold {
+		sec += 1 << 32
+	}
+	nsec := int64((ts & math.MaxUint32) * uint64(time.Second) >> 32)
+	return time.Unix(sec-ntpEpochOffset, nsec)
+}
+
+// ntpShort decodes the 32-bit NTP short format: 16 bits of seconds and a 16-bit fraction.
+func ntpShort(b []byte) float64 {
+	return float64(binary.BigEndian.Uint32(b)) / (1 << 16)
+}
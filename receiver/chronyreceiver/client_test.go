@@ -0,0 +1,288 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chronyreceiver
+
+import (
+	"context"
+	"encoding/binary"
+	"math"
+	"net"
+	"net/url"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// encodeChronyFloat is the inverse of chronyFloat.
+func encodeChronyFloat(v float64) uint32 {
+	frac, exp := math.Frexp(v)
+	coef := int32(math.Round(frac * (1 << 24)))
+	if v == 0 {
+		exp = 0
+	}
+	return uint32(int32(exp+1)&0x7f)<<25 | uint32(coef)&0x1ffffff
+}
+
+func trackingReply(req []byte, refTime time.Time) []byte {
+	reply := make([]byte, chronyTrackingReplyLen)
+	reply[0] = chronyProtoVersion
+	reply[1] = chronyPktTypeReply
+	binary.BigEndian.PutUint16(reply[4:], chronyReqTracking)
+	binary.BigEndian.PutUint16(reply[6:], chronyRpyTracking)
+	copy(reply[16:20], req[8:12])
+
+	data := reply[chronyReplyHeaderLen:]
+	binary.BigEndian.PutUint16(data[24:], 3)
+	binary.BigEndian.PutUint32(data[28:], chronyNoHighSec)
+	binary.BigEndian.PutUint32(data[32:], uint32(refTime.Unix()))
+	binary.BigEndian.PutUint32(data[36:], uint32(refTime.Nanosecond()))
+	binary.BigEndian.PutUint32(data[40:], encodeChronyFloat(-0.00025))
+	binary.BigEndian.PutUint32(data[48:], encodeChronyFloat(0.0001))
+	binary.BigEndian.PutUint32(data[64:], encodeChronyFloat(0.015))
+	binary.BigEndian.PutUint32(data[68:], encodeChronyFloat(0.002))
+	return reply
+}
+
+// serveOnce answers the first packet received on conn with respond.
+func serveOnce(t *testing.T, conn net.PacketConn, respond func(req []byte) []byte) {
+	go func() {
+		buf := make([]byte, 1024)
+		n, addr, err := conn.ReadFrom(buf)
+		if err != nil {
+			return
+		}
+		_, err = conn.WriteTo(respond(buf[:n]), addr)
+		assert.NoError(t, err)
+	}()
+}
+
+func TestChronyFloat(t *testing.T) {
+	for _, v := range []float64{0, 1, -1, 0.5, -0.00025, 123.456, 1e-9} {
+		b := make([]byte, 4)
+		binary.BigEndian.PutUint32(b, encodeChronyFloat(v))
+		assert.InDelta(t, v, chronyFloat(b), math.Abs(v)*1e-6, "value %v", v)
+	}
+}
+
+func TestChronyClientUDP(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer conn.Close()
+
+	refTime := time.Now().Add(-30 * time.Second)
+	reqLen := make(chan int, 1)
+	serveOnce(t, conn, func(req []byte) []byte {
+		reqLen <- len(req)
+		return trackingReply(req, refTime)
+	})
+
+	endpoint, err := parseEndpoint("udp://" + conn.LocalAddr().String())
+	require.NoError(t, err)
+	status, err := newClient(endpoint, time.Second).query(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, chronyTrackingReplyLen, <-reqLen)
+	assert.Equal(t, int64(3), status.stratum)
+	assert.InDelta(t, -0.00025, status.offset, 1e-9)
+	assert.True(t, status.hasRMSOffset)
+	assert.InDelta(t, 0.0001, status.rmsOffset, 1e-9)
+	assert.InDelta(t, 0.015, status.rootDelay, 1e-9)
+	assert.InDelta(t, 0.002, status.rootDispersion, 1e-9)
+	assert.True(t, status.hasSinceLastSync)
+	assert.InDelta(t, 30, status.sinceLastSync, 1)
+}
+
+func TestChronyClientUnixSocket(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "chronyd.sock")
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: path, Net: "unixgram"})
+	require.NoError(t, err)
+	defer conn.Close()
+
+	serveOnce(t, conn, func(req []byte) []byte {
+		return trackingReply(req, time.Now())
+	})
+
+	status, err := newClient(&url.URL{Scheme: schemeUnix, Path: path}, time.Second).query(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), status.stratum)
+
+	// The local socket is removed once the query is done
+	matches, err := filepath.Glob(filepath.Join(dir, "otelcol-chrony.*"))
+	require.NoError(t, err)
+	assert.Empty(t, matches)
+}
+
+func TestParseTrackingReplyErrors(t *testing.T) {
+	req := make([]byte, chronyTrackingReplyLen)
+	binary.BigEndian.PutUint32(req[8:], 42)
+	valid := trackingReply(req, time.Now())
+
+	cases := []struct {
+		name     string
+		modify   func([]byte) []byte
+		expected string
+	}{
+		{
+			name:     "short",
+			modify:   func(b []byte) []byte { return b[:10] },
+			expected: "tracking reply too short: 10 bytes",
+		},
+		{
+			name:     "version",
+			modify:   func(b []byte) []byte { b[0] = 5; return b },
+			expected: "unexpected reply version 5 or type 2",
+		},
+		{
+			name:     "sequence",
+			modify:   func(b []byte) []byte { binary.BigEndian.PutUint32(b[16:], 7); return b },
+			expected: "unexpected reply sequence 7, expected 42",
+		},
+		{
+			name:     "unauthorized",
+			modify:   func(b []byte) []byte { binary.BigEndian.PutUint16(b[8:], chronyStatusUnauth); return b },
+			expected: "chronyd refused the tracking request, allow it with the cmdallow directive",
+		},
+		{
+			name:     "status",
+			modify:   func(b []byte) []byte { binary.BigEndian.PutUint16(b[8:], 1); return b },
+			expected: "chronyd returned status 1",
+		},
+		{
+			name:     "reply",
+			modify:   func(b []byte) []byte { binary.BigEndian.PutUint16(b[6:], 1); return b },
+			expected: "unexpected reply 1 to tracking request",
+		},
+		{
+			name:     "truncated_data",
+			modify:   func(b []byte) []byte { return b[:chronyTrackingReplyLen-4] },
+			expected: "tracking reply too short: 104 bytes",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			reply := tc.modify(append([]byte{}, valid...))
+			_, err := parseTrackingReply(reply, 42, time.Now())
+			assert.EqualError(t, err, tc.expected)
+		})
+	}
+}
+
+func ntpResponse(req []byte, offset time.Duration, ref time.Time) []byte {
+	now := time.Now().Add(offset)
+	resp := make([]byte, ntpPacketLen)
+	resp[0] = ntpVersion<<3 | ntpModeServer
+	resp[1] = 2
+	binary.BigEndian.PutUint32(resp[4:], 1<<15) // 0.5s
+	binary.BigEndian.PutUint32(resp[8:], 1<<14) // 0.25s
+	binary.BigEndian.PutUint64(resp[16:], toNTPTime(ref))
+	copy(resp[24:32], req[40:48])
+	binary.BigEndian.PutUint64(resp[32:], toNTPTime(now))
+	binary.BigEndian.PutUint64(resp[40:], toNTPTime(now))
+	return resp
+}
+
+func TestNTPClient(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer conn.Close()
+
+	ref := time.Now().Add(-time.Minute)
+	serveOnce(t, conn, func(req []byte) []byte {
+		return ntpResponse(req, 2*time.Second, ref)
+	})
+
+	endpoint, err := parseEndpoint("ntp://" + conn.LocalAddr().String())
+	require.NoError(t, err)
+	status, err := newClient(endpoint, time.Second).query(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(2), status.stratum)
+	assert.InDelta(t, 2, status.offset, 0.1)
+	assert.Equal(t, 0.5, status.rootDelay)
+	assert.Equal(t, 0.25, status.rootDispersion)
+	assert.False(t, status.hasRMSOffset)
+	assert.True(t, status.hasSinceLastSync)
+	assert.InDelta(t, 60, status.sinceLastSync, 1)
+}
+
+func TestParseNTPResponseErrors(t *testing.T) {
+	req := make([]byte, ntpPacketLen)
+	binary.BigEndian.PutUint64(req[40:], toNTPTime(time.Now()))
+	valid := ntpResponse(req, 0, time.Now())
+
+	cases := []struct {
+		name     string
+		modify   func([]byte) []byte
+		expected string
+	}{
+		{
+			name:     "short",
+			modify:   func(b []byte) []byte { return b[:12] },
+			expected: "NTP response too short: 12 bytes",
+		},
+		{
+			name:     "mode",
+			modify:   func(b []byte) []byte { b[0] = ntpVersion<<3 | ntpModeClient; return b },
+			expected: "unexpected NTP mode 3",
+		},
+		{
+			name:     "originate",
+			modify:   func(b []byte) []byte { b[31]++; return b },
+			expected: "NTP response does not match the request",
+		},
+		{
+			name: "kiss_of_death",
+			modify: func(b []byte) []byte {
+				b[1] = 0
+				copy(b[12:16], "RATE")
+				return b
+			},
+			expected: `NTP server sent kiss code "RATE"`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			resp := tc.modify(append([]byte{}, valid...))
+			_, err := parseNTPResponse(resp, req, time.Now(), time.Now())
+			assert.EqualError(t, err, tc.expected)
+		})
+	}
+}
+
+func TestNTPTime(t *testing.T) {
+	now := time.Unix(1623000000, 123456789)
+	assert.InDelta(t, 0, fromNTPTime(toNTPTime(now)).Sub(now).Nanoseconds(), 1)
+
+	// After the NTP era rollover in 2036
+	later := time.Date(2040, 1, 1, 0, 0, 0, 0, time.UTC)
+	assert.True(t, later.Equal(fromNTPTime(toNTPTime(later))))
+}
+
+func TestQueryTimeout(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer conn.Close()
+
+	endpoint, err := parseEndpoint("udp://" + conn.LocalAddr().String())
+	require.NoError(t, err)
+	_, err = newClient(endpoint, 50*time.Millisecond).query(context.Background())
+	assert.Error(t, err)
+}
@@ -0,0 +1,87 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chronyreceiver
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"go.opentelemetry.io/collector/receiver/scraperhelper"
+)
+
+const (
+	schemeUnix = "unix"
+	schemeUDP  = "udp"
+	schemeNTP  = "ntp"
+
+	defaultChronyPort = "323"
+	defaultNTPPort    = "123"
+)
+
+// Config defines the configuration for the chrony receiver.
+type Config struct {
+	scraperhelper.ScraperControllerSettings `mapstructure:",squash"`
+
+	// Endpoint is the address queried for the clock synchronization status:
+	//  - unix:///path/to/chronyd.sock queries chronyd through its command socket,
+	//  - udp://host:port queries chronyd through its command port (323 by default),
+	//  - ntp://host:port queries an NTP server (port 123 by default).
+	Endpoint string `mapstructure:"endpoint"`
+
+	// Timeout for each query.
+	Timeout time.Duration `mapstructure:"timeout"`
+}
+
+// Validate checks that the receiver configuration is valid.
+func (cfg *Config) Validate() error {
+	if _, err := parseEndpoint(cfg.Endpoint); err != nil {
+		return err
+	}
+	if cfg.Timeout <= 0 {
+		return errors.New("timeout must be greater than zero")
+	}
+	return nil
+}
+
+// parseEndpoint parses the endpoint, adding the default port for the scheme if missing.
+func parseEndpoint(endpoint string) (*url.URL, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid endpoint %q: %w", endpoint, err)
+	}
+
+	switch u.Scheme {
+	case schemeUnix:
+		if u.Path == "" {
+			return nil, fmt.Errorf("invalid endpoint %q: missing socket path", endpoint)
+		}
+	case schemeUDP, schemeNTP:
+		if u.Hostname() == "" {
+			return nil, fmt.Errorf("invalid endpoint %q: missing host", endpoint)
+		}
+		if u.Port() == "" {
+			port := defaultChronyPort
+			if u.Scheme == schemeNTP {
+				port = defaultNTPPort
+			}
+			u.Host = u.Host + ":" + port
+		}
+	default:
+		return nil, fmt.Errorf("invalid endpoint %q: scheme must be one of unix, udp or ntp", endpoint)
+	}
+	return u, nil
+}
@@ -0,0 +1,95 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chronyreceiver
+
+import (
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configtest"
+	"go.opentelemetry.io/collector/receiver/scraperhelper"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	require.NoError(t, err)
+
+	factory := NewFactory()
+	factories.Receivers[typeStr] = factory
+
+	cfg, err := configtest.LoadConfigAndValidate(path.Join(".", "testdata", "config.yaml"), factories)
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	assert.Equal(t, factory.CreateDefaultConfig(), cfg.Receivers[config.NewID(typeStr)])
+	assert.Equal(t,
+		&Config{
+			ScraperControllerSettings: scraperhelper.ScraperControllerSettings{
+				ReceiverSettings:   config.NewReceiverSettings(config.NewIDWithName(typeStr, "ntp")),
+				CollectionInterval: time.Minute,
+			},
+			Endpoint: "ntp://pool.ntp.org",
+			Timeout:  2 * time.Second,
+		},
+		cfg.Receivers[config.NewIDWithName(typeStr, "ntp")],
+	)
+}
+
+func TestValidateConfig(t *testing.T) {
+	cases := []struct {
+		endpoint string
+		expected string
+	}{
+		{endpoint: "unix:///var/run/chrony/chronyd.sock"},
+		{endpoint: "udp://localhost"},
+		{endpoint: "ntp://time.example.com:1123"},
+		{endpoint: "unix://", expected: `invalid endpoint "unix://": missing socket path`},
+		{endpoint: "udp://:323", expected: `invalid endpoint "udp://:323": missing host`},
+		{endpoint: "localhost:323", expected: `invalid endpoint "localhost:323": scheme must be one of unix, udp or ntp`},
+		{endpoint: "tcp://localhost:323", expected: `invalid endpoint "tcp://localhost:323": scheme must be one of unix, udp or ntp`},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.endpoint, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.Endpoint = tc.endpoint
+			err := cfg.Validate()
+			if tc.expected == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expected)
+			}
+		})
+	}
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Timeout = 0
+	assert.EqualError(t, cfg.Validate(), "timeout must be greater than zero")
+}
+
+func TestParseEndpointDefaultPorts(t *testing.T) {
+	u, err := parseEndpoint("udp://localhost")
+	require.NoError(t, err)
+	assert.Equal(t, "localhost:323", u.Host)
+
+	u, err = parseEndpoint("ntp://[::1]")
+	require.NoError(t, err)
+	assert.Equal(t, "[::1]:123", u.Host)
+}
@@ -0,0 +1,76 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chronyreceiver
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/consumer/simple"
+	"go.opentelemetry.io/collector/receiver/scraperhelper"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/chronyreceiver/internal/metadata"
+)
+
+type chronyScraper struct {
+	client client
+
+	logger *zap.Logger
+}
+
+func newChronyScraper(
+	logger *zap.Logger,
+	config *Config,
+) (scraperhelper.ResourceMetricsScraper, error) {
+	endpoint, err := parseEndpoint(config.Endpoint)
+	if err != nil {
+		return nil, err
+	}
+	cs := &chronyScraper{
+		client: newClient(endpoint, config.Timeout),
+		logger: logger,
+	}
+	return scraperhelper.NewResourceMetricsScraper(config.ID(), cs.scrape), nil
+}
+
+func (r *chronyScraper) scrape(ctx context.Context) (pdata.ResourceMetricsSlice, error) {
+	status, err := r.client.query(ctx)
+	if err != nil {
+		r.logger.Error("Failed to query the clock synchronization status", zap.Error(err))
+		return pdata.ResourceMetricsSlice{}, err
+	}
+
+	metrics := simple.Metrics{
+		Metrics:                    pdata.NewMetrics(),
+		Timestamp:                  time.Now(),
+		MetricFactoriesByName:      metadata.M.FactoriesByName(),
+		InstrumentationLibraryName: "otelcol/chrony",
+	}
+
+	metrics.AddGaugeDataPoint(metadata.M.NtpStratum.Name(), status.stratum)
+	metrics.AddDGaugeDataPoint(metadata.M.NtpTimeOffset.Name(), status.offset)
+	metrics.AddDGaugeDataPoint(metadata.M.NtpRootDelay.Name(), status.rootDelay)
+	metrics.AddDGaugeDataPoint(metadata.M.NtpRootDispersion.Name(), status.rootDispersion)
+	if status.hasRMSOffset {
+		metrics.AddDGaugeDataPoint(metadata.M.NtpTimeRmsOffset.Name(), status.rmsOffset)
+	}
+	if status.hasSinceLastSync {
+		metrics.AddDGaugeDataPoint(metadata.M.NtpTimeSinceLastSync.Name(), status.sinceLastSync)
+	}
+
+	return metrics.Metrics.ResourceMetrics(), nil
+}
@@ -0,0 +1,110 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chronyreceiver
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.uber.org/zap"
+)
+
+type fakeClient struct {
+	status *syncStatus
+	err    error
+}
+
+func (c *fakeClient) query(context.Context) (*syncStatus, error) {
+	return c.status, c.err
+}
+
+func TestScrape(t *testing.T) {
+	cases := []struct {
+		name     string
+		status   *syncStatus
+		expected map[string]interface{}
+	}{
+		{
+			name: "chrony",
+			status: &syncStatus{
+				stratum:          3,
+				offset:           -0.001,
+				rootDelay:        0.02,
+				rootDispersion:   0.003,
+				rmsOffset:        0.0005,
+				hasRMSOffset:     true,
+				sinceLastSync:    64,
+				hasSinceLastSync: true,
+			},
+			expected: map[string]interface{}{
+				"ntp.stratum":              int64(3),
+				"ntp.time.offset":          -0.001,
+				"ntp.root.delay":           0.02,
+				"ntp.root.dispersion":      0.003,
+				"ntp.time.rms_offset":      0.0005,
+				"ntp.time.since_last_sync": float64(64),
+			},
+		},
+		{
+			name: "ntp_never_synchronized",
+			status: &syncStatus{
+				stratum: 16,
+				offset:  0.5,
+			},
+			expected: map[string]interface{}{
+				"ntp.stratum":         int64(16),
+				"ntp.time.offset":     0.5,
+				"ntp.root.delay":      float64(0),
+				"ntp.root.dispersion": float64(0),
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			scraper := &chronyScraper{client: &fakeClient{status: tc.status}, logger: zap.NewNop()}
+			rms, err := scraper.scrape(context.Background())
+			require.NoError(t, err)
+
+			require.Equal(t, 1, rms.Len())
+			ilms := rms.At(0).InstrumentationLibraryMetrics()
+			require.Equal(t, 1, ilms.Len())
+			assert.Equal(t, "otelcol/chrony", ilms.At(0).InstrumentationLibrary().Name())
+
+			values := map[string]interface{}{}
+			metrics := ilms.At(0).Metrics()
+			for i := 0; i < metrics.Len(); i++ {
+				m := metrics.At(i)
+				switch m.DataType() {
+				case pdata.MetricDataTypeIntGauge:
+					values[m.Name()] = m.IntGauge().DataPoints().At(0).Value()
+				case pdata.MetricDataTypeDoubleGauge:
+					values[m.Name()] = m.DoubleGauge().DataPoints().At(0).Value()
+				}
+			}
+			assert.Equal(t, tc.expected, values)
+		})
+	}
+}
+
+func TestScrapeError(t *testing.T) {
+	scraper := &chronyScraper{client: &fakeClient{err: errors.New("connection refused")}, logger: zap.NewNop()}
+	_, err := scraper.scrape(context.Background())
+	assert.EqualError(t, err, "connection refused")
+}
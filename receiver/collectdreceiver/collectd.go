@@ -21,6 +21,7 @@ import (
 	"time"
 
 	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
+	"go.opentelemetry.io/collector/consumer/pdata"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
@@ -29,6 +30,10 @@ const (
 	collectDMetricGauge    = "gauge"
 	collectDMetricCounter  = "counter"
 	collectDMetricAbsolute = "absolute"
+
+	collectDSeverityFailure = "failure"
+	collectDSeverityWarning = "warning"
+	collectDSeverityOkay    = "okay"
 )
 
 type collectDRecord struct {
@@ -95,6 +100,60 @@ func (r *collectDRecord) appendToMetrics(metrics []*metricspb.Metric, defaultLab
 	return metrics, nil
 }
 
+// appendToLogs converts the record to a log record if it's a notification.
+func (r *collectDRecord) appendToLogs(logs pdata.LogSlice, defaultAttrs map[string]string) {
+	if !r.isEvent() {
+		return
+	}
+
+	lr := logs.AppendEmpty()
+	lr.SetTimestamp(pdata.Timestamp(*r.Time * float64(time.Second)))
+	lr.SetSeverityText(*r.Severity)
+	lr.SetSeverityNumber(severityNumber(*r.Severity))
+	lr.Body().SetStringVal(*r.Message)
+
+	attrs := lr.Attributes()
+	for k, v := range defaultAttrs {
+		attrs.UpsertString(k, v)
+	}
+	for k, v := range map[string]*string{
+		"host":            r.Host,
+		"plugin":          r.Plugin,
+		"plugin_instance": r.PluginInstance,
+		"type":            r.TypeS,
+		"type_instance":   r.TypeInstance,
+	} {
+		if !isNilOrEmpty(v) {
+			attrs.UpsertString(k, *v)
+		}
+	}
+	for k, v := range r.Meta {
+		switch val := v.(type) {
+		case string:
+			attrs.UpsertString(k, val)
+		case bool:
+			attrs.UpsertBool(k, val)
+		case float64:
+			attrs.UpsertDouble(k, val)
+		default:
+			attrs.UpsertString(k, fmt.Sprintf("%v", val))
+		}
+	}
+}
+
+// severityNumber maps the severity of a collectd notification to the OTLP one.
+func severityNumber(severity string) pdata.SeverityNumber {
+	switch strings.ToLower(severity) {
+	case collectDSeverityFailure:
+		return pdata.SeverityNumberERROR
+	case collectDSeverityWarning:
+		return pdata.SeverityNumberWARN
+	case collectDSeverityOkay:
+		return pdata.SeverityNumberINFO
+	}
+	return pdata.SeverityNumberUNDEFINED
+}
+
 func (r *collectDRecord) newMetric(name string, dsType *string, val *json.Number, labels map[string]string) (*metricspb.Metric, error) {
 	metric := &metricspb.Metric{}
 	point, isDouble, err := r.newPoint(val)
@@ -23,6 +23,7 @@ import (
 	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/pdata"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
@@ -43,6 +44,62 @@ func TestDecodeEvent(t *testing.T) {
 	}
 }
 
+func TestDecodeEventToLogs(t *testing.T) {
+	jsonData, err := loadFromJSON("./testdata/event.json")
+	require.NoError(t, err)
+
+	records := []collectDRecord{}
+	err = json.Unmarshal(jsonData, &records)
+	require.NoError(t, err)
+
+	logs := pdata.NewLogSlice()
+	for _, r := range records {
+		r.appendToLogs(logs, map[string]string{"k": "v"})
+	}
+	require.Equal(t, 1, logs.Len())
+
+	lr := logs.At(0)
+	assert.Equal(t, pdata.Timestamp(1435104306000000000), lr.Timestamp())
+	assert.Equal(t, "OKAY", lr.SeverityText())
+	assert.Equal(t, pdata.SeverityNumberINFO, lr.SeverityNumber())
+	assert.Equal(t, "my message", lr.Body().StringVal())
+	assert.Equal(t, map[string]interface{}{
+		"k":               "v",
+		"key":             "value",
+		"host":            "mwp-signalbox[a=b]",
+		"plugin":          "my_plugin",
+		"plugin_instance": "my_plugin_instance[f=x]",
+		"type":            "imanotify",
+		"type_instance":   "notify_instance[k=v]",
+	}, attributesToMap(lr.Attributes()))
+
+	// Only the two notifications mixed with the values are converted to logs.
+	jsonData, err = loadFromJSON("./testdata/collectd.json")
+	require.NoError(t, err)
+	records = []collectDRecord{}
+	require.NoError(t, json.Unmarshal(jsonData, &records))
+	for _, r := range records {
+		r.appendToLogs(logs, map[string]string{})
+	}
+	assert.Equal(t, 3, logs.Len())
+}
+
+func TestSeverityNumber(t *testing.T) {
+	assert.Equal(t, pdata.SeverityNumberERROR, severityNumber("FAILURE"))
+	assert.Equal(t, pdata.SeverityNumberWARN, severityNumber("WARNING"))
+	assert.Equal(t, pdata.SeverityNumberINFO, severityNumber("okay"))
+	assert.Equal(t, pdata.SeverityNumberUNDEFINED, severityNumber("unknown"))
+}
+
+func attributesToMap(attrs pdata.AttributeMap) map[string]interface{} {
+	m := map[string]interface{}{}
+	attrs.Range(func(k string, v pdata.AttributeValue) bool {
+		m[k] = v.StringVal()
+		return true
+	})
+	return m
+}
+
 func loadFromJSON(path string) ([]byte, error) {
 	var body []byte
 	jsonFile, err := os.Open(path)
@@ -29,4 +29,7 @@ type Config struct {
 	Timeout          time.Duration `mapstructure:"timeout"`
 	AttributesPrefix string        `mapstructure:"attributes_prefix"`
 	Encoding         string        `mapstructure:"encoding"`
+	// TypesDB is the list of types.db files defining the data sources of the collectd types. When set, the
+	// names and types of the metrics of the known types are taken from these files instead of the records.
+	TypesDB []string `mapstructure:"types_db"`
 }
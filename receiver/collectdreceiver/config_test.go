@@ -53,5 +53,6 @@ func TestLoadConfig(t *testing.T) {
 			Timeout:          time.Second * 50,
 			AttributesPrefix: "dap_",
 			Encoding:         "command",
+			TypesDB:          []string{"./testdata/types.db"},
 		})
 }
@@ -18,9 +18,11 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"sync"
 	"time"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenterror"
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/config/confignet"
 	"go.opentelemetry.io/collector/consumer"
@@ -41,7 +43,8 @@ func NewFactory() component.ReceiverFactory {
 	return receiverhelper.NewFactory(
 		typeStr,
 		createDefaultConfig,
-		receiverhelper.WithMetrics(createMetricsReceiver))
+		receiverhelper.WithMetrics(createMetricsReceiver),
+		receiverhelper.WithLogs(createLogsReceiver))
 }
 func createDefaultConfig() config.Receiver {
 	return &Config{
@@ -60,7 +63,36 @@ func createMetricsReceiver(
 	cfg config.Receiver,
 	nextConsumer consumer.Metrics,
 ) (component.MetricsReceiver, error) {
-	c := cfg.(*Config)
+	if nextConsumer == nil {
+		return nil, componenterror.ErrNilNextConsumer
+	}
+	r, err := getOrCreateReceiver(params, cfg.(*Config))
+	if err != nil {
+		return nil, err
+	}
+	r.registerMetricsConsumer(nextConsumer)
+	return r, nil
+}
+
+func createLogsReceiver(
+	_ context.Context,
+	params component.ReceiverCreateSettings,
+	cfg config.Receiver,
+	nextConsumer consumer.Logs,
+) (component.LogsReceiver, error) {
+	if nextConsumer == nil {
+		return nil, componenterror.ErrNilNextConsumer
+	}
+	r, err := getOrCreateReceiver(params, cfg.(*Config))
+	if err != nil {
+		return nil, err
+	}
+	r.registerLogsConsumer(nextConsumer)
+	return r, nil
+}
+
+// getOrCreateReceiver returns the receiver shared by the metrics and logs pipelines using the given config.
+func getOrCreateReceiver(params component.ReceiverCreateSettings, c *Config) (*collectdReceiver, error) {
 	c.Encoding = strings.ToLower(c.Encoding)
 	// CollectD receiver only supports JSON encoding. We expose a config option
 	// to make it explicit and obvious to the users.
@@ -70,5 +102,21 @@ func createMetricsReceiver(
 			c.Encoding,
 		)
 	}
-	return newCollectdReceiver(params.Logger, c.Endpoint, c.Timeout, c.AttributesPrefix, nextConsumer)
+
+	receiverLock.Lock()
+	defer receiverLock.Unlock()
+
+	r := receivers[c]
+	if r == nil {
+		types, err := loadTypesDB(c.TypesDB)
+		if err != nil {
+			return nil, err
+		}
+		r = newReceiver(params.Logger, c.Endpoint, c.Timeout, c.AttributesPrefix, types)
+		receivers[c] = r
+	}
+	return r, nil
 }
+
+var receiverLock sync.Mutex
+var receivers = map[*Config]*collectdReceiver{}
@@ -28,20 +28,27 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenterror"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/pdata"
 	"go.opentelemetry.io/collector/translator/internaldata"
 	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/admissioncontrol"
 )
 
 var _ component.MetricsReceiver = (*collectdReceiver)(nil)
+var _ component.LogsReceiver = (*collectdReceiver)(nil)
 
-// collectdReceiver implements the component.MetricsReceiver for CollectD protocol.
+// collectdReceiver implements the component.MetricsReceiver and component.LogsReceiver for CollectD protocol.
+// The values are sent to the metrics consumer, and the notifications to the logs consumer.
 type collectdReceiver struct {
 	sync.Mutex
 	logger             *zap.Logger
 	addr               string
 	server             *http.Server
 	defaultAttrsPrefix string
+	types              typesDB
 	nextConsumer       consumer.Metrics
+	logsConsumer       consumer.Logs
 }
 
 // newCollectdReceiver creates the CollectD receiver with the given parameters.
@@ -55,11 +62,23 @@ func newCollectdReceiver(
 		return nil, componenterror.ErrNilNextConsumer
 	}
 
+	r := newReceiver(logger, addr, timeout, defaultAttrsPrefix, nil)
+	r.registerMetricsConsumer(nextConsumer)
+	return r, nil
+}
+
+// newReceiver creates the CollectD receiver without any consumer.
+func newReceiver(
+	logger *zap.Logger,
+	addr string,
+	timeout time.Duration,
+	defaultAttrsPrefix string,
+	types typesDB) *collectdReceiver {
 	r := &collectdReceiver{
 		logger:             logger,
 		addr:               addr,
-		nextConsumer:       nextConsumer,
 		defaultAttrsPrefix: defaultAttrsPrefix,
+		types:              types,
 	}
 	r.server = &http.Server{
 		Addr:         addr,
@@ -67,7 +86,21 @@ func newCollectdReceiver(
 		ReadTimeout:  timeout,
 		WriteTimeout: timeout,
 	}
-	return r, nil
+	return r
+}
+
+func (cdr *collectdReceiver) registerMetricsConsumer(mc consumer.Metrics) {
+	cdr.Lock()
+	defer cdr.Unlock()
+
+	cdr.nextConsumer = mc
+}
+
+func (cdr *collectdReceiver) registerLogsConsumer(lc consumer.Logs) {
+	cdr.Lock()
+	defer cdr.Unlock()
+
+	cdr.logsConsumer = lc
 }
 
 // Start starts an HTTP server that can process CollectD JSON requests.
@@ -75,6 +108,10 @@ func (cdr *collectdReceiver) Start(_ context.Context, host component.Host) error
 	cdr.Lock()
 	defer cdr.Unlock()
 
+	if cdr.nextConsumer == nil && cdr.logsConsumer == nil {
+		return componenterror.ErrNilNextConsumer
+	}
+
 	go func() {
 		if err := cdr.server.ListenAndServe(); err != http.ErrServerClosed {
 			host.ReportFatalError(fmt.Errorf("error starting collectd receiver: %v", err))
@@ -117,19 +154,35 @@ func (cdr *collectdReceiver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	defaultAttrs := cdr.defaultAttributes(r)
 
 	var metrics []*metricspb.Metric
+	logs := pdata.NewLogs()
+	logRecords := logs.ResourceLogs().AppendEmpty().InstrumentationLibraryLogs().AppendEmpty().Logs()
 	ctx := context.Background()
-	for _, record := range records {
+	for i := range records {
+		record := &records[i]
+		cdr.types.apply(record)
 		metrics, err = record.appendToMetrics(metrics, defaultAttrs)
 		if err != nil {
 			cdr.handleHTTPErr(w, err, "unable to process metrics")
 			return
 		}
+		if cdr.logsConsumer != nil {
+			record.appendToLogs(logRecords, defaultAttrs)
+		}
 	}
 
-	err = cdr.nextConsumer.ConsumeMetrics(ctx, internaldata.OCToMetrics(nil, nil, metrics))
-	if err != nil {
-		cdr.handleHTTPErr(w, err, "unable to process metrics")
-		return
+	if cdr.nextConsumer != nil {
+		err = cdr.nextConsumer.ConsumeMetrics(ctx, internaldata.OCToMetrics(nil, nil, metrics))
+		if err != nil {
+			cdr.handleHTTPErr(w, err, "unable to process metrics")
+			return
+		}
+	}
+	if logRecords.Len() > 0 {
+		err = cdr.logsConsumer.ConsumeLogs(ctx, logs)
+		if err != nil {
+			cdr.handleHTTPErr(w, err, "unable to process notifications")
+			return
+		}
 	}
 	w.Write([]byte("OK"))
 }
@@ -156,7 +209,11 @@ func (cdr *collectdReceiver) defaultAttributes(req *http.Request) map[string]str
 
 func (cdr *collectdReceiver) handleHTTPErr(w http.ResponseWriter, err error, msg string) {
 	recordRequestErrors()
-	w.WriteHeader(http.StatusBadRequest)
+	if admissioncontrol.SetRetryAfter(w.Header(), err) {
+		w.WriteHeader(http.StatusTooManyRequests)
+	} else {
+		w.WriteHeader(http.StatusBadRequest)
+	}
 	cdr.logger.Error(msg, zap.Error(err))
 	_, err = w.Write([]byte(msg))
 	if err != nil {
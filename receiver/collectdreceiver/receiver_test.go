@@ -18,6 +18,8 @@ import (
 	"bytes"
 	"context"
 	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -33,6 +35,8 @@ import (
 	"go.uber.org/zap"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/admissioncontrol"
 )
 
 type metricLabel struct {
@@ -209,6 +213,54 @@ func TestCollectDServer(t *testing.T) {
 	}
 }
 
+func TestCollectDServerAdmissionPaused(t *testing.T) {
+	cdr, err := newCollectdReceiver(zap.NewNop(), "localhost:0", defaultTimeout, "",
+		consumertest.NewErr(&admissioncontrol.PausedError{RetryAfter: 1500 * time.Millisecond}))
+	require.NoError(t, err)
+
+	body := `[{"dsnames":["value"],"dstypes":["gauge"],"host":"h","interval":10.0,"plugin":"memory","plugin_instance":"",` +
+		`"time":1415062577.4949999,"type":"memory","type_instance":"free","values":[2.1474]}]`
+	w := httptest.NewRecorder()
+	cdr.(*collectdReceiver).ServeHTTP(w, httptest.NewRequest("POST", "http://localhost", strings.NewReader(body)))
+
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Equal(t, "2", w.Header().Get("Retry-After"))
+}
+
+func TestCollectDServerNotifications(t *testing.T) {
+	const endpoint = "localhost:8082"
+
+	sink := new(consumertest.LogsSink)
+	cdr := newReceiver(zap.NewNop(), endpoint, defaultTimeout, "dap_", nil)
+	cdr.registerLogsConsumer(sink)
+
+	require.NoError(t, cdr.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() {
+		require.NoError(t, cdr.Shutdown(context.Background()))
+	}()
+
+	time.Sleep(time.Second)
+
+	body, err := loadFromJSON("./testdata/event.json")
+	require.NoError(t, err)
+	req, err := http.NewRequest("POST", "http://"+endpoint+"?dap_attr1=attr1val", bytes.NewBuffer(body))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, 200, resp.StatusCode)
+
+	require.Len(t, sink.AllLogs(), 1)
+	logs := sink.AllLogs()[0]
+	require.Equal(t, 1, logs.LogRecordCount())
+	lr := logs.ResourceLogs().At(0).InstrumentationLibraryLogs().At(0).Logs().At(0)
+	assert.Equal(t, "my message", lr.Body().StringVal())
+	attr, ok := lr.Attributes().Get("attr1")
+	assert.True(t, ok)
+	assert.Equal(t, "attr1val", attr.StringVal())
+}
+
 func assertMetricsDataAreEqual(t *testing.T, metricsData1, metricsData2 []*agentmetricspb.ExportMetricsServiceRequest) {
 	if len(metricsData1) != len(metricsData2) {
 		t.Errorf("metrics data length mismatch. got:\n%d\nwant:\n%d\n", len(metricsData1), len(metricsData2))
@@ -0,0 +1,105 @@
+// Copyright 2019, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collectdreceiver
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// dataSource is a data source of a collectd type.
+type dataSource struct {
+	name   string
+	dsType string
+}
+
+// typesDB holds the data sources of the collectd types, keyed by the type name, as defined in
+// the types.db files. See https://collectd.org/documentation/manpages/types.db.5.shtml.
+type typesDB map[string][]dataSource
+
+// loadTypesDB parses the given types.db files, the types defined in the latest files override
+// the ones with the same name defined in the previous files.
+func loadTypesDB(paths []string) (typesDB, error) {
+	db := typesDB{}
+	for _, path := range paths {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open types.db %q: %w", path, err)
+		}
+		err = parseTypesDB(f, db)
+		f.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse types.db %q: %w", path, err)
+		}
+	}
+	return db, nil
+}
+
+// parseTypesDB parses the lines in the format "type ds-name:ds-type:min:max[, ...]".
+func parseTypesDB(r io.Reader, db typesDB) error {
+	scanner := bufio.NewScanner(r)
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			return fmt.Errorf("line %d: type %q has no data source", lineNum, fields[0])
+		}
+
+		var sources []dataSource
+		for _, spec := range strings.Split(strings.Join(fields[1:], ""), ",") {
+			parts := strings.Split(spec, ":")
+			if len(parts) != 4 {
+				return fmt.Errorf("line %d: invalid data source %q", lineNum, spec)
+			}
+			dsType := strings.ToLower(parts[1])
+			switch dsType {
+			case collectDMetricGauge, collectDMetricCounter, collectDMetricDerive, collectDMetricAbsolute:
+			default:
+				return fmt.Errorf("line %d: unknown data source type %q", lineNum, parts[1])
+			}
+			sources = append(sources, dataSource{name: parts[0], dsType: dsType})
+		}
+		db[fields[0]] = sources
+	}
+	return scanner.Err()
+}
+
+// apply replaces the data source names and types of the record with the ones of its type, if known.
+// Records whose number of values doesn't match the number of data sources of the type are left untouched.
+func (db typesDB) apply(r *collectDRecord) {
+	if len(db) == 0 || isNilOrEmpty(r.TypeS) {
+		return
+	}
+	sources, ok := db[*r.TypeS]
+	if !ok || len(sources) != len(r.Values) {
+		return
+	}
+
+	r.Dsnames = make([]*string, len(sources))
+	r.Dstypes = make([]*string, len(sources))
+	for i := range sources {
+		r.Dsnames[i] = &sources[i].name
+		r.Dstypes[i] = &sources[i].dsType
+	}
+}
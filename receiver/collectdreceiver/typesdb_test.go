@@ -0,0 +1,79 @@
+// Copyright 2019, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collectdreceiver
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadTypesDB(t *testing.T) {
+	db, err := loadTypesDB([]string{"./testdata/types.db"})
+	require.NoError(t, err)
+
+	assert.Len(t, db, 3)
+	assert.Equal(t, []dataSource{
+		{name: "rx", dsType: collectDMetricDerive},
+		{name: "tx", dsType: collectDMetricDerive},
+	}, db["if_octets"])
+	assert.Equal(t, []dataSource{{name: "value", dsType: collectDMetricGauge}}, db["memory"])
+
+	_, err = loadTypesDB([]string{"./testdata/missing.db"})
+	assert.Error(t, err)
+}
+
+func TestParseTypesDBInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		line string
+	}{
+		{name: "no-data-source", line: "if_octets"},
+		{name: "invalid-data-source", line: "if_octets rx:DERIVE:0"},
+		{name: "unknown-data-source-type", line: "if_octets rx:HISTOGRAM:0:U"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Error(t, parseTypesDB(strings.NewReader(tt.line), typesDB{}))
+		})
+	}
+}
+
+func TestTypesDBApply(t *testing.T) {
+	db, err := loadTypesDB([]string{"./testdata/types.db"})
+	require.NoError(t, err)
+
+	typ := "if_octets"
+	generic := "value"
+	r := collectDRecord{
+		TypeS:   &typ,
+		Dsnames: []*string{&generic, &generic},
+		Dstypes: []*string{&generic, &generic},
+		Values:  []*json.Number{nil, nil},
+	}
+	db.apply(&r)
+	assert.Equal(t, "rx", *r.Dsnames[0])
+	assert.Equal(t, "tx", *r.Dsnames[1])
+	assert.Equal(t, collectDMetricDerive, *r.Dstypes[0])
+	assert.Equal(t, collectDMetricDerive, *r.Dstypes[1])
+
+	// Records with a number of values not matching the type are left untouched.
+	typ = "memory"
+	db.apply(&r)
+	assert.Equal(t, "rx", *r.Dsnames[0])
+}
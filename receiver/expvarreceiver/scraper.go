@@ -0,0 +1,141 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expvarreceiver
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"runtime"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/consumer/simple"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/expvarreceiver/internal/metadata"
+)
+
+// expvarVars is the part of the document served by the expvar package that is
+// scraped. The "memstats" variable is published by the expvar package itself.
+type expvarVars struct {
+	MemStats *runtime.MemStats `json:"memstats"`
+}
+
+type expvarScraper struct {
+	httpClient *http.Client
+
+	logger *zap.Logger
+	cfg    *Config
+}
+
+func newExpvarScraper(logger *zap.Logger, cfg *Config) *expvarScraper {
+	return &expvarScraper{
+		logger: logger,
+		cfg:    cfg,
+	}
+}
+
+func (e *expvarScraper) start(_ context.Context, host component.Host) error {
+	httpClient, err := e.cfg.ToClient(host.GetExtensions())
+	if err != nil {
+		return err
+	}
+	e.httpClient = httpClient
+	return nil
+}
+
+func (e *expvarScraper) scrape(ctx context.Context) (pdata.ResourceMetricsSlice, error) {
+	vars, err := e.getVars(ctx)
+	if err != nil {
+		e.logger.Error("Failed to fetch expvar variables", zap.String("endpoint", e.cfg.Endpoint), zap.Error(err))
+		return pdata.ResourceMetricsSlice{}, err
+	}
+	if vars.MemStats == nil {
+		return pdata.ResourceMetricsSlice{}, errors.New("memstats variable not found in the response")
+	}
+
+	metrics := simple.Metrics{
+		Metrics:                    pdata.NewMetrics(),
+		Timestamp:                  time.Now(),
+		MetricFactoriesByName:      metadata.M.FactoriesByName(),
+		InstrumentationLibraryName: "otelcol/expvar",
+	}
+	addMemStats(&metrics, vars.MemStats)
+	return metrics.Metrics.ResourceMetrics(), nil
+}
+
+func (e *expvarScraper) getVars(ctx context.Context) (*expvarVars, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, e.cfg.Endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := e.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("expected 200 response, got %d", resp.StatusCode)
+	}
+	var vars expvarVars
+	if err = json.NewDecoder(resp.Body).Decode(&vars); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return &vars, nil
+}
+
+// addMemStats adds the runtime metrics reported by the memstats variable.
+func addMemStats(metrics *simple.Metrics, ms *runtime.MemStats) {
+	metrics.AddGaugeDataPoint(metadata.M.ProcessRuntimeMemstatsBuckHashSys.Name(), int64(ms.BuckHashSys))
+	metrics.AddSumDataPoint(metadata.M.ProcessRuntimeMemstatsFrees.Name(), int64(ms.Frees))
+	metrics.AddDGaugeDataPoint(metadata.M.ProcessRuntimeMemstatsGcCPUFraction.Name(), ms.GCCPUFraction)
+	metrics.AddGaugeDataPoint(metadata.M.ProcessRuntimeMemstatsGcSys.Name(), int64(ms.GCSys))
+	metrics.AddGaugeDataPoint(metadata.M.ProcessRuntimeMemstatsHeapAlloc.Name(), int64(ms.HeapAlloc))
+	metrics.AddGaugeDataPoint(metadata.M.ProcessRuntimeMemstatsHeapIdle.Name(), int64(ms.HeapIdle))
+	metrics.AddGaugeDataPoint(metadata.M.ProcessRuntimeMemstatsHeapInuse.Name(), int64(ms.HeapInuse))
+	metrics.AddGaugeDataPoint(metadata.M.ProcessRuntimeMemstatsHeapObjects.Name(), int64(ms.HeapObjects))
+	metrics.AddGaugeDataPoint(metadata.M.ProcessRuntimeMemstatsHeapReleased.Name(), int64(ms.HeapReleased))
+	metrics.AddGaugeDataPoint(metadata.M.ProcessRuntimeMemstatsHeapSys.Name(), int64(ms.HeapSys))
+	metrics.AddGaugeDataPoint(metadata.M.ProcessRuntimeMemstatsLastPause.Name(), int64(lastPause(ms)))
+	metrics.AddSumDataPoint(metadata.M.ProcessRuntimeMemstatsLookups.Name(), int64(ms.Lookups))
+	metrics.AddSumDataPoint(metadata.M.ProcessRuntimeMemstatsMallocs.Name(), int64(ms.Mallocs))
+	metrics.AddGaugeDataPoint(metadata.M.ProcessRuntimeMemstatsMcacheInuse.Name(), int64(ms.MCacheInuse))
+	metrics.AddGaugeDataPoint(metadata.M.ProcessRuntimeMemstatsMcacheSys.Name(), int64(ms.MCacheSys))
+	metrics.AddGaugeDataPoint(metadata.M.ProcessRuntimeMemstatsMspanInuse.Name(), int64(ms.MSpanInuse))
+	metrics.AddGaugeDataPoint(metadata.M.ProcessRuntimeMemstatsMspanSys.Name(), int64(ms.MSpanSys))
+	metrics.AddGaugeDataPoint(metadata.M.ProcessRuntimeMemstatsNextGc.Name(), int64(ms.NextGC))
+	metrics.AddSumDataPoint(metadata.M.ProcessRuntimeMemstatsNumForcedGc.Name(), int64(ms.NumForcedGC))
+	metrics.AddSumDataPoint(metadata.M.ProcessRuntimeMemstatsNumGc.Name(), int64(ms.NumGC))
+	metrics.AddGaugeDataPoint(metadata.M.ProcessRuntimeMemstatsOtherSys.Name(), int64(ms.OtherSys))
+	metrics.AddSumDataPoint(metadata.M.ProcessRuntimeMemstatsPauseTotal.Name(), int64(ms.PauseTotalNs))
+	metrics.AddGaugeDataPoint(metadata.M.ProcessRuntimeMemstatsStackInuse.Name(), int64(ms.StackInuse))
+	metrics.AddGaugeDataPoint(metadata.M.ProcessRuntimeMemstatsStackSys.Name(), int64(ms.StackSys))
+	metrics.AddGaugeDataPoint(metadata.M.ProcessRuntimeMemstatsSys.Name(), int64(ms.Sys))
+	metrics.AddSumDataPoint(metadata.M.ProcessRuntimeMemstatsTotalAlloc.Name(), int64(ms.TotalAlloc))
+}
+
+// lastPause returns the duration of the most recent GC pause, which is stored in
+// a circular buffer indexed by the number of completed GC cycles.
+func lastPause(ms *runtime.MemStats) uint64 {
+	if ms.NumGC == 0 {
+		return 0
+	}
+	return ms.PauseNs[(ms.NumGC+255)%256]
+}
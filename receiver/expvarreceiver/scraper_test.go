@@ -0,0 +1,129 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expvarreceiver
+
+import (
+	"context"
+	"expvar"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/expvarreceiver/internal/metadata"
+)
+
+func newTestScraper(t *testing.T, endpoint string) *expvarScraper {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = endpoint
+	s := newExpvarScraper(zap.NewNop(), cfg)
+	require.NoError(t, s.start(context.Background(), componenttest.NewNopHost()))
+	return s
+}
+
+func TestScrape(t *testing.T) {
+	body, err := ioutil.ReadFile(path.Join("testdata", "expvar.json"))
+	require.NoError(t, err)
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != "/debug/vars" {
+			rw.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = rw.Write(body)
+	}))
+	defer server.Close()
+
+	rms, err := newTestScraper(t, server.URL+"/debug/vars").scrape(context.Background())
+	require.NoError(t, err)
+
+	require.Equal(t, 1, rms.Len())
+	ilms := rms.At(0).InstrumentationLibraryMetrics()
+	require.Equal(t, 1, ilms.Len())
+	assert.Equal(t, "otelcol/expvar", ilms.At(0).InstrumentationLibrary().Name())
+	ms := ilms.At(0).Metrics()
+	require.Equal(t, len(metadata.M.Names()), ms.Len())
+
+	ints := map[string]int64{}
+	for i := 0; i < ms.Len(); i++ {
+		m := ms.At(i)
+		switch m.DataType() {
+		case pdata.MetricDataTypeIntGauge:
+			ints[m.Name()] = m.IntGauge().DataPoints().At(0).Value()
+		case pdata.MetricDataTypeIntSum:
+			assert.True(t, m.IntSum().IsMonotonic())
+			ints[m.Name()] = m.IntSum().DataPoints().At(0).Value()
+		case pdata.MetricDataTypeDoubleGauge:
+			assert.Equal(t, metadata.M.ProcessRuntimeMemstatsGcCPUFraction.Name(), m.Name())
+			assert.InDelta(t, 0.0000315, m.DoubleGauge().DataPoints().At(0).Value(), 1e-9)
+		default:
+			t.Errorf("unexpected data type %v of %s", m.DataType(), m.Name())
+		}
+	}
+
+	assert.EqualValues(t, 4629784, ints["process.runtime.memstats.total_alloc"])
+	assert.EqualValues(t, 12945424, ints["process.runtime.memstats.sys"])
+	assert.EqualValues(t, 1547504, ints["process.runtime.memstats.heap_alloc"])
+	assert.EqualValues(t, 3092, ints["process.runtime.memstats.heap_objects"])
+	assert.EqualValues(t, 21563, ints["process.runtime.memstats.mallocs"])
+	assert.EqualValues(t, 18471, ints["process.runtime.memstats.frees"])
+	assert.EqualValues(t, 4194304, ints["process.runtime.memstats.next_gc"])
+	assert.EqualValues(t, 2, ints["process.runtime.memstats.num_gc"])
+	assert.EqualValues(t, 1, ints["process.runtime.memstats.num_forced_gc"])
+	assert.EqualValues(t, 126209, ints["process.runtime.memstats.pause_total"])
+	assert.EqualValues(t, 79406, ints["process.runtime.memstats.last_pause"])
+}
+
+func TestScrapeExpvarHandler(t *testing.T) {
+	server := httptest.NewServer(expvar.Handler())
+	defer server.Close()
+
+	rms, err := newTestScraper(t, server.URL).scrape(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 1, rms.Len())
+	assert.Equal(t, len(metadata.M.Names()), rms.At(0).InstrumentationLibraryMetrics().At(0).Metrics().Len())
+}
+
+func TestScrapeErrors(t *testing.T) {
+	cases := []struct {
+		name     string
+		status   int
+		body     string
+		expected string
+	}{
+		{name: "status", status: http.StatusNotFound, expected: "expected 200 response, got 404"},
+		{name: "invalid json", status: http.StatusOK, body: "{", expected: "failed to decode response: unexpected EOF"},
+		{name: "no memstats", status: http.StatusOK, body: `{"cmdline": ["./app"]}`, expected: "memstats variable not found in the response"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				rw.WriteHeader(tc.status)
+				_, _ = rw.Write([]byte(tc.body))
+			}))
+			defer server.Close()
+
+			_, err := newTestScraper(t, server.URL).scrape(context.Background())
+			assert.EqualError(t, err, tc.expected)
+		})
+	}
+}
@@ -66,16 +66,40 @@ func (f ReceiverType) BaseConfig(cfg config.Receiver) stanza.BaseConfig {
 // FileLogConfig defines configuration for the filelog receiver
 type FileLogConfig struct {
 	stanza.BaseConfig `mapstructure:",squash"`
-	Input             stanza.InputConfig `mapstructure:",remain"`
+	// FingerprintOffsets additionally stores the offset of every file under a
+	// key derived from its fingerprint, so that collectors sharing a storage
+	// extension resume the files read by each other.
+	FingerprintOffsets bool               `mapstructure:"fingerprint_offsets"`
+	Input              stanza.InputConfig `mapstructure:",remain"`
 }
 
 // DecodeInputConfig unmarshals the input operator
 func (f ReceiverType) DecodeInputConfig(cfg config.Receiver) (*operator.Config, error) {
+	inputCfg, err := decodeFileInputConfig(cfg.(*FileLogConfig))
+	if err != nil {
+		return nil, err
+	}
+	return &operator.Config{Builder: inputCfg}, nil
+}
+
+// WrapPersister implements stanza.PersisterWrapper to store the file offsets
+// by fingerprint when enabled
+func (f ReceiverType) WrapPersister(cfg config.Receiver, persister operator.Persister) operator.Persister {
 	logConfig := cfg.(*FileLogConfig)
+	if !logConfig.FingerprintOffsets {
+		return persister
+	}
+
+	// The input config was already decoded successfully when the receiver was created
+	inputCfg, _ := decodeFileInputConfig(logConfig)
+	return newFingerprintPersister(persister, inputCfg.Include, inputCfg.Exclude, int(inputCfg.FingerprintSize))
+}
+
+func decodeFileInputConfig(logConfig *FileLogConfig) (*file.InputConfig, error) {
 	yamlBytes, _ := yaml.Marshal(logConfig.Input)
 	inputCfg := file.NewInputConfig("file_input")
 	if err := yaml.Unmarshal(yamlBytes, &inputCfg); err != nil {
 		return nil, err
 	}
-	return &operator.Config{Builder: inputCfg}, nil
+	return inputCfg, nil
 }
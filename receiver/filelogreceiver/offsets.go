@@ -0,0 +1,260 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filelogreceiver
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/bmatcuk/doublestar/v3"
+	"github.com/open-telemetry/opentelemetry-log-collection/operator"
+	"go.uber.org/multierr"
+)
+
+const (
+	// knownFilesKey is the key under which the file_input operator persists
+	// the readers of the files it knows about.
+	knownFilesKey = "knownFiles"
+	// fingerprintKeyPrefix prefixes the keys of the offsets stored per fingerprint.
+	fingerprintKeyPrefix = "fingerprint."
+
+	defaultFingerprintSize = 1000
+)
+
+// knownFile is the persisted state of a file_input reader.
+type knownFile struct {
+	Fingerprint *struct {
+		FirstBytes []byte
+	}
+	Offset int64
+	Path   string
+}
+
+// fingerprintPersister stores every file offset persisted by the file_input
+// operator under a key derived from the file fingerprint, besides the regular
+// list of known files. On startup, the files currently matching the include
+// patterns are fingerprinted and their offsets looked up, so a collector
+// resumes files read by another collector sharing the same storage, e.g. after
+// being rescheduled to a different node with the same log mount.
+//
+// Only files whose fingerprint reached the full fingerprint size get a key:
+// shorter fingerprints change as the file grows and could not be looked up.
+type fingerprintPersister struct {
+	operator.Persister
+
+	include         []string
+	exclude         []string
+	fingerprintSize int
+
+	// keys written on the last sync, to delete the ones of forgotten files
+	lastKeys map[string]struct{}
+}
+
+func newFingerprintPersister(p operator.Persister, include, exclude []string, fingerprintSize int) *fingerprintPersister {
+	if fingerprintSize <= 0 {
+		fingerprintSize = defaultFingerprintSize
+	}
+	return &fingerprintPersister{
+		Persister:       p,
+		include:         include,
+		exclude:         exclude,
+		fingerprintSize: fingerprintSize,
+		lastKeys:        make(map[string]struct{}),
+	}
+}
+
+// Get returns the known files merged with the offsets stored for the
+// fingerprints of the files currently on disk.
+func (p *fingerprintPersister) Get(ctx context.Context, key string) ([]byte, error) {
+	encoded, err := p.Persister.Get(ctx, key)
+	if err != nil || !isKnownFilesKey(key) {
+		return encoded, err
+	}
+
+	files, err := decodeKnownFiles(encoded)
+	if err != nil {
+		return nil, err
+	}
+
+	found := false
+	for _, path := range p.matchingFiles() {
+		firstBytes, err := readFingerprint(path, p.fingerprintSize)
+		if err != nil || len(firstBytes) < p.fingerprintSize || containsFingerprint(files, firstBytes) {
+			continue
+		}
+
+		value, err := p.Persister.Get(ctx, fingerprintKey(key, firstBytes))
+		if err != nil {
+			return nil, err
+		}
+		if value == nil {
+			continue
+		}
+
+		var file knownFile
+		if err := json.Unmarshal(value, &file); err != nil {
+			return nil, fmt.Errorf("decoding offset of %s: %w", path, err)
+		}
+		files = append(files, file)
+		found = true
+	}
+
+	if !found {
+		return encoded, nil
+	}
+	return encodeKnownFiles(files)
+}
+
+// Set persists the known files, and the offset of each of them under its
+// fingerprint key.
+func (p *fingerprintPersister) Set(ctx context.Context, key string, value []byte) error {
+	if err := p.Persister.Set(ctx, key, value); err != nil || !isKnownFilesKey(key) {
+		return err
+	}
+
+	files, err := decodeKnownFiles(value)
+	if err != nil {
+		return err
+	}
+
+	keys := make(map[string]struct{}, len(files))
+	var errs error
+	for _, file := range files {
+		if file.Fingerprint == nil || len(file.Fingerprint.FirstBytes) < p.fingerprintSize {
+			continue
+		}
+
+		encoded, err := json.Marshal(file)
+		if err != nil {
+			errs = multierr.Append(errs, err)
+			continue
+		}
+
+		fpKey := fingerprintKey(key, file.Fingerprint.FirstBytes)
+		keys[fpKey] = struct{}{}
+		errs = multierr.Append(errs, p.Persister.Set(ctx, fpKey, encoded))
+	}
+
+	for fpKey := range p.lastKeys {
+		if _, ok := keys[fpKey]; !ok {
+			errs = multierr.Append(errs, p.Persister.Delete(ctx, fpKey))
+		}
+	}
+	p.lastKeys = keys
+
+	return errs
+}
+
+// matchingFiles returns the paths matching the include but not the exclude patterns.
+func (p *fingerprintPersister) matchingFiles() []string {
+	var paths []string
+	seen := make(map[string]struct{})
+	for _, include := range p.include {
+		matches, _ := doublestar.Glob(include)
+	INCLUDE:
+		for _, match := range matches {
+			for _, exclude := range p.exclude {
+				if itMatches, _ := doublestar.PathMatch(exclude, match); itMatches {
+					continue INCLUDE
+				}
+			}
+			if _, ok := seen[match]; ok {
+				continue
+			}
+			seen[match] = struct{}{}
+			paths = append(paths, match)
+		}
+	}
+	return paths
+}
+
+func isKnownFilesKey(key string) bool {
+	return key == knownFilesKey || strings.HasSuffix(key, "."+knownFilesKey)
+}
+
+// fingerprintKey returns the key of a fingerprint, in the same scope as knownFilesKey.
+func fingerprintKey(key string, firstBytes []byte) string {
+	sum := sha256.Sum256(firstBytes)
+	return strings.TrimSuffix(key, knownFilesKey) + fingerprintKeyPrefix + hex.EncodeToString(sum[:])
+}
+
+func readFingerprint(path string, size int) ([]byte, error) {
+	file, err := os.Open(path) // #nosec G304 -- path matched the configured include patterns
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	buf := make([]byte, size)
+	n, err := io.ReadFull(file, buf)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return nil, err
+	}
+	return buf[:n], nil
+}
+
+func containsFingerprint(files []knownFile, firstBytes []byte) bool {
+	for _, file := range files {
+		if file.Fingerprint != nil && bytes.HasPrefix(firstBytes, file.Fingerprint.FirstBytes) {
+			return true
+		}
+	}
+	return false
+}
+
+// decodeKnownFiles decodes the known files as encoded by the file_input
+// operator: their count followed by each of them, as JSON values.
+func decodeKnownFiles(encoded []byte) ([]knownFile, error) {
+	if encoded == nil {
+		return nil, nil
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(encoded))
+	var count int
+	if err := dec.Decode(&count); err != nil {
+		return nil, fmt.Errorf("decoding file count: %w", err)
+	}
+
+	files := make([]knownFile, 0, count)
+	for i := 0; i < count; i++ {
+		var file knownFile
+		if err := dec.Decode(&file); err != nil {
+			return nil, fmt.Errorf("decoding known file: %w", err)
+		}
+		files = append(files, file)
+	}
+	return files, nil
+}
+
+func encodeKnownFiles(files []knownFile) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	if err := enc.Encode(len(files)); err != nil {
+		return nil, err
+	}
+	for _, file := range files {
+		if err := enc.Encode(file); err != nil {
+			return nil, err
+		}
+	}
+	return buf.Bytes(), nil
+}
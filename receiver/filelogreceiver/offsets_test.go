@@ -0,0 +1,167 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filelogreceiver
+
+import (
+	"context"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/open-telemetry/opentelemetry-log-collection/operator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/stanza"
+)
+
+const testFingerprintSize = 16
+
+type mapPersister map[string][]byte
+
+func (m mapPersister) Get(_ context.Context, key string) ([]byte, error) { return m[key], nil }
+func (m mapPersister) Set(_ context.Context, key string, value []byte) error {
+	m[key] = value
+	return nil
+}
+func (m mapPersister) Delete(_ context.Context, key string) error {
+	delete(m, key)
+	return nil
+}
+
+func TestFingerprintPersisterSharesOffsets(t *testing.T) {
+	ctx := context.Background()
+	logsDir := newTempDir(t)
+	full := writeFile(t, logsDir, "full.log", strings.Repeat("a", 2*testFingerprintSize))
+	short := writeFile(t, logsDir, "short.log", "b")
+
+	storage := mapPersister{}
+	key := "$.file_input." + knownFilesKey
+
+	// First collector syncs the offsets of both files
+	first := newFingerprintPersister(operator.NewScopedPersister("scope", storage), []string{filepath.Join(logsDir, "*.log")}, nil, testFingerprintSize)
+	files := []knownFile{
+		newKnownFile(full, []byte(strings.Repeat("a", testFingerprintSize)), 20),
+		newKnownFile(short, []byte("b"), 1),
+	}
+	encoded, err := encodeKnownFiles(files)
+	require.NoError(t, err)
+	require.NoError(t, first.Set(ctx, key, encoded))
+
+	// Only the file with a full fingerprint has its own key
+	fpKeys := 0
+	for k := range storage {
+		if strings.HasPrefix(k, "scope.$.file_input."+fingerprintKeyPrefix) {
+			fpKeys++
+		}
+	}
+	assert.Equal(t, 1, fpKeys)
+
+	// A second collector, whose own known files were lost, finds the offset by fingerprint
+	delete(storage, "scope."+key)
+	second := newFingerprintPersister(operator.NewScopedPersister("scope", storage), []string{filepath.Join(logsDir, "*.log")}, nil, testFingerprintSize)
+	loaded, err := second.Get(ctx, key)
+	require.NoError(t, err)
+	decoded, err := decodeKnownFiles(loaded)
+	require.NoError(t, err)
+	require.Len(t, decoded, 1)
+	assert.Equal(t, full, decoded[0].Path)
+	assert.Equal(t, int64(20), decoded[0].Offset)
+
+	// Excluded files are not looked up
+	excluding := newFingerprintPersister(operator.NewScopedPersister("scope", storage), []string{filepath.Join(logsDir, "*.log")}, []string{full}, testFingerprintSize)
+	loaded, err = excluding.Get(ctx, key)
+	require.NoError(t, err)
+	assert.Nil(t, loaded)
+}
+
+func TestFingerprintPersisterMergesKnownFiles(t *testing.T) {
+	ctx := context.Background()
+	logsDir := newTempDir(t)
+	known := writeFile(t, logsDir, "known.log", strings.Repeat("k", testFingerprintSize))
+	shared := writeFile(t, logsDir, "shared.log", strings.Repeat("s", testFingerprintSize))
+
+	storage := mapPersister{}
+	p := newFingerprintPersister(storage, []string{filepath.Join(logsDir, "*.log")}, nil, testFingerprintSize)
+
+	sharedFile, err := encodeKnownFiles([]knownFile{newKnownFile(shared, []byte(strings.Repeat("s", testFingerprintSize)), 5)})
+	require.NoError(t, err)
+	require.NoError(t, p.Set(ctx, knownFilesKey, sharedFile))
+
+	knownFiles, err := encodeKnownFiles([]knownFile{newKnownFile(known, []byte(strings.Repeat("k", testFingerprintSize)), 7)})
+	require.NoError(t, err)
+	require.NoError(t, p.Set(ctx, knownFilesKey, knownFiles))
+
+	// The key of the shared file was deleted once it was no longer known
+	require.Len(t, storage, 2)
+
+	// Put it back as if written by another collector
+	otherCollector := newFingerprintPersister(mapPersister{}, nil, nil, testFingerprintSize)
+	require.NoError(t, otherCollector.Set(ctx, knownFilesKey, sharedFile))
+	for k, v := range otherCollector.Persister.(mapPersister) {
+		if k != knownFilesKey {
+			storage[k] = v
+		}
+	}
+
+	loaded, err := p.Get(ctx, knownFilesKey)
+	require.NoError(t, err)
+	decoded, err := decodeKnownFiles(loaded)
+	require.NoError(t, err)
+	require.Len(t, decoded, 2)
+	assert.Equal(t, known, decoded[0].Path)
+	assert.Equal(t, shared, decoded[1].Path)
+	assert.Equal(t, int64(5), decoded[1].Offset)
+}
+
+func TestFingerprintPersisterPassesThroughOtherKeys(t *testing.T) {
+	ctx := context.Background()
+	storage := mapPersister{}
+	p := newFingerprintPersister(storage, nil, nil, 0)
+	assert.Equal(t, defaultFingerprintSize, p.fingerprintSize)
+
+	require.NoError(t, p.Set(ctx, "other", []byte("value")))
+	value, err := p.Get(ctx, "other")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("value"), value)
+	require.Len(t, storage, 1)
+}
+
+func TestWrapPersister(t *testing.T) {
+	var _ stanza.PersisterWrapper = ReceiverType{}
+
+	cfg := testdataConfigYamlAsMap()
+	storage := mapPersister{}
+	assert.Equal(t, storage, ReceiverType{}.WrapPersister(cfg, storage))
+
+	cfg.FingerprintOffsets = true
+	p, ok := ReceiverType{}.WrapPersister(cfg, storage).(*fingerprintPersister)
+	require.True(t, ok)
+	assert.Equal(t, []string{"testdata/simple.log"}, p.include)
+	assert.Equal(t, defaultFingerprintSize, p.fingerprintSize)
+}
+
+func newKnownFile(path string, firstBytes []byte, offset int64) knownFile {
+	file := knownFile{Path: path, Offset: offset}
+	file.Fingerprint = &struct{ FirstBytes []byte }{FirstBytes: firstBytes}
+	return file
+}
+
+func writeFile(t *testing.T, dir, name, content string) string {
+	path := filepath.Join(dir, name)
+	require.NoError(t, ioutil.WriteFile(path, []byte(content), 0600))
+	return path
+}
@@ -33,6 +33,7 @@ type Collector struct {
 	nextConsumer consumer.Logs
 	eventCh      <-chan Event
 	logger       *zap.Logger
+	done         chan struct{}
 }
 
 func newCollector(eventCh <-chan Event, next consumer.Logs, logger *zap.Logger) *Collector {
@@ -40,6 +41,7 @@ func newCollector(eventCh <-chan Event, next consumer.Logs, logger *zap.Logger)
 		nextConsumer: next,
 		eventCh:      eventCh,
 		logger:       logger,
+		done:         make(chan struct{}),
 	}
 }
 
@@ -47,12 +49,23 @@ func (c *Collector) Start(ctx context.Context) {
 	go c.processEvents(ctx)
 }
 
+// Done returns a channel that is closed once the collector stopped, either
+// because its context got cancelled or because it has forwarded all events
+// of the closed event channel.
+func (c *Collector) Done() <-chan struct{} {
+	return c.done
+}
+
 func (c *Collector) processEvents(ctx context.Context) {
+	defer close(c.done)
 	for {
 		select {
 		case <-ctx.Done():
 			return
-		case e := <-c.eventCh:
+		case e, ok := <-c.eventCh:
+			if !ok {
+				return
+			}
 			buffered := []Event{e}
 			// Pull out anything waiting on the eventCh to get better
 			// efficiency on LogResource allocations.
@@ -67,7 +80,10 @@ func (c *Collector) processEvents(ctx context.Context) {
 func fillBufferUntilChanEmpty(eventCh <-chan Event, buf []Event) []Event {
 	for {
 		select {
-		case e2 := <-eventCh:
+		case e2, ok := <-eventCh:
+			if !ok {
+				return buf
+			}
 			buf = append(buf, e2)
 		default:
 			return buf
@@ -14,7 +14,11 @@
 
 package fluentforwardreceiver
 
-import "go.opentelemetry.io/collector/config"
+import (
+	"time"
+
+	"go.opentelemetry.io/collector/config"
+)
 
 // Config defines configuration for the SignalFx receiver.
 type Config struct {
@@ -24,4 +28,9 @@ type Config struct {
 	// of the form `<ip addr>:<port>` (TCP) or `unix://<socket_path>` (Unix
 	// domain socket).
 	ListenAddress string `mapstructure:"endpoint"`
+
+	// How long to wait on shutdown for the events that were already received
+	// to be accepted by the next consumer. No new connections or events are
+	// accepted once the shutdown started.
+	ShutdownDrainTimeout time.Duration `mapstructure:"shutdown_drain_timeout"`
 }
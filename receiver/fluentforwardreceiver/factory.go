@@ -16,6 +16,7 @@ package fluentforwardreceiver
 
 import (
 	"context"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
@@ -26,6 +27,8 @@ import (
 const (
 	// The value of "type" key in configuration.
 	typeStr = "fluentforward"
+
+	defaultShutdownDrainTimeout = 5 * time.Second
 )
 
 // NewFactory return a new component.ReceiverFactory for fluentd forwarder.
@@ -38,7 +41,8 @@ func NewFactory() component.ReceiverFactory {
 
 func createDefaultConfig() config.Receiver {
 	return &Config{
-		ReceiverSettings: config.NewReceiverSettings(config.NewID(typeStr)),
+		ReceiverSettings:     config.NewReceiverSettings(config.NewID(typeStr)),
+		ShutdownDrainTimeout: defaultShutdownDrainTimeout,
 	}
 }
 
@@ -18,6 +18,7 @@ import (
 	"context"
 	"net"
 	"strings"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
@@ -87,7 +88,28 @@ func (r *fluentReceiver) Start(ctx context.Context, _ component.Host) error {
 	return nil
 }
 
-func (r *fluentReceiver) Shutdown(context.Context) error {
+// Shutdown stops accepting new connections and events, then waits at most
+// ShutdownDrainTimeout for the events already received to be accepted by the
+// next consumer.
+func (r *fluentReceiver) Shutdown(ctx context.Context) error {
+	drained := make(chan struct{})
+	go func() {
+		r.server.Stop()
+		<-r.collector.Done()
+		close(drained)
+	}()
+
+	timer := time.NewTimer(r.conf.ShutdownDrainTimeout)
+	defer timer.Stop()
+	select {
+	case <-drained:
+	case <-timer.C:
+		r.logger.Warn("Timed out draining events on shutdown",
+			zap.Duration("shutdown_drain_timeout", r.conf.ShutdownDrainTimeout))
+	case <-ctx.Done():
+		r.logger.Warn("Shutdown context done before draining events", zap.Error(ctx.Err()))
+	}
+
 	r.cancel()
 	return nil
 }
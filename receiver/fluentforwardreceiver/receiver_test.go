@@ -424,3 +424,93 @@ func TestHighVolume(t *testing.T) {
 		return total == totalRoutines*totalMessagesPerRoutine
 	}, 10*time.Second, 100*time.Millisecond)
 }
+
+// gatedLogsSink blocks every ConsumeLogs call until release is closed or the
+// context passed to it is cancelled.
+type gatedLogsSink struct {
+	consumertest.LogsSink
+	release chan struct{}
+}
+
+func (g *gatedLogsSink) ConsumeLogs(ctx context.Context, ld pdata.Logs) error {
+	select {
+	case <-g.release:
+		return g.LogsSink.ConsumeLogs(ctx, ld)
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func makeChunkedEvent(tag string, chunk string) []byte {
+	var b []byte
+
+	b = msgp.AppendArrayHeader(b, 4)
+	b = msgp.AppendString(b, tag)
+	b = msgp.AppendInt(b, 5000)
+	b = msgp.AppendMapHeader(b, 1)
+	b = msgp.AppendString(b, "a")
+	b = msgp.AppendFloat64(b, 5.0)
+	b = msgp.AppendMapStrStr(b, map[string]string{"chunk": chunk})
+	return b
+}
+
+func startGatedReceiver(t *testing.T, drainTimeout time.Duration) (*fluentReceiver, *gatedLogsSink, net.Conn) {
+	next := &gatedLogsSink{release: make(chan struct{})}
+	conf := &Config{
+		ListenAddress:        "127.0.0.1:0",
+		ShutdownDrainTimeout: drainTimeout,
+	}
+
+	receiver, err := newFluentReceiver(zap.NewNop(), conf, next)
+	require.NoError(t, err)
+	require.NoError(t, receiver.Start(context.Background(), nil))
+	r := receiver.(*fluentReceiver)
+
+	conn, err := net.Dial("tcp", r.listener.Addr().String())
+	require.NoError(t, err)
+
+	// Wait for the acknowledgments so that both events have been handed over
+	// to the collector before shutting down.
+	for _, chunk := range []string{"chunk-1", "chunk-2"} {
+		_, err = conn.Write(makeChunkedEvent("my-tag", chunk))
+		require.NoError(t, err)
+
+		require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+		resp := map[string]interface{}{}
+		require.NoError(t, msgp.NewReader(conn).ReadMapStrIntf(resp))
+		require.Equal(t, chunk, resp["ack"])
+	}
+
+	return r, next, conn
+}
+
+func TestShutdownDrainsReceivedEvents(t *testing.T) {
+	r, next, conn := startGatedReceiver(t, 5*time.Second)
+	defer conn.Close()
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		close(next.release)
+	}()
+	require.NoError(t, r.Shutdown(context.Background()))
+	require.Equal(t, 2, next.LogRecordsCount())
+
+	// The open connection gets closed by the shutdown.
+	waitForConnectionClose(t, conn)
+}
+
+func TestShutdownDrainTimeout(t *testing.T) {
+	r, next, conn := startGatedReceiver(t, 100*time.Millisecond)
+	defer conn.Close()
+
+	start := time.Now()
+	require.NoError(t, r.Shutdown(context.Background()))
+	require.Less(t, time.Since(start), 5*time.Second)
+	require.Equal(t, 0, next.LogRecordsCount())
+
+	select {
+	case <-r.collector.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatal("collector should stop once the drain timed out")
+	}
+}
@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"io"
 	"net"
+	"sync"
 	"time"
 
 	"github.com/tinylib/msgp/msgp"
@@ -37,28 +38,90 @@ const readBufferSize = 10 * 1024
 type server struct {
 	outCh  chan<- Event
 	logger *zap.Logger
+
+	listener net.Listener
+	// wg tracks the accept loop and the connection handlers so that outCh
+	// is only closed once nothing can send onto it anymore.
+	wg      sync.WaitGroup
+	mu      sync.Mutex
+	conns   map[net.Conn]struct{}
+	stopped bool
 }
 
 func newServer(outCh chan<- Event, logger *zap.Logger) *server {
 	return &server{
 		outCh:  outCh,
 		logger: logger,
+		conns:  map[net.Conn]struct{}{},
 	}
 }
 
 func (s *server) Start(ctx context.Context, listener net.Listener) {
+	s.listener = listener
+	s.wg.Add(1)
 	go func() {
+		defer s.wg.Done()
 		s.handleConnections(ctx, listener)
-		if ctx.Err() == nil {
+		if ctx.Err() == nil && !s.isStopped() {
 			panic("logic error in receiver, connections should always be listened for while receiver is running")
 		}
 	}()
 }
 
+// Stop stops accepting new connections and closes the open ones, then waits
+// for their handlers to return before closing the out channel. Events that
+// were already read are left on the out channel for the collector to drain.
+func (s *server) Stop() {
+	s.mu.Lock()
+	if s.stopped {
+		s.mu.Unlock()
+		return
+	}
+	s.stopped = true
+	for conn := range s.conns {
+		conn.Close()
+	}
+	s.mu.Unlock()
+
+	if s.listener != nil {
+		s.listener.Close()
+	}
+	s.wg.Wait()
+	close(s.outCh)
+}
+
+func (s *server) isStopped() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.stopped
+}
+
+// trackConn registers an accepted connection so that it gets closed on
+// Stop(), it returns false if the server has already been stopped.
+func (s *server) trackConn(conn net.Conn) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.stopped {
+		return false
+	}
+	s.conns[conn] = struct{}{}
+	s.wg.Add(1)
+	return true
+}
+
+func (s *server) untrackConn(conn net.Conn) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.conns, conn)
+}
+
 func (s *server) handleConnections(ctx context.Context, listener net.Listener) {
 	for {
 		conn, err := listener.Accept()
-		if ctx.Err() != nil {
+		if ctx.Err() != nil || s.isStopped() {
+			if conn != nil {
+				conn.Close()
+			}
 			return
 		}
 		// If there is an error and the receiver isn't shutdown, we need to
@@ -72,7 +135,15 @@ func (s *server) handleConnections(ctx context.Context, listener net.Listener) {
 
 		s.logger.Debug("Got connection", zap.String("remoteAddr", conn.RemoteAddr().String()))
 
+		if !s.trackConn(conn) {
+			stats.Record(ctx, observ.ConnectionsClosed.M(1))
+			conn.Close()
+			return
+		}
+
 		go func() {
+			defer s.wg.Done()
+			defer s.untrackConn(conn)
 			defer stats.Record(ctx, observ.ConnectionsClosed.M(1))
 
 			err := s.handleConn(ctx, conn)
@@ -121,7 +192,11 @@ func (s *server) handleConn(ctx context.Context, conn net.Conn) error {
 
 		stats.Record(ctx, observ.EventsParsed.M(1))
 
-		s.outCh <- event
+		select {
+		case s.outCh <- event:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
 
 		// We must acknowledge the 'chunk' option if given. We could do this in
 		// another goroutine if it is too much of a bottleneck to reading
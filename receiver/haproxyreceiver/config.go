@@ -0,0 +1,45 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package haproxyreceiver
+
+import (
+	"fmt"
+	"net/url"
+
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/receiver/scraperhelper"
+)
+
+// Config defines the configuration for the HAProxy receiver.
+type Config struct {
+	scraperhelper.ScraperControllerSettings `mapstructure:",squash"`
+	// HTTPClientSettings configures the client of the HTTP stats page. Its endpoint is either
+	// the stats socket, as unix:///path/to/socket or tcp://host:port, or the URL of the CSV
+	// export of the stats page, e.g. http://localhost:8404/stats;csv.
+	confighttp.HTTPClientSettings `mapstructure:",squash"`
+}
+
+// Validate checks that the receiver configuration is valid.
+func (cfg *Config) Validate() error {
+	u, err := url.Parse(cfg.Endpoint)
+	if err != nil {
+		return fmt.Errorf("invalid endpoint %q: %w", cfg.Endpoint, err)
+	}
+	switch u.Scheme {
+	case "unix", "tcp", "http", "https":
+		return nil
+	}
+	return fmt.Errorf("invalid endpoint %q: the scheme must be one of unix, tcp, http or https", cfg.Endpoint)
+}
@@ -0,0 +1,83 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package haproxyreceiver
+
+import (
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/config/configtest"
+	"go.opentelemetry.io/collector/receiver/scraperhelper"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	require.NoError(t, err)
+
+	factory := NewFactory()
+	factories.Receivers[typeStr] = factory
+
+	cfg, err := configtest.LoadConfigAndValidate(path.Join(".", "testdata", "config.yaml"), factories)
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	assert.Equal(t, factory.CreateDefaultConfig(), cfg.Receivers[config.NewID(typeStr)])
+	assert.Equal(t,
+		&Config{
+			ScraperControllerSettings: scraperhelper.ScraperControllerSettings{
+				ReceiverSettings:   config.NewReceiverSettings(config.NewIDWithName(typeStr, "http")),
+				CollectionInterval: 30 * time.Second,
+			},
+			HTTPClientSettings: confighttp.HTTPClientSettings{
+				Endpoint: "http://localhost:8404/stats;csv",
+				Timeout:  10 * time.Second,
+				Headers:  map[string]string{"authorization": "Basic YWRtaW46YWRtaW4="},
+			},
+		},
+		cfg.Receivers[config.NewIDWithName(typeStr, "http")],
+	)
+}
+
+func TestValidateConfig(t *testing.T) {
+	cases := []struct {
+		endpoint string
+		expected string
+	}{
+		{endpoint: "unix:///var/run/haproxy.sock"},
+		{endpoint: "tcp://localhost:9999"},
+		{endpoint: "https://localhost:8404/stats;csv"},
+		{endpoint: "localhost:9999", expected: `invalid endpoint "localhost:9999": the scheme must be one of unix, tcp, http or https`},
+		{endpoint: "http://[::1", expected: `invalid endpoint "http://[::1": parse "http://[::1": missing ']' in host`},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.endpoint, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.Endpoint = tc.endpoint
+			err := cfg.Validate()
+			if tc.expected == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,234 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package haproxyreceiver
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/consumer/simple"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/haproxyreceiver/internal/metadata"
+)
+
+// The values of the type field of the stats.
+const (
+	statsTypeFrontend = "0"
+	statsTypeBackend  = "1"
+	statsTypeServer   = "2"
+)
+
+var statsTypes = map[string]string{
+	statsTypeFrontend: metadata.LabelType.Frontend,
+	statsTypeBackend:  metadata.LabelType.Backend,
+	statsTypeServer:   metadata.LabelType.Server,
+}
+
+type haproxyScraper struct {
+	httpClient *http.Client
+
+	logger *zap.Logger
+	cfg    *Config
+}
+
+func newHAProxyScraper(
+	logger *zap.Logger,
+	cfg *Config,
+) *haproxyScraper {
+	return &haproxyScraper{
+		logger: logger,
+		cfg:    cfg,
+	}
+}
+
+func (r *haproxyScraper) start(_ context.Context, host component.Host) error {
+	if !strings.HasPrefix(r.cfg.Endpoint, "http") {
+		return nil
+	}
+	httpClient, err := r.cfg.ToClient(host.GetExtensions())
+	if err != nil {
+		return err
+	}
+	r.httpClient = httpClient
+	return nil
+}
+
+func (r *haproxyScraper) scrape(ctx context.Context) (pdata.ResourceMetricsSlice, error) {
+	data, err := r.readStats(ctx)
+	if err != nil {
+		r.logger.Error("Failed to fetch HAProxy stats", zap.Error(err))
+		return pdata.ResourceMetricsSlice{}, err
+	}
+	records, err := parseStats(data)
+	if err != nil {
+		return pdata.ResourceMetricsSlice{}, err
+	}
+
+	metrics := simple.Metrics{
+		Metrics:                    pdata.NewMetrics(),
+		Timestamp:                  time.Now(),
+		MetricFactoriesByName:      metadata.M.FactoriesByName(),
+		InstrumentationLibraryName: "otelcol/haproxy",
+	}
+	for _, record := range records {
+		statsType, ok := statsTypes[record["type"]]
+		if !ok {
+			// Listeners are not reported.
+			continue
+		}
+		addRecord(metrics.WithLabels(map[string]string{
+			metadata.L.Proxy:  record["pxname"],
+			metadata.L.Server: record["svname"],
+			metadata.L.Type:   statsType,
+		}), record)
+	}
+	return metrics.Metrics.ResourceMetrics(), nil
+}
+
+// readStats returns the CSV stats, read from the stats socket or the HTTP stats page.
+func (r *haproxyScraper) readStats(ctx context.Context) ([]byte, error) {
+	u, err := url.Parse(r.cfg.Endpoint)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme == "http" || u.Scheme == "https" {
+		return r.readStatsPage(ctx)
+	}
+
+	address := u.Host
+	if u.Scheme == "unix" {
+		address = u.Path
+	}
+	dialer := net.Dialer{Timeout: r.cfg.Timeout}
+	conn, err := dialer.DialContext(ctx, u.Scheme, address)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	if r.cfg.Timeout > 0 {
+		if err = conn.SetDeadline(time.Now().Add(r.cfg.Timeout)); err != nil {
+			return nil, err
+		}
+	}
+	if _, err = io.WriteString(conn, "show stat\n"); err != nil {
+		return nil, err
+	}
+	return ioutil.ReadAll(conn)
+}
+
+func (r *haproxyScraper) readStatsPage(ctx context.Context) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, r.cfg.Endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("expected 200 response, got %d", resp.StatusCode)
+	}
+	return ioutil.ReadAll(resp.Body)
+}
+
+// parseStats parses the CSV stats, the first line being the "# "-prefixed header.
+func parseStats(data []byte) ([]map[string]string, error) {
+	data = bytes.TrimPrefix(bytes.TrimSpace(data), []byte("# "))
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse stats header: %w", err)
+	}
+	var records []map[string]string
+	for {
+		fields, err := reader.Read()
+		if err == io.EOF {
+			return records, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse stats: %w", err)
+		}
+		record := make(map[string]string, len(header))
+		for i, name := range header {
+			if i < len(fields) {
+				record[name] = fields[i]
+			}
+		}
+		records = append(records, record)
+	}
+}
+
+// addRecord adds the datapoints of the fields set in the record, the fields not applying to
+// the type of the record being empty.
+func addRecord(metrics *simple.Metrics, record map[string]string) {
+	addInt := func(add func(name string, value int64) *simple.Metrics, name, field string) {
+		if v, err := strconv.ParseInt(record[field], 10, 64); err == nil {
+			add(name, v)
+		}
+	}
+	addGauge := func(m *simple.Metrics, name, field string) { addInt(m.AddGaugeDataPoint, name, field) }
+	addSum := func(m *simple.Metrics, name, field string) { addInt(m.AddSumDataPoint, name, field) }
+
+	addGauge(metrics, metadata.M.HaproxySessionsCurrent.Name(), "scur")
+	addSum(metrics, metadata.M.HaproxySessionsTotal.Name(), "stot")
+	addGauge(metrics, metadata.M.HaproxyQueueCurrent.Name(), "qcur")
+	addSum(metrics.WithLabels(map[string]string{metadata.L.Direction: metadata.LabelDirection.Received}), metadata.M.HaproxyBytes.Name(), "bin")
+	addSum(metrics.WithLabels(map[string]string{metadata.L.Direction: metadata.LabelDirection.Sent}), metadata.M.HaproxyBytes.Name(), "bout")
+	addSum(metrics, metadata.M.HaproxyRequestsTotal.Name(), "req_tot")
+	addSum(metrics, metadata.M.HaproxyRequestsDenied.Name(), "dreq")
+	addSum(metrics, metadata.M.HaproxyRequestsErrors.Name(), "ereq")
+	addSum(metrics, metadata.M.HaproxyConnectionsErrors.Name(), "econ")
+	addSum(metrics, metadata.M.HaproxyResponsesErrors.Name(), "eresp")
+	for _, statusRange := range []string{"1xx", "2xx", "3xx", "4xx", "5xx", "other"} {
+		addSum(metrics.WithLabels(map[string]string{metadata.L.StatusRange: statusRange}), metadata.M.HaproxyResponses.Name(), "hrsp_"+statusRange)
+	}
+	if record["type"] != statsTypeFrontend {
+		for phase, field := range map[string]string{
+			metadata.LabelPhase.Queue:    "qtime",
+			metadata.LabelPhase.Connect:  "ctime",
+			metadata.LabelPhase.Response: "rtime",
+			metadata.LabelPhase.Total:    "ttime",
+		} {
+			addGauge(metrics.WithLabels(map[string]string{metadata.L.Phase: phase}), metadata.M.HaproxyLatency.Name(), field)
+		}
+	}
+
+	// The status is e.g. "UP", "UP 1/3" while going down, "DOWN", "MAINT" or "no check".
+	if status := record["status"]; record["type"] != statsTypeFrontend && status != "" && status != "no check" {
+		up := int64(0)
+		if strings.HasPrefix(status, "UP") {
+			up = 1
+		}
+		metrics.AddGaugeDataPoint(metadata.M.HaproxyUp.Name(), up)
+	}
+}
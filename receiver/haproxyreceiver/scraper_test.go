@@ -0,0 +1,196 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package haproxyreceiver
+
+import (
+	"bufio"
+	"context"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.uber.org/zap"
+)
+
+func TestScraperHTTP(t *testing.T) {
+	haproxyMock := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.URL.Path == "/stats;csv" {
+			http.ServeFile(rw, req, filepath.Join("testdata", "stats.csv"))
+			return
+		}
+		rw.WriteHeader(404)
+	}))
+	defer haproxyMock.Close()
+
+	sc := newHAProxyScraper(zap.NewNop(), &Config{
+		HTTPClientSettings: confighttp.HTTPClientSettings{
+			Endpoint: haproxyMock.URL + "/stats;csv",
+		},
+	})
+	require.NoError(t, sc.start(context.Background(), componenttest.NewNopHost()))
+	rms, err := sc.scrape(context.Background())
+	require.NoError(t, err)
+
+	values := datapointValues(t, rms)
+
+	frontend := "proxy:http-in server:FRONTEND type:frontend"
+	assert.Equal(t, int64(3), values["haproxy.sessions.current "+frontend])
+	assert.Equal(t, int64(1200), values["haproxy.sessions.total "+frontend])
+	assert.Equal(t, int64(524288), values["haproxy.bytes direction:received "+frontend])
+	assert.Equal(t, int64(2097152), values["haproxy.bytes direction:sent "+frontend])
+	assert.Equal(t, int64(1200), values["haproxy.requests.total "+frontend])
+	assert.Equal(t, int64(4), values["haproxy.requests.denied "+frontend])
+	assert.Equal(t, int64(7), values["haproxy.requests.errors "+frontend])
+	assert.Equal(t, int64(1150), values["haproxy.responses proxy:http-in server:FRONTEND status_range:2xx type:frontend"])
+	assert.NotContains(t, values, "haproxy.queue.current "+frontend)
+	assert.NotContains(t, values, "haproxy.up "+frontend)
+	assert.NotContains(t, values, "haproxy.latency phase:total "+frontend)
+
+	web1 := "proxy:servers server:web1 type:server"
+	assert.Equal(t, int64(2), values["haproxy.sessions.current "+web1])
+	assert.Equal(t, int64(0), values["haproxy.queue.current "+web1])
+	assert.Equal(t, int64(1), values["haproxy.connections.errors "+web1])
+	assert.Equal(t, int64(2), values["haproxy.responses.errors "+web1])
+	assert.Equal(t, int64(8), values["haproxy.responses proxy:servers server:web1 status_range:4xx type:server"])
+	assert.Equal(t, int64(1), values["haproxy.latency phase:connect "+web1])
+	assert.Equal(t, int64(12), values["haproxy.latency phase:response "+web1])
+	assert.Equal(t, int64(15), values["haproxy.latency phase:total "+web1])
+	assert.Equal(t, int64(1), values["haproxy.up "+web1])
+	assert.NotContains(t, values, "haproxy.requests.total "+web1)
+
+	web2 := "proxy:servers server:web2 type:server"
+	assert.Equal(t, int64(0), values["haproxy.up "+web2])
+
+	backend := "proxy:servers server:BACKEND type:backend"
+	assert.Equal(t, int64(1), values["haproxy.queue.current "+backend])
+	assert.Equal(t, int64(1), values["haproxy.up "+backend])
+	assert.Equal(t, int64(18), values["haproxy.latency phase:total "+backend])
+
+	// Listeners are not reported.
+	for key := range values {
+		assert.NotContains(t, key, "sock-1")
+	}
+}
+
+func TestScraperSocket(t *testing.T) {
+	dir, err := ioutil.TempDir("", "haproxy")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	socket := filepath.Join(dir, "haproxy.sock")
+
+	l, err := net.Listen("unix", socket)
+	require.NoError(t, err)
+	defer l.Close()
+	go func() {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		cmd, err := bufio.NewReader(conn).ReadString('\n')
+		if err != nil || cmd != "show stat\n" {
+			return
+		}
+		stats, _ := ioutil.ReadFile(filepath.Join("testdata", "stats.csv"))
+		_, _ = conn.Write(stats)
+	}()
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = "unix://" + socket
+	sc := newHAProxyScraper(zap.NewNop(), cfg)
+	require.NoError(t, sc.start(context.Background(), componenttest.NewNopHost()))
+	rms, err := sc.scrape(context.Background())
+	require.NoError(t, err)
+
+	values := datapointValues(t, rms)
+	assert.Equal(t, int64(700), values["haproxy.sessions.total proxy:servers server:web1 type:server"])
+}
+
+func TestScraperError(t *testing.T) {
+	haproxyMock := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(404)
+	}))
+	defer haproxyMock.Close()
+
+	sc := newHAProxyScraper(zap.NewNop(), &Config{
+		HTTPClientSettings: confighttp.HTTPClientSettings{
+			Endpoint: haproxyMock.URL + "/stats;csv",
+		},
+	})
+	require.NoError(t, sc.start(context.Background(), componenttest.NewNopHost()))
+	_, err := sc.scrape(context.Background())
+	require.EqualError(t, err, "expected 200 response, got 404")
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = "unix:///non/existent.sock"
+	sc = newHAProxyScraper(zap.NewNop(), cfg)
+	require.NoError(t, sc.start(context.Background(), componenttest.NewNopHost()))
+	_, err = sc.scrape(context.Background())
+	require.Error(t, err)
+}
+
+func TestParseStats(t *testing.T) {
+	records, err := parseStats([]byte("# pxname,svname,scur,type\nhttp-in,FRONTEND,3,0\nservers,web1,2\n"))
+	require.NoError(t, err)
+	assert.Equal(t, []map[string]string{
+		{"pxname": "http-in", "svname": "FRONTEND", "scur": "3", "type": "0"},
+		{"pxname": "servers", "svname": "web1", "scur": "2"},
+	}, records)
+
+	_, err = parseStats([]byte(""))
+	assert.Error(t, err)
+}
+
+// datapointValues returns the value of each datapoint, keyed by the metric name followed by
+// the sorted labels of the datapoint.
+func datapointValues(t *testing.T, rms pdata.ResourceMetricsSlice) map[string]int64 {
+	require.Equal(t, 1, rms.Len())
+	ms := rms.At(0).InstrumentationLibraryMetrics().At(0).Metrics()
+
+	values := map[string]int64{}
+	for i := 0; i < ms.Len(); i++ {
+		m := ms.At(i)
+		var dps pdata.IntDataPointSlice
+		switch m.DataType() {
+		case pdata.MetricDataTypeIntGauge:
+			dps = m.IntGauge().DataPoints()
+		case pdata.MetricDataTypeIntSum:
+			dps = m.IntSum().DataPoints()
+		default:
+			t.Fatalf("unexpected data type %v of %s", m.DataType(), m.Name())
+		}
+		for j := 0; j < dps.Len(); j++ {
+			var labels []string
+			dps.At(j).LabelsMap().Range(func(k, v string) bool {
+				labels = append(labels, k+":"+v)
+				return true
+			})
+			sort.Strings(labels)
+			values[strings.Join(append([]string{m.Name()}, labels...), " ")] = dps.At(j).Value()
+		}
+	}
+	return values
+}
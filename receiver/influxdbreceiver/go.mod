@@ -7,6 +7,10 @@ require (
 	github.com/influxdata/influxdb-observability/common v0.0.0-20210503044220-4051d4b8738f
 	github.com/influxdata/influxdb-observability/influx2otel v0.0.0-20210503044220-4051d4b8738f
 	github.com/influxdata/line-protocol/v2 v2.0.0-20210428091617-0567a5134992
+	github.com/open-telemetry/opentelemetry-collector-contrib/internal/admissioncontrol v0.0.0-00010101000000-000000000000
+	github.com/stretchr/testify v1.7.0
 	go.opentelemetry.io/collector v0.27.1-0.20210603182316-5369d7e9e83e
 	go.uber.org/zap v1.17.0
 )
+
+replace github.com/open-telemetry/opentelemetry-collector-contrib/internal/admissioncontrol => ../../internal/admissioncontrol
@@ -30,11 +30,14 @@ import (
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/consumer/pdata"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/admissioncontrol"
 )
 
 type metricsReceiver struct {
 	nextConsumer       consumer.Metrics
 	httpServerSettings *confighttp.HTTPServerSettings
+	schema             common.MetricsSchema
 	converter          *influx2otel.LineProtocolToOtelMetrics
 
 	server *http.Server
@@ -60,6 +63,7 @@ func newMetricsReceiver(config *Config, influxLogger common.Logger, nextConsumer
 	receiver := &metricsReceiver{
 		nextConsumer:       nextConsumer,
 		httpServerSettings: &config.HTTPServerSettings,
+		schema:             schema,
 		converter:          converter,
 		logger:             influxLogger,
 	}
@@ -120,9 +124,9 @@ func (r *metricsReceiver) handleWrite(w http.ResponseWriter, req *http.Request)
 		}
 	}
 
-	batch := r.converter.NewBatch()
 	lpDecoder := lineprotocol.NewDecoder(req.Body)
 
+	var points []point
 	var k, vTag []byte
 	var vField lineprotocol.Value
 	for line := 0; lpDecoder.Next(); line++ {
@@ -145,7 +149,7 @@ func (r *metricsReceiver) handleWrite(w http.ResponseWriter, req *http.Request)
 
 		fields := make(map[string]interface{})
 		for k, vField, err = lpDecoder.NextField(); k != nil && err == nil; k, vField, err = lpDecoder.NextField() {
-			fields[string(k)] = vField.Interface()
+			fields[string(k)] = convertFieldValue(vField.Interface())
 		}
 		if err != nil {
 			w.WriteHeader(http.StatusBadRequest)
@@ -166,10 +170,22 @@ func (r *metricsReceiver) handleWrite(w http.ResponseWriter, req *http.Request)
 			return
 		}
 
-		err = batch.AddPoint(string(measurement), tags, fields, ts, common.InfluxMetricValueTypeUntyped)
+		points = append(points, point{
+			measurement: string(measurement),
+			tags:        tags,
+			fields:      fields,
+			ts:          ts,
+		})
+	}
+
+	batch := r.converter.NewBatch()
+	vTypes := inferValueTypes(r.schema, points)
+	for i, p := range points {
+		err := batch.AddPoint(p.measurement, p.tags, p.fields, p.ts, vTypes[i])
 		if err != nil {
 			w.WriteHeader(http.StatusBadRequest)
 			_, _ = fmt.Fprintf(w, "failed to append to the batch")
+			r.logger.Debug("failed to append point to the batch", "error", err)
 			return
 		}
 	}
@@ -187,7 +203,9 @@ func (r *metricsReceiver) handleWrite(w http.ResponseWriter, req *http.Request)
 		return
 	}
 	if err = r.nextConsumer.ConsumeMetrics(req.Context(), md); err != nil {
-		if consumererror.IsPermanent(err) {
+		if admissioncontrol.SetRetryAfter(w.Header(), err) {
+			w.WriteHeader(http.StatusTooManyRequests)
+		} else if consumererror.IsPermanent(err) {
 			w.WriteHeader(http.StatusBadRequest)
 		} else {
 			w.WriteHeader(http.StatusInternalServerError)
@@ -0,0 +1,95 @@
+// Copyright 2021, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package influxdbreceiver
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/testutil"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/admissioncontrol"
+)
+
+func TestWriteTypeHints(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = testutil.GetAvailableLocalAddress(t)
+	sink := new(consumertest.MetricsSink)
+	receiver, err := newMetricsReceiver(cfg, newZapInfluxLogger(zap.NewNop()), sink)
+	require.NoError(t, err)
+	require.NoError(t, receiver.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() {
+		require.NoError(t, receiver.Shutdown(context.Background()))
+	}()
+
+	body := strings.Join([]string{
+		"cpu_temp,foo=bar gauge=87.332 1622505600000000000",
+		"http_requests_total,method=post,code=200 counter=1027i 1622505600000000000",
+		"http_request_duration_seconds 0.05=24054,0.1=33444,+Inf=144320,sum=53423,count=144320 1622505600000000000",
+		"rpc_duration_seconds 0.01=3102,0.99=76656,sum=1.7560473e+07,count=2693 1622505600000000000",
+	}, "\n")
+	res, err := http.Post(fmt.Sprintf("http://%s/api/v2/write", cfg.Endpoint), "text/plain", strings.NewReader(body))
+	require.NoError(t, err)
+	require.NoError(t, res.Body.Close())
+	require.Equal(t, http.StatusAccepted, res.StatusCode)
+
+	require.Len(t, sink.AllMetrics(), 1)
+	dataTypes := make(map[string]pdata.MetricDataType)
+	rms := sink.AllMetrics()[0].ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		ilms := rms.At(i).InstrumentationLibraryMetrics()
+		for j := 0; j < ilms.Len(); j++ {
+			metrics := ilms.At(j).Metrics()
+			for k := 0; k < metrics.Len(); k++ {
+				dataTypes[metrics.At(k).Name()] = metrics.At(k).DataType()
+			}
+		}
+	}
+	assert.Equal(t, map[string]pdata.MetricDataType{
+		"cpu_temp":                      pdata.MetricDataTypeDoubleGauge,
+		"http_requests_total":           pdata.MetricDataTypeDoubleSum,
+		"http_request_duration_seconds": pdata.MetricDataTypeHistogram,
+		"rpc_duration_seconds":          pdata.MetricDataTypeSummary,
+	}, dataTypes)
+}
+
+func TestWriteAdmissionPaused(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = testutil.GetAvailableLocalAddress(t)
+	next := consumertest.NewErr(&admissioncontrol.PausedError{RetryAfter: 1500 * time.Millisecond})
+	receiver, err := newMetricsReceiver(cfg, newZapInfluxLogger(zap.NewNop()), next)
+	require.NoError(t, err)
+	require.NoError(t, receiver.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() {
+		require.NoError(t, receiver.Shutdown(context.Background()))
+	}()
+
+	body := "cpu_temp,foo=bar gauge=87.332 1622505600000000000"
+	res, err := http.Post(fmt.Sprintf("http://%s/api/v2/write", cfg.Endpoint), "text/plain", strings.NewReader(body))
+	require.NoError(t, err)
+	require.NoError(t, res.Body.Close())
+	assert.Equal(t, http.StatusTooManyRequests, res.StatusCode)
+	assert.Equal(t, "2", res.Header.Get("Retry-After"))
+}
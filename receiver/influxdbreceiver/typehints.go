@@ -0,0 +1,143 @@
+// Copyright 2021, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package influxdbreceiver
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/influxdata/influxdb-observability/common"
+)
+
+// metricHistogramInfFieldKeyV1 is the field holding the count of the +Inf bucket of
+// histograms in the telegraf-prometheus-v1 schema.
+const metricHistogramInfFieldKeyV1 = "+Inf"
+
+// point is a line protocol point of a write request.
+type point struct {
+	measurement string
+	tags        map[string]string
+	fields      map[string]interface{}
+	ts          time.Time
+}
+
+// inferValueTypes returns the metric value type of each point of a write request, following
+// the type hints that Telegraf encodes in the field and tag keys of the Prometheus schemata.
+// Points without type hints are untyped, and left to the converter to interpret.
+func inferValueTypes(schema common.MetricsSchema, points []point) []common.InfluxMetricValueType {
+	vTypes := make([]common.InfluxMetricValueType, len(points))
+	switch schema {
+	case common.MetricsSchemaTelegrafPrometheusV1:
+		for i := range points {
+			vTypes[i] = inferValueTypeV1(points[i])
+		}
+	case common.MetricsSchemaTelegrafPrometheusV2:
+		// The count and sum lines of histograms and summaries only differ by the bucket
+		// and quantile lines of the same metric, so collect the summary names first.
+		summaries := make(map[string]struct{})
+		for _, p := range points {
+			if _, found := p.tags[common.MetricSummaryQuantileKeyV2]; found {
+				for k := range p.fields {
+					summaries[k] = struct{}{}
+				}
+			}
+		}
+		for i := range points {
+			vTypes[i] = inferValueTypeV2(points[i], summaries)
+		}
+	}
+	return vTypes
+}
+
+// inferValueTypeV1 infers the value type of a telegraf-prometheus-v1 point:
+// - field "gauge" is a gauge
+// - field "counter" is a sum
+// - fields "count" and "sum" with a "+Inf" bucket field are a histogram
+// - fields "count" and "sum" with quantile fields and no "+Inf" field are a summary
+func inferValueTypeV1(p point) common.InfluxMetricValueType {
+	if _, found := p.fields[common.MetricGaugeFieldKey]; found {
+		return common.InfluxMetricValueTypeGauge
+	}
+	if _, found := p.fields[common.MetricCounterFieldKey]; found {
+		return common.InfluxMetricValueTypeSum
+	}
+	_, foundCount := p.fields[common.MetricHistogramCountFieldKey]
+	_, foundSum := p.fields[common.MetricHistogramSumFieldKey]
+	if !foundCount || !foundSum {
+		return common.InfluxMetricValueTypeUntyped
+	}
+	if _, found := p.fields[metricHistogramInfFieldKeyV1]; found {
+		// The converter derives the +Inf bucket from the count.
+		delete(p.fields, metricHistogramInfFieldKeyV1)
+		return common.InfluxMetricValueTypeHistogram
+	}
+	for k := range p.fields {
+		if quantile, err := strconv.ParseFloat(k, 64); err == nil && quantile >= 0 && quantile <= 1 {
+			return common.InfluxMetricValueTypeSummary
+		}
+	}
+	return common.InfluxMetricValueTypeUntyped
+}
+
+// inferValueTypeV2 infers the value type of a telegraf-prometheus-v2 point:
+// - lines tagged "le" are histogram buckets
+// - lines tagged "quantile" are summary quantiles
+// - "_count" and "_sum" fields belong to a summary if the metric has quantile lines, else to a histogram
+// - a single field suffixed "_total" is a sum, as named by the Prometheus conventions for counters
+func inferValueTypeV2(p point, summaries map[string]struct{}) common.InfluxMetricValueType {
+	if _, found := p.tags[common.MetricHistogramBoundKeyV2]; found {
+		return common.InfluxMetricValueTypeHistogram
+	}
+	if _, found := p.tags[common.MetricSummaryQuantileKeyV2]; found {
+		return common.InfluxMetricValueTypeSummary
+	}
+	for k := range p.fields {
+		var name string
+		switch {
+		case strings.HasSuffix(k, common.MetricHistogramCountSuffix):
+			name = strings.TrimSuffix(k, common.MetricHistogramCountSuffix)
+		case strings.HasSuffix(k, common.MetricHistogramSumSuffix):
+			name = strings.TrimSuffix(k, common.MetricHistogramSumSuffix)
+		default:
+			continue
+		}
+		if _, found := summaries[name]; found {
+			return common.InfluxMetricValueTypeSummary
+		}
+		return common.InfluxMetricValueTypeHistogram
+	}
+	if len(p.fields) == 1 {
+		for k := range p.fields {
+			if strings.HasSuffix(k, "_total") {
+				return common.InfluxMetricValueTypeSum
+			}
+		}
+	}
+	return common.InfluxMetricValueTypeUntyped
+}
+
+// convertFieldValue converts integer field values, as written by Telegraf for integer
+// metrics, to the floating point values expected by the converter.
+func convertFieldValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case int64:
+		return float64(v)
+	case uint64:
+		return float64(v)
+	default:
+		return v
+	}
+}
@@ -0,0 +1,69 @@
+// Copyright 2021, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package influxdbreceiver
+
+import (
+	"testing"
+
+	"github.com/influxdata/influxdb-observability/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInferValueTypesV1(t *testing.T) {
+	points := []point{
+		{measurement: "cpu_temp", fields: map[string]interface{}{"gauge": 87.3}},
+		{measurement: "http_requests_total", fields: map[string]interface{}{"counter": 1027.0}},
+		{measurement: "http_request_duration_seconds", fields: map[string]interface{}{"0.05": 24054.0, "+Inf": 144320.0, "count": 144320.0, "sum": 53423.0}},
+		{measurement: "rpc_duration_seconds", fields: map[string]interface{}{"0.01": 3102.0, "0.99": 76656.0, "count": 2693.0, "sum": 1.7560473e+07}},
+		{measurement: "cpu", fields: map[string]interface{}{"usage_idle": 99.1}},
+	}
+	vTypes := inferValueTypes(common.MetricsSchemaTelegrafPrometheusV1, points)
+	assert.Equal(t, []common.InfluxMetricValueType{
+		common.InfluxMetricValueTypeGauge,
+		common.InfluxMetricValueTypeSum,
+		common.InfluxMetricValueTypeHistogram,
+		common.InfluxMetricValueTypeSummary,
+		common.InfluxMetricValueTypeUntyped,
+	}, vTypes)
+	assert.NotContains(t, points[2].fields, "+Inf")
+}
+
+func TestInferValueTypesV2(t *testing.T) {
+	points := []point{
+		{measurement: "prometheus", fields: map[string]interface{}{"cpu_temp": 87.3}},
+		{measurement: "prometheus", fields: map[string]interface{}{"http_requests_total": 1027.0}},
+		{measurement: "prometheus", tags: map[string]string{"le": "0.05"}, fields: map[string]interface{}{"http_request_duration_seconds_bucket": 24054.0}},
+		{measurement: "prometheus", fields: map[string]interface{}{"http_request_duration_seconds_count": 144320.0, "http_request_duration_seconds_sum": 53423.0}},
+		{measurement: "prometheus", fields: map[string]interface{}{"rpc_duration_seconds_count": 2693.0, "rpc_duration_seconds_sum": 1.7560473e+07}},
+		{measurement: "prometheus", tags: map[string]string{"quantile": "0.99"}, fields: map[string]interface{}{"rpc_duration_seconds": 76656.0}},
+	}
+	vTypes := inferValueTypes(common.MetricsSchemaTelegrafPrometheusV2, points)
+	assert.Equal(t, []common.InfluxMetricValueType{
+		common.InfluxMetricValueTypeUntyped,
+		common.InfluxMetricValueTypeSum,
+		common.InfluxMetricValueTypeHistogram,
+		common.InfluxMetricValueTypeHistogram,
+		common.InfluxMetricValueTypeSummary,
+		common.InfluxMetricValueTypeSummary,
+	}, vTypes)
+}
+
+func TestConvertFieldValue(t *testing.T) {
+	assert.Equal(t, 3.0, convertFieldValue(int64(3)))
+	assert.Equal(t, 3.0, convertFieldValue(uint64(3)))
+	assert.Equal(t, 3.5, convertFieldValue(3.5))
+	assert.Equal(t, "a", convertFieldValue("a"))
+	assert.Equal(t, true, convertFieldValue(true))
+}
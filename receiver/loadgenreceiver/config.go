@@ -0,0 +1,152 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadgenreceiver
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/config"
+)
+
+// Config defines configuration for the load generator receiver.
+type Config struct {
+	config.ReceiverSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+
+	// Interval is how often a batch of generated data is sent to the next consumer.
+	// The size of each batch is derived from the configured rates.
+	Interval time.Duration `mapstructure:"interval"`
+
+	// Seed is the seed of the random generator, so that runs can be reproduced. A zero value
+	// means the generator is seeded from the current time.
+	Seed int64 `mapstructure:"seed"`
+
+	// Attributes are added to every generated span, data point and log record.
+	Attributes []AttributeConfig `mapstructure:"attributes"`
+
+	// Traces configures the generation of traces.
+	Traces TracesConfig `mapstructure:"traces"`
+
+	// Metrics configures the generation of metrics.
+	Metrics MetricsConfig `mapstructure:"metrics"`
+
+	// Logs configures the generation of logs.
+	Logs LogsConfig `mapstructure:"logs"`
+}
+
+// AttributeConfig describes how the values of a generated attribute are distributed.
+type AttributeConfig struct {
+	// Key is the attribute key.
+	Key string `mapstructure:"key"`
+
+	// Values is the list of values to pick from. If not set, values are generated as "<key>-<n>",
+	// with n uniformly distributed between 0 and Cardinality.
+	Values []string `mapstructure:"values"`
+
+	// Weights are the relative weights of each value in Values. All values are equally
+	// likely if not set.
+	Weights []int `mapstructure:"weights"`
+
+	// Cardinality is the number of distinct generated values when Values isn't set.
+	Cardinality int `mapstructure:"cardinality"`
+}
+
+// TracesConfig configures the generation of traces.
+type TracesConfig struct {
+	// Rate is the number of traces generated per second. Traces are disabled if zero.
+	Rate float64 `mapstructure:"rate"`
+
+	// SpansPerTrace is the number of spans in each trace.
+	SpansPerTrace int `mapstructure:"spans_per_trace"`
+
+	// Services is the number of distinct service names.
+	Services int `mapstructure:"services"`
+
+	// Operations is the number of distinct span names.
+	Operations int `mapstructure:"operations"`
+
+	// ErrorRatio is the ratio of spans, between 0 and 1, with an error status.
+	ErrorRatio float64 `mapstructure:"error_ratio"`
+}
+
+// MetricsConfig configures the generation of metrics.
+type MetricsConfig struct {
+	// Rate is the number of data points generated per second. Metrics are disabled if zero.
+	Rate float64 `mapstructure:"rate"`
+
+	// Services is the number of distinct service names.
+	Services int `mapstructure:"services"`
+
+	// Metrics is the number of distinct metric names.
+	Metrics int `mapstructure:"metrics"`
+}
+
+// LogsConfig configures the generation of logs.
+type LogsConfig struct {
+	// Rate is the number of log records generated per second. Logs are disabled if zero.
+	Rate float64 `mapstructure:"rate"`
+
+	// Services is the number of distinct service names.
+	Services int `mapstructure:"services"`
+
+	// ErrorRatio is the ratio of log records, between 0 and 1, with an error severity.
+	ErrorRatio float64 `mapstructure:"error_ratio"`
+}
+
+// Validate checks the receiver configuration is valid.
+func (cfg *Config) Validate() error {
+	if cfg.Interval <= 0 {
+		return errors.New("interval must be greater than zero")
+	}
+	if cfg.Traces.Rate < 0 || cfg.Metrics.Rate < 0 || cfg.Logs.Rate < 0 {
+		return errors.New("rates must not be negative")
+	}
+	if cfg.Traces.Rate > 0 && (cfg.Traces.SpansPerTrace <= 0 || cfg.Traces.Services <= 0 || cfg.Traces.Operations <= 0) {
+		return errors.New("traces: spans_per_trace, services and operations must be greater than zero")
+	}
+	if cfg.Metrics.Rate > 0 && (cfg.Metrics.Services <= 0 || cfg.Metrics.Metrics <= 0) {
+		return errors.New("metrics: services and metrics must be greater than zero")
+	}
+	if cfg.Logs.Rate > 0 && cfg.Logs.Services <= 0 {
+		return errors.New("logs: services must be greater than zero")
+	}
+	if !isRatio(cfg.Traces.ErrorRatio) || !isRatio(cfg.Logs.ErrorRatio) {
+		return errors.New("error_ratio must be between 0 and 1")
+	}
+
+	for _, attr := range cfg.Attributes {
+		if attr.Key == "" {
+			return errors.New("attributes: key must not be empty")
+		}
+		if len(attr.Values) == 0 && attr.Cardinality <= 0 {
+			return fmt.Errorf("attribute %q: either values or cardinality must be set", attr.Key)
+		}
+		if len(attr.Weights) != 0 && len(attr.Weights) != len(attr.Values) {
+			return fmt.Errorf("attribute %q: weights must have as many entries as values", attr.Key)
+		}
+		for _, w := range attr.Weights {
+			if w < 0 {
+				return fmt.Errorf("attribute %q: weights must not be negative", attr.Key)
+			}
+		}
+	}
+
+	return nil
+}
+
+func isRatio(r float64) bool {
+	return r >= 0 && r <= 1
+}
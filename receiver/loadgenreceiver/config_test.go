@@ -0,0 +1,160 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadgenreceiver
+
+import (
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configtest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	require.NoError(t, err)
+
+	factory := NewFactory()
+	factories.Receivers[typeStr] = factory
+	cfg, err := configtest.LoadConfigAndValidate(path.Join(".", "testdata", "config.yaml"), factories)
+
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+	assert.Len(t, cfg.Receivers, 2)
+
+	assert.Equal(t, factory.CreateDefaultConfig(), cfg.Receivers[config.NewID(typeStr)])
+
+	assert.Equal(t,
+		&Config{
+			ReceiverSettings: config.NewReceiverSettings(config.NewIDWithName(typeStr, "custom")),
+			Interval:         100 * time.Millisecond,
+			Seed:             42,
+			Attributes: []AttributeConfig{
+				{Key: "http.method", Values: []string{"GET", "POST", "PUT"}, Weights: []int{16, 3, 1}},
+				{Key: "customer.id", Cardinality: 1000},
+			},
+			Traces: TracesConfig{
+				Rate:          100,
+				SpansPerTrace: 3,
+				Services:      5,
+				Operations:    20,
+				ErrorRatio:    0.05,
+			},
+			Metrics: MetricsConfig{
+				Rate:     1000,
+				Services: 2,
+				Metrics:  50,
+			},
+			Logs: LogsConfig{
+				Rate:       500,
+				Services:   4,
+				ErrorRatio: 0.1,
+			},
+		},
+		cfg.Receivers[config.NewIDWithName(typeStr, "custom")])
+}
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(cfg *Config)
+		wantErr string
+	}{
+		{
+			name:   "default",
+			mutate: func(cfg *Config) {},
+		},
+		{
+			name:    "zero interval",
+			mutate:  func(cfg *Config) { cfg.Interval = 0 },
+			wantErr: "interval must be greater than zero",
+		},
+		{
+			name:    "negative rate",
+			mutate:  func(cfg *Config) { cfg.Logs.Rate = -1 },
+			wantErr: "rates must not be negative",
+		},
+		{
+			name: "traces without spans",
+			mutate: func(cfg *Config) {
+				cfg.Traces.Rate = 1
+				cfg.Traces.SpansPerTrace = 0
+			},
+			wantErr: "traces: spans_per_trace, services and operations must be greater than zero",
+		},
+		{
+			name: "metrics without names",
+			mutate: func(cfg *Config) {
+				cfg.Metrics.Rate = 1
+				cfg.Metrics.Metrics = 0
+			},
+			wantErr: "metrics: services and metrics must be greater than zero",
+		},
+		{
+			name: "logs without services",
+			mutate: func(cfg *Config) {
+				cfg.Logs.Rate = 1
+				cfg.Logs.Services = 0
+			},
+			wantErr: "logs: services must be greater than zero",
+		},
+		{
+			name:    "error ratio out of range",
+			mutate:  func(cfg *Config) { cfg.Traces.ErrorRatio = 1.5 },
+			wantErr: "error_ratio must be between 0 and 1",
+		},
+		{
+			name:    "attribute without key",
+			mutate:  func(cfg *Config) { cfg.Attributes = []AttributeConfig{{Cardinality: 1}} },
+			wantErr: "attributes: key must not be empty",
+		},
+		{
+			name:    "attribute without values",
+			mutate:  func(cfg *Config) { cfg.Attributes = []AttributeConfig{{Key: "a"}} },
+			wantErr: `attribute "a": either values or cardinality must be set`,
+		},
+		{
+			name: "attribute with mismatched weights",
+			mutate: func(cfg *Config) {
+				cfg.Attributes = []AttributeConfig{{Key: "a", Values: []string{"x", "y"}, Weights: []int{1}}}
+			},
+			wantErr: `attribute "a": weights must have as many entries as values`,
+		},
+		{
+			name: "attribute with negative weights",
+			mutate: func(cfg *Config) {
+				cfg.Attributes = []AttributeConfig{{Key: "a", Values: []string{"x"}, Weights: []int{-1}}}
+			},
+			wantErr: `attribute "a": weights must not be negative`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			tt.mutate(cfg)
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+		})
+	}
+}
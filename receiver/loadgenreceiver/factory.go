@@ -0,0 +1,122 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadgenreceiver
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/receiver/receiverhelper"
+)
+
+const (
+	// The value of "type" key in configuration.
+	typeStr = "loadgen"
+
+	defaultInterval = time.Second
+)
+
+// NewFactory creates a factory for the load generator receiver.
+func NewFactory() component.ReceiverFactory {
+	return receiverhelper.NewFactory(
+		typeStr,
+		createDefaultConfig,
+		receiverhelper.WithTraces(createTracesReceiver),
+		receiverhelper.WithMetrics(createMetricsReceiver),
+		receiverhelper.WithLogs(createLogsReceiver))
+}
+
+func createDefaultConfig() config.Receiver {
+	return &Config{
+		ReceiverSettings: config.NewReceiverSettings(config.NewID(typeStr)),
+		Interval:         defaultInterval,
+		Traces: TracesConfig{
+			SpansPerTrace: 5,
+			Services:      3,
+			Operations:    10,
+		},
+		Metrics: MetricsConfig{
+			Services: 3,
+			Metrics:  10,
+		},
+		Logs: LogsConfig{
+			Services: 3,
+		},
+	}
+}
+
+func createTracesReceiver(
+	_ context.Context,
+	params component.ReceiverCreateSettings,
+	cfg config.Receiver,
+	nextConsumer consumer.Traces,
+) (component.TracesReceiver, error) {
+	rCfg := cfg.(*Config)
+	gen := newGenerator(rCfg)
+
+	var r *loadgenReceiver
+	r = newLoadgenReceiver(rCfg.ID(), params.Logger, rCfg.Interval, rCfg.Traces.Rate, func(ctx context.Context, count int) error {
+		ctx = r.obsrecv.StartTracesOp(ctx)
+		td := gen.generateTraces(count)
+		err := nextConsumer.ConsumeTraces(ctx, td)
+		r.obsrecv.EndTracesOp(ctx, typeStr, td.SpanCount(), err)
+		return err
+	})
+	return r, nil
+}
+
+func createMetricsReceiver(
+	_ context.Context,
+	params component.ReceiverCreateSettings,
+	cfg config.Receiver,
+	nextConsumer consumer.Metrics,
+) (component.MetricsReceiver, error) {
+	rCfg := cfg.(*Config)
+	gen := newGenerator(rCfg)
+
+	var r *loadgenReceiver
+	r = newLoadgenReceiver(rCfg.ID(), params.Logger, rCfg.Interval, rCfg.Metrics.Rate, func(ctx context.Context, count int) error {
+		ctx = r.obsrecv.StartMetricsOp(ctx)
+		md := gen.generateMetrics(count)
+		err := nextConsumer.ConsumeMetrics(ctx, md)
+		_, numPoints := md.MetricAndDataPointCount()
+		r.obsrecv.EndMetricsOp(ctx, typeStr, numPoints, err)
+		return err
+	})
+	return r, nil
+}
+
+func createLogsReceiver(
+	_ context.Context,
+	params component.ReceiverCreateSettings,
+	cfg config.Receiver,
+	nextConsumer consumer.Logs,
+) (component.LogsReceiver, error) {
+	rCfg := cfg.(*Config)
+	gen := newGenerator(rCfg)
+
+	var r *loadgenReceiver
+	r = newLoadgenReceiver(rCfg.ID(), params.Logger, rCfg.Interval, rCfg.Logs.Rate, func(ctx context.Context, count int) error {
+		ctx = r.obsrecv.StartLogsOp(ctx)
+		ld := gen.generateLogs(count)
+		err := nextConsumer.ConsumeLogs(ctx, ld)
+		r.obsrecv.EndLogsOp(ctx, typeStr, ld.LogRecordCount(), err)
+		return err
+	})
+	return r, nil
+}
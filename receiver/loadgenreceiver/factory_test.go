@@ -0,0 +1,83 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadgenreceiver
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configcheck"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.uber.org/zap"
+)
+
+func TestCreateDefaultConfig(t *testing.T) {
+	cfg := createDefaultConfig()
+	assert.NotNil(t, cfg, "failed to create default config")
+	assert.NoError(t, configcheck.ValidateConfig(cfg))
+}
+
+func TestCreateReceivers(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.Interval = 10 * time.Millisecond
+	cfg.Traces.Rate = 1000
+	cfg.Metrics.Rate = 1000
+	cfg.Logs.Rate = 1000
+	params := component.ReceiverCreateSettings{Logger: zap.NewNop()}
+
+	traces := new(consumertest.TracesSink)
+	tr, err := factory.CreateTracesReceiver(context.Background(), params, cfg, traces)
+	require.NoError(t, err)
+	metrics := new(consumertest.MetricsSink)
+	mr, err := factory.CreateMetricsReceiver(context.Background(), params, cfg, metrics)
+	require.NoError(t, err)
+	logs := new(consumertest.LogsSink)
+	lr, err := factory.CreateLogsReceiver(context.Background(), params, cfg, logs)
+	require.NoError(t, err)
+
+	for _, r := range []component.Receiver{tr, mr, lr} {
+		require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	}
+
+	assert.Eventually(t, func() bool {
+		return traces.SpansCount() > 0 && metrics.MetricsCount() > 0 && logs.LogRecordsCount() > 0
+	}, 5*time.Second, 10*time.Millisecond)
+
+	for _, r := range []component.Receiver{tr, mr, lr} {
+		require.NoError(t, r.Shutdown(context.Background()))
+	}
+}
+
+func TestDisabledSignal(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.Interval = time.Millisecond
+
+	sink := new(consumertest.TracesSink)
+	r, err := factory.CreateTracesReceiver(context.Background(), component.ReceiverCreateSettings{Logger: zap.NewNop()}, cfg, sink)
+	require.NoError(t, err)
+
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	time.Sleep(20 * time.Millisecond)
+	require.NoError(t, r.Shutdown(context.Background()))
+
+	assert.Equal(t, 0, sink.SpansCount())
+}
@@ -0,0 +1,212 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadgenreceiver
+
+import (
+	"encoding/binary"
+	"math/rand"
+	"strconv"
+	"time"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/translator/conventions"
+)
+
+const instrumentationLibraryName = "loadgenreceiver"
+
+// generator builds synthetic telemetry according to the receiver configuration.
+// It isn't safe for concurrent use.
+type generator struct {
+	cfg *Config
+	rnd *rand.Rand
+}
+
+func newGenerator(cfg *Config) *generator {
+	seed := cfg.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	return &generator{
+		cfg: cfg,
+		rnd: rand.New(rand.NewSource(seed)),
+	}
+}
+
+// generateTraces builds the given number of traces, each one with the configured number of spans
+// belonging to a single randomly picked service.
+func (g *generator) generateTraces(count int) pdata.Traces {
+	tCfg := g.cfg.Traces
+	now := time.Now()
+
+	traces := pdata.NewTraces()
+	for i := 0; i < count; i++ {
+		rs := traces.ResourceSpans().AppendEmpty()
+		rs.Resource().Attributes().InsertString(conventions.AttributeServiceName, g.pick("service", tCfg.Services))
+
+		ils := rs.InstrumentationLibrarySpans().AppendEmpty()
+		ils.InstrumentationLibrary().SetName(instrumentationLibraryName)
+
+		traceID := g.traceID()
+		var parentID pdata.SpanID
+		for j := 0; j < tCfg.SpansPerTrace; j++ {
+			span := ils.Spans().AppendEmpty()
+			span.SetTraceID(traceID)
+			span.SetSpanID(g.spanID())
+			span.SetParentSpanID(parentID)
+			span.SetName(g.pick("operation", tCfg.Operations))
+			if j == 0 {
+				span.SetKind(pdata.SpanKindServer)
+			} else {
+				span.SetKind(pdata.SpanKindInternal)
+			}
+
+			duration := time.Duration(g.rnd.Int63n(int64(time.Second)))
+			span.SetStartTimestamp(pdata.TimestampFromTime(now.Add(-duration)))
+			span.SetEndTimestamp(pdata.TimestampFromTime(now))
+
+			if g.rnd.Float64() < tCfg.ErrorRatio {
+				span.Status().SetCode(pdata.StatusCodeError)
+			}
+			g.insertAttributes(span.Attributes())
+
+			parentID = span.SpanID()
+		}
+	}
+	return traces
+}
+
+// generateMetrics builds the given number of data points, spread across the configured number of metric names.
+// All data points are gauges and belong to a single randomly picked service.
+func (g *generator) generateMetrics(count int) pdata.Metrics {
+	mCfg := g.cfg.Metrics
+	now := pdata.TimestampFromTime(time.Now())
+
+	metrics := pdata.NewMetrics()
+	if count == 0 {
+		return metrics
+	}
+
+	rm := metrics.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().InsertString(conventions.AttributeServiceName, g.pick("service", mCfg.Services))
+
+	ilm := rm.InstrumentationLibraryMetrics().AppendEmpty()
+	ilm.InstrumentationLibrary().SetName(instrumentationLibraryName)
+
+	byName := make(map[string]pdata.Metric)
+	for i := 0; i < count; i++ {
+		name := g.pick("metric", mCfg.Metrics)
+		m, ok := byName[name]
+		if !ok {
+			m = ilm.Metrics().AppendEmpty()
+			m.SetName(name)
+			m.SetDataType(pdata.MetricDataTypeDoubleGauge)
+			byName[name] = m
+		}
+
+		dp := m.DoubleGauge().DataPoints().AppendEmpty()
+		dp.SetTimestamp(now)
+		dp.SetValue(g.rnd.Float64() * 100)
+		g.insertLabels(dp.LabelsMap())
+	}
+	return metrics
+}
+
+// generateLogs builds the given number of log records, belonging to a single randomly picked service.
+func (g *generator) generateLogs(count int) pdata.Logs {
+	lCfg := g.cfg.Logs
+	now := pdata.TimestampFromTime(time.Now())
+
+	logs := pdata.NewLogs()
+	if count == 0 {
+		return logs
+	}
+
+	rl := logs.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().InsertString(conventions.AttributeServiceName, g.pick("service", lCfg.Services))
+
+	ill := rl.InstrumentationLibraryLogs().AppendEmpty()
+	ill.InstrumentationLibrary().SetName(instrumentationLibraryName)
+
+	for i := 0; i < count; i++ {
+		lr := ill.Logs().AppendEmpty()
+		lr.SetTimestamp(now)
+		if g.rnd.Float64() < lCfg.ErrorRatio {
+			lr.SetSeverityNumber(pdata.SeverityNumberERROR)
+			lr.SetSeverityText("ERROR")
+			lr.Body().SetStringVal("synthetic error message " + strconv.Itoa(i))
+		} else {
+			lr.SetSeverityNumber(pdata.SeverityNumberINFO)
+			lr.SetSeverityText("INFO")
+			lr.Body().SetStringVal("synthetic log message " + strconv.Itoa(i))
+		}
+		g.insertAttributes(lr.Attributes())
+	}
+	return logs
+}
+
+// insertAttributes adds the configured attributes to the given map.
+func (g *generator) insertAttributes(attrs pdata.AttributeMap) {
+	for _, attr := range g.cfg.Attributes {
+		attrs.InsertString(attr.Key, g.attributeValue(attr))
+	}
+}
+
+// insertLabels adds the configured attributes to the given data point labels.
+func (g *generator) insertLabels(labels pdata.StringMap) {
+	for _, attr := range g.cfg.Attributes {
+		labels.Insert(attr.Key, g.attributeValue(attr))
+	}
+}
+
+func (g *generator) attributeValue(attr AttributeConfig) string {
+	if len(attr.Values) == 0 {
+		return g.pick(attr.Key, attr.Cardinality)
+	}
+
+	total := 0
+	for _, w := range attr.Weights {
+		total += w
+	}
+	if total == 0 {
+		return attr.Values[g.rnd.Intn(len(attr.Values))]
+	}
+
+	n := g.rnd.Intn(total)
+	for i, w := range attr.Weights {
+		if n < w {
+			return attr.Values[i]
+		}
+		n -= w
+	}
+	return attr.Values[len(attr.Values)-1]
+}
+
+// pick returns one of cardinality distinct values built from the given prefix.
+func (g *generator) pick(prefix string, cardinality int) string {
+	return prefix + "-" + strconv.Itoa(g.rnd.Intn(cardinality))
+}
+
+func (g *generator) traceID() pdata.TraceID {
+	var b [16]byte
+	binary.BigEndian.PutUint64(b[:8], g.rnd.Uint64())
+	binary.BigEndian.PutUint64(b[8:], g.rnd.Uint64())
+	return pdata.NewTraceID(b)
+}
+
+func (g *generator) spanID() pdata.SpanID {
+	var b [8]byte
+	binary.BigEndian.PutUint64(b[:], g.rnd.Uint64())
+	return pdata.NewSpanID(b)
+}
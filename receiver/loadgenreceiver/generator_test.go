@@ -0,0 +1,108 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadgenreceiver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/translator/conventions"
+)
+
+func testConfig() *Config {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Seed = 1
+	cfg.Attributes = []AttributeConfig{
+		{Key: "http.method", Values: []string{"GET", "POST"}, Weights: []int{1, 0}},
+		{Key: "customer.id", Cardinality: 2},
+	}
+	cfg.Traces.ErrorRatio = 1
+	return cfg
+}
+
+func TestGenerateTraces(t *testing.T) {
+	g := newGenerator(testConfig())
+
+	td := g.generateTraces(4)
+
+	require.Equal(t, 4, td.ResourceSpans().Len())
+	assert.Equal(t, 20, td.SpanCount())
+
+	rs := td.ResourceSpans().At(0)
+	svc, ok := rs.Resource().Attributes().Get(conventions.AttributeServiceName)
+	require.True(t, ok)
+	assert.Contains(t, []string{"service-0", "service-1", "service-2"}, svc.StringVal())
+
+	spans := rs.InstrumentationLibrarySpans().At(0).Spans()
+	assert.Equal(t, pdata.SpanKindServer, spans.At(0).Kind())
+	assert.True(t, spans.At(0).ParentSpanID().IsEmpty())
+	for i := 1; i < spans.Len(); i++ {
+		span := spans.At(i)
+		assert.Equal(t, spans.At(0).TraceID(), span.TraceID())
+		assert.Equal(t, spans.At(i-1).SpanID(), span.ParentSpanID())
+		assert.Equal(t, pdata.StatusCodeError, span.Status().Code())
+
+		method, _ := span.Attributes().Get("http.method")
+		assert.Equal(t, "GET", method.StringVal())
+		customer, _ := span.Attributes().Get("customer.id")
+		assert.Contains(t, []string{"customer.id-0", "customer.id-1"}, customer.StringVal())
+	}
+}
+
+func TestGenerateMetrics(t *testing.T) {
+	cfg := testConfig()
+	cfg.Metrics.Metrics = 2
+	g := newGenerator(cfg)
+
+	md := g.generateMetrics(50)
+
+	metricCount, dataPointCount := md.MetricAndDataPointCount()
+	assert.LessOrEqual(t, metricCount, 2)
+	assert.Equal(t, 50, dataPointCount)
+
+	m := md.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics().At(0)
+	assert.Equal(t, pdata.MetricDataTypeDoubleGauge, m.DataType())
+	method, _ := m.DoubleGauge().DataPoints().At(0).LabelsMap().Get("http.method")
+	assert.Equal(t, "GET", method)
+
+	assert.Equal(t, 0, g.generateMetrics(0).MetricCount())
+}
+
+func TestGenerateLogs(t *testing.T) {
+	cfg := testConfig()
+	cfg.Logs.ErrorRatio = 0
+	g := newGenerator(cfg)
+
+	ld := g.generateLogs(10)
+
+	assert.Equal(t, 10, ld.LogRecordCount())
+	lr := ld.ResourceLogs().At(0).InstrumentationLibraryLogs().At(0).Logs().At(0)
+	assert.Equal(t, pdata.SeverityNumberINFO, lr.SeverityNumber())
+	_, ok := lr.Attributes().Get("customer.id")
+	assert.True(t, ok)
+
+	assert.Equal(t, 0, g.generateLogs(0).LogRecordCount())
+}
+
+func TestGeneratorIsReproducible(t *testing.T) {
+	td1 := newGenerator(testConfig()).generateTraces(1)
+	td2 := newGenerator(testConfig()).generateTraces(1)
+
+	assert.Equal(t,
+		td1.ResourceSpans().At(0).InstrumentationLibrarySpans().At(0).Spans().At(0).TraceID(),
+		td2.ResourceSpans().At(0).InstrumentationLibrarySpans().At(0).Spans().At(0).TraceID())
+}
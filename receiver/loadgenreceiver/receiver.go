@@ -0,0 +1,109 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadgenreceiver
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/obsreport"
+	"go.uber.org/zap"
+)
+
+const transport = "loadgen"
+
+// emitFunc generates count items of a signal and sends them to the next consumer.
+type emitFunc func(ctx context.Context, count int) error
+
+// loadgenReceiver periodically emits a batch of generated data for a single signal.
+type loadgenReceiver struct {
+	logger   *zap.Logger
+	interval time.Duration
+	rate     float64
+	emit     emitFunc
+	obsrecv  *obsreport.Receiver
+
+	// pending accumulates the fractional items of each interval, so that
+	// rates lower than one item per interval are honored over time.
+	pending float64
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+var _ component.Receiver = (*loadgenReceiver)(nil)
+
+func newLoadgenReceiver(id config.ComponentID, logger *zap.Logger, interval time.Duration, rate float64, emit emitFunc) *loadgenReceiver {
+	return &loadgenReceiver{
+		logger:   logger,
+		interval: interval,
+		rate:     rate,
+		emit:     emit,
+		obsrecv:  obsreport.NewReceiver(obsreport.ReceiverSettings{ReceiverID: id, Transport: transport}),
+		done:     make(chan struct{}),
+	}
+}
+
+// Start begins emitting data, unless the configured rate is zero.
+func (r *loadgenReceiver) Start(_ context.Context, _ component.Host) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	r.cancel = cancel
+
+	if r.rate <= 0 {
+		close(r.done)
+		return nil
+	}
+
+	go r.run(ctx)
+	return nil
+}
+
+// Shutdown stops emitting data and waits for the in-flight batch to be sent.
+func (r *loadgenReceiver) Shutdown(context.Context) error {
+	if r.cancel == nil {
+		return nil
+	}
+	r.cancel()
+	<-r.done
+	return nil
+}
+
+func (r *loadgenReceiver) run(ctx context.Context) {
+	defer close(r.done)
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := r.emit(ctx, r.nextBatchSize()); err != nil {
+				r.logger.Debug("Failed to send generated data", zap.Error(err))
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// nextBatchSize returns the number of items to emit for the current interval.
+func (r *loadgenReceiver) nextBatchSize() int {
+	r.pending += r.rate * r.interval.Seconds()
+	count := int(r.pending)
+	r.pending -= float64(count)
+	return count
+}
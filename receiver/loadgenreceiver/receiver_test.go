@@ -0,0 +1,46 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadgenreceiver
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/config"
+	"go.uber.org/zap"
+)
+
+func TestNextBatchSize(t *testing.T) {
+	// 2.5 items per interval
+	r := newLoadgenReceiver(config.NewID(typeStr), zap.NewNop(), 500*time.Millisecond, 5, nil)
+
+	var sizes []int
+	for i := 0; i < 4; i++ {
+		sizes = append(sizes, r.nextBatchSize())
+	}
+	assert.Equal(t, []int{2, 3, 2, 3}, sizes)
+}
+
+func TestNextBatchSizeLowRate(t *testing.T) {
+	// one item every 4 intervals
+	r := newLoadgenReceiver(config.NewID(typeStr), zap.NewNop(), time.Second, 0.25, nil)
+
+	total := 0
+	for i := 0; i < 8; i++ {
+		total += r.nextBatchSize()
+	}
+	assert.Equal(t, 2, total)
+}
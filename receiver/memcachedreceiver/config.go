@@ -27,4 +27,8 @@ type Config struct {
 
 	// Timeout for the memcache stats request
 	Timeout time.Duration `mapstructure:"timeout"`
+
+	// SlabStats enables the per slab class metrics, reported from the
+	// "stats slabs" and "stats items" commands.
+	SlabStats bool `mapstructure:"slab_stats"`
 }
@@ -55,30 +55,66 @@ func (m *metricImpl) Init(metric pdata.Metric) {
 }
 
 type metricStruct struct {
-	MemcachedBytes              MetricIntf
-	MemcachedCurrentConnections MetricIntf
-	MemcachedGetHits            MetricIntf
-	MemcachedGetMisses          MetricIntf
-	MemcachedTotalConnections   MetricIntf
+	MemcachedBytes               MetricIntf
+	MemcachedCommands            MetricIntf
+	MemcachedCurrentConnections  MetricIntf
+	MemcachedEvictions           MetricIntf
+	MemcachedGetHits             MetricIntf
+	MemcachedGetMisses           MetricIntf
+	MemcachedOperationHitRatio   MetricIntf
+	MemcachedOperations          MetricIntf
+	MemcachedSlabChunkSize       MetricIntf
+	MemcachedSlabChunks          MetricIntf
+	MemcachedSlabEvictions       MetricIntf
+	MemcachedSlabItemAge         MetricIntf
+	MemcachedSlabItems           MetricIntf
+	MemcachedSlabMemoryRequested MetricIntf
+	MemcachedSlabOutOfMemory     MetricIntf
+	MemcachedSlabPages           MetricIntf
+	MemcachedTotalConnections    MetricIntf
 }
 
 // Names returns a list of all the metric name strings.
 func (m *metricStruct) Names() []string {
 	return []string{
 		"memcached.bytes",
+		"memcached.commands",
 		"memcached.current_connections",
+		"memcached.evictions",
 		"memcached.get_hits",
 		"memcached.get_misses",
+		"memcached.operation_hit_ratio",
+		"memcached.operations",
+		"memcached.slab.chunk_size",
+		"memcached.slab.chunks",
+		"memcached.slab.evictions",
+		"memcached.slab.item_age",
+		"memcached.slab.items",
+		"memcached.slab.memory_requested",
+		"memcached.slab.out_of_memory",
+		"memcached.slab.pages",
 		"memcached.total_connections",
 	}
 }
 
 var metricsByName = map[string]MetricIntf{
-	"memcached.bytes":               Metrics.MemcachedBytes,
-	"memcached.current_connections": Metrics.MemcachedCurrentConnections,
-	"memcached.get_hits":            Metrics.MemcachedGetHits,
-	"memcached.get_misses":          Metrics.MemcachedGetMisses,
-	"memcached.total_connections":   Metrics.MemcachedTotalConnections,
+	"memcached.bytes":                 Metrics.MemcachedBytes,
+	"memcached.commands":              Metrics.MemcachedCommands,
+	"memcached.current_connections":   Metrics.MemcachedCurrentConnections,
+	"memcached.evictions":             Metrics.MemcachedEvictions,
+	"memcached.get_hits":              Metrics.MemcachedGetHits,
+	"memcached.get_misses":            Metrics.MemcachedGetMisses,
+	"memcached.operation_hit_ratio":   Metrics.MemcachedOperationHitRatio,
+	"memcached.operations":            Metrics.MemcachedOperations,
+	"memcached.slab.chunk_size":       Metrics.MemcachedSlabChunkSize,
+	"memcached.slab.chunks":           Metrics.MemcachedSlabChunks,
+	"memcached.slab.evictions":        Metrics.MemcachedSlabEvictions,
+	"memcached.slab.item_age":         Metrics.MemcachedSlabItemAge,
+	"memcached.slab.items":            Metrics.MemcachedSlabItems,
+	"memcached.slab.memory_requested": Metrics.MemcachedSlabMemoryRequested,
+	"memcached.slab.out_of_memory":    Metrics.MemcachedSlabOutOfMemory,
+	"memcached.slab.pages":            Metrics.MemcachedSlabPages,
+	"memcached.total_connections":     Metrics.MemcachedTotalConnections,
 }
 
 func (m *metricStruct) ByName(n string) MetricIntf {
@@ -87,11 +123,23 @@ func (m *metricStruct) ByName(n string) MetricIntf {
 
 func (m *metricStruct) FactoriesByName() map[string]func(pdata.Metric) {
 	return map[string]func(pdata.Metric){
-		Metrics.MemcachedBytes.Name():              Metrics.MemcachedBytes.Init,
-		Metrics.MemcachedCurrentConnections.Name(): Metrics.MemcachedCurrentConnections.Init,
-		Metrics.MemcachedGetHits.Name():            Metrics.MemcachedGetHits.Init,
-		Metrics.MemcachedGetMisses.Name():          Metrics.MemcachedGetMisses.Init,
-		Metrics.MemcachedTotalConnections.Name():   Metrics.MemcachedTotalConnections.Init,
+		Metrics.MemcachedBytes.Name():               Metrics.MemcachedBytes.Init,
+		Metrics.MemcachedCommands.Name():            Metrics.MemcachedCommands.Init,
+		Metrics.MemcachedCurrentConnections.Name():  Metrics.MemcachedCurrentConnections.Init,
+		Metrics.MemcachedEvictions.Name():           Metrics.MemcachedEvictions.Init,
+		Metrics.MemcachedGetHits.Name():             Metrics.MemcachedGetHits.Init,
+		Metrics.MemcachedGetMisses.Name():           Metrics.MemcachedGetMisses.Init,
+		Metrics.MemcachedOperationHitRatio.Name():   Metrics.MemcachedOperationHitRatio.Init,
+		Metrics.MemcachedOperations.Name():          Metrics.MemcachedOperations.Init,
+		Metrics.MemcachedSlabChunkSize.Name():       Metrics.MemcachedSlabChunkSize.Init,
+		Metrics.MemcachedSlabChunks.Name():          Metrics.MemcachedSlabChunks.Init,
+		Metrics.MemcachedSlabEvictions.Name():       Metrics.MemcachedSlabEvictions.Init,
+		Metrics.MemcachedSlabItemAge.Name():         Metrics.MemcachedSlabItemAge.Init,
+		Metrics.MemcachedSlabItems.Name():           Metrics.MemcachedSlabItems.Init,
+		Metrics.MemcachedSlabMemoryRequested.Name(): Metrics.MemcachedSlabMemoryRequested.Init,
+		Metrics.MemcachedSlabOutOfMemory.Name():     Metrics.MemcachedSlabOutOfMemory.Init,
+		Metrics.MemcachedSlabPages.Name():           Metrics.MemcachedSlabPages.Init,
+		Metrics.MemcachedTotalConnections.Name():    Metrics.MemcachedTotalConnections.Init,
 	}
 }
 
@@ -107,6 +155,17 @@ var Metrics = &metricStruct{
 			metric.SetDataType(pdata.MetricDataTypeIntGauge)
 		},
 	},
+	&metricImpl{
+		"memcached.commands",
+		func(metric pdata.Metric) {
+			metric.SetName("memcached.commands")
+			metric.SetDescription("Commands executed, by type")
+			metric.SetUnit("commands")
+			metric.SetDataType(pdata.MetricDataTypeIntSum)
+			metric.IntSum().SetIsMonotonic(true)
+			metric.IntSum().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+		},
+	},
 	&metricImpl{
 		"memcached.current_connections",
 		func(metric pdata.Metric) {
@@ -116,6 +175,17 @@ var Metrics = &metricStruct{
 			metric.SetDataType(pdata.MetricDataTypeIntGauge)
 		},
 	},
+	&metricImpl{
+		"memcached.evictions",
+		func(metric pdata.Metric) {
+			metric.SetName("memcached.evictions")
+			metric.SetDescription("Number of valid items removed from the cache to free memory for new items")
+			metric.SetUnit("evictions")
+			metric.SetDataType(pdata.MetricDataTypeIntSum)
+			metric.IntSum().SetIsMonotonic(true)
+			metric.IntSum().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+		},
+	},
 	&metricImpl{
 		"memcached.get_hits",
 		func(metric pdata.Metric) {
@@ -138,6 +208,102 @@ var Metrics = &metricStruct{
 			metric.IntSum().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
 		},
 	},
+	&metricImpl{
+		"memcached.operation_hit_ratio",
+		func(metric pdata.Metric) {
+			metric.SetName("memcached.operation_hit_ratio")
+			metric.SetDescription("Hit ratio of the operations on the keys, by type")
+			metric.SetUnit("%")
+			metric.SetDataType(pdata.MetricDataTypeDoubleGauge)
+		},
+	},
+	&metricImpl{
+		"memcached.operations",
+		func(metric pdata.Metric) {
+			metric.SetName("memcached.operations")
+			metric.SetDescription("Operations on the keys, by type and result")
+			metric.SetUnit("operations")
+			metric.SetDataType(pdata.MetricDataTypeIntSum)
+			metric.IntSum().SetIsMonotonic(true)
+			metric.IntSum().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+		},
+	},
+	&metricImpl{
+		"memcached.slab.chunk_size",
+		func(metric pdata.Metric) {
+			metric.SetName("memcached.slab.chunk_size")
+			metric.SetDescription("Size of each chunk of the slab class. Only reported when slab_stats is enabled.")
+			metric.SetUnit("By")
+			metric.SetDataType(pdata.MetricDataTypeIntGauge)
+		},
+	},
+	&metricImpl{
+		"memcached.slab.chunks",
+		func(metric pdata.Metric) {
+			metric.SetName("memcached.slab.chunks")
+			metric.SetDescription("Number of chunks of the slab class, by state. Only reported when slab_stats is enabled.")
+			metric.SetUnit("chunks")
+			metric.SetDataType(pdata.MetricDataTypeIntGauge)
+		},
+	},
+	&metricImpl{
+		"memcached.slab.evictions",
+		func(metric pdata.Metric) {
+			metric.SetName("memcached.slab.evictions")
+			metric.SetDescription("Number of valid items removed from the slab class to free memory for new items. Only reported when slab_stats is enabled.")
+			metric.SetUnit("evictions")
+			metric.SetDataType(pdata.MetricDataTypeIntSum)
+			metric.IntSum().SetIsMonotonic(true)
+			metric.IntSum().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+		},
+	},
+	&metricImpl{
+		"memcached.slab.item_age",
+		func(metric pdata.Metric) {
+			metric.SetName("memcached.slab.item_age")
+			metric.SetDescription("Age of the oldest item in the slab class. Only reported when slab_stats is enabled.")
+			metric.SetUnit("s")
+			metric.SetDataType(pdata.MetricDataTypeIntGauge)
+		},
+	},
+	&metricImpl{
+		"memcached.slab.items",
+		func(metric pdata.Metric) {
+			metric.SetName("memcached.slab.items")
+			metric.SetDescription("Number of items stored in the slab class. Only reported when slab_stats is enabled.")
+			metric.SetUnit("items")
+			metric.SetDataType(pdata.MetricDataTypeIntGauge)
+		},
+	},
+	&metricImpl{
+		"memcached.slab.memory_requested",
+		func(metric pdata.Metric) {
+			metric.SetName("memcached.slab.memory_requested")
+			metric.SetDescription("Number of bytes requested to be stored in the slab class. Only reported when slab_stats is enabled.")
+			metric.SetUnit("By")
+			metric.SetDataType(pdata.MetricDataTypeIntGauge)
+		},
+	},
+	&metricImpl{
+		"memcached.slab.out_of_memory",
+		func(metric pdata.Metric) {
+			metric.SetName("memcached.slab.out_of_memory")
+			metric.SetDescription("Number of times the slab class couldn't store a new item. Only reported when slab_stats is enabled.")
+			metric.SetUnit("errors")
+			metric.SetDataType(pdata.MetricDataTypeIntSum)
+			metric.IntSum().SetIsMonotonic(true)
+			metric.IntSum().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+		},
+	},
+	&metricImpl{
+		"memcached.slab.pages",
+		func(metric pdata.Metric) {
+			metric.SetName("memcached.slab.pages")
+			metric.SetDescription("Number of pages allocated to the slab class. Only reported when slab_stats is enabled.")
+			metric.SetUnit("pages")
+			metric.SetDataType(pdata.MetricDataTypeIntGauge)
+		},
+	},
 	&metricImpl{
 		"memcached.total_connections",
 		func(metric pdata.Metric) {
@@ -157,8 +323,72 @@ var M = Metrics
 
 // Labels contains the possible metric labels that can be used.
 var Labels = struct {
-}{}
+	// Command (The type of command)
+	Command string
+	// Operation (The type of operation)
+	Operation string
+	// Slab (The slab class ID)
+	Slab string
+	// State (The state of the slab chunks)
+	State string
+	// Type (The result of the operation)
+	Type string
+}{
+	"command",
+	"operation",
+	"slab",
+	"state",
+	"type",
+}
 
 // L contains the possible metric labels that can be used. L is an alias for
 // Labels.
 var L = Labels
+
+// LabelCommand are the possible values that the label "command" can have.
+var LabelCommand = struct {
+	Get   string
+	Set   string
+	Flush string
+	Touch string
+}{
+	"get",
+	"set",
+	"flush",
+	"touch",
+}
+
+// LabelOperation are the possible values that the label "operation" can have.
+var LabelOperation = struct {
+	Get       string
+	Delete    string
+	Increment string
+	Decrement string
+	Cas       string
+	Touch     string
+}{
+	"get",
+	"delete",
+	"increment",
+	"decrement",
+	"cas",
+	"touch",
+}
+
+// LabelState are the possible values that the label "state" can have.
+var LabelState = struct {
+	Used string
+	Free string
+}{
+	"used",
+	"free",
+}
+
+// LabelType are the possible values that the label "type" can have.
+var LabelType = struct {
+	Hit  string
+	Miss string
+}{
+	"hit",
+	"miss",
+}
@@ -16,6 +16,7 @@ package memcachedreceiver
 
 import (
 	"context"
+	"strconv"
 	"time"
 
 	"github.com/grobie/gomemcache/memcache"
@@ -73,21 +74,98 @@ func (r *memcachedScraper) scrape(_ context.Context) (pdata.ResourceMetricsSlice
 	}
 
 	for _, stats := range stats {
-		for k, v := range stats.Stats {
+		addStats(&metrics, stats.Stats)
+		if r.config.SlabStats {
+			addSlabStats(&metrics, stats)
+		}
+	}
+
+	return metrics.Metrics.ResourceMetrics(), nil
+}
+
+// The operations whose hits and misses are reported, by the prefix of their stats.
+var operations = map[string]string{
+	"get":    metadata.LabelOperation.Get,
+	"delete": metadata.LabelOperation.Delete,
+	"incr":   metadata.LabelOperation.Increment,
+	"decr":   metadata.LabelOperation.Decrement,
+	"cas":    metadata.LabelOperation.Cas,
+	"touch":  metadata.LabelOperation.Touch,
+}
+
+func addStats(metrics *simple.Metrics, stats map[string]string) {
+	for k, v := range stats {
+		switch k {
+		case "bytes":
+			metrics.AddGaugeDataPoint(metadata.M.MemcachedBytes.Name(), parseInt(v))
+		case "curr_connections":
+			metrics.AddGaugeDataPoint(metadata.M.MemcachedCurrentConnections.Name(), parseInt(v))
+		case "total_connections":
+			metrics.AddSumDataPoint(metadata.M.MemcachedTotalConnections.Name(), parseInt(v))
+		case "get_hits":
+			metrics.AddSumDataPoint(metadata.M.MemcachedGetHits.Name(), parseInt(v))
+		case "get_misses":
+			metrics.AddSumDataPoint(metadata.M.MemcachedGetMisses.Name(), parseInt(v))
+		case "evictions":
+			metrics.AddSumDataPoint(metadata.M.MemcachedEvictions.Name(), parseInt(v))
+		case "cmd_get":
+			metrics.WithLabels(map[string]string{metadata.L.Command: metadata.LabelCommand.Get}).AddSumDataPoint(metadata.M.MemcachedCommands.Name(), parseInt(v))
+		case "cmd_set":
+			metrics.WithLabels(map[string]string{metadata.L.Command: metadata.LabelCommand.Set}).AddSumDataPoint(metadata.M.MemcachedCommands.Name(), parseInt(v))
+		case "cmd_flush":
+			metrics.WithLabels(map[string]string{metadata.L.Command: metadata.LabelCommand.Flush}).AddSumDataPoint(metadata.M.MemcachedCommands.Name(), parseInt(v))
+		case "cmd_touch":
+			metrics.WithLabels(map[string]string{metadata.L.Command: metadata.LabelCommand.Touch}).AddSumDataPoint(metadata.M.MemcachedCommands.Name(), parseInt(v))
+		}
+	}
+
+	for prefix, operation := range operations {
+		hitsValue, hasHits := stats[prefix+"_hits"]
+		missesValue, hasMisses := stats[prefix+"_misses"]
+		if !hasHits || !hasMisses {
+			continue
+		}
+		hits, misses := parseInt(hitsValue), parseInt(missesValue)
+		metrics.WithLabels(map[string]string{metadata.L.Operation: operation, metadata.L.Type: metadata.LabelType.Hit}).AddSumDataPoint(metadata.M.MemcachedOperations.Name(), hits)
+		metrics.WithLabels(map[string]string{metadata.L.Operation: operation, metadata.L.Type: metadata.LabelType.Miss}).AddSumDataPoint(metadata.M.MemcachedOperations.Name(), misses)
+		if hits+misses > 0 {
+			metrics.WithLabels(map[string]string{metadata.L.Operation: operation}).AddDGaugeDataPoint(metadata.M.MemcachedOperationHitRatio.Name(), float64(hits)/float64(hits+misses)*100)
+		}
+	}
+}
+
+func addSlabStats(metrics *simple.Metrics, stats memcache.Stats) {
+	for id, slab := range stats.Slabs {
+		slabMetrics := metrics.WithLabels(map[string]string{metadata.L.Slab: strconv.Itoa(id)})
+		for k, v := range slab {
 			switch k {
-			case "bytes":
-				metrics.AddGaugeDataPoint(metadata.M.MemcachedBytes.Name(), parseInt(v))
-			case "curr_connections":
-				metrics.AddGaugeDataPoint(metadata.M.MemcachedCurrentConnections.Name(), parseInt(v))
-			case "total_connections":
-				metrics.AddSumDataPoint(metadata.M.MemcachedTotalConnections.Name(), parseInt(v))
-			case "get_hits":
-				metrics.AddSumDataPoint(metadata.M.MemcachedGetHits.Name(), parseInt(v))
-			case "get_misses":
-				metrics.AddSumDataPoint(metadata.M.MemcachedGetMisses.Name(), parseInt(v))
+			case "used_chunks":
+				slabMetrics.WithLabels(map[string]string{metadata.L.State: metadata.LabelState.Used}).AddGaugeDataPoint(metadata.M.MemcachedSlabChunks.Name(), parseInt(v))
+			case "free_chunks":
+				slabMetrics.WithLabels(map[string]string{metadata.L.State: metadata.LabelState.Free}).AddGaugeDataPoint(metadata.M.MemcachedSlabChunks.Name(), parseInt(v))
+			case "chunk_size":
+				slabMetrics.AddGaugeDataPoint(metadata.M.MemcachedSlabChunkSize.Name(), parseInt(v))
+			case "total_pages":
+				slabMetrics.AddGaugeDataPoint(metadata.M.MemcachedSlabPages.Name(), parseInt(v))
+			case "mem_requested":
+				slabMetrics.AddGaugeDataPoint(metadata.M.MemcachedSlabMemoryRequested.Name(), parseInt(v))
 			}
 		}
 	}
 
-	return metrics.Metrics.ResourceMetrics(), nil
+	for id, items := range stats.Items {
+		slabMetrics := metrics.WithLabels(map[string]string{metadata.L.Slab: strconv.Itoa(id)})
+		for k, v := range items {
+			switch k {
+			case "number":
+				slabMetrics.AddGaugeDataPoint(metadata.M.MemcachedSlabItems.Name(), parseInt(v))
+			case "age":
+				slabMetrics.AddGaugeDataPoint(metadata.M.MemcachedSlabItemAge.Name(), parseInt(v))
+			case "evicted":
+				slabMetrics.AddSumDataPoint(metadata.M.MemcachedSlabEvictions.Name(), parseInt(v))
+			case "outofmemory":
+				slabMetrics.AddSumDataPoint(metadata.M.MemcachedSlabOutOfMemory.Name(), parseInt(v))
+			}
+		}
+	}
 }
@@ -0,0 +1,188 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memcachedreceiver
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.uber.org/zap"
+)
+
+var fakeStats = map[string]string{
+	"stats\r\n": `STAT pid 1
+STAT curr_connections 10
+STAT total_connections 12
+STAT cmd_get 120
+STAT cmd_set 40
+STAT cmd_flush 0
+STAT cmd_touch 2
+STAT get_hits 90
+STAT get_misses 30
+STAT delete_misses 0
+STAT delete_hits 0
+STAT incr_misses 1
+STAT incr_hits 3
+STAT touch_hits 2
+STAT touch_misses 0
+STAT bytes 4096
+STAT evictions 5
+`,
+	"stats slabs\r\n": `STAT 1:chunk_size 96
+STAT 1:chunks_per_page 10922
+STAT 1:total_pages 1
+STAT 1:total_chunks 10922
+STAT 1:used_chunks 30
+STAT 1:free_chunks 10892
+STAT 1:mem_requested 2400
+STAT active_slabs 1
+STAT total_malloced 1048576
+`,
+	"stats items\r\n": `STAT items:1:number 30
+STAT items:1:age 3600
+STAT items:1:evicted 5
+STAT items:1:outofmemory 1
+`,
+}
+
+// newFakeMemcached serves the fake stats until the test ends.
+func newFakeMemcached(t *testing.T) string {
+	l, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { l.Close() })
+
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				rw := bufio.NewReadWriter(bufio.NewReader(conn), bufio.NewWriter(conn))
+				for {
+					cmd, err := rw.ReadString('\n')
+					if err != nil {
+						return
+					}
+					stats := strings.ReplaceAll(fakeStats[cmd], "\n", "\r\n")
+					fmt.Fprint(rw, stats+"END\r\n")
+					rw.Flush()
+				}
+			}()
+		}
+	}()
+	return l.Addr().String()
+}
+
+func TestScraper(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = newFakeMemcached(t)
+	cfg.Timeout = time.Second
+
+	rms, err := newMemcachedScraper(zap.NewNop(), cfg).Scrape(context.Background(), cfg.ID())
+	require.NoError(t, err)
+
+	values := datapointValues(t, rms)
+	assert.Equal(t, 4096.0, values["memcached.bytes"])
+	assert.Equal(t, 90.0, values["memcached.get_hits"])
+	assert.Equal(t, 5.0, values["memcached.evictions"])
+	assert.Equal(t, 120.0, values["memcached.commands command:get"])
+	assert.Equal(t, 40.0, values["memcached.commands command:set"])
+	assert.Equal(t, 2.0, values["memcached.commands command:touch"])
+	assert.Equal(t, 3.0, values["memcached.operations operation:increment type:hit"])
+	assert.Equal(t, 1.0, values["memcached.operations operation:increment type:miss"])
+	assert.Equal(t, 75.0, values["memcached.operation_hit_ratio operation:get"])
+	assert.Equal(t, 75.0, values["memcached.operation_hit_ratio operation:increment"])
+	assert.Equal(t, 100.0, values["memcached.operation_hit_ratio operation:touch"])
+	// No hit ratio without operations.
+	assert.NotContains(t, values, "memcached.operation_hit_ratio operation:delete")
+	assert.Equal(t, 0.0, values["memcached.operations operation:delete type:hit"])
+
+	for key := range values {
+		assert.False(t, strings.HasPrefix(key, "memcached.slab."), "slab stats are disabled by default: %s", key)
+	}
+}
+
+func TestScraperSlabStats(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = newFakeMemcached(t)
+	cfg.Timeout = time.Second
+	cfg.SlabStats = true
+
+	rms, err := newMemcachedScraper(zap.NewNop(), cfg).Scrape(context.Background(), cfg.ID())
+	require.NoError(t, err)
+
+	values := datapointValues(t, rms)
+	assert.Equal(t, 30.0, values["memcached.slab.chunks slab:1 state:used"])
+	assert.Equal(t, 10892.0, values["memcached.slab.chunks slab:1 state:free"])
+	assert.Equal(t, 96.0, values["memcached.slab.chunk_size slab:1"])
+	assert.Equal(t, 1.0, values["memcached.slab.pages slab:1"])
+	assert.Equal(t, 2400.0, values["memcached.slab.memory_requested slab:1"])
+	assert.Equal(t, 30.0, values["memcached.slab.items slab:1"])
+	assert.Equal(t, 3600.0, values["memcached.slab.item_age slab:1"])
+	assert.Equal(t, 5.0, values["memcached.slab.evictions slab:1"])
+	assert.Equal(t, 1.0, values["memcached.slab.out_of_memory slab:1"])
+}
+
+// datapointValues returns the value of each datapoint, keyed by the metric name followed by
+// the sorted labels of the datapoint.
+func datapointValues(t *testing.T, rms pdata.ResourceMetricsSlice) map[string]float64 {
+	require.Equal(t, 1, rms.Len())
+	ms := rms.At(0).InstrumentationLibraryMetrics().At(0).Metrics()
+
+	values := map[string]float64{}
+	add := func(name string, labels pdata.StringMap, value float64) {
+		var keys []string
+		labels.Range(func(k, v string) bool {
+			keys = append(keys, k+":"+v)
+			return true
+		})
+		sort.Strings(keys)
+		values[strings.Join(append([]string{name}, keys...), " ")] = value
+	}
+	for i := 0; i < ms.Len(); i++ {
+		m := ms.At(i)
+		switch m.DataType() {
+		case pdata.MetricDataTypeIntGauge:
+			for j := 0; j < m.IntGauge().DataPoints().Len(); j++ {
+				dp := m.IntGauge().DataPoints().At(j)
+				add(m.Name(), dp.LabelsMap(), float64(dp.Value()))
+			}
+		case pdata.MetricDataTypeIntSum:
+			for j := 0; j < m.IntSum().DataPoints().Len(); j++ {
+				dp := m.IntSum().DataPoints().At(j)
+				add(m.Name(), dp.LabelsMap(), float64(dp.Value()))
+			}
+		case pdata.MetricDataTypeDoubleGauge:
+			for j := 0; j < m.DoubleGauge().DataPoints().Len(); j++ {
+				dp := m.DoubleGauge().DataPoints().At(j)
+				add(m.Name(), dp.LabelsMap(), dp.Value())
+			}
+		default:
+			t.Fatalf("unexpected data type %v of %s", m.DataType(), m.Name())
+		}
+	}
+	return values
+}
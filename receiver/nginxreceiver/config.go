@@ -15,11 +15,33 @@
 package nginxreceiver
 
 import (
+	"fmt"
+
 	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/receiver/scraperhelper"
 )
 
+const (
+	moduleStubStatus = "stub_status"
+	modulePlus       = "plus"
+	moduleVTS        = "vts"
+)
+
 type Config struct {
 	scraperhelper.ScraperControllerSettings `mapstructure:",squash"`
 	confighttp.HTTPClientSettings           `mapstructure:",squash"`
+
+	// Module is the nginx module serving the stats at the endpoint: "stub_status" for
+	// ngx_http_stub_status_module, "plus" for the NGINX Plus REST API or "vts" for the JSON
+	// output of nginx-module-vts. Default is "stub_status".
+	Module string `mapstructure:"module"`
+}
+
+// Validate checks the receiver configuration is valid.
+func (cfg *Config) Validate() error {
+	switch cfg.Module {
+	case moduleStubStatus, modulePlus, moduleVTS:
+		return nil
+	}
+	return fmt.Errorf("invalid module %q, must be one of %q, %q or %q", cfg.Module, moduleStubStatus, modulePlus, moduleVTS)
 }
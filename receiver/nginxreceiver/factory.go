@@ -50,6 +50,7 @@ func createDefaultConfig() config.Receiver {
 			Endpoint: "http://localhost:80/status",
 			Timeout:  10 * time.Second,
 		},
+		Module: moduleStubStatus,
 	}
 }
 
@@ -39,6 +39,13 @@ func TestValidConfig(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestInvalidModule(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.Module = "status"
+	require.Error(t, cfg.Validate())
+}
+
 func TestCreateMetricsReceiver(t *testing.T) {
 	factory := NewFactory()
 	metricsReceiver, err := factory.CreateMetricsReceiver(
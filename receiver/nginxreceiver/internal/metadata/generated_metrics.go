@@ -55,10 +55,19 @@ func (m *metricImpl) Init(metric pdata.Metric) {
 }
 
 type metricStruct struct {
-	NginxConnectionsAccepted MetricIntf
-	NginxConnectionsCurrent  MetricIntf
-	NginxConnectionsHandled  MetricIntf
-	NginxRequests            MetricIntf
+	NginxConnectionsAccepted      MetricIntf
+	NginxConnectionsCurrent       MetricIntf
+	NginxConnectionsHandled       MetricIntf
+	NginxRequests                 MetricIntf
+	NginxServerZoneIo             MetricIntf
+	NginxServerZoneRequests       MetricIntf
+	NginxServerZoneResponses      MetricIntf
+	NginxUpstreamPeerConnections  MetricIntf
+	NginxUpstreamPeerFails        MetricIntf
+	NginxUpstreamPeerIo           MetricIntf
+	NginxUpstreamPeerRequests     MetricIntf
+	NginxUpstreamPeerResponseTime MetricIntf
+	NginxUpstreamPeerResponses    MetricIntf
 }
 
 // Names returns a list of all the metric name strings.
@@ -68,14 +77,32 @@ func (m *metricStruct) Names() []string {
 		"nginx.connections_current",
 		"nginx.connections_handled",
 		"nginx.requests",
+		"nginx.server_zone.io",
+		"nginx.server_zone.requests",
+		"nginx.server_zone.responses",
+		"nginx.upstream.peer.connections",
+		"nginx.upstream.peer.fails",
+		"nginx.upstream.peer.io",
+		"nginx.upstream.peer.requests",
+		"nginx.upstream.peer.response_time",
+		"nginx.upstream.peer.responses",
 	}
 }
 
 var metricsByName = map[string]MetricIntf{
-	"nginx.connections_accepted": Metrics.NginxConnectionsAccepted,
-	"nginx.connections_current":  Metrics.NginxConnectionsCurrent,
-	"nginx.connections_handled":  Metrics.NginxConnectionsHandled,
-	"nginx.requests":             Metrics.NginxRequests,
+	"nginx.connections_accepted":        Metrics.NginxConnectionsAccepted,
+	"nginx.connections_current":         Metrics.NginxConnectionsCurrent,
+	"nginx.connections_handled":         Metrics.NginxConnectionsHandled,
+	"nginx.requests":                    Metrics.NginxRequests,
+	"nginx.server_zone.io":              Metrics.NginxServerZoneIo,
+	"nginx.server_zone.requests":        Metrics.NginxServerZoneRequests,
+	"nginx.server_zone.responses":       Metrics.NginxServerZoneResponses,
+	"nginx.upstream.peer.connections":   Metrics.NginxUpstreamPeerConnections,
+	"nginx.upstream.peer.fails":         Metrics.NginxUpstreamPeerFails,
+	"nginx.upstream.peer.io":            Metrics.NginxUpstreamPeerIo,
+	"nginx.upstream.peer.requests":      Metrics.NginxUpstreamPeerRequests,
+	"nginx.upstream.peer.response_time": Metrics.NginxUpstreamPeerResponseTime,
+	"nginx.upstream.peer.responses":     Metrics.NginxUpstreamPeerResponses,
 }
 
 func (m *metricStruct) ByName(n string) MetricIntf {
@@ -84,10 +111,19 @@ func (m *metricStruct) ByName(n string) MetricIntf {
 
 func (m *metricStruct) FactoriesByName() map[string]func(pdata.Metric) {
 	return map[string]func(pdata.Metric){
-		Metrics.NginxConnectionsAccepted.Name(): Metrics.NginxConnectionsAccepted.Init,
-		Metrics.NginxConnectionsCurrent.Name():  Metrics.NginxConnectionsCurrent.Init,
-		Metrics.NginxConnectionsHandled.Name():  Metrics.NginxConnectionsHandled.Init,
-		Metrics.NginxRequests.Name():            Metrics.NginxRequests.Init,
+		Metrics.NginxConnectionsAccepted.Name():      Metrics.NginxConnectionsAccepted.Init,
+		Metrics.NginxConnectionsCurrent.Name():       Metrics.NginxConnectionsCurrent.Init,
+		Metrics.NginxConnectionsHandled.Name():       Metrics.NginxConnectionsHandled.Init,
+		Metrics.NginxRequests.Name():                 Metrics.NginxRequests.Init,
+		Metrics.NginxServerZoneIo.Name():             Metrics.NginxServerZoneIo.Init,
+		Metrics.NginxServerZoneRequests.Name():       Metrics.NginxServerZoneRequests.Init,
+		Metrics.NginxServerZoneResponses.Name():      Metrics.NginxServerZoneResponses.Init,
+		Metrics.NginxUpstreamPeerConnections.Name():  Metrics.NginxUpstreamPeerConnections.Init,
+		Metrics.NginxUpstreamPeerFails.Name():        Metrics.NginxUpstreamPeerFails.Init,
+		Metrics.NginxUpstreamPeerIo.Name():           Metrics.NginxUpstreamPeerIo.Init,
+		Metrics.NginxUpstreamPeerRequests.Name():     Metrics.NginxUpstreamPeerRequests.Init,
+		Metrics.NginxUpstreamPeerResponseTime.Name(): Metrics.NginxUpstreamPeerResponseTime.Init,
+		Metrics.NginxUpstreamPeerResponses.Name():    Metrics.NginxUpstreamPeerResponses.Init,
 	}
 }
 
@@ -136,6 +172,101 @@ var Metrics = &metricStruct{
 			metric.IntSum().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
 		},
 	},
+	&metricImpl{
+		"nginx.server_zone.io",
+		func(metric pdata.Metric) {
+			metric.SetName("nginx.server_zone.io")
+			metric.SetDescription("Total number of bytes received from and sent to the clients by the server zone. Only reported with the plus and vts modules.")
+			metric.SetUnit("By")
+			metric.SetDataType(pdata.MetricDataTypeIntSum)
+			metric.IntSum().SetIsMonotonic(true)
+			metric.IntSum().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+		},
+	},
+	&metricImpl{
+		"nginx.server_zone.requests",
+		func(metric pdata.Metric) {
+			metric.SetName("nginx.server_zone.requests")
+			metric.SetDescription("Total number of client requests processed by the server zone. Only reported with the plus and vts modules.")
+			metric.SetUnit("requests")
+			metric.SetDataType(pdata.MetricDataTypeIntSum)
+			metric.IntSum().SetIsMonotonic(true)
+			metric.IntSum().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+		},
+	},
+	&metricImpl{
+		"nginx.server_zone.responses",
+		func(metric pdata.Metric) {
+			metric.SetName("nginx.server_zone.responses")
+			metric.SetDescription("Total number of responses sent to the clients by the server zone, by status code class. Only reported with the plus and vts modules.")
+			metric.SetUnit("responses")
+			metric.SetDataType(pdata.MetricDataTypeIntSum)
+			metric.IntSum().SetIsMonotonic(true)
+			metric.IntSum().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+		},
+	},
+	&metricImpl{
+		"nginx.upstream.peer.connections",
+		func(metric pdata.Metric) {
+			metric.SetName("nginx.upstream.peer.connections")
+			metric.SetDescription("The current number of active connections to the upstream server. Only reported with the plus module.")
+			metric.SetUnit("connections")
+			metric.SetDataType(pdata.MetricDataTypeIntGauge)
+		},
+	},
+	&metricImpl{
+		"nginx.upstream.peer.fails",
+		func(metric pdata.Metric) {
+			metric.SetName("nginx.upstream.peer.fails")
+			metric.SetDescription("Total number of unsuccessful attempts to communicate with the upstream server. Only reported with the plus module.")
+			metric.SetUnit("attempts")
+			metric.SetDataType(pdata.MetricDataTypeIntSum)
+			metric.IntSum().SetIsMonotonic(true)
+			metric.IntSum().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+		},
+	},
+	&metricImpl{
+		"nginx.upstream.peer.io",
+		func(metric pdata.Metric) {
+			metric.SetName("nginx.upstream.peer.io")
+			metric.SetDescription("Total number of bytes received from and sent to the upstream server. Only reported with the plus and vts modules.")
+			metric.SetUnit("By")
+			metric.SetDataType(pdata.MetricDataTypeIntSum)
+			metric.IntSum().SetIsMonotonic(true)
+			metric.IntSum().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+		},
+	},
+	&metricImpl{
+		"nginx.upstream.peer.requests",
+		func(metric pdata.Metric) {
+			metric.SetName("nginx.upstream.peer.requests")
+			metric.SetDescription("Total number of client requests forwarded to the upstream server. Only reported with the plus and vts modules.")
+			metric.SetUnit("requests")
+			metric.SetDataType(pdata.MetricDataTypeIntSum)
+			metric.IntSum().SetIsMonotonic(true)
+			metric.IntSum().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+		},
+	},
+	&metricImpl{
+		"nginx.upstream.peer.response_time",
+		func(metric pdata.Metric) {
+			metric.SetName("nginx.upstream.peer.response_time")
+			metric.SetDescription("The average time to get the full response from the upstream server. Only reported with the plus and vts modules.")
+			metric.SetUnit("ms")
+			metric.SetDataType(pdata.MetricDataTypeIntGauge)
+		},
+	},
+	&metricImpl{
+		"nginx.upstream.peer.responses",
+		func(metric pdata.Metric) {
+			metric.SetName("nginx.upstream.peer.responses")
+			metric.SetDescription("Total number of responses obtained from the upstream server, by status code class. Only reported with the plus and vts modules.")
+			metric.SetUnit("responses")
+			metric.SetDataType(pdata.MetricDataTypeIntSum)
+			metric.IntSum().SetIsMonotonic(true)
+			metric.IntSum().SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+		},
+	},
 }
 
 // M contains a set of methods for each metric that help with
@@ -144,16 +275,40 @@ var M = Metrics
 
 // Labels contains the possible metric labels that can be used.
 var Labels = struct {
+	// Direction (The direction of the transferred bytes)
+	Direction string
+	// Peer (The address of the upstream server)
+	Peer string
+	// ServerZone (The name of the server zone)
+	ServerZone string
 	// State (The state of a connection)
 	State string
+	// StatusRange (The class of the response status code (1xx, 2xx, 3xx, 4xx or 5xx))
+	StatusRange string
+	// Upstream (The name of the upstream group)
+	Upstream string
 }{
+	"direction",
+	"peer",
+	"server_zone",
 	"state",
+	"status_range",
+	"upstream",
 }
 
 // L contains the possible metric labels that can be used. L is an alias for
 // Labels.
 var L = Labels
 
+// LabelDirection are the possible values that the label "direction" can have.
+var LabelDirection = struct {
+	Received string
+	Sent     string
+}{
+	"received",
+	"sent",
+}
+
 // LabelState are the possible values that the label "state" can have.
 var LabelState = struct {
 	Active  string
@@ -0,0 +1,108 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nginxreceiver
+
+import (
+	"context"
+	"strings"
+
+	"go.opentelemetry.io/collector/consumer/simple"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/nginxreceiver/internal/metadata"
+)
+
+// plusConnections is the response of the /connections endpoint of the NGINX Plus API.
+type plusConnections struct {
+	Accepted int64 `json:"accepted"`
+	Dropped  int64 `json:"dropped"`
+	Active   int64 `json:"active"`
+	Idle     int64 `json:"idle"`
+}
+
+// plusRequests is the response of the /http/requests endpoint of the NGINX Plus API.
+type plusRequests struct {
+	Total int64 `json:"total"`
+}
+
+// plusServerZone is a server zone of the /http/server_zones endpoint of the NGINX Plus API.
+type plusServerZone struct {
+	Requests  int64            `json:"requests"`
+	Responses map[string]int64 `json:"responses"`
+	Received  int64            `json:"received"`
+	Sent      int64            `json:"sent"`
+}
+
+// plusUpstream is an upstream group of the /http/upstreams endpoint of the NGINX Plus API.
+type plusUpstream struct {
+	Peers []struct {
+		Server       string           `json:"server"`
+		Active       int64            `json:"active"`
+		Requests     int64            `json:"requests"`
+		Responses    map[string]int64 `json:"responses"`
+		Received     int64            `json:"received"`
+		Sent         int64            `json:"sent"`
+		Fails        int64            `json:"fails"`
+		ResponseTime int64            `json:"response_time"`
+	} `json:"peers"`
+}
+
+// scrapePlus scrapes the NGINX Plus REST API, the endpoint being the root of a version of the
+// API, e.g. http://localhost:8080/api/6.
+func (r *nginxScraper) scrapePlus(ctx context.Context, metrics *simple.Metrics) error {
+	endpoint := strings.TrimSuffix(r.cfg.Endpoint, "/")
+
+	var connections plusConnections
+	if err := r.getJSON(ctx, endpoint+"/connections", &connections); err != nil {
+		return err
+	}
+	var requests plusRequests
+	if err := r.getJSON(ctx, endpoint+"/http/requests", &requests); err != nil {
+		return err
+	}
+	var serverZones map[string]plusServerZone
+	if err := r.getJSON(ctx, endpoint+"/http/server_zones", &serverZones); err != nil {
+		return err
+	}
+	var upstreams map[string]plusUpstream
+	if err := r.getJSON(ctx, endpoint+"/http/upstreams", &upstreams); err != nil {
+		return err
+	}
+
+	metrics.AddSumDataPoint(metadata.M.NginxRequests.Name(), requests.Total)
+	metrics.AddSumDataPoint(metadata.M.NginxConnectionsAccepted.Name(), connections.Accepted)
+	metrics.AddSumDataPoint(metadata.M.NginxConnectionsHandled.Name(), connections.Accepted-connections.Dropped)
+	metrics.WithLabels(map[string]string{metadata.L.State: metadata.LabelState.Active}).AddGaugeDataPoint(metadata.M.NginxConnectionsCurrent.Name(), connections.Active)
+	metrics.WithLabels(map[string]string{metadata.L.State: metadata.LabelState.Waiting}).AddGaugeDataPoint(metadata.M.NginxConnectionsCurrent.Name(), connections.Idle)
+
+	for name, zone := range serverZones {
+		zoneMetrics := metrics.WithLabels(map[string]string{metadata.L.ServerZone: name})
+		zoneMetrics.AddSumDataPoint(metadata.M.NginxServerZoneRequests.Name(), zone.Requests)
+		addResponses(zoneMetrics, metadata.M.NginxServerZoneResponses.Name(), zone.Responses)
+		addIO(zoneMetrics, metadata.M.NginxServerZoneIo.Name(), zone.Received, zone.Sent)
+	}
+
+	for name, upstream := range upstreams {
+		for _, peer := range upstream.Peers {
+			peerMetrics := metrics.WithLabels(map[string]string{metadata.L.Upstream: name, metadata.L.Peer: peer.Server})
+			peerMetrics.AddSumDataPoint(metadata.M.NginxUpstreamPeerRequests.Name(), peer.Requests)
+			addResponses(peerMetrics, metadata.M.NginxUpstreamPeerResponses.Name(), peer.Responses)
+			addIO(peerMetrics, metadata.M.NginxUpstreamPeerIo.Name(), peer.Received, peer.Sent)
+			peerMetrics.AddGaugeDataPoint(metadata.M.NginxUpstreamPeerResponseTime.Name(), peer.ResponseTime)
+			peerMetrics.AddGaugeDataPoint(metadata.M.NginxUpstreamPeerConnections.Name(), peer.Active)
+			peerMetrics.AddSumDataPoint(metadata.M.NginxUpstreamPeerFails.Name(), peer.Fails)
+		}
+	}
+	return nil
+}
@@ -16,6 +16,8 @@ package nginxreceiver
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"net/http"
 	"time"
 
@@ -56,7 +58,31 @@ func (r *nginxScraper) start(_ context.Context, host component.Host) error {
 	return nil
 }
 
-func (r *nginxScraper) scrape(context.Context) (pdata.ResourceMetricsSlice, error) {
+func (r *nginxScraper) scrape(ctx context.Context) (pdata.ResourceMetricsSlice, error) {
+	metrics := simple.Metrics{
+		Metrics:                    pdata.NewMetrics(),
+		Timestamp:                  time.Now(),
+		MetricFactoriesByName:      metadata.M.FactoriesByName(),
+		InstrumentationLibraryName: "otelcol/nginx",
+	}
+
+	var err error
+	switch r.cfg.Module {
+	case modulePlus:
+		err = r.scrapePlus(ctx, &metrics)
+	case moduleVTS:
+		err = r.scrapeVTS(ctx, &metrics)
+	default:
+		err = r.scrapeStubStatus(&metrics)
+	}
+	if err != nil {
+		return pdata.ResourceMetricsSlice{}, err
+	}
+
+	return metrics.Metrics.ResourceMetrics(), nil
+}
+
+func (r *nginxScraper) scrapeStubStatus(metrics *simple.Metrics) error {
 	// Init client in scrape method in case there are transient errors in the
 	// constructor.
 	if r.client == nil {
@@ -64,21 +90,14 @@ func (r *nginxScraper) scrape(context.Context) (pdata.ResourceMetricsSlice, erro
 		r.client, err = client.NewNginxClient(r.httpClient, r.cfg.HTTPClientSettings.Endpoint)
 		if err != nil {
 			r.client = nil
-			return pdata.ResourceMetricsSlice{}, err
+			return err
 		}
 	}
 
-	metrics := simple.Metrics{
-		Metrics:                    pdata.NewMetrics(),
-		Timestamp:                  time.Now(),
-		MetricFactoriesByName:      metadata.M.FactoriesByName(),
-		InstrumentationLibraryName: "otelcol/nginx",
-	}
-
 	stats, err := r.client.GetStubStats()
 	if err != nil {
 		r.logger.Error("Failed to fetch nginx stats", zap.Error(err))
-		return pdata.ResourceMetricsSlice{}, err
+		return err
 	}
 
 	metrics.AddSumDataPoint(metadata.M.NginxRequests.Name(), stats.Requests)
@@ -89,6 +108,39 @@ func (r *nginxScraper) scrape(context.Context) (pdata.ResourceMetricsSlice, erro
 	metrics.WithLabels(map[string]string{metadata.L.State: metadata.LabelState.Reading}).AddGaugeDataPoint(metadata.M.NginxConnectionsCurrent.Name(), stats.Connections.Reading)
 	metrics.WithLabels(map[string]string{metadata.L.State: metadata.LabelState.Writing}).AddGaugeDataPoint(metadata.M.NginxConnectionsCurrent.Name(), stats.Connections.Writing)
 	metrics.WithLabels(map[string]string{metadata.L.State: metadata.LabelState.Waiting}).AddGaugeDataPoint(metadata.M.NginxConnectionsCurrent.Name(), stats.Connections.Waiting)
+	return nil
+}
 
-	return metrics.Metrics.ResourceMetrics(), nil
+// getJSON decodes the JSON document served at the given URL into v.
+func (r *nginxScraper) getJSON(ctx context.Context, url string, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("expected 200 response from %s, got %d", url, resp.StatusCode)
+	}
+	if err = json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("failed to decode response from %s: %w", url, err)
+	}
+	return nil
+}
+
+// addResponses adds a datapoint for each status code class of the responses.
+func addResponses(metrics *simple.Metrics, name string, responses map[string]int64) {
+	for _, statusRange := range []string{"1xx", "2xx", "3xx", "4xx", "5xx"} {
+		metrics.WithLabels(map[string]string{metadata.L.StatusRange: statusRange}).AddSumDataPoint(name, responses[statusRange])
+	}
+}
+
+// addIO adds the received and sent bytes datapoints.
+func addIO(metrics *simple.Metrics, name string, received, sent int64) {
+	metrics.WithLabels(map[string]string{metadata.L.Direction: metadata.LabelDirection.Received}).AddSumDataPoint(name, received)
+	metrics.WithLabels(map[string]string{metadata.L.Direction: metadata.LabelDirection.Sent}).AddSumDataPoint(name, sent)
 }
@@ -20,6 +20,9 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"path/filepath"
+	"sort"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -101,6 +104,147 @@ Reading: 6 Writing: 179 Waiting: 106
 	}, metricValues)
 }
 
+func TestScraperPlus(t *testing.T) {
+	files := map[string]string{
+		"/api/6/connections":       "connections.json",
+		"/api/6/http/requests":     "requests.json",
+		"/api/6/http/server_zones": "server_zones.json",
+		"/api/6/http/upstreams":    "upstreams.json",
+	}
+	nginxMock := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		file, ok := files[req.URL.Path]
+		if !ok {
+			rw.WriteHeader(404)
+			return
+		}
+		http.ServeFile(rw, req, filepath.Join("testdata", "plus", file))
+	}))
+	defer nginxMock.Close()
+
+	sc := newNginxScraper(zap.NewNop(), &Config{
+		HTTPClientSettings: confighttp.HTTPClientSettings{
+			Endpoint: nginxMock.URL + "/api/6/",
+		},
+		Module: modulePlus,
+	})
+	require.NoError(t, sc.start(context.Background(), componenttest.NewNopHost()))
+	rms, err := sc.scrape(context.Background())
+	require.NoError(t, err)
+
+	zone := "server_zone:hg.nginx.org"
+	peer := "peer:10.0.0.1:8080 upstream:trac-backend"
+	peerResponses, upstream := "nginx.upstream.peer.responses peer:10.0.0.1:8080 status_range:", "upstream:trac-backend"
+	require.Equal(t, map[string]int64{
+		"nginx.connections_accepted":                                4968119,
+		"nginx.connections_handled":                                 4968117,
+		"nginx.requests":                                            10624511,
+		"nginx.connections_current state:active":                    5,
+		"nginx.connections_current state:waiting":                   117,
+		"nginx.server_zone.requests " + zone:                        175276,
+		"nginx.server_zone.responses " + zone + " status_range:1xx": 0,
+		"nginx.server_zone.responses " + zone + " status_range:2xx": 162948,
+		"nginx.server_zone.responses " + zone + " status_range:3xx": 10117,
+		"nginx.server_zone.responses " + zone + " status_range:4xx": 2125,
+		"nginx.server_zone.responses " + zone + " status_range:5xx": 86,
+		"nginx.server_zone.io direction:received " + zone:           37325865,
+		"nginx.server_zone.io direction:sent " + zone:               5543709943,
+		"nginx.upstream.peer.requests " + peer:                      5213,
+		peerResponses + "1xx " + upstream:                           0,
+		peerResponses + "2xx " + upstream:                           5005,
+		peerResponses + "3xx " + upstream:                           12,
+		peerResponses + "4xx " + upstream:                           190,
+		peerResponses + "5xx " + upstream:                           6,
+		"nginx.upstream.peer.io direction:received " + peer:         93212476,
+		"nginx.upstream.peer.io direction:sent " + peer:             2196013,
+		"nginx.upstream.peer.response_time " + peer:                 85,
+		"nginx.upstream.peer.connections " + peer:                   3,
+		"nginx.upstream.peer.fails " + peer:                         1,
+	}, datapointValues(t, rms))
+}
+
+func TestScraperVTS(t *testing.T) {
+	nginxMock := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.URL.Path == "/status/format/json" {
+			http.ServeFile(rw, req, filepath.Join("testdata", "vts", "status.json"))
+			return
+		}
+		rw.WriteHeader(404)
+	}))
+	defer nginxMock.Close()
+
+	sc := newNginxScraper(zap.NewNop(), &Config{
+		HTTPClientSettings: confighttp.HTTPClientSettings{
+			Endpoint: nginxMock.URL + "/status/format/json",
+		},
+		Module: moduleVTS,
+	})
+	require.NoError(t, sc.start(context.Background(), componenttest.NewNopHost()))
+	rms, err := sc.scrape(context.Background())
+	require.NoError(t, err)
+
+	zone := "server_zone:localhost"
+	peer := "peer:127.0.0.1:8081 upstream:backend"
+	peerResponses, upstream := "nginx.upstream.peer.responses peer:127.0.0.1:8081 status_range:", "upstream:backend"
+	require.Equal(t, map[string]int64{
+		"nginx.connections_accepted":                                120,
+		"nginx.connections_handled":                                 119,
+		"nginx.requests":                                            542,
+		"nginx.connections_current state:active":                    2,
+		"nginx.connections_current state:reading":                   0,
+		"nginx.connections_current state:writing":                   1,
+		"nginx.connections_current state:waiting":                   1,
+		"nginx.server_zone.requests " + zone:                        540,
+		"nginx.server_zone.responses " + zone + " status_range:1xx": 0,
+		"nginx.server_zone.responses " + zone + " status_range:2xx": 520,
+		"nginx.server_zone.responses " + zone + " status_range:3xx": 3,
+		"nginx.server_zone.responses " + zone + " status_range:4xx": 15,
+		"nginx.server_zone.responses " + zone + " status_range:5xx": 2,
+		"nginx.server_zone.io direction:received " + zone:           83200,
+		"nginx.server_zone.io direction:sent " + zone:               1460022,
+		"nginx.upstream.peer.requests " + peer:                      300,
+		peerResponses + "1xx " + upstream:                           0,
+		peerResponses + "2xx " + upstream:                           298,
+		peerResponses + "3xx " + upstream:                           0,
+		peerResponses + "4xx " + upstream:                           1,
+		peerResponses + "5xx " + upstream:                           1,
+		"nginx.upstream.peer.io direction:received " + peer:         48000,
+		"nginx.upstream.peer.io direction:sent " + peer:             810000,
+		"nginx.upstream.peer.response_time " + peer:                 12,
+	}, datapointValues(t, rms))
+}
+
+// datapointValues returns the value of each datapoint, keyed by the metric name followed by
+// the sorted labels of the datapoint.
+func datapointValues(t *testing.T, rms pdata.ResourceMetricsSlice) map[string]int64 {
+	require.Equal(t, 1, rms.Len())
+	ms := rms.At(0).InstrumentationLibraryMetrics().At(0).Metrics()
+
+	values := map[string]int64{}
+	for i := 0; i < ms.Len(); i++ {
+		m := ms.At(i)
+		var dps pdata.IntDataPointSlice
+		switch m.DataType() {
+		case pdata.MetricDataTypeIntGauge:
+			dps = m.IntGauge().DataPoints()
+		case pdata.MetricDataTypeIntSum:
+			dps = m.IntSum().DataPoints()
+		default:
+			t.Fatalf("unexpected data type %v of %s", m.DataType(), m.Name())
+		}
+		for j := 0; j < dps.Len(); j++ {
+			key := []string{m.Name()}
+			var labels []string
+			dps.At(j).LabelsMap().Range(func(k, v string) bool {
+				labels = append(labels, k+":"+v)
+				return true
+			})
+			sort.Strings(labels)
+			values[strings.Join(append(key, labels...), " ")] = dps.At(j).Value()
+		}
+	}
+	return values
+}
+
 func TestScraperError(t *testing.T) {
 	nginxMock := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
 		if req.URL.Path == "/status" {
@@ -0,0 +1,85 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nginxreceiver
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/consumer/simple"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/nginxreceiver/internal/metadata"
+)
+
+// vtsTraffic holds the traffic counters of a server zone or an upstream server of nginx-module-vts.
+type vtsTraffic struct {
+	RequestCounter int64            `json:"requestCounter"`
+	InBytes        int64            `json:"inBytes"`
+	OutBytes       int64            `json:"outBytes"`
+	Responses      map[string]int64 `json:"responses"`
+}
+
+// vtsStatus is the JSON output of nginx-module-vts, e.g. served at /status/format/json.
+type vtsStatus struct {
+	Connections struct {
+		Active   int64 `json:"active"`
+		Reading  int64 `json:"reading"`
+		Writing  int64 `json:"writing"`
+		Waiting  int64 `json:"waiting"`
+		Accepted int64 `json:"accepted"`
+		Handled  int64 `json:"handled"`
+		Requests int64 `json:"requests"`
+	} `json:"connections"`
+	ServerZones   map[string]vtsTraffic `json:"serverZones"`
+	UpstreamZones map[string][]struct {
+		vtsTraffic
+		Server       string `json:"server"`
+		ResponseMsec int64  `json:"responseMsec"`
+	} `json:"upstreamZones"`
+}
+
+// scrapeVTS scrapes the JSON output of nginx-module-vts, which also reports the stub_status stats.
+func (r *nginxScraper) scrapeVTS(ctx context.Context, metrics *simple.Metrics) error {
+	var status vtsStatus
+	if err := r.getJSON(ctx, r.cfg.Endpoint, &status); err != nil {
+		return err
+	}
+
+	connections := status.Connections
+	metrics.AddSumDataPoint(metadata.M.NginxRequests.Name(), connections.Requests)
+	metrics.AddSumDataPoint(metadata.M.NginxConnectionsAccepted.Name(), connections.Accepted)
+	metrics.AddSumDataPoint(metadata.M.NginxConnectionsHandled.Name(), connections.Handled)
+	metrics.WithLabels(map[string]string{metadata.L.State: metadata.LabelState.Active}).AddGaugeDataPoint(metadata.M.NginxConnectionsCurrent.Name(), connections.Active)
+	metrics.WithLabels(map[string]string{metadata.L.State: metadata.LabelState.Reading}).AddGaugeDataPoint(metadata.M.NginxConnectionsCurrent.Name(), connections.Reading)
+	metrics.WithLabels(map[string]string{metadata.L.State: metadata.LabelState.Writing}).AddGaugeDataPoint(metadata.M.NginxConnectionsCurrent.Name(), connections.Writing)
+	metrics.WithLabels(map[string]string{metadata.L.State: metadata.LabelState.Waiting}).AddGaugeDataPoint(metadata.M.NginxConnectionsCurrent.Name(), connections.Waiting)
+
+	for name, zone := range status.ServerZones {
+		zoneMetrics := metrics.WithLabels(map[string]string{metadata.L.ServerZone: name})
+		zoneMetrics.AddSumDataPoint(metadata.M.NginxServerZoneRequests.Name(), zone.RequestCounter)
+		addResponses(zoneMetrics, metadata.M.NginxServerZoneResponses.Name(), zone.Responses)
+		addIO(zoneMetrics, metadata.M.NginxServerZoneIo.Name(), zone.InBytes, zone.OutBytes)
+	}
+
+	for name, peers := range status.UpstreamZones {
+		for _, peer := range peers {
+			peerMetrics := metrics.WithLabels(map[string]string{metadata.L.Upstream: name, metadata.L.Peer: peer.Server})
+			peerMetrics.AddSumDataPoint(metadata.M.NginxUpstreamPeerRequests.Name(), peer.RequestCounter)
+			addResponses(peerMetrics, metadata.M.NginxUpstreamPeerResponses.Name(), peer.Responses)
+			addIO(peerMetrics, metadata.M.NginxUpstreamPeerIo.Name(), peer.InBytes, peer.OutBytes)
+			peerMetrics.AddGaugeDataPoint(metadata.M.NginxUpstreamPeerResponseTime.Name(), peer.ResponseMsec)
+		}
+	}
+	return nil
+}
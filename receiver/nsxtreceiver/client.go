@@ -0,0 +1,223 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nsxtreceiver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// resource is an entity listed by the NSX-T manager API.
+type resource struct {
+	ID          string `json:"id"`
+	DisplayName string `json:"display_name"`
+}
+
+type listResult struct {
+	Results []json.RawMessage `json:"results"`
+	Cursor  string            `json:"cursor"`
+}
+
+type transportNodeStatus struct {
+	Status     string `json:"status"`
+	NodeStatus struct {
+		SystemStatus struct {
+			// The memory and disk usage are reported in kilobytes.
+			MemUsed       int64 `json:"mem_used"`
+			DiskSpaceUsed int64 `json:"disk_space_used"`
+		} `json:"system_status"`
+	} `json:"node_status"`
+}
+
+type networkInterface struct {
+	InterfaceID string `json:"interface_id"`
+}
+
+type interfaceStats struct {
+	RxBytes   int64 `json:"rx_bytes"`
+	RxPackets int64 `json:"rx_packets"`
+	RxDropped int64 `json:"rx_dropped"`
+	RxErrors  int64 `json:"rx_errors"`
+	TxBytes   int64 `json:"tx_bytes"`
+	TxPackets int64 `json:"tx_packets"`
+	TxDropped int64 `json:"tx_dropped"`
+	TxErrors  int64 `json:"tx_errors"`
+}
+
+type trafficCounts struct {
+	Total   int64 `json:"total"`
+	Dropped int64 `json:"dropped"`
+}
+
+type logicalSwitchStats struct {
+	RxBytes   trafficCounts `json:"rx_bytes"`
+	RxPackets trafficCounts `json:"rx_packets"`
+	TxBytes   trafficCounts `json:"tx_bytes"`
+	TxPackets trafficCounts `json:"tx_packets"`
+}
+
+type firewallRuleStats struct {
+	RuleID       string `json:"rule_id"`
+	HitCount     int64  `json:"hit_count"`
+	PacketCount  int64  `json:"packet_count"`
+	ByteCount    int64  `json:"byte_count"`
+	SessionCount int64  `json:"session_count"`
+}
+
+// nsxtClient calls the NSX-T manager API.
+type nsxtClient struct {
+	httpClient *http.Client
+	endpoint   string
+	username   string
+	password   string
+}
+
+func newNSXTClient(httpClient *http.Client, cfg *Config) *nsxtClient {
+	return &nsxtClient{
+		httpClient: httpClient,
+		endpoint:   strings.TrimSuffix(cfg.Endpoint, "/"),
+		username:   cfg.Username,
+		password:   cfg.Password,
+	}
+}
+
+func (c *nsxtClient) transportNodes(ctx context.Context) ([]resource, error) {
+	return c.listResources(ctx, "/api/v1/transport-nodes")
+}
+
+func (c *nsxtClient) transportNodeStatus(ctx context.Context, nodeID string) (*transportNodeStatus, error) {
+	var status transportNodeStatus
+	if err := c.get(ctx, "/api/v1/transport-nodes/"+url.PathEscape(nodeID)+"/status", nil, &status); err != nil {
+		return nil, err
+	}
+	return &status, nil
+}
+
+func (c *nsxtClient) networkInterfaces(ctx context.Context, nodeID string) ([]networkInterface, error) {
+	var interfaces []networkInterface
+	err := c.list(ctx, "/api/v1/transport-nodes/"+url.PathEscape(nodeID)+"/network/interfaces", func(raw json.RawMessage) error {
+		var iface networkInterface
+		if err := json.Unmarshal(raw, &iface); err != nil {
+			return err
+		}
+		interfaces = append(interfaces, iface)
+		return nil
+	})
+	return interfaces, err
+}
+
+func (c *nsxtClient) interfaceStats(ctx context.Context, nodeID, interfaceID string) (*interfaceStats, error) {
+	var stats interfaceStats
+	path := "/api/v1/transport-nodes/" + url.PathEscape(nodeID) + "/network/interfaces/" + url.PathEscape(interfaceID) + "/stats"
+	if err := c.get(ctx, path, nil, &stats); err != nil {
+		return nil, err
+	}
+	return &stats, nil
+}
+
+func (c *nsxtClient) logicalSwitches(ctx context.Context) ([]resource, error) {
+	return c.listResources(ctx, "/api/v1/logical-switches")
+}
+
+func (c *nsxtClient) logicalSwitchStats(ctx context.Context, switchID string) (*logicalSwitchStats, error) {
+	var stats logicalSwitchStats
+	if err := c.get(ctx, "/api/v1/logical-switches/"+url.PathEscape(switchID)+"/statistics", nil, &stats); err != nil {
+		return nil, err
+	}
+	return &stats, nil
+}
+
+func (c *nsxtClient) firewallSections(ctx context.Context) ([]resource, error) {
+	return c.listResources(ctx, "/api/v1/firewall/sections")
+}
+
+func (c *nsxtClient) firewallRuleStats(ctx context.Context, sectionID string) ([]firewallRuleStats, error) {
+	var stats []firewallRuleStats
+	err := c.list(ctx, "/api/v1/firewall/sections/"+url.PathEscape(sectionID)+"/rules/stats", func(raw json.RawMessage) error {
+		var rule firewallRuleStats
+		if err := json.Unmarshal(raw, &rule); err != nil {
+			return err
+		}
+		stats = append(stats, rule)
+		return nil
+	})
+	return stats, err
+}
+
+func (c *nsxtClient) listResources(ctx context.Context, path string) ([]resource, error) {
+	var resources []resource
+	err := c.list(ctx, path, func(raw json.RawMessage) error {
+		var r resource
+		if err := json.Unmarshal(raw, &r); err != nil {
+			return err
+		}
+		resources = append(resources, r)
+		return nil
+	})
+	return resources, err
+}
+
+// list calls fn with each result of the list served at path, following the
+// cursor of paginated lists.
+func (c *nsxtClient) list(ctx context.Context, path string, fn func(json.RawMessage) error) error {
+	query := url.Values{}
+	for {
+		var page listResult
+		if err := c.get(ctx, path, query, &page); err != nil {
+			return err
+		}
+		for _, raw := range page.Results {
+			if err := fn(raw); err != nil {
+				return fmt.Errorf("failed to decode result of %s: %w", path, err)
+			}
+		}
+		if page.Cursor == "" || len(page.Results) == 0 {
+			return nil
+		}
+		query.Set("cursor", page.Cursor)
+	}
+}
+
+// get decodes the JSON document served at path into v.
+func (c *nsxtClient) get(ctx context.Context, path string, query url.Values, v interface{}) error {
+	u := c.endpoint + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return err
+	}
+	req.SetBasicAuth(c.username, c.password)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("expected 200 response from %s, got %d", path, resp.StatusCode)
+	}
+	if err = json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("failed to decode response from %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nsxtreceiver
+
+import (
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/config/configtest"
+	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/receiver/scraperhelper"
+)
+
+func TestLoadConfig(t *testing.T) {
+	require.NoError(t, os.Setenv("NSXT_PASSWORD", "from-env"))
+	defer os.Unsetenv("NSXT_PASSWORD")
+
+	factories, err := componenttest.NopFactories()
+	require.NoError(t, err)
+
+	factory := NewFactory()
+	factories.Receivers[typeStr] = factory
+
+	cfg, err := configtest.LoadConfigAndValidate(path.Join(".", "testdata", "config.yaml"), factories)
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	defaultCfg := factory.CreateDefaultConfig().(*Config)
+	defaultCfg.Endpoint = "https://nsx-manager.example.com"
+	defaultCfg.Username = "admin"
+	defaultCfg.Password = "secret"
+	assert.Equal(t, defaultCfg, cfg.Receivers[config.NewID(typeStr)])
+	assert.Equal(t,
+		&Config{
+			ScraperControllerSettings: scraperhelper.ScraperControllerSettings{
+				ReceiverSettings:   config.NewReceiverSettings(config.NewIDWithName(typeStr, "custom")),
+				CollectionInterval: 2 * time.Minute,
+			},
+			HTTPClientSettings: confighttp.HTTPClientSettings{
+				Endpoint: "https://10.0.0.10",
+				Timeout:  10 * time.Second,
+				TLSSetting: configtls.TLSClientSetting{
+					InsecureSkipVerify: true,
+				},
+			},
+			Username: "otel",
+			Password: "from-env",
+		},
+		cfg.Receivers[config.NewIDWithName(typeStr, "custom")],
+	)
+}
+
+func TestValidateConfig(t *testing.T) {
+	cases := []struct {
+		name     string
+		endpoint string
+		username string
+		expected string
+	}{
+		{name: "valid", endpoint: "https://nsx-manager.example.com", username: "admin"},
+		{name: "no endpoint", username: "admin", expected: `invalid endpoint "": scheme must be http or https`},
+		{name: "no host", endpoint: "https://", username: "admin", expected: `invalid endpoint "https://": host is missing`},
+		{name: "no username", endpoint: "https://nsx-manager.example.com", expected: "username must be specified"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.Endpoint = tc.endpoint
+			cfg.Username = tc.username
+			err := cfg.Validate()
+			if tc.expected == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,205 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nsxtreceiver
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/consumer/simple"
+	"go.opentelemetry.io/collector/receiver/scrapererror"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/nsxtreceiver/internal/metadata"
+)
+
+// nodeStatuses are the statuses reported for a transport node, in the lower
+// case form used as label value.
+var nodeStatuses = []string{
+	metadata.LabelStatus.Up,
+	metadata.LabelStatus.Degraded,
+	metadata.LabelStatus.Down,
+	metadata.LabelStatus.Unknown,
+}
+
+type nsxtScraper struct {
+	client *nsxtClient
+
+	logger *zap.Logger
+	cfg    *Config
+}
+
+func newNSXTScraper(logger *zap.Logger, cfg *Config) *nsxtScraper {
+	return &nsxtScraper{
+		logger: logger,
+		cfg:    cfg,
+	}
+}
+
+func (s *nsxtScraper) start(_ context.Context, host component.Host) error {
+	httpClient, err := s.cfg.ToClient(host.GetExtensions())
+	if err != nil {
+		return err
+	}
+	s.client = newNSXTClient(httpClient, s.cfg)
+	return nil
+}
+
+// scrape collects the stats of the transport nodes, logical switches and firewall
+// rules. A failure to get the stats of one of them is reported as a partial scrape
+// error, the stats of the others are still collected.
+func (s *nsxtScraper) scrape(ctx context.Context) (pdata.ResourceMetricsSlice, error) {
+	metrics := simple.Metrics{
+		Metrics:                    pdata.NewMetrics(),
+		Timestamp:                  time.Now(),
+		MetricFactoriesByName:      metadata.M.FactoriesByName(),
+		InstrumentationLibraryName: "otelcol/nsxt",
+	}
+
+	var errs scrapererror.ScrapeErrors
+	s.scrapeTransportNodes(ctx, &metrics, &errs)
+	s.scrapeLogicalSwitches(ctx, &metrics, &errs)
+	s.scrapeFirewallSections(ctx, &metrics, &errs)
+
+	return metrics.Metrics.ResourceMetrics(), errs.Combine()
+}
+
+func (s *nsxtScraper) scrapeTransportNodes(ctx context.Context, metrics *simple.Metrics, errs *scrapererror.ScrapeErrors) {
+	nodes, err := s.client.transportNodes(ctx)
+	if err != nil {
+		errs.Add(fmt.Errorf("failed to list transport nodes: %w", err))
+		return
+	}
+
+	for _, node := range nodes {
+		nm := metrics.WithLabels(map[string]string{
+			metadata.L.NodeID:   node.ID,
+			metadata.L.NodeName: node.DisplayName,
+		})
+
+		status, err := s.client.transportNodeStatus(ctx, node.ID)
+		if err != nil {
+			errs.AddPartial(1, fmt.Errorf("failed to get the status of transport node %q: %w", node.ID, err))
+		} else {
+			addNodeStatus(nm, status)
+		}
+
+		interfaces, err := s.client.networkInterfaces(ctx, node.ID)
+		if err != nil {
+			errs.AddPartial(1, fmt.Errorf("failed to list the interfaces of transport node %q: %w", node.ID, err))
+			continue
+		}
+		for _, iface := range interfaces {
+			stats, err := s.client.interfaceStats(ctx, node.ID, iface.InterfaceID)
+			if err != nil {
+				errs.AddPartial(1, fmt.Errorf("failed to get the stats of interface %q of transport node %q: %w", iface.InterfaceID, node.ID, err))
+				continue
+			}
+			addInterfaceStats(nm.WithLabels(map[string]string{metadata.L.Interface: iface.InterfaceID}), stats)
+		}
+	}
+}
+
+func (s *nsxtScraper) scrapeLogicalSwitches(ctx context.Context, metrics *simple.Metrics, errs *scrapererror.ScrapeErrors) {
+	switches, err := s.client.logicalSwitches(ctx)
+	if err != nil {
+		errs.Add(fmt.Errorf("failed to list logical switches: %w", err))
+		return
+	}
+
+	for _, ls := range switches {
+		stats, err := s.client.logicalSwitchStats(ctx, ls.ID)
+		if err != nil {
+			errs.AddPartial(1, fmt.Errorf("failed to get the stats of logical switch %q: %w", ls.ID, err))
+			continue
+		}
+		addLogicalSwitchStats(metrics.WithLabels(map[string]string{
+			metadata.L.LogicalSwitchID:   ls.ID,
+			metadata.L.LogicalSwitchName: ls.DisplayName,
+		}), stats)
+	}
+}
+
+func (s *nsxtScraper) scrapeFirewallSections(ctx context.Context, metrics *simple.Metrics, errs *scrapererror.ScrapeErrors) {
+	sections, err := s.client.firewallSections(ctx)
+	if err != nil {
+		errs.Add(fmt.Errorf("failed to list firewall sections: %w", err))
+		return
+	}
+
+	for _, section := range sections {
+		rules, err := s.client.firewallRuleStats(ctx, section.ID)
+		if err != nil {
+			errs.AddPartial(1, fmt.Errorf("failed to get the rule stats of firewall section %q: %w", section.ID, err))
+			continue
+		}
+		sm := metrics.WithLabels(map[string]string{
+			metadata.L.FirewallSectionID:   section.ID,
+			metadata.L.FirewallSectionName: section.DisplayName,
+		})
+		for _, rule := range rules {
+			rm := sm.WithLabels(map[string]string{metadata.L.FirewallRuleID: rule.RuleID})
+			rm.AddSumDataPoint(metadata.M.NsxtFirewallRuleHits.Name(), rule.HitCount)
+			rm.AddSumDataPoint(metadata.M.NsxtFirewallRulePacketCount.Name(), rule.PacketCount)
+			rm.AddSumDataPoint(metadata.M.NsxtFirewallRuleNetworkIo.Name(), rule.ByteCount)
+			rm.AddSumDataPoint(metadata.M.NsxtFirewallRuleSessions.Name(), rule.SessionCount)
+		}
+	}
+}
+
+func addNodeStatus(metrics *simple.Metrics, status *transportNodeStatus) {
+	current := strings.ToLower(status.Status)
+	for _, s := range nodeStatuses {
+		var value int64
+		if s == current {
+			value = 1
+		}
+		metrics.WithLabels(map[string]string{metadata.L.Status: s}).AddGaugeDataPoint(metadata.M.NsxtNodeStatus.Name(), value)
+	}
+
+	systemStatus := status.NodeStatus.SystemStatus
+	metrics.AddGaugeDataPoint(metadata.M.NsxtNodeMemoryUsage.Name(), systemStatus.MemUsed*1024)
+	metrics.AddGaugeDataPoint(metadata.M.NsxtNodeFilesystemUsage.Name(), systemStatus.DiskSpaceUsed*1024)
+}
+
+func addInterfaceStats(metrics *simple.Metrics, stats *interfaceStats) {
+	rx := metrics.WithLabels(map[string]string{metadata.L.Direction: metadata.LabelDirection.Received})
+	rx.AddSumDataPoint(metadata.M.NsxtNodeNetworkIo.Name(), stats.RxBytes)
+	rx.AddSumDataPoint(metadata.M.NsxtNodeNetworkPacketCount.Name(), stats.RxPackets)
+	rx.WithLabels(map[string]string{metadata.L.Type: metadata.LabelType.Dropped}).AddSumDataPoint(metadata.M.NsxtNodeNetworkPacketErrors.Name(), stats.RxDropped)
+	rx.WithLabels(map[string]string{metadata.L.Type: metadata.LabelType.Errored}).AddSumDataPoint(metadata.M.NsxtNodeNetworkPacketErrors.Name(), stats.RxErrors)
+
+	tx := metrics.WithLabels(map[string]string{metadata.L.Direction: metadata.LabelDirection.Transmitted})
+	tx.AddSumDataPoint(metadata.M.NsxtNodeNetworkIo.Name(), stats.TxBytes)
+	tx.AddSumDataPoint(metadata.M.NsxtNodeNetworkPacketCount.Name(), stats.TxPackets)
+	tx.WithLabels(map[string]string{metadata.L.Type: metadata.LabelType.Dropped}).AddSumDataPoint(metadata.M.NsxtNodeNetworkPacketErrors.Name(), stats.TxDropped)
+	tx.WithLabels(map[string]string{metadata.L.Type: metadata.LabelType.Errored}).AddSumDataPoint(metadata.M.NsxtNodeNetworkPacketErrors.Name(), stats.TxErrors)
+}
+
+func addLogicalSwitchStats(metrics *simple.Metrics, stats *logicalSwitchStats) {
+	rx := metrics.WithLabels(map[string]string{metadata.L.Direction: metadata.LabelDirection.Received})
+	rx.AddSumDataPoint(metadata.M.NsxtLogicalSwitchNetworkIo.Name(), stats.RxBytes.Total)
+	rx.AddSumDataPoint(metadata.M.NsxtLogicalSwitchNetworkPacketCount.Name(), stats.RxPackets.Total)
+	rx.AddSumDataPoint(metadata.M.NsxtLogicalSwitchNetworkPacketDropped.Name(), stats.RxPackets.Dropped)
+
+	tx := metrics.WithLabels(map[string]string{metadata.L.Direction: metadata.LabelDirection.Transmitted})
+	tx.AddSumDataPoint(metadata.M.NsxtLogicalSwitchNetworkIo.Name(), stats.TxBytes.Total)
+	tx.AddSumDataPoint(metadata.M.NsxtLogicalSwitchNetworkPacketCount.Name(), stats.TxPackets.Total)
+	tx.AddSumDataPoint(metadata.M.NsxtLogicalSwitchNetworkPacketDropped.Name(), stats.TxPackets.Dropped)
+}
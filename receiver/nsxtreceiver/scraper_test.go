@@ -0,0 +1,191 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nsxtreceiver
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/receiver/scrapererror"
+	"go.uber.org/zap"
+)
+
+const (
+	node1ID   = "f5045ed2-43ab-4a35-a2c5-d20c30a32292"
+	node2ID   = "0e0b1ce4-6ea8-4ea6-a2a6-4a1bf7a3e1b7"
+	switchID  = "3f5e1b3c-9d3a-4f2a-8f5e-6b0a3c7d2e10"
+	sectionID = "8e1b5d5c-7f36-4a8e-9cb4-5b2d1a6f4c21"
+)
+
+// newMockManager serves the NSX-T manager API responses in testdata/api. The
+// responses of the paths in failing are errors.
+func newMockManager(t *testing.T, failing ...string) *httptest.Server {
+	responses := map[string]string{
+		"/api/v1/transport-nodes":                                               "transport_nodes_1.json",
+		"/api/v1/transport-nodes?cursor=00011":                                  "transport_nodes_2.json",
+		"/api/v1/transport-nodes/" + node1ID + "/status":                        "transport_node_status.json",
+		"/api/v1/transport-nodes/" + node2ID + "/status":                        "transport_node_status.json",
+		"/api/v1/transport-nodes/" + node1ID + "/network/interfaces":            "interfaces.json",
+		"/api/v1/transport-nodes/" + node2ID + "/network/interfaces":            "interfaces.json",
+		"/api/v1/transport-nodes/" + node1ID + "/network/interfaces/vmk0/stats": "interface_stats.json",
+		"/api/v1/transport-nodes/" + node2ID + "/network/interfaces/vmk0/stats": "interface_stats.json",
+		"/api/v1/logical-switches":                                              "logical_switches.json",
+		"/api/v1/logical-switches/" + switchID + "/statistics":                  "logical_switch_stats.json",
+		"/api/v1/firewall/sections":                                             "firewall_sections.json",
+		"/api/v1/firewall/sections/" + sectionID + "/rules/stats":               "firewall_rule_stats.json",
+	}
+	for _, f := range failing {
+		delete(responses, f)
+	}
+
+	return httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if user, pass, ok := req.BasicAuth(); !ok || user != "admin" || pass != "secret" {
+			rw.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		file, ok := responses[req.URL.RequestURI()]
+		if !ok {
+			rw.WriteHeader(http.StatusNotFound)
+			return
+		}
+		body, err := ioutil.ReadFile(path.Join("testdata", "api", file))
+		require.NoError(t, err)
+		_, _ = rw.Write(body)
+	}))
+}
+
+func newTestScraper(t *testing.T, endpoint string) *nsxtScraper {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = endpoint
+	cfg.Username = "admin"
+	cfg.Password = "secret"
+	s := newNSXTScraper(zap.NewNop(), cfg)
+	require.NoError(t, s.start(context.Background(), componenttest.NewNopHost()))
+	return s
+}
+
+// dataPoints indexes the int datapoints of the scraped metrics by metric name
+// and labels.
+func dataPoints(t *testing.T, rms pdata.ResourceMetricsSlice) map[string]map[string]int64 {
+	require.Equal(t, 1, rms.Len())
+	ilms := rms.At(0).InstrumentationLibraryMetrics()
+	require.Equal(t, 1, ilms.Len())
+	assert.Equal(t, "otelcol/nsxt", ilms.At(0).InstrumentationLibrary().Name())
+
+	points := map[string]map[string]int64{}
+	ms := ilms.At(0).Metrics()
+	for i := 0; i < ms.Len(); i++ {
+		m := ms.At(i)
+		var dps pdata.IntDataPointSlice
+		switch m.DataType() {
+		case pdata.MetricDataTypeIntGauge:
+			dps = m.IntGauge().DataPoints()
+		case pdata.MetricDataTypeIntSum:
+			assert.True(t, m.IntSum().IsMonotonic())
+			dps = m.IntSum().DataPoints()
+		default:
+			t.Fatalf("unexpected data type %v of %s", m.DataType(), m.Name())
+		}
+		points[m.Name()] = map[string]int64{}
+		for j := 0; j < dps.Len(); j++ {
+			dp := dps.At(j)
+			points[m.Name()][labelsKey(dp.LabelsMap())] = dp.Value()
+		}
+	}
+	return points
+}
+
+// labelsKey returns the values of the labels identifying a datapoint in the tests.
+func labelsKey(labels pdata.StringMap) string {
+	key := ""
+	for _, l := range []string{"node.name", "logical_switch.name", "firewall.rule.id", "interface", "status", "direction", "type"} {
+		if v, ok := labels.Get(l); ok {
+			key += "/" + v
+		}
+	}
+	return key
+}
+
+func TestScrape(t *testing.T) {
+	server := newMockManager(t)
+	defer server.Close()
+
+	rms, err := newTestScraper(t, server.URL+"/").scrape(context.Background())
+	require.NoError(t, err)
+	points := dataPoints(t, rms)
+
+	assert.Equal(t, map[string]int64{
+		"/esxi-01/up": 1, "/esxi-01/degraded": 0, "/esxi-01/down": 0, "/esxi-01/unknown": 0,
+		"/edge-01/up": 1, "/edge-01/degraded": 0, "/edge-01/down": 0, "/edge-01/unknown": 0,
+	}, points["nsxt.node.status"])
+	assert.EqualValues(t, 4294967296, points["nsxt.node.memory.usage"]["/esxi-01"])
+	assert.EqualValues(t, 21474836480, points["nsxt.node.filesystem.usage"]["/edge-01"])
+	assert.Equal(t, map[string]int64{
+		"/esxi-01/vmk0/received": 1095225, "/esxi-01/vmk0/transmitted": 2307162,
+		"/edge-01/vmk0/received": 1095225, "/edge-01/vmk0/transmitted": 2307162,
+	}, points["nsxt.node.network.io"])
+	assert.EqualValues(t, 8721, points["nsxt.node.network.packet.count"]["/esxi-01/vmk0/transmitted"])
+	assert.EqualValues(t, 3, points["nsxt.node.network.packet.errors"]["/esxi-01/vmk0/received/dropped"])
+	assert.EqualValues(t, 1, points["nsxt.node.network.packet.errors"]["/esxi-01/vmk0/received/errored"])
+
+	assert.Equal(t, map[string]int64{"/web-segment/received": 70123, "/web-segment/transmitted": 90456}, points["nsxt.logical_switch.network.io"])
+	assert.Equal(t, map[string]int64{"/web-segment/received": 812, "/web-segment/transmitted": 944}, points["nsxt.logical_switch.network.packet.count"])
+	assert.Equal(t, map[string]int64{"/web-segment/received": 5, "/web-segment/transmitted": 7}, points["nsxt.logical_switch.network.packet.dropped"])
+
+	assert.Equal(t, map[string]int64{"/1001": 120, "/1002": 4}, points["nsxt.firewall.rule.hits"])
+	assert.Equal(t, map[string]int64{"/1001": 4012, "/1002": 18}, points["nsxt.firewall.rule.packet.count"])
+	assert.Equal(t, map[string]int64{"/1001": 512000, "/1002": 2048}, points["nsxt.firewall.rule.network.io"])
+	assert.Equal(t, map[string]int64{"/1001": 60, "/1002": 2}, points["nsxt.firewall.rule.sessions"])
+}
+
+func TestScrapePartialFailure(t *testing.T) {
+	server := newMockManager(t,
+		"/api/v1/transport-nodes/"+node2ID+"/network/interfaces",
+		"/api/v1/logical-switches",
+	)
+	defer server.Close()
+
+	rms, err := newTestScraper(t, server.URL).scrape(context.Background())
+	require.Error(t, err)
+	assert.True(t, scrapererror.IsPartialScrapeError(err))
+	assert.Contains(t, err.Error(), `failed to list the interfaces of transport node "`+node2ID+`": expected 200 response from /api/v1/transport-nodes/`+node2ID+`/network/interfaces, got 404`)
+	assert.Contains(t, err.Error(), "failed to list logical switches: expected 200 response from /api/v1/logical-switches, got 404")
+
+	points := dataPoints(t, rms)
+	assert.Len(t, points["nsxt.node.status"], 8)
+	assert.Equal(t, map[string]int64{"/esxi-01/vmk0/received": 1095225, "/esxi-01/vmk0/transmitted": 2307162}, points["nsxt.node.network.io"])
+	assert.NotContains(t, points, "nsxt.logical_switch.network.io")
+	assert.Len(t, points["nsxt.firewall.rule.hits"], 2)
+}
+
+func TestScrapeUnauthorized(t *testing.T) {
+	server := newMockManager(t)
+	defer server.Close()
+
+	s := newTestScraper(t, server.URL)
+	s.client.password = "wrong"
+	_, err := s.scrape(context.Background())
+	require.Error(t, err)
+	assert.False(t, scrapererror.IsPartialScrapeError(err))
+	assert.Contains(t, err.Error(), "failed to list transport nodes: expected 200 response from /api/v1/transport-nodes, got 401")
+}
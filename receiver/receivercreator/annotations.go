@@ -0,0 +1,83 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package receivercreator
+
+import (
+	"fmt"
+	"strings"
+
+	"go.opentelemetry.io/collector/config/configparser"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer"
+)
+
+// mergeAnnotationConfig expands the receiver configuration found in the given annotation of
+// the endpoint and deep merges it over cfg. cfg is returned unchanged if the endpoint does not
+// have the annotation.
+func mergeAnnotationConfig(cfg userConfigMap, env observer.EndpointEnv, annotation string) (userConfigMap, error) {
+	value, ok := endpointAnnotations(env)[annotation]
+	if !ok {
+		return cfg, nil
+	}
+
+	annotationParser, err := configparser.NewParserFromBuffer(strings.NewReader(value))
+	if err != nil {
+		return nil, fmt.Errorf("annotation is not a valid YAML map: %v", err)
+	}
+
+	annotationConfig, err := expandMap(annotationParser.ToStringMap(), env)
+	if err != nil {
+		return nil, err
+	}
+
+	return mergeMaps(cfg, annotationConfig), nil
+}
+
+// mergeMaps returns a copy of dst with the values of src recursively merged over it.
+func mergeMaps(dst, src map[string]interface{}) map[string]interface{} {
+	merged := make(map[string]interface{}, len(dst))
+	for k, v := range dst {
+		merged[k] = v
+	}
+	for k, v := range src {
+		srcMap, srcIsMap := v.(map[string]interface{})
+		dstMap, dstIsMap := merged[k].(map[string]interface{})
+		if srcIsMap && dstIsMap {
+			merged[k] = mergeMaps(dstMap, srcMap)
+		} else {
+			merged[k] = v
+		}
+	}
+	return merged
+}
+
+// endpointAnnotations returns the annotations of the endpoint. Container labels are used as
+// the annotations of container endpoints, and the annotations of the pod as the annotations
+// of port endpoints.
+func endpointAnnotations(env observer.EndpointEnv) map[string]string {
+	var annotations interface{}
+	switch env["type"] {
+	case string(observer.PodType), string(observer.CloudRunServiceType):
+		annotations = env["annotations"]
+	case string(observer.PortType):
+		if pod, ok := env["pod"].(observer.EndpointEnv); ok {
+			annotations = pod["annotations"]
+		}
+	case string(observer.ContainerType):
+		annotations = env["labels"]
+	}
+	m, _ := annotations.(map[string]string)
+	return m
+}
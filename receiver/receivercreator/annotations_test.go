@@ -0,0 +1,131 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package receivercreator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer"
+)
+
+func TestEndpointAnnotations(t *testing.T) {
+	tests := []struct {
+		name     string
+		endpoint observer.Endpoint
+		want     map[string]string
+	}{
+		{"pod", podEndpoint, map[string]string{"scrape": "true"}},
+		{"port", portEndpoint, map[string]string{"scrape": "true"}},
+		{"container", containerEndpoint, map[string]string{"PROMETHEUS_PORT": "9090"}},
+		{"cloudrun service", cloudRunServiceEndpoint, nil},
+		{"hostport", hostportEndpoint, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env, err := tt.endpoint.Env()
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, endpointAnnotations(env))
+		})
+	}
+}
+
+func TestMergeAnnotationConfig(t *testing.T) {
+	defaults := userConfigMap{
+		"collection_interval": "30s",
+		"tls": map[string]interface{}{
+			"insecure": false,
+			"ca_file":  "/etc/ca.pem",
+		},
+	}
+
+	tests := []struct {
+		name       string
+		annotation string
+		want       userConfigMap
+		wantErr    string
+	}{
+		{
+			name:       "deep merge",
+			annotation: "metrics_path: /stats\ntls:\n  insecure: true\n",
+			want: userConfigMap{
+				"collection_interval": "30s",
+				"metrics_path":        "/stats",
+				"tls": map[string]interface{}{
+					"insecure": true,
+					"ca_file":  "/etc/ca.pem",
+				},
+			},
+		},
+		{
+			name:       "json",
+			annotation: `{"collection_interval": "10s"}`,
+			want: userConfigMap{
+				"collection_interval": "10s",
+				"tls": map[string]interface{}{
+					"insecure": false,
+					"ca_file":  "/etc/ca.pem",
+				},
+			},
+		},
+		{
+			name:       "expansion",
+			annotation: "endpoint: '`endpoint`:9090'",
+			want: userConfigMap{
+				"collection_interval": "30s",
+				"endpoint":            "localhost:9090",
+				"tls": map[string]interface{}{
+					"insecure": false,
+					"ca_file":  "/etc/ca.pem",
+				},
+			},
+		},
+		{
+			name:       "not a map",
+			annotation: "- a\n- b",
+			wantErr:    "annotation is not a valid YAML map",
+		},
+		{
+			name:       "invalid expression",
+			annotation: "endpoint: '`unbalanced'",
+			wantErr:    "failed evaluating config expression",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := pod
+			p.Annotations = map[string]string{"config": tt.annotation}
+			env, err := (&observer.Endpoint{ID: "pod-1", Target: "localhost", Details: &p}).Env()
+			require.NoError(t, err)
+
+			got, err := mergeAnnotationConfig(defaults, env, "config")
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	env, err := podEndpoint.Env()
+	require.NoError(t, err)
+	got, err := mergeAnnotationConfig(defaults, env, "missing")
+	require.NoError(t, err)
+	assert.Equal(t, defaults, got)
+}
@@ -53,6 +53,10 @@ type receiverTemplate struct {
 	// based on receiverTemplate.
 	Rule string `mapstructure:"rule"`
 	rule rule
+
+	// ConfigAnnotation is the name of an annotation of the endpoint holding receiver
+	// configuration to merge over the configuration of the template.
+	ConfigAnnotation string `mapstructure:"config_annotation"`
 }
 
 // resourceAttributes holds a map of default resource attributes for each Endpoint type.
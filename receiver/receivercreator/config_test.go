@@ -85,6 +85,7 @@ func TestLoadConfig(t *testing.T) {
 	assert.Len(t, r1.receiverTemplates, 2)
 	assert.Contains(t, r1.receiverTemplates, "examplereceiver/1")
 	assert.Equal(t, `type == "port"`, r1.receiverTemplates["examplereceiver/1"].Rule)
+	assert.Equal(t, "io.opentelemetry.discovery.metrics/config", r1.receiverTemplates["examplereceiver/1"].ConfigAnnotation)
 	assert.Contains(t, r1.receiverTemplates, "nop/1")
 	assert.Equal(t, `type == "port"`, r1.receiverTemplates["nop/1"].Rule)
 	assert.Equal(t, userConfigMap{
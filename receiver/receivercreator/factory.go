@@ -54,6 +54,22 @@ func createDefaultConfig() config.Receiver {
 				conventions.AttributeK8sPodUID:    "`pod.uid`",
 				conventions.AttributeK8sNamespace: "`pod.namespace`",
 			},
+			observer.ContainerType: map[string]string{
+				conventions.AttributeContainerName:  "`name`",
+				conventions.AttributeContainerID:    "`container_id`",
+				conventions.AttributeContainerImage: "`image`",
+				conventions.AttributeContainerTag:   "`tag`",
+				"aws.ecs.task.arn":                  "`task_arn`",
+				"aws.ecs.task.family":               "`task_family`",
+				"aws.ecs.cluster.arn":               "`cluster`",
+			},
+			observer.CloudRunServiceType: map[string]string{
+				conventions.AttributeCloudProvider: conventions.AttributeCloudProviderGCP,
+				conventions.AttributeCloudAccount:  "`project`",
+				conventions.AttributeCloudRegion:   "`region`",
+				conventions.AttributeFaasName:      "`name`",
+				conventions.AttributeFaasVersion:   "`revision`",
+			},
 		},
 		receiverTemplates: map[string]receiverTemplate{},
 	}
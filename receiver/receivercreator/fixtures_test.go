@@ -59,6 +59,41 @@ var hostportEndpoint = observer.Endpoint{
 	},
 }
 
+var containerEndpoint = observer.Endpoint{
+	ID:     "container-1",
+	Target: "10.0.0.12:9090",
+	Details: &observer.Container{
+		Name:        "app",
+		Image:       "example/app",
+		Tag:         "1.0",
+		Port:        9090,
+		ContainerID: "abcdef",
+		Host:        "10.0.0.12",
+		Transport:   observer.ProtocolTCP,
+		Labels: map[string]string{
+			"PROMETHEUS_PORT": "9090",
+		},
+		TaskARN:    "arn:aws:ecs:us-west-2:123456789012:task/default/1234",
+		TaskFamily: "app",
+		Cluster:    "arn:aws:ecs:us-west-2:123456789012:cluster/default",
+	},
+}
+
+var cloudRunServiceEndpoint = observer.Endpoint{
+	ID:     "service-1",
+	Target: "api-abc123-uc.a.run.app:443",
+	Details: &observer.CloudRunService{
+		Name:     "api",
+		Project:  "my-project",
+		Region:   "us-central1",
+		URL:      "https://api-abc123-uc.a.run.app",
+		Revision: "api-00003-xyz",
+		Labels: map[string]string{
+			"metrics": "prometheus",
+		},
+	},
+}
+
 var unsupportedEndpoint = observer.Endpoint{
 	ID:      "endpoint-1",
 	Target:  "localhost:1234",
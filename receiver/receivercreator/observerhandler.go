@@ -95,6 +95,18 @@ func (obs *observerHandler) OnAdd(added []observer.Endpoint) {
 				continue
 			}
 
+			if template.ConfigAnnotation != "" {
+				resolvedConfig, err = mergeAnnotationConfig(resolvedConfig, env, template.ConfigAnnotation)
+				if err != nil {
+					obs.logger.Error("unable to merge annotation config",
+						zap.String("receiver", template.id.String()),
+						zap.String("annotation", template.ConfigAnnotation),
+						zap.String("endpoint_id", string(e.ID)),
+						zap.Error(err))
+					continue
+				}
+			}
+
 			discoveredConfig := userConfigMap{}
 
 			// If user didn't set endpoint set to default value.
@@ -52,7 +52,7 @@ func TestOnAdd(t *testing.T) {
 	rcvrCfg := receiverConfig{id: config.NewIDWithName("name", "1"), config: userConfigMap{"foo": "bar"}}
 	cfg := createDefaultConfig().(*Config)
 	cfg.receiverTemplates = map[string]receiverTemplate{
-		"name/1": {rcvrCfg, "", newRuleOrPanic(`type == "port"`)},
+		"name/1": {receiverConfig: rcvrCfg, rule: newRuleOrPanic(`type == "port"`)},
 	}
 	handler := &observerHandler{
 		config:                cfg,
@@ -104,7 +104,7 @@ func TestOnChange(t *testing.T) {
 	newRcvr := &nopWithEndpointReceiver{}
 	cfg := createDefaultConfig().(*Config)
 	cfg.receiverTemplates = map[string]receiverTemplate{
-		"name/1": {rcvrCfg, "", newRuleOrPanic(`type == "port"`)},
+		"name/1": {receiverConfig: rcvrCfg, rule: newRuleOrPanic(`type == "port"`)},
 	}
 	handler := &observerHandler{
 		config:                cfg,
@@ -130,6 +130,71 @@ func TestOnChange(t *testing.T) {
 	assert.Same(t, newRcvr, handler.receiversByEndpointID.Get("port-1")[0])
 }
 
+func TestAnnotationConfig(t *testing.T) {
+	runner := &mockRunner{}
+	cfg := createDefaultConfig().(*Config)
+	cfg.receiverTemplates = map[string]receiverTemplate{
+		"name/1": {
+			receiverConfig: receiverConfig{id: config.NewIDWithName("name", "1"), config: userConfigMap{
+				"collection_interval": "30s",
+				"nested":              map[string]interface{}{"a": "default", "b": "`name`"},
+			}},
+			Rule:             `type == "pod"`,
+			rule:             newRuleOrPanic(`type == "pod"`),
+			ConfigAnnotation: "io.opentelemetry.discovery.metrics/config",
+		},
+	}
+	handler := &observerHandler{
+		config:                cfg,
+		logger:                zap.NewNop(),
+		receiversByEndpointID: receiverMap{},
+		runner:                runner,
+	}
+
+	annotatedPod := pod
+	annotatedPod.Annotations = map[string]string{
+		"io.opentelemetry.discovery.metrics/config": "endpoint: '`endpoint`:9090'\nnested:\n  a: annotation\n",
+	}
+	runner.On(
+		"start",
+		receiverConfig{
+			id: config.NewIDWithName("name", "1"),
+			config: userConfigMap{
+				"collection_interval": "30s",
+				endpointConfigKey:     "localhost:9090",
+				"nested":              map[string]interface{}{"a": "annotation", "b": "pod-1"},
+			},
+		},
+		userConfigMap{},
+		mock.IsType(&resourceEnhancer{}),
+	).Return(&nopWithEndpointReceiver{}, nil)
+	runner.On(
+		"start",
+		receiverConfig{
+			id: config.NewIDWithName("name", "1"),
+			config: userConfigMap{
+				"collection_interval": "30s",
+				"nested":              map[string]interface{}{"a": "default", "b": "pod-1"},
+			},
+		},
+		userConfigMap{endpointConfigKey: "localhost"},
+		mock.IsType(&resourceEnhancer{}),
+	).Return(&nopWithEndpointReceiver{}, nil)
+
+	handler.OnAdd([]observer.Endpoint{
+		{ID: "pod-1", Target: "localhost", Details: &annotatedPod},
+		// Invalid annotations do not start a receiver.
+		{ID: "pod-2", Target: "localhost", Details: &observer.Pod{
+			Name:        "pod-2",
+			Annotations: map[string]string{"io.opentelemetry.discovery.metrics/config": "[invalid"},
+		}},
+		podEndpoint,
+	})
+
+	runner.AssertExpectations(t)
+	assert.Equal(t, 2, handler.receiversByEndpointID.Size())
+}
+
 func TestDynamicConfig(t *testing.T) {
 	runner := &mockRunner{}
 	cfg := createDefaultConfig().(*Config)
@@ -36,6 +36,14 @@ func Test_newResourceEnhancer(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
+	containerEnv, err := containerEndpoint.Env()
+	if err != nil {
+		t.Fatal(err)
+	}
+	cloudRunServiceEnv, err := cloudRunServiceEndpoint.Env()
+	if err != nil {
+		t.Fatal(err)
+	}
 
 	cfg := createDefaultConfig().(*Config)
 	type args struct {
@@ -86,6 +94,48 @@ func Test_newResourceEnhancer(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "container endpoint",
+			args: args{
+				resources:    cfg.ResourceAttributes,
+				env:          containerEnv,
+				endpoint:     containerEndpoint,
+				nextConsumer: &consumertest.MetricsSink{},
+			},
+			want: &resourceEnhancer{
+				nextConsumer: &consumertest.MetricsSink{},
+				attrs: map[string]string{
+					"container.name":       "app",
+					"container.id":         "abcdef",
+					"container.image.name": "example/app",
+					"container.image.tag":  "1.0",
+					"aws.ecs.task.arn":     "arn:aws:ecs:us-west-2:123456789012:task/default/1234",
+					"aws.ecs.task.family":  "app",
+					"aws.ecs.cluster.arn":  "arn:aws:ecs:us-west-2:123456789012:cluster/default",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "cloudrun service endpoint",
+			args: args{
+				resources:    cfg.ResourceAttributes,
+				env:          cloudRunServiceEnv,
+				endpoint:     cloudRunServiceEndpoint,
+				nextConsumer: &consumertest.MetricsSink{},
+			},
+			want: &resourceEnhancer{
+				nextConsumer: &consumertest.MetricsSink{},
+				attrs: map[string]string{
+					"cloud.provider":   "gcp",
+					"cloud.account.id": "my-project",
+					"cloud.region":     "us-central1",
+					"faas.name":        "api",
+					"faas.version":     "api-00003-xyz",
+				},
+			},
+			wantErr: false,
+		},
 		{
 			// If the configured attribute value is empty it should not touch that
 			// attribute.
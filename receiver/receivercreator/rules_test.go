@@ -48,6 +48,8 @@ func Test_ruleEval(t *testing.T) {
 		{"basic hostport", args{`type == "hostport" && port == 1234 && process_name == "splunk"`, hostportEndpoint}, true, false},
 		{"basic pod", args{`type == "pod" && labels["region"] == "west-1"`, podEndpoint}, true, false},
 		{"annotations", args{`type == "pod" && annotations["scrape"] == "true"`, podEndpoint}, true, false},
+		{"basic container", args{`type == "container" && image == "example/app" && "PROMETHEUS_PORT" in labels`, containerEndpoint}, true, false},
+		{"basic cloudrun service", args{`type == "cloudrun_service" && region == "us-central1" && labels["metrics"] == "prometheus"`, cloudRunServiceEndpoint}, true, false},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -85,6 +87,8 @@ func Test_newRule(t *testing.T) {
 		{"valid port", args{`type == "port" && port_name == "http"`}, false},
 		{"valid pod", args{`type=="pod" && port_name == "http"`}, false},
 		{"valid hostport", args{`type ==    "hostport" && port_name == "http"`}, false},
+		{"valid container", args{`type == "container" && port == 9090`}, false},
+		{"valid cloudrun service", args{`type == "cloudrun_service" && name == "api"`}, false},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -27,4 +27,10 @@ type Config struct {
 	confighttp.HTTPServerSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
 
 	splunk.AccessTokenPassthroughConfig `mapstructure:",squash"`
+
+	// ValidationMode defines how spans violating the trace specification (zero trace or span ids,
+	// end timestamp before the start timestamp, attribute keys that are not valid UTF-8) are handled:
+	// "passthrough" forwards them as received, "reject" fails the whole request with a 400 status and
+	// "sanitize" fixes them, dropping the spans with zero ids. The default is "passthrough".
+	ValidationMode string `mapstructure:"validation_mode"`
 }
@@ -42,7 +42,7 @@ func TestLoadConfig(t *testing.T) {
 
 	// The receiver `sapm/disabled` doesn't count because disabled receivers
 	// are excluded from the final list.
-	assert.Equal(t, len(cfg.Receivers), 4)
+	assert.Equal(t, len(cfg.Receivers), 5)
 
 	r0 := cfg.Receivers[config.NewID(typeStr)]
 	assert.Equal(t, r0, factory.CreateDefaultConfig())
@@ -54,6 +54,7 @@ func TestLoadConfig(t *testing.T) {
 			HTTPServerSettings: confighttp.HTTPServerSettings{
 				Endpoint: "0.0.0.0:7276",
 			},
+			ValidationMode: "passthrough",
 		})
 
 	r2 := cfg.Receivers[config.NewIDWithName(typeStr, "tls")].(*Config)
@@ -69,6 +70,7 @@ func TestLoadConfig(t *testing.T) {
 					},
 				},
 			},
+			ValidationMode: "passthrough",
 		})
 
 	r3 := cfg.Receivers[config.NewIDWithName(typeStr, "passthrough")].(*Config)
@@ -81,5 +83,15 @@ func TestLoadConfig(t *testing.T) {
 			AccessTokenPassthroughConfig: splunk.AccessTokenPassthroughConfig{
 				AccessTokenPassthrough: true,
 			},
+			ValidationMode: "passthrough",
 		})
+
+	r4 := cfg.Receivers[config.NewIDWithName(typeStr, "sanitize")].(*Config)
+	assert.Equal(t, "sanitize", r4.ValidationMode)
+}
+
+func TestInvalidValidationMode(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.ValidationMode = "drop"
+	assert.EqualError(t, cfg.validate(), `unsupported validation_mode "drop", must be one of passthrough, reject or sanitize`)
 }
@@ -22,6 +22,7 @@ import (
 	"net"
 	"strconv"
 
+	"go.opencensus.io/stats/view"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/config/confighttp"
@@ -39,6 +40,8 @@ const (
 
 // NewFactory creates a factory for SAPM receiver.
 func NewFactory() component.ReceiverFactory {
+	_ = view.Register(MetricViews()...)
+
 	return receiverhelper.NewFactory(
 		typeStr,
 		createDefaultConfig,
@@ -51,6 +54,7 @@ func createDefaultConfig() config.Receiver {
 		HTTPServerSettings: confighttp.HTTPServerSettings{
 			Endpoint: defaultEndpoint,
 		},
+		ValidationMode: validationModePassthrough,
 	}
 }
 
@@ -78,6 +82,11 @@ func (rCfg *Config) validate() error {
 	if err != nil {
 		return err
 	}
+	switch rCfg.ValidationMode {
+	case validationModePassthrough, validationModeReject, validationModeSanitize:
+	default:
+		return fmt.Errorf("unsupported validation_mode %q, must be one of passthrough, reject or sanitize", rCfg.ValidationMode)
+	}
 	return nil
 }
 
@@ -5,6 +5,8 @@ go 1.16
 require (
 	github.com/gorilla/mux v1.8.0
 	github.com/jaegertracing/jaeger v1.22.0
+	github.com/klauspost/compress v1.12.2
+	github.com/open-telemetry/opentelemetry-collector-contrib/internal/admissioncontrol v0.0.0-00010101000000-000000000000
 	github.com/open-telemetry/opentelemetry-collector-contrib/internal/splunk v0.0.0-00010101000000-000000000000
 	github.com/signalfx/sapm-proto v0.7.0
 	github.com/stretchr/testify v1.7.0
@@ -13,4 +15,6 @@ require (
 	go.uber.org/zap v1.17.0
 )
 
+replace github.com/open-telemetry/opentelemetry-collector-contrib/internal/admissioncontrol => ../../internal/admissioncontrol
+
 replace github.com/open-telemetry/opentelemetry-collector-contrib/internal/splunk => ../../internal/splunk
@@ -0,0 +1,71 @@
+// Copyright 2019, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sapmreceiver
+
+import (
+	"context"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+)
+
+var (
+	tagKeyReceiver, _  = tag.NewKey("receiver")
+	tagKeyViolation, _ = tag.NewKey("violation")
+
+	mSpansSanitized   = stats.Int64("sapm_receiver_spans_sanitized", "Spans modified to conform to the trace specification", stats.UnitDimensionless)
+	mSpansDropped     = stats.Int64("sapm_receiver_spans_dropped", "Spans dropped because they violate the trace specification", stats.UnitDimensionless)
+	mRequestsRejected = stats.Int64("sapm_receiver_requests_rejected", "Requests rejected because they contain spans violating the trace specification", stats.UnitDimensionless)
+)
+
+// MetricViews returns the metric views related to the validation of the received spans.
+func MetricViews() []*view.View {
+	tagKeys := []tag.Key{tagKeyReceiver, tagKeyViolation}
+	return []*view.View{
+		{
+			Name:        "receiver/" + typeStr + "/" + mSpansSanitized.Name(),
+			Measure:     mSpansSanitized,
+			Description: mSpansSanitized.Description(),
+			TagKeys:     tagKeys,
+			Aggregation: view.Sum(),
+		},
+		{
+			Name:        "receiver/" + typeStr + "/" + mSpansDropped.Name(),
+			Measure:     mSpansDropped,
+			Description: mSpansDropped.Description(),
+			TagKeys:     tagKeys,
+			Aggregation: view.Sum(),
+		},
+		{
+			Name:        "receiver/" + typeStr + "/" + mRequestsRejected.Name(),
+			Measure:     mRequestsRejected,
+			Description: mRequestsRejected.Description(),
+			TagKeys:     []tag.Key{tagKeyReceiver},
+			Aggregation: view.Sum(),
+		},
+	}
+}
+
+// recordViolations records the number of spans sanitized or dropped per violation.
+func recordViolations(ctx context.Context, violations map[violation]int) {
+	for v, count := range violations {
+		measure := mSpansSanitized
+		if v.droppable() {
+			measure = mSpansDropped
+		}
+		_ = stats.RecordWithTags(ctx, []tag.Mutator{tag.Upsert(tagKeyViolation, string(v))}, measure.M(int64(count)))
+	}
+}
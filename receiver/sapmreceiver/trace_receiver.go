@@ -18,29 +18,43 @@ import (
 	"bytes"
 	"compress/gzip"
 	"context"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"net/http"
 	"sync"
 
 	"github.com/gorilla/mux"
+	"github.com/klauspost/compress/zstd"
 	splunksapm "github.com/signalfx/sapm-proto/gen"
 	"github.com/signalfx/sapm-proto/sapmprotocol"
+	"go.opencensus.io/stats"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/obsreport"
 	jaegertranslator "go.opentelemetry.io/collector/translator/trace/jaeger"
 	"go.uber.org/zap"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/admissioncontrol"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/splunk"
 )
 
+// zstdEncodingHeaderValue is the value used for zstd encoded http headers.
+const zstdEncodingHeaderValue = "zstd"
+
 var gzipWriterPool = &sync.Pool{
 	New: func() interface{} {
 		return gzip.NewWriter(ioutil.Discard)
 	},
 }
 
+const (
+	// maxZstdBodySize is the maximum size of a zstd compressed request body.
+	maxZstdBodySize = 16 << 20
+	// maxZstdDecodedSize is the maximum size a zstd compressed request body is decoded to.
+	maxZstdDecodedSize = 64 << 20
+)
+
 // sapmReceiver receives spans in the Splunk SAPM format over HTTP
 type sapmReceiver struct {
 	// mu protects the fields of this type
@@ -58,10 +72,20 @@ type sapmReceiver struct {
 	defaultResponse []byte
 
 	obsrecv *obsreport.Receiver
+
+	// zstdDecoder is only used through DecodeAll, which is safe for concurrent use.
+	zstdDecoder *zstd.Decoder
 }
 
 // handleRequest parses an http request containing sapm and passes the trace data to the next consumer
-func (sr *sapmReceiver) handleRequest(ctx context.Context, req *http.Request) error {
+func (sr *sapmReceiver) handleRequest(ctx context.Context, rw http.ResponseWriter, req *http.Request) error {
+	// gzip is decoded by the sapm protocol parser, other encodings are decoded beforehand.
+	if req.Header.Get(sapmprotocol.ContentEncodingHeaderName) == zstdEncodingHeaderValue {
+		if err := sr.decodeZstdBody(rw, req); err != nil {
+			return err
+		}
+	}
+
 	sapm, err := sapmprotocol.ParseTraceV2Request(req)
 	// errors processing the request should return http.StatusBadRequest
 	if err != nil {
@@ -77,6 +101,18 @@ func (sr *sapmReceiver) handleRequest(ctx context.Context, req *http.Request) er
 
 	td := jaegertranslator.ProtoBatchesToInternalTraces(sapm.Batches)
 
+	switch sr.config.ValidationMode {
+	case validationModeReject:
+		if violations := checkTraces(td, false); len(violations) > 0 {
+			err = &validationError{violations: violations}
+			_ = stats.RecordWithTags(ctx, nil, mRequestsRejected.M(1))
+			sr.obsrecv.EndTracesOp(ctx, "protobuf", td.SpanCount(), err)
+			return err
+		}
+	case validationModeSanitize:
+		recordViolations(ctx, checkTraces(td, true))
+	}
+
 	if sr.config.AccessTokenPassthrough {
 		if accessToken := req.Header.Get(splunk.SFxAccessTokenHeader); accessToken != "" {
 			rSpans := td.ResourceSpans()
@@ -91,21 +127,46 @@ func (sr *sapmReceiver) handleRequest(ctx context.Context, req *http.Request) er
 	// pass the trace data to the next consumer
 	err = sr.nextConsumer.ConsumeTraces(ctx, td)
 	if err != nil {
-		err = fmt.Errorf("error passing trace data to next consumer: %v", err.Error())
+		err = fmt.Errorf("error passing trace data to next consumer: %w", err)
 	}
 
 	sr.obsrecv.EndTracesOp(ctx, "protobuf", td.SpanCount(), err)
 	return err
 }
 
+// decodeZstdBody replaces the zstd encoded body of req by its decoded content.
+func (sr *sapmReceiver) decodeZstdBody(rw http.ResponseWriter, req *http.Request) error {
+	compressed, err := ioutil.ReadAll(http.MaxBytesReader(rw, req.Body, maxZstdBodySize))
+	if err != nil {
+		return err
+	}
+	body, err := sr.zstdDecoder.DecodeAll(compressed, nil)
+	if err != nil {
+		return fmt.Errorf("failed to decode zstd body: %w", err)
+	}
+	req.Body = ioutil.NopCloser(bytes.NewReader(body))
+	req.Header.Del(sapmprotocol.ContentEncodingHeaderName)
+	return nil
+}
+
 // HTTPHandlerFunction returns an http.HandlerFunc that handles SAPM requests
 func (sr *sapmReceiver) HTTPHandlerFunc(rw http.ResponseWriter, req *http.Request) {
 	// create context with the receiver name from the request context
 	ctx := obsreport.ReceiverContext(req.Context(), sr.config.ID(), "http")
 
 	// handle the request payload
-	err := sr.handleRequest(ctx, req)
+	err := sr.handleRequest(ctx, rw, req)
 	if err != nil {
+		var vErr *validationError
+		if errors.As(err, &vErr) {
+			http.Error(rw, vErr.Error(), http.StatusBadRequest)
+			return
+		}
+		if admissioncontrol.SetRetryAfter(rw.Header(), err) {
+			// the collector is low on memory, the client should send the spans again later
+			rw.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
 		// TODO account for this error (throttled logging or metrics)
 		rw.WriteHeader(http.StatusBadRequest)
 		return
@@ -191,7 +252,9 @@ func (sr *sapmReceiver) Shutdown(context.Context) error {
 	sr.mu.Lock()
 	defer sr.mu.Unlock()
 
-	return sr.server.Close()
+	err := sr.server.Close()
+	sr.zstdDecoder.Close()
+	return err
 }
 
 // this validates at compile time that sapmReceiver implements the component.TracesReceiver interface
@@ -210,6 +273,10 @@ func New(
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal default response body for %v receiver: %w", config.ID(), err)
 	}
+	zstdDecoder, err := zstd.NewReader(nil, zstd.WithDecoderMaxMemory(maxZstdDecodedSize))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create zstd decoder for %v receiver: %w", config.ID(), err)
+	}
 	transport := "http"
 	if config.TLSSetting != nil {
 		transport = "https"
@@ -220,5 +287,6 @@ func New(
 		nextConsumer:    nextConsumer,
 		defaultResponse: defaultResponseBytes,
 		obsrecv:         obsreport.NewReceiver(obsreport.ReceiverSettings{ReceiverID: config.ID(), Transport: transport}),
+		zstdDecoder:     zstdDecoder,
 	}, nil
 }
@@ -21,10 +21,12 @@ import (
 	"encoding/binary"
 	"fmt"
 	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
 	"github.com/jaegertracing/jaeger/model"
+	"github.com/klauspost/compress/zstd"
 	splunksapm "github.com/signalfx/sapm-proto/gen"
 	"github.com/signalfx/sapm-proto/sapmprotocol"
 	"github.com/stretchr/testify/assert"
@@ -34,6 +36,7 @@ import (
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/consumer/pdata"
 	"go.opentelemetry.io/collector/testutil"
@@ -41,6 +44,7 @@ import (
 	tracetranslator "go.opentelemetry.io/collector/translator/trace"
 	"go.uber.org/zap"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/admissioncontrol"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/splunk"
 )
 
@@ -136,14 +140,15 @@ func grpcFixture(t1 time.Time) *model.Batch {
 }
 
 // sendSapm acts as a client for sending sapm to the receiver.  This could be replaced with a sapm exporter in the future.
-func sendSapm(endpoint string, sapm *splunksapm.PostSpansRequest, zipped bool, tlsEnabled bool, token string) (*http.Response, error) {
+func sendSapm(endpoint string, sapm *splunksapm.PostSpansRequest, encoding string, tlsEnabled bool, token string) (*http.Response, error) {
 	// marshal the sapm
 	reqBytes, err := sapm.Marshal()
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal sapm %v", err.Error())
 	}
 
-	if zipped {
+	switch encoding {
+	case sapmprotocol.GZipEncodingHeaderValue:
 		// create a gzip writer
 		var buff bytes.Buffer
 		writer := gzip.NewWriter(&buff)
@@ -162,6 +167,13 @@ func sendSapm(endpoint string, sapm *splunksapm.PostSpansRequest, zipped bool, t
 
 		// save the gzipped bytes as the request bytes
 		reqBytes = buff.Bytes()
+	case zstdEncodingHeaderValue:
+		encoder, errEnc := zstd.NewWriter(nil)
+		if errEnc != nil {
+			return nil, fmt.Errorf("failed to create the zstd encoder %v", errEnc.Error())
+		}
+		reqBytes = encoder.EncodeAll(reqBytes, nil)
+		encoder.Close()
 	}
 
 	// build the request
@@ -172,9 +184,9 @@ func sendSapm(endpoint string, sapm *splunksapm.PostSpansRequest, zipped bool, t
 	req, _ := http.NewRequest(http.MethodPost, url, bytes.NewReader(reqBytes))
 	req.Header.Set(sapmprotocol.ContentTypeHeaderName, sapmprotocol.ContentTypeHeaderValue)
 
-	// set headers for gzip
-	if zipped {
-		req.Header.Set(sapmprotocol.ContentEncodingHeaderName, sapmprotocol.GZipEncodingHeaderValue)
+	// set headers for the compression
+	if encoding != "" {
+		req.Header.Set(sapmprotocol.ContentEncodingHeaderName, encoding)
 		req.Header.Set(sapmprotocol.AcceptEncodingHeaderName, sapmprotocol.GZipEncodingHeaderValue)
 	}
 
@@ -211,7 +223,7 @@ func sendSapm(endpoint string, sapm *splunksapm.PostSpansRequest, zipped bool, t
 	return resp, nil
 }
 
-func setupReceiver(t *testing.T, config *Config, sink *consumertest.TracesSink) component.TracesReceiver {
+func setupReceiver(t *testing.T, config *Config, sink consumer.Traces) component.TracesReceiver {
 	params := component.ReceiverCreateSettings{Logger: zap.NewNop()}
 	sr, err := New(context.Background(), params, config, sink)
 	assert.NoError(t, err, "should not have failed to create the SAPM receiver")
@@ -233,10 +245,10 @@ func TestReception(t *testing.T) {
 	tlsAddress := testutil.GetAvailableLocalAddress(t)
 
 	type args struct {
-		config *Config
-		sapm   *splunksapm.PostSpansRequest
-		zipped bool
-		useTLS bool
+		config   *Config
+		sapm     *splunksapm.PostSpansRequest
+		encoding string
+		useTLS   bool
 	}
 	tests := []struct {
 		name string
@@ -252,9 +264,9 @@ func TestReception(t *testing.T) {
 						Endpoint: defaultEndpoint,
 					},
 				},
-				sapm:   &splunksapm.PostSpansRequest{Batches: []*model.Batch{grpcFixture(now)}},
-				zipped: false,
-				useTLS: false,
+				sapm:     &splunksapm.PostSpansRequest{Batches: []*model.Batch{grpcFixture(now)}},
+				encoding: "",
+				useTLS:   false,
 			},
 			want: expectedTraceData(now, nowPlus10min, nowPlus10min2sec),
 		},
@@ -266,9 +278,23 @@ func TestReception(t *testing.T) {
 						Endpoint: defaultEndpoint,
 					},
 				},
-				sapm:   &splunksapm.PostSpansRequest{Batches: []*model.Batch{grpcFixture(now)}},
-				zipped: true,
-				useTLS: false,
+				sapm:     &splunksapm.PostSpansRequest{Batches: []*model.Batch{grpcFixture(now)}},
+				encoding: sapmprotocol.GZipEncodingHeaderValue,
+				useTLS:   false,
+			},
+			want: expectedTraceData(now, nowPlus10min, nowPlus10min2sec),
+		},
+		{
+			name: "receive zstd compressed sapm",
+			args: args{
+				config: &Config{
+					HTTPServerSettings: confighttp.HTTPServerSettings{
+						Endpoint: defaultEndpoint,
+					},
+				},
+				sapm:     &splunksapm.PostSpansRequest{Batches: []*model.Batch{grpcFixture(now)}},
+				encoding: zstdEncodingHeaderValue,
+				useTLS:   false,
 			},
 			want: expectedTraceData(now, nowPlus10min, nowPlus10min2sec),
 		},
@@ -287,9 +313,9 @@ func TestReception(t *testing.T) {
 						},
 					},
 				},
-				sapm:   &splunksapm.PostSpansRequest{Batches: []*model.Batch{grpcFixture(now)}},
-				zipped: false,
-				useTLS: true,
+				sapm:     &splunksapm.PostSpansRequest{Batches: []*model.Batch{grpcFixture(now)}},
+				encoding: "",
+				useTLS:   true,
 			},
 			want: expectedTraceData(now, nowPlus10min, nowPlus10min2sec),
 		},
@@ -303,7 +329,7 @@ func TestReception(t *testing.T) {
 
 			t.Log("Sending Sapm Request")
 			var resp *http.Response
-			resp, err := sendSapm(tt.args.config.Endpoint, tt.args.sapm, tt.args.zipped, tt.args.useTLS, "")
+			resp, err := sendSapm(tt.args.config.Endpoint, tt.args.sapm, tt.args.encoding, tt.args.useTLS, "")
 			require.NoError(t, err)
 			assert.Equal(t, 200, resp.StatusCode)
 			t.Log("SAPM Request Received")
@@ -366,7 +392,7 @@ func TestAccessTokenPassthrough(t *testing.T) {
 			defer sr.Shutdown(context.Background())
 
 			var resp *http.Response
-			resp, err := sendSapm(config.Endpoint, sapm, true, false, tt.token)
+			resp, err := sendSapm(config.Endpoint, sapm, sapmprotocol.GZipEncodingHeaderValue, false, tt.token)
 			require.NoErrorf(t, err, "should not have failed when sending sapm %v", err)
 			assert.Equal(t, 200, resp.StatusCode)
 
@@ -388,6 +414,108 @@ func TestAccessTokenPassthrough(t *testing.T) {
 	}
 }
 
+func TestValidationMode(t *testing.T) {
+	now := time.Now().UTC()
+	batch := grpcFixture(now)
+	// the first span has no trace id, the second one ends before it starts
+	batch.Spans[0].TraceID = model.TraceID{}
+	batch.Spans[1].Duration = -time.Second
+	sapm := &splunksapm.PostSpansRequest{Batches: []*model.Batch{batch}}
+
+	tests := []struct {
+		mode          string
+		wantStatus    int
+		wantSpanCount int
+	}{
+		{mode: validationModePassthrough, wantStatus: http.StatusOK, wantSpanCount: 2},
+		{mode: validationModeReject, wantStatus: http.StatusBadRequest},
+		{mode: validationModeSanitize, wantStatus: http.StatusOK, wantSpanCount: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.mode, func(t *testing.T) {
+			config := &Config{
+				HTTPServerSettings: confighttp.HTTPServerSettings{
+					Endpoint: testutil.GetAvailableLocalAddress(t),
+				},
+				ValidationMode: tt.mode,
+			}
+
+			sink := new(consumertest.TracesSink)
+			sr := setupReceiver(t, config, sink)
+			defer sr.Shutdown(context.Background())
+
+			resp, err := sendSapm(config.Endpoint, sapm, "", false, "")
+			require.NoError(t, err)
+			defer resp.Body.Close()
+			assert.Equal(t, tt.wantStatus, resp.StatusCode)
+			assert.Equal(t, tt.wantSpanCount, sink.SpansCount())
+
+			if tt.mode == validationModeSanitize {
+				span := sink.AllTraces()[0].ResourceSpans().At(0).InstrumentationLibrarySpans().At(0).Spans().At(0)
+				assert.Equal(t, span.StartTimestamp(), span.EndTimestamp())
+			}
+		})
+	}
+}
+
+func TestAdmissionPaused(t *testing.T) {
+	config := &Config{
+		HTTPServerSettings: confighttp.HTTPServerSettings{
+			Endpoint: testutil.GetAvailableLocalAddress(t),
+		},
+	}
+	sapm := &splunksapm.PostSpansRequest{
+		Batches: []*model.Batch{grpcFixture(time.Now().UTC())},
+	}
+
+	sr := setupReceiver(t, config, consumertest.NewErr(&admissioncontrol.PausedError{RetryAfter: 1500 * time.Millisecond}))
+	defer sr.Shutdown(context.Background())
+
+	resp, err := sendSapm(config.Endpoint, sapm, "", false, "")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	assert.Equal(t, "2", resp.Header.Get("Retry-After"))
+}
+
+func TestZstdBodyLimits(t *testing.T) {
+	encoder, err := zstd.NewWriter(nil)
+	require.NoError(t, err)
+	defer encoder.Close()
+
+	tests := []struct {
+		name string
+		body []byte
+	}{
+		{
+			name: "decoded_body_too_large",
+			body: encoder.EncodeAll(make([]byte, maxZstdDecodedSize+1), nil),
+		},
+		{
+			name: "compressed_body_too_large",
+			body: make([]byte, maxZstdBodySize+1),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sink := new(consumertest.TracesSink)
+			params := component.ReceiverCreateSettings{Logger: zap.NewNop()}
+			sr, err := New(context.Background(), params, &Config{}, sink)
+			require.NoError(t, err)
+			defer sr.(*sapmReceiver).zstdDecoder.Close()
+
+			req := httptest.NewRequest(http.MethodPost, sapmprotocol.TraceEndpointV2, bytes.NewReader(tt.body))
+			req.Header.Set(sapmprotocol.ContentTypeHeaderName, sapmprotocol.ContentTypeHeaderValue)
+			req.Header.Set(sapmprotocol.ContentEncodingHeaderName, zstdEncodingHeaderValue)
+			rw := httptest.NewRecorder()
+			sr.(*sapmReceiver).HTTPHandlerFunc(rw, req)
+
+			assert.Equal(t, http.StatusBadRequest, rw.Code)
+			assert.Equal(t, 0, sink.SpansCount())
+		})
+	}
+}
+
 // assertNoErrorHost implements a component.Host that asserts that there were no errors.
 type assertNoErrorHost struct {
 	component.Host
@@ -0,0 +1,143 @@
+// Copyright 2019, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sapmreceiver
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+const (
+	// validationModePassthrough forwards the spans as received.
+	validationModePassthrough = "passthrough"
+	// validationModeReject rejects requests containing spans violating the specification.
+	validationModeReject = "reject"
+	// validationModeSanitize fixes the spans that can be fixed and drops the others.
+	validationModeSanitize = "sanitize"
+)
+
+// violation is a way a span can violate the trace specification.
+type violation string
+
+const (
+	violationZeroTraceID    violation = "zero_trace_id"
+	violationZeroSpanID     violation = "zero_span_id"
+	violationEndBeforeStart violation = "end_before_start"
+	violationInvalidUTF8Key violation = "invalid_utf8_key"
+)
+
+// droppable reports whether the spans with the violation can not be fixed.
+func (v violation) droppable() bool {
+	return v == violationZeroTraceID || v == violationZeroSpanID
+}
+
+// validationError is returned when a request is rejected because of spans violating the specification.
+type validationError struct {
+	violations map[violation]int
+}
+
+func (e *validationError) Error() string {
+	parts := make([]string, 0, len(e.violations))
+	for v, count := range e.violations {
+		parts = append(parts, fmt.Sprintf("%s: %d", v, count))
+	}
+	sort.Strings(parts)
+	return "invalid spans received (" + strings.Join(parts, ", ") + ")"
+}
+
+// checkTraces counts the spans of td violating the specification. When
+// sanitize is true the spans with a zero trace or span id are removed, the
+// end timestamp of the spans ending before they start is set to their start
+// timestamp and the invalid UTF-8 sequences of attribute keys are replaced.
+func checkTraces(td pdata.Traces, sanitize bool) map[violation]int {
+	violations := map[violation]int{}
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		if checkAttributeKeys(rs.Resource().Attributes(), sanitize) {
+			violations[violationInvalidUTF8Key]++
+		}
+		ilss := rs.InstrumentationLibrarySpans()
+		for j := 0; j < ilss.Len(); j++ {
+			spans := ilss.At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				for _, v := range checkSpan(spans.At(k), sanitize) {
+					violations[v]++
+				}
+			}
+			if sanitize {
+				spans.RemoveIf(func(span pdata.Span) bool {
+					return span.TraceID().IsEmpty() || span.SpanID().IsEmpty()
+				})
+			}
+		}
+	}
+	return violations
+}
+
+func checkSpan(span pdata.Span, sanitize bool) []violation {
+	var violations []violation
+	if span.TraceID().IsEmpty() {
+		violations = append(violations, violationZeroTraceID)
+	}
+	if span.SpanID().IsEmpty() {
+		violations = append(violations, violationZeroSpanID)
+	}
+	if span.EndTimestamp() < span.StartTimestamp() {
+		violations = append(violations, violationEndBeforeStart)
+		if sanitize {
+			span.SetEndTimestamp(span.StartTimestamp())
+		}
+	}
+
+	invalidKey := checkAttributeKeys(span.Attributes(), sanitize)
+	events := span.Events()
+	for i := 0; i < events.Len(); i++ {
+		invalidKey = checkAttributeKeys(events.At(i).Attributes(), sanitize) || invalidKey
+	}
+	links := span.Links()
+	for i := 0; i < links.Len(); i++ {
+		invalidKey = checkAttributeKeys(links.At(i).Attributes(), sanitize) || invalidKey
+	}
+	if invalidKey {
+		violations = append(violations, violationInvalidUTF8Key)
+	}
+	return violations
+}
+
+// checkAttributeKeys reports whether attrs has keys that are not valid UTF-8.
+func checkAttributeKeys(attrs pdata.AttributeMap, sanitize bool) bool {
+	var invalid []string
+	attrs.Range(func(k string, _ pdata.AttributeValue) bool {
+		if !utf8.ValidString(k) {
+			invalid = append(invalid, k)
+		}
+		return true
+	})
+	if sanitize {
+		for _, k := range invalid {
+			v, _ := attrs.Get(k)
+			value := pdata.NewAttributeValueNull()
+			v.CopyTo(value)
+			attrs.Delete(k)
+			attrs.Upsert(strings.ToValidUTF8(k, string(utf8.RuneError)), value)
+		}
+	}
+	return len(invalid) > 0
+}
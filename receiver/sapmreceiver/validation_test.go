@@ -0,0 +1,103 @@
+// Copyright 2019, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sapmreceiver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+func invalidTraces() pdata.Traces {
+	td := pdata.NewTraces()
+	rs := td.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().InsertString("service.name", "svc")
+	rs.Resource().Attributes().InsertString("host\xffname", "h")
+	spans := rs.InstrumentationLibrarySpans().AppendEmpty().Spans()
+
+	valid := spans.AppendEmpty()
+	valid.SetName("valid")
+	valid.SetTraceID(pdata.NewTraceID([16]byte{1}))
+	valid.SetSpanID(pdata.NewSpanID([8]byte{1}))
+	valid.SetStartTimestamp(10)
+	valid.SetEndTimestamp(20)
+
+	zeroTraceID := spans.AppendEmpty()
+	zeroTraceID.SetName("zero trace id")
+	zeroTraceID.SetSpanID(pdata.NewSpanID([8]byte{2}))
+
+	zeroSpanID := spans.AppendEmpty()
+	zeroSpanID.SetName("zero span id")
+	zeroSpanID.SetTraceID(pdata.NewTraceID([16]byte{1}))
+
+	endBeforeStart := spans.AppendEmpty()
+	endBeforeStart.SetName("end before start")
+	endBeforeStart.SetTraceID(pdata.NewTraceID([16]byte{1}))
+	endBeforeStart.SetSpanID(pdata.NewSpanID([8]byte{3}))
+	endBeforeStart.SetStartTimestamp(20)
+	endBeforeStart.SetEndTimestamp(10)
+	endBeforeStart.Events().AppendEmpty().Attributes().InsertInt("count\xfe", 1)
+
+	return td
+}
+
+func TestCheckTracesReportsViolations(t *testing.T) {
+	td := invalidTraces()
+	violations := checkTraces(td, false)
+	assert.Equal(t, map[violation]int{
+		violationZeroTraceID:    1,
+		violationZeroSpanID:     1,
+		violationEndBeforeStart: 1,
+		violationInvalidUTF8Key: 2,
+	}, violations)
+
+	// the data is left untouched
+	assert.Equal(t, invalidTraces(), td)
+}
+
+func TestCheckTracesSanitizes(t *testing.T) {
+	td := invalidTraces()
+	checkTraces(td, true)
+
+	rs := td.ResourceSpans().At(0)
+	_, ok := rs.Resource().Attributes().Get("host�name")
+	assert.True(t, ok)
+	assert.Equal(t, 2, rs.Resource().Attributes().Len())
+
+	spans := rs.InstrumentationLibrarySpans().At(0).Spans()
+	require.Equal(t, 2, spans.Len())
+	assert.Equal(t, "valid", spans.At(0).Name())
+	assert.Equal(t, pdata.Timestamp(20), spans.At(0).EndTimestamp())
+
+	fixed := spans.At(1)
+	assert.Equal(t, "end before start", fixed.Name())
+	assert.Equal(t, pdata.Timestamp(20), fixed.EndTimestamp())
+	v, ok := fixed.Events().At(0).Attributes().Get("count�")
+	assert.True(t, ok)
+	assert.Equal(t, int64(1), v.IntVal())
+
+	// sanitized data has no violation left
+	assert.Empty(t, checkTraces(td, false))
+}
+
+func TestValidationErrorMessage(t *testing.T) {
+	err := &validationError{violations: map[violation]int{
+		violationZeroTraceID:    2,
+		violationEndBeforeStart: 1,
+	}}
+	assert.EqualError(t, err, "invalid spans received (end_before_start: 1, zero_trace_id: 2)")
+}
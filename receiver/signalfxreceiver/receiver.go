@@ -21,6 +21,7 @@ import (
 	"errors"
 	"fmt"
 	"io/ioutil"
+	"mime"
 	"net/http"
 	"sync"
 	"time"
@@ -37,6 +38,7 @@ import (
 	"go.opentelemetry.io/collector/translator/conventions"
 	"go.uber.org/zap"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/admissioncontrol"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/splunk"
 )
 
@@ -46,16 +48,19 @@ const (
 	responseOK                      = "OK"
 	responseInvalidMethod           = "Only \"POST\" method is supported"
 	responseInvalidContentType      = "\"Content-Type\" must be \"application/x-protobuf\""
+	responseInvalidEventContentType = "\"Content-Type\" must be \"application/x-protobuf\" or \"application/json\""
 	responseInvalidEncoding         = "\"Content-Encoding\" must be \"gzip\" or empty"
 	responseErrGzipReader           = "Error on gzip body"
 	responseErrReadBody             = "Failed to read message body"
 	responseErrUnmarshalBody        = "Failed to unmarshal message body"
 	responseErrNextConsumer         = "Internal Server Error"
+	responseErrAdmissionPaused      = "Too Many Requests"
 	responseErrLogsNotConfigured    = "Log pipeline has not been configured to handle events"
 	responseErrMetricsNotConfigured = "Metric pipeline has not been configured to handle datapoints"
 
 	// Centralizing some HTTP and related string constants.
 	protobufContentType       = "application/x-protobuf"
+	jsonContentType           = "application/json"
 	gzipEncoding              = "gzip"
 	httpContentTypeHeader     = "Content-Type"
 	httpContentEncodingHeader = "Content-Encoding"
@@ -64,16 +69,18 @@ const (
 var (
 	errEmptyEndpoint = errors.New("empty endpoint")
 
-	okRespBody               = initJSONResponse(responseOK)
-	invalidMethodRespBody    = initJSONResponse(responseInvalidMethod)
-	invalidContentRespBody   = initJSONResponse(responseInvalidContentType)
-	invalidEncodingRespBody  = initJSONResponse(responseInvalidEncoding)
-	errGzipReaderRespBody    = initJSONResponse(responseErrGzipReader)
-	errReadBodyRespBody      = initJSONResponse(responseErrReadBody)
-	errUnmarshalBodyRespBody = initJSONResponse(responseErrUnmarshalBody)
-	errNextConsumerRespBody  = initJSONResponse(responseErrNextConsumer)
-	errLogsNotConfigured     = initJSONResponse(responseErrLogsNotConfigured)
-	errMetricsNotConfigured  = initJSONResponse(responseErrMetricsNotConfigured)
+	okRespBody                 = initJSONResponse(responseOK)
+	invalidMethodRespBody      = initJSONResponse(responseInvalidMethod)
+	invalidContentRespBody     = initJSONResponse(responseInvalidContentType)
+	invalidEventContentBody    = initJSONResponse(responseInvalidEventContentType)
+	invalidEncodingRespBody    = initJSONResponse(responseInvalidEncoding)
+	errGzipReaderRespBody      = initJSONResponse(responseErrGzipReader)
+	errReadBodyRespBody        = initJSONResponse(responseErrReadBody)
+	errUnmarshalBodyRespBody   = initJSONResponse(responseErrUnmarshalBody)
+	errNextConsumerRespBody    = initJSONResponse(responseErrNextConsumer)
+	errAdmissionPausedRespBody = initJSONResponse(responseErrAdmissionPaused)
+	errLogsNotConfigured       = initJSONResponse(responseErrLogsNotConfigured)
+	errMetricsNotConfigured    = initJSONResponse(responseErrMetricsNotConfigured)
 )
 
 // sfxReceiver implements the component.MetricsReceiver for SignalFx metric protocol.
@@ -166,21 +173,31 @@ func (r *sfxReceiver) Shutdown(context.Context) error {
 	return r.server.Close()
 }
 
-func (r *sfxReceiver) readBody(ctx context.Context, resp http.ResponseWriter, req *http.Request) ([]byte, bool) {
+// readBody validates the request and returns its body along with its media
+// type, which is one of contentTypes, otherwise contentTypeRespBody is sent
+// back.
+func (r *sfxReceiver) readBody(
+	ctx context.Context,
+	resp http.ResponseWriter,
+	req *http.Request,
+	contentTypeRespBody []byte,
+	contentTypes ...string,
+) ([]byte, string, bool) {
 	if req.Method != http.MethodPost {
 		r.failRequest(ctx, resp, http.StatusBadRequest, invalidMethodRespBody, nil)
-		return nil, false
+		return nil, "", false
 	}
 
-	if req.Header.Get(httpContentTypeHeader) != protobufContentType {
-		r.failRequest(ctx, resp, http.StatusUnsupportedMediaType, invalidContentRespBody, nil)
-		return nil, false
+	mediaType, _, err := mime.ParseMediaType(req.Header.Get(httpContentTypeHeader))
+	if err != nil || !contains(contentTypes, mediaType) {
+		r.failRequest(ctx, resp, http.StatusUnsupportedMediaType, contentTypeRespBody, nil)
+		return nil, "", false
 	}
 
 	encoding := req.Header.Get(httpContentEncodingHeader)
 	if encoding != "" && encoding != gzipEncoding {
 		r.failRequest(ctx, resp, http.StatusUnsupportedMediaType, invalidEncodingRespBody, nil)
-		return nil, false
+		return nil, "", false
 	}
 
 	bodyReader := req.Body
@@ -189,19 +206,32 @@ func (r *sfxReceiver) readBody(ctx context.Context, resp http.ResponseWriter, re
 		bodyReader, err = gzip.NewReader(bodyReader)
 		if err != nil {
 			r.failRequest(ctx, resp, http.StatusBadRequest, errGzipReaderRespBody, err)
-			return nil, false
+			return nil, "", false
 		}
 	}
 
 	body, err := ioutil.ReadAll(bodyReader)
 	if err != nil {
 		r.failRequest(ctx, resp, http.StatusBadRequest, errReadBodyRespBody, err)
-		return nil, false
+		return nil, "", false
 	}
-	return body, true
+	return body, mediaType, true
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
 }
 
 func (r *sfxReceiver) writeResponse(ctx context.Context, resp http.ResponseWriter, err error) {
+	if admissioncontrol.SetRetryAfter(resp.Header(), err) {
+		r.failRequest(ctx, resp, http.StatusTooManyRequests, errAdmissionPausedRespBody, err)
+		return
+	}
 	if err != nil {
 		r.failRequest(ctx, resp, http.StatusInternalServerError, errNextConsumerRespBody, err)
 		return
@@ -225,7 +255,7 @@ func (r *sfxReceiver) handleDatapointReq(resp http.ResponseWriter, req *http.Req
 		return
 	}
 
-	body, ok := r.readBody(ctx, resp, req)
+	body, _, ok := r.readBody(ctx, resp, req, invalidContentRespBody, protobufContentType)
 	if !ok {
 		return
 	}
@@ -271,26 +301,38 @@ func (r *sfxReceiver) handleEventReq(resp http.ResponseWriter, req *http.Request
 	}
 
 	ctx := obsreport.ReceiverContext(req.Context(), r.config.ID(), transport)
-	ctx = r.obsrecv.StartMetricsOp(ctx)
+	ctx = r.obsrecv.StartLogsOp(ctx)
 
 	if r.logsConsumer == nil {
 		r.failRequest(ctx, resp, http.StatusBadRequest, errLogsNotConfigured, nil)
 		return
 	}
 
-	body, ok := r.readBody(ctx, resp, req)
+	body, contentType, ok := r.readBody(ctx, resp, req, invalidEventContentBody, protobufContentType, jsonContentType)
 	if !ok {
 		return
 	}
 
-	msg := &sfxpb.EventUploadMessage{}
-	if err := msg.Unmarshal(body); err != nil {
-		r.failRequest(ctx, resp, http.StatusBadRequest, errUnmarshalBodyRespBody, err)
-		return
+	var events []*sfxpb.Event
+	switch {
+	case len(body) == 0:
+	case contentType == jsonContentType:
+		var err error
+		if events, err = signalFxV2JSONToEvents(body); err != nil {
+			r.failRequest(ctx, resp, http.StatusBadRequest, errUnmarshalBodyRespBody, err)
+			return
+		}
+	default:
+		msg := &sfxpb.EventUploadMessage{}
+		if err := msg.Unmarshal(body); err != nil {
+			r.failRequest(ctx, resp, http.StatusBadRequest, errUnmarshalBodyRespBody, err)
+			return
+		}
+		events = msg.Events
 	}
 
-	if len(msg.Events) == 0 {
-		r.obsrecv.EndMetricsOp(ctx, typeStr, 0, nil)
+	if len(events) == 0 {
+		r.obsrecv.EndLogsOp(ctx, typeStr, 0, nil)
 		resp.Write(okRespBody)
 		return
 	}
@@ -298,7 +340,7 @@ func (r *sfxReceiver) handleEventReq(resp http.ResponseWriter, req *http.Request
 	ld := pdata.NewLogs()
 	rl := ld.ResourceLogs().AppendEmpty()
 	ill := rl.InstrumentationLibraryLogs().AppendEmpty()
-	signalFxV2EventsToLogRecords(msg.Events, ill.Logs())
+	signalFxV2EventsToLogRecords(events, ill.Logs())
 
 	if r.config.AccessTokenPassthrough {
 		if accessToken := req.Header.Get(splunk.SFxAccessTokenHeader); accessToken != "" {
@@ -307,10 +349,10 @@ func (r *sfxReceiver) handleEventReq(resp http.ResponseWriter, req *http.Request
 	}
 
 	err := r.logsConsumer.ConsumeLogs(ctx, ld)
-	r.obsrecv.EndMetricsOp(
+	r.obsrecv.EndLogsOp(
 		ctx,
 		typeStr,
-		len(msg.Events),
+		len(events),
 		err)
 
 	r.writeResponse(ctx, resp, err)
@@ -345,8 +387,11 @@ func (r *sfxReceiver) failRequest(
 	traceStatus := trace.Status{
 		Code: trace.StatusCodeInvalidArgument,
 	}
-	if httpStatusCode == http.StatusInternalServerError {
+	switch httpStatusCode {
+	case http.StatusInternalServerError:
 		traceStatus.Code = trace.StatusCodeInternal
+	case http.StatusTooManyRequests:
+		traceStatus.Code = trace.StatusCodeResourceExhausted
 	}
 	if err != nil {
 		traceStatus.Message = err.Error()
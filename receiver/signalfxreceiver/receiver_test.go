@@ -27,6 +27,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"runtime"
+	"strings"
 	"testing"
 	"time"
 
@@ -46,6 +47,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/signalfxexporter"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/admissioncontrol"
 )
 
 func Test_signalfxeceiver_New(t *testing.T) {
@@ -362,6 +364,33 @@ func Test_sfxReceiver_handleReq(t *testing.T) {
 	}
 }
 
+func Test_sfxReceiver_handleReqAdmissionPaused(t *testing.T) {
+	config := createDefaultConfig().(*Config)
+	config.Endpoint = "localhost:0" // Actually not creating the endpoint
+
+	sFxMsg := buildSFxDatapointMsg(time.Now().Unix()*1e3, 13, 3)
+	msgBytes, err := sFxMsg.Marshal()
+	require.NoError(t, err)
+
+	rcv := newReceiver(zap.NewNop(), *config)
+	rcv.RegisterMetricsConsumer(consumertest.NewErr(&admissioncontrol.PausedError{RetryAfter: 1500 * time.Millisecond}))
+
+	req := httptest.NewRequest("POST", "http://localhost", bytes.NewReader(msgBytes))
+	req.Header.Set("Content-Type", "application/x-protobuf")
+	w := httptest.NewRecorder()
+	rcv.handleDatapointReq(w, req)
+
+	resp := w.Result()
+	respBytes, err := ioutil.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	var bodyStr string
+	require.NoError(t, json.Unmarshal(respBytes, &bodyStr))
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	assert.Equal(t, "2", resp.Header.Get("Retry-After"))
+	assert.Equal(t, responseErrAdmissionPaused, bodyStr)
+}
+
 func Test_sfxReceiver_handleEventReq(t *testing.T) {
 	config := (NewFactory()).CreateDefaultConfig().(*Config)
 	config.Endpoint = "localhost:0" // Actually not creating the endpoint
@@ -407,7 +436,7 @@ func Test_sfxReceiver_handleEventReq(t *testing.T) {
 			}(),
 			assertResponse: func(t *testing.T, status int, body string) {
 				assert.Equal(t, http.StatusUnsupportedMediaType, status)
-				assert.Equal(t, responseInvalidContentType, body)
+				assert.Equal(t, responseInvalidEventContentType, body)
 			},
 		},
 		{
@@ -496,6 +525,44 @@ func Test_sfxReceiver_handleEventReq(t *testing.T) {
 				assert.Equal(t, responseOK, body)
 			},
 		},
+		{
+			name: "json_msg_accepted",
+			req: func() *http.Request {
+				req := httptest.NewRequest("POST", "http://localhost", strings.NewReader(
+					`[{"category": "USER_DEFINED", "eventType": "deployment", "dimensions": {"host": "h0"}, "timestamp": 1556793030000}]`,
+				))
+				req.Header.Set("Content-Type", "application/json; charset=utf-8")
+				return req
+			}(),
+			assertResponse: func(t *testing.T, status int, body string) {
+				assert.Equal(t, http.StatusOK, status)
+				assert.Equal(t, responseOK, body)
+			},
+		},
+		{
+			name: "json_empty_body",
+			req: func() *http.Request {
+				req := httptest.NewRequest("POST", "http://localhost", bytes.NewReader(nil))
+				req.Header.Set("Content-Type", "application/json")
+				return req
+			}(),
+			assertResponse: func(t *testing.T, status int, body string) {
+				assert.Equal(t, http.StatusOK, status)
+				assert.Equal(t, responseOK, body)
+			},
+		},
+		{
+			name: "json_bad_data_in_body",
+			req: func() *http.Request {
+				req := httptest.NewRequest("POST", "http://localhost", strings.NewReader(`[{"category": "NOPE", "eventType": "x"}]`))
+				req.Header.Set("Content-Type", "application/json")
+				return req
+			}(),
+			assertResponse: func(t *testing.T, status int, body string) {
+				assert.Equal(t, http.StatusBadRequest, status)
+				assert.Equal(t, responseErrUnmarshalBody, body)
+			},
+		},
 		{
 			name: "bad_gzipped_msg",
 			req: func() *http.Request {
@@ -0,0 +1,125 @@
+// Copyright OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signalfxreceiver
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	sfxpb "github.com/signalfx/com_signalfx_metrics_protobuf/model"
+)
+
+// jsonEvent is a SignalFx event as accepted by the JSON flavor of the
+// /v2/event endpoint.
+type jsonEvent struct {
+	Category   *string                `json:"category"`
+	EventType  string                 `json:"eventType"`
+	Dimensions map[string]string      `json:"dimensions"`
+	Properties map[string]interface{} `json:"properties"`
+	Timestamp  *int64                 `json:"timestamp"`
+}
+
+// signalFxV2JSONToEvents decodes a JSON array of SignalFx events into their
+// proto representation so that they follow the same path as proto events.
+// Events without a timestamp are assigned the current time, as the SignalFx
+// ingest API does.
+func signalFxV2JSONToEvents(body []byte) ([]*sfxpb.Event, error) {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	// Keep numbers as is to tell integer and double properties apart.
+	decoder.UseNumber()
+
+	var jsonEvents []jsonEvent
+	if err := decoder.Decode(&jsonEvents); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UnixNano() / 1e6
+	events := make([]*sfxpb.Event, 0, len(jsonEvents))
+	for _, je := range jsonEvents {
+		if je.EventType == "" {
+			return nil, fmt.Errorf("event is missing eventType")
+		}
+
+		event := &sfxpb.Event{
+			EventType: je.EventType,
+			Timestamp: now,
+		}
+		if je.Timestamp != nil {
+			event.Timestamp = *je.Timestamp
+		}
+
+		if je.Category != nil {
+			cat, ok := sfxpb.EventCategory_value[strings.ToUpper(*je.Category)]
+			if !ok {
+				return nil, fmt.Errorf("event %q has unknown category %q", je.EventType, *je.Category)
+			}
+			category := sfxpb.EventCategory(cat)
+			event.Category = &category
+		}
+
+		dimKeys := make([]string, 0, len(je.Dimensions))
+		for k := range je.Dimensions {
+			dimKeys = append(dimKeys, k)
+		}
+		sort.Strings(dimKeys)
+		for _, k := range dimKeys {
+			event.Dimensions = append(event.Dimensions, &sfxpb.Dimension{Key: k, Value: je.Dimensions[k]})
+		}
+
+		propKeys := make([]string, 0, len(je.Properties))
+		for k := range je.Properties {
+			propKeys = append(propKeys, k)
+		}
+		sort.Strings(propKeys)
+		for _, k := range propKeys {
+			val, err := jsonToPropertyValue(je.Properties[k])
+			if err != nil {
+				return nil, fmt.Errorf("event %q property %q: %w", je.EventType, k, err)
+			}
+			event.Properties = append(event.Properties, &sfxpb.Property{Key: k, Value: val})
+		}
+
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+func jsonToPropertyValue(v interface{}) (*sfxpb.PropertyValue, error) {
+	pv := &sfxpb.PropertyValue{}
+	switch val := v.(type) {
+	case nil:
+	case string:
+		pv.StrValue = &val
+	case bool:
+		pv.BoolValue = &val
+	case json.Number:
+		if i, err := val.Int64(); err == nil {
+			pv.IntValue = &i
+			break
+		}
+		f, err := val.Float64()
+		if err != nil {
+			return nil, err
+		}
+		pv.DoubleValue = &f
+	default:
+		return nil, fmt.Errorf("unsupported value type %T", v)
+	}
+	return pv, nil
+}
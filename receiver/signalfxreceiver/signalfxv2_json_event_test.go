@@ -0,0 +1,118 @@
+// Copyright OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signalfxreceiver
+
+import (
+	"testing"
+
+	sfxpb "github.com/signalfx/com_signalfx_metrics_protobuf/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignalFxV2JSONToEvents(t *testing.T) {
+	userDefinedCat := sfxpb.EventCategory_USER_DEFINED
+	strVal := "prod"
+	intVal := int64(5)
+	doubleVal := 40.5
+	boolVal := true
+
+	tests := []struct {
+		name    string
+		body    string
+		want    []*sfxpb.Event
+		wantErr bool
+	}{
+		{
+			name: "full_event",
+			body: `[{
+				"category": "USER_DEFINED",
+				"eventType": "deployment",
+				"dimensions": {"k1": "v1", "k0": "v0"},
+				"properties": {"env": "prod", "rack": 5, "temp": 40.5, "isActive": true, "nullProp": null},
+				"timestamp": 1556793030000
+			}]`,
+			want: []*sfxpb.Event{{
+				EventType: "deployment",
+				Category:  &userDefinedCat,
+				Timestamp: 1556793030000,
+				Dimensions: []*sfxpb.Dimension{
+					{Key: "k0", Value: "v0"},
+					{Key: "k1", Value: "v1"},
+				},
+				Properties: []*sfxpb.Property{
+					{Key: "env", Value: &sfxpb.PropertyValue{StrValue: &strVal}},
+					{Key: "isActive", Value: &sfxpb.PropertyValue{BoolValue: &boolVal}},
+					{Key: "nullProp", Value: &sfxpb.PropertyValue{}},
+					{Key: "rack", Value: &sfxpb.PropertyValue{IntValue: &intVal}},
+					{Key: "temp", Value: &sfxpb.PropertyValue{DoubleValue: &doubleVal}},
+				},
+			}},
+		},
+		{
+			name: "lower_case_category",
+			body: `[{"category": "user_defined", "eventType": "deployment", "timestamp": 1}]`,
+			want: []*sfxpb.Event{{
+				EventType: "deployment",
+				Category:  &userDefinedCat,
+				Timestamp: 1,
+			}},
+		},
+		{
+			name: "no_events",
+			body: `[]`,
+			want: []*sfxpb.Event{},
+		},
+		{
+			name:    "unknown_category",
+			body:    `[{"category": "NOPE", "eventType": "deployment"}]`,
+			wantErr: true,
+		},
+		{
+			name:    "missing_event_type",
+			body:    `[{"category": "ALERT"}]`,
+			wantErr: true,
+		},
+		{
+			name:    "nested_property",
+			body:    `[{"eventType": "deployment", "properties": {"nested": {"a": 1}}}]`,
+			wantErr: true,
+		},
+		{
+			name:    "not_an_array",
+			body:    `{"eventType": "deployment"}`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := signalFxV2JSONToEvents([]byte(tt.body))
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSignalFxV2JSONToEventsDefaultsTimestamp(t *testing.T) {
+	events, err := signalFxV2JSONToEvents([]byte(`[{"eventType": "deployment"}]`))
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.NotZero(t, events[0].Timestamp)
+	assert.Nil(t, events[0].Category)
+}
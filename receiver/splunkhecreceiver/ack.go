@@ -0,0 +1,110 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package splunkhecreceiver
+
+import (
+	"net/http"
+	"sync"
+)
+
+const (
+	// maxPendingAcks is the maximum number of ack IDs of a channel retained until they are
+	// queried, the oldest are forgotten beyond.
+	maxPendingAcks = 10000
+
+	// Centralizing the names of the channel header and query parameter.
+	httpRequestChannelHeader = "X-Splunk-Request-Channel"
+	channelQueryParam        = "channel"
+)
+
+// ackResponse is the response to a data request when the acknowledgement is enabled.
+type ackResponse struct {
+	Text  string `json:"text"`
+	Code  int    `json:"code"`
+	AckID uint64 `json:"ackId"`
+}
+
+// ackRequest is the body of a request to the ack endpoint.
+type ackRequest struct {
+	Acks []uint64 `json:"acks"`
+}
+
+// ackStatusResponse is the response to a request to the ack endpoint.
+type ackStatusResponse struct {
+	Acks map[uint64]bool `json:"acks"`
+}
+
+// ackTracker emulates the indexer acknowledgement of HEC: it assigns, per channel, an ack ID to
+// each request whose data is accepted by the next consumer, and reports these IDs as
+// acknowledged the first time they are queried.
+type ackTracker struct {
+	mu       sync.Mutex
+	channels map[string]*ackChannel
+}
+
+type ackChannel struct {
+	nextID  uint64
+	pending map[uint64]struct{}
+}
+
+func newAckTracker() *ackTracker {
+	return &ackTracker{channels: map[string]*ackChannel{}}
+}
+
+// add returns a new ack ID of the channel, to be acknowledged.
+func (t *ackTracker) add(channel string) uint64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	c, ok := t.channels[channel]
+	if !ok {
+		c = &ackChannel{pending: map[uint64]struct{}{}}
+		t.channels[channel] = c
+	}
+	id := c.nextID
+	c.nextID++
+	c.pending[id] = struct{}{}
+	if id >= maxPendingAcks {
+		delete(c.pending, id-maxPendingAcks)
+	}
+	return id
+}
+
+// query returns whether the given ack IDs of the channel are acknowledged, the acknowledged
+// IDs are forgotten.
+func (t *ackTracker) query(channel string, ids []uint64) map[uint64]bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	statuses := make(map[uint64]bool, len(ids))
+	c := t.channels[channel]
+	for _, id := range ids {
+		if c == nil {
+			statuses[id] = false
+			continue
+		}
+		_, statuses[id] = c.pending[id]
+		delete(c.pending, id)
+	}
+	return statuses
+}
+
+// requestChannel returns the channel of the request, from its header or its query.
+func requestChannel(req *http.Request) string {
+	if channel := req.Header.Get(httpRequestChannelHeader); channel != "" {
+		return channel
+	}
+	return req.URL.Query().Get(channelQueryParam)
+}
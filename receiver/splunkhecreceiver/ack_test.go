@@ -0,0 +1,51 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package splunkhecreceiver
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAckTracker(t *testing.T) {
+	tracker := newAckTracker()
+	assert.Equal(t, uint64(0), tracker.add("a"))
+	assert.Equal(t, uint64(1), tracker.add("a"))
+	assert.Equal(t, uint64(0), tracker.add("b"))
+
+	assert.Equal(t, map[uint64]bool{0: true, 2: false}, tracker.query("a", []uint64{0, 2}))
+	assert.Equal(t, map[uint64]bool{0: false, 1: true}, tracker.query("a", []uint64{0, 1}))
+	assert.Equal(t, map[uint64]bool{0: true}, tracker.query("b", []uint64{0}))
+	assert.Equal(t, map[uint64]bool{0: false}, tracker.query("c", []uint64{0}))
+}
+
+func TestAckTrackerMaxPending(t *testing.T) {
+	tracker := newAckTracker()
+	for i := 0; i < maxPendingAcks+1; i++ {
+		tracker.add("a")
+	}
+	assert.Len(t, tracker.channels["a"].pending, maxPendingAcks)
+	assert.Equal(t, map[uint64]bool{0: false, 1: true, maxPendingAcks: true}, tracker.query("a", []uint64{0, 1, maxPendingAcks}))
+}
+
+func TestRequestChannel(t *testing.T) {
+	req := httptest.NewRequest("POST", "http://localhost/services/collector?channel=query", nil)
+	assert.Equal(t, "query", requestChannel(req))
+	req.Header.Set(httpRequestChannelHeader, "header")
+	assert.Equal(t, "header", requestChannel(req))
+	assert.Equal(t, "", requestChannel(httptest.NewRequest("POST", "http://localhost", nil)))
+}
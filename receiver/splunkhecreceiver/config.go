@@ -35,6 +35,18 @@ type Config struct {
 	// Path we will listen on, defaults to `*` (anything matches)
 	Path     string `mapstructure:"path"`
 	pathGlob glob.Glob
+	// Ack configures the emulation of the HEC indexer acknowledgement.
+	Ack AckSettings `mapstructure:"ack"`
+}
+
+// AckSettings defines the settings of the emulation of the HEC indexer acknowledgement.
+type AckSettings struct {
+	// Enabled makes data requests require a channel, given by the X-Splunk-Request-Channel
+	// header or the channel query parameter, and answers them with an ack ID which is reported
+	// as acknowledged by the ack endpoint once the data is accepted by the pipeline.
+	Enabled bool `mapstructure:"enabled"`
+	// Path of the ack endpoint, defaults to /services/collector/ack.
+	Path string `mapstructure:"path"`
 }
 
 // initialize and initialize the configuration
@@ -128,6 +128,10 @@ func TestLoadConfig(t *testing.T) {
 				AccessTokenPassthrough: true,
 			},
 			Path: "/foo",
+			Ack: AckSettings{
+				Enabled: true,
+				Path:    "/foo/ack",
+			},
 		})
 
 	r2 := cfg.Receivers[config.NewIDWithName(typeStr, "tls")].(*Config)
@@ -147,5 +151,8 @@ func TestLoadConfig(t *testing.T) {
 				AccessTokenPassthrough: false,
 			},
 			Path: "",
+			Ack: AckSettings{
+				Path: defaultAckPath,
+			},
 		})
 }
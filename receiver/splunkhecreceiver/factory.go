@@ -34,6 +34,9 @@ const (
 
 	// Default endpoints to bind to.
 	defaultEndpoint = ":8088"
+
+	// Default path of the ack endpoint.
+	defaultAckPath = "/services/collector/ack"
 )
 
 // NewFactory creates a factory for SignalFx receiver.
@@ -54,6 +57,9 @@ func createDefaultConfig() config.Receiver {
 		},
 		AccessTokenPassthroughConfig: splunk.AccessTokenPassthroughConfig{},
 		Path:                         "",
+		Ack: AckSettings{
+			Path: defaultAckPath,
+		},
 	}
 }
 
@@ -34,6 +34,7 @@ import (
 	"go.opentelemetry.io/collector/translator/conventions"
 	"go.uber.org/zap"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/admissioncontrol"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/splunk"
 )
 
@@ -47,8 +48,12 @@ const (
 	responseErrGzipReader             = "Error on gzip body"
 	responseErrUnmarshalBody          = "Failed to unmarshal message body"
 	responseErrInternalServerError    = "Internal Server Error"
+	responseErrAdmissionPaused        = "Too Many Requests"
 	responseErrUnsupportedMetricEvent = "Unsupported metric event"
 	responseErrUnsupportedLogEvent    = "Unsupported log event"
+	responseErrDataChannelMissing     = "Data channel is missing"
+	responseErrInvalidDataFormat      = "Invalid data format"
+	responseSuccess                   = "Success"
 
 	// Centralizing some HTTP and related string constants.
 	gzipEncoding              = "gzip"
@@ -67,8 +72,11 @@ var (
 	errGzipReaderRespBody     = initJSONResponse(responseErrGzipReader)
 	errUnmarshalBodyRespBody  = initJSONResponse(responseErrUnmarshalBody)
 	errInternalServerError    = initJSONResponse(responseErrInternalServerError)
+	errAdmissionPaused        = initJSONResponse(responseErrAdmissionPaused)
 	errUnsupportedMetricEvent = initJSONResponse(responseErrUnsupportedMetricEvent)
 	errUnsupportedLogEvent    = initJSONResponse(responseErrUnsupportedLogEvent)
+	errDataChannelMissing     = initJSONResponse(responseErrDataChannelMissing)
+	errInvalidDataFormat      = initJSONResponse(responseErrInvalidDataFormat)
 )
 
 // splunkReceiver implements the component.MetricsReceiver for Splunk HEC metric protocol.
@@ -80,6 +88,8 @@ type splunkReceiver struct {
 	metricsConsumer consumer.Metrics
 	server          *http.Server
 	obsrecv         *obsreport.Receiver
+	// acks is set when the acknowledgement is enabled.
+	acks *ackTracker
 }
 
 var _ component.MetricsReceiver = (*splunkReceiver)(nil)
@@ -116,6 +126,9 @@ func NewMetricsReceiver(
 		},
 		obsrecv: obsreport.NewReceiver(obsreport.ReceiverSettings{ReceiverID: config.ID(), Transport: transport}),
 	}
+	if config.Ack.Enabled {
+		r.acks = newAckTracker()
+	}
 
 	return r, nil
 }
@@ -146,6 +159,9 @@ func NewLogsReceiver(
 			WriteTimeout:      defaultServerTimeout,
 		},
 	}
+	if config.Ack.Enabled {
+		r.acks = newAckTracker()
+	}
 
 	return r, nil
 }
@@ -165,6 +181,9 @@ func (r *splunkReceiver) Start(_ context.Context, host component.Host) error {
 	}
 
 	mx := mux.NewRouter()
+	if r.acks != nil {
+		mx.Path(r.config.Ack.Path).HandlerFunc(r.handleAck)
+	}
 	mx.NewRoute().HandlerFunc(r.handleReq)
 
 	r.server = r.config.HTTPServerSettings.ToServer(mx)
@@ -232,6 +251,11 @@ func (r *splunkReceiver) handleReq(resp http.ResponseWriter, req *http.Request)
 		}
 	}
 
+	if r.acks != nil && requestChannel(req) == "" {
+		r.failRequest(ctx, resp, http.StatusBadRequest, errDataChannelMissing, nil)
+		return
+	}
+
 	if req.ContentLength == 0 {
 		resp.Write(okRespBody)
 		return
@@ -284,11 +308,12 @@ func (r *splunkReceiver) consumeMetrics(ctx context.Context, events []*splunk.Ev
 	decodeErr := r.metricsConsumer.ConsumeMetrics(ctx, md)
 	r.obsrecv.EndMetricsOp(ctx, typeStr, len(events), decodeErr)
 
-	if decodeErr != nil {
+	if admissioncontrol.SetRetryAfter(resp.Header(), decodeErr) {
+		r.failRequest(ctx, resp, http.StatusTooManyRequests, errAdmissionPaused, decodeErr)
+	} else if decodeErr != nil {
 		r.failRequest(ctx, resp, http.StatusInternalServerError, errInternalServerError, decodeErr)
 	} else {
-		resp.WriteHeader(http.StatusAccepted)
-		resp.Write(okRespBody)
+		r.writeSuccess(resp, req)
 	}
 }
 
@@ -301,11 +326,57 @@ func (r *splunkReceiver) consumeLogs(ctx context.Context, events []*splunk.Event
 
 	decodeErr := r.logsConsumer.ConsumeLogs(ctx, ld)
 
-	if decodeErr != nil {
+	if admissioncontrol.SetRetryAfter(resp.Header(), decodeErr) {
+		r.failRequest(ctx, resp, http.StatusTooManyRequests, errAdmissionPaused, decodeErr)
+	} else if decodeErr != nil {
 		r.failRequest(ctx, resp, http.StatusInternalServerError, errInternalServerError, decodeErr)
 	} else {
+		r.writeSuccess(resp, req)
+	}
+}
+
+// writeSuccess answers a request whose data was accepted, with an ack ID when the
+// acknowledgement is enabled.
+func (r *splunkReceiver) writeSuccess(resp http.ResponseWriter, req *http.Request) {
+	if r.acks == nil {
 		resp.WriteHeader(http.StatusAccepted)
 		resp.Write(okRespBody)
+		return
+	}
+
+	resp.Header().Set("Content-Type", "application/json")
+	resp.WriteHeader(http.StatusOK)
+	ack := ackResponse{Text: responseSuccess, AckID: r.acks.add(requestChannel(req))}
+	if err := json.NewEncoder(resp).Encode(ack); err != nil {
+		r.logger.Warn("Error writing HTTP response message", zap.Error(err))
+	}
+}
+
+// handleAck answers the queries of the acknowledgement of ack IDs.
+func (r *splunkReceiver) handleAck(resp http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	if req.Method != http.MethodPost {
+		r.failRequest(ctx, resp, http.StatusBadRequest, invalidMethodRespBody, nil)
+		return
+	}
+
+	channel := requestChannel(req)
+	if channel == "" {
+		r.failRequest(ctx, resp, http.StatusBadRequest, errDataChannelMissing, nil)
+		return
+	}
+
+	var ackReq ackRequest
+	if err := json.NewDecoder(req.Body).Decode(&ackReq); err != nil {
+		r.failRequest(ctx, resp, http.StatusBadRequest, errInvalidDataFormat, err)
+		return
+	}
+
+	resp.Header().Set("Content-Type", "application/json")
+	resp.WriteHeader(http.StatusOK)
+	status := ackStatusResponse{Acks: r.acks.query(channel, ackReq.Acks)}
+	if err := json.NewEncoder(resp).Encode(status); err != nil {
+		r.logger.Warn("Error writing HTTP response message", zap.Error(err))
 	}
 }
 
@@ -334,8 +405,11 @@ func (r *splunkReceiver) failRequest(
 	traceStatus := trace.Status{
 		Code: trace.StatusCodeInvalidArgument,
 	}
-	if httpStatusCode == http.StatusInternalServerError {
+	switch httpStatusCode {
+	case http.StatusInternalServerError:
 		traceStatus.Code = trace.StatusCodeInternal
+	case http.StatusTooManyRequests:
+		traceStatus.Code = trace.StatusCodeResourceExhausted
 	}
 	if err != nil {
 		traceStatus.Message = err.Error()
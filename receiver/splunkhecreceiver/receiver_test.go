@@ -42,6 +42,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/splunkhecexporter"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/admissioncontrol"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/splunk"
 )
 
@@ -384,6 +385,96 @@ func Test_consumer_err_metrics(t *testing.T) {
 	assert.Equal(t, "Internal Server Error", bodyStr)
 }
 
+func Test_consumer_err_admission_paused(t *testing.T) {
+	currentTime := float64(time.Now().UnixNano()) / 1e6
+	splunkMsg := buildSplunkHecMsg(currentTime, 3)
+	config := createDefaultConfig().(*Config)
+	config.Endpoint = "localhost:0" // Actually not creating the endpoint
+	config.initialize()
+	rcv, err := NewLogsReceiver(zap.NewNop(), *config, consumertest.NewErr(&admissioncontrol.PausedError{RetryAfter: 1500 * time.Millisecond}))
+	assert.NoError(t, err)
+
+	r := rcv.(*splunkReceiver)
+	w := httptest.NewRecorder()
+	msgBytes, err := json.Marshal(splunkMsg)
+	require.NoError(t, err)
+	req := httptest.NewRequest("POST", "http://localhost", bytes.NewReader(msgBytes))
+	r.handleReq(w, req)
+
+	resp := w.Result()
+	respBytes, err := ioutil.ReadAll(resp.Body)
+	assert.NoError(t, err)
+
+	var bodyStr string
+	assert.NoError(t, json.Unmarshal(respBytes, &bodyStr))
+
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	assert.Equal(t, "2", resp.Header.Get("Retry-After"))
+	assert.Equal(t, "Too Many Requests", bodyStr)
+}
+
+func Test_splunkhecReceiver_Ack(t *testing.T) {
+	addr := testutil.GetAvailableLocalAddress(t)
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = addr
+	cfg.Ack.Enabled = true
+	require.NoError(t, cfg.initialize())
+	sink := new(consumertest.LogsSink)
+	r, err := NewLogsReceiver(zap.NewNop(), *cfg, sink)
+	require.NoError(t, err)
+	require.NoError(t, r.Start(context.Background(), newAssertNoErrorHost(t)))
+	defer r.Shutdown(context.Background())
+
+	post := func(path string, channel string, body interface{}) (int, []byte) {
+		msgBytes, err := json.Marshal(body)
+		require.NoError(t, err)
+		req, err := http.NewRequest("POST", "http://"+addr+path, bytes.NewReader(msgBytes))
+		require.NoError(t, err)
+		if channel != "" {
+			req.Header.Set("X-Splunk-Request-Channel", channel)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		respBytes, err := ioutil.ReadAll(resp.Body)
+		require.NoError(t, err)
+		return resp.StatusCode, respBytes
+	}
+
+	splunkMsg := buildSplunkHecMsg(float64(time.Now().UnixNano())/1e6, 3)
+
+	status, body := post("/services/collector", "", splunkMsg)
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Equal(t, `"Data channel is missing"`, string(body))
+
+	for i := 0; i < 2; i++ {
+		status, body = post("/services/collector", "channel-1", splunkMsg)
+		assert.Equal(t, http.StatusOK, status)
+		assert.JSONEq(t, fmt.Sprintf(`{"text":"Success","code":0,"ackId":%d}`, i), string(body))
+	}
+	status, body = post("/services/collector/event?channel=channel-2", "", splunkMsg)
+	assert.Equal(t, http.StatusOK, status)
+	assert.JSONEq(t, `{"text":"Success","code":0,"ackId":0}`, string(body))
+	assert.Equal(t, 3, sink.LogRecordsCount())
+
+	status, body = post("/services/collector/ack", "channel-1", ackRequest{Acks: []uint64{0, 1, 2}})
+	assert.Equal(t, http.StatusOK, status)
+	assert.JSONEq(t, `{"acks":{"0":true,"1":true,"2":false}}`, string(body))
+
+	// Acks are only reported once.
+	status, body = post("/services/collector/ack", "channel-1", ackRequest{Acks: []uint64{0}})
+	assert.Equal(t, http.StatusOK, status)
+	assert.JSONEq(t, `{"acks":{"0":false}}`, string(body))
+
+	status, body = post("/services/collector/ack", "", ackRequest{Acks: []uint64{0}})
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Equal(t, `"Data channel is missing"`, string(body))
+
+	status, body = post("/services/collector/ack", "channel-2", "not an ack request")
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Equal(t, `"Invalid data format"`, string(body))
+}
+
 func Test_splunkhecReceiver_TLS(t *testing.T) {
 	addr := testutil.GetAvailableLocalAddress(t)
 	cfg := createDefaultConfig().(*Config)
@@ -56,6 +56,7 @@ func SplunkHecToLogData(logger *zap.Logger, events []*splunk.Event, resourceCust
 		}
 		if event.Source != "" {
 			logRecord.Attributes().InsertString(conventions.AttributeServiceName, event.Source)
+			logRecord.Attributes().InsertString(splunk.SourceLabel, event.Source)
 		}
 		if event.SourceType != "" {
 			logRecord.Attributes().InsertString(splunk.SourcetypeLabel, event.SourceType)
@@ -168,6 +168,7 @@ func createLogsSlice(nanoseconds int) pdata.ResourceLogsSlice {
 	logRecord.SetTimestamp(pdata.Timestamp(nanoseconds))
 	logRecord.Attributes().InsertString("host.name", "localhost")
 	logRecord.Attributes().InsertString("service.name", "mysource")
+	logRecord.Attributes().InsertString("com.splunk.source", "mysource")
 	logRecord.Attributes().InsertString("com.splunk.sourcetype", "mysourcetype")
 	logRecord.Attributes().InsertString("com.splunk.index", "myindex")
 	logRecord.Attributes().InsertString("foo", "bar")
@@ -41,6 +41,7 @@ func SplunkHecToMetricsData(logger *zap.Logger, events []*splunk.Event, resource
 		}
 		if event.Source != "" {
 			attrs.InsertString(conventions.AttributeServiceName, event.Source)
+			attrs.InsertString(splunk.SourceLabel, event.Source)
 		}
 		if event.SourceType != "" {
 			attrs.InsertString(splunk.SourcetypeLabel, event.SourceType)
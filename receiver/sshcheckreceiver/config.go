@@ -0,0 +1,66 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sshcheckreceiver
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	"go.opentelemetry.io/collector/config/confignet"
+	"go.opentelemetry.io/collector/receiver/scraperhelper"
+)
+
+// Config defines the configuration for the SSH check receiver.
+type Config struct {
+	scraperhelper.ScraperControllerSettings `mapstructure:",squash"`
+	confignet.TCPAddr                       `mapstructure:",squash"`
+
+	// Username used to authenticate.
+	Username string `mapstructure:"username"`
+	// Password used to authenticate, if set.
+	Password string `mapstructure:"password"`
+	// KeyFile is the path of a private key used to authenticate, if set.
+	KeyFile string `mapstructure:"key_file"`
+
+	// KnownHosts is the path of a known_hosts file used to verify the host key of the endpoint.
+	KnownHosts string `mapstructure:"known_hosts"`
+	// IgnoreHostKey disables the verification of the host key of the endpoint.
+	IgnoreHostKey bool `mapstructure:"ignore_host_key"`
+
+	// Timeout for connecting, completing the handshake and authenticating.
+	Timeout time.Duration `mapstructure:"timeout"`
+}
+
+// Validate checks that the receiver configuration is valid.
+func (cfg *Config) Validate() error {
+	if _, _, err := net.SplitHostPort(cfg.Endpoint); err != nil {
+		return fmt.Errorf("invalid endpoint %q: %w", cfg.Endpoint, err)
+	}
+	if cfg.Username == "" {
+		return errors.New("username must be specified")
+	}
+	if cfg.Password == "" && cfg.KeyFile == "" {
+		return errors.New("either password or key_file must be specified")
+	}
+	if cfg.KnownHosts == "" && !cfg.IgnoreHostKey {
+		return errors.New("known_hosts must be specified unless ignore_host_key is set")
+	}
+	if cfg.Timeout <= 0 {
+		return errors.New("timeout must be greater than zero")
+	}
+	return nil
+}
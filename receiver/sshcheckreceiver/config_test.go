@@ -0,0 +1,124 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sshcheckreceiver
+
+import (
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/confignet"
+	"go.opentelemetry.io/collector/config/configtest"
+	"go.opentelemetry.io/collector/receiver/scraperhelper"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	require.NoError(t, err)
+
+	factory := NewFactory()
+	factories.Receivers[typeStr] = factory
+
+	cfg, err := configtest.LoadConfigAndValidate(path.Join(".", "testdata", "config.yaml"), factories)
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	defaultCfg := factory.CreateDefaultConfig().(*Config)
+	defaultCfg.Endpoint = "localhost:22"
+	defaultCfg.Username = "otel"
+	defaultCfg.Password = "secret"
+	defaultCfg.KnownHosts = "/home/otel/.ssh/known_hosts"
+	assert.Equal(t, defaultCfg, cfg.Receivers[config.NewID(typeStr)])
+	assert.Equal(t,
+		&Config{
+			ScraperControllerSettings: scraperhelper.ScraperControllerSettings{
+				ReceiverSettings:   config.NewReceiverSettings(config.NewIDWithName(typeStr, "bastion")),
+				CollectionInterval: 30 * time.Second,
+			},
+			TCPAddr:       confignet.TCPAddr{Endpoint: "bastion.example.com:2222"},
+			Username:      "monitor",
+			KeyFile:       "/etc/otel/id_ed25519",
+			IgnoreHostKey: true,
+			Timeout:       5 * time.Second,
+		},
+		cfg.Receivers[config.NewIDWithName(typeStr, "bastion")],
+	)
+}
+
+func TestValidateConfig(t *testing.T) {
+	cases := []struct {
+		name     string
+		modify   func(cfg *Config)
+		expected string
+	}{
+		{
+			name:   "valid",
+			modify: func(cfg *Config) {},
+		},
+		{
+			name:     "invalid_endpoint",
+			modify:   func(cfg *Config) { cfg.Endpoint = "localhost" },
+			expected: `invalid endpoint "localhost": address localhost: missing port in address`,
+		},
+		{
+			name:     "missing_username",
+			modify:   func(cfg *Config) { cfg.Username = "" },
+			expected: "username must be specified",
+		},
+		{
+			name:     "missing_credentials",
+			modify:   func(cfg *Config) { cfg.Password = "" },
+			expected: "either password or key_file must be specified",
+		},
+		{
+			name:     "missing_known_hosts",
+			modify:   func(cfg *Config) { cfg.KnownHosts = "" },
+			expected: "known_hosts must be specified unless ignore_host_key is set",
+		},
+		{
+			name: "ignore_host_key",
+			modify: func(cfg *Config) {
+				cfg.KnownHosts = ""
+				cfg.IgnoreHostKey = true
+			},
+		},
+		{
+			name:     "invalid_timeout",
+			modify:   func(cfg *Config) { cfg.Timeout = 0 },
+			expected: "timeout must be greater than zero",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.Endpoint = "localhost:22"
+			cfg.Username = "otel"
+			cfg.Password = "secret"
+			cfg.KnownHosts = "known_hosts"
+			tc.modify(cfg)
+			err := cfg.Validate()
+			if tc.expected == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expected)
+			}
+		})
+	}
+}
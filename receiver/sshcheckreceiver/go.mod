@@ -0,0 +1,10 @@
+module github.com/open-telemetry/opentelemetry-collector-contrib/receiver/sshcheckreceiver
+
+go 1.16
+
+require (
+	github.com/stretchr/testify v1.7.0
+	go.opentelemetry.io/collector v0.27.1-0.20210603182316-5369d7e9e83e
+	go.uber.org/zap v1.17.0
+	golang.org/x/crypto v0.0.0-20210421170649-83a5a9bb288b
+)
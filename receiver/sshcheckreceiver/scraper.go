@@ -0,0 +1,177 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sshcheckreceiver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"strings"
+	"syscall"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/consumer/simple"
+	"go.uber.org/zap"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/sshcheckreceiver/internal/metadata"
+)
+
+type sshCheckScraper struct {
+	dialer       *net.Dialer
+	clientConfig *ssh.ClientConfig
+
+	logger *zap.Logger
+	cfg    *Config
+}
+
+func newSSHCheckScraper(
+	logger *zap.Logger,
+	cfg *Config,
+) *sshCheckScraper {
+	return &sshCheckScraper{
+		dialer: &net.Dialer{Timeout: cfg.Timeout},
+		logger: logger,
+		cfg:    cfg,
+	}
+}
+
+func (r *sshCheckScraper) start(context.Context, component.Host) error {
+	var auth []ssh.AuthMethod
+	if r.cfg.KeyFile != "" {
+		key, err := ioutil.ReadFile(r.cfg.KeyFile)
+		if err != nil {
+			return fmt.Errorf("failed to read key_file: %w", err)
+		}
+		signer, err := ssh.ParsePrivateKey(key)
+		if err != nil {
+			return fmt.Errorf("failed to parse key_file: %w", err)
+		}
+		auth = append(auth, ssh.PublicKeys(signer))
+	}
+	if r.cfg.Password != "" {
+		auth = append(auth, ssh.Password(r.cfg.Password))
+	}
+
+	hostKeyCallback := ssh.InsecureIgnoreHostKey()
+	if !r.cfg.IgnoreHostKey {
+		var err error
+		hostKeyCallback, err = knownhosts.New(r.cfg.KnownHosts)
+		if err != nil {
+			return fmt.Errorf("failed to load known_hosts: %w", err)
+		}
+	}
+
+	r.clientConfig = &ssh.ClientConfig{
+		User:            r.cfg.Username,
+		Auth:            auth,
+		HostKeyCallback: hostKeyCallback,
+		Timeout:         r.cfg.Timeout,
+	}
+	return nil
+}
+
+// scrape probes the endpoint. A failed probe is reported through metrics rather than as a
+// scrape error, as an unreachable endpoint is what this receiver monitors.
+func (r *sshCheckScraper) scrape(ctx context.Context) (pdata.ResourceMetricsSlice, error) {
+	metrics := simple.Metrics{
+		Metrics:                    pdata.NewMetrics(),
+		Timestamp:                  time.Now(),
+		MetricFactoriesByName:      metadata.M.FactoriesByName(),
+		InstrumentationLibraryName: "otelcol/sshcheck",
+		Labels: map[string]string{
+			metadata.L.SSHEndpoint: r.cfg.Endpoint,
+		},
+	}
+
+	start := time.Now()
+	if err := r.probe(ctx); err != nil {
+		r.logger.Debug("SSH check failed", zap.String("endpoint", r.cfg.Endpoint), zap.Error(err))
+		metrics.AddGaugeDataPoint(metadata.M.SshcheckStatus.Name(), 0)
+		metrics.WithLabels(map[string]string{metadata.L.ErrorCode: errorCode(err)}).
+			AddGaugeDataPoint(metadata.M.SshcheckError.Name(), 1)
+		return metrics.Metrics.ResourceMetrics(), nil
+	}
+	duration := time.Since(start)
+
+	metrics.AddGaugeDataPoint(metadata.M.SshcheckStatus.Name(), 1)
+	metrics.AddGaugeDataPoint(metadata.M.SshcheckDuration.Name(), duration.Milliseconds())
+	return metrics.Metrics.ResourceMetrics(), nil
+}
+
+// probe connects to the endpoint, completes the SSH handshake and authenticates.
+func (r *sshCheckScraper) probe(ctx context.Context) error {
+	conn, err := r.dialer.DialContext(ctx, "tcp", r.cfg.Endpoint)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	// ssh.NewClientConn has no timeout of its own.
+	if err = conn.SetDeadline(time.Now().Add(r.cfg.Timeout)); err != nil {
+		return err
+	}
+	// The handshake error does not wrap the host key error, so keep it to classify the failure.
+	var hostKeyErr error
+	clientConfig := *r.clientConfig
+	clientConfig.HostKeyCallback = func(hostname string, remote net.Addr, key ssh.PublicKey) error {
+		hostKeyErr = r.clientConfig.HostKeyCallback(hostname, remote, key)
+		return hostKeyErr
+	}
+	sshConn, chans, reqs, err := ssh.NewClientConn(conn, r.cfg.Endpoint, &clientConfig)
+	if hostKeyErr != nil {
+		return fmt.Errorf("ssh: handshake failed: %w", hostKeyErr)
+	}
+	if err != nil {
+		return err
+	}
+	go ssh.DiscardRequests(reqs)
+	go func() {
+		for ch := range chans {
+			_ = ch.Reject(ssh.Prohibited, "")
+		}
+	}()
+	return sshConn.Close()
+}
+
+// errorCode classifies a probe error.
+func errorCode(err error) string {
+	var netErr net.Error
+	var dnsErr *net.DNSError
+	var keyErr *knownhosts.KeyError
+	var revokedErr *knownhosts.RevokedError
+	switch {
+	case errors.As(err, &dnsErr):
+		return metadata.LabelErrorCode.DNS
+	case errors.As(err, &netErr) && netErr.Timeout():
+		return metadata.LabelErrorCode.Timeout
+	case errors.Is(err, syscall.ECONNREFUSED):
+		return metadata.LabelErrorCode.ConnectionRefused
+	case errors.Is(err, syscall.ECONNRESET):
+		return metadata.LabelErrorCode.ConnectionReset
+	case errors.As(err, &keyErr), errors.As(err, &revokedErr):
+		return metadata.LabelErrorCode.HostKey
+	// The ssh package does not expose a typed error for rejected credentials.
+	case strings.Contains(err.Error(), "unable to authenticate"):
+		return metadata.LabelErrorCode.Authentication
+	default:
+		return metadata.LabelErrorCode.Other
+	}
+}
@@ -0,0 +1,241 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sshcheckreceiver
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"path/filepath"
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.uber.org/zap"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+// startServer starts an SSH server accepting the "otel" user with the "secret" password.
+func startServer(t *testing.T) (string, ssh.PublicKey) {
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	signer, err := ssh.NewSignerFromKey(priv)
+	require.NoError(t, err)
+
+	serverConfig := &ssh.ServerConfig{
+		PasswordCallback: func(conn ssh.ConnMetadata, password []byte) (*ssh.Permissions, error) {
+			if conn.User() == "otel" && string(password) == "secret" {
+				return nil, nil
+			}
+			return nil, errors.New("access denied")
+		},
+	}
+	serverConfig.AddHostKey(signer)
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { ln.Close() })
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				_, chans, reqs, err := ssh.NewServerConn(conn, serverConfig)
+				if err != nil {
+					return
+				}
+				go ssh.DiscardRequests(reqs)
+				for ch := range chans {
+					_ = ch.Reject(ssh.Prohibited, "")
+				}
+			}()
+		}
+	}()
+	return ln.Addr().String(), signer.PublicKey()
+}
+
+func writeKnownHosts(t *testing.T, endpoint string, key ssh.PublicKey) string {
+	path := filepath.Join(t.TempDir(), "known_hosts")
+	line := knownhosts.Line([]string{knownhosts.Normalize(endpoint)}, key)
+	require.NoError(t, ioutil.WriteFile(path, []byte(line+"\n"), 0600))
+	return path
+}
+
+func scrape(t *testing.T, cfg *Config) map[string]pdata.Metric {
+	scraper := newSSHCheckScraper(zap.NewNop(), cfg)
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+
+	rms, err := scraper.scrape(context.Background())
+	require.NoError(t, err)
+
+	require.Equal(t, 1, rms.Len())
+	ilms := rms.At(0).InstrumentationLibraryMetrics()
+	require.Equal(t, 1, ilms.Len())
+
+	metrics := map[string]pdata.Metric{}
+	ms := ilms.At(0).Metrics()
+	for i := 0; i < ms.Len(); i++ {
+		metrics[ms.At(i).Name()] = ms.At(i)
+	}
+	return metrics
+}
+
+func TestScrape(t *testing.T) {
+	endpoint, hostKey := startServer(t)
+	_, otherKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	otherSigner, err := ssh.NewSignerFromKey(otherKey)
+	require.NoError(t, err)
+
+	cases := []struct {
+		name      string
+		modify    func(cfg *Config)
+		errorCode string
+	}{
+		{
+			name:   "success",
+			modify: func(cfg *Config) {},
+		},
+		{
+			name:   "ignore_host_key",
+			modify: func(cfg *Config) { cfg.IgnoreHostKey = true },
+		},
+		{
+			name:      "wrong_password",
+			modify:    func(cfg *Config) { cfg.Password = "wrong" },
+			errorCode: "authentication",
+		},
+		{
+			name: "unknown_host_key",
+			modify: func(cfg *Config) {
+				cfg.KnownHosts = writeKnownHosts(t, endpoint, otherSigner.PublicKey())
+			},
+			errorCode: "host_key",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.Endpoint = endpoint
+			cfg.Username = "otel"
+			cfg.Password = "secret"
+			cfg.KnownHosts = writeKnownHosts(t, endpoint, hostKey)
+			tc.modify(cfg)
+
+			metrics := scrape(t, cfg)
+			require.Len(t, metrics, 2)
+			if tc.errorCode == "" {
+				assertDataPoint(t, metrics["sshcheck.status"], 1, map[string]string{"ssh.endpoint": endpoint})
+				require.Contains(t, metrics, "sshcheck.duration")
+				assert.GreaterOrEqual(t, metrics["sshcheck.duration"].IntGauge().DataPoints().At(0).Value(), int64(0))
+			} else {
+				assertDataPoint(t, metrics["sshcheck.status"], 0, map[string]string{"ssh.endpoint": endpoint})
+				assertDataPoint(t, metrics["sshcheck.error"], 1, map[string]string{
+					"ssh.endpoint": endpoint,
+					"error.code":   tc.errorCode,
+				})
+			}
+		})
+	}
+}
+
+func TestScrapeConnectionRefused(t *testing.T) {
+	// Reserve a port and release it so that nothing listens on it.
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	endpoint := ln.Addr().String()
+	require.NoError(t, ln.Close())
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = endpoint
+	cfg.Username = "otel"
+	cfg.Password = "secret"
+	cfg.IgnoreHostKey = true
+
+	metrics := scrape(t, cfg)
+	require.Len(t, metrics, 2)
+	assertDataPoint(t, metrics["sshcheck.error"], 1, map[string]string{
+		"ssh.endpoint": endpoint,
+		"error.code":   "connection_refused",
+	})
+}
+
+func TestStartInvalidKeyFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "id_ed25519")
+	require.NoError(t, ioutil.WriteFile(path, []byte("not a key"), 0600))
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = "localhost:22"
+	cfg.Username = "otel"
+	cfg.KeyFile = path
+	cfg.IgnoreHostKey = true
+
+	scraper := newSSHCheckScraper(zap.NewNop(), cfg)
+	err := scraper.start(context.Background(), componenttest.NewNopHost())
+	assert.Contains(t, err.Error(), "failed to parse key_file")
+}
+
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+func TestErrorCode(t *testing.T) {
+	cases := []struct {
+		err      error
+		expected string
+	}{
+		{err: &net.OpError{Op: "dial", Err: timeoutError{}}, expected: "timeout"},
+		{err: &net.OpError{Op: "dial", Err: &net.DNSError{Err: "no such host", Name: "invalid"}}, expected: "dns"},
+		{err: &net.OpError{Op: "dial", Err: fmt.Errorf("connect: %w", syscall.ECONNREFUSED)}, expected: "connection_refused"},
+		{err: &net.OpError{Op: "read", Err: fmt.Errorf("read: %w", syscall.ECONNRESET)}, expected: "connection_reset"},
+		{err: fmt.Errorf("ssh: handshake failed: %w", &knownhosts.KeyError{}), expected: "host_key"},
+		{err: errors.New("ssh: handshake failed: ssh: unable to authenticate, attempted methods [none password], no supported methods remain"), expected: "authentication"},
+		{err: errors.New("unexpected"), expected: "other"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.expected, func(t *testing.T) {
+			assert.Equal(t, tc.expected, errorCode(tc.err))
+		})
+	}
+}
+
+func assertDataPoint(t *testing.T, m pdata.Metric, value int64, labels map[string]string) {
+	require.Equal(t, pdata.MetricDataTypeIntGauge, m.DataType())
+	dps := m.IntGauge().DataPoints()
+	require.Equal(t, 1, dps.Len())
+	dp := dps.At(0)
+	assert.Equal(t, value, dp.Value())
+	assert.Equal(t, len(labels), dp.LabelsMap().Len())
+	for k, v := range labels {
+		got, ok := dp.LabelsMap().Get(k)
+		assert.True(t, ok, k)
+		assert.Equal(t, v, got)
+	}
+}
@@ -32,6 +32,7 @@ type Config struct {
 	AggregationInterval     time.Duration                    `mapstructure:"aggregation_interval"`
 	EnableMetricType        bool                             `mapstructure:"enable_metric_type"`
 	TimerHistogramMapping   []protocol.TimerHistogramMapping `mapstructure:"timer_histogram_mapping"`
+	ShutdownDrainTimeout    time.Duration                    `mapstructure:"shutdown_drain_timeout"`
 }
 
 func (c *Config) validate() error {
@@ -44,6 +45,10 @@ func (c *Config) validate() error {
 		errors = append(errors, fmt.Errorf("aggregation_interval must be a positive duration"))
 	}
 
+	if c.ShutdownDrainTimeout < 0 {
+		errors = append(errors, fmt.Errorf("shutdown_drain_timeout must not be negative"))
+	}
+
 	var TimerHistogramMappingMissingObjectName bool
 	for _, eachMap := range c.TimerHistogramMapping {
 
@@ -55,6 +55,7 @@ func TestLoadConfig(t *testing.T) {
 		},
 		AggregationInterval:   70 * time.Second,
 		TimerHistogramMapping: []protocol.TimerHistogramMapping{{StatsdType: "histogram", ObserverType: "gauge"}, {StatsdType: "timing", ObserverType: "gauge"}},
+		ShutdownDrainTimeout:  10 * time.Second,
 	}, r1)
 }
 
@@ -70,6 +71,7 @@ func TestValidate(t *testing.T) {
 		noObjectNameErr                = "must specify object id for all TimerHistogramMappings"
 		statsdTypeNotSupportErr        = "statsd_type is not supported: %s"
 		observerTypeNotSupportErr      = "observer_type is not supported: %s"
+		negativeShutdownDrainTimeout   = "shutdown_drain_timeout must not be negative"
 	)
 
 	tests := []test{
@@ -123,6 +125,14 @@ func TestValidate(t *testing.T) {
 			},
 			expectedErr: fmt.Sprintf(observerTypeNotSupportErr, "gauge1"),
 		},
+		{
+			name: "negativeShutdownDrainTimeout",
+			cfg: &Config{
+				AggregationInterval:  10,
+				ShutdownDrainTimeout: -1,
+			},
+			expectedErr: negativeShutdownDrainTimeout,
+		},
 	}
 
 	for _, test := range tests {
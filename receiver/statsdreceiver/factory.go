@@ -29,11 +29,12 @@ import (
 
 const (
 	// The value of "type" key in configuration.
-	typeStr                    = "statsd"
-	defaultBindEndpoint        = "localhost:8125"
-	defaultTransport           = "udp"
-	defaultAggregationInterval = 60 * time.Second
-	defaultEnableMetricType    = false
+	typeStr                     = "statsd"
+	defaultBindEndpoint         = "localhost:8125"
+	defaultTransport            = "udp"
+	defaultAggregationInterval  = 60 * time.Second
+	defaultEnableMetricType     = false
+	defaultShutdownDrainTimeout = 5 * time.Second
 )
 
 var (
@@ -59,6 +60,7 @@ func createDefaultConfig() config.Receiver {
 		AggregationInterval:   defaultAggregationInterval,
 		EnableMetricType:      defaultEnableMetricType,
 		TimerHistogramMapping: defaultTimerHistogramMapping,
+		ShutdownDrainTimeout:  defaultShutdownDrainTimeout,
 	}
 }
 
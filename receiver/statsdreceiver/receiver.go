@@ -44,6 +44,9 @@ type statsdReceiver struct {
 	parser       protocol.Parser
 	nextConsumer consumer.Metrics
 	cancel       context.CancelFunc
+	// done is closed once the aggregation loop has exited, after flushing
+	// the remaining aggregated metrics when the server got closed.
+	done chan struct{}
 }
 
 // New creates the StatsD receiver with the given parameters.
@@ -60,6 +63,10 @@ func New(
 		config.NetAddr.Endpoint = "localhost:8125"
 	}
 
+	if config.ShutdownDrainTimeout == 0 {
+		config.ShutdownDrainTimeout = defaultShutdownDrainTimeout
+	}
+
 	server, err := buildTransportServer(config)
 	if err != nil {
 		return nil, err
@@ -92,26 +99,33 @@ func (r *statsdReceiver) Start(ctx context.Context, host component.Host) error {
 	defer r.Unlock()
 
 	ctx, r.cancel = context.WithCancel(ctx)
+	r.done = make(chan struct{})
 	var transferChan = make(chan string, 10)
 	ticker := time.NewTicker(r.config.AggregationInterval)
 	r.parser.Initialize(r.config.EnableMetricType, r.config.TimerHistogramMapping)
 	go func() {
+		// Nothing is sent onto transferChan once the server stopped serving.
+		defer close(transferChan)
 		if err := r.server.ListenAndServe(r.parser, r.nextConsumer, r.reporter, transferChan); err != nil {
 			host.ReportFatalError(err)
 		}
 	}()
 	go func() {
+		defer close(r.done)
+		defer ticker.Stop()
 		for {
 			select {
 			case <-ticker.C:
-				metrics := r.parser.GetMetrics()
-				if metrics.ResourceMetrics().At(0).InstrumentationLibraryMetrics().Len() > 0 {
-					r.Flush(ctx, metrics, r.nextConsumer)
+				r.flushAggregated(ctx)
+			case rawMetric, ok := <-transferChan:
+				if !ok {
+					// The server got closed, flush what has been aggregated
+					// so far instead of dropping it.
+					r.flushAggregated(ctx)
+					return
 				}
-			case rawMetric := <-transferChan:
 				r.parser.Aggregate(rawMetric)
 			case <-ctx.Done():
-				ticker.Stop()
 				return
 			}
 		}
@@ -120,16 +134,39 @@ func (r *statsdReceiver) Start(ctx context.Context, host component.Host) error {
 	return nil
 }
 
-// Shutdown stops the StatsD receiver.
-func (r *statsdReceiver) Shutdown(context.Context) error {
+// Shutdown stops the StatsD receiver. The server stops accepting new data
+// right away while the already aggregated metrics are flushed to the next
+// consumer, waiting at most ShutdownDrainTimeout for it to accept them.
+func (r *statsdReceiver) Shutdown(ctx context.Context) error {
 	r.Lock()
 	defer r.Unlock()
 
 	err := r.server.Close()
+
+	timer := time.NewTimer(r.config.ShutdownDrainTimeout)
+	defer timer.Stop()
+	select {
+	case <-r.done:
+	case <-timer.C:
+		r.logger.Warn("Timed out flushing aggregated metrics on shutdown",
+			zap.Duration("shutdown_drain_timeout", r.config.ShutdownDrainTimeout))
+	case <-ctx.Done():
+		r.logger.Warn("Shutdown context done before flushing aggregated metrics", zap.Error(ctx.Err()))
+	}
+
 	r.cancel()
 	return err
 }
 
+func (r *statsdReceiver) flushAggregated(ctx context.Context) {
+	metrics := r.parser.GetMetrics()
+	if metrics.ResourceMetrics().At(0).InstrumentationLibraryMetrics().Len() > 0 {
+		if err := r.Flush(ctx, metrics, r.nextConsumer); err != nil {
+			r.logger.Debug("Failed to flush aggregated metrics", zap.Error(err))
+		}
+	}
+}
+
 func (r *statsdReceiver) Flush(ctx context.Context, metrics pdata.Metrics, nextConsumer consumer.Metrics) error {
 	error := nextConsumer.ConsumeMetrics(ctx, metrics)
 	if error != nil {
@@ -161,3 +161,40 @@ func Test_statsdreceiver_EndToEnd(t *testing.T) {
 		})
 	}
 }
+
+func Test_statsdreceiver_ShutdownFlushesAggregatedMetrics(t *testing.T) {
+	addr := testutil.GetAvailableLocalAddress(t)
+	host, portStr, err := net.SplitHostPort(addr)
+	require.NoError(t, err)
+	port, err := strconv.Atoi(portStr)
+	require.NoError(t, err)
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.NetAddr.Endpoint = addr
+	// Long enough for the ticker to never flush during the test.
+	cfg.AggregationInterval = time.Hour
+	sink := new(consumertest.MetricsSink)
+	rcv, err := New(zap.NewNop(), *cfg, sink)
+	require.NoError(t, err)
+	r := rcv.(*statsdReceiver)
+	r.reporter = transport.NewMockReporter(1)
+
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+
+	statsdClient, err := client.NewStatsD(client.UDP, host, port)
+	require.NoError(t, err)
+	require.NoError(t, statsdClient.SendMetric(client.Metric{
+		Name:  "test.metric",
+		Value: "42",
+		Type:  "c",
+	}))
+
+	// Give the UDP packet a chance to be read before closing the server.
+	time.Sleep(100 * time.Millisecond)
+	require.NoError(t, r.Shutdown(context.Background()))
+
+	mdd := sink.AllMetrics()
+	require.Len(t, mdd, 1)
+	metric := mdd[0].ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics().At(0)
+	assert.Equal(t, "test.metric", metric.Name())
+}
@@ -0,0 +1,117 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package subprocessreceiver
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/config"
+)
+
+// Config defines configuration for the subprocess receiver.
+type Config struct {
+	config.ReceiverSettings `mapstructure:",squash"`
+
+	// Command is the path of the binary to run. It is run directly, not
+	// through a shell.
+	Command string `mapstructure:"command"`
+
+	// Args are the arguments of the command. The {{port}} placeholder is
+	// replaced by Port.
+	Args []string `mapstructure:"args"`
+
+	// Env are the environment variables set for the subprocess, in addition
+	// to the ones of the collector. The {{port}} placeholder in their values
+	// is replaced by Port.
+	Env []EnvConfig `mapstructure:"env"`
+
+	// WorkingDirectory is the working directory of the subprocess. Default is
+	// the working directory of the collector.
+	WorkingDirectory string `mapstructure:"working_directory"`
+
+	// Port is the port the subprocess serves its Prometheus metrics on. A free
+	// port is picked when the receiver starts if zero.
+	Port int `mapstructure:"port"`
+
+	// Metrics configures scraping the Prometheus metrics of the subprocess,
+	// when the receiver is in a metrics pipeline.
+	Metrics MetricsConfig `mapstructure:"metrics"`
+
+	// Restart configures the delay before the subprocess is restarted once
+	// it exited.
+	Restart RestartConfig `mapstructure:"restart"`
+}
+
+// EnvConfig is an environment variable of the subprocess.
+type EnvConfig struct {
+	Name  string `mapstructure:"name"`
+	Value string `mapstructure:"value"`
+}
+
+// MetricsConfig defines how the Prometheus metrics of the subprocess are scraped.
+type MetricsConfig struct {
+	// Path is the HTTP path of the metrics. Default is "/metrics".
+	Path string `mapstructure:"path"`
+
+	// ScrapeInterval is the time between scrapes. Default is 60s.
+	ScrapeInterval time.Duration `mapstructure:"scrape_interval"`
+
+	// ScrapeTimeout is the timeout of a scrape. Default is 10s.
+	ScrapeTimeout time.Duration `mapstructure:"scrape_timeout"`
+}
+
+// RestartConfig defines the exponential backoff of the restarts of the
+// subprocess: the delay doubles each time the subprocess exits before
+// running for HealthyDuration, up to MaxDelay, and is reset otherwise.
+type RestartConfig struct {
+	// InitialDelay is the delay before the first restart. Default is 1s.
+	InitialDelay time.Duration `mapstructure:"initial_delay"`
+
+	// MaxDelay is the maximum delay between restarts. Default is 5m.
+	MaxDelay time.Duration `mapstructure:"max_delay"`
+
+	// HealthyDuration is how long the subprocess must run for the delay to be
+	// reset. Default is 1m.
+	HealthyDuration time.Duration `mapstructure:"healthy_duration"`
+}
+
+var _ config.Receiver = (*Config)(nil)
+
+// Validate checks if the receiver configuration is valid
+func (cfg *Config) Validate() error {
+	if cfg.Command == "" {
+		return errors.New("missing command")
+	}
+	if cfg.Port < 0 || cfg.Port > 65535 {
+		return fmt.Errorf("invalid port %d, must be between 0 and 65535", cfg.Port)
+	}
+	for _, env := range cfg.Env {
+		if env.Name == "" {
+			return errors.New("env variables must have a name")
+		}
+	}
+	if cfg.Metrics.ScrapeInterval <= 0 || cfg.Metrics.ScrapeTimeout <= 0 {
+		return errors.New("metrics scrape_interval and scrape_timeout must be greater than zero")
+	}
+	if cfg.Restart.InitialDelay <= 0 {
+		return errors.New("restart initial_delay must be greater than zero")
+	}
+	if cfg.Restart.MaxDelay < cfg.Restart.InitialDelay {
+		return errors.New("restart max_delay must be greater than or equal to initial_delay")
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package subprocessreceiver
+
+import (
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configtest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	require.NoError(t, err)
+
+	factory := NewFactory()
+	factories.Receivers[typeStr] = factory
+
+	cfg, err := configtest.LoadConfigAndValidate(path.Join(".", "testdata", "config.yaml"), factories)
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	defaultCfg := factory.CreateDefaultConfig().(*Config)
+	defaultCfg.Command = "/usr/local/bin/node_exporter"
+	assert.Equal(t, defaultCfg, cfg.Receivers[config.NewID(typeStr)])
+	assert.Equal(t,
+		&Config{
+			ReceiverSettings: config.NewReceiverSettings(config.NewIDWithName(typeStr, "all_settings")),
+			Command:          "/usr/local/bin/mysqld_exporter",
+			Args:             []string{"--web.listen-address=localhost:{{port}}", "--log.level=warn"},
+			Env:              []EnvConfig{{Name: "DATA_SOURCE_NAME", Value: "user:password@(localhost:3306)/"}},
+			WorkingDirectory: "/var/lib/mysqld_exporter",
+			Port:             9104,
+			Metrics: MetricsConfig{
+				Path:           "/probe",
+				ScrapeInterval: 30 * time.Second,
+				ScrapeTimeout:  5 * time.Second,
+			},
+			Restart: RestartConfig{
+				InitialDelay:    5 * time.Second,
+				MaxDelay:        10 * time.Minute,
+				HealthyDuration: 5 * time.Minute,
+			},
+		},
+		cfg.Receivers[config.NewIDWithName(typeStr, "all_settings")],
+	)
+}
+
+func TestValidateConfig(t *testing.T) {
+	cases := []struct {
+		name     string
+		modify   func(cfg *Config)
+		expected string
+	}{
+		{name: "valid", modify: func(cfg *Config) {}},
+		{name: "missing command", modify: func(cfg *Config) { cfg.Command = "" }, expected: "missing command"},
+		{name: "invalid port", modify: func(cfg *Config) { cfg.Port = 70000 }, expected: "invalid port 70000, must be between 0 and 65535"},
+		{
+			name:     "unnamed env",
+			modify:   func(cfg *Config) { cfg.Env = []EnvConfig{{Value: "1"}} },
+			expected: "env variables must have a name",
+		},
+		{
+			name:     "zero scrape interval",
+			modify:   func(cfg *Config) { cfg.Metrics.ScrapeInterval = 0 },
+			expected: "metrics scrape_interval and scrape_timeout must be greater than zero",
+		},
+		{
+			name:     "zero initial delay",
+			modify:   func(cfg *Config) { cfg.Restart.InitialDelay = 0 },
+			expected: "restart initial_delay must be greater than zero",
+		},
+		{
+			name:     "max delay below initial delay",
+			modify:   func(cfg *Config) { cfg.Restart.MaxDelay = time.Millisecond },
+			expected: "restart max_delay must be greater than or equal to initial_delay",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.Command = "exporter"
+			tc.modify(cfg)
+			err := cfg.Validate()
+			if tc.expected == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,113 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package subprocessreceiver
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/receiver/receiverhelper"
+)
+
+const (
+	// The value of "type" key in configuration.
+	typeStr = "subprocess"
+
+	defaultMetricsPath     = "/metrics"
+	defaultScrapeInterval  = 60 * time.Second
+	defaultScrapeTimeout   = 10 * time.Second
+	defaultInitialDelay    = time.Second
+	defaultMaxDelay        = 5 * time.Minute
+	defaultHealthyDuration = time.Minute
+)
+
+// NewFactory creates a factory for the subprocess receiver.
+func NewFactory() component.ReceiverFactory {
+	return receiverhelper.NewFactory(
+		typeStr,
+		createDefaultConfig,
+		receiverhelper.WithMetrics(createMetricsReceiver),
+		receiverhelper.WithLogs(createLogsReceiver))
+}
+
+func createDefaultConfig() config.Receiver {
+	return &Config{
+		ReceiverSettings: config.NewReceiverSettings(config.NewID(typeStr)),
+		Metrics: MetricsConfig{
+			Path:           defaultMetricsPath,
+			ScrapeInterval: defaultScrapeInterval,
+			ScrapeTimeout:  defaultScrapeTimeout,
+		},
+		Restart: RestartConfig{
+			InitialDelay:    defaultInitialDelay,
+			MaxDelay:        defaultMaxDelay,
+			HealthyDuration: defaultHealthyDuration,
+		},
+	}
+}
+
+// createMetricsReceiver creates a metrics receiver based on provided config.
+func createMetricsReceiver(
+	_ context.Context,
+	params component.ReceiverCreateSettings,
+	cfg config.Receiver,
+	consumer consumer.Metrics,
+) (component.MetricsReceiver, error) {
+	r, err := getOrCreateReceiver(params, cfg.(*Config))
+	if err != nil {
+		return nil, err
+	}
+	r.RegisterMetricsConsumer(consumer)
+	return r, nil
+}
+
+// createLogsReceiver creates a logs receiver based on provided config.
+func createLogsReceiver(
+	_ context.Context,
+	params component.ReceiverCreateSettings,
+	cfg config.Receiver,
+	consumer consumer.Logs,
+) (component.LogsReceiver, error) {
+	r, err := getOrCreateReceiver(params, cfg.(*Config))
+	if err != nil {
+		return nil, err
+	}
+	r.RegisterLogsConsumer(consumer)
+	return r, nil
+}
+
+// getOrCreateReceiver returns the receiver of rCfg. The metrics and logs
+// pipelines of a config share a receiver, which runs a single subprocess.
+func getOrCreateReceiver(params component.ReceiverCreateSettings, rCfg *Config) (*subprocessReceiver, error) {
+	if err := rCfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	receiverLock.Lock()
+	defer receiverLock.Unlock()
+	r := receivers[rCfg]
+	if r == nil {
+		r = newReceiver(params, *rCfg)
+		receivers[rCfg] = r
+	}
+	return r, nil
+}
+
+var receiverLock sync.Mutex
+var receivers = map[*Config]*subprocessReceiver{}
@@ -0,0 +1,152 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package subprocessreceiver
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// portPlaceholder is replaced by the port of the subprocess in its
+	// arguments and environment.
+	portPlaceholder = "{{port}}"
+
+	streamStdout = "stdout"
+	streamStderr = "stderr"
+)
+
+// process is the subprocess run by the receiver, its placeholders replaced.
+type process struct {
+	command string
+	args    []string
+	env     []string
+	dir     string
+}
+
+func newProcess(cfg Config, port int) *process {
+	replacer := strings.NewReplacer(portPlaceholder, strconv.Itoa(port))
+
+	args := make([]string, len(cfg.Args))
+	for i, arg := range cfg.Args {
+		args[i] = replacer.Replace(arg)
+	}
+	env := os.Environ()
+	for _, e := range cfg.Env {
+		env = append(env, e.Name+"="+replacer.Replace(e.Value))
+	}
+
+	return &process{
+		command: cfg.Command,
+		args:    args,
+		env:     env,
+		dir:     cfg.WorkingDirectory,
+	}
+}
+
+// outputFunc is called with each line the subprocess writes to its stdout or
+// stderr stream.
+type outputFunc func(pid int, stream string, line string)
+
+// run runs the subprocess until it exits or ctx is done, in which case the
+// subprocess is killed. It returns how long the subprocess ran.
+func (p *process) run(ctx context.Context, output outputFunc) (time.Duration, error) {
+	cmd := exec.Command(p.command, p.args...) // #nosec
+	cmd.Env = p.env
+	cmd.Dir = p.dir
+
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return 0, fmt.Errorf("could not get the stdout pipe: %w", err)
+	}
+	stderr, err := cmd.StderrPipe()
+	if err != nil {
+		return 0, fmt.Errorf("could not get the stderr pipe: %w", err)
+	}
+
+	start := time.Now()
+	if err = cmd.Start(); err != nil {
+		return 0, fmt.Errorf("could not start: %w", err)
+	}
+	pid := cmd.Process.Pid
+
+	// Wait must only be called once the pipes were read to the end.
+	var readers sync.WaitGroup
+	readers.Add(2)
+	go readLines(stdout, func(line string) { output(pid, streamStdout, line) }, &readers)
+	go readLines(stderr, func(line string) { output(pid, streamStderr, line) }, &readers)
+
+	exited := make(chan error, 1)
+	go func() {
+		readers.Wait()
+		exited <- cmd.Wait()
+	}()
+
+	select {
+	case err = <-exited:
+		return time.Since(start), err
+	case <-ctx.Done():
+		if err = cmd.Process.Kill(); err != nil {
+			return time.Since(start), fmt.Errorf("could not kill: %w", err)
+		}
+		<-exited
+		return time.Since(start), nil
+	}
+}
+
+// readLines calls consume with each line of r, without its line ending,
+// until r is closed.
+func readLines(r io.Reader, consume func(line string), wg *sync.WaitGroup) {
+	defer wg.Done()
+	reader := bufio.NewReader(r)
+	for {
+		line, err := reader.ReadString('\n')
+		if line = strings.TrimRight(line, "\r\n"); line != "" {
+			consume(line)
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+// restartBackoff computes the delays before restarting the subprocess.
+type restartBackoff struct {
+	cfg   RestartConfig
+	delay time.Duration
+}
+
+// next returns the delay before restarting the subprocess once it exited
+// after running for ran.
+func (b *restartBackoff) next(ran time.Duration) time.Duration {
+	switch {
+	case b.delay == 0 || ran >= b.cfg.HealthyDuration:
+		b.delay = b.cfg.InitialDelay
+	case b.delay < b.cfg.MaxDelay:
+		b.delay *= 2
+		if b.delay > b.cfg.MaxDelay {
+			b.delay = b.cfg.MaxDelay
+		}
+	}
+	return b.delay
+}
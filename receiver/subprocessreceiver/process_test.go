@@ -0,0 +1,115 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package subprocessreceiver
+
+import (
+	"context"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type outputLine struct {
+	stream string
+	line   string
+}
+
+type outputRecorder struct {
+	sync.Mutex
+	lines []outputLine
+}
+
+func (o *outputRecorder) record(_ int, stream string, line string) {
+	o.Lock()
+	defer o.Unlock()
+	o.lines = append(o.lines, outputLine{stream: stream, line: line})
+}
+
+func skipOnWindows(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the tests run the subprocesses with sh")
+	}
+}
+
+func TestNewProcess(t *testing.T) {
+	cfg := Config{
+		Command: "exporter",
+		Args:    []string{"--listen=:{{port}}", "--verbose"},
+		Env:     []EnvConfig{{Name: "EXPORTER_PORT", Value: "{{port}}"}},
+	}
+	proc := newProcess(cfg, 9100)
+	assert.Equal(t, []string{"--listen=:9100", "--verbose"}, proc.args)
+	assert.Equal(t, "EXPORTER_PORT=9100", proc.env[len(proc.env)-1])
+}
+
+func TestProcessRun(t *testing.T) {
+	skipOnWindows(t)
+
+	proc := newProcess(Config{
+		Command: "sh",
+		Args:    []string{"-c", `echo "listening on $PORT"; echo failure >&2; printf 'no newline'; exit 3`},
+		Env:     []EnvConfig{{Name: "PORT", Value: "{{port}}"}},
+	}, 9100)
+
+	var output outputRecorder
+	_, err := proc.run(context.Background(), output.record)
+	assert.EqualError(t, err, "exit status 3")
+	assert.ElementsMatch(t, []outputLine{
+		{stream: streamStdout, line: "listening on 9100"},
+		{stream: streamStderr, line: "failure"},
+		{stream: streamStdout, line: "no newline"},
+	}, output.lines)
+}
+
+func TestProcessRunKilled(t *testing.T) {
+	skipOnWindows(t)
+
+	proc := newProcess(Config{Command: "sh", Args: []string{"-c", "exec sleep 60"}}, 0)
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	ran, err := proc.run(ctx, func(int, string, string) {})
+	assert.NoError(t, err)
+	assert.Less(t, int64(ran), int64(10*time.Second))
+}
+
+func TestProcessRunNotFound(t *testing.T) {
+	proc := newProcess(Config{Command: "/nonexistent/exporter"}, 0)
+	_, err := proc.run(context.Background(), func(int, string, string) {})
+	require.Error(t, err)
+}
+
+func TestRestartBackoff(t *testing.T) {
+	b := &restartBackoff{cfg: RestartConfig{
+		InitialDelay:    time.Second,
+		MaxDelay:        5 * time.Second,
+		HealthyDuration: time.Minute,
+	}}
+
+	// The delay doubles while the subprocess keeps crashing, up to the max.
+	var delays []time.Duration
+	for i := 0; i < 5; i++ {
+		delays = append(delays, b.next(time.Second))
+	}
+	assert.Equal(t, []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second}, delays)
+
+	// It is reset once the subprocess ran for the healthy duration.
+	assert.Equal(t, time.Second, b.next(time.Hour))
+	assert.Equal(t, 2*time.Second, b.next(0))
+}
@@ -0,0 +1,229 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package subprocessreceiver
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/common/model"
+	promconfig "github.com/prometheus/prometheus/config"
+	"github.com/prometheus/prometheus/discovery"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/obsreport"
+	"go.opentelemetry.io/collector/receiver/prometheusreceiver"
+	"go.opentelemetry.io/collector/translator/conventions"
+	"go.uber.org/zap"
+)
+
+const (
+	transport = "subprocess"
+	format    = "text"
+
+	// attributeLogIOStream is the stream a captured log line was written to,
+	// "stdout" or "stderr".
+	attributeLogIOStream = "log.iostream"
+)
+
+// subprocessReceiver runs a subprocess, scrapes its Prometheus metrics and
+// captures its output as logs.
+type subprocessReceiver struct {
+	params component.ReceiverCreateSettings
+	config Config
+
+	metricsConsumer consumer.Metrics
+	logsConsumer    consumer.Logs
+	obsrecv         *obsreport.Receiver
+
+	startOnce          sync.Once
+	stopOnce           sync.Once
+	prometheusReceiver component.MetricsReceiver
+	cancel             context.CancelFunc
+	done               chan struct{}
+}
+
+var _ component.MetricsReceiver = (*subprocessReceiver)(nil)
+var _ component.LogsReceiver = (*subprocessReceiver)(nil)
+
+func newReceiver(params component.ReceiverCreateSettings, config Config) *subprocessReceiver {
+	return &subprocessReceiver{
+		params:  params,
+		config:  config,
+		obsrecv: obsreport.NewReceiver(obsreport.ReceiverSettings{ReceiverID: config.ID(), Transport: transport}),
+	}
+}
+
+// RegisterMetricsConsumer sets the consumer of the scraped metrics.
+func (r *subprocessReceiver) RegisterMetricsConsumer(mc consumer.Metrics) {
+	r.metricsConsumer = mc
+}
+
+// RegisterLogsConsumer sets the consumer of the output of the subprocess.
+func (r *subprocessReceiver) RegisterLogsConsumer(lc consumer.Logs) {
+	r.logsConsumer = lc
+}
+
+// Start starts the subprocess and, in a metrics pipeline, scraping its metrics.
+func (r *subprocessReceiver) Start(ctx context.Context, host component.Host) error {
+	var err error
+	r.startOnce.Do(func() {
+		port := r.config.Port
+		if port == 0 {
+			if port, err = freePort(); err != nil {
+				err = fmt.Errorf("could not pick a port: %w", err)
+				return
+			}
+		}
+
+		if r.metricsConsumer != nil {
+			factory := prometheusreceiver.NewFactory()
+			r.prometheusReceiver, err = factory.CreateMetricsReceiver(ctx, r.params, prometheusConfig(r.config, port), r.metricsConsumer)
+			if err != nil {
+				return
+			}
+			if err = r.prometheusReceiver.Start(ctx, host); err != nil {
+				return
+			}
+		}
+
+		runCtx, cancel := context.WithCancel(context.Background())
+		r.cancel = cancel
+		r.done = make(chan struct{})
+		go r.runProcess(runCtx, newProcess(r.config, port))
+	})
+	return err
+}
+
+// runProcess runs the subprocess, restarting it with backoff when it exits,
+// until ctx is done.
+func (r *subprocessReceiver) runProcess(ctx context.Context, proc *process) {
+	defer close(r.done)
+	backoff := &restartBackoff{cfg: r.config.Restart}
+	for {
+		ran, err := proc.run(ctx, r.consumeOutput)
+		if ctx.Err() != nil {
+			return
+		}
+
+		delay := backoff.next(ran)
+		r.params.Logger.Warn("Subprocess exited, restarting it",
+			zap.String("command", proc.command),
+			zap.Duration("ran_for", ran),
+			zap.Duration("restart_delay", delay),
+			zap.Error(err))
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+	}
+}
+
+// consumeOutput sends a line of output of the subprocess as a log record
+// in a logs pipeline, and logs it at debug level otherwise.
+func (r *subprocessReceiver) consumeOutput(pid int, stream string, line string) {
+	if r.logsConsumer == nil {
+		r.params.Logger.Debug("Subprocess output", zap.Int("pid", pid), zap.String("stream", stream), zap.String("line", line))
+		return
+	}
+
+	logs := pdata.NewLogs()
+	rl := logs.ResourceLogs().AppendEmpty()
+	attrs := rl.Resource().Attributes()
+	attrs.InsertString(conventions.AttributeProcessExecutableName, filepath.Base(r.config.Command))
+	attrs.InsertString(conventions.AttributeProcessExecutablePath, r.config.Command)
+	attrs.InsertInt(conventions.AttributeProcessID, int64(pid))
+	lr := rl.InstrumentationLibraryLogs().AppendEmpty().Logs().AppendEmpty()
+	lr.SetTimestamp(pdata.TimestampFromTime(time.Now()))
+	lr.Body().SetStringVal(line)
+	lr.Attributes().InsertString(attributeLogIOStream, stream)
+
+	ctx := obsreport.ReceiverContext(context.Background(), r.config.ID(), transport)
+	ctx = r.obsrecv.StartLogsOp(ctx)
+	err := r.logsConsumer.ConsumeLogs(ctx, logs)
+	r.obsrecv.EndLogsOp(ctx, format, 1, err)
+}
+
+// Shutdown kills the subprocess and stops scraping its metrics.
+func (r *subprocessReceiver) Shutdown(ctx context.Context) error {
+	var err error
+	r.stopOnce.Do(func() {
+		if r.cancel != nil {
+			r.cancel()
+			<-r.done
+		}
+		if r.prometheusReceiver != nil {
+			err = r.prometheusReceiver.Shutdown(ctx)
+		}
+	})
+	return err
+}
+
+// prometheusConfig returns the config of the Prometheus receiver scraping the
+// metrics the subprocess serves on port.
+func prometheusConfig(cfg Config, port int) *prometheusreceiver.Config {
+	jobName := cfg.ID().Name()
+	if jobName == "" {
+		jobName = string(cfg.ID().Type())
+	}
+	metricsPath := cfg.Metrics.Path
+	if !strings.HasPrefix(metricsPath, "/") {
+		metricsPath = "/" + metricsPath
+	}
+
+	scrapeConfig := &promconfig.ScrapeConfig{
+		JobName:         jobName,
+		ScrapeInterval:  model.Duration(cfg.Metrics.ScrapeInterval),
+		ScrapeTimeout:   model.Duration(cfg.Metrics.ScrapeTimeout),
+		Scheme:          "http",
+		MetricsPath:     metricsPath,
+		HonorTimestamps: true,
+		ServiceDiscoveryConfigs: discovery.Configs{
+			&discovery.StaticConfig{
+				{
+					Targets: []model.LabelSet{
+						{model.AddressLabel: model.LabelValue(net.JoinHostPort("localhost", fmt.Sprint(port)))},
+					},
+				},
+			},
+		},
+	}
+
+	return &prometheusreceiver.Config{
+		ReceiverSettings: config.NewReceiverSettings(config.NewIDWithName(typeStr, cfg.ID().Name())),
+		PrometheusConfig: &promconfig.Config{
+			ScrapeConfigs: []*promconfig.ScrapeConfig{scrapeConfig},
+		},
+	}
+}
+
+// freePort returns a port free on localhost.
+func freePort() (int, error) {
+	listener, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		return 0, err
+	}
+	defer listener.Close()
+	return listener.Addr().(*net.TCPAddr).Port, nil
+}
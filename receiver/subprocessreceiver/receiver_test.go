@@ -0,0 +1,106 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package subprocessreceiver
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/prometheus/discovery"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/translator/conventions"
+	"go.uber.org/zap"
+)
+
+func TestCaptureLogs(t *testing.T) {
+	skipOnWindows(t)
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Command = "sh"
+	cfg.Args = []string{"-c", "echo starting; echo warning >&2; exec sleep 60"}
+
+	sink := new(consumertest.LogsSink)
+	r := newReceiver(component.ReceiverCreateSettings{Logger: zap.NewNop()}, *cfg)
+	r.RegisterLogsConsumer(sink)
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+
+	require.Eventually(t, func() bool {
+		return sink.LogRecordsCount() == 2
+	}, 10*time.Second, 10*time.Millisecond)
+	require.NoError(t, r.Shutdown(context.Background()))
+
+	streams := map[string]string{}
+	for _, logs := range sink.AllLogs() {
+		rl := logs.ResourceLogs().At(0)
+		name, _ := rl.Resource().Attributes().Get(conventions.AttributeProcessExecutableName)
+		assert.Equal(t, "sh", name.StringVal())
+		_, ok := rl.Resource().Attributes().Get(conventions.AttributeProcessID)
+		assert.True(t, ok)
+
+		lr := rl.InstrumentationLibraryLogs().At(0).Logs().At(0)
+		stream, _ := lr.Attributes().Get(attributeLogIOStream)
+		streams[lr.Body().StringVal()] = stream.StringVal()
+	}
+	assert.Equal(t, map[string]string{"starting": streamStdout, "warning": streamStderr}, streams)
+}
+
+func TestRestartExitedProcess(t *testing.T) {
+	skipOnWindows(t)
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Command = "sh"
+	cfg.Args = []string{"-c", "echo started"}
+	cfg.Restart.InitialDelay = 10 * time.Millisecond
+
+	sink := new(consumertest.LogsSink)
+	r := newReceiver(component.ReceiverCreateSettings{Logger: zap.NewNop()}, *cfg)
+	r.RegisterLogsConsumer(sink)
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() { require.NoError(t, r.Shutdown(context.Background())) }()
+
+	require.Eventually(t, func() bool {
+		return sink.LogRecordsCount() >= 3
+	}, 10*time.Second, 10*time.Millisecond)
+}
+
+func TestPrometheusConfig(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.ReceiverSettings = config.NewReceiverSettings(config.NewIDWithName(typeStr, "mysql"))
+	cfg.Metrics.Path = "probe"
+
+	promCfg := prometheusConfig(*cfg, 9104)
+	require.Len(t, promCfg.PrometheusConfig.ScrapeConfigs, 1)
+	scrapeConfig := promCfg.PrometheusConfig.ScrapeConfigs[0]
+	assert.Equal(t, "mysql", scrapeConfig.JobName)
+	assert.Equal(t, "/probe", scrapeConfig.MetricsPath)
+	assert.EqualValues(t, defaultScrapeInterval, scrapeConfig.ScrapeInterval)
+	assert.EqualValues(t, defaultScrapeTimeout, scrapeConfig.ScrapeTimeout)
+	staticConfig := scrapeConfig.ServiceDiscoveryConfigs[0].(*discovery.StaticConfig)
+	assert.EqualValues(t, "localhost:9104", (*staticConfig)[0].Targets[0]["__address__"])
+
+	cfg.ReceiverSettings = config.NewReceiverSettings(config.NewID(typeStr))
+	assert.Equal(t, "subprocess", prometheusConfig(*cfg, 9104).PrometheusConfig.ScrapeConfigs[0].JobName)
+}
+
+func TestShutdownWithoutStart(t *testing.T) {
+	r := newReceiver(component.ReceiverCreateSettings{Logger: zap.NewNop()}, *createDefaultConfig().(*Config))
+	assert.NoError(t, r.Shutdown(context.Background()))
+}
@@ -0,0 +1,97 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tcpcheckreceiver
+
+import (
+	"context"
+	"errors"
+	"net"
+	"syscall"
+	"time"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/consumer/simple"
+	"go.opentelemetry.io/collector/receiver/scraperhelper"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/tcpcheckreceiver/internal/metadata"
+)
+
+type tcpCheckScraper struct {
+	dialer *net.Dialer
+
+	logger *zap.Logger
+	config *Config
+}
+
+func newTCPCheckScraper(
+	logger *zap.Logger,
+	config *Config,
+) scraperhelper.ResourceMetricsScraper {
+	ts := &tcpCheckScraper{
+		dialer: &net.Dialer{Timeout: config.Timeout},
+		logger: logger,
+		config: config,
+	}
+	return scraperhelper.NewResourceMetricsScraper(config.ID(), ts.scrape)
+}
+
+// scrape probes the endpoint. A failed probe is reported through metrics rather than as a
+// scrape error, as an unreachable endpoint is what this receiver monitors.
+func (r *tcpCheckScraper) scrape(ctx context.Context) (pdata.ResourceMetricsSlice, error) {
+	metrics := simple.Metrics{
+		Metrics:                    pdata.NewMetrics(),
+		Timestamp:                  time.Now(),
+		MetricFactoriesByName:      metadata.M.FactoriesByName(),
+		InstrumentationLibraryName: "otelcol/tcpcheck",
+		Labels: map[string]string{
+			metadata.L.TCPEndpoint: r.config.Endpoint,
+		},
+	}
+
+	start := time.Now()
+	conn, err := r.dialer.DialContext(ctx, "tcp", r.config.Endpoint)
+	if err != nil {
+		r.logger.Debug("TCP check failed", zap.String("endpoint", r.config.Endpoint), zap.Error(err))
+		metrics.AddGaugeDataPoint(metadata.M.TcpcheckStatus.Name(), 0)
+		metrics.WithLabels(map[string]string{metadata.L.ErrorCode: errorCode(err)}).
+			AddGaugeDataPoint(metadata.M.TcpcheckError.Name(), 1)
+		return metrics.Metrics.ResourceMetrics(), nil
+	}
+	duration := time.Since(start)
+	conn.Close()
+
+	metrics.AddGaugeDataPoint(metadata.M.TcpcheckStatus.Name(), 1)
+	metrics.AddGaugeDataPoint(metadata.M.TcpcheckDuration.Name(), duration.Milliseconds())
+	return metrics.Metrics.ResourceMetrics(), nil
+}
+
+// errorCode classifies a connection error.
+func errorCode(err error) string {
+	var netErr net.Error
+	var dnsErr *net.DNSError
+	switch {
+	case errors.As(err, &dnsErr):
+		return metadata.LabelErrorCode.DNS
+	case errors.As(err, &netErr) && netErr.Timeout():
+		return metadata.LabelErrorCode.Timeout
+	case errors.Is(err, syscall.ECONNREFUSED):
+		return metadata.LabelErrorCode.ConnectionRefused
+	case errors.Is(err, syscall.ECONNRESET):
+		return metadata.LabelErrorCode.ConnectionReset
+	default:
+		return metadata.LabelErrorCode.Other
+	}
+}
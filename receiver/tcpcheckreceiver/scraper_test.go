@@ -0,0 +1,132 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tcpcheckreceiver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.uber.org/zap"
+)
+
+func TestScrape(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer ln.Close()
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			conn.Close()
+		}
+	}()
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = ln.Addr().String()
+	scraper := newTCPCheckScraper(zap.NewNop(), cfg)
+
+	rms, err := scraper.Scrape(context.Background(), cfg.ID())
+	require.NoError(t, err)
+
+	metrics := collectMetrics(t, rms)
+	require.Len(t, metrics, 2)
+	assertDataPoint(t, metrics["tcpcheck.status"], 1, map[string]string{"tcp.endpoint": cfg.Endpoint})
+	require.Contains(t, metrics, "tcpcheck.duration")
+	assert.GreaterOrEqual(t, metrics["tcpcheck.duration"].IntGauge().DataPoints().At(0).Value(), int64(0))
+}
+
+func TestScrapeConnectionRefused(t *testing.T) {
+	// Reserve a port and release it so that nothing listens on it.
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	endpoint := ln.Addr().String()
+	require.NoError(t, ln.Close())
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = endpoint
+	scraper := newTCPCheckScraper(zap.NewNop(), cfg)
+
+	rms, err := scraper.Scrape(context.Background(), cfg.ID())
+	require.NoError(t, err)
+
+	metrics := collectMetrics(t, rms)
+	require.Len(t, metrics, 2)
+	assertDataPoint(t, metrics["tcpcheck.status"], 0, map[string]string{"tcp.endpoint": endpoint})
+	assertDataPoint(t, metrics["tcpcheck.error"], 1, map[string]string{
+		"tcp.endpoint": endpoint,
+		"error.code":   "connection_refused",
+	})
+}
+
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+func TestErrorCode(t *testing.T) {
+	cases := []struct {
+		err      error
+		expected string
+	}{
+		{err: &net.OpError{Op: "dial", Err: timeoutError{}}, expected: "timeout"},
+		{err: &net.OpError{Op: "dial", Err: &net.DNSError{Err: "no such host", Name: "invalid"}}, expected: "dns"},
+		{err: &net.OpError{Op: "dial", Err: fmt.Errorf("connect: %w", syscall.ECONNREFUSED)}, expected: "connection_refused"},
+		{err: &net.OpError{Op: "read", Err: fmt.Errorf("read: %w", syscall.ECONNRESET)}, expected: "connection_reset"},
+		{err: errors.New("unexpected"), expected: "other"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.expected, func(t *testing.T) {
+			assert.Equal(t, tc.expected, errorCode(tc.err))
+		})
+	}
+}
+
+func collectMetrics(t *testing.T, rms pdata.ResourceMetricsSlice) map[string]pdata.Metric {
+	require.Equal(t, 1, rms.Len())
+	ilms := rms.At(0).InstrumentationLibraryMetrics()
+	require.Equal(t, 1, ilms.Len())
+
+	metrics := map[string]pdata.Metric{}
+	ms := ilms.At(0).Metrics()
+	for i := 0; i < ms.Len(); i++ {
+		metrics[ms.At(i).Name()] = ms.At(i)
+	}
+	return metrics
+}
+
+func assertDataPoint(t *testing.T, m pdata.Metric, value int64, labels map[string]string) {
+	require.Equal(t, pdata.MetricDataTypeIntGauge, m.DataType())
+	dps := m.IntGauge().DataPoints()
+	require.Equal(t, 1, dps.Len())
+	dp := dps.At(0)
+	assert.Equal(t, value, dp.Value())
+	assert.Equal(t, len(labels), dp.LabelsMap().Len())
+	for k, v := range labels {
+		got, ok := dp.LabelsMap().Get(k)
+		assert.True(t, ok, k)
+		assert.Equal(t, v, got)
+	}
+}
@@ -16,18 +16,21 @@ package tcplogreceiver
 
 import (
 	"github.com/open-telemetry/opentelemetry-log-collection/operator"
-	"github.com/open-telemetry/opentelemetry-log-collection/operator/builtin/input/tcp"
+	"go.opencensus.io/stats/view"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
 	"gopkg.in/yaml.v2"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/stanza"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/stanza/operator/input/observ"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/stanza/operator/input/tcp"
 )
 
 const typeStr = "tcplog"
 
 // NewFactory creates a factory for tcp receiver
 func NewFactory() component.ReceiverFactory {
+	_ = view.Register(observ.MetricViews()...)
 	return stanza.NewFactory(ReceiverType{})
 }
 
@@ -17,6 +17,7 @@ package zookeeperreceiver
 import (
 	"time"
 
+	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/config/confignet"
 	"go.opentelemetry.io/collector/receiver/scraperhelper"
 )
@@ -27,4 +28,9 @@ type Config struct {
 
 	// Timeout within which requests should be completed.
 	Timeout time.Duration `mapstructure:"timeout"`
+
+	// AdminServer configures the client of the ZooKeeper AdminServer. When set, metrics
+	// are collected from its "monitor" command instead of the "mntr" four letter word
+	// command, for servers where four letter word commands are disabled.
+	AdminServer *confighttp.HTTPClientSettings `mapstructure:"admin_server"`
 }
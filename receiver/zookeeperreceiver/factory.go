@@ -75,6 +75,7 @@ func createMetricsReceiver(
 			scraperhelper.NewResourceMetricsScraper(
 				rConfig.ID(),
 				zms.scrape,
+				scraperhelper.WithStart(zms.start),
 				scraperhelper.WithShutdown(zms.shutdown),
 			),
 		),
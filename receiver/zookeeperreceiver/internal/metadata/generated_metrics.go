@@ -58,7 +58,9 @@ type metricStruct struct {
 	ZookeeperApproximateDateSize   MetricIntf
 	ZookeeperConnectionsAlive      MetricIntf
 	ZookeeperEphemeralNodes        MetricIntf
+	ZookeeperFollowerSyncTime      MetricIntf
 	ZookeeperFollowers             MetricIntf
+	ZookeeperFsyncTime             MetricIntf
 	ZookeeperFsyncThresholdExceeds MetricIntf
 	ZookeeperLatencyAvg            MetricIntf
 	ZookeeperLatencyMax            MetricIntf
@@ -69,6 +71,8 @@ type metricStruct struct {
 	ZookeeperPacketsReceived       MetricIntf
 	ZookeeperPacketsSent           MetricIntf
 	ZookeeperPendingSyncs          MetricIntf
+	ZookeeperQuorumHealthy         MetricIntf
+	ZookeeperRole                  MetricIntf
 	ZookeeperSyncedFollowers       MetricIntf
 	ZookeeperWatches               MetricIntf
 	ZookeeperZnodes                MetricIntf
@@ -80,7 +84,9 @@ func (m *metricStruct) Names() []string {
 		"zookeeper.approximate_date_size",
 		"zookeeper.connections_alive",
 		"zookeeper.ephemeral_nodes",
+		"zookeeper.follower_sync.time",
 		"zookeeper.followers",
+		"zookeeper.fsync.time",
 		"zookeeper.fsync_threshold_exceeds",
 		"zookeeper.latency.avg",
 		"zookeeper.latency.max",
@@ -91,6 +97,8 @@ func (m *metricStruct) Names() []string {
 		"zookeeper.packets.received",
 		"zookeeper.packets.sent",
 		"zookeeper.pending_syncs",
+		"zookeeper.quorum.healthy",
+		"zookeeper.role",
 		"zookeeper.synced_followers",
 		"zookeeper.watches",
 		"zookeeper.znodes",
@@ -101,7 +109,9 @@ var metricsByName = map[string]MetricIntf{
 	"zookeeper.approximate_date_size":   Metrics.ZookeeperApproximateDateSize,
 	"zookeeper.connections_alive":       Metrics.ZookeeperConnectionsAlive,
 	"zookeeper.ephemeral_nodes":         Metrics.ZookeeperEphemeralNodes,
+	"zookeeper.follower_sync.time":      Metrics.ZookeeperFollowerSyncTime,
 	"zookeeper.followers":               Metrics.ZookeeperFollowers,
+	"zookeeper.fsync.time":              Metrics.ZookeeperFsyncTime,
 	"zookeeper.fsync_threshold_exceeds": Metrics.ZookeeperFsyncThresholdExceeds,
 	"zookeeper.latency.avg":             Metrics.ZookeeperLatencyAvg,
 	"zookeeper.latency.max":             Metrics.ZookeeperLatencyMax,
@@ -112,6 +122,8 @@ var metricsByName = map[string]MetricIntf{
 	"zookeeper.packets.received":        Metrics.ZookeeperPacketsReceived,
 	"zookeeper.packets.sent":            Metrics.ZookeeperPacketsSent,
 	"zookeeper.pending_syncs":           Metrics.ZookeeperPendingSyncs,
+	"zookeeper.quorum.healthy":          Metrics.ZookeeperQuorumHealthy,
+	"zookeeper.role":                    Metrics.ZookeeperRole,
 	"zookeeper.synced_followers":        Metrics.ZookeeperSyncedFollowers,
 	"zookeeper.watches":                 Metrics.ZookeeperWatches,
 	"zookeeper.znodes":                  Metrics.ZookeeperZnodes,
@@ -126,7 +138,9 @@ func (m *metricStruct) FactoriesByName() map[string]func(pdata.Metric) {
 		Metrics.ZookeeperApproximateDateSize.Name():   Metrics.ZookeeperApproximateDateSize.Init,
 		Metrics.ZookeeperConnectionsAlive.Name():      Metrics.ZookeeperConnectionsAlive.Init,
 		Metrics.ZookeeperEphemeralNodes.Name():        Metrics.ZookeeperEphemeralNodes.Init,
+		Metrics.ZookeeperFollowerSyncTime.Name():      Metrics.ZookeeperFollowerSyncTime.Init,
 		Metrics.ZookeeperFollowers.Name():             Metrics.ZookeeperFollowers.Init,
+		Metrics.ZookeeperFsyncTime.Name():             Metrics.ZookeeperFsyncTime.Init,
 		Metrics.ZookeeperFsyncThresholdExceeds.Name(): Metrics.ZookeeperFsyncThresholdExceeds.Init,
 		Metrics.ZookeeperLatencyAvg.Name():            Metrics.ZookeeperLatencyAvg.Init,
 		Metrics.ZookeeperLatencyMax.Name():            Metrics.ZookeeperLatencyMax.Init,
@@ -137,6 +151,8 @@ func (m *metricStruct) FactoriesByName() map[string]func(pdata.Metric) {
 		Metrics.ZookeeperPacketsReceived.Name():       Metrics.ZookeeperPacketsReceived.Init,
 		Metrics.ZookeeperPacketsSent.Name():           Metrics.ZookeeperPacketsSent.Init,
 		Metrics.ZookeeperPendingSyncs.Name():          Metrics.ZookeeperPendingSyncs.Init,
+		Metrics.ZookeeperQuorumHealthy.Name():         Metrics.ZookeeperQuorumHealthy.Init,
+		Metrics.ZookeeperRole.Name():                  Metrics.ZookeeperRole.Init,
 		Metrics.ZookeeperSyncedFollowers.Name():       Metrics.ZookeeperSyncedFollowers.Init,
 		Metrics.ZookeeperWatches.Name():               Metrics.ZookeeperWatches.Init,
 		Metrics.ZookeeperZnodes.Name():                Metrics.ZookeeperZnodes.Init,
@@ -173,6 +189,15 @@ var Metrics = &metricStruct{
 			metric.SetDataType(pdata.MetricDataTypeIntGauge)
 		},
 	},
+	&metricImpl{
+		"zookeeper.follower_sync.time",
+		func(metric pdata.Metric) {
+			metric.SetName("zookeeper.follower_sync.time")
+			metric.SetDescription("Time in milliseconds for a follower to sync with the leader. Only exposed by the leader on ZooKeeper 3.6 and later.")
+			metric.SetUnit("ms")
+			metric.SetDataType(pdata.MetricDataTypeDoubleGauge)
+		},
+	},
 	&metricImpl{
 		"zookeeper.followers",
 		func(metric pdata.Metric) {
@@ -182,6 +207,15 @@ var Metrics = &metricStruct{
 			metric.SetDataType(pdata.MetricDataTypeIntGauge)
 		},
 	},
+	&metricImpl{
+		"zookeeper.fsync.time",
+		func(metric pdata.Metric) {
+			metric.SetName("zookeeper.fsync.time")
+			metric.SetDescription("Time in milliseconds spent syncing the transaction log to disk. Only exposed by ZooKeeper 3.6 and later.")
+			metric.SetUnit("ms")
+			metric.SetDataType(pdata.MetricDataTypeDoubleGauge)
+		},
+	},
 	&metricImpl{
 		"zookeeper.fsync_threshold_exceeds",
 		func(metric pdata.Metric) {
@@ -278,6 +312,24 @@ var Metrics = &metricStruct{
 			metric.SetDataType(pdata.MetricDataTypeIntGauge)
 		},
 	},
+	&metricImpl{
+		"zookeeper.quorum.healthy",
+		func(metric pdata.Metric) {
+			metric.SetName("zookeeper.quorum.healthy")
+			metric.SetDescription("Whether the server is part of a healthy quorum (1) or not (0). A leader is healthy when the followers in sync with it form a majority of the connected voting members.")
+			metric.SetUnit("1")
+			metric.SetDataType(pdata.MetricDataTypeIntGauge)
+		},
+	},
+	&metricImpl{
+		"zookeeper.role",
+		func(metric pdata.Metric) {
+			metric.SetName("zookeeper.role")
+			metric.SetDescription("Role of the server in the ensemble, reported as 1 with the current role as label.")
+			metric.SetUnit("1")
+			metric.SetDataType(pdata.MetricDataTypeIntGauge)
+		},
+	},
 	&metricImpl{
 		"zookeeper.synced_followers",
 		func(metric pdata.Metric) {
@@ -313,15 +365,45 @@ var M = Metrics
 
 // Labels contains the possible metric labels that can be used.
 var Labels = struct {
+	// Role (Role of the server in the ensemble.)
+	Role string
 	// ServerState (State of the Zookeeper server (leader, standalone or follower).)
 	ServerState string
+	// Statistic (Statistic reported for the summary.)
+	Statistic string
 	// ZkVersion (Zookeeper version of the instance.)
 	ZkVersion string
 }{
+	"role",
 	"server.state",
+	"statistic",
 	"zk.version",
 }
 
 // L contains the possible metric labels that can be used. L is an alias for
 // Labels.
 var L = Labels
+
+// LabelRole are the possible values that the label "role" can have.
+var LabelRole = struct {
+	Leader     string
+	Follower   string
+	Observer   string
+	Standalone string
+}{
+	"leader",
+	"follower",
+	"observer",
+	"standalone",
+}
+
+// LabelStatistic are the possible values that the label "statistic" can have.
+var LabelStatistic = struct {
+	Avg string
+	Min string
+	Max string
+}{
+	"avg",
+	"min",
+	"max",
+}
@@ -42,12 +42,36 @@ const (
 	syncedFollowersMetricKey = "zk_synced_followers"
 	pendingSyncsMetricKey    = "zk_pending_syncs"
 
+	// Reported by the leader on ZooKeeper 3.6 and later, where zk_learners
+	// supersedes zk_followers.
+	learnersKey                 = "zk_learners"
+	syncedNonVotingFollowersKey = "zk_synced_non_voting_followers"
+	syncedObserversKey          = "zk_synced_observers"
+
+	// Summaries reported by ZooKeeper 3.6 and later.
+	avgFSyncTimeMetricKey        = "zk_avg_fsynctime"
+	minFSyncTimeMetricKey        = "zk_min_fsynctime"
+	maxFSyncTimeMetricKey        = "zk_max_fsynctime"
+	avgFollowerSyncTimeMetricKey = "zk_avg_follower_sync_time"
+	minFollowerSyncTimeMetricKey = "zk_min_follower_sync_time"
+	maxFollowerSyncTimeMetricKey = "zk_max_follower_sync_time"
+
 	serverStateKey = "zk_server_state"
 	zkVersionKey   = "zk_version"
 
-	metricsLen = 17
+	metricsLen = 25
 )
 
+// summaryStatistics maps the summary entries of "mntr" to the statistic they report.
+var summaryStatistics = map[string]string{
+	avgFSyncTimeMetricKey:        metadata.LabelStatistic.Avg,
+	minFSyncTimeMetricKey:        metadata.LabelStatistic.Min,
+	maxFSyncTimeMetricKey:        metadata.LabelStatistic.Max,
+	avgFollowerSyncTimeMetricKey: metadata.LabelStatistic.Avg,
+	minFollowerSyncTimeMetricKey: metadata.LabelStatistic.Min,
+	maxFollowerSyncTimeMetricKey: metadata.LabelStatistic.Max,
+}
+
 func getOTLPMetricDescriptor(metric string) pdata.Metric {
 	switch metric {
 	case followersMetricKey:
@@ -84,7 +108,33 @@ func getOTLPMetricDescriptor(metric string) pdata.Metric {
 		return metadata.Metrics.ZookeeperPacketsReceived.New()
 	case packetsSentMetricKey:
 		return metadata.Metrics.ZookeeperPacketsSent.New()
+	case avgFSyncTimeMetricKey, minFSyncTimeMetricKey, maxFSyncTimeMetricKey:
+		return metadata.Metrics.ZookeeperFsyncTime.New()
+	case avgFollowerSyncTimeMetricKey, minFollowerSyncTimeMetricKey, maxFollowerSyncTimeMetricKey:
+		return metadata.Metrics.ZookeeperFollowerSyncTime.New()
 	}
 
 	return pdata.NewMetric()
 }
+
+// quorumHealthy reports whether the server is part of a healthy quorum. Followers
+// and observers only report their state while connected to a leader, and a
+// standalone server is its own quorum. A leader is healthy when the followers in
+// sync with it, together with itself, form a majority of the voting members
+// connected to it.
+func quorumHealthy(serverState string, values map[string]int64) int64 {
+	switch serverState {
+	case metadata.LabelRole.Standalone, metadata.LabelRole.Follower, metadata.LabelRole.Observer:
+		return 1
+	case metadata.LabelRole.Leader:
+		learners, ok := values[learnersKey]
+		if !ok {
+			learners = values[followersMetricKey]
+		}
+		voters := learners - values[syncedNonVotingFollowersKey] - values[syncedObserversKey] + 1
+		if values[syncedFollowersMetricKey]+1 > voters/2 {
+			return 1
+		}
+	}
+	return 0
+}
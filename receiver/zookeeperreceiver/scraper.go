@@ -17,13 +17,18 @@ package zookeeperreceiver
 import (
 	"bufio"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net"
+	"net/http"
 	"regexp"
+	"sort"
 	"strconv"
+	"strings"
 	"time"
 
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer/pdata"
 	"go.opentelemetry.io/collector/consumer/simple"
 	"go.uber.org/zap"
@@ -34,7 +39,8 @@ import (
 var zookeeperFormatRE = regexp.MustCompile(`(^zk_\w+)\s+([\w\.\-]+)`)
 
 const (
-	mntrCommand = "mntr"
+	mntrCommand    = "mntr"
+	monitorCommand = "monitor"
 )
 
 type zookeeperMetricsScraper struct {
@@ -42,6 +48,9 @@ type zookeeperMetricsScraper struct {
 	config *Config
 	cancel context.CancelFunc
 
+	// httpClient queries the AdminServer, when configured.
+	httpClient *http.Client
+
 	// For mocking.
 	closeConnection       func(net.Conn) error
 	setConnectionDeadline func(net.Conn, time.Time) error
@@ -71,6 +80,19 @@ func newZookeeperMetricsScraper(logger *zap.Logger, config *Config) (*zookeeperM
 	}, nil
 }
 
+func (z *zookeeperMetricsScraper) start(_ context.Context, host component.Host) error {
+	if z.config.AdminServer == nil {
+		return nil
+	}
+
+	httpClient, err := z.config.AdminServer.ToClient(host.GetExtensions())
+	if err != nil {
+		return err
+	}
+	z.httpClient = httpClient
+	return nil
+}
+
 func (z *zookeeperMetricsScraper) shutdown(_ context.Context) error {
 	z.cancel()
 	return nil
@@ -80,6 +102,10 @@ func (z *zookeeperMetricsScraper) scrape(ctx context.Context) (pdata.ResourceMet
 	var ctxWithTimeout context.Context
 	ctxWithTimeout, z.cancel = context.WithTimeout(ctx, z.config.Timeout)
 
+	if z.httpClient != nil {
+		return z.scrapeAdminServer(ctxWithTimeout)
+	}
+
 	conn, err := z.config.Dial()
 	if err != nil {
 		z.logger.Error("failed to establish connection",
@@ -105,8 +131,10 @@ func (z *zookeeperMetricsScraper) scrape(ctx context.Context) (pdata.ResourceMet
 }
 
 type stat struct {
-	metric pdata.Metric
-	val    int64
+	metric    pdata.Metric
+	labels    map[string]string
+	val       int64
+	doubleVal float64
 }
 
 func (z *zookeeperMetricsScraper) getResourceMetrics(conn net.Conn) (pdata.ResourceMetricsSlice, error) {
@@ -119,6 +147,71 @@ func (z *zookeeperMetricsScraper) getResourceMetrics(conn net.Conn) (pdata.Resou
 		return pdata.NewResourceMetricsSlice(), err
 	}
 
+	return z.buildResourceMetrics(scanner), nil
+}
+
+func (z *zookeeperMetricsScraper) scrapeAdminServer(ctx context.Context) (pdata.ResourceMetricsSlice, error) {
+	scanner, err := z.getMonitorOutput(ctx)
+	if err != nil {
+		z.logger.Error("failed to query AdminServer",
+			zap.String("endpoint", z.config.AdminServer.Endpoint),
+			zap.String("command", monitorCommand),
+			zap.Error(err),
+		)
+		return pdata.NewResourceMetricsSlice(), err
+	}
+
+	return z.buildResourceMetrics(scanner), nil
+}
+
+// getMonitorOutput runs the "monitor" command of the AdminServer and renders its
+// JSON response in the format of "mntr", so that both are parsed alike.
+func (z *zookeeperMetricsScraper) getMonitorOutput(ctx context.Context) (*bufio.Scanner, error) {
+	url := strings.TrimSuffix(z.config.AdminServer.Endpoint, "/") + "/commands/" + monitorCommand
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := z.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+
+	var output map[string]interface{}
+	decoder := json.NewDecoder(resp.Body)
+	decoder.UseNumber()
+	if err := decoder.Decode(&output); err != nil {
+		return nil, err
+	}
+
+	keys := make([]string, 0, len(output))
+	for key := range output {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var sb strings.Builder
+	for _, key := range keys {
+		metricKey := "zk_" + key
+		switch value := output[key].(type) {
+		case json.Number:
+			fmt.Fprintf(&sb, "%s\t%s\n", metricKey, value)
+		case string:
+			if metricKey == zkVersionKey || metricKey == serverStateKey {
+				fmt.Fprintf(&sb, "%s\t%s\n", metricKey, value)
+			}
+		}
+	}
+	return bufio.NewScanner(strings.NewReader(sb.String())), nil
+}
+
+func (z *zookeeperMetricsScraper) buildResourceMetrics(scanner *bufio.Scanner) pdata.ResourceMetricsSlice {
 	stats, attributes := z.getMetricsAndAttributes(scanner)
 	metrics := simple.Metrics{
 		Metrics:                    pdata.NewMetrics(),
@@ -129,20 +222,23 @@ func (z *zookeeperMetricsScraper) getResourceMetrics(conn net.Conn) (pdata.Resou
 	}
 
 	for _, stat := range stats {
-		// Currently the receiver only deals with one metric type.
+		mb := metrics.WithLabels(stat.labels)
 		switch stat.metric.DataType() {
 		case pdata.MetricDataTypeIntGauge:
-			metrics.AddGaugeDataPoint(stat.metric.Name(), stat.val)
+			mb.AddGaugeDataPoint(stat.metric.Name(), stat.val)
+		case pdata.MetricDataTypeDoubleGauge:
+			mb.AddDGaugeDataPoint(stat.metric.Name(), stat.doubleVal)
 		case pdata.MetricDataTypeIntSum:
-			metrics.AddSumDataPoint(stat.metric.Name(), stat.val)
+			mb.AddSumDataPoint(stat.metric.Name(), stat.val)
 		}
 	}
-	return metrics.ResourceMetrics(), nil
+	return metrics.ResourceMetrics()
 }
 
 func (z *zookeeperMetricsScraper) getMetricsAndAttributes(scanner *bufio.Scanner) ([]stat, map[string]string) {
 	attributes := make(map[string]string, 2)
 	stats := make([]stat, 0, metricsLen)
+	values := make(map[string]int64, metricsLen)
 	for scanner.Scan() {
 		line := scanner.Text()
 		parts := zookeeperFormatRE.FindStringSubmatch(line)
@@ -166,7 +262,24 @@ func (z *zookeeperMetricsScraper) getMetricsAndAttributes(scanner *bufio.Scanner
 		default:
 			// Skip metric if there is no descriptor associated with it.
 			metricDescriptor := getOTLPMetricDescriptor(metricKey)
-			int64Val, err := strconv.ParseInt(metricValue, 10, 64)
+			if statistic, ok := summaryStatistics[metricKey]; ok {
+				float64Val, err := strconv.ParseFloat(metricValue, 64)
+				if err != nil {
+					z.logger.Debug(
+						fmt.Sprintf("non-numeric value from %s", mntrCommand),
+						zap.String("value", metricValue),
+					)
+					continue
+				}
+				stats = append(stats, stat{
+					metric:    metricDescriptor,
+					labels:    map[string]string{metadata.Labels.Statistic: statistic},
+					doubleVal: float64Val,
+				})
+				continue
+			}
+
+			int64Val, err := parseInt(metricValue)
 			if err != nil {
 				z.logger.Debug(
 					fmt.Sprintf("non-integer value from %s", mntrCommand),
@@ -174,13 +287,43 @@ func (z *zookeeperMetricsScraper) getMetricsAndAttributes(scanner *bufio.Scanner
 				)
 				continue
 			}
+			values[metricKey] = int64Val
 			stats = append(stats, stat{metric: metricDescriptor, val: int64Val})
 		}
 	}
 
+	// The role and quorum health are synthesized from the state of the server,
+	// which is missing when it is not serving requests.
+	if state, ok := attributes[metadata.Labels.ServerState]; ok {
+		stats = append(stats,
+			stat{
+				metric: metadata.Metrics.ZookeeperRole.New(),
+				labels: map[string]string{metadata.Labels.Role: state},
+				val:    1,
+			},
+			stat{
+				metric: metadata.Metrics.ZookeeperQuorumHealthy.New(),
+				val:    quorumHealthy(state, values),
+			},
+		)
+	}
+
 	return stats, attributes
 }
 
+// parseInt parses an integer value of "mntr", truncating decimal values such as
+// the average latency reported by ZooKeeper 3.6 and later.
+func parseInt(value string) (int64, error) {
+	if int64Val, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return int64Val, nil
+	}
+	float64Val, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, err
+	}
+	return int64(float64Val), nil
+}
+
 func closeConnection(conn net.Conn) error {
 	return conn.Close()
 }
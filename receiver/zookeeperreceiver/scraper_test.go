@@ -20,11 +20,15 @@ import (
 	"errors"
 	"io/ioutil"
 	"net"
+	"net/http"
+	"net/http/httptest"
 	"path"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/config/confignet"
 	"go.opentelemetry.io/collector/consumer/pdata"
 	"go.opentelemetry.io/collector/testutil"
@@ -57,9 +61,15 @@ func TestZookeeperMetricsScraperScrape(t *testing.T) {
 		metadata.Metrics.ZookeeperMaxFileDescriptors.New(),
 	}
 
+	synthesizedMetrics := []pdata.Metric{
+		metadata.Metrics.ZookeeperRole.New(),
+		metadata.Metrics.ZookeeperQuorumHealthy.New(),
+	}
+
 	var metricsV3414 []pdata.Metric
 	metricsV3414 = append(metricsV3414, commonMetrics...)
 	metricsV3414 = append(metricsV3414, metadata.Metrics.ZookeeperFsyncThresholdExceeds.New())
+	metricsV3414 = append(metricsV3414, synthesizedMetrics...)
 
 	tests := []struct {
 		name                         string
@@ -88,7 +98,7 @@ func TestZookeeperMetricsScraperScrape(t *testing.T) {
 			name:                         "Test correctness with v3.5.5",
 			mockedZKOutputSourceFilename: "mntr-3.5.5",
 			expectedMetrics: func() []pdata.Metric {
-				out := make([]pdata.Metric, 0, len(commonMetrics)+3)
+				out := make([]pdata.Metric, 0, len(commonMetrics)+5)
 				out = append(out, commonMetrics...)
 
 				out = append(out, []pdata.Metric{
@@ -96,6 +106,7 @@ func TestZookeeperMetricsScraperScrape(t *testing.T) {
 					metadata.Metrics.ZookeeperSyncedFollowers.New(),
 					metadata.Metrics.ZookeeperPendingSyncs.New(),
 				}...)
+				out = append(out, synthesizedMetrics...)
 				return out
 			}(),
 			expectedResourceAttributes: map[string]string{
@@ -104,6 +115,36 @@ func TestZookeeperMetricsScraperScrape(t *testing.T) {
 			},
 			expectedNumResourceMetrics: 1,
 		},
+		{
+			name:                         "Test correctness with v3.6.3",
+			mockedZKOutputSourceFilename: "mntr-3.6.3",
+			expectedMetrics: []pdata.Metric{
+				metadata.Metrics.ZookeeperEphemeralNodes.New(),
+				metadata.Metrics.ZookeeperLatencyMin.New(),
+				metadata.Metrics.ZookeeperLatencyAvg.New(),
+				metadata.Metrics.ZookeeperConnectionsAlive.New(),
+				metadata.Metrics.ZookeeperMaxFileDescriptors.New(),
+				metadata.Metrics.ZookeeperOutstandingRequests.New(),
+				metadata.Metrics.ZookeeperApproximateDateSize.New(),
+				metadata.Metrics.ZookeeperZnodes.New(),
+				metadata.Metrics.ZookeeperOpenFileDescriptors.New(),
+				metadata.Metrics.ZookeeperLatencyMax.New(),
+				metadata.Metrics.ZookeeperPacketsReceived.New(),
+				metadata.Metrics.ZookeeperPacketsSent.New(),
+				metadata.Metrics.ZookeeperWatches.New(),
+				metadata.Metrics.ZookeeperSyncedFollowers.New(),
+				metadata.Metrics.ZookeeperPendingSyncs.New(),
+				metadata.Metrics.ZookeeperFsyncTime.New(),
+				metadata.Metrics.ZookeeperFollowerSyncTime.New(),
+				metadata.Metrics.ZookeeperRole.New(),
+				metadata.Metrics.ZookeeperQuorumHealthy.New(),
+			},
+			expectedResourceAttributes: map[string]string{
+				"server.state": "leader",
+				"zk.version":   "3.6.3--6401e4ad2087061bc6b9f80dec2d69f2e3c8660a",
+			},
+			expectedNumResourceMetrics: 1,
+		},
 		{
 			name:                "Arbitrary connection error",
 			mockZKConnectionErr: true,
@@ -270,11 +311,185 @@ func assertMetricValid(t *testing.T, metric pdata.Metric, descriptor pdata.Metri
 	switch metric.DataType() {
 	case pdata.MetricDataTypeIntGauge:
 		require.GreaterOrEqual(t, metric.IntGauge().DataPoints().Len(), 1)
+	case pdata.MetricDataTypeDoubleGauge:
+		require.GreaterOrEqual(t, metric.DoubleGauge().DataPoints().Len(), 1)
 	case pdata.MetricDataTypeIntSum:
 		require.GreaterOrEqual(t, metric.IntSum().DataPoints().Len(), 1)
 	}
 }
 
+func TestZookeeperMetricsScraperSummaries(t *testing.T) {
+	localAddr := testutil.GetAvailableLocalAddress(t)
+	ms := mockedServer{ready: make(chan bool, 1)}
+	go ms.mockZKServer(t, localAddr, "mntr-3.6.3")
+	<-ms.ready
+
+	cfg := &Config{
+		TCPAddr: confignet.TCPAddr{
+			Endpoint: localAddr,
+		},
+		Timeout: defaultTimeout,
+	}
+	z, err := newZookeeperMetricsScraper(zap.NewNop(), cfg)
+	require.NoError(t, err)
+
+	rms, err := z.scrape(context.Background())
+	require.NoError(t, err)
+	metrics := rms.At(0).InstrumentationLibraryMetrics().At(0).Metrics()
+
+	got := map[string]map[string]float64{}
+	for i := 0; i < metrics.Len(); i++ {
+		metric := metrics.At(i)
+		if metric.DataType() != pdata.MetricDataTypeDoubleGauge {
+			continue
+		}
+		got[metric.Name()] = map[string]float64{}
+		dps := metric.DoubleGauge().DataPoints()
+		for j := 0; j < dps.Len(); j++ {
+			statistic, _ := dps.At(j).LabelsMap().Get(metadata.Labels.Statistic)
+			got[metric.Name()][statistic] = dps.At(j).Value()
+		}
+	}
+	require.Equal(t, map[string]map[string]float64{
+		"zookeeper.fsync.time":         {"avg": 1.5, "min": 0, "max": 12},
+		"zookeeper.follower_sync.time": {"avg": 7, "min": 4, "max": 10},
+	}, got)
+
+	require.NoError(t, z.shutdown(context.Background()))
+}
+
+func TestZookeeperMetricsScraperAdminServer(t *testing.T) {
+	var requestedPath string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestedPath = r.URL.Path
+		out, err := ioutil.ReadFile(path.Join(".", "testdata", "monitor-3.6.3.json"))
+		require.NoError(t, err)
+		_, err = w.Write(out)
+		require.NoError(t, err)
+	}))
+	defer server.Close()
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.AdminServer = &confighttp.HTTPClientSettings{
+		Endpoint: server.URL,
+	}
+	core, observedLogs := observer.New(zap.DebugLevel)
+	z, err := newZookeeperMetricsScraper(zap.New(core), cfg)
+	require.NoError(t, err)
+	require.NoError(t, z.start(context.Background(), componenttest.NewNopHost()))
+
+	rms, err := z.scrape(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "/commands/monitor", requestedPath)
+	require.Equal(t, 0, observedLogs.Len())
+
+	require.Equal(t, 1, rms.Len())
+	attributes := rms.At(0).Resource().Attributes()
+	state, ok := attributes.Get(metadata.Labels.ServerState)
+	require.True(t, ok)
+	require.Equal(t, "follower", state.StringVal())
+	version, ok := attributes.Get(metadata.Labels.ZkVersion)
+	require.True(t, ok)
+	require.Equal(t, "3.6.3--6401e4ad2087061bc6b9f80dec2d69f2e3c8660a", version.StringVal())
+
+	metrics := rms.At(0).InstrumentationLibraryMetrics().At(0).Metrics()
+	values := map[string]int64{}
+	for i := 0; i < metrics.Len(); i++ {
+		metric := metrics.At(i)
+		if metric.DataType() == pdata.MetricDataTypeIntGauge {
+			values[metric.Name()] = metric.IntGauge().DataPoints().At(0).Value()
+		}
+	}
+	require.Equal(t, int64(3), values["zookeeper.latency.max"])
+	require.Equal(t, int64(1048576), values["zookeeper.max_file_descriptors"])
+	require.Equal(t, int64(1), values["zookeeper.role"])
+	require.Equal(t, int64(1), values["zookeeper.quorum.healthy"])
+
+	require.NoError(t, z.shutdown(context.Background()))
+}
+
+func TestZookeeperMetricsScraperAdminServerError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.AdminServer = &confighttp.HTTPClientSettings{
+		Endpoint: server.URL,
+	}
+	core, observedLogs := observer.New(zap.DebugLevel)
+	z, err := newZookeeperMetricsScraper(zap.New(core), cfg)
+	require.NoError(t, err)
+	require.NoError(t, z.start(context.Background(), componenttest.NewNopHost()))
+
+	rms, err := z.scrape(context.Background())
+	require.EqualError(t, err, "unexpected status code 404")
+	require.Equal(t, pdata.NewResourceMetricsSlice(), rms)
+	require.Equal(t, 1, observedLogs.Len())
+	require.Equal(t, "failed to query AdminServer", observedLogs.All()[0].Message)
+
+	require.NoError(t, z.shutdown(context.Background()))
+}
+
+func TestQuorumHealthy(t *testing.T) {
+	tests := []struct {
+		name   string
+		state  string
+		values map[string]int64
+		want   int64
+	}{
+		{
+			name:  "standalone",
+			state: "standalone",
+			want:  1,
+		},
+		{
+			name:  "follower",
+			state: "follower",
+			want:  1,
+		},
+		{
+			name:  "leader with majority in sync",
+			state: "leader",
+			values: map[string]int64{
+				followersMetricKey:       4,
+				syncedFollowersMetricKey: 2,
+			},
+			want: 1,
+		},
+		{
+			name:  "leader without majority in sync",
+			state: "leader",
+			values: map[string]int64{
+				followersMetricKey:       4,
+				syncedFollowersMetricKey: 1,
+			},
+			want: 0,
+		},
+		{
+			name:  "leader ignores observers",
+			state: "leader",
+			values: map[string]int64{
+				learnersKey:              4,
+				syncedObserversKey:       2,
+				syncedFollowersMetricKey: 1,
+			},
+			want: 1,
+		},
+		{
+			name:  "looking",
+			state: "looking",
+			want:  0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, quorumHealthy(tt.state, tt.values))
+		})
+	}
+}
+
 func assertDescriptorEqual(t *testing.T, expected pdata.Metric, actual pdata.Metric) {
 	require.Equal(t, expected.Name(), actual.Name())
 	require.Equal(t, expected.Description(), actual.Description())
@@ -54,19 +54,19 @@ func TestIntegration(t *testing.T) {
 		{
 			name:               "3.4.14",
 			image:              "docker.io/library/zookeeper:3.4",
-			expectedNumMetrics: 14,
+			expectedNumMetrics: 16,
 			env:                []string{"ZOO_4LW_COMMANDS_WHITELIST=srvr,mntr", "ZOO_STANDALONE_ENABLED=false"},
 		},
 		{
 			name:               "3.5.5-standalone",
 			image:              "docker.io/library/zookeeper:3.5.5",
-			expectedNumMetrics: 13,
+			expectedNumMetrics: 15,
 			env:                []string{"ZOO_4LW_COMMANDS_WHITELIST=srvr,mntr"},
 		},
 		{
 			name:               "3.5.5",
 			image:              "docker.io/library/zookeeper:3.5.5",
-			expectedNumMetrics: 16,
+			expectedNumMetrics: 18,
 			env:                []string{"ZOO_4LW_COMMANDS_WHITELIST=srvr,mntr", "ZOO_STANDALONE_ENABLED=false"},
 		},
 	}
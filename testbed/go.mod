@@ -46,3 +46,7 @@ replace github.com/open-telemetry/opentelemetry-collector-contrib/receiver/signa
 replace github.com/open-telemetry/opentelemetry-collector-contrib/receiver/splunkhecreceiver => ../receiver/splunkhecreceiver
 
 replace github.com/open-telemetry/opentelemetry-collector-contrib/testbed/mockdatareceivers/mockawsxrayreceiver => ../testbed/mockdatareceivers/mockawsxrayreceiver
+
+replace github.com/open-telemetry/opentelemetry-collector-contrib/pkg/queueperresourceattr => ../pkg/queueperresourceattr
+
+replace github.com/open-telemetry/opentelemetry-collector-contrib/internal/admissioncontrol => ../internal/admissioncontrol